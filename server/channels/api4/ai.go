@@ -13,7 +13,7 @@ import (
 func (api *API) InitAI() {
 	// AI root endpoints
 	api.BaseRoutes.AI.Handle("/health", api.APISessionRequired(aiHealthCheck)).Methods(http.MethodGet)
-	api.BaseRoutes.AI.Handle("/config/validate", api.APISessionRequired(aiValidateConfig)).Methods(http.MethodPost)
+	api.BaseRoutes.AI.Handle("/config/validate", api.APISessionRequired(aiValidateConfig, handlerParamSkipRecordWrite)).Methods(http.MethodPost)
 	api.BaseRoutes.AI.Handle("/test", api.APISessionRequired(aiTestConnection)).Methods(http.MethodPost)
 
 	// Feature-specific routes
@@ -27,7 +27,7 @@ type FormatApplyRequest struct {
 }
 
 func (api *API) initFormatterRoutes() {
-	api.BaseRoutes.AI.Handle("/format/preview", api.APISessionRequired(formatPreview)).Methods("POST")
+	api.BaseRoutes.AI.Handle("/format/preview", api.APISessionRequired(formatPreview, handlerParamSkipRecordWrite)).Methods("POST")
 	api.BaseRoutes.AI.Handle("/format/apply", api.APISessionRequired(formatApply)).Methods("POST")
 	api.BaseRoutes.AI.Handle("/format/profiles", api.APISessionRequired(getFormattingProfiles)).Methods("GET")
 }
@@ -78,6 +78,8 @@ type Routes struct {
 	IncomingHook  *mux.Router // 'api/v4/hooks/incoming/{hook_id:[A-Za-z0-9]+}'
 	OutgoingHooks *mux.Router // 'api/v4/hooks/outgoing'
 	OutgoingHook  *mux.Router // 'api/v4/hooks/outgoing/{hook_id:[A-Za-z0-9]+}'
+	EventHooks    *mux.Router // 'api/v4/hooks/events'
+	EventHook     *mux.Router // 'api/v4/hooks/events/{hook_id:[A-Za-z0-9]+}'
 
 	OAuth     *mux.Router // 'api/v4/oauth'
 	OAuthApps *mux.Router // 'api/v4/oauth/apps'
@@ -241,6 +243,8 @@ func Init(srv *app.Server) (*API, error) {
 	api.BaseRoutes.IncomingHook = api.BaseRoutes.IncomingHooks.PathPrefix("/{hook_id:[A-Za-z0-9]+}").Subrouter()
 	api.BaseRoutes.OutgoingHooks = api.BaseRoutes.Hooks.PathPrefix("/outgoing").Subrouter()
 	api.BaseRoutes.OutgoingHook = api.BaseRoutes.OutgoingHooks.PathPrefix("/{hook_id:[A-Za-z0-9]+}").Subrouter()
+	api.BaseRoutes.EventHooks = api.BaseRoutes.Hooks.PathPrefix("/events").Subrouter()
+	api.BaseRoutes.EventHook = api.BaseRoutes.EventHooks.PathPrefix("/{hook_id:[A-Za-z0-9]+}").Subrouter()
 
 	api.BaseRoutes.SAML = api.BaseRoutes.APIRoot.PathPrefix("/saml").Subrouter()
 
@@ -331,6 +335,7 @@ func Init(srv *app.Server) (*API, error) {
 	api.InitLicense()
 	api.InitConfig()
 	api.InitWebhook()
+	api.InitEventSubscription()
 	api.InitPreference()
 	api.InitSaml()
 	api.InitCompliance()
@@ -375,6 +380,7 @@ func Init(srv *app.Server) (*API, error) {
 	api.InitContentFlagging()
 	api.InitAgents()
 	api.InitAI()
+	api.InitNotificationRule()
 
 	// If we allow testing then listen for manual testing URL hits
 	if *srv.Config().ServiceSettings.EnableTesting {
@@ -466,6 +472,8 @@ func InitLocal(srv *app.Server) *API {
 	api.BaseRoutes.AccessControlPolicies = api.BaseRoutes.APIRoot.PathPrefix("/access_control_policies").Subrouter()
 	api.BaseRoutes.AccessControlPolicy = api.BaseRoutes.APIRoot.PathPrefix("/access_control_policies/{policy_id:[A-Za-z0-9]+}").Subrouter()
 
+	api.BaseRoutes.AuditLogs = api.BaseRoutes.APIRoot.PathPrefix("/audit_logs").Subrouter()
+
 	api.InitUserLocal()
 	api.InitTeamLocal()
 	api.InitChannelLocal()
@@ -488,6 +496,7 @@ func InitLocal(srv *app.Server) *API {
 	api.InitSamlLocal()
 	api.InitCustomProfileAttributesLocal()
 	api.InitAccessControlPolicyLocal()
+	api.InitAuditLoggingLocal()
 
 	srv.LocalRouter.Handle("/api/v4/{anything:.*}", http.HandlerFunc(api.Handle404))
 
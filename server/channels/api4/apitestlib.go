@@ -85,7 +85,7 @@ func setupTestHelper(tb testing.TB, dbStore store.Store, sqlSettings *model.SqlS
 	tempWorkspace, err := os.MkdirTemp("", "apptest")
 	require.NoError(tb, err)
 
-	memoryStore, err := config.NewMemoryStoreWithOptions(&config.MemoryStoreOptions{IgnoreEnvironmentOverrides: true})
+	memoryStore, err := config.NewMemoryStoreWithOptions(&config.MemoryStoreOptions{IgnoreEnvironmentOverrides: true, KeepHistory: true})
 	require.NoError(tb, err, "failed to initialize memory store")
 
 	memoryConfig := &model.Config{
@@ -361,9 +361,12 @@ func SetupConfigWithStoreMock(tb testing.TB, updateConfig func(cfg *model.Config
 	statusMock.On("Get", "user1").Return(&model.Status{UserId: "user1", Status: model.StatusOnline}, nil)
 	statusMock.On("UpdateLastActivityAt", "user1", mock.Anything).Return(nil)
 	statusMock.On("SaveOrUpdate", mock.AnythingOfType("*model.Status")).Return(nil)
+	workingHoursMock := mocks.WorkingHoursStore{}
+	workingHoursMock.On("GetEnabled", mock.AnythingOfType("string"), mock.AnythingOfType("int")).Return([]*model.WorkingHours{}, nil)
 	emptyMockStore := mocks.Store{}
 	emptyMockStore.On("Close").Return(nil)
 	emptyMockStore.On("Status").Return(&statusMock)
+	emptyMockStore.On("WorkingHours").Return(&workingHoursMock)
 	th.App.Srv().SetStore(&emptyMockStore)
 	return th
 }
@@ -375,9 +378,12 @@ func SetupWithStoreMock(tb testing.TB) *TestHelper {
 	statusMock.On("Get", "user1").Return(&model.Status{UserId: "user1", Status: model.StatusOnline}, nil)
 	statusMock.On("UpdateLastActivityAt", "user1", mock.Anything).Return(nil)
 	statusMock.On("SaveOrUpdate", mock.AnythingOfType("*model.Status")).Return(nil)
+	workingHoursMock := mocks.WorkingHoursStore{}
+	workingHoursMock.On("GetEnabled", mock.AnythingOfType("string"), mock.AnythingOfType("int")).Return([]*model.WorkingHours{}, nil)
 	emptyMockStore := mocks.Store{}
 	emptyMockStore.On("Close").Return(nil)
 	emptyMockStore.On("Status").Return(&statusMock)
+	emptyMockStore.On("WorkingHours").Return(&workingHoursMock)
 	th.App.Srv().SetStore(&emptyMockStore)
 	return th
 }
@@ -394,9 +400,12 @@ func SetupEnterpriseWithStoreMock(tb testing.TB, options ...app.Option) *TestHel
 	statusMock.On("Get", "user1").Return(&model.Status{UserId: "user1", Status: model.StatusOnline}, nil)
 	statusMock.On("UpdateLastActivityAt", "user1", mock.Anything).Return(nil)
 	statusMock.On("SaveOrUpdate", mock.AnythingOfType("*model.Status")).Return(nil)
+	workingHoursMock := mocks.WorkingHoursStore{}
+	workingHoursMock.On("GetEnabled", mock.AnythingOfType("string"), mock.AnythingOfType("int")).Return([]*model.WorkingHours{}, nil)
 	emptyMockStore := mocks.Store{}
 	emptyMockStore.On("Close").Return(nil)
 	emptyMockStore.On("Status").Return(&statusMock)
+	emptyMockStore.On("WorkingHours").Return(&workingHoursMock)
 	th.App.Srv().SetStore(&emptyMockStore)
 	return th
 }
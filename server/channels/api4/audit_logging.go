@@ -4,15 +4,20 @@
 package api4
 
 import (
+	"encoding/json"
 	"mime/multipart"
 	"net/http"
+	"strconv"
 
 	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
 )
 
 func (api *API) InitAuditLogging() {
 	api.BaseRoutes.AuditLogs.Handle("/certificate", api.APISessionRequired(addAuditLogCertificate)).Methods(http.MethodPost)
 	api.BaseRoutes.AuditLogs.Handle("/certificate", api.APISessionRequired(removeAuditLogCertificate)).Methods(http.MethodDelete)
+	api.BaseRoutes.AuditLogs.Handle("", api.APISessionRequired(searchAuditLog)).Methods(http.MethodGet)
+	api.BaseRoutes.AuditLogs.Handle("/verify", api.APISessionRequired(verifyAuditLog)).Methods(http.MethodGet)
 }
 
 func parseAuditLogCertificateRequest(r *http.Request, maxFileSize int64) (*multipart.FileHeader, *model.AppError) {
@@ -81,3 +86,75 @@ func removeAuditLogCertificate(c *Context, w http.ResponseWriter, r *http.Reques
 	auditRec.Success()
 	ReturnStatusOK(w)
 }
+
+func searchAuditLog(c *Context, w http.ResponseWriter, r *http.Request) {
+	auditRec := c.MakeAuditRecord(model.AuditEventSearchAuditLog, model.AuditStatusFail)
+	defer c.LogAuditRec(auditRec)
+
+	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionReadAudits) {
+		c.SetPermissionError(model.PermissionReadAudits)
+		return
+	}
+
+	query := r.URL.Query()
+	search := model.AuditLogSearch{
+		ActorId:   query.Get("actor_id"),
+		EventName: query.Get("event_name"),
+		ObjectId:  query.Get("object_id"),
+		Status:    query.Get("status"),
+		Page:      c.Params.Page,
+		PerPage:   c.Params.PerPage,
+	}
+	if startTime := query.Get("start_time"); startTime != "" {
+		var err error
+		if search.StartTime, err = strconv.ParseInt(startTime, 10, 64); err != nil {
+			c.SetInvalidParamWithErr("start_time", err)
+			return
+		}
+	}
+	if endTime := query.Get("end_time"); endTime != "" {
+		var err error
+		if search.EndTime, err = strconv.ParseInt(endTime, 10, 64); err != nil {
+			c.SetInvalidParamWithErr("end_time", err)
+			return
+		}
+	}
+	model.AddEventParameterAuditableToAuditRec(auditRec, "search", search)
+
+	entries, appErr := c.App.SearchAuditLog(search)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec.Success()
+	auditRec.AddMeta("count", len(entries))
+
+	if err := json.NewEncoder(w).Encode(entries); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func verifyAuditLog(c *Context, w http.ResponseWriter, r *http.Request) {
+	auditRec := c.MakeAuditRecord(model.AuditEventVerifyAuditLog, model.AuditStatusFail)
+	defer c.LogAuditRec(auditRec)
+
+	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionReadAudits) {
+		c.SetPermissionError(model.PermissionReadAudits)
+		return
+	}
+
+	verification, appErr := c.App.VerifyAuditLog()
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec.Success()
+	auditRec.AddMeta("verified", verification.Verified)
+	auditRec.AddMeta("issues", len(verification.Issues))
+
+	if err := json.NewEncoder(w).Encode(verification); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
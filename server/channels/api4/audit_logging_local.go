@@ -0,0 +1,11 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api4
+
+import "net/http"
+
+func (api *API) InitAuditLoggingLocal() {
+	api.BaseRoutes.AuditLogs.Handle("", api.APILocal(searchAuditLog)).Methods(http.MethodGet)
+	api.BaseRoutes.AuditLogs.Handle("/verify", api.APILocal(verifyAuditLog)).Methods(http.MethodGet)
+}
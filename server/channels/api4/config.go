@@ -11,6 +11,8 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/gorilla/mux"
+
 	"github.com/mattermost/mattermost/server/public/model"
 	"github.com/mattermost/mattermost/server/public/shared/i18n"
 	"github.com/mattermost/mattermost/server/public/shared/mlog"
@@ -38,6 +40,9 @@ func (api *API) InitConfig() {
 	api.BaseRoutes.APIRoot.Handle("/config/reload", api.APISessionRequired(configReload)).Methods(http.MethodPost)
 	api.BaseRoutes.APIRoot.Handle("/config/client", api.APIHandler(getClientConfig)).Methods(http.MethodGet)
 	api.BaseRoutes.APIRoot.Handle("/config/environment", api.APISessionRequired(getEnvironmentConfig)).Methods(http.MethodGet)
+	api.BaseRoutes.APIRoot.Handle("/config/history", api.APISessionRequired(getConfigHistory)).Methods(http.MethodGet)
+	api.BaseRoutes.APIRoot.Handle("/config/history/diff", api.APISessionRequired(diffConfigVersions)).Methods(http.MethodGet)
+	api.BaseRoutes.APIRoot.Handle("/config/history/{version_id:[A-Za-z0-9]+}/rollback", api.APISessionRequired(rollbackConfig)).Methods(http.MethodPost)
 }
 
 func init() {
@@ -190,7 +195,7 @@ func updateConfig(c *Context, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	oldCfg, newCfg, appErr := c.App.SaveConfig(cfg, true)
+	oldCfg, newCfg, appErr := c.App.SaveConfigWithAuthor(cfg, true, c.AppContext.Session().UserId)
 	if appErr != nil {
 		c.Err = appErr
 		return
@@ -340,7 +345,7 @@ func patchConfig(c *Context, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	oldCfg, newCfg, appErr := c.App.SaveConfig(updatedCfg, true)
+	oldCfg, newCfg, appErr := c.App.SaveConfigWithAuthor(updatedCfg, true, c.AppContext.Session().UserId)
 	if appErr != nil {
 		c.Err = appErr
 		return
@@ -444,3 +449,100 @@ func makeFilterConfigByPermission(accessType filterType) func(c *Context, struct
 		return c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionManageSystem)
 	}
 }
+
+func getConfigHistory(c *Context, w http.ResponseWriter, r *http.Request) {
+	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionManageSystem) {
+		c.SetPermissionError(model.PermissionManageSystem)
+		return
+	}
+
+	versions, appErr := c.App.GetConfigHistory()
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(versions); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func diffConfigVersions(c *Context, w http.ResponseWriter, r *http.Request) {
+	from := r.URL.Query().Get("from")
+	if !model.IsValidId(from) {
+		c.SetInvalidURLParam("from")
+		return
+	}
+	to := r.URL.Query().Get("to")
+	if to != "" && !model.IsValidId(to) {
+		c.SetInvalidURLParam("to")
+		return
+	}
+
+	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionManageSystem) {
+		c.SetPermissionError(model.PermissionManageSystem)
+		return
+	}
+
+	diffs, appErr := c.App.DiffConfigVersions(from, to)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(diffs); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func rollbackConfig(c *Context, w http.ResponseWriter, r *http.Request) {
+	versionID := mux.Vars(r)["version_id"]
+	if !model.IsValidId(versionID) {
+		c.SetInvalidURLParam("version_id")
+		return
+	}
+
+	auditRec := c.MakeAuditRecord(model.AuditEventRollbackConfig, model.AuditStatusFail)
+	defer c.LogAuditRec(auditRec)
+	model.AddEventParameterToAuditRec(auditRec, "version_id", versionID)
+
+	if !c.App.SessionHasPermissionToAndNotRestrictedAdmin(*c.AppContext.Session(), model.PermissionManageSystem) {
+		c.SetPermissionError(model.PermissionManageSystem)
+		return
+	}
+
+	cfg, appErr := c.App.GetConfigVersion(versionID)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	// The same settings that cannot be changed through updateConfig cannot be restored either.
+	appCfg := c.App.Config()
+	*cfg.PluginSettings.EnableUploads = *appCfg.PluginSettings.EnableUploads
+	cfg.PluginSettings.SignaturePublicKeyFiles = appCfg.PluginSettings.SignaturePublicKeyFiles
+	if !*appCfg.PluginSettings.EnableUploads {
+		*cfg.PluginSettings.MarketplaceURL = *appCfg.PluginSettings.MarketplaceURL
+	}
+	if c.App.Channels().License().IsCloud() && *appCfg.ComplianceSettings.Directory != *cfg.ComplianceSettings.Directory {
+		c.Err = model.NewAppError("rollbackConfig", "api.config.update_config.not_allowed_security.app_error", map[string]any{"Name": "ComplianceSettings.Directory"}, "", http.StatusForbidden)
+		return
+	}
+
+	oldCfg, newCfg, appErr := c.App.RollbackConfig(cfg, c.AppContext.Session().UserId)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	diffs, err := config.Diff(oldCfg, newCfg)
+	if err != nil {
+		c.Err = model.NewAppError("rollbackConfig", "api.config.update_config.diff.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		return
+	}
+	auditRec.AddEventPriorState(&diffs)
+	auditRec.AddEventObjectType("config")
+	auditRec.Success()
+
+	ReturnStatusOK(w)
+}
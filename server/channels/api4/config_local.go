@@ -9,6 +9,8 @@ import (
 	"reflect"
 	"strconv"
 
+	"github.com/gorilla/mux"
+
 	"github.com/mattermost/mattermost/server/public/model"
 	"github.com/mattermost/mattermost/server/public/shared/mlog"
 	"github.com/mattermost/mattermost/server/v8/channels/utils"
@@ -22,6 +24,9 @@ func (api *API) InitConfigLocal() {
 	api.BaseRoutes.APIRoot.Handle("/config/reload", api.APILocal(configReload)).Methods(http.MethodPost)
 	api.BaseRoutes.APIRoot.Handle("/config/migrate", api.APILocal(localMigrateConfig)).Methods(http.MethodPost)
 	api.BaseRoutes.APIRoot.Handle("/config/client", api.APILocal(localGetClientConfig)).Methods(http.MethodGet)
+	api.BaseRoutes.APIRoot.Handle("/config/history", api.APILocal(getConfigHistory)).Methods(http.MethodGet)
+	api.BaseRoutes.APIRoot.Handle("/config/history/diff", api.APILocal(diffConfigVersions)).Methods(http.MethodGet)
+	api.BaseRoutes.APIRoot.Handle("/config/history/{version_id:[A-Za-z0-9]+}/rollback", api.APILocal(localRollbackConfig)).Methods(http.MethodPost)
 }
 
 func localGetConfig(c *Context, w http.ResponseWriter, r *http.Request) {
@@ -72,7 +77,7 @@ func localUpdateConfig(c *Context, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	oldCfg, newCfg, appErr := c.App.SaveConfig(cfg, true)
+	oldCfg, newCfg, appErr := c.App.SaveConfigWithAuthor(cfg, true, c.AppContext.Session().UserId)
 	if appErr != nil {
 		c.Err = appErr
 		return
@@ -131,7 +136,7 @@ func localPatchConfig(c *Context, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	oldCfg, newCfg, appErr := c.App.SaveConfig(updatedCfg, true)
+	oldCfg, newCfg, appErr := c.App.SaveConfigWithAuthor(updatedCfg, true, c.AppContext.Session().UserId)
 	if appErr != nil {
 		c.Err = appErr
 		return
@@ -194,3 +199,38 @@ func localGetClientConfig(c *Context, w http.ResponseWriter, r *http.Request) {
 		c.Logger.Warn("Error while writing response", mlog.Err(err))
 	}
 }
+
+func localRollbackConfig(c *Context, w http.ResponseWriter, r *http.Request) {
+	versionID := mux.Vars(r)["version_id"]
+	if !model.IsValidId(versionID) {
+		c.SetInvalidURLParam("version_id")
+		return
+	}
+
+	auditRec := c.MakeAuditRecord(model.AuditEventLocalRollbackConfig, model.AuditStatusFail)
+	defer c.LogAuditRec(auditRec)
+	model.AddEventParameterToAuditRec(auditRec, "version_id", versionID)
+
+	cfg, appErr := c.App.GetConfigVersion(versionID)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	oldCfg, newCfg, appErr := c.App.RollbackConfig(cfg, "")
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	diffs, err := config.Diff(oldCfg, newCfg)
+	if err != nil {
+		c.Err = model.NewAppError("localRollbackConfig", "api.config.update_config.diff.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		return
+	}
+	auditRec.AddEventPriorState(&diffs)
+	auditRec.AddEventObjectType("config")
+	auditRec.Success()
+
+	ReturnStatusOK(w)
+}
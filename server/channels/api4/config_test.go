@@ -561,6 +561,74 @@ func TestUpdateConfigDiffInAuditRecord(t *testing.T) {
 			timeoutVal+1, timeoutVal))
 }
 
+func TestConfigHistory(t *testing.T) {
+	th := Setup(t)
+	client := th.Client
+
+	t.Run("as system user", func(t *testing.T) {
+		_, resp, err := client.GetConfigHistory(context.Background())
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+
+		_, resp, err = client.DiffConfigVersions(context.Background(), model.NewId(), "")
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+
+		resp, err = client.RollbackConfig(context.Background(), model.NewId())
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	th.TestForSystemAdminAndLocal(t, func(t *testing.T, client *model.Client4) {
+		_, resp, err := client.DiffConfigVersions(context.Background(), "invalid", "")
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+
+		resp, err = client.RollbackConfig(context.Background(), model.NewId())
+		require.Error(t, err)
+		CheckNotFoundStatus(t, resp)
+	}, "as system admin and local mode")
+
+	t.Run("updates are recorded with their author", func(t *testing.T) {
+		cfg, _, err := th.SystemAdminClient.GetConfig(context.Background())
+		require.NoError(t, err)
+		*cfg.TeamSettings.SiteName = "Updated " + model.NewId()[:8]
+		_, _, err = th.SystemAdminClient.UpdateConfig(context.Background(), cfg)
+		require.NoError(t, err)
+
+		versions, _, err := th.SystemAdminClient.GetConfigHistory(context.Background())
+		require.NoError(t, err)
+		require.GreaterOrEqual(t, len(versions), 2)
+		assert.True(t, versions[0].Active)
+		assert.Equal(t, th.SystemAdminUser.Id, versions[0].AuthorId)
+
+		diffs, _, err := th.SystemAdminClient.DiffConfigVersions(context.Background(), versions[1].Id, "")
+		require.NoError(t, err)
+		paths := make([]string, 0, len(diffs))
+		for _, diff := range diffs {
+			paths = append(paths, diff.Path)
+		}
+		assert.Contains(t, paths, "TeamSettings.SiteName")
+
+		patch := &model.Config{TeamSettings: model.TeamSettings{SiteName: model.NewPointer("Patched " + model.NewId()[:8])}}
+		_, _, err = th.SystemAdminClient.PatchConfig(context.Background(), patch)
+		require.NoError(t, err)
+
+		versions, _, err = th.SystemAdminClient.GetConfigHistory(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, th.SystemAdminUser.Id, versions[0].AuthorId)
+
+		// Local mode has no user to record.
+		*cfg.TeamSettings.SiteName = "Local " + model.NewId()[:8]
+		_, _, err = th.LocalClient.UpdateConfig(context.Background(), cfg)
+		require.NoError(t, err)
+
+		versions, _, err = th.SystemAdminClient.GetConfigHistory(context.Background())
+		require.NoError(t, err)
+		assert.Empty(t, versions[0].AuthorId)
+	})
+}
+
 func TestGetEnvironmentConfig(t *testing.T) {
 	os.Setenv("MM_SERVICESETTINGS_SITEURL", "http://example.mattermost.com")
 	os.Setenv("MM_SERVICESETTINGS_ENABLECUSTOMEMOJI", "true")
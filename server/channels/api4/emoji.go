@@ -22,8 +22,8 @@ const (
 func (api *API) InitEmoji() {
 	api.BaseRoutes.Emojis.Handle("", api.APISessionRequired(createEmoji, handlerParamFileAPI)).Methods(http.MethodPost)
 	api.BaseRoutes.Emojis.Handle("", api.APISessionRequired(getEmojiList)).Methods(http.MethodGet)
-	api.BaseRoutes.Emojis.Handle("/names", api.APISessionRequired(getEmojisByNames)).Methods(http.MethodPost)
-	api.BaseRoutes.Emojis.Handle("/search", api.APISessionRequired(searchEmojis)).Methods(http.MethodPost)
+	api.BaseRoutes.Emojis.Handle("/names", api.APISessionRequired(getEmojisByNames, handlerParamSkipRecordWrite)).Methods(http.MethodPost)
+	api.BaseRoutes.Emojis.Handle("/search", api.APISessionRequired(searchEmojis, handlerParamSkipRecordWrite)).Methods(http.MethodPost)
 	api.BaseRoutes.Emojis.Handle("/autocomplete", api.APISessionRequired(autocompleteEmojis)).Methods(http.MethodGet)
 	api.BaseRoutes.Emoji.Handle("", api.APISessionRequired(deleteEmoji)).Methods(http.MethodDelete)
 	api.BaseRoutes.Emoji.Handle("", api.APISessionRequired(getEmoji)).Methods(http.MethodGet)
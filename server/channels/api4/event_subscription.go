@@ -0,0 +1,220 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api4
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+func (api *API) InitEventSubscription() {
+	api.BaseRoutes.EventHooks.Handle("", api.APISessionRequired(createEventSubscription)).Methods(http.MethodPost)
+	api.BaseRoutes.EventHooks.Handle("", api.APISessionRequired(getEventSubscriptions)).Methods(http.MethodGet)
+	api.BaseRoutes.EventHook.Handle("", api.APISessionRequired(getEventSubscription)).Methods(http.MethodGet)
+	api.BaseRoutes.EventHook.Handle("", api.APISessionRequired(updateEventSubscription)).Methods(http.MethodPut)
+	api.BaseRoutes.EventHook.Handle("", api.APISessionRequired(deleteEventSubscription)).Methods(http.MethodDelete)
+	api.BaseRoutes.EventHook.Handle("/regen_secret", api.APISessionRequired(regenEventSubscriptionSecret)).Methods(http.MethodPost)
+}
+
+// Event subscriptions can see activity across teams and in private channels,
+// so managing them is restricted to system admins, including bot accounts
+// that integrations use with the system admin role.
+
+func createEventSubscription(c *Context, w http.ResponseWriter, r *http.Request) {
+	var subscription model.EventSubscription
+	if jsonErr := json.NewDecoder(r.Body).Decode(&subscription); jsonErr != nil {
+		c.SetInvalidParamWithErr("event_subscription", jsonErr)
+		return
+	}
+
+	auditRec := c.MakeAuditRecord(model.AuditEventCreateEventSubscription, model.AuditStatusFail)
+	model.AddEventParameterAuditableToAuditRec(auditRec, "event_subscription", &subscription)
+	defer c.LogAuditRec(auditRec)
+
+	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionManageSystem) {
+		c.SetPermissionError(model.PermissionManageSystem)
+		return
+	}
+
+	subscription.CreatorId = c.AppContext.Session().UserId
+
+	rsubscription, err := c.App.CreateEventSubscription(c.AppContext, &subscription)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	auditRec.Success()
+	auditRec.AddEventResultState(rsubscription)
+	auditRec.AddEventObjectType("event_subscription")
+
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(rsubscription); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func getEventSubscriptions(c *Context, w http.ResponseWriter, r *http.Request) {
+	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionManageSystem) {
+		c.SetPermissionError(model.PermissionManageSystem)
+		return
+	}
+
+	teamID := r.URL.Query().Get("team_id")
+	if teamID != "" && !model.IsValidId(teamID) {
+		c.SetInvalidURLParam("team_id")
+		return
+	}
+
+	subscriptions, err := c.App.GetEventSubscriptionsPage(teamID, c.Params.Page, c.Params.PerPage)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(subscriptions); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func getEventSubscription(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireHookId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionManageSystem) {
+		c.SetPermissionError(model.PermissionManageSystem)
+		return
+	}
+
+	subscription, err := c.App.GetEventSubscription(c.Params.HookId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(subscription); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func updateEventSubscription(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireHookId()
+	if c.Err != nil {
+		return
+	}
+
+	var updatedSubscription model.EventSubscription
+	if jsonErr := json.NewDecoder(r.Body).Decode(&updatedSubscription); jsonErr != nil {
+		c.SetInvalidParamWithErr("event_subscription", jsonErr)
+		return
+	}
+
+	// The subscription being updated in the payload must be the same one as indicated in the URL.
+	if updatedSubscription.Id != c.Params.HookId {
+		c.SetInvalidParam("hook_id")
+		return
+	}
+
+	auditRec := c.MakeAuditRecord(model.AuditEventUpdateEventSubscription, model.AuditStatusFail)
+	model.AddEventParameterToAuditRec(auditRec, "hook_id", c.Params.HookId)
+	model.AddEventParameterAuditableToAuditRec(auditRec, "event_subscription", &updatedSubscription)
+	defer c.LogAuditRec(auditRec)
+
+	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionManageSystem) {
+		c.SetPermissionError(model.PermissionManageSystem)
+		return
+	}
+
+	oldSubscription, err := c.App.GetEventSubscription(c.Params.HookId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+	auditRec.AddEventPriorState(oldSubscription)
+
+	rsubscription, err := c.App.UpdateEventSubscription(c.AppContext, oldSubscription, &updatedSubscription)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	auditRec.Success()
+	auditRec.AddEventResultState(rsubscription)
+	auditRec.AddEventObjectType("event_subscription")
+
+	if err := json.NewEncoder(w).Encode(rsubscription); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func deleteEventSubscription(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireHookId()
+	if c.Err != nil {
+		return
+	}
+
+	auditRec := c.MakeAuditRecord(model.AuditEventDeleteEventSubscription, model.AuditStatusFail)
+	model.AddEventParameterToAuditRec(auditRec, "hook_id", c.Params.HookId)
+	defer c.LogAuditRec(auditRec)
+
+	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionManageSystem) {
+		c.SetPermissionError(model.PermissionManageSystem)
+		return
+	}
+
+	subscription, err := c.App.GetEventSubscription(c.Params.HookId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+	auditRec.AddEventPriorState(subscription)
+
+	if err := c.App.DeleteEventSubscription(subscription.Id); err != nil {
+		c.Err = err
+		return
+	}
+
+	auditRec.Success()
+	ReturnStatusOK(w)
+}
+
+func regenEventSubscriptionSecret(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireHookId()
+	if c.Err != nil {
+		return
+	}
+
+	auditRec := c.MakeAuditRecord(model.AuditEventRegenEventSubscriptionSecret, model.AuditStatusFail)
+	model.AddEventParameterToAuditRec(auditRec, "hook_id", c.Params.HookId)
+	defer c.LogAuditRec(auditRec)
+
+	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionManageSystem) {
+		c.SetPermissionError(model.PermissionManageSystem)
+		return
+	}
+
+	subscription, err := c.App.GetEventSubscription(c.Params.HookId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	rsubscription, err := c.App.RegenEventSubscriptionSecret(subscription)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	auditRec.Success()
+	auditRec.AddEventObjectType("event_subscription")
+
+	if err := json.NewEncoder(w).Encode(rsubscription); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
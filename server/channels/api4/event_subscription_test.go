@@ -0,0 +1,248 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api4
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func newTestEventSubscription(teamID, channelID string) *model.EventSubscription {
+	return &model.EventSubscription{
+		TeamId:      teamID,
+		ChannelId:   channelID,
+		DisplayName: "Subscription",
+		CallbackURL: "https://example.com/events",
+		Events:      model.StringArray{model.EventSubscriptionEventPostEdited},
+	}
+}
+
+func TestCreateEventSubscription(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t).InitBasic(t)
+
+	t.Run("disabled", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableEventSubscriptions = false })
+
+		_, resp, err := th.SystemAdminClient.CreateEventSubscription(context.Background(), newTestEventSubscription("", ""))
+		require.Error(t, err)
+		CheckNotImplementedStatus(t, resp)
+	})
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableEventSubscriptions = true })
+
+	t.Run("system admin", func(t *testing.T) {
+		subscription, resp, err := th.SystemAdminClient.CreateEventSubscription(context.Background(), newTestEventSubscription("", th.BasicChannel.Id))
+		require.NoError(t, err)
+		CheckCreatedStatus(t, resp)
+		assert.Equal(t, th.SystemAdminUser.Id, subscription.CreatorId)
+		assert.Equal(t, th.BasicTeam.Id, subscription.TeamId)
+		assert.Equal(t, th.BasicChannel.Id, subscription.ChannelId)
+		assert.Len(t, subscription.Secret, 26)
+	})
+
+	t.Run("requires manage system", func(t *testing.T) {
+		_, resp, err := th.Client.CreateEventSubscription(context.Background(), newTestEventSubscription("", ""))
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+
+		th.LoginTeamAdmin(t)
+		defer th.LoginBasic(t)
+		_, resp, err = th.Client.CreateEventSubscription(context.Background(), newTestEventSubscription(th.BasicTeam.Id, ""))
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("invalid subscription", func(t *testing.T) {
+		subscription := newTestEventSubscription("", "")
+		subscription.CallbackURL = "not a url"
+
+		_, resp, err := th.SystemAdminClient.CreateEventSubscription(context.Background(), subscription)
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("missing channel", func(t *testing.T) {
+		_, resp, err := th.SystemAdminClient.CreateEventSubscription(context.Background(), newTestEventSubscription("", model.NewId()))
+		require.Error(t, err)
+		CheckNotFoundStatus(t, resp)
+	})
+}
+
+func TestGetEventSubscriptions(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t).InitBasic(t)
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableEventSubscriptions = true })
+
+	teamSubscription, _, err := th.SystemAdminClient.CreateEventSubscription(context.Background(), newTestEventSubscription(th.BasicTeam.Id, ""))
+	require.NoError(t, err)
+	globalSubscription, _, err := th.SystemAdminClient.CreateEventSubscription(context.Background(), newTestEventSubscription("", ""))
+	require.NoError(t, err)
+
+	t.Run("all", func(t *testing.T) {
+		subscriptions, _, err := th.SystemAdminClient.GetEventSubscriptions(context.Background(), "", 0, 100)
+		require.NoError(t, err)
+		ids := []string{}
+		for _, subscription := range subscriptions {
+			ids = append(ids, subscription.Id)
+		}
+		assert.Subset(t, ids, []string{teamSubscription.Id, globalSubscription.Id})
+	})
+
+	t.Run("by team", func(t *testing.T) {
+		subscriptions, _, err := th.SystemAdminClient.GetEventSubscriptions(context.Background(), th.BasicTeam.Id, 0, 100)
+		require.NoError(t, err)
+		require.Len(t, subscriptions, 1)
+		assert.Equal(t, teamSubscription.Id, subscriptions[0].Id)
+	})
+
+	t.Run("paged", func(t *testing.T) {
+		subscriptions, _, err := th.SystemAdminClient.GetEventSubscriptions(context.Background(), th.BasicTeam.Id, 1, 100)
+		require.NoError(t, err)
+		assert.Empty(t, subscriptions)
+	})
+
+	t.Run("invalid team id", func(t *testing.T) {
+		_, resp, err := th.SystemAdminClient.GetEventSubscriptions(context.Background(), "junk", 0, 100)
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("requires manage system", func(t *testing.T) {
+		_, resp, err := th.Client.GetEventSubscriptions(context.Background(), th.BasicTeam.Id, 0, 100)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+}
+
+func TestGetEventSubscription(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t).InitBasic(t)
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableEventSubscriptions = true })
+
+	subscription, _, err := th.SystemAdminClient.CreateEventSubscription(context.Background(), newTestEventSubscription(th.BasicTeam.Id, ""))
+	require.NoError(t, err)
+
+	got, _, err := th.SystemAdminClient.GetEventSubscription(context.Background(), subscription.Id)
+	require.NoError(t, err)
+	assert.Equal(t, subscription.Id, got.Id)
+	assert.Equal(t, subscription.Events, got.Events)
+
+	_, resp, err := th.SystemAdminClient.GetEventSubscription(context.Background(), "junk")
+	require.Error(t, err)
+	CheckBadRequestStatus(t, resp)
+
+	_, resp, err = th.SystemAdminClient.GetEventSubscription(context.Background(), model.NewId())
+	require.Error(t, err)
+	CheckNotFoundStatus(t, resp)
+
+	_, resp, err = th.Client.GetEventSubscription(context.Background(), subscription.Id)
+	require.Error(t, err)
+	CheckForbiddenStatus(t, resp)
+}
+
+func TestUpdateEventSubscription(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t).InitBasic(t)
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableEventSubscriptions = true })
+
+	subscription, _, err := th.SystemAdminClient.CreateEventSubscription(context.Background(), newTestEventSubscription(th.BasicTeam.Id, ""))
+	require.NoError(t, err)
+
+	t.Run("update", func(t *testing.T) {
+		subscription.Events = model.StringArray{model.EventSubscriptionEventReactionAdded, model.EventSubscriptionEventReactionRemoved}
+		subscription.ChannelId = th.BasicChannel.Id
+
+		updated, _, err := th.SystemAdminClient.UpdateEventSubscription(context.Background(), subscription)
+		require.NoError(t, err)
+		assert.Equal(t, subscription.Events, updated.Events)
+		assert.Equal(t, th.BasicChannel.Id, updated.ChannelId)
+		assert.Equal(t, subscription.Secret, updated.Secret)
+	})
+
+	t.Run("the secret can't be set", func(t *testing.T) {
+		changed := *subscription
+		changed.Secret = model.NewId()
+
+		updated, _, err := th.SystemAdminClient.UpdateEventSubscription(context.Background(), &changed)
+		require.NoError(t, err)
+		assert.Equal(t, subscription.Secret, updated.Secret)
+	})
+
+	t.Run("missing subscription", func(t *testing.T) {
+		missing := *subscription
+		missing.Id = model.NewId()
+
+		_, resp, err := th.SystemAdminClient.UpdateEventSubscription(context.Background(), &missing)
+		require.Error(t, err)
+		CheckNotFoundStatus(t, resp)
+	})
+
+	t.Run("channel outside of the team", func(t *testing.T) {
+		changed := *subscription
+		changed.TeamId = th.CreateTeam(t).Id
+
+		_, resp, err := th.SystemAdminClient.UpdateEventSubscription(context.Background(), &changed)
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("requires manage system", func(t *testing.T) {
+		_, resp, err := th.Client.UpdateEventSubscription(context.Background(), subscription)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+}
+
+func TestRegenEventSubscriptionSecret(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t).InitBasic(t)
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableEventSubscriptions = true })
+
+	subscription, _, err := th.SystemAdminClient.CreateEventSubscription(context.Background(), newTestEventSubscription("", ""))
+	require.NoError(t, err)
+
+	updated, _, err := th.SystemAdminClient.RegenEventSubscriptionSecret(context.Background(), subscription.Id)
+	require.NoError(t, err)
+	assert.Len(t, updated.Secret, 26)
+	assert.NotEqual(t, subscription.Secret, updated.Secret)
+
+	_, resp, err := th.SystemAdminClient.RegenEventSubscriptionSecret(context.Background(), model.NewId())
+	require.Error(t, err)
+	CheckNotFoundStatus(t, resp)
+
+	_, resp, err = th.Client.RegenEventSubscriptionSecret(context.Background(), subscription.Id)
+	require.Error(t, err)
+	CheckForbiddenStatus(t, resp)
+}
+
+func TestDeleteEventSubscription(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t).InitBasic(t)
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableEventSubscriptions = true })
+
+	subscription, _, err := th.SystemAdminClient.CreateEventSubscription(context.Background(), newTestEventSubscription("", ""))
+	require.NoError(t, err)
+
+	resp, err := th.Client.DeleteEventSubscription(context.Background(), subscription.Id)
+	require.Error(t, err)
+	CheckForbiddenStatus(t, resp)
+
+	resp, err = th.SystemAdminClient.DeleteEventSubscription(context.Background(), subscription.Id)
+	require.NoError(t, err)
+	CheckOKStatus(t, resp)
+
+	_, resp, err = th.SystemAdminClient.GetEventSubscription(context.Background(), subscription.Id)
+	require.Error(t, err)
+	CheckNotFoundStatus(t, resp)
+
+	resp, err = th.SystemAdminClient.DeleteEventSubscription(context.Background(), model.NewId())
+	require.Error(t, err)
+	CheckNotFoundStatus(t, resp)
+}
@@ -0,0 +1,103 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api4
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+func (api *API) InitNotificationRule() {
+	api.BaseRoutes.User.Handle("/notification_rules", api.APISessionRequired(getNotificationRules)).Methods(http.MethodGet)
+	api.BaseRoutes.User.Handle("/notification_rules", api.APISessionRequired(updateNotificationRules)).Methods(http.MethodPut)
+	api.BaseRoutes.User.Handle("/notification_rules/test", api.APISessionRequired(testNotificationRule)).Methods(http.MethodPost)
+}
+
+func getNotificationRules(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireUserId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionToUser(*c.AppContext.Session(), c.Params.UserId) {
+		c.SetPermissionError(model.PermissionEditOtherUsers)
+		return
+	}
+
+	rules, err := c.App.GetNotificationRules(c.Params.UserId)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(rules); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func updateNotificationRules(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireUserId()
+	if c.Err != nil {
+		return
+	}
+
+	auditRec := c.MakeAuditRecord(model.AuditEventUpdateNotificationRules, model.AuditStatusFail)
+	defer c.LogAuditRec(auditRec)
+	model.AddEventParameterToAuditRec(auditRec, "user_id", c.Params.UserId)
+
+	if !c.App.SessionHasPermissionToUser(*c.AppContext.Session(), c.Params.UserId) {
+		c.SetPermissionError(model.PermissionEditOtherUsers)
+		return
+	}
+
+	var rules []*model.NotificationRule
+	if err := model.StructFromJSONLimited(r.Body, &rules); err != nil {
+		c.SetInvalidParamWithErr("notification_rules", err)
+		return
+	}
+
+	saved, err := c.App.UpdateNotificationRules(c.Params.UserId, rules)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	auditRec.Success()
+	auditRec.AddMeta("count", len(saved))
+
+	if err := json.NewEncoder(w).Encode(saved); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func testNotificationRule(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireUserId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionToUser(*c.AppContext.Session(), c.Params.UserId) {
+		c.SetPermissionError(model.PermissionEditOtherUsers)
+		return
+	}
+
+	var rule model.NotificationRule
+	if err := model.StructFromJSONLimited(r.Body, &rule); err != nil {
+		c.SetInvalidParamWithErr("notification_rule", err)
+		return
+	}
+
+	result, err := c.App.TestNotificationRule(c.AppContext, c.Params.UserId, &rule)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(result); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
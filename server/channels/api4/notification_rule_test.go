@@ -0,0 +1,89 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api4
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func TestUpdateNotificationRules(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t).InitBasic(t)
+	client := th.Client
+
+	rules := []*model.NotificationRule{
+		{
+			Name:       "Incidents",
+			Enabled:    true,
+			Conditions: model.NotificationRuleConditions{MessagePattern: `(?i)\bincident\b`},
+			Action:     model.NotificationRuleAction{Type: model.NotificationRuleActionEscalate},
+		},
+		{
+			Name:       "Bots",
+			Enabled:    true,
+			Conditions: model.NotificationRuleConditions{FromBot: model.NewPointer(true)},
+			Action:     model.NotificationRuleAction{Type: model.NotificationRuleActionSuppress},
+		},
+	}
+
+	t.Run("no rules", func(t *testing.T) {
+		received, _, err := client.GetNotificationRules(context.Background(), th.BasicUser.Id)
+		require.NoError(t, err)
+		assert.Empty(t, received)
+	})
+
+	t.Run("set rules", func(t *testing.T) {
+		saved, _, err := client.UpdateNotificationRules(context.Background(), th.BasicUser.Id, rules)
+		require.NoError(t, err)
+		require.Len(t, saved, 2)
+		assert.Equal(t, th.BasicUser.Id, saved[0].UserId)
+
+		received, _, err := client.GetNotificationRules(context.Background(), th.BasicUser.Id)
+		require.NoError(t, err)
+		require.Len(t, received, 2)
+		assert.Equal(t, saved[0].Id, received[0].Id)
+		assert.Equal(t, saved[1].Id, received[1].Id)
+	})
+
+	t.Run("invalid rules", func(t *testing.T) {
+		invalid := []*model.NotificationRule{{Name: "Invalid", Action: model.NotificationRuleAction{Type: "ignore"}}}
+		_, resp, err := client.UpdateNotificationRules(context.Background(), th.BasicUser.Id, invalid)
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("test rule", func(t *testing.T) {
+		post, _, err := th.SystemAdminClient.CreatePost(context.Background(), &model.Post{ChannelId: th.BasicChannel.Id, Message: "Incident in progress"})
+		require.NoError(t, err)
+
+		result, _, err := client.TestNotificationRule(context.Background(), th.BasicUser.Id, rules[0])
+		require.NoError(t, err)
+		assert.Contains(t, result.Matched.Order, post.Id)
+	})
+
+	t.Run("other user as regular user", func(t *testing.T) {
+		_, resp, err := client.GetNotificationRules(context.Background(), th.BasicUser2.Id)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+
+		_, resp, err = client.UpdateNotificationRules(context.Background(), th.BasicUser2.Id, rules)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+
+		_, resp, err = client.TestNotificationRule(context.Background(), th.BasicUser2.Id, rules[0])
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("other user as admin user", func(t *testing.T) {
+		_, _, err := th.SystemAdminClient.UpdateNotificationRules(context.Background(), th.BasicUser2.Id, rules)
+		require.NoError(t, err)
+	})
+}
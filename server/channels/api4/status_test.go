@@ -421,3 +421,65 @@ func TestRemoveUserCustomStatus(t *testing.T) {
 		assert.Nil(t, customStatus)
 	})
 }
+
+func TestUpdateUserWorkingHours(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t).InitBasic(t)
+	client := th.Client
+
+	workingHours := &model.WorkingHours{
+		UserId:   th.BasicUser.Id,
+		Enabled:  true,
+		Timezone: "Europe/Paris",
+		Schedule: model.WorkingHoursRanges{{Day: time.Monday, Start: "09:00", End: "17:00"}},
+	}
+
+	t.Run("no working hours", func(t *testing.T) {
+		_, resp, err := client.GetUserWorkingHours(context.Background(), th.BasicUser.Id)
+		require.Error(t, err)
+		CheckNotFoundStatus(t, resp)
+	})
+
+	t.Run("set working hours", func(t *testing.T) {
+		saved, _, err := client.UpdateUserWorkingHours(context.Background(), th.BasicUser.Id, workingHours)
+		require.NoError(t, err)
+		assert.Equal(t, model.StatusDnd, saved.QuietStatus)
+
+		received, _, err := client.GetUserWorkingHours(context.Background(), th.BasicUser.Id)
+		require.NoError(t, err)
+		assert.Equal(t, saved, received)
+	})
+
+	t.Run("invalid working hours", func(t *testing.T) {
+		invalid := *workingHours
+		invalid.QuietStatus = model.StatusOffline
+		_, resp, err := client.UpdateUserWorkingHours(context.Background(), th.BasicUser.Id, &invalid)
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("not matching user id", func(t *testing.T) {
+		_, resp, err := client.UpdateUserWorkingHours(context.Background(), th.BasicUser2.Id, workingHours)
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("other user as regular user", func(t *testing.T) {
+		_, resp, err := client.GetUserWorkingHours(context.Background(), th.BasicUser2.Id)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+
+		other := *workingHours
+		other.UserId = th.BasicUser2.Id
+		_, resp, err = client.UpdateUserWorkingHours(context.Background(), th.BasicUser2.Id, &other)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("other user as admin user", func(t *testing.T) {
+		other := *workingHours
+		other.UserId = th.BasicUser2.Id
+		_, _, err := th.SystemAdminClient.UpdateUserWorkingHours(context.Background(), th.BasicUser2.Id, &other)
+		require.NoError(t, err)
+	})
+}
@@ -48,20 +48,21 @@ func (api *API) InitSystem() {
 	api.BaseRoutes.APIRoot.Handle("/email/test", api.APISessionRequired(testEmail)).Methods(http.MethodPost)
 	api.BaseRoutes.APIRoot.Handle("/site_url/test", api.APISessionRequired(testSiteURL)).Methods(http.MethodPost)
 	api.BaseRoutes.APIRoot.Handle("/file/s3_test", api.APISessionRequired(testS3)).Methods(http.MethodPost)
+	api.BaseRoutes.APIRoot.Handle("/file/storage_migration/cutover", api.APISessionRequired(cutOverFileStorageMigration)).Methods(http.MethodPost)
 	api.BaseRoutes.APIRoot.Handle("/database/recycle", api.APISessionRequired(databaseRecycle)).Methods(http.MethodPost)
 	api.BaseRoutes.APIRoot.Handle("/caches/invalidate", api.APISessionRequired(invalidateCaches)).Methods(http.MethodPost)
 
 	api.BaseRoutes.APIRoot.Handle("/logs", api.APISessionRequired(getLogs)).Methods(http.MethodGet)
 	api.BaseRoutes.APIRoot.Handle("/logs/download", api.APISessionRequired(downloadLogs)).Methods(http.MethodGet)
-	api.BaseRoutes.APIRoot.Handle("/logs/query", api.APISessionRequired(queryLogs)).Methods(http.MethodPost)
-	api.BaseRoutes.APIRoot.Handle("/logs", api.APIHandler(postLog)).Methods(http.MethodPost)
+	api.BaseRoutes.APIRoot.Handle("/logs/query", api.APISessionRequired(queryLogs, handlerParamSkipRecordWrite)).Methods(http.MethodPost)
+	api.BaseRoutes.APIRoot.Handle("/logs", api.APIHandler(postLog, handlerParamSkipRecordWrite)).Methods(http.MethodPost)
 
 	api.BaseRoutes.APIRoot.Handle("/analytics/old", api.APISessionRequired(getAnalytics)).Methods(http.MethodGet)
 	api.BaseRoutes.APIRoot.Handle("/latest_version", api.APISessionRequired(getLatestVersion)).Methods(http.MethodGet)
 
 	api.BaseRoutes.APIRoot.Handle("/redirect_location", api.APISessionRequiredTrustRequester(getRedirectLocation)).Methods(http.MethodGet)
 
-	api.BaseRoutes.APIRoot.Handle("/notifications/ack", api.APISessionRequired(pushNotificationAck)).Methods(http.MethodPost)
+	api.BaseRoutes.APIRoot.Handle("/notifications/ack", api.APISessionRequired(pushNotificationAck, handlerParamSkipRecordWrite)).Methods(http.MethodPost)
 
 	api.BaseRoutes.APIRoot.Handle("/server_busy", api.APISessionRequired(setServerBusy)).Methods(http.MethodPost)
 	api.BaseRoutes.APIRoot.Handle("/server_busy", api.APISessionRequired(getServerBusyExpires)).Methods(http.MethodGet)
@@ -76,6 +77,7 @@ func (api *API) InitSystem() {
 	api.BaseRoutes.System.Handle("/onboarding/complete", api.APISessionRequired(getOnboarding)).Methods(http.MethodGet)
 	api.BaseRoutes.System.Handle("/onboarding/complete", api.APISessionRequired(completeOnboarding)).Methods(http.MethodPost)
 	api.BaseRoutes.System.Handle("/schema/version", api.APISessionRequired(getAppliedSchemaMigrations)).Methods(http.MethodGet)
+	api.BaseRoutes.System.Handle("/rate_limits", api.APISessionRequired(getRateLimitCounters)).Methods(http.MethodGet)
 }
 
 func generateSupportPacket(c *Context, w http.ResponseWriter, r *http.Request) {
@@ -580,6 +582,16 @@ func testS3(c *Context, w http.ResponseWriter, r *http.Request) {
 	if *cfg.FileSettings.AmazonS3SecretAccessKey == model.FakeSetting {
 		cfg.FileSettings.AmazonS3SecretAccessKey = c.App.Config().FileSettings.AmazonS3SecretAccessKey
 	}
+	if *cfg.FileSettings.EncryptionMasterKey == model.FakeSetting {
+		cfg.FileSettings.EncryptionMasterKey = c.App.Config().FileSettings.EncryptionMasterKey
+	}
+	if len(cfg.FileSettings.EncryptionPreviousMasterKeys) == len(c.App.Config().FileSettings.EncryptionPreviousMasterKeys) {
+		for i, value := range cfg.FileSettings.EncryptionPreviousMasterKeys {
+			if value == model.FakeSetting {
+				cfg.FileSettings.EncryptionPreviousMasterKeys[i] = c.App.Config().FileSettings.EncryptionPreviousMasterKeys[i]
+			}
+		}
+	}
 
 	appErr = c.App.TestFileStoreConnectionWithConfig(&cfg.FileSettings)
 	if appErr != nil {
@@ -590,6 +602,24 @@ func testS3(c *Context, w http.ResponseWriter, r *http.Request) {
 	ReturnStatusOK(w)
 }
 
+func cutOverFileStorageMigration(c *Context, w http.ResponseWriter, r *http.Request) {
+	if !c.App.SessionHasPermissionToAndNotRestrictedAdmin(*c.AppContext.Session(), model.PermissionManageSystem) {
+		c.SetPermissionError(model.PermissionManageSystem)
+		return
+	}
+
+	auditRec := c.MakeAuditRecord(model.AuditEventCutOverFileStorageMigration, model.AuditStatusFail)
+	defer c.LogAuditRec(auditRec)
+
+	if appErr := c.App.CutOverFileStorageMigration(c.AppContext); appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec.Success()
+	ReturnStatusOK(w)
+}
+
 func getRedirectLocation(c *Context, w http.ResponseWriter, r *http.Request) {
 	m := make(map[string]string)
 	m["location"] = ""
@@ -1087,3 +1117,26 @@ func checkHasNilFields(value any) bool {
 
 	return false
 }
+
+func getRateLimitCounters(c *Context, w http.ResponseWriter, r *http.Request) {
+	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionSysconsoleReadEnvironmentRateLimiting) {
+		c.SetPermissionError(model.PermissionSysconsoleReadEnvironmentRateLimiting)
+		return
+	}
+
+	key := r.URL.Query().Get("key")
+	if key == "" {
+		c.SetInvalidURLParam("key")
+		return
+	}
+
+	counters, appErr := c.App.GetRateLimitCounters(key, r.URL.Query().Get("rule"))
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(counters); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
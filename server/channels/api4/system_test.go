@@ -23,6 +23,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/channels/app"
 	"github.com/mattermost/mattermost/server/v8/channels/utils/fileutils"
 )
 
@@ -1171,6 +1172,61 @@ func TestGetAppliedSchemaMigrations(t *testing.T) {
 	})
 }
 
+func TestGetRateLimitCounters(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t)
+
+	t.Run("as a regular user", func(t *testing.T) {
+		_, resp, err := th.Client.GetRateLimitCounters(context.Background(), th.BasicUser.Id, "")
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("rate limiting disabled", func(t *testing.T) {
+		_, resp, err := th.SystemAdminClient.GetRateLimitCounters(context.Background(), th.BasicUser.Id, "")
+		require.Error(t, err)
+		CheckNotImplementedStatus(t, resp)
+	})
+
+	settings := model.RateLimitSettings{
+		Rules: []*model.RateLimitRule{{
+			Name:   model.NewPointer("posts"),
+			Routes: []string{"/api/v4/posts"},
+		}},
+	}
+	settings.SetDefaults()
+	rateLimiter, err := app.NewRateLimiter(&settings, nil)
+	require.NoError(t, err)
+	th.App.Srv().RateLimiter = rateLimiter
+	defer func() {
+		th.App.Srv().RateLimiter = nil
+	}()
+
+	t.Run("missing key", func(t *testing.T) {
+		_, resp, err := th.SystemAdminClient.GetRateLimitCounters(context.Background(), "", "")
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("every rule", func(t *testing.T) {
+		counters, resp, err := th.SystemAdminClient.GetRateLimitCounters(context.Background(), "user:"+th.BasicUser.Id, "")
+		require.NoError(t, err)
+		CheckOKStatus(t, resp)
+		require.Len(t, counters, 2)
+		require.Equal(t, model.RateLimitDefaultRuleName, counters[0].Rule)
+		require.Equal(t, "posts", counters[1].Rule)
+		require.Equal(t, 1, counters[1].Limit)
+	})
+
+	t.Run("one rule", func(t *testing.T) {
+		counters, resp, err := th.SystemAdminClient.GetRateLimitCounters(context.Background(), "user:"+th.BasicUser.Id, "posts")
+		require.NoError(t, err)
+		CheckOKStatus(t, resp)
+		require.Len(t, counters, 1)
+		require.Equal(t, "posts", counters[0].Rule)
+	})
+}
+
 func TestCheckHasNilFields(t *testing.T) {
 	mainHelper.Parallel(t)
 	t.Run("check if the empty struct has nil fields", func(t *testing.T) {
@@ -20,7 +20,7 @@ import (
 func (api *API) InitTeamLocal() {
 	api.BaseRoutes.Teams.Handle("", api.APILocal(localCreateTeam)).Methods(http.MethodPost)
 	api.BaseRoutes.Teams.Handle("", api.APILocal(getAllTeams)).Methods(http.MethodGet)
-	api.BaseRoutes.Teams.Handle("/search", api.APILocal(searchTeams)).Methods(http.MethodPost)
+	api.BaseRoutes.Teams.Handle("/search", api.APILocal(searchTeams, handlerParamSkipRecordWrite)).Methods(http.MethodPost)
 
 	api.BaseRoutes.Team.Handle("", api.APILocal(getTeam)).Methods(http.MethodGet)
 	api.BaseRoutes.Team.Handle("", api.APILocal(updateTeam)).Methods(http.MethodPut)
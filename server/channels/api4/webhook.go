@@ -180,6 +180,7 @@ func updateIncomingHook(c *Context, w http.ResponseWriter, r *http.Request) {
 	auditRec.Success()
 	c.LogAudit("success")
 
+	incomingHook.Sanitize()
 	w.WriteHeader(http.StatusCreated)
 	if err := json.NewEncoder(w).Encode(incomingHook); err != nil {
 		c.Logger.Warn("Error while writing response", mlog.Err(err))
@@ -228,6 +229,10 @@ func getIncomingHooks(c *Context, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	for _, hook := range hooks {
+		hook.Sanitize()
+	}
+
 	if c.Params.IncludeTotalCount {
 		totalCount, appErr := c.App.GetIncomingWebhooksCount(teamID, userID)
 
@@ -301,6 +306,7 @@ func getIncomingHook(c *Context, w http.ResponseWriter, r *http.Request) {
 	auditRec.Success()
 	c.LogAudit("success")
 
+	hook.Sanitize()
 	if err := json.NewEncoder(w).Encode(hook); err != nil {
 		c.Logger.Warn("Error while writing response", mlog.Err(err))
 	}
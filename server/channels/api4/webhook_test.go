@@ -449,6 +449,41 @@ func TestGetIncomingWebhook(t *testing.T) {
 		CheckNotFoundStatus(t, resp)
 	}, "WhenHookDoesNotExist")
 
+	t.Run("WhenHookIsSigned", func(t *testing.T) {
+		secret := model.NewId()
+		signedHook, _, err := th.SystemAdminClient.CreateIncomingWebhook(context.Background(), &model.IncomingWebhook{ChannelId: th.BasicChannel.Id, SigningSecret: secret})
+		require.NoError(t, err)
+		assert.Equal(t, secret, signedHook.SigningSecret)
+
+		gotHook, _, err := th.SystemAdminClient.GetIncomingWebhook(context.Background(), signedHook.Id, "")
+		require.NoError(t, err)
+		assert.Empty(t, gotHook.SigningSecret)
+
+		hooks, _, err := th.SystemAdminClient.GetIncomingWebhooksForTeam(context.Background(), th.BasicTeam.Id, 0, 1000, "")
+		require.NoError(t, err)
+		for _, hook := range hooks {
+			assert.Empty(t, hook.SigningSecret)
+		}
+
+		gotHook.DisplayName = "renamed"
+		updatedHook, _, err := th.SystemAdminClient.UpdateIncomingWebhook(context.Background(), gotHook)
+		require.NoError(t, err)
+		assert.Equal(t, "renamed", updatedHook.DisplayName)
+		assert.Empty(t, updatedHook.SigningSecret)
+
+		storedHook, appErr := th.App.GetIncomingWebhook(signedHook.Id)
+		require.Nil(t, appErr)
+		assert.Equal(t, secret, storedHook.SigningSecret)
+
+		updatedHook.RemoveSigningSecret = true
+		_, _, err = th.SystemAdminClient.UpdateIncomingWebhook(context.Background(), updatedHook)
+		require.NoError(t, err)
+
+		storedHook, appErr = th.App.GetIncomingWebhook(signedHook.Id)
+		require.Nil(t, appErr)
+		assert.Empty(t, storedHook.SigningSecret)
+	})
+
 	th.TestForSystemAdminAndLocal(t, func(t *testing.T, client *model.Client4) {
 		_, resp, err := client.GetIncomingWebhook(context.Background(), "abc", "")
 		require.Error(t, err)
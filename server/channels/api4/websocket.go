@@ -20,6 +20,7 @@ const (
 	sequenceNumberParam    = "sequence_number"
 	postedAckParam         = "posted_ack"
 	disconnectErrCodeParam = "disconnect_err_code"
+	lastEventIDHeader      = "Last-Event-ID"
 
 	clientPingTimeoutErrCode      = 4000
 	clientSequenceMismatchErrCode = 4001
@@ -52,6 +53,11 @@ func validateDisconnectErrCode(errCode string) bool {
 func (api *API) InitWebSocket() {
 	// Optionally supports a trailing slash
 	api.BaseRoutes.APIRoot.Handle("/{websocket:websocket(?:\\/)?}", api.APIHandlerTrustRequester(connectWebSocket)).Methods(http.MethodGet)
+
+	// Fallbacks for the clients which can't use a websocket.
+	api.BaseRoutes.APIRoot.Handle("/websocket/events", api.APISessionRequired(connectEventStream)).Methods(http.MethodGet)
+	api.BaseRoutes.APIRoot.Handle("/websocket/poll", api.APISessionRequired(pollEventStream)).Methods(http.MethodGet)
+	api.BaseRoutes.APIRoot.Handle("/websocket/actions", api.APISessionRequired(postEventStreamAction)).Methods(http.MethodPost)
 }
 
 func connectWebSocket(c *Context, w http.ResponseWriter, r *http.Request) {
@@ -123,3 +129,92 @@ func connectWebSocket(c *Context, w http.ResponseWriter, r *http.Request) {
 
 	wc.Pump()
 }
+
+// connectEventStream streams the websocket events as Server-Sent Events. The client resumes
+// the stream with the Last-Event-ID header, or the same query parameters as the websocket.
+func connectEventStream(c *Context, w http.ResponseWriter, r *http.Request) {
+	connectionID := r.URL.Query().Get(connectionIDParam)
+	seqVal := r.URL.Query().Get(sequenceNumberParam)
+	if lastEventID := r.Header.Get(lastEventIDHeader); lastEventID != "" {
+		var err error
+		connectionID, seqVal, err = platform.ParseEventStreamID(lastEventID)
+		if err != nil {
+			c.SetInvalidParamWithErr(lastEventIDHeader, err)
+			return
+		}
+	}
+
+	serveEventStream(c, w, r, platform.NewEventStream(w, r), connectionID, seqVal)
+}
+
+// pollEventStream returns the websocket events following the given sequence number, as
+// soon as there are any, or an empty list if there are none for a while.
+func pollEventStream(c *Context, w http.ResponseWriter, r *http.Request) {
+	serveEventStream(c, w, r, platform.NewLongPollStream(w, r), r.URL.Query().Get(connectionIDParam), r.URL.Query().Get(sequenceNumberParam))
+}
+
+func serveEventStream(c *Context, w http.ResponseWriter, r *http.Request, stream *platform.EventStream, connectionID, seqVal string) {
+	cfg := &platform.WebConnConfig{
+		EventStream:   stream,
+		Session:       *c.AppContext.Session(),
+		TFunc:         c.AppContext.T,
+		Locale:        "",
+		ConnectionID:  connectionID,
+		Active:        true,
+		PostedAck:     r.URL.Query().Get(postedAckParam) == "true",
+		RemoteAddress: c.AppContext.IPAddress(),
+		XForwardedFor: c.AppContext.XForwardedFor(),
+	}
+
+	if c.AppContext.Session().IsMobileApp() {
+		cfg.OriginClient = "mobile"
+	} else {
+		cfg.OriginClient = string(web.GetOriginClient(r))
+	}
+
+	if cfg.ConnectionID == "" {
+		cfg.ConnectionID = model.NewId()
+	} else {
+		var err error
+		cfg, err = c.App.Srv().Platform().PopulateWebConnConfig(c.AppContext.Session(), cfg, seqVal)
+		if err != nil {
+			c.Err = model.NewAppError("serveEventStream", "api.event_stream.connect.app_error", nil, "", http.StatusBadRequest).Wrap(err)
+			return
+		}
+	}
+
+	wc := c.App.Srv().Platform().NewWebConn(cfg, c.App, c.App.Srv().Channels())
+	if err := c.App.Srv().Platform().HubRegister(wc); err != nil {
+		c.Err = model.NewAppError("serveEventStream", "api.event_stream.register.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		return
+	}
+
+	wc.Pump()
+
+	if err := stream.End(); err != nil {
+		c.Logger.Debug("Error while ending event stream", mlog.String("connection_id", wc.GetConnectionID()), mlog.Err(err))
+	}
+}
+
+// postEventStreamAction serves a websocket request of a client connected through an event
+// stream. The response is written to the stream, as it would be to the websocket.
+func postEventStreamAction(c *Context, w http.ResponseWriter, r *http.Request) {
+	connectionID := r.URL.Query().Get(connectionIDParam)
+	if !model.IsValidId(connectionID) {
+		c.SetInvalidURLParam(connectionIDParam)
+		return
+	}
+
+	var req model.WebSocketRequest
+	if err := model.StructFromJSONLimited(r.Body, &req); err != nil {
+		c.SetInvalidParamWithErr("request", err)
+		return
+	}
+
+	if !c.App.Srv().Platform().ServeEventStreamRequest(c.AppContext.Session(), connectionID, &req) {
+		c.Err = model.NewAppError("postEventStreamAction", "api.event_stream.action.not_found.app_error", nil, "", http.StatusNotFound)
+		return
+	}
+
+	ReturnStatusOK(w)
+}
@@ -4,7 +4,9 @@
 package api4
 
 import (
+	"bufio"
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"strings"
@@ -18,6 +20,7 @@ import (
 
 	"github.com/mattermost/mattermost/server/public/model"
 	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/v8/channels/app/platform"
 	"github.com/mattermost/mattermost/server/v8/channels/testlib"
 )
 
@@ -669,3 +672,166 @@ func TestWebSocketMFAEnforcement(t *testing.T) {
 		}
 	})
 }
+
+// readEventStreamMessage reads the messages of a Server-Sent Events stream until one matches,
+// and returns its ID and data.
+func readEventStreamMessage(t *testing.T, rd *bufio.Reader, match func(map[string]any) bool) (string, map[string]any) {
+	t.Helper()
+
+	var id string
+	var data map[string]any
+	for {
+		line, err := rd.ReadString('\n')
+		require.NoError(t, err)
+		line = strings.TrimSuffix(line, "\n")
+
+		switch {
+		case line == "" && data != nil:
+			if match(data) {
+				return id, data
+			}
+			id, data = "", nil
+		case strings.HasPrefix(line, "id: "):
+			id = strings.TrimPrefix(line, "id: ")
+		case strings.HasPrefix(line, "data: "):
+			require.NoError(t, json.Unmarshal([]byte(strings.TrimPrefix(line, "data: ")), &data))
+		}
+	}
+}
+
+func isEventStreamEvent(eventType model.WebsocketEventType) func(map[string]any) bool {
+	return func(data map[string]any) bool {
+		return data["event"] == string(eventType)
+	}
+}
+
+func TestEventStream(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t).InitBasic(t)
+	client := th.Client
+
+	resp, err := client.DoAPIRequestWithHeaders(context.Background(), http.MethodGet, client.APIURL+"/websocket/events", "", nil)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+	rd := bufio.NewReader(resp.Body)
+
+	id, data := readEventStreamMessage(t, rd, isEventStreamEvent(model.WebsocketEventHello))
+	connectionID := data["data"].(map[string]any)["connection_id"].(string)
+	require.Equal(t, connectionID+":0", id)
+
+	var lastID string
+	t.Run("events are filtered as for a websocket", func(t *testing.T) {
+		th.App.Publish(model.NewWebSocketEvent(model.WebsocketEventChannelUpdated, "", model.NewId(), "", nil, ""))
+		th.App.Publish(model.NewWebSocketEvent(model.WebsocketEventChannelUpdated, "", th.BasicChannel.Id, "", nil, ""))
+
+		id, data := readEventStreamMessage(t, rd, isEventStreamEvent(model.WebsocketEventChannelUpdated))
+		require.Equal(t, th.BasicChannel.Id, data["broadcast"].(map[string]any)["channel_id"])
+		require.Equal(t, fmt.Sprintf("%s:%v", connectionID, data["seq"]), id)
+		lastID = id
+	})
+
+	t.Run("actions are answered through the stream", func(t *testing.T) {
+		req := &model.WebSocketRequest{Seq: 1, Action: string(model.WebsocketPresenceIndicator), Data: map[string]any{"channel_id": th.BasicChannel.Id}}
+		actionResp, err := client.DoAPIPostJSON(context.Background(), "/websocket/actions?connection_id="+connectionID, req)
+		require.NoError(t, err)
+		CheckOKStatus(t, model.BuildResponse(actionResp))
+
+		id, data := readEventStreamMessage(t, rd, func(data map[string]any) bool {
+			return data["seq_reply"] != nil
+		})
+		require.Empty(t, id)
+		require.Equal(t, model.StatusOk, data["status"])
+		require.Equal(t, float64(1), data["seq_reply"])
+	})
+
+	t.Run("actions for a connection of another user", func(t *testing.T) {
+		req := &model.WebSocketRequest{Seq: 2, Action: string(model.WebsocketPresenceIndicator)}
+		actionResp, err := th.SystemAdminClient.DoAPIPostJSON(context.Background(), "/websocket/actions?connection_id="+connectionID, req)
+		require.Error(t, err)
+		CheckNotFoundStatus(t, model.BuildResponse(actionResp))
+	})
+
+	t.Run("resume from the last event id", func(t *testing.T) {
+		require.NotEmpty(t, lastID)
+		resp.Body.Close()
+		require.Eventually(t, func() bool {
+			return th.App.Srv().Platform().WebConnCountForUser(th.BasicUser.Id) == 0
+		}, 5*time.Second, 100*time.Millisecond)
+
+		th.App.Publish(model.NewWebSocketEvent(model.WebsocketEventChannelDeleted, "", th.BasicChannel.Id, "", nil, ""))
+
+		resumed, err := client.DoAPIRequestWithHeaders(context.Background(), http.MethodGet, client.APIURL+"/websocket/events", "", map[string]string{
+			"Last-Event-ID": lastID,
+		})
+		require.NoError(t, err)
+		defer resumed.Body.Close()
+
+		_, data := readEventStreamMessage(t, bufio.NewReader(resumed.Body), func(map[string]any) bool { return true })
+		_, seq, err := platform.ParseEventStreamID(lastID)
+		require.NoError(t, err)
+		require.Equal(t, seq, fmt.Sprint(data["seq"]), "the stream should resume right after the last event")
+	})
+
+	t.Run("invalid last event id", func(t *testing.T) {
+		resp, err := client.DoAPIRequestWithHeaders(context.Background(), http.MethodGet, client.APIURL+"/websocket/events", "", map[string]string{
+			"Last-Event-ID": "invalid",
+		})
+		require.Error(t, err)
+		CheckBadRequestStatus(t, model.BuildResponse(resp))
+	})
+}
+
+func TestEventStreamLongPoll(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t).InitBasic(t)
+	client := th.Client
+
+	poll := func(query string) []map[string]any {
+		resp, err := client.DoAPIGet(context.Background(), "/websocket/poll"+query, "")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		var msgs []map[string]any
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&msgs))
+		return msgs
+	}
+
+	msgs := poll("")
+	require.NotEmpty(t, msgs)
+	require.Equal(t, string(model.WebsocketEventHello), msgs[0]["event"])
+	connectionID := msgs[0]["data"].(map[string]any)["connection_id"].(string)
+
+	th.App.Publish(model.NewWebSocketEvent(model.WebsocketEventChannelUpdated, "", th.BasicChannel.Id, "", nil, ""))
+
+	// Polls until the event shows up, resuming from the last event each time.
+	var updated map[string]any
+	next := float64(0)
+	for range 5 {
+		for _, msg := range msgs {
+			require.Equal(t, next, msg["seq"], "events should be returned in sequence")
+			next++
+			if msg["event"] == string(model.WebsocketEventChannelUpdated) {
+				updated = msg
+			}
+		}
+		if updated != nil {
+			break
+		}
+		msgs = poll(fmt.Sprintf("?connection_id=%s&sequence_number=%v", connectionID, next))
+	}
+	require.NotNil(t, updated)
+
+	t.Run("missed events are returned again", func(t *testing.T) {
+		msgs := poll(fmt.Sprintf("?connection_id=%s&sequence_number=%v", connectionID, updated["seq"]))
+		require.NotEmpty(t, msgs)
+		require.Equal(t, updated["seq"], msgs[0]["seq"])
+		require.Equal(t, updated["event"], msgs[0]["event"])
+	})
+
+	t.Run("invalid sequence number", func(t *testing.T) {
+		resp, err := client.DoAPIGet(context.Background(), "/websocket/poll?connection_id="+connectionID+"&sequence_number=one", "")
+		require.Error(t, err)
+		CheckBadRequestStatus(t, model.BuildResponse(resp))
+	})
+}
@@ -5,9 +5,14 @@ package app
 
 import (
 	"context"
+	"errors"
+	"net/http"
+	"time"
 
 	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/plugin"
 	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
 	"github.com/mattermost/mattermost/server/v8/channels/app/openai"
 )
 
@@ -100,6 +105,129 @@ func (s *AIService) TestConnection(ctx context.Context, testPrompt string) (stri
 	return result, nil
 }
 
+// complete sends the prompt to the LLM, once the plugins had the chance to modify or reject it.
+// The error is a *model.AppError when the prompt isn't sent, e.g. because a plugin rejected it.
+func (s *AIService) complete(rctx request.CTX, prompt *model.AIPrompt) (*model.AICompletionResponse, error) {
+	if prompt.UserId == "" {
+		prompt.UserId = rctx.Session().UserId
+	}
+	if prompt.Model == "" {
+		prompt.Model = s.app.GetAIModel()
+	}
+
+	if prompt.PluginId != "" {
+		if appErr := s.app.checkAIPluginRateLimit(prompt.PluginId); appErr != nil {
+			return nil, appErr
+		}
+	}
+
+	if appErr := s.app.runAIPromptWillBeSentHook(rctx, prompt); appErr != nil {
+		return nil, appErr
+	}
+
+	messages := make([]openai.ChatCompletionMessage, 0, 2)
+	if prompt.SystemPrompt != "" {
+		messages = append(messages, openai.ChatCompletionMessage{Role: "system", Content: prompt.SystemPrompt})
+	}
+	messages = append(messages, openai.ChatCompletionMessage{Role: "user", Content: prompt.UserPrompt})
+
+	completion, err := s.client.CreateChatCompletion(rctx.Context(), openai.ChatCompletionRequest{
+		Model:    prompt.Model,
+		Messages: messages,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(completion.Choices) == 0 {
+		return nil, &openai.ClientError{Message: "no choices returned from API"}
+	}
+
+	return &model.AICompletionResponse{
+		Text:             completion.Choices[0].Message.Content,
+		Model:            completion.Model,
+		PromptTokens:     completion.Usage.PromptTokens,
+		CompletionTokens: completion.Usage.CompletionTokens,
+	}, nil
+}
+
+// aiCompletionError returns the app error for a failed completion, keeping the app errors
+// of the prompts which weren't sent as is.
+func aiCompletionError(where, id string, err error) *model.AppError {
+	var appErr *model.AppError
+	if errors.As(err, &appErr) {
+		return appErr
+	}
+	return model.NewAppError(where, id, nil, err.Error(), http.StatusInternalServerError)
+}
+
+// runAIPromptWillBeSentHook lets the plugins modify or reject the prompt before it's sent.
+func (a *App) runAIPromptWillBeSentHook(rctx request.CTX, prompt *model.AIPrompt) *model.AppError {
+	pluginContext := pluginContext(rctx)
+	rejectionReason := ""
+	a.ch.RunMultiHook(func(hooks plugin.Hooks, manifest *model.Manifest) bool {
+		var replacement *model.AIPrompt
+		replacement, rejectionReason = hooks.AIPromptWillBeSent(pluginContext, prompt)
+		if rejectionReason != "" {
+			rctx.Logger().Info("AI prompt rejected by plugin.",
+				mlog.String("feature", prompt.Feature),
+				mlog.String("rejection_reason", rejectionReason),
+				mlog.String("plugin_id", manifest.Id))
+			return false
+		}
+		if replacement != nil {
+			// Only the content of the prompt can be changed.
+			prompt.SystemPrompt = replacement.SystemPrompt
+			prompt.UserPrompt = replacement.UserPrompt
+			if replacement.Model != "" {
+				prompt.Model = replacement.Model
+			}
+		}
+		return true
+	}, plugin.AIPromptWillBeSentID)
+
+	if rejectionReason != "" {
+		return model.NewAppError("runAIPromptWillBeSentHook", "app.ai.prompt_rejected_by_plugin.app_error",
+			map[string]any{"Reason": rejectionReason}, "", http.StatusBadRequest)
+	}
+	return nil
+}
+
+// aiPluginUsage is the number of AI prompts a plugin sent in a given minute.
+type aiPluginUsage struct {
+	minute int64
+	count  int
+}
+
+// checkAIPluginRateLimit counts a prompt sent by the plugin against the AI rate limit, which
+// applies to each plugin separately.
+func (a *App) checkAIPluginRateLimit(pluginID string) *model.AppError {
+	limit := a.GetAIRateLimit()
+	if limit <= 0 {
+		return nil
+	}
+
+	minute := time.Now().Unix() / 60
+
+	a.ch.aiPluginUsageMut.Lock()
+	defer a.ch.aiPluginUsageMut.Unlock()
+
+	if a.ch.aiPluginUsage == nil {
+		a.ch.aiPluginUsage = make(map[string]*aiPluginUsage)
+	}
+	usage := a.ch.aiPluginUsage[pluginID]
+	if usage == nil || usage.minute != minute {
+		usage = &aiPluginUsage{minute: minute}
+		a.ch.aiPluginUsage[pluginID] = usage
+	}
+	if usage.count >= limit {
+		return model.NewAppError("checkAIPluginRateLimit", "app.ai.plugin_rate_limited.app_error",
+			map[string]any{"Limit": limit}, "plugin_id="+pluginID, http.StatusTooManyRequests)
+	}
+	usage.count++
+
+	return nil
+}
+
 // GetAIModel returns the configured AI model or the default
 func (a *App) GetAIModel() string {
 	if a.Config().AISettings.OpenAIModel != nil && *a.Config().AISettings.OpenAIModel != "" {
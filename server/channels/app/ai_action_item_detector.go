@@ -152,11 +152,15 @@ func (a *App) extractActionItemsWithAI(c request.CTX, message string, authorName
 	userPrompt := openai.BuildActionItemExtractionUserPrompt(message, authorName, channelName)
 
 	// Call OpenAI
-	aiModel := a.GetAIModel()
-	response, err := aiService.client.SimpleCompletion(c.Context(), aiModel, systemPrompt, userPrompt)
+	completion, err := aiService.complete(c, &model.AIPrompt{
+		Feature:      model.AIFeatureActionItems,
+		SystemPrompt: systemPrompt,
+		UserPrompt:   userPrompt,
+	})
 	if err != nil {
-		return nil, model.NewAppError("extractActionItemsWithAI", "app.ai.extraction_failed", nil, err.Error(), 500)
+		return nil, aiCompletionError("extractActionItemsWithAI", "app.ai.extraction_failed", err)
 	}
+	response := completion.Text
 
 	// Parse response
 	items, err := a.parseActionItemsResponse(response)
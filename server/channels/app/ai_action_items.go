@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/plugin"
 	"github.com/mattermost/mattermost/server/public/shared/mlog"
 	"github.com/mattermost/mattermost/server/public/shared/request"
 )
@@ -55,6 +56,15 @@ func (a *App) CreateActionItem(c request.CTX, item *model.AIActionItem) (*model.
 		mlog.String("channel_id", created.ChannelId),
 	)
 
+	pluginContext := pluginContext(c)
+	pluginItem := *created
+	a.Srv().Go(func() {
+		a.ch.RunMultiHook(func(hooks plugin.Hooks, _ *model.Manifest) bool {
+			hooks.ActionItemHasBeenCreated(pluginContext, &pluginItem)
+			return true
+		}, plugin.ActionItemHasBeenCreatedID)
+	})
+
 	return created, nil
 }
 
@@ -146,6 +156,8 @@ func (a *App) UpdateActionItem(c request.CTX, actionItemID string, userID string
 		return nil, model.NewAppError("UpdateActionItem", "api.action_item.update.permission_denied", nil, "", 403)
 	}
 
+	oldItem := *item
+
 	// Apply updates
 	if update.Description != nil {
 		item.Description = *update.Description
@@ -189,6 +201,15 @@ func (a *App) UpdateActionItem(c request.CTX, actionItemID string, userID string
 		mlog.String("status", updated.Status),
 	)
 
+	pluginContext := pluginContext(c)
+	pluginNewItem := *updated
+	a.Srv().Go(func() {
+		a.ch.RunMultiHook(func(hooks plugin.Hooks, _ *model.Manifest) bool {
+			hooks.ActionItemHasBeenUpdated(pluginContext, &pluginNewItem, &oldItem)
+			return true
+		}, plugin.ActionItemHasBeenUpdatedID)
+	})
+
 	return updated, nil
 }
 
@@ -54,13 +54,19 @@ func (a *App) FormatMessage(c request.CTX, req *FormattingRequest) (*FormattingR
 	// Call OpenAI
 	systemPrompt, _ := promptTemplate.Substitute(nil)
 	
-	formattedText, err := aiService.client.SimpleCompletion(c.Context(), a.GetAIModel(), systemPrompt, userPrompt)
+	completion, err := aiService.complete(c, &model.AIPrompt{
+		Feature:      model.AIFeatureFormatting,
+		UserId:       req.UserId,
+		PluginId:     req.PluginId,
+		SystemPrompt: systemPrompt,
+		UserPrompt:   userPrompt,
+	})
 	if err != nil {
 		c.Logger().Error("Failed to format message", mlog.Err(err))
-		return nil, model.NewAppError("FormatMessage", "app.ai.formatting_failed", nil, err.Error(), 500)
+		return nil, aiCompletionError("FormatMessage", "app.ai.formatting_failed", err)
 	}
 
-	formattedText = strings.TrimSpace(formattedText)
+	formattedText := strings.TrimSpace(completion.Text)
 
 	// Generate diff for preview
 	diff := a.generateTextDiff(req.Message, formattedText)
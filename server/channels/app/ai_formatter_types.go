@@ -12,6 +12,8 @@ type FormattingRequest struct {
 	Message         string                `json:"message"`
 	Profile         openai.FormattingProfile `json:"profile"`
 	CustomInstructions string              `json:"custom_instructions,omitempty"`
+	UserId             string                `json:"-"`
+	PluginId           string                `json:"-"`
 }
 
 // FormattingResponse represents the response from formatting
@@ -105,11 +105,18 @@ func (a *App) SummarizeThread(c request.CTX, req *SummarizationRequest) (*Summar
 	}
 
 	// Generate summary via OpenAI
-	summaryText, openaiErr := aiService.client.SimpleCompletion(c.Context(), a.GetAIModel(), systemPrompt, userPrompt)
+	completion, openaiErr := aiService.complete(c, &model.AIPrompt{
+		Feature:      model.AIFeatureSummarization,
+		UserId:       req.UserId,
+		PluginId:     req.PluginId,
+		SystemPrompt: systemPrompt,
+		UserPrompt:   userPrompt,
+	})
 	if openaiErr != nil {
 		a.Log().Error("Failed to generate thread summary", mlog.Err(openaiErr))
-		return nil, model.NewAppError("SummarizeThread", "app.ai.openai_error", nil, openaiErr.Error(), 500)
+		return nil, aiCompletionError("SummarizeThread", "app.ai.openai_error", openaiErr)
 	}
+	summaryText := completion.Text
 
 	// Get channel info
 	channel, err := a.GetChannel(c, req.ChannelId)
@@ -146,6 +153,7 @@ func (a *App) SummarizeThread(c request.CTX, req *SummarizationRequest) (*Summar
 	return &SummarizationResponse{
 		Summary:      savedSummary,
 		FromCache:    false,
+		TokensUsed:   completion.PromptTokens + completion.CompletionTokens,
 		ProcessingMs: time.Since(startTime).Milliseconds(),
 	}, nil
 }
@@ -235,11 +243,18 @@ func (a *App) SummarizeChannel(c request.CTX, req *SummarizationRequest) (*Summa
 	}
 
 	// Generate summary via OpenAI
-	summaryText, openaiErr := aiService.client.SimpleCompletion(c.Context(), a.GetAIModel(), systemPrompt, userPrompt)
+	completion, openaiErr := aiService.complete(c, &model.AIPrompt{
+		Feature:      model.AIFeatureSummarization,
+		UserId:       req.UserId,
+		PluginId:     req.PluginId,
+		SystemPrompt: systemPrompt,
+		UserPrompt:   userPrompt,
+	})
 	if openaiErr != nil {
 		a.Log().Error("Failed to generate channel summary", mlog.Err(openaiErr))
-		return nil, model.NewAppError("SummarizeChannel", "app.ai.openai_error", nil, openaiErr.Error(), 500)
+		return nil, aiCompletionError("SummarizeChannel", "app.ai.openai_error", openaiErr)
 	}
+	summaryText := completion.Text
 
 	// Get channel info
 	channel, err := a.GetChannel(c, req.ChannelId)
@@ -276,6 +291,7 @@ func (a *App) SummarizeChannel(c request.CTX, req *SummarizationRequest) (*Summa
 	return &SummarizationResponse{
 		Summary:      savedSummary,
 		FromCache:    false,
+		TokensUsed:   completion.PromptTokens + completion.CompletionTokens,
 		ProcessingMs: time.Since(startTime).Milliseconds(),
 	}, nil
 }
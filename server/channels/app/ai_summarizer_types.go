@@ -17,6 +17,7 @@ type SummarizationRequest struct {
 	MaxMessages    int
 	UserId         string // User requesting the summary
 	UseCache       bool   // Whether to use cached summaries
+	PluginId       string // Plugin requesting the summary, if any
 }
 
 // SummarizationResponse represents the result of a summarization
@@ -0,0 +1,1514 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+// Code generated by "make app-layers"
+// DO NOT EDIT
+
+package app
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"reflect"
+	"time"
+
+	agentclient "github.com/mattermost/mattermost-plugin-ai/public/bridgeclient"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/plugin"
+	"github.com/mattermost/mattermost/server/public/shared/httpservice"
+	"github.com/mattermost/mattermost/server/public/shared/i18n"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/public/shared/timezones"
+	"github.com/mattermost/mattermost/server/v8/channels/app/platform"
+	"github.com/mattermost/mattermost/server/v8/channels/app/properties"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+	"github.com/mattermost/mattermost/server/v8/einterfaces"
+	"github.com/mattermost/mattermost/server/v8/platform/services/imageproxy"
+	"github.com/mattermost/mattermost/server/v8/platform/services/remotecluster"
+	"github.com/mattermost/mattermost/server/v8/platform/services/searchengine"
+	"github.com/mattermost/mattermost/server/v8/platform/shared/filestore"
+)
+
+// AppIface holds the exported methods of App, so that layers such as the tracing layer can
+// wrap it. The methods using an unexported type of the package are left out.
+type AppIface interface {
+	AccountMigration() einterfaces.AccountMigrationInterface
+	ActivateMfa(userID string, token string) *model.AppError
+	ActiveSearchBackend() string
+	AddAuditLogCertificate(rctx request.CTX, fileData *multipart.FileHeader) *model.AppError
+	// AddChannelMember adds a user to a channel. It is a wrapper over AddUserToChannel.
+	AddChannelMember(rctx request.CTX, userID string, channel *model.Channel, opts ChannelMemberOpts) (*model.ChannelMember, *model.AppError)
+	AddChannelsToRetentionPolicy(policyID string, channelIDs []string) *model.AppError
+	AddConfigListener(listener func(*model.Config, *model.Config)) string
+	// AddCursorIdsForPostList adds NextPostId and PrevPostId as cursor to the PostList.
+	// The conditional blocks ensure that it sets those cursor IDs immediately as afterPost, beforePost or empty,
+	// and only query to database whenever necessary.
+	AddCursorIdsForPostList(originalList *model.PostList, afterPost string, beforePost string, since int64, page int, perPage int, collapsedThreads bool)
+	AddDirectChannels(rctx request.CTX, teamID string, user *model.User) *model.AppError
+	AddLdapPrivateCertificate(fileData *multipart.FileHeader) *model.AppError
+	AddLdapPublicCertificate(fileData *multipart.FileHeader) *model.AppError
+	AddLicenseListener(listener func(oldLicense, newLicense *model.License)) string
+	// AddPublicKey will add plugin public key to the config. Overwrites the previous file
+	AddPublicKey(name string, key io.Reader) *model.AppError
+	AddRemoteCluster(rc *model.RemoteCluster) (*model.RemoteCluster, *model.AppError)
+	AddSamlIdpCertificate(fileData *multipart.FileHeader) *model.AppError
+	AddSamlPrivateCertificate(fileData *multipart.FileHeader) *model.AppError
+	AddSamlPublicCertificate(fileData *multipart.FileHeader) *model.AppError
+	AddSessionToCache(session *model.Session)
+	AddTeamMember(rctx request.CTX, teamID string, userID string) (*model.TeamMember, *model.AppError)
+	AddTeamMemberByInviteId(rctx request.CTX, inviteId string, userID string) (*model.TeamMember, *model.AppError)
+	AddTeamMemberByToken(rctx request.CTX, userID string, tokenID string) (*model.TeamMember, *model.AppError)
+	AddTeamMembers(rctx request.CTX, teamID string, userIDs []string, userRequestorId string, graceful bool) ([]*model.TeamMemberWithError, *model.AppError)
+	AddTeamsToRetentionPolicy(policyID string, teamIDs []string) *model.AppError
+	// AddUserToChannel adds a user to a given channel.
+	AddUserToChannel(rctx request.CTX, user *model.User, channel *model.Channel, skipTeamMemberIntegrityCheck bool) (*model.ChannelMember, *model.AppError)
+	AddUserToTeam(rctx request.CTX, teamID string, userID string, userRequestorId string) (*model.Team, *model.TeamMember, *model.AppError)
+	AddUserToTeamByInviteId(rctx request.CTX, inviteId string, userID string) (*model.Team, *model.TeamMember, *model.AppError)
+	AddUserToTeamByInviteIfNeeded(rctx request.CTX, user *model.User, inviteToken string, inviteId string) *model.AppError
+	AddUserToTeamByTeamId(rctx request.CTX, teamID string, user *model.User) *model.AppError
+	AddUserToTeamByToken(rctx request.CTX, userID string, tokenID string) (*model.Team, *model.TeamMember, *model.AppError)
+	AddUserToTeamWithToken(rctx request.CTX, userID string, token *model.Token) (*model.Team, *model.TeamMember, *model.AppError)
+	AdjustImage(rctx request.CTX, file io.ReadSeeker) (*bytes.Buffer, *model.AppError)
+	AdjustInProductLimits(limits *model.ProductLimits, subscription *model.Subscription) *model.AppError
+	AdjustTeamsFromProductLimits(teamLimits *model.TeamsLimits) *model.AppError
+	AllowOAuthAppAccessToUser(rctx request.CTX, userID string, authRequest *model.AuthorizeRequest) (string, *model.AppError)
+	AppendFile(fr io.Reader, path string) (int64, *model.AppError)
+	AssignAccessControlPolicyToChannels(rctx request.CTX, parentID string, channelIDs []string) ([]*model.AccessControlPolicy, *model.AppError)
+	AssignFlaggedPostReviewer(rctx request.CTX, flaggedPostId string, flaggedPostTeamId string, reviewerId string, assigneeId string) *model.AppError
+	AsymmetricSigningKey() *ecdsa.PrivateKey
+	AttachCloudSessionCookie(rctx request.CTX, w http.ResponseWriter, r *http.Request)
+	AttachDeviceId(sessionID string, deviceID string, expiresAt int64) *model.AppError
+	AttachSessionCookies(rctx request.CTX, w http.ResponseWriter, r *http.Request)
+	// AttachWebPushSubscription registers the push subscription of the browser the session is used
+	// from, making the session receive push notifications like the sessions of the mobile apps.
+	AttachWebPushSubscription(rctx request.CTX, session *model.Session, subscription *model.WebPushSubscription) *model.AppError
+	// AuthenticateUserForGuestMagicLink validates an guest magic link token and creates a guest user.
+	// This function handles the passwordless "guest magic link" flow where clicking an email link logs the user in.
+	// Follows the same pattern as SAML/OAuth SSO by creating the user then calling AddUserToTeamByToken.
+	AuthenticateUserForGuestMagicLink(rctx request.CTX, tokenString string) (*model.User, *model.AppError)
+	AuthenticateUserForLogin(rctx request.CTX, id string, loginId string, password string, mfaToken string, cwsToken string, ldapOnly bool) (*model.User, *model.AppError)
+	AuthorizeOAuthUser(rctx request.CTX, w http.ResponseWriter, r *http.Request, service string, code string, state string, redirectURI string) (io.ReadCloser, map[string]string, *model.User, *model.AppError)
+	// AutoDetectAndCreateActionItems automatically detects and creates action items from a post
+	AutoDetectAndCreateActionItems(c request.CTX, post *model.Post) error
+	AutocompleteChannels(rctx request.CTX, userID string, term string) (model.ChannelListWithTeamData, *model.AppError)
+	AutocompleteChannelsForSearch(rctx request.CTX, teamID string, userID string, term string) (model.ChannelList, *model.AppError)
+	AutocompleteChannelsForTeam(rctx request.CTX, teamID string, userID string, term string) (model.ChannelList, *model.AppError)
+	AutocompleteUsersInChannel(rctx request.CTX, teamID string, channelID string, term string, options *model.UserSearchOptions) (*model.UserAutocompleteInChannel, *model.AppError)
+	AutocompleteUsersInTeam(rctx request.CTX, teamID string, term string, options *model.UserSearchOptions) (*model.UserAutocompleteInTeam, *model.AppError)
+	BuildPostReactions(rctx request.CTX, postID string) (*[]ReactionImportData, *model.AppError)
+	BuildPushNotificationMessage(rctx request.CTX, contentsConfig string, post *model.Post, user *model.User, channel *model.Channel, channelName string, senderName string, explicitMention bool, channelWideMention bool, replyToThreadType string) (*model.PushNotification, *model.AppError)
+	BuildSamlMetadataObject(idpMetadata []byte) (*model.SamlMetadataResponse, *model.AppError)
+	BulkExport(rctx request.CTX, writer io.Writer, outPath string, job *model.Job, opts model.BulkExportOpts) *model.AppError
+	BulkImport(rctx request.CTX, jsonlReader io.Reader, attachmentsReader *zip.Reader, dryRun bool, workers int) (int, *model.AppError)
+	BulkImportWithPath(rctx request.CTX, jsonlReader io.Reader, attachmentsReader *zip.Reader, dryRun bool, extractContent bool, workers int, importPath string) (int, *model.AppError)
+	CanNotifyAdmin(rctx request.CTX, trial bool) bool
+	// CanUserAccessActionItem checks if a user can access an action item
+	CanUserAccessActionItem(c request.CTX, userID string, item *model.AIActionItem) bool
+	// CanUserModifyActionItem checks if a user can modify an action item
+	CanUserModifyActionItem(c request.CTX, userID string, item *model.AIActionItem) bool
+	CancelJob(rctx request.CTX, jobId string) *model.AppError
+	ChannelAccessControlled(rctx request.CTX, channelID string) (bool, *model.AppError)
+	// ChannelMembersMinusGroupMembers returns the set of users in the given channel minus the set of users in the given
+	// groups.
+	//
+	// The result can be used, for example, to determine the set of users who would be removed from a channel if the
+	// channel were group-constrained with the given groups.
+	ChannelMembersMinusGroupMembers(channelID string, groupIDs []string, page int, perPage int) ([]*model.UserWithGroups, int64, *model.AppError)
+	// ChannelMembersToAdd returns a slice of UserChannelIDPair that need newly created memberships
+	// based on the groups configurations. The returned list can be optionally scoped to a single given channel.
+	//
+	// Typically since will be the last successful group sync time.
+	// If reAddRemovedMembers is true, then channel members who left or were removed from the channel will
+	// be included; otherwise, they will be excluded.
+	ChannelMembersToAdd(since int64, channelID *string, reAddRemovedMembers bool) ([]*model.UserChannelIDPair, *model.AppError)
+	ChannelMembersToRemove(teamID *string) ([]*model.ChannelMember, *model.AppError)
+	Channels() *Channels
+	CheckCanInviteToSharedChannel(channelId string) error
+	CheckExpression(rctx request.CTX, expression string) ([]model.CELExpressionError, *model.AppError)
+	CheckIfChannelIsRestrictedDM(rctx request.CTX, channel *model.Channel) (bool, *model.AppError)
+	CheckIntegrity() <-chan model.IntegrityCheckResult
+	CheckMandatoryS3Fields(settings *model.FileSettings) *model.AppError
+	CheckPasswordAndAllCriteria(rctx request.CTX, userID string, password string, mfaToken string) *model.AppError
+	CheckPostReminders(rctx request.CTX)
+	// CheckProviderAttributes returns the empty string if the patch can be applied without
+	// overriding attributes set by the user's login provider; otherwise, the name of the offending
+	// field is returned.
+	CheckProviderAttributes(rctx request.CTX, user *model.User, patch *model.UserPatch) string
+	CheckRolesExist(roleNames []string) *model.AppError
+	CheckUserAllAuthenticationCriteria(rctx request.CTX, user *model.User, mfaToken string) *model.AppError
+	CheckUserMfa(rctx request.CTX, user *model.User, token string) *model.AppError
+	CheckUserPostflightAuthenticationCriteria(rctx request.CTX, user *model.User) *model.AppError
+	CheckUserPreflightAuthenticationCriteria(rctx request.CTX, user *model.User, mfaToken string) *model.AppError
+	CleanUpAfterPostDeletion(rctx request.CTX, post *model.Post, deleteByID string) *model.AppError
+	CleanupReportChunks(format string, prefix string, numberOfChunks int) *model.AppError
+	ClearChannelMembersCache(rctx request.CTX, channelID string) error
+	ClearSessionCacheForAllUsers()
+	ClearSessionCacheForAllUsersSkipClusterSend()
+	ClearSessionCacheForUser(userID string)
+	ClearSessionCacheForUserSkipClusterSend(userID string)
+	ClearTeamMembersCache(teamID string) error
+	ClientConfig() map[string]string
+	ClientConfigHash() string
+	Cloud() einterfaces.CloudInterface
+	Cluster() einterfaces.ClusterInterface
+	// CommandsForTeam returns all the plugin commands for the given team.
+	CommandsForTeam(teamID string) []*model.Command
+	CompareAndDeletePluginKey(rctx request.CTX, pluginID string, key string, oldValue []byte) (bool, *model.AppError)
+	CompareAndSetPluginKey(pluginID string, key string, oldValue []byte, newValue []byte) (bool, *model.AppError)
+	CompileReportChunks(format string, prefix string, numberOfChunks int, headers []string) *model.AppError
+	// CompleteActionItem marks an action item as completed
+	CompleteActionItem(c request.CTX, actionItemID string, userID string) (*model.AIActionItem, error)
+	CompleteOAuth(rctx request.CTX, service string, body io.ReadCloser, props map[string]string, tokenUser *model.User) (*model.User, *model.AppError)
+	CompleteOnboarding(rctx request.CTX, request *model.CompleteOnboardingRequest) *model.AppError
+	CompleteSwitchWithOAuth(rctx request.CTX, service string, userData io.Reader, email string, tokenUser *model.User) (*model.User, *model.AppError)
+	Compliance() einterfaces.ComplianceInterface
+	// ComputeLastAccessibleFileTime updates cache with CreateAt time of the last accessible file as per the cloud plan's limit.
+	// Use GetLastAccessibleFileTime() to access the result.
+	ComputeLastAccessibleFileTime() error
+	// ComputeLastAccessiblePostTime updates cache with CreateAt time of the last accessible post as per the license limit.
+	// Use GetLastAccessiblePostTime() to access the result.
+	ComputeLastAccessiblePostTime() error
+	Config() *model.Config
+	ConsumeTokenOnce(tokenType string, tokenStr string) (*model.Token, *model.AppError)
+	ContentFlaggingEnabledForTeam(teamId string) (bool, *model.AppError)
+	ContentFlaggingGroupId() (string, *model.AppError)
+	// ConvertBotToUser converts a bot to user.
+	ConvertBotToUser(rctx request.CTX, bot *model.Bot, userPatch *model.UserPatch, sysadmin bool) (*model.User, *model.AppError)
+	ConvertGroupMessageToChannel(rctx request.CTX, convertedByUserId string, gmConversionRequest *model.GroupMessageConversionRequestBody) (*model.Channel, *model.AppError)
+	// ConvertUserToBot converts a user to bot.
+	ConvertUserToBot(rctx request.CTX, user *model.User) (*model.Bot, *model.AppError)
+	CopyFileInfos(rctx request.CTX, userID string, fileIDs []string) ([]string, *model.AppError)
+	CopyWranglerPostlist(rctx request.CTX, wpl *model.WranglerPostList, targetChannel *model.Channel) (*model.Post, *model.AppError)
+	CountNotification(notificationType model.NotificationType, platform string)
+	CountNotificationAck(notificationType model.NotificationType, platform string)
+	CountNotificationReason(notificationStatus model.NotificationStatus, notificationType model.NotificationType, notificationReason model.NotificationReason, platform string)
+	// ToDo: we should explore moving this to the database cache layer
+	// instead of maintaining the ID cached at the application level
+	CpaGroupID() (string, error)
+	CreateAccessControlSyncJob(rctx request.CTX, jobData map[string]string) (*model.Job, *model.AppError)
+	// CreateActionItem creates a new action item
+	CreateActionItem(c request.CTX, item *model.AIActionItem) (*model.AIActionItem, error)
+	// CreateBot creates the given bot and corresponding user.
+	CreateBot(rctx request.CTX, bot *model.Bot) (*model.Bot, *model.AppError)
+	CreateCPAField(field *model.CPAField) (*model.CPAField, *model.AppError)
+	CreateChannel(rctx request.CTX, channel *model.Channel, addMember bool) (*model.Channel, *model.AppError)
+	CreateChannelBookmark(rctx request.CTX, newBookmark *model.ChannelBookmark, connectionId string) (*model.ChannelBookmarkWithFileInfo, *model.AppError)
+	// CreateChannelScheme creates a new Scheme of scope channel and assigns it to the channel.
+	CreateChannelScheme(rctx request.CTX, channel *model.Channel) (*model.Scheme, *model.AppError)
+	CreateChannelWithUser(rctx request.CTX, channel *model.Channel, userID string) (*model.Channel, *model.AppError)
+	CreateCommand(cmd *model.Command) (*model.Command, *model.AppError)
+	CreateCommandPost(rctx request.CTX, post *model.Post, teamID string, response *model.CommandResponse, skipSlackParsing bool) (*model.Post, *model.AppError)
+	CreateCommandWebhook(commandID string, args *model.CommandArgs) (*model.CommandWebhook, *model.AppError)
+	// CreateDefaultMemberships adds users to teams and channels based on their group memberships and how those groups
+	// are configured to sync with teams and channels for group members on or after the given timestamp.
+	// If params.AddRemovedMembers is true, then members who left or were removed from a team/channel will
+	// be re-added; otherwise, they will not be re-added.
+	CreateDefaultMemberships(rctx request.CTX, params model.CreateDefaultMembershipParams) error
+	CreateEmoji(rctx request.CTX, sessionUserId string, emoji *model.Emoji, multiPartImageData *multipart.Form) (*model.Emoji, *model.AppError)
+	CreateEventSubscription(rctx request.CTX, subscription *model.EventSubscription) (*model.EventSubscription, *model.AppError)
+	CreateGroup(group *model.Group) (*model.Group, *model.AppError)
+	CreateGroupChannel(rctx request.CTX, userIDs []string, creatorId string, channelOptions ...model.ChannelOption) (*model.Channel, *model.AppError)
+	CreateGroupWithUserIds(group *model.GroupWithUserIds) (*model.Group, *model.AppError)
+	// CreateGuest creates a guest and sets several fields of the returned User struct to
+	// their zero values.
+	CreateGuest(rctx request.CTX, user *model.User) (*model.User, *model.AppError)
+	CreateIncomingWebhookForChannel(creatorId string, channel *model.Channel, hook *model.IncomingWebhook) (*model.IncomingWebhook, *model.AppError)
+	CreateJob(rctx request.CTX, job *model.Job) (*model.Job, *model.AppError)
+	CreateOAuthApp(app *model.OAuthApp) (*model.OAuthApp, *model.AppError)
+	// CreateOAuthAppInternal creates an OAuth app with optional secret generation.
+	// If generateSecret is true and ClientSecret is empty, a secret will be auto-generated.
+	// If generateSecret is false, the ClientSecret is left as-is (empty for public clients).
+	CreateOAuthAppInternal(app *model.OAuthApp, generateSecret bool) (*model.OAuthApp, *model.AppError)
+	CreateOAuthStateToken(extra string) (*model.Token, *model.AppError)
+	CreateOAuthUser(rctx request.CTX, service string, userData io.Reader, inviteToken string, inviteId string, tokenUser *model.User) (*model.User, *model.AppError)
+	CreateOrUpdateAccessControlPolicy(rctx request.CTX, policy *model.AccessControlPolicy) (*model.AccessControlPolicy, *model.AppError)
+	CreateOutgoingWebhook(hook *model.OutgoingWebhook) (*model.OutgoingWebhook, *model.AppError)
+	CreatePasswordRecoveryToken(rctx request.CTX, userID string, email string) (*model.Token, *model.AppError)
+	CreatePost(rctx request.CTX, post *model.Post, channel *model.Channel, flags model.CreatePostFlags) (*model.Post, *model.AppError)
+	CreatePostAsUser(rctx request.CTX, post *model.Post, currentSessionId string, setOnline bool) (*model.Post, *model.AppError)
+	CreatePostMissingChannel(rctx request.CTX, post *model.Post, triggerWebhooks bool, setOnline bool) (*model.Post, *model.AppError)
+	CreateRemoteClusterInvite(remoteId string, siteURL string, token string, password string) (string, *model.AppError)
+	CreateRetentionPolicy(policy *model.RetentionPolicyWithTeamAndChannelIDs) (*model.RetentionPolicyWithTeamAndChannelCounts, *model.AppError)
+	CreateRole(role *model.Role) (*model.Role, *model.AppError)
+	CreateSamlRelayToken(tokenType string, extra string) (*model.Token, *model.AppError)
+	CreateScheme(scheme *model.Scheme) (*model.Scheme, *model.AppError)
+	CreateSession(rctx request.CTX, session *model.Session) (*model.Session, *model.AppError)
+	CreateSidebarCategory(rctx request.CTX, userID string, teamID string, newCategory *model.SidebarCategoryWithChannels) (*model.SidebarCategoryWithChannels, *model.AppError)
+	CreateTeam(rctx request.CTX, team *model.Team) (*model.Team, *model.AppError)
+	CreateTeamWithUser(rctx request.CTX, team *model.Team, userID string) (*model.Team, *model.AppError)
+	CreateTermsOfService(text string, userID string) (*model.TermsOfService, *model.AppError)
+	CreateUploadSession(rctx request.CTX, us *model.UploadSession) (*model.UploadSession, *model.AppError)
+	// CreateUser creates a user and sets several fields of the returned User struct to
+	// their zero values.
+	CreateUser(rctx request.CTX, user *model.User) (*model.User, *model.AppError)
+	CreateUserAccessToken(rctx request.CTX, token *model.UserAccessToken) (*model.UserAccessToken, *model.AppError)
+	CreateUserAsAdmin(rctx request.CTX, user *model.User, redirect string) (*model.User, *model.AppError)
+	CreateUserFromSignup(rctx request.CTX, user *model.User, redirect string) (*model.User, *model.AppError)
+	CreateUserWithInviteId(rctx request.CTX, user *model.User, inviteId string, redirect string) (*model.User, *model.AppError)
+	CreateUserWithToken(rctx request.CTX, user *model.User, token *model.Token) (*model.User, *model.AppError)
+	CreateWebhookPost(rctx request.CTX, userID string, channel *model.Channel, text string, overrideUsername string, overrideIconURL string, overrideIconEmoji string, props model.StringInterface, postType string, postRootId string, priority *model.PostPriority) (*model.Post, *model.AppError)
+	// CutOverFileStorageMigration switches the file storage to the migration
+	// target, keeping the previous storage configured as the target with dual
+	// reads enabled, so that files not yet copied can still be served from it.
+	// The migration job needs to have completed successfully beforehand, and the
+	// server needs to be restarted for the change to take effect.
+	CutOverFileStorageMigration(rctx request.CTX) *model.AppError
+	DBHealthCheckDelete() error
+	DBHealthCheckWrite() error
+	DataRetention() einterfaces.DataRetentionInterface
+	DeactivateGuests(rctx request.CTX) *model.AppError
+	DeactivateMagicLinkGuests(rctx request.CTX) *model.AppError
+	DeactivateMfa(userID string) *model.AppError
+	DeauthorizeOAuthAppForUser(rctx request.CTX, userID string, appID string) *model.AppError
+	DecryptRemoteClusterInvite(inviteCode string, password string) (*model.RemoteClusterInvite, *model.AppError)
+	// DefaultChannelNames returns the list of system-wide default channel names.
+	//
+	// By default the list will be (not necessarily in this order):
+	//
+	//	['town-square', 'off-topic']
+	//
+	// However, if TeamSettings.ExperimentalDefaultChannels contains a list of channels then that list will replace
+	// 'off-topic' and be included in the return results in addition to 'town-square'. For example:
+	//
+	//	['town-square', 'game-of-thrones', 'wow']
+	DefaultChannelNames(rctx request.CTX) []string
+	DeleteAccessControlPolicy(rctx request.CTX, id string) *model.AppError
+	DeleteAcknowledgementForPost(rctx request.CTX, postID string, userID string) *model.AppError
+	DeleteAcknowledgementForPostWithModel(rctx request.CTX, acknowledgement *model.PostAcknowledgement) *model.AppError
+	// DeleteActionItem deletes an action item
+	DeleteActionItem(c request.CTX, actionItemID string, userID string) error
+	DeleteAllExpiredPluginKeys() *model.AppError
+	DeleteAllKeysForPlugin(pluginID string) *model.AppError
+	DeleteBrandImage(rctx request.CTX) *model.AppError
+	DeleteCPAField(id string) *model.AppError
+	DeleteCPAValues(userID string) *model.AppError
+	DeleteChannel(rctx request.CTX, channel *model.Channel, userID string) *model.AppError
+	DeleteChannelBookmark(bookmarkId string, connectionId string) (*model.ChannelBookmarkWithFileInfo, *model.AppError)
+	// DeleteChannelScheme deletes a channels scheme and sets its SchemeId to nil.
+	DeleteChannelScheme(rctx request.CTX, channel *model.Channel) (*model.Channel, *model.AppError)
+	DeleteCommand(commandID string) *model.AppError
+	DeleteDraft(rctx request.CTX, draft *model.Draft, connectionID string) *model.AppError
+	DeleteEmoji(rctx request.CTX, emoji *model.Emoji) *model.AppError
+	DeleteEphemeralPost(rctx request.CTX, userID string, postID string)
+	DeleteEventSubscription(id string) *model.AppError
+	DeleteExport(name string) *model.AppError
+	DeleteGroup(groupID string) (*model.Group, *model.AppError)
+	// DeleteGroupConstrainedChannelMemberships deletes channel memberships of users who aren't members of the allowed
+	// groups of the given group-constrained channel. If a channelID is given then the procedure is scoped to the given team,
+	// if channelID is nil then the procedure affects all teams.
+	DeleteGroupConstrainedChannelMemberships(rctx request.CTX, channelID *string) error
+	// DeleteGroupConstrainedMemberships deletes team and channel memberships of users who aren't members of the allowed
+	// groups of all group-constrained teams and channels.
+	DeleteGroupConstrainedMemberships(rctx request.CTX) error
+	// DeleteGroupConstrainedTeamMemberships deletes team memberships of users who aren't members of the allowed
+	// groups of the given group-constrained team. If a teamID is given then the procedure is scoped to the given team,
+	// if teamID is nil then the procedure affects all teams.
+	DeleteGroupConstrainedTeamMemberships(rctx request.CTX, teamID *string) error
+	DeleteGroupMember(groupID string, userID string) (*model.GroupMember, *model.AppError)
+	DeleteGroupMembers(groupID string, userIDs []string) ([]*model.GroupMember, *model.AppError)
+	DeleteGroupSyncable(groupID string, syncableID string, syncableType model.GroupSyncableType) (*model.GroupSyncable, *model.AppError)
+	DeleteImport(name string) *model.AppError
+	DeleteIncomingWebhook(hookID string) *model.AppError
+	DeleteOAuthApp(rctx request.CTX, appID string) *model.AppError
+	DeleteOutgoingWebhook(hookID string) *model.AppError
+	// DeletePersistentNotification stops the persistent notifications.
+	DeletePersistentNotification(rctx request.CTX, post *model.Post) *model.AppError
+	DeletePluginKey(pluginID string, key string) *model.AppError
+	DeletePost(rctx request.CTX, postID string, deleteByID string) (*model.Post, *model.AppError)
+	DeletePreferences(rctx request.CTX, userID string, preferences model.Preferences) *model.AppError
+	DeletePriorityForPost(postId string) *model.AppError
+	// DeletePublicKey will delete plugin public key from the config.
+	DeletePublicKey(name string) *model.AppError
+	DeleteReactionForPost(rctx request.CTX, reaction *model.Reaction) *model.AppError
+	DeleteRemoteCluster(remoteClusterId string) (bool, *model.AppError)
+	DeleteRetentionPolicy(policyID string) *model.AppError
+	DeleteRole(id string) (*model.Role, *model.AppError)
+	DeleteScheduledPost(rctx request.CTX, userId string, scheduledPostId string, connectionId string) (*model.ScheduledPost, *model.AppError)
+	DeleteScheme(schemeId string) (*model.Scheme, *model.AppError)
+	DeleteSharedChannelRemote(id string) (bool, error)
+	DeleteSidebarCategory(rctx request.CTX, userID string, teamID string, categoryId string) *model.AppError
+	DeleteToken(token *model.Token) *model.AppError
+	// DemoteUserToGuest Convert user's roles and all his membership's roles from
+	// regular user roles to guest roles.
+	DemoteUserToGuest(rctx request.CTX, user *model.User) *model.AppError
+	// DetachPlugin allows the server to bind to an existing plugin instance launched elsewhere.
+	DetachPlugin(pluginId string) *model.AppError
+	// DetachWebPushSubscription stops sending push notifications to the browser the session is used from.
+	DetachWebPushSubscription(session *model.Session) *model.AppError
+	// DetectActionItems analyzes a post for potential action items using AI
+	DetectActionItems(c request.CTX, post *model.Post) (*ActionItemDetectionResult, error)
+	// DiffConfigVersions returns the redacted differences between two saved versions of the
+	// configuration. An empty actualID compares against the active version.
+	DiffConfigVersions(baseID string, actualID string) ([]*model.ConfigVersionDiff, *model.AppError)
+	DisableAutoResponder(rctx request.CTX, userID string, asAdmin bool) *model.AppError
+	// DisablePlugin will set the config for an installed plugin to disabled, triggering deactivation if active.
+	// Notifies cluster peers through config change.
+	DisablePlugin(id string) *model.AppError
+	DisableUserAccessToken(rctx request.CTX, token *model.UserAccessToken) *model.AppError
+	// DoActionRequest performs an HTTP POST request to an integration's action endpoint.
+	// Caller must consume and close returned http.Response as necessary.
+	// For internal requests, requests are routed directly to a plugin ServerHTTP hook
+	DoActionRequest(rctx request.CTX, rawURL string, body []byte) (*http.Response, *model.AppError)
+	// This function migrates the default built in roles from code/config to the database.
+	DoAdvancedPermissionsMigration() error
+	DoAppMigrations()
+	DoCheckForAdminNotifications(trial bool) *model.AppError
+	DoCommandRequest(rctx request.CTX, cmd *model.Command, p url.Values) (*model.Command, *model.CommandResponse, *model.AppError)
+	DoEmojisPermissionsMigration() error
+	DoGuestRolesCreationMigration() error
+	DoLocalRequest(rctx request.CTX, rawURL string, body []byte) (*http.Response, *model.AppError)
+	DoLogin(rctx request.CTX, w http.ResponseWriter, r *http.Request, user *model.User, deviceID string, isMobile bool, isOAuthUser bool, isSaml bool) (*model.Session, *model.AppError)
+	// DoPermissionsMigrations execute all the permissions migrations need by the current version.
+	DoPermissionsMigrations() error
+	DoPostActionWithCookie(rctx request.CTX, postID string, actionId string, userID string, selectedOption string, cookie *model.PostActionCookie) (string, *model.AppError)
+	DoSystemConsoleRolesCreationMigration() error
+	DoUploadFile(rctx request.CTX, now time.Time, rawTeamId string, rawChannelId string, rawUserId string, rawFilename string, data []byte, extractContent bool) (*model.FileInfo, *model.AppError)
+	DoUploadFileExpectModification(rctx request.CTX, now time.Time, rawTeamId string, rawChannelId string, rawUserId string, rawFilename string, data []byte, extractContent bool) (*model.FileInfo, []byte, *model.AppError)
+	// This to be used for places we check the users password when they are already logged in
+	DoubleCheckPassword(rctx request.CTX, user *model.User, password string) *model.AppError
+	DownloadFromURL(downloadURL string) ([]byte, error)
+	// EnablePlugin will set the config for an installed plugin to enabled, triggering asynchronous
+	// activation if inactive anywhere in the cluster.
+	// Notifies cluster peers through config change.
+	EnablePlugin(id string) *model.AppError
+	EnableUserAccessToken(rctx request.CTX, token *model.UserAccessToken) *model.AppError
+	// EnsureBot provides similar functionality with the plugin-api BotService. It doesn't accept
+	// any ensureBotOptions hence it is not required for now.
+	EnsureBot(rctx request.CTX, pluginID string, bot *model.Bot) (string, error)
+	EnvironmentConfig(filter func(reflect.StructField) bool) map[string]any
+	ExecuteCommand(rctx request.CTX, args *model.CommandArgs) (*model.CommandResponse, *model.AppError)
+	ExportFileBackend() filestore.FileBackend
+	ExportFileExists(path string) (bool, *model.AppError)
+	ExportFileModTime(path string) (time.Time, *model.AppError)
+	// ExportFileReader returns a ReadCloseSeeker for path from the ExportFileBackend.
+	//
+	// The caller is responsible for closing the returned ReadCloseSeeker.
+	ExportFileReader(path string) (filestore.ReadCloseSeeker, *model.AppError)
+	ExportPermissions(rctx request.CTX, w io.Writer) error
+	// ExportZipReader returns a ReadCloser for path from the ExportFileBackend.
+	// If deflate is true, the zip will use compression.
+	//
+	// The caller is responsible for closing the returned ReadCloser.
+	ExportZipReader(path string, deflate bool) (io.ReadCloser, *model.AppError)
+	ExpressionToVisualAST(rctx request.CTX, expression string) (*model.VisualExpression, *model.AppError)
+	// ExtendSessionExpiryIfNeeded extends Session.ExpiresAt based on session lengths in config.
+	// A new ExpiresAt is only written if enough time has elapsed since last update.
+	// Returns true only if the session was extended.
+	ExtendSessionExpiryIfNeeded(rctx request.CTX, session *model.Session) bool
+	ExtractContentFromFileInfo(rctx request.CTX, fileInfo *model.FileInfo) error
+	FetchSamlMetadataFromIdp(url string) ([]byte, *model.AppError)
+	FileBackend() filestore.FileBackend
+	FileExists(path string) (bool, *model.AppError)
+	FileModTime(path string) (time.Time, *model.AppError)
+	// FileReader returns a ReadCloseSeeker for path from the FileBackend.
+	//
+	// The caller is responsible for closing the returned ReadCloseSeeker.
+	FileReader(path string) (filestore.ReadCloseSeeker, *model.AppError)
+	FileSize(path string) (int64, *model.AppError)
+	// FileStorageMigrationBackends returns the backends that a storage migration
+	// copies files between. Files are copied from the storage in use to the
+	// migration target until the migration is cut over. Afterwards, during the
+	// dual-read period, they're copied back from the previous storage, which is
+	// then the migration target, to pick up what was written there in the
+	// meantime.
+	FileStorageMigrationBackends() (filestore.FileBackend, filestore.FileBackend, *model.AppError)
+	FillInChannelProps(rctx request.CTX, channel *model.Channel) *model.AppError
+	FillInChannelsProps(rctx request.CTX, channelList model.ChannelList) *model.AppError
+	// FillInPostProps should be invoked before saving posts to fill in properties such as
+	// channel_mentions.
+	//
+	// If channel is nil, FillInPostProps will look up the channel corresponding to the post.
+	FillInPostProps(rctx request.CTX, post *model.Post, channel *model.Channel) *model.AppError
+	// FilterNonGroupChannelMembers returns the subset of the given user IDs of the users who are not members of groups
+	// associated to the channel excluding bots
+	FilterNonGroupChannelMembers(rctx request.CTX, userIDs []string, channel *model.Channel) ([]string, error)
+	// FilterNonGroupTeamMembers returns the subset of the given user IDs of the users who are not members of groups
+	// associated to the team excluding bots.
+	FilterNonGroupTeamMembers(rctx request.CTX, userIDs []string, team *model.Team) ([]string, error)
+	FilterUsersByVisible(rctx request.CTX, viewer *model.User, otherUsers []*model.User) ([]*model.User, *model.AppError)
+	FindTeamByName(name string) bool
+	FinishSendAdminNotifyPost(rctx request.CTX, trial bool, now int64, pluginBasedData map[string][]*model.NotifyAdminData)
+	FlagPost(rctx request.CTX, post *model.Post, teamId string, reportingUserId string, flagData model.FlagContentRequest) *model.AppError
+	// FormatMessage formats a message using the specified profile
+	FormatMessage(c request.CTX, req *FormattingRequest) (*FormattingResponse, *model.AppError)
+	GenerateAndSaveDesktopToken(createAt int64, user *model.User) (*string, *model.AppError)
+	GenerateMfaSecret(userID string) (*model.MfaSecret, *model.AppError)
+	GeneratePresignURLForExport(name string) (*model.PresignURLResponse, *model.AppError)
+	GeneratePublicLink(siteURL string, info *model.FileInfo) string
+	GenerateSupportPacket(rctx request.CTX, options *model.SupportPacketOptions) []model.FileData
+	// GetAIMaxMessageLimit returns the maximum number of messages to process for AI operations
+	GetAIMaxMessageLimit() int
+	// GetAIModel returns the configured AI model or the default
+	GetAIModel() string
+	// GetAIRateLimit returns the API rate limit per minute
+	GetAIRateLimit() int
+	// GetAIService returns the AI service instance
+	GetAIService() *AIService
+	GetAccessControlFieldsAutocomplete(rctx request.CTX, after string, limit int) ([]*model.PropertyField, *model.AppError)
+	GetAccessControlPolicy(rctx request.CTX, id string) (*model.AccessControlPolicy, *model.AppError)
+	GetAccessControlPolicyAttributes(rctx request.CTX, channelID string, action string) (map[string][]string, *model.AppError)
+	GetAcknowledgementsForPost(postID string) ([]*model.PostAcknowledgement, *model.AppError)
+	GetAcknowledgementsForPostList(postList *model.PostList) (map[string][]*model.PostAcknowledgement, *model.AppError)
+	// GetActionItem retrieves a single action item by ID
+	GetActionItem(c request.CTX, actionItemID string, userID string) (*model.AIActionItem, error)
+	// GetActionItemStats retrieves statistics about action items for a user
+	GetActionItemStats(c request.CTX, userID string) (*ActionItemStats, error)
+	// GetActionItemsForChannel retrieves action items for a specific channel
+	GetActionItemsForChannel(c request.CTX, channelID string, userID string, filters *ActionItemFilters) ([]*model.AIActionItem, error)
+	// GetActionItemsForUser retrieves action items for a specific user
+	GetActionItemsForUser(c request.CTX, userID string, filters *ActionItemFilters) ([]*model.AIActionItem, error)
+	GetActivePluginManifests() ([]*model.Manifest, *model.AppError)
+	// GetAgents retrieves all available agents from the bridge API
+	GetAgents(rctx request.CTX, userID string) ([]agentclient.BridgeAgentInfo, *model.AppError)
+	GetAllChannels(rctx request.CTX, page int, perPage int, opts model.ChannelSearchOpts) (model.ChannelListWithTeamData, *model.AppError)
+	GetAllChannelsCount(rctx request.CTX, opts model.ChannelSearchOpts) (int64, *model.AppError)
+	// GetAllLdapGroupsPage retrieves all LDAP groups under the configured base DN using the default or configured group
+	// filter.
+	GetAllLdapGroupsPage(rctx request.CTX, page int, perPage int, opts model.LdapGroupSearchOpts) ([]*model.Group, int, *model.AppError)
+	GetAllPrivateTeams() ([]*model.Team, *model.AppError)
+	GetAllPublicTeams() ([]*model.Team, *model.AppError)
+	GetAllRemoteClusters(page int, perPage int, filter model.RemoteClusterQueryFilter) ([]*model.RemoteCluster, *model.AppError)
+	GetAllRoles() ([]*model.Role, *model.AppError)
+	GetAllTeams() ([]*model.Team, *model.AppError)
+	GetAllTeamsPage(offset int, limit int, opts *model.TeamSearch) ([]*model.Team, *model.AppError)
+	GetAllTeamsPageWithCount(offset int, limit int, opts *model.TeamSearch) (*model.TeamsWithCount, *model.AppError)
+	GetAnalytics(rctx request.CTX, name string, teamID string) (model.AnalyticsRows, *model.AppError)
+	GetAnalyticsForSupportPacket(rctx request.CTX) (model.AnalyticsRows, *model.AppError)
+	GetAppliedSchemaMigrations() ([]model.AppliedMigration, *model.AppError)
+	GetAudits(rctx request.CTX, userID string, limit int) (model.Audits, *model.AppError)
+	GetAuditsPage(rctx request.CTX, userID string, page int, perPage int) (model.Audits, *model.AppError)
+	GetAuthorizationCode(rctx request.CTX, w http.ResponseWriter, r *http.Request, service string, props map[string]string, loginHint string) (string, *model.AppError)
+	GetAuthorizationServerMetadata(rctx request.CTX) (*model.AuthorizationServerMetadata, *model.AppError)
+	GetAuthorizedAppsForUser(userID string, page int, perPage int) ([]*model.OAuthApp, *model.AppError)
+	GetBookmark(bookmarkId string, includeDeleted bool) (*model.ChannelBookmarkWithFileInfo, *model.AppError)
+	// GetBot returns the given bot.
+	GetBot(rctx request.CTX, botUserId string, includeDeleted bool) (*model.Bot, *model.AppError)
+	// GetBots returns the requested page of bots.
+	GetBots(rctx request.CTX, options *model.BotGetOptions) (model.BotList, *model.AppError)
+	GetBrandImage(rctx request.CTX) ([]byte, *model.AppError)
+	GetBulkReactionsForPosts(postIDs []string) (map[string][]*model.Reaction, *model.AppError)
+	GetCPAField(fieldID string) (*model.CPAField, *model.AppError)
+	GetCPAValue(valueID string) (*model.PropertyValue, *model.AppError)
+	GetChannel(rctx request.CTX, channelID string) (*model.Channel, *model.AppError)
+	GetChannelBookmarks(channelId string, since int64) ([]*model.ChannelBookmarkWithFileInfo, *model.AppError)
+	GetChannelByName(rctx request.CTX, channelName string, teamID string, includeDeleted bool) (*model.Channel, *model.AppError)
+	GetChannelByNameForTeamName(rctx request.CTX, channelName string, teamName string, includeDeleted bool) (*model.Channel, *model.AppError)
+	GetChannelCounts(rctx request.CTX, teamID string, userID string) (*model.ChannelCounts, *model.AppError)
+	GetChannelFileCount(rctx request.CTX, channelID string) (int64, *model.AppError)
+	// GetChannelGroupUsers returns the users who are associated to the channel via GroupChannels and GroupMembers.
+	GetChannelGroupUsers(channelID string) ([]*model.User, *model.AppError)
+	GetChannelGuestCount(rctx request.CTX, channelID string) (int64, *model.AppError)
+	GetChannelMember(rctx request.CTX, channelID string, userID string) (*model.ChannelMember, *model.AppError)
+	GetChannelMemberCount(rctx request.CTX, channelID string) (int64, *model.AppError)
+	GetChannelMembersByIds(rctx request.CTX, channelID string, userIDs []string) (model.ChannelMembers, *model.AppError)
+	GetChannelMembersForUser(rctx request.CTX, teamID string, userID string) (model.ChannelMembers, *model.AppError)
+	GetChannelMembersForUserWithPagination(rctx request.CTX, userID string, page int, perPage int) ([]*model.ChannelMember, *model.AppError)
+	GetChannelMembersPage(rctx request.CTX, channelID string, page int, perPage int) (model.ChannelMembers, *model.AppError)
+	GetChannelMembersTimezones(rctx request.CTX, channelID string) ([]string, *model.AppError)
+	GetChannelMembersWithTeamDataForUserWithPagination(rctx request.CTX, userID string, cursor *model.ChannelMemberCursor) (model.ChannelMembersWithTeamData, *model.AppError)
+	// GetChannelModerationsForChannel Gets a channels ChannelModerations from either the higherScoped roles or from the channel scheme roles.
+	GetChannelModerationsForChannel(rctx request.CTX, channel *model.Channel) ([]*model.ChannelModeration, *model.AppError)
+	GetChannelPinnedPostCount(rctx request.CTX, channelID string) (int64, *model.AppError)
+	GetChannelPoliciesForUser(userID string, offset int, limit int) (*model.RetentionPolicyForChannelList, *model.AppError)
+	GetChannelUnread(rctx request.CTX, channelID string, userID string) (*model.ChannelUnread, *model.AppError)
+	GetChannels(rctx request.CTX, channelIDs []string) ([]*model.Channel, *model.AppError)
+	GetChannelsByNames(rctx request.CTX, channelNames []string, teamID string) ([]*model.Channel, *model.AppError)
+	GetChannelsForPolicy(rctx request.CTX, policyID string, cursor model.AccessControlPolicyCursor, limit int) ([]*model.ChannelWithTeamData, int64, *model.AppError)
+	GetChannelsForRetentionPolicy(policyID string, offset int, limit int) (*model.ChannelsWithCount, *model.AppError)
+	GetChannelsForScheme(scheme *model.Scheme, offset int, limit int) (model.ChannelList, *model.AppError)
+	GetChannelsForSchemePage(scheme *model.Scheme, page int, perPage int) (model.ChannelList, *model.AppError)
+	GetChannelsForTeamForUser(rctx request.CTX, teamID string, userID string, opts *model.ChannelSearchOpts) (model.ChannelList, *model.AppError)
+	GetChannelsForUser(rctx request.CTX, userID string, includeDeleted bool, lastDeleteAt int, pageSize int, fromChannelID string) (model.ChannelList, *model.AppError)
+	GetChannelsMemberCount(rctx request.CTX, channelIDs []string) (map[string]int64, *model.AppError)
+	GetChannelsUserNotIn(rctx request.CTX, teamID string, userID string, offset int, limit int) (model.ChannelList, *model.AppError)
+	GetCloudSession(token string) (*model.Session, *model.AppError)
+	GetClusterId() string
+	// GetClusterPluginStatuses returns the status for plugins installed anywhere in the cluster.
+	GetClusterPluginStatuses() (model.PluginStatuses, *model.AppError)
+	GetClusterStatus(rctx request.CTX) ([]*model.ClusterInfo, error)
+	GetCommand(commandID string) (*model.Command, *model.AppError)
+	GetCommonTeamIDsForTwoUsers(userID string, otherUserID string) ([]string, *model.AppError)
+	GetComplianceFile(job *model.Compliance) ([]byte, *model.AppError)
+	GetComplianceReport(reportId string) (*model.Compliance, *model.AppError)
+	GetComplianceReports(page int, perPage int) (model.Compliances, *model.AppError)
+	// GetConfigFile proxies access to the given configuration file to the underlying config store.
+	GetConfigFile(name string) ([]byte, error)
+	// GetConfigHistory lists the saved versions of the configuration, most recent first.
+	GetConfigHistory() ([]*model.ConfigVersion, *model.AppError)
+	// GetConfigVersion retrieves a saved version of the configuration.
+	GetConfigVersion(id string) (*model.Config, *model.AppError)
+	GetContentFlaggingConfigReviewerIDs() (*model.ReviewerIDsSettings, *model.AppError)
+	GetContentFlaggingMappedFields(groupId string) (map[string]*model.PropertyField, *model.AppError)
+	GetCookieDomain() string
+	GetCustomStatus(userID string) (*model.CustomStatus, *model.AppError)
+	GetDefaultProfileImage(user *model.User) ([]byte, *model.AppError)
+	GetDeletedChannels(rctx request.CTX, teamID string, offset int, limit int, userID string, skipTeamMembershipCheck bool) (model.ChannelList, *model.AppError)
+	GetDirectOrGroupMessageMembersCommonTeams(rctx request.CTX, channelID string) ([]*model.Team, *model.AppError)
+	GetDraft(userID string, channelID string, rootID string) (*model.Draft, *model.AppError)
+	GetDraftsForUser(rctx request.CTX, userID string, teamID string) ([]*model.Draft, *model.AppError)
+	// GetDueSoonActionItems retrieves action items due within the next 24 hours
+	GetDueSoonActionItems(c request.CTX, hours int) ([]*model.AIActionItem, error)
+	GetEditHistoryForPost(postID string) ([]*model.Post, *model.AppError)
+	GetEmoji(rctx request.CTX, emojiId string) (*model.Emoji, *model.AppError)
+	GetEmojiByName(rctx request.CTX, emojiName string) (*model.Emoji, *model.AppError)
+	GetEmojiImage(rctx request.CTX, emojiId string) ([]byte, string, *model.AppError)
+	GetEmojiList(rctx request.CTX, page int, perPage int, sort string) ([]*model.Emoji, *model.AppError)
+	// GetEmojiStaticURL returns a relative static URL for system default emojis,
+	// and the API route for custom ones. Errors if not found or if custom and deleted.
+	GetEmojiStaticURL(rctx request.CTX, emojiName string) (string, *model.AppError)
+	// GetEnvironmentConfig returns a map of configuration keys whose values have been overridden by an environment variable.
+	// If filter is not nil and returns false for a struct field, that field will be omitted.
+	GetEnvironmentConfig(filter func(reflect.StructField) bool) map[string]any
+	GetEventSubscription(id string) (*model.EventSubscription, *model.AppError)
+	GetEventSubscriptionsPage(teamID string, page int, perPage int) ([]*model.EventSubscription, *model.AppError)
+	GetFile(rctx request.CTX, fileID string) ([]byte, *model.AppError)
+	GetFileInfo(rctx request.CTX, fileID string) (*model.FileInfo, *model.AppError)
+	GetFileInfos(rctx request.CTX, page int, perPage int, opt *model.GetFileInfosOptions) ([]*model.FileInfo, *model.AppError)
+	// GetFileInfosForPost also returns firstInaccessibleFileTime based on cloud plan's limit.
+	GetFileInfosForPost(rctx request.CTX, postID string, fromMaster bool, includeDeleted bool) ([]*model.FileInfo, int64, *model.AppError)
+	GetFileInfosForPostWithMigration(rctx request.CTX, postID string, includeDeleted bool) ([]*model.FileInfo, *model.AppError)
+	// GetFilteredUsersStats is used to get a count of users based on the set of filters supported by UserCountOptions.
+	GetFilteredUsersStats(options *model.UserCountOptions) (*model.UsersStats, *model.AppError)
+	GetFlaggedPosts(userID string, offset int, limit int) (*model.PostList, *model.AppError)
+	GetFlaggedPostsForChannel(userID string, channelID string, offset int, limit int) (*model.PostList, *model.AppError)
+	GetFlaggedPostsForTeam(userID string, teamID string, offset int, limit int) (*model.PostList, *model.AppError)
+	// GetFormattingProfiles returns available formatting profiles
+	GetFormattingProfiles(c request.CTX) ([]FormattingProfileInfo, *model.AppError)
+	GetGlobalRetentionPolicy() (*model.GlobalRetentionPolicy, *model.AppError)
+	GetGroup(id string, opts *model.GetGroupOpts, viewRestrictions *model.ViewUsersRestrictions) (*model.Group, *model.AppError)
+	GetGroupByName(name string, opts model.GroupSearchOpts) (*model.Group, *model.AppError)
+	GetGroupByRemoteID(remoteID string, groupSource model.GroupSource) (*model.Group, *model.AppError)
+	GetGroupChannel(rctx request.CTX, userIDs []string) (*model.Channel, *model.AppError)
+	GetGroupMemberCount(groupID string, viewRestrictions *model.ViewUsersRestrictions) (int64, *model.AppError)
+	GetGroupMemberUsers(groupID string) ([]*model.User, *model.AppError)
+	GetGroupMemberUsersPage(groupID string, page int, perPage int, viewRestrictions *model.ViewUsersRestrictions) ([]*model.User, int, *model.AppError)
+	GetGroupMemberUsersSortedPage(groupID string, page int, perPage int, viewRestrictions *model.ViewUsersRestrictions, teammateNameDisplay string) ([]*model.User, int, *model.AppError)
+	GetGroupSyncable(groupID string, syncableID string, syncableType model.GroupSyncableType) (*model.GroupSyncable, *model.AppError)
+	GetGroupSyncables(groupID string, syncableType model.GroupSyncableType) ([]*model.GroupSyncable, *model.AppError)
+	GetGroups(page int, perPage int, opts model.GroupSearchOpts, viewRestrictions *model.ViewUsersRestrictions) ([]*model.Group, *model.AppError)
+	GetGroupsAssociatedToChannelsByTeam(teamID string, opts model.GroupSearchOpts) (map[string][]*model.GroupWithSchemeAdmin, *model.AppError)
+	GetGroupsByChannel(channelID string, opts model.GroupSearchOpts) ([]*model.GroupWithSchemeAdmin, int, *model.AppError)
+	GetGroupsByIDs(groupIDs []string) ([]*model.Group, *model.AppError)
+	GetGroupsByNames(names []string, restrictions *model.ViewUsersRestrictions) ([]*model.Group, *model.AppError)
+	GetGroupsBySource(groupSource model.GroupSource) ([]*model.Group, *model.AppError)
+	// GetGroupsByTeam returns the paged list and the total count of group associated to the given team.
+	GetGroupsByTeam(teamID string, opts model.GroupSearchOpts) ([]*model.GroupWithSchemeAdmin, int, *model.AppError)
+	GetGroupsByUserId(userID string, opts model.GroupSearchOpts) ([]*model.Group, *model.AppError)
+	GetHubForUserId(userID string) *platform.Hub
+	GetIncomingWebhook(hookID string) (*model.IncomingWebhook, *model.AppError)
+	GetIncomingWebhooksCount(teamID string, userID string) (int64, *model.AppError)
+	GetIncomingWebhooksForTeamPage(teamID string, page int, perPage int) ([]*model.IncomingWebhook, *model.AppError)
+	GetIncomingWebhooksForTeamPageByUser(teamID string, userID string, page int, perPage int) ([]*model.IncomingWebhook, *model.AppError)
+	GetIncomingWebhooksPage(page int, perPage int) ([]*model.IncomingWebhook, *model.AppError)
+	GetIncomingWebhooksPageByUser(userID string, page int, perPage int) ([]*model.IncomingWebhook, *model.AppError)
+	GetJob(rctx request.CTX, id string) (*model.Job, *model.AppError)
+	GetJobsByTypePage(rctx request.CTX, jobType string, page int, perPage int) ([]*model.Job, *model.AppError)
+	GetJobsByTypesAndStatuses(rctx request.CTX, jobTypes []string, status []string, page int, perPage int) ([]*model.Job, *model.AppError)
+	GetJobsByTypesPage(rctx request.CTX, jobType []string, page int, perPage int) ([]*model.Job, *model.AppError)
+	// GetKnownUsers returns the list of user ids of users with any direct
+	// relationship with a user. That means any user sharing any channel, including
+	// direct and group channels.
+	GetKnownUsers(userID string) ([]string, *model.AppError)
+	// GetLLMServices retrieves all available LLM services from the bridge API
+	GetLLMServices(rctx request.CTX, userID string) ([]agentclient.BridgeServiceInfo, *model.AppError)
+	// GetLRUSessions returns the Least Recently Used sessions for userID, skipping over the newest 'offset'
+	// number of sessions. E.g., if userID has 100 sessions, offset 98 will return the oldest 2 sessions.
+	GetLRUSessions(rctx request.CTX, userID string, limit uint64, offset uint64) ([]*model.Session, *model.AppError)
+	// GetLastAccessibleFileTime returns CreateAt time(from cache) of the last accessible post as per the cloud limit
+	GetLastAccessibleFileTime() (int64, *model.AppError)
+	// GetLastAccessiblePostTime returns CreateAt time(from cache) of the last accessible post as per the license limit
+	GetLastAccessiblePostTime() (int64, *model.AppError)
+	GetLatestTermsOfService() (*model.TermsOfService, *model.AppError)
+	GetLatestVersion(rctx request.CTX, latestVersionUrl string) (*model.GithubReleaseInfo, *model.AppError)
+	// GetLdapGroup retrieves a single LDAP group by the given LDAP group id.
+	GetLdapGroup(rctx request.CTX, ldapGroupID string) (*model.Group, *model.AppError)
+	GetLogs(rctx request.CTX, page int, perPage int) ([]string, *model.AppError)
+	GetLogsSkipSend(rctx request.CTX, page int, perPage int, logFilter *model.LogFilter) ([]string, *model.AppError)
+	// GetMarketplacePlugins returns a list of plugins from the marketplace-server,
+	// and plugins that are installed locally.
+	GetMarketplacePlugins(rctx request.CTX, filter *model.MarketplacePluginFilter) ([]*model.MarketplacePlugin, *model.AppError)
+	GetMemberCountsByGroup(rctx request.CTX, channelID string, includeTimezones bool) ([]*model.ChannelMemberCountByGroup, *model.AppError)
+	GetMessageForNotification(post *model.Post, teamName string, siteUrl string, translateFunc i18n.TranslateFunc) string
+	GetMultipleEmojiByName(rctx request.CTX, names []string) ([]*model.Emoji, *model.AppError)
+	GetNewUsersForTeamPage(rctx request.CTX, teamID string, page int, perPage int, asAdmin bool, viewRestrictions *model.ViewUsersRestrictions) ([]*model.User, *model.AppError)
+	GetNextPostIdFromPostList(postList *model.PostList, collapsedThreads bool) string
+	GetNotificationNameFormat(user *model.User) string
+	GetNotificationRules(userID string) ([]*model.NotificationRule, *model.AppError)
+	GetNumberOfChannelsOnTeam(rctx request.CTX, teamID string) (int, *model.AppError)
+	GetOAuthAccessTokenForCodeFlow(rctx request.CTX, clientId string, grantType string, redirectURI string, code string, secret string, refreshToken string, codeVerifier string, resource string) (*model.AccessResponse, *model.AppError)
+	GetOAuthAccessTokenForImplicitFlow(rctx request.CTX, userID string, authRequest *model.AuthorizeRequest) (*model.Session, *model.AppError)
+	GetOAuthApp(appID string) (*model.OAuthApp, *model.AppError)
+	GetOAuthApps(page int, perPage int) ([]*model.OAuthApp, *model.AppError)
+	GetOAuthAppsByCreator(userID string, page int, perPage int) ([]*model.OAuthApp, *model.AppError)
+	GetOAuthCodeRedirect(userID string, authRequest *model.AuthorizeRequest) (string, *model.AppError)
+	GetOAuthImplicitRedirect(rctx request.CTX, userID string, authRequest *model.AuthorizeRequest) (string, *model.AppError)
+	GetOAuthLoginEndpoint(rctx request.CTX, w http.ResponseWriter, r *http.Request, service string, action string, redirectTo string, loginHint string, isMobile bool, desktopToken string, inviteToken string, inviteId string) (string, *model.AppError)
+	GetOAuthSignupEndpoint(rctx request.CTX, w http.ResponseWriter, r *http.Request, service string, desktopToken string, inviteToken string, inviteId string) (string, *model.AppError)
+	GetOAuthStateToken(token string) (*model.Token, *model.AppError)
+	GetOnboarding() (*model.System, *model.AppError)
+	GetOpenGraphMetadata(requestURL string) ([]byte, error)
+	// GetOrCreateAIPreferences gets or creates AI preferences for a user
+	GetOrCreateAIPreferences(userId string) (*model.AIPreferences, error)
+	GetOrCreateDirectChannel(rctx request.CTX, userID string, otherUserID string, channelOptions ...model.ChannelOption) (*model.Channel, *model.AppError)
+	GetOrCreateSystemOwnedBot(rctx request.CTX, botUsername string, botDisplayName string) (*model.Bot, *model.AppError)
+	GetOutgoingWebhook(hookID string) (*model.OutgoingWebhook, *model.AppError)
+	GetOutgoingWebhooksForChannelPageByUser(channelID string, userID string, page int, perPage int) ([]*model.OutgoingWebhook, *model.AppError)
+	GetOutgoingWebhooksForTeamPage(teamID string, page int, perPage int) ([]*model.OutgoingWebhook, *model.AppError)
+	GetOutgoingWebhooksForTeamPageByUser(teamID string, userID string, page int, perPage int) ([]*model.OutgoingWebhook, *model.AppError)
+	GetOutgoingWebhooksPage(page int, perPage int) ([]*model.OutgoingWebhook, *model.AppError)
+	GetOutgoingWebhooksPageByUser(userID string, page int, perPage int) ([]*model.OutgoingWebhook, *model.AppError)
+	// GetOverdueActionItems retrieves all overdue action items
+	GetOverdueActionItems(c request.CTX) ([]*model.AIActionItem, error)
+	GetPasswordRecoveryToken(token string) (*model.Token, *model.AppError)
+	GetPermalinkPost(rctx request.CTX, postID string, userID string) (*model.PostList, *model.AppError)
+	GetPinnedPosts(rctx request.CTX, channelID string) (*model.PostList, *model.AppError)
+	GetPluginKey(pluginID string, key string) ([]byte, *model.AppError)
+	// GetPluginStatus returns the status for a plugin installed on this server.
+	GetPluginStatus(id string) (*model.PluginStatus, *model.AppError)
+	// GetPluginStatuses returns the status for plugins installed on this server.
+	GetPluginStatuses() (model.PluginStatuses, *model.AppError)
+	GetPlugins() (*model.PluginsResponse, *model.AppError)
+	// GetPluginsEnvironment returns the plugin environment for use if plugins are enabled and
+	// initialized.
+	//
+	// To get the plugins environment when the plugins are disabled, manually acquire the plugins
+	// lock instead.
+	GetPluginsEnvironment() *plugin.Environment
+	GetPostAfterTime(channelID string, time int64, collapsedThreads bool) (*model.Post, *model.AppError)
+	GetPostContentFlaggingPropertyValue(postId string, propertyFieldName string) (*model.PropertyValue, *model.AppError)
+	GetPostContentFlaggingPropertyValues(postId string) ([]*model.PropertyValue, *model.AppError)
+	GetPostHistoryLimit() int64
+	GetPostIdAfterTime(channelID string, time int64, collapsedThreads bool) (string, *model.AppError)
+	GetPostIdBeforeTime(channelID string, time int64, collapsedThreads bool) (string, *model.AppError)
+	GetPostIfAuthorized(rctx request.CTX, postID string, session *model.Session, includeDeleted bool) (*model.Post, *model.AppError)
+	GetPostInfo(rctx request.CTX, postID string) (*model.PostInfo, *model.AppError)
+	GetPostThread(rctx request.CTX, postID string, opts model.GetPostsOptions, userID string) (*model.PostList, *model.AppError)
+	GetPosts(rctx request.CTX, channelID string, offset int, limit int) (*model.PostList, *model.AppError)
+	GetPostsAfterPost(rctx request.CTX, options model.GetPostsOptions) (*model.PostList, *model.AppError)
+	GetPostsAroundPost(rctx request.CTX, before bool, options model.GetPostsOptions) (*model.PostList, *model.AppError)
+	GetPostsBeforePost(rctx request.CTX, options model.GetPostsOptions) (*model.PostList, *model.AppError)
+	// GetPostsByIds response bool value indicates, if the post is inaccessible due to cloud plan's limit.
+	GetPostsByIds(postIDs []string) ([]*model.Post, int64, *model.AppError)
+	GetPostsEtag(channelID string, collapsedThreads bool) string
+	GetPostsForChannelAroundLastUnread(rctx request.CTX, channelID string, userID string, limitBefore int, limitAfter int, skipFetchThreads bool, collapsedThreads bool, collapsedThreadsExtended bool) (*model.PostList, *model.AppError)
+	GetPostsForReporting(rctx request.CTX, queryParams model.ReportPostQueryParams, includeMetadata bool) (*model.ReportPostListResponse, *model.AppError)
+	GetPostsPage(rctx request.CTX, options model.GetPostsOptions) (*model.PostList, *model.AppError)
+	GetPostsSince(rctx request.CTX, options model.GetPostsSinceOptions) (*model.PostList, *model.AppError)
+	// GetPostsUsage returns the total posts count rounded down to the most
+	// significant digit
+	GetPostsUsage() (int64, *model.AppError)
+	GetPreferenceByCategoryAndNameForUser(rctx request.CTX, userID string, category string, preferenceName string) (*model.Preference, *model.AppError)
+	GetPreferenceByCategoryForUser(rctx request.CTX, userID string, category string) (model.Preferences, *model.AppError)
+	GetPreferencesForUser(rctx request.CTX, userID string) (model.Preferences, *model.AppError)
+	GetPrevPostIdFromPostList(postList *model.PostList, collapsedThreads bool) string
+	// GetPreviewModalData fetches modal content data from the configured S3 bucket
+	GetPreviewModalData() ([]model.PreviewModalContentData, *model.AppError)
+	GetPriorityForPost(postId string) (*model.PostPriority, *model.AppError)
+	GetPriorityForPostList(list *model.PostList) (map[string]*model.PostPriority, *model.AppError)
+	GetPrivateChannelsForTeam(rctx request.CTX, teamID string, offset int, limit int) (model.ChannelList, *model.AppError)
+	// GetProductNotices is called from the frontend to fetch the product notices that are relevant to the caller
+	GetProductNotices(rctx request.CTX, userID string, teamID string, client model.NoticeClientType, clientVersion string, locale string) (model.NoticeMessages, *model.AppError)
+	GetProfileImage(user *model.User) ([]byte, bool, *model.AppError)
+	// GetProfileImagePaths returns the paths to the profile images for the given user IDs if such a profile image exists.
+	GetProfileImagePath(user *model.User) (string, *model.AppError)
+	GetPublicChannelsByIdsForTeam(rctx request.CTX, teamID string, channelIDs []string) (model.ChannelList, *model.AppError)
+	GetPublicChannelsForTeam(rctx request.CTX, teamID string, offset int, limit int) (model.ChannelList, *model.AppError)
+	// GetPublicKey will return the actual public key saved in the `name` file.
+	GetPublicKey(name string) ([]byte, *model.AppError)
+	// GetRateLimitCounters returns the state of the rate limits of key, for every rule or only the
+	// named one.
+	GetRateLimitCounters(key string, ruleName string) ([]*model.RateLimitCounter, *model.AppError)
+	GetReactionsForPost(postID string) ([]*model.Reaction, *model.AppError)
+	GetRecentlyActiveUsersForTeam(rctx request.CTX, teamID string) (map[string]*model.User, *model.AppError)
+	GetRecentlyActiveUsersForTeamPage(rctx request.CTX, teamID string, page int, perPage int, asAdmin bool, viewRestrictions *model.ViewUsersRestrictions) ([]*model.User, *model.AppError)
+	GetRemoteCluster(remoteClusterId string, includeDeleted bool) (*model.RemoteCluster, *model.AppError)
+	GetRemoteClusterForUser(remoteID string, userID string) (*model.RemoteCluster, *model.AppError)
+	GetRemoteClusterService() (remotecluster.RemoteClusterServiceIFace, *model.AppError)
+	GetRemoteClusterSession(token string, remoteId string) (*model.Session, *model.AppError)
+	GetRetentionPolicies(offset int, limit int) (*model.RetentionPolicyWithTeamAndChannelCountsList, *model.AppError)
+	GetRetentionPoliciesCount() (int64, *model.AppError)
+	GetRetentionPolicy(policyID string) (*model.RetentionPolicyWithTeamAndChannelCounts, *model.AppError)
+	GetRole(id string) (*model.Role, *model.AppError)
+	GetRoleByName(rctx request.CTX, name string) (*model.Role, *model.AppError)
+	GetRolesByNames(names []string) ([]*model.Role, *model.AppError)
+	GetSamlCertificateStatus() *model.SamlCertificateStatus
+	GetSamlEmailToken(token string) (*model.Token, *model.AppError)
+	GetSamlMetadata(rctx request.CTX) (string, *model.AppError)
+	GetSamlMetadataFromIdp(idpMetadataURL string) (*model.SamlMetadataResponse, *model.AppError)
+	GetSanitizeOptions(asAdmin bool) map[string]bool
+	// GetSanitizedConfig gets the configuration for a system admin without any secrets.
+	GetSanitizedConfig() *model.Config
+	GetScheme(id string) (*model.Scheme, *model.AppError)
+	GetSchemeByName(name string) (*model.Scheme, *model.AppError)
+	// GetSchemeRolesForChannel Checks if a channel or its team has an override scheme for channel roles and returns the scheme roles or default channel roles.
+	GetSchemeRolesForChannel(rctx request.CTX, channelID string) (string, string, string, *model.AppError)
+	GetSchemeRolesForTeam(teamID string) (string, string, string, *model.AppError)
+	GetSchemes(scope string, offset int, limit int) ([]*model.Scheme, *model.AppError)
+	GetSchemesPage(scope string, page int, perPage int) ([]*model.Scheme, *model.AppError)
+	GetServerLimits() (*model.ServerLimits, *model.AppError)
+	GetSession(token string) (*model.Session, *model.AppError)
+	GetSessionById(rctx request.CTX, sessionID string) (*model.Session, *model.AppError)
+	// GetSessionLengthInMillis returns the session length, in milliseconds,
+	// based on the type of session (Mobile, SSO, Web/LDAP).
+	GetSessionLengthInMillis(session *model.Session) int64
+	GetSessions(rctx request.CTX, userID string) ([]*model.Session, *model.AppError)
+	GetSharedChannel(channelID string) (*model.SharedChannel, error)
+	GetSharedChannelRemote(id string) (*model.SharedChannelRemote, error)
+	GetSharedChannelRemoteByIds(channelID string, remoteID string) (*model.SharedChannelRemote, error)
+	GetSharedChannelRemotes(page int, perPage int, opts model.SharedChannelRemoteFilterOpts) ([]*model.SharedChannelRemote, error)
+	GetSharedChannelRemotesStatus(channelID string) ([]*model.SharedChannelRemoteStatus, error)
+	GetSharedChannels(page int, perPage int, opts model.SharedChannelFilterOpts) ([]*model.SharedChannel, *model.AppError)
+	GetSharedChannelsCount(opts model.SharedChannelFilterOpts) (int64, error)
+	GetSidebarCategories(rctx request.CTX, userID string, teamID string) (*model.OrderedSidebarCategories, *model.AppError)
+	GetSidebarCategoriesForTeamForUser(rctx request.CTX, userID string, teamID string) (*model.OrderedSidebarCategories, *model.AppError)
+	GetSidebarCategory(rctx request.CTX, categoryId string) (*model.SidebarCategoryWithChannels, *model.AppError)
+	GetSidebarCategoryOrder(rctx request.CTX, userID string, teamID string) ([]string, *model.AppError)
+	GetSinglePost(rctx request.CTX, postID string, includeDeleted bool) (*model.Post, *model.AppError)
+	GetSiteURL() string
+	GetStatus(userID string) (*model.Status, *model.AppError)
+	GetStatusFromCache(userID string) *model.Status
+	// GetStorageUsage returns the sum of files' sizes stored on this instance
+	GetStorageUsage() (int64, *model.AppError)
+	// GetSuggestions returns suggestions for user input.
+	GetSuggestions(rctx request.CTX, commandArgs *model.CommandArgs, commands []*model.Command, roleID string) []model.AutocompleteSuggestion
+	GetSystemBot(rctx request.CTX) (*model.Bot, *model.AppError)
+	GetTeam(teamID string) (*model.Team, *model.AppError)
+	GetTeamByInviteId(inviteId string) (*model.Team, *model.AppError)
+	GetTeamByName(name string) (*model.Team, *model.AppError)
+	// GetTeamGroupUsers returns the users who are associated to the team via GroupTeams and GroupMembers.
+	GetTeamGroupUsers(teamID string) ([]*model.User, *model.AppError)
+	GetTeamIcon(team *model.Team) ([]byte, *model.AppError)
+	GetTeamIdFromQuery(rctx request.CTX, query url.Values) (string, *model.AppError)
+	GetTeamMember(rctx request.CTX, teamID string, userID string) (*model.TeamMember, *model.AppError)
+	GetTeamMembers(teamID string, offset int, limit int, teamMembersGetOptions *model.TeamMembersGetOptions) ([]*model.TeamMember, *model.AppError)
+	GetTeamMembersByIds(teamID string, userIDs []string, restrictions *model.ViewUsersRestrictions) ([]*model.TeamMember, *model.AppError)
+	GetTeamMembersForUser(rctx request.CTX, userID string, excludeTeamID string, includeDeleted bool) ([]*model.TeamMember, *model.AppError)
+	GetTeamMembersForUserWithPagination(userID string, page int, perPage int) ([]*model.TeamMember, *model.AppError)
+	GetTeamPoliciesForUser(userID string, offset int, limit int) (*model.RetentionPolicyForTeamList, *model.AppError)
+	// GetTeamSchemeChannelRoles Checks if a team has an override scheme and returns the scheme channel role names or default channel role names.
+	GetTeamSchemeChannelRoles(rctx request.CTX, teamID string) (string, string, string, *model.AppError)
+	GetTeamStats(teamID string, restrictions *model.ViewUsersRestrictions) (*model.TeamStats, *model.AppError)
+	GetTeamUnread(teamID string, userID string) (*model.TeamUnread, *model.AppError)
+	GetTeams(teamIDs []string) ([]*model.Team, *model.AppError)
+	GetTeamsForRetentionPolicy(policyID string, offset int, limit int) (*model.TeamsWithCount, *model.AppError)
+	GetTeamsForScheme(scheme *model.Scheme, offset int, limit int) ([]*model.Team, *model.AppError)
+	GetTeamsForSchemePage(scheme *model.Scheme, page int, perPage int) ([]*model.Team, *model.AppError)
+	GetTeamsForUser(userID string) ([]*model.Team, *model.AppError)
+	GetTeamsUnreadForUser(excludeTeamId string, userID string, includeCollapsedThreads bool) ([]*model.TeamUnread, *model.AppError)
+	GetTeamsUsage() (*model.TeamsUsage, *model.AppError)
+	GetTermsOfService(id string) (*model.TermsOfService, *model.AppError)
+	GetThreadForUser(rctx request.CTX, threadMembership *model.ThreadMembership, extended bool) (*model.ThreadResponse, *model.AppError)
+	GetThreadMembershipForUser(userId string, threadId string) (*model.ThreadMembership, *model.AppError)
+	GetThreadMembershipsForUser(userID string, teamID string) ([]*model.ThreadMembership, error)
+	GetThreadsForUser(rctx request.CTX, userID string, teamID string, options model.GetUserThreadsOpts) (*model.Threads, *model.AppError)
+	GetTokenById(token string) (*model.Token, *model.AppError)
+	// GetTotalUsersStats is used for the DM list total
+	GetTotalUsersStats(viewRestrictions *model.ViewUsersRestrictions) (*model.UsersStats, *model.AppError)
+	GetUploadSession(rctx request.CTX, uploadId string) (*model.UploadSession, *model.AppError)
+	GetUploadSessionsForUser(userID string) ([]*model.UploadSession, *model.AppError)
+	GetUser(userID string) (*model.User, *model.AppError)
+	GetUserAccessToken(tokenID string, sanitize bool) (*model.UserAccessToken, *model.AppError)
+	GetUserAccessTokens(page int, perPage int) ([]*model.UserAccessToken, *model.AppError)
+	GetUserAccessTokensForUser(userID string, page int, perPage int) ([]*model.UserAccessToken, *model.AppError)
+	GetUserByAuth(authData *string, authService string) (*model.User, *model.AppError)
+	GetUserByEmail(email string) (*model.User, *model.AppError)
+	GetUserByRemoteID(remoteID string) (*model.User, *model.AppError)
+	GetUserByUsername(username string) (*model.User, *model.AppError)
+	GetUserCountForReport(filter *model.UserReportOptions) (*int64, *model.AppError)
+	GetUserForLogin(rctx request.CTX, id string, loginId string) (*model.User, *model.AppError)
+	// GetUserStatusesByIds used by apiV4
+	GetUserStatusesByIds(userIDs []string) ([]*model.Status, *model.AppError)
+	GetUserTeamScheduledPosts(rctx request.CTX, userId string, teamId string) ([]*model.ScheduledPost, *model.AppError)
+	GetUserTermsOfService(userID string) (*model.UserTermsOfService, *model.AppError)
+	GetUsers(rctx request.CTX, userIDs []string) ([]*model.User, *model.AppError)
+	GetUsersByGroupChannelIds(rctx request.CTX, channelIDs []string, asAdmin bool) (map[string][]*model.User, *model.AppError)
+	GetUsersByIds(rctx request.CTX, userIDs []string, options *store.UserGetByIdsOpts) ([]*model.User, *model.AppError)
+	GetUsersByUsernames(usernames []string, asAdmin bool, viewRestrictions *model.ViewUsersRestrictions) ([]*model.User, *model.AppError)
+	GetUsersEtag(restrictionsHash string) string
+	// GetUsersForPosts fetches user information for all posts
+	GetUsersForPosts(posts []*model.Post) (map[string]*model.User, error)
+	GetUsersForReporting(filter *model.UserReportOptions) ([]*model.UserReport, *model.AppError)
+	GetUsersFromProfiles(options *model.UserGetOptions) ([]*model.User, *model.AppError)
+	GetUsersInChannel(options *model.UserGetOptions) ([]*model.User, *model.AppError)
+	GetUsersInChannelByAdmin(options *model.UserGetOptions) ([]*model.User, *model.AppError)
+	GetUsersInChannelByStatus(options *model.UserGetOptions) ([]*model.User, *model.AppError)
+	GetUsersInChannelMap(options *model.UserGetOptions, asAdmin bool) (map[string]*model.User, *model.AppError)
+	GetUsersInChannelPage(options *model.UserGetOptions, asAdmin bool) ([]*model.User, *model.AppError)
+	GetUsersInChannelPageByAdmin(options *model.UserGetOptions, asAdmin bool) ([]*model.User, *model.AppError)
+	GetUsersInChannelPageByStatus(options *model.UserGetOptions, asAdmin bool) ([]*model.User, *model.AppError)
+	GetUsersInTeam(options *model.UserGetOptions) ([]*model.User, *model.AppError)
+	GetUsersInTeamEtag(teamID string, restrictionsHash string) string
+	GetUsersInTeamPage(options *model.UserGetOptions, asAdmin bool) ([]*model.User, *model.AppError)
+	GetUsersNotInAbacChannel(rctx request.CTX, teamID string, channelID string, groupConstrained bool, cursorID string, limit int, asAdmin bool, viewRestrictions *model.ViewUsersRestrictions) ([]*model.User, *model.AppError)
+	GetUsersNotInChannel(teamID string, channelID string, groupConstrained bool, offset int, limit int, viewRestrictions *model.ViewUsersRestrictions) ([]*model.User, *model.AppError)
+	GetUsersNotInChannelMap(teamID string, channelID string, groupConstrained bool, offset int, limit int, asAdmin bool, viewRestrictions *model.ViewUsersRestrictions) (map[string]*model.User, *model.AppError)
+	GetUsersNotInChannelPage(teamID string, channelID string, groupConstrained bool, page int, perPage int, asAdmin bool, viewRestrictions *model.ViewUsersRestrictions) ([]*model.User, *model.AppError)
+	GetUsersNotInGroupPage(groupID string, page int, perPage int, viewRestrictions *model.ViewUsersRestrictions) ([]*model.User, *model.AppError)
+	GetUsersNotInTeam(teamID string, groupConstrained bool, offset int, limit int, viewRestrictions *model.ViewUsersRestrictions) ([]*model.User, *model.AppError)
+	GetUsersNotInTeamEtag(teamID string, restrictionsHash string) string
+	GetUsersNotInTeamPage(teamID string, groupConstrained bool, page int, perPage int, asAdmin bool, viewRestrictions *model.ViewUsersRestrictions) ([]*model.User, *model.AppError)
+	GetUsersPage(options *model.UserGetOptions, asAdmin bool) ([]*model.User, *model.AppError)
+	GetUsersWithInvalidEmails(page int, perPage int) ([]*model.User, *model.AppError)
+	GetUsersWithoutTeam(options *model.UserGetOptions) ([]*model.User, *model.AppError)
+	GetUsersWithoutTeamPage(options *model.UserGetOptions, asAdmin bool) ([]*model.User, *model.AppError)
+	GetVerifyEmailToken(token string) (*model.Token, *model.AppError)
+	GetViewUsersRestrictions(rctx request.CTX, userID string) (*model.ViewUsersRestrictions, *model.AppError)
+	GetWorkingHours(userID string) (*model.WorkingHours, *model.AppError)
+	HTTPService() httpservice.HTTPService
+	HandleCommandResponse(rctx request.CTX, command *model.Command, args *model.CommandArgs, response *model.CommandResponse, builtIn bool) (*model.CommandResponse, *model.AppError)
+	HandleCommandResponsePost(rctx request.CTX, command *model.Command, args *model.CommandArgs, response *model.CommandResponse, builtIn bool) (*model.Post, *model.AppError)
+	HandleCommandWebhook(rctx request.CTX, hookID string, response *model.CommandResponse) *model.AppError
+	HandleImages(rctx request.CTX, previewPathList []string, thumbnailPathList []string, fileData [][]byte)
+	HandleIncomingWebhook(rctx request.CTX, hookID string, req *model.IncomingWebhookRequest) *model.AppError
+	HandleMessageExportConfig(cfg *model.Config, appCfg *model.Config)
+	HasPermissionTo(askingUserId string, permission *model.Permission) bool
+	HasPermissionToChannel(rctx request.CTX, askingUserId string, channelID string, permission *model.Permission) bool
+	HasPermissionToChannelByPost(rctx request.CTX, askingUserId string, postID string, permission *model.Permission) bool
+	HasPermissionToChannelMemberCount(rctx request.CTX, userID string, channel *model.Channel) bool
+	HasPermissionToReadChannel(rctx request.CTX, userID string, channel *model.Channel) bool
+	HasPermissionToTeam(rctx request.CTX, askingUserId string, teamID string, permission *model.Permission) bool
+	HasPermissionToUser(askingUserId string, userID string) bool
+	// HasRemote returns whether a given channelID is present in the channel remotes or not.
+	HasRemote(channelID string, remoteID string) (bool, error)
+	HasSharedChannel(channelID string) (bool, error)
+	IPFiltering() einterfaces.IPFilteringInterface
+	ImageProxy() *imageproxy.ImageProxy
+	ImageProxyAdder() func(string) string
+	ImageProxyRemover() func(string) string
+	InitPlugins(rctx request.CTX, pluginDir string, webappPluginDir string)
+	// InitializeAI initializes AI services based on configuration
+	InitializeAI() error
+	// InstallPlugin unpacks and installs a plugin but does not enable or activate it unless the the
+	// plugin was already enabled.
+	InstallPlugin(pluginFile io.ReadSeeker, replace bool) (*model.Manifest, *model.AppError)
+	InvalidateAllEmailInvites(rctx request.CTX) *model.AppError
+	InvalidateAllResendInviteEmailJobs(rctx request.CTX) *model.AppError
+	InvalidateCacheForUser(userID string)
+	InvalidatePasswordRecoveryTokensForUser(userID string) *model.AppError
+	InviteGuestsToChannels(rctx request.CTX, teamID string, guestsInvite *model.GuestsInvite, senderId string, guestMagicLink bool) *model.AppError
+	InviteGuestsToChannelsGracefully(rctx request.CTX, teamID string, guestsInvite *model.GuestsInvite, senderId string, guestMagicLink bool) ([]*model.EmailInviteWithError, *model.AppError)
+	InviteNewUsersToTeam(rctx request.CTX, emailList []string, teamID string, senderId string) *model.AppError
+	InviteNewUsersToTeamGracefully(rctx request.CTX, memberInvite *model.MemberInvite, teamID string, senderId string, reminderInterval string) ([]*model.EmailInviteWithError, *model.AppError)
+	InviteRemoteToChannel(channelID string, remoteID string, userID string, shareIfNotShared bool) error
+	// IsAIFeatureEnabled checks if a specific AI feature is enabled
+	IsAIFeatureEnabled(feature string) bool
+	// IsBotOwnedByCurrentUserOrPlugin checks if the given user ID is a bot owned by the current session's user or by a plugin.
+	IsBotOwnedByCurrentUserOrPlugin(rctx request.CTX, userID string) (bool, *model.AppError)
+	IsCRTEnabledForUser(rctx request.CTX, userID string) bool
+	IsConfigReadOnly() bool
+	IsFirstUserAccount() bool
+	IsLeader() bool
+	IsPasswordValid(rctx request.CTX, password string) *model.AppError
+	IsPersistentNotificationsEnabled() bool
+	IsPhase2MigrationCompleted() *model.AppError
+	IsPluginActive(pluginName string) (bool, error)
+	IsPostPriorityEnabled() bool
+	IsUserSignUpAllowed() *model.AppError
+	IsUserTeamContentReviewer(userId string, teamId string) (bool, *model.AppError)
+	JoinChannel(rctx request.CTX, channel *model.Channel, userID string) *model.AppError
+	JoinDefaultChannels(rctx request.CTX, teamID string, user *model.User, shouldBeAdmin bool, userRequestorId string) *model.AppError
+	JoinUserToTeam(rctx request.CTX, team *model.Team, user *model.User, userRequestorId string) (*model.TeamMember, *model.AppError)
+	KeepFlaggedPost(rctx request.CTX, actionRequest *model.FlagContentActionRequest, reviewerId string, flaggedPost *model.Post) *model.AppError
+	Ldap() einterfaces.LdapInterface
+	LdapDiagnostic() einterfaces.LdapDiagnosticInterface
+	LeaveChannel(rctx request.CTX, channelID string, userID string) *model.AppError
+	LeaveTeam(rctx request.CTX, team *model.Team, user *model.User, requestorId string) *model.AppError
+	License() *model.License
+	LimitedClientConfig() map[string]string
+	ListAllCommands(teamID string, T i18n.TranslateFunc) ([]*model.Command, *model.AppError)
+	ListAllCommandsByUser(teamID string, userID string, T i18n.TranslateFunc) ([]*model.Command, *model.AppError)
+	// previous ListCommands now ListAutocompleteCommands
+	ListAutocompleteCommands(teamID string, T i18n.TranslateFunc) ([]*model.Command, *model.AppError)
+	ListCPAFields() ([]*model.CPAField, *model.AppError)
+	ListCPAValues(userID string) ([]*model.PropertyValue, *model.AppError)
+	ListDirectory(path string) ([]string, *model.AppError)
+	ListDirectoryRecursively(path string) ([]string, *model.AppError)
+	ListExportDirectory(path string) ([]string, *model.AppError)
+	ListExports() ([]string, *model.AppError)
+	ListImports() ([]string, *model.AppError)
+	ListPluginKeys(pluginID string, page int, perPage int) ([]string, *model.AppError)
+	ListTeamCommands(teamID string) ([]*model.Command, *model.AppError)
+	ListTeamCommandsByUser(teamID string, userID string) ([]*model.Command, *model.AppError)
+	Log() *mlog.Logger
+	// LogAuditRec logs an audit record using default LvlAuditCLI.
+	LogAuditRec(rctx request.CTX, rec *model.AuditRecord, err error)
+	// LogAuditRecWithLevel logs an audit record using specified Level.
+	LogAuditRecWithLevel(rctx request.CTX, rec *model.AuditRecord, level mlog.Level, err error)
+	LoginByOAuth(rctx request.CTX, service string, userData io.Reader, inviteToken string, inviteId string, tokenUser *model.User) (*model.User, *model.AppError)
+	LookupInteractiveDialog(rctx request.CTX, request model.SubmitDialogRequest) (*model.LookupDialogResponse, *model.AppError)
+	MFARequired(rctx request.CTX) *model.AppError
+	// MakeAuditRecord creates a audit record pre-populated with defaults.
+	MakeAuditRecord(rctx request.CTX, event string, initialStatus string) *model.AuditRecord
+	// MarkChanelAsUnreadFromPost will take a post and set the channel as unread from that one.
+	MarkChannelAsUnreadFromPost(rctx request.CTX, postID string, userID string, collapsedThreadsSupported bool) (*model.ChannelUnreadAt, *model.AppError)
+	MarkChannelsAsViewed(rctx request.CTX, channelIDs []string, userID string, currentSessionId string, collapsedThreadsSupported bool, isCRTEnabled bool) (map[string]int64, *model.AppError)
+	MaxPostSize() int
+	// MentionsToPublicChannels returns all the mentions to public channels,
+	// linking them to their channels
+	MentionsToPublicChannels(rctx request.CTX, message string, teamID string) model.ChannelMentionMap
+	// MentionsToTeamMembers returns all the @ mentions found in message that
+	// belong to users in the specified team, linking them to their users
+	MentionsToTeamMembers(rctx request.CTX, message string, teamID string) model.UserMentionMap
+	MessageExport() einterfaces.MessageExportInterface
+	Metrics() einterfaces.MetricsInterface
+	// MigrateFilenamesToFileInfos creates and stores FileInfos for a post created before the FileInfos table existed.
+	MigrateFilenamesToFileInfos(rctx request.CTX, post *model.Post) []*model.FileInfo
+	MigrateIdLDAP(rctx request.CTX, toAttribute string) *model.AppError
+	// MoveChannel method is prone to data races if someone joins to channel during the move process. However this
+	// function is only exposed to sysadmins and the possibility of this edge case is relatively small.
+	MoveChannel(rctx request.CTX, team *model.Team, channel *model.Channel, user *model.User) *model.AppError
+	MoveCommand(team *model.Team, command *model.Command) *model.AppError
+	MoveFile(oldPath string, newPath string) *model.AppError
+	MoveThread(rctx request.CTX, postID string, sourceChannelID string, channelID string, user *model.User) *model.AppError
+	NewPluginAPI(rctx request.CTX, manifest *model.Manifest) plugin.API
+	Notification() einterfaces.NotificationInterface
+	NotifySelfHostedSignupProgress(progress string, userId string)
+	// NotifySessionsExpired is called periodically from the job server to notify any mobile sessions that have expired.
+	NotifySessionsExpired() error
+	NotifySharedChannelUserUpdate(user *model.User)
+	// OnSharedChannelsAttachmentSyncMsg is called by the Shared Channels service for a registered plugin when a file attachment
+	// needs to be synchronized.
+	OnSharedChannelsAttachmentSyncMsg(fi *model.FileInfo, post *model.Post, rc *model.RemoteCluster) error
+	// OnSharedChannelsPing is called by the Shared Channels service for a registered plugin to check that the plugin
+	// is still responding and has a connection to any upstream services it needs (e.g. MS Graph API).
+	OnSharedChannelsPing(rc *model.RemoteCluster) bool
+	// OnSharedChannelsProfileImageSyncMsg is called by the Shared Channels service for a registered plugin when a user's
+	// profile image needs to be synchronized.
+	OnSharedChannelsProfileImageSyncMsg(user *model.User, rc *model.RemoteCluster) error
+	// OnSharedChannelsSyncMsg is called by the Shared Channels service for a registered plugin when there is new content
+	// that needs to be synchronized.
+	OnSharedChannelsSyncMsg(msg *model.SyncMsg, rc *model.RemoteCluster) (model.SyncResponse, error)
+	OpenInteractiveDialog(rctx request.CTX, request model.OpenDialogRequest) *model.AppError
+	OriginChecker() func(*http.Request) bool
+	OutgoingOAuthConnections() einterfaces.OutgoingOAuthConnectionInterface
+	// OverrideIconURLIfEmoji changes the post icon override URL prop, if it has an emoji icon,
+	// so that it points to the URL (relative) of the emoji - static if emoji is default, /api if custom.
+	OverrideIconURLIfEmoji(rctx request.CTX, post *model.Post)
+	// PatchBot applies the given patch to the bot and corresponding user.
+	PatchBot(rctx request.CTX, botUserId string, botPatch *model.BotPatch) (*model.Bot, *model.AppError)
+	PatchCPAField(fieldID string, patch *model.PropertyFieldPatch) (*model.CPAField, *model.AppError)
+	PatchCPAValue(userID string, fieldID string, value json.RawMessage, allowSynced bool) (*model.PropertyValue, *model.AppError)
+	PatchCPAValues(userID string, fieldValueMap map[string]json.RawMessage, allowSynced bool) ([]*model.PropertyValue, *model.AppError)
+	PatchChannel(rctx request.CTX, channel *model.Channel, patch *model.ChannelPatch, userID string) (*model.Channel, *model.AppError)
+	PatchChannelMembersNotifyProps(rctx request.CTX, members []*model.ChannelMemberIdentifier, notifyProps map[string]string) ([]*model.ChannelMember, *model.AppError)
+	// PatchChannelModerationsForChannel Updates a channels scheme roles based on a given ChannelModerationPatch, if the permissions match the higher scoped role the scheme is deleted.
+	PatchChannelModerationsForChannel(rctx request.CTX, channel *model.Channel, channelModerationsPatch []*model.ChannelModerationPatch) ([]*model.ChannelModeration, *model.AppError)
+	PatchPost(rctx request.CTX, postID string, patch *model.PostPatch, patchPostOptions *model.UpdatePostOptions) (*model.Post, *model.AppError)
+	PatchRemoteCluster(rcId string, patch *model.RemoteClusterPatch) (*model.RemoteCluster, *model.AppError)
+	PatchRetentionPolicy(patch *model.RetentionPolicyWithTeamAndChannelIDs) (*model.RetentionPolicyWithTeamAndChannelCounts, *model.AppError)
+	PatchRole(role *model.Role, patch *model.RolePatch) (*model.Role, *model.AppError)
+	PatchScheme(scheme *model.Scheme, patch *model.SchemePatch) (*model.Scheme, *model.AppError)
+	PatchTeam(teamID string, patch *model.TeamPatch) (*model.Team, *model.AppError)
+	PatchUser(rctx request.CTX, userID string, patch *model.UserPatch, asAdmin bool) (*model.User, *model.AppError)
+	PermanentDeleteAllUsers(rctx request.CTX) *model.AppError
+	// PermanentDeleteBot permanently deletes a bot and its corresponding user.
+	PermanentDeleteBot(rctx request.CTX, botUserId string) *model.AppError
+	PermanentDeleteChannel(rctx request.CTX, channel *model.Channel) *model.AppError
+	PermanentDeleteFilesByPost(rctx request.CTX, postID string) *model.AppError
+	PermanentDeleteFlaggedPost(rctx request.CTX, actionRequest *model.FlagContentActionRequest, reviewerId string, flaggedPost *model.Post) *model.AppError
+	PermanentDeletePost(rctx request.CTX, postID string, deleteByID string) *model.AppError
+	PermanentDeleteTeam(rctx request.CTX, team *model.Team) *model.AppError
+	PermanentDeleteTeamId(rctx request.CTX, teamID string) *model.AppError
+	PermanentDeleteUser(rctx request.CTX, user *model.User) *model.AppError
+	// PopulateWebConnConfig checks if the connection id already exists in the hub,
+	// and if so, accordingly populates the other fields of the webconn.
+	PopulateWebConnConfig(s *model.Session, cfg *platform.WebConnConfig, seqVal string) (*platform.WebConnConfig, error)
+	PostActionCookieSecret() []byte
+	PostAddToChannelMessage(rctx request.CTX, user *model.User, addedUser *model.User, channel *model.Channel, postRootId string) *model.AppError
+	PostPatchWithProxyRemovedFromImageURLs(patch *model.PostPatch) *model.PostPatch
+	PostUpdateChannelDisplayNameMessage(rctx request.CTX, userID string, channel *model.Channel, oldChannelDisplayName string, newChannelDisplayName string) *model.AppError
+	PostUpdateChannelHeaderMessage(rctx request.CTX, userID string, channel *model.Channel, oldChannelHeader string, newChannelHeader string) *model.AppError
+	PostUpdateChannelPurposeMessage(rctx request.CTX, userID string, channel *model.Channel, oldChannelPurpose string, newChannelPurpose string) *model.AppError
+	PostWithProxyAddedToImageURLs(post *model.Post) *model.Post
+	PostWithProxyRemovedFromImageURLs(post *model.Post) *model.Post
+	// PrepareIncomingWebhookRequest checks the signature of a raw request to a
+	// signed hook and, for hooks with a payload template, renders the template to
+	// build the request. It returns a nil request when the body should be decoded
+	// as a regular Slack-compatible payload instead.
+	PrepareIncomingWebhookRequest(hookID string, header http.Header, body []byte) (*model.IncomingWebhookRequest, *model.AppError)
+	PreparePostForClient(rctx request.CTX, originalPost *model.Post, opts *model.PreparePostForClientOpts) *model.Post
+	PreparePostForClientWithEmbedsAndImages(rctx request.CTX, originalPost *model.Post, opts *model.PreparePostForClientOpts) *model.Post
+	PreparePostListForClient(rctx request.CTX, originalList *model.PostList) *model.PostList
+	// PreviewFormatting returns a preview of formatted text without applying it
+	PreviewFormatting(c request.CTX, req *FormattingRequest) (*FormattingResponse, *model.AppError)
+	ProcessScheduledPosts(rctx request.CTX)
+	// Expand announcements in incoming webhooks from Slack. Those announcements
+	// can be found in the text attribute, or in the pretext, text, title and value
+	// attributes of the attachment structure. The Slack attachment structure is
+	// documented here: https://api.slack.com/docs/attachments
+	ProcessSlackAttachments(rctx request.CTX, attachments []*model.SlackAttachment) []*model.SlackAttachment
+	ProcessSlackText(rctx request.CTX, text string) string
+	// PromoteGuestToUser Convert user's roles and all his membership's roles from
+	// guest roles to regular user roles.
+	PromoteGuestToUser(rctx request.CTX, user *model.User, requestorId string) *model.AppError
+	PropertyService() *properties.PropertyService
+	Publish(message *model.WebSocketEvent)
+	PublishScheduledPostEvent(rctx request.CTX, eventType model.WebsocketEventType, scheduledPost *model.ScheduledPost, connectionId string)
+	PublishUserTyping(userID string, channelID string, parentId string) *model.AppError
+	PurgeElasticsearchIndexes(rctx request.CTX, indexes []string) *model.AppError
+	QueryLogs(rctx request.CTX, page int, perPage int, logFilter *model.LogFilter) (map[string][]string, *model.AppError)
+	ReadFile(path string) ([]byte, *model.AppError)
+	// ReattachPlugin allows the server to bind to an existing plugin instance launched elsewhere.
+	ReattachPlugin(manifest *model.Manifest, pluginReattachConfig *model.PluginReattachConfig) *model.AppError
+	RecycleDatabaseConnection(rctx request.CTX)
+	RegenCommandToken(cmd *model.Command) (*model.Command, *model.AppError)
+	RegenEventSubscriptionSecret(subscription *model.EventSubscription) (*model.EventSubscription, *model.AppError)
+	RegenOutgoingWebhookToken(hook *model.OutgoingWebhook) (*model.OutgoingWebhook, *model.AppError)
+	RegenerateOAuthAppSecret(app *model.OAuthApp) (*model.OAuthApp, *model.AppError)
+	RegenerateTeamInviteId(teamID string) (*model.Team, *model.AppError)
+	RegisterOAuthClient(rctx request.CTX, req *model.ClientRegistrationRequest, userID string) (*model.OAuthApp, *model.AppError)
+	RegisterPerformanceReport(rctx request.CTX, report *model.PerformanceReport) *model.AppError
+	RegisterPluginCommand(pluginID string, command *model.Command) error
+	RegisterPluginForSharedChannels(rctx request.CTX, opts model.RegisterPluginOpts) (string, error)
+	ReloadConfig() error
+	RemoveAllDeactivatedMembersFromChannel(rctx request.CTX, channel *model.Channel) *model.AppError
+	RemoveAuditLogCertificate(rctx request.CTX) *model.AppError
+	RemoveChannelsFromRetentionPolicy(policyID string, channelIDs []string) *model.AppError
+	// Removes a listener function by the unique ID returned when AddConfigListener was called
+	RemoveConfigListener(id string)
+	RemoveCustomStatus(rctx request.CTX, userID string) *model.AppError
+	RemoveDirectory(path string) *model.AppError
+	RemoveExportFile(path string) *model.AppError
+	RemoveFile(path string) *model.AppError
+	RemoveFileFromFileStore(rctx request.CTX, path string)
+	RemoveFilesFromFileStore(rctx request.CTX, fileInfos []*model.FileInfo)
+	RemoveLdapPrivateCertificate() *model.AppError
+	RemoveLdapPublicCertificate() *model.AppError
+	// This method should be called when a syncable is unlinked from a group
+	RemoveMembershipsFromUnlinkedSyncable(rctx request.CTX, syncableID string, syncableType model.GroupSyncableType)
+	RemoveNotifications(rctx request.CTX, post *model.Post, channel *model.Channel) error
+	RemoveRecentCustomStatus(rctx request.CTX, userID string, status *model.CustomStatus) *model.AppError
+	RemoveSamlIdpCertificate() *model.AppError
+	RemoveSamlPrivateCertificate() *model.AppError
+	RemoveSamlPublicCertificate() *model.AppError
+	RemoveTeamIcon(teamID string) *model.AppError
+	RemoveTeamsFromRetentionPolicy(policyID string, teamIDs []string) *model.AppError
+	RemoveUserFromChannel(rctx request.CTX, userIDToRemove string, removerUserId string, channel *model.Channel) *model.AppError
+	RemoveUserFromTeam(rctx request.CTX, teamID string, userID string, requestorId string) *model.AppError
+	RemoveUsersFromChannelNotMemberOfTeam(rctx request.CTX, remover *model.User, channel *model.Channel, team *model.Team) *model.AppError
+	// RenameChannel is used to rename the channel Name and the DisplayName fields
+	RenameChannel(rctx request.CTX, channel *model.Channel, newChannelName string, newDisplayName string) (*model.Channel, *model.AppError)
+	// RenameTeam is used to rename the team Name and the DisplayName fields
+	RenameTeam(team *model.Team, newTeamName string, newDisplayName string) (*model.Team, *model.AppError)
+	ResetPasswordFailedAttempts(rctx request.CTX, user *model.User) *model.AppError
+	ResetPasswordFromToken(rctx request.CTX, userSuppliedTokenString string, newPassword string) *model.AppError
+	ResetPermissionsSystem() *model.AppError
+	ResetSamlAuthDataToEmail(includeDeleted bool, dryRun bool, userIDs []string) (int, *model.AppError)
+	// ResolvePersistentNotification stops the persistent notifications, if a loggedInUserID(except the post owner) reacts, reply or ack on the post.
+	// Post-owner can only delete the original post to stop the notifications.
+	ResolvePersistentNotification(rctx request.CTX, post *model.Post, loggedInUserID string) *model.AppError
+	RestoreChannel(rctx request.CTX, channel *model.Channel, userID string) (*model.Channel, *model.AppError)
+	RestoreGroup(groupID string) (*model.Group, *model.AppError)
+	RestorePostVersion(rctx request.CTX, userID string, postID string, restoreVersionID string) (*model.Post, *model.AppError)
+	RestoreTeam(teamID string) *model.AppError
+	RestrictUsersGetByPermissions(rctx request.CTX, userID string, options *model.UserGetOptions) (*model.UserGetOptions, *model.AppError)
+	RestrictUsersSearchByPermissions(rctx request.CTX, userID string, options *model.UserSearchOptions) (*model.UserSearchOptions, *model.AppError)
+	RevokeAccessToken(rctx request.CTX, token string) *model.AppError
+	RevokeAllSessions(rctx request.CTX, userID string) *model.AppError
+	RevokeSession(rctx request.CTX, session *model.Session) *model.AppError
+	RevokeSessionById(rctx request.CTX, sessionID string) *model.AppError
+	RevokeSessionsForDeviceId(rctx request.CTX, userID string, deviceID string, currentSessionId string) *model.AppError
+	// RevokeSessionsFromAllUsers will go through all the sessions active
+	// in the server and revoke them
+	RevokeSessionsFromAllUsers() *model.AppError
+	RevokeUserAccessToken(rctx request.CTX, token *model.UserAccessToken) *model.AppError
+	// RewriteMessage rewrites a message using AI based on the specified action
+	RewriteMessage(rctx request.CTX, agentID string, message string, action model.RewriteAction, customPrompt string) (*model.RewriteResponse, *model.AppError)
+	RolesGrantPermission(roleNames []string, permissionId string) bool
+	// RollbackConfig validates a saved version of the configuration and makes it the active one,
+	// recording authorID as the author of the restored version.
+	RollbackConfig(cfg *model.Config, authorID string) (*model.Config, *model.Config, *model.AppError)
+	Saml() einterfaces.SamlInterface
+	SanitizePostListMetadataForUser(rctx request.CTX, postList *model.PostList, userID string) (*model.PostList, *model.AppError)
+	SanitizePostMetadataForUser(rctx request.CTX, post *model.Post, userID string) (*model.Post, *model.AppError)
+	SanitizeProfile(user *model.User, asAdmin bool)
+	SanitizeTeam(session model.Session, team *model.Team) *model.Team
+	SanitizeTeams(session model.Session, teams []*model.Team) []*model.Team
+	// SanitizedConfig sanitizes a given configuration for a system admin without any secrets.
+	SanitizedConfig(cfg *model.Config)
+	SaveAcknowledgementForPost(rctx request.CTX, postID string, userID string) (*model.PostAcknowledgement, *model.AppError)
+	SaveAcknowledgementForPostWithModel(rctx request.CTX, acknowledgement *model.PostAcknowledgement) (*model.PostAcknowledgement, *model.AppError)
+	// SaveAcknowledgementsForPost saves multiple acknowledgements for a post in a single operation.
+	SaveAcknowledgementsForPost(rctx request.CTX, postID string, userIDs []string) ([]*model.PostAcknowledgement, *model.AppError)
+	SaveAdminNotification(userId string, notifyData *model.NotifyAdminToUpgradeRequest) *model.AppError
+	SaveAdminNotifyData(data *model.NotifyAdminData) (*model.NotifyAdminData, *model.AppError)
+	SaveAndBroadcastStatus(status *model.Status)
+	SaveBrandImage(rctx request.CTX, imageData *multipart.FileHeader) *model.AppError
+	SaveComplianceReport(rctx request.CTX, job *model.Compliance) (*model.Compliance, *model.AppError)
+	// SaveConfig replaces the active configuration, optionally notifying cluster peers.
+	SaveConfig(newCfg *model.Config, sendConfigChangeClusterMessage bool) (*model.Config, *model.Config, *model.AppError)
+	// SaveConfigWithAuthor is like SaveConfig, but records the given user as the author of the new version.
+	SaveConfigWithAuthor(newCfg *model.Config, sendConfigChangeClusterMessage bool, authorID string) (*model.Config, *model.Config, *model.AppError)
+	SaveContentFlaggingConfig(config model.ContentFlaggingSettingsRequest) *model.AppError
+	SaveReactionForPost(rctx request.CTX, reaction *model.Reaction) (*model.Reaction, *model.AppError)
+	SaveReportChunk(format string, prefix string, count int, reportData []model.ReportableObject) *model.AppError
+	SaveScheduledPost(rctx request.CTX, scheduledPost *model.ScheduledPost, connectionId string) (*model.ScheduledPost, *model.AppError)
+	SaveSharedChannelRemote(remote *model.SharedChannelRemote) (*model.SharedChannelRemote, error)
+	SaveUserTermsOfService(userID string, termsOfServiceId string, accepted bool) *model.AppError
+	// SaveWorkingHours creates or replaces the working hours of a user, and updates their status right
+	// away if their quiet hours start or end with the new schedule.
+	SaveWorkingHours(rctx request.CTX, workingHours *model.WorkingHours) (*model.WorkingHours, *model.AppError)
+	SchemesIterator(scope string, batchSize int) func() []*model.Scheme
+	SearchAccessControlPolicies(rctx request.CTX, opts model.AccessControlPolicySearch) ([]*model.AccessControlPolicy, int64, *model.AppError)
+	// SearchAllChannels returns a list of channels, the total count of the results of the search (if the paginate search option is true), and an error.
+	SearchAllChannels(rctx request.CTX, term string, opts model.ChannelSearchOpts) (model.ChannelListWithTeamData, int64, *model.AppError)
+	// SearchAllTeams returns a team list and the total count of the results
+	SearchAllTeams(searchOpts *model.TeamSearch) ([]*model.Team, int64, *model.AppError)
+	// SearchAuditLog returns the entries of the database audit log matching the search, most recent first.
+	SearchAuditLog(search model.AuditLogSearch) ([]*model.AuditLogEntry, *model.AppError)
+	SearchChannels(rctx request.CTX, teamID string, term string) (model.ChannelList, *model.AppError)
+	SearchChannelsForUser(rctx request.CTX, userID string, teamID string, term string) (model.ChannelList, *model.AppError)
+	SearchChannelsUserNotIn(rctx request.CTX, teamID string, userID string, term string) (model.ChannelList, *model.AppError)
+	SearchEmoji(rctx request.CTX, name string, prefixOnly bool, limit int) ([]*model.Emoji, *model.AppError)
+	SearchEngine() *searchengine.Broker
+	SearchFilesInTeamForUser(rctx request.CTX, terms string, userId string, teamId string, isOrSearch bool, includeDeletedChannels bool, timeZoneOffset int, page int, perPage int) (*model.FileInfoList, *model.AppError)
+	SearchGroupChannels(rctx request.CTX, userID string, term string) (model.ChannelList, *model.AppError)
+	SearchPostsForUser(rctx request.CTX, terms string, userID string, teamID string, isOrSearch bool, includeDeletedChannels bool, timeZoneOffset int, page int, perPage int) (*model.PostSearchResults, *model.AppError)
+	SearchPostsInTeam(teamID string, paramsList []*model.SearchParams) (*model.PostList, *model.AppError)
+	SearchPrivateTeams(searchOpts *model.TeamSearch) ([]*model.Team, *model.AppError)
+	SearchPublicTeams(searchOpts *model.TeamSearch) ([]*model.Team, *model.AppError)
+	SearchReviewers(rctx request.CTX, term string, teamId string) ([]*model.User, *model.AppError)
+	SearchUserAccessTokens(term string) ([]*model.UserAccessToken, *model.AppError)
+	SearchUsers(rctx request.CTX, props *model.UserSearch, options *model.UserSearchOptions) ([]*model.User, *model.AppError)
+	SearchUsersInChannel(channelID string, term string, options *model.UserSearchOptions) ([]*model.User, *model.AppError)
+	SearchUsersInGroup(groupID string, term string, options *model.UserSearchOptions) ([]*model.User, *model.AppError)
+	SearchUsersInTeam(rctx request.CTX, teamID string, term string, options *model.UserSearchOptions) ([]*model.User, *model.AppError)
+	SearchUsersNotInChannel(teamID string, channelID string, term string, options *model.UserSearchOptions) ([]*model.User, *model.AppError)
+	SearchUsersNotInGroup(groupID string, term string, options *model.UserSearchOptions) ([]*model.User, *model.AppError)
+	SearchUsersNotInTeam(notInTeamId string, term string, options *model.UserSearchOptions) ([]*model.User, *model.AppError)
+	SearchUsersWithoutTeam(term string, options *model.UserSearchOptions) ([]*model.User, *model.AppError)
+	SendAckToPushProxy(rctx request.CTX, ack *model.PushNotificationAck) error
+	SendAutoResponse(rctx request.CTX, channel *model.Channel, receiver *model.User, post *model.Post) (bool, *model.AppError)
+	SendAutoResponseIfNecessary(rctx request.CTX, channel *model.Channel, sender *model.User, post *model.Post) (bool, *model.AppError)
+	SendEmailVerification(user *model.User, newEmail string, redirect string) *model.AppError
+	SendEphemeralPost(rctx request.CTX, userID string, post *model.Post) *model.Post
+	SendIPFiltersChangedEmail(rctx request.CTX, userID string) error
+	SendNotifications(rctx request.CTX, post *model.Post, team *model.Team, channel *model.Channel, sender *model.User, parentPostList *model.PostList, setOnline bool) ([]string, error)
+	SendNotifyAdminPosts(rctx request.CTX, workspaceName string, currentSKU string, trial bool) *model.AppError
+	SendPasswordReset(rctx request.CTX, email string, siteURL string) (bool, *model.AppError)
+	SendPersistentNotifications() error
+	SendReportToUser(rctx request.CTX, job *model.Job, format string) *model.AppError
+	// Create/ Update a subscription history event
+	// This function is run daily to record the number of activated users in the system for Cloud workspaces
+	SendSubscriptionHistoryEvent(userID string) (*model.SubscriptionHistory, error)
+	SendTestMessage(rctx request.CTX, userID string) (*model.Post, *model.AppError)
+	SendTestPushNotification(rctx request.CTX, deviceID string) string
+	// ServeInterPluginRequest handles inter-plugin HTTP requests.
+	// This function does not set user authentication headers, unlike ServeInternalPluginRequest.
+	ServeInterPluginRequest(w http.ResponseWriter, r *http.Request, sourcePluginId string, destinationPluginId string)
+	// ServeInternalPluginRequest handles internal requests to plugins from either core server or other plugins.
+	// This is used by the Plugin Bridge to route requests with proper authentication headers.
+	//
+	// Parameters:
+	//   - userID: User ID to set in the authentication header (empty string if no user context)
+	//   - w: HTTP response writer
+	//   - r: HTTP request (should have URL path set to the endpoint, NOT including plugin ID)
+	//   - sourcePluginID: ID of calling plugin (empty string if from core)
+	//   - targetPluginID: ID of target plugin to call
+	ServeInternalPluginRequest(userID string, w http.ResponseWriter, r *http.Request, sourcePluginID string, targetPluginID string)
+	ServerId() string
+	SessionHasPermissionTo(session model.Session, permission *model.Permission) bool
+	// SessionHasPermissionToAndNotRestrictedAdmin is a variant of [App.SessionHasPermissionTo] that
+	// denies access to restricted system admins. Note that a local session is always unrestricted.
+	SessionHasPermissionToAndNotRestrictedAdmin(session model.Session, permission *model.Permission) bool
+	SessionHasPermissionToAny(session model.Session, permissions []*model.Permission) bool
+	SessionHasPermissionToCategory(rctx request.CTX, session model.Session, userID string, teamID string, categoryId string) bool
+	SessionHasPermissionToChannel(rctx request.CTX, session model.Session, channelID string, permission *model.Permission) bool
+	SessionHasPermissionToChannelByPost(session model.Session, postID string, permission *model.Permission) bool
+	// SessionHasPermissionToChannels returns true only if user has access to all channels.
+	SessionHasPermissionToChannels(rctx request.CTX, session model.Session, channelIDs []string, permission *model.Permission) bool
+	SessionHasPermissionToCreateJob(session model.Session, job *model.Job) (bool, *model.Permission)
+	SessionHasPermissionToGroup(session model.Session, groupID string, permission *model.Permission) bool
+	// SessionHasPermissionToManageBot returns nil if the session has access to manage the given bot.
+	// This function deviates from other authorization checks in returning an error instead of just
+	// a boolean, allowing the permission failure to be exposed with more granularity.
+	SessionHasPermissionToManageBot(rctx request.CTX, session model.Session, botUserId string) *model.AppError
+	SessionHasPermissionToManageJob(session model.Session, job *model.Job) (bool, *model.Permission)
+	SessionHasPermissionToReadChannel(rctx request.CTX, session model.Session, channel *model.Channel) bool
+	SessionHasPermissionToReadJob(session model.Session, jobType string) (bool, *model.Permission)
+	SessionHasPermissionToTeam(session model.Session, teamID string, permission *model.Permission) bool
+	// SessionHasPermissionToTeams returns true only if user has access to all teams.
+	SessionHasPermissionToTeams(rctx request.CTX, session model.Session, teamIDs []string, permission *model.Permission) bool
+	SessionHasPermissionToUser(session model.Session, userID string) bool
+	SessionHasPermissionToUserOrBot(rctx request.CTX, session model.Session, userID string) bool
+	// SessionIsRegistered determines if a specific session has been registered
+	SessionIsRegistered(session model.Session) bool
+	SetActiveChannel(rctx request.CTX, userID string, channelID string) *model.AppError
+	SetAutoResponderStatus(rctx request.CTX, user *model.User, oldNotifyProps model.StringMap)
+	SetChannels(ch *Channels)
+	SetCustomStatus(rctx request.CTX, userID string, cs *model.CustomStatus) *model.AppError
+	SetDefaultProfileImage(rctx request.CTX, user *model.User) *model.AppError
+	SetExtraSessionProps(session *model.Session, newProps map[string]string) *model.AppError
+	SetFileSearchableContent(rctx request.CTX, fileID string, data string) *model.AppError
+	SetPhase2PermissionsMigrationStatus(isComplete bool) error
+	SetPluginKey(pluginID string, key string, value []byte) *model.AppError
+	SetPluginKeyWithExpiry(pluginID string, key string, value []byte, expireInSeconds int64) *model.AppError
+	SetPluginKeyWithOptions(pluginID string, key string, value []byte, options model.PluginKVSetOptions) (bool, *model.AppError)
+	SetPostReminder(rctx request.CTX, postID string, userID string, targetTime int64) *model.AppError
+	SetProfileImage(rctx request.CTX, userID string, imageData *multipart.FileHeader) *model.AppError
+	SetProfileImageFromFile(rctx request.CTX, userID string, file io.ReadSeeker) *model.AppError
+	SetProfileImageFromMultiPartFile(rctx request.CTX, userID string, file multipart.File) *model.AppError
+	SetRemoteClusterLastPingAt(remoteClusterId string) *model.AppError
+	SetSamlIdpCertificateFromMetadata(data []byte) *model.AppError
+	// SetScheduledPostPaused pauses or resumes a recurring scheduled post. The
+	// occurrences that come up while a series is paused are skipped.
+	SetScheduledPostPaused(rctx request.CTX, userId string, scheduledPostId string, paused bool, connectionId string) (*model.ScheduledPost, *model.AppError)
+	SetSearchEngine(se *searchengine.Broker)
+	SetServer(srv *Server)
+	// SetSessionExpireInHours sets the session's expiry the specified number of hours
+	// relative to either the session creation date or the current time, depending
+	// on the `ExtendSessionOnActivity` config setting.
+	SetSessionExpireInHours(session *model.Session, hours int)
+	SetStatusAwayIfNeeded(userID string, manual bool)
+	SetStatusDoNotDisturb(userID string)
+	// SetStatusDoNotDisturbTimed takes endtime in unix epoch format in UTC
+	// and sets status of given userId to dnd which will be restored back after endtime
+	SetStatusDoNotDisturbTimed(userId string, endtime int64)
+	// SetStatusLastActivityAt sets the last activity at for a user on the local app server and updates
+	// status to away if needed. Used by the WS to set status to away if an 'online' device disconnects
+	// while an 'away' device is still connected
+	SetStatusLastActivityAt(userID string, activityAt int64)
+	SetStatusOffline(userID string, manual bool, force bool)
+	SetStatusOnline(userID string, manual bool)
+	SetStatusOutOfOffice(userID string)
+	SetTeamIcon(rctx request.CTX, teamID string, imageData *multipart.FileHeader) *model.AppError
+	SetTeamIconFromFile(rctx request.CTX, team *model.Team, file io.ReadSeeker) *model.AppError
+	SetTeamIconFromMultiPartFile(rctx request.CTX, teamID string, file multipart.File) *model.AppError
+	ShareChannel(rctx request.CTX, sc *model.SharedChannel) (*model.SharedChannel, error)
+	ShouldSendPushNotification(rctx request.CTX, user *model.User, channelNotifyProps model.StringMap, wasMentioned bool, status *model.Status, post *model.Post, isGM bool) bool
+	// SkipNextScheduledPostOccurrence moves a recurring scheduled post on to its
+	// next occurrence without sending the pending one. Skipping the last
+	// occurrence of a series deletes the scheduled post.
+	SkipNextScheduledPostOccurrence(rctx request.CTX, userId string, scheduledPostId string, connectionId string) (*model.ScheduledPost, *model.AppError)
+	SlackImport(rctx request.CTX, fileData multipart.File, fileSize int64, teamID string) (*model.AppError, *bytes.Buffer)
+	SoftDeleteTeam(teamID string) *model.AppError
+	Srv() *Server
+	StartUsersBatchExport(rctx request.CTX, ro *model.UserReportOptions, startAt int64, endAt int64) *model.AppError
+	SubmitInteractiveDialog(rctx request.CTX, request model.SubmitDialogRequest) (*model.SubmitDialogResponse, *model.AppError)
+	// SummarizeChannel generates an AI summary of channel messages
+	SummarizeChannel(c request.CTX, req *SummarizationRequest) (*SummarizationResponse, *model.AppError)
+	// SummarizeThread generates an AI summary of a thread
+	SummarizeThread(c request.CTX, req *SummarizationRequest) (*SummarizationResponse, *model.AppError)
+	SwitchEmailToLdap(rctx request.CTX, email string, password string, code string, ldapLoginId string, ldapPassword string) (string, *model.AppError)
+	SwitchEmailToOAuth(rctx request.CTX, w http.ResponseWriter, r *http.Request, email string, password string, code string, service string) (string, *model.AppError)
+	SwitchLdapToEmail(rctx request.CTX, ldapPassword string, code string, email string, newPassword string) (string, *model.AppError)
+	SwitchOAuthToEmail(rctx request.CTX, email string, password string, requesterId string) (string, *model.AppError)
+	// SyncLdap starts an LDAP sync job.
+	SyncLdap(rctx request.CTX)
+	// SyncPlugins synchronizes the plugins installed locally
+	// with the plugin bundles available in the file store.
+	SyncPlugins() *model.AppError
+	// SyncRolesAndMembership updates the SchemeAdmin status and membership of all of the members of the given
+	// syncable.
+	SyncRolesAndMembership(rctx request.CTX, syncableID string, syncableType model.GroupSyncableType, groupID string)
+	// SyncSharedChannel forces a shared channel to send any changed content to all remote clusters.
+	SyncSharedChannel(channelID string) error
+	// SyncSyncableRoles updates the SchemeAdmin field value of the given syncable's members based on the configuration of
+	// the member's group memberships and the configuration of those groups to the syncable. This method should only
+	// be invoked on group-synced (aka group-constrained) syncables.
+	SyncSyncableRoles(rctx request.CTX, syncableID string, syncableType model.GroupSyncableType) *model.AppError
+	// TeamMembersMinusGroupMembers returns the set of users on the given team minus the set of users in the given
+	// groups.
+	//
+	// The result can be used, for example, to determine the set of users who would be removed from a team if the team
+	// were group-constrained with the given groups.
+	TeamMembersMinusGroupMembers(teamID string, groupIDs []string, page int, perPage int) ([]*model.UserWithGroups, int64, *model.AppError)
+	// TeamMembersToAdd returns a slice of UserTeamIDPair that need newly created memberships
+	// based on the groups configurations. The returned list can be optionally scoped to a single given team.
+	//
+	// Typically since will be the last successful group sync time.
+	// If reAddRemovedMembers is true, then team members who left or were removed from the team will
+	// be included; otherwise, they will be excluded.
+	TeamMembersToAdd(since int64, teamID *string, reAddRemovedMembers bool) ([]*model.UserTeamIDPair, *model.AppError)
+	TeamMembersToRemove(teamID *string) ([]*model.TeamMember, *model.AppError)
+	TestElasticsearch(rctx request.CTX, cfg *model.Config) *model.AppError
+	TestEmail(rctx request.CTX, userID string, cfg *model.Config) *model.AppError
+	TestExpression(rctx request.CTX, expression string, opts model.SubjectSearchOptions) ([]*model.User, int64, *model.AppError)
+	// TestExpressionWithChannelContext tests expressions for channel admins with attribute validation
+	// Channel admins can only see users that match expressions they themselves would match
+	TestExpressionWithChannelContext(rctx request.CTX, expression string, opts model.SubjectSearchOptions) ([]*model.User, int64, *model.AppError)
+	TestFileStoreConnection() *model.AppError
+	TestFileStoreConnectionWithConfig(cfg *model.FileSettings) *model.AppError
+	TestLdap(rctx request.CTX) *model.AppError
+	TestLdapConnection(rctx request.CTX, settings model.LdapSettings) *model.AppError
+	TestLdapDiagnostics(rctx request.CTX, testType model.LdapDiagnosticTestType, settings model.LdapSettings) ([]model.LdapDiagnosticResult, *model.AppError)
+	// TestNotificationRule returns the recent posts of the channels of the user the rule matches,
+	// whether it is enabled or not.
+	TestNotificationRule(rctx request.CTX, userID string, rule *model.NotificationRule) (*model.NotificationRuleTestResult, *model.AppError)
+	TestSiteURL(rctx request.CTX, siteURL string) *model.AppError
+	Timezones() *timezones.Timezones
+	ToggleMuteChannel(rctx request.CTX, channelID string, userID string) (*model.ChannelMember, *model.AppError)
+	TotalWebsocketConnections() int
+	// TransformIncomingWebhookPayload renders the payload template of hook against
+	// the raw request body, producing the Slack-compatible request the rest of the
+	// incoming webhook flow understands. This lets services such as GitHub,
+	// GitLab, Alertmanager or Sentry post directly to a hook.
+	TransformIncomingWebhookPayload(hook *model.IncomingWebhook, header http.Header, body []byte) (*model.IncomingWebhookRequest, *model.AppError)
+	TriggerWebhook(rctx request.CTX, payload *model.OutgoingWebhookPayload, hook *model.OutgoingWebhook, post *model.Post, channel *model.Channel)
+	UnassignPoliciesFromChannels(rctx request.CTX, policyID string, channelIDs []string) *model.AppError
+	UninviteRemoteFromChannel(channelID string, remoteID string) error
+	UnregisterPluginCommand(pluginID string, teamID string, trigger string)
+	UnregisterPluginForSharedChannels(pluginID string) error
+	UnshareChannel(channelID string) (bool, error)
+	UpdateAccessControlPoliciesActive(rctx request.CTX, updates []model.AccessControlPolicyActiveUpdate) ([]*model.AccessControlPolicy, *model.AppError)
+	UpdateAccessControlPolicyActive(rctx request.CTX, policyID string, active bool) *model.AppError
+	// UpdateActionItem updates an existing action item
+	UpdateActionItem(c request.CTX, actionItemID string, userID string, update *ActionItemUpdateRequest) (*model.AIActionItem, error)
+	UpdateActive(rctx request.CTX, user *model.User, active bool) (*model.User, *model.AppError)
+	// UpdateBotActive marks a bot as active or inactive, along with its corresponding user.
+	UpdateBotActive(rctx request.CTX, botUserId string, active bool) (*model.Bot, *model.AppError)
+	// UpdateBotOwner changes a bot's owner to the given value.
+	UpdateBotOwner(rctx request.CTX, botUserId string, newOwnerId string) (*model.Bot, *model.AppError)
+	// UpdateChannel updates a given channel by its Id. It also publishes the CHANNEL_UPDATED event.
+	UpdateChannel(rctx request.CTX, channel *model.Channel) (*model.Channel, *model.AppError)
+	UpdateChannelBookmark(rctx request.CTX, updateBookmark *model.ChannelBookmarkWithFileInfo, connectionId string) (*model.UpdateChannelBookmarkResponse, *model.AppError)
+	UpdateChannelBookmarkSortOrder(bookmarkId string, channelId string, newIndex int64, connectionId string) ([]*model.ChannelBookmarkWithFileInfo, *model.AppError)
+	UpdateChannelMemberNotifyProps(rctx request.CTX, data map[string]string, channelID string, userID string) (*model.ChannelMember, *model.AppError)
+	UpdateChannelMemberRoles(rctx request.CTX, channelID string, userID string, newRoles string) (*model.ChannelMember, *model.AppError)
+	UpdateChannelMemberSchemeRoles(rctx request.CTX, channelID string, userID string, isSchemeGuest bool, isSchemeUser bool, isSchemeAdmin bool) (*model.ChannelMember, *model.AppError)
+	UpdateChannelPrivacy(rctx request.CTX, oldChannel *model.Channel, user *model.User) (*model.Channel, *model.AppError)
+	// UpdateChannelScheme saves the new SchemeId of the channel passed.
+	UpdateChannelScheme(rctx request.CTX, channel *model.Channel) (*model.Channel, *model.AppError)
+	UpdateCommand(oldCmd *model.Command, updatedCmd *model.Command) (*model.Command, *model.AppError)
+	UpdateConfig(f func(*model.Config))
+	// UpdateDNDStatusOfUsers is a recurring task which is started when server starts
+	// which unsets dnd status of users if needed and saves and broadcasts it
+	UpdateDNDStatusOfUsers()
+	UpdateDefaultProfileImage(rctx request.CTX, user *model.User) *model.AppError
+	UpdateEphemeralPost(rctx request.CTX, userID string, post *model.Post) *model.Post
+	UpdateEventSubscription(rctx request.CTX, oldSubscription *model.EventSubscription, updatedSubscription *model.EventSubscription) (*model.EventSubscription, *model.AppError)
+	UpdateExpiredDNDStatuses() ([]*model.Status, error)
+	UpdateGroup(group *model.Group) (*model.Group, *model.AppError)
+	UpdateGroupSyncable(groupSyncable *model.GroupSyncable) (*model.GroupSyncable, *model.AppError)
+	UpdateHashedPassword(user *model.User, newHashedPassword string) *model.AppError
+	UpdateHashedPasswordByUserId(userID string, newHashedPassword string) *model.AppError
+	UpdateIncomingWebhook(oldHook *model.IncomingWebhook, updatedHook *model.IncomingWebhook) (*model.IncomingWebhook, *model.AppError)
+	UpdateJobStatus(rctx request.CTX, job *model.Job, newStatus string) *model.AppError
+	UpdateMfa(rctx request.CTX, activate bool, userID string, token string) *model.AppError
+	UpdateMobileAppBadge(userID string)
+	// UpdateNotificationRules replaces the rules of the user, in the given order. Rules keep their id
+	// when it matches one of the existing rules of the user, and get a new one otherwise.
+	UpdateNotificationRules(userID string, rules []*model.NotificationRule) ([]*model.NotificationRule, *model.AppError)
+	UpdateOAuthApp(oldApp *model.OAuthApp, updatedApp *model.OAuthApp) (*model.OAuthApp, *model.AppError)
+	UpdateOAuthUserAttrs(rctx request.CTX, userData io.Reader, user *model.User, provider einterfaces.OAuthProvider, service string, tokenUser *model.User) *model.AppError
+	UpdateOutgoingWebhook(rctx request.CTX, oldHook *model.OutgoingWebhook, updatedHook *model.OutgoingWebhook) (*model.OutgoingWebhook, *model.AppError)
+	UpdatePassword(rctx request.CTX, user *model.User, newPassword string) *model.AppError
+	UpdatePasswordAsUser(rctx request.CTX, userID string, currentPassword string, newPassword string) *model.AppError
+	UpdatePasswordByUserIdSendEmail(rctx request.CTX, userID string, newPassword string, method string) *model.AppError
+	UpdatePasswordSendEmail(rctx request.CTX, user *model.User, newPassword string, method string) *model.AppError
+	UpdatePost(rctx request.CTX, receivedUpdatedPost *model.Post, updatePostOptions *model.UpdatePostOptions) (*model.Post, *model.AppError)
+	UpdatePreferences(rctx request.CTX, userID string, preferences model.Preferences) *model.AppError
+	// UpdateProductNotices is called periodically from a scheduled worker to fetch new notices and update the cache
+	UpdateProductNotices() *model.AppError
+	UpdateRemoteCluster(rc *model.RemoteCluster) (*model.RemoteCluster, *model.AppError)
+	UpdateRemoteClusterTopics(remoteClusterId string, topics string) (*model.RemoteCluster, *model.AppError)
+	UpdateRole(role *model.Role) (*model.Role, *model.AppError)
+	UpdateScheduledPost(rctx request.CTX, userId string, scheduledPost *model.ScheduledPost, connectionId string) (*model.ScheduledPost, *model.AppError)
+	UpdateScheme(scheme *model.Scheme) (*model.Scheme, *model.AppError)
+	UpdateSharedChannel(sc *model.SharedChannel) (*model.SharedChannel, error)
+	// UpdateSharedChannelCursor updates the cursor for the specified channelID and remoteID.
+	// This can be used to manually set the point of last sync, either forward to skip older posts,
+	// or backward to re-sync history.
+	// This call by itself does not force a re-sync - a change to channel contents or a call to
+	// SyncSharedChannel are needed to force a sync.
+	UpdateSharedChannelCursor(channelID string, remoteID string, cursor model.GetPostsSinceForSyncCursor) error
+	UpdateSharedChannelRemoteCursor(id string, cursor model.GetPostsSinceForSyncCursor) error
+	UpdateSidebarCategories(rctx request.CTX, userID string, teamID string, categories []*model.SidebarCategoryWithChannels) ([]*model.SidebarCategoryWithChannels, *model.AppError)
+	UpdateSidebarCategoryOrder(rctx request.CTX, userID string, teamID string, categoryOrder []string) *model.AppError
+	UpdateTeam(team *model.Team) (*model.Team, *model.AppError)
+	UpdateTeamMemberRoles(rctx request.CTX, teamID string, userID string, newRoles string) (*model.TeamMember, *model.AppError)
+	UpdateTeamMemberSchemeRoles(rctx request.CTX, teamID string, userID string, isSchemeGuest bool, isSchemeUser bool, isSchemeAdmin bool) (*model.TeamMember, *model.AppError)
+	UpdateTeamPrivacy(teamID string, teamType string, allowOpenInvite bool) *model.AppError
+	UpdateTeamScheme(team *model.Team) (*model.Team, *model.AppError)
+	UpdateThreadFollowForUser(userID string, teamID string, threadID string, state bool) *model.AppError
+	UpdateThreadFollowForUserFromChannelAdd(rctx request.CTX, userID string, teamID string, threadID string) *model.AppError
+	UpdateThreadReadForUser(rctx request.CTX, currentSessionId string, userID string, teamID string, threadID string, timestamp int64) (*model.ThreadResponse, *model.AppError)
+	UpdateThreadReadForUserByPost(rctx request.CTX, currentSessionId string, userID string, teamID string, threadID string, postID string) (*model.ThreadResponse, *model.AppError)
+	UpdateThreadsReadForUser(userID string, teamID string) *model.AppError
+	UpdateUser(rctx request.CTX, user *model.User, sendNotifications bool) (*model.User, *model.AppError)
+	UpdateUserActive(rctx request.CTX, userID string, active bool) *model.AppError
+	UpdateUserAsUser(rctx request.CTX, user *model.User, asAdmin bool) (*model.User, *model.AppError)
+	UpdateUserAuth(rctx request.CTX, userID string, userAuth *model.UserAuth) (*model.UserAuth, *model.AppError)
+	UpdateUserRoles(rctx request.CTX, userID string, newRoles string, sendWebSocketEvent bool) (*model.User, *model.AppError)
+	UpdateUserRolesWithUser(rctx request.CTX, user *model.User, newRoles string, sendWebSocketEvent bool) (*model.User, *model.AppError)
+	// UpdateViewedProductNotices is called from the frontend to mark a set of notices as 'viewed' by user
+	UpdateViewedProductNotices(userID string, noticeIds []string) *model.AppError
+	// UpdateViewedProductNoticesForNewUser is called when new user is created to mark all current notices for this
+	// user as viewed in order to avoid showing them imminently on first login
+	UpdateViewedProductNoticesForNewUser(userID string)
+	// UpdateWebConnUserActivity sets the LastUserActivityAt of the hub for the given session.
+	UpdateWebConnUserActivity(session model.Session, activityAt int64)
+	// UpdateWorkingHoursStatuses is a recurring task which switches the status of the users whose quiet
+	// hours started or ended since it last ran.
+	UpdateWorkingHoursStatuses()
+	UploadData(rctx request.CTX, us *model.UploadSession, rd io.Reader) (*model.FileInfo, *model.AppError)
+	// UploadFile uploads a single file in form of a completely constructed byte array for a channel.
+	UploadFile(rctx request.CTX, data []byte, channelID string, filename string) (*model.FileInfo, *model.AppError)
+	UploadFileForUserAndTeam(rctx request.CTX, data []byte, channelID string, filename string, rawUserId string, rawTeamId string) (*model.FileInfo, *model.AppError)
+	// UploadFileX uploads a single file as specified in t. It applies the upload
+	// constraints, executes plugins and image processing logic as needed. It
+	// returns a filled-out FileInfo and an optional error. A plugin may reject the
+	// upload, returning a rejection error. In this case FileInfo would have
+	// contained the last "good" FileInfo before the execution of that plugin.
+	UploadFileX(rctx request.CTX, channelID string, name string, input io.Reader, opts ...func(*UploadFileTask)) (*model.FileInfo, *model.AppError)
+	UpsertDraft(rctx request.CTX, draft *model.Draft, connectionID string) (*model.Draft, *model.AppError)
+	UpsertGroupMember(groupID string, userID string) (*model.GroupMember, *model.AppError)
+	UpsertGroupMembers(groupID string, userIDs []string) ([]*model.GroupMember, *model.AppError)
+	UpsertGroupSyncable(groupSyncable *model.GroupSyncable) (*model.GroupSyncable, *model.AppError)
+	UserAlreadyNotifiedOnRequiredFeature(user string, feature model.MattermostFeature) bool
+	UserCanSeeOtherUser(rctx request.CTX, userID string, otherUserId string) (bool, *model.AppError)
+	UserIsFirstAdmin(rctx request.CTX, user *model.User) bool
+	// UserIsInAdminRoleGroup returns true at least one of the user's groups are configured to set the members as
+	// admins in the given syncable.
+	UserIsInAdminRoleGroup(userID string, syncableID string, syncableType model.GroupSyncableType) (bool, *model.AppError)
+	// ValidateAccessControlPolicyPermission validates if a user has permission to manage a specific existing access control policy
+	ValidateAccessControlPolicyPermission(rctx request.CTX, userID string, policyID string) *model.AppError
+	// ValidateAccessControlPolicyPermissionWithChannelContext validates access control policy permissions with channel context
+	ValidateAccessControlPolicyPermissionWithChannelContext(rctx request.CTX, userID string, policyID string, isReadOnly bool, channelID string) *model.AppError
+	// ValidateAccessControlPolicyPermissionWithMode validates access control policy permissions with read-only mode option
+	ValidateAccessControlPolicyPermissionWithMode(rctx request.CTX, userID string, policyID string, isReadOnly bool) *model.AppError
+	ValidateAccessControlPolicyPermissionWithOptions(rctx request.CTX, userID string, policyID string, opts ValidateAccessControlPolicyPermissionOptions) *model.AppError
+	// ValidateActionItem validates an action item
+	ValidateActionItem(item *model.AIActionItem) error
+	// ValidateChannelAccessControlPermission validates if a user has permission to manage access control for a specific channel
+	ValidateChannelAccessControlPermission(rctx request.CTX, userID string, channelID string) *model.AppError
+	// ValidateChannelAccessControlPolicyCreation validates if a user can create a channel-specific access control policy
+	ValidateChannelAccessControlPolicyCreation(rctx request.CTX, userID string, policy *model.AccessControlPolicy) *model.AppError
+	ValidateDesktopToken(token string, expiryTime int64) (*model.User, *model.AppError)
+	// ValidateExpressionAgainstRequester validates an expression directly against a specific user
+	ValidateExpressionAgainstRequester(rctx request.CTX, expression string, requesterID string) (bool, *model.AppError)
+	// validateMoveOrCopy performs validation on a provided post list to determine
+	// if all permissions are in place to allow the for the posts to be moved or
+	// copied.
+	ValidateMoveOrCopy(rctx request.CTX, wpl *model.WranglerPostList, originalChannel *model.Channel, targetChannel *model.Channel, user *model.User) error
+	// ValidateUserPermissionsOnChannels filters channelIds based on whether userId is authorized to manage channel members. Unauthorized channels are removed from the returned list.
+	ValidateUserPermissionsOnChannels(rctx request.CTX, userId string, channelIds []string) []string
+	// VerifyAuditLog checks the whole database audit log for missing, reordered or modified entries.
+	VerifyAuditLog() (*model.AuditLogVerification, *model.AppError)
+	VerifyEmailFromToken(rctx request.CTX, userSuppliedTokenString string) *model.AppError
+	VerifyUserEmail(userID string, email string) *model.AppError
+	ViewChannel(rctx request.CTX, view *model.ChannelView, userID string, currentSessionId string, collapsedThreadsSupported bool) (map[string]int64, *model.AppError)
+	// WebPushVAPIDKey will return the private key the web push messages are signed with.
+	WebPushVAPIDKey() *ecdsa.PrivateKey
+	WriteExportFile(fr io.Reader, path string) (int64, *model.AppError)
+	WriteExportFileContext(ctxParam context.Context, fr io.Reader, path string) (int64, *model.AppError)
+	WriteFile(fr io.Reader, path string) (int64, *model.AppError)
+	WriteFileContext(ctxParam context.Context, fr io.Reader, path string) (int64, *model.AppError)
+	// WriteZipFile writes a zip file to the provided writer from the provided file data.
+	WriteZipFile(w io.Writer, fileDatas []model.FileData) error
+	// ZipReader returns a ReadCloser for path. If deflate is true, the zip will use compression.
+	//
+	// The caller is responsible for closing the returned ReadCloser.
+	ZipReader(path string, deflate bool) (io.ReadCloser, *model.AppError)
+}
+
+var _ AppIface = (*App)(nil)
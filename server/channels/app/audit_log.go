@@ -0,0 +1,360 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/v8/channels/audit"
+	"github.com/mattermost/mattermost/server/v8/config"
+)
+
+const (
+	// auditLogVerifyBatchSize is the number of entries read at once when verifying the audit log.
+	auditLogVerifyBatchSize = 1000
+
+	// auditLogAnchorPath is where the head of the audit log is anchored, in the file store so
+	// that it's out of reach of whoever can modify the database.
+	auditLogAnchorPath = "audit/audit_log_anchor.json"
+	// auditLogAnchorInterval is how often the head of the audit log is anchored.
+	auditLogAnchorInterval = time.Minute
+
+	// auditLogRetryDelay is the delay before the first retry to save a record, doubled on
+	// every retry up to auditLogMaxRetryDelay.
+	auditLogRetryDelay    = time.Second
+	auditLogMaxRetryDelay = 30 * time.Second
+	// auditLogShutdownRetries is the number of attempts to save a record once the sink is
+	// shutting down, after which the record is written to the server log instead.
+	auditLogShutdownRetries = 3
+)
+
+// auditLogSink keeps the audit records in the database, hash-chained to one another, when
+// ExperimentalAuditSettings.DatabaseEnabled is set. Records are written in the background,
+// in the order they were logged.
+type auditLogSink struct {
+	srv     *Server
+	entries chan *model.AuditLogEntry
+	stop    chan struct{}
+	done    chan struct{}
+
+	retryDelay time.Duration
+
+	// head is the last entry saved by the sink and anchored the sequence number of the last
+	// entry anchored. They are only used by the run goroutine.
+	head     *model.AuditLogEntry
+	anchored int64
+}
+
+func newAuditLogSink(srv *Server) *auditLogSink {
+	sink := &auditLogSink{
+		srv:     srv,
+		entries: make(chan *model.AuditLogEntry, audit.DefMaxQueueSize),
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+
+		retryDelay: auditLogRetryDelay,
+	}
+
+	sink.checkHashKey()
+
+	go sink.run()
+
+	return sink
+}
+
+// checkHashKey warns when the hash key is stored in the database along with the log it
+// protects.
+func (s *auditLogSink) checkHashKey() {
+	configStore := s.srv.platform.GetConfigStore()
+	if configStore == nil || !config.IsDatabaseDSN(configStore.String()) {
+		return
+	}
+
+	key := *configStore.RemoveEnvironmentOverrides(configStore.Get()).ExperimentalAuditSettings.DatabaseHashKey
+	if key != "" && !config.IsSecretReference(key) {
+		s.srv.Log().Warn("ExperimentalAuditSettings.DatabaseHashKey is stored in the database. Set it through the environment or a secret reference, so that the audit log can't be rewritten by whoever can modify the database.")
+	}
+}
+
+func (s *auditLogSink) LogRecord(_ mlog.Level, rec model.AuditRecord) {
+	if !*s.srv.Config().ExperimentalAuditSettings.DatabaseEnabled {
+		return
+	}
+
+	// The record is serialized right away, as its caller may modify it afterwards.
+	entry, err := model.NewAuditLogEntry(rec)
+	if err != nil {
+		s.srv.Log().Error("Failed to serialize audit record", mlog.String("event_name", rec.EventName), mlog.Err(err))
+		return
+	}
+
+	if s.stopping() {
+		s.srv.Log().Error("Audit log is shut down, writing the record to the server log instead.", mlog.String("event_name", entry.EventName), mlog.String("record", entry.Record))
+		return
+	}
+
+	// The request logging the record mustn't wait for the database, so when the queue is full,
+	// e.g. while the database is unavailable, the record is written to the server log instead
+	// of being dropped.
+	select {
+	case s.entries <- entry:
+	default:
+		s.srv.Log().Error("Audit log queue is full, writing the record to the server log instead.", mlog.String("event_name", entry.EventName), mlog.String("record", entry.Record), mlog.Int("queue_size", cap(s.entries)))
+	}
+}
+
+func (s *auditLogSink) run() {
+	defer close(s.done)
+
+	ticker := time.NewTicker(auditLogAnchorInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case entry := <-s.entries:
+			s.write(entry)
+		case <-ticker.C:
+			s.anchor()
+		case <-s.stop:
+			for {
+				select {
+				case entry := <-s.entries:
+					s.write(entry)
+				default:
+					s.anchor()
+					return
+				}
+			}
+		}
+	}
+}
+
+func (s *auditLogSink) stopping() bool {
+	select {
+	case <-s.stop:
+		return true
+	default:
+		return false
+	}
+}
+
+// write saves the entry, retrying until it's saved, as the database log is expected to be
+// complete. Later records wait in the queue meanwhile. Records that can't be saved, or not
+// before shutdown, are written to the server log instead.
+func (s *auditLogSink) write(entry *model.AuditLogEntry) {
+	delay := s.retryDelay
+	for attempt := 1; ; attempt++ {
+		key := s.srv.auditLogHashKey()
+		if len(key) == 0 {
+			s.srv.Log().Error("Audit log hash key isn't set, writing the record to the server log instead.", mlog.String("event_name", entry.EventName), mlog.String("record", entry.Record))
+			return
+		}
+
+		saved, err := s.srv.Store().AuditLog().Append(entry, key)
+		if err == nil {
+			if attempt > 1 {
+				s.srv.Log().Info("Saved audit record after retrying", mlog.String("event_name", entry.EventName), mlog.Int("attempts", attempt))
+			}
+			s.head = saved
+			return
+		}
+
+		// Invalid entries are never going to be saved.
+		var appErr *model.AppError
+		if errors.As(err, &appErr) || (s.stopping() && attempt >= auditLogShutdownRetries) {
+			s.srv.Log().Error("Failed to save audit record, writing it to the server log instead", mlog.String("event_name", entry.EventName), mlog.String("record", entry.Record), mlog.Err(err))
+			return
+		}
+
+		s.srv.Log().Error("Failed to save audit record, retrying", mlog.String("event_name", entry.EventName), mlog.Int("attempt", attempt), mlog.Duration("retry_in", delay), mlog.Err(err))
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-s.stop:
+			// Shutting down, the remaining attempts are made right away.
+			timer.Stop()
+		}
+		delay = min(delay*2, auditLogMaxRetryDelay)
+	}
+}
+
+// anchor records the head of the log in the file store and in the server log, so that
+// entries removed from the end of the log are detected by VerifyAuditLog.
+func (s *auditLogSink) anchor() {
+	if s.head == nil || s.head.Sequence == s.anchored {
+		return
+	}
+
+	if err := s.srv.saveAuditLogAnchor(s.head); err != nil {
+		s.srv.Log().Error("Failed to anchor the audit log", mlog.Int("sequence", s.head.Sequence), mlog.Err(err))
+		return
+	}
+
+	s.srv.Log().Info("Anchored the audit log", mlog.Int("sequence", s.head.Sequence), mlog.String("hash", s.head.Hash))
+	s.anchored = s.head.Sequence
+}
+
+// shutdown writes the queued records and stops the sink.
+func (s *auditLogSink) shutdown() {
+	close(s.stop)
+	<-s.done
+}
+
+func (s *Server) auditLogHashKey() []byte {
+	return []byte(*s.Config().ExperimentalAuditSettings.DatabaseHashKey)
+}
+
+// getAuditLogAnchor returns the anchor of the audit log, or nil if the log was never anchored.
+func (s *Server) getAuditLogAnchor() (*model.AuditLogAnchor, error) {
+	exists, err := s.FileBackend().FileExists(auditLogAnchorPath)
+	if err != nil || !exists {
+		return nil, err
+	}
+
+	data, err := s.FileBackend().ReadFile(auditLogAnchorPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var anchor model.AuditLogAnchor
+	if err := json.Unmarshal(data, &anchor); err != nil {
+		return nil, err
+	}
+
+	return &anchor, nil
+}
+
+// saveAuditLogAnchor anchors the audit log at head, unless it's anchored at a later entry
+// already, e.g. by another node of the cluster.
+func (s *Server) saveAuditLogAnchor(head *model.AuditLogEntry) error {
+	key := s.auditLogHashKey()
+
+	current, err := s.getAuditLogAnchor()
+	if err != nil {
+		return err
+	}
+	if current != nil && current.IsValid(key) && current.Sequence >= head.Sequence {
+		return nil
+	}
+
+	anchor := &model.AuditLogAnchor{
+		Sequence: head.Sequence,
+		Hash:     head.Hash,
+		CreateAt: model.GetMillis(),
+	}
+	anchor.Mac = anchor.ComputeMac(key)
+
+	data, err := json.Marshal(anchor)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.FileBackend().WriteFile(bytes.NewReader(data), auditLogAnchorPath)
+	return err
+}
+
+// SearchAuditLog returns the entries of the database audit log matching the search, most recent first.
+func (a *App) SearchAuditLog(search model.AuditLogSearch) ([]*model.AuditLogEntry, *model.AppError) {
+	if search.Page < 0 {
+		search.Page = 0
+	}
+	if search.PerPage <= 0 {
+		search.PerPage = model.AuditLogSearchDefaultPerPage
+	} else if search.PerPage > model.AuditLogSearchMaxPerPage {
+		search.PerPage = model.AuditLogSearchMaxPerPage
+	}
+
+	if search.StartTime > 0 && search.EndTime > 0 && search.StartTime > search.EndTime {
+		return nil, model.NewAppError("SearchAuditLog", "app.audit_log.search.invalid_time_range.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	entries, err := a.Srv().Store().AuditLog().Search(search)
+	if err != nil {
+		return nil, model.NewAppError("SearchAuditLog", "app.audit_log.search.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return entries, nil
+}
+
+// VerifyAuditLog checks the whole database audit log for missing, reordered or modified entries.
+func (a *App) VerifyAuditLog() (*model.AuditLogVerification, *model.AppError) {
+	key := a.Srv().auditLogHashKey()
+	if len(key) == 0 {
+		return nil, model.NewAppError("VerifyAuditLog", "app.audit_log.verify.hash_key_missing.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	verification := &model.AuditLogVerification{
+		Issues: []*model.AuditLogIssue{},
+	}
+
+	var lastSequence int64
+	var prevHash string
+	for {
+		entries, err := a.Srv().Store().AuditLog().GetRange(lastSequence, auditLogVerifyBatchSize)
+		if err != nil {
+			return nil, model.NewAppError("VerifyAuditLog", "app.audit_log.verify.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+
+		for _, entry := range entries {
+			if entry.Sequence != lastSequence+1 {
+				// The entry following a gap is chained to a missing one, so only the gap is reported.
+				verification.Issues = append(verification.Issues, &model.AuditLogIssue{
+					Sequence: lastSequence + 1,
+					Type:     model.AuditLogIssueGap,
+					Count:    entry.Sequence - lastSequence - 1,
+				})
+			} else if entry.PrevHash != prevHash {
+				verification.Issues = append(verification.Issues, &model.AuditLogIssue{
+					Sequence: entry.Sequence,
+					Type:     model.AuditLogIssueBrokenChain,
+				})
+			}
+
+			if entry.ComputeHash(key) != entry.Hash {
+				verification.Issues = append(verification.Issues, &model.AuditLogIssue{
+					Sequence: entry.Sequence,
+					Type:     model.AuditLogIssueModified,
+				})
+			}
+
+			lastSequence = entry.Sequence
+			prevHash = entry.Hash
+			verification.Checked++
+		}
+
+		if len(entries) < auditLogVerifyBatchSize {
+			break
+		}
+	}
+
+	anchor, err := a.Srv().getAuditLogAnchor()
+	if err != nil {
+		return nil, model.NewAppError("VerifyAuditLog", "app.audit_log.verify.anchor.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	if anchor != nil {
+		if !anchor.IsValid(key) {
+			verification.Issues = append(verification.Issues, &model.AuditLogIssue{
+				Sequence: anchor.Sequence,
+				Type:     model.AuditLogIssueInvalidAnchor,
+			})
+		} else if lastSequence < anchor.Sequence {
+			verification.Issues = append(verification.Issues, &model.AuditLogIssue{
+				Sequence: lastSequence + 1,
+				Type:     model.AuditLogIssueTruncated,
+				Count:    anchor.Sequence - lastSequence,
+			})
+		}
+	}
+
+	verification.Verified = len(verification.Issues) == 0
+
+	return verification, nil
+}
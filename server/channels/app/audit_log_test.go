@@ -0,0 +1,245 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/v8/channels/store/storetest/mocks"
+)
+
+func TestAuditLog(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t)
+
+	_, err := th.SQLStore.GetMaster().Exec("DELETE FROM AuditLogs")
+	require.NoError(t, err)
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.ExperimentalAuditSettings.DatabaseHashKey = model.NewRandomString(model.AuditLogHashKeyMinLength)
+	})
+
+	actorID := model.NewId()
+	sink := newAuditLogSink(th.Server)
+	logRecord := func(eventName, status string) {
+		rec := model.AuditRecord{EventName: eventName, Status: status}
+		rec.Actor.UserId = actorID
+		sink.LogRecord(mlog.LvlAuditAPI, rec)
+	}
+
+	t.Run("records are ignored unless enabled", func(t *testing.T) {
+		logRecord(model.AuditEventUpdateConfig, model.AuditStatusSuccess)
+
+		verification, appErr := th.App.VerifyAuditLog()
+		require.Nil(t, appErr)
+		assert.Zero(t, verification.Checked)
+	})
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.ExperimentalAuditSettings.DatabaseEnabled = true
+	})
+	for range 2 {
+		logRecord(model.AuditEventUpdateConfig, model.AuditStatusSuccess)
+	}
+	for range 3 {
+		logRecord(model.AuditEventPatchUser, model.AuditStatusFail)
+	}
+	sink.shutdown()
+
+	t.Run("search", func(t *testing.T) {
+		entries, appErr := th.App.SearchAuditLog(model.AuditLogSearch{ActorId: actorID})
+		require.Nil(t, appErr)
+		require.Len(t, entries, 5)
+		assert.Equal(t, int64(5), entries[0].Sequence)
+
+		entries, appErr = th.App.SearchAuditLog(model.AuditLogSearch{EventName: model.AuditEventUpdateConfig, PerPage: 1})
+		require.Nil(t, appErr)
+		require.Len(t, entries, 1)
+		assert.Equal(t, int64(2), entries[0].Sequence)
+
+		_, appErr = th.App.SearchAuditLog(model.AuditLogSearch{StartTime: 2, EndTime: 1})
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.audit_log.search.invalid_time_range.app_error", appErr.Id)
+	})
+
+	t.Run("verify intact log", func(t *testing.T) {
+		verification, appErr := th.App.VerifyAuditLog()
+		require.Nil(t, appErr)
+		assert.True(t, verification.Verified)
+		assert.Equal(t, int64(5), verification.Checked)
+		assert.Empty(t, verification.Issues)
+	})
+
+	t.Run("verify tampered log", func(t *testing.T) {
+		_, err := th.SQLStore.GetMaster().Exec("UPDATE AuditLogs SET Status = 'success' WHERE Sequence = 4")
+		require.NoError(t, err)
+		_, err = th.SQLStore.GetMaster().Exec("DELETE FROM AuditLogs WHERE Sequence = 2")
+		require.NoError(t, err)
+
+		verification, appErr := th.App.VerifyAuditLog()
+		require.Nil(t, appErr)
+		assert.False(t, verification.Verified)
+		assert.Equal(t, int64(4), verification.Checked)
+		assert.Equal(t, []*model.AuditLogIssue{
+			{Sequence: 2, Type: model.AuditLogIssueGap, Count: 1},
+			{Sequence: 4, Type: model.AuditLogIssueModified},
+		}, verification.Issues)
+	})
+
+	t.Run("verify truncated log", func(t *testing.T) {
+		_, err := th.SQLStore.GetMaster().Exec("DELETE FROM AuditLogs WHERE Sequence = 5")
+		require.NoError(t, err)
+
+		verification, appErr := th.App.VerifyAuditLog()
+		require.Nil(t, appErr)
+		assert.False(t, verification.Verified)
+		assert.Contains(t, verification.Issues, &model.AuditLogIssue{Sequence: 5, Type: model.AuditLogIssueTruncated, Count: 1})
+	})
+
+	t.Run("verify with another key", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) {
+			*cfg.ExperimentalAuditSettings.DatabaseHashKey = model.NewRandomString(model.AuditLogHashKeyMinLength)
+		})
+
+		verification, appErr := th.App.VerifyAuditLog()
+		require.Nil(t, appErr)
+		assert.False(t, verification.Verified)
+		assert.Contains(t, verification.Issues, &model.AuditLogIssue{Sequence: 1, Type: model.AuditLogIssueModified})
+		assert.Contains(t, verification.Issues, &model.AuditLogIssue{Sequence: 5, Type: model.AuditLogIssueInvalidAnchor})
+	})
+
+	t.Run("verify without a key", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) {
+			*cfg.ExperimentalAuditSettings.DatabaseEnabled = false
+			*cfg.ExperimentalAuditSettings.DatabaseHashKey = ""
+		})
+
+		_, appErr := th.App.VerifyAuditLog()
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.audit_log.verify.hash_key_missing.app_error", appErr.Id)
+	})
+}
+
+func TestAuditLogSinkRetries(t *testing.T) {
+	mainHelper.Parallel(t)
+
+	saved := func(entry *model.AuditLogEntry, _ []byte) (*model.AuditLogEntry, error) {
+		entry.Sequence = 1
+		return entry, nil
+	}
+
+	setup := func(t *testing.T) (*auditLogSink, *mocks.AuditLogStore) {
+		th := SetupWithStoreMock(t)
+
+		// Updating the config computes the client config.
+		mockStore := th.App.Srv().Store().(*mocks.Store)
+		mockUserStore := mocks.UserStore{}
+		mockUserStore.On("Count", mock.Anything).Return(int64(10), nil)
+		mockPostStore := mocks.PostStore{}
+		mockPostStore.On("GetMaxPostSize").Return(65535, nil)
+		mockSystemStore := mocks.SystemStore{}
+		mockSystemStore.On("GetByName", "UpgradedFromTE").Return(&model.System{Name: "UpgradedFromTE", Value: "false"}, nil)
+		mockSystemStore.On("GetByName", "InstallationDate").Return(&model.System{Name: "InstallationDate", Value: "10"}, nil)
+		mockStore.On("User").Return(&mockUserStore)
+		mockStore.On("Post").Return(&mockPostStore)
+		mockStore.On("System").Return(&mockSystemStore)
+		mockStore.On("GetDBSchemaVersion").Return(1, nil)
+
+		th.App.UpdateConfig(func(cfg *model.Config) {
+			*cfg.ExperimentalAuditSettings.DatabaseEnabled = true
+			*cfg.ExperimentalAuditSettings.DatabaseHashKey = model.NewRandomString(model.AuditLogHashKeyMinLength)
+		})
+
+		auditLogStore := &mocks.AuditLogStore{}
+		mockStore.On("AuditLog").Return(auditLogStore)
+
+		sink := newAuditLogSink(th.Server)
+		sink.retryDelay = time.Millisecond
+		return sink, auditLogStore
+	}
+
+	t.Run("failed records are retried", func(t *testing.T) {
+		sink, auditLogStore := setup(t)
+		auditLogStore.On("Append", mock.Anything, mock.Anything).Return(nil, errors.New("connection refused")).Twice()
+		auditLogStore.On("Append", mock.Anything, mock.Anything).Return(saved).Once()
+
+		sink.LogRecord(mlog.LvlAuditAPI, model.AuditRecord{EventName: model.AuditEventUpdateConfig})
+		sink.shutdown()
+
+		auditLogStore.AssertNumberOfCalls(t, "Append", 3)
+		require.NotNil(t, sink.head)
+		assert.Equal(t, model.AuditEventUpdateConfig, sink.head.EventName)
+	})
+
+	t.Run("invalid records are not retried", func(t *testing.T) {
+		sink, auditLogStore := setup(t)
+		auditLogStore.On("Append", mock.Anything, mock.Anything).Return(nil, model.NewAppError("Append", "invalid", nil, "", http.StatusBadRequest))
+
+		sink.LogRecord(mlog.LvlAuditAPI, model.AuditRecord{EventName: model.AuditEventUpdateConfig})
+		sink.shutdown()
+
+		auditLogStore.AssertNumberOfCalls(t, "Append", 1)
+		assert.Nil(t, sink.head)
+	})
+
+	t.Run("a full queue doesn't block the caller", func(t *testing.T) {
+		sink, auditLogStore := setup(t)
+		writing := make(chan struct{}, 1)
+		release := make(chan struct{})
+		auditLogStore.On("Append", mock.Anything, mock.Anything).Run(func(mock.Arguments) {
+			select {
+			case writing <- struct{}{}:
+			default:
+			}
+			<-release
+		}).Return(saved)
+
+		sink.LogRecord(mlog.LvlAuditAPI, model.AuditRecord{EventName: model.AuditEventUpdateConfig})
+		select {
+		case <-writing:
+		case <-time.After(5 * time.Second):
+			require.FailNow(t, "the record wasn't written")
+		}
+
+		logged := make(chan struct{})
+		go func() {
+			defer close(logged)
+			for range cap(sink.entries) + 1 {
+				sink.LogRecord(mlog.LvlAuditAPI, model.AuditRecord{EventName: model.AuditEventPatchUser})
+			}
+		}()
+		select {
+		case <-logged:
+		case <-time.After(5 * time.Second):
+			require.FailNow(t, "logging a record blocked on the full queue")
+		}
+
+		close(release)
+		sink.shutdown()
+
+		// The record which didn't fit in the queue went to the server log.
+		auditLogStore.AssertNumberOfCalls(t, "Append", cap(sink.entries)+1)
+	})
+
+	t.Run("shutdown gives up on failed records", func(t *testing.T) {
+		sink, auditLogStore := setup(t)
+		sink.retryDelay = time.Hour
+		auditLogStore.On("Append", mock.Anything, mock.Anything).Return(nil, errors.New("connection refused"))
+
+		sink.LogRecord(mlog.LvlAuditAPI, model.AuditRecord{EventName: model.AuditEventUpdateConfig})
+		sink.shutdown()
+
+		auditLogStore.AssertNumberOfCalls(t, "Append", auditLogShutdownRetries)
+		assert.Nil(t, sink.head)
+	})
+}
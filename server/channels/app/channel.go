@@ -231,6 +231,32 @@ func (a *App) CreateChannel(rctx request.CTX, channel *model.Channel, addMember
 	a.handleChannelCategoryName(channel)
 
 	channel.DisplayName = strings.TrimSpace(channel.DisplayName)
+
+	var rejectionError *model.AppError
+	pluginContext := pluginContext(rctx)
+	a.ch.RunMultiHook(func(hooks plugin.Hooks, manifest *model.Manifest) bool {
+		replacementChannel, rejectionReason := hooks.ChannelWillBeCreated(pluginContext, channel)
+		if rejectionReason != "" {
+			rctx.Logger().Info("Channel creation rejected by plugin.",
+				mlog.String("rejection_reason", rejectionReason),
+				mlog.String("plugin_id", manifest.Id))
+			rejectionError = model.NewAppError("CreateChannel", "app.channel.create_channel.rejected_by_plugin.app_error",
+				map[string]any{"Reason": rejectionReason}, "", http.StatusBadRequest)
+			return false
+		}
+		if replacementChannel != nil {
+			channel.Name = replacementChannel.Name
+			channel.DisplayName = strings.TrimSpace(replacementChannel.DisplayName)
+			channel.Purpose = replacementChannel.Purpose
+			channel.Header = replacementChannel.Header
+		}
+		return true
+	}, plugin.ChannelWillBeCreatedID)
+
+	if rejectionError != nil {
+		return nil, rejectionError
+	}
+
 	sc, nErr := a.Srv().Store().Channel().Save(rctx, channel, *a.Config().TeamSettings.MaxChannelsPerTeam)
 	if nErr != nil {
 		var invErr *store.ErrInvalidInput
@@ -303,13 +329,17 @@ func (a *App) CreateChannel(rctx request.CTX, channel *model.Channel, addMember
 	}
 
 	a.Srv().Go(func() {
-		pluginContext := pluginContext(rctx)
 		a.ch.RunMultiHook(func(hooks plugin.Hooks, _ *model.Manifest) bool {
 			hooks.ChannelHasBeenCreated(pluginContext, sc)
 			return true
 		}, plugin.ChannelHasBeenCreatedID)
 	})
 
+	message := model.NewWebSocketEvent(model.WebsocketEventChannelCreated, "", "", "", nil, "")
+	message.Add("channel_id", sc.Id)
+	message.Add("team_id", sc.TeamId)
+	a.publishSubscribedEvent(model.EventSubscriptionEventChannelCreated, sc.TeamId, sc.Id, message)
+
 	return sc, nil
 }
 
@@ -747,6 +777,7 @@ func (a *App) UpdateChannel(rctx request.CTX, channel *model.Channel) (*model.Ch
 	}
 	messageWs.Add("channel", string(channelJSON))
 	a.Publish(messageWs)
+	a.publishSubscribedEvent(model.EventSubscriptionEventChannelUpdated, channel.TeamId, channel.Id, messageWs)
 
 	return channel, nil
 }
@@ -1558,6 +1589,25 @@ func (a *App) DeleteChannel(rctx request.CTX, channel *model.Channel, userID str
 		return err
 	}
 
+	var rejectionError *model.AppError
+	pluginContext := pluginContext(rctx)
+	a.ch.RunMultiHook(func(hooks plugin.Hooks, manifest *model.Manifest) bool {
+		if rejectionReason := hooks.ChannelWillBeArchived(pluginContext, channel, user); rejectionReason != "" {
+			rctx.Logger().Info("Channel archival rejected by plugin.",
+				mlog.String("channel_id", channel.Id),
+				mlog.String("rejection_reason", rejectionReason),
+				mlog.String("plugin_id", manifest.Id))
+			rejectionError = model.NewAppError("deleteChannel", "app.channel.delete_channel.rejected_by_plugin.app_error",
+				map[string]any{"Reason": rejectionReason}, "", http.StatusBadRequest)
+			return false
+		}
+		return true
+	}, plugin.ChannelWillBeArchivedID)
+
+	if rejectionError != nil {
+		return rejectionError
+	}
+
 	if user != nil {
 		T := i18n.GetUserTranslations(user.Locale)
 
@@ -1630,6 +1680,7 @@ func (a *App) DeleteChannel(rctx request.CTX, channel *model.Channel, userID str
 	message.Add("channel_id", channel.Id)
 	message.Add("delete_at", deleteAt)
 	a.Publish(message)
+	a.publishSubscribedEvent(model.EventSubscriptionEventChannelArchived, channel.TeamId, channel.Id, message)
 
 	return nil
 }
@@ -1710,6 +1761,31 @@ func (a *App) addUserToChannel(rctx request.CTX, user *model.User, channel *mode
 		}
 	}
 
+	var rejectionError *model.AppError
+	pluginContext := pluginContext(rctx)
+	a.ch.RunMultiHook(func(hooks plugin.Hooks, manifest *model.Manifest) bool {
+		replacementMember, rejectionReason := hooks.UserWillJoinChannel(pluginContext, newMember, channel)
+		if rejectionReason != "" {
+			rctx.Logger().Info("Channel membership rejected by plugin.",
+				mlog.String("user_id", user.Id),
+				mlog.String("channel_id", channel.Id),
+				mlog.String("rejection_reason", rejectionReason),
+				mlog.String("plugin_id", manifest.Id))
+			rejectionError = model.NewAppError("AddUserToChannel", "app.channel.add_user.rejected_by_plugin.app_error",
+				map[string]any{"Reason": rejectionReason}, "", http.StatusForbidden)
+			return false
+		}
+		if replacementMember != nil {
+			newMember.NotifyProps = replacementMember.NotifyProps
+			newMember.SchemeAdmin = replacementMember.SchemeAdmin && !newMember.SchemeGuest
+		}
+		return true
+	}, plugin.UserWillJoinChannelID)
+
+	if rejectionError != nil {
+		return nil, rejectionError
+	}
+
 	newMember, nErr = a.Srv().Store().Channel().SaveMember(rctx, newMember)
 	if nErr != nil {
 		return nil, model.NewAppError("AddUserToChannel", "api.channel.add_user.to.channel.failed.app_error", nil,
@@ -1765,6 +1841,7 @@ func (a *App) AddUserToChannel(rctx request.CTX, user *model.User, channel *mode
 	message.Add("user_id", user.Id)
 	message.Add("team_id", channel.TeamId)
 	a.Publish(message)
+	a.publishSubscribedEvent(model.EventSubscriptionEventUserAddedToChannel, channel.TeamId, channel.Id, message)
 
 	userMessage := model.NewWebSocketEvent(model.WebsocketEventUserAdded, "", channel.Id, user.Id, nil, "")
 	userMessage.Add("user_id", user.Id)
@@ -2758,6 +2835,7 @@ func (a *App) removeUserFromChannel(rctx request.CTX, userIDToRemove string, rem
 	message.Add("user_id", userIDToRemove)
 	message.Add("remover_id", removerUserId)
 	a.Publish(message)
+	a.publishSubscribedEvent(model.EventSubscriptionEventUserRemovedFromChannel, channel.TeamId, channel.Id, message)
 
 	// because the removed user no longer belongs to the channel we need to send a separate websocket event
 	userMsg := model.NewWebSocketEvent(model.WebsocketEventUserRemoved, "", "", userIDToRemove, nil, "")
@@ -18,6 +18,7 @@ import (
 	"github.com/mattermost/mattermost/server/public/plugin"
 	"github.com/mattermost/mattermost/server/public/shared/mlog"
 	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/app/dataretention"
 	"github.com/mattermost/mattermost/server/v8/channels/app/imaging"
 	"github.com/mattermost/mattermost/server/v8/config"
 	"github.com/mattermost/mattermost/server/v8/einterfaces"
@@ -73,15 +74,26 @@ type Channels struct {
 	uploadLockMapMut sync.Mutex
 	uploadLockMap    map[string]bool
 
+	// aiPluginUsage counts the AI prompts sent by each plugin in the current minute,
+	// to hold them to the AI rate limit.
+	aiPluginUsageMut sync.Mutex
+	aiPluginUsage    map[string]*aiPluginUsage
+
 	imgDecoder *imaging.Decoder
 	imgEncoder *imaging.Encoder
 
 	dndTaskMut sync.Mutex
 	dndTask    *model.ScheduledTask
 
+	workingHoursTaskMut sync.Mutex
+	workingHoursTask    *model.ScheduledTask
+
 	postReminderMut  sync.Mutex
 	postReminderTask *model.ScheduledTask
 
+	emailBatchingMut  sync.Mutex
+	emailBatchingTask *model.ScheduledTask
+
 	interruptQuitChan     chan struct{}
 	scheduledPostMut      sync.Mutex
 	scheduledPostTask     *model.ScheduledTask
@@ -112,6 +124,16 @@ func NewChannels(s *Server) (*Channels, error) {
 	}
 	if dataRetentionInterface != nil {
 		ch.DataRetention = dataRetentionInterface(New(ServerConnector(ch)))
+	} else {
+		dataRetention, err := dataretention.New(dataretention.ServiceConfig{
+			RetentionPolicyStore: s.Store().RetentionPolicy(),
+			ConfigFn:             s.platform.Config,
+			LicenseFn:            s.License,
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to create data retention service")
+		}
+		ch.DataRetention = dataRetention
 	}
 	if accountMigrationInterface != nil {
 		ch.AccountMigration = accountMigrationInterface(New(ServerConnector(ch)))
@@ -268,6 +290,10 @@ func (ch *Channels) Start() error {
 		return errors.Wrapf(err, "unable to ensure asymmetric signing key")
 	}
 
+	if err := ch.srv.platform.EnsureWebPushVAPIDKey(); err != nil {
+		return errors.Wrapf(err, "unable to ensure web push VAPID key")
+	}
+
 	if err := ch.ensurePostActionCookieSecret(); err != nil {
 		return errors.Wrapf(err, "unable to ensure PostAction cookie secret")
 	}
@@ -284,6 +310,14 @@ func (ch *Channels) Stop() error {
 	}
 	ch.dndTaskMut.Unlock()
 
+	ch.workingHoursTaskMut.Lock()
+	if ch.workingHoursTask != nil {
+		ch.workingHoursTask.Cancel()
+	}
+	ch.workingHoursTaskMut.Unlock()
+
+	cancelTask(&ch.emailBatchingMut, &ch.emailBatchingTask)
+
 	close(ch.interruptQuitChan)
 
 	return nil
@@ -7,6 +7,7 @@ import (
 	"crypto/ecdsa"
 	"crypto/rand"
 	"encoding/json"
+	"net/http"
 	"net/url"
 	"reflect"
 	"strconv"
@@ -180,6 +181,11 @@ func (a *App) AsymmetricSigningKey() *ecdsa.PrivateKey {
 	return a.ch.AsymmetricSigningKey()
 }
 
+// WebPushVAPIDKey will return the private key the web push messages are signed with.
+func (a *App) WebPushVAPIDKey() *ecdsa.PrivateKey {
+	return a.ch.srv.platform.WebPushVAPIDKey()
+}
+
 func (ch *Channels) PostActionCookieSecret() []byte {
 	return ch.postActionCookieSecret
 }
@@ -244,6 +250,55 @@ func (a *App) SaveConfig(newCfg *model.Config, sendConfigChangeClusterMessage bo
 	return a.Srv().platform.SaveConfig(newCfg, sendConfigChangeClusterMessage)
 }
 
+// SaveConfigWithAuthor is like SaveConfig, but records the given user as the author of the new version.
+func (a *App) SaveConfigWithAuthor(newCfg *model.Config, sendConfigChangeClusterMessage bool, authorID string) (*model.Config, *model.Config, *model.AppError) {
+	return a.Srv().platform.SaveConfigWithAuthor(newCfg, sendConfigChangeClusterMessage, authorID)
+}
+
+// GetConfigHistory lists the saved versions of the configuration, most recent first.
+func (a *App) GetConfigHistory() ([]*model.ConfigVersion, *model.AppError) {
+	return a.Srv().platform.ConfigHistory()
+}
+
+// GetConfigVersion retrieves a saved version of the configuration.
+func (a *App) GetConfigVersion(id string) (*model.Config, *model.AppError) {
+	return a.Srv().platform.ConfigVersion(id)
+}
+
+// DiffConfigVersions returns the redacted differences between two saved versions of the
+// configuration. An empty actualID compares against the active version.
+func (a *App) DiffConfigVersions(baseID, actualID string) ([]*model.ConfigVersionDiff, *model.AppError) {
+	if actualID == "" {
+		versions, appErr := a.GetConfigHistory()
+		if appErr != nil {
+			return nil, appErr
+		}
+		for _, version := range versions {
+			if version.Active {
+				actualID = version.Id
+				break
+			}
+		}
+		if actualID == "" {
+			return nil, model.NewAppError("DiffConfigVersions", "app.config.history.no_active_version.app_error", nil, "", http.StatusNotFound)
+		}
+	}
+
+	return a.Srv().platform.DiffConfigVersions(baseID, actualID)
+}
+
+// RollbackConfig validates a saved version of the configuration and makes it the active one,
+// recording authorID as the author of the restored version.
+func (a *App) RollbackConfig(cfg *model.Config, authorID string) (*model.Config, *model.Config, *model.AppError) {
+	a.HandleMessageExportConfig(cfg, a.Config())
+
+	if appErr := cfg.IsValid(); appErr != nil {
+		return nil, nil, appErr
+	}
+
+	return a.SaveConfigWithAuthor(cfg, true, authorID)
+}
+
 func (a *App) HandleMessageExportConfig(cfg *model.Config, appCfg *model.Config) {
 	// If the Message Export feature has been toggled in the System Console, rewrite the ExportFromTimestamp field to an
 	// appropriate value. The rewriting occurs here to ensure it doesn't affect values written to the config file
@@ -7,6 +7,7 @@ import (
 	"github.com/mattermost/mattermost/server/public/plugin"
 	"github.com/mattermost/mattermost/server/public/shared/request"
 	"github.com/mattermost/mattermost/server/v8/channels/store/sqlstore"
+	"github.com/mattermost/mattermost/server/v8/platform/services/tracing"
 )
 
 // RequestContextWithMaster adds the context value that master DB should be selected for this request.
@@ -21,6 +22,7 @@ func pluginContext(rctx request.CTX) *plugin.Context {
 		IPAddress:      rctx.IPAddress(),
 		AcceptLanguage: rctx.AcceptLanguage(),
 		UserAgent:      rctx.UserAgent(),
+		TraceContext:   tracing.Inject(rctx.Context()),
 	}
 	return context
 }
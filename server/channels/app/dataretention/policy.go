@@ -0,0 +1,309 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package dataretention
+
+import (
+	"net/http"
+	"time"
+	"unicode/utf8"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+const (
+	// PostDurationKeepForever is the post duration of the policies which
+	// never delete anything, overriding the global policy.
+	PostDurationKeepForever = -1
+
+	policyDisplayNameMaxRunes = 64
+)
+
+func (s *DataRetentionService) GetGlobalPolicy() (*model.GlobalRetentionPolicy, *model.AppError) {
+	if appErr := s.checkLicense("GetGlobalPolicy"); appErr != nil {
+		return nil, appErr
+	}
+
+	settings := s.config().DataRetentionSettings
+	policy := &model.GlobalRetentionPolicy{
+		MessageDeletionEnabled: *settings.EnableMessageDeletion,
+		FileDeletionEnabled:    *settings.EnableFileDeletion,
+	}
+	now := time.Now()
+	if policy.MessageDeletionEnabled {
+		policy.MessageRetentionCutoff = model.GetMillisForTime(now.Add(-time.Duration(settings.GetMessageRetentionHours()) * time.Hour))
+	}
+	if policy.FileDeletionEnabled {
+		policy.FileRetentionCutoff = model.GetMillisForTime(now.Add(-time.Duration(settings.GetFileRetentionHours()) * time.Hour))
+	}
+	return policy, nil
+}
+
+func (s *DataRetentionService) GetPolicies(offset, limit int) (*model.RetentionPolicyWithTeamAndChannelCountsList, *model.AppError) {
+	if appErr := s.checkLicense("GetPolicies"); appErr != nil {
+		return nil, appErr
+	}
+
+	policies, err := s.store.GetAll(offset, limit)
+	if err != nil {
+		return nil, storeError("GetPolicies", err)
+	}
+	count, err := s.store.GetCount()
+	if err != nil {
+		return nil, storeError("GetPolicies", err)
+	}
+	return &model.RetentionPolicyWithTeamAndChannelCountsList{
+		Policies:   policies,
+		TotalCount: count,
+	}, nil
+}
+
+func (s *DataRetentionService) GetPoliciesCount() (int64, *model.AppError) {
+	if appErr := s.checkLicense("GetPoliciesCount"); appErr != nil {
+		return 0, appErr
+	}
+
+	count, err := s.store.GetCount()
+	if err != nil {
+		return 0, storeError("GetPoliciesCount", err)
+	}
+	return count, nil
+}
+
+func (s *DataRetentionService) GetPolicy(policyID string) (*model.RetentionPolicyWithTeamAndChannelCounts, *model.AppError) {
+	if appErr := s.checkLicense("GetPolicy"); appErr != nil {
+		return nil, appErr
+	}
+
+	return s.getPolicy("GetPolicy", policyID)
+}
+
+func (s *DataRetentionService) getPolicy(where, policyID string) (*model.RetentionPolicyWithTeamAndChannelCounts, *model.AppError) {
+	if !model.IsValidId(policyID) {
+		return nil, model.NewAppError(where, "ent.data_retention.policies.not_found", nil, "", http.StatusNotFound)
+	}
+
+	policy, err := s.store.Get(policyID)
+	if err != nil {
+		return nil, storeError(where, err)
+	}
+	return policy, nil
+}
+
+func (s *DataRetentionService) CreatePolicy(policy *model.RetentionPolicyWithTeamAndChannelIDs) (*model.RetentionPolicyWithTeamAndChannelCounts, *model.AppError) {
+	if appErr := s.checkLicense("CreatePolicy"); appErr != nil {
+		return nil, appErr
+	}
+
+	if policy.DisplayName == "" || policy.PostDurationDays == nil {
+		return nil, model.NewAppError("CreatePolicy", "ent.data_retention.policies.invalid_policy", nil, "display name and post duration are required", http.StatusBadRequest)
+	}
+	if appErr := validatePolicy("CreatePolicy", policy); appErr != nil {
+		return nil, appErr
+	}
+
+	policy.ID = ""
+	saved, err := s.store.Save(policy)
+	if err != nil {
+		return nil, policyStoreError("CreatePolicy", err)
+	}
+	return saved, nil
+}
+
+func (s *DataRetentionService) PatchPolicy(patch *model.RetentionPolicyWithTeamAndChannelIDs) (*model.RetentionPolicyWithTeamAndChannelCounts, *model.AppError) {
+	if appErr := s.checkLicense("PatchPolicy"); appErr != nil {
+		return nil, appErr
+	}
+
+	if appErr := validatePolicy("PatchPolicy", patch); appErr != nil {
+		return nil, appErr
+	}
+	if _, appErr := s.getPolicy("PatchPolicy", patch.ID); appErr != nil {
+		return nil, appErr
+	}
+
+	patched, err := s.store.Patch(patch)
+	if err != nil {
+		return nil, policyStoreError("PatchPolicy", err)
+	}
+	return patched, nil
+}
+
+func (s *DataRetentionService) DeletePolicy(policyID string) *model.AppError {
+	if appErr := s.checkLicense("DeletePolicy"); appErr != nil {
+		return appErr
+	}
+
+	if _, appErr := s.getPolicy("DeletePolicy", policyID); appErr != nil {
+		return appErr
+	}
+	if err := s.store.Delete(policyID); err != nil {
+		return storeError("DeletePolicy", err)
+	}
+	return nil
+}
+
+func (s *DataRetentionService) GetTeamsForPolicy(policyID string, offset, limit int) (*model.TeamsWithCount, *model.AppError) {
+	if appErr := s.checkLicense("GetTeamsForPolicy"); appErr != nil {
+		return nil, appErr
+	}
+
+	if _, appErr := s.getPolicy("GetTeamsForPolicy", policyID); appErr != nil {
+		return nil, appErr
+	}
+	teams, err := s.store.GetTeams(policyID, offset, limit)
+	if err != nil {
+		return nil, storeError("GetTeamsForPolicy", err)
+	}
+	count, err := s.store.GetTeamsCount(policyID)
+	if err != nil {
+		return nil, storeError("GetTeamsForPolicy", err)
+	}
+	return &model.TeamsWithCount{Teams: teams, TotalCount: count}, nil
+}
+
+func (s *DataRetentionService) AddTeamsToPolicy(policyID string, teamIDs []string) *model.AppError {
+	if appErr := s.checkLicense("AddTeamsToPolicy"); appErr != nil {
+		return appErr
+	}
+
+	if appErr := validateIDs("AddTeamsToPolicy", teamIDs); appErr != nil {
+		return appErr
+	}
+	if _, appErr := s.getPolicy("AddTeamsToPolicy", policyID); appErr != nil {
+		return appErr
+	}
+	if err := s.store.AddTeams(policyID, teamIDs); err != nil {
+		return policyStoreError("AddTeamsToPolicy", err)
+	}
+	return nil
+}
+
+func (s *DataRetentionService) RemoveTeamsFromPolicy(policyID string, teamIDs []string) *model.AppError {
+	if appErr := s.checkLicense("RemoveTeamsFromPolicy"); appErr != nil {
+		return appErr
+	}
+
+	if _, appErr := s.getPolicy("RemoveTeamsFromPolicy", policyID); appErr != nil {
+		return appErr
+	}
+	if err := s.store.RemoveTeams(policyID, teamIDs); err != nil {
+		return storeError("RemoveTeamsFromPolicy", err)
+	}
+	return nil
+}
+
+func (s *DataRetentionService) GetChannelsForPolicy(policyID string, offset, limit int) (*model.ChannelsWithCount, *model.AppError) {
+	if appErr := s.checkLicense("GetChannelsForPolicy"); appErr != nil {
+		return nil, appErr
+	}
+
+	if _, appErr := s.getPolicy("GetChannelsForPolicy", policyID); appErr != nil {
+		return nil, appErr
+	}
+	channels, err := s.store.GetChannels(policyID, offset, limit)
+	if err != nil {
+		return nil, storeError("GetChannelsForPolicy", err)
+	}
+	count, err := s.store.GetChannelsCount(policyID)
+	if err != nil {
+		return nil, storeError("GetChannelsForPolicy", err)
+	}
+	return &model.ChannelsWithCount{Channels: channels, TotalCount: count}, nil
+}
+
+func (s *DataRetentionService) AddChannelsToPolicy(policyID string, channelIDs []string) *model.AppError {
+	if appErr := s.checkLicense("AddChannelsToPolicy"); appErr != nil {
+		return appErr
+	}
+
+	if appErr := validateIDs("AddChannelsToPolicy", channelIDs); appErr != nil {
+		return appErr
+	}
+	if _, appErr := s.getPolicy("AddChannelsToPolicy", policyID); appErr != nil {
+		return appErr
+	}
+	if err := s.store.AddChannels(policyID, channelIDs); err != nil {
+		return policyStoreError("AddChannelsToPolicy", err)
+	}
+	return nil
+}
+
+func (s *DataRetentionService) RemoveChannelsFromPolicy(policyID string, channelIDs []string) *model.AppError {
+	if appErr := s.checkLicense("RemoveChannelsFromPolicy"); appErr != nil {
+		return appErr
+	}
+
+	if _, appErr := s.getPolicy("RemoveChannelsFromPolicy", policyID); appErr != nil {
+		return appErr
+	}
+	if err := s.store.RemoveChannels(policyID, channelIDs); err != nil {
+		return storeError("RemoveChannelsFromPolicy", err)
+	}
+	return nil
+}
+
+func (s *DataRetentionService) GetTeamPoliciesForUser(userID string, offset, limit int) (*model.RetentionPolicyForTeamList, *model.AppError) {
+	if appErr := s.checkLicense("GetTeamPoliciesForUser"); appErr != nil {
+		return nil, appErr
+	}
+
+	policies, err := s.store.GetTeamPoliciesForUser(userID, offset, limit)
+	if err != nil {
+		return nil, storeError("GetTeamPoliciesForUser", err)
+	}
+	count, err := s.store.GetTeamPoliciesCountForUser(userID)
+	if err != nil {
+		return nil, storeError("GetTeamPoliciesForUser", err)
+	}
+	return &model.RetentionPolicyForTeamList{Policies: policies, TotalCount: count}, nil
+}
+
+func (s *DataRetentionService) GetChannelPoliciesForUser(userID string, offset, limit int) (*model.RetentionPolicyForChannelList, *model.AppError) {
+	if appErr := s.checkLicense("GetChannelPoliciesForUser"); appErr != nil {
+		return nil, appErr
+	}
+
+	policies, err := s.store.GetChannelPoliciesForUser(userID, offset, limit)
+	if err != nil {
+		return nil, storeError("GetChannelPoliciesForUser", err)
+	}
+	count, err := s.store.GetChannelPoliciesCountForUser(userID)
+	if err != nil {
+		return nil, storeError("GetChannelPoliciesForUser", err)
+	}
+	return &model.RetentionPolicyForChannelList{Policies: policies, TotalCount: count}, nil
+}
+
+// validatePolicy checks the fields set on a new policy or a patch.
+func validatePolicy(where string, policy *model.RetentionPolicyWithTeamAndChannelIDs) *model.AppError {
+	if utf8.RuneCountInString(policy.DisplayName) > policyDisplayNameMaxRunes {
+		return model.NewAppError(where, "ent.data_retention.policies.invalid_policy", nil, "display name is too long", http.StatusBadRequest)
+	}
+	if policy.PostDurationDays != nil && *policy.PostDurationDays != PostDurationKeepForever && *policy.PostDurationDays < 1 {
+		return model.NewAppError(where, "ent.data_retention.policies.invalid_policy", nil, "post duration must be positive, or -1 to keep messages forever", http.StatusBadRequest)
+	}
+	if appErr := validateIDs(where, policy.TeamIDs); appErr != nil {
+		return appErr
+	}
+	return validateIDs(where, policy.ChannelIDs)
+}
+
+func validateIDs(where string, ids []string) *model.AppError {
+	for _, id := range ids {
+		if !model.IsValidId(id) {
+			return model.NewAppError(where, "ent.data_retention.policies.invalid_policy", nil, "invalid id "+id, http.StatusBadRequest)
+		}
+	}
+	return nil
+}
+
+// policyStoreError converts an error returned when saving a policy. Teams and
+// channels that don't exist make the policy invalid.
+func policyStoreError(where string, err error) *model.AppError {
+	appErr := storeError(where, err)
+	if appErr.StatusCode == http.StatusNotFound {
+		return model.NewAppError(where, "ent.data_retention.policies.invalid_policy", nil, "", http.StatusBadRequest).Wrap(err)
+	}
+	return appErr
+}
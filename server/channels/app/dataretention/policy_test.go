@@ -0,0 +1,181 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package dataretention
+
+import (
+	"database/sql"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+	"github.com/mattermost/mattermost/server/v8/channels/store/storetest/mocks"
+)
+
+func setupService(t *testing.T, licensed bool) (*DataRetentionService, *mocks.RetentionPolicyStore, *model.Config) {
+	t.Helper()
+
+	policyStore := &mocks.RetentionPolicyStore{}
+	t.Cleanup(func() { policyStore.AssertExpectations(t) })
+
+	cfg := &model.Config{}
+	cfg.SetDefaults()
+
+	var license *model.License
+	if licensed {
+		license = model.NewTestLicense("data_retention")
+	}
+
+	service, err := New(ServiceConfig{
+		RetentionPolicyStore: policyStore,
+		ConfigFn:             func() *model.Config { return cfg },
+		LicenseFn:            func() *model.License { return license },
+	})
+	require.NoError(t, err)
+	return service, policyStore, cfg
+}
+
+func TestNew(t *testing.T) {
+	_, err := New(ServiceConfig{})
+	require.Error(t, err)
+}
+
+func TestLicense(t *testing.T) {
+	service, _, _ := setupService(t, false)
+
+	_, appErr := service.GetGlobalPolicy()
+	require.NotNil(t, appErr)
+	require.Equal(t, http.StatusNotImplemented, appErr.StatusCode)
+
+	_, appErr = service.GetPolicies(0, 10)
+	require.NotNil(t, appErr)
+	require.Equal(t, "ent.data_retention.generic.license.error", appErr.Id)
+}
+
+func TestGetGlobalPolicy(t *testing.T) {
+	service, _, cfg := setupService(t, true)
+
+	policy, appErr := service.GetGlobalPolicy()
+	require.Nil(t, appErr)
+	require.False(t, policy.MessageDeletionEnabled)
+	require.Zero(t, policy.MessageRetentionCutoff)
+	require.Zero(t, policy.FileRetentionCutoff)
+
+	*cfg.DataRetentionSettings.EnableMessageDeletion = true
+	*cfg.DataRetentionSettings.MessageRetentionDays = 0
+	*cfg.DataRetentionSettings.MessageRetentionHours = 2
+	policy, appErr = service.GetGlobalPolicy()
+	require.Nil(t, appErr)
+	require.True(t, policy.MessageDeletionEnabled)
+	require.InDelta(t, model.GetMillis()-2*60*60*1000, policy.MessageRetentionCutoff, 1000)
+	require.Zero(t, policy.FileRetentionCutoff)
+}
+
+func TestCreatePolicy(t *testing.T) {
+	t.Run("valid policy", func(t *testing.T) {
+		service, policyStore, _ := setupService(t, true)
+		policy := &model.RetentionPolicyWithTeamAndChannelIDs{
+			RetentionPolicy: model.RetentionPolicy{
+				DisplayName:      "Policy",
+				PostDurationDays: model.NewPointer(int64(30)),
+			},
+			ChannelIDs: []string{model.NewId()},
+		}
+		saved := &model.RetentionPolicyWithTeamAndChannelCounts{RetentionPolicy: policy.RetentionPolicy, ChannelCount: 1}
+		policyStore.On("Save", policy).Return(saved, nil)
+
+		result, appErr := service.CreatePolicy(policy)
+		require.Nil(t, appErr)
+		require.Equal(t, saved, result)
+	})
+
+	t.Run("keep forever", func(t *testing.T) {
+		service, policyStore, _ := setupService(t, true)
+		policy := &model.RetentionPolicyWithTeamAndChannelIDs{
+			RetentionPolicy: model.RetentionPolicy{
+				DisplayName:      "Policy",
+				PostDurationDays: model.NewPointer(int64(PostDurationKeepForever)),
+			},
+		}
+		policyStore.On("Save", policy).Return(&model.RetentionPolicyWithTeamAndChannelCounts{}, nil)
+
+		_, appErr := service.CreatePolicy(policy)
+		require.Nil(t, appErr)
+	})
+
+	for name, policy := range map[string]*model.RetentionPolicyWithTeamAndChannelIDs{
+		"missing display name": {
+			RetentionPolicy: model.RetentionPolicy{PostDurationDays: model.NewPointer(int64(30))},
+		},
+		"missing post duration": {
+			RetentionPolicy: model.RetentionPolicy{DisplayName: "Policy"},
+		},
+		"zero post duration": {
+			RetentionPolicy: model.RetentionPolicy{DisplayName: "Policy", PostDurationDays: model.NewPointer(int64(0))},
+		},
+		"invalid channel id": {
+			RetentionPolicy: model.RetentionPolicy{DisplayName: "Policy", PostDurationDays: model.NewPointer(int64(30))},
+			ChannelIDs:      []string{"invalid"},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			service, _, _ := setupService(t, true)
+			_, appErr := service.CreatePolicy(policy)
+			require.NotNil(t, appErr)
+			require.Equal(t, http.StatusBadRequest, appErr.StatusCode)
+		})
+	}
+
+	t.Run("missing channel", func(t *testing.T) {
+		service, policyStore, _ := setupService(t, true)
+		channelID := model.NewId()
+		policyStore.On("Save", mock.Anything).Return(nil, store.NewErrNotFound("Channel", channelID))
+
+		_, appErr := service.CreatePolicy(&model.RetentionPolicyWithTeamAndChannelIDs{
+			RetentionPolicy: model.RetentionPolicy{DisplayName: "Policy", PostDurationDays: model.NewPointer(int64(30))},
+			ChannelIDs:      []string{channelID},
+		})
+		require.NotNil(t, appErr)
+		require.Equal(t, "ent.data_retention.policies.invalid_policy", appErr.Id)
+	})
+}
+
+func TestPolicyNotFound(t *testing.T) {
+	service, policyStore, _ := setupService(t, true)
+	policyID := model.NewId()
+	policyStore.On("Get", policyID).Return(nil, sql.ErrNoRows)
+
+	_, appErr := service.GetPolicy(policyID)
+	require.NotNil(t, appErr)
+	require.Equal(t, http.StatusNotFound, appErr.StatusCode)
+
+	appErr = service.DeletePolicy(policyID)
+	require.NotNil(t, appErr)
+	require.Equal(t, http.StatusNotFound, appErr.StatusCode)
+
+	appErr = service.AddChannelsToPolicy(policyID, []string{model.NewId()})
+	require.NotNil(t, appErr)
+	require.Equal(t, http.StatusNotFound, appErr.StatusCode)
+
+	_, appErr = service.GetPolicy("invalid")
+	require.NotNil(t, appErr)
+	require.Equal(t, http.StatusNotFound, appErr.StatusCode)
+}
+
+func TestGetChannelsForPolicy(t *testing.T) {
+	service, policyStore, _ := setupService(t, true)
+	policyID := model.NewId()
+	channels := model.ChannelListWithTeamData{{Channel: model.Channel{Id: model.NewId()}}}
+	policyStore.On("Get", policyID).Return(&model.RetentionPolicyWithTeamAndChannelCounts{}, nil)
+	policyStore.On("GetChannels", policyID, 0, 10).Return(channels, nil)
+	policyStore.On("GetChannelsCount", policyID).Return(int64(5), nil)
+
+	result, appErr := service.GetChannelsForPolicy(policyID, 0, 10)
+	require.Nil(t, appErr)
+	require.Equal(t, channels, result.Channels)
+	require.Equal(t, int64(5), result.TotalCount)
+}
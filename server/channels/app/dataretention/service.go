@@ -0,0 +1,72 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package dataretention
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+// DataRetentionService manages the global and granular retention policies. It's
+// used when no other implementation of einterfaces.DataRetentionInterface is
+// registered.
+type DataRetentionService struct {
+	store   store.RetentionPolicyStore
+	config  func() *model.Config
+	license func() *model.License
+}
+
+// ServiceConfig is used to initialize the DataRetentionService.
+type ServiceConfig struct {
+	// Mandatory fields
+	RetentionPolicyStore store.RetentionPolicyStore
+	ConfigFn             func() *model.Config
+	LicenseFn            func() *model.License
+}
+
+func New(c ServiceConfig) (*DataRetentionService, error) {
+	if err := c.validate(); err != nil {
+		return nil, err
+	}
+
+	return &DataRetentionService{
+		store:   c.RetentionPolicyStore,
+		config:  c.ConfigFn,
+		license: c.LicenseFn,
+	}, nil
+}
+
+func (c *ServiceConfig) validate() error {
+	if c.RetentionPolicyStore == nil || c.ConfigFn == nil || c.LicenseFn == nil {
+		return errors.New("required parameters are not provided")
+	}
+
+	return nil
+}
+
+func (s *DataRetentionService) checkLicense(where string) *model.AppError {
+	license := s.license()
+	if license == nil || !*license.Features.DataRetention {
+		return model.NewAppError(where, "ent.data_retention.generic.license.error", nil, "", http.StatusNotImplemented)
+	}
+	return nil
+}
+
+// storeError converts an error returned by the store into an AppError,
+// reporting missing policies, teams and channels as such.
+func storeError(where string, err error) *model.AppError {
+	var nfErr *store.ErrNotFound
+	switch {
+	case errors.As(err, &nfErr):
+		return model.NewAppError(where, "ent.data_retention.policies.not_found", nil, "", http.StatusNotFound).Wrap(err)
+	case errors.Is(err, sql.ErrNoRows):
+		return model.NewAppError(where, "ent.data_retention.policies.not_found", nil, "", http.StatusNotFound).Wrap(err)
+	default:
+		return model.NewAppError(where, "ent.data_retention.policies.internal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+}
@@ -11,17 +11,25 @@ import (
 	"net/http"
 	"strconv"
 	"strings"
-	"sync"
 	"time"
 
+	"github.com/pkg/errors"
+
 	"github.com/mattermost/mattermost/server/public/model"
 	"github.com/mattermost/mattermost/server/public/shared/i18n"
 	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
 	"github.com/mattermost/mattermost/server/v8/channels/utils"
 )
 
 const (
-	EmailBatchingTaskName = "Email Batching"
+	// emailBatchingUsersPerPage is the number of users with pending notifications loaded at once.
+	emailBatchingUsersPerPage = 100
+
+	// maxBatchedPostsPerGroup is the number of posts of a channel or thread shown in a batched email.
+	maxBatchedPostsPerGroup = 5
+	// maxBatchedPosts is the number of posts shown in a batched email, the others being counted at the end.
+	maxBatchedPosts = 20
 )
 
 type postData struct {
@@ -38,203 +46,284 @@ type postData struct {
 }
 
 func (es *Service) InitEmailBatching() {
-	if *es.config().EmailSettings.EnableEmailBatching {
-		if es.EmailBatching == nil {
-			es.EmailBatching = NewEmailBatchingJob(es, *es.config().EmailSettings.EmailBatchingBufferSize)
-		}
-
-		// note that we don't support changing EmailBatchingBufferSize without restarting the server
-
-		es.EmailBatching.Start()
+	if *es.config().EmailSettings.EnableEmailBatching && es.EmailBatching == nil {
+		es.EmailBatching = NewEmailBatchingJob(es)
 	}
 }
 
+// AddNotificationEmailToBatch queues the notification email of the post, for the email batching job to send it
+// along with the other notifications of the user. The queue is kept in the database so that it survives restarts
+// and is shared by all the nodes of a cluster.
 func (es *Service) AddNotificationEmailToBatch(user *model.User, post *model.Post, team *model.Team) *model.AppError {
 	if !*es.config().EmailSettings.EnableEmailBatching {
 		return model.NewAppError("AddNotificationEmailToBatch", "api.email_batching.add_notification_email_to_batch.disabled.app_error", nil, "", http.StatusNotImplemented)
 	}
 
-	if !es.EmailBatching.Add(user, post, team) {
-		mlog.Error("Email batching job's receiving buffer was full. Please increase the EmailBatchingBufferSize. Falling back to sending immediate mail.")
-		return model.NewAppError("AddNotificationEmailToBatch", "api.email_batching.add_notification_email_to_batch.channel_full.app_error", nil, "", http.StatusInternalServerError)
+	notification := &model.EmailBatchNotification{
+		UserId:    user.Id,
+		PostId:    post.Id,
+		ChannelId: post.ChannelId,
+		RootId:    post.RootId,
+		TeamName:  team.Name,
+		CreateAt:  post.CreateAt,
+	}
+	if _, err := es.store.EmailBatchNotification().Save(notification); err != nil {
+		return model.NewAppError("AddNotificationEmailToBatch", "api.email_batching.add_notification_email_to_batch.save.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
 	}
 
 	return nil
 }
 
+// SendBatchedEmails sends the batched emails which are due. It is run periodically on the cluster leader.
+func (es *Service) SendBatchedEmails() error {
+	if !*es.config().EmailSettings.EnableEmailBatching || es.EmailBatching == nil {
+		return nil
+	}
+	return es.EmailBatching.CheckPendingEmails()
+}
+
 type batchedNotification struct {
 	userID   string
 	post     *model.Post
 	teamName string
 }
 
+// EmailBatchingJob sends the queued notification emails, once the email interval of each user has passed.
 type EmailBatchingJob struct {
 	config  func() *model.Config
 	service *Service
-
-	newNotifications     chan *batchedNotification
-	pendingNotifications map[string][]*batchedNotification
-	task                 *model.ScheduledTask
-	taskMutex            sync.Mutex
 }
 
-func NewEmailBatchingJob(es *Service, bufferSize int) *EmailBatchingJob {
+func NewEmailBatchingJob(es *Service) *EmailBatchingJob {
 	return &EmailBatchingJob{
-		config:               es.config,
-		service:              es,
-		newNotifications:     make(chan *batchedNotification, bufferSize),
-		pendingNotifications: make(map[string][]*batchedNotification),
+		config:  es.config,
+		service: es,
 	}
 }
 
-func (job *EmailBatchingJob) Start() {
-	mlog.Debug("Email batching job starting. Checking for pending emails periodically.", mlog.Int("interval_in_seconds", *job.config().EmailSettings.EmailBatchingInterval))
-	newTask := model.CreateRecurringTask(EmailBatchingTaskName, job.CheckPendingEmails, time.Duration(*job.config().EmailSettings.EmailBatchingInterval)*time.Second)
+func (job *EmailBatchingJob) CheckPendingEmails() error {
+	// it's a bit weird to pass the send email function through here, but it makes it so that we can test
+	// without actually sending emails
+	return job.checkPendingNotifications(time.Now(), job.service.sendBatchedEmailNotification)
+}
+
+func (job *EmailBatchingJob) checkPendingNotifications(now time.Time, handler func(*model.User, []*batchedNotification) error) error {
+	afterUserID := ""
+	for {
+		userIDs, err := job.service.store.EmailBatchNotification().GetUserIds(afterUserID, emailBatchingUsersPerPage)
+		if err != nil {
+			return errors.Wrap(err, "failed to get the users with pending email notifications")
+		}
 
-	job.taskMutex.Lock()
-	oldTask := job.task
-	job.task = newTask
-	job.taskMutex.Unlock()
+		for _, userID := range userIDs {
+			if err := job.checkPendingNotificationsForUser(now, userID, handler); err != nil {
+				mlog.Warn("Unable to check the pending email notifications of the user", mlog.String("user_id", userID), mlog.Err(err))
+			}
+		}
 
-	if oldTask != nil {
-		oldTask.Cancel()
+		if len(userIDs) < emailBatchingUsersPerPage {
+			return nil
+		}
+		afterUserID = userIDs[len(userIDs)-1]
 	}
 }
 
-// Stop will cancel the task properly, flushing out any pending notifications.
-// Although this still won't send those notifications which are yet to be sent
-// due to a user's PreferenceNameEmailInterval.
-func (job *EmailBatchingJob) Stop() {
-	job.taskMutex.Lock()
-	if task := job.task; task != nil {
-		task.Cancel()
+func (job *EmailBatchingJob) checkPendingNotificationsForUser(now time.Time, userID string, handler func(*model.User, []*batchedNotification) error) error {
+	pending, err := job.service.store.EmailBatchNotification().GetForUser(userID)
+	if err != nil {
+		return err
+	}
+	if len(pending) == 0 {
+		return nil
 	}
-	job.taskMutex.Unlock()
-}
 
-func (job *EmailBatchingJob) Add(user *model.User, post *model.Post, team *model.Team) bool {
-	notification := &batchedNotification{
-		userID:   user.Id,
-		post:     post,
-		teamName: team.Name,
+	ids := make([]string, len(pending))
+	for i, notification := range pending {
+		ids[i] = notification.Id
 	}
 
-	select {
-	case job.newNotifications <- notification:
-		return true
-	default:
-		// return false if we couldn't queue the email notification so that we can send an immediate email
-		return false
+	batchStartTime := pending[0].CreateAt
+	// Ignore if it isn't time yet to send.
+	if now.Sub(time.UnixMilli(batchStartTime)) <= job.getEmailInterval(userID) {
+		return nil
 	}
-}
 
-func (job *EmailBatchingJob) CheckPendingEmails() {
-	job.handleNewNotifications()
+	user, err := job.service.userService.GetUser(userID)
+	if err != nil {
+		var nfErr *store.ErrNotFound
+		if errors.As(err, &nfErr) {
+			return job.service.store.EmailBatchNotification().Delete(ids)
+		}
+		return err
+	}
 
-	// it's a bit weird to pass the send email function through here, but it makes it so that we can test
-	// without actually sending emails
-	job.checkPendingNotifications(time.Now(), job.service.sendBatchedEmailNotification)
+	// Hold the notifications until the sending window opens in the user's timezone.
+	emailSettings := job.config().EmailSettings
+	if !isInSendingWindow(now.In(user.GetTimezoneLocation()), *emailSettings.EmailBatchingWindowStart, *emailSettings.EmailBatchingWindowEnd) {
+		return nil
+	}
 
-	mlog.Debug("Email batching job ran. Notifications might be still pending.", mlog.Int("number_of_users", len(job.pendingNotifications)))
-}
+	viewed, err := job.hasViewedSince(userID, pending, batchStartTime)
+	if err != nil {
+		return err
+	}
+	if viewed {
+		mlog.Debug("Deleted notifications for user", mlog.String("user_id", userID))
+		return job.service.store.EmailBatchNotification().Delete(ids)
+	}
 
-func (job *EmailBatchingJob) handleNewNotifications() {
-	receiving := true
+	notifications, err := job.getBatchedNotifications(pending)
+	if err != nil {
+		return err
+	}
+	if len(notifications) > 0 {
+		// Keep the notifications for the next run when the email can't be sent.
+		if err := handler(user, notifications); err != nil {
+			return err
+		}
+	}
 
-	// read in new notifications to send
-	for receiving {
-		select {
-		case notification := <-job.newNotifications:
-			userID := notification.userID
+	return job.service.store.EmailBatchNotification().Delete(ids)
+}
 
-			if _, ok := job.pendingNotifications[userID]; !ok {
-				job.pendingNotifications[userID] = []*batchedNotification{notification}
-			} else {
-				job.pendingNotifications[userID] = append(job.pendingNotifications[userID], notification)
-			}
-		default:
-			receiving = false
+// getEmailInterval returns how long the notifications of the user are batched for.
+func (job *EmailBatchingJob) getEmailInterval(userID string) time.Duration {
+	interval, _ := strconv.ParseInt(model.PreferenceEmailIntervalBatchingSeconds, 10, 64)
+	if preference, err := job.service.store.Preference().Get(userID, model.PreferenceCategoryNotifications, model.PreferenceNameEmailInterval); err == nil {
+		// use the default batching interval if the preference can't be deserialized
+		if value, err := strconv.ParseInt(preference.Value, 10, 64); err == nil {
+			interval = value
 		}
 	}
+	return time.Duration(interval) * time.Second
 }
 
-func (job *EmailBatchingJob) checkPendingNotifications(now time.Time, handler func(string, []*batchedNotification)) {
-	for userID, notifications := range job.pendingNotifications {
-		// Defensive code.
-		if len(notifications) == 0 {
-			mlog.Warn("Unexpected result. Got 0 pending notifications for batched email.", mlog.String("user_id", userID))
+// hasViewedSince returns whether the user has viewed any channel of the teams of the notifications since the
+// batch started, in which case the notifications aren't worth sending anymore.
+func (job *EmailBatchingJob) hasViewedSince(userID string, notifications []*model.EmailBatchNotification, batchStartTime int64) (bool, error) {
+	// at most, we'll do one check for each team that notifications were sent for
+	inspectedTeamNames := make(map[string]bool)
+	for _, notification := range notifications {
+		if inspectedTeamNames[notification.TeamName] {
 			continue
 		}
+		inspectedTeamNames[notification.TeamName] = true
 
-		// get how long we need to wait to send notifications to the user
-		var interval int64
-		preference, err := job.service.store.Preference().Get(userID, model.PreferenceCategoryNotifications, model.PreferenceNameEmailInterval)
+		team, err := job.service.store.Team().GetByName(notification.TeamName)
 		if err != nil {
-			// use the default batching interval if an error occurs while fetching user preferences
-			interval, _ = strconv.ParseInt(model.PreferenceEmailIntervalBatchingSeconds, 10, 64)
-		} else {
-			if value, err := strconv.ParseInt(preference.Value, 10, 64); err != nil {
-				// // use the default batching interval if an error occurs while deserializing user preferences
-				interval, _ = strconv.ParseInt(model.PreferenceEmailIntervalBatchingSeconds, 10, 64)
-			} else {
-				interval = value
-			}
-		}
-
-		batchStartTime := notifications[0].post.CreateAt
-		// Ignore if it isn't time yet to send.
-		if now.Sub(time.UnixMilli(batchStartTime)) <= time.Duration(interval)*time.Second {
+			mlog.Warn("Unable to find Team id for notification", mlog.String("team_name", notification.TeamName), mlog.Err(err))
 			continue
 		}
 
-		// If the user has viewed any channels in this team since the notification was queued, delete
-		// all queued notifications
-		inspectedTeamNames := make(map[string]string)
-		for _, notification := range notifications {
-			// at most, we'll do one check for each team that notifications were sent for
-			if inspectedTeamNames[notification.teamName] != "" {
-				continue
-			}
+		channelMembers, err := job.service.store.Channel().GetMembersForUser(team.Id, userID)
+		if err != nil {
+			return false, err
+		}
 
-			team, nErr := job.service.store.Team().GetByName(notifications[0].teamName)
-			if nErr != nil {
-				mlog.Error("Unable to find Team id for notification", mlog.Err(nErr))
-				continue
+		for _, channelMember := range channelMembers {
+			if channelMember.LastViewedAt >= batchStartTime {
+				return true, nil
 			}
+		}
+	}
 
-			if team != nil {
-				inspectedTeamNames[notification.teamName] = team.Id
-			}
+	return false, nil
+}
 
-			channelMembers, err := job.service.store.Channel().GetMembersForUser(inspectedTeamNames[notification.teamName], userID)
-			if err != nil {
-				mlog.Error("Unable to find ChannelMembers for user", mlog.Err(err))
-				continue
-			}
+// getBatchedNotifications loads the posts of the notifications, leaving out the posts deleted since.
+func (job *EmailBatchingJob) getBatchedNotifications(pending []*model.EmailBatchNotification) ([]*batchedNotification, error) {
+	postIDs := make([]string, len(pending))
+	for i, notification := range pending {
+		postIDs[i] = notification.PostId
+	}
 
-			deleted := false
-			for _, channelMember := range channelMembers {
-				if channelMember.LastViewedAt >= batchStartTime {
-					mlog.Debug("Deleted notifications for user", mlog.String("user_id", userID))
-					delete(job.pendingNotifications, userID)
-					deleted = true
-					break
-				}
-			}
-			if deleted {
-				break
-			}
+	posts, err := job.service.store.Post().GetPostsByIds(postIDs)
+	if err != nil {
+		var nfErr *store.ErrNotFound
+		if errors.As(err, &nfErr) {
+			return nil, nil
 		}
+		return nil, err
+	}
 
-		// The notifications might have been cleared from the above step.
-		// We need to check again.
-		if len(job.pendingNotifications[userID]) == 0 {
+	postsByID := make(map[string]*model.Post, len(posts))
+	for _, post := range posts {
+		postsByID[post.Id] = post
+	}
+
+	notifications := make([]*batchedNotification, 0, len(pending))
+	for _, notification := range pending {
+		post, ok := postsByID[notification.PostId]
+		if !ok || post.DeleteAt != 0 {
 			continue
 		}
+		notifications = append(notifications, &batchedNotification{
+			userID:   notification.UserId,
+			post:     post,
+			teamName: notification.TeamName,
+		})
+	}
 
-		handler(userID, job.pendingNotifications[userID])
-		delete(job.pendingNotifications, userID)
+	return notifications, nil
+}
+
+// isInSendingWindow returns whether the local time is within the window of the day batched emails are sent in,
+// given as start and end times formatted as 15:04. An empty window allows sending at any time, and a window
+// ending before it starts spans midnight.
+func isInSendingWindow(localTime time.Time, start, end string) bool {
+	if start == "" && end == "" {
+		return true
 	}
+
+	startTime, startErr := time.Parse("15:04", start)
+	endTime, endErr := time.Parse("15:04", end)
+	if startErr != nil || endErr != nil {
+		return true
+	}
+
+	minutes := localTime.Hour()*60 + localTime.Minute()
+	from := startTime.Hour()*60 + startTime.Minute()
+	to := endTime.Hour()*60 + endTime.Minute()
+
+	if from <= to {
+		return minutes >= from && minutes < to
+	}
+	return minutes >= from || minutes < to
+}
+
+// groupNotifications orders the notifications by channel and thread, the groups following each other in the order
+// of their first notification. Only the first posts of each group, and of the whole batch, are kept. The number of
+// notifications left out is returned along with the ones kept.
+func groupNotifications(notifications []*batchedNotification) ([]*batchedNotification, int) {
+	var keys []string
+	groups := make(map[string][]*batchedNotification)
+	for _, notification := range notifications {
+		key := notification.post.ChannelId
+		if notification.post.RootId != "" {
+			key += "/" + notification.post.RootId
+		}
+		if _, ok := groups[key]; !ok {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], notification)
+	}
+
+	grouped := make([]*batchedNotification, 0, min(len(notifications), maxBatchedPosts))
+	for _, key := range keys {
+		group := groups[key]
+		if len(group) > maxBatchedPostsPerGroup {
+			group = group[:maxBatchedPostsPerGroup]
+		}
+		if len(grouped)+len(group) > maxBatchedPosts {
+			group = group[:maxBatchedPosts-len(grouped)]
+		}
+		grouped = append(grouped, group...)
+		if len(grouped) == maxBatchedPosts {
+			break
+		}
+	}
+
+	return grouped, len(notifications) - len(grouped)
 }
 
 /**
@@ -249,13 +338,7 @@ func truncateUserNames(name string, i int) string {
 	return name
 }
 
-func (es *Service) sendBatchedEmailNotification(userID string, notifications []*batchedNotification) {
-	user, err := es.userService.GetUser(userID)
-	if err != nil {
-		mlog.Warn("Unable to find recipient for batched email notification")
-		return
-	}
-
+func (es *Service) sendBatchedEmailNotification(user *model.User, notifications []*batchedNotification) error {
 	translateFunc := i18n.GetUserTranslations(user.Locale)
 	displayNameFormat := *es.config().TeamSettings.TeammateNameDisplay
 	siteURL := *es.config().ServiceSettings.SiteURL
@@ -274,7 +357,7 @@ func (es *Service) sendBatchedEmailNotification(userID string, notifications []*
 	if !threadsEnabled && appCRT != model.CollapsedThreadsDisabled {
 		threadsEnabled = appCRT == model.CollapsedThreadsDefaultOn
 		// check if a participant has overridden collapsed threads settings
-		if preference, errCrt := es.store.Preference().Get(userID, model.PreferenceCategoryDisplaySettings, model.PreferenceNameCollapsedThreadsEnabled); errCrt == nil {
+		if preference, errCrt := es.store.Preference().Get(user.Id, model.PreferenceCategoryDisplaySettings, model.PreferenceNameCollapsedThreadsEnabled); errCrt == nil {
 			threadsEnabled = preference.Value == "on"
 		}
 	}
@@ -286,8 +369,11 @@ func (es *Service) sendBatchedEmailNotification(userID string, notifications []*
 		useMilitaryTime = data.Value == "true"
 	}
 
+	moreMessages := 0
 	if emailNotificationContentsType == model.EmailNotificationContentsFull {
-		for i, notification := range notifications {
+		var shown []*batchedNotification
+		shown, moreMessages = groupNotifications(notifications)
+		for i, notification := range shown {
 			sender, errSender := es.userService.GetUser(notification.post.UserId)
 			if errSender != nil {
 				mlog.Warn("Unable to find sender of post for batched email notification")
@@ -363,6 +449,9 @@ func (es *Service) sendBatchedEmailNotification(userID string, notifications []*
 	data.Props["Button"] = translateFunc("api.email_batching.send_batched_email_notification.button")
 	data.Props["ButtonURL"] = siteURL
 	data.Props["Posts"] = postsData
+	if moreMessages > 0 {
+		data.Props["MoreMessages"] = translateFunc("api.email_batching.send_batched_email_notification.more_messages", moreMessages, map[string]any{"Count": moreMessages})
+	}
 	data.Props["MessageButton"] = translateFunc("api.email_batching.send_batched_email_notification.messageButton")
 	data.Props["NotificationFooterTitle"] = translateFunc("app.notification.footer.title")
 	data.Props["NotificationFooterInfoLogin"] = translateFunc("app.notification.footer.infoLogin")
@@ -370,10 +459,12 @@ func (es *Service) sendBatchedEmailNotification(userID string, notifications []*
 
 	renderedPage, renderErr := es.templatesContainer.RenderToString("messages_notification", data)
 	if renderErr != nil {
-		mlog.Error("Unable to render email", mlog.Err(renderErr))
+		return errors.Wrap(renderErr, "unable to render the batched email notification")
 	}
 
 	if nErr := es.SendMailWithEmbeddedFiles(user.Email, subject, renderedPage, embeddedFiles, "", "", "", "BatchedEmailNotification"); nErr != nil {
-		mlog.Warn("Unable to send batched email notification", mlog.String("email", user.Email), mlog.Err(nErr))
+		return errors.Wrap(nErr, "unable to send the batched email notification")
 	}
+
+	return nil
 }
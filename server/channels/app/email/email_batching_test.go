@@ -4,97 +4,106 @@
 package email
 
 import (
-	"sync/atomic"
+	"errors"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
 	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/channels/store/storetest/mocks"
 )
 
-func TestHandleNewNotifications(t *testing.T) {
+func enableEmailBatching(t *testing.T, th *TestHelper, f func(cfg *model.Config)) {
+	t.Helper()
+
+	cfg := th.configStore.Get().Clone()
+	*cfg.EmailSettings.EnableEmailBatching = true
+	*cfg.ServiceSettings.SiteURL = "http://localhost:8065"
+	if f != nil {
+		f(cfg)
+	}
+	_, _, err := th.configStore.Set(cfg)
+	require.NoError(t, err)
+}
+
+func (th *TestHelper) queueNotification(t *testing.T, createAt int64, message string) *model.Post {
+	t.Helper()
+
+	post, err := th.store.Post().Save(th.Context, &model.Post{
+		UserId:    th.BasicUser2.Id,
+		ChannelId: th.BasicChannel.Id,
+		CreateAt:  createAt,
+		Message:   message,
+	})
+	require.NoError(t, err)
+
+	appErr := th.service.AddNotificationEmailToBatch(th.BasicUser, post, th.BasicTeam)
+	require.Nil(t, appErr)
+
+	return post
+}
+
+func (th *TestHelper) pendingNotifications(t *testing.T) []*model.EmailBatchNotification {
+	t.Helper()
+
+	notifications, err := th.store.EmailBatchNotification().GetForUser(th.BasicUser.Id)
+	require.NoError(t, err)
+	return notifications
+}
+
+func (th *TestHelper) setLastViewedAt(t *testing.T, lastViewedAt int64) {
+	t.Helper()
+
+	channelMember, err := th.store.Channel().GetMember(th.Context, th.BasicChannel.Id, th.BasicUser.Id)
+	require.NoError(t, err)
+	channelMember.LastViewedAt = lastViewedAt
+	_, err = th.store.Channel().UpdateMember(th.Context, channelMember)
+	require.NoError(t, err)
+}
+
+func TestAddNotificationEmailToBatch(t *testing.T) {
 	mainHelper.Parallel(t)
 
 	th := SetupWithStoreMock(t)
 
-	id1 := model.NewId()
-	id2 := model.NewId()
-	id3 := model.NewId()
-
-	// test queueing of received posts by user
-	job := NewEmailBatchingJob(th.service, 128)
-
-	job.handleNewNotifications()
-
-	require.Empty(t, job.pendingNotifications, "shouldn't have added any pending notifications")
-
-	job.Add(&model.User{Id: id1}, &model.Post{UserId: id1, Message: "test"}, &model.Team{Name: "team"})
-	require.Empty(t, job.pendingNotifications, "shouldn't have added any pending notifications")
-
-	job.handleNewNotifications()
-	require.Len(t, job.pendingNotifications, 1, "should have received posts for 1 user")
-	require.Len(t, job.pendingNotifications[id1], 1, "should have received 1 post for user")
-
-	job.Add(&model.User{Id: id1}, &model.Post{UserId: id1, Message: "test"}, &model.Team{Name: "team"})
-	job.handleNewNotifications()
-	require.Len(t, job.pendingNotifications, 1, "should have received posts for 1 user")
-	require.Len(t, job.pendingNotifications[id1], 2, "should have received 2 posts for user1")
-
-	job.Add(&model.User{Id: id2}, &model.Post{UserId: id1, Message: "test"}, &model.Team{Name: "team"})
-	job.handleNewNotifications()
-	require.Len(t, job.pendingNotifications, 2, "should have received posts for 2 users")
-	require.Len(t, job.pendingNotifications[id1], 2, "should have received 2 posts for user1")
-	require.Len(t, job.pendingNotifications[id2], 1, "should have received 1 post for user2")
-
-	job.Add(&model.User{Id: id2}, &model.Post{UserId: id2, Message: "test"}, &model.Team{Name: "team"})
-	job.Add(&model.User{Id: id1}, &model.Post{UserId: id3, Message: "test"}, &model.Team{Name: "team"})
-	job.Add(&model.User{Id: id3}, &model.Post{UserId: id3, Message: "test"}, &model.Team{Name: "team"})
-	job.Add(&model.User{Id: id2}, &model.Post{UserId: id2, Message: "test"}, &model.Team{Name: "team"})
-	job.handleNewNotifications()
-	require.Len(t, job.pendingNotifications, 3, "should have received posts for 3 users")
-	require.Len(t, job.pendingNotifications[id1], 3, "should have received 3 posts for user1")
-	require.Len(t, job.pendingNotifications[id2], 3, "should have received 3 posts for user2")
-	require.Len(t, job.pendingNotifications[id3], 1, "should have received 1 post for user3")
-
-	// test ordering of received posts
-	job = NewEmailBatchingJob(th.service, 128)
-
-	job.Add(&model.User{Id: id1}, &model.Post{UserId: id1, Message: "test1"}, &model.Team{Name: "team"})
-	job.Add(&model.User{Id: id1}, &model.Post{UserId: id1, Message: "test2"}, &model.Team{Name: "team"})
-	job.Add(&model.User{Id: id2}, &model.Post{UserId: id1, Message: "test3"}, &model.Team{Name: "team"})
-	job.Add(&model.User{Id: id1}, &model.Post{UserId: id1, Message: "test4"}, &model.Team{Name: "team"})
-	job.Add(&model.User{Id: id2}, &model.Post{UserId: id1, Message: "test5"}, &model.Team{Name: "team"})
-	job.handleNewNotifications()
-	assert.Equal(t, job.pendingNotifications[id1][0].post.Message, "test1", "incorrect order of received posts for user1")
-	assert.Equal(t, job.pendingNotifications[id1][1].post.Message, "test2", "incorrect order of received posts for user1")
-	assert.Equal(t, job.pendingNotifications[id1][2].post.Message, "test4", "incorrect order of received posts for user1")
-	assert.Equal(t, job.pendingNotifications[id2][0].post.Message, "test3", "incorrect order of received posts for user2")
-	assert.Equal(t, job.pendingNotifications[id2][1].post.Message, "test5", "incorrect order of received posts for user2")
+	user := &model.User{Id: model.NewId()}
+	post := &model.Post{Id: model.NewId(), ChannelId: model.NewId(), RootId: model.NewId(), CreateAt: 1000}
+	team := &model.Team{Name: "team"}
+
+	t.Run("disabled", func(t *testing.T) {
+		appErr := th.service.AddNotificationEmailToBatch(user, post, team)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "api.email_batching.add_notification_email_to_batch.disabled.app_error", appErr.Id)
+	})
+
+	t.Run("saves the notification", func(t *testing.T) {
+		enableEmailBatching(t, th, nil)
+
+		emailBatchNotificationStore := mocks.EmailBatchNotificationStore{}
+		emailBatchNotificationStore.On("Save", mock.MatchedBy(func(n *model.EmailBatchNotification) bool {
+			return n.UserId == user.Id && n.PostId == post.Id && n.ChannelId == post.ChannelId &&
+				n.RootId == post.RootId && n.TeamName == team.Name && n.CreateAt == post.CreateAt
+		})).Return(&model.EmailBatchNotification{}, nil)
+		th.service.store.(*mocks.Store).On("EmailBatchNotification").Return(&emailBatchNotificationStore)
+
+		appErr := th.service.AddNotificationEmailToBatch(user, post, team)
+		require.Nil(t, appErr)
+		emailBatchNotificationStore.AssertExpectations(t)
+	})
 }
 
 func TestCheckPendingNotifications(t *testing.T) {
 	mainHelper.Parallel(t)
 	th := Setup(t).InitBasic(t)
+	enableEmailBatching(t, th, nil)
 
-	job := NewEmailBatchingJob(th.service, 128)
-	job.pendingNotifications[th.BasicUser.Id] = []*batchedNotification{
-		{
-			post: &model.Post{
-				UserId:    th.BasicUser.Id,
-				ChannelId: th.BasicChannel.Id,
-				CreateAt:  10000000,
-			},
-			teamName: th.BasicTeam.Name,
-		},
-	}
+	job := NewEmailBatchingJob(th.service)
 
-	channelMember, err := th.store.Channel().GetMember(th.Context, th.BasicChannel.Id, th.BasicUser.Id)
-	require.NoError(t, err)
-	channelMember.LastViewedAt = 9999999
-	_, err = th.store.Channel().UpdateMember(th.Context, channelMember)
-	require.NoError(t, err)
+	th.queueNotification(t, 10000000, "post0")
+	th.setLastViewedAt(t, 9999999)
 
 	nErr := th.store.Preference().Save(model.Preferences{{
 		UserId:   th.BasicUser.Id,
@@ -105,17 +114,15 @@ func TestCheckPendingNotifications(t *testing.T) {
 	require.NoError(t, nErr)
 
 	// test that notifications aren't sent before interval
-	job.checkPendingNotifications(time.Unix(10001, 0), func(string, []*batchedNotification) {})
-
-	require.NotNil(t, job.pendingNotifications[th.BasicUser.Id])
-	require.Len(t, job.pendingNotifications[th.BasicUser.Id], 1, "shouldn't have sent queued post")
+	err := job.checkPendingNotifications(time.Unix(10001, 0), func(*model.User, []*batchedNotification) error {
+		require.Fail(t, "email handler should not have been called")
+		return nil
+	})
+	require.NoError(t, err)
+	require.Len(t, th.pendingNotifications(t), 1, "shouldn't have sent queued post")
 
 	// test that notifications are cleared if the user has acted
-	channelMember, err = th.store.Channel().GetMember(th.Context, th.BasicChannel.Id, th.BasicUser.Id)
-	require.NoError(t, err)
-	channelMember.LastViewedAt = 10001000
-	_, err = th.store.Channel().UpdateMember(th.Context, channelMember)
-	require.NoError(t, err)
+	th.setLastViewedAt(t, 10001000)
 
 	// We reset the interval to something shorter
 	nErr = th.store.Preference().Save(model.Preferences{{
@@ -126,68 +133,80 @@ func TestCheckPendingNotifications(t *testing.T) {
 	}})
 	require.NoError(t, nErr)
 
-	var wasCalled int32
-	job.checkPendingNotifications(time.Unix(10050, 0), func(string, []*batchedNotification) {
-		atomic.StoreInt32(&wasCalled, int32(1))
+	err = job.checkPendingNotifications(time.Unix(10050, 0), func(*model.User, []*batchedNotification) error {
+		require.Fail(t, "email handler should not have been called")
+		return nil
 	})
-
-	// A hack to check whether the handler was called.
-	// It's not straightforward to just wait for it using a channel because the test should
-	// NOT call the handler, and it will be called only if the test fails.
-	time.Sleep(1 * time.Second)
-	// We do a check outside the email handler, because otherwise, failing from
-	// inside the handler doesn't let the .Go() function exit cleanly, and it gets
-	// stuck during server shutdown, trying to wait for the goroutine to exit
-	require.Equal(t, int32(0), atomic.LoadInt32(&wasCalled), "email handler should not have been called")
-
-	require.Nil(t, job.pendingNotifications[th.BasicUser.Id])
-	require.Empty(t, job.pendingNotifications[th.BasicUser.Id], "should've remove queued post since user acted")
+	require.NoError(t, err)
+	require.Empty(t, th.pendingNotifications(t), "should've remove queued post since user acted")
 
 	// test that notifications are sent if enough time passes since the first message
-	job.pendingNotifications[th.BasicUser.Id] = []*batchedNotification{
-		{
-			post: &model.Post{
-				UserId:    th.BasicUser.Id,
-				ChannelId: th.BasicChannel.Id,
-				CreateAt:  10060000,
-				Message:   "post1",
-			},
-			teamName: th.BasicTeam.Name,
-		},
-		{
-			post: &model.Post{
-				UserId:    th.BasicUser.Id,
-				ChannelId: th.BasicChannel.Id,
-				CreateAt:  10090000,
-				Message:   "post2",
-			},
-			teamName: th.BasicTeam.Name,
-		},
-	}
-
-	received := make(chan *model.Post, 2)
+	th.queueNotification(t, 10060000, "post1")
+	th.queueNotification(t, 10090000, "post2")
 
-	job.checkPendingNotifications(time.Unix(10130, 0), func(s string, notifications []*batchedNotification) {
+	var received []*model.Post
+	err = job.checkPendingNotifications(time.Unix(10130, 0), func(user *model.User, notifications []*batchedNotification) error {
+		require.Equal(t, th.BasicUser.Id, user.Id)
 		for _, notification := range notifications {
-			received <- notification.post
+			received = append(received, notification.post)
 		}
+		return nil
 	})
+	require.NoError(t, err)
+	require.Empty(t, th.pendingNotifications(t), "should have sent queued posts")
 
-	require.Nil(t, job.pendingNotifications[th.BasicUser.Id], "shouldn't have sent queued post")
+	require.Len(t, received, 2)
+	assert.Equal(t, "post1", received[0].Message, "should've received post1 first")
+	assert.Equal(t, "post2", received[1].Message, "should've received post2 second")
+}
 
-	select {
-	case post := <-received:
-		require.Equal(t, post.Message, "post1", "should've received post1 first")
-	case <-time.After(5 * time.Second):
-		require.Fail(t, "timed out waiting for first post notification")
-	}
+func TestCheckPendingNotificationsDeletedPost(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t).InitBasic(t)
+	enableEmailBatching(t, th, nil)
 
-	select {
-	case post := <-received:
-		require.Equal(t, post.Message, "post2", "should've received post2 second")
-	case <-time.After(5 * time.Second):
-		require.Fail(t, "timed out waiting for second post notification")
-	}
+	job := NewEmailBatchingJob(th.service)
+	th.setLastViewedAt(t, 9999000)
+
+	post := th.queueNotification(t, 10000000, "deleted")
+	err := th.store.Post().Delete(th.Context, post.Id, model.GetMillis(), th.BasicUser2.Id)
+	require.NoError(t, err)
+
+	err = job.checkPendingNotifications(time.Unix(10901, 0), func(*model.User, []*batchedNotification) error {
+		require.Fail(t, "email handler should not have been called")
+		return nil
+	})
+	require.NoError(t, err)
+	require.Empty(t, th.pendingNotifications(t), "should have dropped the notification of the deleted post")
+}
+
+func TestCheckPendingNotificationsSendFailure(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t).InitBasic(t)
+	enableEmailBatching(t, th, nil)
+
+	job := NewEmailBatchingJob(th.service)
+	th.setLastViewedAt(t, 9999000)
+
+	th.queueNotification(t, 10000000, "post")
+
+	err := job.checkPendingNotifications(time.Unix(10901, 0), func(*model.User, []*batchedNotification) error {
+		return errors.New("smtp unavailable")
+	})
+	require.NoError(t, err)
+	require.Len(t, th.pendingNotifications(t), 1, "should have kept the notification to send it again")
+
+	var received []*model.Post
+	err = job.checkPendingNotifications(time.Unix(10961, 0), func(_ *model.User, notifications []*batchedNotification) error {
+		for _, notification := range notifications {
+			received = append(received, notification.post)
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	require.Len(t, received, 1)
+	assert.Equal(t, "post", received[0].Message)
+	require.Empty(t, th.pendingNotifications(t), "should have sent queued post")
 }
 
 /**
@@ -196,41 +215,24 @@ func TestCheckPendingNotifications(t *testing.T) {
 func TestCheckPendingNotificationsDefaultInterval(t *testing.T) {
 	mainHelper.Parallel(t)
 	th := Setup(t).InitBasic(t)
+	enableEmailBatching(t, th, nil)
 
-	job := NewEmailBatchingJob(th.service, 128)
+	job := NewEmailBatchingJob(th.service)
 
 	// bypasses recent user activity check
-	require.NotNil(t, th.store)
-	require.NotNil(t, th.store.Channel())
-
-	require.NotNil(t, th.BasicUser)
-	require.NotNil(t, th.BasicChannel)
-	channelMember, err := th.store.Channel().GetMember(th.Context, th.BasicChannel.Id, th.BasicUser.Id)
-	require.NoError(t, err)
-	channelMember.LastViewedAt = 9999000
-	_, err = th.store.Channel().UpdateMember(th.Context, channelMember)
-	require.NoError(t, err)
+	th.setLastViewedAt(t, 9999000)
 
-	job.pendingNotifications[th.BasicUser.Id] = []*batchedNotification{
-		{
-			post: &model.Post{
-				UserId:    th.BasicUser.Id,
-				ChannelId: th.BasicChannel.Id,
-				CreateAt:  10000000,
-			},
-			teamName: th.BasicTeam.Name,
-		},
-	}
+	th.queueNotification(t, 10000000, "post")
 
 	// notifications should not be sent 1s after post was created, because default batch interval is 15mins
-	job.checkPendingNotifications(time.Unix(10001, 0), func(string, []*batchedNotification) {})
-	require.NotNil(t, job.pendingNotifications[th.BasicUser.Id])
-	require.Len(t, job.pendingNotifications[th.BasicUser.Id], 1, "shouldn't have sent queued post")
+	err := job.checkPendingNotifications(time.Unix(10001, 0), func(*model.User, []*batchedNotification) error { return nil })
+	require.NoError(t, err)
+	require.Len(t, th.pendingNotifications(t), 1, "shouldn't have sent queued post")
 
 	// notifications should be sent 901s after post was created, because default batch interval is 15mins
-	job.checkPendingNotifications(time.Unix(10901, 0), func(string, []*batchedNotification) {})
-	require.Nil(t, job.pendingNotifications[th.BasicUser.Id])
-	require.Empty(t, job.pendingNotifications[th.BasicUser.Id], "should have sent queued post")
+	err = job.checkPendingNotifications(time.Unix(10901, 0), func(*model.User, []*batchedNotification) error { return nil })
+	require.NoError(t, err)
+	require.Empty(t, th.pendingNotifications(t), "should have sent queued post")
 }
 
 /**
@@ -239,19 +241,12 @@ func TestCheckPendingNotificationsDefaultInterval(t *testing.T) {
 func TestCheckPendingNotificationsCantParseInterval(t *testing.T) {
 	mainHelper.Parallel(t)
 	th := Setup(t).InitBasic(t)
+	enableEmailBatching(t, th, nil)
 
-	job := NewEmailBatchingJob(th.service, 128)
+	job := NewEmailBatchingJob(th.service)
 
-	require.NotNil(t, th.store)
-	require.NotNil(t, th.store.Channel())
-	require.NotNil(t, th.BasicChannel)
-	require.NotNil(t, th.BasicUser)
 	// bypasses recent user activity check
-	channelMember, err := th.store.Channel().GetMember(th.Context, th.BasicChannel.Id, th.BasicUser.Id)
-	require.NoError(t, err)
-	channelMember.LastViewedAt = 9999000
-	_, err = th.store.Channel().UpdateMember(th.Context, channelMember)
-	require.NoError(t, err)
+	th.setLastViewedAt(t, 9999000)
 
 	// preference value is not an integer, so we'll fall back to the default 15min value
 	nErr := th.store.Preference().Save(model.Preferences{{
@@ -262,24 +257,130 @@ func TestCheckPendingNotificationsCantParseInterval(t *testing.T) {
 	}})
 	require.NoError(t, nErr)
 
-	job.pendingNotifications[th.BasicUser.Id] = []*batchedNotification{
-		{
-			post: &model.Post{
-				UserId:    th.BasicUser.Id,
-				ChannelId: th.BasicChannel.Id,
-				CreateAt:  10000000,
-			},
-			teamName: th.BasicTeam.Name,
-		},
-	}
+	th.queueNotification(t, 10000000, "post")
 
 	// notifications should not be sent 1s after post was created, because default batch interval is 15mins
-	job.checkPendingNotifications(time.Unix(10001, 0), func(string, []*batchedNotification) {})
-	require.NotNil(t, job.pendingNotifications[th.BasicUser.Id])
-	require.Len(t, job.pendingNotifications[th.BasicUser.Id], 1, "shouldn't have sent queued post")
+	err := job.checkPendingNotifications(time.Unix(10001, 0), func(*model.User, []*batchedNotification) error { return nil })
+	require.NoError(t, err)
+	require.Len(t, th.pendingNotifications(t), 1, "shouldn't have sent queued post")
 
 	// notifications should be sent 901s after post was created, because default batch interval is 15mins
-	job.checkPendingNotifications(time.Unix(10901, 0), func(string, []*batchedNotification) {})
+	err = job.checkPendingNotifications(time.Unix(10901, 0), func(*model.User, []*batchedNotification) error { return nil })
+	require.NoError(t, err)
+	require.Empty(t, th.pendingNotifications(t), "should have sent queued post")
+}
+
+func TestCheckPendingNotificationsSendingWindow(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t).InitBasic(t)
+	enableEmailBatching(t, th, func(cfg *model.Config) {
+		*cfg.EmailSettings.EmailBatchingWindowStart = "08:00"
+		*cfg.EmailSettings.EmailBatchingWindowEnd = "18:00"
+	})
 
-	require.Nil(t, job.pendingNotifications[th.BasicUser.Id], "should have sent queued post")
+	job := NewEmailBatchingJob(th.service)
+	th.setLastViewedAt(t, 9999000)
+
+	// The user has no timezone, so the window is in UTC.
+	th.queueNotification(t, 10000000, "post")
+
+	// The interval has passed, but the window only opens at 08:00.
+	err := job.checkPendingNotifications(time.Unix(11000, 0), func(*model.User, []*batchedNotification) error {
+		require.Fail(t, "email handler should not have been called")
+		return nil
+	})
+	require.NoError(t, err)
+	require.Len(t, th.pendingNotifications(t), 1, "shouldn't have sent queued post outside of the window")
+
+	called := false
+	err = job.checkPendingNotifications(time.Unix(8*60*60+60, 0), func(*model.User, []*batchedNotification) error {
+		called = true
+		return nil
+	})
+	require.NoError(t, err)
+	require.True(t, called)
+	require.Empty(t, th.pendingNotifications(t), "should have sent queued post within the window")
+}
+
+func TestIsInSendingWindow(t *testing.T) {
+	at := func(hour, minute int) time.Time {
+		return time.Date(2024, 1, 1, hour, minute, 0, 0, time.UTC)
+	}
+
+	testCases := []struct {
+		name       string
+		localTime  time.Time
+		start, end string
+		expected   bool
+	}{
+		{"no window", at(3, 0), "", "", true},
+		{"within", at(9, 30), "08:00", "18:00", true},
+		{"at start", at(8, 0), "08:00", "18:00", true},
+		{"at end", at(18, 0), "08:00", "18:00", false},
+		{"before", at(7, 59), "08:00", "18:00", false},
+		{"overnight before midnight", at(23, 0), "22:00", "06:00", true},
+		{"overnight after midnight", at(5, 0), "22:00", "06:00", true},
+		{"overnight during the day", at(12, 0), "22:00", "06:00", false},
+		{"invalid window", at(12, 0), "noon", "06:00", true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, isInSendingWindow(tc.localTime, tc.start, tc.end))
+		})
+	}
+}
+
+func TestGroupNotifications(t *testing.T) {
+	notification := func(channelID, rootID, message string) *batchedNotification {
+		return &batchedNotification{post: &model.Post{ChannelId: channelID, RootId: rootID, Message: message}}
+	}
+	messages := func(notifications []*batchedNotification) []string {
+		result := make([]string, len(notifications))
+		for i, n := range notifications {
+			result[i] = n.post.Message
+		}
+		return result
+	}
+
+	channel1 := model.NewId()
+	channel2 := model.NewId()
+	root := model.NewId()
+
+	t.Run("groups by channel and thread", func(t *testing.T) {
+		grouped, more := groupNotifications([]*batchedNotification{
+			notification(channel1, "", "a1"),
+			notification(channel2, "", "b1"),
+			notification(channel1, root, "t1"),
+			notification(channel1, "", "a2"),
+			notification(channel2, "", "b2"),
+			notification(channel1, root, "t2"),
+		})
+		assert.Equal(t, []string{"a1", "a2", "b1", "b2", "t1", "t2"}, messages(grouped))
+		assert.Zero(t, more)
+	})
+
+	t.Run("limits the posts per group", func(t *testing.T) {
+		var notifications []*batchedNotification
+		for range maxBatchedPostsPerGroup + 2 {
+			notifications = append(notifications, notification(channel1, "", "a"))
+		}
+		notifications = append(notifications, notification(channel2, "", "b"))
+
+		grouped, more := groupNotifications(notifications)
+		assert.Len(t, grouped, maxBatchedPostsPerGroup+1)
+		assert.Equal(t, "b", grouped[len(grouped)-1].post.Message)
+		assert.Equal(t, 2, more)
+	})
+
+	t.Run("limits the posts of the batch", func(t *testing.T) {
+		var notifications []*batchedNotification
+		for range maxBatchedPosts + 5 {
+			notifications = append(notifications, notification(model.NewId(), "", "a"))
+		}
+
+		grouped, more := groupNotifications(notifications)
+		assert.Len(t, grouped, maxBatchedPosts)
+		assert.Equal(t, 5, more)
+	})
 }
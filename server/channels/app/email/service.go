@@ -14,7 +14,6 @@ import (
 
 	"github.com/mattermost/mattermost/server/public/model"
 	"github.com/mattermost/mattermost/server/public/shared/i18n"
-	"github.com/mattermost/mattermost/server/public/shared/mlog"
 	"github.com/mattermost/mattermost/server/v8/channels/app/users"
 	"github.com/mattermost/mattermost/server/v8/channels/store"
 	"github.com/mattermost/mattermost/server/v8/platform/shared/templates"
@@ -85,11 +84,9 @@ func NewService(config ServiceConfig) (*Service, error) {
 	return service, nil
 }
 
+// Stop has nothing to flush, the batched notifications being kept in the database until the email batching job
+// sends them.
 func (es *Service) Stop() {
-	mlog.Info("Shutting down Email batching service...")
-	if es.EmailBatching != nil {
-		es.EmailBatching.Stop()
-	}
 }
 
 func (c *ServiceConfig) validate() error {
@@ -164,6 +161,7 @@ type ServiceInterface interface {
 	SendLicenseUpForRenewalEmail(email, name, locale, siteURL, ctaTitle, ctaLink, ctaText string, daysToExpiration int) error
 	SendRemoveExpiredLicenseEmail(ctaText, ctaLink, email, locale, siteURL string) error
 	AddNotificationEmailToBatch(user *model.User, post *model.Post, team *model.Team) *model.AppError
+	SendBatchedEmails() error
 	GetMessageForNotification(post *model.Post, teamName, siteUrl string, translateFunc i18n.TranslateFunc) string
 	GenerateHyperlinkForChannels(postMessage, teamName, teamURL string) (string, error)
 	InitEmailBatching()
@@ -0,0 +1,283 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+const (
+	eventSubscriptionDeliveryAttempts = 3
+	eventSubscriptionRetryBackoff     = 2 * time.Second
+)
+
+func (a *App) CreateEventSubscription(rctx request.CTX, subscription *model.EventSubscription) (*model.EventSubscription, *model.AppError) {
+	if !*a.Config().ServiceSettings.EnableEventSubscriptions {
+		return nil, model.NewAppError("CreateEventSubscription", "api.event_subscription.disabled.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	if appErr := a.validateEventSubscriptionScope(rctx, "CreateEventSubscription", subscription); appErr != nil {
+		return nil, appErr
+	}
+
+	// The secret is always generated by the server.
+	subscription.Secret = ""
+
+	saved, err := a.Srv().Store().EventSubscription().Save(subscription)
+	if err != nil {
+		var appErr *model.AppError
+		var invErr *store.ErrInvalidInput
+		switch {
+		case errors.As(err, &appErr):
+			return nil, appErr
+		case errors.As(err, &invErr):
+			return nil, model.NewAppError("CreateEventSubscription", "app.event_subscription.save.existing.app_error", nil, "", http.StatusBadRequest).Wrap(err)
+		default:
+			return nil, model.NewAppError("CreateEventSubscription", "app.event_subscription.save.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+	}
+
+	return saved, nil
+}
+
+func (a *App) UpdateEventSubscription(rctx request.CTX, oldSubscription, updatedSubscription *model.EventSubscription) (*model.EventSubscription, *model.AppError) {
+	if !*a.Config().ServiceSettings.EnableEventSubscriptions {
+		return nil, model.NewAppError("UpdateEventSubscription", "api.event_subscription.disabled.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	if appErr := a.validateEventSubscriptionScope(rctx, "UpdateEventSubscription", updatedSubscription); appErr != nil {
+		return nil, appErr
+	}
+
+	updatedSubscription.Id = oldSubscription.Id
+	updatedSubscription.CreatorId = oldSubscription.CreatorId
+	updatedSubscription.CreateAt = oldSubscription.CreateAt
+	updatedSubscription.DeleteAt = oldSubscription.DeleteAt
+	updatedSubscription.Secret = oldSubscription.Secret
+
+	return a.updateEventSubscription("UpdateEventSubscription", updatedSubscription)
+}
+
+func (a *App) RegenEventSubscriptionSecret(subscription *model.EventSubscription) (*model.EventSubscription, *model.AppError) {
+	if !*a.Config().ServiceSettings.EnableEventSubscriptions {
+		return nil, model.NewAppError("RegenEventSubscriptionSecret", "api.event_subscription.disabled.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	subscription.Secret = model.NewId()
+
+	return a.updateEventSubscription("RegenEventSubscriptionSecret", subscription)
+}
+
+func (a *App) updateEventSubscription(where string, subscription *model.EventSubscription) (*model.EventSubscription, *model.AppError) {
+	updated, err := a.Srv().Store().EventSubscription().Update(subscription)
+	if err != nil {
+		var appErr *model.AppError
+		switch {
+		case errors.As(err, &appErr):
+			return nil, appErr
+		default:
+			return nil, model.NewAppError(where, "app.event_subscription.update.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+	}
+
+	return updated, nil
+}
+
+func (a *App) GetEventSubscription(id string) (*model.EventSubscription, *model.AppError) {
+	if !*a.Config().ServiceSettings.EnableEventSubscriptions {
+		return nil, model.NewAppError("GetEventSubscription", "api.event_subscription.disabled.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	subscription, err := a.Srv().Store().EventSubscription().Get(id)
+	if err != nil {
+		var nfErr *store.ErrNotFound
+		switch {
+		case errors.As(err, &nfErr):
+			return nil, model.NewAppError("GetEventSubscription", "app.event_subscription.get.not_found.app_error", nil, "", http.StatusNotFound).Wrap(err)
+		default:
+			return nil, model.NewAppError("GetEventSubscription", "app.event_subscription.get.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+	}
+
+	return subscription, nil
+}
+
+func (a *App) GetEventSubscriptionsPage(teamID string, page, perPage int) ([]*model.EventSubscription, *model.AppError) {
+	if !*a.Config().ServiceSettings.EnableEventSubscriptions {
+		return nil, model.NewAppError("GetEventSubscriptionsPage", "api.event_subscription.disabled.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	subscriptions, err := a.Srv().Store().EventSubscription().GetList(teamID, page*perPage, perPage)
+	if err != nil {
+		return nil, model.NewAppError("GetEventSubscriptionsPage", "app.event_subscription.get_list.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return subscriptions, nil
+}
+
+func (a *App) DeleteEventSubscription(id string) *model.AppError {
+	if !*a.Config().ServiceSettings.EnableEventSubscriptions {
+		return model.NewAppError("DeleteEventSubscription", "api.event_subscription.disabled.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	if err := a.Srv().Store().EventSubscription().Delete(id, model.GetMillis()); err != nil {
+		return model.NewAppError("DeleteEventSubscription", "app.event_subscription.delete.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return nil
+}
+
+// validateEventSubscriptionScope checks that the team and channel a
+// subscription is scoped to exist and agree with each other.
+func (a *App) validateEventSubscriptionScope(rctx request.CTX, where string, subscription *model.EventSubscription) *model.AppError {
+	if subscription.ChannelId != "" {
+		channel, err := a.GetChannel(rctx, subscription.ChannelId)
+		if err != nil {
+			return err
+		}
+		if subscription.TeamId == "" {
+			subscription.TeamId = channel.TeamId
+		}
+		if channel.TeamId != subscription.TeamId {
+			return model.NewAppError(where, "api.event_subscription.channel_team_mismatch.app_error", nil, "", http.StatusBadRequest)
+		}
+	}
+
+	if subscription.TeamId != "" {
+		if _, err := a.GetTeam(subscription.TeamId); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// publishSubscribedEvent delivers a server event to every subscription that
+// matches it. The delivery data is the payload of the WebSocket event message
+// that was broadcast for the same change. Deliveries are made asynchronously
+// and never block the caller.
+func (a *App) publishSubscribedEvent(event, teamID, channelID string, message *model.WebSocketEvent) {
+	if !*a.Config().ServiceSettings.EnableEventSubscriptions {
+		return
+	}
+
+	a.Srv().Go(func() {
+		// Channel scoped events don't always know their team up front, but
+		// subscriptions scoped to a team still need to match them.
+		if teamID == "" && channelID != "" {
+			channel, err := a.Srv().Store().Channel().Get(channelID, true)
+			if err != nil {
+				a.Log().Warn("Failed to get channel for event subscriptions", mlog.String("event", event), mlog.String("channel_id", channelID), mlog.Err(err))
+				return
+			}
+			teamID = channel.TeamId
+		}
+
+		subscriptions, err := a.Srv().Store().EventSubscription().GetForTeam(teamID)
+		if err != nil {
+			a.Log().Warn("Failed to get event subscriptions", mlog.String("event", event), mlog.String("team_id", teamID), mlog.Err(err))
+			return
+		}
+
+		for _, subscription := range subscriptions {
+			if !subscription.Matches(event, teamID, channelID) {
+				continue
+			}
+
+			delivery := &model.EventSubscriptionDelivery{
+				Id:             model.NewId(),
+				SubscriptionId: subscription.Id,
+				Event:          event,
+				CreateAt:       model.GetMillis(),
+				TeamId:         teamID,
+				ChannelId:      channelID,
+				Data:           message.GetData(),
+			}
+
+			a.Srv().Go(func() {
+				a.deliverSubscribedEvent(subscription, delivery)
+			})
+		}
+	})
+}
+
+func (a *App) deliverSubscribedEvent(subscription *model.EventSubscription, delivery *model.EventSubscriptionDelivery) {
+	logger := a.Log().With(
+		mlog.String("event_subscription_id", subscription.Id),
+		mlog.String("delivery_id", delivery.Id),
+		mlog.String("event", delivery.Event),
+	)
+
+	body, err := json.Marshal(delivery)
+	if err != nil {
+		logger.Warn("Failed to encode event subscription delivery", mlog.Err(err))
+		return
+	}
+
+	for attempt := 1; attempt <= eventSubscriptionDeliveryAttempts; attempt++ {
+		err = a.doEventSubscriptionRequest(subscription, delivery, body)
+		if err == nil {
+			return
+		}
+
+		logger.Debug("Event subscription delivery attempt failed", mlog.Int("attempt", attempt), mlog.Err(err))
+		if attempt == eventSubscriptionDeliveryAttempts {
+			break
+		}
+
+		timer := time.NewTimer(time.Duration(attempt) * eventSubscriptionRetryBackoff)
+		select {
+		case <-timer.C:
+		case <-a.Srv().eventSubscriptionsStop:
+			timer.Stop()
+			logger.Warn("Server is shutting down, giving up on event subscription delivery", mlog.String("callback_url", subscription.CallbackURL), mlog.Err(err))
+			return
+		}
+	}
+
+	logger.Warn("Giving up on event subscription delivery", mlog.String("callback_url", subscription.CallbackURL), mlog.Err(err))
+}
+
+func (a *App) doEventSubscriptionRequest(subscription *model.EventSubscription, delivery *model.EventSubscriptionDelivery, body []byte) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(*a.Config().ServiceSettings.OutgoingIntegrationRequestsTimeout)*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.CallbackURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(model.EventSubscriptionEventHeader, delivery.Event)
+	req.Header.Set(model.EventSubscriptionDeliveryHeader, delivery.Id)
+	req.Header.Set(model.EventSubscriptionTimestampHeader, timestamp)
+	req.Header.Set(model.EventSubscriptionSignatureHeader, model.ComputeEventSubscriptionSignature(subscription.Secret, timestamp, body))
+
+	resp, err := a.Srv().outgoingWebhookClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, MaxIntegrationResponseSize))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+
+	return nil
+}
@@ -0,0 +1,262 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func TestCreateEventSubscription(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t).InitBasic(t)
+
+	newSubscription := func() *model.EventSubscription {
+		return &model.EventSubscription{
+			CreatorId:   th.SystemAdminUser.Id,
+			CallbackURL: "https://example.com/events",
+			Events:      model.StringArray{model.EventSubscriptionEventPostEdited},
+		}
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableEventSubscriptions = false })
+
+		_, appErr := th.App.CreateEventSubscription(th.Context, newSubscription())
+		require.NotNil(t, appErr)
+		assert.Equal(t, http.StatusNotImplemented, appErr.StatusCode)
+	})
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableEventSubscriptions = true })
+
+	t.Run("the secret is generated", func(t *testing.T) {
+		subscription := newSubscription()
+		subscription.Secret = "chosen-by-the-caller-secret"
+
+		saved, appErr := th.App.CreateEventSubscription(th.Context, subscription)
+		require.Nil(t, appErr)
+		assert.NotEqual(t, "chosen-by-the-caller-secret", saved.Secret)
+		assert.Len(t, saved.Secret, 26)
+	})
+
+	t.Run("channel scope sets the team", func(t *testing.T) {
+		subscription := newSubscription()
+		subscription.ChannelId = th.BasicChannel.Id
+
+		saved, appErr := th.App.CreateEventSubscription(th.Context, subscription)
+		require.Nil(t, appErr)
+		assert.Equal(t, th.BasicTeam.Id, saved.TeamId)
+	})
+
+	t.Run("channel outside of the team", func(t *testing.T) {
+		otherTeam := th.CreateTeam(t)
+		subscription := newSubscription()
+		subscription.TeamId = otherTeam.Id
+		subscription.ChannelId = th.BasicChannel.Id
+
+		_, appErr := th.App.CreateEventSubscription(th.Context, subscription)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "api.event_subscription.channel_team_mismatch.app_error", appErr.Id)
+	})
+
+	t.Run("missing team", func(t *testing.T) {
+		subscription := newSubscription()
+		subscription.TeamId = model.NewId()
+
+		_, appErr := th.App.CreateEventSubscription(th.Context, subscription)
+		require.NotNil(t, appErr)
+		assert.Equal(t, http.StatusNotFound, appErr.StatusCode)
+	})
+
+	t.Run("invalid subscription", func(t *testing.T) {
+		subscription := newSubscription()
+		subscription.Events = model.StringArray{"not_an_event"}
+
+		_, appErr := th.App.CreateEventSubscription(th.Context, subscription)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "model.event_subscription.unknown_event.app_error", appErr.Id)
+	})
+}
+
+func TestUpdateEventSubscription(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t).InitBasic(t)
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableEventSubscriptions = true })
+
+	subscription, appErr := th.App.CreateEventSubscription(th.Context, &model.EventSubscription{
+		CreatorId:   th.SystemAdminUser.Id,
+		TeamId:      th.BasicTeam.Id,
+		CallbackURL: "https://example.com/events",
+		Events:      model.StringArray{model.EventSubscriptionEventPostEdited},
+	})
+	require.Nil(t, appErr)
+
+	t.Run("immutable fields are kept", func(t *testing.T) {
+		updated, appErr := th.App.UpdateEventSubscription(th.Context, subscription, &model.EventSubscription{
+			Id:          model.NewId(),
+			CreatorId:   th.BasicUser.Id,
+			CallbackURL: "https://example.com/other",
+			Secret:      model.NewId(),
+			Events:      model.StringArray{model.EventSubscriptionEventReactionAdded},
+		})
+		require.Nil(t, appErr)
+		assert.Equal(t, subscription.Id, updated.Id)
+		assert.Equal(t, subscription.CreatorId, updated.CreatorId)
+		assert.Equal(t, subscription.CreateAt, updated.CreateAt)
+		assert.Equal(t, subscription.Secret, updated.Secret)
+		assert.Equal(t, "https://example.com/other", updated.CallbackURL)
+		assert.Equal(t, model.StringArray{model.EventSubscriptionEventReactionAdded}, updated.Events)
+	})
+
+	t.Run("regenerate the secret", func(t *testing.T) {
+		previousSecret := subscription.Secret
+
+		updated, appErr := th.App.RegenEventSubscriptionSecret(subscription)
+		require.Nil(t, appErr)
+		assert.NotEqual(t, previousSecret, updated.Secret)
+
+		got, appErr := th.App.GetEventSubscription(subscription.Id)
+		require.Nil(t, appErr)
+		assert.Equal(t, updated.Secret, got.Secret)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		require.Nil(t, th.App.DeleteEventSubscription(subscription.Id))
+
+		_, appErr := th.App.GetEventSubscription(subscription.Id)
+		require.NotNil(t, appErr)
+		assert.Equal(t, http.StatusNotFound, appErr.StatusCode)
+	})
+}
+
+func TestPublishSubscribedEvent(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t).InitBasic(t)
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.ServiceSettings.EnableEventSubscriptions = true
+		*cfg.ServiceSettings.AllowedUntrustedInternalConnections = "localhost,127.0.0.1"
+	})
+
+	type receivedDelivery struct {
+		header   http.Header
+		body     []byte
+		delivery model.EventSubscriptionDelivery
+	}
+
+	// newEndpoint starts a callback endpoint that fails the first failures requests it
+	// receives.
+	newEndpoint := func(t *testing.T, failures int32) (*httptest.Server, chan receivedDelivery) {
+		received := make(chan receivedDelivery, 10)
+		var requests atomic.Int32
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if requests.Add(1) <= failures {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			var delivery model.EventSubscriptionDelivery
+			require.NoError(t, json.Unmarshal(body, &delivery))
+			received <- receivedDelivery{header: r.Header, body: body, delivery: delivery}
+		}))
+		t.Cleanup(ts.Close)
+
+		return ts, received
+	}
+
+	subscribe := func(t *testing.T, callbackURL, teamID, channelID string) *model.EventSubscription {
+		subscription, appErr := th.App.CreateEventSubscription(th.Context, &model.EventSubscription{
+			CreatorId:   th.SystemAdminUser.Id,
+			TeamId:      teamID,
+			ChannelId:   channelID,
+			CallbackURL: callbackURL,
+			Events:      model.StringArray{model.EventSubscriptionEventReactionAdded},
+		})
+		require.Nil(t, appErr)
+		t.Cleanup(func() { require.Nil(t, th.App.DeleteEventSubscription(subscription.Id)) })
+
+		return subscription
+	}
+
+	react := func(t *testing.T, emojiName string) *model.Reaction {
+		reaction, appErr := th.App.SaveReactionForPost(th.Context, &model.Reaction{
+			UserId:    th.BasicUser.Id,
+			PostId:    th.BasicPost.Id,
+			EmojiName: emojiName,
+		})
+		require.Nil(t, appErr)
+
+		return reaction
+	}
+
+	waitForDelivery := func(t *testing.T, received chan receivedDelivery, timeout time.Duration) receivedDelivery {
+		select {
+		case d := <-received:
+			return d
+		case <-time.After(timeout):
+			require.FailNow(t, "timed out waiting for the delivery")
+			return receivedDelivery{}
+		}
+	}
+
+	t.Run("signed delivery", func(t *testing.T) {
+		ts, received := newEndpoint(t, 0)
+		subscription := subscribe(t, ts.URL, "", th.BasicChannel.Id)
+
+		reaction := react(t, "smile")
+
+		d := waitForDelivery(t, received, 5*time.Second)
+		assert.Equal(t, subscription.Id, d.delivery.SubscriptionId)
+		assert.Equal(t, model.EventSubscriptionEventReactionAdded, d.delivery.Event)
+		assert.Equal(t, th.BasicTeam.Id, d.delivery.TeamId)
+		assert.Equal(t, th.BasicChannel.Id, d.delivery.ChannelId)
+		assert.Contains(t, d.delivery.Data["reaction"], reaction.EmojiName)
+		assert.Equal(t, model.EventSubscriptionEventReactionAdded, d.header.Get(model.EventSubscriptionEventHeader))
+		assert.Equal(t, d.delivery.Id, d.header.Get(model.EventSubscriptionDeliveryHeader))
+
+		timestamp := d.header.Get(model.EventSubscriptionTimestampHeader)
+		assert.Equal(t, model.ComputeEventSubscriptionSignature(subscription.Secret, timestamp, d.body), d.header.Get(model.EventSubscriptionSignatureHeader))
+	})
+
+	t.Run("subscriptions scoped elsewhere don't receive the event", func(t *testing.T) {
+		otherChannel := th.CreateChannel(t, th.BasicTeam)
+		ts, received := newEndpoint(t, 0)
+		subscribe(t, ts.URL, "", otherChannel.Id)
+		subscribe(t, ts.URL, th.CreateTeam(t).Id, "")
+
+		// A matching subscription is delivered to after the other ones are skipped.
+		matching, matchingReceived := newEndpoint(t, 0)
+		subscribe(t, matching.URL, th.BasicTeam.Id, "")
+
+		react(t, "heart")
+
+		waitForDelivery(t, matchingReceived, 5*time.Second)
+		select {
+		case <-received:
+			assert.Fail(t, "the event shouldn't have been delivered")
+		case <-time.After(100 * time.Millisecond):
+		}
+	})
+
+	t.Run("failed deliveries are retried", func(t *testing.T) {
+		ts, received := newEndpoint(t, 1)
+		subscribe(t, ts.URL, th.BasicTeam.Id, "")
+
+		react(t, "+1")
+
+		d := waitForDelivery(t, received, eventSubscriptionRetryBackoff+5*time.Second)
+		assert.Equal(t, model.EventSubscriptionEventReactionAdded, d.delivery.Event)
+	})
+}
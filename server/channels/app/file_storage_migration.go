@@ -0,0 +1,82 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"net/http"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/platform/shared/filestore"
+)
+
+// FileStorageMigrationBackends returns the backends that a storage migration
+// copies files between. Files are copied from the storage in use to the
+// migration target until the migration is cut over. Afterwards, during the
+// dual-read period, they're copied back from the previous storage, which is
+// then the migration target, to pick up what was written there in the
+// meantime.
+func (a *App) FileStorageMigrationBackends() (source filestore.FileBackend, destination filestore.FileBackend, appErr *model.AppError) {
+	fileSettings := &a.Config().FileSettings
+	if *fileSettings.MigrationDriverName == "" {
+		return nil, nil, model.NewAppError("FileStorageMigrationBackends", "app.file_storage_migration.not_configured.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	license := a.Srv().License()
+	complianceEnabled := license != nil && *license.Features.Compliance
+	insecure := a.Config().ServiceSettings.EnableInsecureOutgoingConnections != nil && *a.Config().ServiceSettings.EnableInsecureOutgoingConnections
+
+	current, err := filestore.NewFileBackend(filestore.NewFileBackendSettingsFromConfig(fileSettings, complianceEnabled, insecure))
+	if err != nil {
+		return nil, nil, model.NewAppError("FileStorageMigrationBackends", "api.file.no_driver.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	target, err := filestore.NewFileBackend(filestore.NewMigrationFileBackendSettingsFromConfig(fileSettings, complianceEnabled, insecure))
+	if err != nil {
+		return nil, nil, model.NewAppError("FileStorageMigrationBackends", "api.file.no_driver.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	if *fileSettings.MigrationDualRead {
+		return target, current, nil
+	}
+	return current, target, nil
+}
+
+// CutOverFileStorageMigration switches the file storage to the migration
+// target, keeping the previous storage configured as the target with dual
+// reads enabled, so that files not yet copied can still be served from it.
+// The migration job needs to have completed successfully beforehand, and the
+// server needs to be restarted for the change to take effect.
+func (a *App) CutOverFileStorageMigration(rctx request.CTX) *model.AppError {
+	fileSettings := a.Config().FileSettings
+	if *fileSettings.MigrationDriverName == "" {
+		return model.NewAppError("CutOverFileStorageMigration", "app.file_storage_migration.not_configured.app_error", nil, "", http.StatusBadRequest)
+	}
+	if *fileSettings.MigrationDualRead {
+		return model.NewAppError("CutOverFileStorageMigration", "app.file_storage_migration.already_cut_over.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	job, err := a.Srv().Store().Job().GetNewestJobByStatusesAndType([]string{model.JobStatusSuccess, model.JobStatusPending, model.JobStatusInProgress, model.JobStatusError}, model.JobTypeFileStorageMigration)
+	if err != nil || job.Status != model.JobStatusSuccess {
+		return model.NewAppError("CutOverFileStorageMigration", "app.file_storage_migration.not_completed.app_error", nil, "", http.StatusBadRequest).Wrap(err)
+	}
+
+	_, target, appErr := a.FileStorageMigrationBackends()
+	if appErr != nil {
+		return appErr
+	}
+	if err := target.TestConnection(); err != nil {
+		return connectionTestErrorToAppError(err)
+	}
+
+	cfg := a.Config().Clone()
+	cfg.FileSettings.SwapMigrationTarget()
+	cfg.FileSettings.MigrationDualRead = model.NewPointer(true)
+	if _, _, appErr := a.SaveConfig(cfg, true); appErr != nil {
+		return appErr
+	}
+
+	rctx.Logger().Info("Cut over to the new file storage. Restart the server for the change to take effect.")
+	return nil
+}
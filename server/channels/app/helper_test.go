@@ -271,6 +271,8 @@ func SetupWithStoreMock(tb testing.TB) *TestHelper {
 	statusMock.On("Get", "user1").Return(&model.Status{UserId: "user1", Status: model.StatusOnline}, nil)
 	statusMock.On("UpdateLastActivityAt", "user1", mock.Anything).Return(nil)
 	statusMock.On("SaveOrUpdate", mock.AnythingOfType("*model.Status")).Return(nil)
+	workingHoursMock := mocks.WorkingHoursStore{}
+	workingHoursMock.On("GetEnabled", mock.AnythingOfType("string"), mock.AnythingOfType("int")).Return([]*model.WorkingHours{}, nil)
 
 	pluginMock := mocks.PluginStore{}
 	pluginMock.On("Get", mock.AnythingOfType("string"), mock.AnythingOfType("string")).Return(&model.PluginKeyValue{}, nil)
@@ -278,6 +280,7 @@ func SetupWithStoreMock(tb testing.TB) *TestHelper {
 	emptyMockStore := mocks.Store{}
 	emptyMockStore.On("Close").Return(nil)
 	emptyMockStore.On("Status").Return(&statusMock)
+	emptyMockStore.On("WorkingHours").Return(&workingHoursMock)
 	emptyMockStore.On("Plugin").Return(&pluginMock).Maybe()
 	th.App.Srv().SetStore(&emptyMockStore)
 
@@ -292,9 +295,12 @@ func SetupEnterpriseWithStoreMock(tb testing.TB) *TestHelper {
 	statusMock.On("Get", "user1").Return(&model.Status{UserId: "user1", Status: model.StatusOnline}, nil)
 	statusMock.On("UpdateLastActivityAt", "user1", mock.Anything).Return(nil)
 	statusMock.On("SaveOrUpdate", mock.AnythingOfType("*model.Status")).Return(nil)
+	workingHoursMock := mocks.WorkingHoursStore{}
+	workingHoursMock.On("GetEnabled", mock.AnythingOfType("string"), mock.AnythingOfType("int")).Return([]*model.WorkingHours{}, nil)
 	emptyMockStore := mocks.Store{}
 	emptyMockStore.On("Close").Return(nil)
 	emptyMockStore.On("Status").Return(&statusMock)
+	emptyMockStore.On("WorkingHours").Return(&workingHoursMock)
 	th.App.Srv().SetStore(&emptyMockStore)
 	return th
 }
@@ -0,0 +1,468 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/format"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"log"
+	"os"
+	"path"
+	"slices"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+// reservedNames are the names used by the generated methods, which the parameters are renamed from.
+var reservedNames = map[string]bool{
+	"a":    true,
+	"ctx":  true,
+	"span": true,
+}
+
+var predeclaredTypes = map[string]bool{
+	"any": true, "bool": true, "byte": true, "comparable": true, "complex64": true, "complex128": true,
+	"error": true, "float32": true, "float64": true, "int": true, "int8": true, "int16": true, "int32": true,
+	"int64": true, "rune": true, "string": true, "uint": true, "uint8": true, "uint16": true, "uint32": true,
+	"uint64": true, "uintptr": true,
+}
+
+func main() {
+	metadata, err := extractAppMetadata()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := buildLayer(metadata, "app_iface.go.tmpl", "app_iface.go"); err != nil {
+		log.Fatal(err)
+	}
+	if err := buildLayer(metadata, "tracing_layer.go.tmpl", path.Join("tracinglayer", "tracinglayer.go")); err != nil {
+		log.Fatal(err)
+	}
+}
+
+type methodParam struct {
+	Name string
+	// Type is the type of the parameter in the app package, and QualifiedType outside of it.
+	Type          string
+	QualifiedType string
+}
+
+type methodResult struct {
+	Name          string
+	Type          string
+	QualifiedType string
+}
+
+type methodData struct {
+	Name    string
+	Doc     []string
+	Params  []methodParam
+	Results []methodResult
+}
+
+type appMetadata struct {
+	Methods []methodData
+	// Imports are the packages used by the signatures of the methods, by name.
+	Imports map[string]string
+}
+
+// extractAppMetadata collects the exported methods of App. The methods whose signature uses an unexported type of
+// the app package are left out, as they can't be implemented outside of it.
+func extractAppMetadata() (*appMetadata, error) {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, ".", func(info os.FileInfo) bool {
+		if strings.HasSuffix(info.Name(), "_test.go") || info.Name() == "app_iface.go" {
+			return false
+		}
+		match, err := build.Default.MatchFile(".", info.Name())
+		return err == nil && match
+	}, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+	pkg, ok := pkgs["app"]
+	if !ok {
+		return nil, fmt.Errorf("unable to find the app package")
+	}
+
+	metadata := &appMetadata{Imports: map[string]string{}}
+	for fileName, file := range pkg.Files {
+		fileImports := map[string]string{}
+		for _, spec := range file.Imports {
+			importPath := strings.Trim(spec.Path.Value, `"`)
+			name := packageName(importPath)
+			if spec.Name != nil {
+				name = spec.Name.Name
+			}
+			fileImports[name] = importPath
+		}
+
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || !fn.Name.IsExported() || !isAppReceiver(fn.Recv) {
+				continue
+			}
+
+			method, used, ok := extractMethodData(fset, fn)
+			if !ok {
+				continue
+			}
+			for _, name := range used {
+				importPath, ok := fileImports[name]
+				if !ok {
+					return nil, fmt.Errorf("%s: unable to find the import of %s used by %s", fileName, name, fn.Name.Name)
+				}
+				if existing, ok := metadata.Imports[name]; ok && existing != importPath {
+					return nil, fmt.Errorf("%s: %s is imported as both %s and %s", fileName, name, existing, importPath)
+				}
+				metadata.Imports[name] = importPath
+			}
+			metadata.Methods = append(metadata.Methods, method)
+		}
+	}
+
+	sort.Slice(metadata.Methods, func(i, j int) bool {
+		return metadata.Methods[i].Name < metadata.Methods[j].Name
+	})
+	return metadata, nil
+}
+
+func isAppReceiver(recv *ast.FieldList) bool {
+	if recv == nil || len(recv.List) != 1 {
+		return false
+	}
+	star, ok := recv.List[0].Type.(*ast.StarExpr)
+	if !ok {
+		return false
+	}
+	ident, ok := star.X.(*ast.Ident)
+	return ok && ident.Name == "App"
+}
+
+// packageName guesses the name of a package from its import path, e.g. yaml for gopkg.in/yaml.v3.
+func packageName(importPath string) string {
+	name := path.Base(importPath)
+	if len(name) > 1 && name[0] == 'v' && strings.Trim(name[1:], "0123456789") == "" {
+		name = path.Base(path.Dir(importPath))
+	}
+	name, _, _ = strings.Cut(name, ".")
+	return strings.ReplaceAll(name, "-", "_")
+}
+
+// extractMethodData returns the signature of the method, and the packages it uses. It returns false if the
+// signature uses an unexported type of the app package.
+func extractMethodData(fset *token.FileSet, fn *ast.FuncDecl) (methodData, []string, bool) {
+	method := methodData{Name: fn.Name.Name}
+	if fn.Doc != nil {
+		for _, comment := range fn.Doc.List {
+			method.Doc = append(method.Doc, comment.Text)
+		}
+	}
+
+	var used []string
+	exported := true
+	types := func(expr ast.Expr) (string, string) {
+		walkType(expr, func(ident *ast.Ident) {
+			if !predeclaredTypes[ident.Name] && !ident.IsExported() {
+				exported = false
+			}
+		}, func(name string) {
+			if !slices.Contains(used, name) {
+				used = append(used, name)
+			}
+		})
+		return typeString(fset, expr, false), typeString(fset, expr, true)
+	}
+
+	names := map[string]bool{}
+	for i, field := range fn.Type.Params.List {
+		typ, qualifiedType := types(field.Type)
+		fieldNames := field.Names
+		if len(fieldNames) == 0 {
+			fieldNames = []*ast.Ident{{Name: "_"}}
+		}
+		for _, ident := range fieldNames {
+			name := ident.Name
+			if name == "_" {
+				name = fmt.Sprintf("p%d", i)
+			}
+			for reservedNames[name] || names[name] {
+				name += "Param"
+			}
+			names[name] = true
+			method.Params = append(method.Params, methodParam{Name: name, Type: typ, QualifiedType: qualifiedType})
+		}
+	}
+
+	if fn.Type.Results != nil {
+		errors := 0
+		for _, field := range fn.Type.Results.List {
+			typ, qualifiedType := types(field.Type)
+			count := max(len(field.Names), 1)
+			for range count {
+				name := fmt.Sprintf("resultVar%d", len(method.Results))
+				if len(method.Results) == 0 {
+					name = "result"
+				}
+				if isError(typ) {
+					name = "err"
+					if errors > 0 {
+						name = fmt.Sprintf("err%d", errors)
+					}
+					errors++
+				}
+				method.Results = append(method.Results, methodResult{Name: name, Type: typ, QualifiedType: qualifiedType})
+			}
+		}
+	}
+
+	return method, used, exported
+}
+
+func isError(typeName string) bool {
+	return typeName == "error" || typeName == "*model.AppError"
+}
+
+// walkType calls local for the identifiers of the app package used by the type, and imported for the packages it
+// uses.
+func walkType(expr ast.Expr, local func(*ast.Ident), imported func(string)) {
+	fields := func(list *ast.FieldList) {
+		if list == nil {
+			return
+		}
+		for _, field := range list.List {
+			walkType(field.Type, local, imported)
+		}
+	}
+
+	switch e := expr.(type) {
+	case *ast.Ident:
+		local(e)
+	case *ast.SelectorExpr:
+		if x, ok := e.X.(*ast.Ident); ok {
+			imported(x.Name)
+		}
+	case *ast.StarExpr:
+		walkType(e.X, local, imported)
+	case *ast.ParenExpr:
+		walkType(e.X, local, imported)
+	case *ast.Ellipsis:
+		walkType(e.Elt, local, imported)
+	case *ast.ArrayType:
+		walkType(e.Elt, local, imported)
+	case *ast.MapType:
+		walkType(e.Key, local, imported)
+		walkType(e.Value, local, imported)
+	case *ast.ChanType:
+		walkType(e.Value, local, imported)
+	case *ast.FuncType:
+		fields(e.Params)
+		fields(e.Results)
+	case *ast.StructType:
+		fields(e.Fields)
+	case *ast.InterfaceType:
+		fields(e.Methods)
+	case *ast.IndexExpr:
+		walkType(e.X, local, imported)
+		walkType(e.Index, local, imported)
+	case *ast.IndexListExpr:
+		walkType(e.X, local, imported)
+		for _, index := range e.Indices {
+			walkType(index, local, imported)
+		}
+	}
+}
+
+// typeString prints the type, with the types of the app package qualified if qualify is set.
+func typeString(fset *token.FileSet, expr ast.Expr, qualify bool) string {
+	if qualify {
+		expr = qualifyType(expr)
+	}
+	var buf bytes.Buffer
+	if err := printer.Fprint(&buf, fset, expr); err != nil {
+		log.Fatal(err)
+	}
+	return buf.String()
+}
+
+// qualifyType returns a copy of the type with the exported identifiers of the app package prefixed by its name.
+func qualifyType(expr ast.Expr) ast.Expr {
+	qualifyFields := func(list *ast.FieldList) *ast.FieldList {
+		if list == nil {
+			return nil
+		}
+		qualified := &ast.FieldList{}
+		for _, field := range list.List {
+			qualified.List = append(qualified.List, &ast.Field{Names: field.Names, Type: qualifyType(field.Type)})
+		}
+		return qualified
+	}
+
+	switch e := expr.(type) {
+	case *ast.Ident:
+		if predeclaredTypes[e.Name] || !e.IsExported() {
+			return e
+		}
+		return &ast.SelectorExpr{X: ast.NewIdent("app"), Sel: ast.NewIdent(e.Name)}
+	case *ast.StarExpr:
+		return &ast.StarExpr{X: qualifyType(e.X)}
+	case *ast.ParenExpr:
+		return &ast.ParenExpr{X: qualifyType(e.X)}
+	case *ast.Ellipsis:
+		return &ast.Ellipsis{Elt: qualifyType(e.Elt)}
+	case *ast.ArrayType:
+		return &ast.ArrayType{Len: e.Len, Elt: qualifyType(e.Elt)}
+	case *ast.MapType:
+		return &ast.MapType{Key: qualifyType(e.Key), Value: qualifyType(e.Value)}
+	case *ast.ChanType:
+		return &ast.ChanType{Dir: e.Dir, Value: qualifyType(e.Value)}
+	case *ast.FuncType:
+		return &ast.FuncType{Params: qualifyFields(e.Params), Results: qualifyFields(e.Results)}
+	case *ast.StructType:
+		return &ast.StructType{Fields: qualifyFields(e.Fields)}
+	case *ast.InterfaceType:
+		return &ast.InterfaceType{Methods: qualifyFields(e.Methods)}
+	case *ast.IndexExpr:
+		return &ast.IndexExpr{X: qualifyType(e.X), Index: qualifyType(e.Index)}
+	case *ast.IndexListExpr:
+		indices := make([]ast.Expr, 0, len(e.Indices))
+		for _, index := range e.Indices {
+			indices = append(indices, qualifyType(index))
+		}
+		return &ast.IndexListExpr{X: qualifyType(e.X), Indices: indices}
+	}
+	return expr
+}
+
+func buildLayer(metadata *appMetadata, templateFile, outputFile string) error {
+	funcs := template.FuncMap{
+		"imports": func(extra ...string) string {
+			imports := map[string]string{}
+			for name, importPath := range metadata.Imports {
+				imports[name] = importPath
+			}
+			for _, importPath := range extra {
+				imports[packageName(importPath)] = importPath
+			}
+
+			// The standard library, the third party packages and the packages of the module are grouped.
+			groups := make([][]string, 3)
+			for name, importPath := range imports {
+				spec := fmt.Sprintf("%q", importPath)
+				if packageName(importPath) != name {
+					spec = name + " " + spec
+				}
+				switch {
+				case strings.HasPrefix(importPath, "github.com/mattermost/mattermost/"):
+					groups[2] = append(groups[2], importPath+"\x00"+spec)
+				case strings.Contains(strings.Split(importPath, "/")[0], "."):
+					groups[1] = append(groups[1], importPath+"\x00"+spec)
+				default:
+					groups[0] = append(groups[0], importPath+"\x00"+spec)
+				}
+			}
+			var blocks []string
+			for _, group := range groups {
+				if len(group) == 0 {
+					continue
+				}
+				sort.Strings(group)
+				for i, entry := range group {
+					_, group[i], _ = strings.Cut(entry, "\x00")
+				}
+				blocks = append(blocks, strings.Join(group, "\n"))
+			}
+			return strings.Join(blocks, "\n\n")
+		},
+		"joinParamsWithType": func(params []methodParam, qualified bool) string {
+			paramsWithType := make([]string, 0, len(params))
+			for _, param := range params {
+				typ := param.Type
+				if qualified {
+					typ = param.QualifiedType
+				}
+				paramsWithType = append(paramsWithType, param.Name+" "+typ)
+			}
+			return strings.Join(paramsWithType, ", ")
+		},
+		"joinParams": func(params []methodParam) string {
+			names := make([]string, 0, len(params))
+			for _, param := range params {
+				if strings.HasPrefix(param.Type, "...") {
+					names = append(names, param.Name+"...")
+				} else {
+					names = append(names, param.Name)
+				}
+			}
+			return strings.Join(names, ", ")
+		},
+		"joinResultsForSignature": func(results []methodResult, qualified bool) string {
+			types := make([]string, 0, len(results))
+			for _, result := range results {
+				if qualified {
+					types = append(types, result.QualifiedType)
+				} else {
+					types = append(types, result.Type)
+				}
+			}
+			if len(types) <= 1 {
+				return strings.Join(types, "")
+			}
+			return "(" + strings.Join(types, ", ") + ")"
+		},
+		"resultVars": func(results []methodResult) string {
+			names := make([]string, 0, len(results))
+			for _, result := range results {
+				names = append(names, result.Name)
+			}
+			return strings.Join(names, ", ")
+		},
+		"errorResults": func(results []methodResult) []string {
+			var names []string
+			for _, result := range results {
+				if isError(result.Type) {
+					names = append(names, result.Name)
+				}
+			}
+			return names
+		},
+		// An accessor, with no parameters and no error, e.g. Config, isn't worth a span.
+		"isAccessor": func(method methodData) bool {
+			for _, result := range method.Results {
+				if isError(result.Type) {
+					return false
+				}
+			}
+			return len(method.Params) == 0
+		},
+		"requestParam": func(params []methodParam) string {
+			for _, param := range params {
+				if param.Type == "request.CTX" {
+					return param.Name
+				}
+			}
+			return ""
+		},
+	}
+
+	t := template.Must(template.New(templateFile).Funcs(funcs).ParseFiles(path.Join("layer_generators", templateFile)))
+	var out bytes.Buffer
+	if err := t.Execute(&out, metadata); err != nil {
+		return err
+	}
+	code, err := format.Source(out.Bytes())
+	if err != nil {
+		return fmt.Errorf("unable to format %s: %w", outputFile, err)
+	}
+
+	return os.WriteFile(outputFile, code, 0644)
+}
@@ -30,6 +30,16 @@ func (a *App) canSendPushNotifications() bool {
 		return false
 	}
 
+	if *a.Config().EmailSettings.EnableWebPush {
+		return true
+	}
+
+	return a.canSendToPushProxy()
+}
+
+// canSendToPushProxy returns whether the notifications of the mobile apps can be sent through
+// the configured push proxy. Web push notifications are sent directly, and don't depend on it.
+func (a *App) canSendToPushProxy() bool {
 	pushServer := *a.Config().EmailSettings.PushNotificationServer
 	// Check for MHPNS servers (both current and legacy DNS aliases)
 	isMHPNSServer := pushServer == model.MHPNS ||
@@ -93,6 +103,16 @@ func (a *App) SendNotifications(rctx request.CTX, post *model.Post, team *model.
 		}()
 	}
 
+	var rchan chan store.StoreResult[map[string][]*model.NotificationRule]
+	if !post.IsSystemMessage() && post.GetProp(model.PostPropsForceNotification) == nil {
+		rchan = make(chan store.StoreResult[map[string][]*model.NotificationRule], 1)
+		go func() {
+			rulesByUser, err := a.Srv().Store().NotificationRule().GetEnabledForChannel(channel.Id)
+			rchan <- store.StoreResult[map[string][]*model.NotificationRule]{Data: rulesByUser, NErr: err}
+			close(rchan)
+		}()
+	}
+
 	var tchan chan store.StoreResult[[]string]
 	if isCRTAllowed && post.RootId != "" {
 		tchan = make(chan store.StoreResult[[]string], 1)
@@ -357,6 +377,17 @@ func (a *App) SendNotifications(rctx request.CTX, post *model.Post, team *model.
 		}
 	}
 
+	// The first matching rule of each user decides how they are notified, in place of their
+	// notification preferences.
+	var notificationRuleActions map[string]*model.NotificationRuleAction
+	if rchan != nil {
+		if result := <-rchan; result.NErr != nil {
+			logNotificationRuleError(rctx, post, result.NErr)
+		} else {
+			notificationRuleActions = a.getNotificationRuleActions(post, sender, profileMap, followers, result.Data)
+		}
+	}
+
 	notificationsForCRT := &CRTNotifiers{}
 	if isCRTAllowed && post.RootId != "" {
 		for uid := range followers {
@@ -388,6 +419,12 @@ func (a *App) SendNotifications(rctx request.CTX, post *model.Post, team *model.
 			mlog.String("post_id", post.Id),
 		)
 		emailRecipients := append(mentionedUsersList, notificationsForCRT.Email...)
+		emailRecipients = withoutNotificationRuleUsers(emailRecipients, notificationRuleActions)
+		for id, action := range notificationRuleActions {
+			if action.SendsEmail() {
+				emailRecipients = append(emailRecipients, id)
+			}
+		}
 		emailRecipients = model.RemoveDuplicateStrings(emailRecipients)
 
 		for _, id := range emailRecipients {
@@ -419,7 +456,14 @@ func (a *App) SendNotifications(rctx request.CTX, post *model.Post, team *model.
 				continue
 			}
 
-			if a.userAllowsEmail(rctx, profileMap[id], channelMemberNotifyPropsMap[id], post) {
+			var allowsEmail bool
+			if action, ok := notificationRuleActions[id]; ok {
+				allowsEmail = a.notificationRuleAllowsEmail(rctx, profileMap[id], post, action)
+			} else {
+				allowsEmail = a.userAllowsEmail(rctx, profileMap[id], channelMemberNotifyPropsMap[id], post)
+			}
+
+			if allowsEmail {
 				senderProfileImage, _, err := a.GetProfileImage(sender)
 				if err != nil {
 					rctx.Logger().Warn("Unable to get the sender user profile image.", mlog.String("user_id", sender.Id), mlog.Err(err))
@@ -514,7 +558,7 @@ func (a *App) SendNotifications(rctx request.CTX, post *model.Post, team *model.
 			mlog.String("post_id", post.Id),
 		)
 
-		for _, id := range mentionedUsersList {
+		for _, id := range withoutNotificationRuleUsers(mentionedUsersList, notificationRuleActions) {
 			if profileMap[id] == nil {
 				a.CountNotificationReason(model.NotificationStatusError, model.NotificationTypePush, model.NotificationReasonMissingProfile, model.NotificationNoPlatform)
 				rctx.Logger().LogM(mlog.MlvlNotificationError, "Missing profile",
@@ -566,7 +610,7 @@ func (a *App) SendNotifications(rctx request.CTX, post *model.Post, team *model.
 			}
 		}
 
-		for _, id := range allActivityPushUserIds {
+		for _, id := range withoutNotificationRuleUsers(allActivityPushUserIds, notificationRuleActions) {
 			if profileMap[id] == nil {
 				a.CountNotificationReason(model.NotificationStatusError, model.NotificationTypePush, model.NotificationReasonMissingProfile, model.NotificationNoPlatform)
 				rctx.Logger().LogM(mlog.MlvlNotificationError, "Missing profile",
@@ -610,7 +654,7 @@ func (a *App) SendNotifications(rctx request.CTX, post *model.Post, team *model.
 			}
 		}
 
-		for _, id := range notificationsForCRT.Push {
+		for _, id := range withoutNotificationRuleUsers(notificationsForCRT.Push, notificationRuleActions) {
 			if profileMap[id] == nil {
 				a.CountNotificationReason(model.NotificationStatusError, model.NotificationTypePush, model.NotificationReasonMissingProfile, model.NotificationNoPlatform)
 				rctx.Logger().LogM(mlog.MlvlNotificationError, "Missing profile",
@@ -653,6 +697,40 @@ func (a *App) SendNotifications(rctx request.CTX, post *model.Post, team *model.
 			}
 		}
 
+		for id, action := range notificationRuleActions {
+			if !action.SendsPush() {
+				continue
+			}
+
+			var status *model.Status
+			var err *model.AppError
+			if status, err = a.GetStatus(id); err != nil {
+				status = &model.Status{UserId: id, Status: model.StatusOffline, Manual: false, LastActivityAt: 0, ActiveChannel: ""}
+			}
+
+			isCRTReply := post.RootId != "" && isCRTAllowed && a.IsCRTEnabledForUser(rctx, id)
+			if a.notificationRuleAllowsPush(rctx, profileMap[id], status, post, action, isCRTReply) {
+				mentionType := mentions.Mentions[id]
+
+				replyToThreadType := ""
+				if isCRTReply {
+					replyToThreadType = model.CommentsNotifyCRT
+				} else if mentionType == ThreadMention {
+					replyToThreadType = model.CommentsNotifyAny
+				} else if mentionType == CommentMention {
+					replyToThreadType = model.CommentsNotifyRoot
+				}
+
+				a.sendPushNotification(
+					notification,
+					profileMap[id],
+					mentionType == KeywordMention || mentionType == ChannelMention || mentionType == DMMention,
+					mentionType == ChannelMention,
+					replyToThreadType,
+				)
+			}
+		}
+
 		rctx.Logger().LogM(mlog.MlvlNotificationTrace, "Finished sending push notifications",
 			mlog.String("type", model.NotificationTypePush),
 			mlog.String("sender_id", sender.Id),
@@ -701,6 +779,14 @@ func (a *App) SendNotifications(rctx request.CTX, post *model.Post, team *model.
 		useAddFollowersHook(message, notificationsForCRT.Desktop)
 	}
 
+	if len(notificationRuleActions) > 0 {
+		desktop := make(map[string]string, len(notificationRuleActions))
+		for id, action := range notificationRuleActions {
+			desktop[id] = action.DesktopDecision()
+		}
+		useNotificationRulesHook(message, desktop)
+	}
+
 	// Collect user IDs of whom we want to acknowledge the websocket event for notification metrics
 	usersToAck := []string{}
 	for id, profile := range profileMap {
@@ -1132,6 +1218,16 @@ func (a *App) userAllowsEmail(rctx request.CTX, user *model.User, channelMemberN
 		}
 	}
 
+	if !userAllowsEmails {
+		return false
+	}
+
+	return a.statusAllowsEmail(rctx, user, post)
+}
+
+// statusAllowsEmail returns whether the status and quiet hours of the user allow an email
+// notification of the post.
+func (a *App) statusAllowsEmail(rctx request.CTX, user *model.User, post *model.Post) bool {
 	var status *model.Status
 	var err *model.AppError
 	if status, err = a.GetStatus(user.Id); err != nil {
@@ -1147,7 +1243,18 @@ func (a *App) userAllowsEmail(rctx request.CTX, user *model.User, channelMemberN
 	autoResponderRelated := status.Status == model.StatusOutOfOffice || post.Type == model.PostTypeAutoResponder
 	emailNotificationsAllowedForStatus := status.Status != model.StatusOnline && status.Status != model.StatusDnd
 
-	return userAllowsEmails && emailNotificationsAllowedForStatus && user.DeleteAt == 0 && !autoResponderRelated
+	if workingHours := a.getQuietHours(rctx, user.Id); workingHours != nil {
+		if !quietHoursBypassed(workingHours, post) {
+			return false
+		}
+
+		// Urgent posts bypass the status set for the quiet hours too.
+		if quietStatusBypassed(workingHours, status) {
+			emailNotificationsAllowedForStatus = true
+		}
+	}
+
+	return emailNotificationsAllowedForStatus && user.DeleteAt == 0 && !autoResponderRelated
 }
 
 func (a *App) sendNoUsersNotifiedByGroupInChannel(rctx request.CTX, sender *model.User, post *model.Post, channel *model.Channel, group *model.Group) {
@@ -11,6 +11,7 @@ import (
 	"io"
 	"net/http"
 	"runtime"
+	"strconv"
 	"strings"
 	"sync"
 
@@ -148,6 +149,9 @@ func (a *App) sendPushNotificationToAllSessions(rctx request.CTX, msg *model.Pus
 		)
 	}
 
+	webPushEnabled := *a.Config().EmailSettings.EnableWebPush
+	pushProxyEnabled := a.canSendToPushProxy()
+
 	for _, session := range sessions {
 		// Don't send notifications to this session if it's expired or we want to skip it
 		if session.IsExpired() || (skipSessionId != "" && skipSessionId == session.Id) {
@@ -165,6 +169,10 @@ func (a *App) sendPushNotificationToAllSessions(rctx request.CTX, msg *model.Pus
 		// We made a copy to avoid decoding and parsing all the time
 		tmpMessage := msg.DeepCopy()
 		tmpMessage.SetDeviceIdAndPlatform(session.DeviceId)
+		isWebPush := tmpMessage.Platform == model.PushNotifyWebPush
+		if (isWebPush && !webPushEnabled) || (!isWebPush && !pushProxyEnabled) {
+			continue
+		}
 		tmpMessage.AckId = model.NewId()
 		signature, err := jwt.NewWithClaims(jwt.SigningMethodES256, pushJWTClaims{
 			AckId:    tmpMessage.AckId,
@@ -184,7 +192,11 @@ func (a *App) sendPushNotificationToAllSessions(rctx request.CTX, msg *model.Pus
 		}
 		tmpMessage.Signature = signature
 
-		err = a.sendToPushProxy(rctx, tmpMessage, session)
+		if isWebPush {
+			err = a.sendToWebPush(rctx, tmpMessage, session)
+		} else {
+			err = a.sendToPushProxy(rctx, tmpMessage, session)
+		}
 		if err != nil {
 			reason := model.NotificationReasonPushProxySendError
 			if err.Error() == notificationErrorRemoveDevice {
@@ -557,6 +569,11 @@ func (a *App) SendAckToPushProxy(rctx request.CTX, ack *model.PushNotificationAc
 		mlog.String("status", model.PushReceived),
 	)
 
+	// Web push notifications are delivered without the push proxy, which has nothing to acknowledge.
+	if ack.ClientPlatform == model.PushNotifyWebPush {
+		return nil
+	}
+
 	ackJSON, err := json.Marshal(ack)
 	if err != nil {
 		return fmt.Errorf("failed to encode to JSON: %w", err)
@@ -621,7 +638,33 @@ func (a *App) ShouldSendPushNotification(rctx request.CTX, user *model.User, cha
 		return false
 	}
 
-	if statusAllowedReason := doesStatusAllowPushNotification(user.NotifyProps, status, post.ChannelId, false); statusAllowedReason != "" {
+	return a.statusAllowsPushNotification(rctx, user, status, post, false)
+}
+
+// statusAllowsPushNotification returns whether the status and quiet hours of the user allow a push
+// notification of the post.
+func (a *App) statusAllowsPushNotification(rctx request.CTX, user *model.User, status *model.Status, post *model.Post, isCRT bool) bool {
+	if workingHours := a.getQuietHours(rctx, user.Id); workingHours != nil {
+		if !quietHoursBypassed(workingHours, post) {
+			a.CountNotificationReason(model.NotificationStatusNotSent, model.NotificationTypePush, model.NotificationReasonQuietHours, model.NotificationNoPlatform)
+			rctx.Logger().LogM(mlog.MlvlNotificationDebug, "Notification not sent - quiet hours",
+				mlog.String("type", model.NotificationTypePush),
+				mlog.String("post_id", post.Id),
+				mlog.String("status", model.NotificationStatusNotSent),
+				mlog.String("reason", model.NotificationReasonQuietHours),
+				mlog.String("sender_id", post.UserId),
+				mlog.String("receiver_id", user.Id),
+			)
+			return false
+		}
+
+		// Urgent posts bypass the status set for the quiet hours too.
+		if quietStatusBypassed(workingHours, status) {
+			return true
+		}
+	}
+
+	if statusAllowedReason := doesStatusAllowPushNotification(user.NotifyProps, status, post.ChannelId, isCRT); statusAllowedReason != "" {
 		a.CountNotificationReason(model.NotificationStatusNotSent, model.NotificationTypePush, statusAllowedReason, model.NotificationNoPlatform)
 		rctx.Logger().LogM(mlog.MlvlNotificationDebug, "Notification not sent - status",
 			mlog.String("type", model.NotificationTypePush),
@@ -748,6 +791,12 @@ func (a *App) SendTestPushNotification(rctx request.CTX, deviceID string) string
 	}
 	msg.SetDeviceIdAndPlatform(deviceID)
 
+	// Web push subscriptions are only known to the browsers, which get the test notification
+	// through the Push API when subscribing.
+	if msg.Platform == model.PushNotifyWebPush {
+		return strconv.FormatBool(*a.Config().EmailSettings.EnableWebPush)
+	}
+
 	pushResponse, err := a.rawSendToPushProxy(msg)
 	if err != nil {
 		a.CountNotificationReason(model.NotificationStatusError, model.NotificationTypePush, model.NotificationReasonPushProxySendError, msg.Platform)
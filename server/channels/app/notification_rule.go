@@ -0,0 +1,234 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+const (
+	// notificationRuleTestPeriod and notificationRuleTestMaxPosts bound the recent posts a rule is
+	// tested against.
+	notificationRuleTestPeriod   = 7 * 24 * time.Hour
+	notificationRuleTestMaxPosts = 200
+)
+
+func (a *App) GetNotificationRules(userID string) ([]*model.NotificationRule, *model.AppError) {
+	rules, err := a.Srv().Store().NotificationRule().GetForUser(userID)
+	if err != nil {
+		return nil, model.NewAppError("GetNotificationRules", "app.notification_rule.get.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return rules, nil
+}
+
+// UpdateNotificationRules replaces the rules of the user, in the given order. Rules keep their id
+// when it matches one of the existing rules of the user, and get a new one otherwise.
+func (a *App) UpdateNotificationRules(userID string, rules []*model.NotificationRule) ([]*model.NotificationRule, *model.AppError) {
+	if len(rules) > model.NotificationRulesMaxPerUser {
+		return nil, model.NewAppError("UpdateNotificationRules", "app.notification_rule.update.too_many.app_error", map[string]any{"Max": model.NotificationRulesMaxPerUser}, "", http.StatusBadRequest)
+	}
+
+	existing, appErr := a.GetNotificationRules(userID)
+	if appErr != nil {
+		return nil, appErr
+	}
+	existingByID := make(map[string]*model.NotificationRule, len(existing))
+	for _, rule := range existing {
+		existingByID[rule.Id] = rule
+	}
+
+	seen := make(map[string]bool, len(rules))
+	for _, rule := range rules {
+		if rule == nil {
+			return nil, model.NewAppError("UpdateNotificationRules", "app.notification_rule.update.invalid.app_error", nil, "", http.StatusBadRequest)
+		}
+
+		if previous, ok := existingByID[rule.Id]; ok && !seen[rule.Id] {
+			rule.CreateAt = previous.CreateAt
+		} else {
+			rule.Id = ""
+			rule.CreateAt = 0
+		}
+		seen[rule.Id] = true
+	}
+
+	saved, err := a.Srv().Store().NotificationRule().ReplaceForUser(userID, rules)
+	if err != nil {
+		var appErr *model.AppError
+		switch {
+		case errors.As(err, &appErr):
+			return nil, appErr
+		default:
+			return nil, model.NewAppError("UpdateNotificationRules", "app.notification_rule.update.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+	}
+
+	return saved, nil
+}
+
+// TestNotificationRule returns the recent posts of the channels of the user the rule matches,
+// whether it is enabled or not.
+func (a *App) TestNotificationRule(rctx request.CTX, userID string, rule *model.NotificationRule) (*model.NotificationRuleTestResult, *model.AppError) {
+	rule.UserId = userID
+	rule.PreSave()
+	if appErr := rule.IsValid(); appErr != nil {
+		return nil, appErr
+	}
+
+	user, appErr := a.GetUser(userID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	since := time.Now().Add(-notificationRuleTestPeriod)
+	posts, err := a.Srv().Store().Post().GetRecentPostsForUser(userID, model.GetMillisForTime(since), notificationRuleTestMaxPosts)
+	if err != nil {
+		return nil, model.NewAppError("TestNotificationRule", "app.notification_rule.test.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	senderIDs := make([]string, 0, len(posts))
+	for _, post := range posts {
+		senderIDs = append(senderIDs, post.UserId)
+	}
+	senders, err := a.Srv().Store().User().GetProfileByIds(rctx, model.RemoveDuplicateStrings(senderIDs), nil, true)
+	if err != nil {
+		return nil, model.NewAppError("TestNotificationRule", "app.notification_rule.test.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	bots := make(map[string]bool, len(senders))
+	for _, sender := range senders {
+		bots[sender.Id] = sender.IsBot
+	}
+
+	// Whether the user follows the threads is only needed for the rules with that condition.
+	following := make(map[string]bool)
+	location := user.GetTimezoneLocation()
+	result := &model.NotificationRuleTestResult{Evaluated: len(posts), Matched: model.NewPostList()}
+	for _, post := range posts {
+		threadParticipant := false
+		if rule.Conditions.ThreadParticipant != nil && post.RootId != "" {
+			isFollowing, ok := following[post.RootId]
+			if !ok {
+				membership, err := a.Srv().Store().Thread().GetMembershipForUser(userID, post.RootId)
+				var nfErr *store.ErrNotFound
+				if err != nil && !errors.As(err, &nfErr) {
+					return nil, model.NewAppError("TestNotificationRule", "app.notification_rule.test.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+				}
+				isFollowing = membership != nil && membership.Following
+				following[post.RootId] = isFollowing
+			}
+			threadParticipant = isFollowing
+		}
+
+		if rule.Matches(&model.NotificationRulePost{
+			Post:              post,
+			SenderIsBot:       bots[post.UserId] || post.GetProp(model.PostPropsFromWebhook) == "true",
+			ThreadParticipant: threadParticipant,
+			Time:              time.UnixMilli(post.CreateAt).In(location),
+		}) {
+			result.Matched.AddPost(post)
+			result.Matched.AddOrder(post.Id)
+		}
+	}
+
+	result.Matched = a.PreparePostListForClient(rctx, result.Matched)
+
+	return result, nil
+}
+
+// getNotificationRuleActions returns, by user id, the action of the first rule of the channel
+// members matching the post. The users without a matching rule are notified according to their
+// notification preferences.
+func (a *App) getNotificationRuleActions(post *model.Post, sender *model.User, profileMap map[string]*model.User, followers model.StringSet, rulesByUser map[string][]*model.NotificationRule) map[string]*model.NotificationRuleAction {
+	actions := make(map[string]*model.NotificationRuleAction)
+	if len(rulesByUser) == 0 || post.IsSystemMessage() {
+		return actions
+	}
+
+	fromWebhook := post.GetProp(model.PostPropsFromWebhook) == "true"
+	now := time.Now()
+	for userID, rules := range rulesByUser {
+		profile := profileMap[userID]
+		if profile == nil || (userID == post.UserId && !fromWebhook) {
+			continue
+		}
+
+		rulePost := &model.NotificationRulePost{
+			Post:              post,
+			SenderIsBot:       sender.IsBot || fromWebhook,
+			ThreadParticipant: post.RootId != "" && followers.Has(userID),
+			Time:              now.In(profile.GetTimezoneLocation()),
+		}
+		for _, rule := range rules {
+			if rule.Enabled && rule.Matches(rulePost) {
+				actions[userID] = &rule.Action
+				break
+			}
+		}
+	}
+
+	return actions
+}
+
+// notificationRuleAllowsEmail returns whether the user gets an email notification of the post,
+// according to the action of the rule matching it.
+func (a *App) notificationRuleAllowsEmail(rctx request.CTX, user *model.User, post *model.Post, action *model.NotificationRuleAction) bool {
+	if user.IsBot || user.IsRemote() || user.DeleteAt != 0 || !action.SendsEmail() {
+		return false
+	}
+
+	if action.Type == model.NotificationRuleActionEscalate {
+		return true
+	}
+
+	return a.statusAllowsEmail(rctx, user, post)
+}
+
+// notificationRuleAllowsPush returns whether the user gets a push notification of the post,
+// according to the action of the rule matching it.
+func (a *App) notificationRuleAllowsPush(rctx request.CTX, user *model.User, status *model.Status, post *model.Post, action *model.NotificationRuleAction, isCRT bool) bool {
+	if !action.SendsPush() {
+		return false
+	}
+
+	if action.Type == model.NotificationRuleActionEscalate {
+		return true
+	}
+
+	return a.statusAllowsPushNotification(rctx, user, status, post, isCRT)
+}
+
+// withoutNotificationRuleUsers returns the user ids left to be notified according to their
+// notification preferences, as they have no rule matching the post.
+func withoutNotificationRuleUsers(userIDs []string, actions map[string]*model.NotificationRuleAction) []string {
+	if len(actions) == 0 {
+		return userIDs
+	}
+
+	filtered := make([]string, 0, len(userIDs))
+	for _, id := range userIDs {
+		if _, ok := actions[id]; !ok {
+			filtered = append(filtered, id)
+		}
+	}
+	return filtered
+}
+
+// logNotificationRuleError logs the failure to load the notification rules, in which case the
+// users are notified according to their notification preferences.
+func logNotificationRuleError(rctx request.CTX, post *model.Post, err error) {
+	rctx.Logger().LogM(mlog.MlvlNotificationError, "Error fetching notification rules",
+		mlog.String("post_id", post.Id),
+		mlog.String("status", model.NotificationStatusError),
+		mlog.String("reason", model.NotificationReasonFetchError),
+		mlog.Err(err),
+	)
+}
@@ -0,0 +1,156 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func TestUpdateNotificationRules(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t).InitBasic(t)
+
+	userID := th.BasicUser.Id
+
+	rules, appErr := th.App.GetNotificationRules(userID)
+	require.Nil(t, appErr)
+	assert.Empty(t, rules)
+
+	saved, appErr := th.App.UpdateNotificationRules(userID, []*model.NotificationRule{
+		{Name: "Incidents", Enabled: true, Conditions: model.NotificationRuleConditions{MessagePattern: "incident"}, Action: model.NotificationRuleAction{Type: model.NotificationRuleActionEscalate}},
+		{Name: "Bots", Enabled: true, Conditions: model.NotificationRuleConditions{FromBot: model.NewPointer(true)}, Action: model.NotificationRuleAction{Type: model.NotificationRuleActionSuppress}},
+	})
+	require.Nil(t, appErr)
+	require.Len(t, saved, 2)
+	assert.Equal(t, int64(0), saved[0].SortOrder)
+	assert.Equal(t, int64(1), saved[1].SortOrder)
+
+	t.Run("rules are reordered and keep their id", func(t *testing.T) {
+		incidents, bots := saved[0], saved[1]
+		createAt := incidents.CreateAt
+
+		incidents.CreateAt = 0
+		updated, appErr := th.App.UpdateNotificationRules(userID, []*model.NotificationRule{bots, incidents})
+		require.Nil(t, appErr)
+
+		rules, appErr := th.App.GetNotificationRules(userID)
+		require.Nil(t, appErr)
+		require.Len(t, rules, 2)
+		assert.Equal(t, updated[0].Id, rules[0].Id)
+		assert.Equal(t, "Bots", rules[0].Name)
+		assert.Equal(t, "Incidents", rules[1].Name)
+		assert.Equal(t, incidents.Id, rules[1].Id)
+		assert.Equal(t, createAt, rules[1].CreateAt)
+	})
+
+	t.Run("unknown ids are replaced", func(t *testing.T) {
+		id := model.NewId()
+		updated, appErr := th.App.UpdateNotificationRules(userID, []*model.NotificationRule{
+			{Id: id, Name: "Mute", Enabled: true, Action: model.NotificationRuleAction{Type: model.NotificationRuleActionSuppress}},
+		})
+		require.Nil(t, appErr)
+		require.Len(t, updated, 1)
+		assert.NotEqual(t, id, updated[0].Id)
+	})
+
+	t.Run("invalid rules are rejected", func(t *testing.T) {
+		_, appErr := th.App.UpdateNotificationRules(userID, []*model.NotificationRule{
+			{Name: "Invalid", Action: model.NotificationRuleAction{Type: model.NotificationRuleActionNotify}},
+		})
+		require.NotNil(t, appErr)
+		assert.Equal(t, "model.notification_rule.is_valid.action.app_error", appErr.Id)
+
+		tooMany := make([]*model.NotificationRule, model.NotificationRulesMaxPerUser+1)
+		for i := range tooMany {
+			tooMany[i] = &model.NotificationRule{Name: "Mute", Action: model.NotificationRuleAction{Type: model.NotificationRuleActionSuppress}}
+		}
+		_, appErr = th.App.UpdateNotificationRules(userID, tooMany)
+		require.NotNil(t, appErr)
+		assert.Equal(t, http.StatusBadRequest, appErr.StatusCode)
+
+		rules, appErr := th.App.GetNotificationRules(userID)
+		require.Nil(t, appErr)
+		require.Len(t, rules, 1)
+		assert.Equal(t, "Mute", rules[0].Name)
+	})
+}
+
+func TestTestNotificationRule(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t).InitBasic(t)
+
+	matching := th.CreateMessagePost(t, th.BasicChannel, "Incident in progress")
+	th.CreateMessagePost(t, th.BasicChannel, "Lunch?")
+
+	rule := &model.NotificationRule{
+		Name:       "Incidents",
+		Conditions: model.NotificationRuleConditions{MessagePattern: `(?i)\bincident\b`},
+		Action:     model.NotificationRuleAction{Type: model.NotificationRuleActionEscalate},
+	}
+
+	result, appErr := th.App.TestNotificationRule(th.Context, th.BasicUser2.Id, rule)
+	require.Nil(t, appErr)
+	assert.GreaterOrEqual(t, result.Evaluated, 2)
+	assert.Equal(t, []string{matching.Id}, result.Matched.Order)
+
+	t.Run("own posts are not evaluated", func(t *testing.T) {
+		result, appErr := th.App.TestNotificationRule(th.Context, th.BasicUser.Id, rule)
+		require.Nil(t, appErr)
+		assert.Empty(t, result.Matched.Order)
+	})
+
+	t.Run("invalid rule", func(t *testing.T) {
+		_, appErr := th.App.TestNotificationRule(th.Context, th.BasicUser2.Id, &model.NotificationRule{Name: "Invalid"})
+		require.NotNil(t, appErr)
+		assert.Equal(t, "model.notification_rule.is_valid.action.app_error", appErr.Id)
+	})
+}
+
+func TestGetNotificationRuleActions(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t).InitBasic(t)
+
+	sender := th.BasicUser
+	user := th.BasicUser2
+	profileMap := map[string]*model.User{sender.Id: sender, user.Id: user}
+	post := &model.Post{Id: model.NewId(), UserId: sender.Id, ChannelId: th.BasicChannel.Id, Message: "incident"}
+
+	escalate := &model.NotificationRule{Name: "Incidents", Enabled: true, Conditions: model.NotificationRuleConditions{MessagePattern: "incident"}, Action: model.NotificationRuleAction{Type: model.NotificationRuleActionEscalate}}
+	suppress := &model.NotificationRule{Name: "Mute", Enabled: true, Action: model.NotificationRuleAction{Type: model.NotificationRuleActionSuppress}}
+
+	t.Run("first matching rule applies", func(t *testing.T) {
+		actions := th.App.getNotificationRuleActions(post, sender, profileMap, nil, map[string][]*model.NotificationRule{
+			user.Id:   {escalate, suppress},
+			sender.Id: {suppress},
+		})
+		require.Len(t, actions, 1)
+		assert.Equal(t, model.NotificationRuleActionEscalate, actions[user.Id].Type)
+	})
+
+	t.Run("disabled rules are skipped", func(t *testing.T) {
+		disabled := *escalate
+		disabled.Enabled = false
+		actions := th.App.getNotificationRuleActions(post, sender, profileMap, nil, map[string][]*model.NotificationRule{
+			user.Id: {&disabled, suppress},
+		})
+		assert.Equal(t, model.NotificationRuleActionSuppress, actions[user.Id].Type)
+	})
+
+	t.Run("escalation bypasses the status of the user", func(t *testing.T) {
+		status := &model.Status{UserId: user.Id, Status: model.StatusDnd, Manual: true}
+		notify := &model.NotificationRuleAction{Type: model.NotificationRuleActionNotify, Push: true}
+
+		assert.False(t, th.App.notificationRuleAllowsPush(th.Context, user, status, post, notify, false))
+		assert.True(t, th.App.notificationRuleAllowsPush(th.Context, user, status, post, &escalate.Action, false))
+		assert.False(t, th.App.notificationRuleAllowsPush(th.Context, user, status, post, &suppress.Action, false))
+	})
+
+	assert.Equal(t, []string{sender.Id}, withoutNotificationRuleUsers([]string{sender.Id, user.Id}, map[string]*model.NotificationRuleAction{user.Id: &suppress.Action}))
+}
@@ -13,7 +13,10 @@ import (
 	"strconv"
 	"time"
 
+	"go.opentelemetry.io/otel/attribute"
+
 	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/v8/platform/services/tracing"
 )
 
 const (
@@ -41,6 +44,8 @@ type ClientConfig struct {
 	Logger     mlog.LoggerIFace
 	MaxRetries int
 	RetryDelay time.Duration
+	// Transport makes the requests, traced as spans. Defaults to http.DefaultTransport.
+	Transport http.RoundTripper
 }
 
 // NewClient creates a new OpenAI client
@@ -62,7 +67,8 @@ func NewClient(config ClientConfig) *Client {
 		apiKey:  config.APIKey,
 		baseURL: config.BaseURL,
 		httpClient: &http.Client{
-			Timeout: config.Timeout,
+			Timeout:   config.Timeout,
+			Transport: tracing.NewTransport(config.Transport),
 		},
 		logger:     config.Logger,
 		maxRetries: config.MaxRetries,
@@ -71,7 +77,12 @@ func NewClient(config ClientConfig) *Client {
 }
 
 // CreateChatCompletion creates a chat completion request
-func (c *Client) CreateChatCompletion(ctx context.Context, request ChatCompletionRequest) (*ChatCompletionResponse, error) {
+func (c *Client) CreateChatCompletion(ctx context.Context, request ChatCompletionRequest) (_ *ChatCompletionResponse, err error) {
+	ctx, span := tracing.Start(ctx, "OpenAI.CreateChatCompletion", attribute.String("model", request.Model))
+	defer func() {
+		tracing.End(span, err)
+	}()
+
 	url := fmt.Sprintf("%s/chat/completions", c.baseURL)
 
 	var lastErr error
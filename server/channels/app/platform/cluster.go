@@ -18,6 +18,11 @@ func (ps *PlatformService) Cluster() einterfaces.ClusterInterface {
 	return ps.clusterIFace
 }
 
+// GetStore returns the store, for the built-in cluster implementation.
+func (ps *PlatformService) GetStore() store.Store {
+	return ps.Store
+}
+
 func (ps *PlatformService) NewClusterDiscoveryService() *ClusterDiscoveryService {
 	ds := &ClusterDiscoveryService{
 		ClusterDiscovery: model.ClusterDiscovery{},
@@ -20,6 +20,7 @@ func (ps *PlatformService) RegisterClusterHandlers() {
 	ps.clusterIFace.RegisterClusterMessageHandler(model.ClusterEventBusyStateChanged, ps.clusterBusyStateChgHandler)
 	ps.clusterIFace.RegisterClusterMessageHandler(model.ClusterEventClearSessionCacheForUser, ps.clusterClearSessionCacheForUserHandler)
 	ps.clusterIFace.RegisterClusterMessageHandler(model.ClusterEventClearSessionCacheForAllUsers, ps.clusterClearSessionCacheForAllUsersHandler)
+	ps.clusterIFace.RegisterClusterMessageHandler(model.ClusterEventEventStreamRequest, ps.clusterEventStreamRequestHandler)
 
 	for e, h := range ps.additionalClusterHandlers {
 		ps.clusterIFace.RegisterClusterMessageHandler(e, h)
@@ -81,6 +82,16 @@ func (ps *PlatformService) clusterClearSessionCacheForAllUsersHandler(msg *model
 	ps.ClearSessionCacheForAllUsersSkipClusterSend()
 }
 
+func (ps *PlatformService) clusterEventStreamRequestHandler(msg *model.ClusterMessage) {
+	var streamReq eventStreamRequest
+	if err := json.Unmarshal(msg.Data, &streamReq); err != nil {
+		ps.logger.Warn("Failed to decode event stream request from JSON", mlog.Err(err))
+		return
+	}
+
+	ps.serveEventStreamRequestLocal(streamReq.UserID, streamReq.SessionHash, streamReq.ConnectionID, streamReq.Request)
+}
+
 func (ps *PlatformService) clusterBusyStateChgHandler(msg *model.ClusterMessage) {
 	var sbs model.ServerBusyState
 	if err := json.Unmarshal(msg.Data, &sbs); err != nil {
@@ -89,6 +89,12 @@ func (ps *PlatformService) IsConfigReadOnly() bool {
 // SaveConfig replaces the active configuration, optionally notifying cluster peers.
 // It returns both the previous and current configs.
 func (ps *PlatformService) SaveConfig(newCfg *model.Config, sendConfigChangeClusterMessage bool) (*model.Config, *model.Config, *model.AppError) {
+	return ps.SaveConfigWithAuthor(newCfg, sendConfigChangeClusterMessage, "")
+}
+
+// SaveConfigWithAuthor is like SaveConfig, but records the given user as the author
+// of the new version in the configuration history.
+func (ps *PlatformService) SaveConfigWithAuthor(newCfg *model.Config, sendConfigChangeClusterMessage bool, authorID string) (*model.Config, *model.Config, *model.AppError) {
 	if ps.pluginEnv != nil {
 		var hookErr error
 		ps.pluginEnv.RunMultiHook(func(hooks plugin.Hooks, _ *model.Manifest) bool {
@@ -107,7 +113,7 @@ func (ps *PlatformService) SaveConfig(newCfg *model.Config, sendConfigChangeClus
 		}
 	}
 
-	oldCfg, newCfg, err := ps.configStore.Set(newCfg)
+	oldCfg, newCfg, err := ps.configStore.SetWithAuthor(newCfg, authorID)
 	if errors.Is(err, config.ErrReadOnlyConfiguration) {
 		return nil, nil, model.NewAppError("saveConfig", "ent.cluster.save_config.error", nil, "", http.StatusForbidden).Wrap(err)
 	} else if err != nil {
@@ -125,6 +131,58 @@ func (ps *PlatformService) SaveConfig(newCfg *model.Config, sendConfigChangeClus
 	return oldCfg, newCfg, nil
 }
 
+// ConfigHistory lists the saved versions of the configuration, most recent first.
+func (ps *PlatformService) ConfigHistory() ([]*model.ConfigVersion, *model.AppError) {
+	versions, err := ps.configStore.History()
+	if errors.Is(err, config.ErrHistoryNotSupported) {
+		return nil, model.NewAppError("ConfigHistory", "app.config.history.not_supported.app_error", nil, "", http.StatusNotImplemented).Wrap(err)
+	} else if err != nil {
+		return nil, model.NewAppError("ConfigHistory", "app.config.history.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return versions, nil
+}
+
+// ConfigVersion retrieves a saved version of the configuration, without environment overrides.
+func (ps *PlatformService) ConfigVersion(id string) (*model.Config, *model.AppError) {
+	cfg, err := ps.configStore.GetVersion(id)
+	if err != nil {
+		return nil, configVersionError("ConfigVersion", err)
+	}
+
+	return cfg, nil
+}
+
+// DiffConfigVersions returns the redacted differences between two saved versions of the configuration.
+func (ps *PlatformService) DiffConfigVersions(baseID, actualID string) ([]*model.ConfigVersionDiff, *model.AppError) {
+	diffs, err := ps.configStore.DiffVersions(baseID, actualID)
+	if err != nil {
+		return nil, configVersionError("DiffConfigVersions", err)
+	}
+
+	result := make([]*model.ConfigVersionDiff, 0, len(diffs))
+	for _, d := range diffs {
+		result = append(result, &model.ConfigVersionDiff{
+			Path:      d.Path,
+			BaseVal:   d.BaseVal,
+			ActualVal: d.ActualVal,
+		})
+	}
+
+	return result, nil
+}
+
+func configVersionError(where string, err error) *model.AppError {
+	switch {
+	case errors.Is(err, config.ErrHistoryNotSupported):
+		return model.NewAppError(where, "app.config.history.not_supported.app_error", nil, "", http.StatusNotImplemented).Wrap(err)
+	case errors.Is(err, config.ErrVersionNotFound):
+		return model.NewAppError(where, "app.config.history.version_not_found.app_error", nil, "", http.StatusNotFound).Wrap(err)
+	default:
+		return model.NewAppError(where, "app.config.history.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+}
+
 func (ps *PlatformService) ReloadConfig() error {
 	if err := ps.configStore.Load(); err != nil {
 		return err
@@ -234,6 +292,12 @@ func (ps *PlatformService) regenerateClientConfig() {
 		limitedClientConfig["AsymmetricSigningPublicKey"] = base64.StdEncoding.EncodeToString(der)
 	}
 
+	if key := ps.WebPushVAPIDKey(); key != nil && clientConfig["EnableWebPush"] == "true" {
+		if ecdhKey, err := key.PublicKey.ECDH(); err == nil {
+			clientConfig["WebPushPublicKey"] = base64.RawURLEncoding.EncodeToString(ecdhKey.Bytes())
+		}
+	}
+
 	clientConfigJSON, _ := json.Marshal(clientConfig)
 	ps.clientConfig.Store(clientConfig)
 	ps.limitedClientConfig.Store(limitedClientConfig)
@@ -252,12 +316,48 @@ func (ps *PlatformService) EnsureAsymmetricSigningKey() error {
 		return nil
 	}
 
+	key, err := ps.ensureECDSAKey(model.SystemAsymmetricSigningKeyKey)
+	if err != nil {
+		return err
+	}
+
+	ps.asymmetricSigningKey.Store(key)
+	ps.regenerateClientConfig()
+	return nil
+}
+
+// WebPushVAPIDKey returns the private key the server identifies itself with to the web push services.
+func (ps *PlatformService) WebPushVAPIDKey() *ecdsa.PrivateKey {
+	return ps.webPushVAPIDKey.Load()
+}
+
+// EnsureWebPushVAPIDKey ensures that a VAPID key exists and future calls to WebPushVAPIDKey will
+// always return a valid key. The key is shared by all the nodes of a cluster, since the browsers
+// bind their subscriptions to its public key.
+func (ps *PlatformService) EnsureWebPushVAPIDKey() error {
+	if ps.WebPushVAPIDKey() != nil {
+		return nil
+	}
+
+	key, err := ps.ensureECDSAKey(model.SystemWebPushVAPIDKeyKey)
+	if err != nil {
+		return err
+	}
+
+	ps.webPushVAPIDKey.Store(key)
+	ps.regenerateClientConfig()
+	return nil
+}
+
+// ensureECDSAKey loads the P-256 key stored in the system table under the given name,
+// generating and saving one if there isn't any yet.
+func (ps *PlatformService) ensureECDSAKey(name string) (*ecdsa.PrivateKey, error) {
 	var key *model.SystemAsymmetricSigningKey
 
-	value, err := ps.Store.System().GetByName(model.SystemAsymmetricSigningKeyKey)
+	value, err := ps.Store.System().GetByName(name)
 	if err == nil {
 		if err := json.Unmarshal([]byte(value.Value), &key); err != nil {
-			return err
+			return nil, err
 		}
 	}
 
@@ -265,7 +365,7 @@ func (ps *PlatformService) EnsureAsymmetricSigningKey() error {
 	if key == nil {
 		newECDSAKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		newKey := &model.SystemAsymmetricSigningKey{
 			ECDSAKey: &model.SystemECDSAKey{
@@ -276,16 +376,16 @@ func (ps *PlatformService) EnsureAsymmetricSigningKey() error {
 			},
 		}
 		system := &model.System{
-			Name: model.SystemAsymmetricSigningKeyKey,
+			Name: name,
 		}
 		v, err := json.Marshal(newKey)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		system.Value = string(v)
 		// If we were able to save the key, use it, otherwise log the error.
 		if err = ps.Store.System().Save(system); err != nil {
-			mlog.Warn("Failed to save AsymmetricSigningKey", mlog.Err(err))
+			mlog.Warn("Failed to save key", mlog.String("name", name), mlog.Err(err))
 		} else {
 			key = newKey
 		}
@@ -294,13 +394,13 @@ func (ps *PlatformService) EnsureAsymmetricSigningKey() error {
 	// If we weren't able to save a new key above, another server must have beat us to it. Get the
 	// key from the database, and if that fails, error out.
 	if key == nil {
-		value, err := ps.Store.System().GetByName(model.SystemAsymmetricSigningKeyKey)
+		value, err := ps.Store.System().GetByName(name)
 		if err != nil {
-			return err
+			return nil, err
 		}
 
 		if err := json.Unmarshal([]byte(value.Value), &key); err != nil {
-			return err
+			return nil, err
 		}
 	}
 
@@ -309,18 +409,16 @@ func (ps *PlatformService) EnsureAsymmetricSigningKey() error {
 	case "P-256":
 		curve = elliptic.P256()
 	default:
-		return fmt.Errorf("unknown curve: %s", key.ECDSAKey.Curve)
+		return nil, fmt.Errorf("unknown curve: %s", key.ECDSAKey.Curve)
 	}
-	ps.asymmetricSigningKey.Store(&ecdsa.PrivateKey{
+	return &ecdsa.PrivateKey{
 		PublicKey: ecdsa.PublicKey{
 			Curve: curve,
 			X:     key.ECDSAKey.X,
 			Y:     key.ECDSAKey.Y,
 		},
 		D: key.ECDSAKey.D,
-	})
-	ps.regenerateClientConfig()
-	return nil
+	}, nil
 }
 
 // LimitedClientConfigWithComputed gets the configuration in a format suitable for sending to the client.
@@ -0,0 +1,233 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package platform
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+const (
+	longPollTimeout    = 30 * time.Second
+	longPollBatchDelay = 50 * time.Millisecond
+)
+
+var errEventStreamClosed = errors.New("event stream closed")
+
+// EventStream is the HTTP response a WebConn writes its messages to, for the clients which
+// can't use a websocket, e.g. because a proxy strips the Upgrade header. It is either a
+// Server-Sent Events stream, which lasts until the client goes away, or a long poll, which
+// ends with the first messages written to it.
+//
+// Either way, the client resumes from the connection id and sequence number of the last
+// event it got, as with a reliable websocket, and sends its requests through
+// PlatformService.ServeEventStreamRequest.
+type EventStream struct {
+	w        http.ResponseWriter
+	rc       *http.ResponseController
+	ctx      context.Context
+	longPoll bool
+
+	requests  chan *model.WebSocketRequest
+	closed    chan struct{}
+	closeOnce sync.Once
+
+	// batch holds the messages written to a long poll, until it ends.
+	batch      [][]byte
+	batchMut   sync.Mutex
+	batchTimer *time.Timer
+}
+
+// NewEventStream returns a Server-Sent Events stream writing to w.
+func NewEventStream(w http.ResponseWriter, r *http.Request) *EventStream {
+	return newEventStream(w, r, false)
+}
+
+// NewLongPollStream returns a long poll writing to w.
+func NewLongPollStream(w http.ResponseWriter, r *http.Request) *EventStream {
+	return newEventStream(w, r, true)
+}
+
+func newEventStream(w http.ResponseWriter, r *http.Request, longPoll bool) *EventStream {
+	return &EventStream{
+		w:        w,
+		rc:       http.NewResponseController(w),
+		ctx:      r.Context(),
+		longPoll: longPoll,
+		requests: make(chan *model.WebSocketRequest),
+		closed:   make(chan struct{}),
+	}
+}
+
+// ParseEventStreamID returns the connection id and sequence number to resume an event
+// stream from, given the ID of the last event the client got.
+func ParseEventStreamID(id string) (string, string, error) {
+	connectionID, seq, ok := strings.Cut(id, ":")
+	if !ok || !model.IsValidId(connectionID) {
+		return "", "", fmt.Errorf("invalid event id %q", id)
+	}
+
+	sequence, err := strconv.ParseInt(seq, 10, 64)
+	if err != nil || sequence < 0 {
+		return "", "", fmt.Errorf("invalid event id %q", id)
+	}
+
+	// The client wants the events following the one it got.
+	return connectionID, strconv.FormatInt(sequence+1, 10), nil
+}
+
+// eventStreamID returns the ID of the event with the given sequence number, which the client
+// sends back as the Last-Event-ID header to resume the stream.
+func eventStreamID(connectionID string, sequence int64) string {
+	return connectionID + ":" + strconv.FormatInt(sequence, 10)
+}
+
+// open writes the headers of a Server-Sent Events stream, so that the client knows it's
+// connected before any event is written.
+func (s *EventStream) open() error {
+	if s.longPoll {
+		s.batchTimer = time.AfterFunc(longPollTimeout, s.close)
+		return nil
+	}
+
+	header := s.w.Header()
+	header.Set("Content-Type", "text/event-stream")
+	header.Set("Cache-Control", "no-cache")
+	// Keeps nginx from buffering the stream.
+	header.Set("X-Accel-Buffering", "no")
+	s.w.WriteHeader(http.StatusOK)
+
+	return s.flush()
+}
+
+// write writes an encoded message to the stream. id is the ID of the event the message is,
+// or empty for the responses to the client's requests.
+func (s *EventStream) write(id string, data []byte) error {
+	select {
+	case <-s.closed:
+		return errEventStreamClosed
+	default:
+	}
+
+	data = bytes.TrimRight(data, "\n")
+
+	if s.longPoll {
+		s.batchMut.Lock()
+		defer s.batchMut.Unlock()
+		if len(s.batch) == 0 {
+			// Gives the messages written right after this one the chance to make it in the response.
+			s.batchTimer.Reset(longPollBatchDelay)
+		}
+		s.batch = append(s.batch, bytes.Clone(data))
+		return nil
+	}
+
+	var buf bytes.Buffer
+	buf.Grow(len(data) + 64)
+	if id != "" {
+		buf.WriteString("id: ")
+		buf.WriteString(id)
+		buf.WriteByte('\n')
+	}
+	buf.WriteString("data: ")
+	buf.Write(data)
+	buf.WriteString("\n\n")
+
+	return s.writeBuf(buf.Bytes())
+}
+
+// ping keeps the stream from being closed by proxies for being idle. Long polls don't
+// need it, as they end before any proxy would close them.
+func (s *EventStream) ping() error {
+	if s.longPoll {
+		return nil
+	}
+	return s.writeBuf([]byte(": ping\n\n"))
+}
+
+func (s *EventStream) writeBuf(buf []byte) error {
+	// The deadline also lifts the write timeout of the server for the stream.
+	if err := s.rc.SetWriteDeadline(time.Now().Add(writeWaitTime)); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		return err
+	}
+	if _, err := s.w.Write(buf); err != nil {
+		return err
+	}
+	return s.flush()
+}
+
+func (s *EventStream) flush() error {
+	if err := s.rc.Flush(); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		return err
+	}
+	return nil
+}
+
+// close ends the stream. The pending writes fail, so the messages they were for are
+// written again when the client resumes the stream.
+func (s *EventStream) close() {
+	s.closeOnce.Do(func() {
+		close(s.closed)
+	})
+}
+
+// wait blocks until the stream is closed or the client goes away.
+func (s *EventStream) wait() {
+	select {
+	case <-s.closed:
+	case <-s.ctx.Done():
+	}
+}
+
+// request hands the request over to the connection the stream belongs to. It returns false
+// if the stream ended first.
+func (s *EventStream) request(req *model.WebSocketRequest) bool {
+	select {
+	case s.requests <- req:
+		return true
+	case <-s.closed:
+	case <-s.ctx.Done():
+	}
+	return false
+}
+
+// End writes the rest of the response once the connection the stream belongs to is closed,
+// which for a long poll is the messages written to it, as a JSON array.
+func (s *EventStream) End() error {
+	if !s.longPoll {
+		return nil
+	}
+
+	s.close()
+	s.batchMut.Lock()
+	defer s.batchMut.Unlock()
+	if s.batchTimer != nil {
+		s.batchTimer.Stop()
+	}
+
+	s.w.Header().Set("Content-Type", "application/json")
+	s.w.Header().Set("Cache-Control", "no-store")
+
+	var buf bytes.Buffer
+	buf.WriteByte('[')
+	for i, msg := range s.batch {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		buf.Write(msg)
+	}
+	buf.WriteByte(']')
+
+	_, err := s.w.Write(buf.Bytes())
+	return err
+}
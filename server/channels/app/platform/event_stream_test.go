@@ -0,0 +1,89 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package platform
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func TestParseEventStreamID(t *testing.T) {
+	connectionID := model.NewId()
+
+	t.Run("valid id", func(t *testing.T) {
+		id, seq, err := ParseEventStreamID(eventStreamID(connectionID, 41))
+		require.NoError(t, err)
+		assert.Equal(t, connectionID, id)
+		assert.Equal(t, "42", seq)
+	})
+
+	for _, id := range []string{
+		"",
+		connectionID,
+		"invalid:1",
+		connectionID + ":",
+		connectionID + ":-1",
+		connectionID + ":one",
+	} {
+		t.Run("invalid id "+id, func(t *testing.T) {
+			_, _, err := ParseEventStreamID(id)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestEventStream(t *testing.T) {
+	t.Run("server-sent events", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		stream := NewEventStream(rec, httptest.NewRequest("GET", "/api/v4/websocket/events", nil))
+
+		require.NoError(t, stream.open())
+		assert.Equal(t, "text/event-stream", rec.Header().Get("Content-Type"))
+		assert.True(t, rec.Flushed)
+
+		require.NoError(t, stream.write("abc:1", []byte(`{"event":"typing","seq":1}`+"\n")))
+		require.NoError(t, stream.write("", []byte(`{"status":"OK","seq_reply":2}`)))
+		require.NoError(t, stream.ping())
+		assert.Equal(t, "id: abc:1\ndata: {\"event\":\"typing\",\"seq\":1}\n\n"+
+			"data: {\"status\":\"OK\",\"seq_reply\":2}\n\n"+
+			": ping\n\n", rec.Body.String())
+
+		stream.close()
+		stream.wait()
+		assert.ErrorIs(t, stream.write("abc:2", []byte(`{}`)), errEventStreamClosed)
+		assert.False(t, stream.request(&model.WebSocketRequest{Seq: 1, Action: "user_typing"}))
+		require.NoError(t, stream.End())
+	})
+
+	t.Run("long poll", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		stream := NewLongPollStream(rec, httptest.NewRequest("GET", "/api/v4/websocket/poll", nil))
+
+		require.NoError(t, stream.open())
+		require.NoError(t, stream.ping())
+		require.NoError(t, stream.write("abc:1", []byte(`{"event":"typing","seq":1}`+"\n")))
+		require.NoError(t, stream.write("abc:2", []byte(`{"event":"posted","seq":2}`+"\n")))
+		assert.Zero(t, rec.Body.Len())
+
+		// The poll ends shortly after the first message.
+		stream.wait()
+		require.NoError(t, stream.End())
+		assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+		assert.JSONEq(t, `[{"event":"typing","seq":1},{"event":"posted","seq":2}]`, rec.Body.String())
+	})
+
+	t.Run("empty long poll", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		stream := NewLongPollStream(rec, httptest.NewRequest("GET", "/api/v4/websocket/poll", nil))
+
+		require.NoError(t, stream.open())
+		require.NoError(t, stream.End())
+		assert.Equal(t, "[]", rec.Body.String())
+	})
+}
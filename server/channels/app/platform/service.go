@@ -10,6 +10,7 @@ import (
 	"hash/maphash"
 	"net/http"
 	"runtime"
+	"slices"
 	"strconv"
 	"sync"
 	"sync/atomic"
@@ -18,6 +19,7 @@ import (
 	"github.com/mattermost/mattermost/server/public/model"
 	"github.com/mattermost/mattermost/server/public/plugin"
 	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
 	"github.com/mattermost/mattermost/server/v8/channels/app/featureflag"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs"
 	"github.com/mattermost/mattermost/server/v8/channels/store"
@@ -26,10 +28,13 @@ import (
 	"github.com/mattermost/mattermost/server/v8/channels/store/searchlayer"
 	"github.com/mattermost/mattermost/server/v8/channels/store/sqlstore"
 	"github.com/mattermost/mattermost/server/v8/channels/store/timerlayer"
+	"github.com/mattermost/mattermost/server/v8/channels/store/tracinglayer"
 	"github.com/mattermost/mattermost/server/v8/config"
 	"github.com/mattermost/mattermost/server/v8/einterfaces"
 	"github.com/mattermost/mattermost/server/v8/platform/services/cache"
+	"github.com/mattermost/mattermost/server/v8/platform/services/cluster"
 	"github.com/mattermost/mattermost/server/v8/platform/services/searchengine"
+	"github.com/mattermost/mattermost/server/v8/platform/services/tracing"
 	"github.com/mattermost/mattermost/server/v8/platform/shared/filestore"
 )
 
@@ -58,7 +63,11 @@ type PlatformService struct {
 	statusCache   cache.Cache
 	sessionCache  cache.Cache
 
+	// webSocketEventLog is set when Redis is used as the cache.
+	webSocketEventLog *webSocketEventLog
+
 	asymmetricSigningKey atomic.Pointer[ecdsa.PrivateKey]
+	webPushVAPIDKey      atomic.Pointer[ecdsa.PrivateKey]
 	clientConfig         atomic.Value
 	clientConfigHash     atomic.Value
 	limitedClientConfig  atomic.Value
@@ -72,6 +81,8 @@ type PlatformService struct {
 	metrics      *platformMetrics
 	metricsIFace einterfaces.MetricsInterface
 
+	tracing *tracing.Provider
+
 	featureFlagSynchronizerMutex sync.Mutex
 	featureFlagSynchronizer      *featureflag.Synchronizer
 	featureFlagStop              chan struct{}
@@ -228,6 +239,10 @@ func New(sc ServiceConfig, options ...Option) (*PlatformService, error) {
 
 	ps.Log().Info("Successfully connected to cache backend", mlog.String("backend", *cacheConfig.CacheType), mlog.String("result", res))
 
+	if client := cache.RedisClient(ps.cacheProvider); client != nil {
+		ps.webSocketEventLog = newWebSocketEventLog(ps, client)
+	}
+
 	// Step 3: Search Engine
 	searchEngine := searchengine.NewBroker(ps.Config())
 	ps.SearchEngine = searchEngine
@@ -243,6 +258,9 @@ func New(sc ServiceConfig, options ...Option) (*PlatformService, error) {
 
 	ps.cacheProvider.SetMetrics(ps.metricsIFace)
 
+	// Step 5b: Init Tracing
+	ps.initTracing()
+
 	// Step 6: Store.
 	// Depends on Step 0 (config), 1 (cacheProvider), 3 (search engine), 5 (metrics) and cluster.
 	if ps.newStore == nil {
@@ -254,10 +272,22 @@ func New(sc ServiceConfig, options ...Option) (*PlatformService, error) {
 			// |
 			// Search layer
 			// |
+			// Tracing layer (when tracing is enabled on startup)
+			// |
 			// Timer layer
 			// |
 			// Cache layer
-			ps.sqlStore, err = sqlstore.New(ps.Config().SqlSettings, ps.Log(), ps.metricsIFace, ps.storeOptions...)
+			readYourWritesCache, err2 := ps.cacheProvider.NewCache(&cache.CacheOptions{
+				Name:          "ReadYourWrites",
+				Size:          sqlstore.ReadYourWritesCacheSize,
+				DefaultExpiry: time.Duration(*ps.Config().SqlSettings.ReadYourWritesWindowSeconds) * time.Second,
+			})
+			if err2 != nil {
+				return nil, fmt.Errorf("cannot create read-your-writes cache: %w", err2)
+			}
+
+			storeOptions := append(slices.Clone(ps.storeOptions), sqlstore.ReadYourWritesCache(readYourWritesCache))
+			ps.sqlStore, err = sqlstore.New(ps.Config().SqlSettings, ps.Log(), ps.metricsIFace, storeOptions...)
 			if err != nil {
 				return nil, err
 			}
@@ -272,8 +302,13 @@ func New(sc ServiceConfig, options ...Option) (*PlatformService, error) {
 				searchStore.UpdateConfig(cfg)
 			})
 
+			var tracedStore store.Store = searchStore
+			if *ps.Config().TracingSettings.Enable {
+				tracedStore = tracinglayer.New(searchStore)
+			}
+
 			lcl, err2 := localcachelayer.NewLocalCacheLayer(
-				timerlayer.New(searchStore, ps.metricsIFace),
+				timerlayer.New(tracedStore, ps.metricsIFace),
 				ps.metricsIFace,
 				ps.clusterIFace,
 				ps.cacheProvider,
@@ -350,6 +385,11 @@ func New(sc ServiceConfig, options ...Option) (*PlatformService, error) {
 			return nil, fmt.Errorf("failed to initialize filebackend: %w", err2)
 		}
 
+		backend, err2 = filestore.WithMigrationFallback(backend, &ps.Config().FileSettings, license != nil && *license.Features.Compliance, insecure != nil && *insecure)
+		if err2 != nil {
+			return nil, fmt.Errorf("failed to initialize filebackend: %w", err2)
+		}
+
 		ps.filestore = backend
 	}
 
@@ -386,6 +426,10 @@ func New(sc ServiceConfig, options ...Option) (*PlatformService, error) {
 		return nil, fmt.Errorf("unable to ensure asymmetric signing key: %w", err)
 	}
 
+	if err = ps.EnsureWebPushVAPIDKey(); err != nil {
+		return nil, fmt.Errorf("unable to ensure web push VAPID key: %w", err)
+	}
+
 	ps.Busy = NewBusy(ps.clusterIFace)
 
 	// Enable developer settings and mmctl local mode if this is a "dev" build
@@ -425,6 +469,7 @@ func (ps *PlatformService) Start(broadcastHooks map[string]BroadcastHook) error
 	// Must be done before hub start.
 	go ps.processStatusUpdates()
 
+	ps.webSocketEventLog.start()
 	ps.hubStart(broadcastHooks)
 
 	ps.configListenerId = ps.AddConfigListener(func(_, _ *model.Config) {
@@ -492,6 +537,10 @@ func (ps *PlatformService) SetLogger(logger *mlog.Logger) {
 func (ps *PlatformService) initEnterprise() {
 	if clusterInterface != nil && ps.clusterIFace == nil {
 		ps.clusterIFace = clusterInterface(ps)
+	} else if ps.clusterIFace == nil && *ps.Config().ClusterSettings.Enable {
+		// Fall back to the built-in cluster, which exchanges messages over
+		// Redis when it's used as the cache, and over the database otherwise.
+		ps.clusterIFace = cluster.New(ps, cache.RedisClient(ps.cacheProvider))
 	}
 
 	if elasticsearchInterface != nil {
@@ -525,6 +574,9 @@ func (ps *PlatformService) TotalWebsocketConnections() int {
 func (ps *PlatformService) Shutdown() error {
 	ps.HubStop()
 
+	// Must be done after hub shutdown, and before closing the cache.
+	ps.webSocketEventLog.close()
+
 	// Shutdown status processor.
 	// Must be done after hub shutdown.
 	close(ps.statusUpdateExitSignal)
@@ -556,6 +608,22 @@ func (ps *PlatformService) CacheProvider() cache.Provider {
 	return ps.cacheProvider
 }
 
+// RecordWrite records that the caller of rctx wrote, so that it reads its writes from master
+// while the replicas catch up.
+func (ps *PlatformService) RecordWrite(rctx request.CTX) {
+	if ps.sqlStore != nil {
+		ps.sqlStore.RecordWrite(rctx)
+	}
+}
+
+// ReadYourWritesContext returns rctx reading from master if its caller wrote recently.
+func (ps *PlatformService) ReadYourWritesContext(rctx request.CTX) request.CTX {
+	if ps.sqlStore == nil {
+		return rctx
+	}
+	return ps.sqlStore.ReadYourWritesContext(rctx)
+}
+
 // SetSqlStore is used for plugin testing
 func (ps *PlatformService) SetSqlStore(s *sqlstore.SqlStore) {
 	ps.sqlStore = s
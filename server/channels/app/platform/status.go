@@ -585,6 +585,67 @@ func (ps *PlatformService) SetStatusOutOfOffice(userID string) {
 	}
 }
 
+// SetStatusQuietHours sets the status of the user to the given quiet status when their quiet hours
+// start, returning whether it did. Statuses the user set manually, other than online, are kept as
+// they are.
+func (ps *PlatformService) SetStatusQuietHours(userID string, quietStatus string) bool {
+	if !*ps.Config().ServiceSettings.EnableUserStatuses {
+		return false
+	}
+
+	status, err := ps.GetStatus(userID)
+
+	if err != nil {
+		status = &model.Status{UserId: userID, Status: model.StatusOffline, Manual: false, LastActivityAt: 0, ActiveChannel: ""}
+	}
+
+	if status.DNDEndTime != 0 || (status.Manual && status.Status != model.StatusOnline) {
+		return false
+	}
+
+	status.PrevStatus = status.Status
+	status.Status = quietStatus
+	status.Manual = true
+
+	ps.SaveAndBroadcastStatus(status)
+	if ps.sharedChannelService != nil {
+		ps.sharedChannelService.NotifyUserStatusChanged(status)
+	}
+
+	return true
+}
+
+// UnsetStatusQuietHours restores the status the user had before their quiet hours started, unless
+// the user changed it in the meantime.
+func (ps *PlatformService) UnsetStatusQuietHours(userID string, quietStatus string) {
+	if !*ps.Config().ServiceSettings.EnableUserStatuses {
+		return
+	}
+
+	status, err := ps.GetStatus(userID)
+	if err != nil {
+		return
+	}
+
+	if status.Status != quietStatus || !status.Manual || status.DNDEndTime != 0 {
+		return
+	}
+
+	prevStatus := status.PrevStatus
+	if prevStatus == "" || prevStatus == quietStatus {
+		prevStatus = model.StatusOnline
+	}
+
+	status.PrevStatus = status.Status
+	status.Status = prevStatus
+	status.Manual = false
+
+	ps.SaveAndBroadcastStatus(status)
+	if ps.sharedChannelService != nil {
+		ps.sharedChannelService.NotifyUserStatusChanged(status)
+	}
+}
+
 func (ps *PlatformService) isUserAway(lastActivityAt int64) bool {
 	return model.GetMillis()-lastActivityAt >= *ps.Config().TeamSettings.UserStatusAwayTimeout*1000
 }
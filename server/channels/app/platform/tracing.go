@@ -0,0 +1,33 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package platform
+
+import (
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/v8/platform/services/tracing"
+)
+
+// initTracing configures the export of traces, and keeps it in sync with the configuration.
+func (ps *PlatformService) initTracing() {
+	ps.tracing = tracing.NewProvider()
+	if err := ps.tracing.Configure(ps.Config().TracingSettings); err != nil {
+		ps.Log().Error("Failed to configure tracing", mlog.Err(err))
+	}
+
+	ps.AddConfigListener(func(_, newCfg *model.Config) {
+		if err := ps.tracing.Configure(newCfg.TracingSettings); err != nil {
+			ps.Log().Error("Failed to configure tracing", mlog.Err(err))
+		}
+	})
+}
+
+// ShutdownTracing exports the pending spans and stops tracing.
+func (ps *PlatformService) ShutdownTracing() error {
+	if ps.tracing == nil {
+		return nil
+	}
+
+	return ps.tracing.Shutdown()
+}
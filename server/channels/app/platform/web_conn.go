@@ -47,6 +47,8 @@ const (
 	reconnectFound    = "success"
 	reconnectNotFound = "failure"
 	reconnectLossless = "lossless"
+	reconnectReplayed = "replayed"
+	reconnectResync   = "resync"
 )
 
 const websocketMessagePluginPrefix = "custom_"
@@ -63,6 +65,7 @@ type pluginWSPostedHook struct {
 
 type WebConnConfig struct {
 	WebSocket         *websocket.Conn
+	EventStream       *EventStream
 	Session           model.Session
 	TFunc             i18n.TranslateFunc
 	Locale            string
@@ -80,6 +83,8 @@ type WebConnConfig struct {
 	activeQueue      chan model.WebSocketMessage
 	deadQueue        []*model.WebSocketEvent
 	deadQueuePointer int
+	replayQueue      []*model.WebSocketEvent
+	resyncRequired   bool
 }
 
 // WebConn represents a single websocket connection to a user.
@@ -102,6 +107,9 @@ type WebConn struct {
 	lastAllChannelMembersTime int64
 	lastUserActivityAt        int64
 	send                      chan model.WebSocketMessage
+	// stream is the HTTP response the messages are written to instead of the websocket,
+	// for the clients which can't use one.
+	stream *EventStream
 	// deadQueue behaves like a queue of a finite size
 	// which is used to store all messages that are sent via the websocket.
 	// It basically acts as the user-space socket buffer, and is used
@@ -111,6 +119,18 @@ type WebConn struct {
 	// Pointer which indicates the next slot to insert.
 	// It is only to be incremented during writing or clearing the queue.
 	deadQueuePointer int
+	// replayQueue holds the events missed by a client reconnecting after the node
+	// it was connected to went away, as found in the websocket event log.
+	// They are written before anything else.
+	replayQueue []*model.WebSocketEvent
+	// resyncRequired indicates that some of the events missed by the client are
+	// no longer in the websocket event log.
+	resyncRequired bool
+	// logSequence is the sequence number of the next event queued to the connection
+	// while it's inactive, when logQueued is set. They're only used by the hub, to log
+	// those events.
+	logSequence int64
+	logQueued   bool
 	// active indicates whether there is an open websocket connection attached
 	// to this webConn or not.
 	Active atomic.Bool
@@ -177,10 +197,11 @@ func (ps *PlatformService) PopulateWebConnConfig(s *model.Session, cfg *WebConnC
 		return nil, fmt.Errorf("invalid sequence number %s in query param: %w", seqVal, err)
 	}
 
-	// This does not handle reconnect requests across nodes in a cluster.
-	// It falls back to the non-reliable case in that scenario.
 	res := ps.CheckWebConn(s.UserId, cfg.ConnectionID, seqNum)
-	if res == nil {
+	if res == nil && ps.webSocketEventLog.enabled() {
+		// The connection is gone, but the events it was sent may still be logged.
+		ps.populateWebConnConfigFromEventLog(s.UserId, cfg, seqNum)
+	} else if res == nil {
 		// If the connection is not present, then we assume either timeout,
 		// or server restart. In that case, we set a new one.
 		cfg.ConnectionID = model.NewId()
@@ -196,6 +217,48 @@ func (ps *PlatformService) PopulateWebConnConfig(s *model.Session, cfg *WebConnC
 	return cfg, nil
 }
 
+// populateWebConnConfigFromEventLog sets up the connection to write the events the client
+// missed from the websocket event log, or to tell the client to resync if some of them
+// are no longer there. It falls back to a new connection when nothing is logged for it.
+func (ps *PlatformService) populateWebConnConfigFromEventLog(userID string, cfg *WebConnConfig, seqNum int64) {
+	events, result, err := ps.webSocketEventLog.eventsSince(userID, cfg.ConnectionID, seqNum)
+	if err != nil {
+		ps.Log().Warn("Error while getting websocket events from the log",
+			mlog.String("connection_id", cfg.ConnectionID),
+			mlog.String("user_id", userID),
+			mlog.Int("sequence_number", seqNum),
+			mlog.Err(err))
+		result = webSocketReplayNotFound
+	}
+
+	if result == webSocketReplayGap {
+		// The sequence numbers of the connection start over from the one the client
+		// expects, so the events logged after it have to go.
+		if err := ps.webSocketEventLog.reset(userID, cfg.ConnectionID); err != nil {
+			ps.Log().Warn("Error while resetting websocket event log",
+				mlog.String("connection_id", cfg.ConnectionID),
+				mlog.String("user_id", userID),
+				mlog.Err(err))
+			result = webSocketReplayNotFound
+		}
+	}
+
+	switch result {
+	case webSocketReplayFound:
+		cfg.replayQueue = events
+	case webSocketReplayGap:
+		cfg.resyncRequired = true
+	default:
+		cfg.ConnectionID = model.NewId()
+		return
+	}
+
+	// The connection is reused from another node, so no hello message is sent.
+	cfg.Active = false
+	cfg.ReuseCount = 1
+	cfg.sequence = seqNum
+}
+
 // NewWebConn returns a new WebConn instance.
 func (ps *PlatformService) NewWebConn(cfg *WebConnConfig, suite SuiteIFace, runner HookRunner) *WebConn {
 	userID := cfg.Session.UserId
@@ -207,22 +270,24 @@ func (ps *PlatformService) NewWebConn(cfg *WebConnConfig, suite SuiteIFace, runn
 		})
 	}
 
-	// Disable TCP_NO_DELAY for higher throughput
-	var tcpConn *net.TCPConn
-	switch conn := cfg.WebSocket.UnderlyingConn().(type) {
-	case *net.TCPConn:
-		tcpConn = conn
-	case *tls.Conn:
-		newConn, ok := conn.NetConn().(*net.TCPConn)
-		if ok {
-			tcpConn = newConn
+	if cfg.WebSocket != nil {
+		// Disable TCP_NO_DELAY for higher throughput
+		var tcpConn *net.TCPConn
+		switch conn := cfg.WebSocket.UnderlyingConn().(type) {
+		case *net.TCPConn:
+			tcpConn = conn
+		case *tls.Conn:
+			newConn, ok := conn.NetConn().(*net.TCPConn)
+			if ok {
+				tcpConn = newConn
+			}
 		}
-	}
 
-	if tcpConn != nil {
-		err := tcpConn.SetNoDelay(false)
-		if err != nil {
-			ps.logger.Warn("Error in setting NoDelay socket opts", mlog.Err(err))
+		if tcpConn != nil {
+			err := tcpConn.SetNoDelay(false)
+			if err != nil {
+				ps.logger.Warn("Error in setting NoDelay socket opts", mlog.Err(err))
+			}
 		}
 	}
 
@@ -241,8 +306,11 @@ func (ps *PlatformService) NewWebConn(cfg *WebConnConfig, suite SuiteIFace, runn
 		send:               cfg.activeQueue,
 		deadQueue:          cfg.deadQueue,
 		deadQueuePointer:   cfg.deadQueuePointer,
+		replayQueue:        cfg.replayQueue,
+		resyncRequired:     cfg.resyncRequired,
 		Sequence:           cfg.sequence,
 		WebSocket:          cfg.WebSocket,
+		stream:             cfg.EventStream,
 		lastUserActivityAt: model.GetMillis(),
 		UserId:             cfg.Session.UserId,
 		T:                  cfg.TFunc,
@@ -295,10 +363,19 @@ func (wc *WebConn) pluginPostedConsumer(wg *sync.WaitGroup) {
 
 // Close closes the WebConn.
 func (wc *WebConn) Close() {
-	wc.WebSocket.Close()
+	wc.closeConn()
 	<-wc.pumpFinished
 }
 
+// closeConn closes the websocket, or the event stream, of the connection.
+func (wc *WebConn) closeConn() {
+	if wc.stream != nil {
+		wc.stream.close()
+		return
+	}
+	wc.WebSocket.Close()
+}
+
 // GetSessionExpiresAt returns the time at which the session expires.
 func (wc *WebConn) GetSessionExpiresAt() int64 {
 	return atomic.LoadInt64(&wc.sessionExpiresAt)
@@ -406,6 +483,13 @@ func (wc *WebConn) SetSession(v *model.Session) {
 // Pump starts the WebConn instance. After this, the websocket
 // is ready to send/receive messages.
 func (wc *WebConn) Pump() {
+	if wc.stream != nil {
+		if err := wc.stream.open(); err != nil {
+			wc.logSocketErr("eventStream.open", err)
+			wc.stream.close()
+		}
+	}
+
 	var wg sync.WaitGroup
 	wg.Add(1)
 	go func() {
@@ -416,7 +500,11 @@ func (wc *WebConn) Pump() {
 	wg.Add(1)
 	go wc.pluginPostedConsumer(&wg)
 
-	wc.readPump()
+	if wc.stream != nil {
+		wc.readStream()
+	} else {
+		wc.readPump()
+	}
 	close(wc.endWritePump)
 	close(wc.pluginPosted)
 	wg.Wait()
@@ -483,30 +571,60 @@ func (wc *WebConn) readPump() {
 			return
 		}
 
-		// Messages which actions are prefixed with the plugin prefix
-		// should only be dispatched to the plugins
-		if !strings.HasPrefix(req.Action, websocketMessagePluginPrefix) {
-			wc.Platform.WebSocketRouter.ServeWebSocket(wc, &req)
-		}
+		wc.serveRequest(&req)
+	}
+}
 
-		clonedReq, err := req.Clone()
-		if err != nil {
-			wc.logSocketErr("websocket.cloneRequest", err)
-			continue
+// readStream is the read pump of a connection writing to an event stream, which gets the
+// requests of the client through PlatformService.ServeEventStreamRequest.
+func (wc *WebConn) readStream() {
+	defer func() {
+		if metrics := wc.Platform.metricsIFace; metrics != nil {
+			metrics.DecrementHTTPWebSockets(wc.originClient)
 		}
+		wc.stream.close()
+	}()
+	if metrics := wc.Platform.metricsIFace; metrics != nil {
+		metrics.IncrementHTTPWebSockets(wc.originClient)
+	}
 
-		if session := wc.GetSession(); session != nil {
-			clonedReq.Session.Id = session.Id
+	for {
+		select {
+		case req := <-wc.stream.requests:
+			wc.serveRequest(req)
+		case <-wc.stream.closed:
+			return
+		case <-wc.stream.ctx.Done():
+			return
 		}
+	}
+}
 
-		if clonedReq.Data == nil {
-			clonedReq.Data = map[string]any{}
-		}
-		clonedReq.Data[model.WebSocketRemoteAddr] = wc.remoteAddress
-		clonedReq.Data[model.WebSocketXForwardedFor] = wc.xForwardedFor
+// serveRequest routes a request of the client, and hands it over to the plugins.
+func (wc *WebConn) serveRequest(req *model.WebSocketRequest) {
+	// Messages which actions are prefixed with the plugin prefix
+	// should only be dispatched to the plugins
+	if !strings.HasPrefix(req.Action, websocketMessagePluginPrefix) {
+		wc.Platform.WebSocketRouter.ServeWebSocket(wc, req)
+	}
 
-		wc.pluginPosted <- pluginWSPostedHook{wc.GetConnectionID(), wc.UserId, clonedReq}
+	clonedReq, err := req.Clone()
+	if err != nil {
+		wc.logSocketErr("websocket.cloneRequest", err)
+		return
 	}
+
+	if session := wc.GetSession(); session != nil {
+		clonedReq.Session.Id = session.Id
+	}
+
+	if clonedReq.Data == nil {
+		clonedReq.Data = map[string]any{}
+	}
+	clonedReq.Data[model.WebSocketRemoteAddr] = wc.remoteAddress
+	clonedReq.Data[model.WebSocketXForwardedFor] = wc.xForwardedFor
+
+	wc.pluginPosted <- pluginWSPostedHook{wc.GetConnectionID(), wc.UserId, clonedReq}
 }
 
 func (wc *WebConn) writePump() {
@@ -516,10 +634,22 @@ func (wc *WebConn) writePump() {
 	defer func() {
 		ticker.Stop()
 		authTicker.Stop()
-		wc.WebSocket.Close()
+		wc.closeConn()
 	}()
 
-	if wc.Sequence != 0 {
+	if wc.replayQueue != nil || wc.resyncRequired {
+		if err := wc.drainReplayQueue(); err != nil {
+			wc.logSocketErr("websocket.drainReplayQueue", err)
+			return
+		}
+		if m := wc.Platform.metricsIFace; m != nil {
+			result := reconnectReplayed
+			if wc.resyncRequired {
+				result = reconnectResync
+			}
+			m.IncrementWebsocketReconnectEventWithDisconnectErrCode(result, wc.DisconnectErrCode)
+		}
+	} else if wc.Sequence != 0 {
 		if ok, index := wc.isInDeadQueue(wc.Sequence); ok {
 			if err := wc.drainDeadQueue(index); err != nil {
 				wc.logSocketErr("websocket.drainDeadQueue", err)
@@ -601,9 +731,15 @@ func (wc *WebConn) writePump() {
 
 			if evtOk {
 				wc.addToDeadQueue(evt)
+				wc.Platform.webSocketEventLog.append(wc.UserId, wc.GetConnectionID(), evt.GetSequence(), buf.Bytes())
 			}
 
-			if err := wc.writeMessageBuf(websocket.TextMessage, buf.Bytes()); err != nil {
+			if evtOk {
+				err = wc.writeEventBuf(evt, buf.Bytes())
+			} else {
+				err = wc.writeMessageBuf(websocket.TextMessage, buf.Bytes())
+			}
+			if err != nil {
 				wc.logSocketErr("websocket.send", err)
 				return
 			}
@@ -622,6 +758,10 @@ func (wc *WebConn) writePump() {
 
 		case <-authTicker.C:
 			if wc.GetSessionToken() == "" {
+				if wc.stream != nil {
+					wc.Platform.logger.Debug("websocket.authTicker: did not authenticate", mlog.String("ip_address", wc.remoteAddress))
+					return
+				}
 				wc.Platform.logger.Debug("websocket.authTicker: did not authenticate", mlog.Stringer("ip_address", wc.WebSocket.RemoteAddr()))
 				return
 			}
@@ -633,12 +773,32 @@ func (wc *WebConn) writePump() {
 // writeMessageBuf is a helper utility that wraps the write to the socket
 // along with setting the write deadline.
 func (wc *WebConn) writeMessageBuf(msgType int, data []byte) error {
+	if wc.stream != nil {
+		switch msgType {
+		case websocket.PingMessage:
+			return wc.stream.ping()
+		case websocket.CloseMessage:
+			wc.stream.close()
+			return nil
+		}
+		return wc.stream.write("", data)
+	}
+
 	if err := wc.WebSocket.SetWriteDeadline(time.Now().Add(writeWaitTime)); err != nil {
 		return err
 	}
 	return wc.WebSocket.WriteMessage(msgType, data)
 }
 
+// writeEventBuf writes the encoded event. Event streams give it an ID the client
+// can resume the stream from.
+func (wc *WebConn) writeEventBuf(evt *model.WebSocketEvent, data []byte) error {
+	if wc.stream != nil {
+		return wc.stream.write(eventStreamID(wc.GetConnectionID(), evt.GetSequence()), data)
+	}
+	return wc.writeMessageBuf(websocket.TextMessage, data)
+}
+
 func (wc *WebConn) writeMessage(msg *model.WebSocketEvent) error {
 	// We don't use the encoder from the write pump because it's unwieldy to pass encoders
 	// around, and this is only called during initialization of the webConn.
@@ -649,8 +809,55 @@ func (wc *WebConn) writeMessage(msg *model.WebSocketEvent) error {
 		return nil
 	}
 	wc.Sequence++
+	wc.Platform.webSocketEventLog.append(wc.UserId, wc.GetConnectionID(), msg.GetSequence(), buf.Bytes())
+
+	return wc.writeEventBuf(msg, buf.Bytes())
+}
+
+// drainReplayQueue writes the events missed by the client, as found in the websocket event log,
+// or tells the client to resync if some of them are no longer there.
+func (wc *WebConn) drainReplayQueue() error {
+	if wc.resyncRequired {
+		msg := model.NewWebSocketEvent(model.WebsocketEventResyncRequired, "", "", wc.UserId, nil, "").SetSequence(wc.Sequence)
+		wc.resyncRequired = false
+		wc.addToDeadQueue(msg)
+		return wc.writeMessage(msg)
+	}
+
+	for _, msg := range wc.replayQueue {
+		wc.addToDeadQueue(msg)
+		if err := wc.writeMessage(msg); err != nil {
+			return err
+		}
+	}
+	wc.replayQueue = nil
+
+	return nil
+}
+
+// logQueuedEvents logs the events queued to the connection which weren't written before it
+// became inactive. From then on, the hub logs the events as they are queued to the connection,
+// so that they can be replayed from another node.
+func (wc *WebConn) logQueuedEvents() {
+	wc.logSequence = wc.Sequence
+	wc.logQueued = true
+	for range len(wc.send) {
+		msg := <-wc.send
+		wc.logQueuedEvent(msg)
+		wc.send <- msg
+	}
+}
+
+// logQueuedEvent logs the message queued to the inactive connection, with the sequence number
+// it'll be written with once the connection is reused.
+func (wc *WebConn) logQueuedEvent(msg model.WebSocketMessage) {
+	evt, ok := msg.(*model.WebSocketEvent)
+	if !ok || !wc.logQueued {
+		return
+	}
 
-	return wc.writeMessageBuf(websocket.TextMessage, buf.Bytes())
+	wc.Platform.webSocketEventLog.appendEvent(wc.UserId, wc.GetConnectionID(), wc.logSequence, evt)
+	wc.logSequence++
 }
 
 // addToDeadQueue appends a message to the dead queue.
@@ -237,3 +237,112 @@ func TestWebConnDrainDeadQueue(t *testing.T) {
 		t.Run("Overwritten First", func(t *testing.T) { run(int64(128), deadQueueSize+10) })
 	})
 }
+
+func TestWebConnDrainReplayQueue(t *testing.T) {
+	th := Setup(t)
+
+	var readEvents = func(t *testing.T, received chan<- *model.WebSocketEvent) http.HandlerFunc {
+		return func(w http.ResponseWriter, req *http.Request) {
+			upgrader := &websocket.Upgrader{}
+			conn, err := upgrader.Upgrade(w, req, nil)
+			require.NoError(t, err)
+			defer close(received)
+			for {
+				_, buf, err := conn.ReadMessage()
+				if err != nil {
+					return
+				}
+				ev, jsonErr := model.WebSocketEventFromJSON(bytes.NewReader(buf))
+				require.NoError(t, jsonErr)
+				received <- ev
+			}
+		}
+	}
+
+	var dialConn = func(t *testing.T, addr net.Addr, cfg *WebConnConfig) *WebConn {
+		d := websocket.Dialer{}
+		c, _, err := d.Dial("ws://"+addr.String()+"/ws", nil)
+		require.NoError(t, err)
+
+		cfg.WebSocket = c
+		return th.Service.NewWebConn(cfg, th.Suite, &hookRunner{})
+	}
+
+	t.Run("missed events are written", func(t *testing.T) {
+		received := make(chan *model.WebSocketEvent, 10)
+		s := httptest.NewServer(readEvents(t, received))
+		defer s.Close()
+
+		replayQueue := []*model.WebSocketEvent{
+			model.NewWebSocketEvent(model.WebsocketEventPosted, "", "", "", nil, "").SetSequence(3),
+			model.NewWebSocketEvent(model.WebsocketEventPostEdited, "", "", "", nil, "").SetSequence(4),
+		}
+		wc := dialConn(t, s.Listener.Addr(), &WebConnConfig{sequence: 3, replayQueue: replayQueue})
+
+		require.NoError(t, wc.drainReplayQueue())
+		wc.WebSocket.Close()
+
+		var sequences []int64
+		for ev := range received {
+			sequences = append(sequences, ev.GetSequence())
+		}
+		assert.Equal(t, []int64{3, 4}, sequences)
+		assert.Equal(t, int64(5), wc.Sequence)
+		found, _ := wc.isInDeadQueue(4)
+		assert.True(t, found)
+		assert.Nil(t, wc.replayQueue)
+	})
+
+	t.Run("resync is required", func(t *testing.T) {
+		received := make(chan *model.WebSocketEvent, 10)
+		s := httptest.NewServer(readEvents(t, received))
+		defer s.Close()
+
+		wc := dialConn(t, s.Listener.Addr(), &WebConnConfig{sequence: 7, resyncRequired: true})
+
+		require.NoError(t, wc.drainReplayQueue())
+		wc.WebSocket.Close()
+
+		ev := <-received
+		require.NotNil(t, ev)
+		assert.Equal(t, model.WebsocketEventResyncRequired, ev.EventType())
+		assert.Equal(t, int64(7), ev.GetSequence())
+		assert.Equal(t, int64(8), wc.Sequence)
+		assert.False(t, wc.resyncRequired)
+	})
+}
+
+func TestWebConnLogQueuedEvents(t *testing.T) {
+	th := Setup(t)
+
+	wc := th.Service.NewWebConn(&WebConnConfig{
+		WebSocket: &websocket.Conn{},
+	}, th.Suite, &hookRunner{})
+	wc.Sequence = 5
+
+	first := model.NewWebSocketEvent(model.WebsocketEventPosted, "", "", "", nil, "")
+	response := model.NewWebSocketResponse(model.StatusOk, 1, nil)
+	second := model.NewWebSocketEvent(model.WebsocketEventPostEdited, "", "", "", nil, "")
+
+	// Nothing is logged before the connection becomes inactive.
+	wc.logQueuedEvent(first)
+	assert.Equal(t, int64(0), wc.logSequence)
+
+	wc.send <- first
+	wc.send <- response
+	wc.send <- second
+
+	wc.logQueuedEvents()
+	assert.True(t, wc.logQueued)
+	// The response isn't sequenced when written, so it's skipped.
+	assert.Equal(t, int64(7), wc.logSequence)
+
+	// The queue is left as it was.
+	require.Len(t, wc.send, 3)
+	assert.Equal(t, model.WebSocketMessage(first), <-wc.send)
+	assert.Equal(t, model.WebSocketMessage(response), <-wc.send)
+	assert.Equal(t, model.WebSocketMessage(second), <-wc.send)
+
+	wc.logQueuedEvent(first)
+	assert.Equal(t, int64(8), wc.logSequence)
+}
@@ -4,6 +4,9 @@
 package platform
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"hash/maphash"
 	"iter"
@@ -23,6 +26,7 @@ import (
 const (
 	broadcastQueueSize         = 4096
 	inactiveConnReaperInterval = 5 * time.Minute
+	longPollGracePeriod        = 10 * time.Second
 )
 
 type SuiteIFace interface {
@@ -61,6 +65,12 @@ type webConnCheckMessage struct {
 	result       chan *CheckConnResult
 }
 
+type webConnGetMessage struct {
+	userID       string
+	connectionID string
+	result       chan *WebConn
+}
+
 type webConnCountMessage struct {
 	userID string
 	result chan int
@@ -88,6 +98,7 @@ type Hub struct {
 	explicitStop    bool
 	checkRegistered chan *webConnSessionMessage
 	checkConn       chan *webConnCheckMessage
+	getConn         chan *webConnGetMessage
 	connCount       chan *webConnCountMessage
 	broadcastHooks  map[string]BroadcastHook
 
@@ -109,6 +120,7 @@ func newWebHub(ps *PlatformService) *Hub {
 		directMsg:       make(chan *webConnDirectMessage),
 		checkRegistered: make(chan *webConnSessionMessage),
 		checkConn:       make(chan *webConnCheckMessage),
+		getConn:         make(chan *webConnGetMessage),
 		connCount:       make(chan *webConnCountMessage),
 		hubSemaphore:    make(chan struct{}, hubSemaphoreCount),
 	}
@@ -350,6 +362,67 @@ func (ps *PlatformService) CheckWebConn(userID, connectionID string, seqNum int6
 	return nil
 }
 
+// eventStreamRequest is a request for an event stream connection, sent to the other nodes
+// of the cluster when the connection isn't on the node the client sent it to.
+// The session is identified by a hash of its token, so that the token isn't sent around.
+type eventStreamRequest struct {
+	UserID       string                  `json:"user_id"`
+	SessionHash  string                  `json:"session_hash"`
+	ConnectionID string                  `json:"connection_id"`
+	Request      *model.WebSocketRequest `json:"request"`
+}
+
+// ServeEventStreamRequest hands the request over to the event stream connection of the session
+// with the given id. When the connection isn't on this node, the request is sent to the other
+// nodes of the cluster, as the client may be streaming from any of them. It returns false if
+// there is no such connection on this node and no cluster to look for it in.
+func (ps *PlatformService) ServeEventStreamRequest(session *model.Session, connectionID string, req *model.WebSocketRequest) bool {
+	sessionHash := eventStreamSessionHash(session.Token)
+	if ps.serveEventStreamRequestLocal(session.UserId, sessionHash, connectionID, req) {
+		return true
+	}
+
+	if ps.Cluster() == nil {
+		return false
+	}
+
+	data, err := json.Marshal(eventStreamRequest{
+		UserID:       session.UserId,
+		SessionHash:  sessionHash,
+		ConnectionID: connectionID,
+		Request:      req,
+	})
+	if err != nil {
+		ps.Log().Warn("Failed to encode event stream request", mlog.Err(err))
+		return false
+	}
+
+	ps.Cluster().SendClusterMessage(&model.ClusterMessage{
+		Event:    model.ClusterEventEventStreamRequest,
+		SendType: model.ClusterSendReliable,
+		Data:     data,
+	})
+	return true
+}
+
+func (ps *PlatformService) serveEventStreamRequestLocal(userID, sessionHash, connectionID string, req *model.WebSocketRequest) bool {
+	hub := ps.GetHubForUserId(userID)
+	if hub == nil {
+		return false
+	}
+
+	conn := hub.GetConn(userID, connectionID)
+	if conn == nil || conn.stream == nil || eventStreamSessionHash(conn.GetSessionToken()) != sessionHash {
+		return false
+	}
+	return conn.stream.request(req)
+}
+
+func eventStreamSessionHash(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
+
 // WebConnCountForUser returns the number of active websocket connections
 // for a given userID.
 func (ps *PlatformService) WebConnCountForUser(userID string) int {
@@ -411,6 +484,21 @@ func (h *Hub) CheckConn(userID, connectionID string) *CheckConnResult {
 	return nil
 }
 
+// GetConn returns the active connection of the user with the given id, if any.
+func (h *Hub) GetConn(userID, connectionID string) *WebConn {
+	req := &webConnGetMessage{
+		userID:       userID,
+		connectionID: connectionID,
+		result:       make(chan *WebConn),
+	}
+	select {
+	case h.getConn <- req:
+		return <-req.result
+	case <-h.stop:
+	}
+	return nil
+}
+
 func (h *Hub) WebConnCountForUser(userID string) int {
 	req := &webConnCountMessage{
 		userID: userID,
@@ -556,6 +644,15 @@ func (h *Hub) Start() {
 					}
 				}
 				req.result <- res
+			case req := <-h.getConn:
+				var res *WebConn
+				for conn := range connIndex.ForUser(req.userID) {
+					if conn.GetConnectionID() == req.connectionID && conn.Active.Load() {
+						res = conn
+						break
+					}
+				}
+				req.result <- res
 			case req := <-h.connCount:
 				req.result <- connIndex.ForUserActiveCount(req.userID)
 			case <-ticker.C:
@@ -592,33 +689,32 @@ func (h *Hub) Start() {
 					continue
 				}
 
+				// The write pump is done, so the events it didn't write can be logged.
+				if connIndex.Has(webConn) && h.platform.webSocketEventLog.enabled() {
+					webConn.logQueuedEvents()
+				}
+
 				conns := connIndex.ForUser(webConn.UserId)
 				// areAllInactive also returns true if there are no connections,
 				// which is intentional.
 				if areAllInactive(conns) {
 					userID := webConn.UserId
-					h.ProcessAsync(func() {
-						// If this is an HA setup, get count for this user
-						// from other nodes.
-						var clusterCnt int
-						var appErr *model.AppError
-						if h.platform.Cluster() != nil {
-							clusterCnt, appErr = h.platform.Cluster().WebConnCountForUser(userID)
-						}
-						if appErr != nil {
-							mlog.Error("Error in trying to get the webconn count from cluster", mlog.Err(appErr))
-							// We take a conservative approach
-							// and do not set status to offline in case
-							// there's an error, rather than potentially
-							// incorrectly setting status to offline.
-							return
-						}
-						// Only set to offline if there are no
-						// active connections in other nodes as well.
-						if clusterCnt == 0 {
-							h.platform.QueueSetStatusOffline(userID, false)
-						}
-					})
+					if webConn.stream != nil && webConn.stream.longPoll {
+						// The client polls again right away, so it only goes
+						// offline if it doesn't.
+						time.AfterFunc(longPollGracePeriod, func() {
+							select {
+							case <-h.stop:
+								return
+							default:
+							}
+							if h.WebConnCountForUser(userID) == 0 {
+								h.setStatusOfflineIfInactive(userID)
+							}
+						})
+						continue
+					}
+					h.setStatusOfflineIfInactive(userID)
 					continue
 				}
 				var latestActivity int64
@@ -668,6 +764,9 @@ func (h *Hub) Start() {
 				}
 				select {
 				case directMsg.conn.send <- directMsg.msg:
+					if !directMsg.conn.Active.Load() {
+						directMsg.conn.logQueuedEvent(directMsg.msg)
+					}
 				default:
 					// Don't log the warning if it's an inactive connection.
 					if directMsg.conn.Active.Load() {
@@ -692,8 +791,12 @@ func (h *Hub) Start() {
 						return
 					}
 					if webConn.ShouldSendEvent(msg) {
+						hookedMsg := h.runBroadcastHooks(msg, webConn, broadcastHooks, broadcastHookArgs)
 						select {
-						case webConn.send <- h.runBroadcastHooks(msg, webConn, broadcastHooks, broadcastHookArgs):
+						case webConn.send <- hookedMsg:
+							if !webConn.Active.Load() {
+								webConn.logQueuedEvent(hookedMsg)
+							}
 						default:
 							// Don't log the warning if it's an inactive connection.
 							if webConn.Active.Load() {
@@ -773,6 +876,33 @@ func (h *Hub) Start() {
 	go doRecoverableStart()
 }
 
+// setStatusOfflineIfInactive sets the user offline, unless they have active
+// connections in other nodes.
+func (h *Hub) setStatusOfflineIfInactive(userID string) {
+	h.ProcessAsync(func() {
+		// If this is an HA setup, get count for this user
+		// from other nodes.
+		var clusterCnt int
+		var appErr *model.AppError
+		if h.platform.Cluster() != nil {
+			clusterCnt, appErr = h.platform.Cluster().WebConnCountForUser(userID)
+		}
+		if appErr != nil {
+			mlog.Error("Error in trying to get the webconn count from cluster", mlog.Err(appErr))
+			// We take a conservative approach
+			// and do not set status to offline in case
+			// there's an error, rather than potentially
+			// incorrectly setting status to offline.
+			return
+		}
+		// Only set to offline if there are no
+		// active connections in other nodes as well.
+		if clusterCnt == 0 {
+			h.platform.QueueSetStatusOffline(userID, false)
+		}
+	})
+}
+
 // areAllInactive returns whether all of the connections
 // are inactive or not. It also returns true if there are
 // no connections which is also intentional.
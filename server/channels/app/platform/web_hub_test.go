@@ -953,3 +953,55 @@ func TestClusterBroadcastHooks(t *testing.T) {
 		assert.IsType(t, []any{}, received.GetBroadcast().BroadcastHookArgs[0]["array"])
 	})
 }
+
+func TestServeEventStreamRequestThroughCluster(t *testing.T) {
+	mainHelper.Parallel(t)
+	testCluster := &testlib.FakeClusterInterface{}
+	th := SetupWithCluster(t, testCluster).InitBasic(t)
+
+	session, err := th.Service.CreateSession(th.Context, &model.Session{
+		UserId: th.BasicUser.Id,
+	})
+	require.NoError(t, err)
+
+	// The client streams its events from another node.
+	other := Setup(t)
+	stream := NewEventStream(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/api/v4/websocket/events", nil))
+	wc := other.Service.NewWebConn(&WebConnConfig{
+		EventStream:  stream,
+		Session:      *session,
+		TFunc:        i18n.IdentityTfunc(),
+		Locale:       "en",
+		ConnectionID: model.NewId(),
+		Active:       true,
+	}, other.Suite, &hookRunner{})
+	require.NoError(t, other.Service.HubRegister(wc))
+	go wc.Pump()
+
+	req := &model.WebSocketRequest{Seq: 1, Action: "user_typing"}
+	require.True(t, th.Service.ServeEventStreamRequest(session, wc.GetConnectionID(), req))
+
+	messages := testCluster.SelectMessages(func(msg *model.ClusterMessage) bool {
+		return msg.Event == model.ClusterEventEventStreamRequest
+	})
+	require.Len(t, messages, 1)
+	assert.Equal(t, model.ClusterSendReliable, messages[0].SendType)
+	assert.NotContains(t, string(messages[0].Data), session.Token)
+
+	var streamReq eventStreamRequest
+	require.NoError(t, json.Unmarshal(messages[0].Data, &streamReq))
+	assert.Equal(t, wc.GetConnectionID(), streamReq.ConnectionID)
+	assert.Equal(t, req.Action, streamReq.Request.Action)
+
+	// The node holding the connection serves the request.
+	assert.True(t, other.Service.serveEventStreamRequestLocal(streamReq.UserID, streamReq.SessionHash, streamReq.ConnectionID, streamReq.Request))
+	other.Service.clusterEventStreamRequestHandler(messages[0])
+
+	t.Run("requests for another session are ignored", func(t *testing.T) {
+		assert.False(t, other.Service.serveEventStreamRequestLocal(session.UserId, eventStreamSessionHash(model.NewId()), wc.GetConnectionID(), req))
+	})
+
+	t.Run("without a cluster the connection must be on this node", func(t *testing.T) {
+		assert.False(t, other.Service.ServeEventStreamRequest(session, model.NewId(), req))
+	})
+}
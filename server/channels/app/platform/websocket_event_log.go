@@ -0,0 +1,291 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package platform
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/redis/rueidis"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+const (
+	webSocketEventLogQueueSize = 4096
+	webSocketEventLogBatchSize = 256
+	webSocketEventLogTimeout   = 5 * time.Second
+	webSocketEventLogField     = "event"
+)
+
+// webSocketReplayResult is the outcome of looking up the events a reconnecting client missed.
+type webSocketReplayResult int
+
+const (
+	// webSocketReplayNotFound means nothing is logged for the connection, either because it
+	// expired or because it was never logged.
+	webSocketReplayNotFound webSocketReplayResult = iota
+	// webSocketReplayGap means some of the missed events are no longer in the log, so the client
+	// has to resync.
+	webSocketReplayGap
+	// webSocketReplayFound means all the missed events are in the log.
+	webSocketReplayFound
+)
+
+type webSocketEventLogEntry struct {
+	userID       string
+	connectionID string
+	sequence     int64
+	buf          []byte
+}
+
+// webSocketEventLog persists the events sent to the websocket connections in Redis streams, one
+// per connection, keyed by sequence number. It lets a client reconnecting to any node get the
+// events it missed after the node it was connected to went away, along with their queues.
+//
+// Events are written in the background, in the order they're logged. An event that can't be
+// written leaves a gap in the log, which makes the client resync when it reconnects.
+type webSocketEventLog struct {
+	platform *PlatformService
+	client   rueidis.Client
+
+	entries chan webSocketEventLogEntry
+	stop    chan struct{}
+	wg      sync.WaitGroup
+
+	lastDropLogTime time.Time
+	dropLogMut      sync.Mutex
+}
+
+func newWebSocketEventLog(ps *PlatformService, client rueidis.Client) *webSocketEventLog {
+	return &webSocketEventLog{
+		platform: ps,
+		client:   client,
+		entries:  make(chan webSocketEventLogEntry, webSocketEventLogQueueSize),
+		stop:     make(chan struct{}),
+	}
+}
+
+// enabled returns whether events are logged. It's safe to call on a nil log.
+func (l *webSocketEventLog) enabled() bool {
+	return l != nil && *l.platform.Config().ServiceSettings.EnableWebSocketEventLog
+}
+
+func (l *webSocketEventLog) start() {
+	if l == nil {
+		return
+	}
+
+	l.wg.Add(1)
+	go l.run()
+}
+
+// close writes the events logged so far and stops the log.
+func (l *webSocketEventLog) close() {
+	if l == nil {
+		return
+	}
+
+	close(l.stop)
+	l.wg.Wait()
+}
+
+// append logs the event sent to the connection. buf is the event as written to the
+// websocket, including its sequence number.
+func (l *webSocketEventLog) append(userID, connectionID string, sequence int64, buf []byte) {
+	if !l.enabled() || userID == "" {
+		return
+	}
+
+	entry := webSocketEventLogEntry{
+		userID:       userID,
+		connectionID: connectionID,
+		sequence:     sequence,
+		buf:          bytes.Clone(buf),
+	}
+
+	select {
+	case l.entries <- entry:
+	default:
+		l.dropLogMut.Lock()
+		defer l.dropLogMut.Unlock()
+		if time.Since(l.lastDropLogTime) > websocketSuppressWarnThreshold {
+			l.platform.Log().Warn("WebSocket event log is full, dropping events",
+				mlog.String("user_id", userID),
+				mlog.String("conn_id", connectionID))
+			l.lastDropLogTime = time.Now()
+		}
+	}
+}
+
+// appendEvent logs the event with the given sequence number.
+func (l *webSocketEventLog) appendEvent(userID, connectionID string, sequence int64, evt *model.WebSocketEvent) {
+	if !l.enabled() || userID == "" {
+		return
+	}
+
+	buf, err := evt.SetSequence(sequence).ToJSON()
+	if err != nil {
+		l.platform.Log().Warn("Error in encoding websocket event to log", mlog.String("user_id", userID), mlog.Err(err))
+		return
+	}
+	l.append(userID, connectionID, sequence, buf)
+}
+
+func (l *webSocketEventLog) run() {
+	defer l.wg.Done()
+
+	batch := make([]webSocketEventLogEntry, 0, webSocketEventLogBatchSize)
+	for {
+		select {
+		case entry := <-l.entries:
+			batch = append(batch[:0], entry)
+			batch = l.fill(batch)
+			l.write(batch)
+		case <-l.stop:
+			for len(l.entries) > 0 {
+				batch = l.fill(batch[:0])
+				l.write(batch)
+			}
+			return
+		}
+	}
+}
+
+// fill adds the entries waiting to be written to the batch, without blocking.
+func (l *webSocketEventLog) fill(batch []webSocketEventLogEntry) []webSocketEventLogEntry {
+	for len(batch) < webSocketEventLogBatchSize {
+		select {
+		case entry := <-l.entries:
+			batch = append(batch, entry)
+		default:
+			return batch
+		}
+	}
+	return batch
+}
+
+func (l *webSocketEventLog) write(batch []webSocketEventLogEntry) {
+	if len(batch) == 0 {
+		return
+	}
+
+	settings := l.platform.Config().ServiceSettings
+	maxEvents := strconv.Itoa(*settings.WebSocketEventLogMaxEvents)
+	retention := time.Duration(*settings.WebSocketEventLogRetentionSeconds) * time.Second
+
+	cmds := make(rueidis.Commands, 0, len(batch)+1)
+	keys := make(map[string]bool)
+	for _, entry := range batch {
+		key := l.key(entry.userID, entry.connectionID)
+		cmds = append(cmds, l.client.B().Xadd().Key(key).Maxlen().Almost().Threshold(maxEvents).
+			Id(webSocketEventLogID(entry.sequence)).FieldValue().FieldValue(webSocketEventLogField, rueidis.BinaryString(entry.buf)).Build())
+		keys[key] = true
+	}
+	for key := range keys {
+		cmds = append(cmds, l.client.B().Pexpire().Key(key).Milliseconds(retention.Milliseconds()).Build())
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), webSocketEventLogTimeout)
+	defer cancel()
+	for _, res := range l.client.DoMulti(ctx, cmds...) {
+		// An event can be logged twice, when it's queued to an inactive connection and
+		// written once the connection is reused, in which case the second write is rejected.
+		if err := res.Error(); err != nil && !isWebSocketEventLogDuplicate(err) {
+			l.platform.Log().Warn("Error in writing websocket events to the log", mlog.Err(err))
+			return
+		}
+	}
+}
+
+// eventsSince returns the events logged for the connection from the given sequence number on.
+func (l *webSocketEventLog) eventsSince(userID, connectionID string, sequence int64) ([]*model.WebSocketEvent, webSocketReplayResult, error) {
+	// The event before the wanted one is needed to tell whether all the events up to the
+	// wanted one are still in the log.
+	start := max(sequence-1, 0)
+
+	key := l.key(userID, connectionID)
+	ctx, cancel := context.WithTimeout(context.Background(), webSocketEventLogTimeout)
+	defer cancel()
+	entries, err := l.client.Do(ctx, l.client.B().Xrange().Key(key).Start(webSocketEventLogID(start)).End("+").Build()).AsXRange()
+	if err != nil {
+		return nil, webSocketReplayNotFound, fmt.Errorf("failed to read websocket events: %w", err)
+	}
+
+	if len(entries) == 0 {
+		exists, err := l.client.Do(ctx, l.client.B().Exists().Key(key).Build()).AsInt64()
+		if err != nil {
+			return nil, webSocketReplayNotFound, fmt.Errorf("failed to read websocket events: %w", err)
+		}
+		if exists == 0 {
+			return nil, webSocketReplayNotFound, nil
+		}
+		return nil, webSocketReplayGap, nil
+	}
+
+	events := make([]*model.WebSocketEvent, 0, len(entries))
+	next := start
+	for _, entry := range entries {
+		seq, err := parseWebSocketEventLogID(entry.ID)
+		if err != nil {
+			return nil, webSocketReplayNotFound, err
+		}
+		if seq != next {
+			return nil, webSocketReplayGap, nil
+		}
+		next++
+
+		if seq < sequence {
+			continue
+		}
+		evt, err := model.WebSocketEventFromJSON(strings.NewReader(entry.FieldValues[webSocketEventLogField]))
+		if err != nil {
+			return nil, webSocketReplayNotFound, fmt.Errorf("failed to decode websocket event: %w", err)
+		}
+		events = append(events, evt)
+	}
+
+	return events, webSocketReplayFound, nil
+}
+
+// reset deletes the events logged for the connection, so that it can be logged again from any
+// sequence number.
+func (l *webSocketEventLog) reset(userID, connectionID string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), webSocketEventLogTimeout)
+	defer cancel()
+	if err := l.client.Do(ctx, l.client.B().Del().Key(l.key(userID, connectionID)).Build()).Error(); err != nil {
+		return fmt.Errorf("failed to delete websocket events: %w", err)
+	}
+	return nil
+}
+
+func (l *webSocketEventLog) key(userID, connectionID string) string {
+	return *l.platform.Config().CacheSettings.RedisCachePrefix + "ws_events:" + userID + ":" + connectionID
+}
+
+// webSocketEventLogID returns the stream entry ID of the event with the given sequence
+// number. The sequence part is set as IDs must be greater than 0-0.
+func webSocketEventLogID(sequence int64) string {
+	return strconv.FormatInt(sequence, 10) + "-1"
+}
+
+func parseWebSocketEventLogID(id string) (int64, error) {
+	seq, _, _ := strings.Cut(id, "-")
+	sequence, err := strconv.ParseInt(seq, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid websocket event id %q: %w", id, err)
+	}
+	return sequence, nil
+}
+
+func isWebSocketEventLogDuplicate(err error) bool {
+	redisErr, ok := rueidis.IsRedisErr(err)
+	return ok && strings.Contains(redisErr.Error(), "equal or smaller")
+}
@@ -0,0 +1,36 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package platform
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebSocketEventLogID(t *testing.T) {
+	for _, seq := range []int64{0, 1, 1 << 40} {
+		id := webSocketEventLogID(seq)
+		parsed, err := parseWebSocketEventLogID(id)
+		require.NoError(t, err)
+		assert.Equal(t, seq, parsed)
+	}
+
+	// Stream IDs must be greater than 0-0.
+	assert.Equal(t, "0-1", webSocketEventLogID(0))
+
+	_, err := parseWebSocketEventLogID("invalid")
+	require.Error(t, err)
+}
+
+func TestWebSocketEventLogEnabled(t *testing.T) {
+	var log *webSocketEventLog
+	assert.False(t, log.enabled())
+
+	// Appending to a disabled log is a no-op.
+	log.append("user_id", "connection_id", 1, []byte("{}"))
+	log.start()
+	log.close()
+}
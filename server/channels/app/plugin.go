@@ -15,6 +15,7 @@ import (
 	"sort"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/blang/semver/v4"
 	svg "github.com/h2non/go-is-svg"
@@ -167,6 +168,13 @@ func (a *App) NewPluginAPI(rctx request.CTX, manifest *model.Manifest) plugin.AP
 	return NewPluginAPI(a, rctx, manifest)
 }
 
+func wasmLimits(cfg *model.Config) plugin.WasmLimits {
+	return plugin.WasmLimits{
+		MemoryLimitMB: *cfg.PluginSettings.WasmMemoryLimitMB,
+		HookTimeout:   time.Duration(*cfg.PluginSettings.WasmHookTimeoutMilliseconds) * time.Millisecond,
+	}
+}
+
 func (a *App) InitPlugins(rctx request.CTX, pluginDir, webappPluginDir string) {
 	a.ch.initPlugins(rctx, pluginDir, webappPluginDir)
 }
@@ -184,6 +192,7 @@ func (ch *Channels) initPlugins(rctx request.CTX, pluginDir, webappPluginDir str
 		ch.syncPluginsActiveState()
 		if pluginsEnvironment != nil {
 			pluginsEnvironment.TogglePluginHealthCheckJob(*ch.cfgSvc.Config().PluginSettings.EnableHealthCheck)
+			pluginsEnvironment.SetWasmLimits(wasmLimits(ch.cfgSvc.Config()))
 		}
 		return
 	}
@@ -210,7 +219,7 @@ func (ch *Channels) initPlugins(rctx request.CTX, pluginDir, webappPluginDir str
 		pluginDir,
 		webappPluginDir,
 		ch.srv.Log(),
-		ch.srv.GetMetrics(),
+		newPluginTracingMetrics(ch.srv.GetMetrics()),
 	)
 	if err != nil {
 		ch.srv.Log().Error("Failed to start up plugins", mlog.Err(err))
@@ -221,6 +230,7 @@ func (ch *Channels) initPlugins(rctx request.CTX, pluginDir, webappPluginDir str
 	ch.pluginsLock.Unlock()
 
 	ch.pluginsEnvironment.TogglePluginHealthCheckJob(*ch.cfgSvc.Config().PluginSettings.EnableHealthCheck)
+	ch.pluginsEnvironment.SetWasmLimits(wasmLimits(ch.cfgSvc.Config()))
 
 	if err := ch.syncPlugins(); err != nil {
 		ch.srv.Log().Error("Failed to sync plugins from the file store", mlog.Err(err))
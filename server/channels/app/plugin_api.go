@@ -19,6 +19,7 @@ import (
 	"github.com/mattermost/mattermost/server/public/shared/i18n"
 	"github.com/mattermost/mattermost/server/public/shared/mlog"
 	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/app/openai"
 )
 
 type PluginAPI struct {
@@ -1627,3 +1628,91 @@ func (api *PluginAPI) DeletePropertyValuesForTarget(groupID, targetType, targetI
 func (api *PluginAPI) DeletePropertyValuesForField(groupID, fieldID string) error {
 	return api.app.PropertyService().DeletePropertyValuesForField(groupID, fieldID)
 }
+
+func (api *PluginAPI) SummarizeThread(userID, postID, level string) (*model.AISummary, *model.AppError) {
+	post, appErr := api.app.GetSinglePost(api.ctx, postID, false)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	response, appErr := api.app.SummarizeThread(api.ctx, &SummarizationRequest{
+		ChannelId:    post.ChannelId,
+		PostId:       postID,
+		SummaryLevel: level,
+		MaxMessages:  api.app.GetAIMaxMessageLimit(),
+		UserId:       userID,
+		UseCache:     true,
+		PluginId:     api.id,
+	})
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	return response.Summary, nil
+}
+
+func (api *PluginAPI) FormatMessage(userID, message, profile string) (string, *model.AppError) {
+	if _, appErr := api.app.GetUser(userID); appErr != nil {
+		return "", appErr
+	}
+
+	response, appErr := api.app.FormatMessage(api.ctx, &FormattingRequest{
+		Message:  message,
+		Profile:  openai.FormattingProfile(profile),
+		UserId:   userID,
+		PluginId: api.id,
+	})
+	if appErr != nil {
+		return "", appErr
+	}
+
+	return response.FormattedText, nil
+}
+
+func (api *PluginAPI) CreateActionItem(item *model.AIActionItem) (*model.AIActionItem, *model.AppError) {
+	if !api.app.IsAIFeatureEnabled(model.AIFeatureActionItems) {
+		return nil, model.NewAppError("CreateActionItem", "app.plugin.ai.feature_disabled.app_error", map[string]any{"Feature": model.AIFeatureActionItems}, "", http.StatusForbidden)
+	}
+
+	created, err := api.app.CreateActionItem(api.ctx, item)
+	if err != nil {
+		var appErr *model.AppError
+		if errors.As(err, &appErr) {
+			return nil, appErr
+		}
+		return nil, model.NewAppError("CreateActionItem", "app.plugin.ai.create_action_item.app_error", nil, "", http.StatusBadRequest).Wrap(err)
+	}
+
+	return created, nil
+}
+
+func (api *PluginAPI) CreateAICompletion(userID string, request *model.AICompletionRequest) (*model.AICompletionResponse, *model.AppError) {
+	if request == nil {
+		return nil, model.NewAppError("CreateAICompletion", "app.plugin.ai.completion.invalid_request.app_error", nil, "", http.StatusBadRequest)
+	}
+	if appErr := request.IsValid(); appErr != nil {
+		return nil, appErr
+	}
+	if _, appErr := api.app.GetUser(userID); appErr != nil {
+		return nil, appErr
+	}
+
+	aiService := api.app.GetAIService()
+	if aiService == nil {
+		return nil, model.NewAppError("CreateAICompletion", "app.plugin.ai.service_not_available.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	response, err := aiService.complete(api.ctx, &model.AIPrompt{
+		Feature:      model.AIFeatureCompletion,
+		UserId:       userID,
+		PluginId:     api.id,
+		Model:        request.Model,
+		SystemPrompt: request.SystemPrompt,
+		UserPrompt:   request.UserPrompt,
+	})
+	if err != nil {
+		return nil, aiCompletionError("CreateAICompletion", "app.plugin.ai.completion.app_error", err)
+	}
+
+	return response, nil
+}
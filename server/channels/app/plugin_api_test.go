@@ -3470,3 +3470,119 @@ func TestPluginAPICountPropertyFields(t *testing.T) {
 		assert.Equal(t, int64(0), count)
 	})
 }
+
+func TestPluginAPICreateAICompletion(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t).InitBasic(t)
+
+	api := th.SetupPluginAPI()
+
+	t.Run("invalid request", func(t *testing.T) {
+		_, appErr := api.CreateAICompletion(th.BasicUser.Id, nil)
+		require.NotNil(t, appErr)
+		assert.Equal(t, http.StatusBadRequest, appErr.StatusCode)
+
+		_, appErr = api.CreateAICompletion(th.BasicUser.Id, &model.AICompletionRequest{SystemPrompt: "Be brief."})
+		require.NotNil(t, appErr)
+		assert.Equal(t, "model.ai_completion_request.is_valid.user_prompt.app_error", appErr.Id)
+	})
+
+	t.Run("unknown user", func(t *testing.T) {
+		_, appErr := api.CreateAICompletion(model.NewId(), &model.AICompletionRequest{UserPrompt: "Hello"})
+		require.NotNil(t, appErr)
+	})
+
+	t.Run("AI disabled", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) {
+			*cfg.AISettings.Enable = false
+		})
+
+		_, appErr := api.CreateAICompletion(th.BasicUser.Id, &model.AICompletionRequest{UserPrompt: "Hello"})
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.plugin.ai.service_not_available.app_error", appErr.Id)
+	})
+}
+
+func TestPluginAPICreateActionItem(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t).InitBasic(t)
+
+	api := th.SetupPluginAPI()
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.AISettings.Enable = true
+		*cfg.AISettings.EnableActionItems = true
+	})
+
+	t.Run("create", func(t *testing.T) {
+		item, appErr := api.CreateActionItem(&model.AIActionItem{
+			ChannelId:   th.BasicChannel.Id,
+			CreatedBy:   th.BasicUser.Id,
+			AssigneeId:  th.BasicUser2.Id,
+			Description: "Review the pull request",
+		})
+		require.Nil(t, appErr)
+		assert.NotEmpty(t, item.Id)
+		assert.Equal(t, model.AIActionItemStatusOpen, item.Status)
+	})
+
+	t.Run("invalid item", func(t *testing.T) {
+		_, appErr := api.CreateActionItem(&model.AIActionItem{
+			ChannelId: th.BasicChannel.Id,
+			CreatedBy: th.BasicUser.Id,
+		})
+		require.NotNil(t, appErr)
+		assert.Equal(t, http.StatusBadRequest, appErr.StatusCode)
+	})
+
+	t.Run("no access to the channel", func(t *testing.T) {
+		_, appErr := api.CreateActionItem(&model.AIActionItem{
+			ChannelId:   th.BasicChannel.Id,
+			CreatedBy:   th.CreateUser(t).Id,
+			AssigneeId:  th.BasicUser.Id,
+			Description: "Review the pull request",
+		})
+		require.NotNil(t, appErr)
+		assert.Equal(t, http.StatusForbidden, appErr.StatusCode)
+	})
+
+	t.Run("feature disabled", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) {
+			*cfg.AISettings.EnableActionItems = false
+		})
+
+		_, appErr := api.CreateActionItem(&model.AIActionItem{
+			ChannelId:   th.BasicChannel.Id,
+			CreatedBy:   th.BasicUser.Id,
+			AssigneeId:  th.BasicUser.Id,
+			Description: "Review the pull request",
+		})
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.plugin.ai.feature_disabled.app_error", appErr.Id)
+	})
+}
+
+func TestCheckAIPluginRateLimit(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t)
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.AISettings.APIRateLimit = 2
+	})
+
+	require.Nil(t, th.App.checkAIPluginRateLimit("plugin1"))
+	require.Nil(t, th.App.checkAIPluginRateLimit("plugin1"))
+	require.Nil(t, th.App.checkAIPluginRateLimit("plugin2"))
+
+	// Unless the minute just changed, the third prompt of the plugin is over the limit.
+	if appErr := th.App.checkAIPluginRateLimit("plugin1"); appErr != nil {
+		assert.Equal(t, http.StatusTooManyRequests, appErr.StatusCode)
+	}
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.AISettings.APIRateLimit = 0
+	})
+	for range 5 {
+		require.Nil(t, th.App.checkAIPluginRateLimit("plugin1"))
+	}
+}
@@ -2306,3 +2306,381 @@ func TestUserHasJoinedChannel(t *testing.T) {
 		}
 	})
 }
+
+func TestHookAIPromptWillBeSent(t *testing.T) {
+	mainHelper.Parallel(t)
+
+	tests := []struct {
+		name                 string
+		testCode             string
+		expectedSystemPrompt string
+		expectedUserPrompt   string
+		expectedModel        string
+		expectRejected       bool
+	}{
+		{
+			name:                 "allowed",
+			testCode:             `return nil, ""`,
+			expectedSystemPrompt: "Summarize the thread.",
+			expectedUserPrompt:   "alice: my phone number is 555-0100",
+			expectedModel:        "gpt-4o",
+		},
+		{
+			name:           "rejected",
+			testCode:       `return nil, "contains secrets"`,
+			expectRejected: true,
+		},
+		{
+			name: "redacted",
+			testCode: `prompt.UserPrompt = "alice: my phone number is [redacted]"
+				prompt.Feature = "changed"
+				return prompt, ""`,
+			expectedSystemPrompt: "Summarize the thread.",
+			expectedUserPrompt:   "alice: my phone number is [redacted]",
+			expectedModel:        "gpt-4o",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mainHelper.Parallel(t)
+			th := Setup(t)
+
+			tearDown, _, _ := SetAppEnvironmentWithPlugins(t, []string{`
+			package main
+
+			import (
+				"github.com/mattermost/mattermost/server/public/plugin"
+				"github.com/mattermost/mattermost/server/public/model"
+			)
+
+			type MyPlugin struct {
+				plugin.MattermostPlugin
+			}
+
+			func (p *MyPlugin) AIPromptWillBeSent(c *plugin.Context, prompt *model.AIPrompt) (*model.AIPrompt, string) {
+				` + tt.testCode + `
+			}
+
+			func main() {
+				plugin.ClientMain(&MyPlugin{})
+			}
+			`}, th.App, th.NewPluginAPI)
+			defer tearDown()
+
+			prompt := &model.AIPrompt{
+				Feature:      model.AIFeatureSummarization,
+				Model:        "gpt-4o",
+				SystemPrompt: "Summarize the thread.",
+				UserPrompt:   "alice: my phone number is 555-0100",
+			}
+			appErr := th.App.runAIPromptWillBeSentHook(th.Context, prompt)
+			if tt.expectRejected {
+				require.NotNil(t, appErr)
+				assert.Equal(t, "app.ai.prompt_rejected_by_plugin.app_error", appErr.Id)
+				return
+			}
+
+			require.Nil(t, appErr)
+			assert.Equal(t, model.AIFeatureSummarization, prompt.Feature)
+			assert.Equal(t, tt.expectedSystemPrompt, prompt.SystemPrompt)
+			assert.Equal(t, tt.expectedUserPrompt, prompt.UserPrompt)
+			assert.Equal(t, tt.expectedModel, prompt.Model)
+		})
+	}
+}
+
+func TestHookActionItemHasBeenCreatedAndUpdated(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t).InitBasic(t)
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.AISettings.Enable = true
+		*cfg.AISettings.EnableActionItems = true
+	})
+
+	var mockAPI plugintest.API
+	mockAPI.On("LoadPluginConfiguration", mock.Anything).Return(nil)
+	mockAPI.On("LogDebug", "created status=open").Return(nil)
+	mockAPI.On("LogDebug", "updated status=completed old_status=open").Return(nil)
+
+	tearDown, _, _ := SetAppEnvironmentWithPlugins(t, []string{`
+		package main
+
+		import (
+			"github.com/mattermost/mattermost/server/public/plugin"
+			"github.com/mattermost/mattermost/server/public/model"
+		)
+
+		type MyPlugin struct {
+			plugin.MattermostPlugin
+		}
+
+		func (p *MyPlugin) ActionItemHasBeenCreated(c *plugin.Context, item *model.AIActionItem) {
+			p.API.LogDebug("created status=" + item.Status)
+		}
+
+		func (p *MyPlugin) ActionItemHasBeenUpdated(c *plugin.Context, newItem, oldItem *model.AIActionItem) {
+			p.API.LogDebug("updated status=" + newItem.Status + " old_status=" + oldItem.Status)
+		}
+
+		func main() {
+			plugin.ClientMain(&MyPlugin{})
+		}
+	`}, th.App, func(*model.Manifest) plugin.API { return &mockAPI })
+	defer tearDown()
+
+	item, err := th.App.CreateActionItem(th.Context, &model.AIActionItem{
+		ChannelId:   th.BasicChannel.Id,
+		CreatedBy:   th.BasicUser.Id,
+		AssigneeId:  th.BasicUser.Id,
+		Description: "Ship the release notes",
+	})
+	require.NoError(t, err)
+
+	assert.EventuallyWithT(t, func(c *assert.CollectT) {
+		mockAPI.AssertCalled(&testutils.CollectTWithLogf{CollectT: c}, "LogDebug", "created status=open")
+	}, 5*time.Second, 100*time.Millisecond)
+
+	_, err = th.App.CompleteActionItem(th.Context, item.Id, th.BasicUser.Id)
+	require.NoError(t, err)
+
+	assert.EventuallyWithT(t, func(c *assert.CollectT) {
+		mockAPI.AssertCalled(&testutils.CollectTWithLogf{CollectT: c}, "LogDebug", "updated status=completed old_status=open")
+	}, 5*time.Second, 100*time.Millisecond)
+}
+
+func TestHookChannelWillBeCreated(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t).InitBasic(t)
+
+	tearDown, _, _ := SetAppEnvironmentWithPlugins(t, []string{`
+		package main
+
+		import (
+			"strings"
+
+			"github.com/mattermost/mattermost/server/public/plugin"
+			"github.com/mattermost/mattermost/server/public/model"
+		)
+
+		type MyPlugin struct {
+			plugin.MattermostPlugin
+		}
+
+		func (p *MyPlugin) ChannelWillBeCreated(c *plugin.Context, channel *model.Channel) (*model.Channel, string) {
+			if strings.HasPrefix(channel.Name, "forbidden") {
+				return nil, "channel names can't start with forbidden"
+			}
+			channel.Name = "team-" + channel.Name
+			channel.Type = model.ChannelTypePrivate
+			return channel, ""
+		}
+
+		func main() {
+			plugin.ClientMain(&MyPlugin{})
+		}
+	`}, th.App, th.NewPluginAPI)
+	defer tearDown()
+
+	t.Run("rejected", func(t *testing.T) {
+		_, appErr := th.App.CreateChannel(th.Context, &model.Channel{
+			TeamId:      th.BasicTeam.Id,
+			Name:        "forbidden-" + model.NewId()[:8],
+			DisplayName: "Forbidden",
+			Type:        model.ChannelTypeOpen,
+			CreatorId:   th.BasicUser.Id,
+		}, true)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.channel.create_channel.rejected_by_plugin.app_error", appErr.Id)
+	})
+
+	t.Run("modified", func(t *testing.T) {
+		name := "allowed-" + model.NewId()[:8]
+		channel, appErr := th.App.CreateChannel(th.Context, &model.Channel{
+			TeamId:      th.BasicTeam.Id,
+			Name:        name,
+			DisplayName: "Allowed",
+			Type:        model.ChannelTypeOpen,
+			CreatorId:   th.BasicUser.Id,
+		}, true)
+		require.Nil(t, appErr)
+		assert.Equal(t, "team-"+name, channel.Name)
+		// The type of the channel can't be changed.
+		assert.Equal(t, model.ChannelTypeOpen, channel.Type)
+	})
+
+	t.Run("default channels of a new team", func(t *testing.T) {
+		defaultChannelName := "forbidden-" + model.NewId()[:8]
+		th.App.UpdateConfig(func(cfg *model.Config) {
+			cfg.TeamSettings.ExperimentalDefaultChannels = []string{defaultChannelName}
+		})
+
+		team, appErr := th.App.CreateTeam(th.Context, &model.Team{
+			Name:        "team-" + model.NewId()[:8],
+			DisplayName: "Team",
+			Type:        model.TeamOpen,
+		})
+		require.Nil(t, appErr)
+
+		for _, name := range []string{model.DefaultChannelName, defaultChannelName} {
+			_, appErr := th.App.GetChannelByName(th.Context, name, team.Id, false)
+			require.Nil(t, appErr, name)
+		}
+	})
+}
+
+func TestHookChannelWillBeArchived(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t).InitBasic(t)
+
+	tearDown, _, _ := SetAppEnvironmentWithPlugins(t, []string{`
+		package main
+
+		import (
+			"github.com/mattermost/mattermost/server/public/plugin"
+			"github.com/mattermost/mattermost/server/public/model"
+		)
+
+		type MyPlugin struct {
+			plugin.MattermostPlugin
+		}
+
+		func (p *MyPlugin) ChannelWillBeArchived(c *plugin.Context, channel *model.Channel, actor *model.User) string {
+			if actor != nil && channel.Purpose == "keep" {
+				return "channel is kept by " + actor.Username
+			}
+			return ""
+		}
+
+		func main() {
+			plugin.ClientMain(&MyPlugin{})
+		}
+	`}, th.App, th.NewPluginAPI)
+	defer tearDown()
+
+	kept := th.CreateChannel(t, th.BasicTeam, func(c *model.Channel) { c.Purpose = "keep" })
+	appErr := th.App.DeleteChannel(th.Context, kept, th.BasicUser.Id)
+	require.NotNil(t, appErr)
+	assert.Equal(t, "app.channel.delete_channel.rejected_by_plugin.app_error", appErr.Id)
+
+	kept, appErr = th.App.GetChannel(th.Context, kept.Id)
+	require.Nil(t, appErr)
+	assert.Zero(t, kept.DeleteAt)
+
+	archived := th.CreateChannel(t, th.BasicTeam)
+	require.Nil(t, th.App.DeleteChannel(th.Context, archived, th.BasicUser.Id))
+}
+
+func TestHookUserWillJoinChannel(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t).InitBasic(t)
+
+	tearDown, _, _ := SetAppEnvironmentWithPlugins(t, []string{`
+		package main
+
+		import (
+			"github.com/mattermost/mattermost/server/public/plugin"
+			"github.com/mattermost/mattermost/server/public/model"
+		)
+
+		type MyPlugin struct {
+			plugin.MattermostPlugin
+		}
+
+		func (p *MyPlugin) UserWillJoinChannel(c *plugin.Context, channelMember *model.ChannelMember, channel *model.Channel) (*model.ChannelMember, string) {
+			if channel.Purpose == "closed" {
+				return nil, "channel is closed"
+			}
+			channelMember.NotifyProps[model.MarkUnreadNotifyProp] = model.ChannelMarkUnreadMention
+			channelMember.ChannelId = model.NewId()
+			return channelMember, ""
+		}
+
+		func main() {
+			plugin.ClientMain(&MyPlugin{})
+		}
+	`}, th.App, th.NewPluginAPI)
+	defer tearDown()
+
+	t.Run("rejected", func(t *testing.T) {
+		channel := th.CreateChannel(t, th.BasicTeam, func(c *model.Channel) { c.Purpose = "closed" })
+
+		_, appErr := th.App.AddUserToChannel(th.Context, th.BasicUser2, channel, false)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.channel.add_user.rejected_by_plugin.app_error", appErr.Id)
+
+		appErr = th.App.JoinChannel(th.Context, channel, th.BasicUser2.Id)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.channel.add_user.rejected_by_plugin.app_error", appErr.Id)
+	})
+
+	t.Run("modified", func(t *testing.T) {
+		channel := th.CreateChannel(t, th.BasicTeam)
+
+		member, appErr := th.App.AddUserToChannel(th.Context, th.BasicUser2, channel, false)
+		require.Nil(t, appErr)
+		assert.Equal(t, channel.Id, member.ChannelId)
+		assert.Equal(t, model.ChannelMarkUnreadMention, member.NotifyProps[model.MarkUnreadNotifyProp])
+	})
+}
+
+func TestHookUserWillJoinTeam(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t).InitBasic(t)
+
+	tearDown, _, _ := SetAppEnvironmentWithPlugins(t, []string{`
+		package main
+
+		import (
+			"github.com/mattermost/mattermost/server/public/plugin"
+			"github.com/mattermost/mattermost/server/public/model"
+		)
+
+		type MyPlugin struct {
+			plugin.MattermostPlugin
+		}
+
+		func (p *MyPlugin) UserWillJoinTeam(c *plugin.Context, teamMember *model.TeamMember, team *model.Team) (*model.TeamMember, string) {
+			if team.Description == "closed" {
+				return nil, "team is closed"
+			}
+			teamMember.SchemeAdmin = true
+			return teamMember, ""
+		}
+
+		func main() {
+			plugin.ClientMain(&MyPlugin{})
+		}
+	`}, th.App, th.NewPluginAPI)
+	defer tearDown()
+
+	t.Run("rejected", func(t *testing.T) {
+		team := th.CreateTeam(t)
+		team.Description = "closed"
+		team, appErr := th.App.UpdateTeam(team)
+		require.Nil(t, appErr)
+
+		user := th.CreateUser(t)
+		_, appErr = th.App.JoinUserToTeam(th.Context, team, user, "")
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.team.join_user_to_team.rejected_by_plugin.app_error", appErr.Id)
+
+		_, appErr = th.App.GetTeamMember(th.Context, team.Id, user.Id)
+		require.NotNil(t, appErr)
+	})
+
+	t.Run("modified", func(t *testing.T) {
+		team := th.CreateTeam(t)
+
+		user := th.CreateUser(t)
+		member, appErr := th.App.JoinUserToTeam(th.Context, team, user, "")
+		require.Nil(t, appErr)
+		assert.True(t, member.SchemeAdmin)
+
+		guest := th.CreateGuest(t)
+		member, appErr = th.App.JoinUserToTeam(th.Context, team, guest, "")
+		require.Nil(t, appErr)
+		assert.False(t, member.SchemeAdmin)
+	})
+}
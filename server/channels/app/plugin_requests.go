@@ -18,6 +18,7 @@ import (
 	"github.com/mattermost/mattermost/server/public/shared/mlog"
 	"github.com/mattermost/mattermost/server/public/shared/request"
 	"github.com/mattermost/mattermost/server/v8/channels/utils"
+	"github.com/mattermost/mattermost/server/v8/platform/services/tracing"
 )
 
 func (ch *Channels) ServePluginRequest(w http.ResponseWriter, r *http.Request) {
@@ -84,8 +85,9 @@ func (a *App) ServeInternalPluginRequest(userID string, w http.ResponseWriter, r
 	}
 
 	context := &plugin.Context{
-		RequestId: model.NewId(),
-		UserAgent: r.UserAgent(),
+		RequestId:    model.NewId(),
+		UserAgent:    r.UserAgent(),
+		TraceContext: tracing.Inject(r.Context()),
 	}
 
 	// Set authentication headers - these are trusted because this function is internal
@@ -164,6 +166,7 @@ func (ch *Channels) servePluginRequest(w http.ResponseWriter, r *http.Request, h
 		IPAddress:      utils.GetIPAddress(r, ch.cfgSvc.Config().ServiceSettings.TrustedProxyIPHeader),
 		AcceptLanguage: r.Header.Get("Accept-Language"),
 		UserAgent:      r.UserAgent(),
+		TraceContext:   tracing.Inject(tracing.ExtractHTTP(r)),
 	}
 
 	pluginID := mux.Vars(r)["plugin_id"]
@@ -0,0 +1,64 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/mattermost/mattermost/server/public/plugin"
+	"github.com/mattermost/mattermost/server/v8/einterfaces"
+	"github.com/mattermost/mattermost/server/v8/platform/services/tracing"
+)
+
+// pluginTracingMetrics observes the durations of the plugin hooks and API calls, forwarding
+// them to the metrics if any and recording a span for each of them. Hooks are traced as
+// children of the request or event they were given the trace context of through their
+// plugin.Context. API calls don't carry a context over RPC, so their spans start a trace
+// of their own.
+type pluginTracingMetrics struct {
+	metrics einterfaces.MetricsInterface
+}
+
+func newPluginTracingMetrics(metrics einterfaces.MetricsInterface) *pluginTracingMetrics {
+	return &pluginTracingMetrics{metrics: metrics}
+}
+
+func (m *pluginTracingMetrics) ObservePluginHookDuration(pluginID, hookName string, success bool, elapsed float64) {
+	if m.metrics != nil {
+		m.metrics.ObservePluginHookDuration(pluginID, hookName, success, elapsed)
+	}
+}
+
+func (m *pluginTracingMetrics) TracePluginHook(c *plugin.Context, pluginID, hookName string, success bool, startTime time.Time) {
+	ctx := context.Background()
+	if c != nil {
+		ctx = tracing.Extract(c.TraceContext)
+	}
+	tracing.Record(ctx, "Plugin.Hook."+hookName, startTime, time.Now(), success, attribute.String("plugin_id", pluginID))
+}
+
+func (m *pluginTracingMetrics) ObservePluginMultiHookIterationDuration(pluginID string, elapsed float64) {
+	if m.metrics != nil {
+		m.metrics.ObservePluginMultiHookIterationDuration(pluginID, elapsed)
+	}
+}
+
+func (m *pluginTracingMetrics) ObservePluginMultiHookDuration(elapsed float64) {
+	if m.metrics != nil {
+		m.metrics.ObservePluginMultiHookDuration(elapsed)
+	}
+}
+
+func (m *pluginTracingMetrics) ObservePluginAPIDuration(pluginID, apiName string, success bool, elapsed float64) {
+	if m.metrics != nil {
+		m.metrics.ObservePluginAPIDuration(pluginID, apiName, success, elapsed)
+	}
+
+	end := time.Now()
+	start := end.Add(-time.Duration(elapsed * float64(time.Second)))
+	tracing.Record(context.Background(), "Plugin.API."+apiName, start, end, success, attribute.String("plugin_id", pluginID))
+}
@@ -855,6 +855,7 @@ func (a *App) UpdatePost(rctx request.CTX, receivedUpdatedPost *model.Post, upda
 	if appErr != nil {
 		return nil, appErr
 	}
+	a.publishSubscribedEvent(model.EventSubscriptionEventPostEdited, "", rpost.ChannelId, message)
 
 	a.invalidateCacheForChannelPosts(rpost.ChannelId)
 
@@ -2792,6 +2793,7 @@ func (a *App) CleanUpAfterPostDeletion(rctx request.CTX, post *model.Post, delet
 	adminMessage.Add("delete_by", deleteByID)
 	adminMessage.GetBroadcast().ContainsSensitiveData = true
 	a.Publish(adminMessage)
+	a.publishSubscribedEvent(model.EventSubscriptionEventPostDeleted, "", post.ChannelId, adminMessage)
 
 	a.Srv().Go(func() {
 		a.deleteFlaggedPosts(rctx, post.Id)
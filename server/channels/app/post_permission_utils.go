@@ -11,7 +11,7 @@ import (
 	"github.com/mattermost/mattermost/server/public/shared/request"
 )
 
-func PostPriorityCheckWithApp(where string, a *App, userId string, priority *model.PostPriority, rootId string) *model.AppError {
+func PostPriorityCheckWithApp(where string, a AppIface, userId string, priority *model.PostPriority, rootId string) *model.AppError {
 	user, appErr := a.GetUser(userId)
 	if appErr != nil {
 		return appErr
@@ -82,7 +82,7 @@ func postPriorityCheck(
 	return nil
 }
 
-func PostHardenedModeCheckWithApp(a *App, isIntegration bool, props model.StringInterface) *model.AppError {
+func PostHardenedModeCheckWithApp(a AppIface, isIntegration bool, props model.StringInterface) *model.AppError {
 	hardenedModeEnabled := *a.Config().ServiceSettings.ExperimentalEnableHardenedMode
 	return postHardenedModeCheck(hardenedModeEnabled, isIntegration, props)
 }
@@ -6,10 +6,13 @@ package app
 import (
 	"math"
 	"net/http"
+	"slices"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/pkg/errors"
+	"github.com/redis/rueidis"
 	"github.com/throttled/throttled"
 	"github.com/throttled/throttled/store/memstore"
 
@@ -17,20 +20,87 @@ import (
 	"github.com/mattermost/mattermost/server/public/shared/i18n"
 	"github.com/mattermost/mattermost/server/public/shared/mlog"
 	"github.com/mattermost/mattermost/server/v8/channels/utils"
+	"github.com/mattermost/mattermost/server/v8/einterfaces"
 )
 
 type RateLimiter struct {
 	throttledRateLimiter *throttled.GCRARateLimiter
+	store                throttled.GCRAStore
+	defaultRule          *rateLimitRule
+	rules                []*rateLimitRule
+	subpath              string
+	metrics              einterfaces.MetricsInterface
 	useAuth              bool
 	useIP                bool
 	header               string
 	trustedProxyIPHeader []string
 }
 
-func NewRateLimiter(settings *model.RateLimitSettings, trustedProxyIPHeader []string) (*RateLimiter, error) {
-	store, err := memstore.New(*settings.MemoryStoreSize)
-	if err != nil {
-		return nil, errors.Wrap(err, i18n.T("api.server.start_server.rate_limiting_memory_store"))
+// rateLimitRule is a model.RateLimitRule ready to be matched against requests.
+type rateLimitRule struct {
+	name      string
+	routes    []string
+	methods   []string
+	authTypes []string
+	roles     []string
+	// period is the time between two requests at the rate, and tolerance how far ahead of
+	// the rate the requests may be, i.e. the time the limit takes to reset.
+	period    time.Duration
+	tolerance time.Duration
+	limit     int
+	limiter   *throttled.GCRARateLimiter
+}
+
+type rateLimiterOptions struct {
+	redisClient rueidis.Client
+	redisPrefix string
+	metrics     einterfaces.MetricsInterface
+	subpath     string
+}
+
+type RateLimiterOption func(*rateLimiterOptions)
+
+// RateLimitSubpath sets the subpath the server is served under.
+func RateLimitSubpath(subpath string) RateLimiterOption {
+	return func(opts *rateLimiterOptions) {
+		opts.subpath = subpath
+	}
+}
+
+// RateLimitRedisClient sets the client of the redis store, which is required when the
+// settings use it.
+func RateLimitRedisClient(client rueidis.Client, prefix string) RateLimiterOption {
+	return func(opts *rateLimiterOptions) {
+		opts.redisClient = client
+		opts.redisPrefix = prefix
+	}
+}
+
+// RateLimitMetrics sets the metrics counting the allowed and limited requests.
+func RateLimitMetrics(metrics einterfaces.MetricsInterface) RateLimiterOption {
+	return func(opts *rateLimiterOptions) {
+		opts.metrics = metrics
+	}
+}
+
+func NewRateLimiter(settings *model.RateLimitSettings, trustedProxyIPHeader []string, options ...RateLimiterOption) (*RateLimiter, error) {
+	var opts rateLimiterOptions
+	for _, option := range options {
+		option(&opts)
+	}
+
+	var store throttled.GCRAStore
+	if settings.StoreType != nil && *settings.StoreType == model.RateLimitStoreTypeRedis {
+		if opts.redisClient == nil {
+			return nil, errors.New(i18n.T("api.server.start_server.rate_limiting_redis_store"))
+		}
+		store = newRedisRateLimitStore(opts.redisClient, opts.redisPrefix)
+	} else {
+		memStore, err := memstore.New(*settings.MemoryStoreSize)
+		if err != nil {
+			return nil, errors.Wrap(err, i18n.T("api.server.start_server.rate_limiting_memory_store"))
+		}
+		store = memStore
 	}
 
 	quota := throttled.RateQuota{
@@ -43,8 +113,29 @@ func NewRateLimiter(settings *model.RateLimitSettings, trustedProxyIPHeader []st
 		return nil, errors.Wrap(err, i18n.T("api.server.start_server.rate_limiting_rate_limiter"))
 	}
 
+	rules := make([]*rateLimitRule, 0, len(settings.Rules))
+	for _, settingsRule := range settings.Rules {
+		rule, err := newRateLimitRule(settingsRule, store)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+
+	period := time.Second / time.Duration(*settings.PerSec)
 	return &RateLimiter{
 		throttledRateLimiter: throttledRateLimiter,
+		store:                store,
+		defaultRule: &rateLimitRule{
+			name:      model.RateLimitDefaultRuleName,
+			period:    period,
+			tolerance: time.Duration(*settings.MaxBurst+1) * period,
+			limit:     *settings.MaxBurst + 1,
+			limiter:   throttledRateLimiter,
+		},
+		rules:                rules,
+		subpath:              strings.TrimSuffix(opts.subpath, "/"),
+		metrics:              opts.metrics,
 		useAuth:              *settings.VaryByUser,
 		useIP:                *settings.VaryByRemoteAddr,
 		header:               settings.VaryByHeader,
@@ -52,6 +143,36 @@ func NewRateLimiter(settings *model.RateLimitSettings, trustedProxyIPHeader []st
 	}, nil
 }
 
+func newRateLimitRule(settingsRule *model.RateLimitRule, store throttled.GCRAStore) (*rateLimitRule, error) {
+	quota := throttled.RateQuota{
+		MaxRate:  throttled.PerMin(*settingsRule.PerMinute),
+		MaxBurst: *settingsRule.MaxBurst,
+	}
+
+	limiter, err := throttled.NewGCRARateLimiter(store, quota)
+	if err != nil {
+		return nil, errors.Wrapf(err, "%s: %s", i18n.T("api.server.start_server.rate_limiting_rate_limiter"), *settingsRule.Name)
+	}
+
+	methods := make([]string, len(settingsRule.Methods))
+	for i, method := range settingsRule.Methods {
+		methods[i] = strings.ToUpper(method)
+	}
+
+	period := time.Minute / time.Duration(*settingsRule.PerMinute)
+	return &rateLimitRule{
+		name:      *settingsRule.Name,
+		routes:    settingsRule.Routes,
+		methods:   methods,
+		authTypes: settingsRule.AuthTypes,
+		roles:     settingsRule.Roles,
+		period:    period,
+		tolerance: time.Duration(*settingsRule.MaxBurst+1) * period,
+		limit:     *settingsRule.MaxBurst + 1,
+		limiter:   limiter,
+	}, nil
+}
+
 func (rl *RateLimiter) GenerateKey(r *http.Request) string {
 	key := ""
 
@@ -76,56 +197,260 @@ func (rl *RateLimiter) GenerateKey(r *http.Request) string {
 }
 
 func (rl *RateLimiter) RateLimitWriter(key string, w http.ResponseWriter) bool {
-	limited, context, err := rl.throttledRateLimiter.RateLimit(key, 1)
+	return rl.rateLimit(model.RateLimitDefaultRuleName, rl.throttledRateLimiter, key, w)
+}
+
+func (rl *RateLimiter) UserIdRateLimit(userID string, w http.ResponseWriter) bool {
+	if rl.useAuth {
+		return rl.RateLimitWriter(userID, w)
+	}
+	return false
+}
+
+// RequestRateLimit limits the request with the first rule matching it, or with the default
+// limit when there is none. The path is the one of the request without the subpath.
+func (rl *RateLimiter) RequestRateLimit(r *http.Request, path string, session *model.Session, ipAddress string, w http.ResponseWriter) bool {
+	authType := rateLimitAuthType(session)
+	for _, rule := range rl.rules {
+		if rule.matches(path, r.Method, authType, session) {
+			return rl.rateLimit(rule.name, rule.limiter, rateLimitRuleKey(authType, session, ipAddress), w)
+		}
+	}
+
+	// RateLimitHandler leaves the requests the rules apply to, so the default limit is the
+	// lowest priority rule.
+	if rl.limitsByRules(path) && rl.RateLimitWriter(rl.GenerateKey(r), w) {
+		return true
+	}
+
+	if session != nil && session.UserId != "" {
+		return rl.UserIdRateLimit(session.UserId, w)
+	}
+	return false
+}
+
+// limitsByRules reports whether the rules apply to the requests of path, the path of the
+// request without the subpath. They apply to the API requests only, which are rate limited
+// once authenticated.
+func (rl *RateLimiter) limitsByRules(path string) bool {
+	return len(rl.rules) > 0 && strings.HasPrefix(path, model.APIURLSuffix+"/")
+}
+
+// RateLimitHandler limits the requests with the default limit before they are handled, except
+// the ones the rules apply to, so that the rules may grant them a higher limit.
+func (rl *RateLimiter) RateLimitHandler(wrappedHandler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if rl.limitsByRules(strings.TrimPrefix(r.URL.Path, rl.subpath)) {
+			wrappedHandler.ServeHTTP(w, r)
+			return
+		}
+
+		key := rl.GenerateKey(r)
+
+		if !rl.RateLimitWriter(key, w) {
+			wrappedHandler.ServeHTTP(w, r)
+		}
+	})
+}
+
+// Counters returns the state of the limits of key without counting a request, for every rule
+// or only the named one. The default rule counts by the key GenerateKey returns or by user ID,
+// the other rules by user:<user ID>, token:<session ID> or ip:<address>.
+func (rl *RateLimiter) Counters(key, ruleName string) ([]*model.RateLimitCounter, error) {
+	rules := append([]*rateLimitRule{rl.defaultRule}, rl.rules...)
+
+	counters := []*model.RateLimitCounter{}
+	for _, rule := range rules {
+		if ruleName != "" && rule.name != ruleName {
+			continue
+		}
+
+		counter, err := rl.peek(rule, key)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get the rate limit of rule %s", rule.name)
+		}
+		counters = append(counters, counter)
+	}
+
+	return counters, nil
+}
+
+// peek returns the state of the limit of key for rule, reading the store only. It computes
+// the state the way the GCRA rate limiter does, for a request of quantity 0.
+func (rl *RateLimiter) peek(rule *rateLimitRule, key string) (*model.RateLimitCounter, error) {
+	tatNanos, now, err := rl.store.GetWithTime(rateLimitStoreKey(rule.name, key))
+	if err != nil {
+		return nil, err
+	}
+
+	// The theoretical arrival time of the next request, which is now once the limit is reset.
+	var resetAfter time.Duration
+	if tatNanos != -1 {
+		resetAfter = max(time.Unix(0, tatNanos).Sub(now), 0)
+	}
+
+	remaining := 0
+	if next := rule.tolerance - resetAfter; next > -rule.period {
+		remaining = int(next / rule.period)
+	}
+
+	// The next request is allowed once it's no further ahead of the rate than the tolerance.
+	retryAfter := int64(-1)
+	if wait := resetAfter + rule.period - rule.tolerance; wait > 0 {
+		retryAfter = wait.Milliseconds()
+	}
+
+	return &model.RateLimitCounter{
+		Rule:       rule.name,
+		Key:        key,
+		Limit:      rule.limit,
+		Remaining:  remaining,
+		ResetAfter: resetAfter.Milliseconds(),
+		RetryAfter: retryAfter,
+	}, nil
+}
+
+// GetRateLimitCounters returns the state of the rate limits of key, for every rule or only the
+// named one.
+func (a *App) GetRateLimitCounters(key, ruleName string) ([]*model.RateLimitCounter, *model.AppError) {
+	rateLimiter := a.Srv().RateLimiter
+	if rateLimiter == nil {
+		return nil, model.NewAppError("GetRateLimitCounters", "api.system.get_rate_limit_counters.disabled.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	counters, err := rateLimiter.Counters(key, ruleName)
+	if err != nil {
+		return nil, model.NewAppError("GetRateLimitCounters", "api.system.get_rate_limit_counters.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return counters, nil
+}
+
+func (rl *RateLimiter) rateLimit(ruleName string, limiter *throttled.GCRARateLimiter, key string, w http.ResponseWriter) bool {
+	limited, context, err := limiter.RateLimit(rateLimitStoreKey(ruleName, key), 1)
 	if err != nil {
-		mlog.Error("Internal server error when rate limiting. Rate Limiting broken.", mlog.Err(err))
+		mlog.Error("Internal server error when rate limiting. Rate Limiting broken.", mlog.String("rule", ruleName), mlog.Err(err))
 		return false
 	}
 
 	setRateLimitHeaders(w, context)
 
+	if rl.metrics != nil {
+		rl.metrics.IncrementHTTPRateLimitCounter(ruleName, limited)
+	}
+
 	if limited {
-		mlog.Debug("Denied due to throttling settings code=429", mlog.String("key", key))
+		mlog.Debug("Denied due to throttling settings code=429", mlog.String("rule", ruleName), mlog.String("key", key))
 		http.Error(w, "limit exceeded", http.StatusTooManyRequests)
 	}
 
 	return limited
 }
 
-func (rl *RateLimiter) UserIdRateLimit(userID string, w http.ResponseWriter) bool {
-	if rl.useAuth {
-		return rl.RateLimitWriter(userID, w)
+// rateLimitStoreKey returns the key of the store counting the requests of key for a rule, as
+// all the rules share the store.
+func rateLimitStoreKey(ruleName, key string) string {
+	if ruleName == model.RateLimitDefaultRuleName {
+		return key
 	}
-	return false
+	return ruleName + ":" + key
 }
 
-func (rl *RateLimiter) RateLimitHandler(wrappedHandler http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		key := rl.GenerateKey(r)
+// rateLimitAuthType returns how the request of the session was authenticated.
+func rateLimitAuthType(session *model.Session) string {
+	switch {
+	case session == nil || session.UserId == "":
+		return model.RateLimitAuthTypeNone
+	case session.IsOAuth:
+		return model.RateLimitAuthTypeOAuthApp
+	case session.Props[model.SessionPropIsBot] == model.SessionPropIsBotValue:
+		return model.RateLimitAuthTypeBot
+	case session.Props[model.SessionPropType] == model.SessionTypeUserAccessToken:
+		return model.RateLimitAuthTypeAccessToken
+	default:
+		return model.RateLimitAuthTypeSession
+	}
+}
 
-		if !rl.RateLimitWriter(key, w) {
-			wrappedHandler.ServeHTTP(w, r)
+// rateLimitRuleKey returns the key a rule counts the request by. Users share their limit
+// between their sessions, while each token has a limit of its own.
+func rateLimitRuleKey(authType string, session *model.Session, ipAddress string) string {
+	switch authType {
+	case model.RateLimitAuthTypeNone:
+		return "ip:" + ipAddress
+	case model.RateLimitAuthTypeSession:
+		return "user:" + session.UserId
+	default:
+		return "token:" + session.Id
+	}
+}
+
+func (rule *rateLimitRule) matches(path, method, authType string, session *model.Session) bool {
+	if len(rule.routes) > 0 && !slices.ContainsFunc(rule.routes, func(route string) bool {
+		return matchRateLimitRoute(route, path)
+	}) {
+		return false
+	}
+
+	if len(rule.methods) > 0 && !slices.Contains(rule.methods, method) {
+		return false
+	}
+
+	if len(rule.authTypes) > 0 && !slices.Contains(rule.authTypes, authType) {
+		return false
+	}
+
+	if len(rule.roles) > 0 {
+		if session == nil {
+			return false
 		}
-	})
+		userRoles := session.GetUserRoles()
+		if !slices.ContainsFunc(rule.roles, func(role string) bool {
+			return slices.Contains(userRoles, role)
+		}) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// matchRateLimitRoute reports whether path matches the route pattern, where * matches a single
+// path segment and a trailing /** any number of them.
+func matchRateLimitRoute(pattern, path string) bool {
+	patternSegments := strings.Split(strings.Trim(pattern, "/"), "/")
+	pathSegments := strings.Split(strings.Trim(path, "/"), "/")
+
+	for i, segment := range patternSegments {
+		if segment == "**" && i == len(patternSegments)-1 {
+			return true
+		}
+		if i >= len(pathSegments) || (segment != "*" && segment != pathSegments[i]) {
+			return false
+		}
+	}
+
+	return len(patternSegments) == len(pathSegments)
 }
 
-// Copied from https://github.com/throttled/throttled http.go
+// Copied from https://github.com/throttled/throttled http.go. The headers are set rather than
+// added, so that a rule limiting the request replaces the headers of the default limit.
 func setRateLimitHeaders(w http.ResponseWriter, context throttled.RateLimitResult) {
 	if v := context.Limit; v >= 0 {
-		w.Header().Add("X-RateLimit-Limit", strconv.Itoa(v))
+		w.Header().Set("X-RateLimit-Limit", strconv.Itoa(v))
 	}
 
 	if v := context.Remaining; v >= 0 {
-		w.Header().Add("X-RateLimit-Remaining", strconv.Itoa(v))
+		w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(v))
 	}
 
 	if v := context.ResetAfter; v >= 0 {
 		vi := int(math.Ceil(v.Seconds()))
-		w.Header().Add("X-RateLimit-Reset", strconv.Itoa(vi))
+		w.Header().Set("X-RateLimit-Reset", strconv.Itoa(vi))
 	}
 
 	if v := context.RetryAfter; v >= 0 {
 		vi := int(math.Ceil(v.Seconds()))
-		w.Header().Add("Retry-After", strconv.Itoa(vi))
+		w.Header().Set("Retry-After", strconv.Itoa(vi))
 	}
 }
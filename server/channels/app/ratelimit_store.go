@@ -0,0 +1,137 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"time"
+
+	"github.com/redis/rueidis"
+)
+
+const (
+	redisRateLimitTimeout = 2 * time.Second
+
+	// redisRateLimitCASScript swaps the value of a key if it still holds the old one, returning
+	// -1 if the key doesn't exist.
+	redisRateLimitCASScript = `
+local v = redis.call('GET', KEYS[1])
+if v == false then
+  return -1
+end
+if v ~= ARGV[1] then
+  return 0
+end
+redis.call('SET', KEYS[1], ARGV[2], 'PX', ARGV[3])
+return 1
+`
+)
+
+// redisRateLimitStore is a throttled.GCRAStore keeping the rate limits in Redis, so that all the
+// nodes count the requests together. The time comes from Redis as well, which gives the nodes a
+// common clock.
+type redisRateLimitStore struct {
+	client    rueidis.Client
+	prefix    string
+	casScript *rueidis.Lua
+}
+
+func newRedisRateLimitStore(client rueidis.Client, prefix string) *redisRateLimitStore {
+	return &redisRateLimitStore{
+		client:    client,
+		prefix:    prefix + "ratelimit:",
+		casScript: rueidis.NewLuaScript(redisRateLimitCASScript),
+	}
+}
+
+// key returns the Redis key of a rate limit key. The keys can hold auth tokens, so only their
+// hash leaves the server.
+func (s *redisRateLimitStore) key(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return s.prefix + hex.EncodeToString(sum[:])
+}
+
+func (s *redisRateLimitStore) GetWithTime(key string) (int64, time.Time, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), redisRateLimitTimeout)
+	defer cancel()
+
+	results := s.client.DoMulti(ctx,
+		s.client.B().Time().Build(),
+		s.client.B().Get().Key(s.key(key)).Build(),
+	)
+
+	redisTime, err := results[0].AsStrSlice()
+	if err != nil {
+		return 0, time.Time{}, err
+	}
+	now, err := parseRedisTime(redisTime)
+	if err != nil {
+		return 0, time.Time{}, err
+	}
+
+	value, err := results[1].AsInt64()
+	if rueidis.IsRedisNil(err) {
+		return -1, now, nil
+	} else if err != nil {
+		return 0, now, err
+	}
+
+	return value, now, nil
+}
+
+func (s *redisRateLimitStore) SetIfNotExistsWithTTL(key string, value int64, ttl time.Duration) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), redisRateLimitTimeout)
+	defer cancel()
+
+	err := s.client.Do(ctx, s.client.B().Set().Key(s.key(key)).Value(strconv.FormatInt(value, 10)).Nx().
+		PxMilliseconds(redisRateLimitTTL(ttl)).Build()).Error()
+	if rueidis.IsRedisNil(err) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (s *redisRateLimitStore) CompareAndSwapWithTTL(key string, old, new int64, ttl time.Duration) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), redisRateLimitTimeout)
+	defer cancel()
+
+	args := []string{strconv.FormatInt(old, 10), strconv.FormatInt(new, 10), strconv.FormatInt(redisRateLimitTTL(ttl), 10)}
+	swapped, err := s.casScript.Exec(ctx, s.client, []string{s.key(key)}, args).AsInt64()
+	if err != nil {
+		return false, err
+	}
+
+	return swapped == 1, nil
+}
+
+// redisRateLimitTTL returns the TTL in milliseconds of a key. The keys are kept for at least a
+// second, as a TTL of 0 would delete them right away.
+func redisRateLimitTTL(ttl time.Duration) int64 {
+	return max(ttl.Milliseconds(), time.Second.Milliseconds())
+}
+
+// parseRedisTime parses the reply of the TIME command, the seconds and microseconds since the
+// epoch.
+func parseRedisTime(reply []string) (time.Time, error) {
+	if len(reply) != 2 {
+		return time.Time{}, strconv.ErrSyntax
+	}
+
+	seconds, err := strconv.ParseInt(reply[0], 10, 64)
+	if err != nil {
+		return time.Time{}, err
+	}
+	microseconds, err := strconv.ParseInt(reply[1], 10, 64)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return time.Unix(seconds, microseconds*int64(time.Microsecond)), nil
+}
@@ -8,6 +8,7 @@ import (
 	"net/http/httptest"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -107,3 +108,237 @@ func TestGenerateKey_TrustedHeader(t *testing.T) {
 	key = rateLimiter.GenerateKey(req)
 	require.Equal(t, "10.10.10.5", key, "Wrong key on test without allowed trusted proxy header")
 }
+
+func TestNewRateLimiterRedisStore(t *testing.T) {
+	mainHelper.Parallel(t)
+	settings := genRateLimitSettings(true, false, "")
+	settings.StoreType = model.NewPointer(model.RateLimitStoreTypeRedis)
+
+	rateLimiter, err := NewRateLimiter(settings, nil)
+	require.Nil(t, rateLimiter)
+	require.Error(t, err)
+}
+
+func TestMatchRateLimitRoute(t *testing.T) {
+	mainHelper.Parallel(t)
+	cases := []struct {
+		pattern  string
+		path     string
+		expected bool
+	}{
+		{"/api/v4/posts", "/api/v4/posts", true},
+		{"/api/v4/posts", "/api/v4/posts/", true},
+		{"/api/v4/posts", "/api/v4/posts/abc", false},
+		{"/api/v4/posts", "/api/v4", false},
+		{"/api/v4/channels/*/posts", "/api/v4/channels/abc/posts", true},
+		{"/api/v4/channels/*/posts", "/api/v4/channels/abc/members", false},
+		{"/api/v4/channels/*", "/api/v4/channels/abc/posts", false},
+		{"/api/v4/files/**", "/api/v4/files/abc/preview", true},
+		{"/api/v4/files/**", "/api/v4/files", true},
+		{"/api/v4/files/**", "/api/v4/users", false},
+		{"/**", "/api/v4/users/me", true},
+	}
+
+	for _, tc := range cases {
+		require.Equal(t, tc.expected, matchRateLimitRoute(tc.pattern, tc.path), "pattern %s, path %s", tc.pattern, tc.path)
+	}
+}
+
+func TestRateLimitAuthType(t *testing.T) {
+	mainHelper.Parallel(t)
+	require.Equal(t, model.RateLimitAuthTypeNone, rateLimitAuthType(nil))
+	require.Equal(t, model.RateLimitAuthTypeNone, rateLimitAuthType(&model.Session{}))
+	require.Equal(t, model.RateLimitAuthTypeSession, rateLimitAuthType(&model.Session{UserId: "user"}))
+	require.Equal(t, model.RateLimitAuthTypeOAuthApp, rateLimitAuthType(&model.Session{UserId: "user", IsOAuth: true}))
+	require.Equal(t, model.RateLimitAuthTypeAccessToken, rateLimitAuthType(&model.Session{
+		UserId: "user",
+		Props:  model.StringMap{model.SessionPropType: model.SessionTypeUserAccessToken},
+	}))
+	require.Equal(t, model.RateLimitAuthTypeBot, rateLimitAuthType(&model.Session{
+		UserId: "bot",
+		Props: model.StringMap{
+			model.SessionPropType:  model.SessionTypeUserAccessToken,
+			model.SessionPropIsBot: model.SessionPropIsBotValue,
+		},
+	}))
+}
+
+func TestRequestRateLimit(t *testing.T) {
+	mainHelper.Parallel(t)
+	settings := genRateLimitSettings(true, false, "")
+	settings.Rules = []*model.RateLimitRule{
+		{
+			Name:      model.NewPointer("bot_posts"),
+			Routes:    []string{"/api/v4/posts"},
+			Methods:   []string{"post"},
+			AuthTypes: []string{model.RateLimitAuthTypeBot},
+			PerMinute: model.NewPointer(1),
+			MaxBurst:  model.NewPointer(0),
+		},
+		{
+			Name:      model.NewPointer("admin"),
+			Roles:     []string{model.SystemAdminRoleId},
+			PerMinute: model.NewPointer(1000),
+			MaxBurst:  model.NewPointer(1000),
+		},
+		{
+			Name:      model.NewPointer("anonymous"),
+			Routes:    []string{"/api/v4/users/login"},
+			AuthTypes: []string{model.RateLimitAuthTypeNone},
+			PerMinute: model.NewPointer(1),
+			MaxBurst:  model.NewPointer(1),
+		},
+	}
+	settings.SetDefaults()
+	rateLimiter, err := NewRateLimiter(settings, nil)
+	require.NoError(t, err)
+
+	bot := &model.Session{
+		Id:     model.NewId(),
+		UserId: model.NewId(),
+		Roles:  model.SystemUserRoleId,
+		Props: model.StringMap{
+			model.SessionPropType:  model.SessionTypeUserAccessToken,
+			model.SessionPropIsBot: model.SessionPropIsBotValue,
+		},
+	}
+
+	t.Run("matching rule", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		require.False(t, rateLimiter.RequestRateLimit(httptest.NewRequest(http.MethodPost, "/api/v4/posts", nil), "/api/v4/posts", bot, "10.0.0.1", w))
+		require.Equal(t, "1", w.Header().Get("X-RateLimit-Limit"))
+
+		w = httptest.NewRecorder()
+		require.True(t, rateLimiter.RequestRateLimit(httptest.NewRequest(http.MethodPost, "/api/v4/posts", nil), "/api/v4/posts", bot, "10.0.0.1", w))
+		require.Equal(t, http.StatusTooManyRequests, w.Code)
+		require.NotEmpty(t, w.Header().Get("Retry-After"))
+
+		counters, err := rateLimiter.Counters("token:"+bot.Id, "bot_posts")
+		require.NoError(t, err)
+		require.Len(t, counters, 1)
+		require.Equal(t, "bot_posts", counters[0].Rule)
+		require.Equal(t, 0, counters[0].Remaining)
+		require.Greater(t, counters[0].RetryAfter, int64(0))
+	})
+
+	t.Run("other methods fall back to the default limit", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		require.False(t, rateLimiter.RequestRateLimit(httptest.NewRequest(http.MethodGet, "/api/v4/posts", nil), "/api/v4/posts", bot, "10.0.0.1", w))
+		require.Equal(t, "100", w.Header().Get("X-RateLimit-Remaining"))
+
+		counters, err := rateLimiter.Counters(bot.UserId, model.RateLimitDefaultRuleName)
+		require.NoError(t, err)
+		require.Len(t, counters, 1)
+		require.Equal(t, 100, counters[0].Remaining)
+		require.Equal(t, int64(-1), counters[0].RetryAfter)
+	})
+
+	t.Run("role", func(t *testing.T) {
+		admin := &model.Session{Id: model.NewId(), UserId: model.NewId(), Roles: model.SystemAdminRoleId + " " + model.SystemUserRoleId}
+		w := httptest.NewRecorder()
+		require.False(t, rateLimiter.RequestRateLimit(httptest.NewRequest(http.MethodGet, "/api/v4/users/me", nil), "/api/v4/users/me", admin, "10.0.0.1", w))
+		require.Equal(t, "1000", w.Header().Get("X-RateLimit-Remaining"))
+	})
+
+	t.Run("anonymous requests are counted by IP", func(t *testing.T) {
+		for range 2 {
+			require.False(t, rateLimiter.RequestRateLimit(httptest.NewRequest(http.MethodPost, "/api/v4/users/login", nil), "/api/v4/users/login", &model.Session{}, "10.0.0.2", httptest.NewRecorder()))
+		}
+		require.True(t, rateLimiter.RequestRateLimit(httptest.NewRequest(http.MethodPost, "/api/v4/users/login", nil), "/api/v4/users/login", &model.Session{}, "10.0.0.2", httptest.NewRecorder()))
+		require.False(t, rateLimiter.RequestRateLimit(httptest.NewRequest(http.MethodPost, "/api/v4/users/login", nil), "/api/v4/users/login", &model.Session{}, "10.0.0.3", httptest.NewRecorder()))
+	})
+
+	t.Run("anonymous requests without a rule get the default limit", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		require.False(t, rateLimiter.RequestRateLimit(httptest.NewRequest(http.MethodGet, "/api/v4/system/ping", nil), "/api/v4/system/ping", &model.Session{}, "10.0.0.2", w))
+		require.Equal(t, "101", w.Header().Get("X-RateLimit-Limit"))
+	})
+
+	t.Run("requests outside of the API aren't limited by the rules", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		require.False(t, rateLimiter.RequestRateLimit(httptest.NewRequest(http.MethodGet, "/static/main.js", nil), "/static/main.js", &model.Session{}, "10.0.0.2", w))
+		require.Empty(t, w.Header().Get("X-RateLimit-Limit"))
+	})
+
+	t.Run("counters don't count requests", func(t *testing.T) {
+		key := "ip:" + model.NewId()
+		for range 2 {
+			counters, err := rateLimiter.Counters(key, "anonymous")
+			require.NoError(t, err)
+			require.Len(t, counters, 1)
+			require.Equal(t, 2, counters[0].Limit)
+			require.Equal(t, 2, counters[0].Remaining)
+			require.Zero(t, counters[0].ResetAfter)
+		}
+
+		tat, _, err := rateLimiter.store.GetWithTime(rateLimitStoreKey("anonymous", key))
+		require.NoError(t, err)
+		require.Equal(t, int64(-1), tat)
+	})
+
+	t.Run("counters of every rule", func(t *testing.T) {
+		counters, err := rateLimiter.Counters("ip:10.0.0.4", "")
+		require.NoError(t, err)
+		require.Len(t, counters, 4)
+		require.Equal(t, model.RateLimitDefaultRuleName, counters[0].Rule)
+		require.Equal(t, "anonymous", counters[3].Rule)
+		require.Equal(t, 2, counters[3].Remaining)
+	})
+}
+
+func TestParseRedisTime(t *testing.T) {
+	mainHelper.Parallel(t)
+	now, err := parseRedisTime([]string{"1700000000", "250000"})
+	require.NoError(t, err)
+	require.Equal(t, time.Unix(1700000000, 250*int64(time.Millisecond)), now)
+
+	_, err = parseRedisTime([]string{"1700000000"})
+	require.Error(t, err)
+
+	_, err = parseRedisTime([]string{"1700000000", "abc"})
+	require.Error(t, err)
+}
+
+func TestRateLimitHandler(t *testing.T) {
+	mainHelper.Parallel(t)
+
+	newRateLimiter := func(t *testing.T, rules []*model.RateLimitRule) *RateLimiter {
+		settings := genRateLimitSettings(false, false, "X-Client")
+		*settings.MaxBurst = 0
+		settings.Rules = rules
+		settings.SetDefaults()
+		rateLimiter, err := NewRateLimiter(settings, nil, RateLimitSubpath("/chat/"))
+		require.NoError(t, err)
+		return rateLimiter
+	}
+
+	serve := func(handler http.Handler, path string) int {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, path, nil)
+		r.Header.Set("X-Client", "client")
+		handler.ServeHTTP(w, r)
+		return w.Code
+	}
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+
+	t.Run("without rules", func(t *testing.T) {
+		rateLimitedHandler := newRateLimiter(t, nil).RateLimitHandler(handler)
+		require.Equal(t, http.StatusOK, serve(rateLimitedHandler, "/chat/api/v4/users/me"))
+		require.Equal(t, http.StatusTooManyRequests, serve(rateLimitedHandler, "/chat/api/v4/users/me"))
+	})
+
+	t.Run("API requests are left to the rules", func(t *testing.T) {
+		rateLimitedHandler := newRateLimiter(t, []*model.RateLimitRule{{
+			Name:      model.NewPointer("high"),
+			PerMinute: model.NewPointer(1000),
+		}}).RateLimitHandler(handler)
+
+		for range 3 {
+			require.Equal(t, http.StatusOK, serve(rateLimitedHandler, "/chat/api/v4/users/me"))
+		}
+
+		require.Equal(t, http.StatusOK, serve(rateLimitedHandler, "/chat/static/main.js"))
+		require.Equal(t, http.StatusTooManyRequests, serve(rateLimitedHandler, "/chat/static/main.js"))
+	})
+}
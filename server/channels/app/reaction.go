@@ -195,4 +195,10 @@ func (a *App) sendReactionEvent(rctx request.CTX, event model.WebsocketEventType
 	}
 	message.Add("reaction", string(reactionJSON))
 	a.Publish(message)
+
+	subscribedEvent := model.EventSubscriptionEventReactionAdded
+	if event == model.WebsocketEventReactionRemoved {
+		subscribedEvent = model.EventSubscriptionEventReactionRemoved
+	}
+	a.publishSubscribedEvent(subscribedEvent, "", post.ChannelId, message)
 }
@@ -15,6 +15,9 @@ import (
 func (a *App) SaveScheduledPost(rctx request.CTX, scheduledPost *model.ScheduledPost, connectionId string) (*model.ScheduledPost, *model.AppError) {
 	maxMessageLength := a.Srv().Store().ScheduledPost().GetMaxMessageSize()
 	scheduledPost.PreSave()
+	if appErr := a.normalizeScheduledPostRecurrence(scheduledPost.UserId, scheduledPost); appErr != nil {
+		return nil, appErr
+	}
 	if validationErr := scheduledPost.IsValid(maxMessageLength); validationErr != nil {
 		return nil, validationErr
 	}
@@ -69,6 +72,9 @@ func (a *App) GetUserTeamScheduledPosts(rctx request.CTX, userId, teamId string)
 func (a *App) UpdateScheduledPost(rctx request.CTX, userId string, scheduledPost *model.ScheduledPost, connectionId string) (*model.ScheduledPost, *model.AppError) {
 	maxMessageLength := a.Srv().Store().ScheduledPost().GetMaxMessageSize()
 	scheduledPost.PreUpdate()
+	if appErr := a.normalizeScheduledPostRecurrence(userId, scheduledPost); appErr != nil {
+		return nil, appErr
+	}
 	if validationErr := scheduledPost.IsValid(maxMessageLength); validationErr != nil {
 		return nil, validationErr
 	}
@@ -123,6 +129,91 @@ func (a *App) DeleteScheduledPost(rctx request.CTX, userId, scheduledPostId, con
 	return scheduledPost, nil
 }
 
+// SkipNextScheduledPostOccurrence moves a recurring scheduled post on to its
+// next occurrence without sending the pending one. Skipping the last
+// occurrence of a series deletes the scheduled post.
+func (a *App) SkipNextScheduledPostOccurrence(rctx request.CTX, userId, scheduledPostId, connectionId string) (*model.ScheduledPost, *model.AppError) {
+	scheduledPost, appErr := a.getRecurringScheduledPostForUser("app.SkipNextScheduledPostOccurrence", userId, scheduledPostId)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	if !scheduledPost.AdvanceRecurrence() {
+		return a.DeleteScheduledPost(rctx, userId, scheduledPostId, connectionId)
+	}
+
+	if err := a.Srv().Store().ScheduledPost().UpdatedScheduledPost(scheduledPost); err != nil {
+		return nil, model.NewAppError("app.SkipNextScheduledPostOccurrence", "app.update_scheduled_post.update.error", map[string]any{"user_id": userId, "scheduled_post_id": scheduledPostId}, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	a.PublishScheduledPostEvent(rctx, model.WebsocketScheduledPostUpdated, scheduledPost, connectionId)
+
+	return scheduledPost, nil
+}
+
+// SetScheduledPostPaused pauses or resumes a recurring scheduled post. The
+// occurrences that come up while a series is paused are skipped.
+func (a *App) SetScheduledPostPaused(rctx request.CTX, userId, scheduledPostId string, paused bool, connectionId string) (*model.ScheduledPost, *model.AppError) {
+	scheduledPost, appErr := a.getRecurringScheduledPostForUser("app.SetScheduledPostPaused", userId, scheduledPostId)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	scheduledPost.Recurrence.Paused = paused
+	if err := a.Srv().Store().ScheduledPost().UpdatedScheduledPost(scheduledPost); err != nil {
+		return nil, model.NewAppError("app.SetScheduledPostPaused", "app.update_scheduled_post.update.error", map[string]any{"user_id": userId, "scheduled_post_id": scheduledPostId}, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	a.PublishScheduledPostEvent(rctx, model.WebsocketScheduledPostUpdated, scheduledPost, connectionId)
+
+	return scheduledPost, nil
+}
+
+func (a *App) getRecurringScheduledPostForUser(where, userId, scheduledPostId string) (*model.ScheduledPost, *model.AppError) {
+	scheduledPost, err := a.Srv().Store().ScheduledPost().Get(scheduledPostId)
+	if err != nil {
+		return nil, model.NewAppError(where, "app.update_scheduled_post.get_scheduled_post.error", map[string]any{"user_id": userId, "scheduled_post_id": scheduledPostId}, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	if scheduledPost == nil {
+		return nil, model.NewAppError(where, "app.update_scheduled_post.existing_scheduled_post.not_exist", map[string]any{"user_id": userId, "scheduled_post_id": scheduledPostId}, "", http.StatusNotFound)
+	}
+
+	if scheduledPost.UserId != userId {
+		return nil, model.NewAppError(where, "app.update_scheduled_post.update_permission.error", map[string]any{"user_id": userId, "scheduled_post_id": scheduledPostId}, "", http.StatusForbidden)
+	}
+
+	if !scheduledPost.IsRecurring() {
+		return nil, model.NewAppError(where, "app.scheduled_post.not_recurring.app_error", map[string]any{"user_id": userId, "scheduled_post_id": scheduledPostId}, "", http.StatusBadRequest)
+	}
+
+	if scheduledPost.ErrorCode != "" {
+		return nil, model.NewAppError(where, "app.scheduled_post.series_failed.app_error", map[string]any{"user_id": userId, "scheduled_post_id": scheduledPostId}, "", http.StatusBadRequest)
+	}
+
+	return scheduledPost, nil
+}
+
+// normalizeScheduledPostRecurrence evaluates a recurring scheduled post in its
+// author's timezone unless one was given explicitly.
+func (a *App) normalizeScheduledPostRecurrence(userId string, scheduledPost *model.ScheduledPost) *model.AppError {
+	if !scheduledPost.IsRecurring() {
+		return nil
+	}
+
+	timeZone := scheduledPost.Recurrence.TimeZone
+	if timeZone == "" {
+		user, appErr := a.GetUser(userId)
+		if appErr != nil {
+			return appErr
+		}
+		timeZone = user.GetPreferredTimezone()
+	}
+
+	scheduledPost.NormalizeRecurrence(timeZone)
+	return nil
+}
+
 func (a *App) PublishScheduledPostEvent(rctx request.CTX, eventType model.WebsocketEventType, scheduledPost *model.ScheduledPost, connectionId string) {
 	if scheduledPost == nil {
 		rctx.Logger().Warn("publishScheduledPostEvent called with nil scheduledPost")
@@ -6,6 +6,7 @@ package app
 import (
 	"fmt"
 	"net/http"
+	"sort"
 	"strings"
 	"time"
 
@@ -19,6 +20,7 @@ import (
 const (
 	getPendingScheduledPostsPageSize = 100
 	scheduledPostBatchWaitTime       = 1 * time.Second
+	failedOccurrenceTimeFormat       = "Mon, Jan 2, 2006 at 3:04 PM MST"
 )
 
 func (a *App) ProcessScheduledPosts(rctx request.CTX) {
@@ -89,6 +91,10 @@ func (a *App) ProcessScheduledPosts(rctx request.CTX) {
 		}
 	}
 
+	// recurring scheduled posts whose occurrences are too old to process are
+	// moved on to their next occurrence, reporting the missed ones as failed.
+	a.rescheduleStaleRecurringPosts(rctx, beforeTime)
+
 	// once all scheduled posts are processed, we need to update and close the old ones
 	// as we don't process pending scheduled posts more than 24 hours old.
 	if err := a.Srv().Store().ScheduledPost().UpdateOldScheduledPosts(beforeTime); err != nil {
@@ -103,16 +109,46 @@ func (a *App) ProcessScheduledPosts(rctx request.CTX) {
 // processScheduledPostBatch processes one batch
 func (a *App) processScheduledPostBatch(rctx request.CTX, scheduledPosts []*model.ScheduledPost) error {
 	var failedScheduledPosts []*model.ScheduledPost
+	var failedOccurrences []*model.ScheduledPost
+	var rescheduledScheduledPosts []*model.ScheduledPost
 	var successfulScheduledPostIDs []string
 
 	for i := range scheduledPosts {
+		if scheduledPosts[i].IsRecurring() && scheduledPosts[i].Recurrence.Paused {
+			// occurrences of a paused series are skipped rather than queued up
+			if scheduledPosts[i].AdvanceRecurrence() {
+				rescheduledScheduledPosts = append(rescheduledScheduledPosts, scheduledPosts[i])
+			} else {
+				successfulScheduledPostIDs = append(successfulScheduledPostIDs, scheduledPosts[i].Id)
+			}
+			continue
+		}
+
 		scheduledPost, err := a.postScheduledPost(rctx, scheduledPosts[i])
 		if err != nil {
 			rctx.Logger().Error("processScheduledPostBatch scheduled post processing failed", mlog.String("scheduled_post_id", scheduledPosts[i].Id), mlog.Err(err))
+
+			if scheduledPost.IsRecurring() && !isPermanentScheduledPostError(scheduledPost.ErrorCode) {
+				// The failure only affects this occurrence, so it's reported
+				// and the series carries on with the next one.
+				occurrence := scheduledPost.Clone()
+				if scheduledPost.AdvanceRecurrence() {
+					failedOccurrences = append(failedOccurrences, occurrence)
+					rescheduledScheduledPosts = append(rescheduledScheduledPosts, scheduledPost)
+					continue
+				}
+				scheduledPost = occurrence
+			}
+
 			failedScheduledPosts = append(failedScheduledPosts, scheduledPost)
 			continue
 		}
 
+		if scheduledPost.ErrorCode == "" && scheduledPost.AdvanceRecurrence() {
+			rescheduledScheduledPosts = append(rescheduledScheduledPosts, scheduledPost)
+			continue
+		}
+
 		successfulScheduledPostIDs = append(successfulScheduledPostIDs, scheduledPost.Id)
 	}
 
@@ -120,10 +156,26 @@ func (a *App) processScheduledPostBatch(rctx request.CTX, scheduledPosts []*mode
 		return errors.Wrap(err, "App.processScheduledPostBatch: failed to handle successfully posted scheduled posts")
 	}
 
-	a.handleFailedScheduledPosts(rctx, failedScheduledPosts)
+	a.handleRescheduledScheduledPosts(rctx, rescheduledScheduledPosts)
+	a.handleFailedScheduledPosts(rctx, failedScheduledPosts, failedOccurrences)
 	return nil
 }
 
+// isPermanentScheduledPostError returns whether a scheduled post that failed
+// with errorCode would fail the same way on every later attempt, in which case
+// a recurring series is stopped instead of being moved on.
+func isPermanentScheduledPostError(errorCode string) bool {
+	switch errorCode {
+	case model.ScheduledPostErrorCodeChannelArchived,
+		model.ScheduledPostErrorCodeChannelNotFound,
+		model.ScheduledPostErrorCodeUserDoesNotExist,
+		model.ScheduledPostErrorCodeUserDeleted,
+		model.ScheduledPostErrorThreadDeleted:
+		return true
+	}
+	return false
+}
+
 // postScheduledPost processes an individual scheduled post
 func (a *App) postScheduledPost(rctx request.CTX, scheduledPost *model.ScheduledPost) (*model.ScheduledPost, error) {
 	// we'll process scheduled posts one by one.
@@ -189,6 +241,29 @@ func (a *App) postScheduledPost(rctx request.CTX, scheduledPost *model.Scheduled
 		return scheduledPost, err
 	}
 
+	// Files can only be attached to one post, so every occurrence of a recurring scheduled post gets
+	// its own copies and the scheduled post keeps the originals for the next occurrences.
+	if scheduledPost.IsRecurring() && len(post.FileIds) > 0 {
+		fileIDs, appErr := a.CopyFileInfos(rctx, scheduledPost.UserId, post.FileIds)
+		if appErr != nil {
+			errorCode := model.ScheduledPostErrorUnknownError
+			if appErr.StatusCode == http.StatusNotFound {
+				errorCode = model.ScheduledPostErrorInvalidPost
+			}
+
+			rctx.Logger().Error(
+				"App.processScheduledPostBatch: failed to copy the files of a recurring scheduled post",
+				mlog.String("scheduled_post_id", scheduledPost.Id),
+				mlog.String("error_code", errorCode),
+				mlog.Err(appErr),
+			)
+
+			scheduledPost.ErrorCode = errorCode
+			return scheduledPost, appErr
+		}
+		post.FileIds = fileIDs
+	}
+
 	createPostFlags := model.CreatePostFlags{
 		TriggerWebhooks: true,
 		SetOnline:       false,
@@ -207,8 +282,10 @@ func (a *App) postScheduledPost(rctx request.CTX, scheduledPost *model.Scheduled
 		return scheduledPost, appErr
 	}
 
-	// send the WS event to delete the just posted scheduledPost from list
-	a.PublishScheduledPostEvent(rctx, model.WebsocketScheduledPostDeleted, scheduledPost, "")
+	if !scheduledPost.IsRecurring() {
+		// send the WS event to delete the just posted scheduledPost from list
+		a.PublishScheduledPostEvent(rctx, model.WebsocketScheduledPostDeleted, scheduledPost, "")
+	}
 
 	return scheduledPost, nil
 }
@@ -335,7 +412,29 @@ func (a *App) handleSuccessfulScheduledPosts(rctx request.CTX, successfulSchedul
 	return nil
 }
 
-func (a *App) handleFailedScheduledPosts(rctx request.CTX, failedScheduledPosts []*model.ScheduledPost) {
+// handleRescheduledScheduledPosts saves recurring scheduled posts that have
+// moved on to their next occurrence.
+func (a *App) handleRescheduledScheduledPosts(rctx request.CTX, rescheduledScheduledPosts []*model.ScheduledPost) {
+	for _, rescheduledScheduledPost := range rescheduledScheduledPosts {
+		if err := a.Srv().Store().ScheduledPost().UpdatedScheduledPost(rescheduledScheduledPost); err != nil {
+			// we intentionally don't stop on error as its possible to continue updating other scheduled posts
+			rctx.Logger().Error(
+				"App.handleRescheduledScheduledPosts: failed to reschedule recurring scheduled post",
+				mlog.String("scheduled_post_id", rescheduledScheduledPost.Id),
+				mlog.Err(err),
+			)
+			continue
+		}
+
+		a.PublishScheduledPostEvent(rctx, model.WebsocketScheduledPostUpdated, rescheduledScheduledPost, "")
+	}
+}
+
+// handleFailedScheduledPosts saves scheduled posts that failed and won't be
+// retried, and notifies their authors about them along with failedOccurrences,
+// the failed occurrences of recurring scheduled posts that have already been
+// rescheduled.
+func (a *App) handleFailedScheduledPosts(rctx request.CTX, failedScheduledPosts, failedOccurrences []*model.ScheduledPost) {
 	for _, failedScheduledPost := range failedScheduledPosts {
 		err := a.Srv().Store().ScheduledPost().UpdatedScheduledPost(failedScheduledPost)
 		if err != nil {
@@ -350,8 +449,67 @@ func (a *App) handleFailedScheduledPosts(rctx request.CTX, failedScheduledPosts
 		a.PublishScheduledPostEvent(rctx, model.WebsocketScheduledPostUpdated, failedScheduledPost, "")
 	}
 
-	if len(failedScheduledPosts) > 0 {
-		a.notifyUserAboutFailedScheduledMessages(rctx, failedScheduledPosts)
+	failedMessages := append(failedScheduledPosts, failedOccurrences...)
+	if len(failedMessages) > 0 {
+		a.notifyUserAboutFailedScheduledMessages(rctx, failedMessages)
+	}
+}
+
+// rescheduleStaleRecurringPosts moves recurring scheduled posts whose pending
+// occurrence is older than beforeTime on to their first occurrence at or after
+// it. Every missed occurrence is reported to the author as failed.
+func (a *App) rescheduleStaleRecurringPosts(rctx request.CTX, beforeTime int64) {
+	seen := make(map[string]bool)
+	for {
+		staleScheduledPosts, err := a.Srv().Store().ScheduledPost().GetStaleRecurringScheduledPosts(beforeTime, getPendingScheduledPostsPageSize)
+		if err != nil {
+			rctx.Logger().Error(
+				"App.rescheduleStaleRecurringPosts: failed to fetch stale recurring scheduled posts",
+				mlog.Int("before_time", beforeTime),
+				mlog.Err(err),
+			)
+			return
+		}
+
+		if len(staleScheduledPosts) == 0 || seen[staleScheduledPosts[0].Id] {
+			// a post we've already handled coming back means saving it failed,
+			// so it will be picked up again when the job next runs.
+			return
+		}
+
+		var failedScheduledPosts []*model.ScheduledPost
+		var failedOccurrences []*model.ScheduledPost
+		var rescheduledScheduledPosts []*model.ScheduledPost
+
+		for _, scheduledPost := range staleScheduledPosts {
+			seen[scheduledPost.Id] = true
+			for scheduledPost.ScheduledAt < beforeTime {
+				occurrence := scheduledPost.Clone()
+				occurrence.ErrorCode = model.ScheduledPostErrorUnableToSend
+
+				if !scheduledPost.AdvanceRecurrence() {
+					scheduledPost = occurrence
+					break
+				}
+
+				if !occurrence.Recurrence.Paused {
+					failedOccurrences = append(failedOccurrences, occurrence)
+				}
+			}
+
+			if scheduledPost.ErrorCode != "" {
+				failedScheduledPosts = append(failedScheduledPosts, scheduledPost)
+			} else {
+				rescheduledScheduledPosts = append(rescheduledScheduledPosts, scheduledPost)
+			}
+		}
+
+		a.handleRescheduledScheduledPosts(rctx, rescheduledScheduledPosts)
+		a.handleFailedScheduledPosts(rctx, failedScheduledPosts, failedOccurrences)
+
+		if len(staleScheduledPosts) < getPendingScheduledPostsPageSize {
+			return
+		}
 	}
 }
 
@@ -402,11 +560,20 @@ func (a *App) notifyUser(rctx request.CTX, userId string, userFailedMessages []*
 
 	channelErrorCounts := make(map[channelErrorKey]int)
 	channelIdsSet := make(map[string]struct{})
+	// failures of recurring scheduled posts are reported per occurrence
+	var failedOccurrences []*model.ScheduledPost
 	for _, msg := range userFailedMessages {
+		channelIdsSet[msg.ChannelId] = struct{}{}
+		if msg.IsRecurring() {
+			failedOccurrences = append(failedOccurrences, msg)
+			continue
+		}
 		key := channelErrorKey{ChannelId: msg.ChannelId, ErrorCode: msg.ErrorCode}
 		channelErrorCounts[key]++
-		channelIdsSet[msg.ChannelId] = struct{}{}
 	}
+	sort.Slice(failedOccurrences, func(i, j int) bool {
+		return failedOccurrences[i].ScheduledAt < failedOccurrences[j].ScheduledAt
+	})
 
 	channelNames := make(map[string]string)
 	for channelId := range channelIdsSet {
@@ -445,6 +612,17 @@ func (a *App) notifyUser(rctx request.CTX, userId string, userFailedMessages []*
 		messageBuilder.WriteString("\n")
 	}
 
+	location := user.GetTimezoneLocation()
+	for _, occurrence := range failedOccurrences {
+		detailedMessage := T("app.scheduled_post.failed_occurrence_detail", map[string]any{
+			"ChannelName":  channelNames[occurrence.ChannelId],
+			"OccurrenceAt": time.UnixMilli(occurrence.ScheduledAt).In(location).Format(failedOccurrenceTimeFormat),
+			"ErrorReason":  getErrorReason(T, occurrence.ErrorCode),
+		})
+		messageBuilder.WriteString(detailedMessage)
+		messageBuilder.WriteString("\n")
+	}
+
 	post := &model.Post{
 		ChannelId: channel.Id,
 		Message:   messageBuilder.String(),
@@ -11,6 +11,7 @@ import (
 	"github.com/mattermost/mattermost/server/public/model"
 	"github.com/mattermost/mattermost/server/public/shared/i18n"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestProcessScheduledPosts(t *testing.T) {
@@ -54,6 +55,143 @@ func TestProcessScheduledPosts(t *testing.T) {
 		assert.Len(t, scheduledPosts, 0)
 	})
 
+	t.Run("recurring scheduled post moves on to its next occurrence", func(t *testing.T) {
+		th := Setup(t).InitBasic(t)
+
+		th.App.Srv().SetLicense(getLicWithSkuShortName(model.LicenseShortSkuProfessional))
+
+		scheduledAt := model.GetMillis() + 1000
+		scheduledPost := &model.ScheduledPost{
+			Draft: model.Draft{
+				CreateAt:  model.GetMillis(),
+				UserId:    th.BasicUser.Id,
+				ChannelId: th.BasicChannel.Id,
+				Message:   "this is a recurring scheduled post",
+			},
+			ScheduledAt: scheduledAt,
+			Recurrence: &model.ScheduledPostRecurrence{
+				Frequency: model.ScheduledPostRecurrenceDaily,
+				TimeZone:  "UTC",
+				Count:     2,
+			},
+		}
+		_, err := th.Server.Store().ScheduledPost().CreateScheduledPost(scheduledPost)
+		assert.NoError(t, err)
+
+		time.Sleep(1 * time.Second)
+
+		th.App.ProcessScheduledPosts(th.Context)
+
+		scheduledPosts, err := th.App.Srv().Store().ScheduledPost().GetScheduledPostsForUser(th.BasicUser.Id, th.BasicChannel.TeamId)
+		assert.NoError(t, err)
+		assert.Len(t, scheduledPosts, 1)
+		assert.Empty(t, scheduledPosts[0].ErrorCode)
+		assert.Equal(t, scheduledAt+24*60*60*1000, scheduledPosts[0].ScheduledAt)
+		assert.Equal(t, 1, scheduledPosts[0].Recurrence.Occurrences)
+	})
+
+	t.Run("recurring scheduled post attaches copies of its files", func(t *testing.T) {
+		th := Setup(t).InitBasic(t)
+
+		th.App.Srv().SetLicense(getLicWithSkuShortName(model.LicenseShortSkuProfessional))
+
+		fileInfo, err := th.App.Srv().Store().FileInfo().Save(th.Context, &model.FileInfo{
+			CreatorId: th.BasicUser.Id,
+			Path:      "data/file.txt",
+			Name:      "file.txt",
+		})
+		require.NoError(t, err)
+
+		scheduledAt := model.GetMillis() + 1000
+		scheduledPost := &model.ScheduledPost{
+			Draft: model.Draft{
+				CreateAt:  model.GetMillis(),
+				UserId:    th.BasicUser.Id,
+				ChannelId: th.BasicChannel.Id,
+				Message:   "this is a recurring scheduled post with a file",
+				FileIds:   model.StringArray{fileInfo.Id},
+			},
+			ScheduledAt: scheduledAt,
+			Recurrence: &model.ScheduledPostRecurrence{
+				Frequency: model.ScheduledPostRecurrenceDaily,
+				TimeZone:  "UTC",
+			},
+		}
+		_, err = th.Server.Store().ScheduledPost().CreateScheduledPost(scheduledPost)
+		require.NoError(t, err)
+
+		time.Sleep(1 * time.Second)
+
+		th.App.ProcessScheduledPosts(th.Context)
+
+		posts, appErr := th.App.GetPosts(th.Context, th.BasicChannel.Id, 0, 10)
+		require.Nil(t, appErr)
+		var post *model.Post
+		for _, p := range posts.Posts {
+			if p.Message == scheduledPost.Message {
+				post = p
+			}
+		}
+		require.NotNil(t, post)
+		require.Len(t, post.FileIds, 1)
+		assert.NotEqual(t, fileInfo.Id, post.FileIds[0])
+
+		copied, err := th.App.Srv().Store().FileInfo().Get(post.FileIds[0])
+		require.NoError(t, err)
+		assert.Equal(t, post.Id, copied.PostId)
+		assert.Equal(t, fileInfo.Path, copied.Path)
+
+		// The original stays unattached for the next occurrences.
+		original, err := th.App.Srv().Store().FileInfo().Get(fileInfo.Id)
+		require.NoError(t, err)
+		assert.Empty(t, original.PostId)
+
+		scheduledPosts, err := th.App.Srv().Store().ScheduledPost().GetScheduledPostsForUser(th.BasicUser.Id, th.BasicChannel.TeamId)
+		require.NoError(t, err)
+		require.Len(t, scheduledPosts, 1)
+		assert.Empty(t, scheduledPosts[0].ErrorCode)
+		assert.Equal(t, model.StringArray{fileInfo.Id}, scheduledPosts[0].FileIds)
+	})
+
+	t.Run("paused recurring scheduled post skips its occurrence", func(t *testing.T) {
+		th := Setup(t).InitBasic(t)
+
+		th.App.Srv().SetLicense(getLicWithSkuShortName(model.LicenseShortSkuProfessional))
+
+		scheduledAt := model.GetMillis() + 1000
+		scheduledPost := &model.ScheduledPost{
+			Draft: model.Draft{
+				CreateAt:  model.GetMillis(),
+				UserId:    th.BasicUser.Id,
+				ChannelId: th.BasicChannel.Id,
+				Message:   "this is a paused recurring scheduled post",
+			},
+			ScheduledAt: scheduledAt,
+			Recurrence: &model.ScheduledPostRecurrence{
+				Frequency: model.ScheduledPostRecurrenceWeekly,
+				TimeZone:  "UTC",
+				Paused:    true,
+			},
+		}
+		_, err := th.Server.Store().ScheduledPost().CreateScheduledPost(scheduledPost)
+		assert.NoError(t, err)
+
+		time.Sleep(1 * time.Second)
+
+		th.App.ProcessScheduledPosts(th.Context)
+
+		posts, appErr := th.App.GetPosts(th.Context, th.BasicChannel.Id, 0, 10)
+		assert.Nil(t, appErr)
+		for _, post := range posts.Posts {
+			assert.NotEqual(t, scheduledPost.Message, post.Message)
+		}
+
+		scheduledPosts, err := th.App.Srv().Store().ScheduledPost().GetScheduledPostsForUser(th.BasicUser.Id, th.BasicChannel.TeamId)
+		assert.NoError(t, err)
+		assert.Len(t, scheduledPosts, 1)
+		assert.Equal(t, scheduledAt+7*24*60*60*1000, scheduledPosts[0].ScheduledAt)
+	})
+
 	t.Run("sets error code for archived channel", func(t *testing.T) {
 		th := Setup(t).InitBasic(t)
 
@@ -328,7 +466,7 @@ func TestHandleFailedScheduledPosts(t *testing.T) {
 		messagesUser2, closeWSUser2 := connectFakeWebSocket(t, th, user2.Id, "", []model.WebsocketEventType{model.WebsocketScheduledPostUpdated})
 		defer closeWSUser2()
 
-		th.App.handleFailedScheduledPosts(rctx, failedScheduledPosts)
+		th.App.handleFailedScheduledPosts(rctx, failedScheduledPosts, nil)
 
 		// Validate that the WebSocket events for both users are sent and received correctly
 		for i := range failedScheduledPosts {
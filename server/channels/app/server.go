@@ -40,16 +40,19 @@ import (
 	"github.com/mattermost/mattermost/server/v8/channels/audit"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/active_users"
+	"github.com/mattermost/mattermost/server/v8/channels/jobs/ai_action_item_reminders"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/cleanup_desktop_tokens"
+	"github.com/mattermost/mattermost/server/v8/channels/jobs/data_retention"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/delete_dms_preferences_migration"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/delete_empty_drafts_migration"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/delete_orphan_drafts_migration"
-	"github.com/mattermost/mattermost/server/v8/channels/jobs/ai_action_item_reminders"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/expirynotify"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/export_delete"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/export_process"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/export_users_to_csv"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/extract_content"
+	"github.com/mattermost/mattermost/server/v8/channels/jobs/file_encryption_rekey"
+	"github.com/mattermost/mattermost/server/v8/channels/jobs/file_storage_migration"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/hosted_purchase_screening"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/import_delete"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/import_process"
@@ -73,6 +76,7 @@ import (
 	"github.com/mattermost/mattermost/server/v8/platform/services/remotecluster"
 	"github.com/mattermost/mattermost/server/v8/platform/services/sharedchannel"
 	"github.com/mattermost/mattermost/server/v8/platform/services/telemetry"
+	"github.com/mattermost/mattermost/server/v8/platform/services/tracing"
 	"github.com/mattermost/mattermost/server/v8/platform/services/upgrader"
 	"github.com/mattermost/mattermost/server/v8/platform/shared/filestore"
 	"github.com/mattermost/mattermost/server/v8/platform/shared/mail"
@@ -137,7 +141,12 @@ type Server struct {
 
 	phase2PermissionsMigrationComplete bool
 
-	Audit *audit.Audit
+	Audit        *audit.Audit
+	auditLogSink *auditLogSink
+
+	// eventSubscriptionsStop is closed on shutdown so that event subscription deliveries stop
+	// waiting to be retried.
+	eventSubscriptionsStop chan struct{}
 
 	joinCluster  bool
 	skipPostInit bool
@@ -169,9 +178,10 @@ func NewServer(options ...Option) (*Server, error) {
 	localRouter := mux.NewRouter()
 
 	s := &Server{
-		RootRouter:  rootRouter,
-		LocalRouter: localRouter,
-		timezones:   timezones.New(),
+		RootRouter:             rootRouter,
+		LocalRouter:            localRouter,
+		timezones:              timezones.New(),
+		eventSubscriptionsStop: make(chan struct{}),
 	}
 
 	for _, option := range options {
@@ -199,7 +209,7 @@ func NewServer(options ...Option) (*Server, error) {
 	}
 	s.Router = s.RootRouter.PathPrefix(subpath).Subrouter()
 
-	s.httpService = httpservice.MakeHTTPService(s.platform)
+	s.httpService = tracing.WrapHTTPService(httpservice.MakeHTTPService(s.platform))
 
 	// Step 2: Init Enterprise
 	// Depends on step 1 (s.Platform must be non-nil)
@@ -386,7 +396,8 @@ func NewServer(options ...Option) (*Server, error) {
 		mlog.Error("SiteURL must be set. Some features will operate incorrectly if the SiteURL is not set. See documentation for details: https://mattermost.com/pl/configure-site-url")
 	}
 
-	// Start email batching because it's not like the other jobs
+	// The email batching task sends the batched emails through the email service, which only sets it up when email
+	// batching is enabled
 	s.platform.AddConfigListener(func(_, _ *model.Config) {
 		s.EmailService.InitEmailBatching()
 	})
@@ -404,6 +415,8 @@ func NewServer(options ...Option) (*Server, error) {
 		if err = s.configureAudit(s.Audit, allowAdvancedLogging); err != nil {
 			mlog.Error("Error configuring audit", mlog.Err(err))
 		}
+		s.auditLogSink = newAuditLogSink(s)
+		s.Audit.SetSink(s.auditLogSink)
 	}
 
 	s.platform.RemoveUnlicensedLogTargets(license)
@@ -490,8 +503,10 @@ func (s *Server) runJobs() {
 	s.Go(func() {
 		appInstance := New(ServerConnector(s.Channels()))
 		runDNDStatusExpireJob(appInstance)
+		runWorkingHoursStatusJob(appInstance)
 		runPostReminderJob(appInstance)
 		runScheduledPostJob(appInstance)
+		runEmailBatchingJob(appInstance)
 	})
 	s.Go(func() {
 		runSecurityJob(s)
@@ -663,12 +678,16 @@ func (s *Server) Shutdown() {
 	// Push notification hub needs to be shutdown after HTTP server
 	// to prevent stray requests from generating a push notification after it's shut down.
 	s.StopPushNotificationsHubWorkers()
+	close(s.eventSubscriptionsStop)
 
 	s.platform.StopSearchEngine()
 
 	if err = s.Audit.Shutdown(); err != nil {
 		s.Log().Warn("Failed to shut down audit", mlog.Err(err))
 	}
+	if s.auditLogSink != nil {
+		s.auditLogSink.shutdown()
+	}
 
 	s.platform.StopFeatureFlagUpdateJob()
 
@@ -684,6 +703,10 @@ func (s *Server) Shutdown() {
 		s.Log().Warn("Failed to stop metrics server", mlog.Err(err))
 	}
 
+	if err = s.platform.ShutdownTracing(); err != nil {
+		s.Log().Warn("Failed to stop tracing", mlog.Err(err))
+	}
+
 	// Stopping email service after HTTP server has stopped to prevent
 	// any stray notifications from being queued.
 	s.EmailService.Stop()
@@ -834,7 +857,7 @@ func (s *Server) Start() error {
 	err := s.FileBackend().TestConnection()
 	if err != nil {
 		if _, ok := err.(*filestore.S3FileBackendNoBucketError); ok {
-			err = s.FileBackend().(*filestore.S3FileBackend).MakeBucket()
+			err = filestore.UnwrapFileBackend(s.FileBackend()).(*filestore.S3FileBackend).MakeBucket()
 		}
 		if err != nil {
 			mlog.Error("Problem with file storage settings", mlog.Err(err))
@@ -893,7 +916,16 @@ func (s *Server) Start() error {
 	if *s.platform.Config().RateLimitSettings.Enable {
 		mlog.Info("RateLimiter is enabled")
 
-		rateLimiter, err2 := NewRateLimiter(&s.platform.Config().RateLimitSettings, s.platform.Config().ServiceSettings.TrustedProxyIPHeader)
+		subpath, err2 := utils.GetSubpathFromConfig(s.platform.Config())
+		if err2 != nil {
+			return errors.Wrap(err2, "failed to parse SiteURL subpath")
+		}
+
+		rateLimiter, err2 := NewRateLimiter(&s.platform.Config().RateLimitSettings, s.platform.Config().ServiceSettings.TrustedProxyIPHeader,
+			RateLimitRedisClient(cache.RedisClient(s.platform.CacheProvider()), *s.platform.Config().CacheSettings.RedisCachePrefix),
+			RateLimitMetrics(s.GetMetrics()),
+			RateLimitSubpath(subpath),
+		)
 		if err2 != nil {
 			return err2
 		}
@@ -1446,6 +1478,12 @@ func (s *Server) initJobs() {
 	if jobsDataRetentionJobInterface != nil {
 		builder := jobsDataRetentionJobInterface(s)
 		s.Jobs.RegisterJobType(model.JobTypeDataRetention, builder.MakeWorker(), builder.MakeScheduler())
+	} else {
+		s.Jobs.RegisterJobType(
+			model.JobTypeDataRetention,
+			data_retention.MakeWorker(s.Jobs, s.Store(), New(ServerConnector(s.Channels()))),
+			data_retention.MakeScheduler(s.Jobs, s.Store(), s.License),
+		)
 	}
 
 	if jobsMessageExportJobInterface != nil {
@@ -1621,6 +1659,18 @@ func (s *Server) initJobs() {
 		cleanup_desktop_tokens.MakeScheduler(s.Jobs),
 	)
 
+	s.Jobs.RegisterJobType(
+		model.JobTypeFileEncryptionRekey,
+		file_encryption_rekey.MakeWorker(s.Jobs, s.Store(), New(ServerConnector(s.Channels()))),
+		nil,
+	)
+
+	s.Jobs.RegisterJobType(
+		model.JobTypeFileStorageMigration,
+		file_storage_migration.MakeWorker(s.Jobs, s.Store(), New(ServerConnector(s.Channels()))),
+		nil,
+	)
+
 	s.Jobs.RegisterJobType(
 		model.JobTypeRefreshMaterializedViews,
 		refresh_materialized_views.MakeWorker(s.Jobs, *s.platform.Config().SqlSettings.DriverName),
@@ -1779,6 +1829,24 @@ func runDNDStatusExpireJob(a *App) {
 	})
 }
 
+func runWorkingHoursStatusJob(a *App) {
+	if a.IsLeader() {
+		withMut(&a.ch.workingHoursTaskMut, func() {
+			a.ch.workingHoursTask = model.CreateRecurringTaskFromNextIntervalTime("Update Working Hours Statuses", a.UpdateWorkingHoursStatuses, model.WorkingHoursStatusInterval)
+		})
+	}
+	a.ch.srv.AddClusterLeaderChangedListener(func() {
+		mlog.Info("Cluster leader changed. Determining if working hours status task should be running", mlog.Bool("isLeader", a.IsLeader()))
+		if a.IsLeader() {
+			withMut(&a.ch.workingHoursTaskMut, func() {
+				a.ch.workingHoursTask = model.CreateRecurringTaskFromNextIntervalTime("Update Working Hours Statuses", a.UpdateWorkingHoursStatuses, model.WorkingHoursStatusInterval)
+			})
+		} else {
+			cancelTask(&a.ch.workingHoursTaskMut, &a.ch.workingHoursTask)
+		}
+	})
+}
+
 func runPostReminderJob(a *App) {
 	if a.IsLeader() {
 		rctx := request.EmptyContext(a.Log())
@@ -1832,6 +1900,44 @@ func doRunScheduledPostJob(a *App) {
 	})
 }
 
+// runEmailBatchingJob sends the batched emails from the cluster leader, every email batching interval.
+func runEmailBatchingJob(a *App) {
+	if a.IsLeader() {
+		doRunEmailBatchingJob(a)
+	}
+
+	a.ch.srv.AddClusterLeaderChangedListener(func() {
+		mlog.Info("Cluster leader changed. Determining if email batching task should be running", mlog.Bool("isLeader", a.IsLeader()))
+		if a.IsLeader() {
+			doRunEmailBatchingJob(a)
+		} else {
+			cancelTask(&a.ch.emailBatchingMut, &a.ch.emailBatchingTask)
+		}
+	})
+
+	a.AddConfigListener(func(oldCfg, newCfg *model.Config) {
+		if *oldCfg.EmailSettings.EmailBatchingInterval != *newCfg.EmailSettings.EmailBatchingInterval && a.IsLeader() {
+			doRunEmailBatchingJob(a)
+		}
+	})
+}
+
+func doRunEmailBatchingJob(a *App) {
+	jobInterval := time.Duration(*a.Config().EmailSettings.EmailBatchingInterval) * time.Second
+
+	withMut(&a.ch.emailBatchingMut, func() {
+		if a.ch.emailBatchingTask != nil {
+			a.ch.emailBatchingTask.Cancel()
+		}
+		fn := func() {
+			if err := a.Srv().EmailService.SendBatchedEmails(); err != nil {
+				mlog.Warn("Failed to send the batched emails", mlog.Err(err))
+			}
+		}
+		a.ch.emailBatchingTask = model.CreateRecurringTaskFromNextIntervalTime("Send Batched Emails", fn, jobInterval)
+	})
+}
+
 func (a *App) GetAppliedSchemaMigrations() ([]model.AppliedMigration, *model.AppError) {
 	table, err := a.Srv().Store().GetAppliedMigrations()
 	if err != nil {
@@ -10,6 +10,7 @@ import (
 
 	"github.com/hashicorp/go-multierror"
 	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/plugin"
 	"github.com/mattermost/mattermost/server/public/shared/mlog"
 	"github.com/mattermost/mattermost/server/public/shared/request"
 )
@@ -36,6 +37,11 @@ func (a *App) createDefaultChannelMemberships(rctx request.CTX, params model.Cre
 			mlog.String("channel_id", userChannel.ChannelID),
 		)
 
+		if rejectionReason := a.runUserWillBeAddedToGroupSyncableHook(rctx, userChannel.UserID, userChannel.ChannelID, model.GroupSyncableTypeChannel); rejectionReason != "" {
+			logger.Info("User not added to channel - rejected by plugin", mlog.String("rejection_reason", rejectionReason))
+			continue
+		}
+
 		channel, err := a.GetChannel(rctx, userChannel.ChannelID)
 		if err != nil {
 			multiErr = multierror.Append(multiErr, fmt.Errorf("failed to get channel for default channel membership: %w", err))
@@ -105,6 +111,11 @@ func (a *App) createDefaultTeamMemberships(rctx request.CTX, params model.Create
 			mlog.String("team_id", userTeam.TeamID),
 		)
 
+		if rejectionReason := a.runUserWillBeAddedToGroupSyncableHook(rctx, userTeam.UserID, userTeam.TeamID, model.GroupSyncableTypeTeam); rejectionReason != "" {
+			logger.Info("User not added to team - rejected by plugin", mlog.String("rejection_reason", rejectionReason))
+			continue
+		}
+
 		_, err := a.AddTeamMember(rctx, userTeam.TeamID, userTeam.UserID)
 		if err != nil {
 			if err.Id == "api.team.join_user_to_team.allowed_domains.app_error" {
@@ -121,6 +132,23 @@ func (a *App) createDefaultTeamMemberships(rctx request.CTX, params model.Create
 	return multiErr.ErrorOrNil()
 }
 
+// runUserWillBeAddedToGroupSyncableHook returns the reason a plugin gave to keep the user from
+// being added to the synced team or channel, if any.
+func (a *App) runUserWillBeAddedToGroupSyncableHook(rctx request.CTX, userID, syncableID string, syncableType model.GroupSyncableType) string {
+	rejectionReason := ""
+	pluginContext := pluginContext(rctx)
+	a.ch.RunMultiHook(func(hooks plugin.Hooks, manifest *model.Manifest) bool {
+		rejectionReason = hooks.UserWillBeAddedToGroupSyncable(pluginContext, userID, syncableID, syncableType)
+		if rejectionReason != "" {
+			rctx.Logger().Debug("Group syncable membership rejected by plugin.", mlog.String("plugin_id", manifest.Id))
+			return false
+		}
+		return true
+	}, plugin.UserWillBeAddedToGroupSyncableID)
+
+	return rejectionReason
+}
+
 // CreateDefaultMemberships adds users to teams and channels based on their group memberships and how those groups
 // are configured to sync with teams and channels for group members on or after the given timestamp.
 // If params.AddRemovedMembers is true, then members who left or were removed from a team/channel will
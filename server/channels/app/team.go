@@ -748,7 +748,12 @@ func (a *App) AddUserToTeamByInviteId(rctx request.CTX, inviteId string, userID
 }
 
 func (a *App) JoinUserToTeam(rctx request.CTX, team *model.Team, user *model.User, userRequestorId string) (*model.TeamMember, *model.AppError) {
-	teamMember, alreadyAdded, err := a.ch.srv.teamService.JoinUserToTeam(rctx, team, user)
+	teamMember, alreadyAdded, err := a.ch.srv.teamService.JoinUserToTeam(rctx, team, user, func(tm *model.TeamMember) error {
+		if appErr := a.runUserWillJoinTeamHook(rctx, tm, team); appErr != nil {
+			return appErr
+		}
+		return nil
+	})
 	if err != nil {
 		var appErr *model.AppError
 		var conflictErr *store.ErrConflict
@@ -822,10 +827,36 @@ func (a *App) JoinUserToTeam(rctx request.CTX, team *model.Team, user *model.Use
 	message.Add("team_id", team.Id)
 	message.Add("user_id", user.Id)
 	a.Publish(message)
+	a.publishSubscribedEvent(model.EventSubscriptionEventUserAddedToTeam, team.Id, "", message)
 
 	return teamMember, nil
 }
 
+// runUserWillJoinTeamHook lets the plugins modify or reject the team member before it's saved.
+func (a *App) runUserWillJoinTeamHook(rctx request.CTX, teamMember *model.TeamMember, team *model.Team) *model.AppError {
+	var rejectionError *model.AppError
+	pluginContext := pluginContext(rctx)
+	a.ch.RunMultiHook(func(hooks plugin.Hooks, manifest *model.Manifest) bool {
+		replacementMember, rejectionReason := hooks.UserWillJoinTeam(pluginContext, teamMember, team)
+		if rejectionReason != "" {
+			rctx.Logger().Info("Team membership rejected by plugin.",
+				mlog.String("user_id", teamMember.UserId),
+				mlog.String("team_id", team.Id),
+				mlog.String("rejection_reason", rejectionReason),
+				mlog.String("plugin_id", manifest.Id))
+			rejectionError = model.NewAppError("JoinUserToTeam", "app.team.join_user_to_team.rejected_by_plugin.app_error",
+				map[string]any{"Reason": rejectionReason}, "", http.StatusForbidden)
+			return false
+		}
+		if replacementMember != nil {
+			teamMember.SchemeAdmin = replacementMember.SchemeAdmin && !teamMember.SchemeGuest
+		}
+		return true
+	}, plugin.UserWillJoinTeamID)
+
+	return rejectionError
+}
+
 func (a *App) GetTeam(teamID string) (*model.Team, *model.AppError) {
 	team, err := a.ch.srv.teamService.GetTeam(teamID)
 	if err != nil {
@@ -1185,6 +1216,11 @@ func (a *App) postProcessTeamMemberLeave(rctx request.CTX, teamMember *model.Tea
 		}, plugin.UserHasLeftTeamID)
 	})
 
+	message := model.NewWebSocketEvent(model.WebsocketEventLeaveTeam, teamMember.TeamId, "", "", nil, "")
+	message.Add("user_id", teamMember.UserId)
+	message.Add("team_id", teamMember.TeamId)
+	a.publishSubscribedEvent(model.EventSubscriptionEventUserRemovedFromTeam, teamMember.TeamId, "", message)
+
 	user, nErr := a.Srv().Store().User().Get(context.Background(), teamMember.UserId)
 	if nErr != nil {
 		var nfErr *store.ErrNotFound
@@ -136,7 +136,10 @@ func (ts *TeamService) PatchTeam(teamID string, patch *model.TeamPatch) (*model.
 // 1. a pointer to the team member, if successful
 // 2. a boolean: true if the user has a non-deleted team member for that team already, otherwise false.
 // 3. a pointer to an AppError if something went wrong.
-func (ts *TeamService) JoinUserToTeam(rctx request.CTX, team *model.Team, user *model.User) (*model.TeamMember, bool, error) {
+//
+// willJoin, if set, is called with the new team member before it's saved, and can modify it
+// or return an error to keep the user from joining.
+func (ts *TeamService) JoinUserToTeam(rctx request.CTX, team *model.Team, user *model.User, willJoin func(*model.TeamMember) error) (*model.TeamMember, bool, error) {
 	if !ts.IsTeamEmailAllowed(user, team) {
 		return nil, false, AcceptedDomainError
 	}
@@ -162,6 +165,17 @@ func (ts *TeamService) JoinUserToTeam(rctx request.CTX, team *model.Team, user *
 	}
 
 	rtm, err := ts.store.GetMember(rctx, team.Id, user.Id)
+	if err == nil && rtm.DeleteAt == 0 {
+		// Do nothing if already added
+		return rtm, true, nil
+	}
+
+	if willJoin != nil {
+		if err := willJoin(tm); err != nil {
+			return nil, false, err
+		}
+	}
+
 	if err != nil {
 		// Membership appears to be missing. Lets try to add.
 		tmr, nErr := ts.store.SaveMember(rctx, tm, *ts.config().TeamSettings.MaxUsersPerTeam)
@@ -171,12 +185,7 @@ func (ts *TeamService) JoinUserToTeam(rctx request.CTX, team *model.Team, user *
 		return tmr, false, nil
 	}
 
-	// Membership already exists.  Check if deleted and update, otherwise do nothing
-	// Do nothing if already added
-	if rtm.DeleteAt == 0 {
-		return rtm, true, nil
-	}
-
+	// Membership already exists but was deleted, so update it
 	membersCount, err := ts.store.GetActiveMemberCount(tm.TeamId, nil)
 	if err != nil {
 		return nil, false, MemberCountError
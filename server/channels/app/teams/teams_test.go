@@ -4,6 +4,7 @@
 package teams
 
 import (
+	"errors"
 	"strings"
 	"testing"
 
@@ -97,20 +98,39 @@ func TestJoinUserToTeam(t *testing.T) {
 		ruser := th.CreateUser(&user)
 		defer th.DeleteUser(&user)
 
-		_, alreadyAdded, err := th.service.JoinUserToTeam(th.Context, team, ruser)
+		_, alreadyAdded, err := th.service.JoinUserToTeam(th.Context, team, ruser, nil)
 		require.False(t, alreadyAdded, "Should return already added equal to false")
 		require.NoError(t, err)
 	})
 
+	t.Run("join rejected", func(t *testing.T) {
+		user := model.User{Email: strings.ToLower(model.NewId()) + "success+test@example.com", Nickname: "Darth Vader", Username: "vader" + model.NewId(), Password: "passwd1", AuthService: ""}
+		ruser := th.CreateUser(&user)
+		defer th.DeleteUser(&user)
+
+		rejectErr := errors.New("rejected")
+		var willJoinMember *model.TeamMember
+		_, _, err := th.service.JoinUserToTeam(th.Context, team, ruser, func(tm *model.TeamMember) error {
+			willJoinMember = tm
+			return rejectErr
+		})
+		require.ErrorIs(t, err, rejectErr)
+		require.NotNil(t, willJoinMember)
+		require.Equal(t, ruser.Id, willJoinMember.UserId)
+
+		_, err = th.service.GetMember(th.Context, team.Id, ruser.Id)
+		require.Error(t, err)
+	})
+
 	t.Run("join when you are a member", func(t *testing.T) {
 		user := model.User{Email: strings.ToLower(model.NewId()) + "success+test@example.com", Nickname: "Darth Vader", Username: "vader" + model.NewId(), Password: "passwd1", AuthService: ""}
 		ruser := th.CreateUser(&user)
 		defer th.DeleteUser(&user)
 
-		_, _, err := th.service.JoinUserToTeam(th.Context, team, ruser)
+		_, _, err := th.service.JoinUserToTeam(th.Context, team, ruser, nil)
 		require.NoError(t, err)
 
-		_, alreadyAdded, err := th.service.JoinUserToTeam(th.Context, team, ruser)
+		_, alreadyAdded, err := th.service.JoinUserToTeam(th.Context, team, ruser, nil)
 		require.True(t, alreadyAdded, "Should return already added")
 		require.NoError(t, err)
 	})
@@ -120,12 +140,12 @@ func TestJoinUserToTeam(t *testing.T) {
 		ruser := th.CreateUser(&user)
 		defer th.DeleteUser(&user)
 
-		member, _, err := th.service.JoinUserToTeam(th.Context, team, ruser)
+		member, _, err := th.service.JoinUserToTeam(th.Context, team, ruser, nil)
 		require.NoError(t, err)
 		err = th.service.RemoveTeamMember(th.Context, member)
 		require.NoError(t, err)
 
-		_, alreadyAdded, err := th.service.JoinUserToTeam(th.Context, team, ruser)
+		_, alreadyAdded, err := th.service.JoinUserToTeam(th.Context, team, ruser, nil)
 		require.False(t, alreadyAdded, "Should return already added equal to false")
 		require.NoError(t, err)
 	})
@@ -139,10 +159,10 @@ func TestJoinUserToTeam(t *testing.T) {
 		defer th.DeleteUser(&user1)
 		defer th.DeleteUser(&user2)
 
-		_, _, err := th.service.JoinUserToTeam(th.Context, team, ruser1)
+		_, _, err := th.service.JoinUserToTeam(th.Context, team, ruser1, nil)
 		require.NoError(t, err)
 
-		_, _, err = th.service.JoinUserToTeam(th.Context, team, ruser2)
+		_, _, err = th.service.JoinUserToTeam(th.Context, team, ruser2, nil)
 		require.Error(t, err, "Should fail")
 	})
 
@@ -156,14 +176,14 @@ func TestJoinUserToTeam(t *testing.T) {
 		defer th.DeleteUser(&user1)
 		defer th.DeleteUser(&user2)
 
-		member, _, err := th.service.JoinUserToTeam(th.Context, team, ruser1)
+		member, _, err := th.service.JoinUserToTeam(th.Context, team, ruser1, nil)
 		require.NoError(t, err)
 		err = th.service.RemoveTeamMember(th.Context, member)
 		require.NoError(t, err)
-		_, _, err = th.service.JoinUserToTeam(th.Context, team, ruser2)
+		_, _, err = th.service.JoinUserToTeam(th.Context, team, ruser2, nil)
 		require.NoError(t, err)
 
-		_, _, err = th.service.JoinUserToTeam(th.Context, team, ruser1)
+		_, _, err = th.service.JoinUserToTeam(th.Context, team, ruser1, nil)
 		require.Error(t, err, "Should fail")
 	})
 }
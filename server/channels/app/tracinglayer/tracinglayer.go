@@ -0,0 +1,13730 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+// Code generated by "make app-layers"
+// DO NOT EDIT
+
+package tracinglayer
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"reflect"
+	"time"
+
+	agentclient "github.com/mattermost/mattermost-plugin-ai/public/bridgeclient"
+	"go.opentelemetry.io/otel/codes"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/plugin"
+	"github.com/mattermost/mattermost/server/public/shared/httpservice"
+	"github.com/mattermost/mattermost/server/public/shared/i18n"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/public/shared/timezones"
+	"github.com/mattermost/mattermost/server/v8/channels/app"
+	"github.com/mattermost/mattermost/server/v8/channels/app/platform"
+	"github.com/mattermost/mattermost/server/v8/channels/app/properties"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+	"github.com/mattermost/mattermost/server/v8/einterfaces"
+	"github.com/mattermost/mattermost/server/v8/platform/services/imageproxy"
+	"github.com/mattermost/mattermost/server/v8/platform/services/remotecluster"
+	"github.com/mattermost/mattermost/server/v8/platform/services/searchengine"
+	"github.com/mattermost/mattermost/server/v8/platform/services/tracing"
+	"github.com/mattermost/mattermost/server/v8/platform/shared/filestore"
+)
+
+// TracingAppLayer records a span for each call to the app it wraps, child of the span of the
+// request context of the call, or of the context of the layer. The request context passed on
+// to the app carries the span, so that the store calls and outgoing requests made with it are
+// recorded as its children.
+type TracingAppLayer struct {
+	app app.AppIface
+	ctx context.Context
+}
+
+// requestContext returns the context of the request, or ctx if there is none.
+func requestContext(rctx request.CTX, ctx context.Context) context.Context {
+	if rctx != nil && rctx.Context() != nil {
+		return rctx.Context()
+	}
+	return ctx
+}
+
+func (a *TracingAppLayer) AccountMigration() einterfaces.AccountMigrationInterface {
+	return a.app.AccountMigration()
+}
+
+func (a *TracingAppLayer) ActivateMfa(userID string, token string) *model.AppError {
+	_, span := tracing.Start(a.ctx, "App.ActivateMfa")
+	defer span.End()
+
+	err := a.app.ActivateMfa(userID, token)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) ActiveSearchBackend() string {
+	return a.app.ActiveSearchBackend()
+}
+
+func (a *TracingAppLayer) AddAuditLogCertificate(rctx request.CTX, fileData *multipart.FileHeader) *model.AppError {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.AddAuditLogCertificate")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.AddAuditLogCertificate(rctx, fileData)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) AddChannelMember(rctx request.CTX, userID string, channel *model.Channel, opts app.ChannelMemberOpts) (*model.ChannelMember, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.AddChannelMember")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.AddChannelMember(rctx, userID, channel, opts)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) AddChannelsToRetentionPolicy(policyID string, channelIDs []string) *model.AppError {
+	_, span := tracing.Start(a.ctx, "App.AddChannelsToRetentionPolicy")
+	defer span.End()
+
+	err := a.app.AddChannelsToRetentionPolicy(policyID, channelIDs)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) AddConfigListener(listener func(*model.Config, *model.Config)) string {
+	_, span := tracing.Start(a.ctx, "App.AddConfigListener")
+	defer span.End()
+
+	result := a.app.AddConfigListener(listener)
+	return result
+}
+
+func (a *TracingAppLayer) AddCursorIdsForPostList(originalList *model.PostList, afterPost string, beforePost string, since int64, page int, perPage int, collapsedThreads bool) {
+	_, span := tracing.Start(a.ctx, "App.AddCursorIdsForPostList")
+	defer span.End()
+
+	a.app.AddCursorIdsForPostList(originalList, afterPost, beforePost, since, page, perPage, collapsedThreads)
+}
+
+func (a *TracingAppLayer) AddDirectChannels(rctx request.CTX, teamID string, user *model.User) *model.AppError {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.AddDirectChannels")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.AddDirectChannels(rctx, teamID, user)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) AddLdapPrivateCertificate(fileData *multipart.FileHeader) *model.AppError {
+	_, span := tracing.Start(a.ctx, "App.AddLdapPrivateCertificate")
+	defer span.End()
+
+	err := a.app.AddLdapPrivateCertificate(fileData)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) AddLdapPublicCertificate(fileData *multipart.FileHeader) *model.AppError {
+	_, span := tracing.Start(a.ctx, "App.AddLdapPublicCertificate")
+	defer span.End()
+
+	err := a.app.AddLdapPublicCertificate(fileData)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) AddLicenseListener(listener func(oldLicense, newLicense *model.License)) string {
+	_, span := tracing.Start(a.ctx, "App.AddLicenseListener")
+	defer span.End()
+
+	result := a.app.AddLicenseListener(listener)
+	return result
+}
+
+func (a *TracingAppLayer) AddPublicKey(name string, key io.Reader) *model.AppError {
+	_, span := tracing.Start(a.ctx, "App.AddPublicKey")
+	defer span.End()
+
+	err := a.app.AddPublicKey(name, key)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) AddRemoteCluster(rc *model.RemoteCluster) (*model.RemoteCluster, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.AddRemoteCluster")
+	defer span.End()
+
+	result, err := a.app.AddRemoteCluster(rc)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) AddSamlIdpCertificate(fileData *multipart.FileHeader) *model.AppError {
+	_, span := tracing.Start(a.ctx, "App.AddSamlIdpCertificate")
+	defer span.End()
+
+	err := a.app.AddSamlIdpCertificate(fileData)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) AddSamlPrivateCertificate(fileData *multipart.FileHeader) *model.AppError {
+	_, span := tracing.Start(a.ctx, "App.AddSamlPrivateCertificate")
+	defer span.End()
+
+	err := a.app.AddSamlPrivateCertificate(fileData)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) AddSamlPublicCertificate(fileData *multipart.FileHeader) *model.AppError {
+	_, span := tracing.Start(a.ctx, "App.AddSamlPublicCertificate")
+	defer span.End()
+
+	err := a.app.AddSamlPublicCertificate(fileData)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) AddSessionToCache(session *model.Session) {
+	_, span := tracing.Start(a.ctx, "App.AddSessionToCache")
+	defer span.End()
+
+	a.app.AddSessionToCache(session)
+}
+
+func (a *TracingAppLayer) AddTeamMember(rctx request.CTX, teamID string, userID string) (*model.TeamMember, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.AddTeamMember")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.AddTeamMember(rctx, teamID, userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) AddTeamMemberByInviteId(rctx request.CTX, inviteId string, userID string) (*model.TeamMember, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.AddTeamMemberByInviteId")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.AddTeamMemberByInviteId(rctx, inviteId, userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) AddTeamMemberByToken(rctx request.CTX, userID string, tokenID string) (*model.TeamMember, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.AddTeamMemberByToken")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.AddTeamMemberByToken(rctx, userID, tokenID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) AddTeamMembers(rctx request.CTX, teamID string, userIDs []string, userRequestorId string, graceful bool) ([]*model.TeamMemberWithError, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.AddTeamMembers")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.AddTeamMembers(rctx, teamID, userIDs, userRequestorId, graceful)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) AddTeamsToRetentionPolicy(policyID string, teamIDs []string) *model.AppError {
+	_, span := tracing.Start(a.ctx, "App.AddTeamsToRetentionPolicy")
+	defer span.End()
+
+	err := a.app.AddTeamsToRetentionPolicy(policyID, teamIDs)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) AddUserToChannel(rctx request.CTX, user *model.User, channel *model.Channel, skipTeamMemberIntegrityCheck bool) (*model.ChannelMember, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.AddUserToChannel")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.AddUserToChannel(rctx, user, channel, skipTeamMemberIntegrityCheck)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) AddUserToTeam(rctx request.CTX, teamID string, userID string, userRequestorId string) (*model.Team, *model.TeamMember, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.AddUserToTeam")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, resultVar1, err := a.app.AddUserToTeam(rctx, teamID, userID, userRequestorId)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, resultVar1, err
+}
+
+func (a *TracingAppLayer) AddUserToTeamByInviteId(rctx request.CTX, inviteId string, userID string) (*model.Team, *model.TeamMember, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.AddUserToTeamByInviteId")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, resultVar1, err := a.app.AddUserToTeamByInviteId(rctx, inviteId, userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, resultVar1, err
+}
+
+func (a *TracingAppLayer) AddUserToTeamByInviteIfNeeded(rctx request.CTX, user *model.User, inviteToken string, inviteId string) *model.AppError {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.AddUserToTeamByInviteIfNeeded")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.AddUserToTeamByInviteIfNeeded(rctx, user, inviteToken, inviteId)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) AddUserToTeamByTeamId(rctx request.CTX, teamID string, user *model.User) *model.AppError {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.AddUserToTeamByTeamId")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.AddUserToTeamByTeamId(rctx, teamID, user)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) AddUserToTeamByToken(rctx request.CTX, userID string, tokenID string) (*model.Team, *model.TeamMember, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.AddUserToTeamByToken")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, resultVar1, err := a.app.AddUserToTeamByToken(rctx, userID, tokenID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, resultVar1, err
+}
+
+func (a *TracingAppLayer) AddUserToTeamWithToken(rctx request.CTX, userID string, token *model.Token) (*model.Team, *model.TeamMember, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.AddUserToTeamWithToken")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, resultVar1, err := a.app.AddUserToTeamWithToken(rctx, userID, token)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, resultVar1, err
+}
+
+func (a *TracingAppLayer) AdjustImage(rctx request.CTX, file io.ReadSeeker) (*bytes.Buffer, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.AdjustImage")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.AdjustImage(rctx, file)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) AdjustInProductLimits(limits *model.ProductLimits, subscription *model.Subscription) *model.AppError {
+	_, span := tracing.Start(a.ctx, "App.AdjustInProductLimits")
+	defer span.End()
+
+	err := a.app.AdjustInProductLimits(limits, subscription)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) AdjustTeamsFromProductLimits(teamLimits *model.TeamsLimits) *model.AppError {
+	_, span := tracing.Start(a.ctx, "App.AdjustTeamsFromProductLimits")
+	defer span.End()
+
+	err := a.app.AdjustTeamsFromProductLimits(teamLimits)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) AllowOAuthAppAccessToUser(rctx request.CTX, userID string, authRequest *model.AuthorizeRequest) (string, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.AllowOAuthAppAccessToUser")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.AllowOAuthAppAccessToUser(rctx, userID, authRequest)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) AppendFile(fr io.Reader, path string) (int64, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.AppendFile")
+	defer span.End()
+
+	result, err := a.app.AppendFile(fr, path)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) AssignAccessControlPolicyToChannels(rctx request.CTX, parentID string, channelIDs []string) ([]*model.AccessControlPolicy, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.AssignAccessControlPolicyToChannels")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.AssignAccessControlPolicyToChannels(rctx, parentID, channelIDs)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) AssignFlaggedPostReviewer(rctx request.CTX, flaggedPostId string, flaggedPostTeamId string, reviewerId string, assigneeId string) *model.AppError {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.AssignFlaggedPostReviewer")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.AssignFlaggedPostReviewer(rctx, flaggedPostId, flaggedPostTeamId, reviewerId, assigneeId)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) AsymmetricSigningKey() *ecdsa.PrivateKey {
+	return a.app.AsymmetricSigningKey()
+}
+
+func (a *TracingAppLayer) AttachCloudSessionCookie(rctx request.CTX, w http.ResponseWriter, r *http.Request) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.AttachCloudSessionCookie")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	a.app.AttachCloudSessionCookie(rctx, w, r)
+}
+
+func (a *TracingAppLayer) AttachDeviceId(sessionID string, deviceID string, expiresAt int64) *model.AppError {
+	_, span := tracing.Start(a.ctx, "App.AttachDeviceId")
+	defer span.End()
+
+	err := a.app.AttachDeviceId(sessionID, deviceID, expiresAt)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) AttachSessionCookies(rctx request.CTX, w http.ResponseWriter, r *http.Request) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.AttachSessionCookies")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	a.app.AttachSessionCookies(rctx, w, r)
+}
+
+func (a *TracingAppLayer) AttachWebPushSubscription(rctx request.CTX, session *model.Session, subscription *model.WebPushSubscription) *model.AppError {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.AttachWebPushSubscription")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.AttachWebPushSubscription(rctx, session, subscription)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) AuthenticateUserForGuestMagicLink(rctx request.CTX, tokenString string) (*model.User, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.AuthenticateUserForGuestMagicLink")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.AuthenticateUserForGuestMagicLink(rctx, tokenString)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) AuthenticateUserForLogin(rctx request.CTX, id string, loginId string, password string, mfaToken string, cwsToken string, ldapOnly bool) (*model.User, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.AuthenticateUserForLogin")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.AuthenticateUserForLogin(rctx, id, loginId, password, mfaToken, cwsToken, ldapOnly)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) AuthorizeOAuthUser(rctx request.CTX, w http.ResponseWriter, r *http.Request, service string, code string, state string, redirectURI string) (io.ReadCloser, map[string]string, *model.User, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.AuthorizeOAuthUser")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, resultVar1, resultVar2, err := a.app.AuthorizeOAuthUser(rctx, w, r, service, code, state, redirectURI)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, resultVar1, resultVar2, err
+}
+
+func (a *TracingAppLayer) AutoDetectAndCreateActionItems(c request.CTX, post *model.Post) error {
+	ctx, span := tracing.Start(requestContext(c, a.ctx), "App.AutoDetectAndCreateActionItems")
+	if c != nil {
+		c = c.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.AutoDetectAndCreateActionItems(c, post)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) AutocompleteChannels(rctx request.CTX, userID string, term string) (model.ChannelListWithTeamData, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.AutocompleteChannels")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.AutocompleteChannels(rctx, userID, term)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) AutocompleteChannelsForSearch(rctx request.CTX, teamID string, userID string, term string) (model.ChannelList, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.AutocompleteChannelsForSearch")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.AutocompleteChannelsForSearch(rctx, teamID, userID, term)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) AutocompleteChannelsForTeam(rctx request.CTX, teamID string, userID string, term string) (model.ChannelList, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.AutocompleteChannelsForTeam")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.AutocompleteChannelsForTeam(rctx, teamID, userID, term)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) AutocompleteUsersInChannel(rctx request.CTX, teamID string, channelID string, term string, options *model.UserSearchOptions) (*model.UserAutocompleteInChannel, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.AutocompleteUsersInChannel")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.AutocompleteUsersInChannel(rctx, teamID, channelID, term, options)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) AutocompleteUsersInTeam(rctx request.CTX, teamID string, term string, options *model.UserSearchOptions) (*model.UserAutocompleteInTeam, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.AutocompleteUsersInTeam")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.AutocompleteUsersInTeam(rctx, teamID, term, options)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) BuildPostReactions(rctx request.CTX, postID string) (*[]app.ReactionImportData, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.BuildPostReactions")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.BuildPostReactions(rctx, postID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) BuildPushNotificationMessage(rctx request.CTX, contentsConfig string, post *model.Post, user *model.User, channel *model.Channel, channelName string, senderName string, explicitMention bool, channelWideMention bool, replyToThreadType string) (*model.PushNotification, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.BuildPushNotificationMessage")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.BuildPushNotificationMessage(rctx, contentsConfig, post, user, channel, channelName, senderName, explicitMention, channelWideMention, replyToThreadType)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) BuildSamlMetadataObject(idpMetadata []byte) (*model.SamlMetadataResponse, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.BuildSamlMetadataObject")
+	defer span.End()
+
+	result, err := a.app.BuildSamlMetadataObject(idpMetadata)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) BulkExport(rctx request.CTX, writer io.Writer, outPath string, job *model.Job, opts model.BulkExportOpts) *model.AppError {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.BulkExport")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.BulkExport(rctx, writer, outPath, job, opts)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) BulkImport(rctx request.CTX, jsonlReader io.Reader, attachmentsReader *zip.Reader, dryRun bool, workers int) (int, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.BulkImport")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.BulkImport(rctx, jsonlReader, attachmentsReader, dryRun, workers)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) BulkImportWithPath(rctx request.CTX, jsonlReader io.Reader, attachmentsReader *zip.Reader, dryRun bool, extractContent bool, workers int, importPath string) (int, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.BulkImportWithPath")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.BulkImportWithPath(rctx, jsonlReader, attachmentsReader, dryRun, extractContent, workers, importPath)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) CanNotifyAdmin(rctx request.CTX, trial bool) bool {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.CanNotifyAdmin")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result := a.app.CanNotifyAdmin(rctx, trial)
+	return result
+}
+
+func (a *TracingAppLayer) CanUserAccessActionItem(c request.CTX, userID string, item *model.AIActionItem) bool {
+	ctx, span := tracing.Start(requestContext(c, a.ctx), "App.CanUserAccessActionItem")
+	if c != nil {
+		c = c.WithContext(ctx)
+	}
+	defer span.End()
+
+	result := a.app.CanUserAccessActionItem(c, userID, item)
+	return result
+}
+
+func (a *TracingAppLayer) CanUserModifyActionItem(c request.CTX, userID string, item *model.AIActionItem) bool {
+	ctx, span := tracing.Start(requestContext(c, a.ctx), "App.CanUserModifyActionItem")
+	if c != nil {
+		c = c.WithContext(ctx)
+	}
+	defer span.End()
+
+	result := a.app.CanUserModifyActionItem(c, userID, item)
+	return result
+}
+
+func (a *TracingAppLayer) CancelJob(rctx request.CTX, jobId string) *model.AppError {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.CancelJob")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.CancelJob(rctx, jobId)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) ChannelAccessControlled(rctx request.CTX, channelID string) (bool, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.ChannelAccessControlled")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.ChannelAccessControlled(rctx, channelID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) ChannelMembersMinusGroupMembers(channelID string, groupIDs []string, page int, perPage int) ([]*model.UserWithGroups, int64, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.ChannelMembersMinusGroupMembers")
+	defer span.End()
+
+	result, resultVar1, err := a.app.ChannelMembersMinusGroupMembers(channelID, groupIDs, page, perPage)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, resultVar1, err
+}
+
+func (a *TracingAppLayer) ChannelMembersToAdd(since int64, channelID *string, reAddRemovedMembers bool) ([]*model.UserChannelIDPair, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.ChannelMembersToAdd")
+	defer span.End()
+
+	result, err := a.app.ChannelMembersToAdd(since, channelID, reAddRemovedMembers)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) ChannelMembersToRemove(teamID *string) ([]*model.ChannelMember, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.ChannelMembersToRemove")
+	defer span.End()
+
+	result, err := a.app.ChannelMembersToRemove(teamID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) Channels() *app.Channels {
+	return a.app.Channels()
+}
+
+func (a *TracingAppLayer) CheckCanInviteToSharedChannel(channelId string) error {
+	_, span := tracing.Start(a.ctx, "App.CheckCanInviteToSharedChannel")
+	defer span.End()
+
+	err := a.app.CheckCanInviteToSharedChannel(channelId)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) CheckExpression(rctx request.CTX, expression string) ([]model.CELExpressionError, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.CheckExpression")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.CheckExpression(rctx, expression)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) CheckIfChannelIsRestrictedDM(rctx request.CTX, channel *model.Channel) (bool, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.CheckIfChannelIsRestrictedDM")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.CheckIfChannelIsRestrictedDM(rctx, channel)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) CheckIntegrity() <-chan model.IntegrityCheckResult {
+	return a.app.CheckIntegrity()
+}
+
+func (a *TracingAppLayer) CheckMandatoryS3Fields(settings *model.FileSettings) *model.AppError {
+	_, span := tracing.Start(a.ctx, "App.CheckMandatoryS3Fields")
+	defer span.End()
+
+	err := a.app.CheckMandatoryS3Fields(settings)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) CheckPasswordAndAllCriteria(rctx request.CTX, userID string, password string, mfaToken string) *model.AppError {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.CheckPasswordAndAllCriteria")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.CheckPasswordAndAllCriteria(rctx, userID, password, mfaToken)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) CheckPostReminders(rctx request.CTX) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.CheckPostReminders")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	a.app.CheckPostReminders(rctx)
+}
+
+func (a *TracingAppLayer) CheckProviderAttributes(rctx request.CTX, user *model.User, patch *model.UserPatch) string {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.CheckProviderAttributes")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result := a.app.CheckProviderAttributes(rctx, user, patch)
+	return result
+}
+
+func (a *TracingAppLayer) CheckRolesExist(roleNames []string) *model.AppError {
+	_, span := tracing.Start(a.ctx, "App.CheckRolesExist")
+	defer span.End()
+
+	err := a.app.CheckRolesExist(roleNames)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) CheckUserAllAuthenticationCriteria(rctx request.CTX, user *model.User, mfaToken string) *model.AppError {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.CheckUserAllAuthenticationCriteria")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.CheckUserAllAuthenticationCriteria(rctx, user, mfaToken)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) CheckUserMfa(rctx request.CTX, user *model.User, token string) *model.AppError {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.CheckUserMfa")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.CheckUserMfa(rctx, user, token)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) CheckUserPostflightAuthenticationCriteria(rctx request.CTX, user *model.User) *model.AppError {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.CheckUserPostflightAuthenticationCriteria")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.CheckUserPostflightAuthenticationCriteria(rctx, user)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) CheckUserPreflightAuthenticationCriteria(rctx request.CTX, user *model.User, mfaToken string) *model.AppError {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.CheckUserPreflightAuthenticationCriteria")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.CheckUserPreflightAuthenticationCriteria(rctx, user, mfaToken)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) CleanUpAfterPostDeletion(rctx request.CTX, post *model.Post, deleteByID string) *model.AppError {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.CleanUpAfterPostDeletion")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.CleanUpAfterPostDeletion(rctx, post, deleteByID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) CleanupReportChunks(format string, prefix string, numberOfChunks int) *model.AppError {
+	_, span := tracing.Start(a.ctx, "App.CleanupReportChunks")
+	defer span.End()
+
+	err := a.app.CleanupReportChunks(format, prefix, numberOfChunks)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) ClearChannelMembersCache(rctx request.CTX, channelID string) error {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.ClearChannelMembersCache")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.ClearChannelMembersCache(rctx, channelID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) ClearSessionCacheForAllUsers() {
+	a.app.ClearSessionCacheForAllUsers()
+}
+
+func (a *TracingAppLayer) ClearSessionCacheForAllUsersSkipClusterSend() {
+	a.app.ClearSessionCacheForAllUsersSkipClusterSend()
+}
+
+func (a *TracingAppLayer) ClearSessionCacheForUser(userID string) {
+	_, span := tracing.Start(a.ctx, "App.ClearSessionCacheForUser")
+	defer span.End()
+
+	a.app.ClearSessionCacheForUser(userID)
+}
+
+func (a *TracingAppLayer) ClearSessionCacheForUserSkipClusterSend(userID string) {
+	_, span := tracing.Start(a.ctx, "App.ClearSessionCacheForUserSkipClusterSend")
+	defer span.End()
+
+	a.app.ClearSessionCacheForUserSkipClusterSend(userID)
+}
+
+func (a *TracingAppLayer) ClearTeamMembersCache(teamID string) error {
+	_, span := tracing.Start(a.ctx, "App.ClearTeamMembersCache")
+	defer span.End()
+
+	err := a.app.ClearTeamMembersCache(teamID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) ClientConfig() map[string]string {
+	return a.app.ClientConfig()
+}
+
+func (a *TracingAppLayer) ClientConfigHash() string {
+	return a.app.ClientConfigHash()
+}
+
+func (a *TracingAppLayer) Cloud() einterfaces.CloudInterface {
+	return a.app.Cloud()
+}
+
+func (a *TracingAppLayer) Cluster() einterfaces.ClusterInterface {
+	return a.app.Cluster()
+}
+
+func (a *TracingAppLayer) CommandsForTeam(teamID string) []*model.Command {
+	_, span := tracing.Start(a.ctx, "App.CommandsForTeam")
+	defer span.End()
+
+	result := a.app.CommandsForTeam(teamID)
+	return result
+}
+
+func (a *TracingAppLayer) CompareAndDeletePluginKey(rctx request.CTX, pluginID string, key string, oldValue []byte) (bool, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.CompareAndDeletePluginKey")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.CompareAndDeletePluginKey(rctx, pluginID, key, oldValue)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) CompareAndSetPluginKey(pluginID string, key string, oldValue []byte, newValue []byte) (bool, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.CompareAndSetPluginKey")
+	defer span.End()
+
+	result, err := a.app.CompareAndSetPluginKey(pluginID, key, oldValue, newValue)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) CompileReportChunks(format string, prefix string, numberOfChunks int, headers []string) *model.AppError {
+	_, span := tracing.Start(a.ctx, "App.CompileReportChunks")
+	defer span.End()
+
+	err := a.app.CompileReportChunks(format, prefix, numberOfChunks, headers)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) CompleteActionItem(c request.CTX, actionItemID string, userID string) (*model.AIActionItem, error) {
+	ctx, span := tracing.Start(requestContext(c, a.ctx), "App.CompleteActionItem")
+	if c != nil {
+		c = c.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.CompleteActionItem(c, actionItemID, userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) CompleteOAuth(rctx request.CTX, service string, body io.ReadCloser, props map[string]string, tokenUser *model.User) (*model.User, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.CompleteOAuth")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.CompleteOAuth(rctx, service, body, props, tokenUser)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) CompleteOnboarding(rctx request.CTX, request *model.CompleteOnboardingRequest) *model.AppError {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.CompleteOnboarding")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.CompleteOnboarding(rctx, request)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) CompleteSwitchWithOAuth(rctx request.CTX, service string, userData io.Reader, email string, tokenUser *model.User) (*model.User, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.CompleteSwitchWithOAuth")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.CompleteSwitchWithOAuth(rctx, service, userData, email, tokenUser)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) Compliance() einterfaces.ComplianceInterface {
+	return a.app.Compliance()
+}
+
+func (a *TracingAppLayer) ComputeLastAccessibleFileTime() error {
+	_, span := tracing.Start(a.ctx, "App.ComputeLastAccessibleFileTime")
+	defer span.End()
+
+	err := a.app.ComputeLastAccessibleFileTime()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) ComputeLastAccessiblePostTime() error {
+	_, span := tracing.Start(a.ctx, "App.ComputeLastAccessiblePostTime")
+	defer span.End()
+
+	err := a.app.ComputeLastAccessiblePostTime()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) Config() *model.Config {
+	return a.app.Config()
+}
+
+func (a *TracingAppLayer) ConsumeTokenOnce(tokenType string, tokenStr string) (*model.Token, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.ConsumeTokenOnce")
+	defer span.End()
+
+	result, err := a.app.ConsumeTokenOnce(tokenType, tokenStr)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) ContentFlaggingEnabledForTeam(teamId string) (bool, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.ContentFlaggingEnabledForTeam")
+	defer span.End()
+
+	result, err := a.app.ContentFlaggingEnabledForTeam(teamId)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) ContentFlaggingGroupId() (string, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.ContentFlaggingGroupId")
+	defer span.End()
+
+	result, err := a.app.ContentFlaggingGroupId()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) ConvertBotToUser(rctx request.CTX, bot *model.Bot, userPatch *model.UserPatch, sysadmin bool) (*model.User, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.ConvertBotToUser")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.ConvertBotToUser(rctx, bot, userPatch, sysadmin)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) ConvertGroupMessageToChannel(rctx request.CTX, convertedByUserId string, gmConversionRequest *model.GroupMessageConversionRequestBody) (*model.Channel, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.ConvertGroupMessageToChannel")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.ConvertGroupMessageToChannel(rctx, convertedByUserId, gmConversionRequest)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) ConvertUserToBot(rctx request.CTX, user *model.User) (*model.Bot, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.ConvertUserToBot")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.ConvertUserToBot(rctx, user)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) CopyFileInfos(rctx request.CTX, userID string, fileIDs []string) ([]string, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.CopyFileInfos")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.CopyFileInfos(rctx, userID, fileIDs)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) CopyWranglerPostlist(rctx request.CTX, wpl *model.WranglerPostList, targetChannel *model.Channel) (*model.Post, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.CopyWranglerPostlist")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.CopyWranglerPostlist(rctx, wpl, targetChannel)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) CountNotification(notificationType model.NotificationType, platform string) {
+	_, span := tracing.Start(a.ctx, "App.CountNotification")
+	defer span.End()
+
+	a.app.CountNotification(notificationType, platform)
+}
+
+func (a *TracingAppLayer) CountNotificationAck(notificationType model.NotificationType, platform string) {
+	_, span := tracing.Start(a.ctx, "App.CountNotificationAck")
+	defer span.End()
+
+	a.app.CountNotificationAck(notificationType, platform)
+}
+
+func (a *TracingAppLayer) CountNotificationReason(notificationStatus model.NotificationStatus, notificationType model.NotificationType, notificationReason model.NotificationReason, platform string) {
+	_, span := tracing.Start(a.ctx, "App.CountNotificationReason")
+	defer span.End()
+
+	a.app.CountNotificationReason(notificationStatus, notificationType, notificationReason, platform)
+}
+
+func (a *TracingAppLayer) CpaGroupID() (string, error) {
+	_, span := tracing.Start(a.ctx, "App.CpaGroupID")
+	defer span.End()
+
+	result, err := a.app.CpaGroupID()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) CreateAccessControlSyncJob(rctx request.CTX, jobData map[string]string) (*model.Job, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.CreateAccessControlSyncJob")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.CreateAccessControlSyncJob(rctx, jobData)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) CreateActionItem(c request.CTX, item *model.AIActionItem) (*model.AIActionItem, error) {
+	ctx, span := tracing.Start(requestContext(c, a.ctx), "App.CreateActionItem")
+	if c != nil {
+		c = c.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.CreateActionItem(c, item)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) CreateBot(rctx request.CTX, bot *model.Bot) (*model.Bot, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.CreateBot")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.CreateBot(rctx, bot)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) CreateCPAField(field *model.CPAField) (*model.CPAField, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.CreateCPAField")
+	defer span.End()
+
+	result, err := a.app.CreateCPAField(field)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) CreateChannel(rctx request.CTX, channel *model.Channel, addMember bool) (*model.Channel, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.CreateChannel")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.CreateChannel(rctx, channel, addMember)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) CreateChannelBookmark(rctx request.CTX, newBookmark *model.ChannelBookmark, connectionId string) (*model.ChannelBookmarkWithFileInfo, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.CreateChannelBookmark")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.CreateChannelBookmark(rctx, newBookmark, connectionId)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) CreateChannelScheme(rctx request.CTX, channel *model.Channel) (*model.Scheme, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.CreateChannelScheme")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.CreateChannelScheme(rctx, channel)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) CreateChannelWithUser(rctx request.CTX, channel *model.Channel, userID string) (*model.Channel, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.CreateChannelWithUser")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.CreateChannelWithUser(rctx, channel, userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) CreateCommand(cmd *model.Command) (*model.Command, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.CreateCommand")
+	defer span.End()
+
+	result, err := a.app.CreateCommand(cmd)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) CreateCommandPost(rctx request.CTX, post *model.Post, teamID string, response *model.CommandResponse, skipSlackParsing bool) (*model.Post, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.CreateCommandPost")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.CreateCommandPost(rctx, post, teamID, response, skipSlackParsing)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) CreateCommandWebhook(commandID string, args *model.CommandArgs) (*model.CommandWebhook, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.CreateCommandWebhook")
+	defer span.End()
+
+	result, err := a.app.CreateCommandWebhook(commandID, args)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) CreateDefaultMemberships(rctx request.CTX, params model.CreateDefaultMembershipParams) error {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.CreateDefaultMemberships")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.CreateDefaultMemberships(rctx, params)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) CreateEmoji(rctx request.CTX, sessionUserId string, emoji *model.Emoji, multiPartImageData *multipart.Form) (*model.Emoji, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.CreateEmoji")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.CreateEmoji(rctx, sessionUserId, emoji, multiPartImageData)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) CreateEventSubscription(rctx request.CTX, subscription *model.EventSubscription) (*model.EventSubscription, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.CreateEventSubscription")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.CreateEventSubscription(rctx, subscription)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) CreateGroup(group *model.Group) (*model.Group, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.CreateGroup")
+	defer span.End()
+
+	result, err := a.app.CreateGroup(group)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) CreateGroupChannel(rctx request.CTX, userIDs []string, creatorId string, channelOptions ...model.ChannelOption) (*model.Channel, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.CreateGroupChannel")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.CreateGroupChannel(rctx, userIDs, creatorId, channelOptions...)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) CreateGroupWithUserIds(group *model.GroupWithUserIds) (*model.Group, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.CreateGroupWithUserIds")
+	defer span.End()
+
+	result, err := a.app.CreateGroupWithUserIds(group)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) CreateGuest(rctx request.CTX, user *model.User) (*model.User, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.CreateGuest")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.CreateGuest(rctx, user)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) CreateIncomingWebhookForChannel(creatorId string, channel *model.Channel, hook *model.IncomingWebhook) (*model.IncomingWebhook, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.CreateIncomingWebhookForChannel")
+	defer span.End()
+
+	result, err := a.app.CreateIncomingWebhookForChannel(creatorId, channel, hook)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) CreateJob(rctx request.CTX, job *model.Job) (*model.Job, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.CreateJob")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.CreateJob(rctx, job)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) CreateOAuthApp(app *model.OAuthApp) (*model.OAuthApp, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.CreateOAuthApp")
+	defer span.End()
+
+	result, err := a.app.CreateOAuthApp(app)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) CreateOAuthAppInternal(app *model.OAuthApp, generateSecret bool) (*model.OAuthApp, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.CreateOAuthAppInternal")
+	defer span.End()
+
+	result, err := a.app.CreateOAuthAppInternal(app, generateSecret)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) CreateOAuthStateToken(extra string) (*model.Token, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.CreateOAuthStateToken")
+	defer span.End()
+
+	result, err := a.app.CreateOAuthStateToken(extra)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) CreateOAuthUser(rctx request.CTX, service string, userData io.Reader, inviteToken string, inviteId string, tokenUser *model.User) (*model.User, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.CreateOAuthUser")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.CreateOAuthUser(rctx, service, userData, inviteToken, inviteId, tokenUser)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) CreateOrUpdateAccessControlPolicy(rctx request.CTX, policy *model.AccessControlPolicy) (*model.AccessControlPolicy, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.CreateOrUpdateAccessControlPolicy")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.CreateOrUpdateAccessControlPolicy(rctx, policy)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) CreateOutgoingWebhook(hook *model.OutgoingWebhook) (*model.OutgoingWebhook, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.CreateOutgoingWebhook")
+	defer span.End()
+
+	result, err := a.app.CreateOutgoingWebhook(hook)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) CreatePasswordRecoveryToken(rctx request.CTX, userID string, email string) (*model.Token, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.CreatePasswordRecoveryToken")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.CreatePasswordRecoveryToken(rctx, userID, email)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) CreatePost(rctx request.CTX, post *model.Post, channel *model.Channel, flags model.CreatePostFlags) (*model.Post, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.CreatePost")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.CreatePost(rctx, post, channel, flags)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) CreatePostAsUser(rctx request.CTX, post *model.Post, currentSessionId string, setOnline bool) (*model.Post, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.CreatePostAsUser")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.CreatePostAsUser(rctx, post, currentSessionId, setOnline)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) CreatePostMissingChannel(rctx request.CTX, post *model.Post, triggerWebhooks bool, setOnline bool) (*model.Post, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.CreatePostMissingChannel")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.CreatePostMissingChannel(rctx, post, triggerWebhooks, setOnline)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) CreateRemoteClusterInvite(remoteId string, siteURL string, token string, password string) (string, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.CreateRemoteClusterInvite")
+	defer span.End()
+
+	result, err := a.app.CreateRemoteClusterInvite(remoteId, siteURL, token, password)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) CreateRetentionPolicy(policy *model.RetentionPolicyWithTeamAndChannelIDs) (*model.RetentionPolicyWithTeamAndChannelCounts, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.CreateRetentionPolicy")
+	defer span.End()
+
+	result, err := a.app.CreateRetentionPolicy(policy)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) CreateRole(role *model.Role) (*model.Role, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.CreateRole")
+	defer span.End()
+
+	result, err := a.app.CreateRole(role)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) CreateSamlRelayToken(tokenType string, extra string) (*model.Token, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.CreateSamlRelayToken")
+	defer span.End()
+
+	result, err := a.app.CreateSamlRelayToken(tokenType, extra)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) CreateScheme(scheme *model.Scheme) (*model.Scheme, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.CreateScheme")
+	defer span.End()
+
+	result, err := a.app.CreateScheme(scheme)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) CreateSession(rctx request.CTX, session *model.Session) (*model.Session, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.CreateSession")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.CreateSession(rctx, session)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) CreateSidebarCategory(rctx request.CTX, userID string, teamID string, newCategory *model.SidebarCategoryWithChannels) (*model.SidebarCategoryWithChannels, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.CreateSidebarCategory")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.CreateSidebarCategory(rctx, userID, teamID, newCategory)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) CreateTeam(rctx request.CTX, team *model.Team) (*model.Team, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.CreateTeam")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.CreateTeam(rctx, team)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) CreateTeamWithUser(rctx request.CTX, team *model.Team, userID string) (*model.Team, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.CreateTeamWithUser")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.CreateTeamWithUser(rctx, team, userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) CreateTermsOfService(text string, userID string) (*model.TermsOfService, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.CreateTermsOfService")
+	defer span.End()
+
+	result, err := a.app.CreateTermsOfService(text, userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) CreateUploadSession(rctx request.CTX, us *model.UploadSession) (*model.UploadSession, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.CreateUploadSession")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.CreateUploadSession(rctx, us)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) CreateUser(rctx request.CTX, user *model.User) (*model.User, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.CreateUser")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.CreateUser(rctx, user)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) CreateUserAccessToken(rctx request.CTX, token *model.UserAccessToken) (*model.UserAccessToken, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.CreateUserAccessToken")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.CreateUserAccessToken(rctx, token)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) CreateUserAsAdmin(rctx request.CTX, user *model.User, redirect string) (*model.User, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.CreateUserAsAdmin")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.CreateUserAsAdmin(rctx, user, redirect)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) CreateUserFromSignup(rctx request.CTX, user *model.User, redirect string) (*model.User, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.CreateUserFromSignup")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.CreateUserFromSignup(rctx, user, redirect)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) CreateUserWithInviteId(rctx request.CTX, user *model.User, inviteId string, redirect string) (*model.User, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.CreateUserWithInviteId")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.CreateUserWithInviteId(rctx, user, inviteId, redirect)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) CreateUserWithToken(rctx request.CTX, user *model.User, token *model.Token) (*model.User, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.CreateUserWithToken")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.CreateUserWithToken(rctx, user, token)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) CreateWebhookPost(rctx request.CTX, userID string, channel *model.Channel, text string, overrideUsername string, overrideIconURL string, overrideIconEmoji string, props model.StringInterface, postType string, postRootId string, priority *model.PostPriority) (*model.Post, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.CreateWebhookPost")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.CreateWebhookPost(rctx, userID, channel, text, overrideUsername, overrideIconURL, overrideIconEmoji, props, postType, postRootId, priority)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) CutOverFileStorageMigration(rctx request.CTX) *model.AppError {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.CutOverFileStorageMigration")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.CutOverFileStorageMigration(rctx)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) DBHealthCheckDelete() error {
+	_, span := tracing.Start(a.ctx, "App.DBHealthCheckDelete")
+	defer span.End()
+
+	err := a.app.DBHealthCheckDelete()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) DBHealthCheckWrite() error {
+	_, span := tracing.Start(a.ctx, "App.DBHealthCheckWrite")
+	defer span.End()
+
+	err := a.app.DBHealthCheckWrite()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) DataRetention() einterfaces.DataRetentionInterface {
+	return a.app.DataRetention()
+}
+
+func (a *TracingAppLayer) DeactivateGuests(rctx request.CTX) *model.AppError {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.DeactivateGuests")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.DeactivateGuests(rctx)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) DeactivateMagicLinkGuests(rctx request.CTX) *model.AppError {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.DeactivateMagicLinkGuests")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.DeactivateMagicLinkGuests(rctx)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) DeactivateMfa(userID string) *model.AppError {
+	_, span := tracing.Start(a.ctx, "App.DeactivateMfa")
+	defer span.End()
+
+	err := a.app.DeactivateMfa(userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) DeauthorizeOAuthAppForUser(rctx request.CTX, userID string, appID string) *model.AppError {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.DeauthorizeOAuthAppForUser")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.DeauthorizeOAuthAppForUser(rctx, userID, appID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) DecryptRemoteClusterInvite(inviteCode string, password string) (*model.RemoteClusterInvite, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.DecryptRemoteClusterInvite")
+	defer span.End()
+
+	result, err := a.app.DecryptRemoteClusterInvite(inviteCode, password)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) DefaultChannelNames(rctx request.CTX) []string {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.DefaultChannelNames")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result := a.app.DefaultChannelNames(rctx)
+	return result
+}
+
+func (a *TracingAppLayer) DeleteAccessControlPolicy(rctx request.CTX, id string) *model.AppError {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.DeleteAccessControlPolicy")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.DeleteAccessControlPolicy(rctx, id)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) DeleteAcknowledgementForPost(rctx request.CTX, postID string, userID string) *model.AppError {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.DeleteAcknowledgementForPost")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.DeleteAcknowledgementForPost(rctx, postID, userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) DeleteAcknowledgementForPostWithModel(rctx request.CTX, acknowledgement *model.PostAcknowledgement) *model.AppError {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.DeleteAcknowledgementForPostWithModel")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.DeleteAcknowledgementForPostWithModel(rctx, acknowledgement)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) DeleteActionItem(c request.CTX, actionItemID string, userID string) error {
+	ctx, span := tracing.Start(requestContext(c, a.ctx), "App.DeleteActionItem")
+	if c != nil {
+		c = c.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.DeleteActionItem(c, actionItemID, userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) DeleteAllExpiredPluginKeys() *model.AppError {
+	_, span := tracing.Start(a.ctx, "App.DeleteAllExpiredPluginKeys")
+	defer span.End()
+
+	err := a.app.DeleteAllExpiredPluginKeys()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) DeleteAllKeysForPlugin(pluginID string) *model.AppError {
+	_, span := tracing.Start(a.ctx, "App.DeleteAllKeysForPlugin")
+	defer span.End()
+
+	err := a.app.DeleteAllKeysForPlugin(pluginID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) DeleteBrandImage(rctx request.CTX) *model.AppError {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.DeleteBrandImage")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.DeleteBrandImage(rctx)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) DeleteCPAField(id string) *model.AppError {
+	_, span := tracing.Start(a.ctx, "App.DeleteCPAField")
+	defer span.End()
+
+	err := a.app.DeleteCPAField(id)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) DeleteCPAValues(userID string) *model.AppError {
+	_, span := tracing.Start(a.ctx, "App.DeleteCPAValues")
+	defer span.End()
+
+	err := a.app.DeleteCPAValues(userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) DeleteChannel(rctx request.CTX, channel *model.Channel, userID string) *model.AppError {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.DeleteChannel")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.DeleteChannel(rctx, channel, userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) DeleteChannelBookmark(bookmarkId string, connectionId string) (*model.ChannelBookmarkWithFileInfo, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.DeleteChannelBookmark")
+	defer span.End()
+
+	result, err := a.app.DeleteChannelBookmark(bookmarkId, connectionId)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) DeleteChannelScheme(rctx request.CTX, channel *model.Channel) (*model.Channel, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.DeleteChannelScheme")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.DeleteChannelScheme(rctx, channel)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) DeleteCommand(commandID string) *model.AppError {
+	_, span := tracing.Start(a.ctx, "App.DeleteCommand")
+	defer span.End()
+
+	err := a.app.DeleteCommand(commandID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) DeleteDraft(rctx request.CTX, draft *model.Draft, connectionID string) *model.AppError {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.DeleteDraft")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.DeleteDraft(rctx, draft, connectionID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) DeleteEmoji(rctx request.CTX, emoji *model.Emoji) *model.AppError {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.DeleteEmoji")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.DeleteEmoji(rctx, emoji)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) DeleteEphemeralPost(rctx request.CTX, userID string, postID string) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.DeleteEphemeralPost")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	a.app.DeleteEphemeralPost(rctx, userID, postID)
+}
+
+func (a *TracingAppLayer) DeleteEventSubscription(id string) *model.AppError {
+	_, span := tracing.Start(a.ctx, "App.DeleteEventSubscription")
+	defer span.End()
+
+	err := a.app.DeleteEventSubscription(id)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) DeleteExport(name string) *model.AppError {
+	_, span := tracing.Start(a.ctx, "App.DeleteExport")
+	defer span.End()
+
+	err := a.app.DeleteExport(name)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) DeleteGroup(groupID string) (*model.Group, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.DeleteGroup")
+	defer span.End()
+
+	result, err := a.app.DeleteGroup(groupID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) DeleteGroupConstrainedChannelMemberships(rctx request.CTX, channelID *string) error {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.DeleteGroupConstrainedChannelMemberships")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.DeleteGroupConstrainedChannelMemberships(rctx, channelID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) DeleteGroupConstrainedMemberships(rctx request.CTX) error {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.DeleteGroupConstrainedMemberships")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.DeleteGroupConstrainedMemberships(rctx)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) DeleteGroupConstrainedTeamMemberships(rctx request.CTX, teamID *string) error {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.DeleteGroupConstrainedTeamMemberships")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.DeleteGroupConstrainedTeamMemberships(rctx, teamID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) DeleteGroupMember(groupID string, userID string) (*model.GroupMember, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.DeleteGroupMember")
+	defer span.End()
+
+	result, err := a.app.DeleteGroupMember(groupID, userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) DeleteGroupMembers(groupID string, userIDs []string) ([]*model.GroupMember, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.DeleteGroupMembers")
+	defer span.End()
+
+	result, err := a.app.DeleteGroupMembers(groupID, userIDs)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) DeleteGroupSyncable(groupID string, syncableID string, syncableType model.GroupSyncableType) (*model.GroupSyncable, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.DeleteGroupSyncable")
+	defer span.End()
+
+	result, err := a.app.DeleteGroupSyncable(groupID, syncableID, syncableType)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) DeleteImport(name string) *model.AppError {
+	_, span := tracing.Start(a.ctx, "App.DeleteImport")
+	defer span.End()
+
+	err := a.app.DeleteImport(name)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) DeleteIncomingWebhook(hookID string) *model.AppError {
+	_, span := tracing.Start(a.ctx, "App.DeleteIncomingWebhook")
+	defer span.End()
+
+	err := a.app.DeleteIncomingWebhook(hookID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) DeleteOAuthApp(rctx request.CTX, appID string) *model.AppError {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.DeleteOAuthApp")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.DeleteOAuthApp(rctx, appID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) DeleteOutgoingWebhook(hookID string) *model.AppError {
+	_, span := tracing.Start(a.ctx, "App.DeleteOutgoingWebhook")
+	defer span.End()
+
+	err := a.app.DeleteOutgoingWebhook(hookID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) DeletePersistentNotification(rctx request.CTX, post *model.Post) *model.AppError {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.DeletePersistentNotification")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.DeletePersistentNotification(rctx, post)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) DeletePluginKey(pluginID string, key string) *model.AppError {
+	_, span := tracing.Start(a.ctx, "App.DeletePluginKey")
+	defer span.End()
+
+	err := a.app.DeletePluginKey(pluginID, key)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) DeletePost(rctx request.CTX, postID string, deleteByID string) (*model.Post, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.DeletePost")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.DeletePost(rctx, postID, deleteByID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) DeletePreferences(rctx request.CTX, userID string, preferences model.Preferences) *model.AppError {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.DeletePreferences")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.DeletePreferences(rctx, userID, preferences)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) DeletePriorityForPost(postId string) *model.AppError {
+	_, span := tracing.Start(a.ctx, "App.DeletePriorityForPost")
+	defer span.End()
+
+	err := a.app.DeletePriorityForPost(postId)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) DeletePublicKey(name string) *model.AppError {
+	_, span := tracing.Start(a.ctx, "App.DeletePublicKey")
+	defer span.End()
+
+	err := a.app.DeletePublicKey(name)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) DeleteReactionForPost(rctx request.CTX, reaction *model.Reaction) *model.AppError {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.DeleteReactionForPost")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.DeleteReactionForPost(rctx, reaction)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) DeleteRemoteCluster(remoteClusterId string) (bool, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.DeleteRemoteCluster")
+	defer span.End()
+
+	result, err := a.app.DeleteRemoteCluster(remoteClusterId)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) DeleteRetentionPolicy(policyID string) *model.AppError {
+	_, span := tracing.Start(a.ctx, "App.DeleteRetentionPolicy")
+	defer span.End()
+
+	err := a.app.DeleteRetentionPolicy(policyID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) DeleteRole(id string) (*model.Role, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.DeleteRole")
+	defer span.End()
+
+	result, err := a.app.DeleteRole(id)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) DeleteScheduledPost(rctx request.CTX, userId string, scheduledPostId string, connectionId string) (*model.ScheduledPost, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.DeleteScheduledPost")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.DeleteScheduledPost(rctx, userId, scheduledPostId, connectionId)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) DeleteScheme(schemeId string) (*model.Scheme, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.DeleteScheme")
+	defer span.End()
+
+	result, err := a.app.DeleteScheme(schemeId)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) DeleteSharedChannelRemote(id string) (bool, error) {
+	_, span := tracing.Start(a.ctx, "App.DeleteSharedChannelRemote")
+	defer span.End()
+
+	result, err := a.app.DeleteSharedChannelRemote(id)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) DeleteSidebarCategory(rctx request.CTX, userID string, teamID string, categoryId string) *model.AppError {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.DeleteSidebarCategory")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.DeleteSidebarCategory(rctx, userID, teamID, categoryId)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) DeleteToken(token *model.Token) *model.AppError {
+	_, span := tracing.Start(a.ctx, "App.DeleteToken")
+	defer span.End()
+
+	err := a.app.DeleteToken(token)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) DemoteUserToGuest(rctx request.CTX, user *model.User) *model.AppError {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.DemoteUserToGuest")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.DemoteUserToGuest(rctx, user)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) DetachPlugin(pluginId string) *model.AppError {
+	_, span := tracing.Start(a.ctx, "App.DetachPlugin")
+	defer span.End()
+
+	err := a.app.DetachPlugin(pluginId)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) DetachWebPushSubscription(session *model.Session) *model.AppError {
+	_, span := tracing.Start(a.ctx, "App.DetachWebPushSubscription")
+	defer span.End()
+
+	err := a.app.DetachWebPushSubscription(session)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) DetectActionItems(c request.CTX, post *model.Post) (*app.ActionItemDetectionResult, error) {
+	ctx, span := tracing.Start(requestContext(c, a.ctx), "App.DetectActionItems")
+	if c != nil {
+		c = c.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.DetectActionItems(c, post)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) DiffConfigVersions(baseID string, actualID string) ([]*model.ConfigVersionDiff, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.DiffConfigVersions")
+	defer span.End()
+
+	result, err := a.app.DiffConfigVersions(baseID, actualID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) DisableAutoResponder(rctx request.CTX, userID string, asAdmin bool) *model.AppError {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.DisableAutoResponder")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.DisableAutoResponder(rctx, userID, asAdmin)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) DisablePlugin(id string) *model.AppError {
+	_, span := tracing.Start(a.ctx, "App.DisablePlugin")
+	defer span.End()
+
+	err := a.app.DisablePlugin(id)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) DisableUserAccessToken(rctx request.CTX, token *model.UserAccessToken) *model.AppError {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.DisableUserAccessToken")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.DisableUserAccessToken(rctx, token)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) DoActionRequest(rctx request.CTX, rawURL string, body []byte) (*http.Response, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.DoActionRequest")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.DoActionRequest(rctx, rawURL, body)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) DoAdvancedPermissionsMigration() error {
+	_, span := tracing.Start(a.ctx, "App.DoAdvancedPermissionsMigration")
+	defer span.End()
+
+	err := a.app.DoAdvancedPermissionsMigration()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) DoAppMigrations() {
+	a.app.DoAppMigrations()
+}
+
+func (a *TracingAppLayer) DoCheckForAdminNotifications(trial bool) *model.AppError {
+	_, span := tracing.Start(a.ctx, "App.DoCheckForAdminNotifications")
+	defer span.End()
+
+	err := a.app.DoCheckForAdminNotifications(trial)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) DoCommandRequest(rctx request.CTX, cmd *model.Command, p url.Values) (*model.Command, *model.CommandResponse, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.DoCommandRequest")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, resultVar1, err := a.app.DoCommandRequest(rctx, cmd, p)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, resultVar1, err
+}
+
+func (a *TracingAppLayer) DoEmojisPermissionsMigration() error {
+	_, span := tracing.Start(a.ctx, "App.DoEmojisPermissionsMigration")
+	defer span.End()
+
+	err := a.app.DoEmojisPermissionsMigration()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) DoGuestRolesCreationMigration() error {
+	_, span := tracing.Start(a.ctx, "App.DoGuestRolesCreationMigration")
+	defer span.End()
+
+	err := a.app.DoGuestRolesCreationMigration()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) DoLocalRequest(rctx request.CTX, rawURL string, body []byte) (*http.Response, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.DoLocalRequest")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.DoLocalRequest(rctx, rawURL, body)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) DoLogin(rctx request.CTX, w http.ResponseWriter, r *http.Request, user *model.User, deviceID string, isMobile bool, isOAuthUser bool, isSaml bool) (*model.Session, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.DoLogin")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.DoLogin(rctx, w, r, user, deviceID, isMobile, isOAuthUser, isSaml)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) DoPermissionsMigrations() error {
+	_, span := tracing.Start(a.ctx, "App.DoPermissionsMigrations")
+	defer span.End()
+
+	err := a.app.DoPermissionsMigrations()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) DoPostActionWithCookie(rctx request.CTX, postID string, actionId string, userID string, selectedOption string, cookie *model.PostActionCookie) (string, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.DoPostActionWithCookie")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.DoPostActionWithCookie(rctx, postID, actionId, userID, selectedOption, cookie)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) DoSystemConsoleRolesCreationMigration() error {
+	_, span := tracing.Start(a.ctx, "App.DoSystemConsoleRolesCreationMigration")
+	defer span.End()
+
+	err := a.app.DoSystemConsoleRolesCreationMigration()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) DoUploadFile(rctx request.CTX, now time.Time, rawTeamId string, rawChannelId string, rawUserId string, rawFilename string, data []byte, extractContent bool) (*model.FileInfo, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.DoUploadFile")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.DoUploadFile(rctx, now, rawTeamId, rawChannelId, rawUserId, rawFilename, data, extractContent)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) DoUploadFileExpectModification(rctx request.CTX, now time.Time, rawTeamId string, rawChannelId string, rawUserId string, rawFilename string, data []byte, extractContent bool) (*model.FileInfo, []byte, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.DoUploadFileExpectModification")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, resultVar1, err := a.app.DoUploadFileExpectModification(rctx, now, rawTeamId, rawChannelId, rawUserId, rawFilename, data, extractContent)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, resultVar1, err
+}
+
+func (a *TracingAppLayer) DoubleCheckPassword(rctx request.CTX, user *model.User, password string) *model.AppError {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.DoubleCheckPassword")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.DoubleCheckPassword(rctx, user, password)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) DownloadFromURL(downloadURL string) ([]byte, error) {
+	_, span := tracing.Start(a.ctx, "App.DownloadFromURL")
+	defer span.End()
+
+	result, err := a.app.DownloadFromURL(downloadURL)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) EnablePlugin(id string) *model.AppError {
+	_, span := tracing.Start(a.ctx, "App.EnablePlugin")
+	defer span.End()
+
+	err := a.app.EnablePlugin(id)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) EnableUserAccessToken(rctx request.CTX, token *model.UserAccessToken) *model.AppError {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.EnableUserAccessToken")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.EnableUserAccessToken(rctx, token)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) EnsureBot(rctx request.CTX, pluginID string, bot *model.Bot) (string, error) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.EnsureBot")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.EnsureBot(rctx, pluginID, bot)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) EnvironmentConfig(filter func(reflect.StructField) bool) map[string]any {
+	_, span := tracing.Start(a.ctx, "App.EnvironmentConfig")
+	defer span.End()
+
+	result := a.app.EnvironmentConfig(filter)
+	return result
+}
+
+func (a *TracingAppLayer) ExecuteCommand(rctx request.CTX, args *model.CommandArgs) (*model.CommandResponse, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.ExecuteCommand")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.ExecuteCommand(rctx, args)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) ExportFileBackend() filestore.FileBackend {
+	return a.app.ExportFileBackend()
+}
+
+func (a *TracingAppLayer) ExportFileExists(path string) (bool, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.ExportFileExists")
+	defer span.End()
+
+	result, err := a.app.ExportFileExists(path)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) ExportFileModTime(path string) (time.Time, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.ExportFileModTime")
+	defer span.End()
+
+	result, err := a.app.ExportFileModTime(path)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) ExportFileReader(path string) (filestore.ReadCloseSeeker, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.ExportFileReader")
+	defer span.End()
+
+	result, err := a.app.ExportFileReader(path)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) ExportPermissions(rctx request.CTX, w io.Writer) error {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.ExportPermissions")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.ExportPermissions(rctx, w)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) ExportZipReader(path string, deflate bool) (io.ReadCloser, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.ExportZipReader")
+	defer span.End()
+
+	result, err := a.app.ExportZipReader(path, deflate)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) ExpressionToVisualAST(rctx request.CTX, expression string) (*model.VisualExpression, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.ExpressionToVisualAST")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.ExpressionToVisualAST(rctx, expression)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) ExtendSessionExpiryIfNeeded(rctx request.CTX, session *model.Session) bool {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.ExtendSessionExpiryIfNeeded")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result := a.app.ExtendSessionExpiryIfNeeded(rctx, session)
+	return result
+}
+
+func (a *TracingAppLayer) ExtractContentFromFileInfo(rctx request.CTX, fileInfo *model.FileInfo) error {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.ExtractContentFromFileInfo")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.ExtractContentFromFileInfo(rctx, fileInfo)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) FetchSamlMetadataFromIdp(url string) ([]byte, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.FetchSamlMetadataFromIdp")
+	defer span.End()
+
+	result, err := a.app.FetchSamlMetadataFromIdp(url)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) FileBackend() filestore.FileBackend {
+	return a.app.FileBackend()
+}
+
+func (a *TracingAppLayer) FileExists(path string) (bool, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.FileExists")
+	defer span.End()
+
+	result, err := a.app.FileExists(path)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) FileModTime(path string) (time.Time, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.FileModTime")
+	defer span.End()
+
+	result, err := a.app.FileModTime(path)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) FileReader(path string) (filestore.ReadCloseSeeker, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.FileReader")
+	defer span.End()
+
+	result, err := a.app.FileReader(path)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) FileSize(path string) (int64, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.FileSize")
+	defer span.End()
+
+	result, err := a.app.FileSize(path)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) FileStorageMigrationBackends() (filestore.FileBackend, filestore.FileBackend, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.FileStorageMigrationBackends")
+	defer span.End()
+
+	result, resultVar1, err := a.app.FileStorageMigrationBackends()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, resultVar1, err
+}
+
+func (a *TracingAppLayer) FillInChannelProps(rctx request.CTX, channel *model.Channel) *model.AppError {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.FillInChannelProps")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.FillInChannelProps(rctx, channel)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) FillInChannelsProps(rctx request.CTX, channelList model.ChannelList) *model.AppError {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.FillInChannelsProps")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.FillInChannelsProps(rctx, channelList)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) FillInPostProps(rctx request.CTX, post *model.Post, channel *model.Channel) *model.AppError {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.FillInPostProps")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.FillInPostProps(rctx, post, channel)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) FilterNonGroupChannelMembers(rctx request.CTX, userIDs []string, channel *model.Channel) ([]string, error) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.FilterNonGroupChannelMembers")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.FilterNonGroupChannelMembers(rctx, userIDs, channel)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) FilterNonGroupTeamMembers(rctx request.CTX, userIDs []string, team *model.Team) ([]string, error) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.FilterNonGroupTeamMembers")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.FilterNonGroupTeamMembers(rctx, userIDs, team)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) FilterUsersByVisible(rctx request.CTX, viewer *model.User, otherUsers []*model.User) ([]*model.User, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.FilterUsersByVisible")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.FilterUsersByVisible(rctx, viewer, otherUsers)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) FindTeamByName(name string) bool {
+	_, span := tracing.Start(a.ctx, "App.FindTeamByName")
+	defer span.End()
+
+	result := a.app.FindTeamByName(name)
+	return result
+}
+
+func (a *TracingAppLayer) FinishSendAdminNotifyPost(rctx request.CTX, trial bool, now int64, pluginBasedData map[string][]*model.NotifyAdminData) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.FinishSendAdminNotifyPost")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	a.app.FinishSendAdminNotifyPost(rctx, trial, now, pluginBasedData)
+}
+
+func (a *TracingAppLayer) FlagPost(rctx request.CTX, post *model.Post, teamId string, reportingUserId string, flagData model.FlagContentRequest) *model.AppError {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.FlagPost")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.FlagPost(rctx, post, teamId, reportingUserId, flagData)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) FormatMessage(c request.CTX, req *app.FormattingRequest) (*app.FormattingResponse, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(c, a.ctx), "App.FormatMessage")
+	if c != nil {
+		c = c.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.FormatMessage(c, req)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GenerateAndSaveDesktopToken(createAt int64, user *model.User) (*string, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GenerateAndSaveDesktopToken")
+	defer span.End()
+
+	result, err := a.app.GenerateAndSaveDesktopToken(createAt, user)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GenerateMfaSecret(userID string) (*model.MfaSecret, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GenerateMfaSecret")
+	defer span.End()
+
+	result, err := a.app.GenerateMfaSecret(userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GeneratePresignURLForExport(name string) (*model.PresignURLResponse, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GeneratePresignURLForExport")
+	defer span.End()
+
+	result, err := a.app.GeneratePresignURLForExport(name)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GeneratePublicLink(siteURL string, info *model.FileInfo) string {
+	_, span := tracing.Start(a.ctx, "App.GeneratePublicLink")
+	defer span.End()
+
+	result := a.app.GeneratePublicLink(siteURL, info)
+	return result
+}
+
+func (a *TracingAppLayer) GenerateSupportPacket(rctx request.CTX, options *model.SupportPacketOptions) []model.FileData {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.GenerateSupportPacket")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result := a.app.GenerateSupportPacket(rctx, options)
+	return result
+}
+
+func (a *TracingAppLayer) GetAIMaxMessageLimit() int {
+	return a.app.GetAIMaxMessageLimit()
+}
+
+func (a *TracingAppLayer) GetAIModel() string {
+	return a.app.GetAIModel()
+}
+
+func (a *TracingAppLayer) GetAIRateLimit() int {
+	return a.app.GetAIRateLimit()
+}
+
+func (a *TracingAppLayer) GetAIService() *app.AIService {
+	return a.app.GetAIService()
+}
+
+func (a *TracingAppLayer) GetAccessControlFieldsAutocomplete(rctx request.CTX, after string, limit int) ([]*model.PropertyField, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.GetAccessControlFieldsAutocomplete")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.GetAccessControlFieldsAutocomplete(rctx, after, limit)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetAccessControlPolicy(rctx request.CTX, id string) (*model.AccessControlPolicy, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.GetAccessControlPolicy")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.GetAccessControlPolicy(rctx, id)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetAccessControlPolicyAttributes(rctx request.CTX, channelID string, action string) (map[string][]string, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.GetAccessControlPolicyAttributes")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.GetAccessControlPolicyAttributes(rctx, channelID, action)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetAcknowledgementsForPost(postID string) ([]*model.PostAcknowledgement, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetAcknowledgementsForPost")
+	defer span.End()
+
+	result, err := a.app.GetAcknowledgementsForPost(postID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetAcknowledgementsForPostList(postList *model.PostList) (map[string][]*model.PostAcknowledgement, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetAcknowledgementsForPostList")
+	defer span.End()
+
+	result, err := a.app.GetAcknowledgementsForPostList(postList)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetActionItem(c request.CTX, actionItemID string, userID string) (*model.AIActionItem, error) {
+	ctx, span := tracing.Start(requestContext(c, a.ctx), "App.GetActionItem")
+	if c != nil {
+		c = c.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.GetActionItem(c, actionItemID, userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetActionItemStats(c request.CTX, userID string) (*app.ActionItemStats, error) {
+	ctx, span := tracing.Start(requestContext(c, a.ctx), "App.GetActionItemStats")
+	if c != nil {
+		c = c.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.GetActionItemStats(c, userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetActionItemsForChannel(c request.CTX, channelID string, userID string, filters *app.ActionItemFilters) ([]*model.AIActionItem, error) {
+	ctx, span := tracing.Start(requestContext(c, a.ctx), "App.GetActionItemsForChannel")
+	if c != nil {
+		c = c.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.GetActionItemsForChannel(c, channelID, userID, filters)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetActionItemsForUser(c request.CTX, userID string, filters *app.ActionItemFilters) ([]*model.AIActionItem, error) {
+	ctx, span := tracing.Start(requestContext(c, a.ctx), "App.GetActionItemsForUser")
+	if c != nil {
+		c = c.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.GetActionItemsForUser(c, userID, filters)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetActivePluginManifests() ([]*model.Manifest, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetActivePluginManifests")
+	defer span.End()
+
+	result, err := a.app.GetActivePluginManifests()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetAgents(rctx request.CTX, userID string) ([]agentclient.BridgeAgentInfo, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.GetAgents")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.GetAgents(rctx, userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetAllChannels(rctx request.CTX, page int, perPage int, opts model.ChannelSearchOpts) (model.ChannelListWithTeamData, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.GetAllChannels")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.GetAllChannels(rctx, page, perPage, opts)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetAllChannelsCount(rctx request.CTX, opts model.ChannelSearchOpts) (int64, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.GetAllChannelsCount")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.GetAllChannelsCount(rctx, opts)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetAllLdapGroupsPage(rctx request.CTX, page int, perPage int, opts model.LdapGroupSearchOpts) ([]*model.Group, int, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.GetAllLdapGroupsPage")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, resultVar1, err := a.app.GetAllLdapGroupsPage(rctx, page, perPage, opts)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, resultVar1, err
+}
+
+func (a *TracingAppLayer) GetAllPrivateTeams() ([]*model.Team, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetAllPrivateTeams")
+	defer span.End()
+
+	result, err := a.app.GetAllPrivateTeams()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetAllPublicTeams() ([]*model.Team, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetAllPublicTeams")
+	defer span.End()
+
+	result, err := a.app.GetAllPublicTeams()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetAllRemoteClusters(page int, perPage int, filter model.RemoteClusterQueryFilter) ([]*model.RemoteCluster, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetAllRemoteClusters")
+	defer span.End()
+
+	result, err := a.app.GetAllRemoteClusters(page, perPage, filter)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetAllRoles() ([]*model.Role, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetAllRoles")
+	defer span.End()
+
+	result, err := a.app.GetAllRoles()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetAllTeams() ([]*model.Team, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetAllTeams")
+	defer span.End()
+
+	result, err := a.app.GetAllTeams()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetAllTeamsPage(offset int, limit int, opts *model.TeamSearch) ([]*model.Team, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetAllTeamsPage")
+	defer span.End()
+
+	result, err := a.app.GetAllTeamsPage(offset, limit, opts)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetAllTeamsPageWithCount(offset int, limit int, opts *model.TeamSearch) (*model.TeamsWithCount, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetAllTeamsPageWithCount")
+	defer span.End()
+
+	result, err := a.app.GetAllTeamsPageWithCount(offset, limit, opts)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetAnalytics(rctx request.CTX, name string, teamID string) (model.AnalyticsRows, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.GetAnalytics")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.GetAnalytics(rctx, name, teamID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetAnalyticsForSupportPacket(rctx request.CTX) (model.AnalyticsRows, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.GetAnalyticsForSupportPacket")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.GetAnalyticsForSupportPacket(rctx)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetAppliedSchemaMigrations() ([]model.AppliedMigration, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetAppliedSchemaMigrations")
+	defer span.End()
+
+	result, err := a.app.GetAppliedSchemaMigrations()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetAudits(rctx request.CTX, userID string, limit int) (model.Audits, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.GetAudits")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.GetAudits(rctx, userID, limit)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetAuditsPage(rctx request.CTX, userID string, page int, perPage int) (model.Audits, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.GetAuditsPage")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.GetAuditsPage(rctx, userID, page, perPage)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetAuthorizationCode(rctx request.CTX, w http.ResponseWriter, r *http.Request, service string, props map[string]string, loginHint string) (string, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.GetAuthorizationCode")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.GetAuthorizationCode(rctx, w, r, service, props, loginHint)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetAuthorizationServerMetadata(rctx request.CTX) (*model.AuthorizationServerMetadata, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.GetAuthorizationServerMetadata")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.GetAuthorizationServerMetadata(rctx)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetAuthorizedAppsForUser(userID string, page int, perPage int) ([]*model.OAuthApp, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetAuthorizedAppsForUser")
+	defer span.End()
+
+	result, err := a.app.GetAuthorizedAppsForUser(userID, page, perPage)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetBookmark(bookmarkId string, includeDeleted bool) (*model.ChannelBookmarkWithFileInfo, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetBookmark")
+	defer span.End()
+
+	result, err := a.app.GetBookmark(bookmarkId, includeDeleted)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetBot(rctx request.CTX, botUserId string, includeDeleted bool) (*model.Bot, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.GetBot")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.GetBot(rctx, botUserId, includeDeleted)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetBots(rctx request.CTX, options *model.BotGetOptions) (model.BotList, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.GetBots")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.GetBots(rctx, options)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetBrandImage(rctx request.CTX) ([]byte, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.GetBrandImage")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.GetBrandImage(rctx)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetBulkReactionsForPosts(postIDs []string) (map[string][]*model.Reaction, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetBulkReactionsForPosts")
+	defer span.End()
+
+	result, err := a.app.GetBulkReactionsForPosts(postIDs)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetCPAField(fieldID string) (*model.CPAField, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetCPAField")
+	defer span.End()
+
+	result, err := a.app.GetCPAField(fieldID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetCPAValue(valueID string) (*model.PropertyValue, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetCPAValue")
+	defer span.End()
+
+	result, err := a.app.GetCPAValue(valueID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetChannel(rctx request.CTX, channelID string) (*model.Channel, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.GetChannel")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.GetChannel(rctx, channelID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetChannelBookmarks(channelId string, since int64) ([]*model.ChannelBookmarkWithFileInfo, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetChannelBookmarks")
+	defer span.End()
+
+	result, err := a.app.GetChannelBookmarks(channelId, since)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetChannelByName(rctx request.CTX, channelName string, teamID string, includeDeleted bool) (*model.Channel, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.GetChannelByName")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.GetChannelByName(rctx, channelName, teamID, includeDeleted)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetChannelByNameForTeamName(rctx request.CTX, channelName string, teamName string, includeDeleted bool) (*model.Channel, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.GetChannelByNameForTeamName")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.GetChannelByNameForTeamName(rctx, channelName, teamName, includeDeleted)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetChannelCounts(rctx request.CTX, teamID string, userID string) (*model.ChannelCounts, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.GetChannelCounts")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.GetChannelCounts(rctx, teamID, userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetChannelFileCount(rctx request.CTX, channelID string) (int64, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.GetChannelFileCount")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.GetChannelFileCount(rctx, channelID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetChannelGroupUsers(channelID string) ([]*model.User, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetChannelGroupUsers")
+	defer span.End()
+
+	result, err := a.app.GetChannelGroupUsers(channelID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetChannelGuestCount(rctx request.CTX, channelID string) (int64, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.GetChannelGuestCount")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.GetChannelGuestCount(rctx, channelID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetChannelMember(rctx request.CTX, channelID string, userID string) (*model.ChannelMember, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.GetChannelMember")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.GetChannelMember(rctx, channelID, userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetChannelMemberCount(rctx request.CTX, channelID string) (int64, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.GetChannelMemberCount")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.GetChannelMemberCount(rctx, channelID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetChannelMembersByIds(rctx request.CTX, channelID string, userIDs []string) (model.ChannelMembers, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.GetChannelMembersByIds")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.GetChannelMembersByIds(rctx, channelID, userIDs)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetChannelMembersForUser(rctx request.CTX, teamID string, userID string) (model.ChannelMembers, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.GetChannelMembersForUser")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.GetChannelMembersForUser(rctx, teamID, userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetChannelMembersForUserWithPagination(rctx request.CTX, userID string, page int, perPage int) ([]*model.ChannelMember, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.GetChannelMembersForUserWithPagination")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.GetChannelMembersForUserWithPagination(rctx, userID, page, perPage)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetChannelMembersPage(rctx request.CTX, channelID string, page int, perPage int) (model.ChannelMembers, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.GetChannelMembersPage")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.GetChannelMembersPage(rctx, channelID, page, perPage)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetChannelMembersTimezones(rctx request.CTX, channelID string) ([]string, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.GetChannelMembersTimezones")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.GetChannelMembersTimezones(rctx, channelID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetChannelMembersWithTeamDataForUserWithPagination(rctx request.CTX, userID string, cursor *model.ChannelMemberCursor) (model.ChannelMembersWithTeamData, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.GetChannelMembersWithTeamDataForUserWithPagination")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.GetChannelMembersWithTeamDataForUserWithPagination(rctx, userID, cursor)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetChannelModerationsForChannel(rctx request.CTX, channel *model.Channel) ([]*model.ChannelModeration, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.GetChannelModerationsForChannel")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.GetChannelModerationsForChannel(rctx, channel)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetChannelPinnedPostCount(rctx request.CTX, channelID string) (int64, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.GetChannelPinnedPostCount")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.GetChannelPinnedPostCount(rctx, channelID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetChannelPoliciesForUser(userID string, offset int, limit int) (*model.RetentionPolicyForChannelList, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetChannelPoliciesForUser")
+	defer span.End()
+
+	result, err := a.app.GetChannelPoliciesForUser(userID, offset, limit)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetChannelUnread(rctx request.CTX, channelID string, userID string) (*model.ChannelUnread, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.GetChannelUnread")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.GetChannelUnread(rctx, channelID, userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetChannels(rctx request.CTX, channelIDs []string) ([]*model.Channel, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.GetChannels")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.GetChannels(rctx, channelIDs)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetChannelsByNames(rctx request.CTX, channelNames []string, teamID string) ([]*model.Channel, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.GetChannelsByNames")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.GetChannelsByNames(rctx, channelNames, teamID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetChannelsForPolicy(rctx request.CTX, policyID string, cursor model.AccessControlPolicyCursor, limit int) ([]*model.ChannelWithTeamData, int64, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.GetChannelsForPolicy")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, resultVar1, err := a.app.GetChannelsForPolicy(rctx, policyID, cursor, limit)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, resultVar1, err
+}
+
+func (a *TracingAppLayer) GetChannelsForRetentionPolicy(policyID string, offset int, limit int) (*model.ChannelsWithCount, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetChannelsForRetentionPolicy")
+	defer span.End()
+
+	result, err := a.app.GetChannelsForRetentionPolicy(policyID, offset, limit)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetChannelsForScheme(scheme *model.Scheme, offset int, limit int) (model.ChannelList, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetChannelsForScheme")
+	defer span.End()
+
+	result, err := a.app.GetChannelsForScheme(scheme, offset, limit)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetChannelsForSchemePage(scheme *model.Scheme, page int, perPage int) (model.ChannelList, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetChannelsForSchemePage")
+	defer span.End()
+
+	result, err := a.app.GetChannelsForSchemePage(scheme, page, perPage)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetChannelsForTeamForUser(rctx request.CTX, teamID string, userID string, opts *model.ChannelSearchOpts) (model.ChannelList, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.GetChannelsForTeamForUser")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.GetChannelsForTeamForUser(rctx, teamID, userID, opts)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetChannelsForUser(rctx request.CTX, userID string, includeDeleted bool, lastDeleteAt int, pageSize int, fromChannelID string) (model.ChannelList, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.GetChannelsForUser")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.GetChannelsForUser(rctx, userID, includeDeleted, lastDeleteAt, pageSize, fromChannelID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetChannelsMemberCount(rctx request.CTX, channelIDs []string) (map[string]int64, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.GetChannelsMemberCount")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.GetChannelsMemberCount(rctx, channelIDs)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetChannelsUserNotIn(rctx request.CTX, teamID string, userID string, offset int, limit int) (model.ChannelList, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.GetChannelsUserNotIn")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.GetChannelsUserNotIn(rctx, teamID, userID, offset, limit)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetCloudSession(token string) (*model.Session, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetCloudSession")
+	defer span.End()
+
+	result, err := a.app.GetCloudSession(token)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetClusterId() string {
+	return a.app.GetClusterId()
+}
+
+func (a *TracingAppLayer) GetClusterPluginStatuses() (model.PluginStatuses, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetClusterPluginStatuses")
+	defer span.End()
+
+	result, err := a.app.GetClusterPluginStatuses()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetClusterStatus(rctx request.CTX) ([]*model.ClusterInfo, error) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.GetClusterStatus")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.GetClusterStatus(rctx)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetCommand(commandID string) (*model.Command, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetCommand")
+	defer span.End()
+
+	result, err := a.app.GetCommand(commandID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetCommonTeamIDsForTwoUsers(userID string, otherUserID string) ([]string, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetCommonTeamIDsForTwoUsers")
+	defer span.End()
+
+	result, err := a.app.GetCommonTeamIDsForTwoUsers(userID, otherUserID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetComplianceFile(job *model.Compliance) ([]byte, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetComplianceFile")
+	defer span.End()
+
+	result, err := a.app.GetComplianceFile(job)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetComplianceReport(reportId string) (*model.Compliance, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetComplianceReport")
+	defer span.End()
+
+	result, err := a.app.GetComplianceReport(reportId)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetComplianceReports(page int, perPage int) (model.Compliances, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetComplianceReports")
+	defer span.End()
+
+	result, err := a.app.GetComplianceReports(page, perPage)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetConfigFile(name string) ([]byte, error) {
+	_, span := tracing.Start(a.ctx, "App.GetConfigFile")
+	defer span.End()
+
+	result, err := a.app.GetConfigFile(name)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetConfigHistory() ([]*model.ConfigVersion, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetConfigHistory")
+	defer span.End()
+
+	result, err := a.app.GetConfigHistory()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetConfigVersion(id string) (*model.Config, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetConfigVersion")
+	defer span.End()
+
+	result, err := a.app.GetConfigVersion(id)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetContentFlaggingConfigReviewerIDs() (*model.ReviewerIDsSettings, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetContentFlaggingConfigReviewerIDs")
+	defer span.End()
+
+	result, err := a.app.GetContentFlaggingConfigReviewerIDs()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetContentFlaggingMappedFields(groupId string) (map[string]*model.PropertyField, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetContentFlaggingMappedFields")
+	defer span.End()
+
+	result, err := a.app.GetContentFlaggingMappedFields(groupId)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetCookieDomain() string {
+	return a.app.GetCookieDomain()
+}
+
+func (a *TracingAppLayer) GetCustomStatus(userID string) (*model.CustomStatus, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetCustomStatus")
+	defer span.End()
+
+	result, err := a.app.GetCustomStatus(userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetDefaultProfileImage(user *model.User) ([]byte, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetDefaultProfileImage")
+	defer span.End()
+
+	result, err := a.app.GetDefaultProfileImage(user)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetDeletedChannels(rctx request.CTX, teamID string, offset int, limit int, userID string, skipTeamMembershipCheck bool) (model.ChannelList, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.GetDeletedChannels")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.GetDeletedChannels(rctx, teamID, offset, limit, userID, skipTeamMembershipCheck)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetDirectOrGroupMessageMembersCommonTeams(rctx request.CTX, channelID string) ([]*model.Team, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.GetDirectOrGroupMessageMembersCommonTeams")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.GetDirectOrGroupMessageMembersCommonTeams(rctx, channelID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetDraft(userID string, channelID string, rootID string) (*model.Draft, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetDraft")
+	defer span.End()
+
+	result, err := a.app.GetDraft(userID, channelID, rootID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetDraftsForUser(rctx request.CTX, userID string, teamID string) ([]*model.Draft, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.GetDraftsForUser")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.GetDraftsForUser(rctx, userID, teamID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetDueSoonActionItems(c request.CTX, hours int) ([]*model.AIActionItem, error) {
+	ctx, span := tracing.Start(requestContext(c, a.ctx), "App.GetDueSoonActionItems")
+	if c != nil {
+		c = c.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.GetDueSoonActionItems(c, hours)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetEditHistoryForPost(postID string) ([]*model.Post, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetEditHistoryForPost")
+	defer span.End()
+
+	result, err := a.app.GetEditHistoryForPost(postID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetEmoji(rctx request.CTX, emojiId string) (*model.Emoji, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.GetEmoji")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.GetEmoji(rctx, emojiId)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetEmojiByName(rctx request.CTX, emojiName string) (*model.Emoji, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.GetEmojiByName")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.GetEmojiByName(rctx, emojiName)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetEmojiImage(rctx request.CTX, emojiId string) ([]byte, string, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.GetEmojiImage")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, resultVar1, err := a.app.GetEmojiImage(rctx, emojiId)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, resultVar1, err
+}
+
+func (a *TracingAppLayer) GetEmojiList(rctx request.CTX, page int, perPage int, sort string) ([]*model.Emoji, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.GetEmojiList")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.GetEmojiList(rctx, page, perPage, sort)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetEmojiStaticURL(rctx request.CTX, emojiName string) (string, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.GetEmojiStaticURL")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.GetEmojiStaticURL(rctx, emojiName)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetEnvironmentConfig(filter func(reflect.StructField) bool) map[string]any {
+	_, span := tracing.Start(a.ctx, "App.GetEnvironmentConfig")
+	defer span.End()
+
+	result := a.app.GetEnvironmentConfig(filter)
+	return result
+}
+
+func (a *TracingAppLayer) GetEventSubscription(id string) (*model.EventSubscription, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetEventSubscription")
+	defer span.End()
+
+	result, err := a.app.GetEventSubscription(id)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetEventSubscriptionsPage(teamID string, page int, perPage int) ([]*model.EventSubscription, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetEventSubscriptionsPage")
+	defer span.End()
+
+	result, err := a.app.GetEventSubscriptionsPage(teamID, page, perPage)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetFile(rctx request.CTX, fileID string) ([]byte, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.GetFile")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.GetFile(rctx, fileID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetFileInfo(rctx request.CTX, fileID string) (*model.FileInfo, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.GetFileInfo")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.GetFileInfo(rctx, fileID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetFileInfos(rctx request.CTX, page int, perPage int, opt *model.GetFileInfosOptions) ([]*model.FileInfo, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.GetFileInfos")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.GetFileInfos(rctx, page, perPage, opt)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetFileInfosForPost(rctx request.CTX, postID string, fromMaster bool, includeDeleted bool) ([]*model.FileInfo, int64, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.GetFileInfosForPost")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, resultVar1, err := a.app.GetFileInfosForPost(rctx, postID, fromMaster, includeDeleted)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, resultVar1, err
+}
+
+func (a *TracingAppLayer) GetFileInfosForPostWithMigration(rctx request.CTX, postID string, includeDeleted bool) ([]*model.FileInfo, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.GetFileInfosForPostWithMigration")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.GetFileInfosForPostWithMigration(rctx, postID, includeDeleted)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetFilteredUsersStats(options *model.UserCountOptions) (*model.UsersStats, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetFilteredUsersStats")
+	defer span.End()
+
+	result, err := a.app.GetFilteredUsersStats(options)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetFlaggedPosts(userID string, offset int, limit int) (*model.PostList, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetFlaggedPosts")
+	defer span.End()
+
+	result, err := a.app.GetFlaggedPosts(userID, offset, limit)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetFlaggedPostsForChannel(userID string, channelID string, offset int, limit int) (*model.PostList, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetFlaggedPostsForChannel")
+	defer span.End()
+
+	result, err := a.app.GetFlaggedPostsForChannel(userID, channelID, offset, limit)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetFlaggedPostsForTeam(userID string, teamID string, offset int, limit int) (*model.PostList, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetFlaggedPostsForTeam")
+	defer span.End()
+
+	result, err := a.app.GetFlaggedPostsForTeam(userID, teamID, offset, limit)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetFormattingProfiles(c request.CTX) ([]app.FormattingProfileInfo, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(c, a.ctx), "App.GetFormattingProfiles")
+	if c != nil {
+		c = c.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.GetFormattingProfiles(c)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetGlobalRetentionPolicy() (*model.GlobalRetentionPolicy, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetGlobalRetentionPolicy")
+	defer span.End()
+
+	result, err := a.app.GetGlobalRetentionPolicy()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetGroup(id string, opts *model.GetGroupOpts, viewRestrictions *model.ViewUsersRestrictions) (*model.Group, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetGroup")
+	defer span.End()
+
+	result, err := a.app.GetGroup(id, opts, viewRestrictions)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetGroupByName(name string, opts model.GroupSearchOpts) (*model.Group, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetGroupByName")
+	defer span.End()
+
+	result, err := a.app.GetGroupByName(name, opts)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetGroupByRemoteID(remoteID string, groupSource model.GroupSource) (*model.Group, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetGroupByRemoteID")
+	defer span.End()
+
+	result, err := a.app.GetGroupByRemoteID(remoteID, groupSource)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetGroupChannel(rctx request.CTX, userIDs []string) (*model.Channel, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.GetGroupChannel")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.GetGroupChannel(rctx, userIDs)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetGroupMemberCount(groupID string, viewRestrictions *model.ViewUsersRestrictions) (int64, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetGroupMemberCount")
+	defer span.End()
+
+	result, err := a.app.GetGroupMemberCount(groupID, viewRestrictions)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetGroupMemberUsers(groupID string) ([]*model.User, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetGroupMemberUsers")
+	defer span.End()
+
+	result, err := a.app.GetGroupMemberUsers(groupID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetGroupMemberUsersPage(groupID string, page int, perPage int, viewRestrictions *model.ViewUsersRestrictions) ([]*model.User, int, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetGroupMemberUsersPage")
+	defer span.End()
+
+	result, resultVar1, err := a.app.GetGroupMemberUsersPage(groupID, page, perPage, viewRestrictions)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, resultVar1, err
+}
+
+func (a *TracingAppLayer) GetGroupMemberUsersSortedPage(groupID string, page int, perPage int, viewRestrictions *model.ViewUsersRestrictions, teammateNameDisplay string) ([]*model.User, int, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetGroupMemberUsersSortedPage")
+	defer span.End()
+
+	result, resultVar1, err := a.app.GetGroupMemberUsersSortedPage(groupID, page, perPage, viewRestrictions, teammateNameDisplay)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, resultVar1, err
+}
+
+func (a *TracingAppLayer) GetGroupSyncable(groupID string, syncableID string, syncableType model.GroupSyncableType) (*model.GroupSyncable, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetGroupSyncable")
+	defer span.End()
+
+	result, err := a.app.GetGroupSyncable(groupID, syncableID, syncableType)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetGroupSyncables(groupID string, syncableType model.GroupSyncableType) ([]*model.GroupSyncable, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetGroupSyncables")
+	defer span.End()
+
+	result, err := a.app.GetGroupSyncables(groupID, syncableType)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetGroups(page int, perPage int, opts model.GroupSearchOpts, viewRestrictions *model.ViewUsersRestrictions) ([]*model.Group, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetGroups")
+	defer span.End()
+
+	result, err := a.app.GetGroups(page, perPage, opts, viewRestrictions)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetGroupsAssociatedToChannelsByTeam(teamID string, opts model.GroupSearchOpts) (map[string][]*model.GroupWithSchemeAdmin, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetGroupsAssociatedToChannelsByTeam")
+	defer span.End()
+
+	result, err := a.app.GetGroupsAssociatedToChannelsByTeam(teamID, opts)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetGroupsByChannel(channelID string, opts model.GroupSearchOpts) ([]*model.GroupWithSchemeAdmin, int, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetGroupsByChannel")
+	defer span.End()
+
+	result, resultVar1, err := a.app.GetGroupsByChannel(channelID, opts)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, resultVar1, err
+}
+
+func (a *TracingAppLayer) GetGroupsByIDs(groupIDs []string) ([]*model.Group, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetGroupsByIDs")
+	defer span.End()
+
+	result, err := a.app.GetGroupsByIDs(groupIDs)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetGroupsByNames(names []string, restrictions *model.ViewUsersRestrictions) ([]*model.Group, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetGroupsByNames")
+	defer span.End()
+
+	result, err := a.app.GetGroupsByNames(names, restrictions)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetGroupsBySource(groupSource model.GroupSource) ([]*model.Group, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetGroupsBySource")
+	defer span.End()
+
+	result, err := a.app.GetGroupsBySource(groupSource)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetGroupsByTeam(teamID string, opts model.GroupSearchOpts) ([]*model.GroupWithSchemeAdmin, int, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetGroupsByTeam")
+	defer span.End()
+
+	result, resultVar1, err := a.app.GetGroupsByTeam(teamID, opts)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, resultVar1, err
+}
+
+func (a *TracingAppLayer) GetGroupsByUserId(userID string, opts model.GroupSearchOpts) ([]*model.Group, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetGroupsByUserId")
+	defer span.End()
+
+	result, err := a.app.GetGroupsByUserId(userID, opts)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetHubForUserId(userID string) *platform.Hub {
+	_, span := tracing.Start(a.ctx, "App.GetHubForUserId")
+	defer span.End()
+
+	result := a.app.GetHubForUserId(userID)
+	return result
+}
+
+func (a *TracingAppLayer) GetIncomingWebhook(hookID string) (*model.IncomingWebhook, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetIncomingWebhook")
+	defer span.End()
+
+	result, err := a.app.GetIncomingWebhook(hookID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetIncomingWebhooksCount(teamID string, userID string) (int64, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetIncomingWebhooksCount")
+	defer span.End()
+
+	result, err := a.app.GetIncomingWebhooksCount(teamID, userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetIncomingWebhooksForTeamPage(teamID string, page int, perPage int) ([]*model.IncomingWebhook, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetIncomingWebhooksForTeamPage")
+	defer span.End()
+
+	result, err := a.app.GetIncomingWebhooksForTeamPage(teamID, page, perPage)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetIncomingWebhooksForTeamPageByUser(teamID string, userID string, page int, perPage int) ([]*model.IncomingWebhook, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetIncomingWebhooksForTeamPageByUser")
+	defer span.End()
+
+	result, err := a.app.GetIncomingWebhooksForTeamPageByUser(teamID, userID, page, perPage)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetIncomingWebhooksPage(page int, perPage int) ([]*model.IncomingWebhook, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetIncomingWebhooksPage")
+	defer span.End()
+
+	result, err := a.app.GetIncomingWebhooksPage(page, perPage)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetIncomingWebhooksPageByUser(userID string, page int, perPage int) ([]*model.IncomingWebhook, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetIncomingWebhooksPageByUser")
+	defer span.End()
+
+	result, err := a.app.GetIncomingWebhooksPageByUser(userID, page, perPage)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetJob(rctx request.CTX, id string) (*model.Job, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.GetJob")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.GetJob(rctx, id)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetJobsByTypePage(rctx request.CTX, jobType string, page int, perPage int) ([]*model.Job, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.GetJobsByTypePage")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.GetJobsByTypePage(rctx, jobType, page, perPage)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetJobsByTypesAndStatuses(rctx request.CTX, jobTypes []string, status []string, page int, perPage int) ([]*model.Job, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.GetJobsByTypesAndStatuses")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.GetJobsByTypesAndStatuses(rctx, jobTypes, status, page, perPage)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetJobsByTypesPage(rctx request.CTX, jobType []string, page int, perPage int) ([]*model.Job, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.GetJobsByTypesPage")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.GetJobsByTypesPage(rctx, jobType, page, perPage)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetKnownUsers(userID string) ([]string, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetKnownUsers")
+	defer span.End()
+
+	result, err := a.app.GetKnownUsers(userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetLLMServices(rctx request.CTX, userID string) ([]agentclient.BridgeServiceInfo, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.GetLLMServices")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.GetLLMServices(rctx, userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetLRUSessions(rctx request.CTX, userID string, limit uint64, offset uint64) ([]*model.Session, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.GetLRUSessions")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.GetLRUSessions(rctx, userID, limit, offset)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetLastAccessibleFileTime() (int64, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetLastAccessibleFileTime")
+	defer span.End()
+
+	result, err := a.app.GetLastAccessibleFileTime()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetLastAccessiblePostTime() (int64, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetLastAccessiblePostTime")
+	defer span.End()
+
+	result, err := a.app.GetLastAccessiblePostTime()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetLatestTermsOfService() (*model.TermsOfService, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetLatestTermsOfService")
+	defer span.End()
+
+	result, err := a.app.GetLatestTermsOfService()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetLatestVersion(rctx request.CTX, latestVersionUrl string) (*model.GithubReleaseInfo, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.GetLatestVersion")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.GetLatestVersion(rctx, latestVersionUrl)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetLdapGroup(rctx request.CTX, ldapGroupID string) (*model.Group, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.GetLdapGroup")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.GetLdapGroup(rctx, ldapGroupID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetLogs(rctx request.CTX, page int, perPage int) ([]string, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.GetLogs")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.GetLogs(rctx, page, perPage)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetLogsSkipSend(rctx request.CTX, page int, perPage int, logFilter *model.LogFilter) ([]string, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.GetLogsSkipSend")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.GetLogsSkipSend(rctx, page, perPage, logFilter)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetMarketplacePlugins(rctx request.CTX, filter *model.MarketplacePluginFilter) ([]*model.MarketplacePlugin, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.GetMarketplacePlugins")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.GetMarketplacePlugins(rctx, filter)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetMemberCountsByGroup(rctx request.CTX, channelID string, includeTimezones bool) ([]*model.ChannelMemberCountByGroup, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.GetMemberCountsByGroup")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.GetMemberCountsByGroup(rctx, channelID, includeTimezones)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetMessageForNotification(post *model.Post, teamName string, siteUrl string, translateFunc i18n.TranslateFunc) string {
+	_, span := tracing.Start(a.ctx, "App.GetMessageForNotification")
+	defer span.End()
+
+	result := a.app.GetMessageForNotification(post, teamName, siteUrl, translateFunc)
+	return result
+}
+
+func (a *TracingAppLayer) GetMultipleEmojiByName(rctx request.CTX, names []string) ([]*model.Emoji, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.GetMultipleEmojiByName")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.GetMultipleEmojiByName(rctx, names)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetNewUsersForTeamPage(rctx request.CTX, teamID string, page int, perPage int, asAdmin bool, viewRestrictions *model.ViewUsersRestrictions) ([]*model.User, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.GetNewUsersForTeamPage")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.GetNewUsersForTeamPage(rctx, teamID, page, perPage, asAdmin, viewRestrictions)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetNextPostIdFromPostList(postList *model.PostList, collapsedThreads bool) string {
+	_, span := tracing.Start(a.ctx, "App.GetNextPostIdFromPostList")
+	defer span.End()
+
+	result := a.app.GetNextPostIdFromPostList(postList, collapsedThreads)
+	return result
+}
+
+func (a *TracingAppLayer) GetNotificationNameFormat(user *model.User) string {
+	_, span := tracing.Start(a.ctx, "App.GetNotificationNameFormat")
+	defer span.End()
+
+	result := a.app.GetNotificationNameFormat(user)
+	return result
+}
+
+func (a *TracingAppLayer) GetNotificationRules(userID string) ([]*model.NotificationRule, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetNotificationRules")
+	defer span.End()
+
+	result, err := a.app.GetNotificationRules(userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetNumberOfChannelsOnTeam(rctx request.CTX, teamID string) (int, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.GetNumberOfChannelsOnTeam")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.GetNumberOfChannelsOnTeam(rctx, teamID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetOAuthAccessTokenForCodeFlow(rctx request.CTX, clientId string, grantType string, redirectURI string, code string, secret string, refreshToken string, codeVerifier string, resource string) (*model.AccessResponse, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.GetOAuthAccessTokenForCodeFlow")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.GetOAuthAccessTokenForCodeFlow(rctx, clientId, grantType, redirectURI, code, secret, refreshToken, codeVerifier, resource)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetOAuthAccessTokenForImplicitFlow(rctx request.CTX, userID string, authRequest *model.AuthorizeRequest) (*model.Session, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.GetOAuthAccessTokenForImplicitFlow")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.GetOAuthAccessTokenForImplicitFlow(rctx, userID, authRequest)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetOAuthApp(appID string) (*model.OAuthApp, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetOAuthApp")
+	defer span.End()
+
+	result, err := a.app.GetOAuthApp(appID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetOAuthApps(page int, perPage int) ([]*model.OAuthApp, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetOAuthApps")
+	defer span.End()
+
+	result, err := a.app.GetOAuthApps(page, perPage)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetOAuthAppsByCreator(userID string, page int, perPage int) ([]*model.OAuthApp, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetOAuthAppsByCreator")
+	defer span.End()
+
+	result, err := a.app.GetOAuthAppsByCreator(userID, page, perPage)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetOAuthCodeRedirect(userID string, authRequest *model.AuthorizeRequest) (string, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetOAuthCodeRedirect")
+	defer span.End()
+
+	result, err := a.app.GetOAuthCodeRedirect(userID, authRequest)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetOAuthImplicitRedirect(rctx request.CTX, userID string, authRequest *model.AuthorizeRequest) (string, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.GetOAuthImplicitRedirect")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.GetOAuthImplicitRedirect(rctx, userID, authRequest)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetOAuthLoginEndpoint(rctx request.CTX, w http.ResponseWriter, r *http.Request, service string, action string, redirectTo string, loginHint string, isMobile bool, desktopToken string, inviteToken string, inviteId string) (string, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.GetOAuthLoginEndpoint")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.GetOAuthLoginEndpoint(rctx, w, r, service, action, redirectTo, loginHint, isMobile, desktopToken, inviteToken, inviteId)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetOAuthSignupEndpoint(rctx request.CTX, w http.ResponseWriter, r *http.Request, service string, desktopToken string, inviteToken string, inviteId string) (string, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.GetOAuthSignupEndpoint")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.GetOAuthSignupEndpoint(rctx, w, r, service, desktopToken, inviteToken, inviteId)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetOAuthStateToken(token string) (*model.Token, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetOAuthStateToken")
+	defer span.End()
+
+	result, err := a.app.GetOAuthStateToken(token)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetOnboarding() (*model.System, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetOnboarding")
+	defer span.End()
+
+	result, err := a.app.GetOnboarding()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetOpenGraphMetadata(requestURL string) ([]byte, error) {
+	_, span := tracing.Start(a.ctx, "App.GetOpenGraphMetadata")
+	defer span.End()
+
+	result, err := a.app.GetOpenGraphMetadata(requestURL)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetOrCreateAIPreferences(userId string) (*model.AIPreferences, error) {
+	_, span := tracing.Start(a.ctx, "App.GetOrCreateAIPreferences")
+	defer span.End()
+
+	result, err := a.app.GetOrCreateAIPreferences(userId)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetOrCreateDirectChannel(rctx request.CTX, userID string, otherUserID string, channelOptions ...model.ChannelOption) (*model.Channel, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.GetOrCreateDirectChannel")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.GetOrCreateDirectChannel(rctx, userID, otherUserID, channelOptions...)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetOrCreateSystemOwnedBot(rctx request.CTX, botUsername string, botDisplayName string) (*model.Bot, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.GetOrCreateSystemOwnedBot")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.GetOrCreateSystemOwnedBot(rctx, botUsername, botDisplayName)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetOutgoingWebhook(hookID string) (*model.OutgoingWebhook, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetOutgoingWebhook")
+	defer span.End()
+
+	result, err := a.app.GetOutgoingWebhook(hookID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetOutgoingWebhooksForChannelPageByUser(channelID string, userID string, page int, perPage int) ([]*model.OutgoingWebhook, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetOutgoingWebhooksForChannelPageByUser")
+	defer span.End()
+
+	result, err := a.app.GetOutgoingWebhooksForChannelPageByUser(channelID, userID, page, perPage)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetOutgoingWebhooksForTeamPage(teamID string, page int, perPage int) ([]*model.OutgoingWebhook, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetOutgoingWebhooksForTeamPage")
+	defer span.End()
+
+	result, err := a.app.GetOutgoingWebhooksForTeamPage(teamID, page, perPage)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetOutgoingWebhooksForTeamPageByUser(teamID string, userID string, page int, perPage int) ([]*model.OutgoingWebhook, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetOutgoingWebhooksForTeamPageByUser")
+	defer span.End()
+
+	result, err := a.app.GetOutgoingWebhooksForTeamPageByUser(teamID, userID, page, perPage)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetOutgoingWebhooksPage(page int, perPage int) ([]*model.OutgoingWebhook, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetOutgoingWebhooksPage")
+	defer span.End()
+
+	result, err := a.app.GetOutgoingWebhooksPage(page, perPage)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetOutgoingWebhooksPageByUser(userID string, page int, perPage int) ([]*model.OutgoingWebhook, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetOutgoingWebhooksPageByUser")
+	defer span.End()
+
+	result, err := a.app.GetOutgoingWebhooksPageByUser(userID, page, perPage)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetOverdueActionItems(c request.CTX) ([]*model.AIActionItem, error) {
+	ctx, span := tracing.Start(requestContext(c, a.ctx), "App.GetOverdueActionItems")
+	if c != nil {
+		c = c.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.GetOverdueActionItems(c)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetPasswordRecoveryToken(token string) (*model.Token, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetPasswordRecoveryToken")
+	defer span.End()
+
+	result, err := a.app.GetPasswordRecoveryToken(token)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetPermalinkPost(rctx request.CTX, postID string, userID string) (*model.PostList, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.GetPermalinkPost")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.GetPermalinkPost(rctx, postID, userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetPinnedPosts(rctx request.CTX, channelID string) (*model.PostList, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.GetPinnedPosts")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.GetPinnedPosts(rctx, channelID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetPluginKey(pluginID string, key string) ([]byte, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetPluginKey")
+	defer span.End()
+
+	result, err := a.app.GetPluginKey(pluginID, key)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetPluginStatus(id string) (*model.PluginStatus, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetPluginStatus")
+	defer span.End()
+
+	result, err := a.app.GetPluginStatus(id)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetPluginStatuses() (model.PluginStatuses, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetPluginStatuses")
+	defer span.End()
+
+	result, err := a.app.GetPluginStatuses()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetPlugins() (*model.PluginsResponse, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetPlugins")
+	defer span.End()
+
+	result, err := a.app.GetPlugins()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetPluginsEnvironment() *plugin.Environment {
+	return a.app.GetPluginsEnvironment()
+}
+
+func (a *TracingAppLayer) GetPostAfterTime(channelID string, time int64, collapsedThreads bool) (*model.Post, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetPostAfterTime")
+	defer span.End()
+
+	result, err := a.app.GetPostAfterTime(channelID, time, collapsedThreads)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetPostContentFlaggingPropertyValue(postId string, propertyFieldName string) (*model.PropertyValue, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetPostContentFlaggingPropertyValue")
+	defer span.End()
+
+	result, err := a.app.GetPostContentFlaggingPropertyValue(postId, propertyFieldName)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetPostContentFlaggingPropertyValues(postId string) ([]*model.PropertyValue, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetPostContentFlaggingPropertyValues")
+	defer span.End()
+
+	result, err := a.app.GetPostContentFlaggingPropertyValues(postId)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetPostHistoryLimit() int64 {
+	return a.app.GetPostHistoryLimit()
+}
+
+func (a *TracingAppLayer) GetPostIdAfterTime(channelID string, time int64, collapsedThreads bool) (string, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetPostIdAfterTime")
+	defer span.End()
+
+	result, err := a.app.GetPostIdAfterTime(channelID, time, collapsedThreads)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetPostIdBeforeTime(channelID string, time int64, collapsedThreads bool) (string, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetPostIdBeforeTime")
+	defer span.End()
+
+	result, err := a.app.GetPostIdBeforeTime(channelID, time, collapsedThreads)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetPostIfAuthorized(rctx request.CTX, postID string, session *model.Session, includeDeleted bool) (*model.Post, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.GetPostIfAuthorized")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.GetPostIfAuthorized(rctx, postID, session, includeDeleted)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetPostInfo(rctx request.CTX, postID string) (*model.PostInfo, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.GetPostInfo")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.GetPostInfo(rctx, postID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetPostThread(rctx request.CTX, postID string, opts model.GetPostsOptions, userID string) (*model.PostList, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.GetPostThread")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.GetPostThread(rctx, postID, opts, userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetPosts(rctx request.CTX, channelID string, offset int, limit int) (*model.PostList, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.GetPosts")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.GetPosts(rctx, channelID, offset, limit)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetPostsAfterPost(rctx request.CTX, options model.GetPostsOptions) (*model.PostList, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.GetPostsAfterPost")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.GetPostsAfterPost(rctx, options)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetPostsAroundPost(rctx request.CTX, before bool, options model.GetPostsOptions) (*model.PostList, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.GetPostsAroundPost")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.GetPostsAroundPost(rctx, before, options)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetPostsBeforePost(rctx request.CTX, options model.GetPostsOptions) (*model.PostList, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.GetPostsBeforePost")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.GetPostsBeforePost(rctx, options)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetPostsByIds(postIDs []string) ([]*model.Post, int64, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetPostsByIds")
+	defer span.End()
+
+	result, resultVar1, err := a.app.GetPostsByIds(postIDs)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, resultVar1, err
+}
+
+func (a *TracingAppLayer) GetPostsEtag(channelID string, collapsedThreads bool) string {
+	_, span := tracing.Start(a.ctx, "App.GetPostsEtag")
+	defer span.End()
+
+	result := a.app.GetPostsEtag(channelID, collapsedThreads)
+	return result
+}
+
+func (a *TracingAppLayer) GetPostsForChannelAroundLastUnread(rctx request.CTX, channelID string, userID string, limitBefore int, limitAfter int, skipFetchThreads bool, collapsedThreads bool, collapsedThreadsExtended bool) (*model.PostList, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.GetPostsForChannelAroundLastUnread")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.GetPostsForChannelAroundLastUnread(rctx, channelID, userID, limitBefore, limitAfter, skipFetchThreads, collapsedThreads, collapsedThreadsExtended)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetPostsForReporting(rctx request.CTX, queryParams model.ReportPostQueryParams, includeMetadata bool) (*model.ReportPostListResponse, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.GetPostsForReporting")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.GetPostsForReporting(rctx, queryParams, includeMetadata)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetPostsPage(rctx request.CTX, options model.GetPostsOptions) (*model.PostList, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.GetPostsPage")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.GetPostsPage(rctx, options)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetPostsSince(rctx request.CTX, options model.GetPostsSinceOptions) (*model.PostList, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.GetPostsSince")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.GetPostsSince(rctx, options)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetPostsUsage() (int64, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetPostsUsage")
+	defer span.End()
+
+	result, err := a.app.GetPostsUsage()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetPreferenceByCategoryAndNameForUser(rctx request.CTX, userID string, category string, preferenceName string) (*model.Preference, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.GetPreferenceByCategoryAndNameForUser")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.GetPreferenceByCategoryAndNameForUser(rctx, userID, category, preferenceName)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetPreferenceByCategoryForUser(rctx request.CTX, userID string, category string) (model.Preferences, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.GetPreferenceByCategoryForUser")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.GetPreferenceByCategoryForUser(rctx, userID, category)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetPreferencesForUser(rctx request.CTX, userID string) (model.Preferences, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.GetPreferencesForUser")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.GetPreferencesForUser(rctx, userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetPrevPostIdFromPostList(postList *model.PostList, collapsedThreads bool) string {
+	_, span := tracing.Start(a.ctx, "App.GetPrevPostIdFromPostList")
+	defer span.End()
+
+	result := a.app.GetPrevPostIdFromPostList(postList, collapsedThreads)
+	return result
+}
+
+func (a *TracingAppLayer) GetPreviewModalData() ([]model.PreviewModalContentData, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetPreviewModalData")
+	defer span.End()
+
+	result, err := a.app.GetPreviewModalData()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetPriorityForPost(postId string) (*model.PostPriority, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetPriorityForPost")
+	defer span.End()
+
+	result, err := a.app.GetPriorityForPost(postId)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetPriorityForPostList(list *model.PostList) (map[string]*model.PostPriority, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetPriorityForPostList")
+	defer span.End()
+
+	result, err := a.app.GetPriorityForPostList(list)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetPrivateChannelsForTeam(rctx request.CTX, teamID string, offset int, limit int) (model.ChannelList, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.GetPrivateChannelsForTeam")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.GetPrivateChannelsForTeam(rctx, teamID, offset, limit)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetProductNotices(rctx request.CTX, userID string, teamID string, client model.NoticeClientType, clientVersion string, locale string) (model.NoticeMessages, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.GetProductNotices")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.GetProductNotices(rctx, userID, teamID, client, clientVersion, locale)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetProfileImage(user *model.User) ([]byte, bool, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetProfileImage")
+	defer span.End()
+
+	result, resultVar1, err := a.app.GetProfileImage(user)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, resultVar1, err
+}
+
+func (a *TracingAppLayer) GetProfileImagePath(user *model.User) (string, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetProfileImagePath")
+	defer span.End()
+
+	result, err := a.app.GetProfileImagePath(user)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetPublicChannelsByIdsForTeam(rctx request.CTX, teamID string, channelIDs []string) (model.ChannelList, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.GetPublicChannelsByIdsForTeam")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.GetPublicChannelsByIdsForTeam(rctx, teamID, channelIDs)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetPublicChannelsForTeam(rctx request.CTX, teamID string, offset int, limit int) (model.ChannelList, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.GetPublicChannelsForTeam")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.GetPublicChannelsForTeam(rctx, teamID, offset, limit)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetPublicKey(name string) ([]byte, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetPublicKey")
+	defer span.End()
+
+	result, err := a.app.GetPublicKey(name)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetRateLimitCounters(key string, ruleName string) ([]*model.RateLimitCounter, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetRateLimitCounters")
+	defer span.End()
+
+	result, err := a.app.GetRateLimitCounters(key, ruleName)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetReactionsForPost(postID string) ([]*model.Reaction, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetReactionsForPost")
+	defer span.End()
+
+	result, err := a.app.GetReactionsForPost(postID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetRecentlyActiveUsersForTeam(rctx request.CTX, teamID string) (map[string]*model.User, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.GetRecentlyActiveUsersForTeam")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.GetRecentlyActiveUsersForTeam(rctx, teamID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetRecentlyActiveUsersForTeamPage(rctx request.CTX, teamID string, page int, perPage int, asAdmin bool, viewRestrictions *model.ViewUsersRestrictions) ([]*model.User, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.GetRecentlyActiveUsersForTeamPage")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.GetRecentlyActiveUsersForTeamPage(rctx, teamID, page, perPage, asAdmin, viewRestrictions)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetRemoteCluster(remoteClusterId string, includeDeleted bool) (*model.RemoteCluster, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetRemoteCluster")
+	defer span.End()
+
+	result, err := a.app.GetRemoteCluster(remoteClusterId, includeDeleted)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetRemoteClusterForUser(remoteID string, userID string) (*model.RemoteCluster, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetRemoteClusterForUser")
+	defer span.End()
+
+	result, err := a.app.GetRemoteClusterForUser(remoteID, userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetRemoteClusterService() (remotecluster.RemoteClusterServiceIFace, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetRemoteClusterService")
+	defer span.End()
+
+	result, err := a.app.GetRemoteClusterService()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetRemoteClusterSession(token string, remoteId string) (*model.Session, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetRemoteClusterSession")
+	defer span.End()
+
+	result, err := a.app.GetRemoteClusterSession(token, remoteId)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetRetentionPolicies(offset int, limit int) (*model.RetentionPolicyWithTeamAndChannelCountsList, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetRetentionPolicies")
+	defer span.End()
+
+	result, err := a.app.GetRetentionPolicies(offset, limit)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetRetentionPoliciesCount() (int64, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetRetentionPoliciesCount")
+	defer span.End()
+
+	result, err := a.app.GetRetentionPoliciesCount()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetRetentionPolicy(policyID string) (*model.RetentionPolicyWithTeamAndChannelCounts, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetRetentionPolicy")
+	defer span.End()
+
+	result, err := a.app.GetRetentionPolicy(policyID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetRole(id string) (*model.Role, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetRole")
+	defer span.End()
+
+	result, err := a.app.GetRole(id)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetRoleByName(rctx request.CTX, name string) (*model.Role, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.GetRoleByName")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.GetRoleByName(rctx, name)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetRolesByNames(names []string) ([]*model.Role, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetRolesByNames")
+	defer span.End()
+
+	result, err := a.app.GetRolesByNames(names)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetSamlCertificateStatus() *model.SamlCertificateStatus {
+	return a.app.GetSamlCertificateStatus()
+}
+
+func (a *TracingAppLayer) GetSamlEmailToken(token string) (*model.Token, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetSamlEmailToken")
+	defer span.End()
+
+	result, err := a.app.GetSamlEmailToken(token)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetSamlMetadata(rctx request.CTX) (string, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.GetSamlMetadata")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.GetSamlMetadata(rctx)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetSamlMetadataFromIdp(idpMetadataURL string) (*model.SamlMetadataResponse, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetSamlMetadataFromIdp")
+	defer span.End()
+
+	result, err := a.app.GetSamlMetadataFromIdp(idpMetadataURL)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetSanitizeOptions(asAdmin bool) map[string]bool {
+	_, span := tracing.Start(a.ctx, "App.GetSanitizeOptions")
+	defer span.End()
+
+	result := a.app.GetSanitizeOptions(asAdmin)
+	return result
+}
+
+func (a *TracingAppLayer) GetSanitizedConfig() *model.Config {
+	return a.app.GetSanitizedConfig()
+}
+
+func (a *TracingAppLayer) GetScheme(id string) (*model.Scheme, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetScheme")
+	defer span.End()
+
+	result, err := a.app.GetScheme(id)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetSchemeByName(name string) (*model.Scheme, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetSchemeByName")
+	defer span.End()
+
+	result, err := a.app.GetSchemeByName(name)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetSchemeRolesForChannel(rctx request.CTX, channelID string) (string, string, string, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.GetSchemeRolesForChannel")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, resultVar1, resultVar2, err := a.app.GetSchemeRolesForChannel(rctx, channelID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, resultVar1, resultVar2, err
+}
+
+func (a *TracingAppLayer) GetSchemeRolesForTeam(teamID string) (string, string, string, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetSchemeRolesForTeam")
+	defer span.End()
+
+	result, resultVar1, resultVar2, err := a.app.GetSchemeRolesForTeam(teamID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, resultVar1, resultVar2, err
+}
+
+func (a *TracingAppLayer) GetSchemes(scope string, offset int, limit int) ([]*model.Scheme, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetSchemes")
+	defer span.End()
+
+	result, err := a.app.GetSchemes(scope, offset, limit)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetSchemesPage(scope string, page int, perPage int) ([]*model.Scheme, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetSchemesPage")
+	defer span.End()
+
+	result, err := a.app.GetSchemesPage(scope, page, perPage)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetServerLimits() (*model.ServerLimits, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetServerLimits")
+	defer span.End()
+
+	result, err := a.app.GetServerLimits()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetSession(token string) (*model.Session, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetSession")
+	defer span.End()
+
+	result, err := a.app.GetSession(token)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetSessionById(rctx request.CTX, sessionID string) (*model.Session, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.GetSessionById")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.GetSessionById(rctx, sessionID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetSessionLengthInMillis(session *model.Session) int64 {
+	_, span := tracing.Start(a.ctx, "App.GetSessionLengthInMillis")
+	defer span.End()
+
+	result := a.app.GetSessionLengthInMillis(session)
+	return result
+}
+
+func (a *TracingAppLayer) GetSessions(rctx request.CTX, userID string) ([]*model.Session, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.GetSessions")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.GetSessions(rctx, userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetSharedChannel(channelID string) (*model.SharedChannel, error) {
+	_, span := tracing.Start(a.ctx, "App.GetSharedChannel")
+	defer span.End()
+
+	result, err := a.app.GetSharedChannel(channelID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetSharedChannelRemote(id string) (*model.SharedChannelRemote, error) {
+	_, span := tracing.Start(a.ctx, "App.GetSharedChannelRemote")
+	defer span.End()
+
+	result, err := a.app.GetSharedChannelRemote(id)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetSharedChannelRemoteByIds(channelID string, remoteID string) (*model.SharedChannelRemote, error) {
+	_, span := tracing.Start(a.ctx, "App.GetSharedChannelRemoteByIds")
+	defer span.End()
+
+	result, err := a.app.GetSharedChannelRemoteByIds(channelID, remoteID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetSharedChannelRemotes(page int, perPage int, opts model.SharedChannelRemoteFilterOpts) ([]*model.SharedChannelRemote, error) {
+	_, span := tracing.Start(a.ctx, "App.GetSharedChannelRemotes")
+	defer span.End()
+
+	result, err := a.app.GetSharedChannelRemotes(page, perPage, opts)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetSharedChannelRemotesStatus(channelID string) ([]*model.SharedChannelRemoteStatus, error) {
+	_, span := tracing.Start(a.ctx, "App.GetSharedChannelRemotesStatus")
+	defer span.End()
+
+	result, err := a.app.GetSharedChannelRemotesStatus(channelID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetSharedChannels(page int, perPage int, opts model.SharedChannelFilterOpts) ([]*model.SharedChannel, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetSharedChannels")
+	defer span.End()
+
+	result, err := a.app.GetSharedChannels(page, perPage, opts)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetSharedChannelsCount(opts model.SharedChannelFilterOpts) (int64, error) {
+	_, span := tracing.Start(a.ctx, "App.GetSharedChannelsCount")
+	defer span.End()
+
+	result, err := a.app.GetSharedChannelsCount(opts)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetSidebarCategories(rctx request.CTX, userID string, teamID string) (*model.OrderedSidebarCategories, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.GetSidebarCategories")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.GetSidebarCategories(rctx, userID, teamID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetSidebarCategoriesForTeamForUser(rctx request.CTX, userID string, teamID string) (*model.OrderedSidebarCategories, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.GetSidebarCategoriesForTeamForUser")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.GetSidebarCategoriesForTeamForUser(rctx, userID, teamID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetSidebarCategory(rctx request.CTX, categoryId string) (*model.SidebarCategoryWithChannels, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.GetSidebarCategory")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.GetSidebarCategory(rctx, categoryId)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetSidebarCategoryOrder(rctx request.CTX, userID string, teamID string) ([]string, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.GetSidebarCategoryOrder")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.GetSidebarCategoryOrder(rctx, userID, teamID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetSinglePost(rctx request.CTX, postID string, includeDeleted bool) (*model.Post, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.GetSinglePost")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.GetSinglePost(rctx, postID, includeDeleted)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetSiteURL() string {
+	return a.app.GetSiteURL()
+}
+
+func (a *TracingAppLayer) GetStatus(userID string) (*model.Status, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetStatus")
+	defer span.End()
+
+	result, err := a.app.GetStatus(userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetStatusFromCache(userID string) *model.Status {
+	_, span := tracing.Start(a.ctx, "App.GetStatusFromCache")
+	defer span.End()
+
+	result := a.app.GetStatusFromCache(userID)
+	return result
+}
+
+func (a *TracingAppLayer) GetStorageUsage() (int64, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetStorageUsage")
+	defer span.End()
+
+	result, err := a.app.GetStorageUsage()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetSuggestions(rctx request.CTX, commandArgs *model.CommandArgs, commands []*model.Command, roleID string) []model.AutocompleteSuggestion {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.GetSuggestions")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result := a.app.GetSuggestions(rctx, commandArgs, commands, roleID)
+	return result
+}
+
+func (a *TracingAppLayer) GetSystemBot(rctx request.CTX) (*model.Bot, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.GetSystemBot")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.GetSystemBot(rctx)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetTeam(teamID string) (*model.Team, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetTeam")
+	defer span.End()
+
+	result, err := a.app.GetTeam(teamID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetTeamByInviteId(inviteId string) (*model.Team, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetTeamByInviteId")
+	defer span.End()
+
+	result, err := a.app.GetTeamByInviteId(inviteId)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetTeamByName(name string) (*model.Team, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetTeamByName")
+	defer span.End()
+
+	result, err := a.app.GetTeamByName(name)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetTeamGroupUsers(teamID string) ([]*model.User, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetTeamGroupUsers")
+	defer span.End()
+
+	result, err := a.app.GetTeamGroupUsers(teamID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetTeamIcon(team *model.Team) ([]byte, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetTeamIcon")
+	defer span.End()
+
+	result, err := a.app.GetTeamIcon(team)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetTeamIdFromQuery(rctx request.CTX, query url.Values) (string, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.GetTeamIdFromQuery")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.GetTeamIdFromQuery(rctx, query)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetTeamMember(rctx request.CTX, teamID string, userID string) (*model.TeamMember, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.GetTeamMember")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.GetTeamMember(rctx, teamID, userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetTeamMembers(teamID string, offset int, limit int, teamMembersGetOptions *model.TeamMembersGetOptions) ([]*model.TeamMember, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetTeamMembers")
+	defer span.End()
+
+	result, err := a.app.GetTeamMembers(teamID, offset, limit, teamMembersGetOptions)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetTeamMembersByIds(teamID string, userIDs []string, restrictions *model.ViewUsersRestrictions) ([]*model.TeamMember, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetTeamMembersByIds")
+	defer span.End()
+
+	result, err := a.app.GetTeamMembersByIds(teamID, userIDs, restrictions)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetTeamMembersForUser(rctx request.CTX, userID string, excludeTeamID string, includeDeleted bool) ([]*model.TeamMember, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.GetTeamMembersForUser")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.GetTeamMembersForUser(rctx, userID, excludeTeamID, includeDeleted)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetTeamMembersForUserWithPagination(userID string, page int, perPage int) ([]*model.TeamMember, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetTeamMembersForUserWithPagination")
+	defer span.End()
+
+	result, err := a.app.GetTeamMembersForUserWithPagination(userID, page, perPage)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetTeamPoliciesForUser(userID string, offset int, limit int) (*model.RetentionPolicyForTeamList, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetTeamPoliciesForUser")
+	defer span.End()
+
+	result, err := a.app.GetTeamPoliciesForUser(userID, offset, limit)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetTeamSchemeChannelRoles(rctx request.CTX, teamID string) (string, string, string, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.GetTeamSchemeChannelRoles")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, resultVar1, resultVar2, err := a.app.GetTeamSchemeChannelRoles(rctx, teamID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, resultVar1, resultVar2, err
+}
+
+func (a *TracingAppLayer) GetTeamStats(teamID string, restrictions *model.ViewUsersRestrictions) (*model.TeamStats, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetTeamStats")
+	defer span.End()
+
+	result, err := a.app.GetTeamStats(teamID, restrictions)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetTeamUnread(teamID string, userID string) (*model.TeamUnread, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetTeamUnread")
+	defer span.End()
+
+	result, err := a.app.GetTeamUnread(teamID, userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetTeams(teamIDs []string) ([]*model.Team, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetTeams")
+	defer span.End()
+
+	result, err := a.app.GetTeams(teamIDs)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetTeamsForRetentionPolicy(policyID string, offset int, limit int) (*model.TeamsWithCount, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetTeamsForRetentionPolicy")
+	defer span.End()
+
+	result, err := a.app.GetTeamsForRetentionPolicy(policyID, offset, limit)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetTeamsForScheme(scheme *model.Scheme, offset int, limit int) ([]*model.Team, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetTeamsForScheme")
+	defer span.End()
+
+	result, err := a.app.GetTeamsForScheme(scheme, offset, limit)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetTeamsForSchemePage(scheme *model.Scheme, page int, perPage int) ([]*model.Team, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetTeamsForSchemePage")
+	defer span.End()
+
+	result, err := a.app.GetTeamsForSchemePage(scheme, page, perPage)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetTeamsForUser(userID string) ([]*model.Team, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetTeamsForUser")
+	defer span.End()
+
+	result, err := a.app.GetTeamsForUser(userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetTeamsUnreadForUser(excludeTeamId string, userID string, includeCollapsedThreads bool) ([]*model.TeamUnread, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetTeamsUnreadForUser")
+	defer span.End()
+
+	result, err := a.app.GetTeamsUnreadForUser(excludeTeamId, userID, includeCollapsedThreads)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetTeamsUsage() (*model.TeamsUsage, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetTeamsUsage")
+	defer span.End()
+
+	result, err := a.app.GetTeamsUsage()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetTermsOfService(id string) (*model.TermsOfService, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetTermsOfService")
+	defer span.End()
+
+	result, err := a.app.GetTermsOfService(id)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetThreadForUser(rctx request.CTX, threadMembership *model.ThreadMembership, extended bool) (*model.ThreadResponse, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.GetThreadForUser")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.GetThreadForUser(rctx, threadMembership, extended)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetThreadMembershipForUser(userId string, threadId string) (*model.ThreadMembership, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetThreadMembershipForUser")
+	defer span.End()
+
+	result, err := a.app.GetThreadMembershipForUser(userId, threadId)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetThreadMembershipsForUser(userID string, teamID string) ([]*model.ThreadMembership, error) {
+	_, span := tracing.Start(a.ctx, "App.GetThreadMembershipsForUser")
+	defer span.End()
+
+	result, err := a.app.GetThreadMembershipsForUser(userID, teamID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetThreadsForUser(rctx request.CTX, userID string, teamID string, options model.GetUserThreadsOpts) (*model.Threads, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.GetThreadsForUser")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.GetThreadsForUser(rctx, userID, teamID, options)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetTokenById(token string) (*model.Token, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetTokenById")
+	defer span.End()
+
+	result, err := a.app.GetTokenById(token)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetTotalUsersStats(viewRestrictions *model.ViewUsersRestrictions) (*model.UsersStats, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetTotalUsersStats")
+	defer span.End()
+
+	result, err := a.app.GetTotalUsersStats(viewRestrictions)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetUploadSession(rctx request.CTX, uploadId string) (*model.UploadSession, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.GetUploadSession")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.GetUploadSession(rctx, uploadId)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetUploadSessionsForUser(userID string) ([]*model.UploadSession, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetUploadSessionsForUser")
+	defer span.End()
+
+	result, err := a.app.GetUploadSessionsForUser(userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetUser(userID string) (*model.User, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetUser")
+	defer span.End()
+
+	result, err := a.app.GetUser(userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetUserAccessToken(tokenID string, sanitize bool) (*model.UserAccessToken, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetUserAccessToken")
+	defer span.End()
+
+	result, err := a.app.GetUserAccessToken(tokenID, sanitize)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetUserAccessTokens(page int, perPage int) ([]*model.UserAccessToken, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetUserAccessTokens")
+	defer span.End()
+
+	result, err := a.app.GetUserAccessTokens(page, perPage)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetUserAccessTokensForUser(userID string, page int, perPage int) ([]*model.UserAccessToken, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetUserAccessTokensForUser")
+	defer span.End()
+
+	result, err := a.app.GetUserAccessTokensForUser(userID, page, perPage)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetUserByAuth(authData *string, authService string) (*model.User, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetUserByAuth")
+	defer span.End()
+
+	result, err := a.app.GetUserByAuth(authData, authService)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetUserByEmail(email string) (*model.User, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetUserByEmail")
+	defer span.End()
+
+	result, err := a.app.GetUserByEmail(email)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetUserByRemoteID(remoteID string) (*model.User, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetUserByRemoteID")
+	defer span.End()
+
+	result, err := a.app.GetUserByRemoteID(remoteID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetUserByUsername(username string) (*model.User, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetUserByUsername")
+	defer span.End()
+
+	result, err := a.app.GetUserByUsername(username)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetUserCountForReport(filter *model.UserReportOptions) (*int64, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetUserCountForReport")
+	defer span.End()
+
+	result, err := a.app.GetUserCountForReport(filter)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetUserForLogin(rctx request.CTX, id string, loginId string) (*model.User, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.GetUserForLogin")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.GetUserForLogin(rctx, id, loginId)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetUserStatusesByIds(userIDs []string) ([]*model.Status, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetUserStatusesByIds")
+	defer span.End()
+
+	result, err := a.app.GetUserStatusesByIds(userIDs)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetUserTeamScheduledPosts(rctx request.CTX, userId string, teamId string) ([]*model.ScheduledPost, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.GetUserTeamScheduledPosts")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.GetUserTeamScheduledPosts(rctx, userId, teamId)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetUserTermsOfService(userID string) (*model.UserTermsOfService, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetUserTermsOfService")
+	defer span.End()
+
+	result, err := a.app.GetUserTermsOfService(userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetUsers(rctx request.CTX, userIDs []string) ([]*model.User, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.GetUsers")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.GetUsers(rctx, userIDs)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetUsersByGroupChannelIds(rctx request.CTX, channelIDs []string, asAdmin bool) (map[string][]*model.User, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.GetUsersByGroupChannelIds")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.GetUsersByGroupChannelIds(rctx, channelIDs, asAdmin)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetUsersByIds(rctx request.CTX, userIDs []string, options *store.UserGetByIdsOpts) ([]*model.User, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.GetUsersByIds")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.GetUsersByIds(rctx, userIDs, options)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetUsersByUsernames(usernames []string, asAdmin bool, viewRestrictions *model.ViewUsersRestrictions) ([]*model.User, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetUsersByUsernames")
+	defer span.End()
+
+	result, err := a.app.GetUsersByUsernames(usernames, asAdmin, viewRestrictions)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetUsersEtag(restrictionsHash string) string {
+	_, span := tracing.Start(a.ctx, "App.GetUsersEtag")
+	defer span.End()
+
+	result := a.app.GetUsersEtag(restrictionsHash)
+	return result
+}
+
+func (a *TracingAppLayer) GetUsersForPosts(posts []*model.Post) (map[string]*model.User, error) {
+	_, span := tracing.Start(a.ctx, "App.GetUsersForPosts")
+	defer span.End()
+
+	result, err := a.app.GetUsersForPosts(posts)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetUsersForReporting(filter *model.UserReportOptions) ([]*model.UserReport, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetUsersForReporting")
+	defer span.End()
+
+	result, err := a.app.GetUsersForReporting(filter)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetUsersFromProfiles(options *model.UserGetOptions) ([]*model.User, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetUsersFromProfiles")
+	defer span.End()
+
+	result, err := a.app.GetUsersFromProfiles(options)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetUsersInChannel(options *model.UserGetOptions) ([]*model.User, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetUsersInChannel")
+	defer span.End()
+
+	result, err := a.app.GetUsersInChannel(options)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetUsersInChannelByAdmin(options *model.UserGetOptions) ([]*model.User, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetUsersInChannelByAdmin")
+	defer span.End()
+
+	result, err := a.app.GetUsersInChannelByAdmin(options)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetUsersInChannelByStatus(options *model.UserGetOptions) ([]*model.User, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetUsersInChannelByStatus")
+	defer span.End()
+
+	result, err := a.app.GetUsersInChannelByStatus(options)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetUsersInChannelMap(options *model.UserGetOptions, asAdmin bool) (map[string]*model.User, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetUsersInChannelMap")
+	defer span.End()
+
+	result, err := a.app.GetUsersInChannelMap(options, asAdmin)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetUsersInChannelPage(options *model.UserGetOptions, asAdmin bool) ([]*model.User, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetUsersInChannelPage")
+	defer span.End()
+
+	result, err := a.app.GetUsersInChannelPage(options, asAdmin)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetUsersInChannelPageByAdmin(options *model.UserGetOptions, asAdmin bool) ([]*model.User, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetUsersInChannelPageByAdmin")
+	defer span.End()
+
+	result, err := a.app.GetUsersInChannelPageByAdmin(options, asAdmin)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetUsersInChannelPageByStatus(options *model.UserGetOptions, asAdmin bool) ([]*model.User, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetUsersInChannelPageByStatus")
+	defer span.End()
+
+	result, err := a.app.GetUsersInChannelPageByStatus(options, asAdmin)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetUsersInTeam(options *model.UserGetOptions) ([]*model.User, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetUsersInTeam")
+	defer span.End()
+
+	result, err := a.app.GetUsersInTeam(options)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetUsersInTeamEtag(teamID string, restrictionsHash string) string {
+	_, span := tracing.Start(a.ctx, "App.GetUsersInTeamEtag")
+	defer span.End()
+
+	result := a.app.GetUsersInTeamEtag(teamID, restrictionsHash)
+	return result
+}
+
+func (a *TracingAppLayer) GetUsersInTeamPage(options *model.UserGetOptions, asAdmin bool) ([]*model.User, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetUsersInTeamPage")
+	defer span.End()
+
+	result, err := a.app.GetUsersInTeamPage(options, asAdmin)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetUsersNotInAbacChannel(rctx request.CTX, teamID string, channelID string, groupConstrained bool, cursorID string, limit int, asAdmin bool, viewRestrictions *model.ViewUsersRestrictions) ([]*model.User, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.GetUsersNotInAbacChannel")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.GetUsersNotInAbacChannel(rctx, teamID, channelID, groupConstrained, cursorID, limit, asAdmin, viewRestrictions)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetUsersNotInChannel(teamID string, channelID string, groupConstrained bool, offset int, limit int, viewRestrictions *model.ViewUsersRestrictions) ([]*model.User, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetUsersNotInChannel")
+	defer span.End()
+
+	result, err := a.app.GetUsersNotInChannel(teamID, channelID, groupConstrained, offset, limit, viewRestrictions)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetUsersNotInChannelMap(teamID string, channelID string, groupConstrained bool, offset int, limit int, asAdmin bool, viewRestrictions *model.ViewUsersRestrictions) (map[string]*model.User, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetUsersNotInChannelMap")
+	defer span.End()
+
+	result, err := a.app.GetUsersNotInChannelMap(teamID, channelID, groupConstrained, offset, limit, asAdmin, viewRestrictions)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetUsersNotInChannelPage(teamID string, channelID string, groupConstrained bool, page int, perPage int, asAdmin bool, viewRestrictions *model.ViewUsersRestrictions) ([]*model.User, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetUsersNotInChannelPage")
+	defer span.End()
+
+	result, err := a.app.GetUsersNotInChannelPage(teamID, channelID, groupConstrained, page, perPage, asAdmin, viewRestrictions)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetUsersNotInGroupPage(groupID string, page int, perPage int, viewRestrictions *model.ViewUsersRestrictions) ([]*model.User, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetUsersNotInGroupPage")
+	defer span.End()
+
+	result, err := a.app.GetUsersNotInGroupPage(groupID, page, perPage, viewRestrictions)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetUsersNotInTeam(teamID string, groupConstrained bool, offset int, limit int, viewRestrictions *model.ViewUsersRestrictions) ([]*model.User, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetUsersNotInTeam")
+	defer span.End()
+
+	result, err := a.app.GetUsersNotInTeam(teamID, groupConstrained, offset, limit, viewRestrictions)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetUsersNotInTeamEtag(teamID string, restrictionsHash string) string {
+	_, span := tracing.Start(a.ctx, "App.GetUsersNotInTeamEtag")
+	defer span.End()
+
+	result := a.app.GetUsersNotInTeamEtag(teamID, restrictionsHash)
+	return result
+}
+
+func (a *TracingAppLayer) GetUsersNotInTeamPage(teamID string, groupConstrained bool, page int, perPage int, asAdmin bool, viewRestrictions *model.ViewUsersRestrictions) ([]*model.User, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetUsersNotInTeamPage")
+	defer span.End()
+
+	result, err := a.app.GetUsersNotInTeamPage(teamID, groupConstrained, page, perPage, asAdmin, viewRestrictions)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetUsersPage(options *model.UserGetOptions, asAdmin bool) ([]*model.User, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetUsersPage")
+	defer span.End()
+
+	result, err := a.app.GetUsersPage(options, asAdmin)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetUsersWithInvalidEmails(page int, perPage int) ([]*model.User, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetUsersWithInvalidEmails")
+	defer span.End()
+
+	result, err := a.app.GetUsersWithInvalidEmails(page, perPage)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetUsersWithoutTeam(options *model.UserGetOptions) ([]*model.User, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetUsersWithoutTeam")
+	defer span.End()
+
+	result, err := a.app.GetUsersWithoutTeam(options)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetUsersWithoutTeamPage(options *model.UserGetOptions, asAdmin bool) ([]*model.User, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetUsersWithoutTeamPage")
+	defer span.End()
+
+	result, err := a.app.GetUsersWithoutTeamPage(options, asAdmin)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetVerifyEmailToken(token string) (*model.Token, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetVerifyEmailToken")
+	defer span.End()
+
+	result, err := a.app.GetVerifyEmailToken(token)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetViewUsersRestrictions(rctx request.CTX, userID string) (*model.ViewUsersRestrictions, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.GetViewUsersRestrictions")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.GetViewUsersRestrictions(rctx, userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) GetWorkingHours(userID string) (*model.WorkingHours, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.GetWorkingHours")
+	defer span.End()
+
+	result, err := a.app.GetWorkingHours(userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) HTTPService() httpservice.HTTPService {
+	return a.app.HTTPService()
+}
+
+func (a *TracingAppLayer) HandleCommandResponse(rctx request.CTX, command *model.Command, args *model.CommandArgs, response *model.CommandResponse, builtIn bool) (*model.CommandResponse, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.HandleCommandResponse")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.HandleCommandResponse(rctx, command, args, response, builtIn)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) HandleCommandResponsePost(rctx request.CTX, command *model.Command, args *model.CommandArgs, response *model.CommandResponse, builtIn bool) (*model.Post, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.HandleCommandResponsePost")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.HandleCommandResponsePost(rctx, command, args, response, builtIn)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) HandleCommandWebhook(rctx request.CTX, hookID string, response *model.CommandResponse) *model.AppError {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.HandleCommandWebhook")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.HandleCommandWebhook(rctx, hookID, response)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) HandleImages(rctx request.CTX, previewPathList []string, thumbnailPathList []string, fileData [][]byte) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.HandleImages")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	a.app.HandleImages(rctx, previewPathList, thumbnailPathList, fileData)
+}
+
+func (a *TracingAppLayer) HandleIncomingWebhook(rctx request.CTX, hookID string, req *model.IncomingWebhookRequest) *model.AppError {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.HandleIncomingWebhook")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.HandleIncomingWebhook(rctx, hookID, req)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) HandleMessageExportConfig(cfg *model.Config, appCfg *model.Config) {
+	_, span := tracing.Start(a.ctx, "App.HandleMessageExportConfig")
+	defer span.End()
+
+	a.app.HandleMessageExportConfig(cfg, appCfg)
+}
+
+func (a *TracingAppLayer) HasPermissionTo(askingUserId string, permission *model.Permission) bool {
+	_, span := tracing.Start(a.ctx, "App.HasPermissionTo")
+	defer span.End()
+
+	result := a.app.HasPermissionTo(askingUserId, permission)
+	return result
+}
+
+func (a *TracingAppLayer) HasPermissionToChannel(rctx request.CTX, askingUserId string, channelID string, permission *model.Permission) bool {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.HasPermissionToChannel")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result := a.app.HasPermissionToChannel(rctx, askingUserId, channelID, permission)
+	return result
+}
+
+func (a *TracingAppLayer) HasPermissionToChannelByPost(rctx request.CTX, askingUserId string, postID string, permission *model.Permission) bool {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.HasPermissionToChannelByPost")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result := a.app.HasPermissionToChannelByPost(rctx, askingUserId, postID, permission)
+	return result
+}
+
+func (a *TracingAppLayer) HasPermissionToChannelMemberCount(rctx request.CTX, userID string, channel *model.Channel) bool {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.HasPermissionToChannelMemberCount")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result := a.app.HasPermissionToChannelMemberCount(rctx, userID, channel)
+	return result
+}
+
+func (a *TracingAppLayer) HasPermissionToReadChannel(rctx request.CTX, userID string, channel *model.Channel) bool {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.HasPermissionToReadChannel")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result := a.app.HasPermissionToReadChannel(rctx, userID, channel)
+	return result
+}
+
+func (a *TracingAppLayer) HasPermissionToTeam(rctx request.CTX, askingUserId string, teamID string, permission *model.Permission) bool {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.HasPermissionToTeam")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result := a.app.HasPermissionToTeam(rctx, askingUserId, teamID, permission)
+	return result
+}
+
+func (a *TracingAppLayer) HasPermissionToUser(askingUserId string, userID string) bool {
+	_, span := tracing.Start(a.ctx, "App.HasPermissionToUser")
+	defer span.End()
+
+	result := a.app.HasPermissionToUser(askingUserId, userID)
+	return result
+}
+
+func (a *TracingAppLayer) HasRemote(channelID string, remoteID string) (bool, error) {
+	_, span := tracing.Start(a.ctx, "App.HasRemote")
+	defer span.End()
+
+	result, err := a.app.HasRemote(channelID, remoteID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) HasSharedChannel(channelID string) (bool, error) {
+	_, span := tracing.Start(a.ctx, "App.HasSharedChannel")
+	defer span.End()
+
+	result, err := a.app.HasSharedChannel(channelID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) IPFiltering() einterfaces.IPFilteringInterface {
+	return a.app.IPFiltering()
+}
+
+func (a *TracingAppLayer) ImageProxy() *imageproxy.ImageProxy {
+	return a.app.ImageProxy()
+}
+
+func (a *TracingAppLayer) ImageProxyAdder() func(string) string {
+	return a.app.ImageProxyAdder()
+}
+
+func (a *TracingAppLayer) ImageProxyRemover() func(string) string {
+	return a.app.ImageProxyRemover()
+}
+
+func (a *TracingAppLayer) InitPlugins(rctx request.CTX, pluginDir string, webappPluginDir string) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.InitPlugins")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	a.app.InitPlugins(rctx, pluginDir, webappPluginDir)
+}
+
+func (a *TracingAppLayer) InitializeAI() error {
+	_, span := tracing.Start(a.ctx, "App.InitializeAI")
+	defer span.End()
+
+	err := a.app.InitializeAI()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) InstallPlugin(pluginFile io.ReadSeeker, replace bool) (*model.Manifest, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.InstallPlugin")
+	defer span.End()
+
+	result, err := a.app.InstallPlugin(pluginFile, replace)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) InvalidateAllEmailInvites(rctx request.CTX) *model.AppError {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.InvalidateAllEmailInvites")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.InvalidateAllEmailInvites(rctx)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) InvalidateAllResendInviteEmailJobs(rctx request.CTX) *model.AppError {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.InvalidateAllResendInviteEmailJobs")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.InvalidateAllResendInviteEmailJobs(rctx)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) InvalidateCacheForUser(userID string) {
+	_, span := tracing.Start(a.ctx, "App.InvalidateCacheForUser")
+	defer span.End()
+
+	a.app.InvalidateCacheForUser(userID)
+}
+
+func (a *TracingAppLayer) InvalidatePasswordRecoveryTokensForUser(userID string) *model.AppError {
+	_, span := tracing.Start(a.ctx, "App.InvalidatePasswordRecoveryTokensForUser")
+	defer span.End()
+
+	err := a.app.InvalidatePasswordRecoveryTokensForUser(userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) InviteGuestsToChannels(rctx request.CTX, teamID string, guestsInvite *model.GuestsInvite, senderId string, guestMagicLink bool) *model.AppError {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.InviteGuestsToChannels")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.InviteGuestsToChannels(rctx, teamID, guestsInvite, senderId, guestMagicLink)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) InviteGuestsToChannelsGracefully(rctx request.CTX, teamID string, guestsInvite *model.GuestsInvite, senderId string, guestMagicLink bool) ([]*model.EmailInviteWithError, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.InviteGuestsToChannelsGracefully")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.InviteGuestsToChannelsGracefully(rctx, teamID, guestsInvite, senderId, guestMagicLink)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) InviteNewUsersToTeam(rctx request.CTX, emailList []string, teamID string, senderId string) *model.AppError {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.InviteNewUsersToTeam")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.InviteNewUsersToTeam(rctx, emailList, teamID, senderId)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) InviteNewUsersToTeamGracefully(rctx request.CTX, memberInvite *model.MemberInvite, teamID string, senderId string, reminderInterval string) ([]*model.EmailInviteWithError, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.InviteNewUsersToTeamGracefully")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.InviteNewUsersToTeamGracefully(rctx, memberInvite, teamID, senderId, reminderInterval)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) InviteRemoteToChannel(channelID string, remoteID string, userID string, shareIfNotShared bool) error {
+	_, span := tracing.Start(a.ctx, "App.InviteRemoteToChannel")
+	defer span.End()
+
+	err := a.app.InviteRemoteToChannel(channelID, remoteID, userID, shareIfNotShared)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) IsAIFeatureEnabled(feature string) bool {
+	_, span := tracing.Start(a.ctx, "App.IsAIFeatureEnabled")
+	defer span.End()
+
+	result := a.app.IsAIFeatureEnabled(feature)
+	return result
+}
+
+func (a *TracingAppLayer) IsBotOwnedByCurrentUserOrPlugin(rctx request.CTX, userID string) (bool, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.IsBotOwnedByCurrentUserOrPlugin")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.IsBotOwnedByCurrentUserOrPlugin(rctx, userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) IsCRTEnabledForUser(rctx request.CTX, userID string) bool {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.IsCRTEnabledForUser")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result := a.app.IsCRTEnabledForUser(rctx, userID)
+	return result
+}
+
+func (a *TracingAppLayer) IsConfigReadOnly() bool {
+	return a.app.IsConfigReadOnly()
+}
+
+func (a *TracingAppLayer) IsFirstUserAccount() bool {
+	return a.app.IsFirstUserAccount()
+}
+
+func (a *TracingAppLayer) IsLeader() bool {
+	return a.app.IsLeader()
+}
+
+func (a *TracingAppLayer) IsPasswordValid(rctx request.CTX, password string) *model.AppError {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.IsPasswordValid")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.IsPasswordValid(rctx, password)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) IsPersistentNotificationsEnabled() bool {
+	return a.app.IsPersistentNotificationsEnabled()
+}
+
+func (a *TracingAppLayer) IsPhase2MigrationCompleted() *model.AppError {
+	_, span := tracing.Start(a.ctx, "App.IsPhase2MigrationCompleted")
+	defer span.End()
+
+	err := a.app.IsPhase2MigrationCompleted()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) IsPluginActive(pluginName string) (bool, error) {
+	_, span := tracing.Start(a.ctx, "App.IsPluginActive")
+	defer span.End()
+
+	result, err := a.app.IsPluginActive(pluginName)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) IsPostPriorityEnabled() bool {
+	return a.app.IsPostPriorityEnabled()
+}
+
+func (a *TracingAppLayer) IsUserSignUpAllowed() *model.AppError {
+	_, span := tracing.Start(a.ctx, "App.IsUserSignUpAllowed")
+	defer span.End()
+
+	err := a.app.IsUserSignUpAllowed()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) IsUserTeamContentReviewer(userId string, teamId string) (bool, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.IsUserTeamContentReviewer")
+	defer span.End()
+
+	result, err := a.app.IsUserTeamContentReviewer(userId, teamId)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) JoinChannel(rctx request.CTX, channel *model.Channel, userID string) *model.AppError {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.JoinChannel")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.JoinChannel(rctx, channel, userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) JoinDefaultChannels(rctx request.CTX, teamID string, user *model.User, shouldBeAdmin bool, userRequestorId string) *model.AppError {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.JoinDefaultChannels")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.JoinDefaultChannels(rctx, teamID, user, shouldBeAdmin, userRequestorId)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) JoinUserToTeam(rctx request.CTX, team *model.Team, user *model.User, userRequestorId string) (*model.TeamMember, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.JoinUserToTeam")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.JoinUserToTeam(rctx, team, user, userRequestorId)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) KeepFlaggedPost(rctx request.CTX, actionRequest *model.FlagContentActionRequest, reviewerId string, flaggedPost *model.Post) *model.AppError {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.KeepFlaggedPost")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.KeepFlaggedPost(rctx, actionRequest, reviewerId, flaggedPost)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) Ldap() einterfaces.LdapInterface {
+	return a.app.Ldap()
+}
+
+func (a *TracingAppLayer) LdapDiagnostic() einterfaces.LdapDiagnosticInterface {
+	return a.app.LdapDiagnostic()
+}
+
+func (a *TracingAppLayer) LeaveChannel(rctx request.CTX, channelID string, userID string) *model.AppError {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.LeaveChannel")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.LeaveChannel(rctx, channelID, userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) LeaveTeam(rctx request.CTX, team *model.Team, user *model.User, requestorId string) *model.AppError {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.LeaveTeam")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.LeaveTeam(rctx, team, user, requestorId)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) License() *model.License {
+	return a.app.License()
+}
+
+func (a *TracingAppLayer) LimitedClientConfig() map[string]string {
+	return a.app.LimitedClientConfig()
+}
+
+func (a *TracingAppLayer) ListAllCommands(teamID string, T i18n.TranslateFunc) ([]*model.Command, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.ListAllCommands")
+	defer span.End()
+
+	result, err := a.app.ListAllCommands(teamID, T)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) ListAllCommandsByUser(teamID string, userID string, T i18n.TranslateFunc) ([]*model.Command, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.ListAllCommandsByUser")
+	defer span.End()
+
+	result, err := a.app.ListAllCommandsByUser(teamID, userID, T)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) ListAutocompleteCommands(teamID string, T i18n.TranslateFunc) ([]*model.Command, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.ListAutocompleteCommands")
+	defer span.End()
+
+	result, err := a.app.ListAutocompleteCommands(teamID, T)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) ListCPAFields() ([]*model.CPAField, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.ListCPAFields")
+	defer span.End()
+
+	result, err := a.app.ListCPAFields()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) ListCPAValues(userID string) ([]*model.PropertyValue, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.ListCPAValues")
+	defer span.End()
+
+	result, err := a.app.ListCPAValues(userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) ListDirectory(path string) ([]string, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.ListDirectory")
+	defer span.End()
+
+	result, err := a.app.ListDirectory(path)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) ListDirectoryRecursively(path string) ([]string, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.ListDirectoryRecursively")
+	defer span.End()
+
+	result, err := a.app.ListDirectoryRecursively(path)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) ListExportDirectory(path string) ([]string, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.ListExportDirectory")
+	defer span.End()
+
+	result, err := a.app.ListExportDirectory(path)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) ListExports() ([]string, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.ListExports")
+	defer span.End()
+
+	result, err := a.app.ListExports()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) ListImports() ([]string, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.ListImports")
+	defer span.End()
+
+	result, err := a.app.ListImports()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) ListPluginKeys(pluginID string, page int, perPage int) ([]string, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.ListPluginKeys")
+	defer span.End()
+
+	result, err := a.app.ListPluginKeys(pluginID, page, perPage)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) ListTeamCommands(teamID string) ([]*model.Command, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.ListTeamCommands")
+	defer span.End()
+
+	result, err := a.app.ListTeamCommands(teamID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) ListTeamCommandsByUser(teamID string, userID string) ([]*model.Command, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.ListTeamCommandsByUser")
+	defer span.End()
+
+	result, err := a.app.ListTeamCommandsByUser(teamID, userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) Log() *mlog.Logger {
+	return a.app.Log()
+}
+
+func (a *TracingAppLayer) LogAuditRec(rctx request.CTX, rec *model.AuditRecord, err error) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.LogAuditRec")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	a.app.LogAuditRec(rctx, rec, err)
+}
+
+func (a *TracingAppLayer) LogAuditRecWithLevel(rctx request.CTX, rec *model.AuditRecord, level mlog.Level, err error) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.LogAuditRecWithLevel")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	a.app.LogAuditRecWithLevel(rctx, rec, level, err)
+}
+
+func (a *TracingAppLayer) LoginByOAuth(rctx request.CTX, service string, userData io.Reader, inviteToken string, inviteId string, tokenUser *model.User) (*model.User, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.LoginByOAuth")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.LoginByOAuth(rctx, service, userData, inviteToken, inviteId, tokenUser)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) LookupInteractiveDialog(rctx request.CTX, request model.SubmitDialogRequest) (*model.LookupDialogResponse, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.LookupInteractiveDialog")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.LookupInteractiveDialog(rctx, request)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) MFARequired(rctx request.CTX) *model.AppError {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.MFARequired")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.MFARequired(rctx)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) MakeAuditRecord(rctx request.CTX, event string, initialStatus string) *model.AuditRecord {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.MakeAuditRecord")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result := a.app.MakeAuditRecord(rctx, event, initialStatus)
+	return result
+}
+
+func (a *TracingAppLayer) MarkChannelAsUnreadFromPost(rctx request.CTX, postID string, userID string, collapsedThreadsSupported bool) (*model.ChannelUnreadAt, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.MarkChannelAsUnreadFromPost")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.MarkChannelAsUnreadFromPost(rctx, postID, userID, collapsedThreadsSupported)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) MarkChannelsAsViewed(rctx request.CTX, channelIDs []string, userID string, currentSessionId string, collapsedThreadsSupported bool, isCRTEnabled bool) (map[string]int64, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.MarkChannelsAsViewed")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.MarkChannelsAsViewed(rctx, channelIDs, userID, currentSessionId, collapsedThreadsSupported, isCRTEnabled)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) MaxPostSize() int {
+	return a.app.MaxPostSize()
+}
+
+func (a *TracingAppLayer) MentionsToPublicChannels(rctx request.CTX, message string, teamID string) model.ChannelMentionMap {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.MentionsToPublicChannels")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result := a.app.MentionsToPublicChannels(rctx, message, teamID)
+	return result
+}
+
+func (a *TracingAppLayer) MentionsToTeamMembers(rctx request.CTX, message string, teamID string) model.UserMentionMap {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.MentionsToTeamMembers")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result := a.app.MentionsToTeamMembers(rctx, message, teamID)
+	return result
+}
+
+func (a *TracingAppLayer) MessageExport() einterfaces.MessageExportInterface {
+	return a.app.MessageExport()
+}
+
+func (a *TracingAppLayer) Metrics() einterfaces.MetricsInterface {
+	return a.app.Metrics()
+}
+
+func (a *TracingAppLayer) MigrateFilenamesToFileInfos(rctx request.CTX, post *model.Post) []*model.FileInfo {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.MigrateFilenamesToFileInfos")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result := a.app.MigrateFilenamesToFileInfos(rctx, post)
+	return result
+}
+
+func (a *TracingAppLayer) MigrateIdLDAP(rctx request.CTX, toAttribute string) *model.AppError {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.MigrateIdLDAP")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.MigrateIdLDAP(rctx, toAttribute)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) MoveChannel(rctx request.CTX, team *model.Team, channel *model.Channel, user *model.User) *model.AppError {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.MoveChannel")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.MoveChannel(rctx, team, channel, user)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) MoveCommand(team *model.Team, command *model.Command) *model.AppError {
+	_, span := tracing.Start(a.ctx, "App.MoveCommand")
+	defer span.End()
+
+	err := a.app.MoveCommand(team, command)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) MoveFile(oldPath string, newPath string) *model.AppError {
+	_, span := tracing.Start(a.ctx, "App.MoveFile")
+	defer span.End()
+
+	err := a.app.MoveFile(oldPath, newPath)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) MoveThread(rctx request.CTX, postID string, sourceChannelID string, channelID string, user *model.User) *model.AppError {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.MoveThread")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.MoveThread(rctx, postID, sourceChannelID, channelID, user)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) NewPluginAPI(rctx request.CTX, manifest *model.Manifest) plugin.API {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.NewPluginAPI")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result := a.app.NewPluginAPI(rctx, manifest)
+	return result
+}
+
+func (a *TracingAppLayer) Notification() einterfaces.NotificationInterface {
+	return a.app.Notification()
+}
+
+func (a *TracingAppLayer) NotifySelfHostedSignupProgress(progress string, userId string) {
+	_, span := tracing.Start(a.ctx, "App.NotifySelfHostedSignupProgress")
+	defer span.End()
+
+	a.app.NotifySelfHostedSignupProgress(progress, userId)
+}
+
+func (a *TracingAppLayer) NotifySessionsExpired() error {
+	_, span := tracing.Start(a.ctx, "App.NotifySessionsExpired")
+	defer span.End()
+
+	err := a.app.NotifySessionsExpired()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) NotifySharedChannelUserUpdate(user *model.User) {
+	_, span := tracing.Start(a.ctx, "App.NotifySharedChannelUserUpdate")
+	defer span.End()
+
+	a.app.NotifySharedChannelUserUpdate(user)
+}
+
+func (a *TracingAppLayer) OnSharedChannelsAttachmentSyncMsg(fi *model.FileInfo, post *model.Post, rc *model.RemoteCluster) error {
+	_, span := tracing.Start(a.ctx, "App.OnSharedChannelsAttachmentSyncMsg")
+	defer span.End()
+
+	err := a.app.OnSharedChannelsAttachmentSyncMsg(fi, post, rc)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) OnSharedChannelsPing(rc *model.RemoteCluster) bool {
+	_, span := tracing.Start(a.ctx, "App.OnSharedChannelsPing")
+	defer span.End()
+
+	result := a.app.OnSharedChannelsPing(rc)
+	return result
+}
+
+func (a *TracingAppLayer) OnSharedChannelsProfileImageSyncMsg(user *model.User, rc *model.RemoteCluster) error {
+	_, span := tracing.Start(a.ctx, "App.OnSharedChannelsProfileImageSyncMsg")
+	defer span.End()
+
+	err := a.app.OnSharedChannelsProfileImageSyncMsg(user, rc)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) OnSharedChannelsSyncMsg(msg *model.SyncMsg, rc *model.RemoteCluster) (model.SyncResponse, error) {
+	_, span := tracing.Start(a.ctx, "App.OnSharedChannelsSyncMsg")
+	defer span.End()
+
+	result, err := a.app.OnSharedChannelsSyncMsg(msg, rc)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) OpenInteractiveDialog(rctx request.CTX, request model.OpenDialogRequest) *model.AppError {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.OpenInteractiveDialog")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.OpenInteractiveDialog(rctx, request)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) OriginChecker() func(*http.Request) bool {
+	return a.app.OriginChecker()
+}
+
+func (a *TracingAppLayer) OutgoingOAuthConnections() einterfaces.OutgoingOAuthConnectionInterface {
+	return a.app.OutgoingOAuthConnections()
+}
+
+func (a *TracingAppLayer) OverrideIconURLIfEmoji(rctx request.CTX, post *model.Post) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.OverrideIconURLIfEmoji")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	a.app.OverrideIconURLIfEmoji(rctx, post)
+}
+
+func (a *TracingAppLayer) PatchBot(rctx request.CTX, botUserId string, botPatch *model.BotPatch) (*model.Bot, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.PatchBot")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.PatchBot(rctx, botUserId, botPatch)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) PatchCPAField(fieldID string, patch *model.PropertyFieldPatch) (*model.CPAField, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.PatchCPAField")
+	defer span.End()
+
+	result, err := a.app.PatchCPAField(fieldID, patch)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) PatchCPAValue(userID string, fieldID string, value json.RawMessage, allowSynced bool) (*model.PropertyValue, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.PatchCPAValue")
+	defer span.End()
+
+	result, err := a.app.PatchCPAValue(userID, fieldID, value, allowSynced)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) PatchCPAValues(userID string, fieldValueMap map[string]json.RawMessage, allowSynced bool) ([]*model.PropertyValue, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.PatchCPAValues")
+	defer span.End()
+
+	result, err := a.app.PatchCPAValues(userID, fieldValueMap, allowSynced)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) PatchChannel(rctx request.CTX, channel *model.Channel, patch *model.ChannelPatch, userID string) (*model.Channel, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.PatchChannel")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.PatchChannel(rctx, channel, patch, userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) PatchChannelMembersNotifyProps(rctx request.CTX, members []*model.ChannelMemberIdentifier, notifyProps map[string]string) ([]*model.ChannelMember, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.PatchChannelMembersNotifyProps")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.PatchChannelMembersNotifyProps(rctx, members, notifyProps)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) PatchChannelModerationsForChannel(rctx request.CTX, channel *model.Channel, channelModerationsPatch []*model.ChannelModerationPatch) ([]*model.ChannelModeration, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.PatchChannelModerationsForChannel")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.PatchChannelModerationsForChannel(rctx, channel, channelModerationsPatch)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) PatchPost(rctx request.CTX, postID string, patch *model.PostPatch, patchPostOptions *model.UpdatePostOptions) (*model.Post, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.PatchPost")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.PatchPost(rctx, postID, patch, patchPostOptions)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) PatchRemoteCluster(rcId string, patch *model.RemoteClusterPatch) (*model.RemoteCluster, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.PatchRemoteCluster")
+	defer span.End()
+
+	result, err := a.app.PatchRemoteCluster(rcId, patch)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) PatchRetentionPolicy(patch *model.RetentionPolicyWithTeamAndChannelIDs) (*model.RetentionPolicyWithTeamAndChannelCounts, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.PatchRetentionPolicy")
+	defer span.End()
+
+	result, err := a.app.PatchRetentionPolicy(patch)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) PatchRole(role *model.Role, patch *model.RolePatch) (*model.Role, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.PatchRole")
+	defer span.End()
+
+	result, err := a.app.PatchRole(role, patch)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) PatchScheme(scheme *model.Scheme, patch *model.SchemePatch) (*model.Scheme, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.PatchScheme")
+	defer span.End()
+
+	result, err := a.app.PatchScheme(scheme, patch)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) PatchTeam(teamID string, patch *model.TeamPatch) (*model.Team, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.PatchTeam")
+	defer span.End()
+
+	result, err := a.app.PatchTeam(teamID, patch)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) PatchUser(rctx request.CTX, userID string, patch *model.UserPatch, asAdmin bool) (*model.User, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.PatchUser")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.PatchUser(rctx, userID, patch, asAdmin)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) PermanentDeleteAllUsers(rctx request.CTX) *model.AppError {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.PermanentDeleteAllUsers")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.PermanentDeleteAllUsers(rctx)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) PermanentDeleteBot(rctx request.CTX, botUserId string) *model.AppError {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.PermanentDeleteBot")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.PermanentDeleteBot(rctx, botUserId)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) PermanentDeleteChannel(rctx request.CTX, channel *model.Channel) *model.AppError {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.PermanentDeleteChannel")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.PermanentDeleteChannel(rctx, channel)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) PermanentDeleteFilesByPost(rctx request.CTX, postID string) *model.AppError {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.PermanentDeleteFilesByPost")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.PermanentDeleteFilesByPost(rctx, postID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) PermanentDeleteFlaggedPost(rctx request.CTX, actionRequest *model.FlagContentActionRequest, reviewerId string, flaggedPost *model.Post) *model.AppError {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.PermanentDeleteFlaggedPost")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.PermanentDeleteFlaggedPost(rctx, actionRequest, reviewerId, flaggedPost)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) PermanentDeletePost(rctx request.CTX, postID string, deleteByID string) *model.AppError {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.PermanentDeletePost")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.PermanentDeletePost(rctx, postID, deleteByID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) PermanentDeleteTeam(rctx request.CTX, team *model.Team) *model.AppError {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.PermanentDeleteTeam")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.PermanentDeleteTeam(rctx, team)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) PermanentDeleteTeamId(rctx request.CTX, teamID string) *model.AppError {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.PermanentDeleteTeamId")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.PermanentDeleteTeamId(rctx, teamID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) PermanentDeleteUser(rctx request.CTX, user *model.User) *model.AppError {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.PermanentDeleteUser")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.PermanentDeleteUser(rctx, user)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) PopulateWebConnConfig(s *model.Session, cfg *platform.WebConnConfig, seqVal string) (*platform.WebConnConfig, error) {
+	_, span := tracing.Start(a.ctx, "App.PopulateWebConnConfig")
+	defer span.End()
+
+	result, err := a.app.PopulateWebConnConfig(s, cfg, seqVal)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) PostActionCookieSecret() []byte {
+	return a.app.PostActionCookieSecret()
+}
+
+func (a *TracingAppLayer) PostAddToChannelMessage(rctx request.CTX, user *model.User, addedUser *model.User, channel *model.Channel, postRootId string) *model.AppError {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.PostAddToChannelMessage")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.PostAddToChannelMessage(rctx, user, addedUser, channel, postRootId)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) PostPatchWithProxyRemovedFromImageURLs(patch *model.PostPatch) *model.PostPatch {
+	_, span := tracing.Start(a.ctx, "App.PostPatchWithProxyRemovedFromImageURLs")
+	defer span.End()
+
+	result := a.app.PostPatchWithProxyRemovedFromImageURLs(patch)
+	return result
+}
+
+func (a *TracingAppLayer) PostUpdateChannelDisplayNameMessage(rctx request.CTX, userID string, channel *model.Channel, oldChannelDisplayName string, newChannelDisplayName string) *model.AppError {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.PostUpdateChannelDisplayNameMessage")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.PostUpdateChannelDisplayNameMessage(rctx, userID, channel, oldChannelDisplayName, newChannelDisplayName)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) PostUpdateChannelHeaderMessage(rctx request.CTX, userID string, channel *model.Channel, oldChannelHeader string, newChannelHeader string) *model.AppError {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.PostUpdateChannelHeaderMessage")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.PostUpdateChannelHeaderMessage(rctx, userID, channel, oldChannelHeader, newChannelHeader)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) PostUpdateChannelPurposeMessage(rctx request.CTX, userID string, channel *model.Channel, oldChannelPurpose string, newChannelPurpose string) *model.AppError {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.PostUpdateChannelPurposeMessage")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.PostUpdateChannelPurposeMessage(rctx, userID, channel, oldChannelPurpose, newChannelPurpose)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) PostWithProxyAddedToImageURLs(post *model.Post) *model.Post {
+	_, span := tracing.Start(a.ctx, "App.PostWithProxyAddedToImageURLs")
+	defer span.End()
+
+	result := a.app.PostWithProxyAddedToImageURLs(post)
+	return result
+}
+
+func (a *TracingAppLayer) PostWithProxyRemovedFromImageURLs(post *model.Post) *model.Post {
+	_, span := tracing.Start(a.ctx, "App.PostWithProxyRemovedFromImageURLs")
+	defer span.End()
+
+	result := a.app.PostWithProxyRemovedFromImageURLs(post)
+	return result
+}
+
+func (a *TracingAppLayer) PrepareIncomingWebhookRequest(hookID string, header http.Header, body []byte) (*model.IncomingWebhookRequest, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.PrepareIncomingWebhookRequest")
+	defer span.End()
+
+	result, err := a.app.PrepareIncomingWebhookRequest(hookID, header, body)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) PreparePostForClient(rctx request.CTX, originalPost *model.Post, opts *model.PreparePostForClientOpts) *model.Post {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.PreparePostForClient")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result := a.app.PreparePostForClient(rctx, originalPost, opts)
+	return result
+}
+
+func (a *TracingAppLayer) PreparePostForClientWithEmbedsAndImages(rctx request.CTX, originalPost *model.Post, opts *model.PreparePostForClientOpts) *model.Post {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.PreparePostForClientWithEmbedsAndImages")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result := a.app.PreparePostForClientWithEmbedsAndImages(rctx, originalPost, opts)
+	return result
+}
+
+func (a *TracingAppLayer) PreparePostListForClient(rctx request.CTX, originalList *model.PostList) *model.PostList {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.PreparePostListForClient")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result := a.app.PreparePostListForClient(rctx, originalList)
+	return result
+}
+
+func (a *TracingAppLayer) PreviewFormatting(c request.CTX, req *app.FormattingRequest) (*app.FormattingResponse, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(c, a.ctx), "App.PreviewFormatting")
+	if c != nil {
+		c = c.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.PreviewFormatting(c, req)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) ProcessScheduledPosts(rctx request.CTX) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.ProcessScheduledPosts")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	a.app.ProcessScheduledPosts(rctx)
+}
+
+func (a *TracingAppLayer) ProcessSlackAttachments(rctx request.CTX, attachments []*model.SlackAttachment) []*model.SlackAttachment {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.ProcessSlackAttachments")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result := a.app.ProcessSlackAttachments(rctx, attachments)
+	return result
+}
+
+func (a *TracingAppLayer) ProcessSlackText(rctx request.CTX, text string) string {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.ProcessSlackText")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result := a.app.ProcessSlackText(rctx, text)
+	return result
+}
+
+func (a *TracingAppLayer) PromoteGuestToUser(rctx request.CTX, user *model.User, requestorId string) *model.AppError {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.PromoteGuestToUser")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.PromoteGuestToUser(rctx, user, requestorId)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) PropertyService() *properties.PropertyService {
+	return a.app.PropertyService()
+}
+
+func (a *TracingAppLayer) Publish(message *model.WebSocketEvent) {
+	_, span := tracing.Start(a.ctx, "App.Publish")
+	defer span.End()
+
+	a.app.Publish(message)
+}
+
+func (a *TracingAppLayer) PublishScheduledPostEvent(rctx request.CTX, eventType model.WebsocketEventType, scheduledPost *model.ScheduledPost, connectionId string) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.PublishScheduledPostEvent")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	a.app.PublishScheduledPostEvent(rctx, eventType, scheduledPost, connectionId)
+}
+
+func (a *TracingAppLayer) PublishUserTyping(userID string, channelID string, parentId string) *model.AppError {
+	_, span := tracing.Start(a.ctx, "App.PublishUserTyping")
+	defer span.End()
+
+	err := a.app.PublishUserTyping(userID, channelID, parentId)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) PurgeElasticsearchIndexes(rctx request.CTX, indexes []string) *model.AppError {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.PurgeElasticsearchIndexes")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.PurgeElasticsearchIndexes(rctx, indexes)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) QueryLogs(rctx request.CTX, page int, perPage int, logFilter *model.LogFilter) (map[string][]string, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.QueryLogs")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.QueryLogs(rctx, page, perPage, logFilter)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) ReadFile(path string) ([]byte, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.ReadFile")
+	defer span.End()
+
+	result, err := a.app.ReadFile(path)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) ReattachPlugin(manifest *model.Manifest, pluginReattachConfig *model.PluginReattachConfig) *model.AppError {
+	_, span := tracing.Start(a.ctx, "App.ReattachPlugin")
+	defer span.End()
+
+	err := a.app.ReattachPlugin(manifest, pluginReattachConfig)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) RecycleDatabaseConnection(rctx request.CTX) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.RecycleDatabaseConnection")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	a.app.RecycleDatabaseConnection(rctx)
+}
+
+func (a *TracingAppLayer) RegenCommandToken(cmd *model.Command) (*model.Command, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.RegenCommandToken")
+	defer span.End()
+
+	result, err := a.app.RegenCommandToken(cmd)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) RegenEventSubscriptionSecret(subscription *model.EventSubscription) (*model.EventSubscription, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.RegenEventSubscriptionSecret")
+	defer span.End()
+
+	result, err := a.app.RegenEventSubscriptionSecret(subscription)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) RegenOutgoingWebhookToken(hook *model.OutgoingWebhook) (*model.OutgoingWebhook, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.RegenOutgoingWebhookToken")
+	defer span.End()
+
+	result, err := a.app.RegenOutgoingWebhookToken(hook)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) RegenerateOAuthAppSecret(app *model.OAuthApp) (*model.OAuthApp, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.RegenerateOAuthAppSecret")
+	defer span.End()
+
+	result, err := a.app.RegenerateOAuthAppSecret(app)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) RegenerateTeamInviteId(teamID string) (*model.Team, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.RegenerateTeamInviteId")
+	defer span.End()
+
+	result, err := a.app.RegenerateTeamInviteId(teamID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) RegisterOAuthClient(rctx request.CTX, req *model.ClientRegistrationRequest, userID string) (*model.OAuthApp, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.RegisterOAuthClient")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.RegisterOAuthClient(rctx, req, userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) RegisterPerformanceReport(rctx request.CTX, report *model.PerformanceReport) *model.AppError {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.RegisterPerformanceReport")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.RegisterPerformanceReport(rctx, report)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) RegisterPluginCommand(pluginID string, command *model.Command) error {
+	_, span := tracing.Start(a.ctx, "App.RegisterPluginCommand")
+	defer span.End()
+
+	err := a.app.RegisterPluginCommand(pluginID, command)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) RegisterPluginForSharedChannels(rctx request.CTX, opts model.RegisterPluginOpts) (string, error) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.RegisterPluginForSharedChannels")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.RegisterPluginForSharedChannels(rctx, opts)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) ReloadConfig() error {
+	_, span := tracing.Start(a.ctx, "App.ReloadConfig")
+	defer span.End()
+
+	err := a.app.ReloadConfig()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) RemoveAllDeactivatedMembersFromChannel(rctx request.CTX, channel *model.Channel) *model.AppError {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.RemoveAllDeactivatedMembersFromChannel")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.RemoveAllDeactivatedMembersFromChannel(rctx, channel)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) RemoveAuditLogCertificate(rctx request.CTX) *model.AppError {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.RemoveAuditLogCertificate")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.RemoveAuditLogCertificate(rctx)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) RemoveChannelsFromRetentionPolicy(policyID string, channelIDs []string) *model.AppError {
+	_, span := tracing.Start(a.ctx, "App.RemoveChannelsFromRetentionPolicy")
+	defer span.End()
+
+	err := a.app.RemoveChannelsFromRetentionPolicy(policyID, channelIDs)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) RemoveConfigListener(id string) {
+	_, span := tracing.Start(a.ctx, "App.RemoveConfigListener")
+	defer span.End()
+
+	a.app.RemoveConfigListener(id)
+}
+
+func (a *TracingAppLayer) RemoveCustomStatus(rctx request.CTX, userID string) *model.AppError {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.RemoveCustomStatus")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.RemoveCustomStatus(rctx, userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) RemoveDirectory(path string) *model.AppError {
+	_, span := tracing.Start(a.ctx, "App.RemoveDirectory")
+	defer span.End()
+
+	err := a.app.RemoveDirectory(path)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) RemoveExportFile(path string) *model.AppError {
+	_, span := tracing.Start(a.ctx, "App.RemoveExportFile")
+	defer span.End()
+
+	err := a.app.RemoveExportFile(path)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) RemoveFile(path string) *model.AppError {
+	_, span := tracing.Start(a.ctx, "App.RemoveFile")
+	defer span.End()
+
+	err := a.app.RemoveFile(path)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) RemoveFileFromFileStore(rctx request.CTX, path string) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.RemoveFileFromFileStore")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	a.app.RemoveFileFromFileStore(rctx, path)
+}
+
+func (a *TracingAppLayer) RemoveFilesFromFileStore(rctx request.CTX, fileInfos []*model.FileInfo) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.RemoveFilesFromFileStore")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	a.app.RemoveFilesFromFileStore(rctx, fileInfos)
+}
+
+func (a *TracingAppLayer) RemoveLdapPrivateCertificate() *model.AppError {
+	_, span := tracing.Start(a.ctx, "App.RemoveLdapPrivateCertificate")
+	defer span.End()
+
+	err := a.app.RemoveLdapPrivateCertificate()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) RemoveLdapPublicCertificate() *model.AppError {
+	_, span := tracing.Start(a.ctx, "App.RemoveLdapPublicCertificate")
+	defer span.End()
+
+	err := a.app.RemoveLdapPublicCertificate()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) RemoveMembershipsFromUnlinkedSyncable(rctx request.CTX, syncableID string, syncableType model.GroupSyncableType) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.RemoveMembershipsFromUnlinkedSyncable")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	a.app.RemoveMembershipsFromUnlinkedSyncable(rctx, syncableID, syncableType)
+}
+
+func (a *TracingAppLayer) RemoveNotifications(rctx request.CTX, post *model.Post, channel *model.Channel) error {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.RemoveNotifications")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.RemoveNotifications(rctx, post, channel)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) RemoveRecentCustomStatus(rctx request.CTX, userID string, status *model.CustomStatus) *model.AppError {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.RemoveRecentCustomStatus")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.RemoveRecentCustomStatus(rctx, userID, status)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) RemoveSamlIdpCertificate() *model.AppError {
+	_, span := tracing.Start(a.ctx, "App.RemoveSamlIdpCertificate")
+	defer span.End()
+
+	err := a.app.RemoveSamlIdpCertificate()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) RemoveSamlPrivateCertificate() *model.AppError {
+	_, span := tracing.Start(a.ctx, "App.RemoveSamlPrivateCertificate")
+	defer span.End()
+
+	err := a.app.RemoveSamlPrivateCertificate()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) RemoveSamlPublicCertificate() *model.AppError {
+	_, span := tracing.Start(a.ctx, "App.RemoveSamlPublicCertificate")
+	defer span.End()
+
+	err := a.app.RemoveSamlPublicCertificate()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) RemoveTeamIcon(teamID string) *model.AppError {
+	_, span := tracing.Start(a.ctx, "App.RemoveTeamIcon")
+	defer span.End()
+
+	err := a.app.RemoveTeamIcon(teamID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) RemoveTeamsFromRetentionPolicy(policyID string, teamIDs []string) *model.AppError {
+	_, span := tracing.Start(a.ctx, "App.RemoveTeamsFromRetentionPolicy")
+	defer span.End()
+
+	err := a.app.RemoveTeamsFromRetentionPolicy(policyID, teamIDs)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) RemoveUserFromChannel(rctx request.CTX, userIDToRemove string, removerUserId string, channel *model.Channel) *model.AppError {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.RemoveUserFromChannel")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.RemoveUserFromChannel(rctx, userIDToRemove, removerUserId, channel)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) RemoveUserFromTeam(rctx request.CTX, teamID string, userID string, requestorId string) *model.AppError {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.RemoveUserFromTeam")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.RemoveUserFromTeam(rctx, teamID, userID, requestorId)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) RemoveUsersFromChannelNotMemberOfTeam(rctx request.CTX, remover *model.User, channel *model.Channel, team *model.Team) *model.AppError {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.RemoveUsersFromChannelNotMemberOfTeam")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.RemoveUsersFromChannelNotMemberOfTeam(rctx, remover, channel, team)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) RenameChannel(rctx request.CTX, channel *model.Channel, newChannelName string, newDisplayName string) (*model.Channel, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.RenameChannel")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.RenameChannel(rctx, channel, newChannelName, newDisplayName)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) RenameTeam(team *model.Team, newTeamName string, newDisplayName string) (*model.Team, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.RenameTeam")
+	defer span.End()
+
+	result, err := a.app.RenameTeam(team, newTeamName, newDisplayName)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) ResetPasswordFailedAttempts(rctx request.CTX, user *model.User) *model.AppError {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.ResetPasswordFailedAttempts")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.ResetPasswordFailedAttempts(rctx, user)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) ResetPasswordFromToken(rctx request.CTX, userSuppliedTokenString string, newPassword string) *model.AppError {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.ResetPasswordFromToken")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.ResetPasswordFromToken(rctx, userSuppliedTokenString, newPassword)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) ResetPermissionsSystem() *model.AppError {
+	_, span := tracing.Start(a.ctx, "App.ResetPermissionsSystem")
+	defer span.End()
+
+	err := a.app.ResetPermissionsSystem()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) ResetSamlAuthDataToEmail(includeDeleted bool, dryRun bool, userIDs []string) (int, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.ResetSamlAuthDataToEmail")
+	defer span.End()
+
+	result, err := a.app.ResetSamlAuthDataToEmail(includeDeleted, dryRun, userIDs)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) ResolvePersistentNotification(rctx request.CTX, post *model.Post, loggedInUserID string) *model.AppError {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.ResolvePersistentNotification")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.ResolvePersistentNotification(rctx, post, loggedInUserID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) RestoreChannel(rctx request.CTX, channel *model.Channel, userID string) (*model.Channel, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.RestoreChannel")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.RestoreChannel(rctx, channel, userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) RestoreGroup(groupID string) (*model.Group, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.RestoreGroup")
+	defer span.End()
+
+	result, err := a.app.RestoreGroup(groupID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) RestorePostVersion(rctx request.CTX, userID string, postID string, restoreVersionID string) (*model.Post, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.RestorePostVersion")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.RestorePostVersion(rctx, userID, postID, restoreVersionID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) RestoreTeam(teamID string) *model.AppError {
+	_, span := tracing.Start(a.ctx, "App.RestoreTeam")
+	defer span.End()
+
+	err := a.app.RestoreTeam(teamID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) RestrictUsersGetByPermissions(rctx request.CTX, userID string, options *model.UserGetOptions) (*model.UserGetOptions, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.RestrictUsersGetByPermissions")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.RestrictUsersGetByPermissions(rctx, userID, options)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) RestrictUsersSearchByPermissions(rctx request.CTX, userID string, options *model.UserSearchOptions) (*model.UserSearchOptions, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.RestrictUsersSearchByPermissions")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.RestrictUsersSearchByPermissions(rctx, userID, options)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) RevokeAccessToken(rctx request.CTX, token string) *model.AppError {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.RevokeAccessToken")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.RevokeAccessToken(rctx, token)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) RevokeAllSessions(rctx request.CTX, userID string) *model.AppError {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.RevokeAllSessions")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.RevokeAllSessions(rctx, userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) RevokeSession(rctx request.CTX, session *model.Session) *model.AppError {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.RevokeSession")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.RevokeSession(rctx, session)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) RevokeSessionById(rctx request.CTX, sessionID string) *model.AppError {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.RevokeSessionById")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.RevokeSessionById(rctx, sessionID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) RevokeSessionsForDeviceId(rctx request.CTX, userID string, deviceID string, currentSessionId string) *model.AppError {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.RevokeSessionsForDeviceId")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.RevokeSessionsForDeviceId(rctx, userID, deviceID, currentSessionId)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) RevokeSessionsFromAllUsers() *model.AppError {
+	_, span := tracing.Start(a.ctx, "App.RevokeSessionsFromAllUsers")
+	defer span.End()
+
+	err := a.app.RevokeSessionsFromAllUsers()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) RevokeUserAccessToken(rctx request.CTX, token *model.UserAccessToken) *model.AppError {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.RevokeUserAccessToken")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.RevokeUserAccessToken(rctx, token)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) RewriteMessage(rctx request.CTX, agentID string, message string, action model.RewriteAction, customPrompt string) (*model.RewriteResponse, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.RewriteMessage")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.RewriteMessage(rctx, agentID, message, action, customPrompt)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) RolesGrantPermission(roleNames []string, permissionId string) bool {
+	_, span := tracing.Start(a.ctx, "App.RolesGrantPermission")
+	defer span.End()
+
+	result := a.app.RolesGrantPermission(roleNames, permissionId)
+	return result
+}
+
+func (a *TracingAppLayer) RollbackConfig(cfg *model.Config, authorID string) (*model.Config, *model.Config, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.RollbackConfig")
+	defer span.End()
+
+	result, resultVar1, err := a.app.RollbackConfig(cfg, authorID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, resultVar1, err
+}
+
+func (a *TracingAppLayer) Saml() einterfaces.SamlInterface {
+	return a.app.Saml()
+}
+
+func (a *TracingAppLayer) SanitizePostListMetadataForUser(rctx request.CTX, postList *model.PostList, userID string) (*model.PostList, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.SanitizePostListMetadataForUser")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.SanitizePostListMetadataForUser(rctx, postList, userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) SanitizePostMetadataForUser(rctx request.CTX, post *model.Post, userID string) (*model.Post, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.SanitizePostMetadataForUser")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.SanitizePostMetadataForUser(rctx, post, userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) SanitizeProfile(user *model.User, asAdmin bool) {
+	_, span := tracing.Start(a.ctx, "App.SanitizeProfile")
+	defer span.End()
+
+	a.app.SanitizeProfile(user, asAdmin)
+}
+
+func (a *TracingAppLayer) SanitizeTeam(session model.Session, team *model.Team) *model.Team {
+	_, span := tracing.Start(a.ctx, "App.SanitizeTeam")
+	defer span.End()
+
+	result := a.app.SanitizeTeam(session, team)
+	return result
+}
+
+func (a *TracingAppLayer) SanitizeTeams(session model.Session, teams []*model.Team) []*model.Team {
+	_, span := tracing.Start(a.ctx, "App.SanitizeTeams")
+	defer span.End()
+
+	result := a.app.SanitizeTeams(session, teams)
+	return result
+}
+
+func (a *TracingAppLayer) SanitizedConfig(cfg *model.Config) {
+	_, span := tracing.Start(a.ctx, "App.SanitizedConfig")
+	defer span.End()
+
+	a.app.SanitizedConfig(cfg)
+}
+
+func (a *TracingAppLayer) SaveAcknowledgementForPost(rctx request.CTX, postID string, userID string) (*model.PostAcknowledgement, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.SaveAcknowledgementForPost")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.SaveAcknowledgementForPost(rctx, postID, userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) SaveAcknowledgementForPostWithModel(rctx request.CTX, acknowledgement *model.PostAcknowledgement) (*model.PostAcknowledgement, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.SaveAcknowledgementForPostWithModel")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.SaveAcknowledgementForPostWithModel(rctx, acknowledgement)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) SaveAcknowledgementsForPost(rctx request.CTX, postID string, userIDs []string) ([]*model.PostAcknowledgement, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.SaveAcknowledgementsForPost")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.SaveAcknowledgementsForPost(rctx, postID, userIDs)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) SaveAdminNotification(userId string, notifyData *model.NotifyAdminToUpgradeRequest) *model.AppError {
+	_, span := tracing.Start(a.ctx, "App.SaveAdminNotification")
+	defer span.End()
+
+	err := a.app.SaveAdminNotification(userId, notifyData)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) SaveAdminNotifyData(data *model.NotifyAdminData) (*model.NotifyAdminData, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.SaveAdminNotifyData")
+	defer span.End()
+
+	result, err := a.app.SaveAdminNotifyData(data)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) SaveAndBroadcastStatus(status *model.Status) {
+	_, span := tracing.Start(a.ctx, "App.SaveAndBroadcastStatus")
+	defer span.End()
+
+	a.app.SaveAndBroadcastStatus(status)
+}
+
+func (a *TracingAppLayer) SaveBrandImage(rctx request.CTX, imageData *multipart.FileHeader) *model.AppError {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.SaveBrandImage")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.SaveBrandImage(rctx, imageData)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) SaveComplianceReport(rctx request.CTX, job *model.Compliance) (*model.Compliance, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.SaveComplianceReport")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.SaveComplianceReport(rctx, job)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) SaveConfig(newCfg *model.Config, sendConfigChangeClusterMessage bool) (*model.Config, *model.Config, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.SaveConfig")
+	defer span.End()
+
+	result, resultVar1, err := a.app.SaveConfig(newCfg, sendConfigChangeClusterMessage)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, resultVar1, err
+}
+
+func (a *TracingAppLayer) SaveConfigWithAuthor(newCfg *model.Config, sendConfigChangeClusterMessage bool, authorID string) (*model.Config, *model.Config, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.SaveConfigWithAuthor")
+	defer span.End()
+
+	result, resultVar1, err := a.app.SaveConfigWithAuthor(newCfg, sendConfigChangeClusterMessage, authorID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, resultVar1, err
+}
+
+func (a *TracingAppLayer) SaveContentFlaggingConfig(config model.ContentFlaggingSettingsRequest) *model.AppError {
+	_, span := tracing.Start(a.ctx, "App.SaveContentFlaggingConfig")
+	defer span.End()
+
+	err := a.app.SaveContentFlaggingConfig(config)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) SaveReactionForPost(rctx request.CTX, reaction *model.Reaction) (*model.Reaction, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.SaveReactionForPost")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.SaveReactionForPost(rctx, reaction)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) SaveReportChunk(format string, prefix string, count int, reportData []model.ReportableObject) *model.AppError {
+	_, span := tracing.Start(a.ctx, "App.SaveReportChunk")
+	defer span.End()
+
+	err := a.app.SaveReportChunk(format, prefix, count, reportData)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) SaveScheduledPost(rctx request.CTX, scheduledPost *model.ScheduledPost, connectionId string) (*model.ScheduledPost, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.SaveScheduledPost")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.SaveScheduledPost(rctx, scheduledPost, connectionId)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) SaveSharedChannelRemote(remote *model.SharedChannelRemote) (*model.SharedChannelRemote, error) {
+	_, span := tracing.Start(a.ctx, "App.SaveSharedChannelRemote")
+	defer span.End()
+
+	result, err := a.app.SaveSharedChannelRemote(remote)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) SaveUserTermsOfService(userID string, termsOfServiceId string, accepted bool) *model.AppError {
+	_, span := tracing.Start(a.ctx, "App.SaveUserTermsOfService")
+	defer span.End()
+
+	err := a.app.SaveUserTermsOfService(userID, termsOfServiceId, accepted)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) SaveWorkingHours(rctx request.CTX, workingHours *model.WorkingHours) (*model.WorkingHours, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.SaveWorkingHours")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.SaveWorkingHours(rctx, workingHours)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) SchemesIterator(scope string, batchSize int) func() []*model.Scheme {
+	_, span := tracing.Start(a.ctx, "App.SchemesIterator")
+	defer span.End()
+
+	result := a.app.SchemesIterator(scope, batchSize)
+	return result
+}
+
+func (a *TracingAppLayer) SearchAccessControlPolicies(rctx request.CTX, opts model.AccessControlPolicySearch) ([]*model.AccessControlPolicy, int64, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.SearchAccessControlPolicies")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, resultVar1, err := a.app.SearchAccessControlPolicies(rctx, opts)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, resultVar1, err
+}
+
+func (a *TracingAppLayer) SearchAllChannels(rctx request.CTX, term string, opts model.ChannelSearchOpts) (model.ChannelListWithTeamData, int64, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.SearchAllChannels")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, resultVar1, err := a.app.SearchAllChannels(rctx, term, opts)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, resultVar1, err
+}
+
+func (a *TracingAppLayer) SearchAllTeams(searchOpts *model.TeamSearch) ([]*model.Team, int64, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.SearchAllTeams")
+	defer span.End()
+
+	result, resultVar1, err := a.app.SearchAllTeams(searchOpts)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, resultVar1, err
+}
+
+func (a *TracingAppLayer) SearchAuditLog(search model.AuditLogSearch) ([]*model.AuditLogEntry, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.SearchAuditLog")
+	defer span.End()
+
+	result, err := a.app.SearchAuditLog(search)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) SearchChannels(rctx request.CTX, teamID string, term string) (model.ChannelList, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.SearchChannels")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.SearchChannels(rctx, teamID, term)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) SearchChannelsForUser(rctx request.CTX, userID string, teamID string, term string) (model.ChannelList, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.SearchChannelsForUser")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.SearchChannelsForUser(rctx, userID, teamID, term)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) SearchChannelsUserNotIn(rctx request.CTX, teamID string, userID string, term string) (model.ChannelList, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.SearchChannelsUserNotIn")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.SearchChannelsUserNotIn(rctx, teamID, userID, term)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) SearchEmoji(rctx request.CTX, name string, prefixOnly bool, limit int) ([]*model.Emoji, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.SearchEmoji")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.SearchEmoji(rctx, name, prefixOnly, limit)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) SearchEngine() *searchengine.Broker {
+	return a.app.SearchEngine()
+}
+
+func (a *TracingAppLayer) SearchFilesInTeamForUser(rctx request.CTX, terms string, userId string, teamId string, isOrSearch bool, includeDeletedChannels bool, timeZoneOffset int, page int, perPage int) (*model.FileInfoList, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.SearchFilesInTeamForUser")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.SearchFilesInTeamForUser(rctx, terms, userId, teamId, isOrSearch, includeDeletedChannels, timeZoneOffset, page, perPage)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) SearchGroupChannels(rctx request.CTX, userID string, term string) (model.ChannelList, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.SearchGroupChannels")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.SearchGroupChannels(rctx, userID, term)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) SearchPostsForUser(rctx request.CTX, terms string, userID string, teamID string, isOrSearch bool, includeDeletedChannels bool, timeZoneOffset int, page int, perPage int) (*model.PostSearchResults, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.SearchPostsForUser")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.SearchPostsForUser(rctx, terms, userID, teamID, isOrSearch, includeDeletedChannels, timeZoneOffset, page, perPage)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) SearchPostsInTeam(teamID string, paramsList []*model.SearchParams) (*model.PostList, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.SearchPostsInTeam")
+	defer span.End()
+
+	result, err := a.app.SearchPostsInTeam(teamID, paramsList)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) SearchPrivateTeams(searchOpts *model.TeamSearch) ([]*model.Team, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.SearchPrivateTeams")
+	defer span.End()
+
+	result, err := a.app.SearchPrivateTeams(searchOpts)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) SearchPublicTeams(searchOpts *model.TeamSearch) ([]*model.Team, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.SearchPublicTeams")
+	defer span.End()
+
+	result, err := a.app.SearchPublicTeams(searchOpts)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) SearchReviewers(rctx request.CTX, term string, teamId string) ([]*model.User, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.SearchReviewers")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.SearchReviewers(rctx, term, teamId)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) SearchUserAccessTokens(term string) ([]*model.UserAccessToken, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.SearchUserAccessTokens")
+	defer span.End()
+
+	result, err := a.app.SearchUserAccessTokens(term)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) SearchUsers(rctx request.CTX, props *model.UserSearch, options *model.UserSearchOptions) ([]*model.User, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.SearchUsers")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.SearchUsers(rctx, props, options)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) SearchUsersInChannel(channelID string, term string, options *model.UserSearchOptions) ([]*model.User, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.SearchUsersInChannel")
+	defer span.End()
+
+	result, err := a.app.SearchUsersInChannel(channelID, term, options)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) SearchUsersInGroup(groupID string, term string, options *model.UserSearchOptions) ([]*model.User, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.SearchUsersInGroup")
+	defer span.End()
+
+	result, err := a.app.SearchUsersInGroup(groupID, term, options)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) SearchUsersInTeam(rctx request.CTX, teamID string, term string, options *model.UserSearchOptions) ([]*model.User, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.SearchUsersInTeam")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.SearchUsersInTeam(rctx, teamID, term, options)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) SearchUsersNotInChannel(teamID string, channelID string, term string, options *model.UserSearchOptions) ([]*model.User, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.SearchUsersNotInChannel")
+	defer span.End()
+
+	result, err := a.app.SearchUsersNotInChannel(teamID, channelID, term, options)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) SearchUsersNotInGroup(groupID string, term string, options *model.UserSearchOptions) ([]*model.User, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.SearchUsersNotInGroup")
+	defer span.End()
+
+	result, err := a.app.SearchUsersNotInGroup(groupID, term, options)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) SearchUsersNotInTeam(notInTeamId string, term string, options *model.UserSearchOptions) ([]*model.User, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.SearchUsersNotInTeam")
+	defer span.End()
+
+	result, err := a.app.SearchUsersNotInTeam(notInTeamId, term, options)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) SearchUsersWithoutTeam(term string, options *model.UserSearchOptions) ([]*model.User, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.SearchUsersWithoutTeam")
+	defer span.End()
+
+	result, err := a.app.SearchUsersWithoutTeam(term, options)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) SendAckToPushProxy(rctx request.CTX, ack *model.PushNotificationAck) error {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.SendAckToPushProxy")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.SendAckToPushProxy(rctx, ack)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) SendAutoResponse(rctx request.CTX, channel *model.Channel, receiver *model.User, post *model.Post) (bool, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.SendAutoResponse")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.SendAutoResponse(rctx, channel, receiver, post)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) SendAutoResponseIfNecessary(rctx request.CTX, channel *model.Channel, sender *model.User, post *model.Post) (bool, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.SendAutoResponseIfNecessary")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.SendAutoResponseIfNecessary(rctx, channel, sender, post)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) SendEmailVerification(user *model.User, newEmail string, redirect string) *model.AppError {
+	_, span := tracing.Start(a.ctx, "App.SendEmailVerification")
+	defer span.End()
+
+	err := a.app.SendEmailVerification(user, newEmail, redirect)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) SendEphemeralPost(rctx request.CTX, userID string, post *model.Post) *model.Post {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.SendEphemeralPost")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result := a.app.SendEphemeralPost(rctx, userID, post)
+	return result
+}
+
+func (a *TracingAppLayer) SendIPFiltersChangedEmail(rctx request.CTX, userID string) error {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.SendIPFiltersChangedEmail")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.SendIPFiltersChangedEmail(rctx, userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) SendNotifications(rctx request.CTX, post *model.Post, team *model.Team, channel *model.Channel, sender *model.User, parentPostList *model.PostList, setOnline bool) ([]string, error) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.SendNotifications")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.SendNotifications(rctx, post, team, channel, sender, parentPostList, setOnline)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) SendNotifyAdminPosts(rctx request.CTX, workspaceName string, currentSKU string, trial bool) *model.AppError {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.SendNotifyAdminPosts")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.SendNotifyAdminPosts(rctx, workspaceName, currentSKU, trial)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) SendPasswordReset(rctx request.CTX, email string, siteURL string) (bool, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.SendPasswordReset")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.SendPasswordReset(rctx, email, siteURL)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) SendPersistentNotifications() error {
+	_, span := tracing.Start(a.ctx, "App.SendPersistentNotifications")
+	defer span.End()
+
+	err := a.app.SendPersistentNotifications()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) SendReportToUser(rctx request.CTX, job *model.Job, format string) *model.AppError {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.SendReportToUser")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.SendReportToUser(rctx, job, format)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) SendSubscriptionHistoryEvent(userID string) (*model.SubscriptionHistory, error) {
+	_, span := tracing.Start(a.ctx, "App.SendSubscriptionHistoryEvent")
+	defer span.End()
+
+	result, err := a.app.SendSubscriptionHistoryEvent(userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) SendTestMessage(rctx request.CTX, userID string) (*model.Post, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.SendTestMessage")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.SendTestMessage(rctx, userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) SendTestPushNotification(rctx request.CTX, deviceID string) string {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.SendTestPushNotification")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result := a.app.SendTestPushNotification(rctx, deviceID)
+	return result
+}
+
+func (a *TracingAppLayer) ServeInterPluginRequest(w http.ResponseWriter, r *http.Request, sourcePluginId string, destinationPluginId string) {
+	_, span := tracing.Start(a.ctx, "App.ServeInterPluginRequest")
+	defer span.End()
+
+	a.app.ServeInterPluginRequest(w, r, sourcePluginId, destinationPluginId)
+}
+
+func (a *TracingAppLayer) ServeInternalPluginRequest(userID string, w http.ResponseWriter, r *http.Request, sourcePluginID string, targetPluginID string) {
+	_, span := tracing.Start(a.ctx, "App.ServeInternalPluginRequest")
+	defer span.End()
+
+	a.app.ServeInternalPluginRequest(userID, w, r, sourcePluginID, targetPluginID)
+}
+
+func (a *TracingAppLayer) ServerId() string {
+	return a.app.ServerId()
+}
+
+func (a *TracingAppLayer) SessionHasPermissionTo(session model.Session, permission *model.Permission) bool {
+	_, span := tracing.Start(a.ctx, "App.SessionHasPermissionTo")
+	defer span.End()
+
+	result := a.app.SessionHasPermissionTo(session, permission)
+	return result
+}
+
+func (a *TracingAppLayer) SessionHasPermissionToAndNotRestrictedAdmin(session model.Session, permission *model.Permission) bool {
+	_, span := tracing.Start(a.ctx, "App.SessionHasPermissionToAndNotRestrictedAdmin")
+	defer span.End()
+
+	result := a.app.SessionHasPermissionToAndNotRestrictedAdmin(session, permission)
+	return result
+}
+
+func (a *TracingAppLayer) SessionHasPermissionToAny(session model.Session, permissions []*model.Permission) bool {
+	_, span := tracing.Start(a.ctx, "App.SessionHasPermissionToAny")
+	defer span.End()
+
+	result := a.app.SessionHasPermissionToAny(session, permissions)
+	return result
+}
+
+func (a *TracingAppLayer) SessionHasPermissionToCategory(rctx request.CTX, session model.Session, userID string, teamID string, categoryId string) bool {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.SessionHasPermissionToCategory")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result := a.app.SessionHasPermissionToCategory(rctx, session, userID, teamID, categoryId)
+	return result
+}
+
+func (a *TracingAppLayer) SessionHasPermissionToChannel(rctx request.CTX, session model.Session, channelID string, permission *model.Permission) bool {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.SessionHasPermissionToChannel")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result := a.app.SessionHasPermissionToChannel(rctx, session, channelID, permission)
+	return result
+}
+
+func (a *TracingAppLayer) SessionHasPermissionToChannelByPost(session model.Session, postID string, permission *model.Permission) bool {
+	_, span := tracing.Start(a.ctx, "App.SessionHasPermissionToChannelByPost")
+	defer span.End()
+
+	result := a.app.SessionHasPermissionToChannelByPost(session, postID, permission)
+	return result
+}
+
+func (a *TracingAppLayer) SessionHasPermissionToChannels(rctx request.CTX, session model.Session, channelIDs []string, permission *model.Permission) bool {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.SessionHasPermissionToChannels")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result := a.app.SessionHasPermissionToChannels(rctx, session, channelIDs, permission)
+	return result
+}
+
+func (a *TracingAppLayer) SessionHasPermissionToCreateJob(session model.Session, job *model.Job) (bool, *model.Permission) {
+	_, span := tracing.Start(a.ctx, "App.SessionHasPermissionToCreateJob")
+	defer span.End()
+
+	result, resultVar1 := a.app.SessionHasPermissionToCreateJob(session, job)
+	return result, resultVar1
+}
+
+func (a *TracingAppLayer) SessionHasPermissionToGroup(session model.Session, groupID string, permission *model.Permission) bool {
+	_, span := tracing.Start(a.ctx, "App.SessionHasPermissionToGroup")
+	defer span.End()
+
+	result := a.app.SessionHasPermissionToGroup(session, groupID, permission)
+	return result
+}
+
+func (a *TracingAppLayer) SessionHasPermissionToManageBot(rctx request.CTX, session model.Session, botUserId string) *model.AppError {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.SessionHasPermissionToManageBot")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.SessionHasPermissionToManageBot(rctx, session, botUserId)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) SessionHasPermissionToManageJob(session model.Session, job *model.Job) (bool, *model.Permission) {
+	_, span := tracing.Start(a.ctx, "App.SessionHasPermissionToManageJob")
+	defer span.End()
+
+	result, resultVar1 := a.app.SessionHasPermissionToManageJob(session, job)
+	return result, resultVar1
+}
+
+func (a *TracingAppLayer) SessionHasPermissionToReadChannel(rctx request.CTX, session model.Session, channel *model.Channel) bool {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.SessionHasPermissionToReadChannel")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result := a.app.SessionHasPermissionToReadChannel(rctx, session, channel)
+	return result
+}
+
+func (a *TracingAppLayer) SessionHasPermissionToReadJob(session model.Session, jobType string) (bool, *model.Permission) {
+	_, span := tracing.Start(a.ctx, "App.SessionHasPermissionToReadJob")
+	defer span.End()
+
+	result, resultVar1 := a.app.SessionHasPermissionToReadJob(session, jobType)
+	return result, resultVar1
+}
+
+func (a *TracingAppLayer) SessionHasPermissionToTeam(session model.Session, teamID string, permission *model.Permission) bool {
+	_, span := tracing.Start(a.ctx, "App.SessionHasPermissionToTeam")
+	defer span.End()
+
+	result := a.app.SessionHasPermissionToTeam(session, teamID, permission)
+	return result
+}
+
+func (a *TracingAppLayer) SessionHasPermissionToTeams(rctx request.CTX, session model.Session, teamIDs []string, permission *model.Permission) bool {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.SessionHasPermissionToTeams")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result := a.app.SessionHasPermissionToTeams(rctx, session, teamIDs, permission)
+	return result
+}
+
+func (a *TracingAppLayer) SessionHasPermissionToUser(session model.Session, userID string) bool {
+	_, span := tracing.Start(a.ctx, "App.SessionHasPermissionToUser")
+	defer span.End()
+
+	result := a.app.SessionHasPermissionToUser(session, userID)
+	return result
+}
+
+func (a *TracingAppLayer) SessionHasPermissionToUserOrBot(rctx request.CTX, session model.Session, userID string) bool {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.SessionHasPermissionToUserOrBot")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result := a.app.SessionHasPermissionToUserOrBot(rctx, session, userID)
+	return result
+}
+
+func (a *TracingAppLayer) SessionIsRegistered(session model.Session) bool {
+	_, span := tracing.Start(a.ctx, "App.SessionIsRegistered")
+	defer span.End()
+
+	result := a.app.SessionIsRegistered(session)
+	return result
+}
+
+func (a *TracingAppLayer) SetActiveChannel(rctx request.CTX, userID string, channelID string) *model.AppError {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.SetActiveChannel")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.SetActiveChannel(rctx, userID, channelID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) SetAutoResponderStatus(rctx request.CTX, user *model.User, oldNotifyProps model.StringMap) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.SetAutoResponderStatus")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	a.app.SetAutoResponderStatus(rctx, user, oldNotifyProps)
+}
+
+func (a *TracingAppLayer) SetChannels(ch *app.Channels) {
+	_, span := tracing.Start(a.ctx, "App.SetChannels")
+	defer span.End()
+
+	a.app.SetChannels(ch)
+}
+
+func (a *TracingAppLayer) SetCustomStatus(rctx request.CTX, userID string, cs *model.CustomStatus) *model.AppError {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.SetCustomStatus")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.SetCustomStatus(rctx, userID, cs)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) SetDefaultProfileImage(rctx request.CTX, user *model.User) *model.AppError {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.SetDefaultProfileImage")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.SetDefaultProfileImage(rctx, user)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) SetExtraSessionProps(session *model.Session, newProps map[string]string) *model.AppError {
+	_, span := tracing.Start(a.ctx, "App.SetExtraSessionProps")
+	defer span.End()
+
+	err := a.app.SetExtraSessionProps(session, newProps)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) SetFileSearchableContent(rctx request.CTX, fileID string, data string) *model.AppError {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.SetFileSearchableContent")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.SetFileSearchableContent(rctx, fileID, data)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) SetPhase2PermissionsMigrationStatus(isComplete bool) error {
+	_, span := tracing.Start(a.ctx, "App.SetPhase2PermissionsMigrationStatus")
+	defer span.End()
+
+	err := a.app.SetPhase2PermissionsMigrationStatus(isComplete)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) SetPluginKey(pluginID string, key string, value []byte) *model.AppError {
+	_, span := tracing.Start(a.ctx, "App.SetPluginKey")
+	defer span.End()
+
+	err := a.app.SetPluginKey(pluginID, key, value)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) SetPluginKeyWithExpiry(pluginID string, key string, value []byte, expireInSeconds int64) *model.AppError {
+	_, span := tracing.Start(a.ctx, "App.SetPluginKeyWithExpiry")
+	defer span.End()
+
+	err := a.app.SetPluginKeyWithExpiry(pluginID, key, value, expireInSeconds)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) SetPluginKeyWithOptions(pluginID string, key string, value []byte, options model.PluginKVSetOptions) (bool, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.SetPluginKeyWithOptions")
+	defer span.End()
+
+	result, err := a.app.SetPluginKeyWithOptions(pluginID, key, value, options)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) SetPostReminder(rctx request.CTX, postID string, userID string, targetTime int64) *model.AppError {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.SetPostReminder")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.SetPostReminder(rctx, postID, userID, targetTime)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) SetProfileImage(rctx request.CTX, userID string, imageData *multipart.FileHeader) *model.AppError {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.SetProfileImage")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.SetProfileImage(rctx, userID, imageData)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) SetProfileImageFromFile(rctx request.CTX, userID string, file io.ReadSeeker) *model.AppError {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.SetProfileImageFromFile")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.SetProfileImageFromFile(rctx, userID, file)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) SetProfileImageFromMultiPartFile(rctx request.CTX, userID string, file multipart.File) *model.AppError {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.SetProfileImageFromMultiPartFile")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.SetProfileImageFromMultiPartFile(rctx, userID, file)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) SetRemoteClusterLastPingAt(remoteClusterId string) *model.AppError {
+	_, span := tracing.Start(a.ctx, "App.SetRemoteClusterLastPingAt")
+	defer span.End()
+
+	err := a.app.SetRemoteClusterLastPingAt(remoteClusterId)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) SetSamlIdpCertificateFromMetadata(data []byte) *model.AppError {
+	_, span := tracing.Start(a.ctx, "App.SetSamlIdpCertificateFromMetadata")
+	defer span.End()
+
+	err := a.app.SetSamlIdpCertificateFromMetadata(data)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) SetScheduledPostPaused(rctx request.CTX, userId string, scheduledPostId string, paused bool, connectionId string) (*model.ScheduledPost, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.SetScheduledPostPaused")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.SetScheduledPostPaused(rctx, userId, scheduledPostId, paused, connectionId)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) SetSearchEngine(se *searchengine.Broker) {
+	_, span := tracing.Start(a.ctx, "App.SetSearchEngine")
+	defer span.End()
+
+	a.app.SetSearchEngine(se)
+}
+
+func (a *TracingAppLayer) SetServer(srv *app.Server) {
+	_, span := tracing.Start(a.ctx, "App.SetServer")
+	defer span.End()
+
+	a.app.SetServer(srv)
+}
+
+func (a *TracingAppLayer) SetSessionExpireInHours(session *model.Session, hours int) {
+	_, span := tracing.Start(a.ctx, "App.SetSessionExpireInHours")
+	defer span.End()
+
+	a.app.SetSessionExpireInHours(session, hours)
+}
+
+func (a *TracingAppLayer) SetStatusAwayIfNeeded(userID string, manual bool) {
+	_, span := tracing.Start(a.ctx, "App.SetStatusAwayIfNeeded")
+	defer span.End()
+
+	a.app.SetStatusAwayIfNeeded(userID, manual)
+}
+
+func (a *TracingAppLayer) SetStatusDoNotDisturb(userID string) {
+	_, span := tracing.Start(a.ctx, "App.SetStatusDoNotDisturb")
+	defer span.End()
+
+	a.app.SetStatusDoNotDisturb(userID)
+}
+
+func (a *TracingAppLayer) SetStatusDoNotDisturbTimed(userId string, endtime int64) {
+	_, span := tracing.Start(a.ctx, "App.SetStatusDoNotDisturbTimed")
+	defer span.End()
+
+	a.app.SetStatusDoNotDisturbTimed(userId, endtime)
+}
+
+func (a *TracingAppLayer) SetStatusLastActivityAt(userID string, activityAt int64) {
+	_, span := tracing.Start(a.ctx, "App.SetStatusLastActivityAt")
+	defer span.End()
+
+	a.app.SetStatusLastActivityAt(userID, activityAt)
+}
+
+func (a *TracingAppLayer) SetStatusOffline(userID string, manual bool, force bool) {
+	_, span := tracing.Start(a.ctx, "App.SetStatusOffline")
+	defer span.End()
+
+	a.app.SetStatusOffline(userID, manual, force)
+}
+
+func (a *TracingAppLayer) SetStatusOnline(userID string, manual bool) {
+	_, span := tracing.Start(a.ctx, "App.SetStatusOnline")
+	defer span.End()
+
+	a.app.SetStatusOnline(userID, manual)
+}
+
+func (a *TracingAppLayer) SetStatusOutOfOffice(userID string) {
+	_, span := tracing.Start(a.ctx, "App.SetStatusOutOfOffice")
+	defer span.End()
+
+	a.app.SetStatusOutOfOffice(userID)
+}
+
+func (a *TracingAppLayer) SetTeamIcon(rctx request.CTX, teamID string, imageData *multipart.FileHeader) *model.AppError {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.SetTeamIcon")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.SetTeamIcon(rctx, teamID, imageData)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) SetTeamIconFromFile(rctx request.CTX, team *model.Team, file io.ReadSeeker) *model.AppError {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.SetTeamIconFromFile")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.SetTeamIconFromFile(rctx, team, file)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) SetTeamIconFromMultiPartFile(rctx request.CTX, teamID string, file multipart.File) *model.AppError {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.SetTeamIconFromMultiPartFile")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.SetTeamIconFromMultiPartFile(rctx, teamID, file)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) ShareChannel(rctx request.CTX, sc *model.SharedChannel) (*model.SharedChannel, error) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.ShareChannel")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.ShareChannel(rctx, sc)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) ShouldSendPushNotification(rctx request.CTX, user *model.User, channelNotifyProps model.StringMap, wasMentioned bool, status *model.Status, post *model.Post, isGM bool) bool {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.ShouldSendPushNotification")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result := a.app.ShouldSendPushNotification(rctx, user, channelNotifyProps, wasMentioned, status, post, isGM)
+	return result
+}
+
+func (a *TracingAppLayer) SkipNextScheduledPostOccurrence(rctx request.CTX, userId string, scheduledPostId string, connectionId string) (*model.ScheduledPost, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.SkipNextScheduledPostOccurrence")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.SkipNextScheduledPostOccurrence(rctx, userId, scheduledPostId, connectionId)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) SlackImport(rctx request.CTX, fileData multipart.File, fileSize int64, teamID string) (*model.AppError, *bytes.Buffer) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.SlackImport")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err, resultVar1 := a.app.SlackImport(rctx, fileData, fileSize, teamID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err, resultVar1
+}
+
+func (a *TracingAppLayer) SoftDeleteTeam(teamID string) *model.AppError {
+	_, span := tracing.Start(a.ctx, "App.SoftDeleteTeam")
+	defer span.End()
+
+	err := a.app.SoftDeleteTeam(teamID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) Srv() *app.Server {
+	return a.app.Srv()
+}
+
+func (a *TracingAppLayer) StartUsersBatchExport(rctx request.CTX, ro *model.UserReportOptions, startAt int64, endAt int64) *model.AppError {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.StartUsersBatchExport")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.StartUsersBatchExport(rctx, ro, startAt, endAt)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) SubmitInteractiveDialog(rctx request.CTX, request model.SubmitDialogRequest) (*model.SubmitDialogResponse, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.SubmitInteractiveDialog")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.SubmitInteractiveDialog(rctx, request)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) SummarizeChannel(c request.CTX, req *app.SummarizationRequest) (*app.SummarizationResponse, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(c, a.ctx), "App.SummarizeChannel")
+	if c != nil {
+		c = c.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.SummarizeChannel(c, req)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) SummarizeThread(c request.CTX, req *app.SummarizationRequest) (*app.SummarizationResponse, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(c, a.ctx), "App.SummarizeThread")
+	if c != nil {
+		c = c.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.SummarizeThread(c, req)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) SwitchEmailToLdap(rctx request.CTX, email string, password string, code string, ldapLoginId string, ldapPassword string) (string, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.SwitchEmailToLdap")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.SwitchEmailToLdap(rctx, email, password, code, ldapLoginId, ldapPassword)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) SwitchEmailToOAuth(rctx request.CTX, w http.ResponseWriter, r *http.Request, email string, password string, code string, service string) (string, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.SwitchEmailToOAuth")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.SwitchEmailToOAuth(rctx, w, r, email, password, code, service)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) SwitchLdapToEmail(rctx request.CTX, ldapPassword string, code string, email string, newPassword string) (string, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.SwitchLdapToEmail")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.SwitchLdapToEmail(rctx, ldapPassword, code, email, newPassword)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) SwitchOAuthToEmail(rctx request.CTX, email string, password string, requesterId string) (string, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.SwitchOAuthToEmail")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.SwitchOAuthToEmail(rctx, email, password, requesterId)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) SyncLdap(rctx request.CTX) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.SyncLdap")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	a.app.SyncLdap(rctx)
+}
+
+func (a *TracingAppLayer) SyncPlugins() *model.AppError {
+	_, span := tracing.Start(a.ctx, "App.SyncPlugins")
+	defer span.End()
+
+	err := a.app.SyncPlugins()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) SyncRolesAndMembership(rctx request.CTX, syncableID string, syncableType model.GroupSyncableType, groupID string) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.SyncRolesAndMembership")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	a.app.SyncRolesAndMembership(rctx, syncableID, syncableType, groupID)
+}
+
+func (a *TracingAppLayer) SyncSharedChannel(channelID string) error {
+	_, span := tracing.Start(a.ctx, "App.SyncSharedChannel")
+	defer span.End()
+
+	err := a.app.SyncSharedChannel(channelID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) SyncSyncableRoles(rctx request.CTX, syncableID string, syncableType model.GroupSyncableType) *model.AppError {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.SyncSyncableRoles")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.SyncSyncableRoles(rctx, syncableID, syncableType)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) TeamMembersMinusGroupMembers(teamID string, groupIDs []string, page int, perPage int) ([]*model.UserWithGroups, int64, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.TeamMembersMinusGroupMembers")
+	defer span.End()
+
+	result, resultVar1, err := a.app.TeamMembersMinusGroupMembers(teamID, groupIDs, page, perPage)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, resultVar1, err
+}
+
+func (a *TracingAppLayer) TeamMembersToAdd(since int64, teamID *string, reAddRemovedMembers bool) ([]*model.UserTeamIDPair, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.TeamMembersToAdd")
+	defer span.End()
+
+	result, err := a.app.TeamMembersToAdd(since, teamID, reAddRemovedMembers)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) TeamMembersToRemove(teamID *string) ([]*model.TeamMember, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.TeamMembersToRemove")
+	defer span.End()
+
+	result, err := a.app.TeamMembersToRemove(teamID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) TestElasticsearch(rctx request.CTX, cfg *model.Config) *model.AppError {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.TestElasticsearch")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.TestElasticsearch(rctx, cfg)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) TestEmail(rctx request.CTX, userID string, cfg *model.Config) *model.AppError {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.TestEmail")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.TestEmail(rctx, userID, cfg)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) TestExpression(rctx request.CTX, expression string, opts model.SubjectSearchOptions) ([]*model.User, int64, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.TestExpression")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, resultVar1, err := a.app.TestExpression(rctx, expression, opts)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, resultVar1, err
+}
+
+func (a *TracingAppLayer) TestExpressionWithChannelContext(rctx request.CTX, expression string, opts model.SubjectSearchOptions) ([]*model.User, int64, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.TestExpressionWithChannelContext")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, resultVar1, err := a.app.TestExpressionWithChannelContext(rctx, expression, opts)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, resultVar1, err
+}
+
+func (a *TracingAppLayer) TestFileStoreConnection() *model.AppError {
+	_, span := tracing.Start(a.ctx, "App.TestFileStoreConnection")
+	defer span.End()
+
+	err := a.app.TestFileStoreConnection()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) TestFileStoreConnectionWithConfig(cfg *model.FileSettings) *model.AppError {
+	_, span := tracing.Start(a.ctx, "App.TestFileStoreConnectionWithConfig")
+	defer span.End()
+
+	err := a.app.TestFileStoreConnectionWithConfig(cfg)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) TestLdap(rctx request.CTX) *model.AppError {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.TestLdap")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.TestLdap(rctx)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) TestLdapConnection(rctx request.CTX, settings model.LdapSettings) *model.AppError {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.TestLdapConnection")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.TestLdapConnection(rctx, settings)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) TestLdapDiagnostics(rctx request.CTX, testType model.LdapDiagnosticTestType, settings model.LdapSettings) ([]model.LdapDiagnosticResult, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.TestLdapDiagnostics")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.TestLdapDiagnostics(rctx, testType, settings)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) TestNotificationRule(rctx request.CTX, userID string, rule *model.NotificationRule) (*model.NotificationRuleTestResult, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.TestNotificationRule")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.TestNotificationRule(rctx, userID, rule)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) TestSiteURL(rctx request.CTX, siteURL string) *model.AppError {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.TestSiteURL")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.TestSiteURL(rctx, siteURL)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) Timezones() *timezones.Timezones {
+	return a.app.Timezones()
+}
+
+func (a *TracingAppLayer) ToggleMuteChannel(rctx request.CTX, channelID string, userID string) (*model.ChannelMember, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.ToggleMuteChannel")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.ToggleMuteChannel(rctx, channelID, userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) TotalWebsocketConnections() int {
+	return a.app.TotalWebsocketConnections()
+}
+
+func (a *TracingAppLayer) TransformIncomingWebhookPayload(hook *model.IncomingWebhook, header http.Header, body []byte) (*model.IncomingWebhookRequest, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.TransformIncomingWebhookPayload")
+	defer span.End()
+
+	result, err := a.app.TransformIncomingWebhookPayload(hook, header, body)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) TriggerWebhook(rctx request.CTX, payload *model.OutgoingWebhookPayload, hook *model.OutgoingWebhook, post *model.Post, channel *model.Channel) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.TriggerWebhook")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	a.app.TriggerWebhook(rctx, payload, hook, post, channel)
+}
+
+func (a *TracingAppLayer) UnassignPoliciesFromChannels(rctx request.CTX, policyID string, channelIDs []string) *model.AppError {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.UnassignPoliciesFromChannels")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.UnassignPoliciesFromChannels(rctx, policyID, channelIDs)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) UninviteRemoteFromChannel(channelID string, remoteID string) error {
+	_, span := tracing.Start(a.ctx, "App.UninviteRemoteFromChannel")
+	defer span.End()
+
+	err := a.app.UninviteRemoteFromChannel(channelID, remoteID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) UnregisterPluginCommand(pluginID string, teamID string, trigger string) {
+	_, span := tracing.Start(a.ctx, "App.UnregisterPluginCommand")
+	defer span.End()
+
+	a.app.UnregisterPluginCommand(pluginID, teamID, trigger)
+}
+
+func (a *TracingAppLayer) UnregisterPluginForSharedChannels(pluginID string) error {
+	_, span := tracing.Start(a.ctx, "App.UnregisterPluginForSharedChannels")
+	defer span.End()
+
+	err := a.app.UnregisterPluginForSharedChannels(pluginID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) UnshareChannel(channelID string) (bool, error) {
+	_, span := tracing.Start(a.ctx, "App.UnshareChannel")
+	defer span.End()
+
+	result, err := a.app.UnshareChannel(channelID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) UpdateAccessControlPoliciesActive(rctx request.CTX, updates []model.AccessControlPolicyActiveUpdate) ([]*model.AccessControlPolicy, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.UpdateAccessControlPoliciesActive")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.UpdateAccessControlPoliciesActive(rctx, updates)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) UpdateAccessControlPolicyActive(rctx request.CTX, policyID string, active bool) *model.AppError {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.UpdateAccessControlPolicyActive")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.UpdateAccessControlPolicyActive(rctx, policyID, active)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) UpdateActionItem(c request.CTX, actionItemID string, userID string, update *app.ActionItemUpdateRequest) (*model.AIActionItem, error) {
+	ctx, span := tracing.Start(requestContext(c, a.ctx), "App.UpdateActionItem")
+	if c != nil {
+		c = c.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.UpdateActionItem(c, actionItemID, userID, update)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) UpdateActive(rctx request.CTX, user *model.User, active bool) (*model.User, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.UpdateActive")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.UpdateActive(rctx, user, active)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) UpdateBotActive(rctx request.CTX, botUserId string, active bool) (*model.Bot, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.UpdateBotActive")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.UpdateBotActive(rctx, botUserId, active)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) UpdateBotOwner(rctx request.CTX, botUserId string, newOwnerId string) (*model.Bot, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.UpdateBotOwner")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.UpdateBotOwner(rctx, botUserId, newOwnerId)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) UpdateChannel(rctx request.CTX, channel *model.Channel) (*model.Channel, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.UpdateChannel")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.UpdateChannel(rctx, channel)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) UpdateChannelBookmark(rctx request.CTX, updateBookmark *model.ChannelBookmarkWithFileInfo, connectionId string) (*model.UpdateChannelBookmarkResponse, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.UpdateChannelBookmark")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.UpdateChannelBookmark(rctx, updateBookmark, connectionId)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) UpdateChannelBookmarkSortOrder(bookmarkId string, channelId string, newIndex int64, connectionId string) ([]*model.ChannelBookmarkWithFileInfo, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.UpdateChannelBookmarkSortOrder")
+	defer span.End()
+
+	result, err := a.app.UpdateChannelBookmarkSortOrder(bookmarkId, channelId, newIndex, connectionId)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) UpdateChannelMemberNotifyProps(rctx request.CTX, data map[string]string, channelID string, userID string) (*model.ChannelMember, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.UpdateChannelMemberNotifyProps")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.UpdateChannelMemberNotifyProps(rctx, data, channelID, userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) UpdateChannelMemberRoles(rctx request.CTX, channelID string, userID string, newRoles string) (*model.ChannelMember, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.UpdateChannelMemberRoles")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.UpdateChannelMemberRoles(rctx, channelID, userID, newRoles)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) UpdateChannelMemberSchemeRoles(rctx request.CTX, channelID string, userID string, isSchemeGuest bool, isSchemeUser bool, isSchemeAdmin bool) (*model.ChannelMember, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.UpdateChannelMemberSchemeRoles")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.UpdateChannelMemberSchemeRoles(rctx, channelID, userID, isSchemeGuest, isSchemeUser, isSchemeAdmin)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) UpdateChannelPrivacy(rctx request.CTX, oldChannel *model.Channel, user *model.User) (*model.Channel, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.UpdateChannelPrivacy")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.UpdateChannelPrivacy(rctx, oldChannel, user)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) UpdateChannelScheme(rctx request.CTX, channel *model.Channel) (*model.Channel, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.UpdateChannelScheme")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.UpdateChannelScheme(rctx, channel)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) UpdateCommand(oldCmd *model.Command, updatedCmd *model.Command) (*model.Command, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.UpdateCommand")
+	defer span.End()
+
+	result, err := a.app.UpdateCommand(oldCmd, updatedCmd)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) UpdateConfig(f func(*model.Config)) {
+	_, span := tracing.Start(a.ctx, "App.UpdateConfig")
+	defer span.End()
+
+	a.app.UpdateConfig(f)
+}
+
+func (a *TracingAppLayer) UpdateDNDStatusOfUsers() {
+	a.app.UpdateDNDStatusOfUsers()
+}
+
+func (a *TracingAppLayer) UpdateDefaultProfileImage(rctx request.CTX, user *model.User) *model.AppError {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.UpdateDefaultProfileImage")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.UpdateDefaultProfileImage(rctx, user)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) UpdateEphemeralPost(rctx request.CTX, userID string, post *model.Post) *model.Post {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.UpdateEphemeralPost")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result := a.app.UpdateEphemeralPost(rctx, userID, post)
+	return result
+}
+
+func (a *TracingAppLayer) UpdateEventSubscription(rctx request.CTX, oldSubscription *model.EventSubscription, updatedSubscription *model.EventSubscription) (*model.EventSubscription, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.UpdateEventSubscription")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.UpdateEventSubscription(rctx, oldSubscription, updatedSubscription)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) UpdateExpiredDNDStatuses() ([]*model.Status, error) {
+	_, span := tracing.Start(a.ctx, "App.UpdateExpiredDNDStatuses")
+	defer span.End()
+
+	result, err := a.app.UpdateExpiredDNDStatuses()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) UpdateGroup(group *model.Group) (*model.Group, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.UpdateGroup")
+	defer span.End()
+
+	result, err := a.app.UpdateGroup(group)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) UpdateGroupSyncable(groupSyncable *model.GroupSyncable) (*model.GroupSyncable, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.UpdateGroupSyncable")
+	defer span.End()
+
+	result, err := a.app.UpdateGroupSyncable(groupSyncable)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) UpdateHashedPassword(user *model.User, newHashedPassword string) *model.AppError {
+	_, span := tracing.Start(a.ctx, "App.UpdateHashedPassword")
+	defer span.End()
+
+	err := a.app.UpdateHashedPassword(user, newHashedPassword)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) UpdateHashedPasswordByUserId(userID string, newHashedPassword string) *model.AppError {
+	_, span := tracing.Start(a.ctx, "App.UpdateHashedPasswordByUserId")
+	defer span.End()
+
+	err := a.app.UpdateHashedPasswordByUserId(userID, newHashedPassword)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) UpdateIncomingWebhook(oldHook *model.IncomingWebhook, updatedHook *model.IncomingWebhook) (*model.IncomingWebhook, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.UpdateIncomingWebhook")
+	defer span.End()
+
+	result, err := a.app.UpdateIncomingWebhook(oldHook, updatedHook)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) UpdateJobStatus(rctx request.CTX, job *model.Job, newStatus string) *model.AppError {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.UpdateJobStatus")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.UpdateJobStatus(rctx, job, newStatus)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) UpdateMfa(rctx request.CTX, activate bool, userID string, token string) *model.AppError {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.UpdateMfa")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.UpdateMfa(rctx, activate, userID, token)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) UpdateMobileAppBadge(userID string) {
+	_, span := tracing.Start(a.ctx, "App.UpdateMobileAppBadge")
+	defer span.End()
+
+	a.app.UpdateMobileAppBadge(userID)
+}
+
+func (a *TracingAppLayer) UpdateNotificationRules(userID string, rules []*model.NotificationRule) ([]*model.NotificationRule, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.UpdateNotificationRules")
+	defer span.End()
+
+	result, err := a.app.UpdateNotificationRules(userID, rules)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) UpdateOAuthApp(oldApp *model.OAuthApp, updatedApp *model.OAuthApp) (*model.OAuthApp, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.UpdateOAuthApp")
+	defer span.End()
+
+	result, err := a.app.UpdateOAuthApp(oldApp, updatedApp)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) UpdateOAuthUserAttrs(rctx request.CTX, userData io.Reader, user *model.User, provider einterfaces.OAuthProvider, service string, tokenUser *model.User) *model.AppError {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.UpdateOAuthUserAttrs")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.UpdateOAuthUserAttrs(rctx, userData, user, provider, service, tokenUser)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) UpdateOutgoingWebhook(rctx request.CTX, oldHook *model.OutgoingWebhook, updatedHook *model.OutgoingWebhook) (*model.OutgoingWebhook, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.UpdateOutgoingWebhook")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.UpdateOutgoingWebhook(rctx, oldHook, updatedHook)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) UpdatePassword(rctx request.CTX, user *model.User, newPassword string) *model.AppError {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.UpdatePassword")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.UpdatePassword(rctx, user, newPassword)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) UpdatePasswordAsUser(rctx request.CTX, userID string, currentPassword string, newPassword string) *model.AppError {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.UpdatePasswordAsUser")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.UpdatePasswordAsUser(rctx, userID, currentPassword, newPassword)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) UpdatePasswordByUserIdSendEmail(rctx request.CTX, userID string, newPassword string, method string) *model.AppError {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.UpdatePasswordByUserIdSendEmail")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.UpdatePasswordByUserIdSendEmail(rctx, userID, newPassword, method)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) UpdatePasswordSendEmail(rctx request.CTX, user *model.User, newPassword string, method string) *model.AppError {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.UpdatePasswordSendEmail")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.UpdatePasswordSendEmail(rctx, user, newPassword, method)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) UpdatePost(rctx request.CTX, receivedUpdatedPost *model.Post, updatePostOptions *model.UpdatePostOptions) (*model.Post, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.UpdatePost")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.UpdatePost(rctx, receivedUpdatedPost, updatePostOptions)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) UpdatePreferences(rctx request.CTX, userID string, preferences model.Preferences) *model.AppError {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.UpdatePreferences")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.UpdatePreferences(rctx, userID, preferences)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) UpdateProductNotices() *model.AppError {
+	_, span := tracing.Start(a.ctx, "App.UpdateProductNotices")
+	defer span.End()
+
+	err := a.app.UpdateProductNotices()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) UpdateRemoteCluster(rc *model.RemoteCluster) (*model.RemoteCluster, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.UpdateRemoteCluster")
+	defer span.End()
+
+	result, err := a.app.UpdateRemoteCluster(rc)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) UpdateRemoteClusterTopics(remoteClusterId string, topics string) (*model.RemoteCluster, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.UpdateRemoteClusterTopics")
+	defer span.End()
+
+	result, err := a.app.UpdateRemoteClusterTopics(remoteClusterId, topics)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) UpdateRole(role *model.Role) (*model.Role, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.UpdateRole")
+	defer span.End()
+
+	result, err := a.app.UpdateRole(role)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) UpdateScheduledPost(rctx request.CTX, userId string, scheduledPost *model.ScheduledPost, connectionId string) (*model.ScheduledPost, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.UpdateScheduledPost")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.UpdateScheduledPost(rctx, userId, scheduledPost, connectionId)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) UpdateScheme(scheme *model.Scheme) (*model.Scheme, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.UpdateScheme")
+	defer span.End()
+
+	result, err := a.app.UpdateScheme(scheme)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) UpdateSharedChannel(sc *model.SharedChannel) (*model.SharedChannel, error) {
+	_, span := tracing.Start(a.ctx, "App.UpdateSharedChannel")
+	defer span.End()
+
+	result, err := a.app.UpdateSharedChannel(sc)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) UpdateSharedChannelCursor(channelID string, remoteID string, cursor model.GetPostsSinceForSyncCursor) error {
+	_, span := tracing.Start(a.ctx, "App.UpdateSharedChannelCursor")
+	defer span.End()
+
+	err := a.app.UpdateSharedChannelCursor(channelID, remoteID, cursor)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) UpdateSharedChannelRemoteCursor(id string, cursor model.GetPostsSinceForSyncCursor) error {
+	_, span := tracing.Start(a.ctx, "App.UpdateSharedChannelRemoteCursor")
+	defer span.End()
+
+	err := a.app.UpdateSharedChannelRemoteCursor(id, cursor)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) UpdateSidebarCategories(rctx request.CTX, userID string, teamID string, categories []*model.SidebarCategoryWithChannels) ([]*model.SidebarCategoryWithChannels, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.UpdateSidebarCategories")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.UpdateSidebarCategories(rctx, userID, teamID, categories)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) UpdateSidebarCategoryOrder(rctx request.CTX, userID string, teamID string, categoryOrder []string) *model.AppError {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.UpdateSidebarCategoryOrder")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.UpdateSidebarCategoryOrder(rctx, userID, teamID, categoryOrder)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) UpdateTeam(team *model.Team) (*model.Team, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.UpdateTeam")
+	defer span.End()
+
+	result, err := a.app.UpdateTeam(team)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) UpdateTeamMemberRoles(rctx request.CTX, teamID string, userID string, newRoles string) (*model.TeamMember, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.UpdateTeamMemberRoles")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.UpdateTeamMemberRoles(rctx, teamID, userID, newRoles)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) UpdateTeamMemberSchemeRoles(rctx request.CTX, teamID string, userID string, isSchemeGuest bool, isSchemeUser bool, isSchemeAdmin bool) (*model.TeamMember, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.UpdateTeamMemberSchemeRoles")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.UpdateTeamMemberSchemeRoles(rctx, teamID, userID, isSchemeGuest, isSchemeUser, isSchemeAdmin)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) UpdateTeamPrivacy(teamID string, teamType string, allowOpenInvite bool) *model.AppError {
+	_, span := tracing.Start(a.ctx, "App.UpdateTeamPrivacy")
+	defer span.End()
+
+	err := a.app.UpdateTeamPrivacy(teamID, teamType, allowOpenInvite)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) UpdateTeamScheme(team *model.Team) (*model.Team, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.UpdateTeamScheme")
+	defer span.End()
+
+	result, err := a.app.UpdateTeamScheme(team)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) UpdateThreadFollowForUser(userID string, teamID string, threadID string, state bool) *model.AppError {
+	_, span := tracing.Start(a.ctx, "App.UpdateThreadFollowForUser")
+	defer span.End()
+
+	err := a.app.UpdateThreadFollowForUser(userID, teamID, threadID, state)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) UpdateThreadFollowForUserFromChannelAdd(rctx request.CTX, userID string, teamID string, threadID string) *model.AppError {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.UpdateThreadFollowForUserFromChannelAdd")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.UpdateThreadFollowForUserFromChannelAdd(rctx, userID, teamID, threadID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) UpdateThreadReadForUser(rctx request.CTX, currentSessionId string, userID string, teamID string, threadID string, timestamp int64) (*model.ThreadResponse, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.UpdateThreadReadForUser")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.UpdateThreadReadForUser(rctx, currentSessionId, userID, teamID, threadID, timestamp)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) UpdateThreadReadForUserByPost(rctx request.CTX, currentSessionId string, userID string, teamID string, threadID string, postID string) (*model.ThreadResponse, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.UpdateThreadReadForUserByPost")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.UpdateThreadReadForUserByPost(rctx, currentSessionId, userID, teamID, threadID, postID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) UpdateThreadsReadForUser(userID string, teamID string) *model.AppError {
+	_, span := tracing.Start(a.ctx, "App.UpdateThreadsReadForUser")
+	defer span.End()
+
+	err := a.app.UpdateThreadsReadForUser(userID, teamID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) UpdateUser(rctx request.CTX, user *model.User, sendNotifications bool) (*model.User, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.UpdateUser")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.UpdateUser(rctx, user, sendNotifications)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) UpdateUserActive(rctx request.CTX, userID string, active bool) *model.AppError {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.UpdateUserActive")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.UpdateUserActive(rctx, userID, active)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) UpdateUserAsUser(rctx request.CTX, user *model.User, asAdmin bool) (*model.User, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.UpdateUserAsUser")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.UpdateUserAsUser(rctx, user, asAdmin)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) UpdateUserAuth(rctx request.CTX, userID string, userAuth *model.UserAuth) (*model.UserAuth, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.UpdateUserAuth")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.UpdateUserAuth(rctx, userID, userAuth)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) UpdateUserRoles(rctx request.CTX, userID string, newRoles string, sendWebSocketEvent bool) (*model.User, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.UpdateUserRoles")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.UpdateUserRoles(rctx, userID, newRoles, sendWebSocketEvent)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) UpdateUserRolesWithUser(rctx request.CTX, user *model.User, newRoles string, sendWebSocketEvent bool) (*model.User, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.UpdateUserRolesWithUser")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.UpdateUserRolesWithUser(rctx, user, newRoles, sendWebSocketEvent)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) UpdateViewedProductNotices(userID string, noticeIds []string) *model.AppError {
+	_, span := tracing.Start(a.ctx, "App.UpdateViewedProductNotices")
+	defer span.End()
+
+	err := a.app.UpdateViewedProductNotices(userID, noticeIds)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) UpdateViewedProductNoticesForNewUser(userID string) {
+	_, span := tracing.Start(a.ctx, "App.UpdateViewedProductNoticesForNewUser")
+	defer span.End()
+
+	a.app.UpdateViewedProductNoticesForNewUser(userID)
+}
+
+func (a *TracingAppLayer) UpdateWebConnUserActivity(session model.Session, activityAt int64) {
+	_, span := tracing.Start(a.ctx, "App.UpdateWebConnUserActivity")
+	defer span.End()
+
+	a.app.UpdateWebConnUserActivity(session, activityAt)
+}
+
+func (a *TracingAppLayer) UpdateWorkingHoursStatuses() {
+	a.app.UpdateWorkingHoursStatuses()
+}
+
+func (a *TracingAppLayer) UploadData(rctx request.CTX, us *model.UploadSession, rd io.Reader) (*model.FileInfo, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.UploadData")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.UploadData(rctx, us, rd)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) UploadFile(rctx request.CTX, data []byte, channelID string, filename string) (*model.FileInfo, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.UploadFile")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.UploadFile(rctx, data, channelID, filename)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) UploadFileForUserAndTeam(rctx request.CTX, data []byte, channelID string, filename string, rawUserId string, rawTeamId string) (*model.FileInfo, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.UploadFileForUserAndTeam")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.UploadFileForUserAndTeam(rctx, data, channelID, filename, rawUserId, rawTeamId)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) UploadFileX(rctx request.CTX, channelID string, name string, input io.Reader, opts ...func(*app.UploadFileTask)) (*model.FileInfo, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.UploadFileX")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.UploadFileX(rctx, channelID, name, input, opts...)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) UpsertDraft(rctx request.CTX, draft *model.Draft, connectionID string) (*model.Draft, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.UpsertDraft")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.UpsertDraft(rctx, draft, connectionID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) UpsertGroupMember(groupID string, userID string) (*model.GroupMember, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.UpsertGroupMember")
+	defer span.End()
+
+	result, err := a.app.UpsertGroupMember(groupID, userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) UpsertGroupMembers(groupID string, userIDs []string) ([]*model.GroupMember, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.UpsertGroupMembers")
+	defer span.End()
+
+	result, err := a.app.UpsertGroupMembers(groupID, userIDs)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) UpsertGroupSyncable(groupSyncable *model.GroupSyncable) (*model.GroupSyncable, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.UpsertGroupSyncable")
+	defer span.End()
+
+	result, err := a.app.UpsertGroupSyncable(groupSyncable)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) UserAlreadyNotifiedOnRequiredFeature(user string, feature model.MattermostFeature) bool {
+	_, span := tracing.Start(a.ctx, "App.UserAlreadyNotifiedOnRequiredFeature")
+	defer span.End()
+
+	result := a.app.UserAlreadyNotifiedOnRequiredFeature(user, feature)
+	return result
+}
+
+func (a *TracingAppLayer) UserCanSeeOtherUser(rctx request.CTX, userID string, otherUserId string) (bool, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.UserCanSeeOtherUser")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.UserCanSeeOtherUser(rctx, userID, otherUserId)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) UserIsFirstAdmin(rctx request.CTX, user *model.User) bool {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.UserIsFirstAdmin")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result := a.app.UserIsFirstAdmin(rctx, user)
+	return result
+}
+
+func (a *TracingAppLayer) UserIsInAdminRoleGroup(userID string, syncableID string, syncableType model.GroupSyncableType) (bool, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.UserIsInAdminRoleGroup")
+	defer span.End()
+
+	result, err := a.app.UserIsInAdminRoleGroup(userID, syncableID, syncableType)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) ValidateAccessControlPolicyPermission(rctx request.CTX, userID string, policyID string) *model.AppError {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.ValidateAccessControlPolicyPermission")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.ValidateAccessControlPolicyPermission(rctx, userID, policyID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) ValidateAccessControlPolicyPermissionWithChannelContext(rctx request.CTX, userID string, policyID string, isReadOnly bool, channelID string) *model.AppError {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.ValidateAccessControlPolicyPermissionWithChannelContext")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.ValidateAccessControlPolicyPermissionWithChannelContext(rctx, userID, policyID, isReadOnly, channelID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) ValidateAccessControlPolicyPermissionWithMode(rctx request.CTX, userID string, policyID string, isReadOnly bool) *model.AppError {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.ValidateAccessControlPolicyPermissionWithMode")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.ValidateAccessControlPolicyPermissionWithMode(rctx, userID, policyID, isReadOnly)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) ValidateAccessControlPolicyPermissionWithOptions(rctx request.CTX, userID string, policyID string, opts app.ValidateAccessControlPolicyPermissionOptions) *model.AppError {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.ValidateAccessControlPolicyPermissionWithOptions")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.ValidateAccessControlPolicyPermissionWithOptions(rctx, userID, policyID, opts)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) ValidateActionItem(item *model.AIActionItem) error {
+	_, span := tracing.Start(a.ctx, "App.ValidateActionItem")
+	defer span.End()
+
+	err := a.app.ValidateActionItem(item)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) ValidateChannelAccessControlPermission(rctx request.CTX, userID string, channelID string) *model.AppError {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.ValidateChannelAccessControlPermission")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.ValidateChannelAccessControlPermission(rctx, userID, channelID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) ValidateChannelAccessControlPolicyCreation(rctx request.CTX, userID string, policy *model.AccessControlPolicy) *model.AppError {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.ValidateChannelAccessControlPolicyCreation")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.ValidateChannelAccessControlPolicyCreation(rctx, userID, policy)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) ValidateDesktopToken(token string, expiryTime int64) (*model.User, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.ValidateDesktopToken")
+	defer span.End()
+
+	result, err := a.app.ValidateDesktopToken(token, expiryTime)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) ValidateExpressionAgainstRequester(rctx request.CTX, expression string, requesterID string) (bool, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.ValidateExpressionAgainstRequester")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.ValidateExpressionAgainstRequester(rctx, expression, requesterID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) ValidateMoveOrCopy(rctx request.CTX, wpl *model.WranglerPostList, originalChannel *model.Channel, targetChannel *model.Channel, user *model.User) error {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.ValidateMoveOrCopy")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.ValidateMoveOrCopy(rctx, wpl, originalChannel, targetChannel, user)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) ValidateUserPermissionsOnChannels(rctx request.CTX, userId string, channelIds []string) []string {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.ValidateUserPermissionsOnChannels")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result := a.app.ValidateUserPermissionsOnChannels(rctx, userId, channelIds)
+	return result
+}
+
+func (a *TracingAppLayer) VerifyAuditLog() (*model.AuditLogVerification, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.VerifyAuditLog")
+	defer span.End()
+
+	result, err := a.app.VerifyAuditLog()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) VerifyEmailFromToken(rctx request.CTX, userSuppliedTokenString string) *model.AppError {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.VerifyEmailFromToken")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	err := a.app.VerifyEmailFromToken(rctx, userSuppliedTokenString)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) VerifyUserEmail(userID string, email string) *model.AppError {
+	_, span := tracing.Start(a.ctx, "App.VerifyUserEmail")
+	defer span.End()
+
+	err := a.app.VerifyUserEmail(userID, email)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) ViewChannel(rctx request.CTX, view *model.ChannelView, userID string, currentSessionId string, collapsedThreadsSupported bool) (map[string]int64, *model.AppError) {
+	ctx, span := tracing.Start(requestContext(rctx, a.ctx), "App.ViewChannel")
+	if rctx != nil {
+		rctx = rctx.WithContext(ctx)
+	}
+	defer span.End()
+
+	result, err := a.app.ViewChannel(rctx, view, userID, currentSessionId, collapsedThreadsSupported)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) WebPushVAPIDKey() *ecdsa.PrivateKey {
+	return a.app.WebPushVAPIDKey()
+}
+
+func (a *TracingAppLayer) WriteExportFile(fr io.Reader, path string) (int64, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.WriteExportFile")
+	defer span.End()
+
+	result, err := a.app.WriteExportFile(fr, path)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) WriteExportFileContext(ctxParam context.Context, fr io.Reader, path string) (int64, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.WriteExportFileContext")
+	defer span.End()
+
+	result, err := a.app.WriteExportFileContext(ctxParam, fr, path)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) WriteFile(fr io.Reader, path string) (int64, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.WriteFile")
+	defer span.End()
+
+	result, err := a.app.WriteFile(fr, path)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) WriteFileContext(ctxParam context.Context, fr io.Reader, path string) (int64, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.WriteFileContext")
+	defer span.End()
+
+	result, err := a.app.WriteFileContext(ctxParam, fr, path)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (a *TracingAppLayer) WriteZipFile(w io.Writer, fileDatas []model.FileData) error {
+	_, span := tracing.Start(a.ctx, "App.WriteZipFile")
+	defer span.End()
+
+	err := a.app.WriteZipFile(w, fileDatas)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (a *TracingAppLayer) ZipReader(path string, deflate bool) (io.ReadCloser, *model.AppError) {
+	_, span := tracing.Start(a.ctx, "App.ZipReader")
+	defer span.End()
+
+	result, err := a.app.ZipReader(path, deflate)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+// New wraps the app in a tracing layer, recording the spans of the calls without a request
+// context as children of the span carried by ctx.
+func New(childApp app.AppIface, ctx context.Context) *TracingAppLayer {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return &TracingAppLayer{app: childApp, ctx: ctx}
+}
@@ -0,0 +1,93 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package tracinglayer
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/app"
+)
+
+type testApp struct {
+	app.AppIface
+
+	post    *model.Post
+	spanCtx trace.SpanContext
+}
+
+func (a *testApp) GetSinglePost(rctx request.CTX, postID string, includeDeleted bool) (*model.Post, *model.AppError) {
+	a.spanCtx = trace.SpanContextFromContext(rctx.Context())
+	if a.post == nil || a.post.Id != postID {
+		return nil, model.NewAppError("GetSinglePost", "app.post.get.app_error", nil, "", http.StatusNotFound)
+	}
+	return a.post, nil
+}
+
+func setupExporter(t *testing.T) *tracetest.InMemoryExporter {
+	t.Helper()
+
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	prevProvider := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	t.Cleanup(func() {
+		otel.SetTracerProvider(prevProvider)
+		require.NoError(t, provider.Shutdown(context.Background()))
+	})
+
+	return exporter
+}
+
+func TestTracingAppLayer(t *testing.T) {
+	exporter := setupExporter(t)
+
+	ctx, parent := otel.Tracer("test").Start(context.Background(), "request")
+	defer parent.End()
+
+	post := &model.Post{Id: model.NewId()}
+	childApp := &testApp{post: post}
+	a := New(childApp, ctx)
+
+	t.Run("the span is a child of the request span and is passed to the app", func(t *testing.T) {
+		exporter.Reset()
+
+		rctx := request.TestContext(t).WithContext(ctx)
+		got, appErr := a.GetSinglePost(rctx, post.Id, false)
+		require.Nil(t, appErr)
+		assert.Equal(t, post, got)
+
+		spans := exporter.GetSpans()
+		require.Len(t, spans, 1)
+		assert.Equal(t, "App.GetSinglePost", spans[0].Name)
+		assert.Equal(t, parent.SpanContext().SpanID(), spans[0].Parent.SpanID())
+		assert.Equal(t, spans[0].SpanContext.SpanID(), childApp.spanCtx.SpanID())
+		assert.Equal(t, codes.Unset, spans[0].Status.Code)
+	})
+
+	t.Run("an error marks the span as failed", func(t *testing.T) {
+		exporter.Reset()
+
+		rctx := request.TestContext(t).WithContext(ctx)
+		_, appErr := a.GetSinglePost(rctx, model.NewId(), false)
+		require.NotNil(t, appErr)
+
+		spans := exporter.GetSpans()
+		require.Len(t, spans, 1)
+		assert.Equal(t, codes.Error, spans[0].Status.Code)
+		require.Len(t, spans[0].Events, 1)
+		assert.Equal(t, "exception", spans[0].Events[0].Name)
+	})
+}
@@ -1201,6 +1201,14 @@ func (a *App) UpdateActive(rctx request.CTX, user *model.User, active bool) (*mo
 	}
 	a.sendUpdatedUserEvent(ruser)
 
+	if !active {
+		adminCopyOfUser := ruser.DeepCopy()
+		a.SanitizeProfile(adminCopyOfUser, true)
+		message := model.NewWebSocketEvent(model.WebsocketEventUserUpdated, "", "", "", nil, "")
+		message.Add("user", adminCopyOfUser)
+		a.publishSubscribedEvent(model.EventSubscriptionEventUserDeactivated, "", "", message)
+	}
+
 	if !active && user.DeleteAt != 0 {
 		a.Srv().Go(func() {
 			pluginContext := pluginContext(rctx)
@@ -2015,6 +2023,22 @@ func (a *App) PermanentDeleteUser(rctx request.CTX, user *model.User) *model.App
 		return model.NewAppError("PermanentDeleteUser", "app.webhooks.permanent_delete_outgoing_by_user.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
 	}
 
+	if err := a.Srv().Store().EventSubscription().PermanentDeleteByUser(user.Id); err != nil {
+		return model.NewAppError("PermanentDeleteUser", "app.event_subscription.permanent_delete_by_user.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	if err := a.Srv().Store().EmailBatchNotification().PermanentDeleteByUser(user.Id); err != nil {
+		return model.NewAppError("PermanentDeleteUser", "app.email_batch_notification.permanent_delete_by_user.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	if err := a.Srv().Store().WorkingHours().PermanentDeleteByUser(user.Id); err != nil {
+		return model.NewAppError("PermanentDeleteUser", "app.working_hours.permanent_delete_by_user.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	if err := a.Srv().Store().NotificationRule().PermanentDeleteByUser(user.Id); err != nil {
+		return model.NewAppError("PermanentDeleteUser", "app.notification_rule.permanent_delete_by_user.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
 	if err := a.Srv().Store().Command().PermanentDeleteByUser(user.Id); err != nil {
 		return model.NewAppError("PermanentDeleteUser", "app.user.permanentdeleteuser.internal_error", nil, "", http.StatusInternalServerError).Wrap(err)
 	}
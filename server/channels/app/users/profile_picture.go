@@ -65,7 +65,7 @@ func (us *UserService) FileBackend() (filestore.FileBackend, error) {
 	if err != nil {
 		return nil, err
 	}
-	return backend, nil
+	return filestore.WithMigrationFallback(backend, &us.config().FileSettings, license != nil && *license.Features.Compliance, insecure != nil && *insecure)
 }
 
 func (us *UserService) ReadFile(path string) ([]byte, error) {
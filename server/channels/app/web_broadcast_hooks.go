@@ -15,18 +15,20 @@ import (
 )
 
 const (
-	broadcastAddMentions  = "add_mentions"
-	broadcastAddFollowers = "add_followers"
-	broadcastPostedAck    = "posted_ack"
-	broadcastPermalink    = "permalink"
+	broadcastAddMentions       = "add_mentions"
+	broadcastAddFollowers      = "add_followers"
+	broadcastPostedAck         = "posted_ack"
+	broadcastPermalink         = "permalink"
+	broadcastNotificationRules = "notification_rules"
 )
 
 func (s *Server) makeBroadcastHooks() map[string]platform.BroadcastHook {
 	return map[string]platform.BroadcastHook{
-		broadcastAddMentions:  &addMentionsBroadcastHook{},
-		broadcastAddFollowers: &addFollowersBroadcastHook{},
-		broadcastPostedAck:    &postedAckBroadcastHook{},
-		broadcastPermalink:    &permalinkBroadcastHook{},
+		broadcastAddMentions:       &addMentionsBroadcastHook{},
+		broadcastAddFollowers:      &addFollowersBroadcastHook{},
+		broadcastPostedAck:         &postedAckBroadcastHook{},
+		broadcastPermalink:         &permalinkBroadcastHook{},
+		broadcastNotificationRules: &notificationRulesBroadcastHook{},
 	}
 }
 
@@ -167,6 +169,29 @@ func (h *permalinkBroadcastHook) Process(msg *platform.HookedWebSocketEvent, web
 	return nil
 }
 
+func useNotificationRulesHook(message *model.WebSocketEvent, desktop map[string]string) {
+	message.GetBroadcast().AddHook(broadcastNotificationRules, map[string]any{
+		"desktop": desktop,
+	})
+}
+
+type notificationRulesBroadcastHook struct{}
+
+// Process adds how the desktop notification of the post is handled, when a notification rule of
+// the user matches it.
+func (h *notificationRulesBroadcastHook) Process(msg *platform.HookedWebSocketEvent, webConn *platform.WebConn, args map[string]any) error {
+	desktop, err := getTypedArg[map[string]string](args, "desktop")
+	if err != nil {
+		return errors.Wrap(err, "Invalid desktop value passed to notificationRulesBroadcastHook")
+	}
+
+	if decision, ok := desktop[webConn.UserId]; ok {
+		msg.Add("notification_rule", decision)
+	}
+
+	return nil
+}
+
 func incrementWebsocketCounter(wc *platform.WebConn) {
 	if wc.Platform.Metrics() == nil {
 		return
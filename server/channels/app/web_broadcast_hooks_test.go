@@ -281,3 +281,35 @@ func TestPermalinkBroadcastHook(t *testing.T) {
 	require.True(t, ok)
 	require.Equal(t, removedJSON, gotJSON)
 }
+
+func TestNotificationRulesHook_Process(t *testing.T) {
+	mainHelper.Parallel(t)
+	hook := &notificationRulesBroadcastHook{}
+
+	userID := model.NewId()
+	webConn := &platform.WebConn{
+		UserId: userID,
+	}
+
+	t.Run("should add the decision of the rule of the current user", func(t *testing.T) {
+		msg := platform.MakeHookedWebSocketEvent(model.NewWebSocketEvent(model.WebsocketEventPosted, "", "", "", nil, ""))
+
+		err := hook.Process(msg, webConn, map[string]any{
+			"desktop": map[string]string{userID: model.NotificationRuleActionEscalate},
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, model.NotificationRuleActionEscalate, msg.Event().GetData()["notification_rule"])
+	})
+
+	t.Run("should not add anything without a rule of the current user", func(t *testing.T) {
+		msg := platform.MakeHookedWebSocketEvent(model.NewWebSocketEvent(model.WebsocketEventPosted, "", "", "", nil, ""))
+
+		err := hook.Process(msg, webConn, map[string]any{
+			"desktop": map[string]string{model.NewId(): model.NotificationRuleActionSuppress},
+		})
+		require.NoError(t, err)
+
+		assert.Nil(t, msg.Event().GetData()["notification_rule"])
+	})
+}
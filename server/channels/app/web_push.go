@@ -0,0 +1,330 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/hkdf"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+)
+
+const (
+	// webPushRecordSize is the record size advertised in the header of the encrypted payloads.
+	// The payloads are always sent in a single record.
+	webPushRecordSize = 4096
+	// webPushHeaderSize is the size of the aes128gcm header: salt, record size, key id length
+	// and the public key of the server.
+	webPushHeaderSize = 16 + 4 + 1 + model.WebPushPublicKeyLength
+	// webPushMaxPayloadSize is the largest plaintext the push services are guaranteed to accept,
+	// once encrypted in a 4096 bytes message.
+	webPushMaxPayloadSize = webPushRecordSize - webPushHeaderSize - 16 - 1
+
+	webPushMessageTTL = 24 * time.Hour
+	webPushVAPIDTTL   = 12 * time.Hour
+)
+
+// AttachWebPushSubscription registers the push subscription of the browser the session is used
+// from, making the session receive push notifications like the sessions of the mobile apps.
+func (a *App) AttachWebPushSubscription(rctx request.CTX, session *model.Session, subscription *model.WebPushSubscription) *model.AppError {
+	if !*a.Config().EmailSettings.SendPushNotifications || !*a.Config().EmailSettings.EnableWebPush {
+		return model.NewAppError("AttachWebPushSubscription", "api.push_notification.web_push.disabled.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	if appErr := subscription.IsValid(); appErr != nil {
+		return appErr
+	}
+
+	subscriptionJSON, err := json.Marshal(subscription)
+	if err != nil {
+		return model.NewAppError("AttachWebPushSubscription", "api.marshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	deviceID := subscription.DeviceId()
+
+	// A browser keeps its subscription across logins, so the sessions it was previously used from are stale.
+	if appErr := a.RevokeSessionsForDeviceId(rctx, session.UserId, deviceID, session.Id); appErr != nil {
+		return appErr
+	}
+
+	if appErr := a.SetExtraSessionProps(session, map[string]string{
+		model.SessionPropWebPushSubscription: string(subscriptionJSON),
+	}); appErr != nil {
+		return appErr
+	}
+
+	if appErr := a.AttachDeviceId(session.Id, deviceID, session.ExpiresAt); appErr != nil {
+		return appErr
+	}
+
+	a.ClearSessionCacheForUser(session.UserId)
+	return nil
+}
+
+// DetachWebPushSubscription stops sending push notifications to the browser the session is used from.
+func (a *App) DetachWebPushSubscription(session *model.Session) *model.AppError {
+	if session.Props[model.SessionPropWebPushSubscription] == "" {
+		return nil
+	}
+
+	if appErr := a.SetExtraSessionProps(session, map[string]string{
+		model.SessionPropWebPushSubscription: "",
+	}); appErr != nil {
+		return appErr
+	}
+
+	if appErr := a.AttachDeviceId(session.Id, "", session.ExpiresAt); appErr != nil {
+		return appErr
+	}
+
+	a.ClearSessionCacheForUser(session.UserId)
+	return nil
+}
+
+// sendToWebPush delivers the notification to the push service of the browser the session is
+// used from, encrypted for the browser only.
+func (a *App) sendToWebPush(rctx request.CTX, msg *model.PushNotification, session *model.Session) error {
+	msg.ServerId = a.ServerId()
+
+	rctx.Logger().LogM(mlog.MlvlNotificationTrace, "Notification will be sent",
+		mlog.String("status", model.PushSendPrepare),
+	)
+
+	var subscription model.WebPushSubscription
+	if err := json.Unmarshal([]byte(session.Props[model.SessionPropWebPushSubscription]), &subscription); err != nil {
+		return fmt.Errorf("failed to decode the web push subscription: %w", err)
+	}
+
+	payload, err := marshalWebPushPayload(msg)
+	if err != nil {
+		return err
+	}
+
+	body, err := encryptWebPushPayload(&subscription, payload)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt the payload: %w", err)
+	}
+
+	authorization, err := a.webPushAuthorization(subscription.Endpoint)
+	if err != nil {
+		return fmt.Errorf("failed to sign the VAPID token: %w", err)
+	}
+
+	request, err := http.NewRequest(http.MethodPost, subscription.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Authorization", authorization)
+	request.Header.Set("Content-Type", "application/octet-stream")
+	request.Header.Set("Content-Encoding", "aes128gcm")
+	request.Header.Set("TTL", strconv.Itoa(int(webPushMessageTTL.Seconds())))
+	if msg.Type == model.PushTypeMessage {
+		request.Header.Set("Urgency", "high")
+	} else {
+		request.Header.Set("Urgency", "normal")
+	}
+
+	// The endpoint is provided by the browser, so it must not reach the internal network.
+	resp, err := a.HTTPService().MakeClient(false).Do(request)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusCreated, http.StatusAccepted:
+		return nil
+	case http.StatusNotFound, http.StatusGone:
+		// The subscription expired or the user revoked the permission.
+		appErr := a.SetExtraSessionProps(session, map[string]string{
+			model.SessionPropLastRemovedDeviceId: session.DeviceId,
+		})
+		if appErr != nil {
+			return fmt.Errorf("Failed to set extra session properties: %w", appErr)
+		}
+		a.ClearSessionCacheForUser(session.UserId)
+		return errors.New(notificationErrorRemoveDevice)
+	default:
+		return fmt.Errorf("response returned error code: %d", resp.StatusCode)
+	}
+}
+
+// marshalWebPushPayload encodes the notification, shortening its message if needed for it to fit in
+// a single web push message.
+func marshalWebPushPayload(msg *model.PushNotification) ([]byte, error) {
+	payload, err := json.Marshal(msg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode to JSON: %w", err)
+	}
+
+	for len(payload) > webPushMaxPayloadSize && msg.Message != "" {
+		message := msg.Message
+		length := max(len(message)-(len(payload)-webPushMaxPayloadSize)-len("..."), 0)
+		for length > 0 && !utf8.RuneStart(message[length]) {
+			length--
+		}
+		msg.Message = message[:length]
+		if msg.Message != "" {
+			msg.Message += "..."
+		}
+
+		if payload, err = json.Marshal(msg); err != nil {
+			return nil, fmt.Errorf("failed to encode to JSON: %w", err)
+		}
+	}
+
+	if len(payload) > webPushMaxPayloadSize {
+		return nil, fmt.Errorf("payload of %d bytes is too large", len(payload))
+	}
+
+	return payload, nil
+}
+
+// encryptWebPushPayload encrypts the payload for the browser the subscription belongs to, as a
+// single aes128gcm record following RFC 8291.
+func encryptWebPushPayload(subscription *model.WebPushSubscription, payload []byte) ([]byte, error) {
+	clientPublicKeyBytes, err := model.DecodeWebPushKey(subscription.Keys.P256dh)
+	if err != nil {
+		return nil, err
+	}
+	clientPublicKey, err := ecdh.P256().NewPublicKey(clientPublicKeyBytes)
+	if err != nil {
+		return nil, err
+	}
+	authSecret, err := model.DecodeWebPushKey(subscription.Keys.Auth)
+	if err != nil {
+		return nil, err
+	}
+
+	// A new key pair and salt are used for every message.
+	serverKey, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	serverPublicKeyBytes := serverKey.PublicKey().Bytes()
+
+	salt := make([]byte, 16)
+	if _, err = rand.Read(salt); err != nil {
+		return nil, err
+	}
+
+	sharedSecret, err := serverKey.ECDH(clientPublicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	keyInfo := "WebPush: info\x00" + string(clientPublicKeyBytes) + string(serverPublicKeyBytes)
+	ikm, err := hkdf.Key(sha256.New, sharedSecret, authSecret, keyInfo, 32)
+	if err != nil {
+		return nil, err
+	}
+	contentEncryptionKey, err := hkdf.Key(sha256.New, ikm, salt, "Content-Encoding: aes128gcm\x00", 16)
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := hkdf.Key(sha256.New, ikm, salt, "Content-Encoding: nonce\x00", 12)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(contentEncryptionKey)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	header := make([]byte, 0, webPushHeaderSize)
+	header = append(header, salt...)
+	header = binary.BigEndian.AppendUint32(header, webPushRecordSize)
+	header = append(header, byte(len(serverPublicKeyBytes)))
+	header = append(header, serverPublicKeyBytes...)
+
+	// The padding delimiter of the last record.
+	record := append(payload, 0x02)
+
+	return gcm.Seal(header, nonce, record, nil), nil
+}
+
+// webPushAuthorization returns the VAPID authorization header for the push service of the
+// endpoint, as specified by RFC 8292.
+func (a *App) webPushAuthorization(endpoint string) (string, error) {
+	key := a.WebPushVAPIDKey()
+	if key == nil {
+		return "", errors.New("no VAPID key")
+	}
+
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return "", err
+	}
+
+	claims := jwt.MapClaims{
+		"aud": u.Scheme + "://" + u.Host,
+		"exp": time.Now().Add(webPushVAPIDTTL).Unix(),
+	}
+	if subject := a.webPushSubject(); subject != "" {
+		claims["sub"] = subject
+	}
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodES256, claims).SignedString(key)
+	if err != nil {
+		return "", err
+	}
+
+	publicKey, err := webPushPublicKey(key)
+	if err != nil {
+		return "", err
+	}
+
+	return "vapid t=" + token + ", k=" + publicKey, nil
+}
+
+// webPushSubject returns the contact the push services can reach the administrators at.
+func (a *App) webPushSubject() string {
+	if subject := *a.Config().EmailSettings.WebPushSubject; subject != "" {
+		return subject
+	}
+	if feedbackEmail := *a.Config().EmailSettings.FeedbackEmail; feedbackEmail != "" {
+		return "mailto:" + feedbackEmail
+	}
+	if siteURL := *a.Config().ServiceSettings.SiteURL; strings.HasPrefix(siteURL, "https://") {
+		return siteURL
+	}
+	return ""
+}
+
+// webPushPublicKey returns the public key of the VAPID key, as the browsers expect it.
+func webPushPublicKey(key *ecdsa.PrivateKey) (string, error) {
+	publicKey, err := key.PublicKey.ECDH()
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(publicKey.Bytes()), nil
+}
@@ -0,0 +1,210 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/hkdf"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/channels/store/storetest/mocks"
+)
+
+type testWebPushBrowser struct {
+	key        *ecdh.PrivateKey
+	authSecret []byte
+}
+
+func newTestWebPushBrowser(t *testing.T) *testWebPushBrowser {
+	key, err := ecdh.P256().GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	authSecret := make([]byte, model.WebPushAuthSecretLength)
+	_, err = rand.Read(authSecret)
+	require.NoError(t, err)
+
+	return &testWebPushBrowser{key: key, authSecret: authSecret}
+}
+
+func (b *testWebPushBrowser) subscription(endpoint string) *model.WebPushSubscription {
+	return &model.WebPushSubscription{
+		Endpoint: endpoint,
+		Keys: model.WebPushSubscriptionKeys{
+			P256dh: base64.RawURLEncoding.EncodeToString(b.key.PublicKey().Bytes()),
+			Auth:   base64.RawURLEncoding.EncodeToString(b.authSecret),
+		},
+	}
+}
+
+// decrypt decrypts the message the way the browsers do.
+func (b *testWebPushBrowser) decrypt(t *testing.T, body []byte) []byte {
+	t.Helper()
+
+	require.Greater(t, len(body), webPushHeaderSize)
+	salt := body[:16]
+	assert.Equal(t, uint32(webPushRecordSize), binary.BigEndian.Uint32(body[16:20]))
+	require.Equal(t, byte(model.WebPushPublicKeyLength), body[20])
+	serverPublicKeyBytes := body[21:webPushHeaderSize]
+
+	serverPublicKey, err := ecdh.P256().NewPublicKey(serverPublicKeyBytes)
+	require.NoError(t, err)
+	sharedSecret, err := b.key.ECDH(serverPublicKey)
+	require.NoError(t, err)
+
+	keyInfo := "WebPush: info\x00" + string(b.key.PublicKey().Bytes()) + string(serverPublicKeyBytes)
+	ikm, err := hkdf.Key(sha256.New, sharedSecret, b.authSecret, keyInfo, 32)
+	require.NoError(t, err)
+	contentEncryptionKey, err := hkdf.Key(sha256.New, ikm, salt, "Content-Encoding: aes128gcm\x00", 16)
+	require.NoError(t, err)
+	nonce, err := hkdf.Key(sha256.New, ikm, salt, "Content-Encoding: nonce\x00", 12)
+	require.NoError(t, err)
+
+	block, err := aes.NewCipher(contentEncryptionKey)
+	require.NoError(t, err)
+	gcm, err := cipher.NewGCM(block)
+	require.NoError(t, err)
+	record, err := gcm.Open(nil, nonce, body[webPushHeaderSize:], nil)
+	require.NoError(t, err)
+
+	require.Equal(t, byte(0x02), record[len(record)-1])
+	return record[:len(record)-1]
+}
+
+func TestEncryptWebPushPayload(t *testing.T) {
+	browser := newTestWebPushBrowser(t)
+	subscription := browser.subscription("https://push.example.com/send/abc")
+
+	payload := []byte(`{"type":"message","message":"hello"}`)
+	body, err := encryptWebPushPayload(subscription, payload)
+	require.NoError(t, err)
+	assert.Equal(t, payload, browser.decrypt(t, body))
+
+	// Every message is encrypted with new keys.
+	other, err := encryptWebPushPayload(subscription, payload)
+	require.NoError(t, err)
+	assert.NotEqual(t, body[:webPushHeaderSize], other[:webPushHeaderSize])
+
+	_, err = encryptWebPushPayload(&model.WebPushSubscription{}, payload)
+	require.Error(t, err)
+}
+
+func TestMarshalWebPushPayload(t *testing.T) {
+	t.Run("short message", func(t *testing.T) {
+		msg := &model.PushNotification{Type: model.PushTypeMessage, Message: "hello"}
+		payload, err := marshalWebPushPayload(msg)
+		require.NoError(t, err)
+
+		var decoded model.PushNotification
+		require.NoError(t, json.Unmarshal(payload, &decoded))
+		assert.Equal(t, "hello", decoded.Message)
+	})
+
+	t.Run("long message", func(t *testing.T) {
+		msg := &model.PushNotification{Type: model.PushTypeMessage, Message: strings.Repeat("é", webPushMaxPayloadSize)}
+		payload, err := marshalWebPushPayload(msg)
+		require.NoError(t, err)
+		assert.LessOrEqual(t, len(payload), webPushMaxPayloadSize)
+
+		var decoded model.PushNotification
+		require.NoError(t, json.Unmarshal(payload, &decoded))
+		assert.True(t, strings.HasSuffix(decoded.Message, "..."))
+		assert.True(t, utf8.ValidString(decoded.Message))
+	})
+}
+
+func TestSendToWebPush(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := SetupWithStoreMock(t)
+
+	browser := newTestWebPushBrowser(t)
+	var status int
+	var received []byte
+	var headers http.Header
+	pushService := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		headers = r.Header
+		received, _ = io.ReadAll(r.Body)
+		w.WriteHeader(status)
+	}))
+	defer pushService.Close()
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.EmailSettings.EnableWebPush = true
+		*cfg.ServiceSettings.AllowedUntrustedInternalConnections = "127.0.0.1"
+	})
+
+	subscription := browser.subscription(pushService.URL + "/send/abc")
+	subscriptionJSON, err := json.Marshal(subscription)
+	require.NoError(t, err)
+	session := &model.Session{
+		Id:        model.NewId(),
+		UserId:    model.NewId(),
+		DeviceId:  subscription.DeviceId(),
+		ExpiresAt: model.GetMillis() + 100000,
+		Props: model.StringMap{
+			model.SessionPropWebPushSubscription: string(subscriptionJSON),
+		},
+	}
+
+	mockStore := th.App.Srv().Store().(*mocks.Store)
+	mockSessionStore := mocks.SessionStore{}
+	mockSessionStore.On("UpdateProps", mock.Anything).Return(nil)
+	mockStore.On("Session").Return(&mockSessionStore)
+
+	t.Run("delivered", func(t *testing.T) {
+		status = http.StatusCreated
+		msg := &model.PushNotification{Type: model.PushTypeMessage, Message: "hello", ChannelId: model.NewId()}
+		require.NoError(t, th.App.sendToWebPush(th.Context, msg, session))
+
+		assert.Equal(t, "aes128gcm", headers.Get("Content-Encoding"))
+		assert.Equal(t, "high", headers.Get("Urgency"))
+		assert.True(t, strings.HasPrefix(headers.Get("Authorization"), "vapid t="))
+
+		var decoded model.PushNotification
+		require.NoError(t, json.Unmarshal(browser.decrypt(t, received), &decoded))
+		assert.Equal(t, msg.ChannelId, decoded.ChannelId)
+		assert.Equal(t, "hello", decoded.Message)
+	})
+
+	t.Run("expired subscription", func(t *testing.T) {
+		status = http.StatusGone
+		err := th.App.sendToWebPush(th.Context, &model.PushNotification{Type: model.PushTypeClear}, session)
+		require.EqualError(t, err, notificationErrorRemoveDevice)
+		assert.Equal(t, session.DeviceId, session.Props[model.SessionPropLastRemovedDeviceId])
+	})
+}
+
+func TestWebPushAuthorization(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := SetupWithStoreMock(t)
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.EmailSettings.EnableWebPush = true
+		*cfg.EmailSettings.WebPushSubject = "mailto:admin@example.com"
+	})
+
+	publicKey, err := webPushPublicKey(th.App.WebPushVAPIDKey())
+	require.NoError(t, err)
+	assert.Equal(t, publicKey, th.App.ClientConfig()["WebPushPublicKey"])
+
+	authorization, err := th.App.webPushAuthorization("https://push.example.com/send/abc")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(authorization, "vapid t="))
+	assert.True(t, strings.HasSuffix(authorization, ", k="+publicKey))
+}
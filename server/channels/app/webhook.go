@@ -398,6 +398,10 @@ func (a *App) CreateIncomingWebhookForChannel(creatorId string, channel *model.C
 		return nil, model.NewAppError("CreateIncomingWebhookForChannel", "api.incoming_webhook.invalid_username.app_error", nil, "", http.StatusBadRequest)
 	}
 
+	if appErr := validateWebhookPayloadTemplate("CreateIncomingWebhookForChannel", hook.PayloadTemplate); appErr != nil {
+		return nil, appErr
+	}
+
 	webhook, err := a.Srv().Store().Webhook().SaveIncoming(hook)
 	if err != nil {
 		var invErr *store.ErrInvalidInput
@@ -431,6 +435,21 @@ func (a *App) UpdateIncomingWebhook(oldHook, updatedHook *model.IncomingWebhook)
 		return nil, model.NewAppError("UpdateIncomingWebhook", "api.incoming_webhook.invalid_username.app_error", nil, "", http.StatusBadRequest)
 	}
 
+	if appErr := validateWebhookPayloadTemplate("UpdateIncomingWebhook", updatedHook.PayloadTemplate); appErr != nil {
+		return nil, appErr
+	}
+
+	// The signing secret isn't returned by the API, so clients that don't send it back keep it.
+	// Removing it takes an explicit flag.
+	if updatedHook.RemoveSigningSecret {
+		if updatedHook.SigningSecret != "" {
+			return nil, model.NewAppError("UpdateIncomingWebhook", "api.incoming_webhook.remove_signing_secret.app_error", nil, "", http.StatusBadRequest)
+		}
+		updatedHook.RemoveSigningSecret = false
+	} else if updatedHook.SigningSecret == "" {
+		updatedHook.SigningSecret = oldHook.SigningSecret
+	}
+
 	updatedHook.Id = oldHook.Id
 	updatedHook.UserId = oldHook.UserId
 	updatedHook.CreateAt = oldHook.CreateAt
@@ -728,6 +747,31 @@ func (a *App) RegenOutgoingWebhookToken(hook *model.OutgoingWebhook) (*model.Out
 	return webhook, nil
 }
 
+// PrepareIncomingWebhookRequest checks the signature of a raw request to a
+// signed hook and, for hooks with a payload template, renders the template to
+// build the request. It returns a nil request when the body should be decoded
+// as a regular Slack-compatible payload instead.
+func (a *App) PrepareIncomingWebhookRequest(hookID string, header http.Header, body []byte) (*model.IncomingWebhookRequest, *model.AppError) {
+	if !*a.Config().ServiceSettings.EnableIncomingWebhooks {
+		return nil, model.NewAppError("PrepareIncomingWebhookRequest", "web.incoming_webhook.disabled.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	hook, err := a.Srv().Store().Webhook().GetIncoming(hookID, true)
+	if err != nil {
+		return nil, model.NewAppError("PrepareIncomingWebhookRequest", "web.incoming_webhook.invalid.app_error", nil, "", http.StatusBadRequest).Wrap(err)
+	}
+
+	if appErr := hook.VerifySignature(header, body, time.Now()); appErr != nil {
+		return nil, appErr
+	}
+
+	if hook.PayloadTemplate == "" {
+		return nil, nil
+	}
+
+	return a.TransformIncomingWebhookPayload(hook, header, body)
+}
+
 func (a *App) HandleIncomingWebhook(rctx request.CTX, hookID string, req *model.IncomingWebhookRequest) *model.AppError {
 	if !*a.Config().ServiceSettings.EnableIncomingWebhooks {
 		return model.NewAppError("HandleIncomingWebhook", "web.incoming_webhook.disabled.app_error", nil, "", http.StatusNotImplemented)
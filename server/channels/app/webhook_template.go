@@ -0,0 +1,135 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+// maxWebhookTemplateOutput caps the size of the rendered payload template so a
+// pathological template can't balloon a small request into a huge post.
+const maxWebhookTemplateOutput = 1024 * 1024
+
+var errWebhookTemplateOutputTooLarge = errors.New("rendered payload template exceeds the maximum size")
+
+// webhookTemplateData is the value a payload template is executed against.
+type webhookTemplateData struct {
+	// Payload is the request body decoded as generic JSON.
+	Payload any
+	// Headers holds the first value of every request header, keyed by
+	// canonical header name, e.g. {{ index .Headers "X-Github-Event" }}.
+	Headers map[string]string
+}
+
+type limitedBuffer struct {
+	bytes.Buffer
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if b.Len()+len(p) > maxWebhookTemplateOutput {
+		return 0, errWebhookTemplateOutputTooLarge
+	}
+	return b.Buffer.Write(p)
+}
+
+var webhookTemplateFuncs = template.FuncMap{
+	// json renders v as a JSON value, which is the safe way to splice
+	// arbitrary strings into the JSON document a template produces.
+	"json": func(v any) (string, error) {
+		b, err := json.Marshal(v)
+		return string(b), err
+	},
+	"default": func(def, v any) any {
+		if v == nil {
+			return def
+		}
+		if s, ok := v.(string); ok && s == "" {
+			return def
+		}
+		return v
+	},
+	"join": func(sep string, v []any) string {
+		parts := make([]string, 0, len(v))
+		for _, item := range v {
+			parts = append(parts, fmt.Sprint(item))
+		}
+		return strings.Join(parts, sep)
+	},
+	"upper": strings.ToUpper,
+	"lower": strings.ToLower,
+	"truncate": func(n int, s string) string {
+		r := []rune(s)
+		if len(r) <= n {
+			return s
+		}
+		return string(r[:n]) + "…"
+	},
+	"time": func(layout string, v any) string {
+		switch t := v.(type) {
+		case string:
+			if parsed, err := time.Parse(time.RFC3339, t); err == nil {
+				return parsed.UTC().Format(layout)
+			}
+			return t
+		case float64:
+			return time.Unix(int64(t), 0).UTC().Format(layout)
+		}
+		return fmt.Sprint(v)
+	},
+}
+
+func parseWebhookPayloadTemplate(text string) (*template.Template, error) {
+	return template.New("payload").Option("missingkey=zero").Funcs(webhookTemplateFuncs).Parse(text)
+}
+
+// validateWebhookPayloadTemplate checks that a payload template compiles before
+// it's saved on a hook.
+func validateWebhookPayloadTemplate(where string, text string) *model.AppError {
+	if text == "" {
+		return nil
+	}
+	if _, err := parseWebhookPayloadTemplate(text); err != nil {
+		return model.NewAppError(where, "api.incoming_webhook.invalid_payload_template.app_error", map[string]any{"Error": err.Error()}, "", http.StatusBadRequest).Wrap(err)
+	}
+	return nil
+}
+
+// TransformIncomingWebhookPayload renders the payload template of hook against
+// the raw request body, producing the Slack-compatible request the rest of the
+// incoming webhook flow understands. This lets services such as GitHub,
+// GitLab, Alertmanager or Sentry post directly to a hook.
+func (a *App) TransformIncomingWebhookPayload(hook *model.IncomingWebhook, header http.Header, body []byte) (*model.IncomingWebhookRequest, *model.AppError) {
+	tmpl, err := parseWebhookPayloadTemplate(hook.PayloadTemplate)
+	if err != nil {
+		return nil, model.NewAppError("TransformIncomingWebhookPayload", "web.incoming_webhook.template.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	data := webhookTemplateData{
+		Headers: make(map[string]string, len(header)),
+	}
+	for name := range header {
+		data.Headers[name] = header.Get(name)
+	}
+	if len(bytes.TrimSpace(body)) > 0 {
+		if err := json.Unmarshal(body, &data.Payload); err != nil {
+			return nil, model.NewAppError("TransformIncomingWebhookPayload", "web.incoming_webhook.template_payload.app_error", nil, "", http.StatusBadRequest).Wrap(err)
+		}
+	}
+
+	var out limitedBuffer
+	if err := tmpl.Execute(&out, data); err != nil {
+		return nil, model.NewAppError("TransformIncomingWebhookPayload", "web.incoming_webhook.template_execute.app_error", nil, "", http.StatusBadRequest).Wrap(err)
+	}
+
+	return model.IncomingWebhookRequestFromJSON(&out)
+}
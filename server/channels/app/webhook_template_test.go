@@ -0,0 +1,141 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+const githubPushTemplate = `{
+	"text": {{ printf "%s pushed %d commit(s) to %s" .Payload.pusher.name (len .Payload.commits) .Payload.repository.full_name | json }},
+	"attachments": [{
+		"fallback": {{ index .Headers "X-Github-Event" | json }},
+		"title": {{ .Payload.head_commit.message | truncate 20 | json }},
+		"title_link": {{ .Payload.compare | json }}
+	}]
+}`
+
+func TestTransformIncomingWebhookPayload(t *testing.T) {
+	a := &App{}
+
+	t.Run("renders a GitHub push payload", func(t *testing.T) {
+		hook := &model.IncomingWebhook{PayloadTemplate: githubPushTemplate}
+		header := http.Header{}
+		header.Set("X-GitHub-Event", "push")
+		body := []byte(`{
+			"pusher": {"name": "octocat"},
+			"repository": {"full_name": "mattermost/mattermost"},
+			"compare": "https://example.com/compare",
+			"head_commit": {"message": "Fix the flux capacitor once and for all"},
+			"commits": [{}, {}]
+		}`)
+
+		req, appErr := a.TransformIncomingWebhookPayload(hook, header, body)
+		require.Nil(t, appErr)
+		assert.Equal(t, "octocat pushed 2 commit(s) to mattermost/mattermost", req.Text)
+		require.Len(t, req.Attachments, 1)
+		assert.Equal(t, "push", req.Attachments[0].Fallback)
+		assert.Equal(t, "Fix the flux capacit…", req.Attachments[0].Title)
+		assert.Equal(t, "https://example.com/compare", req.Attachments[0].TitleLink)
+	})
+
+	t.Run("json escapes untrusted values", func(t *testing.T) {
+		hook := &model.IncomingWebhook{PayloadTemplate: `{"text": {{ .Payload.msg | json }}}`}
+		req, appErr := a.TransformIncomingWebhookPayload(hook, http.Header{}, []byte(`{"msg": "quote \" and\nnewline"}`))
+		require.Nil(t, appErr)
+		assert.Equal(t, "quote \" and\nnewline", req.Text)
+	})
+
+	t.Run("missing keys render as defaults", func(t *testing.T) {
+		hook := &model.IncomingWebhook{PayloadTemplate: `{"text": {{ default "no title" .Payload.title | json }}}`}
+		req, appErr := a.TransformIncomingWebhookPayload(hook, http.Header{}, []byte(`{}`))
+		require.Nil(t, appErr)
+		assert.Equal(t, "no title", req.Text)
+	})
+
+	t.Run("body that isn't JSON", func(t *testing.T) {
+		hook := &model.IncomingWebhook{PayloadTemplate: `{"text": "static"}`}
+		_, appErr := a.TransformIncomingWebhookPayload(hook, http.Header{}, []byte(`not json`))
+		require.NotNil(t, appErr)
+		assert.Equal(t, http.StatusBadRequest, appErr.StatusCode)
+	})
+
+	t.Run("template that doesn't produce a valid request", func(t *testing.T) {
+		hook := &model.IncomingWebhook{PayloadTemplate: `text: {{ .Payload.msg }}`}
+		_, appErr := a.TransformIncomingWebhookPayload(hook, http.Header{}, []byte(`{"msg": "hi"}`))
+		require.NotNil(t, appErr)
+	})
+}
+
+func TestValidateWebhookPayloadTemplate(t *testing.T) {
+	require.Nil(t, validateWebhookPayloadTemplate("test", ""))
+	require.Nil(t, validateWebhookPayloadTemplate("test", githubPushTemplate))
+
+	appErr := validateWebhookPayloadTemplate("test", `{"text": {{ .Payload.msg }`)
+	require.NotNil(t, appErr)
+	assert.Equal(t, http.StatusBadRequest, appErr.StatusCode)
+
+	appErr = validateWebhookPayloadTemplate("test", `{{ unknownFunc .Payload }}`)
+	require.NotNil(t, appErr)
+}
+
+func TestPrepareIncomingWebhookRequest(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t).InitBasic(t)
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableIncomingWebhooks = true })
+
+	secret := model.NewId()
+	hook, appErr := th.App.CreateIncomingWebhookForChannel(th.BasicUser.Id, th.BasicChannel, &model.IncomingWebhook{
+		ChannelId:       th.BasicChannel.Id,
+		SigningSecret:   secret,
+		PayloadTemplate: `{"text": {{ .Payload.alert | json }}}`,
+	})
+	require.Nil(t, appErr)
+
+	body := []byte(`{"alert": "disk full"}`)
+
+	t.Run("unsigned request is rejected", func(t *testing.T) {
+		_, appErr := th.App.PrepareIncomingWebhookRequest(hook.Id, http.Header{}, body)
+		require.NotNil(t, appErr)
+		assert.Equal(t, http.StatusUnauthorized, appErr.StatusCode)
+	})
+
+	t.Run("signed request is transformed", func(t *testing.T) {
+		timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+		header := http.Header{}
+		header.Set(model.IncomingWebhookTimestampHeader, timestamp)
+		header.Set(model.IncomingWebhookSignatureHeader, model.ComputeIncomingWebhookSignature(secret, timestamp, body))
+
+		req, appErr := th.App.PrepareIncomingWebhookRequest(hook.Id, header, body)
+		require.Nil(t, appErr)
+		require.NotNil(t, req)
+		assert.Equal(t, "disk full", req.Text)
+	})
+
+	t.Run("plain hook defers to regular decoding", func(t *testing.T) {
+		plain, appErr := th.App.CreateIncomingWebhookForChannel(th.BasicUser.Id, th.BasicChannel, &model.IncomingWebhook{ChannelId: th.BasicChannel.Id})
+		require.Nil(t, appErr)
+
+		req, appErr := th.App.PrepareIncomingWebhookRequest(plain.Id, http.Header{}, body)
+		require.Nil(t, appErr)
+		assert.Nil(t, req)
+	})
+
+	t.Run("invalid template is rejected on save", func(t *testing.T) {
+		_, appErr := th.App.CreateIncomingWebhookForChannel(th.BasicUser.Id, th.BasicChannel, &model.IncomingWebhook{
+			ChannelId:       th.BasicChannel.Id,
+			PayloadTemplate: `{{ .Payload.alert `,
+		})
+		require.NotNil(t, appErr)
+	})
+}
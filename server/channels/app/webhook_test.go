@@ -292,6 +292,67 @@ func TestUpdateIncomingWebhook(t *testing.T) {
 	}
 }
 
+func TestUpdateIncomingWebhookSigningSecret(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t).InitBasic(t)
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableIncomingWebhooks = true })
+
+	secret := model.NewId()
+	hook, appErr := th.App.CreateIncomingWebhookForChannel(th.BasicUser.Id, th.BasicChannel, &model.IncomingWebhook{
+		ChannelId:     th.BasicChannel.Id,
+		SigningSecret: secret,
+	})
+	require.Nil(t, appErr)
+
+	t.Run("an empty secret keeps the current one", func(t *testing.T) {
+		updatedHook, appErr := th.App.UpdateIncomingWebhook(hook, &model.IncomingWebhook{
+			DisplayName: "renamed",
+			ChannelId:   th.BasicChannel.Id,
+		})
+		require.Nil(t, appErr)
+		assert.Equal(t, "renamed", updatedHook.DisplayName)
+		assert.Equal(t, secret, updatedHook.SigningSecret)
+
+		hook, appErr = th.App.GetIncomingWebhook(hook.Id)
+		require.Nil(t, appErr)
+		assert.Equal(t, secret, hook.SigningSecret)
+	})
+
+	t.Run("a new secret replaces the current one", func(t *testing.T) {
+		newSecret := model.NewId()
+		updatedHook, appErr := th.App.UpdateIncomingWebhook(hook, &model.IncomingWebhook{
+			ChannelId:     th.BasicChannel.Id,
+			SigningSecret: newSecret,
+		})
+		require.Nil(t, appErr)
+		assert.Equal(t, newSecret, updatedHook.SigningSecret)
+	})
+
+	t.Run("a new secret can't be removed at the same time", func(t *testing.T) {
+		_, appErr := th.App.UpdateIncomingWebhook(hook, &model.IncomingWebhook{
+			ChannelId:           th.BasicChannel.Id,
+			SigningSecret:       model.NewId(),
+			RemoveSigningSecret: true,
+		})
+		require.NotNil(t, appErr)
+		assert.Equal(t, "api.incoming_webhook.remove_signing_secret.app_error", appErr.Id)
+	})
+
+	t.Run("the secret is removed on request", func(t *testing.T) {
+		updatedHook, appErr := th.App.UpdateIncomingWebhook(hook, &model.IncomingWebhook{
+			ChannelId:           th.BasicChannel.Id,
+			RemoveSigningSecret: true,
+		})
+		require.Nil(t, appErr)
+		assert.Empty(t, updatedHook.SigningSecret)
+
+		hook, appErr = th.App.GetIncomingWebhook(hook.Id)
+		require.Nil(t, appErr)
+		assert.False(t, hook.IsSigned())
+	})
+}
+
 func TestCreateWebhookPost(t *testing.T) {
 	mainHelper.Parallel(t)
 	testCluster := &testlib.FakeClusterInterface{}
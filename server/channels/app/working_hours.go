@@ -0,0 +1,158 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+const workingHoursBatchSize = 1000
+
+func (a *App) GetWorkingHours(userID string) (*model.WorkingHours, *model.AppError) {
+	workingHours, err := a.Srv().Store().WorkingHours().Get(userID)
+	if err != nil {
+		var nfErr *store.ErrNotFound
+		switch {
+		case errors.As(err, &nfErr):
+			return nil, model.NewAppError("GetWorkingHours", "app.working_hours.get.not_found.app_error", nil, "", http.StatusNotFound).Wrap(err)
+		default:
+			return nil, model.NewAppError("GetWorkingHours", "app.working_hours.get.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+	}
+
+	return workingHours, nil
+}
+
+// SaveWorkingHours creates or replaces the working hours of a user, and updates their status right
+// away if their quiet hours start or end with the new schedule.
+func (a *App) SaveWorkingHours(rctx request.CTX, workingHours *model.WorkingHours) (*model.WorkingHours, *model.AppError) {
+	workingHours.PreSave()
+	if appErr := workingHours.IsValid(); appErr != nil {
+		return nil, appErr
+	}
+
+	// InQuietHours and QuietStatusSet are maintained by the server, and carried over from the saved
+	// working hours.
+	workingHours.InQuietHours = false
+	workingHours.QuietStatusSet = false
+	existing, err := a.Srv().Store().WorkingHours().Get(workingHours.UserId)
+	if err != nil {
+		var nfErr *store.ErrNotFound
+		if !errors.As(err, &nfErr) {
+			return nil, model.NewAppError("SaveWorkingHours", "app.working_hours.get.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+	} else if existing.InQuietHours {
+		if existing.QuietStatus == workingHours.QuietStatus {
+			workingHours.InQuietHours = true
+			workingHours.QuietStatusSet = existing.QuietStatusSet
+		} else if existing.QuietStatusSet {
+			a.Srv().Platform().UnsetStatusQuietHours(existing.UserId, existing.QuietStatus)
+		}
+	}
+
+	saved, err := a.Srv().Store().WorkingHours().Save(workingHours)
+	if err != nil {
+		var appErr *model.AppError
+		switch {
+		case errors.As(err, &appErr):
+			return nil, appErr
+		default:
+			return nil, model.NewAppError("SaveWorkingHours", "app.working_hours.save.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+	}
+
+	if err := a.applyWorkingHours(saved, time.Now()); err != nil {
+		rctx.Logger().Warn("Failed to apply the working hours", mlog.String("user_id", saved.UserId), mlog.Err(err))
+	}
+
+	return saved, nil
+}
+
+// UpdateWorkingHoursStatuses is a recurring task which switches the status of the users whose quiet
+// hours started or ended since it last ran.
+func (a *App) UpdateWorkingHoursStatuses() {
+	now := time.Now()
+	afterUserID := ""
+	for {
+		list, err := a.Srv().Store().WorkingHours().GetEnabled(afterUserID, workingHoursBatchSize)
+		if err != nil {
+			mlog.Warn("Failed to fetch the working hours from store", mlog.Err(err))
+			return
+		}
+
+		for _, workingHours := range list {
+			if err := a.applyWorkingHours(workingHours, now); err != nil {
+				mlog.Warn("Failed to apply the working hours", mlog.String("user_id", workingHours.UserId), mlog.Err(err))
+			}
+		}
+
+		if len(list) < workingHoursBatchSize {
+			return
+		}
+		afterUserID = list[len(list)-1].UserId
+	}
+}
+
+// applyWorkingHours switches the status of the user when their quiet hours start or end at the
+// given time. Nothing is changed while they are going on, so that a status the user sets in the
+// meantime is kept.
+func (a *App) applyWorkingHours(workingHours *model.WorkingHours, now time.Time) error {
+	inQuietHours := workingHours.IsQuietTime(now)
+	if inQuietHours == workingHours.InQuietHours {
+		return nil
+	}
+
+	// A status the user set on their own is neither replaced nor restored.
+	quietStatusSet := false
+	if inQuietHours {
+		quietStatusSet = a.Srv().Platform().SetStatusQuietHours(workingHours.UserId, workingHours.QuietStatus)
+	} else if workingHours.QuietStatusSet {
+		a.Srv().Platform().UnsetStatusQuietHours(workingHours.UserId, workingHours.QuietStatus)
+	}
+
+	if err := a.Srv().Store().WorkingHours().SetInQuietHours(workingHours.UserId, inQuietHours, quietStatusSet); err != nil {
+		return err
+	}
+	workingHours.InQuietHours = inQuietHours
+	workingHours.QuietStatusSet = quietStatusSet
+
+	return nil
+}
+
+// getQuietHours returns the working hours of the user when they are in quiet hours, and nil
+// otherwise.
+func (a *App) getQuietHours(rctx request.CTX, userID string) *model.WorkingHours {
+	workingHours, err := a.Srv().Store().WorkingHours().Get(userID)
+	if err != nil {
+		var nfErr *store.ErrNotFound
+		if !errors.As(err, &nfErr) {
+			rctx.Logger().Warn("Failed to get the working hours", mlog.String("user_id", userID), mlog.Err(err))
+		}
+		return nil
+	}
+
+	if !workingHours.IsQuietTime(time.Now()) {
+		return nil
+	}
+
+	return workingHours
+}
+
+// quietHoursBypassed returns whether the post is sent to the user in spite of their quiet hours.
+func quietHoursBypassed(workingHours *model.WorkingHours, post *model.Post) bool {
+	return workingHours.UrgentBypass && post.IsUrgent()
+}
+
+// quietStatusBypassed returns whether the status of the user is the one set for their quiet hours,
+// which posts bypassing the quiet hours bypass too. A status the user set on their own is honored.
+func quietStatusBypassed(workingHours *model.WorkingHours, status *model.Status) bool {
+	return workingHours.QuietStatusSet && status.Status == workingHours.QuietStatus
+}
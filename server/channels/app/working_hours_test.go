@@ -0,0 +1,176 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+// alwaysWorkingSchedule returns a schedule covering the whole week.
+func alwaysWorkingSchedule() model.WorkingHoursRanges {
+	schedule := model.WorkingHoursRanges{}
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		schedule = append(schedule,
+			model.WorkingHoursRange{Day: day, Start: "00:00", End: "12:00"},
+			model.WorkingHoursRange{Day: day, Start: "12:00", End: "00:00"},
+		)
+	}
+	return schedule
+}
+
+func TestSaveWorkingHours(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t).InitBasic(t)
+
+	userID := th.BasicUser.Id
+
+	_, appErr := th.App.GetWorkingHours(userID)
+	require.NotNil(t, appErr)
+	assert.Equal(t, http.StatusNotFound, appErr.StatusCode)
+
+	_, appErr = th.App.SaveWorkingHours(th.Context, &model.WorkingHours{UserId: userID, Timezone: "Nowhere/Special"})
+	require.NotNil(t, appErr)
+	assert.Equal(t, "model.working_hours.is_valid.timezone.app_error", appErr.Id)
+
+	t.Run("status is switched when the quiet hours start and end", func(t *testing.T) {
+		th.App.SetStatusOnline(userID, false)
+
+		// Without any working time, the user is always in quiet hours.
+		workingHours, appErr := th.App.SaveWorkingHours(th.Context, &model.WorkingHours{UserId: userID, Enabled: true, Timezone: "UTC"})
+		require.Nil(t, appErr)
+		assert.True(t, workingHours.InQuietHours)
+
+		status, appErr := th.App.GetStatus(userID)
+		require.Nil(t, appErr)
+		assert.Equal(t, model.StatusDnd, status.Status)
+		assert.True(t, status.Manual)
+
+		workingHours.Schedule = alwaysWorkingSchedule()
+		workingHours, appErr = th.App.SaveWorkingHours(th.Context, workingHours)
+		require.Nil(t, appErr)
+		assert.False(t, workingHours.InQuietHours)
+
+		status, appErr = th.App.GetStatus(userID)
+		require.Nil(t, appErr)
+		assert.Equal(t, model.StatusOnline, status.Status)
+		assert.False(t, status.Manual)
+	})
+
+	t.Run("status set by the user is kept", func(t *testing.T) {
+		th.App.SetStatusAwayIfNeeded(userID, true)
+
+		workingHours, appErr := th.App.SaveWorkingHours(th.Context, &model.WorkingHours{UserId: userID, Enabled: true, Timezone: "UTC"})
+		require.Nil(t, appErr)
+		assert.True(t, workingHours.InQuietHours)
+
+		status, appErr := th.App.GetStatus(userID)
+		require.Nil(t, appErr)
+		assert.Equal(t, model.StatusAway, status.Status)
+
+		workingHours.Enabled = false
+		workingHours, appErr = th.App.SaveWorkingHours(th.Context, workingHours)
+		require.Nil(t, appErr)
+		assert.False(t, workingHours.InQuietHours)
+
+		status, appErr = th.App.GetStatus(userID)
+		require.Nil(t, appErr)
+		assert.Equal(t, model.StatusAway, status.Status)
+	})
+
+	t.Run("do not disturb set by the user is kept", func(t *testing.T) {
+		th.App.SetStatusDoNotDisturb(userID)
+
+		workingHours, appErr := th.App.SaveWorkingHours(th.Context, &model.WorkingHours{UserId: userID, Enabled: true, Timezone: "UTC"})
+		require.Nil(t, appErr)
+		assert.True(t, workingHours.InQuietHours)
+		assert.False(t, workingHours.QuietStatusSet)
+
+		workingHours.Enabled = false
+		_, appErr = th.App.SaveWorkingHours(th.Context, workingHours)
+		require.Nil(t, appErr)
+
+		status, appErr := th.App.GetStatus(userID)
+		require.Nil(t, appErr)
+		assert.Equal(t, model.StatusDnd, status.Status)
+		assert.True(t, status.Manual)
+	})
+}
+
+func TestUpdateWorkingHoursStatuses(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t).InitBasic(t)
+
+	userID := th.BasicUser.Id
+	th.App.SetStatusOnline(userID, false)
+
+	_, err := th.App.Srv().Store().WorkingHours().Save(&model.WorkingHours{UserId: userID, Enabled: true, Timezone: "UTC", QuietStatus: model.StatusAway})
+	require.NoError(t, err)
+
+	th.App.UpdateWorkingHoursStatuses()
+
+	status, appErr := th.App.GetStatus(userID)
+	require.Nil(t, appErr)
+	assert.Equal(t, model.StatusAway, status.Status)
+
+	workingHours, appErr := th.App.GetWorkingHours(userID)
+	require.Nil(t, appErr)
+	assert.True(t, workingHours.InQuietHours)
+
+	// Nothing changes while the quiet hours go on.
+	th.App.SetStatusOnline(userID, true)
+	th.App.UpdateWorkingHoursStatuses()
+
+	status, appErr = th.App.GetStatus(userID)
+	require.Nil(t, appErr)
+	assert.Equal(t, model.StatusOnline, status.Status)
+}
+
+func TestQuietHoursNotifications(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t).InitBasic(t)
+
+	user := th.BasicUser
+	user.NotifyProps[model.PushNotifyProp] = model.UserNotifyAll
+	user.NotifyProps[model.EmailNotifyProp] = "true"
+
+	_, err := th.App.Srv().Store().WorkingHours().Save(&model.WorkingHours{UserId: user.Id, Enabled: true, Timezone: "UTC", InQuietHours: true, QuietStatusSet: true})
+	require.NoError(t, err)
+
+	status := &model.Status{UserId: user.Id, Status: model.StatusDnd, Manual: true}
+	post := &model.Post{UserId: th.BasicUser2.Id, ChannelId: th.BasicChannel.Id}
+	urgentPost := &model.Post{
+		UserId:    th.BasicUser2.Id,
+		ChannelId: th.BasicChannel.Id,
+		Metadata: &model.PostMetadata{
+			Priority: &model.PostPriority{
+				Priority: model.NewPointer(model.PostPriorityUrgent),
+			},
+		},
+	}
+
+	assert.False(t, th.App.ShouldSendPushNotification(th.Context, user, model.StringMap{}, true, status, post, false))
+	assert.False(t, th.App.ShouldSendPushNotification(th.Context, user, model.StringMap{}, true, status, urgentPost, false))
+
+	_, appErr := th.App.SaveWorkingHours(th.Context, &model.WorkingHours{UserId: user.Id, Enabled: true, Timezone: "UTC", UrgentBypass: true})
+	require.Nil(t, appErr)
+
+	assert.False(t, th.App.ShouldSendPushNotification(th.Context, user, model.StringMap{}, true, status, post, false))
+	assert.True(t, th.App.ShouldSendPushNotification(th.Context, user, model.StringMap{}, true, status, urgentPost, false))
+
+	th.App.SetStatusDoNotDisturb(user.Id)
+	assert.False(t, th.App.userAllowsEmail(th.Context, user, model.StringMap{}, post))
+	assert.True(t, th.App.userAllowsEmail(th.Context, user, model.StringMap{}, urgentPost))
+
+	// Urgent posts don't bypass a status the user set on their own.
+	require.NoError(t, th.App.Srv().Store().WorkingHours().SetInQuietHours(user.Id, true, false))
+	assert.False(t, th.App.ShouldSendPushNotification(th.Context, user, model.StringMap{}, true, status, urgentPost, false))
+	assert.False(t, th.App.userAllowsEmail(th.Context, user, model.StringMap{}, urgentPost))
+}
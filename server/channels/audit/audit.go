@@ -12,9 +12,16 @@ import (
 
 const DefMaxQueueSize = 1000
 
+// RecordSink receives every audit record, in addition to the configured targets.
+type RecordSink interface {
+	LogRecord(level mlog.Level, rec model.AuditRecord)
+}
+
 type Audit struct {
 	logger *mlog.Logger
 
+	sink RecordSink
+
 	// OnQueueFull is called on an attempt to add an audit record to a full queue.
 	// Return true to drop record, or false to block until there is room in queue.
 	OnQueueFull func(qname string, maxQueueSize int) bool
@@ -44,6 +51,16 @@ func (a *Audit) LogRecord(level mlog.Level, rec model.AuditRecord) {
 	}
 
 	a.logger.Log(level, "", flds...)
+
+	if a.sink != nil {
+		a.sink.LogRecord(level, rec)
+	}
+}
+
+// SetSink sets the sink receiving the audit records. It must be called before
+// any record is logged.
+func (a *Audit) SetSink(sink RecordSink) {
+	a.sink = sink
 }
 
 // Configure sets zero or more target to output audit logs to.
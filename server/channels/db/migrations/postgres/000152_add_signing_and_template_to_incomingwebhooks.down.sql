@@ -0,0 +1,2 @@
+ALTER TABLE incomingwebhooks DROP COLUMN IF EXISTS payloadtemplate;
+ALTER TABLE incomingwebhooks DROP COLUMN IF EXISTS signingsecret;
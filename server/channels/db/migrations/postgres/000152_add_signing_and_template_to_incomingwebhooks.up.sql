@@ -0,0 +1,2 @@
+ALTER TABLE incomingwebhooks ADD COLUMN IF NOT EXISTS signingsecret varchar(128) DEFAULT '';
+ALTER TABLE incomingwebhooks ADD COLUMN IF NOT EXISTS payloadtemplate text DEFAULT '';
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_eventsubscriptions_teamid_deleteat;
+DROP TABLE IF EXISTS eventsubscriptions;
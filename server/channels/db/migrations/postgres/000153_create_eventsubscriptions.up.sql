@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS eventsubscriptions (
+    id varchar(26) PRIMARY KEY,
+    createat bigint NOT NULL,
+    updateat bigint NOT NULL,
+    deleteat bigint NOT NULL DEFAULT 0,
+    creatorid varchar(26) NOT NULL,
+    teamid varchar(26) NOT NULL DEFAULT '',
+    channelid varchar(26) NOT NULL DEFAULT '',
+    displayname varchar(64) NOT NULL DEFAULT '',
+    description varchar(500) NOT NULL DEFAULT '',
+    callbackurl varchar(1024) NOT NULL,
+    secret varchar(26) NOT NULL,
+    events jsonb NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_eventsubscriptions_teamid_deleteat ON eventsubscriptions (teamid, deleteat);
@@ -0,0 +1 @@
+ALTER TABLE scheduledposts ADD COLUMN IF NOT EXISTS recurrence jsonb;
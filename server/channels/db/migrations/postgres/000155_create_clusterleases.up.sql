@@ -0,0 +1,5 @@
+CREATE TABLE IF NOT EXISTS clusterleases (
+    name varchar(64) PRIMARY KEY,
+    holderid varchar(26) NOT NULL,
+    expireat bigint NOT NULL
+);
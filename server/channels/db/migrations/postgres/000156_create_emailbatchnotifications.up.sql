@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS emailbatchnotifications (
+    id varchar(26) PRIMARY KEY,
+    userid varchar(26) NOT NULL,
+    postid varchar(26) NOT NULL,
+    channelid varchar(26) NOT NULL,
+    rootid varchar(26) NOT NULL DEFAULT '',
+    teamname varchar(64) NOT NULL DEFAULT '',
+    createat bigint NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_emailbatchnotifications_userid_createat ON emailbatchnotifications (userid, createat);
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS workinghours (
+    userid varchar(26) PRIMARY KEY,
+    enabled boolean NOT NULL DEFAULT false,
+    timezone varchar(64) NOT NULL,
+    schedule jsonb NOT NULL,
+    exceptions jsonb NOT NULL,
+    quietstatus varchar(32) NOT NULL,
+    urgentbypass boolean NOT NULL DEFAULT false,
+    inquiethours boolean NOT NULL DEFAULT false,
+    updateat bigint NOT NULL
+);
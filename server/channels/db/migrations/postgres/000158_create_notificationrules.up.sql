@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS notificationrules (
+    id varchar(26) PRIMARY KEY,
+    userid varchar(26) NOT NULL,
+    name varchar(64) NOT NULL,
+    enabled boolean NOT NULL DEFAULT true,
+    sortorder bigint NOT NULL DEFAULT 0,
+    conditions jsonb NOT NULL,
+    action jsonb NOT NULL,
+    createat bigint NOT NULL,
+    updateat bigint NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_notificationrules_userid_sortorder ON notificationrules (userid, sortorder);
@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS auditlogs (
+    id varchar(26) PRIMARY KEY,
+    sequence bigint NOT NULL UNIQUE,
+    createat bigint NOT NULL,
+    eventname varchar(128) NOT NULL,
+    status varchar(32) NOT NULL DEFAULT '',
+    actorid varchar(128) NOT NULL DEFAULT '',
+    objectid varchar(128) NOT NULL DEFAULT '',
+    record text NOT NULL,
+    prevhash varchar(64) NOT NULL DEFAULT '',
+    hash varchar(64) NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_auditlogs_createat ON auditlogs (createat);
+CREATE INDEX IF NOT EXISTS idx_auditlogs_actorid_createat ON auditlogs (actorid, createat);
+CREATE INDEX IF NOT EXISTS idx_auditlogs_eventname_createat ON auditlogs (eventname, createat);
+CREATE INDEX IF NOT EXISTS idx_auditlogs_objectid_createat ON auditlogs (objectid, createat);
@@ -0,0 +1 @@
+ALTER TABLE workinghours DROP COLUMN IF EXISTS quietstatusset;
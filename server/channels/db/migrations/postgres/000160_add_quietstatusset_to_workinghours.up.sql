@@ -0,0 +1 @@
+ALTER TABLE workinghours ADD COLUMN IF NOT EXISTS quietstatusset boolean NOT NULL DEFAULT false;
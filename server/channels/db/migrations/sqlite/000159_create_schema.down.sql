@@ -0,0 +1,90 @@
+DROP VIEW IF EXISTS attributeview;
+DROP VIEW IF EXISTS file_stats;
+DROP VIEW IF EXISTS bot_posts_by_team_day;
+DROP VIEW IF EXISTS posts_by_team_day;
+DROP VIEW IF EXISTS poststats;
+DROP TABLE IF EXISTS retentionidsfordeletion;
+DROP TABLE IF EXISTS auditlogs;
+DROP TABLE IF EXISTS notificationrules;
+DROP TABLE IF EXISTS workinghours;
+DROP TABLE IF EXISTS emailbatchnotifications;
+DROP TABLE IF EXISTS clusterleases;
+DROP TABLE IF EXISTS eventsubscriptions;
+DROP TABLE IF EXISTS aipreferences;
+DROP TABLE IF EXISTS aianalytics;
+DROP TABLE IF EXISTS aisummaries;
+DROP TABLE IF EXISTS aiactionitems;
+DROP TABLE IF EXISTS translations;
+DROP TABLE IF EXISTS contentflaggingteamreviewers;
+DROP TABLE IF EXISTS contentflaggingteamsettings;
+DROP TABLE IF EXISTS contentflaggingcommonreviewers;
+DROP TABLE IF EXISTS accesscontrolpolicyhistory;
+DROP TABLE IF EXISTS accesscontrolpolicies;
+DROP TABLE IF EXISTS propertyvalues;
+DROP TABLE IF EXISTS propertyfields;
+DROP TABLE IF EXISTS propertygroups;
+DROP TABLE IF EXISTS scheduledposts;
+DROP TABLE IF EXISTS channelbookmarks;
+DROP TABLE IF EXISTS outgoingoauthconnections;
+DROP TABLE IF EXISTS desktoptokens;
+DROP TABLE IF EXISTS persistentnotifications;
+DROP TABLE IF EXISTS drafts;
+DROP TABLE IF EXISTS postacknowledgements;
+DROP TABLE IF EXISTS postspriority;
+DROP TABLE IF EXISTS notifyadmin;
+DROP TABLE IF EXISTS postreminders;
+DROP TABLE IF EXISTS recentsearches;
+DROP TABLE IF EXISTS retentionpolicieschannels;
+DROP TABLE IF EXISTS retentionpoliciesteams;
+DROP TABLE IF EXISTS retentionpolicies;
+DROP TABLE IF EXISTS publicchannels;
+DROP TABLE IF EXISTS channelmembers;
+DROP TABLE IF EXISTS channels;
+DROP TABLE IF EXISTS oauthapps;
+DROP TABLE IF EXISTS fileinfo;
+DROP TABLE IF EXISTS users;
+DROP TABLE IF EXISTS pluginkeyvaluestore;
+DROP TABLE IF EXISTS usertermsofservice;
+DROP TABLE IF EXISTS threadmemberships;
+DROP TABLE IF EXISTS threads;
+DROP TABLE IF EXISTS uploadsessions;
+DROP TABLE IF EXISTS sidebarcategories;
+DROP TABLE IF EXISTS channelmemberhistory;
+DROP TABLE IF EXISTS jobs;
+DROP TABLE IF EXISTS sharedchannelremotes;
+DROP TABLE IF EXISTS sharedchannelusers;
+DROP TABLE IF EXISTS sharedchannelattachments;
+DROP TABLE IF EXISTS oauthauthdata;
+DROP TABLE IF EXISTS sidebarchannels;
+DROP TABLE IF EXISTS sharedchannels;
+DROP TABLE IF EXISTS remoteclusters;
+DROP TABLE IF EXISTS useraccesstokens;
+DROP TABLE IF EXISTS bots;
+DROP TABLE IF EXISTS tokens;
+DROP TABLE IF EXISTS status;
+DROP TABLE IF EXISTS preferences;
+DROP TABLE IF EXISTS oauthaccessdata;
+DROP TABLE IF EXISTS audits;
+DROP TABLE IF EXISTS termsofservice;
+DROP TABLE IF EXISTS sessions;
+DROP TABLE IF EXISTS productnoticeviewstate;
+DROP TABLE IF EXISTS posts;
+DROP TABLE IF EXISTS licenses;
+DROP TABLE IF EXISTS schemes;
+DROP TABLE IF EXISTS roles;
+DROP TABLE IF EXISTS reactions;
+DROP TABLE IF EXISTS systems;
+DROP TABLE IF EXISTS outgoingwebhooks;
+DROP TABLE IF EXISTS incomingwebhooks;
+DROP TABLE IF EXISTS commands;
+DROP TABLE IF EXISTS linkmetadata;
+DROP TABLE IF EXISTS groupchannels;
+DROP TABLE IF EXISTS groupteams;
+DROP TABLE IF EXISTS groupmembers;
+DROP TABLE IF EXISTS usergroups;
+DROP TABLE IF EXISTS emoji;
+DROP TABLE IF EXISTS compliances;
+DROP TABLE IF EXISTS commandwebhooks;
+DROP TABLE IF EXISTS clusterdiscovery;
+DROP TABLE IF EXISTS teammembers;
+DROP TABLE IF EXISTS teams;
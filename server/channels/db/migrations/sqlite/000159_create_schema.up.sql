@@ -0,0 +1,1226 @@
+-- Baseline schema for SQLite, equivalent to the Postgres schema at version 159.
+-- Later migrations are added to both directories under the same version.
+
+CREATE TABLE IF NOT EXISTS teams (
+    id varchar(26) PRIMARY KEY CHECK (length(id) <= 26),
+    createat bigint,
+    updateat bigint,
+    deleteat bigint,
+    displayname varchar(64) CHECK (length(displayname) <= 64),
+    name varchar(64) CHECK (length(name) <= 64),
+    description varchar(255) CHECK (length(description) <= 255),
+    email varchar(128) CHECK (length(email) <= 128),
+    type varchar(255) CHECK (type IN ('I', 'O')) CHECK (length(type) <= 255),
+    companyname varchar(64) CHECK (length(companyname) <= 64),
+    alloweddomains varchar(1000) CHECK (length(alloweddomains) <= 1000),
+    inviteid varchar(32) CHECK (length(inviteid) <= 32),
+    schemeid varchar(26) CHECK (length(schemeid) <= 26),
+    allowopeninvite boolean,
+    lastteamiconupdate bigint,
+    groupconstrained boolean,
+    cloudlimitsarchived boolean NOT NULL DEFAULT false,
+    UNIQUE (name)
+);
+CREATE INDEX IF NOT EXISTS idx_teams_invite_id ON teams (inviteid);
+CREATE INDEX IF NOT EXISTS idx_teams_update_at ON teams (updateat);
+CREATE INDEX IF NOT EXISTS idx_teams_create_at ON teams (createat);
+CREATE INDEX IF NOT EXISTS idx_teams_delete_at ON teams (deleteat);
+CREATE INDEX IF NOT EXISTS idx_teams_scheme_id ON teams (schemeid);
+
+CREATE TABLE IF NOT EXISTS teammembers (
+    teamid varchar(26) NOT NULL CHECK (length(teamid) <= 26),
+    userid varchar(26) NOT NULL CHECK (length(userid) <= 26),
+    roles varchar(256) CHECK (length(roles) <= 256),
+    deleteat bigint,
+    schemeuser boolean,
+    schemeadmin boolean,
+    schemeguest boolean,
+    createat bigint DEFAULT 0,
+    PRIMARY KEY (teamid, userid)
+);
+CREATE INDEX IF NOT EXISTS idx_teammembers_user_id ON teammembers (userid);
+CREATE INDEX IF NOT EXISTS idx_teammembers_delete_at ON teammembers (deleteat);
+CREATE INDEX IF NOT EXISTS idx_teammembers_createat ON teammembers (createat);
+
+CREATE TABLE IF NOT EXISTS clusterdiscovery (
+    id varchar(26) PRIMARY KEY CHECK (length(id) <= 26),
+    type varchar(64) CHECK (length(type) <= 64),
+    clustername varchar(64) CHECK (length(clustername) <= 64),
+    hostname varchar(512) CHECK (length(hostname) <= 512),
+    gossipport integer,
+    port integer,
+    createat bigint,
+    lastpingat bigint
+);
+
+CREATE TABLE IF NOT EXISTS commandwebhooks (
+    id varchar(26) PRIMARY KEY CHECK (length(id) <= 26),
+    createat bigint,
+    commandid varchar(26) CHECK (length(commandid) <= 26),
+    userid varchar(26) CHECK (length(userid) <= 26),
+    channelid varchar(26) CHECK (length(channelid) <= 26),
+    rootid varchar(26) CHECK (length(rootid) <= 26),
+    usecount integer
+);
+CREATE INDEX IF NOT EXISTS idx_command_webhook_create_at ON commandwebhooks (createat);
+
+CREATE TABLE IF NOT EXISTS compliances (
+    id varchar(26) PRIMARY KEY CHECK (length(id) <= 26),
+    createat bigint,
+    userid varchar(26) CHECK (length(userid) <= 26),
+    status varchar(64) CHECK (length(status) <= 64),
+    count integer,
+    desc varchar(512) CHECK (length(desc) <= 512),
+    type varchar(64) CHECK (length(type) <= 64),
+    startat bigint,
+    endat bigint,
+    keywords varchar(512) CHECK (length(keywords) <= 512),
+    emails varchar(1024) CHECK (length(emails) <= 1024)
+);
+
+CREATE TABLE IF NOT EXISTS emoji (
+    id varchar(26) PRIMARY KEY CHECK (length(id) <= 26),
+    createat bigint,
+    updateat bigint,
+    deleteat bigint,
+    creatorid varchar(26) CHECK (length(creatorid) <= 26),
+    name varchar(64) CHECK (length(name) <= 64),
+    UNIQUE (name, deleteat)
+);
+CREATE INDEX IF NOT EXISTS idx_emoji_update_at ON emoji (updateat);
+CREATE INDEX IF NOT EXISTS idx_emoji_create_at ON emoji (createat);
+CREATE INDEX IF NOT EXISTS idx_emoji_delete_at ON emoji (deleteat);
+
+CREATE TABLE IF NOT EXISTS usergroups (
+    id varchar(26) PRIMARY KEY CHECK (length(id) <= 26),
+    name varchar(64) CHECK (length(name) <= 64),
+    displayname varchar(128) CHECK (length(displayname) <= 128),
+    description varchar(1024) CHECK (length(description) <= 1024),
+    source varchar(64) CHECK (length(source) <= 64),
+    remoteid varchar(48) CHECK (length(remoteid) <= 48),
+    createat bigint,
+    updateat bigint,
+    deleteat bigint,
+    allowreference boolean,
+    UNIQUE (source, remoteid),
+    UNIQUE (name)
+);
+CREATE INDEX IF NOT EXISTS idx_usergroups_remote_id ON usergroups (remoteid);
+CREATE INDEX IF NOT EXISTS idx_usergroups_delete_at ON usergroups (deleteat);
+CREATE INDEX IF NOT EXISTS idx_usergroups_displayname ON usergroups(displayname);
+
+CREATE TABLE IF NOT EXISTS groupmembers (
+    groupid varchar(26) CHECK (length(groupid) <= 26),
+    userid varchar(26) CHECK (length(userid) <= 26),
+    createat bigint,
+    deleteat bigint,
+    PRIMARY KEY (groupid, userid)
+);
+CREATE INDEX IF NOT EXISTS idx_groupmembers_create_at ON groupmembers (createat);
+
+CREATE TABLE IF NOT EXISTS groupteams (
+    groupid varchar(26) CHECK (length(groupid) <= 26),
+    autoadd boolean,
+    schemeadmin boolean,
+    createat bigint,
+    deleteat bigint,
+    updateat bigint,
+    teamid varchar(26) CHECK (length(teamid) <= 26),
+    PRIMARY KEY (groupid, teamid)
+);
+CREATE INDEX IF NOT EXISTS idx_groupteams_schemeadmin ON groupteams (schemeadmin);
+CREATE INDEX IF NOT EXISTS idx_groupteams_teamid ON groupteams (teamid);
+
+CREATE TABLE IF NOT EXISTS groupchannels (
+    groupid varchar(26) CHECK (length(groupid) <= 26),
+    autoadd boolean,
+    schemeadmin boolean,
+    createat bigint,
+    deleteat bigint,
+    updateat bigint,
+    channelid varchar(26) CHECK (length(channelid) <= 26),
+    PRIMARY KEY (groupid, channelid)
+);
+CREATE INDEX IF NOT EXISTS idx_groupchannels_channelid ON groupchannels (channelid);
+CREATE INDEX IF NOT EXISTS idx_groupchannels_schemeadmin ON groupchannels(schemeadmin);
+
+CREATE TABLE IF NOT EXISTS linkmetadata (
+    hash bigint PRIMARY KEY,
+    url varchar(2048) CHECK (length(url) <= 2048),
+    timestamp bigint,
+    type varchar(16) CHECK (length(type) <= 16),
+    data text
+);
+CREATE INDEX IF NOT EXISTS idx_link_metadata_url_timestamp ON linkmetadata (url, timestamp);
+
+CREATE TABLE IF NOT EXISTS commands (
+    id varchar(26) PRIMARY KEY CHECK (length(id) <= 26),
+    token varchar(26) CHECK (length(token) <= 26),
+    createat bigint,
+    updateat bigint,
+    deleteat bigint,
+    creatorid varchar(26) CHECK (length(creatorid) <= 26),
+    teamid varchar(26) CHECK (length(teamid) <= 26),
+    trigger varchar(128) CHECK (length(trigger) <= 128),
+    method varchar(1) CHECK (length(method) <= 1),
+    username varchar(64) CHECK (length(username) <= 64),
+    iconurl varchar(1024) CHECK (length(iconurl) <= 1024),
+    autocomplete boolean,
+    autocompletedesc varchar(1024) CHECK (length(autocompletedesc) <= 1024),
+    autocompletehint varchar(1024) CHECK (length(autocompletehint) <= 1024),
+    displayname varchar(64) CHECK (length(displayname) <= 64),
+    description varchar(128) CHECK (length(description) <= 128),
+    url varchar(1024) CHECK (length(url) <= 1024),
+    pluginid varchar(190) CHECK (length(pluginid) <= 190)
+);
+CREATE INDEX IF NOT EXISTS idx_command_team_id ON commands (teamid);
+CREATE INDEX IF NOT EXISTS idx_command_update_at ON commands (updateat);
+CREATE INDEX IF NOT EXISTS idx_command_create_at ON commands (createat);
+CREATE INDEX IF NOT EXISTS idx_command_delete_at ON commands (deleteat);
+
+CREATE TABLE IF NOT EXISTS incomingwebhooks (
+    id varchar(26) PRIMARY KEY CHECK (length(id) <= 26),
+    createat bigint,
+    updateat bigint,
+    deleteat bigint,
+    userid varchar(26) CHECK (length(userid) <= 26),
+    channelid varchar(26) CHECK (length(channelid) <= 26),
+    teamid varchar(26) CHECK (length(teamid) <= 26),
+    displayname varchar(64) CHECK (length(displayname) <= 64),
+    description varchar(500) CHECK (length(description) <= 500),
+    username varchar(255) CHECK (length(username) <= 255),
+    iconurl varchar(1024) CHECK (length(iconurl) <= 1024),
+    channellocked boolean,
+    signingsecret varchar(128) DEFAULT '' CHECK (length(signingsecret) <= 128),
+    payloadtemplate text DEFAULT ''
+);
+CREATE INDEX IF NOT EXISTS idx_incoming_webhook_user_id ON incomingwebhooks (userid);
+CREATE INDEX IF NOT EXISTS idx_incoming_webhook_team_id ON incomingwebhooks (teamid);
+CREATE INDEX IF NOT EXISTS idx_incoming_webhook_update_at ON incomingwebhooks (updateat);
+CREATE INDEX IF NOT EXISTS idx_incoming_webhook_create_at ON incomingwebhooks (createat);
+CREATE INDEX IF NOT EXISTS idx_incoming_webhook_delete_at ON incomingwebhooks (deleteat);
+
+CREATE TABLE IF NOT EXISTS outgoingwebhooks (
+    id varchar(26) PRIMARY KEY CHECK (length(id) <= 26),
+    token varchar(26) CHECK (length(token) <= 26),
+    createat bigint,
+    updateat bigint,
+    deleteat bigint,
+    creatorid varchar(26) CHECK (length(creatorid) <= 26),
+    channelid varchar(26) CHECK (length(channelid) <= 26),
+    teamid varchar(26) CHECK (length(teamid) <= 26),
+    triggerwords varchar(1024) CHECK (length(triggerwords) <= 1024),
+    callbackurls varchar(1024) CHECK (length(callbackurls) <= 1024),
+    displayname varchar(64) CHECK (length(displayname) <= 64),
+    contenttype varchar(128) CHECK (length(contenttype) <= 128),
+    triggerwhen integer,
+    username varchar(64) CHECK (length(username) <= 64),
+    iconurl varchar(1024) CHECK (length(iconurl) <= 1024),
+    description varchar(500) CHECK (length(description) <= 500)
+);
+CREATE INDEX IF NOT EXISTS idx_outgoing_webhook_team_id ON outgoingwebhooks (teamid);
+CREATE INDEX IF NOT EXISTS idx_outgoing_webhook_update_at ON outgoingwebhooks (updateat);
+CREATE INDEX IF NOT EXISTS idx_outgoing_webhook_create_at ON outgoingwebhooks (createat);
+CREATE INDEX IF NOT EXISTS idx_outgoing_webhook_delete_at ON outgoingwebhooks (deleteat);
+
+CREATE TABLE IF NOT EXISTS systems (
+    name varchar(64) PRIMARY KEY CHECK (length(name) <= 64),
+    value varchar(1024) CHECK (length(value) <= 1024)
+);
+
+CREATE TABLE IF NOT EXISTS reactions (
+    userid varchar(26) NOT NULL CHECK (length(userid) <= 26),
+    postid varchar(26) NOT NULL CHECK (length(postid) <= 26),
+    emojiname varchar(64) NOT NULL CHECK (length(emojiname) <= 64),
+    createat bigint,
+    updateat bigint,
+    deleteat bigint,
+    remoteid varchar(26) CHECK (length(remoteid) <= 26),
+    channelid varchar(26) NOT NULL DEFAULT '' CHECK (length(channelid) <= 26),
+    PRIMARY KEY (postid, userid, emojiname)
+);
+CREATE INDEX IF NOT EXISTS idx_reactions_channel_id ON reactions (channelid);
+
+CREATE TABLE IF NOT EXISTS roles (
+    id varchar(26) PRIMARY KEY CHECK (length(id) <= 26),
+    name varchar(64) CHECK (length(name) <= 64),
+    displayname varchar(128) CHECK (length(displayname) <= 128),
+    description varchar(1024) CHECK (length(description) <= 1024),
+    createat bigint,
+    updateat bigint,
+    deleteat bigint,
+    permissions text,
+    schememanaged boolean,
+    builtin boolean,
+    UNIQUE (name)
+);
+
+CREATE TABLE IF NOT EXISTS schemes (
+    id varchar(26) PRIMARY KEY CHECK (length(id) <= 26),
+    name varchar(64) CHECK (length(name) <= 64),
+    displayname varchar(128) CHECK (length(displayname) <= 128),
+    description varchar(1024) CHECK (length(description) <= 1024),
+    createat bigint,
+    updateat bigint,
+    deleteat bigint,
+    scope varchar(32) CHECK (length(scope) <= 32),
+    defaultteamadminrole varchar(64) CHECK (length(defaultteamadminrole) <= 64),
+    defaultteamuserrole varchar(64) CHECK (length(defaultteamuserrole) <= 64),
+    defaultchanneladminrole varchar(64) CHECK (length(defaultchanneladminrole) <= 64),
+    defaultchanneluserrole varchar(64) CHECK (length(defaultchanneluserrole) <= 64),
+    defaultteamguestrole varchar(64) CHECK (length(defaultteamguestrole) <= 64),
+    defaultchannelguestrole varchar(64) CHECK (length(defaultchannelguestrole) <= 64),
+    defaultplaybookadminrole varchar(64) DEFAULT '' CHECK (length(defaultplaybookadminrole) <= 64),
+    defaultplaybookmemberrole varchar(64) DEFAULT '' CHECK (length(defaultplaybookmemberrole) <= 64),
+    defaultrunadminrole varchar(64) DEFAULT '' CHECK (length(defaultrunadminrole) <= 64),
+    defaultrunmemberrole varchar(64) DEFAULT '' CHECK (length(defaultrunmemberrole) <= 64),
+    UNIQUE (name)
+);
+CREATE INDEX IF NOT EXISTS idx_schemes_channel_guest_role ON schemes (defaultchannelguestrole);
+CREATE INDEX IF NOT EXISTS idx_schemes_channel_user_role ON schemes (defaultchanneluserrole);
+CREATE INDEX IF NOT EXISTS idx_schemes_channel_admin_role ON schemes (defaultchanneladminrole);
+
+CREATE TABLE IF NOT EXISTS licenses (
+    id varchar(26) PRIMARY KEY CHECK (length(id) <= 26),
+    createat bigint,
+    bytes varchar(10000) CHECK (length(bytes) <= 10000)
+);
+
+CREATE TABLE IF NOT EXISTS posts (
+    id varchar(26) PRIMARY KEY CHECK (length(id) <= 26),
+    createat bigint,
+    updateat bigint,
+    deleteat bigint,
+    userid varchar(26) CHECK (length(userid) <= 26),
+    channelid varchar(26) CHECK (length(channelid) <= 26),
+    rootid varchar(26) CHECK (length(rootid) <= 26),
+    originalid varchar(26) CHECK (length(originalid) <= 26),
+    message varchar(65535) CHECK (length(message) <= 65535),
+    type varchar(26) CHECK (length(type) <= 26),
+    props text,
+    hashtags varchar(1000) CHECK (length(hashtags) <= 1000),
+    filenames varchar(4000) CHECK (length(filenames) <= 4000),
+    fileids varchar(300) CHECK (length(fileids) <= 300),
+    hasreactions boolean,
+    editat bigint,
+    ispinned boolean,
+    remoteid varchar(26) CHECK (length(remoteid) <= 26)
+);
+CREATE INDEX IF NOT EXISTS idx_posts_update_at ON posts(updateat);
+CREATE INDEX IF NOT EXISTS idx_posts_create_at ON posts(createat);
+CREATE INDEX IF NOT EXISTS idx_posts_delete_at ON posts(deleteat);
+CREATE INDEX IF NOT EXISTS idx_posts_user_id ON posts(userid);
+CREATE INDEX IF NOT EXISTS idx_posts_is_pinned ON posts(ispinned);
+CREATE INDEX IF NOT EXISTS idx_posts_channel_id_update_at ON posts(channelid, updateat);
+CREATE INDEX IF NOT EXISTS idx_posts_channel_id_delete_at_create_at ON posts(channelid, deleteat, createat);
+CREATE INDEX IF NOT EXISTS idx_posts_root_id_delete_at ON posts(rootid, deleteat);
+CREATE INDEX IF NOT EXISTS idx_posts_create_at_id ON posts(createat, id);
+CREATE INDEX IF NOT EXISTS idx_posts_original_id ON posts(originalid);
+
+CREATE TABLE IF NOT EXISTS productnoticeviewstate (
+    userid varchar(26) CHECK (length(userid) <= 26),
+    noticeid varchar(26) CHECK (length(noticeid) <= 26),
+    viewed integer,
+    timestamp bigint,
+    PRIMARY KEY (userid, noticeid)
+);
+CREATE INDEX IF NOT EXISTS idx_notice_views_notice_id ON productnoticeviewstate(noticeid);
+CREATE INDEX IF NOT EXISTS idx_notice_views_timestamp ON productnoticeviewstate(timestamp);
+
+CREATE TABLE IF NOT EXISTS sessions (
+    id varchar(26) PRIMARY KEY CHECK (length(id) <= 26),
+    token varchar(26) CHECK (length(token) <= 26),
+    createat bigint,
+    expiresat bigint,
+    lastactivityat bigint,
+    userid varchar(26) CHECK (length(userid) <= 26),
+    deviceid varchar(512) CHECK (length(deviceid) <= 512),
+    roles varchar(256) CHECK (length(roles) <= 256),
+    isoauth boolean,
+    props text,
+    expirednotify boolean
+);
+CREATE INDEX IF NOT EXISTS idx_sessions_user_id ON sessions (userid);
+CREATE INDEX IF NOT EXISTS idx_sessions_token ON sessions (token);
+CREATE INDEX IF NOT EXISTS idx_sessions_expires_at ON sessions (expiresat);
+CREATE INDEX IF NOT EXISTS idx_sessions_create_at ON sessions (createat);
+CREATE INDEX IF NOT EXISTS idx_sessions_last_activity_at ON sessions (lastactivityat);
+
+CREATE TABLE IF NOT EXISTS termsofservice (
+    id varchar(26) PRIMARY KEY CHECK (length(id) <= 26),
+    createat bigint,
+    userid varchar(26) CHECK (length(userid) <= 26),
+    text varchar(65535) CHECK (length(text) <= 65535)
+);
+
+CREATE TABLE IF NOT EXISTS audits (
+    id varchar(26) PRIMARY KEY CHECK (length(id) <= 26),
+    createat bigint,
+    userid varchar(26) CHECK (length(userid) <= 26),
+    action varchar(512) CHECK (length(action) <= 512),
+    extrainfo varchar(1024) CHECK (length(extrainfo) <= 1024),
+    ipaddress varchar(64) CHECK (length(ipaddress) <= 64),
+    sessionid varchar(26) CHECK (length(sessionid) <= 26)
+);
+CREATE INDEX IF NOT EXISTS idx_audits_user_id ON audits (userid);
+
+CREATE TABLE IF NOT EXISTS oauthaccessdata (
+    token varchar(26) PRIMARY KEY CHECK (length(token) <= 26),
+    refreshtoken varchar(26) CHECK (length(refreshtoken) <= 26),
+    redirecturi varchar(256) CHECK (length(redirecturi) <= 256),
+    clientid varchar(26) CHECK (length(clientid) <= 26),
+    userid varchar(26) CHECK (length(userid) <= 26),
+    expiresat bigint,
+    scope varchar(128) CHECK (length(scope) <= 128),
+    audience varchar(512) DEFAULT '' CHECK (length(audience) <= 512),
+    UNIQUE (clientid, userid)
+);
+CREATE INDEX IF NOT EXISTS idx_oauthaccessdata_refresh_token ON oauthaccessdata (refreshtoken);
+CREATE INDEX IF NOT EXISTS idx_oauthaccessdata_user_id ON oauthaccessdata (userid);
+
+CREATE TABLE IF NOT EXISTS preferences (
+    userid varchar(26) NOT NULL CHECK (length(userid) <= 26),
+    category varchar(32) NOT NULL CHECK (length(category) <= 32),
+    name varchar(32) NOT NULL CHECK (length(name) <= 32),
+    value text,
+    PRIMARY KEY (userid, category, name)
+);
+CREATE INDEX IF NOT EXISTS idx_preferences_category ON preferences(category);
+CREATE INDEX IF NOT EXISTS idx_preferences_name ON preferences(name);
+
+CREATE TABLE IF NOT EXISTS status (
+    userid varchar(26) PRIMARY KEY CHECK (length(userid) <= 26),
+    status varchar(32) CHECK (length(status) <= 32),
+    manual boolean,
+    lastactivityat bigint,
+    dndendtime bigint,
+    prevstatus varchar(32) CHECK (length(prevstatus) <= 32)
+);
+CREATE INDEX IF NOT EXISTS idx_status_status_dndendtime ON status(status, dndendtime);
+
+CREATE TABLE IF NOT EXISTS tokens (
+    token varchar(64) PRIMARY KEY CHECK (length(token) <= 64),
+    createat bigint,
+    type varchar(64) CHECK (length(type) <= 64),
+    extra varchar(2048) CHECK (length(extra) <= 2048)
+);
+
+CREATE TABLE IF NOT EXISTS bots (
+    userid varchar(26) PRIMARY KEY CHECK (length(userid) <= 26),
+    description varchar(1024) CHECK (length(description) <= 1024),
+    ownerid varchar(190) CHECK (length(ownerid) <= 190),
+    createat bigint,
+    updateat bigint,
+    deleteat bigint,
+    lasticonupdate bigint
+);
+
+CREATE TABLE IF NOT EXISTS useraccesstokens (
+    id varchar(26) PRIMARY KEY CHECK (length(id) <= 26),
+    token varchar(26) CHECK (length(token) <= 26),
+    userid varchar(26) CHECK (length(userid) <= 26),
+    description varchar(512) CHECK (length(description) <= 512),
+    isactive boolean,
+    UNIQUE (token)
+);
+CREATE INDEX IF NOT EXISTS idx_user_access_tokens_user_id ON useraccesstokens(userid);
+
+CREATE TABLE IF NOT EXISTS remoteclusters (
+    remoteid varchar(26) NOT NULL CHECK (length(remoteid) <= 26),
+    remoteteamid varchar(26) CHECK (length(remoteteamid) <= 26),
+    name varchar(64) NOT NULL CHECK (length(name) <= 64),
+    displayname varchar(64) CHECK (length(displayname) <= 64),
+    siteurl varchar(512) CHECK (length(siteurl) <= 512),
+    createat bigint,
+    lastpingat bigint,
+    token varchar(26) CHECK (length(token) <= 26),
+    remotetoken varchar(26) CHECK (length(remotetoken) <= 26),
+    topics varchar(512) CHECK (length(topics) <= 512),
+    creatorid varchar(26) CHECK (length(creatorid) <= 26),
+    pluginid varchar(190) NOT NULL DEFAULT '' CHECK (length(pluginid) <= 190),
+    options smallint NOT NULL DEFAULT 0,
+    defaultteamid varchar(26) DEFAULT '' CHECK (length(defaultteamid) <= 26),
+    deleteat bigint DEFAULT 0,
+    lastglobalusersyncat bigint DEFAULT 0,
+    PRIMARY KEY (remoteid, name)
+);
+
+CREATE TABLE IF NOT EXISTS sharedchannels (
+    channelid varchar(26) PRIMARY KEY CHECK (length(channelid) <= 26),
+    teamid varchar(26) CHECK (length(teamid) <= 26),
+    home boolean,
+    readonly boolean,
+    sharename varchar(64) CHECK (length(sharename) <= 64),
+    sharedisplayname varchar(64) CHECK (length(sharedisplayname) <= 64),
+    sharepurpose varchar(250) CHECK (length(sharepurpose) <= 250),
+    shareheader varchar(1024) CHECK (length(shareheader) <= 1024),
+    creatorid varchar(26) CHECK (length(creatorid) <= 26),
+    createat bigint,
+    updateat bigint,
+    remoteid varchar(26) CHECK (length(remoteid) <= 26),
+    UNIQUE (sharename, teamid)
+);
+
+CREATE TABLE IF NOT EXISTS sidebarchannels (
+    channelid varchar(26) CHECK (length(channelid) <= 26),
+    userid varchar(26) CHECK (length(userid) <= 26),
+    categoryid varchar(128) CHECK (length(categoryid) <= 128),
+    sortorder bigint,
+    PRIMARY KEY (channelid, userid, categoryid)
+);
+
+CREATE TABLE IF NOT EXISTS oauthauthdata (
+    clientid varchar(26) CHECK (length(clientid) <= 26),
+    userid varchar(26) CHECK (length(userid) <= 26),
+    code varchar(128) PRIMARY KEY CHECK (length(code) <= 128),
+    expiresin integer,
+    createat bigint,
+    redirecturi varchar(256) CHECK (length(redirecturi) <= 256),
+    state varchar(1024) CHECK (length(state) <= 1024),
+    scope varchar(128) CHECK (length(scope) <= 128),
+    codechallenge varchar(128) DEFAULT '' CHECK (length(codechallenge) <= 128),
+    codechallengemethod varchar(10) DEFAULT '' CHECK (length(codechallengemethod) <= 10),
+    resource varchar(512) DEFAULT '' CHECK (length(resource) <= 512)
+);
+
+CREATE TABLE IF NOT EXISTS sharedchannelattachments (
+    id varchar(26) PRIMARY KEY CHECK (length(id) <= 26),
+    fileid varchar(26) CHECK (length(fileid) <= 26),
+    remoteid varchar(26) CHECK (length(remoteid) <= 26),
+    createat bigint,
+    lastsyncat bigint,
+    UNIQUE (fileid, remoteid)
+);
+
+CREATE TABLE IF NOT EXISTS sharedchannelusers (
+    id varchar(26) PRIMARY KEY CHECK (length(id) <= 26),
+    userid varchar(26) CHECK (length(userid) <= 26),
+    remoteid varchar(26) CHECK (length(remoteid) <= 26),
+    createat bigint,
+    lastsyncat bigint,
+    channelid varchar(26) CHECK (length(channelid) <= 26),
+    lastmembershipsyncat bigint DEFAULT 0,
+    UNIQUE (userid, channelid, remoteid)
+);
+CREATE INDEX IF NOT EXISTS idx_sharedchannelusers_remote_id ON sharedchannelusers(remoteid);
+
+CREATE TABLE IF NOT EXISTS sharedchannelremotes (
+    id varchar(26) NOT NULL CHECK (length(id) <= 26),
+    channelid varchar(26) NOT NULL CHECK (length(channelid) <= 26),
+    creatorid varchar(26) CHECK (length(creatorid) <= 26),
+    createat bigint,
+    updateat bigint,
+    isinviteaccepted boolean,
+    isinviteconfirmed boolean,
+    remoteid varchar(26) CHECK (length(remoteid) <= 26),
+    lastpostupdateat bigint,
+    lastpostid varchar(26) CHECK (length(lastpostid) <= 26),
+    lastpostcreateat bigint NOT NULL DEFAULT 0,
+    lastpostcreateid varchar(26) CHECK (length(lastpostcreateid) <= 26),
+    deleteat bigint DEFAULT 0,
+    lastmemberssyncat bigint DEFAULT 0,
+    PRIMARY KEY (id, channelid),
+    UNIQUE (channelid, remoteid)
+);
+
+CREATE TABLE IF NOT EXISTS jobs (
+    id varchar(26) PRIMARY KEY CHECK (length(id) <= 26),
+    type varchar(32) CHECK (length(type) <= 32),
+    priority bigint,
+    createat bigint,
+    startat bigint,
+    lastactivityat bigint,
+    status varchar(32) CHECK (length(status) <= 32),
+    progress bigint,
+    data text
+);
+CREATE INDEX IF NOT EXISTS idx_jobs_type ON jobs (type);
+CREATE INDEX IF NOT EXISTS idx_jobs_status_type ON jobs(status, type);
+
+CREATE TABLE IF NOT EXISTS channelmemberhistory (
+    channelid varchar(26) NOT NULL CHECK (length(channelid) <= 26),
+    userid varchar(26) NOT NULL CHECK (length(userid) <= 26),
+    jointime bigint NOT NULL,
+    leavetime bigint,
+    PRIMARY KEY (channelid, userid, jointime)
+);
+
+CREATE TABLE IF NOT EXISTS sidebarcategories (
+    id varchar(128) PRIMARY KEY CHECK (length(id) <= 128),
+    userid varchar(26) CHECK (length(userid) <= 26),
+    teamid varchar(26) CHECK (length(teamid) <= 26),
+    sortorder bigint,
+    sorting varchar(64) CHECK (length(sorting) <= 64),
+    type varchar(64) CHECK (length(type) <= 64),
+    displayname varchar(64) CHECK (length(displayname) <= 64),
+    muted boolean,
+    collapsed boolean
+);
+CREATE INDEX IF NOT EXISTS idx_sidebarcategories_userid_teamid ON sidebarcategories (userid, teamid);
+
+CREATE TABLE IF NOT EXISTS uploadsessions (
+    id varchar(26) PRIMARY KEY CHECK (length(id) <= 26),
+    type varchar(32) CHECK (type IN ('attachment', 'import')) CHECK (length(type) <= 32),
+    createat bigint,
+    userid varchar(26) CHECK (length(userid) <= 26),
+    channelid varchar(26) CHECK (length(channelid) <= 26),
+    filename varchar(256) CHECK (length(filename) <= 256),
+    path varchar(512) CHECK (length(path) <= 512),
+    filesize bigint,
+    fileoffset bigint,
+    remoteid varchar(26) CHECK (length(remoteid) <= 26),
+    reqfileid varchar(26) CHECK (length(reqfileid) <= 26)
+);
+CREATE INDEX IF NOT EXISTS idx_uploadsessions_user_id ON uploadsessions(userid);
+CREATE INDEX IF NOT EXISTS idx_uploadsessions_create_at ON uploadsessions(createat);
+CREATE INDEX IF NOT EXISTS idx_uploadsessions_type ON uploadsessions(type);
+
+CREATE TABLE IF NOT EXISTS threads (
+    postid varchar(26) PRIMARY KEY CHECK (length(postid) <= 26),
+    replycount bigint,
+    lastreplyat bigint,
+    participants text,
+    channelid varchar(26) CHECK (length(channelid) <= 26),
+    threaddeleteat bigint,
+    threadteamid varchar(26) CHECK (length(threadteamid) <= 26)
+);
+CREATE INDEX IF NOT EXISTS idx_threads_channel_id_last_reply_at ON threads(channelid, lastreplyat);
+
+CREATE TABLE IF NOT EXISTS threadmemberships (
+    postid varchar(26) NOT NULL CHECK (length(postid) <= 26),
+    userid varchar(26) NOT NULL CHECK (length(userid) <= 26),
+    following boolean,
+    lastviewed bigint,
+    lastupdated bigint,
+    unreadmentions bigint,
+    PRIMARY KEY (postid, userid)
+);
+CREATE INDEX IF NOT EXISTS idx_thread_memberships_last_update_at ON threadmemberships(lastupdated);
+CREATE INDEX IF NOT EXISTS idx_thread_memberships_last_view_at ON threadmemberships(lastviewed);
+CREATE INDEX IF NOT EXISTS idx_thread_memberships_user_id ON threadmemberships(userid);
+
+CREATE TABLE IF NOT EXISTS usertermsofservice (
+    userid varchar(26) PRIMARY KEY CHECK (length(userid) <= 26),
+    termsofserviceid varchar(26) CHECK (length(termsofserviceid) <= 26),
+    createat bigint
+);
+
+CREATE TABLE IF NOT EXISTS pluginkeyvaluestore (
+    pluginid varchar(190) NOT NULL CHECK (length(pluginid) <= 190),
+    pkey varchar(150) NOT NULL CHECK (length(pkey) <= 150),
+    pvalue blob,
+    expireat bigint,
+    PRIMARY KEY (pluginid, pkey)
+);
+
+CREATE TABLE IF NOT EXISTS users (
+    id varchar(26) PRIMARY KEY CHECK (length(id) <= 26),
+    createat bigint,
+    updateat bigint,
+    deleteat bigint,
+    username varchar(64) CHECK (length(username) <= 64),
+    password varchar(128) CHECK (length(password) <= 128),
+    authdata varchar(128) CHECK (length(authdata) <= 128),
+    authservice varchar(32) CHECK (length(authservice) <= 32),
+    email varchar(128) CHECK (length(email) <= 128),
+    emailverified boolean,
+    nickname varchar(64) CHECK (length(nickname) <= 64),
+    firstname varchar(64) CHECK (length(firstname) <= 64),
+    lastname varchar(64) CHECK (length(lastname) <= 64),
+    roles varchar(256) CHECK (length(roles) <= 256),
+    allowmarketing boolean,
+    props text,
+    notifyprops text,
+    lastpasswordupdate bigint,
+    lastpictureupdate bigint,
+    failedattempts integer,
+    locale varchar(5) CHECK (length(locale) <= 5),
+    mfaactive boolean,
+    mfasecret varchar(128) CHECK (length(mfasecret) <= 128),
+    position varchar(128) CHECK (length(position) <= 128),
+    timezone text,
+    remoteid varchar(26) CHECK (length(remoteid) <= 26),
+    lastlogin bigint NOT NULL DEFAULT 0,
+    mfausedtimestamps text,
+    UNIQUE (email),
+    UNIQUE (authdata),
+    UNIQUE (username)
+);
+CREATE INDEX IF NOT EXISTS idx_users_update_at ON users (updateat);
+CREATE INDEX IF NOT EXISTS idx_users_create_at ON users (createat);
+CREATE INDEX IF NOT EXISTS idx_users_delete_at ON users (deleteat);
+CREATE INDEX IF NOT EXISTS idx_users_id_locale ON users (id, locale);
+
+CREATE TABLE IF NOT EXISTS fileinfo (
+    id varchar(26) PRIMARY KEY CHECK (length(id) <= 26),
+    creatorid varchar(26) CHECK (length(creatorid) <= 26),
+    postid varchar(26) CHECK (length(postid) <= 26),
+    createat bigint,
+    updateat bigint,
+    deleteat bigint,
+    path varchar(512) CHECK (length(path) <= 512),
+    thumbnailpath varchar(512) CHECK (length(thumbnailpath) <= 512),
+    previewpath varchar(512) CHECK (length(previewpath) <= 512),
+    name varchar(256) CHECK (length(name) <= 256),
+    extension varchar(64) CHECK (length(extension) <= 64),
+    size bigint,
+    mimetype varchar(256) CHECK (length(mimetype) <= 256),
+    width integer,
+    height integer,
+    haspreviewimage boolean,
+    minipreview blob,
+    content text,
+    remoteid varchar(26) CHECK (length(remoteid) <= 26),
+    archived boolean NOT NULL DEFAULT false,
+    channelid varchar(26) CHECK (length(channelid) <= 26)
+);
+CREATE INDEX IF NOT EXISTS idx_fileinfo_update_at ON fileinfo (updateat);
+CREATE INDEX IF NOT EXISTS idx_fileinfo_create_at ON fileinfo (createat);
+CREATE INDEX IF NOT EXISTS idx_fileinfo_delete_at ON fileinfo (deleteat);
+CREATE INDEX IF NOT EXISTS idx_fileinfo_postid_at ON fileinfo (postid);
+CREATE INDEX IF NOT EXISTS idx_fileinfo_extension_at ON fileinfo (extension);
+CREATE INDEX IF NOT EXISTS idx_fileinfo_channel_id_create_at ON fileinfo(channelid, createat);
+
+CREATE TABLE IF NOT EXISTS oauthapps (
+    id varchar(26) PRIMARY KEY CHECK (length(id) <= 26),
+    creatorid varchar(26) CHECK (length(creatorid) <= 26),
+    createat bigint,
+    updateat bigint,
+    clientsecret varchar(128) CHECK (length(clientsecret) <= 128),
+    name varchar(64) CHECK (length(name) <= 64),
+    description varchar(512) CHECK (length(description) <= 512),
+    callbackurls varchar(1024) CHECK (length(callbackurls) <= 1024),
+    homepage varchar(256) CHECK (length(homepage) <= 256),
+    istrusted boolean,
+    iconurl varchar(512) CHECK (length(iconurl) <= 512),
+    mattermostappid varchar(32) NOT NULL DEFAULT '' CHECK (length(mattermostappid) <= 32),
+    isdynamicallyregistered boolean DEFAULT false
+);
+CREATE INDEX IF NOT EXISTS idx_oauthapps_creator_id ON oauthapps (creatorid);
+
+CREATE TABLE IF NOT EXISTS channels (
+    id varchar(26) PRIMARY KEY CHECK (length(id) <= 26),
+    createat bigint,
+    updateat bigint,
+    deleteat bigint,
+    teamid varchar(26) CHECK (length(teamid) <= 26),
+    type varchar(1) CHECK (type IN ('P', 'G', 'O', 'D')) CHECK (length(type) <= 1),
+    displayname varchar(64) CHECK (length(displayname) <= 64),
+    name varchar(64) CHECK (length(name) <= 64),
+    header varchar(1024) CHECK (length(header) <= 1024),
+    purpose varchar(250) CHECK (length(purpose) <= 250),
+    lastpostat bigint,
+    totalmsgcount bigint,
+    totalmsgcountroot bigint,
+    lastrootpostat bigint DEFAULT 0,
+    extraupdateat bigint,
+    creatorid varchar(26) CHECK (length(creatorid) <= 26),
+    schemeid varchar(26) CHECK (length(schemeid) <= 26),
+    groupconstrained boolean,
+    shared boolean,
+    bannerinfo text,
+    defaultcategoryname varchar(64) NOT NULL DEFAULT '' CHECK (length(defaultcategoryname) <= 64),
+    autotranslation boolean NOT NULL DEFAULT false,
+    UNIQUE (name, teamid)
+);
+CREATE INDEX IF NOT EXISTS idx_channels_displayname_lower ON channels (lower(displayname));
+CREATE INDEX IF NOT EXISTS idx_channels_name_lower ON channels (lower(name));
+CREATE INDEX IF NOT EXISTS idx_channels_update_at ON channels (updateat);
+CREATE INDEX IF NOT EXISTS idx_channels_delete_at ON channels (deleteat);
+CREATE INDEX IF NOT EXISTS idx_channels_create_at ON channels (createat);
+CREATE INDEX IF NOT EXISTS idx_channels_scheme_id ON channels (schemeid);
+CREATE INDEX IF NOT EXISTS idx_channels_team_id_display_name ON channels(teamid, displayname);
+CREATE INDEX IF NOT EXISTS idx_channels_team_id_type ON channels(teamid, type);
+CREATE INDEX IF NOT EXISTS idx_channels_autotranslation_enabled ON channels (id) WHERE autotranslation = true;
+
+CREATE TABLE IF NOT EXISTS channelmembers (
+    channelid varchar(26) NOT NULL CHECK (length(channelid) <= 26),
+    userid varchar(26) NOT NULL CHECK (length(userid) <= 26),
+    roles varchar(256) CHECK (length(roles) <= 256),
+    lastviewedat bigint,
+    msgcount bigint,
+    mentioncount bigint,
+    notifyprops text,
+    lastupdateat bigint,
+    schemeuser boolean,
+    schemeadmin boolean,
+    schemeguest boolean,
+    mentioncountroot bigint,
+    msgcountroot bigint,
+    urgentmentioncount bigint,
+    autotranslation boolean NOT NULL DEFAULT false,
+    PRIMARY KEY (channelid, userid)
+);
+CREATE INDEX IF NOT EXISTS idx_channelmembers_user_id_channel_id_last_viewed_at ON channelmembers(userid, channelid, lastviewedat);
+CREATE INDEX IF NOT EXISTS idx_channelmembers_channel_id_scheme_guest_user_id ON channelmembers(channelid, schemeguest, userid);
+CREATE INDEX IF NOT EXISTS idx_channelmembers_autotranslation_enabled ON channelmembers (channelid) WHERE autotranslation = true;
+
+CREATE TABLE IF NOT EXISTS publicchannels (
+    id varchar(26) PRIMARY KEY CHECK (length(id) <= 26),
+    deleteat bigint,
+    teamid varchar(26) CHECK (length(teamid) <= 26),
+    displayname varchar(64) CHECK (length(displayname) <= 64),
+    name varchar(64) CHECK (length(name) <= 64),
+    header varchar(1024) CHECK (length(header) <= 1024),
+    purpose varchar(250) CHECK (length(purpose) <= 250),
+    UNIQUE (name, teamid)
+);
+CREATE INDEX IF NOT EXISTS idx_publicchannels_team_id ON publicchannels (teamid);
+CREATE INDEX IF NOT EXISTS idx_publicchannels_delete_at ON publicchannels (deleteat);
+CREATE INDEX IF NOT EXISTS idx_publicchannels_name_lower ON publicchannels (lower(name));
+CREATE INDEX IF NOT EXISTS idx_publicchannels_displayname_lower ON publicchannels (lower(displayname));
+
+CREATE TABLE IF NOT EXISTS retentionpolicies (
+    id varchar(26) PRIMARY KEY CHECK (length(id) <= 26),
+    displayname varchar(64) CHECK (length(displayname) <= 64),
+    postduration bigint
+);
+CREATE INDEX IF NOT EXISTS idx_retentionpolicies_displayname ON retentionpolicies (displayname);
+
+CREATE TABLE IF NOT EXISTS retentionpoliciesteams (
+    policyid varchar(26) REFERENCES retentionpolicies (id) ON DELETE CASCADE CHECK (length(policyid) <= 26),
+    teamid varchar(26) PRIMARY KEY CHECK (length(teamid) <= 26)
+);
+CREATE INDEX IF NOT EXISTS idx_retentionpoliciesteams_policyid ON retentionpoliciesteams (policyid);
+
+CREATE TABLE IF NOT EXISTS retentionpolicieschannels (
+    policyid varchar(26) REFERENCES retentionpolicies (id) ON DELETE CASCADE CHECK (length(policyid) <= 26),
+    channelid varchar(26) PRIMARY KEY CHECK (length(channelid) <= 26)
+);
+CREATE INDEX IF NOT EXISTS idx_retentionpolicieschannels_policyid ON retentionpolicieschannels (policyid);
+
+CREATE TABLE IF NOT EXISTS recentsearches (
+    userid char(26),
+    searchpointer integer,
+    query text,
+    createat bigint NOT NULL,
+    PRIMARY KEY (userid, searchpointer)
+);
+
+CREATE TABLE IF NOT EXISTS postreminders (
+    postid varchar(26) NOT NULL CHECK (length(postid) <= 26),
+    userid varchar(26) NOT NULL CHECK (length(userid) <= 26),
+    targettime bigint,
+    PRIMARY KEY (postid, userid)
+);
+CREATE INDEX IF NOT EXISTS idx_postreminders_targettime ON postreminders(targettime);
+
+CREATE TABLE IF NOT EXISTS notifyadmin (
+    userid varchar(26) NOT NULL CHECK (length(userid) <= 26),
+    createat bigint DEFAULT NULL,
+    requiredplan varchar(100) NOT NULL CHECK (length(requiredplan) <= 100),
+    requiredfeature varchar(255) NOT NULL CHECK (length(requiredfeature) <= 255),
+    trial boolean NOT NULL,
+    sentat bigint DEFAULT NULL,
+    PRIMARY KEY (userid, requiredfeature, requiredplan)
+);
+
+CREATE TABLE IF NOT EXISTS postspriority (
+    postid varchar(26) PRIMARY KEY CHECK (length(postid) <= 26),
+    channelid varchar(26) NOT NULL CHECK (length(channelid) <= 26),
+    priority varchar(32) NOT NULL CHECK (length(priority) <= 32),
+    requestedack boolean,
+    persistentnotifications boolean
+);
+
+CREATE TABLE IF NOT EXISTS postacknowledgements (
+    postid varchar(26) NOT NULL CHECK (length(postid) <= 26),
+    userid varchar(26) NOT NULL CHECK (length(userid) <= 26),
+    acknowledgedat bigint,
+    remoteid varchar(26) DEFAULT '' CHECK (length(remoteid) <= 26),
+    channelid varchar(26) DEFAULT '' CHECK (length(channelid) <= 26),
+    PRIMARY KEY (postid, userid)
+);
+
+CREATE TABLE IF NOT EXISTS drafts (
+    createat bigint,
+    updateat bigint,
+    deleteat bigint,
+    userid varchar(26) CHECK (length(userid) <= 26),
+    channelid varchar(26) CHECK (length(channelid) <= 26),
+    rootid varchar(26) DEFAULT '' CHECK (length(rootid) <= 26),
+    message varchar(65535) CHECK (length(message) <= 65535),
+    props text,
+    fileids varchar(300) CHECK (length(fileids) <= 300),
+    priority text,
+    PRIMARY KEY (userid, channelid, rootid)
+);
+
+CREATE TABLE IF NOT EXISTS persistentnotifications (
+    postid varchar(26) PRIMARY KEY CHECK (length(postid) <= 26),
+    createat bigint,
+    lastsentat bigint,
+    deleteat bigint,
+    sentcount smallint
+);
+
+CREATE TABLE IF NOT EXISTS desktoptokens (
+    token varchar(64) PRIMARY KEY CHECK (length(token) <= 64),
+    createat bigint NOT NULL,
+    userid varchar(26) NOT NULL CHECK (length(userid) <= 26)
+);
+CREATE INDEX IF NOT EXISTS idx_desktoptokens_token_createat ON desktoptokens(token, createat);
+
+CREATE TABLE IF NOT EXISTS outgoingoauthconnections (
+    id varchar(26) PRIMARY KEY CHECK (length(id) <= 26),
+    name varchar(64) CHECK (length(name) <= 64),
+    creatorid varchar(26) CHECK (length(creatorid) <= 26),
+    createat bigint,
+    updateat bigint,
+    clientid varchar(255) CHECK (length(clientid) <= 255),
+    clientsecret varchar(255) CHECK (length(clientsecret) <= 255),
+    credentialsusername varchar(255) CHECK (length(credentialsusername) <= 255),
+    credentialspassword varchar(255) CHECK (length(credentialspassword) <= 255),
+    oauthtokenurl text,
+    granttype varchar(32) DEFAULT 'client_credentials' CHECK (granttype IN ('client_credentials', 'password')) CHECK (length(granttype) <= 32),
+    audiences varchar(1024) CHECK (length(audiences) <= 1024)
+);
+CREATE INDEX IF NOT EXISTS idx_outgoingoauthconnections_name ON outgoingoauthconnections (name);
+
+CREATE TABLE IF NOT EXISTS channelbookmarks (
+    id varchar(26) PRIMARY KEY CHECK (length(id) <= 26),
+    ownerid varchar(26) NOT NULL CHECK (length(ownerid) <= 26),
+    channelid varchar(26) NOT NULL CHECK (length(channelid) <= 26),
+    fileinfoid varchar(26) DEFAULT NULL CHECK (length(fileinfoid) <= 26),
+    createat bigint DEFAULT 0,
+    updateat bigint DEFAULT 0,
+    deleteat bigint DEFAULT 0,
+    displayname text DEFAULT '',
+    sortorder integer DEFAULT 0,
+    linkurl text DEFAULT NULL,
+    imageurl text DEFAULT NULL,
+    emoji varchar(64) DEFAULT NULL CHECK (length(emoji) <= 64),
+    type varchar(32) DEFAULT 'link' CHECK (type IN ('link', 'file')) CHECK (length(type) <= 32),
+    originalid varchar(26) DEFAULT NULL CHECK (length(originalid) <= 26),
+    parentid varchar(26) DEFAULT NULL CHECK (length(parentid) <= 26)
+);
+CREATE INDEX IF NOT EXISTS idx_channelbookmarks_channelid ON channelbookmarks (channelid);
+CREATE INDEX IF NOT EXISTS idx_channelbookmarks_update_at ON channelbookmarks (updateat);
+CREATE INDEX IF NOT EXISTS idx_channelbookmarks_delete_at ON channelbookmarks (deleteat);
+
+CREATE TABLE IF NOT EXISTS scheduledposts (
+    id varchar(26) PRIMARY KEY CHECK (length(id) <= 26),
+    createat bigint,
+    updateat bigint,
+    userid varchar(26) NOT NULL CHECK (length(userid) <= 26),
+    channelid varchar(26) NOT NULL CHECK (length(channelid) <= 26),
+    rootid varchar(26) CHECK (length(rootid) <= 26),
+    message varchar(65535) CHECK (length(message) <= 65535),
+    props text,
+    fileids varchar(300) CHECK (length(fileids) <= 300),
+    priority text,
+    scheduledat bigint NOT NULL,
+    processedat bigint,
+    errorcode varchar(200) CHECK (length(errorcode) <= 200),
+    recurrence text
+);
+CREATE INDEX IF NOT EXISTS idx_scheduledposts_userid_channel_id_scheduled_at ON scheduledposts (userid, channelid, scheduledat);
+
+CREATE TABLE IF NOT EXISTS propertygroups (
+    id varchar(26) PRIMARY KEY CHECK (length(id) <= 26),
+    name varchar(64) NOT NULL CHECK (length(name) <= 64),
+    UNIQUE (name)
+);
+
+CREATE TABLE IF NOT EXISTS propertyfields (
+    id varchar(26) PRIMARY KEY CHECK (length(id) <= 26),
+    groupid varchar(26) NOT NULL CHECK (length(groupid) <= 26),
+    name varchar(255) NOT NULL CHECK (length(name) <= 255),
+    type varchar(32) CHECK (type IN ('text', 'select', 'multiselect', 'date', 'user', 'multiuser')) CHECK (length(type) <= 32),
+    attrs text,
+    targetid varchar(255) CHECK (length(targetid) <= 255),
+    targettype varchar(255) CHECK (length(targettype) <= 255),
+    createat bigint NOT NULL,
+    updateat bigint NOT NULL,
+    deleteat bigint NOT NULL
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_propertyfields_unique ON propertyfields (groupid, targetid, name) WHERE deleteat = 0;
+
+CREATE TABLE IF NOT EXISTS propertyvalues (
+    id varchar(26) PRIMARY KEY CHECK (length(id) <= 26),
+    targetid varchar(255) NOT NULL CHECK (length(targetid) <= 255),
+    targettype varchar(255) NOT NULL CHECK (length(targettype) <= 255),
+    groupid varchar(26) NOT NULL CHECK (length(groupid) <= 26),
+    fieldid varchar(26) NOT NULL CHECK (length(fieldid) <= 26),
+    value text NOT NULL,
+    createat bigint NOT NULL,
+    updateat bigint NOT NULL,
+    deleteat bigint NOT NULL
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_propertyvalues_unique ON propertyvalues (groupid, targetid, fieldid) WHERE deleteat = 0;
+CREATE INDEX IF NOT EXISTS idx_propertyvalues_targetid_groupid ON propertyvalues (targetid, groupid);
+
+CREATE TABLE IF NOT EXISTS accesscontrolpolicies (
+    id varchar(26) PRIMARY KEY CHECK (length(id) <= 26),
+    name varchar(128) NOT NULL CHECK (length(name) <= 128),
+    type varchar(128) NOT NULL CHECK (length(type) <= 128),
+    active boolean NOT NULL,
+    createat bigint NOT NULL,
+    revision integer NOT NULL,
+    version varchar(8) NOT NULL CHECK (length(version) <= 8),
+    data text,
+    props text
+);
+
+CREATE TABLE IF NOT EXISTS accesscontrolpolicyhistory (
+    id varchar(26) NOT NULL CHECK (length(id) <= 26),
+    name varchar(128) NOT NULL CHECK (length(name) <= 128),
+    type varchar(128) NOT NULL CHECK (length(type) <= 128),
+    createat bigint NOT NULL,
+    revision integer NOT NULL,
+    version varchar(8) NOT NULL CHECK (length(version) <= 8),
+    data text,
+    props text,
+    PRIMARY KEY (id, revision)
+);
+
+CREATE TABLE IF NOT EXISTS contentflaggingcommonreviewers (
+    userid varchar(26) PRIMARY KEY CHECK (length(userid) <= 26)
+);
+
+CREATE TABLE IF NOT EXISTS contentflaggingteamsettings (
+    teamid varchar(26) PRIMARY KEY CHECK (length(teamid) <= 26),
+    enabled boolean
+);
+
+CREATE TABLE IF NOT EXISTS contentflaggingteamreviewers (
+    teamid varchar(26) CHECK (length(teamid) <= 26),
+    userid varchar(26) CHECK (length(userid) <= 26),
+    PRIMARY KEY (teamid, userid)
+);
+
+CREATE TABLE IF NOT EXISTS translations (
+    objectid varchar(26) NOT NULL CHECK (length(objectid) <= 26),
+    dstlang text NOT NULL,
+    objecttype text,
+    providerid text NOT NULL,
+    normhash char(64) NOT NULL,
+    text text NOT NULL,
+    confidence real,
+    meta text,
+    updateat bigint NOT NULL,
+    PRIMARY KEY (objectid, dstlang)
+);
+CREATE INDEX IF NOT EXISTS idx_translations_updateat ON translations (updateat desc);
+
+CREATE TABLE IF NOT EXISTS aiactionitems (
+    id varchar(26) PRIMARY KEY CHECK (length(id) <= 26),
+    channelid varchar(26) NOT NULL CHECK (length(channelid) <= 26),
+    postid varchar(26) CHECK (length(postid) <= 26),
+    createdby varchar(26) NOT NULL CHECK (length(createdby) <= 26),
+    assigneeid varchar(26) CHECK (length(assigneeid) <= 26),
+    description text NOT NULL,
+    duedate bigint,
+    priority varchar(64) DEFAULT 'medium' CHECK (length(priority) <= 64),
+    status varchar(64) DEFAULT 'open' CHECK (length(status) <= 64),
+    completedat bigint DEFAULT 0,
+    createdat bigint NOT NULL,
+    updatedat bigint NOT NULL,
+    deletedat bigint DEFAULT 0
+);
+CREATE INDEX IF NOT EXISTS idx_aiactionitems_channelid ON aiactionitems(channelid);
+CREATE INDEX IF NOT EXISTS idx_aiactionitems_createdby ON aiactionitems(createdby);
+CREATE INDEX IF NOT EXISTS idx_aiactionitems_assigneeid ON aiactionitems(assigneeid);
+CREATE INDEX IF NOT EXISTS idx_aiactionitems_status ON aiactionitems(status);
+CREATE INDEX IF NOT EXISTS idx_aiactionitems_priority ON aiactionitems(priority);
+CREATE INDEX IF NOT EXISTS idx_aiactionitems_duedate ON aiactionitems(duedate) WHERE duedate is not null;
+CREATE INDEX IF NOT EXISTS idx_aiactionitems_deletedat ON aiactionitems(deletedat);
+
+CREATE TABLE IF NOT EXISTS aisummaries (
+    id varchar(26) PRIMARY KEY CHECK (length(id) <= 26),
+    channelid varchar(26) NOT NULL CHECK (length(channelid) <= 26),
+    postid varchar(26) CHECK (length(postid) <= 26),
+    summarytype varchar(64) NOT NULL CHECK (length(summarytype) <= 64),
+    summary text NOT NULL,
+    messagecount integer NOT NULL,
+    starttime bigint NOT NULL,
+    endtime bigint NOT NULL,
+    userid varchar(26) NOT NULL CHECK (length(userid) <= 26),
+    participants text,
+    cachekey varchar(255) CHECK (length(cachekey) <= 255),
+    channelname varchar(255) CHECK (length(channelname) <= 255),
+    createdat bigint NOT NULL,
+    expiresat bigint NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_aisummaries_channelid ON aisummaries(channelid);
+CREATE INDEX IF NOT EXISTS idx_aisummaries_postid ON aisummaries(postid) WHERE postid is not null;
+CREATE INDEX IF NOT EXISTS idx_aisummaries_expiresat ON aisummaries(expiresat);
+CREATE INDEX IF NOT EXISTS idx_aisummaries_createdat ON aisummaries(createdat);
+CREATE INDEX IF NOT EXISTS idx_aisummaries_cachekey ON aisummaries(cachekey) WHERE cachekey is not null;
+
+CREATE TABLE IF NOT EXISTS aianalytics (
+    id varchar(26) PRIMARY KEY CHECK (length(id) <= 26),
+    channelid varchar(26) NOT NULL CHECK (length(channelid) <= 26),
+    date varchar(10) NOT NULL CHECK (length(date) <= 10),
+    messagecount integer DEFAULT 0,
+    usercount integer DEFAULT 0,
+    avgresponsetime bigint DEFAULT 0,
+    topcontributors text,
+    hourlydistribution text,
+    createdat bigint NOT NULL,
+    updatedat bigint NOT NULL,
+    UNIQUE (channelid, date)
+);
+CREATE INDEX IF NOT EXISTS idx_aianalytics_channelid ON aianalytics(channelid);
+CREATE INDEX IF NOT EXISTS idx_aianalytics_date ON aianalytics(date);
+CREATE INDEX IF NOT EXISTS idx_aianalytics_channelid_date ON aianalytics(channelid, date);
+
+CREATE TABLE IF NOT EXISTS aipreferences (
+    id varchar(26) PRIMARY KEY CHECK (length(id) <= 26),
+    userid varchar(26) NOT NULL CHECK (length(userid) <= 26),
+    enablesummarization boolean DEFAULT true,
+    enableanalytics boolean DEFAULT true,
+    enableactionitems boolean DEFAULT true,
+    enableformatting boolean DEFAULT true,
+    defaultmodel varchar(64) DEFAULT 'gpt-3.5-turbo' CHECK (length(defaultmodel) <= 64),
+    formattingprofile varchar(64) DEFAULT 'professional' CHECK (length(formattingprofile) <= 64),
+    createdat bigint NOT NULL,
+    updatedat bigint NOT NULL,
+    UNIQUE (userid)
+);
+CREATE INDEX IF NOT EXISTS idx_aipreferences_userid ON aipreferences(userid);
+
+CREATE TABLE IF NOT EXISTS eventsubscriptions (
+    id varchar(26) PRIMARY KEY CHECK (length(id) <= 26),
+    createat bigint NOT NULL,
+    updateat bigint NOT NULL,
+    deleteat bigint NOT NULL DEFAULT 0,
+    creatorid varchar(26) NOT NULL CHECK (length(creatorid) <= 26),
+    teamid varchar(26) NOT NULL DEFAULT '' CHECK (length(teamid) <= 26),
+    channelid varchar(26) NOT NULL DEFAULT '' CHECK (length(channelid) <= 26),
+    displayname varchar(64) NOT NULL DEFAULT '' CHECK (length(displayname) <= 64),
+    description varchar(500) NOT NULL DEFAULT '' CHECK (length(description) <= 500),
+    callbackurl varchar(1024) NOT NULL CHECK (length(callbackurl) <= 1024),
+    secret varchar(26) NOT NULL CHECK (length(secret) <= 26),
+    events text NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_eventsubscriptions_teamid_deleteat ON eventsubscriptions (teamid, deleteat);
+
+CREATE TABLE IF NOT EXISTS clusterleases (
+    name varchar(64) PRIMARY KEY CHECK (length(name) <= 64),
+    holderid varchar(26) NOT NULL CHECK (length(holderid) <= 26),
+    expireat bigint NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS emailbatchnotifications (
+    id varchar(26) PRIMARY KEY CHECK (length(id) <= 26),
+    userid varchar(26) NOT NULL CHECK (length(userid) <= 26),
+    postid varchar(26) NOT NULL CHECK (length(postid) <= 26),
+    channelid varchar(26) NOT NULL CHECK (length(channelid) <= 26),
+    rootid varchar(26) NOT NULL DEFAULT '' CHECK (length(rootid) <= 26),
+    teamname varchar(64) NOT NULL DEFAULT '' CHECK (length(teamname) <= 64),
+    createat bigint NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_emailbatchnotifications_userid_createat ON emailbatchnotifications (userid, createat);
+
+CREATE TABLE IF NOT EXISTS workinghours (
+    userid varchar(26) PRIMARY KEY CHECK (length(userid) <= 26),
+    enabled boolean NOT NULL DEFAULT false,
+    timezone varchar(64) NOT NULL CHECK (length(timezone) <= 64),
+    schedule text NOT NULL,
+    exceptions text NOT NULL,
+    quietstatus varchar(32) NOT NULL CHECK (length(quietstatus) <= 32),
+    urgentbypass boolean NOT NULL DEFAULT false,
+    inquiethours boolean NOT NULL DEFAULT false,
+    updateat bigint NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS notificationrules (
+    id varchar(26) PRIMARY KEY CHECK (length(id) <= 26),
+    userid varchar(26) NOT NULL CHECK (length(userid) <= 26),
+    name varchar(64) NOT NULL CHECK (length(name) <= 64),
+    enabled boolean NOT NULL DEFAULT true,
+    sortorder bigint NOT NULL DEFAULT 0,
+    conditions text NOT NULL,
+    action text NOT NULL,
+    createat bigint NOT NULL,
+    updateat bigint NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_notificationrules_userid_sortorder ON notificationrules (userid, sortorder);
+
+CREATE TABLE IF NOT EXISTS auditlogs (
+    id varchar(26) PRIMARY KEY CHECK (length(id) <= 26),
+    sequence bigint NOT NULL,
+    createat bigint NOT NULL,
+    eventname varchar(128) NOT NULL CHECK (length(eventname) <= 128),
+    status varchar(32) NOT NULL DEFAULT '' CHECK (length(status) <= 32),
+    actorid varchar(128) NOT NULL DEFAULT '' CHECK (length(actorid) <= 128),
+    objectid varchar(128) NOT NULL DEFAULT '' CHECK (length(objectid) <= 128),
+    record text NOT NULL,
+    prevhash varchar(64) NOT NULL DEFAULT '' CHECK (length(prevhash) <= 64),
+    hash varchar(64) NOT NULL CHECK (length(hash) <= 64),
+    UNIQUE (sequence)
+);
+CREATE INDEX IF NOT EXISTS idx_auditlogs_createat ON auditlogs (createat);
+CREATE INDEX IF NOT EXISTS idx_auditlogs_actorid_createat ON auditlogs (actorid, createat);
+CREATE INDEX IF NOT EXISTS idx_auditlogs_eventname_createat ON auditlogs (eventname, createat);
+CREATE INDEX IF NOT EXISTS idx_auditlogs_objectid_createat ON auditlogs (objectid, createat);
+
+CREATE TABLE IF NOT EXISTS retentionidsfordeletion (
+    id varchar(26) PRIMARY KEY CHECK (length(id) <= 26),
+    tablename varchar(64) CHECK (length(tablename) <= 64),
+    ids text
+);
+CREATE INDEX IF NOT EXISTS idx_retentionidsfordeletion_tablename ON retentionidsfordeletion (tablename);
+
+CREATE INDEX IF NOT EXISTS idx_users_email_lower_textpattern ON users (lower(email));
+CREATE INDEX IF NOT EXISTS idx_users_username_lower_textpattern ON users (lower(username));
+CREATE INDEX IF NOT EXISTS idx_users_nickname_lower_textpattern ON users (lower(nickname));
+CREATE INDEX IF NOT EXISTS idx_users_firstname_lower_textpattern ON users (lower(firstname));
+CREATE INDEX IF NOT EXISTS idx_users_lastname_lower_textpattern ON users (lower(lastname));
+CREATE INDEX IF NOT EXISTS idx_propertyvalues_create_at_id ON propertyvalues (createat, id);
+CREATE INDEX IF NOT EXISTS idx_propertyfields_create_at_id ON propertyfields (createat, id);
+CREATE INDEX IF NOT EXISTS idx_sidebarchannels_categoryid ON sidebarchannels (categoryid);
+CREATE INDEX IF NOT EXISTS idx_contentflaggingteamreviewers_userid ON contentflaggingteamreviewers (userid);
+
+CREATE VIEW IF NOT EXISTS poststats AS
+SELECT userid, date(createat / 1000, 'unixepoch') AS day, COUNT(*) AS numposts, MAX(createat) AS lastpostdate
+FROM posts
+GROUP BY userid, day;
+
+CREATE VIEW IF NOT EXISTS posts_by_team_day AS
+SELECT date(p.createat / 1000, 'unixepoch') AS day, COUNT(*) AS num, c.teamid AS teamid
+FROM posts p JOIN channels c ON p.channelid = c.id
+GROUP BY day, c.teamid;
+
+CREATE VIEW IF NOT EXISTS bot_posts_by_team_day AS
+SELECT date(p.createat / 1000, 'unixepoch') AS day, COUNT(*) AS num, c.teamid AS teamid
+FROM posts p
+JOIN bots b ON p.userid = b.userid
+JOIN channels c ON p.channelid = c.id
+GROUP BY day, c.teamid;
+
+CREATE VIEW IF NOT EXISTS file_stats AS
+SELECT COUNT(*) AS num, COALESCE(SUM(size), 0) AS usage
+FROM fileinfo
+WHERE deleteat = 0;
+
+CREATE VIEW IF NOT EXISTS attributeview AS
+SELECT
+    pv.groupid AS groupid,
+    pv.targetid AS targetid,
+    pv.targettype AS targettype,
+    json_group_object(
+        pf.name,
+        CASE
+            WHEN pf.type = 'select' THEN (
+                SELECT json_quote(json_extract(o.value, '$.name'))
+                FROM json_each(pf.attrs, '$.options') AS o
+                WHERE json_extract(o.value, '$.id') = pv.value ->> '$'
+                LIMIT 1
+            )
+            WHEN pf.type = 'multiselect' AND json_type(pv.value) = 'array' THEN (
+                SELECT json_group_array(json_extract(o.value, '$.name'))
+                FROM json_each(pv.value) AS v
+                JOIN json_each(pf.attrs, '$.options') AS o ON json_extract(o.value, '$.id') = v.value
+            )
+            ELSE json(pv.value)
+        END
+    ) AS attributes
+FROM propertyvalues pv
+LEFT JOIN propertyfields pf ON pf.id = pv.fieldid
+WHERE (pv.deleteat = 0 OR pv.deleteat IS NULL) AND (pf.deleteat = 0 OR pf.deleteat IS NULL)
+GROUP BY pv.groupid, pv.targetid, pv.targettype;
+
+INSERT INTO systems (name, value) VALUES ('CRTChannelMembershipCountsMigrationComplete', 'true') ON CONFLICT DO NOTHING;
+INSERT INTO systems (name, value) VALUES ('CRTThreadCountsAndUnreadsMigrationComplete', 'true') ON CONFLICT DO NOTHING;
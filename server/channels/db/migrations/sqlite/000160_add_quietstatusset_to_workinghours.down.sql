@@ -0,0 +1 @@
+ALTER TABLE workinghours DROP COLUMN quietstatusset;
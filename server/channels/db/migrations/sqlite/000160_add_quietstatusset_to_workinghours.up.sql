@@ -0,0 +1 @@
+ALTER TABLE workinghours ADD COLUMN quietstatusset boolean NOT NULL DEFAULT false;
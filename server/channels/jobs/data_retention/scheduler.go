@@ -0,0 +1,36 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package data_retention
+
+import (
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/channels/jobs"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+// MakeScheduler creates a scheduler running the job daily at the deletion job
+// start time, as long as the global policy deletes messages or files, or
+// there are granular policies.
+func MakeScheduler(jobServer *jobs.JobServer, store store.Store, license func() *model.License) *jobs.DailyScheduler {
+	startTime := func(cfg *model.Config) *time.Time {
+		parsedTime, err := time.Parse("15:04", *cfg.DataRetentionSettings.DeletionJobStartTime)
+		if err == nil {
+			return &parsedTime
+		}
+		return nil
+	}
+	isEnabled := func(cfg *model.Config) bool {
+		if l := license(); l == nil || !*l.Features.DataRetention {
+			return false
+		}
+		if *cfg.DataRetentionSettings.EnableMessageDeletion || *cfg.DataRetentionSettings.EnableFileDeletion {
+			return true
+		}
+		count, err := store.RetentionPolicy().GetCount()
+		return err == nil && count > 0
+	}
+	return jobs.NewDailyScheduler(jobServer, model.JobTypeDataRetention, startTime, isEnabled)
+}
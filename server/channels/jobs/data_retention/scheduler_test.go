@@ -0,0 +1,52 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package data_retention
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func TestSchedulerEnabled(t *testing.T) {
+	if testing.Short() {
+		t.SkipNow()
+	}
+	th := setup(t, nil)
+	license := model.NewTestLicense("data_retention")
+	scheduler := MakeScheduler(th.jobServer, th.store, func() *model.License { return license })
+
+	cfg := th.app.config.Clone()
+
+	t.Run("nothing to delete", func(t *testing.T) {
+		assert.False(t, scheduler.Enabled(cfg))
+	})
+
+	t.Run("global message deletion", func(t *testing.T) {
+		cfg := cfg.Clone()
+		cfg.DataRetentionSettings.EnableMessageDeletion = model.NewPointer(true)
+		assert.True(t, scheduler.Enabled(cfg))
+	})
+
+	t.Run("global file deletion", func(t *testing.T) {
+		cfg := cfg.Clone()
+		cfg.DataRetentionSettings.EnableFileDeletion = model.NewPointer(true)
+		assert.True(t, scheduler.Enabled(cfg))
+	})
+
+	t.Run("granular policies", func(t *testing.T) {
+		th.createPolicy(t, 1, nil, []string{th.createChannel(t, th.createTeam(t).Id).Id})
+		assert.True(t, scheduler.Enabled(cfg))
+	})
+
+	t.Run("without the license", func(t *testing.T) {
+		license = nil
+		assert.False(t, scheduler.Enabled(cfg))
+
+		license = model.NewTestLicenseWithFalseDefaults("data_retention")
+		assert.False(t, scheduler.Enabled(cfg))
+	})
+}
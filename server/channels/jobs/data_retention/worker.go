@@ -0,0 +1,371 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package data_retention
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/jobs"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+	"github.com/mattermost/mattermost/server/v8/platform/services/searchengine"
+)
+
+const (
+	jobDataPhase               = "phase"
+	jobDataNow                 = "now"
+	jobDataMessageEndTime      = "message_end_time"
+	jobDataFileEndTime         = "file_end_time"
+	jobDataChannelPoliciesDone = "channel_policies_done"
+	jobDataTeamPoliciesDone    = "team_policies_done"
+	jobDataGlobalPoliciesDone  = "global_policies_done"
+	jobDataFileCreateAt        = "file_create_at"
+	jobDataFileID              = "file_id"
+	jobDataOrphansStep         = "orphans_step"
+
+	JobDataPostsDeleted     = "posts_deleted"
+	JobDataReactionsDeleted = "reactions_deleted"
+	JobDataFilesDeleted     = "files_deleted"
+	JobDataRowsDeleted      = "rows_deleted"
+)
+
+const (
+	phasePosts                = "posts"
+	phasePostAttachments      = "post_attachments"
+	phaseChannelMemberHistory = "channel_member_history"
+	phaseThreads              = "threads"
+	phaseThreadMemberships    = "thread_memberships"
+	phaseFiles                = "files"
+	phaseOrphans              = "orphans"
+	phaseDone                 = "done"
+)
+
+// phases are the steps of the job, in order. The posts are deleted first, so
+// that their attachments and reactions can be deleted next.
+var phases = []string{
+	phasePosts,
+	phasePostAttachments,
+	phaseChannelMemberHistory,
+	phaseThreads,
+	phaseThreadMemberships,
+	phaseFiles,
+	phaseOrphans,
+}
+
+type AppIface interface {
+	Config() *model.Config
+	License() *model.License
+	SearchEngine() *searchengine.Broker
+	RemoveFilesFromFileStore(rctx request.CTX, fileInfos []*model.FileInfo)
+}
+
+// MakeWorker creates a worker that deletes, in batches, the messages older
+// than the retention period of the channel, team or global policy they fall
+// under, along with their reactions, attachments and search index entries.
+// When file deletion is enabled, files older than the global file retention
+// period are deleted too. Messages and files of the users and channels on
+// legal hold are never deleted.
+func MakeWorker(jobServer *jobs.JobServer, store store.Store, app AppIface) *jobs.BatchWorker {
+	w := &worker{
+		jobServer: jobServer,
+		store:     store,
+		app:       app,
+	}
+	timeBetweenBatches := time.Duration(*app.Config().DataRetentionSettings.TimeBetweenBatchesMilliseconds) * time.Millisecond
+	return jobs.MakeBatchWorker(jobServer, store, timeBetweenBatches, w.doBatch)
+}
+
+type worker struct {
+	jobServer *jobs.JobServer
+	store     store.Store
+	app       AppIface
+}
+
+// deletion holds the state of a single batch of the job.
+type deletion struct {
+	rctx     request.CTX
+	job      *model.Job
+	settings model.DataRetentionSettings
+	engines  []searchengine.SearchEngineInterface
+}
+
+func (w *worker) doBatch(rctx request.CTX, job *model.Job) bool {
+	logger := rctx.Logger()
+
+	if license := w.app.License(); license == nil || !*license.Features.DataRetention {
+		w.setJobError(logger, job, model.NewAppError("doBatch", "ent.data_retention.generic.license.error", nil, "", http.StatusNotImplemented))
+		return true
+	}
+
+	d := &deletion{
+		rctx:     rctx,
+		job:      job,
+		settings: w.app.Config().DataRetentionSettings,
+	}
+	for _, engine := range w.app.SearchEngine().GetActiveEngines() {
+		if engine.IsIndexingEnabled() {
+			d.engines = append(d.engines, engine)
+		}
+	}
+
+	phase := job.Data[jobDataPhase]
+	if phase == "" {
+		phase = phasePosts
+		d.start()
+	}
+
+	var done bool
+	var err error
+	switch phase {
+	case phasePosts:
+		// The ids of the deleted posts are recorded by the store, so their
+		// attachments and reactions are deleted in the next phase.
+		done, err = d.deleteForPolicies(w.store.Post().PermanentDeleteBatchForRetentionPolicies, JobDataPostsDeleted)
+	case phasePostAttachments:
+		done, err = w.deletePostAttachments(d)
+	case phaseChannelMemberHistory:
+		done, err = d.deleteForPolicies(w.store.ChannelMemberHistory().PermanentDeleteBatchForRetentionPolicies, JobDataRowsDeleted)
+	case phaseThreads:
+		done, err = d.deleteForPolicies(w.store.Thread().PermanentDeleteBatchForRetentionPolicies, JobDataRowsDeleted)
+	case phaseThreadMemberships:
+		done, err = d.deleteForPolicies(w.store.Thread().PermanentDeleteBatchThreadMembershipsForRetentionPolicies, JobDataRowsDeleted)
+	case phaseFiles:
+		done, err = w.deleteFiles(d)
+	case phaseOrphans:
+		done, err = w.deleteOrphans(d)
+	case phaseDone:
+		return w.finish(logger, job)
+	default:
+		err = fmt.Errorf("unknown phase %q", phase)
+	}
+	if err != nil {
+		w.setJobError(logger, job, model.NewAppError("doBatch", "ent.data_retention.run_failed.error", nil, "", http.StatusInternalServerError).Wrap(err))
+		return true
+	}
+
+	index := slices.Index(phases, phase)
+	if done {
+		logger.Info("Finished data retention phase", mlog.String("phase", phase))
+		if index == len(phases)-1 {
+			return w.finish(logger, job)
+		}
+		job.Data[jobDataPhase] = phases[index+1]
+		d.resetCursor()
+	} else {
+		job.Data[jobDataPhase] = phase
+	}
+
+	if appErr := w.jobServer.SetJobProgress(job, int64(index*100/len(phases))); appErr != nil {
+		logger.Warn("Failed to update the job progress", mlog.Err(appErr))
+	}
+	return false
+}
+
+func (w *worker) finish(logger mlog.LoggerIFace, job *model.Job) bool {
+	job.Data[jobDataPhase] = phaseDone
+
+	if appErr := w.jobServer.SetJobProgress(job, 100); appErr != nil {
+		logger.Warn("Failed to update the job progress", mlog.Err(appErr))
+	}
+	if appErr := w.jobServer.SetJobSuccess(job); appErr != nil {
+		logger.Error("Failed to set the job as successful", mlog.Err(appErr))
+		w.setJobError(logger, job, appErr)
+	}
+	return true
+}
+
+func (w *worker) setJobError(logger mlog.LoggerIFace, job *model.Job, appErr *model.AppError) {
+	logger.Error("Failed to run data retention. Exiting", mlog.Err(appErr))
+	if err := w.jobServer.SetJobError(job, appErr); err != nil {
+		logger.Error("Failed to set the job error", mlog.Err(err))
+	}
+}
+
+// start records the times the records are compared to, so they don't change
+// while the job runs.
+func (d *deletion) start() {
+	now := time.Now()
+	d.job.Data[jobDataNow] = strconv.FormatInt(model.GetMillisForTime(now), 10)
+	if *d.settings.EnableMessageDeletion {
+		endTime := now.Add(-time.Duration(d.settings.GetMessageRetentionHours()) * time.Hour)
+		d.job.Data[jobDataMessageEndTime] = strconv.FormatInt(model.GetMillisForTime(endTime), 10)
+	}
+	if *d.settings.EnableFileDeletion {
+		endTime := now.Add(-time.Duration(d.settings.GetFileRetentionHours()) * time.Hour)
+		d.job.Data[jobDataFileEndTime] = strconv.FormatInt(model.GetMillisForTime(endTime), 10)
+	}
+}
+
+func (d *deletion) int64Data(key string) int64 {
+	value, _ := strconv.ParseInt(d.job.Data[key], 10, 64)
+	return value
+}
+
+func (d *deletion) increment(key string, n int64) {
+	d.job.Data[key] = strconv.FormatInt(d.int64Data(key)+n, 10)
+}
+
+func (d *deletion) resetCursor() {
+	delete(d.job.Data, jobDataChannelPoliciesDone)
+	delete(d.job.Data, jobDataTeamPoliciesDone)
+	delete(d.job.Data, jobDataGlobalPoliciesDone)
+}
+
+func (d *deletion) batchConfigs() model.RetentionPolicyBatchConfigs {
+	return model.RetentionPolicyBatchConfigs{
+		Now:                 d.int64Data(jobDataNow),
+		GlobalPolicyEndTime: d.int64Data(jobDataMessageEndTime),
+		Limit:               int64(*d.settings.BatchSize),
+		PreservePinnedPosts: *d.settings.PreservePinnedPosts,
+		LegalHoldUserIDs:    d.settings.LegalHoldUserIds,
+		LegalHoldChannelIDs: d.settings.LegalHoldChannelIds,
+	}
+}
+
+// deleteForPolicies deletes the next batch of records under the channel, team
+// and global policies, in that order, adding their number to the given
+// counter.
+func (d *deletion) deleteForPolicies(deleteBatch func(model.RetentionPolicyBatchConfigs, model.RetentionPolicyCursor) (int64, model.RetentionPolicyCursor, error), counter string) (bool, error) {
+	cursor := model.RetentionPolicyCursor{
+		ChannelPoliciesDone: d.job.Data[jobDataChannelPoliciesDone] == "true",
+		TeamPoliciesDone:    d.job.Data[jobDataTeamPoliciesDone] == "true",
+		GlobalPoliciesDone:  d.job.Data[jobDataGlobalPoliciesDone] == "true",
+	}
+
+	deleted, cursor, err := deleteBatch(d.batchConfigs(), cursor)
+	if err != nil {
+		return false, err
+	}
+
+	d.increment(counter, deleted)
+	d.job.Data[jobDataChannelPoliciesDone] = strconv.FormatBool(cursor.ChannelPoliciesDone)
+	d.job.Data[jobDataTeamPoliciesDone] = strconv.FormatBool(cursor.TeamPoliciesDone)
+	d.job.Data[jobDataGlobalPoliciesDone] = strconv.FormatBool(cursor.GlobalPoliciesDone)
+	return cursor.ChannelPoliciesDone && cursor.TeamPoliciesDone && cursor.GlobalPoliciesDone, nil
+}
+
+// deletePostAttachments deletes the files, reactions and search index entries
+// of the next set of deleted posts. Each set holds up to a batch of posts.
+func (w *worker) deletePostAttachments(d *deletion) (bool, error) {
+	rows, err := w.store.RetentionPolicy().GetIdsForDeletionByTableName("Posts", 1)
+	if err != nil {
+		return false, fmt.Errorf("failed to get the ids of deleted posts: %w", err)
+	}
+	if len(rows) == 0 {
+		return true, nil
+	}
+	row := rows[0]
+
+	for _, postID := range row.Ids {
+		fileInfos, err := w.store.FileInfo().GetForPost(postID, true, true, false)
+		if err != nil {
+			return false, fmt.Errorf("failed to get the files of post %s: %w", postID, err)
+		}
+		if len(fileInfos) == 0 {
+			continue
+		}
+
+		w.app.RemoveFilesFromFileStore(d.rctx, fileInfos)
+		if err := w.store.FileInfo().PermanentDeleteForPost(d.rctx, postID); err != nil {
+			return false, fmt.Errorf("failed to delete the files of post %s: %w", postID, err)
+		}
+		for _, fileInfo := range fileInfos {
+			d.deleteFileFromIndex(fileInfo.Id)
+		}
+		d.increment(JobDataFilesDeleted, int64(len(fileInfos)))
+	}
+
+	for _, engine := range d.engines {
+		if appErr := engine.DeletePosts(d.rctx, row.Ids); appErr != nil {
+			d.rctx.Logger().Warn("Failed to delete posts from the search index", mlog.String("search_engine", engine.GetName()), mlog.Err(appErr))
+		}
+	}
+
+	// Deleting the reactions also removes the ids, marking them as done.
+	reactionsDeleted, err := w.store.Reaction().DeleteOrphanedRowsByIds(row)
+	if err != nil {
+		return false, fmt.Errorf("failed to delete reactions: %w", err)
+	}
+	d.increment(JobDataReactionsDeleted, reactionsDeleted)
+
+	return false, nil
+}
+
+func (d *deletion) deleteFileFromIndex(fileID string) {
+	for _, engine := range d.engines {
+		if appErr := engine.DeleteFile(fileID); appErr != nil {
+			d.rctx.Logger().Warn("Failed to delete file from the search index", mlog.String("search_engine", engine.GetName()), mlog.String("file_id", fileID), mlog.Err(appErr))
+		}
+	}
+}
+
+// deleteFiles deletes the next batch of files older than the global file
+// retention period, unless file deletion is disabled.
+func (w *worker) deleteFiles(d *deletion) (bool, error) {
+	endTime := d.int64Data(jobDataFileEndTime)
+	if endTime == 0 {
+		return true, nil
+	}
+
+	files, err := w.store.FileInfo().GetFilesBatchForIndexing(d.int64Data(jobDataFileCreateAt), d.job.Data[jobDataFileID], true, *d.settings.BatchSize)
+	if err != nil {
+		return false, fmt.Errorf("failed to get files: %w", err)
+	}
+
+	for _, file := range files {
+		if file.CreateAt >= endTime {
+			return true, nil
+		}
+		d.job.Data[jobDataFileCreateAt] = strconv.FormatInt(file.CreateAt, 10)
+		d.job.Data[jobDataFileID] = file.Id
+
+		if slices.Contains(d.settings.LegalHoldUserIds, file.CreatorId) || slices.Contains(d.settings.LegalHoldChannelIds, file.ChannelId) {
+			continue
+		}
+
+		w.app.RemoveFilesFromFileStore(d.rctx, []*model.FileInfo{&file.FileInfo})
+		if err := w.store.FileInfo().PermanentDelete(d.rctx, file.Id); err != nil {
+			return false, fmt.Errorf("failed to delete file %s: %w", file.Id, err)
+		}
+		d.deleteFileFromIndex(file.Id)
+		d.increment(JobDataFilesDeleted, 1)
+	}
+
+	return len(files) < *d.settings.BatchSize, nil
+}
+
+// deleteOrphans deletes the next batch of records left behind by deleted
+// channels, posts and policies.
+func (w *worker) deleteOrphans(d *deletion) (bool, error) {
+	steps := []func(limit int) (int64, error){
+		w.store.RetentionPolicy().DeleteOrphanedRows,
+		w.store.ChannelMemberHistory().DeleteOrphanedRows,
+		w.store.Thread().DeleteOrphanedRows,
+		w.store.Preference().DeleteOrphanedRows,
+	}
+
+	step := int(d.int64Data(jobDataOrphansStep))
+	if step >= len(steps) {
+		return true, nil
+	}
+
+	limit := *d.settings.BatchSize
+	deleted, err := steps[step](limit)
+	if err != nil {
+		return false, fmt.Errorf("failed to delete orphaned rows: %w", err)
+	}
+	d.increment(JobDataRowsDeleted, deleted)
+
+	if deleted < int64(limit) {
+		step++
+		d.job.Data[jobDataOrphansStep] = strconv.Itoa(step)
+	}
+	return step == len(steps), nil
+}
@@ -0,0 +1,406 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package data_retention
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/jobs"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+	"github.com/mattermost/mattermost/server/v8/channels/utils/testutils"
+	"github.com/mattermost/mattermost/server/v8/platform/services/searchengine"
+	searchenginemocks "github.com/mattermost/mattermost/server/v8/platform/services/searchengine/mocks"
+)
+
+type testApp struct {
+	config       *model.Config
+	license      *model.License
+	searchEngine *searchengine.Broker
+	removedFiles []string
+}
+
+func (a *testApp) Config() *model.Config {
+	return a.config
+}
+
+func (a *testApp) License() *model.License {
+	return a.license
+}
+
+func (a *testApp) SearchEngine() *searchengine.Broker {
+	return a.searchEngine
+}
+
+func (a *testApp) RemoveFilesFromFileStore(rctx request.CTX, fileInfos []*model.FileInfo) {
+	for _, fileInfo := range fileInfos {
+		a.removedFiles = append(a.removedFiles, fileInfo.Path)
+	}
+}
+
+type testHelper struct {
+	rctx      request.CTX
+	store     store.Store
+	app       *testApp
+	jobServer *jobs.JobServer
+
+	indexedPostsDeleted []string
+	indexedFilesDeleted []string
+}
+
+func setup(t *testing.T, updateSettings func(settings *model.DataRetentionSettings)) *testHelper {
+	ss := mainHelper.GetStore()
+	ss.DropAllTables()
+
+	cfg := &model.Config{}
+	cfg.SetDefaults()
+	cfg.DataRetentionSettings.TimeBetweenBatchesMilliseconds = model.NewPointer(0)
+	if updateSettings != nil {
+		updateSettings(&cfg.DataRetentionSettings)
+	}
+
+	th := &testHelper{
+		rctx:      request.TestContext(t),
+		store:     ss,
+		jobServer: jobs.NewJobServer(&testutils.StaticConfigService{Cfg: cfg}, ss, nil, mlog.CreateConsoleTestLogger(t)),
+	}
+
+	engine := &searchenginemocks.SearchEngineInterface{}
+	engine.On("IsActive").Return(true)
+	engine.On("IsIndexingEnabled").Return(true)
+	engine.On("GetName").Return("mock").Maybe()
+	engine.On("DeletePosts", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		th.indexedPostsDeleted = append(th.indexedPostsDeleted, args.Get(1).([]string)...)
+	}).Return(nil).Maybe()
+	engine.On("DeleteFile", mock.Anything).Run(func(args mock.Arguments) {
+		th.indexedFilesDeleted = append(th.indexedFilesDeleted, args.String(0))
+	}).Return(nil).Maybe()
+	broker := searchengine.NewBroker(cfg)
+	broker.RegisterElasticsearchEngine(engine)
+
+	th.app = &testApp{
+		config:       cfg,
+		license:      model.NewTestLicense("data_retention"),
+		searchEngine: broker,
+	}
+
+	return th
+}
+
+func (th *testHelper) newWorker() *worker {
+	return &worker{
+		jobServer: th.jobServer,
+		store:     th.store,
+		app:       th.app,
+	}
+}
+
+func (th *testHelper) createTeam(t *testing.T) *model.Team {
+	team, err := th.store.Team().Save(&model.Team{
+		DisplayName: "Team",
+		Name:        "t-" + model.NewId(),
+		Email:       "success+" + model.NewId() + "@simulator.amazonses.com",
+		Type:        model.TeamOpen,
+	})
+	require.NoError(t, err)
+	return team
+}
+
+func (th *testHelper) createChannel(t *testing.T, teamID string) *model.Channel {
+	channel, err := th.store.Channel().Save(th.rctx, &model.Channel{
+		TeamId:      teamID,
+		DisplayName: "Channel",
+		Name:        "c-" + model.NewId(),
+		Type:        model.ChannelTypeOpen,
+	}, -1)
+	require.NoError(t, err)
+	return channel
+}
+
+func (th *testHelper) createPolicy(t *testing.T, days int64, teamIDs, channelIDs []string) {
+	_, err := th.store.RetentionPolicy().Save(&model.RetentionPolicyWithTeamAndChannelIDs{
+		RetentionPolicy: model.RetentionPolicy{
+			DisplayName:      "Policy",
+			PostDurationDays: model.NewPointer(days),
+		},
+		TeamIDs:    teamIDs,
+		ChannelIDs: channelIDs,
+	})
+	require.NoError(t, err)
+}
+
+func (th *testHelper) createPost(t *testing.T, channelID, userID string, age time.Duration) *model.Post {
+	post, err := th.store.Post().Save(th.rctx, &model.Post{
+		ChannelId: channelID,
+		UserId:    userID,
+		Message:   "message",
+		CreateAt:  model.GetMillisForTime(time.Now().Add(-age)),
+	})
+	require.NoError(t, err)
+	return post
+}
+
+func (th *testHelper) createFile(t *testing.T, channelID, userID, postID string, age time.Duration) *model.FileInfo {
+	id := model.NewId()
+	fileInfo, err := th.store.FileInfo().Save(th.rctx, &model.FileInfo{
+		Id:        id,
+		CreatorId: userID,
+		ChannelId: channelID,
+		PostId:    postID,
+		Name:      "file.txt",
+		Path:      "data/" + id + "/file.txt",
+		CreateAt:  model.GetMillisForTime(time.Now().Add(-age)),
+	})
+	require.NoError(t, err)
+	return fileInfo
+}
+
+func (th *testHelper) newJob(t *testing.T) *model.Job {
+	job, err := th.store.Job().Save(&model.Job{
+		Id:     model.NewId(),
+		Type:   model.JobTypeDataRetention,
+		Status: model.JobStatusInProgress,
+		Data:   model.StringMap{},
+	})
+	require.NoError(t, err)
+	return job
+}
+
+// runJob runs the batches of the job until it's done, and returns the job as stored.
+func (th *testHelper) runJob(t *testing.T, w *worker, job *model.Job) *model.Job {
+	for range 100 {
+		if w.doBatch(th.rctx, job) {
+			return th.getJob(t, job.Id)
+		}
+	}
+	require.FailNow(t, "the job didn't finish")
+	return nil
+}
+
+func (th *testHelper) getJob(t *testing.T, id string) *model.Job {
+	job, err := th.store.Job().Get(th.rctx, id)
+	require.NoError(t, err)
+	return job
+}
+
+func (th *testHelper) assertPostDeleted(t *testing.T, post *model.Post, deleted bool) {
+	t.Helper()
+	_, err := th.store.Post().GetSingle(th.rctx, post.Id, true)
+	if !deleted {
+		assert.NoError(t, err, "post %s should have been kept", post.Id)
+		return
+	}
+	var nfErr *store.ErrNotFound
+	assert.True(t, errors.As(err, &nfErr), "post %s should have been deleted", post.Id)
+}
+
+func (th *testHelper) assertFileDeleted(t *testing.T, fileInfo *model.FileInfo, deleted bool) {
+	t.Helper()
+	_, err := th.store.FileInfo().Get(fileInfo.Id)
+	if !deleted {
+		assert.NoError(t, err, "file %s should have been kept", fileInfo.Id)
+		assert.NotContains(t, th.app.removedFiles, fileInfo.Path)
+		return
+	}
+	var nfErr *store.ErrNotFound
+	assert.True(t, errors.As(err, &nfErr), "file %s should have been deleted", fileInfo.Id)
+	assert.Contains(t, th.app.removedFiles, fileInfo.Path)
+	assert.Contains(t, th.indexedFilesDeleted, fileInfo.Id)
+}
+
+const day = 24 * time.Hour
+
+func TestDataRetentionWorkerRequiresLicense(t *testing.T) {
+	if testing.Short() {
+		t.SkipNow()
+	}
+	th := setup(t, nil)
+	th.app.license = nil
+
+	job := th.runJob(t, th.newWorker(), th.newJob(t))
+	assert.Equal(t, model.JobStatusError, job.Status)
+}
+
+func TestDataRetentionWorkerPolicies(t *testing.T) {
+	if testing.Short() {
+		t.SkipNow()
+	}
+	th := setup(t, nil)
+	userID := model.NewId()
+
+	channelPolicyTeam := th.createTeam(t)
+	channelPolicyChannel := th.createChannel(t, channelPolicyTeam.Id)
+	th.createPolicy(t, 1, nil, []string{channelPolicyChannel.Id})
+
+	teamPolicyTeam := th.createTeam(t)
+	teamPolicyChannel := th.createChannel(t, teamPolicyTeam.Id)
+	th.createPolicy(t, 5, []string{teamPolicyTeam.Id}, nil)
+
+	// The global policy doesn't delete messages, so the channels without a
+	// granular policy are left alone.
+	unmanagedChannel := th.createChannel(t, th.createTeam(t).Id)
+
+	expiredInChannel := th.createPost(t, channelPolicyChannel.Id, userID, 2*day)
+	recentInChannel := th.createPost(t, channelPolicyChannel.Id, userID, time.Hour)
+	expiredInTeam := th.createPost(t, teamPolicyChannel.Id, userID, 10*day)
+	recentInTeam := th.createPost(t, teamPolicyChannel.Id, userID, 2*day)
+	unmanaged := th.createPost(t, unmanagedChannel.Id, userID, 100*day)
+
+	expiredAttachment := th.createFile(t, channelPolicyChannel.Id, userID, expiredInChannel.Id, 2*day)
+	recentAttachment := th.createFile(t, channelPolicyChannel.Id, userID, recentInChannel.Id, time.Hour)
+	_, err := th.store.Reaction().Save(&model.Reaction{UserId: userID, PostId: expiredInChannel.Id, ChannelId: channelPolicyChannel.Id, EmojiName: "smile"})
+	require.NoError(t, err)
+	_, err = th.store.Reaction().Save(&model.Reaction{UserId: userID, PostId: recentInChannel.Id, ChannelId: channelPolicyChannel.Id, EmojiName: "smile"})
+	require.NoError(t, err)
+
+	job := th.runJob(t, th.newWorker(), th.newJob(t))
+	require.Equal(t, model.JobStatusSuccess, job.Status)
+	assert.EqualValues(t, 100, job.Progress)
+	assert.Equal(t, phaseDone, job.Data[jobDataPhase])
+	assert.Equal(t, "2", job.Data[JobDataPostsDeleted])
+	assert.Equal(t, "1", job.Data[JobDataFilesDeleted])
+	assert.Equal(t, "1", job.Data[JobDataReactionsDeleted])
+
+	th.assertPostDeleted(t, expiredInChannel, true)
+	th.assertPostDeleted(t, recentInChannel, false)
+	th.assertPostDeleted(t, expiredInTeam, true)
+	th.assertPostDeleted(t, recentInTeam, false)
+	th.assertPostDeleted(t, unmanaged, false)
+	assert.ElementsMatch(t, []string{expiredInChannel.Id, expiredInTeam.Id}, th.indexedPostsDeleted)
+
+	th.assertFileDeleted(t, expiredAttachment, true)
+	th.assertFileDeleted(t, recentAttachment, false)
+
+	reactions, err := th.store.Reaction().GetForPost(expiredInChannel.Id, false)
+	require.NoError(t, err)
+	assert.Empty(t, reactions)
+	reactions, err = th.store.Reaction().GetForPost(recentInChannel.Id, false)
+	require.NoError(t, err)
+	assert.Len(t, reactions, 1)
+}
+
+func TestDataRetentionWorkerLegalHold(t *testing.T) {
+	if testing.Short() {
+		t.SkipNow()
+	}
+	th := setup(t, func(settings *model.DataRetentionSettings) {
+		settings.EnableFileDeletion = model.NewPointer(true)
+		settings.FileRetentionHours = model.NewPointer(24)
+	})
+	heldUserID := model.NewId()
+	userID := model.NewId()
+
+	policyTeam := th.createTeam(t)
+	channel := th.createChannel(t, policyTeam.Id)
+	heldChannel := th.createChannel(t, policyTeam.Id)
+	th.createPolicy(t, 1, []string{policyTeam.Id}, nil)
+
+	th.app.config.DataRetentionSettings.LegalHoldUserIds = []string{heldUserID}
+	th.app.config.DataRetentionSettings.LegalHoldChannelIds = []string{heldChannel.Id}
+
+	expired := th.createPost(t, channel.Id, userID, 2*day)
+	expiredByHeldUser := th.createPost(t, channel.Id, heldUserID, 2*day)
+	expiredInHeldChannel := th.createPost(t, heldChannel.Id, userID, 2*day)
+
+	expiredFile := th.createFile(t, channel.Id, userID, "", 2*day)
+	expiredFileOfHeldUser := th.createFile(t, channel.Id, heldUserID, "", 2*day)
+	expiredFileInHeldChannel := th.createFile(t, heldChannel.Id, userID, "", 2*day)
+	recentFile := th.createFile(t, channel.Id, userID, "", time.Hour)
+
+	job := th.runJob(t, th.newWorker(), th.newJob(t))
+	require.Equal(t, model.JobStatusSuccess, job.Status)
+	assert.Equal(t, "1", job.Data[JobDataPostsDeleted])
+	assert.Equal(t, "1", job.Data[JobDataFilesDeleted])
+
+	th.assertPostDeleted(t, expired, true)
+	th.assertPostDeleted(t, expiredByHeldUser, false)
+	th.assertPostDeleted(t, expiredInHeldChannel, false)
+
+	th.assertFileDeleted(t, expiredFile, true)
+	th.assertFileDeleted(t, expiredFileOfHeldUser, false)
+	th.assertFileDeleted(t, expiredFileInHeldChannel, false)
+	th.assertFileDeleted(t, recentFile, false)
+}
+
+func TestDataRetentionWorkerBatches(t *testing.T) {
+	if testing.Short() {
+		t.SkipNow()
+	}
+	th := setup(t, func(settings *model.DataRetentionSettings) {
+		settings.BatchSize = model.NewPointer(2)
+	})
+	userID := model.NewId()
+
+	channel := th.createChannel(t, th.createTeam(t).Id)
+	th.createPolicy(t, 1, nil, []string{channel.Id})
+
+	posts := make([]*model.Post, 0, 5)
+	for range 5 {
+		posts = append(posts, th.createPost(t, channel.Id, userID, 2*day))
+	}
+	recent := th.createPost(t, channel.Id, userID, time.Hour)
+
+	job := th.newJob(t)
+	w := th.newWorker()
+
+	t.Run("progress is saved after each batch", func(t *testing.T) {
+		require.False(t, w.doBatch(th.rctx, job))
+
+		stored := th.getJob(t, job.Id)
+		assert.Equal(t, model.JobStatusInProgress, stored.Status)
+		assert.Equal(t, phasePosts, stored.Data[jobDataPhase])
+		assert.Equal(t, "2", stored.Data[JobDataPostsDeleted])
+		assert.NotEmpty(t, stored.Data[jobDataNow])
+		assert.EqualValues(t, 0, stored.Progress)
+	})
+
+	t.Run("a new worker resumes from the saved progress", func(t *testing.T) {
+		stored := th.getJob(t, job.Id)
+		now := stored.Data[jobDataNow]
+
+		require.False(t, th.newWorker().doBatch(th.rctx, stored))
+		assert.Equal(t, now, stored.Data[jobDataNow], "the times the records are compared to must not change")
+		assert.Equal(t, "4", stored.Data[JobDataPostsDeleted])
+
+		stored = th.runJob(t, th.newWorker(), th.getJob(t, job.Id))
+		require.Equal(t, model.JobStatusSuccess, stored.Status)
+		assert.Equal(t, "5", stored.Data[JobDataPostsDeleted])
+	})
+
+	for _, post := range posts {
+		th.assertPostDeleted(t, post, true)
+	}
+	th.assertPostDeleted(t, recent, false)
+}
+
+func TestDataRetentionWorkerPhaseProgress(t *testing.T) {
+	if testing.Short() {
+		t.SkipNow()
+	}
+	th := setup(t, nil)
+
+	job := th.newJob(t)
+	w := th.newWorker()
+
+	var progress []int64
+	for !w.doBatch(th.rctx, job) {
+		progress = append(progress, th.getJob(t, job.Id).Progress)
+		require.Less(t, len(progress), 100, "the job didn't finish")
+	}
+
+	assert.IsNonDecreasing(t, progress)
+	for _, p := range progress {
+		assert.Less(t, p, int64(100))
+	}
+	assert.EqualValues(t, 100, th.getJob(t, job.Id).Progress)
+	assert.Equal(t, "0", job.Data[JobDataPostsDeleted])
+}
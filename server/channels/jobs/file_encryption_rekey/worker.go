@@ -0,0 +1,137 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package file_encryption_rekey
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/jobs"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+	"github.com/mattermost/mattermost/server/v8/platform/shared/filestore"
+)
+
+const (
+	// Files are re-keyed one per batch, so that the job can be stopped
+	// between any two files.
+	timeBetweenBatches = 0
+
+	// pageSize is the number of paths listed at once.
+	pageSize = 100
+
+	jobDataLastPath  = "last_path"
+	jobDataProcessed = "processed"
+	jobDataRekeyed   = "rekeyed"
+	jobDataFailed    = "failed"
+)
+
+type AppIface interface {
+	FileBackend() filestore.FileBackend
+}
+
+// MakeWorker creates a worker that encrypts every file in the file store
+// that isn't yet, and re-wraps the data keys of the files that are encrypted
+// under a master key other than the active one. Files are processed in order
+// of their path, and the last one is recorded in the job data after each
+// file, so a job that's stopped resumes where it left off.
+func MakeWorker(jobServer *jobs.JobServer, store store.Store, app AppIface) *jobs.BatchWorker {
+	w := &worker{
+		jobServer: jobServer,
+		app:       app,
+	}
+	return jobs.MakeBatchWorker(jobServer, store, timeBetweenBatches, w.doBatch)
+}
+
+type worker struct {
+	jobServer *jobs.JobServer
+	app       AppIface
+
+	// The paths listed for the job and not yet processed. A worker only runs
+	// one job at a time.
+	pageJobId string
+	page      []string
+}
+
+func (w *worker) doBatch(rctx request.CTX, job *model.Job) bool {
+	logger := rctx.Logger()
+
+	fileBackend := w.app.FileBackend()
+	if fallback, ok := fileBackend.(*filestore.FallbackFileBackend); ok {
+		fileBackend = fallback.Unwrap()
+	}
+	backend, ok := fileBackend.(*filestore.EncryptedFileBackend)
+	if !ok {
+		w.setJobError(logger, job, model.NewAppError("doBatch", "jobs.file_encryption_rekey.not_enabled.app_error", nil, "", http.StatusBadRequest))
+		return true
+	}
+
+	lastPath := job.Data[jobDataLastPath]
+	if w.pageJobId != job.Id || len(w.page) == 0 {
+		page, err := filestore.ListDirectoryRecursivelyAfter(backend, "", lastPath, pageSize)
+		if err != nil {
+			w.setJobError(logger, job, model.NewAppError("doBatch", "jobs.file_encryption_rekey.list.app_error", nil, "", http.StatusInternalServerError).Wrap(err))
+			return true
+		}
+		w.pageJobId = job.Id
+		w.page = page
+	}
+	if len(w.page) == 0 {
+		return w.finish(logger, job)
+	}
+
+	path := w.page[0]
+	w.page = w.page[1:]
+	if !filestore.IsEncryptionTempFile(path) {
+		changed, err := backend.RekeyFile(path)
+		if err != nil {
+			logger.Warn("Failed to re-key file", mlog.String("path", path), mlog.Err(err))
+			increment(job, jobDataFailed)
+		} else if changed {
+			increment(job, jobDataRekeyed)
+		}
+		increment(job, jobDataProcessed)
+	}
+
+	job.Data[jobDataLastPath] = path
+	if appErr := w.jobServer.UpdateInProgressJobData(job); appErr != nil {
+		logger.Warn("Failed to update the job data", mlog.Err(appErr))
+	}
+	return false
+}
+
+func (w *worker) finish(logger mlog.LoggerIFace, job *model.Job) bool {
+	w.pageJobId = ""
+	w.page = nil
+
+	if failed, _ := strconv.Atoi(job.Data[jobDataFailed]); failed > 0 {
+		w.setJobError(logger, job, model.NewAppError("doBatch", "jobs.file_encryption_rekey.failures.app_error", map[string]any{"Count": failed}, "", http.StatusInternalServerError))
+		return true
+	}
+
+	if appErr := w.jobServer.SetJobProgress(job, 100); appErr != nil {
+		logger.Warn("Failed to update the job progress", mlog.Err(appErr))
+	}
+	if appErr := w.jobServer.SetJobSuccess(job); appErr != nil {
+		logger.Error("Failed to set the job as successful", mlog.Err(appErr))
+		w.setJobError(logger, job, appErr)
+	}
+	return true
+}
+
+func (w *worker) setJobError(logger mlog.LoggerIFace, job *model.Job, appErr *model.AppError) {
+	logger.Error("Failed to re-key files. Exiting", mlog.Err(appErr))
+	w.pageJobId = ""
+	w.page = nil
+	if err := w.jobServer.SetJobError(job, appErr); err != nil {
+		logger.Error("Failed to set the job error", mlog.Err(err))
+	}
+}
+
+func increment(job *model.Job, key string) {
+	value, _ := strconv.Atoi(job.Data[key])
+	job.Data[key] = strconv.Itoa(value + 1)
+}
@@ -0,0 +1,173 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package file_encryption_rekey
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/jobs"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+	"github.com/mattermost/mattermost/server/v8/channels/utils/testutils"
+	"github.com/mattermost/mattermost/server/v8/platform/shared/filestore"
+)
+
+type testApp struct {
+	backend filestore.FileBackend
+}
+
+func (a *testApp) FileBackend() filestore.FileBackend {
+	return a.backend
+}
+
+type testHelper struct {
+	rctx      request.CTX
+	store     store.Store
+	jobServer *jobs.JobServer
+	dir       string
+}
+
+func setup(t *testing.T) *testHelper {
+	ss := mainHelper.GetStore()
+	ss.DropAllTables()
+
+	cfg := &model.Config{}
+	cfg.SetDefaults()
+
+	return &testHelper{
+		rctx:      request.TestContext(t),
+		store:     ss,
+		jobServer: jobs.NewJobServer(&testutils.StaticConfigService{Cfg: cfg}, ss, nil, mlog.CreateConsoleTestLogger(t)),
+		dir:       t.TempDir(),
+	}
+}
+
+func newMasterKey(t *testing.T) string {
+	key := make([]byte, model.FileEncryptionMasterKeyLength)
+	_, err := rand.Read(key)
+	require.NoError(t, err)
+	return base64.StdEncoding.EncodeToString(key)
+}
+
+func (th *testHelper) newBackend(t *testing.T, masterKey string, previousMasterKeys ...string) *filestore.EncryptedFileBackend {
+	local, err := filestore.NewFileBackend(filestore.FileBackendSettings{DriverName: model.ImageDriverLocal, Directory: th.dir})
+	require.NoError(t, err)
+	keys, err := filestore.NewConfigKeyProvider(masterKey, previousMasterKeys)
+	require.NoError(t, err)
+	return filestore.NewEncryptedFileBackend(local, keys)
+}
+
+func (th *testHelper) writePlaintext(t *testing.T, path, data string) {
+	require.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(th.dir, path)), 0750))
+	require.NoError(t, os.WriteFile(filepath.Join(th.dir, path), []byte(data), 0600))
+}
+
+func (th *testHelper) readRaw(t *testing.T, path string) string {
+	raw, err := os.ReadFile(filepath.Join(th.dir, path))
+	require.NoError(t, err)
+	return string(raw)
+}
+
+func (th *testHelper) newJob(t *testing.T) *model.Job {
+	job, err := th.store.Job().Save(&model.Job{
+		Id:     model.NewId(),
+		Type:   model.JobTypeFileEncryptionRekey,
+		Status: model.JobStatusInProgress,
+		Data:   model.StringMap{},
+	})
+	require.NoError(t, err)
+	return job
+}
+
+func (th *testHelper) getJob(t *testing.T, id string) *model.Job {
+	job, err := th.store.Job().Get(th.rctx, id)
+	require.NoError(t, err)
+	return job
+}
+
+func TestWorker(t *testing.T) {
+	if testing.Short() {
+		t.SkipNow()
+	}
+
+	t.Run("files are encrypted and re-keyed, and the job resumes where it stopped", func(t *testing.T) {
+		th := setup(t)
+		oldKey := newMasterKey(t)
+		_, err := th.newBackend(t, oldKey).WriteFile(bytes.NewReader([]byte("old key")), "data/old.txt")
+		require.NoError(t, err)
+
+		var paths []string
+		for i := range pageSize + 5 {
+			path := fmt.Sprintf("data/%03d/file.txt", i)
+			th.writePlaintext(t, path, "plaintext "+strconv.Itoa(i))
+			paths = append(paths, path)
+		}
+		// Temporary files left behind by an interrupted re-key are skipped.
+		th.writePlaintext(t, "data/leftover.txt.rekey", "partial")
+
+		newKey := newMasterKey(t)
+		backend := th.newBackend(t, newKey, oldKey)
+		app := &testApp{backend: backend}
+		job := th.newJob(t)
+
+		// Stop after a few files, and pick the job up again with another worker,
+		// as after a restart.
+		w := &worker{jobServer: th.jobServer, app: app}
+		for range 3 {
+			require.False(t, w.doBatch(th.rctx, job))
+		}
+		stored := th.getJob(t, job.Id)
+		assert.Equal(t, "3", stored.Data[jobDataProcessed])
+		assert.Equal(t, "data/002/file.txt", stored.Data[jobDataLastPath])
+
+		w = &worker{jobServer: th.jobServer, app: app}
+		for range 2 * (pageSize + 10) {
+			if w.doBatch(th.rctx, stored) {
+				break
+			}
+		}
+
+		stored = th.getJob(t, job.Id)
+		assert.Equal(t, model.JobStatusSuccess, stored.Status)
+		assert.Equal(t, strconv.Itoa(len(paths)+1), stored.Data[jobDataProcessed])
+		assert.Equal(t, strconv.Itoa(len(paths)+1), stored.Data[jobDataRekeyed])
+		assert.Empty(t, stored.Data[jobDataFailed])
+
+		for i, path := range paths {
+			assert.NotContains(t, th.readRaw(t, path), "plaintext", path)
+			data, err := backend.ReadFile(path)
+			require.NoError(t, err)
+			assert.Equal(t, "plaintext "+strconv.Itoa(i), string(data))
+		}
+		assert.Equal(t, "partial", th.readRaw(t, "data/leftover.txt.rekey"))
+
+		// The file encrypted under the old key can now be read without it.
+		data, err := th.newBackend(t, newKey).ReadFile("data/old.txt")
+		require.NoError(t, err)
+		assert.Equal(t, "old key", string(data))
+	})
+
+	t.Run("encryption must be enabled", func(t *testing.T) {
+		th := setup(t)
+		local, err := filestore.NewFileBackend(filestore.FileBackendSettings{DriverName: model.ImageDriverLocal, Directory: th.dir})
+		require.NoError(t, err)
+		job := th.newJob(t)
+
+		w := &worker{jobServer: th.jobServer, app: &testApp{backend: local}}
+		require.True(t, w.doBatch(th.rctx, job))
+		assert.Equal(t, model.JobStatusError, th.getJob(t, job.Id).Status)
+	})
+}
@@ -0,0 +1,135 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package file_storage_migration
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/mattermost/mattermost/server/v8/platform/shared/filestore"
+)
+
+type copyResult int
+
+const (
+	copyResultCopied copyResult = iota
+	// copyResultSkipped is returned for files that the destination already
+	// has, which is the case for all files copied before the job was resumed.
+	copyResultSkipped
+	// copyResultMissing is returned for files that are referenced but that the
+	// source doesn't have either.
+	copyResultMissing
+)
+
+// copyFile copies path from source to destination, verifying that the SHA-256
+// checksum of the copy matches the one of the original. Files that already
+// exist in the destination with the same size are skipped.
+func copyFile(source, destination filestore.FileBackend, path string, throttle *throttle) (copyResult, int64, error) {
+	exists, err := source.FileExists(path)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to check whether the file exists: %w", err)
+	} else if !exists {
+		return copyResultMissing, 0, nil
+	}
+
+	size, err := source.FileSize(path)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to get the file size: %w", err)
+	}
+
+	if exists, err = destination.FileExists(path); err != nil {
+		return 0, 0, fmt.Errorf("failed to check whether the file was already copied: %w", err)
+	} else if exists {
+		if copiedSize, sizeErr := destination.FileSize(path); sizeErr == nil && copiedSize == size {
+			return copyResultSkipped, 0, nil
+		}
+	}
+
+	r, err := source.Reader(path)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to open the file: %w", err)
+	}
+	defer r.Close()
+
+	hash := sha256.New()
+	// The write isn't bound to a timeout, as large files can take a while to
+	// copy, all the more so when throttled.
+	written, err := filestore.TryWriteFileContext(context.Background(), destination, io.TeeReader(throttle.reader(r), hash), path)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to write the file: %w", err)
+	}
+
+	copied, err := destination.Reader(path)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to open the copied file: %w", err)
+	}
+	defer copied.Close()
+
+	copiedHash := sha256.New()
+	if _, err := io.Copy(copiedHash, copied); err != nil {
+		return 0, 0, fmt.Errorf("failed to read the copied file: %w", err)
+	}
+
+	if !bytes.Equal(hash.Sum(nil), copiedHash.Sum(nil)) {
+		if err := destination.RemoveFile(path); err != nil {
+			return 0, 0, fmt.Errorf("checksum mismatch, and failed to remove the copied file: %w", err)
+		}
+		return 0, 0, fmt.Errorf("checksum mismatch")
+	}
+
+	return copyResultCopied, written, nil
+}
+
+// throttle limits the rate at which files are read from the source backend.
+// A nil throttle doesn't limit anything.
+type throttle struct {
+	bytesPerSecond int64
+	start          time.Time
+	bytes          int64
+}
+
+func newThrottle(bytesPerSecond int64) *throttle {
+	if bytesPerSecond <= 0 {
+		return nil
+	}
+	return &throttle{
+		bytesPerSecond: bytesPerSecond,
+		start:          time.Now(),
+	}
+}
+
+func (t *throttle) reader(r io.Reader) io.Reader {
+	if t == nil {
+		return r
+	}
+	return &throttledReader{r: r, throttle: t}
+}
+
+// wait blocks until n more bytes can be read without going over the limit.
+func (t *throttle) wait(n int) {
+	t.bytes += int64(n)
+	expected := time.Duration(float64(t.bytes) / float64(t.bytesPerSecond) * float64(time.Second))
+	if elapsed := time.Since(t.start); expected > elapsed {
+		time.Sleep(expected - elapsed)
+	}
+}
+
+type throttledReader struct {
+	r        io.Reader
+	throttle *throttle
+}
+
+func (tr *throttledReader) Read(p []byte) (int, error) {
+	// Read at most a second's worth of data at once to keep the rate smooth.
+	if int64(len(p)) > tr.throttle.bytesPerSecond {
+		p = p[:tr.throttle.bytesPerSecond]
+	}
+	n, err := tr.r.Read(p)
+	tr.throttle.wait(n)
+	return n, err
+}
@@ -0,0 +1,86 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package file_storage_migration
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/v8/platform/shared/filestore"
+)
+
+func newLocalBackend(t *testing.T) (filestore.FileBackend, string) {
+	dir := t.TempDir()
+	backend, err := filestore.NewFileBackend(filestore.FileBackendSettings{
+		DriverName: "local",
+		Directory:  dir,
+	})
+	require.NoError(t, err)
+	return backend, dir
+}
+
+func TestCopyFile(t *testing.T) {
+	source, _ := newLocalBackend(t)
+	destination, destinationDir := newLocalBackend(t)
+
+	data := bytes.Repeat([]byte("attachment"), 100)
+	_, err := source.WriteFile(bytes.NewReader(data), "data/file.txt")
+	require.NoError(t, err)
+
+	t.Run("copies and verifies the file", func(t *testing.T) {
+		result, written, err := copyFile(source, destination, "data/file.txt", nil)
+		require.NoError(t, err)
+		assert.Equal(t, copyResultCopied, result)
+		assert.EqualValues(t, len(data), written)
+
+		copied, err := destination.ReadFile("data/file.txt")
+		require.NoError(t, err)
+		assert.Equal(t, data, copied)
+	})
+
+	t.Run("skips files already copied", func(t *testing.T) {
+		result, _, err := copyFile(source, destination, "data/file.txt", nil)
+		require.NoError(t, err)
+		assert.Equal(t, copyResultSkipped, result)
+	})
+
+	t.Run("copies again files partially copied", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(filepath.Join(destinationDir, "data/file.txt"), data[:10], 0600))
+
+		result, _, err := copyFile(source, destination, "data/file.txt", nil)
+		require.NoError(t, err)
+		assert.Equal(t, copyResultCopied, result)
+
+		copied, err := destination.ReadFile("data/file.txt")
+		require.NoError(t, err)
+		assert.Equal(t, data, copied)
+	})
+
+	t.Run("reports missing files", func(t *testing.T) {
+		result, _, err := copyFile(source, destination, "data/missing.txt", nil)
+		require.NoError(t, err)
+		assert.Equal(t, copyResultMissing, result)
+	})
+}
+
+func TestThrottle(t *testing.T) {
+	source, _ := newLocalBackend(t)
+	destination, _ := newLocalBackend(t)
+
+	_, err := source.WriteFile(bytes.NewReader(make([]byte, 3000)), "file.bin")
+	require.NoError(t, err)
+
+	start := time.Now()
+	_, _, err = copyFile(source, destination, "file.bin", newThrottle(10000))
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, time.Since(start), 250*time.Millisecond)
+
+	assert.Nil(t, newThrottle(0))
+}
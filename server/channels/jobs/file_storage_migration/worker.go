@@ -0,0 +1,325 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package file_storage_migration
+
+import (
+	"fmt"
+	"net/http"
+	"path"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/jobs"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+	"github.com/mattermost/mattermost/server/v8/platform/shared/filestore"
+)
+
+const (
+	timeBetweenBatches = 100 * time.Millisecond
+	batchSize          = 100
+
+	// pluginsFolder is where the bundles of the installed plugins are kept.
+	pluginsFolder = "plugins"
+	// teamsFolder is where the team icons are kept, as teams/<id>/teamIcon.png.
+	teamsFolder = "teams"
+	// botsFolder is where the bot icons are kept, as bots/<id>/icon.svg.
+	botsFolder = "bots"
+	// brandFolder is where the custom brand image is kept.
+	brandFolder = "brand"
+
+	// JobDataMaxBytesPerSecond can be set when creating the job to throttle
+	// the rate at which files are read from the source storage.
+	JobDataMaxBytesPerSecond = "max_bytes_per_second"
+
+	jobDataPhase            = "phase"
+	jobDataFileInfoCreateAt = "file_info_create_at"
+	jobDataFileInfoID       = "file_info_id"
+	jobDataEmojiCreateAt    = "emoji_create_at"
+	jobDataEmojiID          = "emoji_id"
+	jobDataUserID           = "user_id"
+	jobDataLastPath         = "last_path"
+	jobDataCopied           = "copied"
+	jobDataSkipped          = "skipped"
+	jobDataMissing          = "missing"
+	jobDataFailed           = "failed"
+	jobDataBytes            = "bytes"
+)
+
+const (
+	phaseFileInfos     = "file_infos"
+	phaseEmojis        = "emojis"
+	phaseProfileImages = "profile_images"
+	phaseTeamIcons     = "team_icons"
+	phaseBotIcons      = "bot_icons"
+	phaseBrand         = "brand"
+	phasePlugins       = "plugins"
+	phaseExports       = "exports"
+	phaseDone          = "done"
+)
+
+// phases are the kinds of files copied by the job, in order.
+var phases = []string{phaseFileInfos, phaseEmojis, phaseProfileImages, phaseTeamIcons, phaseBotIcons, phaseBrand, phasePlugins, phaseExports}
+
+type AppIface interface {
+	Config() *model.Config
+	FileStorageMigrationBackends() (source filestore.FileBackend, destination filestore.FileBackend, appErr *model.AppError)
+}
+
+// MakeWorker creates a worker that copies every file referenced by the
+// server, that is file attachments along with their thumbnails and previews,
+// custom emoji, profile images, team and bot icons, the brand image, plugin
+// bundles and exports, from the storage
+// in use to the storage migration target. Copies are verified against the
+// checksum of the original, and files already present in the destination are
+// skipped, so the job can be resumed or run again to pick up new files.
+func MakeWorker(jobServer *jobs.JobServer, store store.Store, app AppIface) *jobs.BatchWorker {
+	w := &worker{
+		jobServer: jobServer,
+		store:     store,
+		app:       app,
+	}
+	return jobs.MakeBatchWorker(jobServer, store, timeBetweenBatches, w.doBatch)
+}
+
+type worker struct {
+	jobServer *jobs.JobServer
+	store     store.Store
+	app       AppIface
+
+	// The migration of the job being run, kept across its batches so that the
+	// backends are only built once. A worker only runs one job at a time.
+	migration *migration
+}
+
+// migration holds the state of a run of the job.
+type migration struct {
+	job         *model.Job
+	logger      mlog.LoggerIFace
+	source      filestore.FileBackend
+	destination filestore.FileBackend
+	throttle    *throttle
+}
+
+func (w *worker) doBatch(rctx request.CTX, job *model.Job) bool {
+	logger := rctx.Logger()
+
+	m := w.migration
+	if m == nil || m.job.Id != job.Id {
+		source, destination, appErr := w.app.FileStorageMigrationBackends()
+		if appErr != nil {
+			w.setJobError(logger, job, appErr)
+			return true
+		}
+
+		maxBytesPerSecond, _ := strconv.ParseInt(job.Data[JobDataMaxBytesPerSecond], 10, 64)
+		m = &migration{
+			source:      source,
+			destination: destination,
+			throttle:    newThrottle(maxBytesPerSecond),
+		}
+		w.migration = m
+	}
+	m.job = job
+	m.logger = logger
+
+	phase := job.Data[jobDataPhase]
+	if phase == "" {
+		phase = phaseFileInfos
+	}
+
+	var done bool
+	var err error
+	switch phase {
+	case phaseFileInfos:
+		done, err = w.copyFileInfos(m)
+	case phaseEmojis:
+		done, err = w.copyEmojis(m)
+	case phaseProfileImages:
+		done, err = w.copyProfileImages(m)
+	case phaseTeamIcons:
+		done, err = m.copyDirectory(teamsFolder)
+	case phaseBotIcons:
+		done, err = m.copyDirectory(botsFolder)
+	case phaseBrand:
+		done, err = m.copyDirectory(brandFolder)
+	case phasePlugins:
+		done, err = m.copyDirectory(pluginsFolder)
+	case phaseExports:
+		done, err = m.copyExports(w.app.Config())
+	case phaseDone:
+		return w.finish(logger, job)
+	default:
+		err = fmt.Errorf("unknown phase %q", phase)
+	}
+	if err != nil {
+		w.setJobError(logger, job, model.NewAppError("doBatch", model.NoTranslation, nil, "", http.StatusInternalServerError).Wrap(err))
+		return true
+	}
+
+	index := slices.Index(phases, phase)
+	if done {
+		logger.Info("Finished copying files", mlog.String("phase", phase))
+		if index == len(phases)-1 {
+			return w.finish(logger, job)
+		}
+		job.Data[jobDataPhase] = phases[index+1]
+		job.Data[jobDataLastPath] = ""
+	} else {
+		job.Data[jobDataPhase] = phase
+	}
+
+	if appErr := w.jobServer.SetJobProgress(job, int64(index*100/len(phases))); appErr != nil {
+		logger.Warn("Failed to update the job progress", mlog.Err(appErr))
+	}
+	return false
+}
+
+func (w *worker) finish(logger mlog.LoggerIFace, job *model.Job) bool {
+	w.migration = nil
+	job.Data[jobDataPhase] = phaseDone
+
+	if failed, _ := strconv.Atoi(job.Data[jobDataFailed]); failed > 0 {
+		w.setJobError(logger, job, model.NewAppError("doBatch", "jobs.file_storage_migration.failures.app_error", map[string]any{"Count": failed}, "", http.StatusInternalServerError))
+		return true
+	}
+
+	if appErr := w.jobServer.SetJobProgress(job, 100); appErr != nil {
+		logger.Warn("Failed to update the job progress", mlog.Err(appErr))
+	}
+	if appErr := w.jobServer.SetJobSuccess(job); appErr != nil {
+		logger.Error("Failed to set the job as successful", mlog.Err(appErr))
+		w.setJobError(logger, job, appErr)
+	}
+	return true
+}
+
+func (w *worker) setJobError(logger mlog.LoggerIFace, job *model.Job, appErr *model.AppError) {
+	logger.Error("Failed to migrate files. Exiting", mlog.Err(appErr))
+	w.migration = nil
+	if err := w.jobServer.SetJobError(job, appErr); err != nil {
+		logger.Error("Failed to set the job error", mlog.Err(err))
+	}
+}
+
+// copyFileInfos copies the next batch of file attachments, thumbnails and
+// previews, including those of deleted files which can still be restored.
+func (w *worker) copyFileInfos(m *migration) (bool, error) {
+	createAt, _ := strconv.ParseInt(m.job.Data[jobDataFileInfoCreateAt], 10, 64)
+	fileInfos, err := w.store.FileInfo().GetFilesBatchForIndexing(createAt, m.job.Data[jobDataFileInfoID], true, batchSize)
+	if err != nil {
+		return false, fmt.Errorf("failed to get file infos: %w", err)
+	}
+
+	for _, fileInfo := range fileInfos {
+		for _, path := range []string{fileInfo.Path, fileInfo.ThumbnailPath, fileInfo.PreviewPath} {
+			if path != "" {
+				m.copyFile(path)
+			}
+		}
+	}
+
+	if len(fileInfos) > 0 {
+		last := fileInfos[len(fileInfos)-1]
+		m.job.Data[jobDataFileInfoCreateAt] = strconv.FormatInt(last.CreateAt, 10)
+		m.job.Data[jobDataFileInfoID] = last.Id
+	}
+	return len(fileInfos) < batchSize, nil
+}
+
+// copyEmojis copies the next batch of custom emoji, in order of creation so
+// that emoji added or deleted during the job don't shift the others.
+func (w *worker) copyEmojis(m *migration) (bool, error) {
+	createAt, _ := strconv.ParseInt(m.job.Data[jobDataEmojiCreateAt], 10, 64)
+	emojis, err := w.store.Emoji().GetAllAfter(createAt, m.job.Data[jobDataEmojiID], batchSize)
+	if err != nil {
+		return false, fmt.Errorf("failed to get emojis: %w", err)
+	}
+
+	for _, emoji := range emojis {
+		m.copyFile(path.Join("emoji", emoji.Id, "image"))
+	}
+
+	if len(emojis) > 0 {
+		last := emojis[len(emojis)-1]
+		m.job.Data[jobDataEmojiCreateAt] = strconv.FormatInt(last.CreateAt, 10)
+		m.job.Data[jobDataEmojiID] = last.Id
+	}
+	return len(emojis) < batchSize, nil
+}
+
+func (w *worker) copyProfileImages(m *migration) (bool, error) {
+	users, err := w.store.User().GetAllAfter(batchSize, m.job.Data[jobDataUserID])
+	if err != nil {
+		return false, fmt.Errorf("failed to get users: %w", err)
+	}
+
+	for _, user := range users {
+		// Users whose profile image was never generated have nothing stored,
+		// which is counted as missing.
+		m.copyFile(path.Join("users", user.Id, "profile.png"))
+	}
+
+	if len(users) > 0 {
+		m.job.Data[jobDataUserID] = users[len(users)-1].Id
+	}
+	return len(users) < batchSize, nil
+}
+
+// copyExports copies the exports, unless they're kept in a dedicated store
+// that isn't affected by the migration.
+func (m *migration) copyExports(cfg *model.Config) (bool, error) {
+	if *cfg.FileSettings.DedicatedExportStore {
+		return true, nil
+	}
+	return m.copyDirectory(*cfg.ExportSettings.Directory)
+}
+
+// copyDirectory copies the next batch of files under dir, in order of their
+// path.
+func (m *migration) copyDirectory(dir string) (bool, error) {
+	paths, err := filestore.ListDirectoryRecursivelyAfter(m.source, dir, m.job.Data[jobDataLastPath], batchSize)
+	if err != nil {
+		return false, fmt.Errorf("failed to list the directory %s: %w", dir, err)
+	}
+
+	for _, path := range paths {
+		m.copyFile(path)
+	}
+
+	if len(paths) > 0 {
+		m.job.Data[jobDataLastPath] = paths[len(paths)-1]
+	}
+	return len(paths) < batchSize, nil
+}
+
+// copyFile copies a single file and updates the counters of the job. Failures
+// are logged and counted rather than interrupting the job, which fails at the
+// end if there were any.
+func (m *migration) copyFile(path string) {
+	result, written, err := copyFile(m.source, m.destination, path, m.throttle)
+	if err != nil {
+		m.logger.Warn("Failed to copy file", mlog.String("path", path), mlog.Err(err))
+		m.increment(jobDataFailed, 1)
+		return
+	}
+
+	switch result {
+	case copyResultCopied:
+		m.increment(jobDataCopied, 1)
+		m.increment(jobDataBytes, written)
+	case copyResultSkipped:
+		m.increment(jobDataSkipped, 1)
+	case copyResultMissing:
+		m.increment(jobDataMissing, 1)
+	}
+}
+
+func (m *migration) increment(key string, n int64) {
+	value, _ := strconv.ParseInt(m.job.Data[key], 10, 64)
+	m.job.Data[key] = strconv.FormatInt(value+n, 10)
+}
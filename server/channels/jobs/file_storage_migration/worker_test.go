@@ -0,0 +1,154 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package file_storage_migration
+
+import (
+	"fmt"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/jobs"
+	"github.com/mattermost/mattermost/server/v8/channels/utils/testutils"
+	"github.com/mattermost/mattermost/server/v8/platform/shared/filestore"
+)
+
+type testApp struct {
+	cfg         *model.Config
+	source      filestore.FileBackend
+	destination filestore.FileBackend
+	calls       int
+}
+
+func (a *testApp) Config() *model.Config {
+	return a.cfg
+}
+
+func (a *testApp) FileStorageMigrationBackends() (filestore.FileBackend, filestore.FileBackend, *model.AppError) {
+	a.calls++
+	return a.source, a.destination, nil
+}
+
+func TestWorker(t *testing.T) {
+	if testing.Short() {
+		t.SkipNow()
+	}
+
+	ss := mainHelper.GetStore()
+	ss.DropAllTables()
+	rctx := request.TestContext(t)
+
+	cfg := &model.Config{}
+	cfg.SetDefaults()
+	cfg.ExportSettings.Directory = model.NewPointer("export")
+	jobServer := jobs.NewJobServer(&testutils.StaticConfigService{Cfg: cfg}, ss, nil, mlog.CreateConsoleTestLogger(t))
+
+	source, _ := newLocalBackend(t)
+	destination, _ := newLocalBackend(t)
+	app := &testApp{cfg: cfg, source: source, destination: destination}
+
+	// One file of each kind the server keeps.
+	fileInfo, err := ss.FileInfo().Save(rctx, &model.FileInfo{
+		Id:        model.NewId(),
+		CreatorId: model.NewId(),
+		Path:      "data/attachment.txt",
+		Name:      "attachment.txt",
+	})
+	require.NoError(t, err)
+	emoji, err := ss.Emoji().Save(&model.Emoji{CreatorId: model.NewId(), Name: "emoji" + model.NewId()[:8]})
+	require.NoError(t, err)
+	user, err := ss.User().Save(rctx, &model.User{Email: model.NewId() + "@example.com", Username: "user" + model.NewId()[:8]})
+	require.NoError(t, err)
+
+	paths := []string{
+		fileInfo.Path,
+		path.Join("emoji", emoji.Id, "image"),
+		path.Join("users", user.Id, "profile.png"),
+		path.Join(teamsFolder, model.NewId(), "teamIcon.png"),
+		path.Join(botsFolder, model.NewId(), "icon.svg"),
+		path.Join(brandFolder, "image.png"),
+		path.Join(pluginsFolder, "com.example.plugin.tar.gz"),
+		path.Join("export", "export.zip"),
+	}
+	for _, p := range paths {
+		_, err = source.WriteFile(strings.NewReader(p), p)
+		require.NoError(t, err)
+	}
+
+	job, err := ss.Job().Save(&model.Job{
+		Id:     model.NewId(),
+		Type:   model.JobTypeFileStorageMigration,
+		Status: model.JobStatusInProgress,
+		Data:   model.StringMap{},
+	})
+	require.NoError(t, err)
+
+	w := &worker{jobServer: jobServer, store: ss, app: app}
+	for i := 0; !w.doBatch(rctx, job); i++ {
+		require.Less(t, i, 2*len(phases), "the job should be done")
+	}
+
+	stored, err := ss.Job().Get(rctx, job.Id)
+	require.NoError(t, err)
+	assert.Equal(t, model.JobStatusSuccess, stored.Status)
+	assert.Equal(t, fmt.Sprint(len(paths)), stored.Data[jobDataCopied])
+	assert.Equal(t, 1, app.calls, "the backends should be built once per run")
+
+	// After the cutover, the destination serves every file on its own.
+	for _, p := range paths {
+		data, err := destination.ReadFile(p)
+		require.NoError(t, err, p)
+		assert.Equal(t, p, string(data))
+	}
+}
+
+func TestCopyDirectory(t *testing.T) {
+	source, _ := newLocalBackend(t)
+	destination, _ := newLocalBackend(t)
+
+	const count = batchSize + batchSize/2
+	for i := range count {
+		_, err := source.WriteFile(strings.NewReader("bundle"), fmt.Sprintf("plugins/%d/plugin%03d.tar.gz", i%3, i))
+		require.NoError(t, err)
+	}
+	_, err := source.WriteFile(strings.NewReader("attachment"), "data/file.txt")
+	require.NoError(t, err)
+
+	job := &model.Job{Id: model.NewId(), Data: model.StringMap{}}
+	newMigration := func() *migration {
+		return &migration{
+			job:         job,
+			logger:      mlog.CreateConsoleTestLogger(t),
+			source:      source,
+			destination: destination,
+		}
+	}
+
+	done, err := newMigration().copyDirectory(pluginsFolder)
+	require.NoError(t, err)
+	assert.False(t, done)
+	assert.Equal(t, fmt.Sprint(batchSize), job.Data[jobDataCopied])
+	assert.True(t, strings.HasPrefix(job.Data[jobDataLastPath], "plugins/1/"), job.Data[jobDataLastPath])
+
+	// A new run of the job picks up after the last path copied.
+	done, err = newMigration().copyDirectory(pluginsFolder)
+	require.NoError(t, err)
+	assert.True(t, done)
+	assert.Equal(t, fmt.Sprint(count), job.Data[jobDataCopied])
+	assert.Empty(t, job.Data[jobDataSkipped])
+
+	copied, err := destination.ListDirectoryRecursively(pluginsFolder)
+	require.NoError(t, err)
+	assert.Len(t, copied, count)
+
+	exists, err := destination.FileExists("data/file.txt")
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
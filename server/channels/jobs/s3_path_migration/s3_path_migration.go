@@ -35,8 +35,9 @@ type S3PathMigrationWorker struct {
 
 func MakeWorker(jobServer *jobs.JobServer, store store.Store, fileBackend filestore.FileBackend) *S3PathMigrationWorker {
 	// If the type cast fails, it will be nil
-	// which is checked later.
-	s3Backend, _ := fileBackend.(*filestore.S3FileBackend)
+	// which is checked later. The files are moved within the bucket, so
+	// encrypted ones stay readable, as their keys aren't bound to their path.
+	s3Backend, _ := filestore.UnwrapFileBackend(fileBackend).(*filestore.S3FileBackend)
 	const workerName = "S3PathMigration"
 	worker := &S3PathMigrationWorker{
 		name:        workerName,
@@ -17,7 +17,8 @@ type contextValue string
 
 // Different possible values of contextValue.
 const (
-	useMaster contextValue = "useMaster"
+	useMaster      contextValue = "useMaster"
+	readYourWrites contextValue = "readYourWrites"
 )
 
 // WithMaster adds the context value that master DB should be selected for this request.
@@ -44,3 +45,21 @@ func HasMaster(ctx context.Context) bool {
 	}
 	return false
 }
+
+// RequestContextWithReadYourWrites adds the context values that master DB should be selected for
+// this request because its caller wrote recently, so that it reads its own writes.
+func RequestContextWithReadYourWrites(rctx request.CTX) request.CTX {
+	ctx := context.WithValue(WithMaster(rctx.Context()), storeContextKey(readYourWrites), true)
+	return rctx.WithContext(ctx)
+}
+
+// HasReadYourWrites is a helper function to check whether master DB is selected because the
+// caller wrote recently.
+func HasReadYourWrites(ctx context.Context) bool {
+	if v := ctx.Value(storeContextKey(readYourWrites)); v != nil {
+		if res, ok := v.(bool); ok && res {
+			return true
+		}
+	}
+	return false
+}
@@ -53,3 +53,14 @@ func TestRequestContextWithMaster(t *testing.T) {
 		assert.False(t, HasMaster(rctxCopy.Context()))
 	})
 }
+
+func TestRequestContextWithReadYourWrites(t *testing.T) {
+	var rctx request.CTX = request.TestContext(t)
+	assert.False(t, HasReadYourWrites(rctx.Context()))
+
+	rctx = RequestContextWithReadYourWrites(rctx)
+	assert.True(t, HasMaster(rctx.Context()))
+	assert.True(t, HasReadYourWrites(rctx.Context()))
+
+	assert.False(t, HasReadYourWrites(RequestContextWithMaster(request.TestContext(t)).Context()))
+}
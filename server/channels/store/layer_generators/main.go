@@ -34,6 +34,9 @@ func main() {
 	if err := buildRetryLayer(); err != nil {
 		log.Fatal(err)
 	}
+	if err := buildTracingLayer(); err != nil {
+		log.Fatal(err)
+	}
 }
 
 func buildRetryLayer() error {
@@ -62,6 +65,19 @@ func buildTimerLayer() error {
 	return os.WriteFile(path.Join("timerlayer", "timerlayer.go"), formatedCode, 0644)
 }
 
+func buildTracingLayer() error {
+	code, err := generateLayer("TracingLayer", "tracing_layer.go.tmpl")
+	if err != nil {
+		return err
+	}
+	formatedCode, err := format.Source(code)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path.Join("tracinglayer", "tracinglayer.go"), formatedCode, 0644)
+}
+
 type methodParam struct {
 	Name string
 	Type string
@@ -226,6 +242,17 @@ func generateLayer(name, templateFile string) ([]byte, error) {
 			}
 			return ""
 		},
+		"spanContext": func(params []methodParam) string {
+			for _, param := range params {
+				switch param.Type {
+				case "request.CTX":
+					return fmt.Sprintf("requestContext(%s)", param.Name)
+				case "context.Context":
+					return fmt.Sprintf("parentContext(%s)", param.Name)
+				}
+			}
+			return "context.Background()"
+		},
 		"joinParams": func(params []methodParam) string {
 			paramsNames := make([]string, 0, len(params))
 			for _, param := range params {
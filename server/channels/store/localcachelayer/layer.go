@@ -75,6 +75,9 @@ const (
 	UserAutoTranslationCacheSec  = 15 * 60
 
 	ContentFlaggingCacheSize = 100
+
+	WorkingHoursCacheSize = model.SessionCacheSize
+	WorkingHoursCacheSec  = 30 * 60
 )
 
 var clearCacheMessageData = []byte("")
@@ -135,6 +138,9 @@ type LocalCacheStore struct {
 	userAutoTranslationCache cache.Cache
 	contentFlagging          LocalCacheContentFlaggingStore
 	contentFlaggingCache     cache.Cache
+
+	workingHours      LocalCacheWorkingHoursStore
+	workingHoursCache cache.Cache
 }
 
 func NewLocalCacheLayer(baseStore store.Store, metrics einterfaces.MetricsInterface, cluster einterfaces.ClusterInterface, cacheProvider cache.Provider, logger mlog.LoggerIFace) (localCacheStore LocalCacheStore, err error) {
@@ -396,6 +402,17 @@ func NewLocalCacheLayer(baseStore store.Store, metrics einterfaces.MetricsInterf
 	}
 	localCacheStore.contentFlagging = LocalCacheContentFlaggingStore{ContentFlaggingStore: baseStore.ContentFlagging(), rootStore: &localCacheStore}
 
+	// Working hours
+	if localCacheStore.workingHoursCache, err = cacheProvider.NewCache(&cache.CacheOptions{
+		Size:                   WorkingHoursCacheSize,
+		Name:                   "WorkingHours",
+		DefaultExpiry:          WorkingHoursCacheSec * time.Second,
+		InvalidateClusterEvent: model.ClusterEventInvalidateCacheForWorkingHours,
+	}); err != nil {
+		return
+	}
+	localCacheStore.workingHours = LocalCacheWorkingHoursStore{WorkingHoursStore: baseStore.WorkingHours(), rootStore: &localCacheStore}
+
 	if cluster != nil {
 		cluster.RegisterClusterMessageHandler(model.ClusterEventInvalidateCacheForReactions, localCacheStore.reaction.handleClusterInvalidateReaction)
 		cluster.RegisterClusterMessageHandler(model.ClusterEventInvalidateCacheForRoles, localCacheStore.role.handleClusterInvalidateRole)
@@ -422,6 +439,7 @@ func NewLocalCacheLayer(baseStore store.Store, metrics einterfaces.MetricsInterf
 		cluster.RegisterClusterMessageHandler(model.ClusterEventInvalidateCacheForTeams, localCacheStore.team.handleClusterInvalidateTeam)
 		cluster.RegisterClusterMessageHandler(model.ClusterEventInvalidateCacheForUserAutoTranslation, localCacheStore.autotranslation.handleClusterInvalidateUserAutoTranslation)
 		cluster.RegisterClusterMessageHandler(model.ClusterEventInvalidateCacheForContentFlagging, localCacheStore.contentFlagging.handleClusterInvalidateContentFlagging)
+		cluster.RegisterClusterMessageHandler(model.ClusterEventInvalidateCacheForWorkingHours, localCacheStore.workingHours.handleClusterInvalidateWorkingHours)
 	}
 	return
 }
@@ -478,6 +496,10 @@ func (s LocalCacheStore) ContentFlagging() store.ContentFlaggingStore {
 	return s.contentFlagging
 }
 
+func (s LocalCacheStore) WorkingHours() store.WorkingHoursStore {
+	return s.workingHours
+}
+
 func (s LocalCacheStore) DropAllTables() {
 	s.Invalidate()
 	s.Store.DropAllTables()
@@ -612,6 +634,7 @@ func (s *LocalCacheStore) Invalidate() {
 	s.doClearCacheCluster(s.teamAllTeamIdsForUserCache)
 	s.doClearCacheCluster(s.rolePermissionsCache)
 	s.doClearCacheCluster(s.userAutoTranslationCache)
+	s.doClearCacheCluster(s.workingHoursCache)
 }
 
 // allocateCacheTargets is used to fill target value types
@@ -194,6 +194,14 @@ func getMockStore(t *testing.T) *mocks.Store {
 	mockContentFlaggingStore := mocks.ContentFlaggingStore{}
 	mockStore.On("ContentFlagging").Return(&mockContentFlaggingStore)
 
+	fakeWorkingHours := model.WorkingHours{UserId: "123", Enabled: true, Timezone: "UTC", QuietStatus: model.StatusDnd}
+	mockWorkingHoursStore := mocks.WorkingHoursStore{}
+	mockWorkingHoursStore.On("Get", "123").Return(&fakeWorkingHours, nil)
+	mockWorkingHoursStore.On("Get", "456").Return(nil, store.NewErrNotFound("WorkingHours", "456"))
+	mockWorkingHoursStore.On("Save", &fakeWorkingHours).Return(&fakeWorkingHours, nil)
+	mockWorkingHoursStore.On("SetInQuietHours", "123", true, true).Return(nil)
+	mockStore.On("WorkingHours").Return(&mockWorkingHoursStore)
+
 	return &mockStore
 }
 
@@ -0,0 +1,94 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package localcachelayer
+
+import (
+	"bytes"
+	"errors"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+// LocalCacheWorkingHoursStore caches the working hours of users, which are looked up for each
+// recipient of a notification. As most users have none, their absence is cached too, as working
+// hours without a user id.
+type LocalCacheWorkingHoursStore struct {
+	store.WorkingHoursStore
+	rootStore *LocalCacheStore
+}
+
+func (s *LocalCacheWorkingHoursStore) handleClusterInvalidateWorkingHours(msg *model.ClusterMessage) {
+	if bytes.Equal(msg.Data, clearCacheMessageData) {
+		s.rootStore.workingHoursCache.Purge()
+	} else {
+		s.rootStore.workingHoursCache.Remove(string(msg.Data))
+	}
+}
+
+func (s LocalCacheWorkingHoursStore) ClearCaches() {
+	s.rootStore.doClearCacheCluster(s.rootStore.workingHoursCache)
+
+	if s.rootStore.metrics != nil {
+		s.rootStore.metrics.IncrementMemCacheInvalidationCounter(s.rootStore.workingHoursCache.Name())
+	}
+}
+
+func (s LocalCacheWorkingHoursStore) invalidateWorkingHours(userID string) {
+	s.rootStore.doInvalidateCacheCluster(s.rootStore.workingHoursCache, userID, nil)
+
+	if s.rootStore.metrics != nil {
+		s.rootStore.metrics.IncrementMemCacheInvalidationCounter(s.rootStore.workingHoursCache.Name())
+	}
+}
+
+func (s LocalCacheWorkingHoursStore) Save(workingHours *model.WorkingHours) (*model.WorkingHours, error) {
+	saved, err := s.WorkingHoursStore.Save(workingHours)
+	if err != nil {
+		return nil, err
+	}
+
+	s.invalidateWorkingHours(saved.UserId)
+	return saved, nil
+}
+
+func (s LocalCacheWorkingHoursStore) Get(userID string) (*model.WorkingHours, error) {
+	var workingHours *model.WorkingHours
+	if err := s.rootStore.doStandardReadCache(s.rootStore.workingHoursCache, userID, &workingHours); err == nil {
+		if workingHours.UserId == "" {
+			return nil, store.NewErrNotFound("WorkingHours", userID)
+		}
+		return workingHours, nil
+	}
+
+	workingHours, err := s.WorkingHoursStore.Get(userID)
+	if err != nil {
+		var nfErr *store.ErrNotFound
+		if errors.As(err, &nfErr) {
+			s.rootStore.doStandardAddToCache(s.rootStore.workingHoursCache, userID, &model.WorkingHours{})
+		}
+		return nil, err
+	}
+
+	s.rootStore.doStandardAddToCache(s.rootStore.workingHoursCache, userID, workingHours)
+	return workingHours, nil
+}
+
+func (s LocalCacheWorkingHoursStore) SetInQuietHours(userID string, inQuietHours, quietStatusSet bool) error {
+	if err := s.WorkingHoursStore.SetInQuietHours(userID, inQuietHours, quietStatusSet); err != nil {
+		return err
+	}
+
+	s.invalidateWorkingHours(userID)
+	return nil
+}
+
+func (s LocalCacheWorkingHoursStore) PermanentDeleteByUser(userID string) error {
+	if err := s.WorkingHoursStore.PermanentDeleteByUser(userID); err != nil {
+		return err
+	}
+
+	s.invalidateWorkingHours(userID)
+	return nil
+}
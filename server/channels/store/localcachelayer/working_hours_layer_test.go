@@ -0,0 +1,85 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package localcachelayer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+	"github.com/mattermost/mattermost/server/v8/channels/store/storetest"
+	"github.com/mattermost/mattermost/server/v8/channels/store/storetest/mocks"
+)
+
+func TestWorkingHoursStore(t *testing.T) {
+	StoreTestWithSqlStore(t, storetest.TestWorkingHoursStore)
+}
+
+func TestWorkingHoursStoreCache(t *testing.T) {
+	fakeWorkingHours := model.WorkingHours{UserId: "123", Enabled: true, Timezone: "UTC", QuietStatus: model.StatusDnd}
+	logger := mlog.CreateConsoleTestLogger(t)
+
+	t.Run("first call not cached, second cached and returning same data", func(t *testing.T) {
+		mockStore := getMockStore(t)
+		mockCacheProvider := getMockCacheProvider()
+		cachedStore, err := NewLocalCacheLayer(mockStore, nil, nil, mockCacheProvider, logger)
+		require.NoError(t, err)
+
+		workingHours, err := cachedStore.WorkingHours().Get("123")
+		require.NoError(t, err)
+		assert.Equal(t, &fakeWorkingHours, workingHours)
+		mockStore.WorkingHours().(*mocks.WorkingHoursStore).AssertNumberOfCalls(t, "Get", 1)
+
+		workingHours, err = cachedStore.WorkingHours().Get("123")
+		require.NoError(t, err)
+		assert.Equal(t, &fakeWorkingHours, workingHours)
+		mockStore.WorkingHours().(*mocks.WorkingHoursStore).AssertNumberOfCalls(t, "Get", 1)
+	})
+
+	t.Run("missing working hours are cached too", func(t *testing.T) {
+		mockStore := getMockStore(t)
+		mockCacheProvider := getMockCacheProvider()
+		cachedStore, err := NewLocalCacheLayer(mockStore, nil, nil, mockCacheProvider, logger)
+		require.NoError(t, err)
+
+		var nfErr *store.ErrNotFound
+		_, err = cachedStore.WorkingHours().Get("456")
+		require.ErrorAs(t, err, &nfErr)
+		mockStore.WorkingHours().(*mocks.WorkingHoursStore).AssertNumberOfCalls(t, "Get", 1)
+
+		_, err = cachedStore.WorkingHours().Get("456")
+		require.ErrorAs(t, err, &nfErr)
+		mockStore.WorkingHours().(*mocks.WorkingHoursStore).AssertNumberOfCalls(t, "Get", 1)
+	})
+
+	t.Run("first call not cached, save, and then not cached again", func(t *testing.T) {
+		mockStore := getMockStore(t)
+		mockCacheProvider := getMockCacheProvider()
+		cachedStore, err := NewLocalCacheLayer(mockStore, nil, nil, mockCacheProvider, logger)
+		require.NoError(t, err)
+
+		cachedStore.WorkingHours().Get("123")
+		mockStore.WorkingHours().(*mocks.WorkingHoursStore).AssertNumberOfCalls(t, "Get", 1)
+		cachedStore.WorkingHours().Save(&fakeWorkingHours)
+		cachedStore.WorkingHours().Get("123")
+		mockStore.WorkingHours().(*mocks.WorkingHoursStore).AssertNumberOfCalls(t, "Get", 2)
+	})
+
+	t.Run("first call not cached, set in quiet hours, and then not cached again", func(t *testing.T) {
+		mockStore := getMockStore(t)
+		mockCacheProvider := getMockCacheProvider()
+		cachedStore, err := NewLocalCacheLayer(mockStore, nil, nil, mockCacheProvider, logger)
+		require.NoError(t, err)
+
+		cachedStore.WorkingHours().Get("123")
+		mockStore.WorkingHours().(*mocks.WorkingHoursStore).AssertNumberOfCalls(t, "Get", 1)
+		require.NoError(t, cachedStore.WorkingHours().SetInQuietHours("123", true, true))
+		cachedStore.WorkingHours().Get("123")
+		mockStore.WorkingHours().(*mocks.WorkingHoursStore).AssertNumberOfCalls(t, "Get", 2)
+	})
+}
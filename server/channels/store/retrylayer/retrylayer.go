@@ -12,6 +12,8 @@ import (
 
 	"github.com/lib/pq"
 	"github.com/pkg/errors"
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
 
 	"github.com/mattermost/mattermost/server/public/model"
 	"github.com/mattermost/mattermost/server/public/shared/request"
@@ -23,6 +25,7 @@ type RetryLayer struct {
 	AccessControlPolicyStore        store.AccessControlPolicyStore
 	AttributesStore                 store.AttributesStore
 	AuditStore                      store.AuditStore
+	AuditLogStore                   store.AuditLogStore
 	AutoTranslationStore            store.AutoTranslationStore
 	BotStore                        store.BotStore
 	ChannelStore                    store.ChannelStore
@@ -35,12 +38,15 @@ type RetryLayer struct {
 	ContentFlaggingStore            store.ContentFlaggingStore
 	DesktopTokensStore              store.DesktopTokensStore
 	DraftStore                      store.DraftStore
+	EmailBatchNotificationStore     store.EmailBatchNotificationStore
 	EmojiStore                      store.EmojiStore
+	EventSubscriptionStore          store.EventSubscriptionStore
 	FileInfoStore                   store.FileInfoStore
 	GroupStore                      store.GroupStore
 	JobStore                        store.JobStore
 	LicenseStore                    store.LicenseStore
 	LinkMetadataStore               store.LinkMetadataStore
+	NotificationRuleStore           store.NotificationRuleStore
 	NotifyAdminStore                store.NotifyAdminStore
 	OAuthStore                      store.OAuthStore
 	OutgoingOAuthConnectionStore    store.OutgoingOAuthConnectionStore
@@ -73,6 +79,7 @@ type RetryLayer struct {
 	UserAccessTokenStore            store.UserAccessTokenStore
 	UserTermsOfServiceStore         store.UserTermsOfServiceStore
 	WebhookStore                    store.WebhookStore
+	WorkingHoursStore               store.WorkingHoursStore
 }
 
 func (s *RetryLayer) AccessControlPolicy() store.AccessControlPolicyStore {
@@ -87,6 +94,10 @@ func (s *RetryLayer) Audit() store.AuditStore {
 	return s.AuditStore
 }
 
+func (s *RetryLayer) AuditLog() store.AuditLogStore {
+	return s.AuditLogStore
+}
+
 func (s *RetryLayer) AutoTranslation() store.AutoTranslationStore {
 	return s.AutoTranslationStore
 }
@@ -135,10 +146,18 @@ func (s *RetryLayer) Draft() store.DraftStore {
 	return s.DraftStore
 }
 
+func (s *RetryLayer) EmailBatchNotification() store.EmailBatchNotificationStore {
+	return s.EmailBatchNotificationStore
+}
+
 func (s *RetryLayer) Emoji() store.EmojiStore {
 	return s.EmojiStore
 }
 
+func (s *RetryLayer) EventSubscription() store.EventSubscriptionStore {
+	return s.EventSubscriptionStore
+}
+
 func (s *RetryLayer) FileInfo() store.FileInfoStore {
 	return s.FileInfoStore
 }
@@ -159,6 +178,10 @@ func (s *RetryLayer) LinkMetadata() store.LinkMetadataStore {
 	return s.LinkMetadataStore
 }
 
+func (s *RetryLayer) NotificationRule() store.NotificationRuleStore {
+	return s.NotificationRuleStore
+}
+
 func (s *RetryLayer) NotifyAdmin() store.NotifyAdminStore {
 	return s.NotifyAdminStore
 }
@@ -287,6 +310,10 @@ func (s *RetryLayer) Webhook() store.WebhookStore {
 	return s.WebhookStore
 }
 
+func (s *RetryLayer) WorkingHours() store.WorkingHoursStore {
+	return s.WorkingHoursStore
+}
+
 type RetryLayerAccessControlPolicyStore struct {
 	store.AccessControlPolicyStore
 	Root *RetryLayer
@@ -302,6 +329,11 @@ type RetryLayerAuditStore struct {
 	Root *RetryLayer
 }
 
+type RetryLayerAuditLogStore struct {
+	store.AuditLogStore
+	Root *RetryLayer
+}
+
 type RetryLayerAutoTranslationStore struct {
 	store.AutoTranslationStore
 	Root *RetryLayer
@@ -362,11 +394,21 @@ type RetryLayerDraftStore struct {
 	Root *RetryLayer
 }
 
+type RetryLayerEmailBatchNotificationStore struct {
+	store.EmailBatchNotificationStore
+	Root *RetryLayer
+}
+
 type RetryLayerEmojiStore struct {
 	store.EmojiStore
 	Root *RetryLayer
 }
 
+type RetryLayerEventSubscriptionStore struct {
+	store.EventSubscriptionStore
+	Root *RetryLayer
+}
+
 type RetryLayerFileInfoStore struct {
 	store.FileInfoStore
 	Root *RetryLayer
@@ -392,6 +434,11 @@ type RetryLayerLinkMetadataStore struct {
 	Root *RetryLayer
 }
 
+type RetryLayerNotificationRuleStore struct {
+	store.NotificationRuleStore
+	Root *RetryLayer
+}
+
 type RetryLayerNotifyAdminStore struct {
 	store.NotifyAdminStore
 	Root *RetryLayer
@@ -552,13 +599,24 @@ type RetryLayerWebhookStore struct {
 	Root *RetryLayer
 }
 
+type RetryLayerWorkingHoursStore struct {
+	store.WorkingHoursStore
+	Root *RetryLayer
+}
+
 func isRepeatableError(err error) bool {
 	var pqErr *pq.Error
+	var sqliteErr *sqlite.Error
 	switch {
 	case errors.As(errors.Cause(err), &pqErr):
 		if pqErr.Code == "40001" || pqErr.Code == "40P01" {
 			return true
 		}
+	case errors.As(errors.Cause(err), &sqliteErr):
+		// The database stayed locked by another connection for longer than the busy timeout.
+		if sqliteErr.Code()&0xff == sqlite3.SQLITE_BUSY {
+			return true
+		}
 	}
 	return false
 }
@@ -836,6 +894,69 @@ func (s *RetryLayerAuditStore) Save(audit *model.Audit) error {
 
 }
 
+func (s *RetryLayerAuditLogStore) Append(entry *model.AuditLogEntry, hashKey []byte) (*model.AuditLogEntry, error) {
+
+	tries := 0
+	for {
+		result, err := s.AuditLogStore.Append(entry, hashKey)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerAuditLogStore) GetRange(afterSequence int64, limit int) ([]*model.AuditLogEntry, error) {
+
+	tries := 0
+	for {
+		result, err := s.AuditLogStore.GetRange(afterSequence, limit)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerAuditLogStore) Search(search model.AuditLogSearch) ([]*model.AuditLogEntry, error) {
+
+	tries := 0
+	for {
+		result, err := s.AuditLogStore.Search(search)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
 func (s *RetryLayerAutoTranslationStore) ClearCaches() {
 
 	s.AutoTranslationStore.ClearCaches()
@@ -3755,6 +3876,27 @@ func (s *RetryLayerChannelMemberHistoryStore) PermanentDeleteBatchForRetentionPo
 
 }
 
+func (s *RetryLayerClusterDiscoveryStore) AcquireLease(name string, holderID string, durationMillis int64) (bool, error) {
+
+	tries := 0
+	for {
+		result, err := s.ClusterDiscoveryStore.AcquireLease(name, holderID, durationMillis)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
 func (s *RetryLayerClusterDiscoveryStore) Cleanup() error {
 
 	tries := 0
@@ -3839,6 +3981,27 @@ func (s *RetryLayerClusterDiscoveryStore) GetAll(discoveryType string, clusterNa
 
 }
 
+func (s *RetryLayerClusterDiscoveryStore) ReleaseLease(name string, holderID string) error {
+
+	tries := 0
+	for {
+		err := s.ClusterDiscoveryStore.ReleaseLease(name, holderID)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
 func (s *RetryLayerClusterDiscoveryStore) Save(discovery *model.ClusterDiscovery) error {
 
 	tries := 0
@@ -4574,23 +4737,296 @@ func (s *RetryLayerDraftStore) PermanentDeleteByUser(userId string) error {
 			return nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerDraftStore) Upsert(d *model.Draft) (*model.Draft, error) {
+
+	tries := 0
+	for {
+		result, err := s.DraftStore.Upsert(d)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerEmailBatchNotificationStore) Delete(ids []string) error {
+
+	tries := 0
+	for {
+		err := s.EmailBatchNotificationStore.Delete(ids)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerEmailBatchNotificationStore) GetForUser(userID string) ([]*model.EmailBatchNotification, error) {
+
+	tries := 0
+	for {
+		result, err := s.EmailBatchNotificationStore.GetForUser(userID)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerEmailBatchNotificationStore) GetUserIds(afterUserID string, limit int) ([]string, error) {
+
+	tries := 0
+	for {
+		result, err := s.EmailBatchNotificationStore.GetUserIds(afterUserID, limit)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerEmailBatchNotificationStore) PermanentDeleteByUser(userID string) error {
+
+	tries := 0
+	for {
+		err := s.EmailBatchNotificationStore.PermanentDeleteByUser(userID)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerEmailBatchNotificationStore) Save(notification *model.EmailBatchNotification) (*model.EmailBatchNotification, error) {
+
+	tries := 0
+	for {
+		result, err := s.EmailBatchNotificationStore.Save(notification)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerEmojiStore) Delete(emoji *model.Emoji, timestamp int64) error {
+
+	tries := 0
+	for {
+		err := s.EmojiStore.Delete(emoji, timestamp)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerEmojiStore) Get(rctx request.CTX, id string, allowFromCache bool) (*model.Emoji, error) {
+
+	tries := 0
+	for {
+		result, err := s.EmojiStore.Get(rctx, id, allowFromCache)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerEmojiStore) GetAllAfter(createAt int64, id string, limit int) ([]*model.Emoji, error) {
+
+	tries := 0
+	for {
+		result, err := s.EmojiStore.GetAllAfter(createAt, id, limit)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerEmojiStore) GetByName(rctx request.CTX, name string, allowFromCache bool) (*model.Emoji, error) {
+
+	tries := 0
+	for {
+		result, err := s.EmojiStore.GetByName(rctx, name, allowFromCache)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerEmojiStore) GetList(offset int, limit int, sort string) ([]*model.Emoji, error) {
+
+	tries := 0
+	for {
+		result, err := s.EmojiStore.GetList(offset, limit, sort)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerEmojiStore) GetMultipleByName(rctx request.CTX, names []string) ([]*model.Emoji, error) {
+
+	tries := 0
+	for {
+		result, err := s.EmojiStore.GetMultipleByName(rctx, names)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerEmojiStore) Save(emoji *model.Emoji) (*model.Emoji, error) {
+
+	tries := 0
+	for {
+		result, err := s.EmojiStore.Save(emoji)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerDraftStore) Upsert(d *model.Draft) (*model.Draft, error) {
+func (s *RetryLayerEmojiStore) Search(name string, prefixOnly bool, limit int) ([]*model.Emoji, error) {
 
 	tries := 0
 	for {
-		result, err := s.DraftStore.Upsert(d)
+		result, err := s.EmojiStore.Search(name, prefixOnly, limit)
 		if err == nil {
 			return result, nil
 		}
@@ -4607,11 +5043,11 @@ func (s *RetryLayerDraftStore) Upsert(d *model.Draft) (*model.Draft, error) {
 
 }
 
-func (s *RetryLayerEmojiStore) Delete(emoji *model.Emoji, timestamp int64) error {
+func (s *RetryLayerEventSubscriptionStore) Delete(id string, deleteAt int64) error {
 
 	tries := 0
 	for {
-		err := s.EmojiStore.Delete(emoji, timestamp)
+		err := s.EventSubscriptionStore.Delete(id, deleteAt)
 		if err == nil {
 			return nil
 		}
@@ -4628,11 +5064,11 @@ func (s *RetryLayerEmojiStore) Delete(emoji *model.Emoji, timestamp int64) error
 
 }
 
-func (s *RetryLayerEmojiStore) Get(rctx request.CTX, id string, allowFromCache bool) (*model.Emoji, error) {
+func (s *RetryLayerEventSubscriptionStore) Get(id string) (*model.EventSubscription, error) {
 
 	tries := 0
 	for {
-		result, err := s.EmojiStore.Get(rctx, id, allowFromCache)
+		result, err := s.EventSubscriptionStore.Get(id)
 		if err == nil {
 			return result, nil
 		}
@@ -4649,11 +5085,11 @@ func (s *RetryLayerEmojiStore) Get(rctx request.CTX, id string, allowFromCache b
 
 }
 
-func (s *RetryLayerEmojiStore) GetByName(rctx request.CTX, name string, allowFromCache bool) (*model.Emoji, error) {
+func (s *RetryLayerEventSubscriptionStore) GetForTeam(teamID string) ([]*model.EventSubscription, error) {
 
 	tries := 0
 	for {
-		result, err := s.EmojiStore.GetByName(rctx, name, allowFromCache)
+		result, err := s.EventSubscriptionStore.GetForTeam(teamID)
 		if err == nil {
 			return result, nil
 		}
@@ -4670,11 +5106,11 @@ func (s *RetryLayerEmojiStore) GetByName(rctx request.CTX, name string, allowFro
 
 }
 
-func (s *RetryLayerEmojiStore) GetList(offset int, limit int, sort string) ([]*model.Emoji, error) {
+func (s *RetryLayerEventSubscriptionStore) GetList(teamID string, offset int, limit int) ([]*model.EventSubscription, error) {
 
 	tries := 0
 	for {
-		result, err := s.EmojiStore.GetList(offset, limit, sort)
+		result, err := s.EventSubscriptionStore.GetList(teamID, offset, limit)
 		if err == nil {
 			return result, nil
 		}
@@ -4691,32 +5127,32 @@ func (s *RetryLayerEmojiStore) GetList(offset int, limit int, sort string) ([]*m
 
 }
 
-func (s *RetryLayerEmojiStore) GetMultipleByName(rctx request.CTX, names []string) ([]*model.Emoji, error) {
+func (s *RetryLayerEventSubscriptionStore) PermanentDeleteByUser(userID string) error {
 
 	tries := 0
 	for {
-		result, err := s.EmojiStore.GetMultipleByName(rctx, names)
+		err := s.EventSubscriptionStore.PermanentDeleteByUser(userID)
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerEmojiStore) Save(emoji *model.Emoji) (*model.Emoji, error) {
+func (s *RetryLayerEventSubscriptionStore) Save(subscription *model.EventSubscription) (*model.EventSubscription, error) {
 
 	tries := 0
 	for {
-		result, err := s.EmojiStore.Save(emoji)
+		result, err := s.EventSubscriptionStore.Save(subscription)
 		if err == nil {
 			return result, nil
 		}
@@ -4733,11 +5169,11 @@ func (s *RetryLayerEmojiStore) Save(emoji *model.Emoji) (*model.Emoji, error) {
 
 }
 
-func (s *RetryLayerEmojiStore) Search(name string, prefixOnly bool, limit int) ([]*model.Emoji, error) {
+func (s *RetryLayerEventSubscriptionStore) Update(subscription *model.EventSubscription) (*model.EventSubscription, error) {
 
 	tries := 0
 	for {
-		result, err := s.EmojiStore.Search(name, prefixOnly, limit)
+		result, err := s.EventSubscriptionStore.Update(subscription)
 		if err == nil {
 			return result, nil
 		}
@@ -6908,6 +7344,90 @@ func (s *RetryLayerLinkMetadataStore) Save(linkMetadata *model.LinkMetadata) (*m
 
 }
 
+func (s *RetryLayerNotificationRuleStore) GetEnabledForChannel(channelID string) (map[string][]*model.NotificationRule, error) {
+
+	tries := 0
+	for {
+		result, err := s.NotificationRuleStore.GetEnabledForChannel(channelID)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerNotificationRuleStore) GetForUser(userID string) ([]*model.NotificationRule, error) {
+
+	tries := 0
+	for {
+		result, err := s.NotificationRuleStore.GetForUser(userID)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerNotificationRuleStore) PermanentDeleteByUser(userID string) error {
+
+	tries := 0
+	for {
+		err := s.NotificationRuleStore.PermanentDeleteByUser(userID)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerNotificationRuleStore) ReplaceForUser(userID string, rules []*model.NotificationRule) ([]*model.NotificationRule, error) {
+
+	tries := 0
+	for {
+		result, err := s.NotificationRuleStore.ReplaceForUser(userID, rules)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
 func (s *RetryLayerNotifyAdminStore) DeleteBefore(trial bool, now int64) error {
 
 	tries := 0
@@ -8354,6 +8874,27 @@ func (s *RetryLayerPostStore) GetPostsCreatedAt(channelID string, timestamp int6
 
 }
 
+func (s *RetryLayerPostStore) GetPostsForReporting(rctx request.CTX, queryParams model.ReportPostQueryParams) (*model.ReportPostListResponse, error) {
+
+	tries := 0
+	for {
+		result, err := s.PostStore.GetPostsForReporting(rctx, queryParams)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
 func (s *RetryLayerPostStore) GetPostsSince(rctx request.CTX, options model.GetPostsSinceOptions, allowFromCache bool, sanitizeOptions map[string]bool) (*model.PostList, error) {
 
 	tries := 0
@@ -8396,6 +8937,27 @@ func (s *RetryLayerPostStore) GetPostsSinceForSync(options model.GetPostsSinceFo
 
 }
 
+func (s *RetryLayerPostStore) GetRecentPostsForUser(userID string, since int64, limit int) ([]*model.Post, error) {
+
+	tries := 0
+	for {
+		result, err := s.PostStore.GetRecentPostsForUser(userID, since, limit)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
 func (s *RetryLayerPostStore) GetRepliesForExport(parentID string) ([]*model.ReplyForExport, error) {
 
 	tries := 0
@@ -11159,6 +11721,27 @@ func (s *RetryLayerScheduledPostStore) GetScheduledPostsForUser(userId string, t
 
 }
 
+func (s *RetryLayerScheduledPostStore) GetStaleRecurringScheduledPosts(beforeTime int64, perPage uint64) ([]*model.ScheduledPost, error) {
+
+	tries := 0
+	for {
+		result, err := s.ScheduledPostStore.GetStaleRecurringScheduledPosts(beforeTime, perPage)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
 func (s *RetryLayerScheduledPostStore) PermanentDeleteByUser(userId string) error {
 
 	tries := 0
@@ -17174,6 +17757,111 @@ func (s *RetryLayerWebhookStore) UpdateOutgoing(hook *model.OutgoingWebhook) (*m
 
 }
 
+func (s *RetryLayerWorkingHoursStore) Get(userID string) (*model.WorkingHours, error) {
+
+	tries := 0
+	for {
+		result, err := s.WorkingHoursStore.Get(userID)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerWorkingHoursStore) GetEnabled(afterUserID string, limit int) ([]*model.WorkingHours, error) {
+
+	tries := 0
+	for {
+		result, err := s.WorkingHoursStore.GetEnabled(afterUserID, limit)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerWorkingHoursStore) PermanentDeleteByUser(userID string) error {
+
+	tries := 0
+	for {
+		err := s.WorkingHoursStore.PermanentDeleteByUser(userID)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerWorkingHoursStore) Save(workingHours *model.WorkingHours) (*model.WorkingHours, error) {
+
+	tries := 0
+	for {
+		result, err := s.WorkingHoursStore.Save(workingHours)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerWorkingHoursStore) SetInQuietHours(userID string, inQuietHours bool, quietStatusSet bool) error {
+
+	tries := 0
+	for {
+		err := s.WorkingHoursStore.SetInQuietHours(userID, inQuietHours, quietStatusSet)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
 func (s *RetryLayer) Close() {
 	s.Store.Close()
 }
@@ -17214,6 +17902,7 @@ func New(childStore store.Store) *RetryLayer {
 	newStore.AccessControlPolicyStore = &RetryLayerAccessControlPolicyStore{AccessControlPolicyStore: childStore.AccessControlPolicy(), Root: &newStore}
 	newStore.AttributesStore = &RetryLayerAttributesStore{AttributesStore: childStore.Attributes(), Root: &newStore}
 	newStore.AuditStore = &RetryLayerAuditStore{AuditStore: childStore.Audit(), Root: &newStore}
+	newStore.AuditLogStore = &RetryLayerAuditLogStore{AuditLogStore: childStore.AuditLog(), Root: &newStore}
 	newStore.AutoTranslationStore = &RetryLayerAutoTranslationStore{AutoTranslationStore: childStore.AutoTranslation(), Root: &newStore}
 	newStore.BotStore = &RetryLayerBotStore{BotStore: childStore.Bot(), Root: &newStore}
 	newStore.ChannelStore = &RetryLayerChannelStore{ChannelStore: childStore.Channel(), Root: &newStore}
@@ -17226,12 +17915,15 @@ func New(childStore store.Store) *RetryLayer {
 	newStore.ContentFlaggingStore = &RetryLayerContentFlaggingStore{ContentFlaggingStore: childStore.ContentFlagging(), Root: &newStore}
 	newStore.DesktopTokensStore = &RetryLayerDesktopTokensStore{DesktopTokensStore: childStore.DesktopTokens(), Root: &newStore}
 	newStore.DraftStore = &RetryLayerDraftStore{DraftStore: childStore.Draft(), Root: &newStore}
+	newStore.EmailBatchNotificationStore = &RetryLayerEmailBatchNotificationStore{EmailBatchNotificationStore: childStore.EmailBatchNotification(), Root: &newStore}
 	newStore.EmojiStore = &RetryLayerEmojiStore{EmojiStore: childStore.Emoji(), Root: &newStore}
+	newStore.EventSubscriptionStore = &RetryLayerEventSubscriptionStore{EventSubscriptionStore: childStore.EventSubscription(), Root: &newStore}
 	newStore.FileInfoStore = &RetryLayerFileInfoStore{FileInfoStore: childStore.FileInfo(), Root: &newStore}
 	newStore.GroupStore = &RetryLayerGroupStore{GroupStore: childStore.Group(), Root: &newStore}
 	newStore.JobStore = &RetryLayerJobStore{JobStore: childStore.Job(), Root: &newStore}
 	newStore.LicenseStore = &RetryLayerLicenseStore{LicenseStore: childStore.License(), Root: &newStore}
 	newStore.LinkMetadataStore = &RetryLayerLinkMetadataStore{LinkMetadataStore: childStore.LinkMetadata(), Root: &newStore}
+	newStore.NotificationRuleStore = &RetryLayerNotificationRuleStore{NotificationRuleStore: childStore.NotificationRule(), Root: &newStore}
 	newStore.NotifyAdminStore = &RetryLayerNotifyAdminStore{NotifyAdminStore: childStore.NotifyAdmin(), Root: &newStore}
 	newStore.OAuthStore = &RetryLayerOAuthStore{OAuthStore: childStore.OAuth(), Root: &newStore}
 	newStore.OutgoingOAuthConnectionStore = &RetryLayerOutgoingOAuthConnectionStore{OutgoingOAuthConnectionStore: childStore.OutgoingOAuthConnection(), Root: &newStore}
@@ -17264,5 +17956,6 @@ func New(childStore store.Store) *RetryLayer {
 	newStore.UserAccessTokenStore = &RetryLayerUserAccessTokenStore{UserAccessTokenStore: childStore.UserAccessToken(), Root: &newStore}
 	newStore.UserTermsOfServiceStore = &RetryLayerUserTermsOfServiceStore{UserTermsOfServiceStore: childStore.UserTermsOfService(), Root: &newStore}
 	newStore.WebhookStore = &RetryLayerWebhookStore{WebhookStore: childStore.Webhook(), Root: &newStore}
+	newStore.WorkingHoursStore = &RetryLayerWorkingHoursStore{WorkingHoursStore: childStore.WorkingHours(), Root: &newStore}
 	return &newStore
 }
@@ -360,9 +360,12 @@ func (s *SqlAccessControlPolicyStore) SetActiveStatus(rctx request.CTX, id strin
 	if existingPolicy.Type == model.AccessControlPolicyTypeParent {
 		// if the policy is a parent, we need to update the child policies
 		var expr sq.Sqlizer
-		if s.DriverName() == model.DatabaseDriverPostgres {
+		switch s.DriverName() {
+		case model.DatabaseDriverPostgres:
 			expr = sq.Expr("Data->'imports' @> ?::jsonb", fmt.Sprintf("%q", id))
-		} else {
+		case model.DatabaseDriverSqlite:
+			expr = sq.Expr(sqliteJSONArrayContains("Data", "$.imports"), id)
+		default:
 			expr = sq.Expr("JSON_CONTAINS(JSON_EXTRACT(Data, '$.imports'), ?)", fmt.Sprintf("%q", id))
 		}
 		query, args, err = s.getQueryBuilder().Update("AccessControlPolicies").Set("Active", active).Where(expr).ToSql()
@@ -541,9 +544,12 @@ func (s *SqlAccessControlPolicyStore) GetAll(_ request.CTX, opts model.GetAccess
 	query := s.selectQueryBuilder
 
 	if opts.ParentID != "" {
-		if s.DriverName() == model.DatabaseDriverPostgres {
+		switch s.DriverName() {
+		case model.DatabaseDriverPostgres:
 			query = query.Where(sq.Expr("Data->'imports' @> ?", fmt.Sprintf("%q", opts.ParentID)))
-		} else {
+		case model.DatabaseDriverSqlite:
+			query = query.Where(sq.Expr(sqliteJSONArrayContains("Data", "$.imports"), opts.ParentID))
+		default:
 			query = query.Where(sq.Expr("JSON_CONTAINS(JSON_EXTRACT(Data, '$.imports'), ?)", fmt.Sprintf("%q", opts.ParentID)))
 		}
 	}
@@ -603,6 +609,13 @@ func (s *SqlAccessControlPolicyStore) SearchPolicies(rctx request.CTX, opts mode
      FROM AccessControlPolicies c
 	 WHERE c.Type != 'parent'
      AND c.Data->'imports' @> JSONB_BUILD_ARRAY(p.ID)), '[]'::json) AS ChildIDs`
+		if s.DriverName() == model.DatabaseDriverSqlite {
+			// Cast to a blob so that it is scanned into bytes, like the Postgres json.
+			childIDs = `CAST((SELECT json_group_array(c.ID)
+     FROM AccessControlPolicies c
+	 WHERE c.Type != 'parent'
+     AND EXISTS (SELECT 1 FROM json_each(c.Data, '$.imports') WHERE json_each.value = p.ID)) AS BLOB) AS ChildIDs`
+		}
 		columns = append(columns, childIDs)
 		query = s.getQueryBuilder().Select(columns...).From("AccessControlPolicies p")
 	} else {
@@ -625,6 +638,9 @@ func (s *SqlAccessControlPolicyStore) SearchPolicies(rctx request.CTX, opts mode
 
 	if opts.ParentID != "" {
 		condition := sq.Expr("Data->'imports' @> ?", fmt.Sprintf("%q", opts.ParentID))
+		if s.DriverName() == model.DatabaseDriverSqlite {
+			condition = sq.Expr(sqliteJSONArrayContains("Data", "$.imports"), opts.ParentID)
+		}
 		query = query.Where(condition)
 		count = count.Where(condition)
 	}
@@ -656,7 +672,7 @@ func (s *SqlAccessControlPolicyStore) SearchPolicies(rctx request.CTX, opts mode
 	query = query.Limit(limit)
 	query = query.OrderBy("Id ASC")
 
-	err := s.GetReplica().SelectBuilder(&p, query)
+	err := s.DBXFromContext(rctx.Context()).SelectBuilder(&p, query)
 	if err != nil {
 		return nil, 0, errors.Wrapf(err, "failed to find policies with opts={\"name\"=%q, \"resourceType\"=%q", opts.Term, opts.Type)
 	}
@@ -687,7 +703,7 @@ func (s *SqlAccessControlPolicyStore) SearchPolicies(rctx request.CTX, opts mode
 	}
 
 	var total int64
-	err = s.GetReplica().GetBuilder(&total, count)
+	err = s.DBXFromContext(rctx.Context()).GetBuilder(&total, count)
 	if err != nil {
 		return nil, 0, errors.Wrapf(err, "failed to count policies with opts={\"name\"=%q, \"resourceType\"=%q", opts.Term, opts.Type)
 	}
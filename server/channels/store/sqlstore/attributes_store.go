@@ -68,7 +68,7 @@ func (s *SqlAttributesStore) GetSubject(rctx request.CTX, ID, groupID string) (*
 		return nil, errors.Wrap(err, "failed to build query for subject")
 	}
 
-	row := s.GetReplica().QueryRowxContext(rctx.Context(), q, args...)
+	row := s.DBXFromContext(rctx.Context()).QueryRowxContext(rctx.Context(), q, args...)
 	if err := row.Err(); err != nil {
 		return nil, errors.Wrap(err, "failed to get subject")
 	}
@@ -153,7 +153,7 @@ func (s *SqlAttributesStore) SearchUsers(rctx request.CTX, opts model.SubjectSea
 	}
 
 	users := []*model.User{}
-	if err = s.GetReplica().Select(&users, q, args...); err != nil {
+	if err = s.DBXFromContext(rctx.Context()).Select(&users, q, args...); err != nil {
 		return nil, 0, errors.Wrapf(err, "failed to find Users with term=%s and searchType=%v", opts.Term, searchFields)
 	}
 
@@ -164,7 +164,7 @@ func (s *SqlAttributesStore) SearchUsers(rctx request.CTX, opts model.SubjectSea
 	var total int64
 
 	if !opts.IgnoreCount {
-		err = s.GetReplica().GetBuilder(&total, count)
+		err = s.DBXFromContext(rctx.Context()).GetBuilder(&total, count)
 		if err != nil {
 			return nil, 0, errors.Wrapf(err, "failed to count Users with term=%s and searchType=%v", opts.Term, searchFields)
 		}
@@ -204,7 +204,7 @@ func (s *SqlAttributesStore) GetChannelMembersToRemove(rctx request.CTX, channel
 	}
 
 	members := []*model.ChannelMember{}
-	if err := s.GetReplica().Select(&members, q, args...); err != nil {
+	if err := s.DBXFromContext(rctx.Context()).Select(&members, q, args...); err != nil {
 		return nil, errors.Wrapf(err, "failed to find channel members with for channel id=%s", channelID)
 	}
 
@@ -0,0 +1,132 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+
+	sq "github.com/mattermost/squirrel"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+type SqlAuditLogStore struct {
+	*SqlStore
+
+	auditLogSelectQuery sq.SelectBuilder
+}
+
+func newSqlAuditLogStore(sqlStore *SqlStore) store.AuditLogStore {
+	s := &SqlAuditLogStore{
+		SqlStore: sqlStore,
+	}
+
+	s.auditLogSelectQuery = s.getQueryBuilder().
+		Select("Id", "Sequence", "CreateAt", "EventName", "Status", "ActorId", "ObjectId", "Record", "PrevHash", "Hash").
+		From("AuditLogs")
+
+	return s
+}
+
+func (s *SqlAuditLogStore) Append(entry *model.AuditLogEntry, hashKey []byte) (_ *model.AuditLogEntry, err error) {
+	if appErr := entry.IsValid(); appErr != nil {
+		return nil, appErr
+	}
+	if len(hashKey) == 0 {
+		return nil, errors.New("the hash key of the audit log is empty")
+	}
+
+	transaction, err := s.GetMaster().Beginx()
+	if err != nil {
+		return nil, errors.Wrap(err, "begin_transaction")
+	}
+	defer finalizeTransactionX(transaction, &err)
+
+	// Entries are chained to one another, so appending must be serialized across the
+	// cluster. The lock still lets readers through and is released on commit. SQLite
+	// transactions hold the database write lock already.
+	if s.DriverName() != model.DatabaseDriverSqlite {
+		if _, err = transaction.Exec("LOCK TABLE AuditLogs IN EXCLUSIVE MODE"); err != nil {
+			return nil, errors.Wrap(err, "failed to lock AuditLogs")
+		}
+	}
+
+	var last struct {
+		Sequence int64
+		Hash     string
+	}
+	err = transaction.Get(&last, "SELECT Sequence, Hash FROM AuditLogs ORDER BY Sequence DESC LIMIT 1")
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, errors.Wrap(err, "failed to get the last AuditLog")
+	}
+
+	entry.Sequence = last.Sequence + 1
+	entry.PrevHash = last.Hash
+	entry.Hash = entry.ComputeHash(hashKey)
+
+	builder := s.getQueryBuilder().
+		Insert("AuditLogs").
+		Columns("Id", "Sequence", "CreateAt", "EventName", "Status", "ActorId", "ObjectId", "Record", "PrevHash", "Hash").
+		Values(entry.Id, entry.Sequence, entry.CreateAt, entry.EventName, entry.Status, entry.ActorId, entry.ObjectId, entry.Record, entry.PrevHash, entry.Hash)
+	if _, err = transaction.ExecBuilder(builder); err != nil {
+		return nil, errors.Wrapf(err, "failed to save AuditLog with id=%s", entry.Id)
+	}
+
+	if err = transaction.Commit(); err != nil {
+		return nil, errors.Wrap(err, "commit_transaction")
+	}
+
+	return entry, nil
+}
+
+func (s *SqlAuditLogStore) Search(search model.AuditLogSearch) ([]*model.AuditLogEntry, error) {
+	builder := s.auditLogSelectQuery.
+		OrderBy("Sequence DESC").
+		Limit(uint64(search.PerPage)).
+		Offset(uint64(search.Page * search.PerPage))
+
+	if search.ActorId != "" {
+		builder = builder.Where(sq.Eq{"ActorId": search.ActorId})
+	}
+	if search.EventName != "" {
+		builder = builder.Where(sq.Eq{"EventName": search.EventName})
+	}
+	if search.ObjectId != "" {
+		builder = builder.Where(sq.Eq{"ObjectId": search.ObjectId})
+	}
+	if search.Status != "" {
+		builder = builder.Where(sq.Eq{"Status": search.Status})
+	}
+	if search.StartTime > 0 {
+		builder = builder.Where(sq.GtOrEq{"CreateAt": search.StartTime})
+	}
+	if search.EndTime > 0 {
+		builder = builder.Where(sq.LtOrEq{"CreateAt": search.EndTime})
+	}
+
+	entries := []*model.AuditLogEntry{}
+	if err := s.GetReplica().SelectBuilder(&entries, builder); err != nil {
+		return nil, errors.Wrap(err, "failed to search AuditLogs")
+	}
+
+	return entries, nil
+}
+
+func (s *SqlAuditLogStore) GetRange(afterSequence int64, limit int) ([]*model.AuditLogEntry, error) {
+	builder := s.auditLogSelectQuery.
+		Where(sq.Gt{"Sequence": afterSequence}).
+		OrderBy("Sequence").
+		Limit(uint64(limit))
+
+	// The entries are read from the master, as the log is verified against its anchor, which may
+	// be ahead of a replica.
+	entries := []*model.AuditLogEntry{}
+	if err := s.GetMaster().SelectBuilder(&entries, builder); err != nil {
+		return nil, errors.Wrapf(err, "failed to get AuditLogs after sequence=%d", afterSequence)
+	}
+
+	return entries, nil
+}
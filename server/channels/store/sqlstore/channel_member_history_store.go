@@ -243,6 +243,9 @@ func (s SqlChannelMemberHistoryStore) PermanentDeleteBatchForRetentionPolicies(r
 		GlobalPolicyEndTime: retentionPolicyBatchConfigs.GlobalPolicyEndTime,
 		Limit:               retentionPolicyBatchConfigs.Limit,
 		StoreDeletedIds:     false,
+		UserIDColumn:        "UserId",
+		LegalHoldUserIDs:    retentionPolicyBatchConfigs.LegalHoldUserIDs,
+		LegalHoldChannelIDs: retentionPolicyBatchConfigs.LegalHoldChannelIDs,
 	}, s.SqlStore, cursor)
 }
 
@@ -274,10 +277,15 @@ func (s SqlChannelMemberHistoryStore) PermanentDeleteBatch(endTime int64, limit
 		err   error
 	)
 
-	if s.DriverName() == model.DatabaseDriverPostgres {
+	if s.DriverName() == model.DatabaseDriverPostgres || s.DriverName() == model.DatabaseDriverSqlite {
+		// Both have a hidden column identifying the rows of tables without a single column key.
+		rowID := "ctid"
+		if s.DriverName() == model.DatabaseDriverSqlite {
+			rowID = "rowid"
+		}
 		var innerSelect string
 		innerSelect, args, err = s.getQueryBuilder().
-			Select("ctid").
+			Select(rowID).
 			From("ChannelMemberHistory").
 			Where(sq.And{
 				sq.NotEq{"LeaveTime": nil},
@@ -290,7 +298,7 @@ func (s SqlChannelMemberHistoryStore) PermanentDeleteBatch(endTime int64, limit
 		query, _, err = s.getQueryBuilder().
 			Delete("ChannelMemberHistory").
 			Where(fmt.Sprintf(
-				"ctid IN (%s)", innerSelect,
+				"%s IN (%s)", rowID, innerSelect,
 			)).ToSql()
 	} else {
 		query, args, err = s.getQueryBuilder().
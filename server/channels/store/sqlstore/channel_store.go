@@ -683,9 +683,9 @@ func (s SqlChannelStore) SaveDirectChannel(rctx request.CTX, directChannel *mode
 	member2.ChannelId = newChannel.Id
 
 	if member1.UserId != member2.UserId {
-		_, err = s.saveMultipleMembers([]*model.ChannelMember{member1, member2})
+		_, err = s.saveMultipleMembers(transaction, []*model.ChannelMember{member1, member2})
 	} else {
-		_, err = s.saveMemberT(member2)
+		_, err = s.saveMemberT(transaction, member2)
 	}
 	if err != nil {
 		return nil, err
@@ -1654,7 +1654,7 @@ func (s SqlChannelStore) SaveMultipleMembers(members []*model.ChannelMember) ([]
 		defer s.InvalidateAllChannelMembersForUser(member.UserId)
 	}
 
-	newMembers, err := s.saveMultipleMembers(members)
+	newMembers, err := s.saveMultipleMembers(s.GetMaster(), members)
 	if err != nil {
 		return nil, err
 	}
@@ -1670,7 +1670,7 @@ func (s SqlChannelStore) SaveMember(rctx request.CTX, member *model.ChannelMembe
 	return newMembers[0], nil
 }
 
-func (s SqlChannelStore) saveMultipleMembers(members []*model.ChannelMember) ([]*model.ChannelMember, error) {
+func (s SqlChannelStore) saveMultipleMembers(ex sqlxExecutor, members []*model.ChannelMember) ([]*model.ChannelMember, error) {
 	newChannelMembers := map[string]int{}
 	users := map[string]bool{}
 	for _, member := range members {
@@ -1721,7 +1721,7 @@ func (s SqlChannelStore) saveMultipleMembers(members []*model.ChannelMember) ([]
 		User  sql.NullString
 		Admin sql.NullString
 	}{}
-	err = s.GetMaster().Select(&defaultChannelsRoles, channelRolesSql, channelRolesArgs...)
+	err = ex.Select(&defaultChannelsRoles, channelRolesSql, channelRolesArgs...)
 	if err != nil {
 		return nil, errors.Wrap(err, "default_channel_roles_select")
 	}
@@ -1760,7 +1760,7 @@ func (s SqlChannelStore) saveMultipleMembers(members []*model.ChannelMember) ([]
 		User  sql.NullString
 		Admin sql.NullString
 	}{}
-	err = s.GetMaster().Select(&defaultTeamsRoles, teamRolesSql, teamRolesArgs...)
+	err = ex.Select(&defaultTeamsRoles, teamRolesSql, teamRolesArgs...)
 	if err != nil {
 		return nil, errors.Wrap(err, "default_team_roles_select")
 	}
@@ -1779,7 +1779,7 @@ func (s SqlChannelStore) saveMultipleMembers(members []*model.ChannelMember) ([]
 		return nil, errors.Wrap(err, "channel_members_tosql")
 	}
 
-	if _, err := s.GetMaster().Exec(sql, args...); err != nil {
+	if _, err := ex.Exec(sql, args...); err != nil {
 		if IsUniqueConstraintError(err, []string{"ChannelId", "channelmembers_pkey", "PRIMARY"}) {
 			return nil, store.NewErrConflict("ChannelMembers", err, "")
 		}
@@ -1811,8 +1811,8 @@ func (s SqlChannelStore) saveMultipleMembers(members []*model.ChannelMember) ([]
 	return newMembers, nil
 }
 
-func (s SqlChannelStore) saveMemberT(member *model.ChannelMember) (*model.ChannelMember, error) {
-	members, err := s.saveMultipleMembers([]*model.ChannelMember{member})
+func (s SqlChannelStore) saveMemberT(ex sqlxExecutor, member *model.ChannelMember) (*model.ChannelMember, error) {
+	members, err := s.saveMultipleMembers(ex, []*model.ChannelMember{member})
 	if err != nil {
 		return nil, err
 	}
@@ -1902,7 +1902,7 @@ func (s SqlChannelStore) UpdateMemberNotifyProps(channelID, userID string, props
 
 	sqlStr, args, err2 := s.getQueryBuilder().
 		Update("channelmembers").
-		Set("notifyprops", sq.Expr("notifyprops || ?::jsonb", jsonNotifyProps)).
+		Set("notifyprops", sq.Expr(s.jsonMergeExpr("notifyprops"), jsonNotifyProps)).
 		Set("LastUpdateAt", model.GetMillis()).
 		Where(sq.Eq{
 			"userid":    userID,
@@ -1968,7 +1968,7 @@ func (s SqlChannelStore) PatchMultipleMembersNotifyProps(members []*model.Channe
 
 	jsonNotifyProps := string(model.ToJSON(notifyProps))
 	builder = builder.
-		Set("notifyprops", sq.Expr("notifyprops || ?::jsonb", jsonNotifyProps)).
+		Set("notifyprops", sq.Expr(s.jsonMergeExpr("notifyprops"), jsonNotifyProps)).
 		Set("LastUpdateAt", model.GetMillis()).
 		Where(whereClause)
 
@@ -2625,6 +2625,10 @@ func (s SqlChannelStore) UpdateLastViewedAt(channelIds []string, userId string)
 			"UserId":    userId,
 			"ChannelId": channelIds,
 		})
+	if s.DriverName() == model.DatabaseDriverSqlite {
+		// SQLite assigns the values of the row before the update.
+		updateQuery = updateQuery.Set("LastUpdateAt", lastViewedQuery)
+	}
 
 	sql, args, err = updateQuery.ToSql()
 	if err != nil {
@@ -3116,7 +3120,7 @@ func (s SqlChannelStore) Autocomplete(rctx request.CTX, userID, term string, inc
 	}
 
 	channels := model.ChannelListWithTeamData{}
-	err = s.GetReplica().Select(&channels, sql, args...)
+	err = s.DBXFromContext(rctx.Context()).Select(&channels, sql, args...)
 	if err != nil {
 		return nil, errors.Wrapf(err, "could not find channel with term=%s", trimInput(term))
 	}
@@ -3473,9 +3477,12 @@ func (s SqlChannelStore) channelSearchQuery(opts *store.ChannelSearchOpts) sq.Se
 	if opts.ExcludeAccessControlPolicyEnforced {
 		query = query.Where("c.Id NOT IN (SELECT ID From AccessControlPolicies WHERE Type = ?)", model.AccessControlPolicyTypeChannel)
 	} else if opts.ParentAccessControlPolicyId != "" {
-		if s.DriverName() == model.DatabaseDriverPostgres {
+		switch s.DriverName() {
+		case model.DatabaseDriverPostgres:
 			query = query.Where(sq.Expr("c.Id IN (SELECT ID From AccessControlPolicies WHERE Type = ? AND Data->'imports' @> ?)", model.AccessControlPolicyTypeChannel, fmt.Sprintf("%q", opts.ParentAccessControlPolicyId)))
-		} else {
+		case model.DatabaseDriverSqlite:
+			query = query.Where(sq.Expr("c.Id IN (SELECT ID From AccessControlPolicies WHERE Type = ? AND "+sqliteJSONArrayContains("Data", "$.imports")+")", model.AccessControlPolicyTypeChannel, opts.ParentAccessControlPolicyId))
+		default:
 			query = query.Where(sq.Expr("c.Id IN (SELECT ID From AccessControlPolicies WHERE Type = ? AND JSON_CONTAINS(JSON_EXTRACT(Data, '$.imports'), ?))", model.AccessControlPolicyTypeChannel, fmt.Sprintf("%q", opts.ParentAccessControlPolicyId)))
 		}
 	} else if opts.AccessControlPolicyEnforced {
@@ -3617,7 +3624,7 @@ func (s SqlChannelStore) buildFulltextClause(term string, searchColumns string)
 
 	fulltextTerm = strings.Join(splitTerm, " & ")
 
-	fulltextClause = fmt.Sprintf("((to_tsvector('%[1]s', %[2]s)) @@ to_tsquery('%[1]s', :FulltextTerm))", s.pgDefaultTextSearchConfig, convertMySQLFullTextColumnsToPostgres(searchColumns))
+	fulltextClause = "(" + s.textSearchClause(s.pgDefaultTextSearchConfig, convertMySQLFullTextColumnsToPostgres(searchColumns), ":FulltextTerm") + ")"
 
 	return
 }
@@ -3635,7 +3642,7 @@ func (s SqlChannelStore) buildFulltextClauseX(term string, searchColumns ...stri
 	}, fulltextTerm)
 
 	// Prepare the FULLTEXT portion of the query.
-	if s.DriverName() == model.DatabaseDriverPostgres {
+	if s.DriverName() == model.DatabaseDriverPostgres || s.DriverName() == model.DatabaseDriverSqlite {
 		// remove all pipes |
 		fulltextTerm = strings.ReplaceAll(fulltextTerm, "|", "")
 
@@ -3648,7 +3655,7 @@ func (s SqlChannelStore) buildFulltextClauseX(term string, searchColumns ...stri
 		// join the search term with &
 		fulltextTerm = strings.Join(splitTerm, " & ")
 
-		expr := fmt.Sprintf("((to_tsvector('%[1]s', %[2]s)) @@ to_tsquery('%[1]s', ?))", s.pgDefaultTextSearchConfig, strings.Join(searchColumns, " || ' ' || "))
+		expr := "(" + s.textSearchClause(s.pgDefaultTextSearchConfig, strings.Join(searchColumns, " || ' ' || "), "?") + ")"
 		return sq.Expr(expr, fulltextTerm)
 	}
 
@@ -3728,6 +3735,9 @@ func (s SqlChannelStore) searchGroupChannelsQuery(userId, term string, isPostgre
 	}
 
 	baseLikeTerm = "GROUP_CONCAT(u.Username SEPARATOR ', ') LIKE ?"
+	if s.DriverName() == model.DatabaseDriverSqlite {
+		baseLikeTerm = "GROUP_CONCAT(u.Username, ', ') LIKE ?"
+	}
 
 	for _, term := range terms {
 		term = sanitizeSearchTerm(term, "\\")
@@ -4080,7 +4090,7 @@ func (s SqlChannelStore) GetAllChannelsForExportAfter(limit int, afterId string)
 			sq.Gt{"Channels.Id": afterId},
 			sq.Eq{"Channels.Type": []model.ChannelType{model.ChannelTypeOpen, model.ChannelTypePrivate}},
 		}).
-		OrderBy("Id").
+		OrderBy("Channels.Id").
 		Limit(uint64(limit))
 
 	channels := []*model.ChannelForExport{}
@@ -4331,6 +4341,12 @@ func (s SqlChannelStore) GroupSyncedChannelCount() (int64, error) {
 
 // SetShared sets the Shared flag true/false
 func (s SqlChannelStore) SetShared(channelId string, shared bool) error {
+	return setChannelSharedT(s.GetMaster(), s.SqlStore, channelId, shared)
+}
+
+// setChannelSharedT is SetShared for callers updating the channel as part of their own
+// transaction.
+func setChannelSharedT(ex sqlxExecutor, s *SqlStore, channelId string, shared bool) error {
 	squery, args, err := s.getQueryBuilder().
 		Update("Channels").
 		Set("Shared", shared).
@@ -4340,7 +4356,7 @@ func (s SqlChannelStore) SetShared(channelId string, shared bool) error {
 		return errors.Wrap(err, "channel_set_shared_tosql")
 	}
 
-	result, err := s.GetMaster().Exec(squery, args...)
+	result, err := ex.Exec(squery, args...)
 	if err != nil {
 		return errors.Wrap(err, "failed to update `Shared` for Channels")
 	}
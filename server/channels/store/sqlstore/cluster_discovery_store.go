@@ -154,3 +154,52 @@ func (s sqlClusterDiscoveryStore) Cleanup() error {
 	}
 	return nil
 }
+
+func (s sqlClusterDiscoveryStore) AcquireLease(name, holderID string, durationMillis int64) (bool, error) {
+	// The current time of the database, in milliseconds.
+	now := "(EXTRACT(EPOCH FROM now()) * 1000)::bigint"
+	if s.DriverName() == model.DatabaseDriverSqlite {
+		now = "CAST((julianday('now') - 2440587.5) * 86400000 AS INTEGER)"
+	}
+
+	res, err := s.GetMaster().Exec(`
+		INSERT INTO
+			ClusterLeases
+			(Name, HolderId, ExpireAt)
+		VALUES
+			($1, $2, `+now+` + $3)
+		ON CONFLICT (Name) DO UPDATE SET
+			HolderId = EXCLUDED.HolderId,
+			ExpireAt = EXCLUDED.ExpireAt
+		WHERE
+			ClusterLeases.HolderId = EXCLUDED.HolderId
+			OR ClusterLeases.ExpireAt < `+now+`
+	`, name, holderID, durationMillis)
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to acquire ClusterLease with name=%s", name)
+	}
+
+	count, err := res.RowsAffected()
+	if err != nil {
+		return false, errors.Wrap(err, "failed to count rows affected")
+	}
+
+	return count != 0, nil
+}
+
+func (s sqlClusterDiscoveryStore) ReleaseLease(name, holderID string) error {
+	query := s.getQueryBuilder().
+		Delete("ClusterLeases").
+		Where(sq.Eq{"Name": name}).
+		Where(sq.Eq{"HolderId": holderID})
+
+	queryString, args, err := query.ToSql()
+	if err != nil {
+		return errors.Wrap(err, "cluster_lease_tosql")
+	}
+
+	if _, err := s.GetMaster().Exec(queryString, args...); err != nil {
+		return errors.Wrapf(err, "failed to release ClusterLease with name=%s", name)
+	}
+	return nil
+}
@@ -361,7 +361,7 @@ func (s SqlComplianceStore) MessageExport(rctx request.CTX, cursor model.Message
 	}
 
 	cposts := []*model.MessageExport{}
-	if err := s.GetReplica().SelectBuilderCtx(rctx.Context(), &cposts, builder); err != nil {
+	if err := s.DBXFromContext(rctx.Context()).SelectBuilderCtx(rctx.Context(), &cposts, builder); err != nil {
 		return nil, cursor, errors.Wrap(err, "unable to export messages")
 	}
 	if len(cposts) > 0 {
@@ -31,6 +31,9 @@ func HasMaster(ctx context.Context) bool {
 // DBXFromContext is a helper utility that returns the sqlx DB handle from a given context.
 func (ss *SqlStore) DBXFromContext(ctx context.Context) *sqlxDBWrapper {
 	if HasMaster(ctx) {
+		if store.HasReadYourWrites(ctx) {
+			ss.countReplicaReadRedirect(ReplicaReadRedirectReadYourWrites)
+		}
 		return ss.GetMaster()
 	}
 	return ss.GetReplica()
@@ -240,6 +240,11 @@ func (s *SqlDraftStore) determineMaxDraftSize() int {
 		`); err != nil {
 			mlog.Warn("Unable to determine the maximum supported draft size", mlog.Err(err))
 		}
+	} else if s.DriverName() == model.DatabaseDriverSqlite {
+		var err error
+		if maxDraftSizeBytes, err = s.sqliteColumnSize("Drafts", "Message"); err != nil {
+			mlog.Warn("Unable to determine the maximum supported draft size", mlog.Err(err))
+		}
 	} else {
 		mlog.Warn("No implementation found to determine the maximum supported draft size")
 	}
@@ -309,6 +314,24 @@ func (s *SqlDraftStore) DeleteEmptyDraftsByCreateAtAndUserId(createAt int64, use
 			Where("d.ChannelId = dd.ChannelId").
 			Where("d.RootId = dd.RootId").
 			Where("d.Message = ''")
+	} else if s.DriverName() == model.DatabaseDriverSqlite {
+		// SQLite has no DELETE ... USING, the batch is matched on the key instead.
+		batch := s.getSubQueryBuilder().
+			Select("UserId", "ChannelId", "RootId").
+			From("Drafts").
+			Where(sq.Or{
+				sq.Gt{"CreateAt": createAt},
+				sq.And{
+					sq.Eq{"CreateAt": createAt},
+					sq.Gt{"UserId": userId},
+				},
+			}).
+			OrderBy("CreateAt", "UserId").
+			Limit(100)
+		builder = s.getQueryBuilder().
+			Delete("Drafts").
+			Where(sq.Expr("(UserId, ChannelId, RootId) IN (?)", batch)).
+			Where("Message = ''")
 	}
 
 	if _, err := s.GetMaster().ExecBuilder(builder); err != nil {
@@ -343,6 +366,24 @@ func (s *SqlDraftStore) DeleteOrphanDraftsByCreateAtAndUserId(createAt int64, us
 			Where("d.ChannelId = dd.ChannelId").
 			Where("d.RootId = dd.RootId").
 			Suffix("AND (d.RootId IN (SELECT Id FROM Posts WHERE DeleteAt <> 0) OR NOT EXISTS (SELECT 1 FROM Posts WHERE Posts.Id = d.RootId))")
+	} else if s.DriverName() == model.DatabaseDriverSqlite {
+		// SQLite has no DELETE ... USING, the batch is matched on the key instead.
+		batch := s.getSubQueryBuilder().
+			Select("UserId", "ChannelId", "RootId").
+			From("Drafts").
+			Where(sq.Or{
+				sq.Gt{"CreateAt": createAt},
+				sq.And{
+					sq.Eq{"CreateAt": createAt},
+					sq.Gt{"UserId": userId},
+				},
+			}).
+			OrderBy("CreateAt", "UserId").
+			Limit(100)
+		builder = s.getQueryBuilder().
+			Delete("Drafts").
+			Where(sq.Expr("(UserId, ChannelId, RootId) IN (?)", batch)).
+			Where("(RootId IN (SELECT Id FROM Posts WHERE DeleteAt <> 0) OR NOT EXISTS (SELECT 1 FROM Posts WHERE Posts.Id = Drafts.RootId))")
 	}
 
 	if _, err := s.GetMaster().ExecBuilder(builder); err != nil {
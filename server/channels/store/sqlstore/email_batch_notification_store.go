@@ -0,0 +1,95 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	sq "github.com/mattermost/squirrel"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+type SqlEmailBatchNotificationStore struct {
+	*SqlStore
+}
+
+func newSqlEmailBatchNotificationStore(sqlStore *SqlStore) store.EmailBatchNotificationStore {
+	return &SqlEmailBatchNotificationStore{
+		SqlStore: sqlStore,
+	}
+}
+
+func (s *SqlEmailBatchNotificationStore) Save(notification *model.EmailBatchNotification) (*model.EmailBatchNotification, error) {
+	notification.PreSave()
+
+	builder := s.getQueryBuilder().
+		Insert("EmailBatchNotifications").
+		Columns("Id", "UserId", "PostId", "ChannelId", "RootId", "TeamName", "CreateAt").
+		Values(notification.Id, notification.UserId, notification.PostId, notification.ChannelId, notification.RootId, notification.TeamName, notification.CreateAt)
+
+	if _, err := s.GetMaster().ExecBuilder(builder); err != nil {
+		return nil, errors.Wrapf(err, "failed to save EmailBatchNotification with id=%s", notification.Id)
+	}
+
+	return notification, nil
+}
+
+// GetUserIds returns, ordered by id, the users with pending notifications whose id is after the given one.
+func (s *SqlEmailBatchNotificationStore) GetUserIds(afterUserID string, limit int) ([]string, error) {
+	builder := s.getQueryBuilder().
+		Select("DISTINCT UserId").
+		From("EmailBatchNotifications").
+		Where(sq.Gt{"UserId": afterUserID}).
+		OrderBy("UserId").
+		Limit(uint64(limit))
+
+	userIDs := []string{}
+	// The notifications are deleted once sent, and must not be read from a lagging replica.
+	if err := s.GetMaster().SelectBuilder(&userIDs, builder); err != nil {
+		return nil, errors.Wrap(err, "failed to get the users with pending EmailBatchNotifications")
+	}
+
+	return userIDs, nil
+}
+
+// GetForUser returns the pending notifications of the user, oldest first.
+func (s *SqlEmailBatchNotificationStore) GetForUser(userID string) ([]*model.EmailBatchNotification, error) {
+	builder := s.getQueryBuilder().
+		Select("Id", "UserId", "PostId", "ChannelId", "RootId", "TeamName", "CreateAt").
+		From("EmailBatchNotifications").
+		Where(sq.Eq{"UserId": userID}).
+		OrderBy("CreateAt", "Id")
+
+	notifications := []*model.EmailBatchNotification{}
+	if err := s.GetMaster().SelectBuilder(&notifications, builder); err != nil {
+		return nil, errors.Wrapf(err, "failed to get EmailBatchNotifications for userId=%s", userID)
+	}
+
+	return notifications, nil
+}
+
+func (s *SqlEmailBatchNotificationStore) Delete(ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	builder := s.getQueryBuilder().
+		Delete("EmailBatchNotifications").
+		Where(sq.Eq{"Id": ids})
+
+	if _, err := s.GetMaster().ExecBuilder(builder); err != nil {
+		return errors.Wrap(err, "failed to delete EmailBatchNotifications")
+	}
+
+	return nil
+}
+
+func (s *SqlEmailBatchNotificationStore) PermanentDeleteByUser(userID string) error {
+	if _, err := s.GetMaster().Exec("DELETE FROM EmailBatchNotifications WHERE UserId = ?", userID); err != nil {
+		return errors.Wrapf(err, "failed to delete EmailBatchNotifications with userId=%s", userID)
+	}
+
+	return nil
+}
@@ -87,6 +87,25 @@ func (es SqlEmojiStore) GetList(offset, limit int, sort string) ([]*model.Emoji,
 	return emojis, nil
 }
 
+func (es SqlEmojiStore) GetAllAfter(createAt int64, id string, limit int) ([]*model.Emoji, error) {
+	query := es.emojiSelectQuery.
+		Where(sq.Or{
+			sq.Gt{"CreateAt": createAt},
+			sq.And{
+				sq.Eq{"CreateAt": createAt},
+				sq.Gt{"Id": id},
+			},
+		}).
+		OrderBy("CreateAt ASC", "Id ASC").
+		Limit(uint64(limit))
+
+	emojis := []*model.Emoji{}
+	if err := es.GetReplica().SelectBuilder(&emojis, query); err != nil {
+		return nil, errors.Wrap(err, "could not get emojis")
+	}
+	return emojis, nil
+}
+
 func (es SqlEmojiStore) Delete(emoji *model.Emoji, time int64) error {
 	if sqlResult, err := es.GetMaster().Exec(
 		`UPDATE
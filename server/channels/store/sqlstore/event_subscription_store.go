@@ -0,0 +1,150 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+
+	sq "github.com/mattermost/squirrel"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+type SqlEventSubscriptionStore struct {
+	*SqlStore
+
+	selectQuery sq.SelectBuilder
+}
+
+func newSqlEventSubscriptionStore(sqlStore *SqlStore) store.EventSubscriptionStore {
+	s := &SqlEventSubscriptionStore{
+		SqlStore: sqlStore,
+	}
+
+	s.selectQuery = s.getQueryBuilder().
+		Select(
+			"Id",
+			"CreateAt",
+			"UpdateAt",
+			"DeleteAt",
+			"CreatorId",
+			"TeamId",
+			"ChannelId",
+			"DisplayName",
+			"Description",
+			"CallbackURL",
+			"Secret",
+			"Events",
+		).
+		From("EventSubscriptions")
+
+	return s
+}
+
+func (s *SqlEventSubscriptionStore) Save(subscription *model.EventSubscription) (*model.EventSubscription, error) {
+	if subscription.Id != "" {
+		return nil, store.NewErrInvalidInput("EventSubscription", "id", subscription.Id)
+	}
+
+	subscription.PreSave()
+	if err := subscription.IsValid(); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.GetMaster().NamedExec(`INSERT INTO EventSubscriptions
+		(Id, CreateAt, UpdateAt, DeleteAt, CreatorId, TeamId, ChannelId, DisplayName, Description, CallbackURL, Secret, Events)
+		VALUES
+		(:Id, :CreateAt, :UpdateAt, :DeleteAt, :CreatorId, :TeamId, :ChannelId, :DisplayName, :Description, :CallbackURL, :Secret, :Events)`, subscription); err != nil {
+		return nil, errors.Wrapf(err, "failed to save EventSubscription with id=%s", subscription.Id)
+	}
+
+	return subscription, nil
+}
+
+func (s *SqlEventSubscriptionStore) Update(subscription *model.EventSubscription) (*model.EventSubscription, error) {
+	subscription.PreUpdate()
+	if err := subscription.IsValid(); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.GetMaster().NamedExec(`UPDATE EventSubscriptions SET
+			UpdateAt=:UpdateAt, DeleteAt=:DeleteAt, TeamId=:TeamId, ChannelId=:ChannelId, DisplayName=:DisplayName,
+			Description=:Description, CallbackURL=:CallbackURL, Secret=:Secret, Events=:Events
+			WHERE Id=:Id`, subscription); err != nil {
+		return nil, errors.Wrapf(err, "failed to update EventSubscription with id=%s", subscription.Id)
+	}
+
+	return subscription, nil
+}
+
+func (s *SqlEventSubscriptionStore) Get(id string) (*model.EventSubscription, error) {
+	var subscription model.EventSubscription
+
+	query := s.selectQuery.Where(sq.Eq{"Id": id, "DeleteAt": 0})
+	if err := s.GetReplica().GetBuilder(&subscription, query); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, store.NewErrNotFound("EventSubscription", id)
+		}
+		return nil, errors.Wrapf(err, "failed to get EventSubscription with id=%s", id)
+	}
+
+	return &subscription, nil
+}
+
+func (s *SqlEventSubscriptionStore) GetList(teamID string, offset, limit int) ([]*model.EventSubscription, error) {
+	subscriptions := []*model.EventSubscription{}
+
+	query := s.selectQuery.
+		Where(sq.Eq{"DeleteAt": 0}).
+		OrderBy("CreateAt", "Id").
+		Limit(uint64(limit)).
+		Offset(uint64(offset))
+
+	if teamID != "" {
+		query = query.Where(sq.Eq{"TeamId": teamID})
+	}
+
+	if err := s.GetReplica().SelectBuilder(&subscriptions, query); err != nil {
+		return nil, errors.Wrap(err, "failed to find EventSubscriptions")
+	}
+
+	return subscriptions, nil
+}
+
+func (s *SqlEventSubscriptionStore) GetForTeam(teamID string) ([]*model.EventSubscription, error) {
+	subscriptions := []*model.EventSubscription{}
+
+	query := s.selectQuery.
+		Where(sq.And{
+			sq.Eq{"DeleteAt": 0},
+			sq.Or{
+				sq.Eq{"TeamId": ""},
+				sq.Eq{"TeamId": teamID},
+			},
+		})
+
+	if err := s.GetReplica().SelectBuilder(&subscriptions, query); err != nil {
+		return nil, errors.Wrapf(err, "failed to find EventSubscriptions for teamId=%s", teamID)
+	}
+
+	return subscriptions, nil
+}
+
+func (s *SqlEventSubscriptionStore) Delete(id string, deleteAt int64) error {
+	if _, err := s.GetMaster().Exec("UPDATE EventSubscriptions SET DeleteAt = ?, UpdateAt = ? WHERE Id = ?", deleteAt, deleteAt, id); err != nil {
+		return errors.Wrapf(err, "failed to delete EventSubscription with id=%s", id)
+	}
+
+	return nil
+}
+
+func (s *SqlEventSubscriptionStore) PermanentDeleteByUser(userID string) error {
+	if _, err := s.GetMaster().Exec("DELETE FROM EventSubscriptions WHERE CreatorId = ?", userID); err != nil {
+		return errors.Wrapf(err, "failed to delete EventSubscriptions with creatorId=%s", userID)
+	}
+
+	return nil
+}
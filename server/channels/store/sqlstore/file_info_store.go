@@ -495,9 +495,12 @@ func (fs SqlFileInfoStore) PermanentDelete(rctx request.CTX, fileId string) erro
 
 func (fs SqlFileInfoStore) PermanentDeleteBatch(rctx request.CTX, endTime int64, limit int64) (int64, error) {
 	var query string
-	if fs.DriverName() == "postgres" {
+	switch fs.DriverName() {
+	case model.DatabaseDriverPostgres:
 		query = "DELETE from FileInfo WHERE Id = any (array (SELECT Id FROM FileInfo WHERE CreateAt < ? AND CreatorId != ? LIMIT ?))"
-	} else {
+	case model.DatabaseDriverSqlite:
+		query = "DELETE from FileInfo WHERE Id IN (SELECT Id FROM FileInfo WHERE CreateAt < ? AND CreatorId != ? LIMIT ?)"
+	default:
 		query = "DELETE from FileInfo WHERE CreateAt < ? AND CreatorId != ? LIMIT ?"
 	}
 
@@ -631,7 +634,7 @@ func (fs SqlFileInfoStore) Search(rctx request.CTX, paramsList []*model.SearchPa
 
 		if terms == "" && excludedTerms == "" {
 			// we've already confirmed that we have a channel or user to search for
-		} else if fs.DriverName() == model.DatabaseDriverPostgres {
+		} else if fs.DriverName() == model.DatabaseDriverPostgres || fs.DriverName() == model.DatabaseDriverSqlite {
 			// Parse text for wildcards
 			if wildcard, err := regexp.Compile(`\*($| )`); err == nil {
 				terms = wildcard.ReplaceAllLiteralString(terms, ":* ")
@@ -651,9 +654,9 @@ func (fs SqlFileInfoStore) Search(rctx request.CTX, paramsList []*model.SearchPa
 			}
 
 			query = query.Where(sq.Or{
-				sq.Expr(fmt.Sprintf("to_tsvector('%[1]s', FileInfo.Name) @@  to_tsquery('%[1]s', ?)", fs.pgDefaultTextSearchConfig), queryTerms),
-				sq.Expr(fmt.Sprintf("to_tsvector('%[1]s', Translate(FileInfo.Name, '.,-', '   ')) @@  to_tsquery('%[1]s', ?)", fs.pgDefaultTextSearchConfig), queryTerms),
-				sq.Expr(fmt.Sprintf("to_tsvector('%[1]s', FileInfo.Content) @@  to_tsquery('%[1]s', ?)", fs.pgDefaultTextSearchConfig), queryTerms),
+				sq.Expr(fs.textSearchClause(fs.pgDefaultTextSearchConfig, "FileInfo.Name", "?"), queryTerms),
+				sq.Expr(fs.textSearchClause(fs.pgDefaultTextSearchConfig, "Translate(FileInfo.Name, '.,-', '   ')", "?"), queryTerms),
+				sq.Expr(fs.textSearchClause(fs.pgDefaultTextSearchConfig, "FileInfo.Content", "?"), queryTerms),
 			})
 		}
 	}
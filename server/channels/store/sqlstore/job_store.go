@@ -13,6 +13,7 @@ import (
 	"github.com/lib/pq"
 	sq "github.com/mattermost/squirrel"
 	"github.com/pkg/errors"
+	sqlite3 "modernc.org/sqlite/lib"
 
 	"github.com/mattermost/mattermost/server/public/model"
 	"github.com/mattermost/mattermost/server/public/shared/request"
@@ -233,7 +234,7 @@ func (jss SqlJobStore) Get(rctx request.CTX, id string) (*model.Job, error) {
 	}
 
 	var status model.Job
-	if err = jss.GetReplica().Get(&status, query, args...); err != nil {
+	if err = jss.DBXFromContext(rctx.Context()).Get(&status, query, args...); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, store.NewErrNotFound("Job", id)
 		}
@@ -255,7 +256,7 @@ func (jss SqlJobStore) GetAllByTypesPage(rctx request.CTX, jobTypes []string, pa
 	}
 
 	var jobs []*model.Job
-	if err = jss.GetReplica().Select(&jobs, query, args...); err != nil {
+	if err = jss.DBXFromContext(rctx.Context()).Select(&jobs, query, args...); err != nil {
 		return nil, errors.Wrapf(err, "failed to find Jobs with types")
 	}
 
@@ -271,7 +272,7 @@ func (jss SqlJobStore) GetAllByType(rctx request.CTX, jobType string) ([]*model.
 	}
 
 	statuses := []*model.Job{}
-	if err = jss.GetReplica().Select(&statuses, query, args...); err != nil {
+	if err = jss.DBXFromContext(rctx.Context()).Select(&statuses, query, args...); err != nil {
 		return nil, errors.Wrapf(err, "failed to find Jobs with type=%s", jobType)
 	}
 
@@ -287,7 +288,7 @@ func (jss SqlJobStore) GetAllByTypeAndStatus(rctx request.CTX, jobType string, s
 	}
 
 	jobs := []*model.Job{}
-	if err = jss.GetReplica().Select(&jobs, query, args...); err != nil {
+	if err = jss.DBXFromContext(rctx.Context()).Select(&jobs, query, args...); err != nil {
 		return nil, errors.Wrapf(err, "failed to find Jobs with type=%s", jobType)
 	}
 
@@ -306,7 +307,7 @@ func (jss SqlJobStore) GetAllByTypePage(rctx request.CTX, jobType string, page i
 	}
 
 	statuses := []*model.Job{}
-	if err = jss.GetReplica().Select(&statuses, query, args...); err != nil {
+	if err = jss.DBXFromContext(rctx.Context()).Select(&statuses, query, args...); err != nil {
 		return nil, errors.Wrapf(err, "failed to find Jobs with type=%s", jobType)
 	}
 
@@ -322,7 +323,7 @@ func (jss SqlJobStore) GetAllByStatus(rctx request.CTX, status string) ([]*model
 		return nil, errors.Wrap(err, "job_tosql")
 	}
 
-	if err = jss.GetReplica().Select(&statuses, query, args...); err != nil {
+	if err = jss.DBXFromContext(rctx.Context()).Select(&statuses, query, args...); err != nil {
 		return nil, errors.Wrapf(err, "failed to find Jobs with status=%s", status)
 	}
 
@@ -340,7 +341,7 @@ func (jss SqlJobStore) GetAllByTypesAndStatusesPage(rctx request.CTX, jobType []
 	}
 
 	jobs := []*model.Job{}
-	if err = jss.GetReplica().Select(&jobs, query, args...); err != nil {
+	if err = jss.DBXFromContext(rctx.Context()).Select(&jobs, query, args...); err != nil {
 		return nil, errors.Wrapf(err, "failed to find Jobs with types=%s and statuses=%s", strings.Join(jobType, ","), strings.Join(status, ","))
 	}
 
@@ -407,7 +408,7 @@ func (jss SqlJobStore) GetByTypeAndData(rctx request.CTX, jobType string, data m
 
 	var jobs []*model.Job
 	// For consistency-critical operations (like job deduplication), use master
-	db := jss.GetReplica()
+	db := jss.DBXFromContext(rctx.Context())
 	if useMaster {
 		db = jss.GetMaster()
 	}
@@ -435,7 +436,7 @@ func (jss SqlJobStore) Delete(id string) (string, error) {
 
 func (jss SqlJobStore) Cleanup(expiryTime int64, batchSize int) error {
 	var query string
-	if jss.DriverName() == model.DatabaseDriverPostgres {
+	if jss.DriverName() == model.DatabaseDriverPostgres || jss.DriverName() == model.DatabaseDriverSqlite {
 		query = "DELETE FROM Jobs WHERE Id IN (SELECT Id FROM Jobs WHERE CreateAt < ? AND (Status != ? AND Status != ?) ORDER BY CreateAt ASC LIMIT ?)"
 	} else {
 		query = "DELETE FROM Jobs WHERE CreateAt < ? AND (Status != ? AND Status != ?) ORDER BY CreateAt ASC LIMIT ?"
@@ -471,6 +472,8 @@ func isRepeatableError(err error) bool {
 		if pqErr.Code == "40001" || pqErr.Code == "40P01" {
 			return true
 		}
+	case isSqliteErrorCode(err, sqlite3.SQLITE_BUSY):
+		return true
 	}
 	return false
 }
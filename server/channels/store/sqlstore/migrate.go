@@ -19,6 +19,7 @@ import (
 	"github.com/mattermost/morph"
 	"github.com/mattermost/morph/drivers"
 	ps "github.com/mattermost/morph/drivers/postgres"
+	sqlitedriver "github.com/mattermost/morph/drivers/sqlite"
 	"github.com/mattermost/morph/models"
 	mbindata "github.com/mattermost/morph/sources/embedded"
 )
@@ -115,6 +116,8 @@ func (ss *SqlStore) initMorph(dryRun, enableLogging bool) (*morph.Morph, error)
 	switch ss.DriverName() {
 	case model.DatabaseDriverPostgres:
 		driver, err = ps.WithInstance(ss.GetMaster().DB.DB)
+	case model.DatabaseDriverSqlite:
+		driver, err = sqlitedriver.WithInstance(ss.GetMaster().DB.DB)
 	default:
 		err = fmt.Errorf("unsupported database type %s for migration", ss.DriverName())
 	}
@@ -131,10 +134,13 @@ func (ss *SqlStore) initMorph(dryRun, enableLogging bool) (*morph.Morph, error)
 
 	opts := []morph.EngineOption{
 		morph.WithLogger(log.New(logWriter, "", log.Lshortfile)),
-		morph.WithLock("mm-lock-key"),
 		morph.SetStatementTimeoutInSeconds(*ss.settings.MigrationsStatementTimeoutSeconds),
 		morph.SetDryRun(dryRun),
 	}
+	// A SQLite database is only used by a single server, there are no other nodes to lock out.
+	if ss.DriverName() != model.DatabaseDriverSqlite {
+		opts = append(opts, morph.WithLock("mm-lock-key"))
+	}
 
 	engine, err := morph.New(context.Background(), driver, src, opts...)
 	if err != nil {
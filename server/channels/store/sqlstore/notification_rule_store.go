@@ -0,0 +1,111 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	sq "github.com/mattermost/squirrel"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+type SqlNotificationRuleStore struct {
+	*SqlStore
+
+	notificationRuleSelectQuery sq.SelectBuilder
+}
+
+func newSqlNotificationRuleStore(sqlStore *SqlStore) store.NotificationRuleStore {
+	s := &SqlNotificationRuleStore{
+		SqlStore: sqlStore,
+	}
+
+	s.notificationRuleSelectQuery = s.getQueryBuilder().
+		Select("nr.Id", "nr.UserId", "nr.Name", "nr.Enabled", "nr.SortOrder", "nr.Conditions", "nr.Action", "nr.CreateAt", "nr.UpdateAt").
+		From("NotificationRules nr")
+
+	return s
+}
+
+func (s *SqlNotificationRuleStore) GetForUser(userID string) ([]*model.NotificationRule, error) {
+	builder := s.notificationRuleSelectQuery.
+		Where(sq.Eq{"nr.UserId": userID}).
+		OrderBy("nr.SortOrder", "nr.Id")
+
+	rules := []*model.NotificationRule{}
+	if err := s.GetReplica().SelectBuilder(&rules, builder); err != nil {
+		return nil, errors.Wrapf(err, "failed to get NotificationRules with userId=%s", userID)
+	}
+
+	return rules, nil
+}
+
+func (s *SqlNotificationRuleStore) GetEnabledForChannel(channelID string) (map[string][]*model.NotificationRule, error) {
+	builder := s.notificationRuleSelectQuery.
+		Join("ChannelMembers cm ON cm.UserId = nr.UserId").
+		Where(sq.Eq{"cm.ChannelId": channelID}).
+		Where(sq.Eq{"nr.Enabled": true}).
+		OrderBy("nr.UserId", "nr.SortOrder", "nr.Id")
+
+	rules := []*model.NotificationRule{}
+	if err := s.GetReplica().SelectBuilder(&rules, builder); err != nil {
+		return nil, errors.Wrapf(err, "failed to get NotificationRules with channelId=%s", channelID)
+	}
+
+	rulesByUser := make(map[string][]*model.NotificationRule)
+	for _, rule := range rules {
+		rulesByUser[rule.UserId] = append(rulesByUser[rule.UserId], rule)
+	}
+
+	return rulesByUser, nil
+}
+
+func (s *SqlNotificationRuleStore) ReplaceForUser(userID string, rules []*model.NotificationRule) (_ []*model.NotificationRule, err error) {
+	for i, rule := range rules {
+		rule.UserId = userID
+		rule.SortOrder = int64(i)
+		rule.PreSave()
+		if appErr := rule.IsValid(); appErr != nil {
+			return nil, appErr
+		}
+	}
+
+	transaction, err := s.GetMaster().Beginx()
+	if err != nil {
+		return nil, errors.Wrap(err, "begin_transaction")
+	}
+	defer finalizeTransactionX(transaction, &err)
+
+	if _, err = transaction.Exec("DELETE FROM NotificationRules WHERE UserId = ?", userID); err != nil {
+		return nil, errors.Wrapf(err, "failed to delete NotificationRules with userId=%s", userID)
+	}
+
+	if len(rules) > 0 {
+		builder := s.getQueryBuilder().
+			Insert("NotificationRules").
+			Columns("Id", "UserId", "Name", "Enabled", "SortOrder", "Conditions", "Action", "CreateAt", "UpdateAt")
+		for _, rule := range rules {
+			builder = builder.Values(rule.Id, rule.UserId, rule.Name, rule.Enabled, rule.SortOrder, rule.Conditions, rule.Action, rule.CreateAt, rule.UpdateAt)
+		}
+
+		if _, err = transaction.ExecBuilder(builder); err != nil {
+			return nil, errors.Wrapf(err, "failed to save NotificationRules with userId=%s", userID)
+		}
+	}
+
+	if err = transaction.Commit(); err != nil {
+		return nil, errors.Wrap(err, "commit_transaction")
+	}
+
+	return rules, nil
+}
+
+func (s *SqlNotificationRuleStore) PermanentDeleteByUser(userID string) error {
+	if _, err := s.GetMaster().Exec("DELETE FROM NotificationRules WHERE UserId = ?", userID); err != nil {
+		return errors.Wrapf(err, "failed to delete NotificationRules with userId=%s", userID)
+	}
+
+	return nil
+}
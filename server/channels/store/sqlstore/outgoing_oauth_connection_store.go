@@ -98,7 +98,7 @@ func (s *SqlOutgoingOAuthConnectionStore) GetConnection(rctx request.CTX, id str
 	conn := &model.OutgoingOAuthConnection{}
 	query := s.tableSelectQuery.Where(sq.Eq{"Id": id})
 
-	if err := s.GetReplica().GetBuilder(conn, query); err != nil {
+	if err := s.DBXFromContext(rctx.Context()).GetBuilder(conn, query); err != nil {
 		if err == sql.ErrNoRows {
 			return nil, store.NewErrNotFound("OutgoingOAuthConnection", id)
 		}
@@ -121,7 +121,7 @@ func (s *SqlOutgoingOAuthConnectionStore) GetConnections(rctx request.CTX, filte
 		query = query.Where(sq.Like{"Audiences": fmt.Sprint("%", filters.Audience, "%")})
 	}
 
-	if err := s.GetReplica().SelectBuilderCtx(rctx.Context(), &conns, query); err != nil {
+	if err := s.DBXFromContext(rctx.Context()).SelectBuilderCtx(rctx.Context(), &conns, query); err != nil {
 		return nil, errors.Wrap(err, "failed to get OutgoingOAuthConnections")
 	}
 
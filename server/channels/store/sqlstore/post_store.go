@@ -598,7 +598,7 @@ func (s *SqlPostStore) getPostWithCollapsedThreads(rctx request.CTX, id, userID
 		return nil, errors.Wrap(err, "getPostWithCollapsedThreads_ToSql2")
 	}
 
-	err = s.GetReplica().Get(&post, postFetchQuery, args...)
+	err = s.DBXFromContext(rctx.Context()).Get(&post, postFetchQuery, args...)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, store.NewErrNotFound("Post", id)
@@ -680,7 +680,7 @@ func (s *SqlPostStore) getPostWithCollapsedThreads(rctx request.CTX, id, userID
 	if err != nil {
 		return nil, errors.Wrap(err, "getPostWithCollapsedThreads_Tosql2")
 	}
-	err = s.GetReplica().Select(&posts, sql, args...)
+	err = s.DBXFromContext(rctx.Context()).Select(&posts, sql, args...)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to find Posts for thread %s", id)
 	}
@@ -849,7 +849,7 @@ func (s *SqlPostStore) Get(rctx request.CTX, id string, opts model.GetPostsOptio
 		}
 
 		posts := []*model.Post{}
-		err = s.GetReplica().Select(&posts, sql, args...)
+		err = s.DBXFromContext(rctx.Context()).Select(&posts, sql, args...)
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to find Posts")
 		}
@@ -963,11 +963,19 @@ func (s *SqlPostStore) Delete(rctx request.CTX, postID string, time int64, delet
 		return errors.Wrapf(err, "failed to delete Post with id=%s", postID)
 	}
 
-	_, err = transaction.Exec(`UPDATE Posts
+	if s.DriverName() == model.DatabaseDriverSqlite {
+		_, err = transaction.Exec(`UPDATE Posts
+			SET DeleteAt = $1,
+				UpdateAt = $1,
+				Props = json_set(Props, $2, $3)
+			WHERE Id = $4 OR RootId = $4`, time, "$."+model.PostPropsDeleteBy, deleteByID, postID)
+	} else {
+		_, err = transaction.Exec(`UPDATE Posts
 			SET DeleteAt = $1,
 				UpdateAt = $1,
 				Props = jsonb_set(Props, $2, $3)
 			WHERE Id = $4 OR RootId = $4`, time, jsonKeyPath(model.PostPropsDeleteBy), jsonStringVal(deleteByID), postID)
+	}
 
 	if err != nil {
 		return errors.Wrap(err, "failed to update Posts")
@@ -1260,7 +1268,7 @@ func (s *SqlPostStore) getPostsCollapsedThreads(rctx request.CTX, options model.
 		Offset(uint64(offset)).
 		OrderBy("Posts.CreateAt DESC").ToSql()
 
-	err := s.GetReplica().Select(&posts, postFetchQuery, args...)
+	err := s.DBXFromContext(rctx.Context()).Select(&posts, postFetchQuery, args...)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to find Posts with channelId=%s", options.ChannelId)
 	}
@@ -1348,7 +1356,7 @@ func (s *SqlPostStore) getPostsSinceCollapsedThreads(rctx request.CTX, options m
 		return nil, errors.Wrapf(err, "getPostsSinceCollapsedThreads_ToSql")
 	}
 
-	err = s.GetReplica().Select(&posts, postFetchQuery, args...)
+	err = s.DBXFromContext(rctx.Context()).Select(&posts, postFetchQuery, args...)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to find Posts with channelId=%s", options.ChannelId)
 	}
@@ -1384,13 +1392,13 @@ func (s *SqlPostStore) GetPostsSince(rctx request.CTX, options model.GetPostsSin
 	WHERE
 	       UpdateAt > ? AND ChannelId = ?
 	       LIMIT 1000)
-	(SELECT *` + replyCountQuery2 + ` FROM cte)
+	SELECT *` + replyCountQuery2 + ` FROM cte
 	UNION
-	(SELECT *` + replyCountQuery1 + ` FROM Posts p1 WHERE id in (SELECT rootid FROM cte))
+	SELECT *` + replyCountQuery1 + ` FROM Posts p1 WHERE id in (SELECT rootid FROM cte)
 	ORDER BY CreateAt ` + order
 
 	params = []any{options.Time, options.ChannelId}
-	err := s.GetReplica().Select(&posts, query, params...)
+	err := s.DBXFromContext(rctx.Context()).Select(&posts, query, params...)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to find Posts with channelId=%s", options.ChannelId)
 	}
@@ -1557,7 +1565,7 @@ func (s *SqlPostStore) GetPostsForReporting(rctx request.CTX, queryParams model.
 
 	// Execute query on replica
 	posts := []*model.Post{}
-	if err := s.GetReplica().SelectBuilder(&posts, query); err != nil {
+	if err := s.DBXFromContext(rctx.Context()).SelectBuilder(&posts, query); err != nil {
 		return nil, errors.Wrap(err, "failed to get posts for reporting")
 	}
 
@@ -1622,6 +1630,28 @@ func (s *SqlPostStore) GetPostsByThread(threadId string, since int64) ([]*model.
 	return result, nil
 }
 
+func (s *SqlPostStore) GetRecentPostsForUser(userID string, since int64, limit int) ([]*model.Post, error) {
+	query := s.getQueryBuilder().
+		Select("p.*").
+		From("Posts p").
+		Join("ChannelMembers cm ON cm.ChannelId = p.ChannelId").
+		Where(sq.Eq{"cm.UserId": userID}).
+		Where(sq.NotEq{"p.UserId": userID}).
+		Where(sq.Eq{"p.DeleteAt": 0}).
+		Where(sq.GtOrEq{"p.CreateAt": since}).
+		Where(sq.NotLike{"p.Type": model.PostSystemMessagePrefix + "%"}).
+		OrderBy("p.CreateAt DESC").
+		Limit(uint64(limit))
+
+	result := []*model.Post{}
+	err := s.GetReplica().SelectBuilder(&result, query)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to fetch recent posts for userId=%s", userID)
+	}
+
+	return result, nil
+}
+
 func (s *SqlPostStore) getPostsAround(rctx request.CTX, before bool, options model.GetPostsOptions, sanitizeOptions map[string]bool) (*model.PostList, error) {
 	if options.Page < 0 {
 		return nil, store.NewErrInvalidInput("Post", "<options.Page>", options.Page)
@@ -1685,7 +1715,7 @@ func (s *SqlPostStore) getPostsAround(rctx request.CTX, before bool, options mod
 	if err != nil {
 		return nil, errors.Wrap(err, "post_tosql")
 	}
-	err = s.GetReplica().Select(&posts, queryString, args...)
+	err = s.DBXFromContext(rctx.Context()).Select(&posts, queryString, args...)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to find Posts with channelId=%s", options.ChannelId)
 	}
@@ -1723,7 +1753,7 @@ func (s *SqlPostStore) getPostsAround(rctx request.CTX, before bool, options mod
 		if nErr != nil {
 			return nil, errors.Wrap(nErr, "post_tosql")
 		}
-		nErr = s.GetReplica().Select(&parents, rootQueryString, rootArgs...)
+		nErr = s.DBXFromContext(rctx.Context()).Select(&parents, rootQueryString, rootArgs...)
 		if nErr != nil {
 			return nil, errors.Wrapf(nErr, "failed to find Posts with channelId=%s", options.ChannelId)
 		}
@@ -2220,7 +2250,7 @@ func (s *SqlPostStore) search(teamId string, userId string, params *model.Search
 			textSearchCfg = "simple"
 		}
 
-		searchClause := fmt.Sprintf("to_tsvector('%[1]s', %[2]s) @@  to_tsquery('%[1]s', ?)", textSearchCfg, searchType)
+		searchClause := s.textSearchClause(textSearchCfg, searchType, "?")
 		baseQuery = baseQuery.Where(searchClause, tsQueryClause)
 	}
 
@@ -2282,9 +2312,10 @@ func (s *SqlPostStore) search(teamId string, userId string, params *model.Search
 // TODO: convert to squirrel HW
 func (s *SqlPostStore) AnalyticsUserCountsWithPostsByDay(teamId string) (model.AnalyticsRows, error) {
 	var args []any
+	day := s.postCreateDayExpr()
 	query :=
 		`SELECT DISTINCT
-		        DATE(FROM_UNIXTIME(Posts.CreateAt / 1000)) AS Name,
+		        ` + day + ` AS Name,
 		        COUNT(DISTINCT Posts.UserId) AS Value
 		FROM Posts`
 
@@ -2296,7 +2327,7 @@ func (s *SqlPostStore) AnalyticsUserCountsWithPostsByDay(teamId string) (model.A
 	}
 
 	query += ` Posts.CreateAt >= ? AND Posts.CreateAt <= ?
-		GROUP BY DATE(FROM_UNIXTIME(Posts.CreateAt / 1000))
+		GROUP BY ` + day + `
 		ORDER BY Name DESC
 		LIMIT 30`
 
@@ -2408,9 +2439,10 @@ func (s *SqlPostStore) AnalyticsPostCountsByDay(options *model.AnalyticsPostCoun
 	}
 
 	var args []any
+	day := s.postCreateDayExpr()
 	query :=
 		`SELECT
-		        DATE(FROM_UNIXTIME(Posts.CreateAt / 1000)) AS Name,
+		        ` + day + ` AS Name,
 		        COUNT(Posts.Id) AS Value
 		    FROM Posts`
 
@@ -2427,7 +2459,7 @@ func (s *SqlPostStore) AnalyticsPostCountsByDay(options *model.AnalyticsPostCoun
 
 	query += ` Posts.CreateAt <= ?
 		            AND Posts.CreateAt >= ?
-		GROUP BY DATE(FROM_UNIXTIME(Posts.CreateAt / 1000))
+		GROUP BY ` + day + `
 		ORDER BY Name DESC
 		LIMIT 30`
 
@@ -2446,6 +2478,15 @@ func (s *SqlPostStore) AnalyticsPostCountsByDay(options *model.AnalyticsPostCoun
 	return rows, nil
 }
 
+// postCreateDayExpr returns the expression of the day a post was created at, for the queries
+// that don't use the materialized views.
+func (s *SqlPostStore) postCreateDayExpr() string {
+	if s.DriverName() == model.DatabaseDriverSqlite {
+		return "DATE(Posts.CreateAt / 1000, 'unixepoch')"
+	}
+	return "DATE(FROM_UNIXTIME(Posts.CreateAt / 1000))"
+}
+
 func (s *SqlPostStore) countByTeam(teamID string) (int64, error) {
 	query := s.getQueryBuilder().
 		Select("COALESCE(SUM(num), 0) AS total").
@@ -2649,14 +2690,20 @@ func (s *SqlPostStore) PermanentDeleteBatchForRetentionPolicies(retentionPolicyB
 		GlobalPolicyEndTime: retentionPolicyBatchConfigs.GlobalPolicyEndTime,
 		Limit:               retentionPolicyBatchConfigs.Limit,
 		StoreDeletedIds:     true,
+		UserIDColumn:        "UserId",
+		LegalHoldUserIDs:    retentionPolicyBatchConfigs.LegalHoldUserIDs,
+		LegalHoldChannelIDs: retentionPolicyBatchConfigs.LegalHoldChannelIDs,
 	}, s.SqlStore, cursor)
 }
 
 func (s *SqlPostStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, error) {
 	var query string
-	if s.DriverName() == model.DatabaseDriverPostgres {
+	switch s.DriverName() {
+	case model.DatabaseDriverPostgres:
 		query = "DELETE from Posts WHERE Id = any (array (SELECT Id FROM Posts WHERE CreateAt < ? LIMIT ?))"
-	} else {
+	case model.DatabaseDriverSqlite:
+		query = "DELETE from Posts WHERE Id IN (SELECT Id FROM Posts WHERE CreateAt < ? LIMIT ?)"
+	default:
 		query = "DELETE from Posts WHERE CreateAt < ? LIMIT ?"
 	}
 
@@ -2703,6 +2750,11 @@ func (s *SqlPostStore) determineMaxPostSize() int {
 		`); err != nil {
 			mlog.Warn("Unable to determine the maximum supported post size", mlog.Err(err))
 		}
+	} else if s.DriverName() == model.DatabaseDriverSqlite {
+		var err error
+		if maxPostSizeBytes, err = s.sqliteColumnSize("Posts", "Message"); err != nil {
+			mlog.Warn("Unable to determine the maximum supported post size", mlog.Err(err))
+		}
 	} else {
 		mlog.Error("No implementation found to determine the maximum supported post size")
 	}
@@ -3021,7 +3073,7 @@ func (s *SqlPostStore) deleteThread(transaction *sqlxTxWrapper, postId string, d
 
 func (s *SqlPostStore) deleteThreadFiles(transaction *sqlxTxWrapper, postID string, deleteAtTime int64) error {
 	var query sq.UpdateBuilder
-	if s.DriverName() == model.DatabaseDriverPostgres {
+	if s.DriverName() == model.DatabaseDriverPostgres || s.DriverName() == model.DatabaseDriverSqlite {
 		query = s.getQueryBuilder().Update("FileInfo").
 			Set("DeleteAt", deleteAtTime).
 			From("Posts")
@@ -3072,9 +3124,12 @@ func (s *SqlPostStore) updateThreadAfterReplyDeletion(transaction *sqlxTxWrapper
 		updateQuery := s.getQueryBuilder().Update("Threads")
 
 		if count == 0 {
-			if s.DriverName() == model.DatabaseDriverPostgres {
+			switch s.DriverName() {
+			case model.DatabaseDriverPostgres:
 				updateQuery = updateQuery.Set("Participants", sq.Expr("Participants - ?", userId))
-			} else {
+			case model.DatabaseDriverSqlite:
+				updateQuery = updateQuery.Set("Participants", sq.Expr(sqliteJSONArrayRemove("Participants"), userId))
+			default:
 				updateQuery = updateQuery.
 					Set("Participants", sq.Expr(
 						`IFNULL(JSON_REMOVE(Participants, JSON_UNQUOTE(JSON_SEARCH(Participants, 'one', ?))), Participants)`, userId,
@@ -3405,7 +3460,7 @@ func (s *SqlPostStore) RestoreContentFlaggedPost(flaggedPost *model.Post, status
 	baseSubQuery := s.getSubQueryBuilder().
 		Select("p.Id as PostId").
 		From("Posts as p").
-		InnerJoin("PropertyValues as pv_managed ON pv_managed.TargetId = p.Id AND pv_managed.FieldId = ? AND pv_managed.DeleteAt = 0 AND pv_managed.Value = 'true'", contentFlaggingManagedFieldId).
+		InnerJoin("PropertyValues as pv_managed ON pv_managed.TargetId = p.Id AND pv_managed.FieldId = ? AND pv_managed.DeleteAt = 0 AND "+s.jsonValueExpr("pv_managed.Value")+" = 'true'", contentFlaggingManagedFieldId).
 		LeftJoin("PropertyValues as pv_status ON pv_status.TargetId = p.Id AND pv_status.FieldId = ? AND pv_status.DeleteAt = 0", statusFieldId)
 
 	err = s.restoreContentFlaggedRootPost(tx, baseSubQuery, flaggedPost.Id, contentFlaggingManagedFieldId)
@@ -3441,8 +3496,8 @@ func (s *SqlPostStore) restoreContentFlaggedRootPost(tx *sqlxTxWrapper, baseSubQ
 	postIdSubQuery := baseSubQuery.
 		Where(sq.Eq{"p.Id": postId}).
 		Where(sq.Or{
-			sq.Eq{"pv_status.value": fmt.Sprintf("\"%s\"", model.ContentFlaggingStatusPending)},
-			sq.Eq{"pv_status.value": fmt.Sprintf("\"%s\"", model.ContentFlaggingStatusAssigned)},
+			sq.Eq{s.jsonValueExpr("pv_status.value"): fmt.Sprintf("\"%s\"", model.ContentFlaggingStatusPending)},
+			sq.Eq{s.jsonValueExpr("pv_status.value"): fmt.Sprintf("\"%s\"", model.ContentFlaggingStatusAssigned)},
 		})
 
 	// Restoring the post
@@ -3464,7 +3519,7 @@ func (s *SqlPostStore) restoreContentFlaggedPostReplies(tx *sqlxTxWrapper, baseS
 		Where(sq.NotEq{"p.DeleteAt": 0}).
 		Where(sq.Or{
 			sq.Expr("pv_status.id IS NULL"),
-			sq.Eq{"pv_status.value": fmt.Sprintf("\"%s\"", model.ContentFlaggingStatusRetained)},
+			sq.Eq{s.jsonValueExpr("pv_status.value"): fmt.Sprintf("\"%s\"", model.ContentFlaggingStatusRetained)},
 		})
 
 	queryBuilder := s.getQueryBuilder().
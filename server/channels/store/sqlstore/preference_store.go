@@ -298,7 +298,7 @@ func (s SqlPreferenceStore) DeleteInvalidVisibleDmsGms() (int64, error) {
 			sq.Lt{"SUBSTRING(CONCAT('000000000000000', Value), LENGTH(Value) + 1, 15)": "000000000000001"},
 		},
 	}
-	if s.DriverName() == "postgres" {
+	if s.DriverName() == model.DatabaseDriverPostgres || s.DriverName() == model.DatabaseDriverSqlite {
 		subQuery := s.getQueryBuilder().
 			Select("UserId, Category, Name").
 			From("Preferences").
@@ -346,9 +346,12 @@ func (s *SqlReactionStore) DeleteOrphanedRowsByIds(r *model.RetentionIdsForDelet
 
 func (s *SqlReactionStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, error) {
 	var query string
-	if s.DriverName() == "postgres" {
+	switch s.DriverName() {
+	case model.DatabaseDriverPostgres:
 		query = "DELETE from Reactions WHERE CreateAt = any (array (SELECT CreateAt FROM Reactions WHERE CreateAt < ? LIMIT ?))"
-	} else {
+	case model.DatabaseDriverSqlite:
+		query = "DELETE from Reactions WHERE CreateAt IN (SELECT CreateAt FROM Reactions WHERE CreateAt < ? LIMIT ?)"
+	default:
 		query = "DELETE from Reactions WHERE CreateAt < ? LIMIT ?"
 	}
 
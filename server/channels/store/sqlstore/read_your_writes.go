@@ -0,0 +1,157 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+	"github.com/mattermost/mattermost/server/v8/platform/services/cache"
+)
+
+const (
+	// ReplicaReadRedirectReadYourWrites is the reason of the reads sent to master because their
+	// caller wrote recently.
+	ReplicaReadRedirectReadYourWrites = "read_your_writes"
+	// ReplicaReadRedirectLag is the reason of the reads sent away from a lagging replica.
+	ReplicaReadRedirectLag = "replica_lag"
+
+	ReadYourWritesCacheSize = 50000
+
+	// replicaLagQuery returns the replication lag of a Postgres replica in seconds. A replica
+	// that replayed everything it received isn't lagging, however old its last transaction is.
+	replicaLagQuery = `SELECT CASE
+		WHEN pg_last_wal_receive_lsn() = pg_last_wal_replay_lsn() THEN 0
+		ELSE COALESCE(EXTRACT(EPOCH FROM now() - pg_last_xact_replay_timestamp()), 0)
+	END`
+)
+
+// ReadYourWritesCache sets the cache recording the callers that wrote recently. A cache shared
+// between the nodes, such as Redis, lets a caller read its writes on all of them.
+func ReadYourWritesCache(c cache.Cache) Option {
+	return func(s *SqlStore) error {
+		s.readYourWritesCache = c
+		return nil
+	}
+}
+
+func (ss *SqlStore) readYourWritesWindow() time.Duration {
+	if ss.settings.ReadYourWritesWindowSeconds == nil {
+		return 0
+	}
+	return time.Duration(*ss.settings.ReadYourWritesWindowSeconds) * time.Second
+}
+
+// readsFromReplicas reports whether reads may go to the replicas at all.
+func (ss *SqlStore) readsFromReplicas() bool {
+	return len(ss.settings.DataSourceReplicas) > 0 && !ss.lockedToMaster && ss.hasLicense()
+}
+
+func readYourWritesKeys(rctx request.CTX) []string {
+	session := rctx.Session()
+	if session == nil {
+		return nil
+	}
+
+	keys := make([]string, 0, 2)
+	if session.UserId != "" {
+		keys = append(keys, "user:"+session.UserId)
+	}
+	if session.Id != "" {
+		keys = append(keys, "session:"+session.Id)
+	}
+	return keys
+}
+
+// RecordWrite records that the user and the session of rctx wrote, so that their reads go to
+// master for the next ReadYourWritesWindowSeconds.
+func (ss *SqlStore) RecordWrite(rctx request.CTX) {
+	window := ss.readYourWritesWindow()
+	if ss.readYourWritesCache == nil || window <= 0 || !ss.readsFromReplicas() {
+		return
+	}
+
+	now := model.GetMillis()
+	for _, key := range readYourWritesKeys(rctx) {
+		if err := ss.readYourWritesCache.SetWithExpiry(key, now, window); err != nil {
+			rctx.Logger().Warn("Failed to record a write for read-your-writes consistency", mlog.String("key", key), mlog.Err(err))
+		}
+	}
+}
+
+// ReadYourWritesContext returns rctx selecting master when its user or session wrote within the
+// last ReadYourWritesWindowSeconds, and rctx as is otherwise.
+func (ss *SqlStore) ReadYourWritesContext(rctx request.CTX) request.CTX {
+	if ss.readYourWritesCache == nil || ss.readYourWritesWindow() <= 0 || !ss.readsFromReplicas() {
+		return rctx
+	}
+
+	keys := readYourWritesKeys(rctx)
+	if len(keys) == 0 {
+		return rctx
+	}
+
+	values := make([]any, len(keys))
+	for i := range values {
+		var writtenAt int64
+		values[i] = &writtenAt
+	}
+
+	for i, err := range ss.readYourWritesCache.GetMulti(keys, values) {
+		if err == nil {
+			return store.RequestContextWithReadYourWrites(rctx)
+		}
+		if !errors.Is(err, cache.ErrKeyNotFound) {
+			rctx.Logger().Warn("Failed to check for recent writes", mlog.String("key", keys[i]), mlog.Err(err))
+		}
+	}
+
+	return rctx
+}
+
+func (ss *SqlStore) countReplicaReadRedirect(reason string) {
+	if ss.metrics != nil {
+		ss.metrics.IncrementDBReplicaReadRedirect(reason)
+	}
+}
+
+// checkReplicaLag measures the lag of a replica and takes it out of rotation while it exceeds
+// ReplicaMaxLagSeconds.
+func (ss *SqlStore) checkReplicaLag(r *atomic.Pointer[sqlxDBWrapper], name string) {
+	replica := r.Load()
+	if !replica.Online() {
+		return
+	}
+
+	if ss.settings.ReplicaMaxLagSeconds == nil || *ss.settings.ReplicaMaxLagSeconds <= 0 || ss.DriverName() != model.DatabaseDriverPostgres {
+		replica.isLagging.Store(false)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), replica.queryTimeout)
+	defer cancel()
+
+	var lag float64
+	if err := replica.DB.QueryRowContext(ctx, replicaLagQuery).Scan(&lag); err != nil {
+		mlog.Warn("Failed to measure the replica lag", mlog.String("db", name), mlog.Err(err))
+		return
+	}
+
+	lagging := lag > float64(*ss.settings.ReplicaMaxLagSeconds)
+	if replica.isLagging.Swap(lagging) == lagging {
+		return
+	}
+
+	if lagging {
+		mlog.Warn("Replica lag is above the maximum, taking the replica out of rotation", mlog.String("db", name), mlog.Float("lag_seconds", lag))
+	} else {
+		mlog.Info("Replica caught up, putting the replica back in rotation", mlog.String("db", name), mlog.Float("lag_seconds", lag))
+	}
+}
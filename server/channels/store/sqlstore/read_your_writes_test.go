@@ -0,0 +1,143 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+	"github.com/mattermost/mattermost/server/v8/einterfaces/mocks"
+	"github.com/mattermost/mattermost/server/v8/platform/services/cache"
+)
+
+func newTestReplica(online, lagging bool) *atomic.Pointer[sqlxDBWrapper] {
+	replica := &sqlxDBWrapper{isOnline: &atomic.Bool{}}
+	replica.isOnline.Store(online)
+	replica.isLagging.Store(lagging)
+
+	pointer := &atomic.Pointer[sqlxDBWrapper]{}
+	pointer.Store(replica)
+	return pointer
+}
+
+func TestNextReplica(t *testing.T) {
+	t.Run("skips offline and lagging replicas", func(t *testing.T) {
+		metrics := &mocks.MetricsInterface{}
+		metrics.On("IncrementDBReplicaReadRedirect", ReplicaReadRedirectLag).Return()
+		ss := &SqlStore{metrics: metrics}
+
+		replicas := []*atomic.Pointer[sqlxDBWrapper]{
+			newTestReplica(false, false),
+			newTestReplica(true, true),
+			newTestReplica(true, false),
+		}
+
+		var counter int64
+		for range 6 {
+			assert.Same(t, replicas[2].Load(), ss.nextReplica(replicas, &counter))
+		}
+		metrics.AssertCalled(t, "IncrementDBReplicaReadRedirect", ReplicaReadRedirectLag)
+	})
+
+	t.Run("no replica in rotation", func(t *testing.T) {
+		metrics := &mocks.MetricsInterface{}
+		metrics.On("IncrementDBReplicaReadRedirect", ReplicaReadRedirectLag).Return()
+		ss := &SqlStore{metrics: metrics}
+
+		replicas := []*atomic.Pointer[sqlxDBWrapper]{
+			newTestReplica(false, false),
+			newTestReplica(true, true),
+		}
+
+		var counter int64
+		assert.Nil(t, ss.nextReplica(replicas, &counter))
+		metrics.AssertNumberOfCalls(t, "IncrementDBReplicaReadRedirect", 1)
+	})
+
+	t.Run("no lagging replica", func(t *testing.T) {
+		ss := &SqlStore{metrics: &mocks.MetricsInterface{}}
+
+		replicas := []*atomic.Pointer[sqlxDBWrapper]{
+			newTestReplica(true, false),
+			newTestReplica(true, false),
+		}
+
+		var counter int64
+		assert.Same(t, replicas[1].Load(), ss.nextReplica(replicas, &counter))
+		assert.Same(t, replicas[0].Load(), ss.nextReplica(replicas, &counter))
+	})
+}
+
+func TestReadYourWrites(t *testing.T) {
+	newStore := func(t *testing.T, replicas []string, window int) *SqlStore {
+		metrics := &mocks.MetricsInterface{}
+		metrics.On("IncrementDBReplicaReadRedirect", ReplicaReadRedirectReadYourWrites).Return()
+
+		ss := &SqlStore{
+			masterX: &sqlxDBWrapper{isOnline: &atomic.Bool{}},
+			settings: &model.SqlSettings{
+				DataSourceReplicas:          replicas,
+				ReadYourWritesWindowSeconds: model.NewPointer(window),
+			},
+			license: &model.License{},
+			metrics: metrics,
+		}
+		require.NoError(t, ReadYourWritesCache(cache.NewLRU(&cache.CacheOptions{Size: 100}))(ss))
+		return ss
+	}
+
+	newContext := func(t *testing.T, userID string) request.CTX {
+		return request.TestContext(t).WithSession(&model.Session{Id: model.NewId(), UserId: userID})
+	}
+
+	t.Run("reads of the writer go to master", func(t *testing.T) {
+		ss := newStore(t, []string{"replica"}, 5)
+		userID := model.NewId()
+		rctx := newContext(t, userID)
+
+		assert.False(t, store.HasMaster(ss.ReadYourWritesContext(rctx).Context()))
+
+		ss.RecordWrite(rctx)
+
+		rctx = ss.ReadYourWritesContext(rctx)
+		assert.True(t, store.HasMaster(rctx.Context()))
+		assert.True(t, store.HasReadYourWrites(rctx.Context()))
+		assert.Same(t, ss.GetMaster(), ss.DBXFromContext(rctx.Context()))
+		ss.metrics.(*mocks.MetricsInterface).AssertCalled(t, "IncrementDBReplicaReadRedirect", ReplicaReadRedirectReadYourWrites)
+
+		// Other sessions of the user read their writes too, unlike other users.
+		assert.True(t, store.HasMaster(ss.ReadYourWritesContext(newContext(t, userID)).Context()))
+		assert.False(t, store.HasMaster(ss.ReadYourWritesContext(newContext(t, model.NewId())).Context()))
+	})
+
+	t.Run("without replicas", func(t *testing.T) {
+		ss := newStore(t, []string{}, 5)
+		rctx := newContext(t, model.NewId())
+
+		ss.RecordWrite(rctx)
+		assert.False(t, store.HasMaster(ss.ReadYourWritesContext(rctx).Context()))
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		ss := newStore(t, []string{"replica"}, 0)
+		rctx := newContext(t, model.NewId())
+
+		ss.RecordWrite(rctx)
+		assert.False(t, store.HasMaster(ss.ReadYourWritesContext(rctx).Context()))
+	})
+
+	t.Run("without a session", func(t *testing.T) {
+		ss := newStore(t, []string{"replica"}, 5)
+		rctx := request.TestContext(t)
+
+		ss.RecordWrite(rctx)
+		assert.False(t, store.HasMaster(ss.ReadYourWritesContext(rctx).Context()))
+	})
+}
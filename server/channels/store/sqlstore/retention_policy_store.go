@@ -13,6 +13,8 @@ import (
 	"github.com/lib/pq"
 	sq "github.com/mattermost/squirrel"
 	"github.com/pkg/errors"
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
 
 	"github.com/mattermost/mattermost/server/public/model"
 	"github.com/mattermost/mattermost/server/v8/channels/store"
@@ -510,6 +512,10 @@ func (s *SqlRetentionPolicyStore) AddChannels(policyId string, channelIds []stri
 			if dbErr.Code == PGForeignKeyViolationErrorCode {
 				return store.NewErrNotFound("RetentionPolicy", policyId)
 			}
+		case *sqlite.Error:
+			if isSqliteErrorCode(dbErr, sqlite3.SQLITE_CONSTRAINT_FOREIGNKEY) {
+				return store.NewErrNotFound("RetentionPolicy", policyId)
+			}
 		}
 	}
 
@@ -922,6 +928,9 @@ func insertRetentionIdsForDeletion(txn *sqlxTxWrapper, row *model.RetentionIdsFo
 // will be deleted by the global policy if it does not fall under a granular policy.
 // To disable the granular policies, set `NowMillis` to 0.
 // To disable the global policy, set `GlobalPolicyEndTime` to 0.
+// `LegalHoldChannelIDs` excludes the records of the given channels from the deletion.
+// `LegalHoldUserIDs` does the same for the records of the given users, and is only
+// applied if `UserIDColumn`, the column of `table` holding the user ID, is set.
 type RetentionPolicyBatchDeletionInfo struct {
 	BaseBuilder         sq.SelectBuilder
 	Table               string
@@ -932,6 +941,9 @@ type RetentionPolicyBatchDeletionInfo struct {
 	GlobalPolicyEndTime int64
 	Limit               int64
 	StoreDeletedIds     bool
+	UserIDColumn        string
+	LegalHoldUserIDs    []string
+	LegalHoldChannelIDs []string
 }
 
 // genericPermanentDeleteBatchForRetentionPolicies is a helper function for tables
@@ -942,6 +954,12 @@ func genericPermanentDeleteBatchForRetentionPolicies(
 	cursor model.RetentionPolicyCursor,
 ) (int64, model.RetentionPolicyCursor, error) {
 	baseBuilder := r.BaseBuilder.InnerJoin("Channels ON " + r.ChannelIDTable + ".ChannelId = Channels.Id")
+	if len(r.LegalHoldChannelIDs) > 0 {
+		baseBuilder = baseBuilder.Where(sq.NotEq{r.ChannelIDTable + ".ChannelId": r.LegalHoldChannelIDs})
+	}
+	if r.UserIDColumn != "" && len(r.LegalHoldUserIDs) > 0 {
+		baseBuilder = baseBuilder.Where(sq.NotEq{r.Table + "." + r.UserIDColumn: r.LegalHoldUserIDs})
+	}
 
 	scopedTimeColumn := r.Table + "." + r.TimeColumn
 	nowStr := strconv.FormatInt(r.NowMillis, 10)
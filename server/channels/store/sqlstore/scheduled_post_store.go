@@ -45,9 +45,24 @@ func (s *SqlScheduledPostStore) columns(prefix string) []string {
 		prefix + "ScheduledAt",
 		prefix + "ProcessedAt",
 		prefix + "ErrorCode",
+		prefix + "Recurrence",
 	}
 }
 
+// selectColumns returns the columns to select scheduled posts with. SQLite reads the
+// recurrence as text, while ScheduledPostRecurrence scans the JSON from bytes.
+func (s *SqlScheduledPostStore) selectColumns(prefix string) []string {
+	columns := s.columns(prefix)
+	if s.DriverName() == model.DatabaseDriverSqlite {
+		for i, column := range columns {
+			if strings.HasSuffix(column, "Recurrence") {
+				columns[i] = "CAST(" + column + " AS BLOB) AS Recurrence"
+			}
+		}
+	}
+	return columns
+}
+
 func (s *SqlScheduledPostStore) scheduledPostToSlice(scheduledPost *model.ScheduledPost) []any {
 	return []any{
 		scheduledPost.Id,
@@ -63,6 +78,7 @@ func (s *SqlScheduledPostStore) scheduledPostToSlice(scheduledPost *model.Schedu
 		scheduledPost.ScheduledAt,
 		scheduledPost.ProcessedAt,
 		scheduledPost.ErrorCode,
+		scheduledPost.Recurrence,
 	}
 }
 
@@ -102,7 +118,7 @@ func (s *SqlScheduledPostStore) GetScheduledPostsForUser(userId, teamId string)
 	// joining with Channels table.
 
 	query := s.getQueryBuilder().
-		Select(s.columns("sp")...).
+		Select(s.selectColumns("sp")...).
 		From("ScheduledPosts AS sp").
 		InnerJoin("Channels as c on sp.ChannelId = c.Id").
 		Where(sq.Eq{
@@ -137,7 +153,7 @@ func (s *SqlScheduledPostStore) GetMaxMessageSize() int {
 
 func (s *SqlScheduledPostStore) GetPendingScheduledPosts(beforeTime, afterTime int64, lastScheduledPostId string, perPage uint64) ([]*model.ScheduledPost, error) {
 	query := s.getQueryBuilder().
-		Select(s.columns("")...).
+		Select(s.selectColumns("")...).
 		From("ScheduledPosts").
 		Where(sq.Eq{"ErrorCode": ""}).
 		OrderBy("ScheduledAt DESC", "Id").
@@ -241,12 +257,13 @@ func (s *SqlScheduledPostStore) toUpdateMap(scheduledPost *model.ScheduledPost)
 		"ScheduledAt": scheduledPost.ScheduledAt,
 		"ProcessedAt": now,
 		"ErrorCode":   scheduledPost.ErrorCode,
+		"Recurrence":  scheduledPost.Recurrence,
 	}
 }
 
 func (s *SqlScheduledPostStore) Get(scheduledPostId string) (*model.ScheduledPost, error) {
 	query := s.getQueryBuilder().
-		Select(s.columns("")...).
+		Select(s.selectColumns("")...).
 		From("ScheduledPosts").
 		Where(sq.Eq{
 			"Id": scheduledPostId,
@@ -271,6 +288,8 @@ func (s *SqlScheduledPostStore) UpdateOldScheduledPosts(beforeTime int64) error
 		Where(sq.And{
 			sq.Eq{"ErrorCode": ""},
 			sq.Lt{"ScheduledAt": beforeTime},
+			// recurring scheduled posts are moved on to their next occurrence instead
+			sq.Eq{"Recurrence": nil},
 		})
 
 	query, args, err := builder.ToSql()
@@ -288,6 +307,27 @@ func (s *SqlScheduledPostStore) UpdateOldScheduledPosts(beforeTime int64) error
 	return nil
 }
 
+func (s *SqlScheduledPostStore) GetStaleRecurringScheduledPosts(beforeTime int64, perPage uint64) ([]*model.ScheduledPost, error) {
+	query := s.getQueryBuilder().
+		Select(s.selectColumns("")...).
+		From("ScheduledPosts").
+		Where(sq.And{
+			sq.Eq{"ErrorCode": ""},
+			sq.Lt{"ScheduledAt": beforeTime},
+			sq.NotEq{"Recurrence": nil},
+		}).
+		OrderBy("ScheduledAt", "Id").
+		Limit(perPage)
+
+	var scheduledPosts []*model.ScheduledPost
+	if err := s.GetMaster().SelectBuilder(&scheduledPosts, query); err != nil {
+		mlog.Error("SqlScheduledPostStore.GetStaleRecurringScheduledPosts: failed to fetch stale recurring scheduled posts", mlog.Int("before_time", beforeTime), mlog.Err(err))
+		return nil, errors.Wrapf(err, "SqlScheduledPostStore.GetStaleRecurringScheduledPosts: failed to fetch stale recurring scheduled posts, before_time: %d", beforeTime)
+	}
+
+	return scheduledPosts, nil
+}
+
 func (s *SqlScheduledPostStore) PermanentDeleteByUser(userId string) error {
 	query := s.getQueryBuilder().
 		Delete("ScheduledPosts").
@@ -135,7 +135,7 @@ func (me SqlSessionStore) GetSessions(rctx request.CTX, userId string) ([]*model
 		return nil, errors.Wrap(err, "session_get_sessions_tosql")
 	}
 
-	err = me.GetReplica().Select(&sessions, sql, args...)
+	err = me.DBXFromContext(rctx.Context()).Select(&sessions, sql, args...)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to find Sessions with userId=%s", userId)
 	}
@@ -170,7 +170,7 @@ func (me SqlSessionStore) GetLRUSessions(rctx request.CTX, userId string, limit
 	}
 
 	var sessions []*model.Session
-	if err := me.GetReplica().Select(&sessions, query, args...); err != nil {
+	if err := me.DBXFromContext(rctx.Context()).Select(&sessions, query, args...); err != nil {
 		return nil, errors.Wrapf(err, "failed to find Sessions with userId=%s", userId)
 	}
 	return sessions, nil
@@ -201,6 +201,9 @@ func (me SqlSessionStore) GetMobileSessionMetadata() ([]*model.MobileSessionMeta
 	versionProp := model.SessionPropMobileVersion
 	notificationDisabledProp := model.SessionPropDeviceNotificationDisabled
 	platformQuery := "NULLIF(SPLIT_PART(deviceid, ':', 1), '')"
+	if me.DriverName() == model.DatabaseDriverSqlite {
+		platformQuery = "NULLIF(substr(deviceid, 1, instr(deviceid || ':', ':') - 1), '')"
+	}
 
 	query, args, err := me.getQueryBuilder().
 		Select(fmt.Sprintf(
@@ -370,7 +373,7 @@ func (me SqlSessionStore) AnalyticsSessionCount() (int64, error) {
 
 func (me SqlSessionStore) Cleanup(expiryTime int64, batchSize int64) error {
 	var query string
-	if me.DriverName() == model.DatabaseDriverPostgres {
+	if me.DriverName() == model.DatabaseDriverPostgres || me.DriverName() == model.DatabaseDriverSqlite {
 		query = "DELETE FROM Sessions WHERE Id IN (SELECT Id FROM Sessions WHERE ExpiresAt != 0 AND ? > ExpiresAt LIMIT ?)"
 	} else {
 		query = "DELETE FROM Sessions WHERE ExpiresAt != 0 AND ? > ExpiresAt LIMIT ?"
@@ -0,0 +1,421 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+// SQLite is meant for development and small installs. The whole database is a single local
+// file used by a single server, so the config validation rejects clustering and read replicas,
+// writes are serialized by taking the database lock when a transaction begins, and the full
+// text searches scan the rows instead of using an index.
+//
+// The store queries are written for Postgres. The connections below translate the few bits of
+// syntax SQLite doesn't understand and lowercase the result column names like Postgres does for
+// unquoted identifiers, so that the queries map to the model structs the same way. Queries
+// relying on features SQLite lacks altogether branch on the driver name instead.
+
+const (
+	sqliteBusyTimeout         = 10 * time.Second
+	sqliteDefaultSearchConfig = "english"
+	// minimumRequiredSqliteVersion is 3.38, the first version with the ->> operator.
+	minimumRequiredSqliteVersion = 3038000
+)
+
+var sqlitePragmas = []string{
+	fmt.Sprintf("busy_timeout(%d)", sqliteBusyTimeout.Milliseconds()),
+	"foreign_keys(1)",
+	"journal_mode(WAL)",
+	"synchronous(NORMAL)",
+}
+
+var (
+	sqliteConstraintRegex  = regexp.MustCompile(`UNIQUE constraint failed: ([\w.]+(?:, [\w.]+)*)`)
+	sqliteColumnSizeRegex  = regexp.MustCompile(`\((\d+)\)`)
+	sqliteILikeRegex       = regexp.MustCompile(`(?i)\bILIKE\b`)
+	sqliteCastRegex        = regexp.MustCompile(`::[a-zA-Z]+(?:\s*\(\d+\))?(?:\[\])?`)
+	sqliteQuotedRegex      = regexp.MustCompile(`"([^"]+)"`)
+	sqliteJSONAggRegex     = regexp.MustCompile(`(?i)\bjson_agg\s*\(`)
+	sqliteTruncateRegex    = regexp.MustCompile(`(?i)^\s*TRUNCATE\s+(?:TABLE\s+)?(\w+)\s*;?\s*$`)
+	sqliteGreatestRegex    = regexp.MustCompile(`(?i)\b(GREATEST|LEAST)\s*\(`)
+	sqliteDeleteUsingRegex = regexp.MustCompile(`(?is)^\s*DELETE\s+FROM\s+(\w+)(\s+\w+)?\s+USING\s+(\w+(?:\s+\w+)?)\s+WHERE\s+(.*)$`)
+	sqliteLockingRegex     = regexp.MustCompile(`(?i)\s+FOR\s+(?:UPDATE|SHARE|NO\s+KEY\s+UPDATE)(?:\s+OF\s+\w+)?(?:\s+(?:SKIP\s+LOCKED|NOWAIT))?`)
+)
+
+func init() {
+	// The queries are rebound to the Postgres placeholders, which SQLite understands too.
+	sqlx.BindDriver(model.DatabaseDriverSqlite, sqlx.DOLLAR)
+
+	sqlite.MustRegisterDeterministicScalarFunction("ts_match", 3, sqliteTextSearchMatch)
+	sqlite.MustRegisterDeterministicScalarFunction("translate", 3, sqliteTranslate)
+}
+
+// sqliteDataSource adds the pragmas every connection needs to the data source, unless it sets
+// them already. Transactions take the write lock when they begin, since SQLite can't upgrade a
+// read transaction to a write one once another connection has written.
+func sqliteDataSource(dataSource string) string {
+	name, rawQuery, _ := strings.Cut(dataSource, "?")
+	params, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return dataSource
+	}
+
+	set := map[string]bool{}
+	for _, pragma := range params["_pragma"] {
+		pragmaName, _, _ := strings.Cut(pragma, "(")
+		set[strings.ToLower(strings.TrimSpace(pragmaName))] = true
+	}
+	for _, pragma := range sqlitePragmas {
+		pragmaName, _, _ := strings.Cut(pragma, "(")
+		if !set[pragmaName] {
+			params.Add("_pragma", pragma)
+		}
+	}
+	if params.Get("_txlock") == "" {
+		params.Set("_txlock", "immediate")
+	}
+
+	return name + "?" + params.Encode()
+}
+
+// setupSqliteConnection opens the connection pool to the SQLite database and applies the pool
+// settings.
+func setupSqliteConnection(logger mlog.LoggerIFace, dataSource string, settings *model.SqlSettings) (*sql.DB, error) {
+	// Open doesn't connect, it is only used to get hold of the driver the SQL functions are
+	// registered with.
+	registered, err := sql.Open(model.DatabaseDriverSqlite, "")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get the SQLite driver")
+	}
+	defer registered.Close()
+
+	db := sql.OpenDB(&sqliteConnector{
+		driver:     registered.Driver(),
+		dataSource: sqliteDataSource(dataSource),
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), sqliteBusyTimeout)
+	defer cancel()
+	if err := db.PingContext(ctx); err != nil {
+		db.Close()
+		return nil, errors.Wrap(err, "failed to open the SQLite database")
+	}
+
+	logger.Info("Opened SQLite database", mlog.String("database", "master"), mlog.String("dataSource", dataSource))
+
+	db.SetMaxIdleConns(*settings.MaxIdleConns)
+	db.SetMaxOpenConns(*settings.MaxOpenConns)
+	db.SetConnMaxLifetime(time.Duration(*settings.ConnMaxLifetimeMilliseconds) * time.Millisecond)
+	db.SetConnMaxIdleTime(time.Duration(*settings.ConnMaxIdleTimeMilliseconds) * time.Millisecond)
+
+	return db, nil
+}
+
+// translateSqliteQuery rewrites the Postgres syntax that has a direct SQLite equivalent.
+func translateSqliteQuery(query string) string {
+	if m := sqliteTruncateRegex.FindStringSubmatch(query); m != nil {
+		return "DELETE FROM " + m[1]
+	}
+	if m := sqliteDeleteUsingRegex.FindStringSubmatch(query); m != nil {
+		// DELETE ... USING joins the other table, a correlated EXISTS does the same.
+		table := m[1]
+		if alias := strings.TrimSpace(m[2]); alias != "" {
+			table += " AS " + alias
+		}
+		query = "DELETE FROM " + table + " WHERE EXISTS (SELECT 1 FROM " + m[3] + " WHERE " + m[4] + ")"
+	}
+	if strings.Contains(query, "::") {
+		query = sqliteCastRegex.ReplaceAllString(query, "")
+	}
+	if strings.Contains(strings.ToUpper(query), "ILIKE") {
+		query = sqliteILikeRegex.ReplaceAllString(query, "LIKE")
+	}
+	if upper := strings.ToUpper(query); strings.Contains(upper, "GREATEST") || strings.Contains(upper, "LEAST") {
+		// The multi-argument max and min, unlike GREATEST and LEAST, return NULL if any argument is NULL.
+		query = sqliteGreatestRegex.ReplaceAllStringFunc(query, func(match string) string {
+			if strings.EqualFold(match[:5], "LEAST") {
+				return "min("
+			}
+			return "max("
+		})
+	}
+	if strings.Contains(strings.ToUpper(query), "JSON_AGG") {
+		query = sqliteJSONAggRegex.ReplaceAllString(query, "json_group_array(")
+	}
+	if strings.Contains(strings.ToUpper(query), " FOR ") {
+		query = sqliteLockingRegex.ReplaceAllString(query, "")
+	}
+	return query
+}
+
+type sqliteConnector struct {
+	driver     driver.Driver
+	dataSource string
+}
+
+func (c *sqliteConnector) Connect(context.Context) (driver.Conn, error) {
+	conn, err := c.driver.Open(c.dataSource)
+	if err != nil {
+		return nil, err
+	}
+
+	return &sqliteConn{Conn: conn}, nil
+}
+
+func (c *sqliteConnector) Driver() driver.Driver {
+	return c.driver
+}
+
+// sqliteConn wraps a connection of the SQLite driver, which implements all the optional
+// interfaces used below.
+type sqliteConn struct {
+	driver.Conn
+}
+
+func (c *sqliteConn) Prepare(query string) (driver.Stmt, error) {
+	return c.PrepareContext(context.Background(), query)
+}
+
+func (c *sqliteConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	stmt, err := c.Conn.(driver.ConnPrepareContext).PrepareContext(ctx, translateSqliteQuery(query))
+	if err != nil {
+		return nil, err
+	}
+
+	return &sqliteStmt{Stmt: stmt, quoted: sqliteQuotedIdentifiers(query)}, nil
+}
+
+func (c *sqliteConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	// SQLite transactions are always serializable, there is nothing else to ask for.
+	opts.Isolation = driver.IsolationLevel(sql.LevelDefault)
+	return c.Conn.(driver.ConnBeginTx).BeginTx(ctx, opts)
+}
+
+func (c *sqliteConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	return c.Conn.(driver.ExecerContext).ExecContext(ctx, translateSqliteQuery(query), args)
+}
+
+func (c *sqliteConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	rows, err := c.Conn.(driver.QueryerContext).QueryContext(ctx, translateSqliteQuery(query), args)
+	if err != nil {
+		return nil, err
+	}
+
+	return &sqliteRows{Rows: rows, quoted: sqliteQuotedIdentifiers(query)}, nil
+}
+
+func (c *sqliteConn) Ping(ctx context.Context) error {
+	return c.Conn.(driver.Pinger).Ping(ctx)
+}
+
+func (c *sqliteConn) ResetSession(ctx context.Context) error {
+	return c.Conn.(driver.SessionResetter).ResetSession(ctx)
+}
+
+func (c *sqliteConn) IsValid() bool {
+	return c.Conn.(driver.Validator).IsValid()
+}
+
+type sqliteStmt struct {
+	driver.Stmt
+	quoted map[string]bool
+}
+
+func (s *sqliteStmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	return s.Stmt.(driver.StmtExecContext).ExecContext(ctx, args)
+}
+
+func (s *sqliteStmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	rows, err := s.Stmt.(driver.StmtQueryContext).QueryContext(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+
+	return &sqliteRows{Rows: rows, quoted: s.quoted}, nil
+}
+
+// sqliteRows lowercases the column names but the quoted ones, SQLite returns them as they are
+// written in the query.
+type sqliteRows struct {
+	driver.Rows
+	quoted map[string]bool
+}
+
+func (r *sqliteRows) Columns() []string {
+	columns := r.Rows.Columns()
+	for i := range columns {
+		if !r.quoted[columns[i]] {
+			columns[i] = strings.ToLower(columns[i])
+		}
+	}
+	return columns
+}
+
+// sqliteQuotedIdentifiers returns the quoted identifiers of the query, whose case is kept.
+func sqliteQuotedIdentifiers(query string) map[string]bool {
+	if !strings.Contains(query, `"`) {
+		return nil
+	}
+
+	quoted := map[string]bool{}
+	for _, match := range sqliteQuotedRegex.FindAllStringSubmatch(query, -1) {
+		quoted[match[1]] = true
+	}
+	return quoted
+}
+
+func sqliteTranslate(_ *sqlite.FunctionContext, args []driver.Value) (driver.Value, error) {
+	str, ok := args[0].(string)
+	if !ok {
+		return args[0], nil
+	}
+	from, _ := args[1].(string)
+	to, _ := args[2].(string)
+
+	fromRunes := []rune(from)
+	toRunes := []rune(to)
+	return strings.Map(func(r rune) rune {
+		for i, f := range fromRunes {
+			if f == r {
+				if i < len(toRunes) {
+					return toRunes[i]
+				}
+				return -1
+			}
+		}
+		return r
+	}, str), nil
+}
+
+// sqliteNumericalVersion encodes a SQLite version like SQLITE_VERSION_NUMBER does.
+func sqliteNumericalVersion(version string) (string, error) {
+	parts := strings.SplitN(version, ".", 3)
+	number := 0
+	for i, factor := range []int{1000000, 1000, 1} {
+		if i >= len(parts) {
+			break
+		}
+		part, err := strconv.Atoi(parts[i])
+		if err != nil {
+			return "", errors.Wrapf(err, "cannot parse SQLite version %q", version)
+		}
+		number += part * factor
+	}
+
+	return strconv.Itoa(number), nil
+}
+
+// isSqliteErrorCode reports whether the error comes from SQLite with one of the given result
+// codes, either primary or extended ones.
+func isSqliteErrorCode(err error, codes ...int) bool {
+	var sqliteErr *sqlite.Error
+	if !errors.As(err, &sqliteErr) {
+		return false
+	}
+
+	for _, code := range codes {
+		if sqliteErr.Code() == code || sqliteErr.Code()&0xff == code {
+			return true
+		}
+	}
+	return false
+}
+
+// sqliteUniqueConstraint returns the name Postgres gives to the constraint violated by a SQLite
+// unique constraint error, so that callers can tell them apart the same way for both drivers.
+// SQLite only reports the columns: a composite primary key on channelmembers is reported as
+// channelmembers_pkey and a unique constraint on the name and teamid of channels as
+// channels_name_teamid_key.
+func sqliteUniqueConstraint(err error) (string, bool) {
+	var sqliteErr *sqlite.Error
+	if !errors.As(err, &sqliteErr) {
+		return "", false
+	}
+
+	code := sqliteErr.Code()
+	if code != sqlite3.SQLITE_CONSTRAINT_UNIQUE && code != sqlite3.SQLITE_CONSTRAINT_PRIMARYKEY {
+		return "", false
+	}
+
+	m := sqliteConstraintRegex.FindStringSubmatch(sqliteErr.Error())
+	if m == nil {
+		return sqliteErr.Error(), true
+	}
+
+	var table string
+	var columns []string
+	for column := range strings.SplitSeq(m[1], ", ") {
+		table, column, _ = strings.Cut(column, ".")
+		columns = append(columns, column)
+	}
+	if code == sqlite3.SQLITE_CONSTRAINT_PRIMARYKEY {
+		return table + "_pkey", true
+	}
+
+	return table + "_" + strings.Join(columns, "_") + "_key", true
+}
+
+// sqliteColumnSize returns the size of a varchar column, as declared in the migrations.
+func (ss *SqlStore) sqliteColumnSize(tableName, columnName string) (int32, error) {
+	var columnType string
+	if err := ss.GetReplica().Get(&columnType, `SELECT type FROM pragma_table_info(lower(?)) WHERE lower(name) = lower(?)`, tableName, columnName); err != nil {
+		return 0, err
+	}
+
+	m := sqliteColumnSizeRegex.FindStringSubmatch(columnType)
+	if m == nil {
+		return 0, nil
+	}
+
+	size, err := strconv.ParseInt(m[1], 10, 32)
+	if err != nil {
+		return 0, err
+	}
+	return int32(size), nil
+}
+
+// dropAllSqliteTables empties every table but the migrations one, like DropAllTables does for
+// Postgres.
+func (ss *SqlStore) dropAllSqliteTables() {
+	var tables []string
+	if err := ss.masterX.Select(&tables, `SELECT name FROM sqlite_schema WHERE type = 'table' AND name NOT LIKE 'sqlite_%' AND name != 'db_migrations'`); err != nil {
+		mlog.Warn("Failed to list the SQLite tables", mlog.Err(err))
+		return
+	}
+
+	for _, table := range tables {
+		if _, err := ss.masterX.Exec(`DELETE FROM ` + table); err != nil {
+			mlog.Warn("Failed to empty the SQLite table", mlog.String("table", table), mlog.Err(err))
+		}
+	}
+}
+
+// sqliteJSONArrayContains returns the condition matching the rows whose JSON array at path in
+// column contains the value passed as argument, what column->key @> '["value"]' does in Postgres.
+func sqliteJSONArrayContains(column, path string) string {
+	return fmt.Sprintf("EXISTS (SELECT 1 FROM json_each(%s, '%s') WHERE json_each.value = ?)", column, path)
+}
+
+// sqliteJSONArrayRemove returns the expression of the JSON array in column without the value
+// passed as argument, what column - 'value' does in Postgres.
+func sqliteJSONArrayRemove(column string) string {
+	return fmt.Sprintf("(SELECT json_group_array(json_each.value) FROM json_each(%s) WHERE json_each.value != ?)", column)
+}
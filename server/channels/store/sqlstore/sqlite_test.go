@@ -0,0 +1,111 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql/driver"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTranslateSqliteQuery(t *testing.T) {
+	testCases := []struct {
+		name     string
+		query    string
+		expected string
+	}{
+		{
+			name:     "truncate",
+			query:    "TRUNCATE TABLE Posts",
+			expected: "DELETE FROM Posts",
+		},
+		{
+			name:     "casts",
+			query:    "SELECT Props::jsonb, Id::varchar(26), Roles::text[] FROM Users",
+			expected: "SELECT Props, Id, Roles FROM Users",
+		},
+		{
+			name:     "ilike",
+			query:    "SELECT * FROM Users WHERE Username ILIKE ?",
+			expected: "SELECT * FROM Users WHERE Username LIKE ?",
+		},
+		{
+			name:     "greatest and least",
+			query:    "SELECT GREATEST(a, b), least(c, d) FROM t",
+			expected: "SELECT max(a, b), min(c, d) FROM t",
+		},
+		{
+			name:     "json_agg",
+			query:    "SELECT json_agg(Id) FROM Users",
+			expected: "SELECT json_group_array(Id) FROM Users",
+		},
+		{
+			name:     "row locks",
+			query:    "SELECT * FROM Jobs WHERE Status = ? FOR UPDATE SKIP LOCKED",
+			expected: "SELECT * FROM Jobs WHERE Status = ?",
+		},
+		{
+			name:     "delete using with aliases",
+			query:    "DELETE FROM Sessions s USING UserAccessTokens o WHERE o.Token = s.Token AND o.Id = ?",
+			expected: "DELETE FROM Sessions AS s WHERE EXISTS (SELECT 1 FROM UserAccessTokens o WHERE o.Token = s.Token AND o.Id = ?)",
+		},
+		{
+			name: "delete using without aliases",
+			query: `DELETE FROM
+				SidebarChannels
+			USING
+				SidebarCategories
+			WHERE
+				SidebarChannels.CategoryId = SidebarCategories.Id`,
+			expected: `DELETE FROM SidebarChannels WHERE EXISTS (SELECT 1 FROM SidebarCategories WHERE SidebarChannels.CategoryId = SidebarCategories.Id)`,
+		},
+		{
+			name:     "unchanged",
+			query:    "SELECT * FROM Users WHERE Id = ?",
+			expected: "SELECT * FROM Users WHERE Id = ?",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, translateSqliteQuery(tc.query))
+		})
+	}
+}
+
+func TestSqliteTextSearchMatch(t *testing.T) {
+	testCases := []struct {
+		name     string
+		config   string
+		document string
+		query    string
+		expected bool
+	}{
+		{"stemmed word", "english", "searching the channels", "search", true},
+		{"missing word", "english", "searching the channels", "teams", false},
+		{"and", "english", "hello searching world", "hello & world", true},
+		{"and with a missing word", "english", "hello searching world", "hello & teams", false},
+		{"or", "english", "hello searching world", "teams | world", true},
+		{"not", "english", "hello searching world", "hello & !world", false},
+		{"phrase", "english", "hello searching world", "searching<->world", true},
+		{"phrase out of order", "english", "hello searching world", "world<->searching", false},
+		{"prefix", "english", "hello searching world", "sea:*", true},
+		{"stop word prefix is ignored", "english", "is now searchable", "now:* & searchable:*", true},
+		{"only stop words", "english", "the channel", "the", false},
+		{"mention", "english", "test system @testuser", "@testuser", true},
+		{"hashtag", "english", "test system #testuser", "@testuser", true},
+		{"email", "english", "contact me at someone@example.com", "someone@example.com", true},
+		{"simple config doesn't stem", "simple", "searching the channels", "search", false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			matched, err := sqliteTextSearchMatch(nil, []driver.Value{tc.config, tc.document, tc.query})
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, matched)
+		})
+	}
+}
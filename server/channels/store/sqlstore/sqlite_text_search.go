@@ -0,0 +1,359 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql/driver"
+	"strings"
+	"unicode"
+
+	"github.com/kljensen/snowball/english"
+	"modernc.org/sqlite"
+)
+
+// sqliteTextSearchMatch implements ts_match(config, document, query), the SQLite replacement for
+// to_tsvector(config, document) @@ to_tsquery(config, query). It understands the subset of the
+// to_tsquery syntax the stores generate: terms combined with & (and), | (or), ! (not), <-> (followed
+// by) and parentheses, with :* marking prefix terms. The english configuration drops stop words and
+// stems the words like Postgres does, any other configuration only lowercases them.
+func sqliteTextSearchMatch(_ *sqlite.FunctionContext, args []driver.Value) (driver.Value, error) {
+	config, _ := args[0].(string)
+	document := sqliteTextValue(args[1])
+	query := sqliteTextValue(args[2])
+	if document == "" || query == "" {
+		return false, nil
+	}
+
+	node := (&tsQueryParser{tokens: tsQueryTokens(query)}).parseOr()
+	if node == nil {
+		return false, nil
+	}
+
+	doc := newTSDocument(config, document)
+	matched, ignored := node.match(config, doc)
+	return matched && !ignored, nil
+}
+
+func sqliteTextValue(value driver.Value) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case []byte:
+		return string(v)
+	}
+	return ""
+}
+
+func isTSWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// isTSConnectorRune reports whether r joins words into a compound token, like the parts of an
+// email address, a host name or a hyphenated word.
+func isTSConnectorRune(r rune) bool {
+	return r == '@' || r == '.' || r == '-' || r == '_'
+}
+
+// normalizeTSWord returns the lexeme of a word, or an empty string for a stop word.
+func normalizeTSWord(config, word string) string {
+	word = strings.ToLower(word)
+	if config != sqliteDefaultSearchConfig {
+		return word
+	}
+
+	for _, r := range word {
+		if !isTSWordRune(r) {
+			// Compound tokens aren't stemmed.
+			return word
+		}
+	}
+	if english.IsStopWord(word) {
+		return ""
+	}
+	return english.Stem(word, false)
+}
+
+type tsDocument struct {
+	positions map[string][]int
+}
+
+func newTSDocument(config, text string) *tsDocument {
+	doc := &tsDocument{positions: map[string][]int{}}
+	position := 0
+	add := func(word string) {
+		position++
+		if lexeme := normalizeTSWord(config, word); lexeme != "" {
+			doc.positions[lexeme] = append(doc.positions[lexeme], position)
+		}
+	}
+
+	chunks := strings.FieldsFunc(text, func(r rune) bool {
+		return !isTSWordRune(r) && !isTSConnectorRune(r)
+	})
+	for _, chunk := range chunks {
+		chunk = strings.TrimFunc(chunk, isTSConnectorRune)
+		if chunk == "" {
+			continue
+		}
+
+		parts := strings.FieldsFunc(chunk, isTSConnectorRune)
+		if len(parts) > 1 {
+			add(chunk)
+		}
+		for _, part := range parts {
+			add(part)
+		}
+	}
+
+	return doc
+}
+
+// lexemePositions returns the positions of the lexeme in the document, or of all the lexemes it
+// prefixes.
+func (d *tsDocument) lexemePositions(lexeme string, prefix bool) []int {
+	if !prefix {
+		return d.positions[lexeme]
+	}
+
+	var positions []int
+	for word, wordPositions := range d.positions {
+		if strings.HasPrefix(word, lexeme) {
+			positions = append(positions, wordPositions...)
+		}
+	}
+	return positions
+}
+
+func tsQueryTokens(query string) []string {
+	var tokens []string
+	var word strings.Builder
+	flush := func() {
+		if word.Len() > 0 {
+			tokens = append(tokens, word.String())
+			word.Reset()
+		}
+	}
+
+	runes := []rune(query)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case r == '&' || r == '|' || r == '!' || r == '(' || r == ')':
+			flush()
+			tokens = append(tokens, string(r))
+		case r == '<':
+			// <-> and <N> both read as "followed by".
+			flush()
+			for i < len(runes) && runes[i] != '>' {
+				i++
+			}
+			tokens = append(tokens, "<->")
+		case r == ':' && i+1 < len(runes) && runes[i+1] == '*':
+			word.WriteString(":*")
+			i++
+			flush()
+		case unicode.IsSpace(r) || r == '"' || r == '\'' || r == ':':
+			flush()
+		default:
+			word.WriteRune(r)
+		}
+	}
+	flush()
+
+	return tokens
+}
+
+type tsNode struct {
+	op          string
+	lexeme      string
+	prefix      bool
+	left, right *tsNode
+}
+
+type tsQueryParser struct {
+	tokens []string
+	pos    int
+}
+
+func (p *tsQueryParser) peek() string {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos]
+	}
+	return ""
+}
+
+func (p *tsQueryParser) parseOr() *tsNode {
+	node := p.parseAnd()
+	for p.peek() == "|" {
+		p.pos++
+		node = combineTSNodes("|", node, p.parseAnd())
+	}
+	return node
+}
+
+func (p *tsQueryParser) parseAnd() *tsNode {
+	node := p.parsePhrase()
+	for p.peek() == "&" {
+		p.pos++
+		node = combineTSNodes("&", node, p.parsePhrase())
+	}
+	return node
+}
+
+func (p *tsQueryParser) parsePhrase() *tsNode {
+	node := p.parseNot()
+	for p.peek() == "<->" {
+		p.pos++
+		node = combineTSNodes("<->", node, p.parseNot())
+	}
+	return node
+}
+
+func (p *tsQueryParser) parseNot() *tsNode {
+	switch token := p.peek(); token {
+	case "":
+		return nil
+	case "!":
+		p.pos++
+		operand := p.parseNot()
+		if operand == nil {
+			return nil
+		}
+		return &tsNode{op: "!", left: operand}
+	case "(":
+		p.pos++
+		node := p.parseOr()
+		if p.peek() == ")" {
+			p.pos++
+		}
+		return node
+	case "&", "|", "<->", ")":
+		// A missing operand, skip the operator.
+		p.pos++
+		return nil
+	default:
+		p.pos++
+		prefix := strings.HasSuffix(token, ":*")
+		return &tsNode{op: "term", lexeme: strings.TrimSuffix(token, ":*"), prefix: prefix}
+	}
+}
+
+func combineTSNodes(op string, left, right *tsNode) *tsNode {
+	if left == nil {
+		return right
+	}
+	if right == nil {
+		return left
+	}
+	return &tsNode{op: op, left: left, right: right}
+}
+
+// match reports whether the document matches the node. Nodes made only of stop words are ignored,
+// the same way Postgres removes them from the query.
+func (n *tsNode) match(config string, doc *tsDocument) (matched, ignored bool) {
+	switch n.op {
+	case "term", "<->":
+		positions, ignored := n.positions(config, doc)
+		return len(positions) > 0, ignored
+	case "!":
+		matched, ignored := n.left.match(config, doc)
+		return !matched, ignored
+	}
+
+	leftMatched, leftIgnored := n.left.match(config, doc)
+	rightMatched, rightIgnored := n.right.match(config, doc)
+	switch {
+	case leftIgnored && rightIgnored:
+		return false, true
+	case leftIgnored:
+		return rightMatched, false
+	case rightIgnored:
+		return leftMatched, false
+	case n.op == "&":
+		return leftMatched && rightMatched, false
+	default:
+		return leftMatched || rightMatched, false
+	}
+}
+
+// positions returns the positions at which the node ends in the document.
+func (n *tsNode) positions(config string, doc *tsDocument) (positions []int, ignored bool) {
+	switch n.op {
+	case "term":
+		lexeme := n.lexeme
+		if n.prefix {
+			lexeme = strings.ToLower(lexeme)
+		}
+		var parts []string
+		for part := range strings.FieldsFuncSeq(lexeme, func(r rune) bool {
+			return !isTSWordRune(r) && !isTSConnectorRune(r)
+		}) {
+			// Like in the document, leading and trailing connectors aren't part of the word.
+			if part = strings.TrimFunc(part, isTSConnectorRune); part != "" {
+				parts = append(parts, part)
+			}
+		}
+		if len(parts) == 0 {
+			return nil, true
+		}
+		if n.prefix {
+			lexeme := normalizeTSWord(config, strings.Join(parts, ""))
+			if lexeme == "" {
+				return nil, true
+			}
+			return doc.lexemePositions(lexeme, true), false
+		}
+
+		// A term with punctuation, like an email address, matches as a phrase of its words.
+		var node *tsNode
+		for _, part := range parts {
+			normalized := normalizeTSWord(config, part)
+			if normalized == "" {
+				continue
+			}
+			node = combineTSNodes("<->", node, &tsNode{op: "lexeme", lexeme: normalized})
+		}
+		if node == nil {
+			return nil, true
+		}
+		if node.op == "lexeme" {
+			return doc.lexemePositions(node.lexeme, false), false
+		}
+		if positions := doc.lexemePositions(normalizeTSWord(config, strings.Join(parts, "")), false); len(positions) > 0 {
+			return positions, false
+		}
+		return node.positions(config, doc)
+	case "lexeme":
+		return doc.lexemePositions(n.lexeme, false), false
+	case "<->":
+		left, leftIgnored := n.left.positions(config, doc)
+		right, rightIgnored := n.right.positions(config, doc)
+		switch {
+		case leftIgnored:
+			return right, rightIgnored
+		case rightIgnored:
+			return left, false
+		}
+
+		follows := map[int]bool{}
+		for _, position := range left {
+			follows[position+1] = true
+		}
+		for _, position := range right {
+			if follows[position] {
+				positions = append(positions, position)
+			}
+		}
+		return positions, false
+	}
+
+	// Boolean operators in a phrase only tell whether they match, at an unknown position.
+	matched, ignored := n.match(config, doc)
+	if matched {
+		for _, wordPositions := range doc.positions {
+			positions = append(positions, wordPositions...)
+		}
+	}
+	return positions, ignored
+}
@@ -75,6 +75,7 @@ type sqlxDBWrapper struct {
 	queryTimeout time.Duration
 	trace        bool
 	isOnline     *atomic.Bool
+	isLagging    atomic.Bool
 }
 
 func newSqlxDBWrapper(db *sqlx.DB, timeout time.Duration, trace bool) *sqlxDBWrapper {
@@ -134,7 +135,7 @@ func (w *sqlxDBWrapper) GetBuilder(dest any, builder Builder) error {
 }
 
 func (w *sqlxDBWrapper) NamedExec(query string, arg any) (sql.Result, error) {
-	if w.DB.DriverName() == model.DatabaseDriverPostgres {
+	if w.DB.DriverName() == model.DatabaseDriverPostgres || w.DB.DriverName() == model.DatabaseDriverSqlite {
 		query = namedParamRegex.ReplaceAllStringFunc(query, strings.ToLower)
 	}
 	ctx, cancel := context.WithTimeout(context.Background(), w.queryTimeout)
@@ -192,7 +193,7 @@ func (w *sqlxDBWrapper) ExecRaw(query string, args ...any) (sql.Result, error) {
 }
 
 func (w *sqlxDBWrapper) NamedQuery(query string, arg any) (*sqlx.Rows, error) {
-	if w.DB.DriverName() == model.DatabaseDriverPostgres {
+	if w.DB.DriverName() == model.DatabaseDriverPostgres || w.DB.DriverName() == model.DatabaseDriverSqlite {
 		query = namedParamRegex.ReplaceAllStringFunc(query, strings.ToLower)
 	}
 	ctx, cancel := context.WithTimeout(context.Background(), w.queryTimeout)
@@ -348,7 +349,7 @@ func (w *sqlxTxWrapper) ExecRaw(query string, args ...any) (sql.Result, error) {
 }
 
 func (w *sqlxTxWrapper) NamedExec(query string, arg any) (sql.Result, error) {
-	if w.Tx.DriverName() == model.DatabaseDriverPostgres {
+	if w.Tx.DriverName() == model.DatabaseDriverPostgres || w.Tx.DriverName() == model.DatabaseDriverSqlite {
 		query = namedParamRegex.ReplaceAllStringFunc(query, strings.ToLower)
 	}
 	ctx, cancel := context.WithTimeout(context.Background(), w.queryTimeout)
@@ -364,7 +365,7 @@ func (w *sqlxTxWrapper) NamedExec(query string, arg any) (sql.Result, error) {
 }
 
 func (w *sqlxTxWrapper) NamedQuery(query string, arg any) (*sqlx.Rows, error) {
-	if w.Tx.DriverName() == model.DatabaseDriverPostgres {
+	if w.Tx.DriverName() == model.DatabaseDriverPostgres || w.Tx.DriverName() == model.DatabaseDriverSqlite {
 		query = namedParamRegex.ReplaceAllStringFunc(query, strings.ToLower)
 	}
 	ctx, cancel := context.WithTimeout(context.Background(), w.queryTimeout)
@@ -497,3 +498,8 @@ func (w *sqlxDBWrapper) checkErr(err error) error {
 func (w *sqlxDBWrapper) Online() bool {
 	return w.isOnline.Load()
 }
+
+// Lagging reports whether the replica lags too much behind master to be read from.
+func (w *sqlxDBWrapper) Lagging() bool {
+	return w.isLagging.Load()
+}
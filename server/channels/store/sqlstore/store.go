@@ -28,6 +28,7 @@ import (
 	"github.com/mattermost/mattermost/server/v8/channels/db"
 	"github.com/mattermost/mattermost/server/v8/channels/store"
 	"github.com/mattermost/mattermost/server/v8/einterfaces"
+	"github.com/mattermost/mattermost/server/v8/platform/services/cache"
 )
 
 type migrationDirection string
@@ -77,11 +78,16 @@ type SqlStoreStores struct {
 	webhook                    store.WebhookStore
 	command                    store.CommandStore
 	commandWebhook             store.CommandWebhookStore
+	eventSubscription          store.EventSubscriptionStore
+	emailBatchNotification     store.EmailBatchNotificationStore
 	preference                 store.PreferenceStore
 	license                    store.LicenseStore
 	token                      store.TokenStore
 	emoji                      store.EmojiStore
 	status                     store.StatusStore
+	workingHours               store.WorkingHoursStore
+	notificationRule           store.NotificationRuleStore
+	auditLog                   store.AuditLogStore
 	fileInfo                   store.FileInfoStore
 	uploadSession              store.UploadSessionStore
 	reaction                   store.ReactionStore
@@ -146,6 +152,8 @@ type SqlStore struct {
 
 	quitMonitor chan struct{}
 	wgMonitor   *sync.WaitGroup
+
+	readYourWritesCache cache.Cache
 }
 
 func SkipMigrations() Option {
@@ -231,11 +239,16 @@ func New(settings model.SqlSettings, logger mlog.LoggerIFace, metrics einterface
 	store.stores.webhook = newSqlWebhookStore(store, metrics)
 	store.stores.command = newSqlCommandStore(store)
 	store.stores.commandWebhook = newSqlCommandWebhookStore(store)
+	store.stores.eventSubscription = newSqlEventSubscriptionStore(store)
+	store.stores.emailBatchNotification = newSqlEmailBatchNotificationStore(store)
 	store.stores.preference = newSqlPreferenceStore(store)
 	store.stores.license = newSqlLicenseStore(store)
 	store.stores.token = newSqlTokenStore(store)
 	store.stores.emoji = newSqlEmojiStore(store, metrics)
 	store.stores.status = newSqlStatusStore(store)
+	store.stores.workingHours = newSqlWorkingHoursStore(store)
+	store.stores.notificationRule = newSqlNotificationRuleStore(store)
+	store.stores.auditLog = newSqlAuditLogStore(store)
 	store.stores.fileInfo = newSqlFileInfoStore(store, metrics)
 	store.stores.uploadSession = newSqlUploadSessionStore(store)
 	store.stores.thread = newSqlThreadStore(store)
@@ -284,7 +297,13 @@ func (ss *SqlStore) Logger() mlog.LoggerIFace {
 func (ss *SqlStore) initConnection() error {
 	dataSource := *ss.settings.DataSource
 
-	handle, err := sqlUtils.SetupConnection(ss.Logger(), "master", dataSource, ss.settings, DBPingAttempts)
+	var handle *sql.DB
+	var err error
+	if ss.DriverName() == model.DatabaseDriverSqlite {
+		handle, err = setupSqliteConnection(ss.Logger(), dataSource, ss.settings)
+	} else {
+		handle, err = sqlUtils.SetupConnection(ss.Logger(), "master", dataSource, ss.settings, DBPingAttempts)
+	}
 	if err != nil {
 		return err
 	}
@@ -361,13 +380,43 @@ func (ss *SqlStore) specialSearchChars() []string {
 
 	// Postgres can handle "@" without any errors
 	// Also helps postgres in enabling search for EmailAddresses
-	if ss.DriverName() != model.DatabaseDriverPostgres {
+	if ss.DriverName() != model.DatabaseDriverPostgres && ss.DriverName() != model.DatabaseDriverSqlite {
 		chars = append(chars, "@")
 	}
 
 	return chars
 }
 
+// textSearchClause returns the condition matching the document, an SQL expression, against the
+// text search query bound to the placeholder.
+func (ss *SqlStore) textSearchClause(config, document, placeholder string) string {
+	if ss.DriverName() == model.DatabaseDriverSqlite {
+		return fmt.Sprintf("ts_match('%s', %s, %s)", config, document, placeholder)
+	}
+
+	return fmt.Sprintf("to_tsvector('%[1]s', %[2]s) @@ to_tsquery('%[1]s', %[3]s)", config, document, placeholder)
+}
+
+// jsonMergeExpr returns the expression of the JSON object in column with the keys of the JSON
+// object passed as argument added or replaced.
+func (ss *SqlStore) jsonMergeExpr(column string) string {
+	if ss.DriverName() == model.DatabaseDriverSqlite {
+		return fmt.Sprintf("json_patch(%s, ?)", column)
+	}
+
+	return fmt.Sprintf("%s || ?::jsonb", column)
+}
+
+// jsonValueExpr returns the expression to compare the JSON document in column with a JSON text.
+// SQLite stores the documents as blobs, which are never equal to a text value.
+func (ss *SqlStore) jsonValueExpr(column string) string {
+	if ss.DriverName() == model.DatabaseDriverSqlite {
+		return fmt.Sprintf("CAST(%s AS TEXT)", column)
+	}
+
+	return column
+}
+
 // computeBinaryParam returns whether the data source uses binary_parameters
 // when using Postgres
 func (ss *SqlStore) computeBinaryParam() (bool, error) {
@@ -379,6 +428,10 @@ func (ss *SqlStore) computeBinaryParam() (bool, error) {
 }
 
 func (ss *SqlStore) computeDefaultTextSearchConfig() (string, error) {
+	if ss.DriverName() == model.DatabaseDriverSqlite {
+		return sqliteDefaultSearchConfig, nil
+	}
+
 	if ss.DriverName() != model.DatabaseDriverPostgres {
 		return "", nil
 	}
@@ -397,13 +450,16 @@ func (ss *SqlStore) IsBinaryParamEnabled() bool {
 // that can be parsed by callers.
 func (ss *SqlStore) GetDbVersion(numerical bool) (string, error) {
 	var sqlVersion string
-	if ss.DriverName() == model.DatabaseDriverPostgres {
+	switch ss.DriverName() {
+	case model.DatabaseDriverPostgres:
 		if numerical {
 			sqlVersion = `SHOW server_version_num`
 		} else {
 			sqlVersion = `SHOW server_version`
 		}
-	} else {
+	case model.DatabaseDriverSqlite:
+		sqlVersion = `SELECT sqlite_version()`
+	default:
 		return "", errors.New("Not supported driver")
 	}
 
@@ -413,6 +469,10 @@ func (ss *SqlStore) GetDbVersion(numerical bool) (string, error) {
 		return "", err
 	}
 
+	if numerical && ss.DriverName() == model.DatabaseDriverSqlite {
+		return sqliteNumericalVersion(version)
+	}
+
 	return version, nil
 }
 
@@ -439,11 +499,8 @@ func (ss *SqlStore) GetSearchReplicaX() *sqlxDBWrapper {
 		return ss.GetReplica()
 	}
 
-	for i := 0; i < len(ss.searchReplicaXs); i++ {
-		rrNum := atomic.AddInt64(&ss.srCounter, 1) % int64(len(ss.searchReplicaXs))
-		if ss.searchReplicaXs[rrNum].Load().Online() {
-			return ss.searchReplicaXs[rrNum].Load()
-		}
+	if replica := ss.nextReplica(ss.searchReplicaXs, &ss.srCounter); replica != nil {
+		return replica
 	}
 
 	// If all search replicas are down, then go with replica.
@@ -455,17 +512,41 @@ func (ss *SqlStore) GetReplica() *sqlxDBWrapper {
 		return ss.GetMaster()
 	}
 
-	for i := 0; i < len(ss.ReplicaXs); i++ {
-		rrNum := atomic.AddInt64(&ss.rrCounter, 1) % int64(len(ss.ReplicaXs))
-		if ss.ReplicaXs[rrNum].Load().Online() {
-			return ss.ReplicaXs[rrNum].Load()
-		}
+	if replica := ss.nextReplica(ss.ReplicaXs, &ss.rrCounter); replica != nil {
+		return replica
 	}
 
 	// If all replicas are down, then go with master.
 	return ss.GetMaster()
 }
 
+// nextReplica returns the next online replica in round robin order, skipping the lagging ones,
+// or nil if there is none.
+func (ss *SqlStore) nextReplica(replicas []*atomic.Pointer[sqlxDBWrapper], counter *int64) *sqlxDBWrapper {
+	skippedLagging := false
+	for range replicas {
+		rrNum := atomic.AddInt64(counter, 1) % int64(len(replicas))
+		replica := replicas[rrNum].Load()
+		if !replica.Online() {
+			continue
+		}
+		if replica.Lagging() {
+			skippedLagging = true
+			continue
+		}
+
+		if skippedLagging {
+			ss.countReplicaReadRedirect(ReplicaReadRedirectLag)
+		}
+		return replica
+	}
+
+	if skippedLagging {
+		ss.countReplicaReadRedirect(ReplicaReadRedirectLag)
+	}
+	return nil
+}
+
 func (ss *SqlStore) monitorReplicas() {
 	t := time.NewTicker(time.Duration(*ss.settings.ReplicaMonitorIntervalSeconds) * time.Second)
 	defer func() {
@@ -499,6 +580,14 @@ func (ss *SqlStore) monitorReplicas() {
 			for i, replica := range ss.searchReplicaXs {
 				setupReplica(replica, ss.settings.DataSourceSearchReplicas[i], "search-replica-"+strconv.Itoa(i))
 			}
+
+			for i, replica := range ss.ReplicaXs {
+				ss.checkReplicaLag(replica, "replica-"+strconv.Itoa(i))
+			}
+
+			for i, replica := range ss.searchReplicaXs {
+				ss.checkReplicaLag(replica, "search-replica-"+strconv.Itoa(i))
+			}
 		}
 	}
 }
@@ -610,13 +699,18 @@ func (ss *SqlStore) MarkSystemRanUnitTests() {
 
 func IsUniqueConstraintError(err error, indexName []string) bool {
 	unique := false
+	message := ""
 	if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
 		unique = true
+		message = err.Error()
+	} else if constraint, ok := sqliteUniqueConstraint(err); ok {
+		unique = true
+		message = constraint
 	}
 
 	field := false
 	for _, contain := range indexName {
-		if strings.Contains(err.Error(), contain) {
+		if strings.Contains(message, contain) {
 			field = true
 			break
 		}
@@ -754,6 +848,14 @@ func (ss *SqlStore) CommandWebhook() store.CommandWebhookStore {
 	return ss.stores.commandWebhook
 }
 
+func (ss *SqlStore) EventSubscription() store.EventSubscriptionStore {
+	return ss.stores.eventSubscription
+}
+
+func (ss *SqlStore) EmailBatchNotification() store.EmailBatchNotificationStore {
+	return ss.stores.emailBatchNotification
+}
+
 func (ss *SqlStore) Preference() store.PreferenceStore {
 	return ss.stores.preference
 }
@@ -774,6 +876,18 @@ func (ss *SqlStore) Status() store.StatusStore {
 	return ss.stores.status
 }
 
+func (ss *SqlStore) WorkingHours() store.WorkingHoursStore {
+	return ss.stores.workingHours
+}
+
+func (ss *SqlStore) NotificationRule() store.NotificationRuleStore {
+	return ss.stores.notificationRule
+}
+
+func (ss *SqlStore) AuditLog() store.AuditLogStore {
+	return ss.stores.auditLog
+}
+
 func (ss *SqlStore) FileInfo() store.FileInfoStore {
 	return ss.stores.fileInfo
 }
@@ -891,6 +1005,11 @@ func (ss *SqlStore) AutoTranslation() store.AutoTranslationStore {
 }
 
 func (ss *SqlStore) DropAllTables() {
+	if ss.DriverName() == model.DatabaseDriverSqlite {
+		ss.dropAllSqliteTables()
+		return
+	}
+
 	ss.masterX.Exec(`DO
 		$func$
 		BEGIN
@@ -980,6 +1099,14 @@ func (ss *SqlStore) ensureMinimumDBVersion(ver string) (bool, error) {
 		if intVer < minimumRequiredPostgresVersion {
 			return false, fmt.Errorf("minimum Postgres version requirements not met. Found: %s, Wanted: %s", versionString(intVer, *ss.settings.DriverName), versionString(minimumRequiredPostgresVersion, *ss.settings.DriverName))
 		}
+	case model.DatabaseDriverSqlite:
+		intVer, err := strconv.Atoi(ver)
+		if err != nil {
+			return false, fmt.Errorf("cannot parse DB version: %v", err)
+		}
+		if intVer < minimumRequiredSqliteVersion {
+			return false, fmt.Errorf("minimum SQLite version requirements not met. Found: %s, Wanted: %s", versionString(intVer, *ss.settings.DriverName), versionString(minimumRequiredSqliteVersion, *ss.settings.DriverName))
+		}
 	}
 	return true, nil
 }
@@ -988,7 +1115,12 @@ func (ss *SqlStore) ensureMinimumDBVersion(ver string) (bool, error) {
 // to a pretty-printed string.
 // Postgres doesn't follow three-part version numbers from 10.0 onwards:
 // https://www.postgresql.org/docs/13/libpq-status.html#LIBPQ-PQSERVERVERSION.
+// SQLite versions are encoded like SQLITE_VERSION_NUMBER, 3.38.0 being 3038000.
 func versionString(v int, driver string) string {
+	if driver == model.DatabaseDriverSqlite {
+		return fmt.Sprintf("%d.%d.%d", v/1000000, v/1000%1000, v%1000)
+	}
+
 	minor := v % 10000
 	major := v / 10000
 	return strconv.Itoa(major) + "." + strconv.Itoa(minor)
@@ -1047,7 +1179,13 @@ func (ss *SqlStore) determineMaxColumnSize(tableName, columnName string) (int, e
 	var columnSizeBytes int32
 	ss.getQueryPlaceholder()
 
-	if err := ss.GetReplica().Get(&columnSizeBytes, `
+	if ss.DriverName() == model.DatabaseDriverSqlite {
+		var err error
+		if columnSizeBytes, err = ss.sqliteColumnSize(tableName, columnName); err != nil {
+			mlog.Warn("Unable to determine the maximum supported column size for SQLite", mlog.Err(err))
+			return 0, err
+		}
+	} else if err := ss.GetReplica().Get(&columnSizeBytes, `
 		SELECT
 			COALESCE(character_maximum_length, 0)
 		FROM
@@ -160,31 +160,30 @@ func initStores(logger mlog.LoggerIFace, parallelism int) {
 	// for subtests or paused tests that might also run in parallel and initialize a new store.
 	parallelTestsPoolSize := parallelism * 2
 
+	addStoreType := func(name, driver string) {
+		storeTypes = append(storeTypes, newStoreType(name, driver))
+		if enableFullyParallelTests {
+			storePool, err := NewTestPool(logger, driver, parallelTestsPoolSize)
+			if err != nil {
+				panic(err)
+			}
+			storePools = append(storePools, storePool)
+		}
+	}
+
 	// In CI, we run the test suite for the current database set.
 	if os.Getenv("IS_CI") == "true" {
 		switch os.Getenv("MM_SQLSETTINGS_DRIVERNAME") {
 		case "postgres":
-			storeTypes = append(storeTypes, newStoreType("PostgreSQL", model.DatabaseDriverPostgres))
-			if enableFullyParallelTests {
-				pgStorePool, err := NewTestPool(logger, model.DatabaseDriverPostgres, parallelTestsPoolSize)
-				if err != nil {
-					panic(err)
-				}
-				storePools = append(storePools, pgStorePool)
-			}
+			addStoreType("PostgreSQL", model.DatabaseDriverPostgres)
+		case model.DatabaseDriverSqlite:
+			addStoreType("SQLite", model.DatabaseDriverSqlite)
 		}
+	} else if os.Getenv("MM_SQLSETTINGS_DRIVERNAME") == model.DatabaseDriverSqlite {
+		// SQLite doesn't need a database server, so the suite can run against it alone.
+		addStoreType("SQLite", model.DatabaseDriverSqlite)
 	} else {
-		storeTypes = append(storeTypes,
-			newStoreType("PostgreSQL", model.DatabaseDriverPostgres),
-		)
-
-		if enableFullyParallelTests {
-			pgStorePool, err := NewTestPool(logger, model.DatabaseDriverPostgres, parallelTestsPoolSize)
-			if err != nil {
-				panic(err)
-			}
-			storePools = append(storePools, pgStorePool)
-		}
+		addStoreType("PostgreSQL", model.DatabaseDriverPostgres)
 	}
 
 	defer func() {
@@ -923,6 +922,9 @@ func makeSqlSettings(driver string) (*model.SqlSettings, error) {
 func TestExecNoTimeout(t *testing.T) {
 	StoreTest(t, func(t *testing.T, rctx request.CTX, ss store.Store) {
 		sqlStore := ss.(*SqlStore)
+		if sqlStore.DriverName() == model.DatabaseDriverSqlite {
+			t.Skip("SQLite has no sleep function to outlast the query timeout with")
+		}
 		timeout := sqlStore.masterX.queryTimeout
 		sqlStore.masterX.queryTimeout = 1
 		defer func() {
@@ -1469,7 +1469,7 @@ func (s SqlTeamStore) GetAllForExportAfter(limit int, afterId string) ([]*model.
 		From("Teams").
 		LeftJoin("Schemes ON Teams.SchemeId = Schemes.Id").
 		Where(sq.Gt{"Teams.Id": afterId}).
-		OrderBy("Id").
+		OrderBy("Teams.Id").
 		Limit(uint64(limit)).ToSql()
 	if err != nil {
 		return nil, errors.Wrap(err, "team_tosql")
@@ -359,7 +359,7 @@ func (s *SqlThreadStore) GetThreadsForUser(rctx request.CTX, userId, teamId stri
 		Limit(pageSize)
 
 	var threads []*JoinedThread
-	err := s.GetReplica().SelectBuilder(&threads, query)
+	err := s.DBXFromContext(rctx.Context()).SelectBuilder(&threads, query)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to fetch threads for user id=%s", userId)
 	}
@@ -584,7 +584,7 @@ func (s *SqlThreadStore) GetThreadForUser(rctx request.CTX, threadMembership *mo
 			LeftJoin("PostsPriority ON PostsPriority.PostId = Threads.PostId")
 	}
 
-	err := s.GetReplica().GetBuilder(&thread, query)
+	err := s.DBXFromContext(rctx.Context()).GetBuilder(&thread, query)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, store.NewErrNotFound("Thread", threadMembership.PostId)
@@ -627,7 +627,7 @@ func (s *SqlThreadStore) MarkAllAsReadByChannels(userID string, channelIDs []str
 	now := model.GetMillis()
 
 	var query sq.UpdateBuilder
-	if s.DriverName() == model.DatabaseDriverPostgres {
+	if s.DriverName() == model.DatabaseDriverPostgres || s.DriverName() == model.DatabaseDriverSqlite {
 		query = s.getQueryBuilder().Update("ThreadMemberships").From("Threads")
 	} else {
 		query = s.getQueryBuilder().Update("ThreadMemberships", "Threads")
@@ -673,7 +673,7 @@ func (s *SqlThreadStore) MarkAllAsReadByTeam(userId, teamId string) error {
 	timestamp := model.GetMillis()
 
 	var query sq.UpdateBuilder
-	if s.DriverName() == model.DatabaseDriverPostgres {
+	if s.DriverName() == model.DatabaseDriverPostgres || s.DriverName() == model.DatabaseDriverSqlite {
 		query = s.getQueryBuilder().Update("ThreadMemberships").From("Threads")
 	} else {
 		query = s.getQueryBuilder().Update("ThreadMemberships", "Threads")
@@ -1001,6 +1001,7 @@ func (s *SqlThreadStore) PermanentDeleteBatchForRetentionPolicies(retentionPolic
 		GlobalPolicyEndTime: retentionPolicyBatchConfigs.GlobalPolicyEndTime,
 		Limit:               retentionPolicyBatchConfigs.Limit,
 		StoreDeletedIds:     false,
+		LegalHoldChannelIDs: retentionPolicyBatchConfigs.LegalHoldChannelIDs,
 	}, s.SqlStore, cursor)
 }
 
@@ -1022,6 +1023,7 @@ func (s *SqlThreadStore) PermanentDeleteBatchThreadMembershipsForRetentionPolici
 		GlobalPolicyEndTime: retentionPolicyBatchConfigs.GlobalPolicyEndTime,
 		Limit:               retentionPolicyBatchConfigs.Limit,
 		StoreDeletedIds:     false,
+		LegalHoldChannelIDs: retentionPolicyBatchConfigs.LegalHoldChannelIDs,
 	}, s.SqlStore, cursor)
 }
 
@@ -1126,6 +1128,13 @@ func (s *SqlThreadStore) updateThreadParticipantsForUserTx(trx *sqlxTxWrapper, p
 					AND NOT participants ? $3`, userIdParam, postID, userID); err != nil {
 			return err
 		}
+	} else if s.DriverName() == model.DatabaseDriverSqlite {
+		if _, err := trx.Exec(`UPDATE Threads
+			SET Participants = json_insert(Participants, '$[#]', ?)
+			WHERE PostId=?
+			AND NOT `+sqliteJSONArrayContains("Participants", "$"), userID, postID, userID); err != nil {
+			return err
+		}
 	} else {
 		// CONCAT('$[', JSON_LENGTH(Participants), ']') just generates $[n]
 		// which is the positional syntax required for appending.
@@ -2456,8 +2456,10 @@ func (us SqlUserStore) GetUserCountForReport(filter *model.UserReportOptions) (i
 
 func (us SqlUserStore) GetUserReport(filter *model.UserReportOptions) ([]*model.UserReportQuery, error) {
 	isPostgres := us.DriverName() == model.DatabaseDriverPostgres
+	// SQLite keeps PostStats as a plain view instead of a materialized one.
+	hasPostStats := isPostgres || us.DriverName() == model.DatabaseDriverSqlite
 	selectColumns := append(getUsersColumns(), "MAX(s.LastActivityAt) AS LastStatusAt")
-	if isPostgres {
+	if hasPostStats {
 		selectColumns = append(selectColumns,
 			"MAX(ps.LastPostDate) AS LastPostDate",
 			"COUNT(ps.Day) AS DaysActive",
@@ -2509,7 +2511,7 @@ func (us SqlUserStore) GetUserReport(filter *model.UserReportOptions) ([]*model.
 		query = query.Limit(uint64(filter.PageSize))
 	}
 
-	if isPostgres {
+	if hasPostStats {
 		joinSql := sq.And{}
 		if filter.StartAt > 0 {
 			startDate := time.UnixMilli(filter.StartAt)
@@ -0,0 +1,110 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+
+	sq "github.com/mattermost/squirrel"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+type SqlWorkingHoursStore struct {
+	*SqlStore
+
+	workingHoursSelectQuery sq.SelectBuilder
+}
+
+func newSqlWorkingHoursStore(sqlStore *SqlStore) store.WorkingHoursStore {
+	s := &SqlWorkingHoursStore{
+		SqlStore: sqlStore,
+	}
+
+	s.workingHoursSelectQuery = s.getQueryBuilder().
+		Select("UserId", "Enabled", "Timezone", "Schedule", "Exceptions", "QuietStatus", "UrgentBypass", "InQuietHours", "QuietStatusSet", "UpdateAt").
+		From("WorkingHours")
+
+	return s
+}
+
+func (s *SqlWorkingHoursStore) Save(workingHours *model.WorkingHours) (*model.WorkingHours, error) {
+	workingHours.PreSave()
+	if err := workingHours.IsValid(); err != nil {
+		return nil, err
+	}
+
+	builder := s.getQueryBuilder().
+		Insert("WorkingHours").
+		Columns("UserId", "Enabled", "Timezone", "Schedule", "Exceptions", "QuietStatus", "UrgentBypass", "InQuietHours", "QuietStatusSet", "UpdateAt").
+		Values(workingHours.UserId, workingHours.Enabled, workingHours.Timezone, workingHours.Schedule, workingHours.Exceptions, workingHours.QuietStatus, workingHours.UrgentBypass, workingHours.InQuietHours, workingHours.QuietStatusSet, workingHours.UpdateAt).
+		Suffix(`ON CONFLICT (UserId) DO UPDATE SET
+			Enabled = excluded.Enabled,
+			Timezone = excluded.Timezone,
+			Schedule = excluded.Schedule,
+			Exceptions = excluded.Exceptions,
+			QuietStatus = excluded.QuietStatus,
+			UrgentBypass = excluded.UrgentBypass,
+			InQuietHours = excluded.InQuietHours,
+			QuietStatusSet = excluded.QuietStatusSet,
+			UpdateAt = excluded.UpdateAt`)
+
+	if _, err := s.GetMaster().ExecBuilder(builder); err != nil {
+		return nil, errors.Wrapf(err, "failed to save WorkingHours with userId=%s", workingHours.UserId)
+	}
+
+	return workingHours, nil
+}
+
+func (s *SqlWorkingHoursStore) Get(userID string) (*model.WorkingHours, error) {
+	var workingHours model.WorkingHours
+	if err := s.GetReplica().GetBuilder(&workingHours, s.workingHoursSelectQuery.Where(sq.Eq{"UserId": userID})); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, store.NewErrNotFound("WorkingHours", userID)
+		}
+		return nil, errors.Wrapf(err, "failed to get WorkingHours with userId=%s", userID)
+	}
+
+	return &workingHours, nil
+}
+
+func (s *SqlWorkingHoursStore) GetEnabled(afterUserID string, limit int) ([]*model.WorkingHours, error) {
+	builder := s.workingHoursSelectQuery.
+		Where(sq.Eq{"Enabled": true}).
+		Where(sq.Gt{"UserId": afterUserID}).
+		OrderBy("UserId").
+		Limit(uint64(limit))
+
+	list := []*model.WorkingHours{}
+	// InQuietHours is updated by the caller, and must not be read from a lagging replica.
+	if err := s.GetMaster().SelectBuilder(&list, builder); err != nil {
+		return nil, errors.Wrap(err, "failed to get the enabled WorkingHours")
+	}
+
+	return list, nil
+}
+
+func (s *SqlWorkingHoursStore) SetInQuietHours(userID string, inQuietHours, quietStatusSet bool) error {
+	builder := s.getQueryBuilder().
+		Update("WorkingHours").
+		Set("InQuietHours", inQuietHours).
+		Set("QuietStatusSet", quietStatusSet).
+		Where(sq.Eq{"UserId": userID})
+
+	if _, err := s.GetMaster().ExecBuilder(builder); err != nil {
+		return errors.Wrapf(err, "failed to update WorkingHours with userId=%s", userID)
+	}
+
+	return nil
+}
+
+func (s *SqlWorkingHoursStore) PermanentDeleteByUser(userID string) error {
+	if _, err := s.GetMaster().Exec("DELETE FROM WorkingHours WHERE UserId = ?", userID); err != nil {
+		return errors.Wrapf(err, "failed to delete WorkingHours with userId=%s", userID)
+	}
+
+	return nil
+}
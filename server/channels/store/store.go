@@ -41,11 +41,16 @@ type Store interface {
 	Webhook() WebhookStore
 	Command() CommandStore
 	CommandWebhook() CommandWebhookStore
+	EventSubscription() EventSubscriptionStore
+	EmailBatchNotification() EmailBatchNotificationStore
 	Preference() PreferenceStore
 	License() LicenseStore
 	Token() TokenStore
 	Emoji() EmojiStore
 	Status() StatusStore
+	WorkingHours() WorkingHoursStore
+	NotificationRule() NotificationRuleStore
+	AuditLog() AuditLogStore
 	FileInfo() FileInfoStore
 	UploadSession() UploadSessionStore
 	Reaction() ReactionStore
@@ -389,6 +394,9 @@ type PostStore interface {
 	GetPostsForReporting(rctx request.CTX, queryParams model.ReportPostQueryParams) (*model.ReportPostListResponse, error)
 	GetPostsSince(rctx request.CTX, options model.GetPostsSinceOptions, allowFromCache bool, sanitizeOptions map[string]bool) (*model.PostList, error)
 	GetPostsByThread(threadID string, since int64) ([]*model.Post, error)
+	// GetRecentPostsForUser returns the latest posts created since the given time by other users in
+	// the channels of the user, excluding system messages.
+	GetRecentPostsForUser(userID string, since int64, limit int) ([]*model.Post, error)
 	GetPostAfterTime(channelID string, timestamp int64, collapsedThreads bool) (*model.Post, error)
 	GetPostIdAfterTime(channelID string, timestamp int64, collapsedThreads bool) (string, error)
 	GetPostIdBeforeTime(channelID string, timestamp int64, collapsedThreads bool) (string, error)
@@ -554,6 +562,17 @@ type AuditStore interface {
 	PermanentDeleteByUser(userID string) error
 }
 
+type AuditLogStore interface {
+	// Append chains the entry to the last one of the log and saves it, setting its
+	// sequence number and its hashes, keyed by hashKey.
+	Append(entry *model.AuditLogEntry, hashKey []byte) (*model.AuditLogEntry, error)
+	// Search returns the entries matching the search, most recent first.
+	Search(search model.AuditLogSearch) ([]*model.AuditLogEntry, error)
+	// GetRange returns up to limit entries with a sequence number greater than
+	// afterSequence, in order, read from the master.
+	GetRange(afterSequence int64, limit int) ([]*model.AuditLogEntry, error)
+}
+
 type ClusterDiscoveryStore interface {
 	Save(discovery *model.ClusterDiscovery) error
 	Delete(discovery *model.ClusterDiscovery) (bool, error)
@@ -561,6 +580,13 @@ type ClusterDiscoveryStore interface {
 	GetAll(discoveryType, clusterName string) ([]*model.ClusterDiscovery, error)
 	SetLastPingAt(discovery *model.ClusterDiscovery) error
 	Cleanup() error
+	// AcquireLease takes or renews the lease called name on behalf of holderID for
+	// durationMillis, returning whether it succeeded. It only succeeds if the lease is free,
+	// expired or already held by holderID. Expiry is measured against the database
+	// clock, so that the clocks of the nodes don't need to agree.
+	AcquireLease(name, holderID string, durationMillis int64) (bool, error)
+	// ReleaseLease gives up the lease called name if held by holderID.
+	ReleaseLease(name, holderID string) error
 }
 
 type RemoteClusterStore interface {
@@ -677,6 +703,30 @@ type CommandWebhookStore interface {
 	Cleanup()
 }
 
+type EventSubscriptionStore interface {
+	Save(subscription *model.EventSubscription) (*model.EventSubscription, error)
+	Update(subscription *model.EventSubscription) (*model.EventSubscription, error)
+	Get(id string) (*model.EventSubscription, error)
+	// GetList returns subscriptions page by page. An empty teamID returns
+	// subscriptions from every team as well as system-wide ones.
+	GetList(teamID string, offset, limit int) ([]*model.EventSubscription, error)
+	// GetForTeam returns the active subscriptions that can match an event
+	// raised in teamID: those scoped to that team and system-wide ones.
+	GetForTeam(teamID string) ([]*model.EventSubscription, error)
+	Delete(id string, deleteAt int64) error
+	PermanentDeleteByUser(userID string) error
+}
+
+type EmailBatchNotificationStore interface {
+	Save(notification *model.EmailBatchNotification) (*model.EmailBatchNotification, error)
+	// GetUserIds returns, ordered by id, the users with pending notifications
+	// whose id is after afterUserID.
+	GetUserIds(afterUserID string, limit int) ([]string, error)
+	GetForUser(userID string) ([]*model.EmailBatchNotification, error)
+	Delete(ids []string) error
+	PermanentDeleteByUser(userID string) error
+}
+
 type PreferenceStore interface {
 	Save(preferences model.Preferences) error
 	GetCategory(userID string, category string) (model.Preferences, error)
@@ -723,6 +773,9 @@ type EmojiStore interface {
 	GetByName(rctx request.CTX, name string, allowFromCache bool) (*model.Emoji, error)
 	GetMultipleByName(rctx request.CTX, names []string) ([]*model.Emoji, error)
 	GetList(offset, limit int, sort string) ([]*model.Emoji, error)
+	// GetAllAfter returns up to limit emojis in order of creation, starting after the one created
+	// at createAt with the given id.
+	GetAllAfter(createAt int64, id string, limit int) ([]*model.Emoji, error)
 	Delete(emoji *model.Emoji, timestamp int64) error
 	Search(name string, prefixOnly bool, limit int) ([]*model.Emoji, error)
 }
@@ -738,6 +791,30 @@ type StatusStore interface {
 	UpdateExpiredDNDStatuses() ([]*model.Status, error)
 }
 
+type WorkingHoursStore interface {
+	// Save creates or replaces the working hours of the user.
+	Save(workingHours *model.WorkingHours) (*model.WorkingHours, error)
+	Get(userID string) (*model.WorkingHours, error)
+	// GetEnabled returns, ordered by user id, the enabled working hours of
+	// the users whose id is after afterUserID.
+	GetEnabled(afterUserID string, limit int) ([]*model.WorkingHours, error)
+	// SetInQuietHours records whether the user is in quiet hours, and whether
+	// their status was switched when the quiet hours started.
+	SetInQuietHours(userID string, inQuietHours, quietStatusSet bool) error
+	PermanentDeleteByUser(userID string) error
+}
+
+type NotificationRuleStore interface {
+	// GetForUser returns the rules of the user, in order.
+	GetForUser(userID string) ([]*model.NotificationRule, error)
+	// GetEnabledForChannel returns, by user id, the enabled rules of the
+	// members of the channel, in order.
+	GetEnabledForChannel(channelID string) (map[string][]*model.NotificationRule, error)
+	// ReplaceForUser replaces all the rules of the user.
+	ReplaceForUser(userID string, rules []*model.NotificationRule) ([]*model.NotificationRule, error)
+	PermanentDeleteByUser(userID string) error
+}
+
 type FileInfoStore interface {
 	Save(rctx request.CTX, info *model.FileInfo) (*model.FileInfo, error)
 	Upsert(rctx request.CTX, info *model.FileInfo) (*model.FileInfo, error)
@@ -1107,6 +1184,7 @@ type ScheduledPostStore interface {
 	UpdatedScheduledPost(scheduledPost *model.ScheduledPost) error
 	Get(scheduledPostId string) (*model.ScheduledPost, error)
 	UpdateOldScheduledPosts(beforeTime int64) error
+	GetStaleRecurringScheduledPosts(beforeTime int64, perPage uint64) ([]*model.ScheduledPost, error)
 	PermanentDeleteByUser(userId string) error
 }
 
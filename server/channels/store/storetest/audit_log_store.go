@@ -0,0 +1,114 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+func TestAuditLogStore(t *testing.T, rctx request.CTX, ss store.Store, s SqlStore) {
+	t.Run("AppendChainsEntries", func(t *testing.T) { testAuditLogStoreAppendChainsEntries(t, rctx, ss) })
+	t.Run("Search", func(t *testing.T) { testAuditLogStoreSearch(t, rctx, ss) })
+}
+
+var testAuditLogHashKey = []byte(model.NewRandomString(model.AuditLogHashKeyMinLength))
+
+func makeAuditLogEntry(t *testing.T, eventName, actorID, objectID, status string) *model.AuditLogEntry {
+	t.Helper()
+
+	entry, err := model.NewAuditLogEntry(model.AuditRecord{
+		EventName: eventName,
+		Status:    status,
+		Actor:     model.AuditEventActor{UserId: actorID},
+		EventData: model.AuditEventData{ResultState: map[string]any{"id": objectID}},
+	})
+	require.NoError(t, err)
+
+	return entry
+}
+
+func lastAuditLogSequence(t *testing.T, ss store.Store) int64 {
+	t.Helper()
+
+	entries, err := ss.AuditLog().Search(model.AuditLogSearch{PerPage: 1})
+	require.NoError(t, err)
+	if len(entries) == 0 {
+		return 0
+	}
+	return entries[0].Sequence
+}
+
+func testAuditLogStoreAppendChainsEntries(t *testing.T, _ request.CTX, ss store.Store) {
+	last := lastAuditLogSequence(t, ss)
+
+	first, err := ss.AuditLog().Append(makeAuditLogEntry(t, "createPost", model.NewId(), model.NewId(), model.AuditStatusSuccess), testAuditLogHashKey)
+	require.NoError(t, err)
+	second, err := ss.AuditLog().Append(makeAuditLogEntry(t, "deletePost", model.NewId(), model.NewId(), model.AuditStatusFail), testAuditLogHashKey)
+	require.NoError(t, err)
+
+	assert.Equal(t, last+1, first.Sequence)
+	assert.Equal(t, last+2, second.Sequence)
+	assert.Equal(t, first.Hash, second.PrevHash)
+	assert.Equal(t, second.ComputeHash(testAuditLogHashKey), second.Hash)
+
+	entries, err := ss.AuditLog().GetRange(last, 10)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, first, entries[0])
+	assert.Equal(t, second, entries[1])
+
+	_, err = ss.AuditLog().Append(&model.AuditLogEntry{Id: "invalid"}, testAuditLogHashKey)
+	require.Error(t, err)
+
+	_, err = ss.AuditLog().Append(makeAuditLogEntry(t, "createPost", model.NewId(), model.NewId(), model.AuditStatusSuccess), nil)
+	require.Error(t, err)
+}
+
+func testAuditLogStoreSearch(t *testing.T, _ request.CTX, ss store.Store) {
+	actorID, objectID := model.NewId(), model.NewId()
+
+	created, err := ss.AuditLog().Append(makeAuditLogEntry(t, "createChannel", actorID, objectID, model.AuditStatusSuccess), testAuditLogHashKey)
+	require.NoError(t, err)
+	failed, err := ss.AuditLog().Append(makeAuditLogEntry(t, "deleteChannel", actorID, objectID, model.AuditStatusFail), testAuditLogHashKey)
+	require.NoError(t, err)
+	_, err = ss.AuditLog().Append(makeAuditLogEntry(t, "deleteChannel", model.NewId(), model.NewId(), model.AuditStatusSuccess), testAuditLogHashKey)
+	require.NoError(t, err)
+
+	entries, err := ss.AuditLog().Search(model.AuditLogSearch{ActorId: actorID, PerPage: 10})
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, failed.Id, entries[0].Id)
+	assert.Equal(t, created.Id, entries[1].Id)
+
+	entries, err = ss.AuditLog().Search(model.AuditLogSearch{ObjectId: objectID, EventName: "deleteChannel", PerPage: 10})
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, failed.Id, entries[0].Id)
+
+	entries, err = ss.AuditLog().Search(model.AuditLogSearch{ActorId: actorID, Status: model.AuditStatusSuccess, PerPage: 10})
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, created.Id, entries[0].Id)
+
+	entries, err = ss.AuditLog().Search(model.AuditLogSearch{ActorId: actorID, StartTime: failed.CreateAt + 1, PerPage: 10})
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+
+	entries, err = ss.AuditLog().Search(model.AuditLogSearch{ActorId: actorID, EndTime: created.CreateAt, PerPage: 10})
+	require.NoError(t, err)
+	require.NotEmpty(t, entries)
+	assert.Equal(t, created.Id, entries[len(entries)-1].Id)
+
+	entries, err = ss.AuditLog().Search(model.AuditLogSearch{ActorId: actorID, Page: 1, PerPage: 1})
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, created.Id, entries[0].Id)
+}
@@ -5,6 +5,7 @@ package storetest
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -135,6 +136,9 @@ func testAutoTranslationSetChannelEnabled(t *testing.T, rctx request.CTX, ss sto
 		require.NoError(t, nErr)
 		originalUpdateAt := originalChannel.UpdateAt
 
+		// Make sure the update lands in a later millisecond
+		time.Sleep(time.Millisecond)
+
 		// Enable autotranslation
 		appErr := ss.AutoTranslation().SetChannelEnabled(channel.Id, true)
 		require.Nil(t, appErr)
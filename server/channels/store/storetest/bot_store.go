@@ -7,6 +7,7 @@ import (
 	"errors"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -24,6 +25,9 @@ func makeBotWithUser(t *testing.T, rctx request.CTX, ss store.Store, bot *model.
 	bot, nErr := ss.Bot().Save(bot)
 	require.NoError(t, nErr)
 
+	// Bots are listed by creation time, keep the next one from being created in the same millisecond.
+	time.Sleep(time.Millisecond)
+
 	return bot, user
 }
 
@@ -21,6 +21,7 @@ func TestClusterDiscoveryStore(t *testing.T, rctx request.CTX, ss store.Store) {
 	t.Run("LastPing", func(t *testing.T) { testClusterDiscoveryStoreLastPing(t, rctx, ss) })
 	t.Run("Exists", func(t *testing.T) { testClusterDiscoveryStoreExists(t, rctx, ss) })
 	t.Run("ClusterDiscoveryGetStore", func(t *testing.T) { testClusterDiscoveryGetStore(t, rctx, ss) })
+	t.Run("Lease", func(t *testing.T) { testClusterDiscoveryLease(t, rctx, ss) })
 }
 
 func testClusterDiscoveryStore(t *testing.T, rctx request.CTX, ss store.Store) {
@@ -155,3 +156,39 @@ func testClusterDiscoveryGetStore(t *testing.T, rctx request.CTX, ss store.Store
 	require.NoError(t, err)
 	assert.Empty(t, list)
 }
+
+func testClusterDiscoveryLease(t *testing.T, rctx request.CTX, ss store.Store) {
+	name := "lease_" + model.NewId()
+	holder1 := model.NewId()
+	holder2 := model.NewId()
+
+	acquired, err := ss.ClusterDiscovery().AcquireLease(name, holder1, 60*1000)
+	require.NoError(t, err)
+	assert.True(t, acquired)
+
+	// The holder can renew its lease.
+	acquired, err = ss.ClusterDiscovery().AcquireLease(name, holder1, 120*1000)
+	require.NoError(t, err)
+	assert.True(t, acquired)
+
+	// Others can't take a lease that hasn't expired.
+	acquired, err = ss.ClusterDiscovery().AcquireLease(name, holder2, 60*1000)
+	require.NoError(t, err)
+	assert.False(t, acquired)
+
+	// Releasing someone else's lease does nothing.
+	require.NoError(t, ss.ClusterDiscovery().ReleaseLease(name, holder2))
+	acquired, err = ss.ClusterDiscovery().AcquireLease(name, holder2, 60*1000)
+	require.NoError(t, err)
+	assert.False(t, acquired)
+
+	require.NoError(t, ss.ClusterDiscovery().ReleaseLease(name, holder1))
+	acquired, err = ss.ClusterDiscovery().AcquireLease(name, holder2, -1000)
+	require.NoError(t, err)
+	assert.True(t, acquired)
+
+	// An expired lease can be taken over.
+	acquired, err = ss.ClusterDiscovery().AcquireLease(name, holder1, 60*1000)
+	require.NoError(t, err)
+	assert.True(t, acquired)
+}
@@ -0,0 +1,111 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetest
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+func TestEmailBatchNotificationStore(t *testing.T, rctx request.CTX, ss store.Store, s SqlStore) {
+	t.Run("SaveAndGetForUser", func(t *testing.T) { testEmailBatchNotificationStoreSaveAndGetForUser(t, rctx, ss) })
+	t.Run("GetUserIds", func(t *testing.T) { testEmailBatchNotificationStoreGetUserIds(t, rctx, ss) })
+	t.Run("Delete", func(t *testing.T) { testEmailBatchNotificationStoreDelete(t, rctx, ss) })
+	t.Run("PermanentDeleteByUser", func(t *testing.T) { testEmailBatchNotificationStorePermanentDeleteByUser(t, rctx, ss) })
+}
+
+func saveEmailBatchNotification(t *testing.T, ss store.Store, userID string, createAt int64) *model.EmailBatchNotification {
+	t.Helper()
+
+	notification, err := ss.EmailBatchNotification().Save(&model.EmailBatchNotification{
+		UserId:    userID,
+		PostId:    model.NewId(),
+		ChannelId: model.NewId(),
+		TeamName:  "team",
+		CreateAt:  createAt,
+	})
+	require.NoError(t, err)
+	return notification
+}
+
+func testEmailBatchNotificationStoreSaveAndGetForUser(t *testing.T, rctx request.CTX, ss store.Store) {
+	userID := model.NewId()
+
+	n2 := saveEmailBatchNotification(t, ss, userID, 20)
+	n1 := saveEmailBatchNotification(t, ss, userID, 10)
+	saveEmailBatchNotification(t, ss, model.NewId(), 10)
+
+	require.NotEmpty(t, n1.Id)
+
+	notifications, err := ss.EmailBatchNotification().GetForUser(userID)
+	require.NoError(t, err)
+	require.Len(t, notifications, 2)
+	assert.Equal(t, n1, notifications[0])
+	assert.Equal(t, n2, notifications[1])
+
+	notifications, err = ss.EmailBatchNotification().GetForUser(model.NewId())
+	require.NoError(t, err)
+	assert.Empty(t, notifications)
+}
+
+func testEmailBatchNotificationStoreGetUserIds(t *testing.T, rctx request.CTX, ss store.Store) {
+	userIDs := []string{model.NewId(), model.NewId(), model.NewId()}
+	sort.Strings(userIDs)
+	for _, userID := range userIDs {
+		saveEmailBatchNotification(t, ss, userID, 10)
+		saveEmailBatchNotification(t, ss, userID, 20)
+	}
+
+	// Start right before the first user, as other tests may have left notifications behind.
+	after := userIDs[0][:len(userIDs[0])-1]
+
+	page, err := ss.EmailBatchNotification().GetUserIds(after, 2)
+	require.NoError(t, err)
+	require.Len(t, page, 2)
+	assert.Equal(t, userIDs[0], page[0])
+
+	page, err = ss.EmailBatchNotification().GetUserIds(userIDs[0], 1000)
+	require.NoError(t, err)
+	assert.Contains(t, page, userIDs[1])
+	assert.Contains(t, page, userIDs[2])
+	assert.NotContains(t, page, userIDs[0])
+}
+
+func testEmailBatchNotificationStoreDelete(t *testing.T, rctx request.CTX, ss store.Store) {
+	userID := model.NewId()
+	n1 := saveEmailBatchNotification(t, ss, userID, 10)
+	n2 := saveEmailBatchNotification(t, ss, userID, 20)
+
+	require.NoError(t, ss.EmailBatchNotification().Delete(nil))
+	require.NoError(t, ss.EmailBatchNotification().Delete([]string{n1.Id}))
+
+	notifications, err := ss.EmailBatchNotification().GetForUser(userID)
+	require.NoError(t, err)
+	require.Len(t, notifications, 1)
+	assert.Equal(t, n2.Id, notifications[0].Id)
+}
+
+func testEmailBatchNotificationStorePermanentDeleteByUser(t *testing.T, rctx request.CTX, ss store.Store) {
+	userID := model.NewId()
+	otherUserID := model.NewId()
+	saveEmailBatchNotification(t, ss, userID, 10)
+	saveEmailBatchNotification(t, ss, otherUserID, 10)
+
+	require.NoError(t, ss.EmailBatchNotification().PermanentDeleteByUser(userID))
+
+	notifications, err := ss.EmailBatchNotification().GetForUser(userID)
+	require.NoError(t, err)
+	assert.Empty(t, notifications)
+
+	notifications, err = ss.EmailBatchNotification().GetForUser(otherUserID)
+	require.NoError(t, err)
+	assert.Len(t, notifications, 1)
+}
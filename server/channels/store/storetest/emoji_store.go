@@ -21,6 +21,7 @@ func TestEmojiStore(t *testing.T, rctx request.CTX, ss store.Store) {
 	t.Run("EmojiGetByName", func(t *testing.T) { testEmojiGetByName(t, rctx, ss) })
 	t.Run("EmojiGetMultipleByName", func(t *testing.T) { testEmojiGetMultipleByName(t, rctx, ss) })
 	t.Run("EmojiGetList", func(t *testing.T) { testEmojiGetList(t, rctx, ss) })
+	t.Run("EmojiGetAllAfter", func(t *testing.T) { testEmojiGetAllAfter(t, rctx, ss) })
 	t.Run("EmojiSearch", func(t *testing.T) { testEmojiSearch(t, rctx, ss) })
 }
 
@@ -237,6 +238,68 @@ func testEmojiGetList(t *testing.T, rctx request.CTX, ss store.Store) {
 	assert.Equal(t, emojis[2].Name, remojis[1].Name)
 }
 
+func testEmojiGetAllAfter(t *testing.T, rctx request.CTX, ss store.Store) {
+	createAt := model.GetMillis() + 100000
+	emojis := make([]*model.Emoji, 4)
+	for i := range emojis {
+		emoji := &model.Emoji{
+			CreatorId: model.NewId(),
+			Name:      model.NewId(),
+		}
+		saved, err := ss.Emoji().Save(emoji)
+		require.NoError(t, err)
+		emojis[i] = saved
+	}
+	defer func() {
+		for _, emoji := range emojis {
+			err := ss.Emoji().Delete(emoji, time.Now().Unix())
+			require.NoError(t, err)
+		}
+	}()
+
+	// Emojis are saved with the current time, so the ones created in the same millisecond are
+	// ordered by id. Later ones are only found after those.
+	var all []*model.Emoji
+	lastCreateAt, lastID := int64(0), ""
+	for {
+		page, err := ss.Emoji().GetAllAfter(lastCreateAt, lastID, 2)
+		require.NoError(t, err)
+		require.LessOrEqual(t, len(page), 2)
+		all = append(all, page...)
+		if len(page) < 2 {
+			break
+		}
+		lastCreateAt, lastID = page[len(page)-1].CreateAt, page[len(page)-1].Id
+	}
+
+	for i := 1; i < len(all); i++ {
+		assert.True(t, all[i-1].CreateAt < all[i].CreateAt || (all[i-1].CreateAt == all[i].CreateAt && all[i-1].Id < all[i].Id), "emojis should be in order of creation")
+	}
+	for _, emoji := range emojis {
+		found := false
+		for _, e := range all {
+			if e.Id == emoji.Id {
+				found = true
+				break
+			}
+		}
+		assert.Truef(t, found, "failed to get emoji with id %v", emoji.Id)
+	}
+
+	page, err := ss.Emoji().GetAllAfter(createAt, "", 10)
+	require.NoError(t, err)
+	assert.Empty(t, page)
+
+	// Deleted emojis are left out.
+	require.NoError(t, ss.Emoji().Delete(emojis[0], time.Now().Unix()))
+	page, err = ss.Emoji().GetAllAfter(emojis[0].CreateAt, "", 100)
+	require.NoError(t, err)
+	for _, e := range page {
+		assert.NotEqual(t, emojis[0].Id, e.Id)
+	}
+	emojis = emojis[1:]
+}
+
 func testEmojiSearch(t *testing.T, rctx request.CTX, ss store.Store) {
 	emojis := []model.Emoji{
 		{
@@ -0,0 +1,230 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetest
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+func TestEventSubscriptionStore(t *testing.T, rctx request.CTX, ss store.Store) {
+	t.Run("Save", func(t *testing.T) { testEventSubscriptionStoreSave(t, rctx, ss) })
+	t.Run("Update", func(t *testing.T) { testEventSubscriptionStoreUpdate(t, rctx, ss) })
+	t.Run("Get", func(t *testing.T) { testEventSubscriptionStoreGet(t, rctx, ss) })
+	t.Run("GetList", func(t *testing.T) { testEventSubscriptionStoreGetList(t, rctx, ss) })
+	t.Run("GetForTeam", func(t *testing.T) { testEventSubscriptionStoreGetForTeam(t, rctx, ss) })
+	t.Run("Delete", func(t *testing.T) { testEventSubscriptionStoreDelete(t, rctx, ss) })
+	t.Run("PermanentDeleteByUser", func(t *testing.T) { testEventSubscriptionStorePermanentDeleteByUser(t, rctx, ss) })
+}
+
+func buildEventSubscription(teamID, channelID string) *model.EventSubscription {
+	return &model.EventSubscription{
+		CreatorId:   model.NewId(),
+		TeamId:      teamID,
+		ChannelId:   channelID,
+		DisplayName: "Subscription",
+		CallbackURL: "https://example.com/events",
+		Events:      model.StringArray{model.EventSubscriptionEventPostEdited, model.EventSubscriptionEventPostDeleted},
+	}
+}
+
+func eventSubscriptionIds(subscriptions []*model.EventSubscription) []string {
+	ids := make([]string, 0, len(subscriptions))
+	for _, subscription := range subscriptions {
+		ids = append(ids, subscription.Id)
+	}
+	return ids
+}
+
+func testEventSubscriptionStoreSave(t *testing.T, rctx request.CTX, ss store.Store) {
+	t.Run("generates the id and secret", func(t *testing.T) {
+		subscription, err := ss.EventSubscription().Save(buildEventSubscription(model.NewId(), ""))
+		require.NoError(t, err)
+		assert.True(t, model.IsValidId(subscription.Id))
+		assert.Len(t, subscription.Secret, 26)
+		assert.NotZero(t, subscription.CreateAt)
+		assert.Equal(t, subscription.CreateAt, subscription.UpdateAt)
+	})
+
+	t.Run("can't save twice", func(t *testing.T) {
+		subscription, err := ss.EventSubscription().Save(buildEventSubscription("", ""))
+		require.NoError(t, err)
+
+		_, err = ss.EventSubscription().Save(subscription)
+		var invErr *store.ErrInvalidInput
+		require.True(t, errors.As(err, &invErr))
+	})
+
+	t.Run("invalid subscription", func(t *testing.T) {
+		subscription := buildEventSubscription("", "")
+		subscription.Events = model.StringArray{"not_an_event"}
+
+		_, err := ss.EventSubscription().Save(subscription)
+		var appErr *model.AppError
+		require.True(t, errors.As(err, &appErr))
+		assert.Equal(t, "model.event_subscription.unknown_event.app_error", appErr.Id)
+	})
+}
+
+func testEventSubscriptionStoreUpdate(t *testing.T, rctx request.CTX, ss store.Store) {
+	subscription, err := ss.EventSubscription().Save(buildEventSubscription(model.NewId(), ""))
+	require.NoError(t, err)
+	previousUpdateAt := subscription.UpdateAt
+
+	time.Sleep(time.Millisecond)
+
+	subscription.DisplayName = "Renamed"
+	subscription.Events = model.StringArray{model.EventSubscriptionEventReactionAdded}
+	subscription.Secret = model.NewId()
+	_, err = ss.EventSubscription().Update(subscription)
+	require.NoError(t, err)
+
+	updated, err := ss.EventSubscription().Get(subscription.Id)
+	require.NoError(t, err)
+	assert.Equal(t, "Renamed", updated.DisplayName)
+	assert.Equal(t, model.StringArray{model.EventSubscriptionEventReactionAdded}, updated.Events)
+	assert.Equal(t, subscription.Secret, updated.Secret)
+	assert.Greater(t, updated.UpdateAt, previousUpdateAt)
+
+	t.Run("invalid subscription", func(t *testing.T) {
+		subscription.CallbackURL = "not a url"
+		_, err := ss.EventSubscription().Update(subscription)
+		var appErr *model.AppError
+		require.True(t, errors.As(err, &appErr))
+	})
+}
+
+func testEventSubscriptionStoreGet(t *testing.T, rctx request.CTX, ss store.Store) {
+	subscription, err := ss.EventSubscription().Save(buildEventSubscription(model.NewId(), model.NewId()))
+	require.NoError(t, err)
+
+	got, err := ss.EventSubscription().Get(subscription.Id)
+	require.NoError(t, err)
+	assert.Equal(t, subscription, got)
+
+	_, err = ss.EventSubscription().Get(model.NewId())
+	var nfErr *store.ErrNotFound
+	require.True(t, errors.As(err, &nfErr))
+}
+
+func testEventSubscriptionStoreGetList(t *testing.T, rctx request.CTX, ss store.Store) {
+	teamID := model.NewId()
+
+	s1, err := ss.EventSubscription().Save(buildEventSubscription(teamID, ""))
+	require.NoError(t, err)
+	time.Sleep(time.Millisecond)
+	s2, err := ss.EventSubscription().Save(buildEventSubscription(teamID, model.NewId()))
+	require.NoError(t, err)
+	time.Sleep(time.Millisecond)
+	s3, err := ss.EventSubscription().Save(buildEventSubscription(teamID, ""))
+	require.NoError(t, err)
+	other, err := ss.EventSubscription().Save(buildEventSubscription(model.NewId(), ""))
+	require.NoError(t, err)
+	global, err := ss.EventSubscription().Save(buildEventSubscription("", ""))
+	require.NoError(t, err)
+	deleted, err := ss.EventSubscription().Save(buildEventSubscription(teamID, ""))
+	require.NoError(t, err)
+	require.NoError(t, ss.EventSubscription().Delete(deleted.Id, model.GetMillis()))
+
+	t.Run("by team", func(t *testing.T) {
+		subscriptions, err := ss.EventSubscription().GetList(teamID, 0, 100)
+		require.NoError(t, err)
+		assert.Equal(t, []string{s1.Id, s2.Id, s3.Id}, eventSubscriptionIds(subscriptions))
+	})
+
+	t.Run("paged", func(t *testing.T) {
+		subscriptions, err := ss.EventSubscription().GetList(teamID, 0, 2)
+		require.NoError(t, err)
+		assert.Equal(t, []string{s1.Id, s2.Id}, eventSubscriptionIds(subscriptions))
+
+		subscriptions, err = ss.EventSubscription().GetList(teamID, 2, 2)
+		require.NoError(t, err)
+		assert.Equal(t, []string{s3.Id}, eventSubscriptionIds(subscriptions))
+	})
+
+	t.Run("all teams", func(t *testing.T) {
+		subscriptions, err := ss.EventSubscription().GetList("", 0, 1000)
+		require.NoError(t, err)
+		ids := eventSubscriptionIds(subscriptions)
+		assert.Subset(t, ids, []string{s1.Id, s2.Id, s3.Id, other.Id, global.Id})
+		assert.NotContains(t, ids, deleted.Id)
+	})
+}
+
+func testEventSubscriptionStoreGetForTeam(t *testing.T, rctx request.CTX, ss store.Store) {
+	teamID := model.NewId()
+
+	team, err := ss.EventSubscription().Save(buildEventSubscription(teamID, ""))
+	require.NoError(t, err)
+	channel, err := ss.EventSubscription().Save(buildEventSubscription(teamID, model.NewId()))
+	require.NoError(t, err)
+	global, err := ss.EventSubscription().Save(buildEventSubscription("", ""))
+	require.NoError(t, err)
+	other, err := ss.EventSubscription().Save(buildEventSubscription(model.NewId(), ""))
+	require.NoError(t, err)
+	deleted, err := ss.EventSubscription().Save(buildEventSubscription(teamID, ""))
+	require.NoError(t, err)
+	require.NoError(t, ss.EventSubscription().Delete(deleted.Id, model.GetMillis()))
+
+	subscriptions, err := ss.EventSubscription().GetForTeam(teamID)
+	require.NoError(t, err)
+	ids := eventSubscriptionIds(subscriptions)
+	assert.Subset(t, ids, []string{team.Id, channel.Id, global.Id})
+	assert.NotContains(t, ids, other.Id)
+	assert.NotContains(t, ids, deleted.Id)
+
+	t.Run("events without a team only match global subscriptions", func(t *testing.T) {
+		subscriptions, err := ss.EventSubscription().GetForTeam("")
+		require.NoError(t, err)
+		ids := eventSubscriptionIds(subscriptions)
+		assert.Contains(t, ids, global.Id)
+		assert.NotContains(t, ids, team.Id)
+		assert.NotContains(t, ids, other.Id)
+	})
+}
+
+func testEventSubscriptionStoreDelete(t *testing.T, rctx request.CTX, ss store.Store) {
+	subscription, err := ss.EventSubscription().Save(buildEventSubscription(model.NewId(), ""))
+	require.NoError(t, err)
+
+	require.NoError(t, ss.EventSubscription().Delete(subscription.Id, model.GetMillis()))
+
+	_, err = ss.EventSubscription().Get(subscription.Id)
+	var nfErr *store.ErrNotFound
+	require.True(t, errors.As(err, &nfErr))
+}
+
+func testEventSubscriptionStorePermanentDeleteByUser(t *testing.T, rctx request.CTX, ss store.Store) {
+	creatorID := model.NewId()
+
+	s1 := buildEventSubscription(model.NewId(), "")
+	s1.CreatorId = creatorID
+	s1, err := ss.EventSubscription().Save(s1)
+	require.NoError(t, err)
+	s2 := buildEventSubscription("", "")
+	s2.CreatorId = creatorID
+	s2, err = ss.EventSubscription().Save(s2)
+	require.NoError(t, err)
+	kept, err := ss.EventSubscription().Save(buildEventSubscription("", ""))
+	require.NoError(t, err)
+
+	require.NoError(t, ss.EventSubscription().PermanentDeleteByUser(creatorID))
+
+	var nfErr *store.ErrNotFound
+	_, err = ss.EventSubscription().Get(s1.Id)
+	require.True(t, errors.As(err, &nfErr))
+	_, err = ss.EventSubscription().Get(s2.Id)
+	require.True(t, errors.As(err, &nfErr))
+
+	_, err = ss.EventSubscription().Get(kept.Id)
+	require.NoError(t, err)
+}
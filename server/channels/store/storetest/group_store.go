@@ -733,6 +733,8 @@ func testGroupStoreUpdate(t *testing.T, rctx request.CTX, ss store.Store) {
 	require.Contains(t, err.Error(), "unique constraint: Name")
 
 	// Cannot update CreateAt
+	// Ensure someVal differs from d1's CreateAt
+	time.Sleep(2 * time.Millisecond)
 	someVal := model.GetMillis()
 	d1.CreateAt = someVal
 	d3, err := ss.Group().Update(d1)
@@ -1967,6 +1969,8 @@ func testTeamMembersToAdd(t *testing.T, rctx request.CTX, ss store.Store) {
 	require.Empty(t, teamMembers)
 
 	// Delete and restore GroupMember should return result
+	// Ensure the restored member's CreateAt > syncable's CreateAt+1
+	time.Sleep(2 * time.Millisecond)
 	_, err = ss.Group().DeleteMember(group.Id, user.Id)
 	require.NoError(t, err)
 	_, err = ss.Group().UpsertMember(group.Id, user.Id)
@@ -2232,6 +2236,8 @@ func testChannelMembersToAdd(t *testing.T, rctx request.CTX, ss store.Store) {
 	require.Empty(t, channelMembers)
 
 	// Delete and restore GroupMember should return result
+	// Ensure the restored member's CreateAt > syncable's CreateAt+1
+	time.Sleep(2 * time.Millisecond)
 	_, err = ss.Group().DeleteMember(group.Id, user.Id)
 	require.NoError(t, err)
 	_, err = ss.Group().UpsertMember(group.Id, user.Id)
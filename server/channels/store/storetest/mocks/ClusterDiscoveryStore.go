@@ -14,6 +14,34 @@ type ClusterDiscoveryStore struct {
 	mock.Mock
 }
 
+// AcquireLease provides a mock function with given fields: name, holderID, durationMillis
+func (_m *ClusterDiscoveryStore) AcquireLease(name string, holderID string, durationMillis int64) (bool, error) {
+	ret := _m.Called(name, holderID, durationMillis)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AcquireLease")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string, int64) (bool, error)); ok {
+		return rf(name, holderID, durationMillis)
+	}
+	if rf, ok := ret.Get(0).(func(string, string, int64) bool); ok {
+		r0 = rf(name, holderID, durationMillis)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string, int64) error); ok {
+		r1 = rf(name, holderID, durationMillis)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Cleanup provides a mock function with no fields
 func (_m *ClusterDiscoveryStore) Cleanup() error {
 	ret := _m.Called()
@@ -118,6 +146,24 @@ func (_m *ClusterDiscoveryStore) GetAll(discoveryType string, clusterName string
 	return r0, r1
 }
 
+// ReleaseLease provides a mock function with given fields: name, holderID
+func (_m *ClusterDiscoveryStore) ReleaseLease(name string, holderID string) error {
+	ret := _m.Called(name, holderID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReleaseLease")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(name, holderID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Save provides a mock function with given fields: discovery
 func (_m *ClusterDiscoveryStore) Save(discovery *model.ClusterDiscovery) error {
 	ret := _m.Called(discovery)
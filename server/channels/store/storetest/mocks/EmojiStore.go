@@ -63,6 +63,36 @@ func (_m *EmojiStore) Get(rctx request.CTX, id string, allowFromCache bool) (*mo
 	return r0, r1
 }
 
+// GetAllAfter provides a mock function with given fields: createAt, id, limit
+func (_m *EmojiStore) GetAllAfter(createAt int64, id string, limit int) ([]*model.Emoji, error) {
+	ret := _m.Called(createAt, id, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAllAfter")
+	}
+
+	var r0 []*model.Emoji
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64, string, int) ([]*model.Emoji, error)); ok {
+		return rf(createAt, id, limit)
+	}
+	if rf, ok := ret.Get(0).(func(int64, string, int) []*model.Emoji); ok {
+		r0 = rf(createAt, id, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Emoji)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int64, string, int) error); ok {
+		r1 = rf(createAt, id, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetByName provides a mock function with given fields: rctx, name, allowFromCache
 func (_m *EmojiStore) GetByName(rctx request.CTX, name string, allowFromCache bool) (*model.Emoji, error) {
 	ret := _m.Called(rctx, name, allowFromCache)
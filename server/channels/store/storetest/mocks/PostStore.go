@@ -959,6 +959,36 @@ func (_m *PostStore) GetPostsSinceForSync(options model.GetPostsSinceForSyncOpti
 	return r0, r1, r2
 }
 
+// GetRecentPostsForUser provides a mock function with given fields: userID, since, limit
+func (_m *PostStore) GetRecentPostsForUser(userID string, since int64, limit int) ([]*model.Post, error) {
+	ret := _m.Called(userID, since, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRecentPostsForUser")
+	}
+
+	var r0 []*model.Post
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, int64, int) ([]*model.Post, error)); ok {
+		return rf(userID, since, limit)
+	}
+	if rf, ok := ret.Get(0).(func(string, int64, int) []*model.Post); ok {
+		r0 = rf(userID, since, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Post)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, int64, int) error); ok {
+		r1 = rf(userID, since, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetRepliesForExport provides a mock function with given fields: parentID
 func (_m *PostStore) GetRepliesForExport(parentID string) ([]*model.ReplyForExport, error) {
 	ret := _m.Called(parentID)
@@ -152,6 +152,36 @@ func (_m *ScheduledPostStore) GetScheduledPostsForUser(userId string, teamId str
 	return r0, r1
 }
 
+// GetStaleRecurringScheduledPosts provides a mock function with given fields: beforeTime, perPage
+func (_m *ScheduledPostStore) GetStaleRecurringScheduledPosts(beforeTime int64, perPage uint64) ([]*model.ScheduledPost, error) {
+	ret := _m.Called(beforeTime, perPage)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetStaleRecurringScheduledPosts")
+	}
+
+	var r0 []*model.ScheduledPost
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64, uint64) ([]*model.ScheduledPost, error)); ok {
+		return rf(beforeTime, perPage)
+	}
+	if rf, ok := ret.Get(0).(func(int64, uint64) []*model.ScheduledPost); ok {
+		r0 = rf(beforeTime, perPage)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.ScheduledPost)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int64, uint64) error); ok {
+		r1 = rf(beforeTime, perPage)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // PermanentDeleteByUser provides a mock function with given fields: userId
 func (_m *ScheduledPostStore) PermanentDeleteByUser(userId string) error {
 	ret := _m.Called(userId)
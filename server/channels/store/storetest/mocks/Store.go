@@ -160,6 +160,26 @@ func (_m *Store) Audit() store.AuditStore {
 	return r0
 }
 
+// AuditLog provides a mock function with no fields
+func (_m *Store) AuditLog() store.AuditLogStore {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for AuditLog")
+	}
+
+	var r0 store.AuditLogStore
+	if rf, ok := ret.Get(0).(func() store.AuditLogStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.AuditLogStore)
+		}
+	}
+
+	return r0
+}
+
 // AutoTranslation provides a mock function with no fields
 func (_m *Store) AutoTranslation() store.AutoTranslationStore {
 	ret := _m.Called()
@@ -430,6 +450,26 @@ func (_m *Store) DropAllTables() {
 	_m.Called()
 }
 
+// EmailBatchNotification provides a mock function with no fields
+func (_m *Store) EmailBatchNotification() store.EmailBatchNotificationStore {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for EmailBatchNotification")
+	}
+
+	var r0 store.EmailBatchNotificationStore
+	if rf, ok := ret.Get(0).(func() store.EmailBatchNotificationStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.EmailBatchNotificationStore)
+		}
+	}
+
+	return r0
+}
+
 // Emoji provides a mock function with no fields
 func (_m *Store) Emoji() store.EmojiStore {
 	ret := _m.Called()
@@ -450,6 +490,26 @@ func (_m *Store) Emoji() store.EmojiStore {
 	return r0
 }
 
+// EventSubscription provides a mock function with no fields
+func (_m *Store) EventSubscription() store.EventSubscriptionStore {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for EventSubscription")
+	}
+
+	var r0 store.EventSubscriptionStore
+	if rf, ok := ret.Get(0).(func() store.EventSubscriptionStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.EventSubscriptionStore)
+		}
+	}
+
+	return r0
+}
+
 // FileInfo provides a mock function with no fields
 func (_m *Store) FileInfo() store.FileInfoStore {
 	ret := _m.Called()
@@ -764,6 +824,26 @@ func (_m *Store) MarkSystemRanUnitTests() {
 	_m.Called()
 }
 
+// NotificationRule provides a mock function with no fields
+func (_m *Store) NotificationRule() store.NotificationRuleStore {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for NotificationRule")
+	}
+
+	var r0 store.NotificationRuleStore
+	if rf, ok := ret.Get(0).(func() store.NotificationRuleStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.NotificationRuleStore)
+		}
+	}
+
+	return r0
+}
+
 // NotifyAdmin provides a mock function with no fields
 func (_m *Store) NotifyAdmin() store.NotifyAdminStore {
 	ret := _m.Called()
@@ -1504,6 +1584,26 @@ func (_m *Store) Webhook() store.WebhookStore {
 	return r0
 }
 
+// WorkingHours provides a mock function with no fields
+func (_m *Store) WorkingHours() store.WorkingHoursStore {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for WorkingHours")
+	}
+
+	var r0 store.WorkingHoursStore
+	if rf, ok := ret.Get(0).(func() store.WorkingHoursStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.WorkingHoursStore)
+		}
+	}
+
+	return r0
+}
+
 // NewStore creates a new instance of Store. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewStore(t interface {
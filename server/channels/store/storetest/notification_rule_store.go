@@ -0,0 +1,132 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+func TestNotificationRuleStore(t *testing.T, rctx request.CTX, ss store.Store, s SqlStore) {
+	t.Run("ReplaceAndGetForUser", func(t *testing.T) { testNotificationRuleStoreReplaceAndGetForUser(t, rctx, ss) })
+	t.Run("GetEnabledForChannel", func(t *testing.T) { testNotificationRuleStoreGetEnabledForChannel(t, rctx, ss) })
+	t.Run("PermanentDeleteByUser", func(t *testing.T) { testNotificationRuleStorePermanentDeleteByUser(t, rctx, ss) })
+}
+
+func makeNotificationRule(name string, enabled bool) *model.NotificationRule {
+	return &model.NotificationRule{
+		Name:    name,
+		Enabled: enabled,
+		Conditions: model.NotificationRuleConditions{
+			FromBot:        model.NewPointer(true),
+			MessagePattern: `(?i)\bincident\b`,
+		},
+		Action: model.NotificationRuleAction{Type: model.NotificationRuleActionSuppress},
+	}
+}
+
+func testNotificationRuleStoreReplaceAndGetForUser(t *testing.T, rctx request.CTX, ss store.Store) {
+	userID := model.NewId()
+
+	rules, err := ss.NotificationRule().GetForUser(userID)
+	require.NoError(t, err)
+	assert.Empty(t, rules)
+
+	saved, err := ss.NotificationRule().ReplaceForUser(userID, []*model.NotificationRule{
+		makeNotificationRule("first", true),
+		makeNotificationRule("second", false),
+	})
+	require.NoError(t, err)
+	require.Len(t, saved, 2)
+	assert.NotEmpty(t, saved[0].Id)
+	assert.Equal(t, userID, saved[1].UserId)
+
+	rules, err = ss.NotificationRule().GetForUser(userID)
+	require.NoError(t, err)
+	require.Len(t, rules, 2)
+	assert.Equal(t, saved, rules)
+
+	// Replacing the rules changes their order, and removes the rules left out.
+	third := makeNotificationRule("third", true)
+	third.Action = model.NotificationRuleAction{Type: model.NotificationRuleActionNotify, Push: true}
+	_, err = ss.NotificationRule().ReplaceForUser(userID, []*model.NotificationRule{third, rules[0]})
+	require.NoError(t, err)
+
+	rules, err = ss.NotificationRule().GetForUser(userID)
+	require.NoError(t, err)
+	require.Len(t, rules, 2)
+	assert.Equal(t, "third", rules[0].Name)
+	assert.Equal(t, model.NotificationRuleAction{Type: model.NotificationRuleActionNotify, Push: true}, rules[0].Action)
+	assert.Equal(t, "first", rules[1].Name)
+	assert.Equal(t, int64(1), rules[1].SortOrder)
+
+	invalid := makeNotificationRule("", true)
+	_, err = ss.NotificationRule().ReplaceForUser(userID, []*model.NotificationRule{invalid})
+	require.Error(t, err)
+
+	_, err = ss.NotificationRule().ReplaceForUser(userID, []*model.NotificationRule{})
+	require.NoError(t, err)
+	rules, err = ss.NotificationRule().GetForUser(userID)
+	require.NoError(t, err)
+	assert.Empty(t, rules)
+}
+
+func testNotificationRuleStoreGetEnabledForChannel(t *testing.T, rctx request.CTX, ss store.Store) {
+	channel, err := ss.Channel().Save(rctx, &model.Channel{
+		DisplayName: model.NewId(),
+		Type:        model.ChannelTypeOpen,
+		Name:        model.NewId(),
+	}, 999)
+	require.NoError(t, err)
+
+	member, err := ss.User().Save(rctx, &model.User{Email: MakeEmail(), Username: model.NewUsername()})
+	require.NoError(t, err)
+	_, err = ss.Channel().SaveMember(rctx, &model.ChannelMember{
+		ChannelId:   channel.Id,
+		UserId:      member.Id,
+		NotifyProps: model.GetDefaultChannelNotifyProps(),
+	})
+	require.NoError(t, err)
+
+	_, err = ss.NotificationRule().ReplaceForUser(member.Id, []*model.NotificationRule{
+		makeNotificationRule("disabled", false),
+		makeNotificationRule("first", true),
+		makeNotificationRule("second", true),
+	})
+	require.NoError(t, err)
+	_, err = ss.NotificationRule().ReplaceForUser(model.NewId(), []*model.NotificationRule{makeNotificationRule("other", true)})
+	require.NoError(t, err)
+
+	rulesByUser, err := ss.NotificationRule().GetEnabledForChannel(channel.Id)
+	require.NoError(t, err)
+	require.Len(t, rulesByUser, 1)
+	require.Len(t, rulesByUser[member.Id], 2)
+	assert.Equal(t, "first", rulesByUser[member.Id][0].Name)
+	assert.Equal(t, "second", rulesByUser[member.Id][1].Name)
+}
+
+func testNotificationRuleStorePermanentDeleteByUser(t *testing.T, rctx request.CTX, ss store.Store) {
+	userID := model.NewId()
+	otherUserID := model.NewId()
+	_, err := ss.NotificationRule().ReplaceForUser(userID, []*model.NotificationRule{makeNotificationRule("rule", true)})
+	require.NoError(t, err)
+	_, err = ss.NotificationRule().ReplaceForUser(otherUserID, []*model.NotificationRule{makeNotificationRule("rule", true)})
+	require.NoError(t, err)
+
+	require.NoError(t, ss.NotificationRule().PermanentDeleteByUser(userID))
+
+	rules, err := ss.NotificationRule().GetForUser(userID)
+	require.NoError(t, err)
+	assert.Empty(t, rules)
+
+	rules, err = ss.NotificationRule().GetForUser(otherUserID)
+	require.NoError(t, err)
+	assert.Len(t, rules, 1)
+}
@@ -5,6 +5,7 @@ package storetest
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -58,6 +59,7 @@ func testPostAcknowledgementsStoreSave(t *testing.T, rctx request.CTX, ss store.
 
 	t.Run("saving should update the update at of the post", func(t *testing.T) {
 		oldUpdateAt := post.UpdateAt
+		time.Sleep(time.Millisecond)
 		ack := &model.PostAcknowledgement{PostId: post.Id, UserId: userID1, AcknowledgedAt: 0, ChannelId: post.ChannelId}
 		_, err := ss.PostAcknowledgement().SaveWithModel(ack)
 		require.NoError(t, err)
@@ -316,6 +318,7 @@ func testPostAcknowledgementsStoreBatchSave(t *testing.T, rctx request.CTX, ss s
 		currentPost, err := ss.Post().GetSingle(rctx, post.Id, false)
 		require.NoError(t, err)
 		oldUpdateAt := currentPost.UpdateAt
+		time.Sleep(time.Millisecond)
 
 		// Create a batch of new acknowledgements
 		acks := []*model.PostAcknowledgement{
@@ -389,6 +392,7 @@ func testPostAcknowledgementsStoreBatchDelete(t *testing.T, rctx request.CTX, ss
 		currentPost, err := ss.Post().GetSingle(rctx, post.Id, false)
 		require.NoError(t, err)
 		oldUpdateAt := currentPost.UpdateAt
+		time.Sleep(time.Millisecond)
 
 		// Delete acknowledgements in batch
 		err = ss.PostAcknowledgement().BatchDelete([]*model.PostAcknowledgement{ack1, ack2})
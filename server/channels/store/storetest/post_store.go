@@ -46,6 +46,7 @@ func TestPostStore(t *testing.T, rctx request.CTX, ss store.Store, s SqlStore) {
 	t.Run("GetFlaggedPosts", func(t *testing.T) { testPostStoreGetFlaggedPosts(t, rctx, ss) })
 	t.Run("GetFlaggedPostsForChannel", func(t *testing.T) { testPostStoreGetFlaggedPostsForChannel(t, rctx, ss) })
 	t.Run("GetPostsCreatedAt", func(t *testing.T) { testPostStoreGetPostsCreatedAt(t, rctx, ss) })
+	t.Run("GetRecentPostsForUser", func(t *testing.T) { testPostStoreGetRecentPostsForUser(t, rctx, ss) })
 	t.Run("Overwrite", func(t *testing.T) { testPostStoreOverwrite(t, rctx, ss) })
 	t.Run("OverwriteMultiple", func(t *testing.T) { testPostStoreOverwriteMultiple(t, rctx, ss) })
 	t.Run("GetPostsByIds", func(t *testing.T) { testPostStoreGetPostsByIds(t, rctx, ss) })
@@ -1416,6 +1417,7 @@ func testPostStoreDelete(t *testing.T, rctx request.CTX, ss store.Store) {
 		require.NoError(t, err)
 
 		beforeDeleteTime := updatedRootPost.UpdateAt
+		time.Sleep(time.Millisecond)
 
 		// Delete the reply previous to last
 		err = ss.Post().Delete(rctx, replyPost2.Id, model.GetMillis(), "")
@@ -3738,6 +3740,56 @@ func testPostStoreGetFlaggedPostsForChannel(t *testing.T, rctx request.CTX, ss s
 	require.Len(t, r.Order, 0, "should have 0 posts")
 }
 
+func testPostStoreGetRecentPostsForUser(t *testing.T, rctx request.CTX, ss store.Store) {
+	userID := model.NewId()
+	channel, err := ss.Channel().Save(rctx, &model.Channel{
+		TeamId:      model.NewId(),
+		DisplayName: "DisplayName1",
+		Name:        "channel" + model.NewId(),
+		Type:        model.ChannelTypeOpen,
+	}, -1)
+	require.NoError(t, err)
+	_, err = ss.Channel().SaveMember(rctx, &model.ChannelMember{
+		ChannelId:   channel.Id,
+		UserId:      userID,
+		NotifyProps: model.GetDefaultChannelNotifyProps(),
+	})
+	require.NoError(t, err)
+
+	since := model.GetMillis()
+	savePost := func(channelID, senderID, postType string, createAt int64) *model.Post {
+		post, err := ss.Post().Save(rctx, &model.Post{
+			ChannelId: channelID,
+			UserId:    senderID,
+			Message:   NewTestID(),
+			Type:      postType,
+			CreateAt:  createAt,
+		})
+		require.NoError(t, err)
+		return post
+	}
+
+	savePost(channel.Id, model.NewId(), "", since-1)
+	p1 := savePost(channel.Id, model.NewId(), "", since+1)
+	p2 := savePost(channel.Id, model.NewId(), "", since+2)
+	p3 := savePost(channel.Id, model.NewId(), "", since+3)
+	savePost(channel.Id, userID, "", since+4)
+	savePost(channel.Id, model.NewId(), model.PostTypeJoinChannel, since+5)
+	savePost(model.NewId(), model.NewId(), "", since+6)
+
+	posts, err := ss.Post().GetRecentPostsForUser(userID, since, 10)
+	require.NoError(t, err)
+	require.Len(t, posts, 3)
+	assert.Equal(t, p3.Id, posts[0].Id)
+	assert.Equal(t, p2.Id, posts[1].Id)
+	assert.Equal(t, p1.Id, posts[2].Id)
+
+	posts, err = ss.Post().GetRecentPostsForUser(userID, since, 2)
+	require.NoError(t, err)
+	require.Len(t, posts, 2)
+	assert.Equal(t, p3.Id, posts[0].Id)
+}
+
 func testPostStoreGetPostsCreatedAt(t *testing.T, rctx request.CTX, ss store.Store) {
 	teamID := model.NewId()
 	channel1, err := ss.Channel().Save(rctx, &model.Channel{
@@ -4471,6 +4523,66 @@ func testPostStorePermanentDeleteBatch(t *testing.T, rctx request.CTX, ss store.
 		}
 	})
 
+	t.Run("with legal hold", func(t *testing.T) {
+		heldChannel, err2 := ss.Channel().Save(rctx, &model.Channel{
+			TeamId:      team.Id,
+			DisplayName: "DisplayName",
+			Name:        "channel" + model.NewId(),
+			Type:        model.ChannelTypeOpen,
+		}, -1)
+		require.NoError(t, err2)
+		heldUserID := model.NewId()
+
+		heldByChannel, err2 := ss.Post().Save(rctx, &model.Post{
+			ChannelId: heldChannel.Id,
+			UserId:    model.NewId(),
+			Message:   "message",
+			CreateAt:  1,
+		})
+		require.NoError(t, err2)
+		heldByUser, err2 := ss.Post().Save(rctx, &model.Post{
+			ChannelId: channel.Id,
+			UserId:    heldUserID,
+			Message:   "message",
+			CreateAt:  1,
+		})
+		require.NoError(t, err2)
+		notHeld, err2 := ss.Post().Save(rctx, &model.Post{
+			ChannelId: channel.Id,
+			UserId:    model.NewId(),
+			Message:   "message",
+			CreateAt:  1,
+		})
+		require.NoError(t, err2)
+
+		deleted, _, err2 = ss.Post().PermanentDeleteBatchForRetentionPolicies(model.RetentionPolicyBatchConfigs{
+			Now:                 0,
+			GlobalPolicyEndTime: 2,
+			Limit:               1000,
+			LegalHoldUserIDs:    []string{heldUserID},
+			LegalHoldChannelIDs: []string{heldChannel.Id},
+		}, model.RetentionPolicyCursor{})
+		require.NoError(t, err2)
+		require.Equal(t, int64(1), deleted)
+
+		_, err2 = ss.Post().Get(rctx, heldByChannel.Id, model.GetPostsOptions{}, "", map[string]bool{})
+		require.NoError(t, err2, "post in a channel on legal hold should have been kept")
+		_, err2 = ss.Post().Get(rctx, heldByUser.Id, model.GetPostsOptions{}, "", map[string]bool{})
+		require.NoError(t, err2, "post of a user on legal hold should have been kept")
+		_, err2 = ss.Post().Get(rctx, notHeld.Id, model.GetPostsOptions{}, "", map[string]bool{})
+		require.Error(t, err2, "post not on legal hold should have been deleted")
+
+		// Clean up retention ids table
+		rows, err = ss.RetentionPolicy().GetIdsForDeletionByTableName("Posts", 1000)
+		require.NoError(t, err)
+		for _, row := range rows {
+			_, err = ss.Reaction().DeleteOrphanedRowsByIds(row)
+			require.NoError(t, err)
+		}
+		_, err = ss.Post().PermanentDeleteBatch(2, 1000)
+		require.NoError(t, err)
+	})
+
 	t.Run("with preserve pinned posts true", func(t *testing.T) {
 		p1 := &model.Post{}
 		p1.ChannelId = channel.Id
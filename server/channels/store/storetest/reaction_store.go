@@ -155,6 +155,7 @@ func testReactionDelete(t *testing.T, rctx request.CTX, ss store.Store) {
 		require.NoError(t, err)
 
 		firstUpdateAt := result.Posts[post.Id].UpdateAt
+		time.Sleep(time.Millisecond)
 
 		_, nErr = ss.Reaction().Delete(reaction)
 		require.NoError(t, nErr)
@@ -20,6 +20,7 @@ func TestScheduledPostStore(t *testing.T, rctx request.CTX, ss store.Store, s Sq
 	t.Run("PermanentlyDeleteScheduledPosts", func(t *testing.T) { testPermanentlyDeleteScheduledPosts(t, rctx, ss, s) })
 	t.Run("UpdatedScheduledPost", func(t *testing.T) { testUpdatedScheduledPost(t, rctx, ss, s) })
 	t.Run("UpdateOldScheduledPosts", func(t *testing.T) { testUpdateOldScheduledPosts(t, rctx, ss, s) })
+	t.Run("GetStaleRecurringScheduledPosts", func(t *testing.T) { testGetStaleRecurringScheduledPosts(t, rctx, ss, s) })
 	t.Run("PermanentDeleteByUser", func(t *testing.T) { testPermanentDeleteScheduledPostsByUser(t, rctx, ss, s) })
 }
 
@@ -471,6 +472,71 @@ func testUpdateOldScheduledPosts(t *testing.T, rctx request.CTX, ss store.Store,
 	})
 }
 
+func testGetStaleRecurringScheduledPosts(t *testing.T, rctx request.CTX, ss store.Store, s SqlStore) {
+	channel := &model.Channel{
+		TeamId:      model.NewId(),
+		Type:        model.ChannelTypeOpen,
+		Name:        "channel_name",
+		DisplayName: "Channel Name",
+	}
+	createdChannel, err := ss.Channel().Save(rctx, channel, 1000)
+	require.NoError(t, err)
+	defer func() {
+		_ = ss.Channel().PermanentDelete(rctx, createdChannel.Id)
+	}()
+
+	now := model.GetMillis()
+	userId := model.NewId()
+
+	newScheduledPost := func(scheduledAt int64, recurrence *model.ScheduledPostRecurrence) *model.ScheduledPost {
+		scheduledPost, err := ss.ScheduledPost().CreateScheduledPost(&model.ScheduledPost{
+			Draft: model.Draft{
+				CreateAt:  model.GetMillis(),
+				UserId:    userId,
+				ChannelId: createdChannel.Id,
+				Message:   "this is a scheduled post",
+			},
+			ScheduledAt: scheduledAt,
+			Recurrence:  recurrence,
+		})
+		require.NoError(t, err)
+		return scheduledPost
+	}
+
+	daily := func() *model.ScheduledPostRecurrence {
+		return &model.ScheduledPostRecurrence{Frequency: model.ScheduledPostRecurrenceDaily, TimeZone: "UTC"}
+	}
+
+	staleRecurring := newScheduledPost(now-2*86400000, daily())
+	staleOneOff := newScheduledPost(now-2*86400000, nil)
+	upcomingRecurring := newScheduledPost(now+86400000, daily())
+	defer func() {
+		_ = ss.ScheduledPost().PermanentlyDeleteScheduledPosts([]string{staleRecurring.Id, staleOneOff.Id, upcomingRecurring.Id})
+	}()
+
+	t.Run("should only return stale recurring scheduled posts", func(t *testing.T) {
+		scheduledPosts, err := ss.ScheduledPost().GetStaleRecurringScheduledPosts(now, 10)
+		require.NoError(t, err)
+		require.Len(t, scheduledPosts, 1)
+		assert.Equal(t, staleRecurring.Id, scheduledPosts[0].Id)
+		require.NotNil(t, scheduledPosts[0].Recurrence)
+		assert.Equal(t, model.ScheduledPostRecurrenceDaily, scheduledPosts[0].Recurrence.Frequency)
+	})
+
+	t.Run("old recurring scheduled posts should not be marked as unable to send", func(t *testing.T) {
+		err := ss.ScheduledPost().UpdateOldScheduledPosts(now)
+		require.NoError(t, err)
+
+		scheduledPost, err := ss.ScheduledPost().Get(staleRecurring.Id)
+		require.NoError(t, err)
+		assert.Empty(t, scheduledPost.ErrorCode)
+
+		scheduledPost, err = ss.ScheduledPost().Get(staleOneOff.Id)
+		require.NoError(t, err)
+		assert.Equal(t, model.ScheduledPostErrorUnableToSend, scheduledPost.ErrorCode)
+	})
+}
+
 func testPermanentDeleteScheduledPostsByUser(t *testing.T, rctx request.CTX, ss store.Store, s SqlStore) {
 	t.Run("should delete all scheduled posts for a given user", func(t *testing.T) {
 		userId := model.NewId()
@@ -9,6 +9,7 @@ import (
 	"net/url"
 	"os"
 	"path"
+	"path/filepath"
 	"strings"
 
 	_ "github.com/lib/pq"
@@ -50,6 +51,14 @@ func PostgreSQLSettings() *model.SqlSettings {
 	return databaseSettings("postgres", dsnURL.String())
 }
 
+// SqliteSettings returns the database settings to use a SQLite unittesting database, stored in a
+// randomly named file of the temporary directory.
+func SqliteSettings() *model.SqlSettings {
+	dataSource := filepath.Join(os.TempDir(), "mattermost_test_"+model.NewId()+".db")
+
+	return databaseSettings(model.DatabaseDriverSqlite, dataSource)
+}
+
 func postgreSQLRootDSN(dsn string) string {
 	dsnURL, err := url.Parse(dsn)
 	if err != nil {
@@ -135,6 +144,11 @@ func MakeSqlSettings(driver string) *model.SqlSettings {
 	case model.DatabaseDriverPostgres:
 		settings = PostgreSQLSettings()
 		dbName = postgreSQLDSNDatabase(*settings.DataSource)
+	case model.DatabaseDriverSqlite:
+		// The database file is created when the store first connects.
+		settings = SqliteSettings()
+		settings.ReplicaMonitorIntervalSeconds = model.NewPointer(5)
+		return settings
 	default:
 		panic("unsupported driver " + driver)
 	}
@@ -164,6 +178,13 @@ func CleanupSqlSettings(settings *model.SqlSettings) {
 	switch driver {
 	case model.DatabaseDriverPostgres:
 		dbName = postgreSQLDSNDatabase(*settings.DataSource)
+	case model.DatabaseDriverSqlite:
+		for _, suffix := range []string{"", "-wal", "-shm"} {
+			if err := os.Remove(*settings.DataSource + suffix); err != nil && !os.IsNotExist(err) {
+				panic("failed to remove temporary database " + *settings.DataSource + suffix + ": " + err.Error())
+			}
+		}
+		return
 	default:
 		panic("unsupported driver " + driver)
 	}
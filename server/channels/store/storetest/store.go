@@ -34,12 +34,17 @@ type Store struct {
 	WebhookStore                    mocks.WebhookStore
 	CommandStore                    mocks.CommandStore
 	CommandWebhookStore             mocks.CommandWebhookStore
+	EventSubscriptionStore          mocks.EventSubscriptionStore
+	EmailBatchNotificationStore     mocks.EmailBatchNotificationStore
 	PreferenceStore                 mocks.PreferenceStore
 	LicenseStore                    mocks.LicenseStore
 	TokenStore                      mocks.TokenStore
 	EmojiStore                      mocks.EmojiStore
 	ThreadStore                     mocks.ThreadStore
 	StatusStore                     mocks.StatusStore
+	WorkingHoursStore               mocks.WorkingHoursStore
+	NotificationRuleStore           mocks.NotificationRuleStore
+	AuditLogStore                   mocks.AuditLogStore
 	FileInfoStore                   mocks.FileInfoStore
 	UploadSessionStore              mocks.UploadSessionStore
 	ReactionStore                   mocks.ReactionStore
@@ -90,16 +95,23 @@ func (s *Store) OAuth() store.OAuthStore                       { return &s.OAuth
 func (s *Store) OutgoingOAuthConnection() store.OutgoingOAuthConnectionStore {
 	return &s.OutgoingOAuthConnectionStore
 }
-func (s *Store) System() store.SystemStore                         { return &s.SystemStore }
-func (s *Store) Webhook() store.WebhookStore                       { return &s.WebhookStore }
-func (s *Store) Command() store.CommandStore                       { return &s.CommandStore }
-func (s *Store) CommandWebhook() store.CommandWebhookStore         { return &s.CommandWebhookStore }
+func (s *Store) System() store.SystemStore                       { return &s.SystemStore }
+func (s *Store) Webhook() store.WebhookStore                     { return &s.WebhookStore }
+func (s *Store) Command() store.CommandStore                     { return &s.CommandStore }
+func (s *Store) CommandWebhook() store.CommandWebhookStore       { return &s.CommandWebhookStore }
+func (s *Store) EventSubscription() store.EventSubscriptionStore { return &s.EventSubscriptionStore }
+func (s *Store) EmailBatchNotification() store.EmailBatchNotificationStore {
+	return &s.EmailBatchNotificationStore
+}
 func (s *Store) Preference() store.PreferenceStore                 { return &s.PreferenceStore }
 func (s *Store) License() store.LicenseStore                       { return &s.LicenseStore }
 func (s *Store) Token() store.TokenStore                           { return &s.TokenStore }
 func (s *Store) Emoji() store.EmojiStore                           { return &s.EmojiStore }
 func (s *Store) Thread() store.ThreadStore                         { return &s.ThreadStore }
 func (s *Store) Status() store.StatusStore                         { return &s.StatusStore }
+func (s *Store) WorkingHours() store.WorkingHoursStore             { return &s.WorkingHoursStore }
+func (s *Store) NotificationRule() store.NotificationRuleStore     { return &s.NotificationRuleStore }
+func (s *Store) AuditLog() store.AuditLogStore                     { return &s.AuditLogStore }
 func (s *Store) FileInfo() store.FileInfoStore                     { return &s.FileInfoStore }
 func (s *Store) UploadSession() store.UploadSessionStore           { return &s.UploadSessionStore }
 func (s *Store) Reaction() store.ReactionStore                     { return &s.ReactionStore }
@@ -191,11 +203,16 @@ func (s *Store) AssertExpectations(t mock.TestingT) bool {
 		&s.WebhookStore,
 		&s.CommandStore,
 		&s.CommandWebhookStore,
+		&s.EventSubscriptionStore,
+		&s.EmailBatchNotificationStore,
 		&s.PreferenceStore,
 		&s.LicenseStore,
 		&s.TokenStore,
 		&s.EmojiStore,
 		&s.StatusStore,
+		&s.WorkingHoursStore,
+		&s.NotificationRuleStore,
+		&s.AuditLogStore,
 		&s.FileInfoStore,
 		&s.UploadSessionStore,
 		&s.ReactionStore,
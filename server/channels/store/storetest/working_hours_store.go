@@ -0,0 +1,129 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetest
+
+import (
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+func TestWorkingHoursStore(t *testing.T, rctx request.CTX, ss store.Store, s SqlStore) {
+	t.Run("SaveAndGet", func(t *testing.T) { testWorkingHoursStoreSaveAndGet(t, rctx, ss) })
+	t.Run("GetEnabled", func(t *testing.T) { testWorkingHoursStoreGetEnabled(t, rctx, ss) })
+	t.Run("SetInQuietHours", func(t *testing.T) { testWorkingHoursStoreSetInQuietHours(t, rctx, ss) })
+	t.Run("PermanentDeleteByUser", func(t *testing.T) { testWorkingHoursStorePermanentDeleteByUser(t, rctx, ss) })
+}
+
+func saveWorkingHours(t *testing.T, ss store.Store, userID string, enabled bool) *model.WorkingHours {
+	t.Helper()
+
+	workingHours, err := ss.WorkingHours().Save(&model.WorkingHours{
+		UserId:   userID,
+		Enabled:  enabled,
+		Timezone: "Europe/Paris",
+		Schedule: model.WorkingHoursRanges{{Day: time.Monday, Start: "09:00", End: "17:00"}},
+	})
+	require.NoError(t, err)
+	return workingHours
+}
+
+func testWorkingHoursStoreSaveAndGet(t *testing.T, rctx request.CTX, ss store.Store) {
+	userID := model.NewId()
+
+	_, err := ss.WorkingHours().Get(userID)
+	var nfErr *store.ErrNotFound
+	require.ErrorAs(t, err, &nfErr)
+
+	saved := saveWorkingHours(t, ss, userID, true)
+	assert.Equal(t, model.StatusDnd, saved.QuietStatus)
+
+	workingHours, err := ss.WorkingHours().Get(userID)
+	require.NoError(t, err)
+	assert.Equal(t, saved, workingHours)
+
+	// Saving again replaces the working hours.
+	workingHours.Exceptions = model.WorkingHoursExceptions{{Date: "2026-12-25", Name: "Christmas"}}
+	workingHours.QuietStatus = model.StatusAway
+	workingHours.UrgentBypass = true
+	_, err = ss.WorkingHours().Save(workingHours)
+	require.NoError(t, err)
+
+	updated, err := ss.WorkingHours().Get(userID)
+	require.NoError(t, err)
+	assert.Equal(t, workingHours, updated)
+
+	_, err = ss.WorkingHours().Save(&model.WorkingHours{UserId: userID, Timezone: "Nowhere/Special"})
+	require.Error(t, err)
+}
+
+func testWorkingHoursStoreGetEnabled(t *testing.T, rctx request.CTX, ss store.Store) {
+	userIDs := []string{model.NewId(), model.NewId(), model.NewId()}
+	sort.Strings(userIDs)
+	for _, userID := range userIDs {
+		saveWorkingHours(t, ss, userID, true)
+	}
+	disabledUserID := model.NewId()
+	saveWorkingHours(t, ss, disabledUserID, false)
+
+	var enabled []string
+	afterUserID := ""
+	for {
+		list, err := ss.WorkingHours().GetEnabled(afterUserID, 2)
+		require.NoError(t, err)
+		if len(list) == 0 {
+			break
+		}
+		require.LessOrEqual(t, len(list), 2)
+		for _, workingHours := range list {
+			require.True(t, workingHours.Enabled)
+			enabled = append(enabled, workingHours.UserId)
+		}
+		afterUserID = list[len(list)-1].UserId
+	}
+
+	assert.Subset(t, enabled, userIDs)
+	assert.NotContains(t, enabled, disabledUserID)
+	assert.True(t, sort.StringsAreSorted(enabled))
+}
+
+func testWorkingHoursStoreSetInQuietHours(t *testing.T, rctx request.CTX, ss store.Store) {
+	userID := model.NewId()
+	saveWorkingHours(t, ss, userID, true)
+
+	require.NoError(t, ss.WorkingHours().SetInQuietHours(userID, true, true))
+	workingHours, err := ss.WorkingHours().Get(userID)
+	require.NoError(t, err)
+	assert.True(t, workingHours.InQuietHours)
+	assert.True(t, workingHours.QuietStatusSet)
+
+	require.NoError(t, ss.WorkingHours().SetInQuietHours(userID, false, false))
+	workingHours, err = ss.WorkingHours().Get(userID)
+	require.NoError(t, err)
+	assert.False(t, workingHours.InQuietHours)
+	assert.False(t, workingHours.QuietStatusSet)
+}
+
+func testWorkingHoursStorePermanentDeleteByUser(t *testing.T, rctx request.CTX, ss store.Store) {
+	userID := model.NewId()
+	otherUserID := model.NewId()
+	saveWorkingHours(t, ss, userID, true)
+	saveWorkingHours(t, ss, otherUserID, true)
+
+	require.NoError(t, ss.WorkingHours().PermanentDeleteByUser(userID))
+
+	_, err := ss.WorkingHours().Get(userID)
+	var nfErr *store.ErrNotFound
+	require.ErrorAs(t, err, &nfErr)
+
+	_, err = ss.WorkingHours().Get(otherUserID)
+	require.NoError(t, err)
+}
@@ -22,6 +22,7 @@ type TimerLayer struct {
 	AccessControlPolicyStore        store.AccessControlPolicyStore
 	AttributesStore                 store.AttributesStore
 	AuditStore                      store.AuditStore
+	AuditLogStore                   store.AuditLogStore
 	AutoTranslationStore            store.AutoTranslationStore
 	BotStore                        store.BotStore
 	ChannelStore                    store.ChannelStore
@@ -34,12 +35,15 @@ type TimerLayer struct {
 	ContentFlaggingStore            store.ContentFlaggingStore
 	DesktopTokensStore              store.DesktopTokensStore
 	DraftStore                      store.DraftStore
+	EmailBatchNotificationStore     store.EmailBatchNotificationStore
 	EmojiStore                      store.EmojiStore
+	EventSubscriptionStore          store.EventSubscriptionStore
 	FileInfoStore                   store.FileInfoStore
 	GroupStore                      store.GroupStore
 	JobStore                        store.JobStore
 	LicenseStore                    store.LicenseStore
 	LinkMetadataStore               store.LinkMetadataStore
+	NotificationRuleStore           store.NotificationRuleStore
 	NotifyAdminStore                store.NotifyAdminStore
 	OAuthStore                      store.OAuthStore
 	OutgoingOAuthConnectionStore    store.OutgoingOAuthConnectionStore
@@ -72,6 +76,7 @@ type TimerLayer struct {
 	UserAccessTokenStore            store.UserAccessTokenStore
 	UserTermsOfServiceStore         store.UserTermsOfServiceStore
 	WebhookStore                    store.WebhookStore
+	WorkingHoursStore               store.WorkingHoursStore
 }
 
 func (s *TimerLayer) AccessControlPolicy() store.AccessControlPolicyStore {
@@ -86,6 +91,10 @@ func (s *TimerLayer) Audit() store.AuditStore {
 	return s.AuditStore
 }
 
+func (s *TimerLayer) AuditLog() store.AuditLogStore {
+	return s.AuditLogStore
+}
+
 func (s *TimerLayer) AutoTranslation() store.AutoTranslationStore {
 	return s.AutoTranslationStore
 }
@@ -134,10 +143,18 @@ func (s *TimerLayer) Draft() store.DraftStore {
 	return s.DraftStore
 }
 
+func (s *TimerLayer) EmailBatchNotification() store.EmailBatchNotificationStore {
+	return s.EmailBatchNotificationStore
+}
+
 func (s *TimerLayer) Emoji() store.EmojiStore {
 	return s.EmojiStore
 }
 
+func (s *TimerLayer) EventSubscription() store.EventSubscriptionStore {
+	return s.EventSubscriptionStore
+}
+
 func (s *TimerLayer) FileInfo() store.FileInfoStore {
 	return s.FileInfoStore
 }
@@ -158,6 +175,10 @@ func (s *TimerLayer) LinkMetadata() store.LinkMetadataStore {
 	return s.LinkMetadataStore
 }
 
+func (s *TimerLayer) NotificationRule() store.NotificationRuleStore {
+	return s.NotificationRuleStore
+}
+
 func (s *TimerLayer) NotifyAdmin() store.NotifyAdminStore {
 	return s.NotifyAdminStore
 }
@@ -286,6 +307,10 @@ func (s *TimerLayer) Webhook() store.WebhookStore {
 	return s.WebhookStore
 }
 
+func (s *TimerLayer) WorkingHours() store.WorkingHoursStore {
+	return s.WorkingHoursStore
+}
+
 type TimerLayerAccessControlPolicyStore struct {
 	store.AccessControlPolicyStore
 	Root *TimerLayer
@@ -301,6 +326,11 @@ type TimerLayerAuditStore struct {
 	Root *TimerLayer
 }
 
+type TimerLayerAuditLogStore struct {
+	store.AuditLogStore
+	Root *TimerLayer
+}
+
 type TimerLayerAutoTranslationStore struct {
 	store.AutoTranslationStore
 	Root *TimerLayer
@@ -361,11 +391,21 @@ type TimerLayerDraftStore struct {
 	Root *TimerLayer
 }
 
+type TimerLayerEmailBatchNotificationStore struct {
+	store.EmailBatchNotificationStore
+	Root *TimerLayer
+}
+
 type TimerLayerEmojiStore struct {
 	store.EmojiStore
 	Root *TimerLayer
 }
 
+type TimerLayerEventSubscriptionStore struct {
+	store.EventSubscriptionStore
+	Root *TimerLayer
+}
+
 type TimerLayerFileInfoStore struct {
 	store.FileInfoStore
 	Root *TimerLayer
@@ -391,6 +431,11 @@ type TimerLayerLinkMetadataStore struct {
 	Root *TimerLayer
 }
 
+type TimerLayerNotificationRuleStore struct {
+	store.NotificationRuleStore
+	Root *TimerLayer
+}
+
 type TimerLayerNotifyAdminStore struct {
 	store.NotifyAdminStore
 	Root *TimerLayer
@@ -551,6 +596,11 @@ type TimerLayerWebhookStore struct {
 	Root *TimerLayer
 }
 
+type TimerLayerWorkingHoursStore struct {
+	store.WorkingHoursStore
+	Root *TimerLayer
+}
+
 func (s *TimerLayerAccessControlPolicyStore) Delete(rctx request.CTX, id string) error {
 	start := time.Now()
 
@@ -759,6 +809,54 @@ func (s *TimerLayerAuditStore) Save(audit *model.Audit) error {
 	return err
 }
 
+func (s *TimerLayerAuditLogStore) Append(entry *model.AuditLogEntry, hashKey []byte) (*model.AuditLogEntry, error) {
+	start := time.Now()
+
+	result, err := s.AuditLogStore.Append(entry, hashKey)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("AuditLogStore.Append", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerAuditLogStore) GetRange(afterSequence int64, limit int) ([]*model.AuditLogEntry, error) {
+	start := time.Now()
+
+	result, err := s.AuditLogStore.GetRange(afterSequence, limit)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("AuditLogStore.GetRange", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerAuditLogStore) Search(search model.AuditLogSearch) ([]*model.AuditLogEntry, error) {
+	start := time.Now()
+
+	result, err := s.AuditLogStore.Search(search)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("AuditLogStore.Search", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerAutoTranslationStore) ClearCaches() {
 	start := time.Now()
 
@@ -3211,6 +3309,22 @@ func (s *TimerLayerChannelMemberHistoryStore) PermanentDeleteBatchForRetentionPo
 	return result, resultVar1, err
 }
 
+func (s *TimerLayerClusterDiscoveryStore) AcquireLease(name string, holderID string, durationMillis int64) (bool, error) {
+	start := time.Now()
+
+	result, err := s.ClusterDiscoveryStore.AcquireLease(name, holderID, durationMillis)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ClusterDiscoveryStore.AcquireLease", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerClusterDiscoveryStore) Cleanup() error {
 	start := time.Now()
 
@@ -3275,6 +3389,22 @@ func (s *TimerLayerClusterDiscoveryStore) GetAll(discoveryType string, clusterNa
 	return result, err
 }
 
+func (s *TimerLayerClusterDiscoveryStore) ReleaseLease(name string, holderID string) error {
+	start := time.Now()
+
+	err := s.ClusterDiscoveryStore.ReleaseLease(name, holderID)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ClusterDiscoveryStore.ReleaseLease", success, elapsed)
+	}
+	return err
+}
+
 func (s *TimerLayerClusterDiscoveryStore) Save(discovery *model.ClusterDiscovery) error {
 	start := time.Now()
 
@@ -3881,6 +4011,86 @@ func (s *TimerLayerDraftStore) Upsert(d *model.Draft) (*model.Draft, error) {
 	return result, err
 }
 
+func (s *TimerLayerEmailBatchNotificationStore) Delete(ids []string) error {
+	start := time.Now()
+
+	err := s.EmailBatchNotificationStore.Delete(ids)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("EmailBatchNotificationStore.Delete", success, elapsed)
+	}
+	return err
+}
+
+func (s *TimerLayerEmailBatchNotificationStore) GetForUser(userID string) ([]*model.EmailBatchNotification, error) {
+	start := time.Now()
+
+	result, err := s.EmailBatchNotificationStore.GetForUser(userID)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("EmailBatchNotificationStore.GetForUser", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerEmailBatchNotificationStore) GetUserIds(afterUserID string, limit int) ([]string, error) {
+	start := time.Now()
+
+	result, err := s.EmailBatchNotificationStore.GetUserIds(afterUserID, limit)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("EmailBatchNotificationStore.GetUserIds", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerEmailBatchNotificationStore) PermanentDeleteByUser(userID string) error {
+	start := time.Now()
+
+	err := s.EmailBatchNotificationStore.PermanentDeleteByUser(userID)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("EmailBatchNotificationStore.PermanentDeleteByUser", success, elapsed)
+	}
+	return err
+}
+
+func (s *TimerLayerEmailBatchNotificationStore) Save(notification *model.EmailBatchNotification) (*model.EmailBatchNotification, error) {
+	start := time.Now()
+
+	result, err := s.EmailBatchNotificationStore.Save(notification)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("EmailBatchNotificationStore.Save", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerEmojiStore) Delete(emoji *model.Emoji, timestamp int64) error {
 	start := time.Now()
 
@@ -3913,6 +4123,22 @@ func (s *TimerLayerEmojiStore) Get(rctx request.CTX, id string, allowFromCache b
 	return result, err
 }
 
+func (s *TimerLayerEmojiStore) GetAllAfter(createAt int64, id string, limit int) ([]*model.Emoji, error) {
+	start := time.Now()
+
+	result, err := s.EmojiStore.GetAllAfter(createAt, id, limit)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("EmojiStore.GetAllAfter", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerEmojiStore) GetByName(rctx request.CTX, name string, allowFromCache bool) (*model.Emoji, error) {
 	start := time.Now()
 
@@ -3993,6 +4219,118 @@ func (s *TimerLayerEmojiStore) Search(name string, prefixOnly bool, limit int) (
 	return result, err
 }
 
+func (s *TimerLayerEventSubscriptionStore) Delete(id string, deleteAt int64) error {
+	start := time.Now()
+
+	err := s.EventSubscriptionStore.Delete(id, deleteAt)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("EventSubscriptionStore.Delete", success, elapsed)
+	}
+	return err
+}
+
+func (s *TimerLayerEventSubscriptionStore) Get(id string) (*model.EventSubscription, error) {
+	start := time.Now()
+
+	result, err := s.EventSubscriptionStore.Get(id)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("EventSubscriptionStore.Get", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerEventSubscriptionStore) GetForTeam(teamID string) ([]*model.EventSubscription, error) {
+	start := time.Now()
+
+	result, err := s.EventSubscriptionStore.GetForTeam(teamID)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("EventSubscriptionStore.GetForTeam", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerEventSubscriptionStore) GetList(teamID string, offset int, limit int) ([]*model.EventSubscription, error) {
+	start := time.Now()
+
+	result, err := s.EventSubscriptionStore.GetList(teamID, offset, limit)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("EventSubscriptionStore.GetList", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerEventSubscriptionStore) PermanentDeleteByUser(userID string) error {
+	start := time.Now()
+
+	err := s.EventSubscriptionStore.PermanentDeleteByUser(userID)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("EventSubscriptionStore.PermanentDeleteByUser", success, elapsed)
+	}
+	return err
+}
+
+func (s *TimerLayerEventSubscriptionStore) Save(subscription *model.EventSubscription) (*model.EventSubscription, error) {
+	start := time.Now()
+
+	result, err := s.EventSubscriptionStore.Save(subscription)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("EventSubscriptionStore.Save", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerEventSubscriptionStore) Update(subscription *model.EventSubscription) (*model.EventSubscription, error) {
+	start := time.Now()
+
+	result, err := s.EventSubscriptionStore.Update(subscription)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("EventSubscriptionStore.Update", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerFileInfoStore) AttachToPost(rctx request.CTX, fileID string, postID string, channelID string, creatorID string) error {
 	start := time.Now()
 
@@ -5655,6 +5993,70 @@ func (s *TimerLayerLinkMetadataStore) Save(linkMetadata *model.LinkMetadata) (*m
 	return result, err
 }
 
+func (s *TimerLayerNotificationRuleStore) GetEnabledForChannel(channelID string) (map[string][]*model.NotificationRule, error) {
+	start := time.Now()
+
+	result, err := s.NotificationRuleStore.GetEnabledForChannel(channelID)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("NotificationRuleStore.GetEnabledForChannel", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerNotificationRuleStore) GetForUser(userID string) ([]*model.NotificationRule, error) {
+	start := time.Now()
+
+	result, err := s.NotificationRuleStore.GetForUser(userID)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("NotificationRuleStore.GetForUser", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerNotificationRuleStore) PermanentDeleteByUser(userID string) error {
+	start := time.Now()
+
+	err := s.NotificationRuleStore.PermanentDeleteByUser(userID)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("NotificationRuleStore.PermanentDeleteByUser", success, elapsed)
+	}
+	return err
+}
+
+func (s *TimerLayerNotificationRuleStore) ReplaceForUser(userID string, rules []*model.NotificationRule) ([]*model.NotificationRule, error) {
+	start := time.Now()
+
+	result, err := s.NotificationRuleStore.ReplaceForUser(userID, rules)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("NotificationRuleStore.ReplaceForUser", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerNotifyAdminStore) DeleteBefore(trial bool, now int64) error {
 	start := time.Now()
 
@@ -6790,6 +7192,22 @@ func (s *TimerLayerPostStore) GetPostsCreatedAt(channelID string, timestamp int6
 	return result, err
 }
 
+func (s *TimerLayerPostStore) GetPostsForReporting(rctx request.CTX, queryParams model.ReportPostQueryParams) (*model.ReportPostListResponse, error) {
+	start := time.Now()
+
+	result, err := s.PostStore.GetPostsForReporting(rctx, queryParams)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetPostsForReporting", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerPostStore) GetPostsSince(rctx request.CTX, options model.GetPostsSinceOptions, allowFromCache bool, sanitizeOptions map[string]bool) (*model.PostList, error) {
 	start := time.Now()
 
@@ -6822,6 +7240,22 @@ func (s *TimerLayerPostStore) GetPostsSinceForSync(options model.GetPostsSinceFo
 	return result, resultVar1, err
 }
 
+func (s *TimerLayerPostStore) GetRecentPostsForUser(userID string, since int64, limit int) ([]*model.Post, error) {
+	start := time.Now()
+
+	result, err := s.PostStore.GetRecentPostsForUser(userID, since, limit)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.GetRecentPostsForUser", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerPostStore) GetRepliesForExport(parentID string) ([]*model.ReplyForExport, error) {
 	start := time.Now()
 
@@ -8949,6 +9383,22 @@ func (s *TimerLayerScheduledPostStore) GetScheduledPostsForUser(userId string, t
 	return result, err
 }
 
+func (s *TimerLayerScheduledPostStore) GetStaleRecurringScheduledPosts(beforeTime int64, perPage uint64) ([]*model.ScheduledPost, error) {
+	start := time.Now()
+
+	result, err := s.ScheduledPostStore.GetStaleRecurringScheduledPosts(beforeTime, perPage)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ScheduledPostStore.GetStaleRecurringScheduledPosts", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerScheduledPostStore) PermanentDeleteByUser(userId string) error {
 	start := time.Now()
 
@@ -13660,6 +14110,86 @@ func (s *TimerLayerWebhookStore) UpdateOutgoing(hook *model.OutgoingWebhook) (*m
 	return result, err
 }
 
+func (s *TimerLayerWorkingHoursStore) Get(userID string) (*model.WorkingHours, error) {
+	start := time.Now()
+
+	result, err := s.WorkingHoursStore.Get(userID)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("WorkingHoursStore.Get", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerWorkingHoursStore) GetEnabled(afterUserID string, limit int) ([]*model.WorkingHours, error) {
+	start := time.Now()
+
+	result, err := s.WorkingHoursStore.GetEnabled(afterUserID, limit)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("WorkingHoursStore.GetEnabled", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerWorkingHoursStore) PermanentDeleteByUser(userID string) error {
+	start := time.Now()
+
+	err := s.WorkingHoursStore.PermanentDeleteByUser(userID)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("WorkingHoursStore.PermanentDeleteByUser", success, elapsed)
+	}
+	return err
+}
+
+func (s *TimerLayerWorkingHoursStore) Save(workingHours *model.WorkingHours) (*model.WorkingHours, error) {
+	start := time.Now()
+
+	result, err := s.WorkingHoursStore.Save(workingHours)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("WorkingHoursStore.Save", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerWorkingHoursStore) SetInQuietHours(userID string, inQuietHours bool, quietStatusSet bool) error {
+	start := time.Now()
+
+	err := s.WorkingHoursStore.SetInQuietHours(userID, inQuietHours, quietStatusSet)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("WorkingHoursStore.SetInQuietHours", success, elapsed)
+	}
+	return err
+}
+
 func (s *TimerLayer) Close() {
 	s.Store.Close()
 }
@@ -13701,6 +14231,7 @@ func New(childStore store.Store, metrics einterfaces.MetricsInterface) *TimerLay
 	newStore.AccessControlPolicyStore = &TimerLayerAccessControlPolicyStore{AccessControlPolicyStore: childStore.AccessControlPolicy(), Root: &newStore}
 	newStore.AttributesStore = &TimerLayerAttributesStore{AttributesStore: childStore.Attributes(), Root: &newStore}
 	newStore.AuditStore = &TimerLayerAuditStore{AuditStore: childStore.Audit(), Root: &newStore}
+	newStore.AuditLogStore = &TimerLayerAuditLogStore{AuditLogStore: childStore.AuditLog(), Root: &newStore}
 	newStore.AutoTranslationStore = &TimerLayerAutoTranslationStore{AutoTranslationStore: childStore.AutoTranslation(), Root: &newStore}
 	newStore.BotStore = &TimerLayerBotStore{BotStore: childStore.Bot(), Root: &newStore}
 	newStore.ChannelStore = &TimerLayerChannelStore{ChannelStore: childStore.Channel(), Root: &newStore}
@@ -13713,12 +14244,15 @@ func New(childStore store.Store, metrics einterfaces.MetricsInterface) *TimerLay
 	newStore.ContentFlaggingStore = &TimerLayerContentFlaggingStore{ContentFlaggingStore: childStore.ContentFlagging(), Root: &newStore}
 	newStore.DesktopTokensStore = &TimerLayerDesktopTokensStore{DesktopTokensStore: childStore.DesktopTokens(), Root: &newStore}
 	newStore.DraftStore = &TimerLayerDraftStore{DraftStore: childStore.Draft(), Root: &newStore}
+	newStore.EmailBatchNotificationStore = &TimerLayerEmailBatchNotificationStore{EmailBatchNotificationStore: childStore.EmailBatchNotification(), Root: &newStore}
 	newStore.EmojiStore = &TimerLayerEmojiStore{EmojiStore: childStore.Emoji(), Root: &newStore}
+	newStore.EventSubscriptionStore = &TimerLayerEventSubscriptionStore{EventSubscriptionStore: childStore.EventSubscription(), Root: &newStore}
 	newStore.FileInfoStore = &TimerLayerFileInfoStore{FileInfoStore: childStore.FileInfo(), Root: &newStore}
 	newStore.GroupStore = &TimerLayerGroupStore{GroupStore: childStore.Group(), Root: &newStore}
 	newStore.JobStore = &TimerLayerJobStore{JobStore: childStore.Job(), Root: &newStore}
 	newStore.LicenseStore = &TimerLayerLicenseStore{LicenseStore: childStore.License(), Root: &newStore}
 	newStore.LinkMetadataStore = &TimerLayerLinkMetadataStore{LinkMetadataStore: childStore.LinkMetadata(), Root: &newStore}
+	newStore.NotificationRuleStore = &TimerLayerNotificationRuleStore{NotificationRuleStore: childStore.NotificationRule(), Root: &newStore}
 	newStore.NotifyAdminStore = &TimerLayerNotifyAdminStore{NotifyAdminStore: childStore.NotifyAdmin(), Root: &newStore}
 	newStore.OAuthStore = &TimerLayerOAuthStore{OAuthStore: childStore.OAuth(), Root: &newStore}
 	newStore.OutgoingOAuthConnectionStore = &TimerLayerOutgoingOAuthConnectionStore{OutgoingOAuthConnectionStore: childStore.OutgoingOAuthConnection(), Root: &newStore}
@@ -13751,5 +14285,6 @@ func New(childStore store.Store, metrics einterfaces.MetricsInterface) *TimerLay
 	newStore.UserAccessTokenStore = &TimerLayerUserAccessTokenStore{UserAccessTokenStore: childStore.UserAccessToken(), Root: &newStore}
 	newStore.UserTermsOfServiceStore = &TimerLayerUserTermsOfServiceStore{UserTermsOfServiceStore: childStore.UserTermsOfService(), Root: &newStore}
 	newStore.WebhookStore = &TimerLayerWebhookStore{WebhookStore: childStore.Webhook(), Root: &newStore}
+	newStore.WorkingHoursStore = &TimerLayerWorkingHoursStore{WorkingHoursStore: childStore.WorkingHours(), Root: &newStore}
 	return &newStore
 }
@@ -0,0 +1,10733 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+// Code generated by "make store-layers"
+// DO NOT EDIT
+
+package tracinglayer
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/codes"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+	"github.com/mattermost/mattermost/server/v8/platform/services/tracing"
+)
+
+type TracingLayer struct {
+	store.Store
+	AccessControlPolicyStore        store.AccessControlPolicyStore
+	AttributesStore                 store.AttributesStore
+	AuditStore                      store.AuditStore
+	AuditLogStore                   store.AuditLogStore
+	AutoTranslationStore            store.AutoTranslationStore
+	BotStore                        store.BotStore
+	ChannelStore                    store.ChannelStore
+	ChannelBookmarkStore            store.ChannelBookmarkStore
+	ChannelMemberHistoryStore       store.ChannelMemberHistoryStore
+	ClusterDiscoveryStore           store.ClusterDiscoveryStore
+	CommandStore                    store.CommandStore
+	CommandWebhookStore             store.CommandWebhookStore
+	ComplianceStore                 store.ComplianceStore
+	ContentFlaggingStore            store.ContentFlaggingStore
+	DesktopTokensStore              store.DesktopTokensStore
+	DraftStore                      store.DraftStore
+	EmailBatchNotificationStore     store.EmailBatchNotificationStore
+	EmojiStore                      store.EmojiStore
+	EventSubscriptionStore          store.EventSubscriptionStore
+	FileInfoStore                   store.FileInfoStore
+	GroupStore                      store.GroupStore
+	JobStore                        store.JobStore
+	LicenseStore                    store.LicenseStore
+	LinkMetadataStore               store.LinkMetadataStore
+	NotificationRuleStore           store.NotificationRuleStore
+	NotifyAdminStore                store.NotifyAdminStore
+	OAuthStore                      store.OAuthStore
+	OutgoingOAuthConnectionStore    store.OutgoingOAuthConnectionStore
+	PluginStore                     store.PluginStore
+	PostStore                       store.PostStore
+	PostAcknowledgementStore        store.PostAcknowledgementStore
+	PostPersistentNotificationStore store.PostPersistentNotificationStore
+	PostPriorityStore               store.PostPriorityStore
+	PreferenceStore                 store.PreferenceStore
+	ProductNoticesStore             store.ProductNoticesStore
+	PropertyFieldStore              store.PropertyFieldStore
+	PropertyGroupStore              store.PropertyGroupStore
+	PropertyValueStore              store.PropertyValueStore
+	ReactionStore                   store.ReactionStore
+	RemoteClusterStore              store.RemoteClusterStore
+	RetentionPolicyStore            store.RetentionPolicyStore
+	RoleStore                       store.RoleStore
+	ScheduledPostStore              store.ScheduledPostStore
+	SchemeStore                     store.SchemeStore
+	SessionStore                    store.SessionStore
+	SharedChannelStore              store.SharedChannelStore
+	StatusStore                     store.StatusStore
+	SystemStore                     store.SystemStore
+	TeamStore                       store.TeamStore
+	TermsOfServiceStore             store.TermsOfServiceStore
+	ThreadStore                     store.ThreadStore
+	TokenStore                      store.TokenStore
+	UploadSessionStore              store.UploadSessionStore
+	UserStore                       store.UserStore
+	UserAccessTokenStore            store.UserAccessTokenStore
+	UserTermsOfServiceStore         store.UserTermsOfServiceStore
+	WebhookStore                    store.WebhookStore
+	WorkingHoursStore               store.WorkingHoursStore
+}
+
+func (s *TracingLayer) AccessControlPolicy() store.AccessControlPolicyStore {
+	return s.AccessControlPolicyStore
+}
+
+func (s *TracingLayer) Attributes() store.AttributesStore {
+	return s.AttributesStore
+}
+
+func (s *TracingLayer) Audit() store.AuditStore {
+	return s.AuditStore
+}
+
+func (s *TracingLayer) AuditLog() store.AuditLogStore {
+	return s.AuditLogStore
+}
+
+func (s *TracingLayer) AutoTranslation() store.AutoTranslationStore {
+	return s.AutoTranslationStore
+}
+
+func (s *TracingLayer) Bot() store.BotStore {
+	return s.BotStore
+}
+
+func (s *TracingLayer) Channel() store.ChannelStore {
+	return s.ChannelStore
+}
+
+func (s *TracingLayer) ChannelBookmark() store.ChannelBookmarkStore {
+	return s.ChannelBookmarkStore
+}
+
+func (s *TracingLayer) ChannelMemberHistory() store.ChannelMemberHistoryStore {
+	return s.ChannelMemberHistoryStore
+}
+
+func (s *TracingLayer) ClusterDiscovery() store.ClusterDiscoveryStore {
+	return s.ClusterDiscoveryStore
+}
+
+func (s *TracingLayer) Command() store.CommandStore {
+	return s.CommandStore
+}
+
+func (s *TracingLayer) CommandWebhook() store.CommandWebhookStore {
+	return s.CommandWebhookStore
+}
+
+func (s *TracingLayer) Compliance() store.ComplianceStore {
+	return s.ComplianceStore
+}
+
+func (s *TracingLayer) ContentFlagging() store.ContentFlaggingStore {
+	return s.ContentFlaggingStore
+}
+
+func (s *TracingLayer) DesktopTokens() store.DesktopTokensStore {
+	return s.DesktopTokensStore
+}
+
+func (s *TracingLayer) Draft() store.DraftStore {
+	return s.DraftStore
+}
+
+func (s *TracingLayer) EmailBatchNotification() store.EmailBatchNotificationStore {
+	return s.EmailBatchNotificationStore
+}
+
+func (s *TracingLayer) Emoji() store.EmojiStore {
+	return s.EmojiStore
+}
+
+func (s *TracingLayer) EventSubscription() store.EventSubscriptionStore {
+	return s.EventSubscriptionStore
+}
+
+func (s *TracingLayer) FileInfo() store.FileInfoStore {
+	return s.FileInfoStore
+}
+
+func (s *TracingLayer) Group() store.GroupStore {
+	return s.GroupStore
+}
+
+func (s *TracingLayer) Job() store.JobStore {
+	return s.JobStore
+}
+
+func (s *TracingLayer) License() store.LicenseStore {
+	return s.LicenseStore
+}
+
+func (s *TracingLayer) LinkMetadata() store.LinkMetadataStore {
+	return s.LinkMetadataStore
+}
+
+func (s *TracingLayer) NotificationRule() store.NotificationRuleStore {
+	return s.NotificationRuleStore
+}
+
+func (s *TracingLayer) NotifyAdmin() store.NotifyAdminStore {
+	return s.NotifyAdminStore
+}
+
+func (s *TracingLayer) OAuth() store.OAuthStore {
+	return s.OAuthStore
+}
+
+func (s *TracingLayer) OutgoingOAuthConnection() store.OutgoingOAuthConnectionStore {
+	return s.OutgoingOAuthConnectionStore
+}
+
+func (s *TracingLayer) Plugin() store.PluginStore {
+	return s.PluginStore
+}
+
+func (s *TracingLayer) Post() store.PostStore {
+	return s.PostStore
+}
+
+func (s *TracingLayer) PostAcknowledgement() store.PostAcknowledgementStore {
+	return s.PostAcknowledgementStore
+}
+
+func (s *TracingLayer) PostPersistentNotification() store.PostPersistentNotificationStore {
+	return s.PostPersistentNotificationStore
+}
+
+func (s *TracingLayer) PostPriority() store.PostPriorityStore {
+	return s.PostPriorityStore
+}
+
+func (s *TracingLayer) Preference() store.PreferenceStore {
+	return s.PreferenceStore
+}
+
+func (s *TracingLayer) ProductNotices() store.ProductNoticesStore {
+	return s.ProductNoticesStore
+}
+
+func (s *TracingLayer) PropertyField() store.PropertyFieldStore {
+	return s.PropertyFieldStore
+}
+
+func (s *TracingLayer) PropertyGroup() store.PropertyGroupStore {
+	return s.PropertyGroupStore
+}
+
+func (s *TracingLayer) PropertyValue() store.PropertyValueStore {
+	return s.PropertyValueStore
+}
+
+func (s *TracingLayer) Reaction() store.ReactionStore {
+	return s.ReactionStore
+}
+
+func (s *TracingLayer) RemoteCluster() store.RemoteClusterStore {
+	return s.RemoteClusterStore
+}
+
+func (s *TracingLayer) RetentionPolicy() store.RetentionPolicyStore {
+	return s.RetentionPolicyStore
+}
+
+func (s *TracingLayer) Role() store.RoleStore {
+	return s.RoleStore
+}
+
+func (s *TracingLayer) ScheduledPost() store.ScheduledPostStore {
+	return s.ScheduledPostStore
+}
+
+func (s *TracingLayer) Scheme() store.SchemeStore {
+	return s.SchemeStore
+}
+
+func (s *TracingLayer) Session() store.SessionStore {
+	return s.SessionStore
+}
+
+func (s *TracingLayer) SharedChannel() store.SharedChannelStore {
+	return s.SharedChannelStore
+}
+
+func (s *TracingLayer) Status() store.StatusStore {
+	return s.StatusStore
+}
+
+func (s *TracingLayer) System() store.SystemStore {
+	return s.SystemStore
+}
+
+func (s *TracingLayer) Team() store.TeamStore {
+	return s.TeamStore
+}
+
+func (s *TracingLayer) TermsOfService() store.TermsOfServiceStore {
+	return s.TermsOfServiceStore
+}
+
+func (s *TracingLayer) Thread() store.ThreadStore {
+	return s.ThreadStore
+}
+
+func (s *TracingLayer) Token() store.TokenStore {
+	return s.TokenStore
+}
+
+func (s *TracingLayer) UploadSession() store.UploadSessionStore {
+	return s.UploadSessionStore
+}
+
+func (s *TracingLayer) User() store.UserStore {
+	return s.UserStore
+}
+
+func (s *TracingLayer) UserAccessToken() store.UserAccessTokenStore {
+	return s.UserAccessTokenStore
+}
+
+func (s *TracingLayer) UserTermsOfService() store.UserTermsOfServiceStore {
+	return s.UserTermsOfServiceStore
+}
+
+func (s *TracingLayer) Webhook() store.WebhookStore {
+	return s.WebhookStore
+}
+
+func (s *TracingLayer) WorkingHours() store.WorkingHoursStore {
+	return s.WorkingHoursStore
+}
+
+type TracingLayerAccessControlPolicyStore struct {
+	store.AccessControlPolicyStore
+	Root *TracingLayer
+}
+
+type TracingLayerAttributesStore struct {
+	store.AttributesStore
+	Root *TracingLayer
+}
+
+type TracingLayerAuditStore struct {
+	store.AuditStore
+	Root *TracingLayer
+}
+
+type TracingLayerAuditLogStore struct {
+	store.AuditLogStore
+	Root *TracingLayer
+}
+
+type TracingLayerAutoTranslationStore struct {
+	store.AutoTranslationStore
+	Root *TracingLayer
+}
+
+type TracingLayerBotStore struct {
+	store.BotStore
+	Root *TracingLayer
+}
+
+type TracingLayerChannelStore struct {
+	store.ChannelStore
+	Root *TracingLayer
+}
+
+type TracingLayerChannelBookmarkStore struct {
+	store.ChannelBookmarkStore
+	Root *TracingLayer
+}
+
+type TracingLayerChannelMemberHistoryStore struct {
+	store.ChannelMemberHistoryStore
+	Root *TracingLayer
+}
+
+type TracingLayerClusterDiscoveryStore struct {
+	store.ClusterDiscoveryStore
+	Root *TracingLayer
+}
+
+type TracingLayerCommandStore struct {
+	store.CommandStore
+	Root *TracingLayer
+}
+
+type TracingLayerCommandWebhookStore struct {
+	store.CommandWebhookStore
+	Root *TracingLayer
+}
+
+type TracingLayerComplianceStore struct {
+	store.ComplianceStore
+	Root *TracingLayer
+}
+
+type TracingLayerContentFlaggingStore struct {
+	store.ContentFlaggingStore
+	Root *TracingLayer
+}
+
+type TracingLayerDesktopTokensStore struct {
+	store.DesktopTokensStore
+	Root *TracingLayer
+}
+
+type TracingLayerDraftStore struct {
+	store.DraftStore
+	Root *TracingLayer
+}
+
+type TracingLayerEmailBatchNotificationStore struct {
+	store.EmailBatchNotificationStore
+	Root *TracingLayer
+}
+
+type TracingLayerEmojiStore struct {
+	store.EmojiStore
+	Root *TracingLayer
+}
+
+type TracingLayerEventSubscriptionStore struct {
+	store.EventSubscriptionStore
+	Root *TracingLayer
+}
+
+type TracingLayerFileInfoStore struct {
+	store.FileInfoStore
+	Root *TracingLayer
+}
+
+type TracingLayerGroupStore struct {
+	store.GroupStore
+	Root *TracingLayer
+}
+
+type TracingLayerJobStore struct {
+	store.JobStore
+	Root *TracingLayer
+}
+
+type TracingLayerLicenseStore struct {
+	store.LicenseStore
+	Root *TracingLayer
+}
+
+type TracingLayerLinkMetadataStore struct {
+	store.LinkMetadataStore
+	Root *TracingLayer
+}
+
+type TracingLayerNotificationRuleStore struct {
+	store.NotificationRuleStore
+	Root *TracingLayer
+}
+
+type TracingLayerNotifyAdminStore struct {
+	store.NotifyAdminStore
+	Root *TracingLayer
+}
+
+type TracingLayerOAuthStore struct {
+	store.OAuthStore
+	Root *TracingLayer
+}
+
+type TracingLayerOutgoingOAuthConnectionStore struct {
+	store.OutgoingOAuthConnectionStore
+	Root *TracingLayer
+}
+
+type TracingLayerPluginStore struct {
+	store.PluginStore
+	Root *TracingLayer
+}
+
+type TracingLayerPostStore struct {
+	store.PostStore
+	Root *TracingLayer
+}
+
+type TracingLayerPostAcknowledgementStore struct {
+	store.PostAcknowledgementStore
+	Root *TracingLayer
+}
+
+type TracingLayerPostPersistentNotificationStore struct {
+	store.PostPersistentNotificationStore
+	Root *TracingLayer
+}
+
+type TracingLayerPostPriorityStore struct {
+	store.PostPriorityStore
+	Root *TracingLayer
+}
+
+type TracingLayerPreferenceStore struct {
+	store.PreferenceStore
+	Root *TracingLayer
+}
+
+type TracingLayerProductNoticesStore struct {
+	store.ProductNoticesStore
+	Root *TracingLayer
+}
+
+type TracingLayerPropertyFieldStore struct {
+	store.PropertyFieldStore
+	Root *TracingLayer
+}
+
+type TracingLayerPropertyGroupStore struct {
+	store.PropertyGroupStore
+	Root *TracingLayer
+}
+
+type TracingLayerPropertyValueStore struct {
+	store.PropertyValueStore
+	Root *TracingLayer
+}
+
+type TracingLayerReactionStore struct {
+	store.ReactionStore
+	Root *TracingLayer
+}
+
+type TracingLayerRemoteClusterStore struct {
+	store.RemoteClusterStore
+	Root *TracingLayer
+}
+
+type TracingLayerRetentionPolicyStore struct {
+	store.RetentionPolicyStore
+	Root *TracingLayer
+}
+
+type TracingLayerRoleStore struct {
+	store.RoleStore
+	Root *TracingLayer
+}
+
+type TracingLayerScheduledPostStore struct {
+	store.ScheduledPostStore
+	Root *TracingLayer
+}
+
+type TracingLayerSchemeStore struct {
+	store.SchemeStore
+	Root *TracingLayer
+}
+
+type TracingLayerSessionStore struct {
+	store.SessionStore
+	Root *TracingLayer
+}
+
+type TracingLayerSharedChannelStore struct {
+	store.SharedChannelStore
+	Root *TracingLayer
+}
+
+type TracingLayerStatusStore struct {
+	store.StatusStore
+	Root *TracingLayer
+}
+
+type TracingLayerSystemStore struct {
+	store.SystemStore
+	Root *TracingLayer
+}
+
+type TracingLayerTeamStore struct {
+	store.TeamStore
+	Root *TracingLayer
+}
+
+type TracingLayerTermsOfServiceStore struct {
+	store.TermsOfServiceStore
+	Root *TracingLayer
+}
+
+type TracingLayerThreadStore struct {
+	store.ThreadStore
+	Root *TracingLayer
+}
+
+type TracingLayerTokenStore struct {
+	store.TokenStore
+	Root *TracingLayer
+}
+
+type TracingLayerUploadSessionStore struct {
+	store.UploadSessionStore
+	Root *TracingLayer
+}
+
+type TracingLayerUserStore struct {
+	store.UserStore
+	Root *TracingLayer
+}
+
+type TracingLayerUserAccessTokenStore struct {
+	store.UserAccessTokenStore
+	Root *TracingLayer
+}
+
+type TracingLayerUserTermsOfServiceStore struct {
+	store.UserTermsOfServiceStore
+	Root *TracingLayer
+}
+
+type TracingLayerWebhookStore struct {
+	store.WebhookStore
+	Root *TracingLayer
+}
+
+type TracingLayerWorkingHoursStore struct {
+	store.WorkingHoursStore
+	Root *TracingLayer
+}
+
+// requestContext returns the context of the request, if any.
+func requestContext(rctx request.CTX) context.Context {
+	if rctx == nil {
+		return context.Background()
+	}
+	return parentContext(rctx.Context())
+}
+
+// parentContext returns ctx, or an empty context if nil.
+func parentContext(ctx context.Context) context.Context {
+	if ctx == nil {
+		return context.Background()
+	}
+	return ctx
+}
+
+func (s *TracingLayerAccessControlPolicyStore) Delete(rctx request.CTX, id string) error {
+	_, span := tracing.Start(requestContext(rctx), "AccessControlPolicyStore.Delete")
+	defer span.End()
+
+	err := s.AccessControlPolicyStore.Delete(rctx, id)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerAccessControlPolicyStore) Get(rctx request.CTX, id string) (*model.AccessControlPolicy, error) {
+	_, span := tracing.Start(requestContext(rctx), "AccessControlPolicyStore.Get")
+	defer span.End()
+
+	result, err := s.AccessControlPolicyStore.Get(rctx, id)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerAccessControlPolicyStore) Save(rctx request.CTX, policy *model.AccessControlPolicy) (*model.AccessControlPolicy, error) {
+	_, span := tracing.Start(requestContext(rctx), "AccessControlPolicyStore.Save")
+	defer span.End()
+
+	result, err := s.AccessControlPolicyStore.Save(rctx, policy)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerAccessControlPolicyStore) SearchPolicies(rctx request.CTX, opts model.AccessControlPolicySearch) ([]*model.AccessControlPolicy, int64, error) {
+	_, span := tracing.Start(requestContext(rctx), "AccessControlPolicyStore.SearchPolicies")
+	defer span.End()
+
+	result, resultVar1, err := s.AccessControlPolicyStore.SearchPolicies(rctx, opts)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, resultVar1, err
+}
+
+func (s *TracingLayerAccessControlPolicyStore) SetActiveStatus(rctx request.CTX, id string, active bool) (*model.AccessControlPolicy, error) {
+	_, span := tracing.Start(requestContext(rctx), "AccessControlPolicyStore.SetActiveStatus")
+	defer span.End()
+
+	result, err := s.AccessControlPolicyStore.SetActiveStatus(rctx, id, active)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerAccessControlPolicyStore) SetActiveStatusMultiple(rctx request.CTX, list []model.AccessControlPolicyActiveUpdate) ([]*model.AccessControlPolicy, error) {
+	_, span := tracing.Start(requestContext(rctx), "AccessControlPolicyStore.SetActiveStatusMultiple")
+	defer span.End()
+
+	result, err := s.AccessControlPolicyStore.SetActiveStatusMultiple(rctx, list)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerAttributesStore) GetChannelMembersToRemove(rctx request.CTX, channelID string, opts model.SubjectSearchOptions) ([]*model.ChannelMember, error) {
+	_, span := tracing.Start(requestContext(rctx), "AttributesStore.GetChannelMembersToRemove")
+	defer span.End()
+
+	result, err := s.AttributesStore.GetChannelMembersToRemove(rctx, channelID, opts)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerAttributesStore) GetSubject(rctx request.CTX, ID string, groupID string) (*model.Subject, error) {
+	_, span := tracing.Start(requestContext(rctx), "AttributesStore.GetSubject")
+	defer span.End()
+
+	result, err := s.AttributesStore.GetSubject(rctx, ID, groupID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerAttributesStore) RefreshAttributes() error {
+	_, span := tracing.Start(context.Background(), "AttributesStore.RefreshAttributes")
+	defer span.End()
+
+	err := s.AttributesStore.RefreshAttributes()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerAttributesStore) SearchUsers(rctx request.CTX, opts model.SubjectSearchOptions) ([]*model.User, int64, error) {
+	_, span := tracing.Start(requestContext(rctx), "AttributesStore.SearchUsers")
+	defer span.End()
+
+	result, resultVar1, err := s.AttributesStore.SearchUsers(rctx, opts)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, resultVar1, err
+}
+
+func (s *TracingLayerAuditStore) Get(userID string, offset int, limit int) (model.Audits, error) {
+	_, span := tracing.Start(context.Background(), "AuditStore.Get")
+	defer span.End()
+
+	result, err := s.AuditStore.Get(userID, offset, limit)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerAuditStore) PermanentDeleteByUser(userID string) error {
+	_, span := tracing.Start(context.Background(), "AuditStore.PermanentDeleteByUser")
+	defer span.End()
+
+	err := s.AuditStore.PermanentDeleteByUser(userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerAuditStore) Save(audit *model.Audit) error {
+	_, span := tracing.Start(context.Background(), "AuditStore.Save")
+	defer span.End()
+
+	err := s.AuditStore.Save(audit)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerAuditLogStore) Append(entry *model.AuditLogEntry, hashKey []byte) (*model.AuditLogEntry, error) {
+	_, span := tracing.Start(context.Background(), "AuditLogStore.Append")
+	defer span.End()
+
+	result, err := s.AuditLogStore.Append(entry, hashKey)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerAuditLogStore) GetRange(afterSequence int64, limit int) ([]*model.AuditLogEntry, error) {
+	_, span := tracing.Start(context.Background(), "AuditLogStore.GetRange")
+	defer span.End()
+
+	result, err := s.AuditLogStore.GetRange(afterSequence, limit)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerAuditLogStore) Search(search model.AuditLogSearch) ([]*model.AuditLogEntry, error) {
+	_, span := tracing.Start(context.Background(), "AuditLogStore.Search")
+	defer span.End()
+
+	result, err := s.AuditLogStore.Search(search)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerAutoTranslationStore) ClearCaches() {
+	_, span := tracing.Start(context.Background(), "AutoTranslationStore.ClearCaches")
+	defer span.End()
+
+	s.AutoTranslationStore.ClearCaches()
+}
+
+func (s *TracingLayerAutoTranslationStore) Get(objectID string, dstLang string) (*model.Translation, *model.AppError) {
+	_, span := tracing.Start(context.Background(), "AutoTranslationStore.Get")
+	defer span.End()
+
+	result, resultVar1 := s.AutoTranslationStore.Get(objectID, dstLang)
+	return result, resultVar1
+}
+
+func (s *TracingLayerAutoTranslationStore) GetActiveDestinationLanguages(channelID string, excludeUserID string, filterUserIDs []string) ([]string, *model.AppError) {
+	_, span := tracing.Start(context.Background(), "AutoTranslationStore.GetActiveDestinationLanguages")
+	defer span.End()
+
+	result, resultVar1 := s.AutoTranslationStore.GetActiveDestinationLanguages(channelID, excludeUserID, filterUserIDs)
+	return result, resultVar1
+}
+
+func (s *TracingLayerAutoTranslationStore) GetUserLanguage(userID string, channelID string) (string, *model.AppError) {
+	_, span := tracing.Start(context.Background(), "AutoTranslationStore.GetUserLanguage")
+	defer span.End()
+
+	result, resultVar1 := s.AutoTranslationStore.GetUserLanguage(userID, channelID)
+	return result, resultVar1
+}
+
+func (s *TracingLayerAutoTranslationStore) InvalidateUserAutoTranslation(userID string, channelID string) {
+	_, span := tracing.Start(context.Background(), "AutoTranslationStore.InvalidateUserAutoTranslation")
+	defer span.End()
+
+	s.AutoTranslationStore.InvalidateUserAutoTranslation(userID, channelID)
+}
+
+func (s *TracingLayerAutoTranslationStore) InvalidateUserLocaleCache(userID string) {
+	_, span := tracing.Start(context.Background(), "AutoTranslationStore.InvalidateUserLocaleCache")
+	defer span.End()
+
+	s.AutoTranslationStore.InvalidateUserLocaleCache(userID)
+}
+
+func (s *TracingLayerAutoTranslationStore) IsChannelEnabled(channelID string) (bool, *model.AppError) {
+	_, span := tracing.Start(context.Background(), "AutoTranslationStore.IsChannelEnabled")
+	defer span.End()
+
+	result, resultVar1 := s.AutoTranslationStore.IsChannelEnabled(channelID)
+	return result, resultVar1
+}
+
+func (s *TracingLayerAutoTranslationStore) IsUserEnabled(userID string, channelID string) (bool, *model.AppError) {
+	_, span := tracing.Start(context.Background(), "AutoTranslationStore.IsUserEnabled")
+	defer span.End()
+
+	result, resultVar1 := s.AutoTranslationStore.IsUserEnabled(userID, channelID)
+	return result, resultVar1
+}
+
+func (s *TracingLayerAutoTranslationStore) Save(translation *model.Translation) *model.AppError {
+	_, span := tracing.Start(context.Background(), "AutoTranslationStore.Save")
+	defer span.End()
+
+	result := s.AutoTranslationStore.Save(translation)
+	return result
+}
+
+func (s *TracingLayerAutoTranslationStore) SetChannelEnabled(channelID string, enabled bool) *model.AppError {
+	_, span := tracing.Start(context.Background(), "AutoTranslationStore.SetChannelEnabled")
+	defer span.End()
+
+	result := s.AutoTranslationStore.SetChannelEnabled(channelID, enabled)
+	return result
+}
+
+func (s *TracingLayerAutoTranslationStore) SetUserEnabled(userID string, channelID string, enabled bool) *model.AppError {
+	_, span := tracing.Start(context.Background(), "AutoTranslationStore.SetUserEnabled")
+	defer span.End()
+
+	result := s.AutoTranslationStore.SetUserEnabled(userID, channelID, enabled)
+	return result
+}
+
+func (s *TracingLayerBotStore) Get(userID string, includeDeleted bool) (*model.Bot, error) {
+	_, span := tracing.Start(context.Background(), "BotStore.Get")
+	defer span.End()
+
+	result, err := s.BotStore.Get(userID, includeDeleted)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerBotStore) GetAll(options *model.BotGetOptions) ([]*model.Bot, error) {
+	_, span := tracing.Start(context.Background(), "BotStore.GetAll")
+	defer span.End()
+
+	result, err := s.BotStore.GetAll(options)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerBotStore) GetAllAfter(limit int, afterId string) ([]*model.Bot, error) {
+	_, span := tracing.Start(context.Background(), "BotStore.GetAllAfter")
+	defer span.End()
+
+	result, err := s.BotStore.GetAllAfter(limit, afterId)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerBotStore) GetByUsername(username string) (*model.Bot, error) {
+	_, span := tracing.Start(context.Background(), "BotStore.GetByUsername")
+	defer span.End()
+
+	result, err := s.BotStore.GetByUsername(username)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerBotStore) PermanentDelete(userID string) error {
+	_, span := tracing.Start(context.Background(), "BotStore.PermanentDelete")
+	defer span.End()
+
+	err := s.BotStore.PermanentDelete(userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerBotStore) Save(bot *model.Bot) (*model.Bot, error) {
+	_, span := tracing.Start(context.Background(), "BotStore.Save")
+	defer span.End()
+
+	result, err := s.BotStore.Save(bot)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerBotStore) Update(bot *model.Bot) (*model.Bot, error) {
+	_, span := tracing.Start(context.Background(), "BotStore.Update")
+	defer span.End()
+
+	result, err := s.BotStore.Update(bot)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerChannelStore) AnalyticsCountAll(teamID string) (map[model.ChannelType]int64, error) {
+	_, span := tracing.Start(context.Background(), "ChannelStore.AnalyticsCountAll")
+	defer span.End()
+
+	result, err := s.ChannelStore.AnalyticsCountAll(teamID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerChannelStore) AnalyticsDeletedTypeCount(teamID string, channelType model.ChannelType) (int64, error) {
+	_, span := tracing.Start(context.Background(), "ChannelStore.AnalyticsDeletedTypeCount")
+	defer span.End()
+
+	result, err := s.ChannelStore.AnalyticsDeletedTypeCount(teamID, channelType)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerChannelStore) AnalyticsTypeCount(teamID string, channelType model.ChannelType) (int64, error) {
+	_, span := tracing.Start(context.Background(), "ChannelStore.AnalyticsTypeCount")
+	defer span.End()
+
+	result, err := s.ChannelStore.AnalyticsTypeCount(teamID, channelType)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerChannelStore) Autocomplete(rctx request.CTX, userID string, term string, includeDeleted bool, isGuest bool) (model.ChannelListWithTeamData, error) {
+	_, span := tracing.Start(requestContext(rctx), "ChannelStore.Autocomplete")
+	defer span.End()
+
+	result, err := s.ChannelStore.Autocomplete(rctx, userID, term, includeDeleted, isGuest)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerChannelStore) AutocompleteInTeam(rctx request.CTX, teamID string, userID string, term string, includeDeleted bool, isGuest bool) (model.ChannelList, error) {
+	_, span := tracing.Start(requestContext(rctx), "ChannelStore.AutocompleteInTeam")
+	defer span.End()
+
+	result, err := s.ChannelStore.AutocompleteInTeam(rctx, teamID, userID, term, includeDeleted, isGuest)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerChannelStore) AutocompleteInTeamForSearch(teamID string, userID string, term string, includeDeleted bool) (model.ChannelList, error) {
+	_, span := tracing.Start(context.Background(), "ChannelStore.AutocompleteInTeamForSearch")
+	defer span.End()
+
+	result, err := s.ChannelStore.AutocompleteInTeamForSearch(teamID, userID, term, includeDeleted)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerChannelStore) ClearAllCustomRoleAssignments() error {
+	_, span := tracing.Start(context.Background(), "ChannelStore.ClearAllCustomRoleAssignments")
+	defer span.End()
+
+	err := s.ChannelStore.ClearAllCustomRoleAssignments()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerChannelStore) ClearCaches() {
+	_, span := tracing.Start(context.Background(), "ChannelStore.ClearCaches")
+	defer span.End()
+
+	s.ChannelStore.ClearCaches()
+}
+
+func (s *TracingLayerChannelStore) ClearMembersForUserCache() {
+	_, span := tracing.Start(context.Background(), "ChannelStore.ClearMembersForUserCache")
+	defer span.End()
+
+	s.ChannelStore.ClearMembersForUserCache()
+}
+
+func (s *TracingLayerChannelStore) ClearSidebarOnTeamLeave(userID string, teamID string) error {
+	_, span := tracing.Start(context.Background(), "ChannelStore.ClearSidebarOnTeamLeave")
+	defer span.End()
+
+	err := s.ChannelStore.ClearSidebarOnTeamLeave(userID, teamID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerChannelStore) CountPostsAfter(channelID string, timestamp int64, excludedUserID string) (int, int, error) {
+	_, span := tracing.Start(context.Background(), "ChannelStore.CountPostsAfter")
+	defer span.End()
+
+	result, resultVar1, err := s.ChannelStore.CountPostsAfter(channelID, timestamp, excludedUserID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, resultVar1, err
+}
+
+func (s *TracingLayerChannelStore) CountUrgentPostsAfter(channelID string, timestamp int64, excludedUserID string) (int, error) {
+	_, span := tracing.Start(context.Background(), "ChannelStore.CountUrgentPostsAfter")
+	defer span.End()
+
+	result, err := s.ChannelStore.CountUrgentPostsAfter(channelID, timestamp, excludedUserID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerChannelStore) CreateDirectChannel(rctx request.CTX, userID *model.User, otherUserID *model.User, channelOptions ...model.ChannelOption) (*model.Channel, error) {
+	_, span := tracing.Start(requestContext(rctx), "ChannelStore.CreateDirectChannel")
+	defer span.End()
+
+	result, err := s.ChannelStore.CreateDirectChannel(rctx, userID, otherUserID, channelOptions...)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerChannelStore) CreateInitialSidebarCategories(rctx request.CTX, userID string, teamID string) (*model.OrderedSidebarCategories, error) {
+	_, span := tracing.Start(requestContext(rctx), "ChannelStore.CreateInitialSidebarCategories")
+	defer span.End()
+
+	result, err := s.ChannelStore.CreateInitialSidebarCategories(rctx, userID, teamID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerChannelStore) CreateSidebarCategory(userID string, teamID string, newCategory *model.SidebarCategoryWithChannels) (*model.SidebarCategoryWithChannels, error) {
+	_, span := tracing.Start(context.Background(), "ChannelStore.CreateSidebarCategory")
+	defer span.End()
+
+	result, err := s.ChannelStore.CreateSidebarCategory(userID, teamID, newCategory)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerChannelStore) Delete(channelID string, timestamp int64) error {
+	_, span := tracing.Start(context.Background(), "ChannelStore.Delete")
+	defer span.End()
+
+	err := s.ChannelStore.Delete(channelID, timestamp)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerChannelStore) DeleteAllSidebarChannelForChannel(channelID string) error {
+	_, span := tracing.Start(context.Background(), "ChannelStore.DeleteAllSidebarChannelForChannel")
+	defer span.End()
+
+	err := s.ChannelStore.DeleteAllSidebarChannelForChannel(channelID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerChannelStore) DeleteSidebarCategory(categoryID string) error {
+	_, span := tracing.Start(context.Background(), "ChannelStore.DeleteSidebarCategory")
+	defer span.End()
+
+	err := s.ChannelStore.DeleteSidebarCategory(categoryID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerChannelStore) DeleteSidebarChannelsByPreferences(preferences model.Preferences) error {
+	_, span := tracing.Start(context.Background(), "ChannelStore.DeleteSidebarChannelsByPreferences")
+	defer span.End()
+
+	err := s.ChannelStore.DeleteSidebarChannelsByPreferences(preferences)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerChannelStore) Get(id string, allowFromCache bool) (*model.Channel, error) {
+	_, span := tracing.Start(context.Background(), "ChannelStore.Get")
+	defer span.End()
+
+	result, err := s.ChannelStore.Get(id, allowFromCache)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerChannelStore) GetAll(teamID string) ([]*model.Channel, error) {
+	_, span := tracing.Start(context.Background(), "ChannelStore.GetAll")
+	defer span.End()
+
+	result, err := s.ChannelStore.GetAll(teamID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerChannelStore) GetAllChannelMemberIdsByChannelId(id string) ([]string, error) {
+	_, span := tracing.Start(context.Background(), "ChannelStore.GetAllChannelMemberIdsByChannelId")
+	defer span.End()
+
+	result, err := s.ChannelStore.GetAllChannelMemberIdsByChannelId(id)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerChannelStore) GetAllChannelMembersForUser(rctx request.CTX, userID string, allowFromCache bool, includeDeleted bool) (map[string]string, error) {
+	_, span := tracing.Start(requestContext(rctx), "ChannelStore.GetAllChannelMembersForUser")
+	defer span.End()
+
+	result, err := s.ChannelStore.GetAllChannelMembersForUser(rctx, userID, allowFromCache, includeDeleted)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerChannelStore) GetAllChannelMembersNotifyPropsForChannel(channelID string, allowFromCache bool) (map[string]model.StringMap, error) {
+	_, span := tracing.Start(context.Background(), "ChannelStore.GetAllChannelMembersNotifyPropsForChannel")
+	defer span.End()
+
+	result, err := s.ChannelStore.GetAllChannelMembersNotifyPropsForChannel(channelID, allowFromCache)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerChannelStore) GetAllChannels(page int, perPage int, opts store.ChannelSearchOpts) (model.ChannelListWithTeamData, error) {
+	_, span := tracing.Start(context.Background(), "ChannelStore.GetAllChannels")
+	defer span.End()
+
+	result, err := s.ChannelStore.GetAllChannels(page, perPage, opts)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerChannelStore) GetAllChannelsCount(opts store.ChannelSearchOpts) (int64, error) {
+	_, span := tracing.Start(context.Background(), "ChannelStore.GetAllChannelsCount")
+	defer span.End()
+
+	result, err := s.ChannelStore.GetAllChannelsCount(opts)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerChannelStore) GetAllChannelsForExportAfter(limit int, afterID string) ([]*model.ChannelForExport, error) {
+	_, span := tracing.Start(context.Background(), "ChannelStore.GetAllChannelsForExportAfter")
+	defer span.End()
+
+	result, err := s.ChannelStore.GetAllChannelsForExportAfter(limit, afterID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerChannelStore) GetAllDirectChannelsForExportAfter(limit int, afterID string, includeArchivedChannels bool) ([]*model.DirectChannelForExport, error) {
+	_, span := tracing.Start(context.Background(), "ChannelStore.GetAllDirectChannelsForExportAfter")
+	defer span.End()
+
+	result, err := s.ChannelStore.GetAllDirectChannelsForExportAfter(limit, afterID, includeArchivedChannels)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerChannelStore) GetByName(teamID string, name string, allowFromCache bool) (*model.Channel, error) {
+	_, span := tracing.Start(context.Background(), "ChannelStore.GetByName")
+	defer span.End()
+
+	result, err := s.ChannelStore.GetByName(teamID, name, allowFromCache)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerChannelStore) GetByNameIncludeDeleted(teamID string, name string, allowFromCache bool) (*model.Channel, error) {
+	_, span := tracing.Start(context.Background(), "ChannelStore.GetByNameIncludeDeleted")
+	defer span.End()
+
+	result, err := s.ChannelStore.GetByNameIncludeDeleted(teamID, name, allowFromCache)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerChannelStore) GetByNames(teamID string, names []string, allowFromCache bool) ([]*model.Channel, error) {
+	_, span := tracing.Start(context.Background(), "ChannelStore.GetByNames")
+	defer span.End()
+
+	result, err := s.ChannelStore.GetByNames(teamID, names, allowFromCache)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerChannelStore) GetByNamesIncludeDeleted(teamID string, names []string, allowFromCache bool) ([]*model.Channel, error) {
+	_, span := tracing.Start(context.Background(), "ChannelStore.GetByNamesIncludeDeleted")
+	defer span.End()
+
+	result, err := s.ChannelStore.GetByNamesIncludeDeleted(teamID, names, allowFromCache)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerChannelStore) GetChannelCounts(teamID string, userID string) (*model.ChannelCounts, error) {
+	_, span := tracing.Start(context.Background(), "ChannelStore.GetChannelCounts")
+	defer span.End()
+
+	result, err := s.ChannelStore.GetChannelCounts(teamID, userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerChannelStore) GetChannelMembersForExport(userID string, teamID string, includeArchivedChannel bool) ([]*model.ChannelMemberForExport, error) {
+	_, span := tracing.Start(context.Background(), "ChannelStore.GetChannelMembersForExport")
+	defer span.End()
+
+	result, err := s.ChannelStore.GetChannelMembersForExport(userID, teamID, includeArchivedChannel)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerChannelStore) GetChannelMembersTimezones(channelID string) ([]model.StringMap, error) {
+	_, span := tracing.Start(context.Background(), "ChannelStore.GetChannelMembersTimezones")
+	defer span.End()
+
+	result, err := s.ChannelStore.GetChannelMembersTimezones(channelID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerChannelStore) GetChannelUnread(channelID string, userID string) (*model.ChannelUnread, error) {
+	_, span := tracing.Start(context.Background(), "ChannelStore.GetChannelUnread")
+	defer span.End()
+
+	result, err := s.ChannelStore.GetChannelUnread(channelID, userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerChannelStore) GetChannels(teamID string, userID string, opts *model.ChannelSearchOpts) (model.ChannelList, error) {
+	_, span := tracing.Start(context.Background(), "ChannelStore.GetChannels")
+	defer span.End()
+
+	result, err := s.ChannelStore.GetChannels(teamID, userID, opts)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerChannelStore) GetChannelsBatchForIndexing(startTime int64, startChannelID string, limit int) ([]*model.Channel, error) {
+	_, span := tracing.Start(context.Background(), "ChannelStore.GetChannelsBatchForIndexing")
+	defer span.End()
+
+	result, err := s.ChannelStore.GetChannelsBatchForIndexing(startTime, startChannelID, limit)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerChannelStore) GetChannelsByIds(channelIds []string, includeDeleted bool) ([]*model.Channel, error) {
+	_, span := tracing.Start(context.Background(), "ChannelStore.GetChannelsByIds")
+	defer span.End()
+
+	result, err := s.ChannelStore.GetChannelsByIds(channelIds, includeDeleted)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerChannelStore) GetChannelsByScheme(schemeID string, offset int, limit int) (model.ChannelList, error) {
+	_, span := tracing.Start(context.Background(), "ChannelStore.GetChannelsByScheme")
+	defer span.End()
+
+	result, err := s.ChannelStore.GetChannelsByScheme(schemeID, offset, limit)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerChannelStore) GetChannelsByUser(userID string, includeDeleted bool, lastDeleteAt int, pageSize int, fromChannelID string) (model.ChannelList, error) {
+	_, span := tracing.Start(context.Background(), "ChannelStore.GetChannelsByUser")
+	defer span.End()
+
+	result, err := s.ChannelStore.GetChannelsByUser(userID, includeDeleted, lastDeleteAt, pageSize, fromChannelID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerChannelStore) GetChannelsMemberCount(channelIDs []string) (map[string]int64, error) {
+	_, span := tracing.Start(context.Background(), "ChannelStore.GetChannelsMemberCount")
+	defer span.End()
+
+	result, err := s.ChannelStore.GetChannelsMemberCount(channelIDs)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerChannelStore) GetChannelsWithTeamDataByIds(channelIds []string, includeDeleted bool) ([]*model.ChannelWithTeamData, error) {
+	_, span := tracing.Start(context.Background(), "ChannelStore.GetChannelsWithTeamDataByIds")
+	defer span.End()
+
+	result, err := s.ChannelStore.GetChannelsWithTeamDataByIds(channelIds, includeDeleted)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerChannelStore) GetChannelsWithUnreadsAndWithMentions(rctx request.CTX, channelIDs []string, userID string, userNotifyProps model.StringMap) ([]string, []string, map[string]int64, error) {
+	_, span := tracing.Start(requestContext(rctx), "ChannelStore.GetChannelsWithUnreadsAndWithMentions")
+	defer span.End()
+
+	result, resultVar1, resultVar2, err := s.ChannelStore.GetChannelsWithUnreadsAndWithMentions(rctx, channelIDs, userID, userNotifyProps)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, resultVar1, resultVar2, err
+}
+
+func (s *TracingLayerChannelStore) GetDeleted(teamID string, offset int, limit int, userID string, skipTeamMembershipCheck bool) (model.ChannelList, error) {
+	_, span := tracing.Start(context.Background(), "ChannelStore.GetDeleted")
+	defer span.End()
+
+	result, err := s.ChannelStore.GetDeleted(teamID, offset, limit, userID, skipTeamMembershipCheck)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerChannelStore) GetDeletedByName(teamID string, name string) (*model.Channel, error) {
+	_, span := tracing.Start(context.Background(), "ChannelStore.GetDeletedByName")
+	defer span.End()
+
+	result, err := s.ChannelStore.GetDeletedByName(teamID, name)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerChannelStore) GetFileCount(channelID string) (int64, error) {
+	_, span := tracing.Start(context.Background(), "ChannelStore.GetFileCount")
+	defer span.End()
+
+	result, err := s.ChannelStore.GetFileCount(channelID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerChannelStore) GetForPost(postID string) (*model.Channel, error) {
+	_, span := tracing.Start(context.Background(), "ChannelStore.GetForPost")
+	defer span.End()
+
+	result, err := s.ChannelStore.GetForPost(postID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerChannelStore) GetGuestCount(channelID string, allowFromCache bool) (int64, error) {
+	_, span := tracing.Start(context.Background(), "ChannelStore.GetGuestCount")
+	defer span.End()
+
+	result, err := s.ChannelStore.GetGuestCount(channelID, allowFromCache)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerChannelStore) GetMany(ids []string, allowFromCache bool) (model.ChannelList, error) {
+	_, span := tracing.Start(context.Background(), "ChannelStore.GetMany")
+	defer span.End()
+
+	result, err := s.ChannelStore.GetMany(ids, allowFromCache)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerChannelStore) GetMember(rctx request.CTX, channelID string, userID string) (*model.ChannelMember, error) {
+	_, span := tracing.Start(requestContext(rctx), "ChannelStore.GetMember")
+	defer span.End()
+
+	result, err := s.ChannelStore.GetMember(rctx, channelID, userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerChannelStore) GetMemberCount(channelID string, allowFromCache bool) (int64, error) {
+	_, span := tracing.Start(context.Background(), "ChannelStore.GetMemberCount")
+	defer span.End()
+
+	result, err := s.ChannelStore.GetMemberCount(channelID, allowFromCache)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerChannelStore) GetMemberCountFromCache(channelID string) int64 {
+	_, span := tracing.Start(context.Background(), "ChannelStore.GetMemberCountFromCache")
+	defer span.End()
+
+	result := s.ChannelStore.GetMemberCountFromCache(channelID)
+	return result
+}
+
+func (s *TracingLayerChannelStore) GetMemberCountsByGroup(rctx request.CTX, channelID string, includeTimezones bool) ([]*model.ChannelMemberCountByGroup, error) {
+	_, span := tracing.Start(requestContext(rctx), "ChannelStore.GetMemberCountsByGroup")
+	defer span.End()
+
+	result, err := s.ChannelStore.GetMemberCountsByGroup(rctx, channelID, includeTimezones)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerChannelStore) GetMemberForPost(postID string, userID string) (*model.ChannelMember, error) {
+	_, span := tracing.Start(context.Background(), "ChannelStore.GetMemberForPost")
+	defer span.End()
+
+	result, err := s.ChannelStore.GetMemberForPost(postID, userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerChannelStore) GetMemberLastViewedAt(rctx request.CTX, channelID string, userID string) (int64, error) {
+	_, span := tracing.Start(requestContext(rctx), "ChannelStore.GetMemberLastViewedAt")
+	defer span.End()
+
+	result, err := s.ChannelStore.GetMemberLastViewedAt(rctx, channelID, userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerChannelStore) GetMembers(opts model.ChannelMembersGetOptions) (model.ChannelMembers, error) {
+	_, span := tracing.Start(context.Background(), "ChannelStore.GetMembers")
+	defer span.End()
+
+	result, err := s.ChannelStore.GetMembers(opts)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerChannelStore) GetMembersByChannelIds(channelIds []string, userID string) (model.ChannelMembers, error) {
+	_, span := tracing.Start(context.Background(), "ChannelStore.GetMembersByChannelIds")
+	defer span.End()
+
+	result, err := s.ChannelStore.GetMembersByChannelIds(channelIds, userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerChannelStore) GetMembersByIds(channelID string, userIds []string) (model.ChannelMembers, error) {
+	_, span := tracing.Start(context.Background(), "ChannelStore.GetMembersByIds")
+	defer span.End()
+
+	result, err := s.ChannelStore.GetMembersByIds(channelID, userIds)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerChannelStore) GetMembersForUser(teamID string, userID string) (model.ChannelMembers, error) {
+	_, span := tracing.Start(context.Background(), "ChannelStore.GetMembersForUser")
+	defer span.End()
+
+	result, err := s.ChannelStore.GetMembersForUser(teamID, userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerChannelStore) GetMembersForUserWithCursorPagination(userId string, perPage int, fromChanneID string) (model.ChannelMembersWithTeamData, error) {
+	_, span := tracing.Start(context.Background(), "ChannelStore.GetMembersForUserWithCursorPagination")
+	defer span.End()
+
+	result, err := s.ChannelStore.GetMembersForUserWithCursorPagination(userId, perPage, fromChanneID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerChannelStore) GetMembersForUserWithPagination(userID string, page int, perPage int) (model.ChannelMembersWithTeamData, error) {
+	_, span := tracing.Start(context.Background(), "ChannelStore.GetMembersForUserWithPagination")
+	defer span.End()
+
+	result, err := s.ChannelStore.GetMembersForUserWithPagination(userID, page, perPage)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerChannelStore) GetMembersInfoByChannelIds(channelIDs []string) (map[string][]*model.User, error) {
+	_, span := tracing.Start(context.Background(), "ChannelStore.GetMembersInfoByChannelIds")
+	defer span.End()
+
+	result, err := s.ChannelStore.GetMembersInfoByChannelIds(channelIDs)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerChannelStore) GetMoreChannels(teamID string, userID string, offset int, limit int) (model.ChannelList, error) {
+	_, span := tracing.Start(context.Background(), "ChannelStore.GetMoreChannels")
+	defer span.End()
+
+	result, err := s.ChannelStore.GetMoreChannels(teamID, userID, offset, limit)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerChannelStore) GetPinnedPostCount(channelID string, allowFromCache bool) (int64, error) {
+	_, span := tracing.Start(context.Background(), "ChannelStore.GetPinnedPostCount")
+	defer span.End()
+
+	result, err := s.ChannelStore.GetPinnedPostCount(channelID, allowFromCache)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerChannelStore) GetPinnedPosts(channelID string) (*model.PostList, error) {
+	_, span := tracing.Start(context.Background(), "ChannelStore.GetPinnedPosts")
+	defer span.End()
+
+	result, err := s.ChannelStore.GetPinnedPosts(channelID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerChannelStore) GetPrivateChannelsForTeam(teamID string, offset int, limit int) (model.ChannelList, error) {
+	_, span := tracing.Start(context.Background(), "ChannelStore.GetPrivateChannelsForTeam")
+	defer span.End()
+
+	result, err := s.ChannelStore.GetPrivateChannelsForTeam(teamID, offset, limit)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerChannelStore) GetPublicChannelsByIdsForTeam(teamID string, channelIds []string) (model.ChannelList, error) {
+	_, span := tracing.Start(context.Background(), "ChannelStore.GetPublicChannelsByIdsForTeam")
+	defer span.End()
+
+	result, err := s.ChannelStore.GetPublicChannelsByIdsForTeam(teamID, channelIds)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerChannelStore) GetPublicChannelsForTeam(teamID string, offset int, limit int) (model.ChannelList, error) {
+	_, span := tracing.Start(context.Background(), "ChannelStore.GetPublicChannelsForTeam")
+	defer span.End()
+
+	result, err := s.ChannelStore.GetPublicChannelsForTeam(teamID, offset, limit)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerChannelStore) GetSidebarCategories(userID string, teamID string) (*model.OrderedSidebarCategories, error) {
+	_, span := tracing.Start(context.Background(), "ChannelStore.GetSidebarCategories")
+	defer span.End()
+
+	result, err := s.ChannelStore.GetSidebarCategories(userID, teamID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerChannelStore) GetSidebarCategoriesForTeamForUser(userID string, teamID string) (*model.OrderedSidebarCategories, error) {
+	_, span := tracing.Start(context.Background(), "ChannelStore.GetSidebarCategoriesForTeamForUser")
+	defer span.End()
+
+	result, err := s.ChannelStore.GetSidebarCategoriesForTeamForUser(userID, teamID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerChannelStore) GetSidebarCategory(categoryID string) (*model.SidebarCategoryWithChannels, error) {
+	_, span := tracing.Start(context.Background(), "ChannelStore.GetSidebarCategory")
+	defer span.End()
+
+	result, err := s.ChannelStore.GetSidebarCategory(categoryID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerChannelStore) GetSidebarCategoryOrder(userID string, teamID string) ([]string, error) {
+	_, span := tracing.Start(context.Background(), "ChannelStore.GetSidebarCategoryOrder")
+	defer span.End()
+
+	result, err := s.ChannelStore.GetSidebarCategoryOrder(userID, teamID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerChannelStore) GetTeamChannels(teamID string) (model.ChannelList, error) {
+	_, span := tracing.Start(context.Background(), "ChannelStore.GetTeamChannels")
+	defer span.End()
+
+	result, err := s.ChannelStore.GetTeamChannels(teamID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerChannelStore) GetTeamForChannel(channelID string) (*model.Team, error) {
+	_, span := tracing.Start(context.Background(), "ChannelStore.GetTeamForChannel")
+	defer span.End()
+
+	result, err := s.ChannelStore.GetTeamForChannel(channelID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerChannelStore) GetTeamMembersForChannel(rctx request.CTX, channelID string) ([]string, error) {
+	_, span := tracing.Start(requestContext(rctx), "ChannelStore.GetTeamMembersForChannel")
+	defer span.End()
+
+	result, err := s.ChannelStore.GetTeamMembersForChannel(rctx, channelID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerChannelStore) GroupSyncedChannelCount() (int64, error) {
+	_, span := tracing.Start(context.Background(), "ChannelStore.GroupSyncedChannelCount")
+	defer span.End()
+
+	result, err := s.ChannelStore.GroupSyncedChannelCount()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerChannelStore) IncrementMentionCount(channelID string, userIDs []string, isRoot bool, isUrgent bool) error {
+	_, span := tracing.Start(context.Background(), "ChannelStore.IncrementMentionCount")
+	defer span.End()
+
+	err := s.ChannelStore.IncrementMentionCount(channelID, userIDs, isRoot, isUrgent)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerChannelStore) InvalidateAllChannelMembersForUser(userID string) {
+	_, span := tracing.Start(context.Background(), "ChannelStore.InvalidateAllChannelMembersForUser")
+	defer span.End()
+
+	s.ChannelStore.InvalidateAllChannelMembersForUser(userID)
+}
+
+func (s *TracingLayerChannelStore) InvalidateCacheForChannelMembersNotifyProps(channelID string) {
+	_, span := tracing.Start(context.Background(), "ChannelStore.InvalidateCacheForChannelMembersNotifyProps")
+	defer span.End()
+
+	s.ChannelStore.InvalidateCacheForChannelMembersNotifyProps(channelID)
+}
+
+func (s *TracingLayerChannelStore) InvalidateChannel(id string) {
+	_, span := tracing.Start(context.Background(), "ChannelStore.InvalidateChannel")
+	defer span.End()
+
+	s.ChannelStore.InvalidateChannel(id)
+}
+
+func (s *TracingLayerChannelStore) InvalidateChannelByName(teamID string, name string) {
+	_, span := tracing.Start(context.Background(), "ChannelStore.InvalidateChannelByName")
+	defer span.End()
+
+	s.ChannelStore.InvalidateChannelByName(teamID, name)
+}
+
+func (s *TracingLayerChannelStore) InvalidateGuestCount(channelID string) {
+	_, span := tracing.Start(context.Background(), "ChannelStore.InvalidateGuestCount")
+	defer span.End()
+
+	s.ChannelStore.InvalidateGuestCount(channelID)
+}
+
+func (s *TracingLayerChannelStore) InvalidateMemberCount(channelID string) {
+	_, span := tracing.Start(context.Background(), "ChannelStore.InvalidateMemberCount")
+	defer span.End()
+
+	s.ChannelStore.InvalidateMemberCount(channelID)
+}
+
+func (s *TracingLayerChannelStore) InvalidatePinnedPostCount(channelID string) {
+	_, span := tracing.Start(context.Background(), "ChannelStore.InvalidatePinnedPostCount")
+	defer span.End()
+
+	s.ChannelStore.InvalidatePinnedPostCount(channelID)
+}
+
+func (s *TracingLayerChannelStore) IsChannelReadOnlyScheme(schemeID string) (bool, error) {
+	_, span := tracing.Start(context.Background(), "ChannelStore.IsChannelReadOnlyScheme")
+	defer span.End()
+
+	result, err := s.ChannelStore.IsChannelReadOnlyScheme(schemeID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerChannelStore) IsReadOnlyChannel(channelID string) (bool, error) {
+	_, span := tracing.Start(context.Background(), "ChannelStore.IsReadOnlyChannel")
+	defer span.End()
+
+	result, err := s.ChannelStore.IsReadOnlyChannel(channelID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerChannelStore) MigrateChannelMembers(fromChannelID string, fromUserID string) (map[string]string, error) {
+	_, span := tracing.Start(context.Background(), "ChannelStore.MigrateChannelMembers")
+	defer span.End()
+
+	result, err := s.ChannelStore.MigrateChannelMembers(fromChannelID, fromUserID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerChannelStore) PatchMultipleMembersNotifyProps(members []*model.ChannelMemberIdentifier, notifyProps map[string]string) ([]*model.ChannelMember, error) {
+	_, span := tracing.Start(context.Background(), "ChannelStore.PatchMultipleMembersNotifyProps")
+	defer span.End()
+
+	result, err := s.ChannelStore.PatchMultipleMembersNotifyProps(members, notifyProps)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerChannelStore) PermanentDelete(rctx request.CTX, channelID string) error {
+	_, span := tracing.Start(requestContext(rctx), "ChannelStore.PermanentDelete")
+	defer span.End()
+
+	err := s.ChannelStore.PermanentDelete(rctx, channelID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerChannelStore) PermanentDeleteByTeam(teamID string) error {
+	_, span := tracing.Start(context.Background(), "ChannelStore.PermanentDeleteByTeam")
+	defer span.End()
+
+	err := s.ChannelStore.PermanentDeleteByTeam(teamID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerChannelStore) PermanentDeleteMembersByChannel(rctx request.CTX, channelID string) error {
+	_, span := tracing.Start(requestContext(rctx), "ChannelStore.PermanentDeleteMembersByChannel")
+	defer span.End()
+
+	err := s.ChannelStore.PermanentDeleteMembersByChannel(rctx, channelID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerChannelStore) PermanentDeleteMembersByUser(rctx request.CTX, userID string) error {
+	_, span := tracing.Start(requestContext(rctx), "ChannelStore.PermanentDeleteMembersByUser")
+	defer span.End()
+
+	err := s.ChannelStore.PermanentDeleteMembersByUser(rctx, userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerChannelStore) RemoveAllDeactivatedMembers(rctx request.CTX, channelID string) error {
+	_, span := tracing.Start(requestContext(rctx), "ChannelStore.RemoveAllDeactivatedMembers")
+	defer span.End()
+
+	err := s.ChannelStore.RemoveAllDeactivatedMembers(rctx, channelID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerChannelStore) RemoveMember(rctx request.CTX, channelID string, userID string) error {
+	_, span := tracing.Start(requestContext(rctx), "ChannelStore.RemoveMember")
+	defer span.End()
+
+	err := s.ChannelStore.RemoveMember(rctx, channelID, userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerChannelStore) RemoveMembers(rctx request.CTX, channelID string, userIds []string) error {
+	_, span := tracing.Start(requestContext(rctx), "ChannelStore.RemoveMembers")
+	defer span.End()
+
+	err := s.ChannelStore.RemoveMembers(rctx, channelID, userIds)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerChannelStore) ResetAllChannelSchemes() error {
+	_, span := tracing.Start(context.Background(), "ChannelStore.ResetAllChannelSchemes")
+	defer span.End()
+
+	err := s.ChannelStore.ResetAllChannelSchemes()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerChannelStore) Restore(channelID string, timestamp int64) error {
+	_, span := tracing.Start(context.Background(), "ChannelStore.Restore")
+	defer span.End()
+
+	err := s.ChannelStore.Restore(channelID, timestamp)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerChannelStore) Save(rctx request.CTX, channel *model.Channel, maxChannelsPerTeam int64, channelOptions ...model.ChannelOption) (*model.Channel, error) {
+	_, span := tracing.Start(requestContext(rctx), "ChannelStore.Save")
+	defer span.End()
+
+	result, err := s.ChannelStore.Save(rctx, channel, maxChannelsPerTeam, channelOptions...)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerChannelStore) SaveDirectChannel(rctx request.CTX, channel *model.Channel, member1 *model.ChannelMember, member2 *model.ChannelMember) (*model.Channel, error) {
+	_, span := tracing.Start(requestContext(rctx), "ChannelStore.SaveDirectChannel")
+	defer span.End()
+
+	result, err := s.ChannelStore.SaveDirectChannel(rctx, channel, member1, member2)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerChannelStore) SaveMember(rctx request.CTX, member *model.ChannelMember) (*model.ChannelMember, error) {
+	_, span := tracing.Start(requestContext(rctx), "ChannelStore.SaveMember")
+	defer span.End()
+
+	result, err := s.ChannelStore.SaveMember(rctx, member)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerChannelStore) SaveMultipleMembers(members []*model.ChannelMember) ([]*model.ChannelMember, error) {
+	_, span := tracing.Start(context.Background(), "ChannelStore.SaveMultipleMembers")
+	defer span.End()
+
+	result, err := s.ChannelStore.SaveMultipleMembers(members)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerChannelStore) SearchAllChannels(term string, opts store.ChannelSearchOpts) (model.ChannelListWithTeamData, int64, error) {
+	_, span := tracing.Start(context.Background(), "ChannelStore.SearchAllChannels")
+	defer span.End()
+
+	result, resultVar1, err := s.ChannelStore.SearchAllChannels(term, opts)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, resultVar1, err
+}
+
+func (s *TracingLayerChannelStore) SearchForUserInTeam(userID string, teamID string, term string, includeDeleted bool) (model.ChannelList, error) {
+	_, span := tracing.Start(context.Background(), "ChannelStore.SearchForUserInTeam")
+	defer span.End()
+
+	result, err := s.ChannelStore.SearchForUserInTeam(userID, teamID, term, includeDeleted)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerChannelStore) SearchGroupChannels(userID string, term string) (model.ChannelList, error) {
+	_, span := tracing.Start(context.Background(), "ChannelStore.SearchGroupChannels")
+	defer span.End()
+
+	result, err := s.ChannelStore.SearchGroupChannels(userID, term)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerChannelStore) SearchInTeam(teamID string, term string, includeDeleted bool) (model.ChannelList, error) {
+	_, span := tracing.Start(context.Background(), "ChannelStore.SearchInTeam")
+	defer span.End()
+
+	result, err := s.ChannelStore.SearchInTeam(teamID, term, includeDeleted)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerChannelStore) SearchMore(userID string, teamID string, term string) (model.ChannelList, error) {
+	_, span := tracing.Start(context.Background(), "ChannelStore.SearchMore")
+	defer span.End()
+
+	result, err := s.ChannelStore.SearchMore(userID, teamID, term)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerChannelStore) SetDeleteAt(channelID string, deleteAt int64, updateAt int64) error {
+	_, span := tracing.Start(context.Background(), "ChannelStore.SetDeleteAt")
+	defer span.End()
+
+	err := s.ChannelStore.SetDeleteAt(channelID, deleteAt, updateAt)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerChannelStore) SetShared(channelID string, shared bool) error {
+	_, span := tracing.Start(context.Background(), "ChannelStore.SetShared")
+	defer span.End()
+
+	err := s.ChannelStore.SetShared(channelID, shared)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerChannelStore) Update(rctx request.CTX, channel *model.Channel) (*model.Channel, error) {
+	_, span := tracing.Start(requestContext(rctx), "ChannelStore.Update")
+	defer span.End()
+
+	result, err := s.ChannelStore.Update(rctx, channel)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerChannelStore) UpdateLastViewedAt(channelIds []string, userID string) (map[string]int64, error) {
+	_, span := tracing.Start(context.Background(), "ChannelStore.UpdateLastViewedAt")
+	defer span.End()
+
+	result, err := s.ChannelStore.UpdateLastViewedAt(channelIds, userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerChannelStore) UpdateLastViewedAtPost(unreadPost *model.Post, userID string, mentionCount int, mentionCountRoot int, urgentMentionCount int, setUnreadCountRoot bool) (*model.ChannelUnreadAt, error) {
+	_, span := tracing.Start(context.Background(), "ChannelStore.UpdateLastViewedAtPost")
+	defer span.End()
+
+	result, err := s.ChannelStore.UpdateLastViewedAtPost(unreadPost, userID, mentionCount, mentionCountRoot, urgentMentionCount, setUnreadCountRoot)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerChannelStore) UpdateMember(rctx request.CTX, member *model.ChannelMember) (*model.ChannelMember, error) {
+	_, span := tracing.Start(requestContext(rctx), "ChannelStore.UpdateMember")
+	defer span.End()
+
+	result, err := s.ChannelStore.UpdateMember(rctx, member)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerChannelStore) UpdateMemberNotifyProps(channelID string, userID string, props map[string]string) (*model.ChannelMember, error) {
+	_, span := tracing.Start(context.Background(), "ChannelStore.UpdateMemberNotifyProps")
+	defer span.End()
+
+	result, err := s.ChannelStore.UpdateMemberNotifyProps(channelID, userID, props)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerChannelStore) UpdateMembersRole(channelID string, userIDs []string) ([]*model.ChannelMember, error) {
+	_, span := tracing.Start(context.Background(), "ChannelStore.UpdateMembersRole")
+	defer span.End()
+
+	result, err := s.ChannelStore.UpdateMembersRole(channelID, userIDs)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerChannelStore) UpdateMultipleMembers(members []*model.ChannelMember) ([]*model.ChannelMember, error) {
+	_, span := tracing.Start(context.Background(), "ChannelStore.UpdateMultipleMembers")
+	defer span.End()
+
+	result, err := s.ChannelStore.UpdateMultipleMembers(members)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerChannelStore) UpdateSidebarCategories(userID string, teamID string, categories []*model.SidebarCategoryWithChannels) ([]*model.SidebarCategoryWithChannels, []*model.SidebarCategoryWithChannels, error) {
+	_, span := tracing.Start(context.Background(), "ChannelStore.UpdateSidebarCategories")
+	defer span.End()
+
+	result, resultVar1, err := s.ChannelStore.UpdateSidebarCategories(userID, teamID, categories)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, resultVar1, err
+}
+
+func (s *TracingLayerChannelStore) UpdateSidebarCategoryOrder(userID string, teamID string, categoryOrder []string) error {
+	_, span := tracing.Start(context.Background(), "ChannelStore.UpdateSidebarCategoryOrder")
+	defer span.End()
+
+	err := s.ChannelStore.UpdateSidebarCategoryOrder(userID, teamID, categoryOrder)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerChannelStore) UpdateSidebarChannelCategoryOnMove(channel *model.Channel, newTeamID string) error {
+	_, span := tracing.Start(context.Background(), "ChannelStore.UpdateSidebarChannelCategoryOnMove")
+	defer span.End()
+
+	err := s.ChannelStore.UpdateSidebarChannelCategoryOnMove(channel, newTeamID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerChannelStore) UpdateSidebarChannelsByPreferences(preferences model.Preferences) error {
+	_, span := tracing.Start(context.Background(), "ChannelStore.UpdateSidebarChannelsByPreferences")
+	defer span.End()
+
+	err := s.ChannelStore.UpdateSidebarChannelsByPreferences(preferences)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerChannelStore) UserBelongsToChannels(userID string, channelIds []string) (bool, error) {
+	_, span := tracing.Start(context.Background(), "ChannelStore.UserBelongsToChannels")
+	defer span.End()
+
+	result, err := s.ChannelStore.UserBelongsToChannels(userID, channelIds)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerChannelBookmarkStore) Delete(bookmarkID string, deleteFile bool) error {
+	_, span := tracing.Start(context.Background(), "ChannelBookmarkStore.Delete")
+	defer span.End()
+
+	err := s.ChannelBookmarkStore.Delete(bookmarkID, deleteFile)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerChannelBookmarkStore) ErrorIfBookmarkFileInfoAlreadyAttached(fileID string, channelID string) error {
+	_, span := tracing.Start(context.Background(), "ChannelBookmarkStore.ErrorIfBookmarkFileInfoAlreadyAttached")
+	defer span.End()
+
+	err := s.ChannelBookmarkStore.ErrorIfBookmarkFileInfoAlreadyAttached(fileID, channelID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerChannelBookmarkStore) Get(Id string, includeDeleted bool) (*model.ChannelBookmarkWithFileInfo, error) {
+	_, span := tracing.Start(context.Background(), "ChannelBookmarkStore.Get")
+	defer span.End()
+
+	result, err := s.ChannelBookmarkStore.Get(Id, includeDeleted)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerChannelBookmarkStore) GetBookmarksForChannelSince(channelID string, since int64) ([]*model.ChannelBookmarkWithFileInfo, error) {
+	_, span := tracing.Start(context.Background(), "ChannelBookmarkStore.GetBookmarksForChannelSince")
+	defer span.End()
+
+	result, err := s.ChannelBookmarkStore.GetBookmarksForChannelSince(channelID, since)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerChannelBookmarkStore) Save(bookmark *model.ChannelBookmark, increaseSortOrder bool) (*model.ChannelBookmarkWithFileInfo, error) {
+	_, span := tracing.Start(context.Background(), "ChannelBookmarkStore.Save")
+	defer span.End()
+
+	result, err := s.ChannelBookmarkStore.Save(bookmark, increaseSortOrder)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerChannelBookmarkStore) Update(bookmark *model.ChannelBookmark) error {
+	_, span := tracing.Start(context.Background(), "ChannelBookmarkStore.Update")
+	defer span.End()
+
+	err := s.ChannelBookmarkStore.Update(bookmark)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerChannelBookmarkStore) UpdateSortOrder(bookmarkID string, channelID string, newIndex int64) ([]*model.ChannelBookmarkWithFileInfo, error) {
+	_, span := tracing.Start(context.Background(), "ChannelBookmarkStore.UpdateSortOrder")
+	defer span.End()
+
+	result, err := s.ChannelBookmarkStore.UpdateSortOrder(bookmarkID, channelID, newIndex)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerChannelMemberHistoryStore) DeleteOrphanedRows(limit int) (int64, error) {
+	_, span := tracing.Start(context.Background(), "ChannelMemberHistoryStore.DeleteOrphanedRows")
+	defer span.End()
+
+	result, err := s.ChannelMemberHistoryStore.DeleteOrphanedRows(limit)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerChannelMemberHistoryStore) GetChannelsLeftSince(userID string, since int64) ([]string, error) {
+	_, span := tracing.Start(context.Background(), "ChannelMemberHistoryStore.GetChannelsLeftSince")
+	defer span.End()
+
+	result, err := s.ChannelMemberHistoryStore.GetChannelsLeftSince(userID, since)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerChannelMemberHistoryStore) GetChannelsWithActivityDuring(startTime int64, endTime int64) ([]string, error) {
+	_, span := tracing.Start(context.Background(), "ChannelMemberHistoryStore.GetChannelsWithActivityDuring")
+	defer span.End()
+
+	result, err := s.ChannelMemberHistoryStore.GetChannelsWithActivityDuring(startTime, endTime)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerChannelMemberHistoryStore) GetUsersInChannelDuring(startTime int64, endTime int64, channelID []string) ([]*model.ChannelMemberHistoryResult, error) {
+	_, span := tracing.Start(context.Background(), "ChannelMemberHistoryStore.GetUsersInChannelDuring")
+	defer span.End()
+
+	result, err := s.ChannelMemberHistoryStore.GetUsersInChannelDuring(startTime, endTime, channelID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerChannelMemberHistoryStore) LogJoinEvent(userID string, channelID string, joinTime int64) error {
+	_, span := tracing.Start(context.Background(), "ChannelMemberHistoryStore.LogJoinEvent")
+	defer span.End()
+
+	err := s.ChannelMemberHistoryStore.LogJoinEvent(userID, channelID, joinTime)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerChannelMemberHistoryStore) LogLeaveEvent(userID string, channelID string, leaveTime int64) error {
+	_, span := tracing.Start(context.Background(), "ChannelMemberHistoryStore.LogLeaveEvent")
+	defer span.End()
+
+	err := s.ChannelMemberHistoryStore.LogLeaveEvent(userID, channelID, leaveTime)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerChannelMemberHistoryStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, error) {
+	_, span := tracing.Start(context.Background(), "ChannelMemberHistoryStore.PermanentDeleteBatch")
+	defer span.End()
+
+	result, err := s.ChannelMemberHistoryStore.PermanentDeleteBatch(endTime, limit)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerChannelMemberHistoryStore) PermanentDeleteBatchForRetentionPolicies(retentionPolicyBatchConfigs model.RetentionPolicyBatchConfigs, cursor model.RetentionPolicyCursor) (int64, model.RetentionPolicyCursor, error) {
+	_, span := tracing.Start(context.Background(), "ChannelMemberHistoryStore.PermanentDeleteBatchForRetentionPolicies")
+	defer span.End()
+
+	result, resultVar1, err := s.ChannelMemberHistoryStore.PermanentDeleteBatchForRetentionPolicies(retentionPolicyBatchConfigs, cursor)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, resultVar1, err
+}
+
+func (s *TracingLayerClusterDiscoveryStore) AcquireLease(name string, holderID string, durationMillis int64) (bool, error) {
+	_, span := tracing.Start(context.Background(), "ClusterDiscoveryStore.AcquireLease")
+	defer span.End()
+
+	result, err := s.ClusterDiscoveryStore.AcquireLease(name, holderID, durationMillis)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerClusterDiscoveryStore) Cleanup() error {
+	_, span := tracing.Start(context.Background(), "ClusterDiscoveryStore.Cleanup")
+	defer span.End()
+
+	err := s.ClusterDiscoveryStore.Cleanup()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerClusterDiscoveryStore) Delete(discovery *model.ClusterDiscovery) (bool, error) {
+	_, span := tracing.Start(context.Background(), "ClusterDiscoveryStore.Delete")
+	defer span.End()
+
+	result, err := s.ClusterDiscoveryStore.Delete(discovery)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerClusterDiscoveryStore) Exists(discovery *model.ClusterDiscovery) (bool, error) {
+	_, span := tracing.Start(context.Background(), "ClusterDiscoveryStore.Exists")
+	defer span.End()
+
+	result, err := s.ClusterDiscoveryStore.Exists(discovery)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerClusterDiscoveryStore) GetAll(discoveryType string, clusterName string) ([]*model.ClusterDiscovery, error) {
+	_, span := tracing.Start(context.Background(), "ClusterDiscoveryStore.GetAll")
+	defer span.End()
+
+	result, err := s.ClusterDiscoveryStore.GetAll(discoveryType, clusterName)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerClusterDiscoveryStore) ReleaseLease(name string, holderID string) error {
+	_, span := tracing.Start(context.Background(), "ClusterDiscoveryStore.ReleaseLease")
+	defer span.End()
+
+	err := s.ClusterDiscoveryStore.ReleaseLease(name, holderID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerClusterDiscoveryStore) Save(discovery *model.ClusterDiscovery) error {
+	_, span := tracing.Start(context.Background(), "ClusterDiscoveryStore.Save")
+	defer span.End()
+
+	err := s.ClusterDiscoveryStore.Save(discovery)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerClusterDiscoveryStore) SetLastPingAt(discovery *model.ClusterDiscovery) error {
+	_, span := tracing.Start(context.Background(), "ClusterDiscoveryStore.SetLastPingAt")
+	defer span.End()
+
+	err := s.ClusterDiscoveryStore.SetLastPingAt(discovery)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerCommandStore) AnalyticsCommandCount(teamID string) (int64, error) {
+	_, span := tracing.Start(context.Background(), "CommandStore.AnalyticsCommandCount")
+	defer span.End()
+
+	result, err := s.CommandStore.AnalyticsCommandCount(teamID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerCommandStore) Delete(commandID string, timestamp int64) error {
+	_, span := tracing.Start(context.Background(), "CommandStore.Delete")
+	defer span.End()
+
+	err := s.CommandStore.Delete(commandID, timestamp)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerCommandStore) Get(id string) (*model.Command, error) {
+	_, span := tracing.Start(context.Background(), "CommandStore.Get")
+	defer span.End()
+
+	result, err := s.CommandStore.Get(id)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerCommandStore) GetByTeam(teamID string) ([]*model.Command, error) {
+	_, span := tracing.Start(context.Background(), "CommandStore.GetByTeam")
+	defer span.End()
+
+	result, err := s.CommandStore.GetByTeam(teamID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerCommandStore) GetByTrigger(teamID string, trigger string) (*model.Command, error) {
+	_, span := tracing.Start(context.Background(), "CommandStore.GetByTrigger")
+	defer span.End()
+
+	result, err := s.CommandStore.GetByTrigger(teamID, trigger)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerCommandStore) PermanentDeleteByTeam(teamID string) error {
+	_, span := tracing.Start(context.Background(), "CommandStore.PermanentDeleteByTeam")
+	defer span.End()
+
+	err := s.CommandStore.PermanentDeleteByTeam(teamID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerCommandStore) PermanentDeleteByUser(userID string) error {
+	_, span := tracing.Start(context.Background(), "CommandStore.PermanentDeleteByUser")
+	defer span.End()
+
+	err := s.CommandStore.PermanentDeleteByUser(userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerCommandStore) Save(webhook *model.Command) (*model.Command, error) {
+	_, span := tracing.Start(context.Background(), "CommandStore.Save")
+	defer span.End()
+
+	result, err := s.CommandStore.Save(webhook)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerCommandStore) Update(hook *model.Command) (*model.Command, error) {
+	_, span := tracing.Start(context.Background(), "CommandStore.Update")
+	defer span.End()
+
+	result, err := s.CommandStore.Update(hook)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerCommandWebhookStore) Cleanup() {
+	_, span := tracing.Start(context.Background(), "CommandWebhookStore.Cleanup")
+	defer span.End()
+
+	s.CommandWebhookStore.Cleanup()
+}
+
+func (s *TracingLayerCommandWebhookStore) Get(id string) (*model.CommandWebhook, error) {
+	_, span := tracing.Start(context.Background(), "CommandWebhookStore.Get")
+	defer span.End()
+
+	result, err := s.CommandWebhookStore.Get(id)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerCommandWebhookStore) Save(webhook *model.CommandWebhook) (*model.CommandWebhook, error) {
+	_, span := tracing.Start(context.Background(), "CommandWebhookStore.Save")
+	defer span.End()
+
+	result, err := s.CommandWebhookStore.Save(webhook)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerCommandWebhookStore) TryUse(id string, limit int) error {
+	_, span := tracing.Start(context.Background(), "CommandWebhookStore.TryUse")
+	defer span.End()
+
+	err := s.CommandWebhookStore.TryUse(id, limit)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerComplianceStore) ComplianceExport(compliance *model.Compliance, cursor model.ComplianceExportCursor, limit int) ([]*model.CompliancePost, model.ComplianceExportCursor, error) {
+	_, span := tracing.Start(context.Background(), "ComplianceStore.ComplianceExport")
+	defer span.End()
+
+	result, resultVar1, err := s.ComplianceStore.ComplianceExport(compliance, cursor, limit)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, resultVar1, err
+}
+
+func (s *TracingLayerComplianceStore) Get(id string) (*model.Compliance, error) {
+	_, span := tracing.Start(context.Background(), "ComplianceStore.Get")
+	defer span.End()
+
+	result, err := s.ComplianceStore.Get(id)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerComplianceStore) GetAll(offset int, limit int) (model.Compliances, error) {
+	_, span := tracing.Start(context.Background(), "ComplianceStore.GetAll")
+	defer span.End()
+
+	result, err := s.ComplianceStore.GetAll(offset, limit)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerComplianceStore) MessageExport(rctx request.CTX, cursor model.MessageExportCursor, limit int) ([]*model.MessageExport, model.MessageExportCursor, error) {
+	_, span := tracing.Start(requestContext(rctx), "ComplianceStore.MessageExport")
+	defer span.End()
+
+	result, resultVar1, err := s.ComplianceStore.MessageExport(rctx, cursor, limit)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, resultVar1, err
+}
+
+func (s *TracingLayerComplianceStore) Save(compliance *model.Compliance) (*model.Compliance, error) {
+	_, span := tracing.Start(context.Background(), "ComplianceStore.Save")
+	defer span.End()
+
+	result, err := s.ComplianceStore.Save(compliance)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerComplianceStore) Update(compliance *model.Compliance) (*model.Compliance, error) {
+	_, span := tracing.Start(context.Background(), "ComplianceStore.Update")
+	defer span.End()
+
+	result, err := s.ComplianceStore.Update(compliance)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerContentFlaggingStore) ClearCaches() {
+	_, span := tracing.Start(context.Background(), "ContentFlaggingStore.ClearCaches")
+	defer span.End()
+
+	s.ContentFlaggingStore.ClearCaches()
+}
+
+func (s *TracingLayerContentFlaggingStore) GetReviewerSettings() (*model.ReviewerIDsSettings, error) {
+	_, span := tracing.Start(context.Background(), "ContentFlaggingStore.GetReviewerSettings")
+	defer span.End()
+
+	result, err := s.ContentFlaggingStore.GetReviewerSettings()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerContentFlaggingStore) SaveReviewerSettings(reviewerSettings model.ReviewerIDsSettings) error {
+	_, span := tracing.Start(context.Background(), "ContentFlaggingStore.SaveReviewerSettings")
+	defer span.End()
+
+	err := s.ContentFlaggingStore.SaveReviewerSettings(reviewerSettings)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerDesktopTokensStore) Delete(token string) error {
+	_, span := tracing.Start(context.Background(), "DesktopTokensStore.Delete")
+	defer span.End()
+
+	err := s.DesktopTokensStore.Delete(token)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerDesktopTokensStore) DeleteByUserId(userID string) error {
+	_, span := tracing.Start(context.Background(), "DesktopTokensStore.DeleteByUserId")
+	defer span.End()
+
+	err := s.DesktopTokensStore.DeleteByUserId(userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerDesktopTokensStore) DeleteOlderThan(minCreatedAt int64) error {
+	_, span := tracing.Start(context.Background(), "DesktopTokensStore.DeleteOlderThan")
+	defer span.End()
+
+	err := s.DesktopTokensStore.DeleteOlderThan(minCreatedAt)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerDesktopTokensStore) GetUserId(token string, minCreatedAt int64) (*string, error) {
+	_, span := tracing.Start(context.Background(), "DesktopTokensStore.GetUserId")
+	defer span.End()
+
+	result, err := s.DesktopTokensStore.GetUserId(token, minCreatedAt)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerDesktopTokensStore) Insert(token string, createAt int64, userID string) error {
+	_, span := tracing.Start(context.Background(), "DesktopTokensStore.Insert")
+	defer span.End()
+
+	err := s.DesktopTokensStore.Insert(token, createAt, userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerDraftStore) Delete(userID string, channelID string, rootID string) error {
+	_, span := tracing.Start(context.Background(), "DraftStore.Delete")
+	defer span.End()
+
+	err := s.DraftStore.Delete(userID, channelID, rootID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerDraftStore) DeleteDraftsAssociatedWithPost(channelID string, rootID string) error {
+	_, span := tracing.Start(context.Background(), "DraftStore.DeleteDraftsAssociatedWithPost")
+	defer span.End()
+
+	err := s.DraftStore.DeleteDraftsAssociatedWithPost(channelID, rootID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerDraftStore) DeleteEmptyDraftsByCreateAtAndUserId(createAt int64, userID string) error {
+	_, span := tracing.Start(context.Background(), "DraftStore.DeleteEmptyDraftsByCreateAtAndUserId")
+	defer span.End()
+
+	err := s.DraftStore.DeleteEmptyDraftsByCreateAtAndUserId(createAt, userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerDraftStore) DeleteOrphanDraftsByCreateAtAndUserId(createAt int64, userID string) error {
+	_, span := tracing.Start(context.Background(), "DraftStore.DeleteOrphanDraftsByCreateAtAndUserId")
+	defer span.End()
+
+	err := s.DraftStore.DeleteOrphanDraftsByCreateAtAndUserId(createAt, userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerDraftStore) Get(userID string, channelID string, rootID string, includeDeleted bool) (*model.Draft, error) {
+	_, span := tracing.Start(context.Background(), "DraftStore.Get")
+	defer span.End()
+
+	result, err := s.DraftStore.Get(userID, channelID, rootID, includeDeleted)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerDraftStore) GetDraftsForUser(userID string, teamID string) ([]*model.Draft, error) {
+	_, span := tracing.Start(context.Background(), "DraftStore.GetDraftsForUser")
+	defer span.End()
+
+	result, err := s.DraftStore.GetDraftsForUser(userID, teamID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerDraftStore) GetLastCreateAtAndUserIdValuesForEmptyDraftsMigration(createAt int64, userID string) (int64, string, error) {
+	_, span := tracing.Start(context.Background(), "DraftStore.GetLastCreateAtAndUserIdValuesForEmptyDraftsMigration")
+	defer span.End()
+
+	result, resultVar1, err := s.DraftStore.GetLastCreateAtAndUserIdValuesForEmptyDraftsMigration(createAt, userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, resultVar1, err
+}
+
+func (s *TracingLayerDraftStore) PermanentDeleteByUser(userId string) error {
+	_, span := tracing.Start(context.Background(), "DraftStore.PermanentDeleteByUser")
+	defer span.End()
+
+	err := s.DraftStore.PermanentDeleteByUser(userId)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerDraftStore) Upsert(d *model.Draft) (*model.Draft, error) {
+	_, span := tracing.Start(context.Background(), "DraftStore.Upsert")
+	defer span.End()
+
+	result, err := s.DraftStore.Upsert(d)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerEmailBatchNotificationStore) Delete(ids []string) error {
+	_, span := tracing.Start(context.Background(), "EmailBatchNotificationStore.Delete")
+	defer span.End()
+
+	err := s.EmailBatchNotificationStore.Delete(ids)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerEmailBatchNotificationStore) GetForUser(userID string) ([]*model.EmailBatchNotification, error) {
+	_, span := tracing.Start(context.Background(), "EmailBatchNotificationStore.GetForUser")
+	defer span.End()
+
+	result, err := s.EmailBatchNotificationStore.GetForUser(userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerEmailBatchNotificationStore) GetUserIds(afterUserID string, limit int) ([]string, error) {
+	_, span := tracing.Start(context.Background(), "EmailBatchNotificationStore.GetUserIds")
+	defer span.End()
+
+	result, err := s.EmailBatchNotificationStore.GetUserIds(afterUserID, limit)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerEmailBatchNotificationStore) PermanentDeleteByUser(userID string) error {
+	_, span := tracing.Start(context.Background(), "EmailBatchNotificationStore.PermanentDeleteByUser")
+	defer span.End()
+
+	err := s.EmailBatchNotificationStore.PermanentDeleteByUser(userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerEmailBatchNotificationStore) Save(notification *model.EmailBatchNotification) (*model.EmailBatchNotification, error) {
+	_, span := tracing.Start(context.Background(), "EmailBatchNotificationStore.Save")
+	defer span.End()
+
+	result, err := s.EmailBatchNotificationStore.Save(notification)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerEmojiStore) Delete(emoji *model.Emoji, timestamp int64) error {
+	_, span := tracing.Start(context.Background(), "EmojiStore.Delete")
+	defer span.End()
+
+	err := s.EmojiStore.Delete(emoji, timestamp)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerEmojiStore) Get(rctx request.CTX, id string, allowFromCache bool) (*model.Emoji, error) {
+	_, span := tracing.Start(requestContext(rctx), "EmojiStore.Get")
+	defer span.End()
+
+	result, err := s.EmojiStore.Get(rctx, id, allowFromCache)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerEmojiStore) GetAllAfter(createAt int64, id string, limit int) ([]*model.Emoji, error) {
+	_, span := tracing.Start(context.Background(), "EmojiStore.GetAllAfter")
+	defer span.End()
+
+	result, err := s.EmojiStore.GetAllAfter(createAt, id, limit)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerEmojiStore) GetByName(rctx request.CTX, name string, allowFromCache bool) (*model.Emoji, error) {
+	_, span := tracing.Start(requestContext(rctx), "EmojiStore.GetByName")
+	defer span.End()
+
+	result, err := s.EmojiStore.GetByName(rctx, name, allowFromCache)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerEmojiStore) GetList(offset int, limit int, sort string) ([]*model.Emoji, error) {
+	_, span := tracing.Start(context.Background(), "EmojiStore.GetList")
+	defer span.End()
+
+	result, err := s.EmojiStore.GetList(offset, limit, sort)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerEmojiStore) GetMultipleByName(rctx request.CTX, names []string) ([]*model.Emoji, error) {
+	_, span := tracing.Start(requestContext(rctx), "EmojiStore.GetMultipleByName")
+	defer span.End()
+
+	result, err := s.EmojiStore.GetMultipleByName(rctx, names)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerEmojiStore) Save(emoji *model.Emoji) (*model.Emoji, error) {
+	_, span := tracing.Start(context.Background(), "EmojiStore.Save")
+	defer span.End()
+
+	result, err := s.EmojiStore.Save(emoji)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerEmojiStore) Search(name string, prefixOnly bool, limit int) ([]*model.Emoji, error) {
+	_, span := tracing.Start(context.Background(), "EmojiStore.Search")
+	defer span.End()
+
+	result, err := s.EmojiStore.Search(name, prefixOnly, limit)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerEventSubscriptionStore) Delete(id string, deleteAt int64) error {
+	_, span := tracing.Start(context.Background(), "EventSubscriptionStore.Delete")
+	defer span.End()
+
+	err := s.EventSubscriptionStore.Delete(id, deleteAt)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerEventSubscriptionStore) Get(id string) (*model.EventSubscription, error) {
+	_, span := tracing.Start(context.Background(), "EventSubscriptionStore.Get")
+	defer span.End()
+
+	result, err := s.EventSubscriptionStore.Get(id)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerEventSubscriptionStore) GetForTeam(teamID string) ([]*model.EventSubscription, error) {
+	_, span := tracing.Start(context.Background(), "EventSubscriptionStore.GetForTeam")
+	defer span.End()
+
+	result, err := s.EventSubscriptionStore.GetForTeam(teamID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerEventSubscriptionStore) GetList(teamID string, offset int, limit int) ([]*model.EventSubscription, error) {
+	_, span := tracing.Start(context.Background(), "EventSubscriptionStore.GetList")
+	defer span.End()
+
+	result, err := s.EventSubscriptionStore.GetList(teamID, offset, limit)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerEventSubscriptionStore) PermanentDeleteByUser(userID string) error {
+	_, span := tracing.Start(context.Background(), "EventSubscriptionStore.PermanentDeleteByUser")
+	defer span.End()
+
+	err := s.EventSubscriptionStore.PermanentDeleteByUser(userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerEventSubscriptionStore) Save(subscription *model.EventSubscription) (*model.EventSubscription, error) {
+	_, span := tracing.Start(context.Background(), "EventSubscriptionStore.Save")
+	defer span.End()
+
+	result, err := s.EventSubscriptionStore.Save(subscription)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerEventSubscriptionStore) Update(subscription *model.EventSubscription) (*model.EventSubscription, error) {
+	_, span := tracing.Start(context.Background(), "EventSubscriptionStore.Update")
+	defer span.End()
+
+	result, err := s.EventSubscriptionStore.Update(subscription)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerFileInfoStore) AttachToPost(rctx request.CTX, fileID string, postID string, channelID string, creatorID string) error {
+	_, span := tracing.Start(requestContext(rctx), "FileInfoStore.AttachToPost")
+	defer span.End()
+
+	err := s.FileInfoStore.AttachToPost(rctx, fileID, postID, channelID, creatorID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerFileInfoStore) ClearCaches() {
+	_, span := tracing.Start(context.Background(), "FileInfoStore.ClearCaches")
+	defer span.End()
+
+	s.FileInfoStore.ClearCaches()
+}
+
+func (s *TracingLayerFileInfoStore) CountAll() (int64, error) {
+	_, span := tracing.Start(context.Background(), "FileInfoStore.CountAll")
+	defer span.End()
+
+	result, err := s.FileInfoStore.CountAll()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerFileInfoStore) DeleteForPost(rctx request.CTX, postID string) (string, error) {
+	_, span := tracing.Start(requestContext(rctx), "FileInfoStore.DeleteForPost")
+	defer span.End()
+
+	result, err := s.FileInfoStore.DeleteForPost(rctx, postID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerFileInfoStore) DeleteForPostByIds(rctx request.CTX, postId string, fileIDs []string) error {
+	_, span := tracing.Start(requestContext(rctx), "FileInfoStore.DeleteForPostByIds")
+	defer span.End()
+
+	err := s.FileInfoStore.DeleteForPostByIds(rctx, postId, fileIDs)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerFileInfoStore) Get(id string) (*model.FileInfo, error) {
+	_, span := tracing.Start(context.Background(), "FileInfoStore.Get")
+	defer span.End()
+
+	result, err := s.FileInfoStore.Get(id)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerFileInfoStore) GetByIds(ids []string, includeDeleted bool, allowFromCache bool) ([]*model.FileInfo, error) {
+	_, span := tracing.Start(context.Background(), "FileInfoStore.GetByIds")
+	defer span.End()
+
+	result, err := s.FileInfoStore.GetByIds(ids, includeDeleted, allowFromCache)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerFileInfoStore) GetByPath(path string) (*model.FileInfo, error) {
+	_, span := tracing.Start(context.Background(), "FileInfoStore.GetByPath")
+	defer span.End()
+
+	result, err := s.FileInfoStore.GetByPath(path)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerFileInfoStore) GetFilesBatchForIndexing(startTime int64, startFileID string, includeDeleted bool, limit int) ([]*model.FileForIndexing, error) {
+	_, span := tracing.Start(context.Background(), "FileInfoStore.GetFilesBatchForIndexing")
+	defer span.End()
+
+	result, err := s.FileInfoStore.GetFilesBatchForIndexing(startTime, startFileID, includeDeleted, limit)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerFileInfoStore) GetForPost(postID string, readFromMaster bool, includeDeleted bool, allowFromCache bool) ([]*model.FileInfo, error) {
+	_, span := tracing.Start(context.Background(), "FileInfoStore.GetForPost")
+	defer span.End()
+
+	result, err := s.FileInfoStore.GetForPost(postID, readFromMaster, includeDeleted, allowFromCache)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerFileInfoStore) GetForUser(userID string) ([]*model.FileInfo, error) {
+	_, span := tracing.Start(context.Background(), "FileInfoStore.GetForUser")
+	defer span.End()
+
+	result, err := s.FileInfoStore.GetForUser(userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerFileInfoStore) GetFromMaster(id string) (*model.FileInfo, error) {
+	_, span := tracing.Start(context.Background(), "FileInfoStore.GetFromMaster")
+	defer span.End()
+
+	result, err := s.FileInfoStore.GetFromMaster(id)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerFileInfoStore) GetStorageUsage(allowFromCache bool, includeDeleted bool) (int64, error) {
+	_, span := tracing.Start(context.Background(), "FileInfoStore.GetStorageUsage")
+	defer span.End()
+
+	result, err := s.FileInfoStore.GetStorageUsage(allowFromCache, includeDeleted)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerFileInfoStore) GetUptoNSizeFileTime(n int64) (int64, error) {
+	_, span := tracing.Start(context.Background(), "FileInfoStore.GetUptoNSizeFileTime")
+	defer span.End()
+
+	result, err := s.FileInfoStore.GetUptoNSizeFileTime(n)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerFileInfoStore) GetWithOptions(page int, perPage int, opt *model.GetFileInfosOptions) ([]*model.FileInfo, error) {
+	_, span := tracing.Start(context.Background(), "FileInfoStore.GetWithOptions")
+	defer span.End()
+
+	result, err := s.FileInfoStore.GetWithOptions(page, perPage, opt)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerFileInfoStore) InvalidateFileInfosForPostCache(postID string, deleted bool) {
+	_, span := tracing.Start(context.Background(), "FileInfoStore.InvalidateFileInfosForPostCache")
+	defer span.End()
+
+	s.FileInfoStore.InvalidateFileInfosForPostCache(postID, deleted)
+}
+
+func (s *TracingLayerFileInfoStore) PermanentDelete(rctx request.CTX, fileID string) error {
+	_, span := tracing.Start(requestContext(rctx), "FileInfoStore.PermanentDelete")
+	defer span.End()
+
+	err := s.FileInfoStore.PermanentDelete(rctx, fileID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerFileInfoStore) PermanentDeleteBatch(rctx request.CTX, endTime int64, limit int64) (int64, error) {
+	_, span := tracing.Start(requestContext(rctx), "FileInfoStore.PermanentDeleteBatch")
+	defer span.End()
+
+	result, err := s.FileInfoStore.PermanentDeleteBatch(rctx, endTime, limit)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerFileInfoStore) PermanentDeleteByUser(rctx request.CTX, userID string) (int64, error) {
+	_, span := tracing.Start(requestContext(rctx), "FileInfoStore.PermanentDeleteByUser")
+	defer span.End()
+
+	result, err := s.FileInfoStore.PermanentDeleteByUser(rctx, userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerFileInfoStore) PermanentDeleteForPost(rctx request.CTX, postID string) error {
+	_, span := tracing.Start(requestContext(rctx), "FileInfoStore.PermanentDeleteForPost")
+	defer span.End()
+
+	err := s.FileInfoStore.PermanentDeleteForPost(rctx, postID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerFileInfoStore) RefreshFileStats() error {
+	_, span := tracing.Start(context.Background(), "FileInfoStore.RefreshFileStats")
+	defer span.End()
+
+	err := s.FileInfoStore.RefreshFileStats()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerFileInfoStore) RestoreForPostByIds(rctx request.CTX, postId string, fileIDs []string) error {
+	_, span := tracing.Start(requestContext(rctx), "FileInfoStore.RestoreForPostByIds")
+	defer span.End()
+
+	err := s.FileInfoStore.RestoreForPostByIds(rctx, postId, fileIDs)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerFileInfoStore) Save(rctx request.CTX, info *model.FileInfo) (*model.FileInfo, error) {
+	_, span := tracing.Start(requestContext(rctx), "FileInfoStore.Save")
+	defer span.End()
+
+	result, err := s.FileInfoStore.Save(rctx, info)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerFileInfoStore) Search(rctx request.CTX, paramsList []*model.SearchParams, userID string, teamID string, page int, perPage int) (*model.FileInfoList, error) {
+	_, span := tracing.Start(requestContext(rctx), "FileInfoStore.Search")
+	defer span.End()
+
+	result, err := s.FileInfoStore.Search(rctx, paramsList, userID, teamID, page, perPage)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerFileInfoStore) SetContent(rctx request.CTX, fileID string, content string) error {
+	_, span := tracing.Start(requestContext(rctx), "FileInfoStore.SetContent")
+	defer span.End()
+
+	err := s.FileInfoStore.SetContent(rctx, fileID, content)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerFileInfoStore) Upsert(rctx request.CTX, info *model.FileInfo) (*model.FileInfo, error) {
+	_, span := tracing.Start(requestContext(rctx), "FileInfoStore.Upsert")
+	defer span.End()
+
+	result, err := s.FileInfoStore.Upsert(rctx, info)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerGroupStore) AdminRoleGroupsForSyncableMember(userID string, syncableID string, syncableType model.GroupSyncableType) ([]string, error) {
+	_, span := tracing.Start(context.Background(), "GroupStore.AdminRoleGroupsForSyncableMember")
+	defer span.End()
+
+	result, err := s.GroupStore.AdminRoleGroupsForSyncableMember(userID, syncableID, syncableType)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerGroupStore) ChannelMembersMinusGroupMembers(channelID string, groupIDs []string, page int, perPage int) ([]*model.UserWithGroups, error) {
+	_, span := tracing.Start(context.Background(), "GroupStore.ChannelMembersMinusGroupMembers")
+	defer span.End()
+
+	result, err := s.GroupStore.ChannelMembersMinusGroupMembers(channelID, groupIDs, page, perPage)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerGroupStore) ChannelMembersToAdd(since int64, channelID *string, reAddRemovedMembers bool) ([]*model.UserChannelIDPair, error) {
+	_, span := tracing.Start(context.Background(), "GroupStore.ChannelMembersToAdd")
+	defer span.End()
+
+	result, err := s.GroupStore.ChannelMembersToAdd(since, channelID, reAddRemovedMembers)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerGroupStore) ChannelMembersToRemove(channelID *string) ([]*model.ChannelMember, error) {
+	_, span := tracing.Start(context.Background(), "GroupStore.ChannelMembersToRemove")
+	defer span.End()
+
+	result, err := s.GroupStore.ChannelMembersToRemove(channelID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerGroupStore) CountChannelMembersMinusGroupMembers(channelID string, groupIDs []string) (int64, error) {
+	_, span := tracing.Start(context.Background(), "GroupStore.CountChannelMembersMinusGroupMembers")
+	defer span.End()
+
+	result, err := s.GroupStore.CountChannelMembersMinusGroupMembers(channelID, groupIDs)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerGroupStore) CountGroupsByChannel(channelID string, opts model.GroupSearchOpts) (int64, error) {
+	_, span := tracing.Start(context.Background(), "GroupStore.CountGroupsByChannel")
+	defer span.End()
+
+	result, err := s.GroupStore.CountGroupsByChannel(channelID, opts)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerGroupStore) CountGroupsByTeam(teamID string, opts model.GroupSearchOpts) (int64, error) {
+	_, span := tracing.Start(context.Background(), "GroupStore.CountGroupsByTeam")
+	defer span.End()
+
+	result, err := s.GroupStore.CountGroupsByTeam(teamID, opts)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerGroupStore) CountTeamMembersMinusGroupMembers(teamID string, groupIDs []string) (int64, error) {
+	_, span := tracing.Start(context.Background(), "GroupStore.CountTeamMembersMinusGroupMembers")
+	defer span.End()
+
+	result, err := s.GroupStore.CountTeamMembersMinusGroupMembers(teamID, groupIDs)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerGroupStore) Create(group *model.Group) (*model.Group, error) {
+	_, span := tracing.Start(context.Background(), "GroupStore.Create")
+	defer span.End()
+
+	result, err := s.GroupStore.Create(group)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerGroupStore) CreateGroupSyncable(groupSyncable *model.GroupSyncable) (*model.GroupSyncable, error) {
+	_, span := tracing.Start(context.Background(), "GroupStore.CreateGroupSyncable")
+	defer span.End()
+
+	result, err := s.GroupStore.CreateGroupSyncable(groupSyncable)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerGroupStore) CreateWithUserIds(group *model.GroupWithUserIds) (*model.Group, error) {
+	_, span := tracing.Start(context.Background(), "GroupStore.CreateWithUserIds")
+	defer span.End()
+
+	result, err := s.GroupStore.CreateWithUserIds(group)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerGroupStore) Delete(groupID string) (*model.Group, error) {
+	_, span := tracing.Start(context.Background(), "GroupStore.Delete")
+	defer span.End()
+
+	result, err := s.GroupStore.Delete(groupID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerGroupStore) DeleteGroupSyncable(groupID string, syncableID string, syncableType model.GroupSyncableType) (*model.GroupSyncable, error) {
+	_, span := tracing.Start(context.Background(), "GroupStore.DeleteGroupSyncable")
+	defer span.End()
+
+	result, err := s.GroupStore.DeleteGroupSyncable(groupID, syncableID, syncableType)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerGroupStore) DeleteMember(groupID string, userID string) (*model.GroupMember, error) {
+	_, span := tracing.Start(context.Background(), "GroupStore.DeleteMember")
+	defer span.End()
+
+	result, err := s.GroupStore.DeleteMember(groupID, userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerGroupStore) DeleteMembers(groupID string, userIDs []string) ([]*model.GroupMember, error) {
+	_, span := tracing.Start(context.Background(), "GroupStore.DeleteMembers")
+	defer span.End()
+
+	result, err := s.GroupStore.DeleteMembers(groupID, userIDs)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerGroupStore) DistinctGroupMemberCount() (int64, error) {
+	_, span := tracing.Start(context.Background(), "GroupStore.DistinctGroupMemberCount")
+	defer span.End()
+
+	result, err := s.GroupStore.DistinctGroupMemberCount()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerGroupStore) DistinctGroupMemberCountForSource(source model.GroupSource) (int64, error) {
+	_, span := tracing.Start(context.Background(), "GroupStore.DistinctGroupMemberCountForSource")
+	defer span.End()
+
+	result, err := s.GroupStore.DistinctGroupMemberCountForSource(source)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerGroupStore) Get(groupID string) (*model.Group, error) {
+	_, span := tracing.Start(context.Background(), "GroupStore.Get")
+	defer span.End()
+
+	result, err := s.GroupStore.Get(groupID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerGroupStore) GetAllBySource(groupSource model.GroupSource) ([]*model.Group, error) {
+	_, span := tracing.Start(context.Background(), "GroupStore.GetAllBySource")
+	defer span.End()
+
+	result, err := s.GroupStore.GetAllBySource(groupSource)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerGroupStore) GetAllGroupSyncablesByGroupId(groupID string, syncableType model.GroupSyncableType) ([]*model.GroupSyncable, error) {
+	_, span := tracing.Start(context.Background(), "GroupStore.GetAllGroupSyncablesByGroupId")
+	defer span.End()
+
+	result, err := s.GroupStore.GetAllGroupSyncablesByGroupId(groupID, syncableType)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerGroupStore) GetByIDs(groupIDs []string) ([]*model.Group, error) {
+	_, span := tracing.Start(context.Background(), "GroupStore.GetByIDs")
+	defer span.End()
+
+	result, err := s.GroupStore.GetByIDs(groupIDs)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerGroupStore) GetByName(name string, opts model.GroupSearchOpts) (*model.Group, error) {
+	_, span := tracing.Start(context.Background(), "GroupStore.GetByName")
+	defer span.End()
+
+	result, err := s.GroupStore.GetByName(name, opts)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerGroupStore) GetByNames(names []string, viewRestrictions *model.ViewUsersRestrictions) ([]*model.Group, error) {
+	_, span := tracing.Start(context.Background(), "GroupStore.GetByNames")
+	defer span.End()
+
+	result, err := s.GroupStore.GetByNames(names, viewRestrictions)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerGroupStore) GetByRemoteID(remoteID string, groupSource model.GroupSource) (*model.Group, error) {
+	_, span := tracing.Start(context.Background(), "GroupStore.GetByRemoteID")
+	defer span.End()
+
+	result, err := s.GroupStore.GetByRemoteID(remoteID, groupSource)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerGroupStore) GetByUser(userID string, opts model.GroupSearchOpts) ([]*model.Group, error) {
+	_, span := tracing.Start(context.Background(), "GroupStore.GetByUser")
+	defer span.End()
+
+	result, err := s.GroupStore.GetByUser(userID, opts)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerGroupStore) GetGroupSyncable(groupID string, syncableID string, syncableType model.GroupSyncableType) (*model.GroupSyncable, error) {
+	_, span := tracing.Start(context.Background(), "GroupStore.GetGroupSyncable")
+	defer span.End()
+
+	result, err := s.GroupStore.GetGroupSyncable(groupID, syncableID, syncableType)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerGroupStore) GetGroups(page int, perPage int, opts model.GroupSearchOpts, viewRestrictions *model.ViewUsersRestrictions) ([]*model.Group, error) {
+	_, span := tracing.Start(context.Background(), "GroupStore.GetGroups")
+	defer span.End()
+
+	result, err := s.GroupStore.GetGroups(page, perPage, opts, viewRestrictions)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerGroupStore) GetGroupsAssociatedToChannelsByTeam(teamID string, opts model.GroupSearchOpts) (map[string][]*model.GroupWithSchemeAdmin, error) {
+	_, span := tracing.Start(context.Background(), "GroupStore.GetGroupsAssociatedToChannelsByTeam")
+	defer span.End()
+
+	result, err := s.GroupStore.GetGroupsAssociatedToChannelsByTeam(teamID, opts)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerGroupStore) GetGroupsByChannel(channelID string, opts model.GroupSearchOpts) ([]*model.GroupWithSchemeAdmin, error) {
+	_, span := tracing.Start(context.Background(), "GroupStore.GetGroupsByChannel")
+	defer span.End()
+
+	result, err := s.GroupStore.GetGroupsByChannel(channelID, opts)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerGroupStore) GetGroupsByTeam(teamID string, opts model.GroupSearchOpts) ([]*model.GroupWithSchemeAdmin, error) {
+	_, span := tracing.Start(context.Background(), "GroupStore.GetGroupsByTeam")
+	defer span.End()
+
+	result, err := s.GroupStore.GetGroupsByTeam(teamID, opts)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerGroupStore) GetMember(groupID string, userID string) (*model.GroupMember, error) {
+	_, span := tracing.Start(context.Background(), "GroupStore.GetMember")
+	defer span.End()
+
+	result, err := s.GroupStore.GetMember(groupID, userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerGroupStore) GetMemberCount(groupID string) (int64, error) {
+	_, span := tracing.Start(context.Background(), "GroupStore.GetMemberCount")
+	defer span.End()
+
+	result, err := s.GroupStore.GetMemberCount(groupID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerGroupStore) GetMemberCountWithRestrictions(groupID string, viewRestrictions *model.ViewUsersRestrictions) (int64, error) {
+	_, span := tracing.Start(context.Background(), "GroupStore.GetMemberCountWithRestrictions")
+	defer span.End()
+
+	result, err := s.GroupStore.GetMemberCountWithRestrictions(groupID, viewRestrictions)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerGroupStore) GetMemberUsers(groupID string) ([]*model.User, error) {
+	_, span := tracing.Start(context.Background(), "GroupStore.GetMemberUsers")
+	defer span.End()
+
+	result, err := s.GroupStore.GetMemberUsers(groupID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerGroupStore) GetMemberUsersInTeam(groupID string, teamID string) ([]*model.User, error) {
+	_, span := tracing.Start(context.Background(), "GroupStore.GetMemberUsersInTeam")
+	defer span.End()
+
+	result, err := s.GroupStore.GetMemberUsersInTeam(groupID, teamID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerGroupStore) GetMemberUsersNotInChannel(groupID string, channelID string) ([]*model.User, error) {
+	_, span := tracing.Start(context.Background(), "GroupStore.GetMemberUsersNotInChannel")
+	defer span.End()
+
+	result, err := s.GroupStore.GetMemberUsersNotInChannel(groupID, channelID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerGroupStore) GetMemberUsersPage(groupID string, page int, perPage int, viewRestrictions *model.ViewUsersRestrictions) ([]*model.User, error) {
+	_, span := tracing.Start(context.Background(), "GroupStore.GetMemberUsersPage")
+	defer span.End()
+
+	result, err := s.GroupStore.GetMemberUsersPage(groupID, page, perPage, viewRestrictions)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerGroupStore) GetMemberUsersSortedPage(groupID string, page int, perPage int, viewRestrictions *model.ViewUsersRestrictions, teammateNameDisplay string) ([]*model.User, error) {
+	_, span := tracing.Start(context.Background(), "GroupStore.GetMemberUsersSortedPage")
+	defer span.End()
+
+	result, err := s.GroupStore.GetMemberUsersSortedPage(groupID, page, perPage, viewRestrictions, teammateNameDisplay)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerGroupStore) GetNonMemberUsersPage(groupID string, page int, perPage int, viewRestrictions *model.ViewUsersRestrictions) ([]*model.User, error) {
+	_, span := tracing.Start(context.Background(), "GroupStore.GetNonMemberUsersPage")
+	defer span.End()
+
+	result, err := s.GroupStore.GetNonMemberUsersPage(groupID, page, perPage, viewRestrictions)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerGroupStore) GroupChannelCount() (int64, error) {
+	_, span := tracing.Start(context.Background(), "GroupStore.GroupChannelCount")
+	defer span.End()
+
+	result, err := s.GroupStore.GroupChannelCount()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerGroupStore) GroupCount() (int64, error) {
+	_, span := tracing.Start(context.Background(), "GroupStore.GroupCount")
+	defer span.End()
+
+	result, err := s.GroupStore.GroupCount()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerGroupStore) GroupCountBySource(source model.GroupSource) (int64, error) {
+	_, span := tracing.Start(context.Background(), "GroupStore.GroupCountBySource")
+	defer span.End()
+
+	result, err := s.GroupStore.GroupCountBySource(source)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerGroupStore) GroupCountWithAllowReference() (int64, error) {
+	_, span := tracing.Start(context.Background(), "GroupStore.GroupCountWithAllowReference")
+	defer span.End()
+
+	result, err := s.GroupStore.GroupCountWithAllowReference()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerGroupStore) GroupMemberCount() (int64, error) {
+	_, span := tracing.Start(context.Background(), "GroupStore.GroupMemberCount")
+	defer span.End()
+
+	result, err := s.GroupStore.GroupMemberCount()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerGroupStore) GroupTeamCount() (int64, error) {
+	_, span := tracing.Start(context.Background(), "GroupStore.GroupTeamCount")
+	defer span.End()
+
+	result, err := s.GroupStore.GroupTeamCount()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerGroupStore) PermanentDeleteMembersByUser(userID string) error {
+	_, span := tracing.Start(context.Background(), "GroupStore.PermanentDeleteMembersByUser")
+	defer span.End()
+
+	err := s.GroupStore.PermanentDeleteMembersByUser(userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerGroupStore) PermittedSyncableAdmins(syncableID string, syncableType model.GroupSyncableType) ([]string, error) {
+	_, span := tracing.Start(context.Background(), "GroupStore.PermittedSyncableAdmins")
+	defer span.End()
+
+	result, err := s.GroupStore.PermittedSyncableAdmins(syncableID, syncableType)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerGroupStore) Restore(groupID string) (*model.Group, error) {
+	_, span := tracing.Start(context.Background(), "GroupStore.Restore")
+	defer span.End()
+
+	result, err := s.GroupStore.Restore(groupID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerGroupStore) TeamMembersMinusGroupMembers(teamID string, groupIDs []string, page int, perPage int) ([]*model.UserWithGroups, error) {
+	_, span := tracing.Start(context.Background(), "GroupStore.TeamMembersMinusGroupMembers")
+	defer span.End()
+
+	result, err := s.GroupStore.TeamMembersMinusGroupMembers(teamID, groupIDs, page, perPage)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerGroupStore) TeamMembersToAdd(since int64, teamID *string, reAddRemovedMembers bool) ([]*model.UserTeamIDPair, error) {
+	_, span := tracing.Start(context.Background(), "GroupStore.TeamMembersToAdd")
+	defer span.End()
+
+	result, err := s.GroupStore.TeamMembersToAdd(since, teamID, reAddRemovedMembers)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerGroupStore) TeamMembersToRemove(teamID *string) ([]*model.TeamMember, error) {
+	_, span := tracing.Start(context.Background(), "GroupStore.TeamMembersToRemove")
+	defer span.End()
+
+	result, err := s.GroupStore.TeamMembersToRemove(teamID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerGroupStore) Update(group *model.Group) (*model.Group, error) {
+	_, span := tracing.Start(context.Background(), "GroupStore.Update")
+	defer span.End()
+
+	result, err := s.GroupStore.Update(group)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerGroupStore) UpdateGroupSyncable(groupSyncable *model.GroupSyncable) (*model.GroupSyncable, error) {
+	_, span := tracing.Start(context.Background(), "GroupStore.UpdateGroupSyncable")
+	defer span.End()
+
+	result, err := s.GroupStore.UpdateGroupSyncable(groupSyncable)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerGroupStore) UpsertMember(groupID string, userID string) (*model.GroupMember, error) {
+	_, span := tracing.Start(context.Background(), "GroupStore.UpsertMember")
+	defer span.End()
+
+	result, err := s.GroupStore.UpsertMember(groupID, userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerGroupStore) UpsertMembers(groupID string, userIDs []string) ([]*model.GroupMember, error) {
+	_, span := tracing.Start(context.Background(), "GroupStore.UpsertMembers")
+	defer span.End()
+
+	result, err := s.GroupStore.UpsertMembers(groupID, userIDs)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerJobStore) Cleanup(expiryTime int64, batchSize int) error {
+	_, span := tracing.Start(context.Background(), "JobStore.Cleanup")
+	defer span.End()
+
+	err := s.JobStore.Cleanup(expiryTime, batchSize)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerJobStore) Delete(id string) (string, error) {
+	_, span := tracing.Start(context.Background(), "JobStore.Delete")
+	defer span.End()
+
+	result, err := s.JobStore.Delete(id)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerJobStore) Get(rctx request.CTX, id string) (*model.Job, error) {
+	_, span := tracing.Start(requestContext(rctx), "JobStore.Get")
+	defer span.End()
+
+	result, err := s.JobStore.Get(rctx, id)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerJobStore) GetAllByStatus(rctx request.CTX, status string) ([]*model.Job, error) {
+	_, span := tracing.Start(requestContext(rctx), "JobStore.GetAllByStatus")
+	defer span.End()
+
+	result, err := s.JobStore.GetAllByStatus(rctx, status)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerJobStore) GetAllByType(rctx request.CTX, jobType string) ([]*model.Job, error) {
+	_, span := tracing.Start(requestContext(rctx), "JobStore.GetAllByType")
+	defer span.End()
+
+	result, err := s.JobStore.GetAllByType(rctx, jobType)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerJobStore) GetAllByTypeAndStatus(rctx request.CTX, jobType string, status string) ([]*model.Job, error) {
+	_, span := tracing.Start(requestContext(rctx), "JobStore.GetAllByTypeAndStatus")
+	defer span.End()
+
+	result, err := s.JobStore.GetAllByTypeAndStatus(rctx, jobType, status)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerJobStore) GetAllByTypePage(rctx request.CTX, jobType string, offset int, limit int) ([]*model.Job, error) {
+	_, span := tracing.Start(requestContext(rctx), "JobStore.GetAllByTypePage")
+	defer span.End()
+
+	result, err := s.JobStore.GetAllByTypePage(rctx, jobType, offset, limit)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerJobStore) GetAllByTypesAndStatusesPage(rctx request.CTX, jobType []string, status []string, offset int, limit int) ([]*model.Job, error) {
+	_, span := tracing.Start(requestContext(rctx), "JobStore.GetAllByTypesAndStatusesPage")
+	defer span.End()
+
+	result, err := s.JobStore.GetAllByTypesAndStatusesPage(rctx, jobType, status, offset, limit)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerJobStore) GetAllByTypesPage(rctx request.CTX, jobTypes []string, offset int, limit int) ([]*model.Job, error) {
+	_, span := tracing.Start(requestContext(rctx), "JobStore.GetAllByTypesPage")
+	defer span.End()
+
+	result, err := s.JobStore.GetAllByTypesPage(rctx, jobTypes, offset, limit)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerJobStore) GetByTypeAndData(rctx request.CTX, jobType string, data map[string]string, useMaster bool, statuses ...string) ([]*model.Job, error) {
+	_, span := tracing.Start(requestContext(rctx), "JobStore.GetByTypeAndData")
+	defer span.End()
+
+	result, err := s.JobStore.GetByTypeAndData(rctx, jobType, data, useMaster, statuses...)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerJobStore) GetCountByStatusAndType(status string, jobType string) (int64, error) {
+	_, span := tracing.Start(context.Background(), "JobStore.GetCountByStatusAndType")
+	defer span.End()
+
+	result, err := s.JobStore.GetCountByStatusAndType(status, jobType)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerJobStore) GetNewestJobByStatusAndType(status string, jobType string) (*model.Job, error) {
+	_, span := tracing.Start(context.Background(), "JobStore.GetNewestJobByStatusAndType")
+	defer span.End()
+
+	result, err := s.JobStore.GetNewestJobByStatusAndType(status, jobType)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerJobStore) GetNewestJobByStatusesAndType(statuses []string, jobType string) (*model.Job, error) {
+	_, span := tracing.Start(context.Background(), "JobStore.GetNewestJobByStatusesAndType")
+	defer span.End()
+
+	result, err := s.JobStore.GetNewestJobByStatusesAndType(statuses, jobType)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerJobStore) Save(job *model.Job) (*model.Job, error) {
+	_, span := tracing.Start(context.Background(), "JobStore.Save")
+	defer span.End()
+
+	result, err := s.JobStore.Save(job)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerJobStore) SaveOnce(job *model.Job) (*model.Job, error) {
+	_, span := tracing.Start(context.Background(), "JobStore.SaveOnce")
+	defer span.End()
+
+	result, err := s.JobStore.SaveOnce(job)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerJobStore) UpdateOptimistically(job *model.Job, currentStatus string) (bool, error) {
+	_, span := tracing.Start(context.Background(), "JobStore.UpdateOptimistically")
+	defer span.End()
+
+	result, err := s.JobStore.UpdateOptimistically(job, currentStatus)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerJobStore) UpdateStatus(id string, status string) (*model.Job, error) {
+	_, span := tracing.Start(context.Background(), "JobStore.UpdateStatus")
+	defer span.End()
+
+	result, err := s.JobStore.UpdateStatus(id, status)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerJobStore) UpdateStatusOptimistically(id string, currentStatus string, newStatus string) (*model.Job, error) {
+	_, span := tracing.Start(context.Background(), "JobStore.UpdateStatusOptimistically")
+	defer span.End()
+
+	result, err := s.JobStore.UpdateStatusOptimistically(id, currentStatus, newStatus)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerLicenseStore) Get(rctx request.CTX, id string) (*model.LicenseRecord, error) {
+	_, span := tracing.Start(requestContext(rctx), "LicenseStore.Get")
+	defer span.End()
+
+	result, err := s.LicenseStore.Get(rctx, id)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerLicenseStore) GetAll() ([]*model.LicenseRecord, error) {
+	_, span := tracing.Start(context.Background(), "LicenseStore.GetAll")
+	defer span.End()
+
+	result, err := s.LicenseStore.GetAll()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerLicenseStore) Save(license *model.LicenseRecord) error {
+	_, span := tracing.Start(context.Background(), "LicenseStore.Save")
+	defer span.End()
+
+	err := s.LicenseStore.Save(license)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerLinkMetadataStore) Get(url string, timestamp int64) (*model.LinkMetadata, error) {
+	_, span := tracing.Start(context.Background(), "LinkMetadataStore.Get")
+	defer span.End()
+
+	result, err := s.LinkMetadataStore.Get(url, timestamp)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerLinkMetadataStore) Save(linkMetadata *model.LinkMetadata) (*model.LinkMetadata, error) {
+	_, span := tracing.Start(context.Background(), "LinkMetadataStore.Save")
+	defer span.End()
+
+	result, err := s.LinkMetadataStore.Save(linkMetadata)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerNotificationRuleStore) GetEnabledForChannel(channelID string) (map[string][]*model.NotificationRule, error) {
+	_, span := tracing.Start(context.Background(), "NotificationRuleStore.GetEnabledForChannel")
+	defer span.End()
+
+	result, err := s.NotificationRuleStore.GetEnabledForChannel(channelID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerNotificationRuleStore) GetForUser(userID string) ([]*model.NotificationRule, error) {
+	_, span := tracing.Start(context.Background(), "NotificationRuleStore.GetForUser")
+	defer span.End()
+
+	result, err := s.NotificationRuleStore.GetForUser(userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerNotificationRuleStore) PermanentDeleteByUser(userID string) error {
+	_, span := tracing.Start(context.Background(), "NotificationRuleStore.PermanentDeleteByUser")
+	defer span.End()
+
+	err := s.NotificationRuleStore.PermanentDeleteByUser(userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerNotificationRuleStore) ReplaceForUser(userID string, rules []*model.NotificationRule) ([]*model.NotificationRule, error) {
+	_, span := tracing.Start(context.Background(), "NotificationRuleStore.ReplaceForUser")
+	defer span.End()
+
+	result, err := s.NotificationRuleStore.ReplaceForUser(userID, rules)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerNotifyAdminStore) DeleteBefore(trial bool, now int64) error {
+	_, span := tracing.Start(context.Background(), "NotifyAdminStore.DeleteBefore")
+	defer span.End()
+
+	err := s.NotifyAdminStore.DeleteBefore(trial, now)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerNotifyAdminStore) Get(trial bool) ([]*model.NotifyAdminData, error) {
+	_, span := tracing.Start(context.Background(), "NotifyAdminStore.Get")
+	defer span.End()
+
+	result, err := s.NotifyAdminStore.Get(trial)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerNotifyAdminStore) GetDataByUserIdAndFeature(userID string, feature model.MattermostFeature) ([]*model.NotifyAdminData, error) {
+	_, span := tracing.Start(context.Background(), "NotifyAdminStore.GetDataByUserIdAndFeature")
+	defer span.End()
+
+	result, err := s.NotifyAdminStore.GetDataByUserIdAndFeature(userID, feature)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerNotifyAdminStore) Save(data *model.NotifyAdminData) (*model.NotifyAdminData, error) {
+	_, span := tracing.Start(context.Background(), "NotifyAdminStore.Save")
+	defer span.End()
+
+	result, err := s.NotifyAdminStore.Save(data)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerNotifyAdminStore) Update(userID string, requiredPlan string, requiredFeature model.MattermostFeature, now int64) error {
+	_, span := tracing.Start(context.Background(), "NotifyAdminStore.Update")
+	defer span.End()
+
+	err := s.NotifyAdminStore.Update(userID, requiredPlan, requiredFeature, now)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerOAuthStore) DeleteApp(id string) error {
+	_, span := tracing.Start(context.Background(), "OAuthStore.DeleteApp")
+	defer span.End()
+
+	err := s.OAuthStore.DeleteApp(id)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerOAuthStore) GetAccessData(token string) (*model.AccessData, error) {
+	_, span := tracing.Start(context.Background(), "OAuthStore.GetAccessData")
+	defer span.End()
+
+	result, err := s.OAuthStore.GetAccessData(token)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerOAuthStore) GetAccessDataByRefreshToken(token string) (*model.AccessData, error) {
+	_, span := tracing.Start(context.Background(), "OAuthStore.GetAccessDataByRefreshToken")
+	defer span.End()
+
+	result, err := s.OAuthStore.GetAccessDataByRefreshToken(token)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerOAuthStore) GetAccessDataByUserForApp(userID string, clientID string) ([]*model.AccessData, error) {
+	_, span := tracing.Start(context.Background(), "OAuthStore.GetAccessDataByUserForApp")
+	defer span.End()
+
+	result, err := s.OAuthStore.GetAccessDataByUserForApp(userID, clientID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerOAuthStore) GetApp(id string) (*model.OAuthApp, error) {
+	_, span := tracing.Start(context.Background(), "OAuthStore.GetApp")
+	defer span.End()
+
+	result, err := s.OAuthStore.GetApp(id)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerOAuthStore) GetAppByUser(userID string, offset int, limit int) ([]*model.OAuthApp, error) {
+	_, span := tracing.Start(context.Background(), "OAuthStore.GetAppByUser")
+	defer span.End()
+
+	result, err := s.OAuthStore.GetAppByUser(userID, offset, limit)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerOAuthStore) GetApps(offset int, limit int) ([]*model.OAuthApp, error) {
+	_, span := tracing.Start(context.Background(), "OAuthStore.GetApps")
+	defer span.End()
+
+	result, err := s.OAuthStore.GetApps(offset, limit)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerOAuthStore) GetAuthData(code string) (*model.AuthData, error) {
+	_, span := tracing.Start(context.Background(), "OAuthStore.GetAuthData")
+	defer span.End()
+
+	result, err := s.OAuthStore.GetAuthData(code)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerOAuthStore) GetAuthorizedApps(userID string, offset int, limit int) ([]*model.OAuthApp, error) {
+	_, span := tracing.Start(context.Background(), "OAuthStore.GetAuthorizedApps")
+	defer span.End()
+
+	result, err := s.OAuthStore.GetAuthorizedApps(userID, offset, limit)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerOAuthStore) GetPreviousAccessData(userID string, clientID string) (*model.AccessData, error) {
+	_, span := tracing.Start(context.Background(), "OAuthStore.GetPreviousAccessData")
+	defer span.End()
+
+	result, err := s.OAuthStore.GetPreviousAccessData(userID, clientID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerOAuthStore) PermanentDeleteAuthDataByUser(userID string) error {
+	_, span := tracing.Start(context.Background(), "OAuthStore.PermanentDeleteAuthDataByUser")
+	defer span.End()
+
+	err := s.OAuthStore.PermanentDeleteAuthDataByUser(userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerOAuthStore) RemoveAccessData(token string) error {
+	_, span := tracing.Start(context.Background(), "OAuthStore.RemoveAccessData")
+	defer span.End()
+
+	err := s.OAuthStore.RemoveAccessData(token)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerOAuthStore) RemoveAllAccessData() error {
+	_, span := tracing.Start(context.Background(), "OAuthStore.RemoveAllAccessData")
+	defer span.End()
+
+	err := s.OAuthStore.RemoveAllAccessData()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerOAuthStore) RemoveAuthData(code string) error {
+	_, span := tracing.Start(context.Background(), "OAuthStore.RemoveAuthData")
+	defer span.End()
+
+	err := s.OAuthStore.RemoveAuthData(code)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerOAuthStore) RemoveAuthDataByClientId(clientID string, userID string) error {
+	_, span := tracing.Start(context.Background(), "OAuthStore.RemoveAuthDataByClientId")
+	defer span.End()
+
+	err := s.OAuthStore.RemoveAuthDataByClientId(clientID, userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerOAuthStore) RemoveAuthDataByUserId(userID string) error {
+	_, span := tracing.Start(context.Background(), "OAuthStore.RemoveAuthDataByUserId")
+	defer span.End()
+
+	err := s.OAuthStore.RemoveAuthDataByUserId(userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerOAuthStore) SaveAccessData(accessData *model.AccessData) (*model.AccessData, error) {
+	_, span := tracing.Start(context.Background(), "OAuthStore.SaveAccessData")
+	defer span.End()
+
+	result, err := s.OAuthStore.SaveAccessData(accessData)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerOAuthStore) SaveApp(app *model.OAuthApp) (*model.OAuthApp, error) {
+	_, span := tracing.Start(context.Background(), "OAuthStore.SaveApp")
+	defer span.End()
+
+	result, err := s.OAuthStore.SaveApp(app)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerOAuthStore) SaveAuthData(authData *model.AuthData) (*model.AuthData, error) {
+	_, span := tracing.Start(context.Background(), "OAuthStore.SaveAuthData")
+	defer span.End()
+
+	result, err := s.OAuthStore.SaveAuthData(authData)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerOAuthStore) UpdateAccessData(accessData *model.AccessData) (*model.AccessData, error) {
+	_, span := tracing.Start(context.Background(), "OAuthStore.UpdateAccessData")
+	defer span.End()
+
+	result, err := s.OAuthStore.UpdateAccessData(accessData)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerOAuthStore) UpdateApp(app *model.OAuthApp) (*model.OAuthApp, error) {
+	_, span := tracing.Start(context.Background(), "OAuthStore.UpdateApp")
+	defer span.End()
+
+	result, err := s.OAuthStore.UpdateApp(app)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerOutgoingOAuthConnectionStore) DeleteConnection(rctx request.CTX, id string) error {
+	_, span := tracing.Start(requestContext(rctx), "OutgoingOAuthConnectionStore.DeleteConnection")
+	defer span.End()
+
+	err := s.OutgoingOAuthConnectionStore.DeleteConnection(rctx, id)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerOutgoingOAuthConnectionStore) GetConnection(rctx request.CTX, id string) (*model.OutgoingOAuthConnection, error) {
+	_, span := tracing.Start(requestContext(rctx), "OutgoingOAuthConnectionStore.GetConnection")
+	defer span.End()
+
+	result, err := s.OutgoingOAuthConnectionStore.GetConnection(rctx, id)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerOutgoingOAuthConnectionStore) GetConnections(rctx request.CTX, filters model.OutgoingOAuthConnectionGetConnectionsFilter) ([]*model.OutgoingOAuthConnection, error) {
+	_, span := tracing.Start(requestContext(rctx), "OutgoingOAuthConnectionStore.GetConnections")
+	defer span.End()
+
+	result, err := s.OutgoingOAuthConnectionStore.GetConnections(rctx, filters)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerOutgoingOAuthConnectionStore) SaveConnection(rctx request.CTX, conn *model.OutgoingOAuthConnection) (*model.OutgoingOAuthConnection, error) {
+	_, span := tracing.Start(requestContext(rctx), "OutgoingOAuthConnectionStore.SaveConnection")
+	defer span.End()
+
+	result, err := s.OutgoingOAuthConnectionStore.SaveConnection(rctx, conn)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerOutgoingOAuthConnectionStore) UpdateConnection(rctx request.CTX, conn *model.OutgoingOAuthConnection) (*model.OutgoingOAuthConnection, error) {
+	_, span := tracing.Start(requestContext(rctx), "OutgoingOAuthConnectionStore.UpdateConnection")
+	defer span.End()
+
+	result, err := s.OutgoingOAuthConnectionStore.UpdateConnection(rctx, conn)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerPluginStore) CompareAndDelete(keyVal *model.PluginKeyValue, oldValue []byte) (bool, error) {
+	_, span := tracing.Start(context.Background(), "PluginStore.CompareAndDelete")
+	defer span.End()
+
+	result, err := s.PluginStore.CompareAndDelete(keyVal, oldValue)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerPluginStore) CompareAndSet(keyVal *model.PluginKeyValue, oldValue []byte) (bool, error) {
+	_, span := tracing.Start(context.Background(), "PluginStore.CompareAndSet")
+	defer span.End()
+
+	result, err := s.PluginStore.CompareAndSet(keyVal, oldValue)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerPluginStore) Delete(pluginID string, key string) error {
+	_, span := tracing.Start(context.Background(), "PluginStore.Delete")
+	defer span.End()
+
+	err := s.PluginStore.Delete(pluginID, key)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerPluginStore) DeleteAllExpired() error {
+	_, span := tracing.Start(context.Background(), "PluginStore.DeleteAllExpired")
+	defer span.End()
+
+	err := s.PluginStore.DeleteAllExpired()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerPluginStore) DeleteAllForPlugin(PluginID string) error {
+	_, span := tracing.Start(context.Background(), "PluginStore.DeleteAllForPlugin")
+	defer span.End()
+
+	err := s.PluginStore.DeleteAllForPlugin(PluginID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerPluginStore) Get(pluginID string, key string) (*model.PluginKeyValue, error) {
+	_, span := tracing.Start(context.Background(), "PluginStore.Get")
+	defer span.End()
+
+	result, err := s.PluginStore.Get(pluginID, key)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerPluginStore) List(pluginID string, page int, perPage int) ([]string, error) {
+	_, span := tracing.Start(context.Background(), "PluginStore.List")
+	defer span.End()
+
+	result, err := s.PluginStore.List(pluginID, page, perPage)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerPluginStore) SaveOrUpdate(keyVal *model.PluginKeyValue) (*model.PluginKeyValue, error) {
+	_, span := tracing.Start(context.Background(), "PluginStore.SaveOrUpdate")
+	defer span.End()
+
+	result, err := s.PluginStore.SaveOrUpdate(keyVal)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerPluginStore) SetWithOptions(pluginID string, key string, value []byte, options model.PluginKVSetOptions) (bool, error) {
+	_, span := tracing.Start(context.Background(), "PluginStore.SetWithOptions")
+	defer span.End()
+
+	result, err := s.PluginStore.SetWithOptions(pluginID, key, value, options)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerPostStore) AnalyticsPostCount(options *model.PostCountOptions) (int64, error) {
+	_, span := tracing.Start(context.Background(), "PostStore.AnalyticsPostCount")
+	defer span.End()
+
+	result, err := s.PostStore.AnalyticsPostCount(options)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerPostStore) AnalyticsPostCountByTeam(teamID string) (int64, error) {
+	_, span := tracing.Start(context.Background(), "PostStore.AnalyticsPostCountByTeam")
+	defer span.End()
+
+	result, err := s.PostStore.AnalyticsPostCountByTeam(teamID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerPostStore) AnalyticsPostCountsByDay(options *model.AnalyticsPostCountsOptions) (model.AnalyticsRows, error) {
+	_, span := tracing.Start(context.Background(), "PostStore.AnalyticsPostCountsByDay")
+	defer span.End()
+
+	result, err := s.PostStore.AnalyticsPostCountsByDay(options)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerPostStore) AnalyticsUserCountsWithPostsByDay(teamID string) (model.AnalyticsRows, error) {
+	_, span := tracing.Start(context.Background(), "PostStore.AnalyticsUserCountsWithPostsByDay")
+	defer span.End()
+
+	result, err := s.PostStore.AnalyticsUserCountsWithPostsByDay(teamID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerPostStore) ClearCaches() {
+	_, span := tracing.Start(context.Background(), "PostStore.ClearCaches")
+	defer span.End()
+
+	s.PostStore.ClearCaches()
+}
+
+func (s *TracingLayerPostStore) Delete(rctx request.CTX, postID string, timestamp int64, deleteByID string) error {
+	_, span := tracing.Start(requestContext(rctx), "PostStore.Delete")
+	defer span.End()
+
+	err := s.PostStore.Delete(rctx, postID, timestamp, deleteByID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerPostStore) DeleteAllPostRemindersForPost(postId string) error {
+	_, span := tracing.Start(context.Background(), "PostStore.DeleteAllPostRemindersForPost")
+	defer span.End()
+
+	err := s.PostStore.DeleteAllPostRemindersForPost(postId)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerPostStore) Get(rctx request.CTX, id string, opts model.GetPostsOptions, userID string, sanitizeOptions map[string]bool) (*model.PostList, error) {
+	_, span := tracing.Start(requestContext(rctx), "PostStore.Get")
+	defer span.End()
+
+	result, err := s.PostStore.Get(rctx, id, opts, userID, sanitizeOptions)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerPostStore) GetDirectPostParentsForExportAfter(limit int, afterID string, includeArchivedChannels bool) ([]*model.DirectPostForExport, error) {
+	_, span := tracing.Start(context.Background(), "PostStore.GetDirectPostParentsForExportAfter")
+	defer span.End()
+
+	result, err := s.PostStore.GetDirectPostParentsForExportAfter(limit, afterID, includeArchivedChannels)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerPostStore) GetEditHistoryForPost(postID string) ([]*model.Post, error) {
+	_, span := tracing.Start(context.Background(), "PostStore.GetEditHistoryForPost")
+	defer span.End()
+
+	result, err := s.PostStore.GetEditHistoryForPost(postID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerPostStore) GetEtag(channelID string, allowFromCache bool, collapsedThreads bool) string {
+	_, span := tracing.Start(context.Background(), "PostStore.GetEtag")
+	defer span.End()
+
+	result := s.PostStore.GetEtag(channelID, allowFromCache, collapsedThreads)
+	return result
+}
+
+func (s *TracingLayerPostStore) GetFlaggedPosts(userID string, offset int, limit int) (*model.PostList, error) {
+	_, span := tracing.Start(context.Background(), "PostStore.GetFlaggedPosts")
+	defer span.End()
+
+	result, err := s.PostStore.GetFlaggedPosts(userID, offset, limit)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerPostStore) GetFlaggedPostsForChannel(userID string, channelID string, offset int, limit int) (*model.PostList, error) {
+	_, span := tracing.Start(context.Background(), "PostStore.GetFlaggedPostsForChannel")
+	defer span.End()
+
+	result, err := s.PostStore.GetFlaggedPostsForChannel(userID, channelID, offset, limit)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerPostStore) GetFlaggedPostsForTeam(userID string, teamID string, offset int, limit int) (*model.PostList, error) {
+	_, span := tracing.Start(context.Background(), "PostStore.GetFlaggedPostsForTeam")
+	defer span.End()
+
+	result, err := s.PostStore.GetFlaggedPostsForTeam(userID, teamID, offset, limit)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerPostStore) GetMaxPostSize() int {
+	_, span := tracing.Start(context.Background(), "PostStore.GetMaxPostSize")
+	defer span.End()
+
+	result := s.PostStore.GetMaxPostSize()
+	return result
+}
+
+func (s *TracingLayerPostStore) GetNthRecentPostTime(n int64) (int64, error) {
+	_, span := tracing.Start(context.Background(), "PostStore.GetNthRecentPostTime")
+	defer span.End()
+
+	result, err := s.PostStore.GetNthRecentPostTime(n)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerPostStore) GetOldest() (*model.Post, error) {
+	_, span := tracing.Start(context.Background(), "PostStore.GetOldest")
+	defer span.End()
+
+	result, err := s.PostStore.GetOldest()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerPostStore) GetOldestEntityCreationTime() (int64, error) {
+	_, span := tracing.Start(context.Background(), "PostStore.GetOldestEntityCreationTime")
+	defer span.End()
+
+	result, err := s.PostStore.GetOldestEntityCreationTime()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerPostStore) GetParentsForExportAfter(limit int, afterID string, includeArchivedChannels bool) ([]*model.PostForExport, error) {
+	_, span := tracing.Start(context.Background(), "PostStore.GetParentsForExportAfter")
+	defer span.End()
+
+	result, err := s.PostStore.GetParentsForExportAfter(limit, afterID, includeArchivedChannels)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerPostStore) GetPostAfterTime(channelID string, timestamp int64, collapsedThreads bool) (*model.Post, error) {
+	_, span := tracing.Start(context.Background(), "PostStore.GetPostAfterTime")
+	defer span.End()
+
+	result, err := s.PostStore.GetPostAfterTime(channelID, timestamp, collapsedThreads)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerPostStore) GetPostIdAfterTime(channelID string, timestamp int64, collapsedThreads bool) (string, error) {
+	_, span := tracing.Start(context.Background(), "PostStore.GetPostIdAfterTime")
+	defer span.End()
+
+	result, err := s.PostStore.GetPostIdAfterTime(channelID, timestamp, collapsedThreads)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerPostStore) GetPostIdBeforeTime(channelID string, timestamp int64, collapsedThreads bool) (string, error) {
+	_, span := tracing.Start(context.Background(), "PostStore.GetPostIdBeforeTime")
+	defer span.End()
+
+	result, err := s.PostStore.GetPostIdBeforeTime(channelID, timestamp, collapsedThreads)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerPostStore) GetPostReminderMetadata(postID string) (*store.PostReminderMetadata, error) {
+	_, span := tracing.Start(context.Background(), "PostStore.GetPostReminderMetadata")
+	defer span.End()
+
+	result, err := s.PostStore.GetPostReminderMetadata(postID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerPostStore) GetPostReminders(now int64) ([]*model.PostReminder, error) {
+	_, span := tracing.Start(context.Background(), "PostStore.GetPostReminders")
+	defer span.End()
+
+	result, err := s.PostStore.GetPostReminders(now)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerPostStore) GetPosts(rctx request.CTX, options model.GetPostsOptions, allowFromCache bool, sanitizeOptions map[string]bool) (*model.PostList, error) {
+	_, span := tracing.Start(requestContext(rctx), "PostStore.GetPosts")
+	defer span.End()
+
+	result, err := s.PostStore.GetPosts(rctx, options, allowFromCache, sanitizeOptions)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerPostStore) GetPostsAfter(rctx request.CTX, options model.GetPostsOptions, sanitizeOptions map[string]bool) (*model.PostList, error) {
+	_, span := tracing.Start(requestContext(rctx), "PostStore.GetPostsAfter")
+	defer span.End()
+
+	result, err := s.PostStore.GetPostsAfter(rctx, options, sanitizeOptions)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerPostStore) GetPostsBatchForIndexing(startTime int64, startPostID string, limit int) ([]*model.PostForIndexing, error) {
+	_, span := tracing.Start(context.Background(), "PostStore.GetPostsBatchForIndexing")
+	defer span.End()
+
+	result, err := s.PostStore.GetPostsBatchForIndexing(startTime, startPostID, limit)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerPostStore) GetPostsBefore(rctx request.CTX, options model.GetPostsOptions, sanitizeOptions map[string]bool) (*model.PostList, error) {
+	_, span := tracing.Start(requestContext(rctx), "PostStore.GetPostsBefore")
+	defer span.End()
+
+	result, err := s.PostStore.GetPostsBefore(rctx, options, sanitizeOptions)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerPostStore) GetPostsByIds(postIds []string) ([]*model.Post, error) {
+	_, span := tracing.Start(context.Background(), "PostStore.GetPostsByIds")
+	defer span.End()
+
+	result, err := s.PostStore.GetPostsByIds(postIds)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerPostStore) GetPostsByThread(threadID string, since int64) ([]*model.Post, error) {
+	_, span := tracing.Start(context.Background(), "PostStore.GetPostsByThread")
+	defer span.End()
+
+	result, err := s.PostStore.GetPostsByThread(threadID, since)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerPostStore) GetPostsCreatedAt(channelID string, timestamp int64) ([]*model.Post, error) {
+	_, span := tracing.Start(context.Background(), "PostStore.GetPostsCreatedAt")
+	defer span.End()
+
+	result, err := s.PostStore.GetPostsCreatedAt(channelID, timestamp)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerPostStore) GetPostsForReporting(rctx request.CTX, queryParams model.ReportPostQueryParams) (*model.ReportPostListResponse, error) {
+	_, span := tracing.Start(requestContext(rctx), "PostStore.GetPostsForReporting")
+	defer span.End()
+
+	result, err := s.PostStore.GetPostsForReporting(rctx, queryParams)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerPostStore) GetPostsSince(rctx request.CTX, options model.GetPostsSinceOptions, allowFromCache bool, sanitizeOptions map[string]bool) (*model.PostList, error) {
+	_, span := tracing.Start(requestContext(rctx), "PostStore.GetPostsSince")
+	defer span.End()
+
+	result, err := s.PostStore.GetPostsSince(rctx, options, allowFromCache, sanitizeOptions)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerPostStore) GetPostsSinceForSync(options model.GetPostsSinceForSyncOptions, cursor model.GetPostsSinceForSyncCursor, limit int) ([]*model.Post, model.GetPostsSinceForSyncCursor, error) {
+	_, span := tracing.Start(context.Background(), "PostStore.GetPostsSinceForSync")
+	defer span.End()
+
+	result, resultVar1, err := s.PostStore.GetPostsSinceForSync(options, cursor, limit)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, resultVar1, err
+}
+
+func (s *TracingLayerPostStore) GetRecentPostsForUser(userID string, since int64, limit int) ([]*model.Post, error) {
+	_, span := tracing.Start(context.Background(), "PostStore.GetRecentPostsForUser")
+	defer span.End()
+
+	result, err := s.PostStore.GetRecentPostsForUser(userID, since, limit)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerPostStore) GetRepliesForExport(parentID string) ([]*model.ReplyForExport, error) {
+	_, span := tracing.Start(context.Background(), "PostStore.GetRepliesForExport")
+	defer span.End()
+
+	result, err := s.PostStore.GetRepliesForExport(parentID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerPostStore) GetSingle(rctx request.CTX, id string, inclDeleted bool) (*model.Post, error) {
+	_, span := tracing.Start(requestContext(rctx), "PostStore.GetSingle")
+	defer span.End()
+
+	result, err := s.PostStore.GetSingle(rctx, id, inclDeleted)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerPostStore) HasAutoResponsePostByUserSince(options model.GetPostsSinceOptions, userID string) (bool, error) {
+	_, span := tracing.Start(context.Background(), "PostStore.HasAutoResponsePostByUserSince")
+	defer span.End()
+
+	result, err := s.PostStore.HasAutoResponsePostByUserSince(options, userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerPostStore) InvalidateLastPostTimeCache(channelID string) {
+	_, span := tracing.Start(context.Background(), "PostStore.InvalidateLastPostTimeCache")
+	defer span.End()
+
+	s.PostStore.InvalidateLastPostTimeCache(channelID)
+}
+
+func (s *TracingLayerPostStore) Overwrite(rctx request.CTX, post *model.Post) (*model.Post, error) {
+	_, span := tracing.Start(requestContext(rctx), "PostStore.Overwrite")
+	defer span.End()
+
+	result, err := s.PostStore.Overwrite(rctx, post)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerPostStore) OverwriteMultiple(rctx request.CTX, posts []*model.Post) ([]*model.Post, int, error) {
+	_, span := tracing.Start(requestContext(rctx), "PostStore.OverwriteMultiple")
+	defer span.End()
+
+	result, resultVar1, err := s.PostStore.OverwriteMultiple(rctx, posts)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, resultVar1, err
+}
+
+func (s *TracingLayerPostStore) PermanentDelete(rctx request.CTX, postID string) error {
+	_, span := tracing.Start(requestContext(rctx), "PostStore.PermanentDelete")
+	defer span.End()
+
+	err := s.PostStore.PermanentDelete(rctx, postID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerPostStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, error) {
+	_, span := tracing.Start(context.Background(), "PostStore.PermanentDeleteBatch")
+	defer span.End()
+
+	result, err := s.PostStore.PermanentDeleteBatch(endTime, limit)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerPostStore) PermanentDeleteBatchForRetentionPolicies(retentionPolicyBatchConfigs model.RetentionPolicyBatchConfigs, cursor model.RetentionPolicyCursor) (int64, model.RetentionPolicyCursor, error) {
+	_, span := tracing.Start(context.Background(), "PostStore.PermanentDeleteBatchForRetentionPolicies")
+	defer span.End()
+
+	result, resultVar1, err := s.PostStore.PermanentDeleteBatchForRetentionPolicies(retentionPolicyBatchConfigs, cursor)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, resultVar1, err
+}
+
+func (s *TracingLayerPostStore) PermanentDeleteByChannel(rctx request.CTX, channelID string) error {
+	_, span := tracing.Start(requestContext(rctx), "PostStore.PermanentDeleteByChannel")
+	defer span.End()
+
+	err := s.PostStore.PermanentDeleteByChannel(rctx, channelID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerPostStore) PermanentDeleteByUser(rctx request.CTX, userID string) error {
+	_, span := tracing.Start(requestContext(rctx), "PostStore.PermanentDeleteByUser")
+	defer span.End()
+
+	err := s.PostStore.PermanentDeleteByUser(rctx, userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerPostStore) RefreshPostStats() error {
+	_, span := tracing.Start(context.Background(), "PostStore.RefreshPostStats")
+	defer span.End()
+
+	err := s.PostStore.RefreshPostStats()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerPostStore) RestoreContentFlaggedPost(post *model.Post, statusFieldId string, contentFlaggingManagedFieldId string) error {
+	_, span := tracing.Start(context.Background(), "PostStore.RestoreContentFlaggedPost")
+	defer span.End()
+
+	err := s.PostStore.RestoreContentFlaggedPost(post, statusFieldId, contentFlaggingManagedFieldId)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerPostStore) Save(rctx request.CTX, post *model.Post) (*model.Post, error) {
+	_, span := tracing.Start(requestContext(rctx), "PostStore.Save")
+	defer span.End()
+
+	result, err := s.PostStore.Save(rctx, post)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerPostStore) SaveMultiple(rctx request.CTX, posts []*model.Post) ([]*model.Post, int, error) {
+	_, span := tracing.Start(requestContext(rctx), "PostStore.SaveMultiple")
+	defer span.End()
+
+	result, resultVar1, err := s.PostStore.SaveMultiple(rctx, posts)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, resultVar1, err
+}
+
+func (s *TracingLayerPostStore) Search(teamID string, userID string, params *model.SearchParams) (*model.PostList, error) {
+	_, span := tracing.Start(context.Background(), "PostStore.Search")
+	defer span.End()
+
+	result, err := s.PostStore.Search(teamID, userID, params)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerPostStore) SearchPostsForUser(rctx request.CTX, paramsList []*model.SearchParams, userID string, teamID string, page int, perPage int) (*model.PostSearchResults, error) {
+	_, span := tracing.Start(requestContext(rctx), "PostStore.SearchPostsForUser")
+	defer span.End()
+
+	result, err := s.PostStore.SearchPostsForUser(rctx, paramsList, userID, teamID, page, perPage)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerPostStore) SetPostReminder(reminder *model.PostReminder) error {
+	_, span := tracing.Start(context.Background(), "PostStore.SetPostReminder")
+	defer span.End()
+
+	err := s.PostStore.SetPostReminder(reminder)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerPostStore) Update(rctx request.CTX, newPost *model.Post, oldPost *model.Post) (*model.Post, error) {
+	_, span := tracing.Start(requestContext(rctx), "PostStore.Update")
+	defer span.End()
+
+	result, err := s.PostStore.Update(rctx, newPost, oldPost)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerPostAcknowledgementStore) BatchDelete(acknowledgements []*model.PostAcknowledgement) error {
+	_, span := tracing.Start(context.Background(), "PostAcknowledgementStore.BatchDelete")
+	defer span.End()
+
+	err := s.PostAcknowledgementStore.BatchDelete(acknowledgements)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerPostAcknowledgementStore) BatchSave(acknowledgements []*model.PostAcknowledgement) ([]*model.PostAcknowledgement, error) {
+	_, span := tracing.Start(context.Background(), "PostAcknowledgementStore.BatchSave")
+	defer span.End()
+
+	result, err := s.PostAcknowledgementStore.BatchSave(acknowledgements)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerPostAcknowledgementStore) Delete(acknowledgement *model.PostAcknowledgement) error {
+	_, span := tracing.Start(context.Background(), "PostAcknowledgementStore.Delete")
+	defer span.End()
+
+	err := s.PostAcknowledgementStore.Delete(acknowledgement)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerPostAcknowledgementStore) DeleteAllForPost(postID string) error {
+	_, span := tracing.Start(context.Background(), "PostAcknowledgementStore.DeleteAllForPost")
+	defer span.End()
+
+	err := s.PostAcknowledgementStore.DeleteAllForPost(postID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerPostAcknowledgementStore) Get(postID string, userID string) (*model.PostAcknowledgement, error) {
+	_, span := tracing.Start(context.Background(), "PostAcknowledgementStore.Get")
+	defer span.End()
+
+	result, err := s.PostAcknowledgementStore.Get(postID, userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerPostAcknowledgementStore) GetForPost(postID string) ([]*model.PostAcknowledgement, error) {
+	_, span := tracing.Start(context.Background(), "PostAcknowledgementStore.GetForPost")
+	defer span.End()
+
+	result, err := s.PostAcknowledgementStore.GetForPost(postID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerPostAcknowledgementStore) GetForPostSince(postID string, since int64, excludeRemoteID string, inclDeleted bool) ([]*model.PostAcknowledgement, error) {
+	_, span := tracing.Start(context.Background(), "PostAcknowledgementStore.GetForPostSince")
+	defer span.End()
+
+	result, err := s.PostAcknowledgementStore.GetForPostSince(postID, since, excludeRemoteID, inclDeleted)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerPostAcknowledgementStore) GetForPosts(postIds []string) ([]*model.PostAcknowledgement, error) {
+	_, span := tracing.Start(context.Background(), "PostAcknowledgementStore.GetForPosts")
+	defer span.End()
+
+	result, err := s.PostAcknowledgementStore.GetForPosts(postIds)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerPostAcknowledgementStore) GetSingle(userID string, postID string, remoteID string) (*model.PostAcknowledgement, error) {
+	_, span := tracing.Start(context.Background(), "PostAcknowledgementStore.GetSingle")
+	defer span.End()
+
+	result, err := s.PostAcknowledgementStore.GetSingle(userID, postID, remoteID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerPostAcknowledgementStore) SaveWithModel(acknowledgement *model.PostAcknowledgement) (*model.PostAcknowledgement, error) {
+	_, span := tracing.Start(context.Background(), "PostAcknowledgementStore.SaveWithModel")
+	defer span.End()
+
+	result, err := s.PostAcknowledgementStore.SaveWithModel(acknowledgement)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerPostPersistentNotificationStore) Delete(postIds []string) error {
+	_, span := tracing.Start(context.Background(), "PostPersistentNotificationStore.Delete")
+	defer span.End()
+
+	err := s.PostPersistentNotificationStore.Delete(postIds)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerPostPersistentNotificationStore) DeleteByChannel(channelIds []string) error {
+	_, span := tracing.Start(context.Background(), "PostPersistentNotificationStore.DeleteByChannel")
+	defer span.End()
+
+	err := s.PostPersistentNotificationStore.DeleteByChannel(channelIds)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerPostPersistentNotificationStore) DeleteByTeam(teamIds []string) error {
+	_, span := tracing.Start(context.Background(), "PostPersistentNotificationStore.DeleteByTeam")
+	defer span.End()
+
+	err := s.PostPersistentNotificationStore.DeleteByTeam(teamIds)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerPostPersistentNotificationStore) DeleteExpired(maxSentCount int16) error {
+	_, span := tracing.Start(context.Background(), "PostPersistentNotificationStore.DeleteExpired")
+	defer span.End()
+
+	err := s.PostPersistentNotificationStore.DeleteExpired(maxSentCount)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerPostPersistentNotificationStore) Get(params model.GetPersistentNotificationsPostsParams) ([]*model.PostPersistentNotifications, error) {
+	_, span := tracing.Start(context.Background(), "PostPersistentNotificationStore.Get")
+	defer span.End()
+
+	result, err := s.PostPersistentNotificationStore.Get(params)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerPostPersistentNotificationStore) GetSingle(postID string) (*model.PostPersistentNotifications, error) {
+	_, span := tracing.Start(context.Background(), "PostPersistentNotificationStore.GetSingle")
+	defer span.End()
+
+	result, err := s.PostPersistentNotificationStore.GetSingle(postID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerPostPersistentNotificationStore) UpdateLastActivity(postIds []string) error {
+	_, span := tracing.Start(context.Background(), "PostPersistentNotificationStore.UpdateLastActivity")
+	defer span.End()
+
+	err := s.PostPersistentNotificationStore.UpdateLastActivity(postIds)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerPostPriorityStore) Delete(postID string) error {
+	_, span := tracing.Start(context.Background(), "PostPriorityStore.Delete")
+	defer span.End()
+
+	err := s.PostPriorityStore.Delete(postID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerPostPriorityStore) GetForPost(postID string) (*model.PostPriority, error) {
+	_, span := tracing.Start(context.Background(), "PostPriorityStore.GetForPost")
+	defer span.End()
+
+	result, err := s.PostPriorityStore.GetForPost(postID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerPostPriorityStore) GetForPosts(ids []string) ([]*model.PostPriority, error) {
+	_, span := tracing.Start(context.Background(), "PostPriorityStore.GetForPosts")
+	defer span.End()
+
+	result, err := s.PostPriorityStore.GetForPosts(ids)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerPostPriorityStore) Save(priority *model.PostPriority) (*model.PostPriority, error) {
+	_, span := tracing.Start(context.Background(), "PostPriorityStore.Save")
+	defer span.End()
+
+	result, err := s.PostPriorityStore.Save(priority)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerPreferenceStore) CleanupFlagsBatch(limit int64) (int64, error) {
+	_, span := tracing.Start(context.Background(), "PreferenceStore.CleanupFlagsBatch")
+	defer span.End()
+
+	result, err := s.PreferenceStore.CleanupFlagsBatch(limit)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerPreferenceStore) Delete(userID string, category string, name string) error {
+	_, span := tracing.Start(context.Background(), "PreferenceStore.Delete")
+	defer span.End()
+
+	err := s.PreferenceStore.Delete(userID, category, name)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerPreferenceStore) DeleteCategory(userID string, category string) error {
+	_, span := tracing.Start(context.Background(), "PreferenceStore.DeleteCategory")
+	defer span.End()
+
+	err := s.PreferenceStore.DeleteCategory(userID, category)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerPreferenceStore) DeleteCategoryAndName(category string, name string) error {
+	_, span := tracing.Start(context.Background(), "PreferenceStore.DeleteCategoryAndName")
+	defer span.End()
+
+	err := s.PreferenceStore.DeleteCategoryAndName(category, name)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerPreferenceStore) DeleteInvalidVisibleDmsGms() (int64, error) {
+	_, span := tracing.Start(context.Background(), "PreferenceStore.DeleteInvalidVisibleDmsGms")
+	defer span.End()
+
+	result, err := s.PreferenceStore.DeleteInvalidVisibleDmsGms()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerPreferenceStore) DeleteOrphanedRows(limit int) (int64, error) {
+	_, span := tracing.Start(context.Background(), "PreferenceStore.DeleteOrphanedRows")
+	defer span.End()
+
+	result, err := s.PreferenceStore.DeleteOrphanedRows(limit)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerPreferenceStore) Get(userID string, category string, name string) (*model.Preference, error) {
+	_, span := tracing.Start(context.Background(), "PreferenceStore.Get")
+	defer span.End()
+
+	result, err := s.PreferenceStore.Get(userID, category, name)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerPreferenceStore) GetAll(userID string) (model.Preferences, error) {
+	_, span := tracing.Start(context.Background(), "PreferenceStore.GetAll")
+	defer span.End()
+
+	result, err := s.PreferenceStore.GetAll(userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerPreferenceStore) GetCategory(userID string, category string) (model.Preferences, error) {
+	_, span := tracing.Start(context.Background(), "PreferenceStore.GetCategory")
+	defer span.End()
+
+	result, err := s.PreferenceStore.GetCategory(userID, category)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerPreferenceStore) GetCategoryAndName(category string, name string) (model.Preferences, error) {
+	_, span := tracing.Start(context.Background(), "PreferenceStore.GetCategoryAndName")
+	defer span.End()
+
+	result, err := s.PreferenceStore.GetCategoryAndName(category, name)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerPreferenceStore) PermanentDeleteByUser(userID string) error {
+	_, span := tracing.Start(context.Background(), "PreferenceStore.PermanentDeleteByUser")
+	defer span.End()
+
+	err := s.PreferenceStore.PermanentDeleteByUser(userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerPreferenceStore) Save(preferences model.Preferences) error {
+	_, span := tracing.Start(context.Background(), "PreferenceStore.Save")
+	defer span.End()
+
+	err := s.PreferenceStore.Save(preferences)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerProductNoticesStore) Clear(notices []string) error {
+	_, span := tracing.Start(context.Background(), "ProductNoticesStore.Clear")
+	defer span.End()
+
+	err := s.ProductNoticesStore.Clear(notices)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerProductNoticesStore) ClearOldNotices(currentNotices model.ProductNotices) error {
+	_, span := tracing.Start(context.Background(), "ProductNoticesStore.ClearOldNotices")
+	defer span.End()
+
+	err := s.ProductNoticesStore.ClearOldNotices(currentNotices)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerProductNoticesStore) GetViews(userID string) ([]model.ProductNoticeViewState, error) {
+	_, span := tracing.Start(context.Background(), "ProductNoticesStore.GetViews")
+	defer span.End()
+
+	result, err := s.ProductNoticesStore.GetViews(userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerProductNoticesStore) View(userID string, notices []string) error {
+	_, span := tracing.Start(context.Background(), "ProductNoticesStore.View")
+	defer span.End()
+
+	err := s.ProductNoticesStore.View(userID, notices)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerPropertyFieldStore) CountForGroup(groupID string, includeDeleted bool) (int64, error) {
+	_, span := tracing.Start(context.Background(), "PropertyFieldStore.CountForGroup")
+	defer span.End()
+
+	result, err := s.PropertyFieldStore.CountForGroup(groupID, includeDeleted)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerPropertyFieldStore) CountForTarget(groupID string, targetType string, targetID string, includeDeleted bool) (int64, error) {
+	_, span := tracing.Start(context.Background(), "PropertyFieldStore.CountForTarget")
+	defer span.End()
+
+	result, err := s.PropertyFieldStore.CountForTarget(groupID, targetType, targetID, includeDeleted)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerPropertyFieldStore) Create(field *model.PropertyField) (*model.PropertyField, error) {
+	_, span := tracing.Start(context.Background(), "PropertyFieldStore.Create")
+	defer span.End()
+
+	result, err := s.PropertyFieldStore.Create(field)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerPropertyFieldStore) Delete(groupID string, id string) error {
+	_, span := tracing.Start(context.Background(), "PropertyFieldStore.Delete")
+	defer span.End()
+
+	err := s.PropertyFieldStore.Delete(groupID, id)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerPropertyFieldStore) Get(groupID string, id string) (*model.PropertyField, error) {
+	_, span := tracing.Start(context.Background(), "PropertyFieldStore.Get")
+	defer span.End()
+
+	result, err := s.PropertyFieldStore.Get(groupID, id)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerPropertyFieldStore) GetFieldByName(groupID string, targetID string, name string) (*model.PropertyField, error) {
+	_, span := tracing.Start(context.Background(), "PropertyFieldStore.GetFieldByName")
+	defer span.End()
+
+	result, err := s.PropertyFieldStore.GetFieldByName(groupID, targetID, name)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerPropertyFieldStore) GetMany(groupID string, ids []string) ([]*model.PropertyField, error) {
+	_, span := tracing.Start(context.Background(), "PropertyFieldStore.GetMany")
+	defer span.End()
+
+	result, err := s.PropertyFieldStore.GetMany(groupID, ids)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerPropertyFieldStore) SearchPropertyFields(opts model.PropertyFieldSearchOpts) ([]*model.PropertyField, error) {
+	_, span := tracing.Start(context.Background(), "PropertyFieldStore.SearchPropertyFields")
+	defer span.End()
+
+	result, err := s.PropertyFieldStore.SearchPropertyFields(opts)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerPropertyFieldStore) Update(groupID string, fields []*model.PropertyField) ([]*model.PropertyField, error) {
+	_, span := tracing.Start(context.Background(), "PropertyFieldStore.Update")
+	defer span.End()
+
+	result, err := s.PropertyFieldStore.Update(groupID, fields)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerPropertyGroupStore) Get(name string) (*model.PropertyGroup, error) {
+	_, span := tracing.Start(context.Background(), "PropertyGroupStore.Get")
+	defer span.End()
+
+	result, err := s.PropertyGroupStore.Get(name)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerPropertyGroupStore) Register(name string) (*model.PropertyGroup, error) {
+	_, span := tracing.Start(context.Background(), "PropertyGroupStore.Register")
+	defer span.End()
+
+	result, err := s.PropertyGroupStore.Register(name)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerPropertyValueStore) Create(value *model.PropertyValue) (*model.PropertyValue, error) {
+	_, span := tracing.Start(context.Background(), "PropertyValueStore.Create")
+	defer span.End()
+
+	result, err := s.PropertyValueStore.Create(value)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerPropertyValueStore) CreateMany(values []*model.PropertyValue) ([]*model.PropertyValue, error) {
+	_, span := tracing.Start(context.Background(), "PropertyValueStore.CreateMany")
+	defer span.End()
+
+	result, err := s.PropertyValueStore.CreateMany(values)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerPropertyValueStore) Delete(groupID string, id string) error {
+	_, span := tracing.Start(context.Background(), "PropertyValueStore.Delete")
+	defer span.End()
+
+	err := s.PropertyValueStore.Delete(groupID, id)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerPropertyValueStore) DeleteForField(groupID string, fieldID string) error {
+	_, span := tracing.Start(context.Background(), "PropertyValueStore.DeleteForField")
+	defer span.End()
+
+	err := s.PropertyValueStore.DeleteForField(groupID, fieldID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerPropertyValueStore) DeleteForTarget(groupID string, targetType string, targetID string) error {
+	_, span := tracing.Start(context.Background(), "PropertyValueStore.DeleteForTarget")
+	defer span.End()
+
+	err := s.PropertyValueStore.DeleteForTarget(groupID, targetType, targetID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerPropertyValueStore) Get(groupID string, id string) (*model.PropertyValue, error) {
+	_, span := tracing.Start(context.Background(), "PropertyValueStore.Get")
+	defer span.End()
+
+	result, err := s.PropertyValueStore.Get(groupID, id)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerPropertyValueStore) GetMany(groupID string, ids []string) ([]*model.PropertyValue, error) {
+	_, span := tracing.Start(context.Background(), "PropertyValueStore.GetMany")
+	defer span.End()
+
+	result, err := s.PropertyValueStore.GetMany(groupID, ids)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerPropertyValueStore) SearchPropertyValues(opts model.PropertyValueSearchOpts) ([]*model.PropertyValue, error) {
+	_, span := tracing.Start(context.Background(), "PropertyValueStore.SearchPropertyValues")
+	defer span.End()
+
+	result, err := s.PropertyValueStore.SearchPropertyValues(opts)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerPropertyValueStore) Update(groupID string, values []*model.PropertyValue) ([]*model.PropertyValue, error) {
+	_, span := tracing.Start(context.Background(), "PropertyValueStore.Update")
+	defer span.End()
+
+	result, err := s.PropertyValueStore.Update(groupID, values)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerPropertyValueStore) Upsert(values []*model.PropertyValue) ([]*model.PropertyValue, error) {
+	_, span := tracing.Start(context.Background(), "PropertyValueStore.Upsert")
+	defer span.End()
+
+	result, err := s.PropertyValueStore.Upsert(values)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerReactionStore) BulkGetForPosts(postIds []string) ([]*model.Reaction, error) {
+	_, span := tracing.Start(context.Background(), "ReactionStore.BulkGetForPosts")
+	defer span.End()
+
+	result, err := s.ReactionStore.BulkGetForPosts(postIds)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerReactionStore) Delete(reaction *model.Reaction) (*model.Reaction, error) {
+	_, span := tracing.Start(context.Background(), "ReactionStore.Delete")
+	defer span.End()
+
+	result, err := s.ReactionStore.Delete(reaction)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerReactionStore) DeleteAllWithEmojiName(emojiName string) error {
+	_, span := tracing.Start(context.Background(), "ReactionStore.DeleteAllWithEmojiName")
+	defer span.End()
+
+	err := s.ReactionStore.DeleteAllWithEmojiName(emojiName)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerReactionStore) DeleteOrphanedRowsByIds(r *model.RetentionIdsForDeletion) (int64, error) {
+	_, span := tracing.Start(context.Background(), "ReactionStore.DeleteOrphanedRowsByIds")
+	defer span.End()
+
+	result, err := s.ReactionStore.DeleteOrphanedRowsByIds(r)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerReactionStore) ExistsOnPost(postID string, emojiName string) (bool, error) {
+	_, span := tracing.Start(context.Background(), "ReactionStore.ExistsOnPost")
+	defer span.End()
+
+	result, err := s.ReactionStore.ExistsOnPost(postID, emojiName)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerReactionStore) GetForPost(postID string, allowFromCache bool) ([]*model.Reaction, error) {
+	_, span := tracing.Start(context.Background(), "ReactionStore.GetForPost")
+	defer span.End()
+
+	result, err := s.ReactionStore.GetForPost(postID, allowFromCache)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerReactionStore) GetForPostSince(postID string, since int64, excludeRemoteID string, inclDeleted bool) ([]*model.Reaction, error) {
+	_, span := tracing.Start(context.Background(), "ReactionStore.GetForPostSince")
+	defer span.End()
+
+	result, err := s.ReactionStore.GetForPostSince(postID, since, excludeRemoteID, inclDeleted)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerReactionStore) GetSingle(userID string, postID string, remoteID string, emojiName string) (*model.Reaction, error) {
+	_, span := tracing.Start(context.Background(), "ReactionStore.GetSingle")
+	defer span.End()
+
+	result, err := s.ReactionStore.GetSingle(userID, postID, remoteID, emojiName)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerReactionStore) GetUniqueCountForPost(postID string) (int, error) {
+	_, span := tracing.Start(context.Background(), "ReactionStore.GetUniqueCountForPost")
+	defer span.End()
+
+	result, err := s.ReactionStore.GetUniqueCountForPost(postID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerReactionStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, error) {
+	_, span := tracing.Start(context.Background(), "ReactionStore.PermanentDeleteBatch")
+	defer span.End()
+
+	result, err := s.ReactionStore.PermanentDeleteBatch(endTime, limit)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerReactionStore) PermanentDeleteByUser(userID string) error {
+	_, span := tracing.Start(context.Background(), "ReactionStore.PermanentDeleteByUser")
+	defer span.End()
+
+	err := s.ReactionStore.PermanentDeleteByUser(userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerReactionStore) Save(reaction *model.Reaction) (*model.Reaction, error) {
+	_, span := tracing.Start(context.Background(), "ReactionStore.Save")
+	defer span.End()
+
+	result, err := s.ReactionStore.Save(reaction)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerRemoteClusterStore) Delete(remoteClusterID string) (bool, error) {
+	_, span := tracing.Start(context.Background(), "RemoteClusterStore.Delete")
+	defer span.End()
+
+	result, err := s.RemoteClusterStore.Delete(remoteClusterID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerRemoteClusterStore) Get(remoteClusterID string, includeDeleted bool) (*model.RemoteCluster, error) {
+	_, span := tracing.Start(context.Background(), "RemoteClusterStore.Get")
+	defer span.End()
+
+	result, err := s.RemoteClusterStore.Get(remoteClusterID, includeDeleted)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerRemoteClusterStore) GetAll(offset int, limit int, filter model.RemoteClusterQueryFilter) ([]*model.RemoteCluster, error) {
+	_, span := tracing.Start(context.Background(), "RemoteClusterStore.GetAll")
+	defer span.End()
+
+	result, err := s.RemoteClusterStore.GetAll(offset, limit, filter)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerRemoteClusterStore) GetByPluginID(pluginID string) (*model.RemoteCluster, error) {
+	_, span := tracing.Start(context.Background(), "RemoteClusterStore.GetByPluginID")
+	defer span.End()
+
+	result, err := s.RemoteClusterStore.GetByPluginID(pluginID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerRemoteClusterStore) Save(rc *model.RemoteCluster) (*model.RemoteCluster, error) {
+	_, span := tracing.Start(context.Background(), "RemoteClusterStore.Save")
+	defer span.End()
+
+	result, err := s.RemoteClusterStore.Save(rc)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerRemoteClusterStore) SetLastPingAt(remoteClusterID string) error {
+	_, span := tracing.Start(context.Background(), "RemoteClusterStore.SetLastPingAt")
+	defer span.End()
+
+	err := s.RemoteClusterStore.SetLastPingAt(remoteClusterID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerRemoteClusterStore) Update(rc *model.RemoteCluster) (*model.RemoteCluster, error) {
+	_, span := tracing.Start(context.Background(), "RemoteClusterStore.Update")
+	defer span.End()
+
+	result, err := s.RemoteClusterStore.Update(rc)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerRemoteClusterStore) UpdateLastGlobalUserSyncAt(remoteID string, syncAt int64) error {
+	_, span := tracing.Start(context.Background(), "RemoteClusterStore.UpdateLastGlobalUserSyncAt")
+	defer span.End()
+
+	err := s.RemoteClusterStore.UpdateLastGlobalUserSyncAt(remoteID, syncAt)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerRemoteClusterStore) UpdateTopics(remoteClusterID string, topics string) (*model.RemoteCluster, error) {
+	_, span := tracing.Start(context.Background(), "RemoteClusterStore.UpdateTopics")
+	defer span.End()
+
+	result, err := s.RemoteClusterStore.UpdateTopics(remoteClusterID, topics)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerRetentionPolicyStore) AddChannels(policyID string, channelIds []string) error {
+	_, span := tracing.Start(context.Background(), "RetentionPolicyStore.AddChannels")
+	defer span.End()
+
+	err := s.RetentionPolicyStore.AddChannels(policyID, channelIds)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerRetentionPolicyStore) AddTeams(policyID string, teamIds []string) error {
+	_, span := tracing.Start(context.Background(), "RetentionPolicyStore.AddTeams")
+	defer span.End()
+
+	err := s.RetentionPolicyStore.AddTeams(policyID, teamIds)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerRetentionPolicyStore) Delete(id string) error {
+	_, span := tracing.Start(context.Background(), "RetentionPolicyStore.Delete")
+	defer span.End()
+
+	err := s.RetentionPolicyStore.Delete(id)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerRetentionPolicyStore) DeleteOrphanedRows(limit int) (int64, error) {
+	_, span := tracing.Start(context.Background(), "RetentionPolicyStore.DeleteOrphanedRows")
+	defer span.End()
+
+	result, err := s.RetentionPolicyStore.DeleteOrphanedRows(limit)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerRetentionPolicyStore) Get(id string) (*model.RetentionPolicyWithTeamAndChannelCounts, error) {
+	_, span := tracing.Start(context.Background(), "RetentionPolicyStore.Get")
+	defer span.End()
+
+	result, err := s.RetentionPolicyStore.Get(id)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerRetentionPolicyStore) GetAll(offset int, limit int) ([]*model.RetentionPolicyWithTeamAndChannelCounts, error) {
+	_, span := tracing.Start(context.Background(), "RetentionPolicyStore.GetAll")
+	defer span.End()
+
+	result, err := s.RetentionPolicyStore.GetAll(offset, limit)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerRetentionPolicyStore) GetChannelPoliciesCountForUser(userID string) (int64, error) {
+	_, span := tracing.Start(context.Background(), "RetentionPolicyStore.GetChannelPoliciesCountForUser")
+	defer span.End()
+
+	result, err := s.RetentionPolicyStore.GetChannelPoliciesCountForUser(userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerRetentionPolicyStore) GetChannelPoliciesForUser(userID string, offset int, limit int) ([]*model.RetentionPolicyForChannel, error) {
+	_, span := tracing.Start(context.Background(), "RetentionPolicyStore.GetChannelPoliciesForUser")
+	defer span.End()
+
+	result, err := s.RetentionPolicyStore.GetChannelPoliciesForUser(userID, offset, limit)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerRetentionPolicyStore) GetChannels(policyID string, offset int, limit int) (model.ChannelListWithTeamData, error) {
+	_, span := tracing.Start(context.Background(), "RetentionPolicyStore.GetChannels")
+	defer span.End()
+
+	result, err := s.RetentionPolicyStore.GetChannels(policyID, offset, limit)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerRetentionPolicyStore) GetChannelsCount(policyID string) (int64, error) {
+	_, span := tracing.Start(context.Background(), "RetentionPolicyStore.GetChannelsCount")
+	defer span.End()
+
+	result, err := s.RetentionPolicyStore.GetChannelsCount(policyID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerRetentionPolicyStore) GetCount() (int64, error) {
+	_, span := tracing.Start(context.Background(), "RetentionPolicyStore.GetCount")
+	defer span.End()
+
+	result, err := s.RetentionPolicyStore.GetCount()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerRetentionPolicyStore) GetIdsForDeletionByTableName(tableName string, limit int) ([]*model.RetentionIdsForDeletion, error) {
+	_, span := tracing.Start(context.Background(), "RetentionPolicyStore.GetIdsForDeletionByTableName")
+	defer span.End()
+
+	result, err := s.RetentionPolicyStore.GetIdsForDeletionByTableName(tableName, limit)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerRetentionPolicyStore) GetTeamPoliciesCountForUser(userID string) (int64, error) {
+	_, span := tracing.Start(context.Background(), "RetentionPolicyStore.GetTeamPoliciesCountForUser")
+	defer span.End()
+
+	result, err := s.RetentionPolicyStore.GetTeamPoliciesCountForUser(userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerRetentionPolicyStore) GetTeamPoliciesForUser(userID string, offset int, limit int) ([]*model.RetentionPolicyForTeam, error) {
+	_, span := tracing.Start(context.Background(), "RetentionPolicyStore.GetTeamPoliciesForUser")
+	defer span.End()
+
+	result, err := s.RetentionPolicyStore.GetTeamPoliciesForUser(userID, offset, limit)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerRetentionPolicyStore) GetTeams(policyID string, offset int, limit int) ([]*model.Team, error) {
+	_, span := tracing.Start(context.Background(), "RetentionPolicyStore.GetTeams")
+	defer span.End()
+
+	result, err := s.RetentionPolicyStore.GetTeams(policyID, offset, limit)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerRetentionPolicyStore) GetTeamsCount(policyID string) (int64, error) {
+	_, span := tracing.Start(context.Background(), "RetentionPolicyStore.GetTeamsCount")
+	defer span.End()
+
+	result, err := s.RetentionPolicyStore.GetTeamsCount(policyID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerRetentionPolicyStore) Patch(patch *model.RetentionPolicyWithTeamAndChannelIDs) (*model.RetentionPolicyWithTeamAndChannelCounts, error) {
+	_, span := tracing.Start(context.Background(), "RetentionPolicyStore.Patch")
+	defer span.End()
+
+	result, err := s.RetentionPolicyStore.Patch(patch)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerRetentionPolicyStore) RemoveChannels(policyID string, channelIds []string) error {
+	_, span := tracing.Start(context.Background(), "RetentionPolicyStore.RemoveChannels")
+	defer span.End()
+
+	err := s.RetentionPolicyStore.RemoveChannels(policyID, channelIds)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerRetentionPolicyStore) RemoveTeams(policyID string, teamIds []string) error {
+	_, span := tracing.Start(context.Background(), "RetentionPolicyStore.RemoveTeams")
+	defer span.End()
+
+	err := s.RetentionPolicyStore.RemoveTeams(policyID, teamIds)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerRetentionPolicyStore) Save(policy *model.RetentionPolicyWithTeamAndChannelIDs) (*model.RetentionPolicyWithTeamAndChannelCounts, error) {
+	_, span := tracing.Start(context.Background(), "RetentionPolicyStore.Save")
+	defer span.End()
+
+	result, err := s.RetentionPolicyStore.Save(policy)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerRoleStore) AllChannelSchemeRoles() ([]*model.Role, error) {
+	_, span := tracing.Start(context.Background(), "RoleStore.AllChannelSchemeRoles")
+	defer span.End()
+
+	result, err := s.RoleStore.AllChannelSchemeRoles()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerRoleStore) ChannelHigherScopedPermissions(roleNames []string) (map[string]*model.RolePermissions, error) {
+	_, span := tracing.Start(context.Background(), "RoleStore.ChannelHigherScopedPermissions")
+	defer span.End()
+
+	result, err := s.RoleStore.ChannelHigherScopedPermissions(roleNames)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerRoleStore) ChannelRolesUnderTeamRole(roleName string) ([]*model.Role, error) {
+	_, span := tracing.Start(context.Background(), "RoleStore.ChannelRolesUnderTeamRole")
+	defer span.End()
+
+	result, err := s.RoleStore.ChannelRolesUnderTeamRole(roleName)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerRoleStore) Delete(roleID string) (*model.Role, error) {
+	_, span := tracing.Start(context.Background(), "RoleStore.Delete")
+	defer span.End()
+
+	result, err := s.RoleStore.Delete(roleID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerRoleStore) Get(roleID string) (*model.Role, error) {
+	_, span := tracing.Start(context.Background(), "RoleStore.Get")
+	defer span.End()
+
+	result, err := s.RoleStore.Get(roleID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerRoleStore) GetAll() ([]*model.Role, error) {
+	_, span := tracing.Start(context.Background(), "RoleStore.GetAll")
+	defer span.End()
+
+	result, err := s.RoleStore.GetAll()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerRoleStore) GetByName(ctx context.Context, name string) (*model.Role, error) {
+	_, span := tracing.Start(parentContext(ctx), "RoleStore.GetByName")
+	defer span.End()
+
+	result, err := s.RoleStore.GetByName(ctx, name)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerRoleStore) GetByNames(names []string) ([]*model.Role, error) {
+	_, span := tracing.Start(context.Background(), "RoleStore.GetByNames")
+	defer span.End()
+
+	result, err := s.RoleStore.GetByNames(names)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerRoleStore) PermanentDeleteAll() error {
+	_, span := tracing.Start(context.Background(), "RoleStore.PermanentDeleteAll")
+	defer span.End()
+
+	err := s.RoleStore.PermanentDeleteAll()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerRoleStore) Save(role *model.Role) (*model.Role, error) {
+	_, span := tracing.Start(context.Background(), "RoleStore.Save")
+	defer span.End()
+
+	result, err := s.RoleStore.Save(role)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerScheduledPostStore) CreateScheduledPost(scheduledPost *model.ScheduledPost) (*model.ScheduledPost, error) {
+	_, span := tracing.Start(context.Background(), "ScheduledPostStore.CreateScheduledPost")
+	defer span.End()
+
+	result, err := s.ScheduledPostStore.CreateScheduledPost(scheduledPost)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerScheduledPostStore) Get(scheduledPostId string) (*model.ScheduledPost, error) {
+	_, span := tracing.Start(context.Background(), "ScheduledPostStore.Get")
+	defer span.End()
+
+	result, err := s.ScheduledPostStore.Get(scheduledPostId)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerScheduledPostStore) GetMaxMessageSize() int {
+	_, span := tracing.Start(context.Background(), "ScheduledPostStore.GetMaxMessageSize")
+	defer span.End()
+
+	result := s.ScheduledPostStore.GetMaxMessageSize()
+	return result
+}
+
+func (s *TracingLayerScheduledPostStore) GetPendingScheduledPosts(beforeTime int64, afterTime int64, lastScheduledPostId string, perPage uint64) ([]*model.ScheduledPost, error) {
+	_, span := tracing.Start(context.Background(), "ScheduledPostStore.GetPendingScheduledPosts")
+	defer span.End()
+
+	result, err := s.ScheduledPostStore.GetPendingScheduledPosts(beforeTime, afterTime, lastScheduledPostId, perPage)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerScheduledPostStore) GetScheduledPostsForUser(userId string, teamId string) ([]*model.ScheduledPost, error) {
+	_, span := tracing.Start(context.Background(), "ScheduledPostStore.GetScheduledPostsForUser")
+	defer span.End()
+
+	result, err := s.ScheduledPostStore.GetScheduledPostsForUser(userId, teamId)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerScheduledPostStore) GetStaleRecurringScheduledPosts(beforeTime int64, perPage uint64) ([]*model.ScheduledPost, error) {
+	_, span := tracing.Start(context.Background(), "ScheduledPostStore.GetStaleRecurringScheduledPosts")
+	defer span.End()
+
+	result, err := s.ScheduledPostStore.GetStaleRecurringScheduledPosts(beforeTime, perPage)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerScheduledPostStore) PermanentDeleteByUser(userId string) error {
+	_, span := tracing.Start(context.Background(), "ScheduledPostStore.PermanentDeleteByUser")
+	defer span.End()
+
+	err := s.ScheduledPostStore.PermanentDeleteByUser(userId)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerScheduledPostStore) PermanentlyDeleteScheduledPosts(scheduledPostIDs []string) error {
+	_, span := tracing.Start(context.Background(), "ScheduledPostStore.PermanentlyDeleteScheduledPosts")
+	defer span.End()
+
+	err := s.ScheduledPostStore.PermanentlyDeleteScheduledPosts(scheduledPostIDs)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerScheduledPostStore) UpdateOldScheduledPosts(beforeTime int64) error {
+	_, span := tracing.Start(context.Background(), "ScheduledPostStore.UpdateOldScheduledPosts")
+	defer span.End()
+
+	err := s.ScheduledPostStore.UpdateOldScheduledPosts(beforeTime)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerScheduledPostStore) UpdatedScheduledPost(scheduledPost *model.ScheduledPost) error {
+	_, span := tracing.Start(context.Background(), "ScheduledPostStore.UpdatedScheduledPost")
+	defer span.End()
+
+	err := s.ScheduledPostStore.UpdatedScheduledPost(scheduledPost)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerSchemeStore) CountByScope(scope string) (int64, error) {
+	_, span := tracing.Start(context.Background(), "SchemeStore.CountByScope")
+	defer span.End()
+
+	result, err := s.SchemeStore.CountByScope(scope)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerSchemeStore) CountWithoutPermission(scope string, permissionID string, roleScope model.RoleScope, roleType model.RoleType) (int64, error) {
+	_, span := tracing.Start(context.Background(), "SchemeStore.CountWithoutPermission")
+	defer span.End()
+
+	result, err := s.SchemeStore.CountWithoutPermission(scope, permissionID, roleScope, roleType)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerSchemeStore) Delete(schemeID string) (*model.Scheme, error) {
+	_, span := tracing.Start(context.Background(), "SchemeStore.Delete")
+	defer span.End()
+
+	result, err := s.SchemeStore.Delete(schemeID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerSchemeStore) Get(schemeID string) (*model.Scheme, error) {
+	_, span := tracing.Start(context.Background(), "SchemeStore.Get")
+	defer span.End()
+
+	result, err := s.SchemeStore.Get(schemeID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerSchemeStore) GetAllPage(scope string, offset int, limit int) ([]*model.Scheme, error) {
+	_, span := tracing.Start(context.Background(), "SchemeStore.GetAllPage")
+	defer span.End()
+
+	result, err := s.SchemeStore.GetAllPage(scope, offset, limit)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerSchemeStore) GetByName(schemeName string) (*model.Scheme, error) {
+	_, span := tracing.Start(context.Background(), "SchemeStore.GetByName")
+	defer span.End()
+
+	result, err := s.SchemeStore.GetByName(schemeName)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerSchemeStore) PermanentDeleteAll() error {
+	_, span := tracing.Start(context.Background(), "SchemeStore.PermanentDeleteAll")
+	defer span.End()
+
+	err := s.SchemeStore.PermanentDeleteAll()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerSchemeStore) Save(scheme *model.Scheme) (*model.Scheme, error) {
+	_, span := tracing.Start(context.Background(), "SchemeStore.Save")
+	defer span.End()
+
+	result, err := s.SchemeStore.Save(scheme)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerSessionStore) AnalyticsSessionCount() (int64, error) {
+	_, span := tracing.Start(context.Background(), "SessionStore.AnalyticsSessionCount")
+	defer span.End()
+
+	result, err := s.SessionStore.AnalyticsSessionCount()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerSessionStore) Cleanup(expiryTime int64, batchSize int64) error {
+	_, span := tracing.Start(context.Background(), "SessionStore.Cleanup")
+	defer span.End()
+
+	err := s.SessionStore.Cleanup(expiryTime, batchSize)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerSessionStore) Get(rctx request.CTX, sessionIDOrToken string) (*model.Session, error) {
+	_, span := tracing.Start(requestContext(rctx), "SessionStore.Get")
+	defer span.End()
+
+	result, err := s.SessionStore.Get(rctx, sessionIDOrToken)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerSessionStore) GetLRUSessions(rctx request.CTX, userID string, limit uint64, offset uint64) ([]*model.Session, error) {
+	_, span := tracing.Start(requestContext(rctx), "SessionStore.GetLRUSessions")
+	defer span.End()
+
+	result, err := s.SessionStore.GetLRUSessions(rctx, userID, limit, offset)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerSessionStore) GetMobileSessionMetadata() ([]*model.MobileSessionMetadata, error) {
+	_, span := tracing.Start(context.Background(), "SessionStore.GetMobileSessionMetadata")
+	defer span.End()
+
+	result, err := s.SessionStore.GetMobileSessionMetadata()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerSessionStore) GetSessions(rctx request.CTX, userID string) ([]*model.Session, error) {
+	_, span := tracing.Start(requestContext(rctx), "SessionStore.GetSessions")
+	defer span.End()
+
+	result, err := s.SessionStore.GetSessions(rctx, userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerSessionStore) GetSessionsExpired(thresholdMillis int64, mobileOnly bool, unnotifiedOnly bool) ([]*model.Session, error) {
+	_, span := tracing.Start(context.Background(), "SessionStore.GetSessionsExpired")
+	defer span.End()
+
+	result, err := s.SessionStore.GetSessionsExpired(thresholdMillis, mobileOnly, unnotifiedOnly)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerSessionStore) GetSessionsWithActiveDeviceIds(userID string) ([]*model.Session, error) {
+	_, span := tracing.Start(context.Background(), "SessionStore.GetSessionsWithActiveDeviceIds")
+	defer span.End()
+
+	result, err := s.SessionStore.GetSessionsWithActiveDeviceIds(userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerSessionStore) PermanentDeleteSessionsByUser(teamID string) error {
+	_, span := tracing.Start(context.Background(), "SessionStore.PermanentDeleteSessionsByUser")
+	defer span.End()
+
+	err := s.SessionStore.PermanentDeleteSessionsByUser(teamID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerSessionStore) Remove(sessionIDOrToken string) error {
+	_, span := tracing.Start(context.Background(), "SessionStore.Remove")
+	defer span.End()
+
+	err := s.SessionStore.Remove(sessionIDOrToken)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerSessionStore) RemoveAllSessions() error {
+	_, span := tracing.Start(context.Background(), "SessionStore.RemoveAllSessions")
+	defer span.End()
+
+	err := s.SessionStore.RemoveAllSessions()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerSessionStore) Save(rctx request.CTX, session *model.Session) (*model.Session, error) {
+	_, span := tracing.Start(requestContext(rctx), "SessionStore.Save")
+	defer span.End()
+
+	result, err := s.SessionStore.Save(rctx, session)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerSessionStore) UpdateDeviceId(id string, deviceID string, expiresAt int64) (string, error) {
+	_, span := tracing.Start(context.Background(), "SessionStore.UpdateDeviceId")
+	defer span.End()
+
+	result, err := s.SessionStore.UpdateDeviceId(id, deviceID, expiresAt)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerSessionStore) UpdateExpiredNotify(sessionid string, notified bool) error {
+	_, span := tracing.Start(context.Background(), "SessionStore.UpdateExpiredNotify")
+	defer span.End()
+
+	err := s.SessionStore.UpdateExpiredNotify(sessionid, notified)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerSessionStore) UpdateExpiresAt(sessionID string, timestamp int64) error {
+	_, span := tracing.Start(context.Background(), "SessionStore.UpdateExpiresAt")
+	defer span.End()
+
+	err := s.SessionStore.UpdateExpiresAt(sessionID, timestamp)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerSessionStore) UpdateLastActivityAt(sessionID string, timestamp int64) error {
+	_, span := tracing.Start(context.Background(), "SessionStore.UpdateLastActivityAt")
+	defer span.End()
+
+	err := s.SessionStore.UpdateLastActivityAt(sessionID, timestamp)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerSessionStore) UpdateProps(session *model.Session) error {
+	_, span := tracing.Start(context.Background(), "SessionStore.UpdateProps")
+	defer span.End()
+
+	err := s.SessionStore.UpdateProps(session)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerSessionStore) UpdateRoles(userID string, roles string) (string, error) {
+	_, span := tracing.Start(context.Background(), "SessionStore.UpdateRoles")
+	defer span.End()
+
+	result, err := s.SessionStore.UpdateRoles(userID, roles)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerSharedChannelStore) Delete(channelID string) (bool, error) {
+	_, span := tracing.Start(context.Background(), "SharedChannelStore.Delete")
+	defer span.End()
+
+	result, err := s.SharedChannelStore.Delete(channelID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerSharedChannelStore) DeleteRemote(remoteID string) (bool, error) {
+	_, span := tracing.Start(context.Background(), "SharedChannelStore.DeleteRemote")
+	defer span.End()
+
+	result, err := s.SharedChannelStore.DeleteRemote(remoteID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerSharedChannelStore) Get(channelID string) (*model.SharedChannel, error) {
+	_, span := tracing.Start(context.Background(), "SharedChannelStore.Get")
+	defer span.End()
+
+	result, err := s.SharedChannelStore.Get(channelID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerSharedChannelStore) GetAll(offset int, limit int, opts model.SharedChannelFilterOpts) ([]*model.SharedChannel, error) {
+	_, span := tracing.Start(context.Background(), "SharedChannelStore.GetAll")
+	defer span.End()
+
+	result, err := s.SharedChannelStore.GetAll(offset, limit, opts)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerSharedChannelStore) GetAllCount(opts model.SharedChannelFilterOpts) (int64, error) {
+	_, span := tracing.Start(context.Background(), "SharedChannelStore.GetAllCount")
+	defer span.End()
+
+	result, err := s.SharedChannelStore.GetAllCount(opts)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerSharedChannelStore) GetAttachment(fileID string, remoteID string) (*model.SharedChannelAttachment, error) {
+	_, span := tracing.Start(context.Background(), "SharedChannelStore.GetAttachment")
+	defer span.End()
+
+	result, err := s.SharedChannelStore.GetAttachment(fileID, remoteID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerSharedChannelStore) GetRemote(id string) (*model.SharedChannelRemote, error) {
+	_, span := tracing.Start(context.Background(), "SharedChannelStore.GetRemote")
+	defer span.End()
+
+	result, err := s.SharedChannelStore.GetRemote(id)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerSharedChannelStore) GetRemoteByIds(channelID string, remoteID string) (*model.SharedChannelRemote, error) {
+	_, span := tracing.Start(context.Background(), "SharedChannelStore.GetRemoteByIds")
+	defer span.End()
+
+	result, err := s.SharedChannelStore.GetRemoteByIds(channelID, remoteID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerSharedChannelStore) GetRemoteForUser(remoteID string, userID string) (*model.RemoteCluster, error) {
+	_, span := tracing.Start(context.Background(), "SharedChannelStore.GetRemoteForUser")
+	defer span.End()
+
+	result, err := s.SharedChannelStore.GetRemoteForUser(remoteID, userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerSharedChannelStore) GetRemotes(offset int, limit int, opts model.SharedChannelRemoteFilterOpts) ([]*model.SharedChannelRemote, error) {
+	_, span := tracing.Start(context.Background(), "SharedChannelStore.GetRemotes")
+	defer span.End()
+
+	result, err := s.SharedChannelStore.GetRemotes(offset, limit, opts)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerSharedChannelStore) GetRemotesStatus(channelID string) ([]*model.SharedChannelRemoteStatus, error) {
+	_, span := tracing.Start(context.Background(), "SharedChannelStore.GetRemotesStatus")
+	defer span.End()
+
+	result, err := s.SharedChannelStore.GetRemotesStatus(channelID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerSharedChannelStore) GetSingleUser(userID string, channelID string, remoteID string) (*model.SharedChannelUser, error) {
+	_, span := tracing.Start(context.Background(), "SharedChannelStore.GetSingleUser")
+	defer span.End()
+
+	result, err := s.SharedChannelStore.GetSingleUser(userID, channelID, remoteID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerSharedChannelStore) GetUserChanges(userID string, channelID string, afterTime int64) ([]*model.SharedChannelUser, error) {
+	_, span := tracing.Start(context.Background(), "SharedChannelStore.GetUserChanges")
+	defer span.End()
+
+	result, err := s.SharedChannelStore.GetUserChanges(userID, channelID, afterTime)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerSharedChannelStore) GetUsersForSync(filter model.GetUsersForSyncFilter) ([]*model.User, error) {
+	_, span := tracing.Start(context.Background(), "SharedChannelStore.GetUsersForSync")
+	defer span.End()
+
+	result, err := s.SharedChannelStore.GetUsersForSync(filter)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerSharedChannelStore) GetUsersForUser(userID string) ([]*model.SharedChannelUser, error) {
+	_, span := tracing.Start(context.Background(), "SharedChannelStore.GetUsersForUser")
+	defer span.End()
+
+	result, err := s.SharedChannelStore.GetUsersForUser(userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerSharedChannelStore) HasChannel(channelID string) (bool, error) {
+	_, span := tracing.Start(context.Background(), "SharedChannelStore.HasChannel")
+	defer span.End()
+
+	result, err := s.SharedChannelStore.HasChannel(channelID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerSharedChannelStore) HasRemote(channelID string, remoteID string) (bool, error) {
+	_, span := tracing.Start(context.Background(), "SharedChannelStore.HasRemote")
+	defer span.End()
+
+	result, err := s.SharedChannelStore.HasRemote(channelID, remoteID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerSharedChannelStore) Save(sc *model.SharedChannel) (*model.SharedChannel, error) {
+	_, span := tracing.Start(context.Background(), "SharedChannelStore.Save")
+	defer span.End()
+
+	result, err := s.SharedChannelStore.Save(sc)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerSharedChannelStore) SaveAttachment(remote *model.SharedChannelAttachment) (*model.SharedChannelAttachment, error) {
+	_, span := tracing.Start(context.Background(), "SharedChannelStore.SaveAttachment")
+	defer span.End()
+
+	result, err := s.SharedChannelStore.SaveAttachment(remote)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerSharedChannelStore) SaveRemote(remote *model.SharedChannelRemote) (*model.SharedChannelRemote, error) {
+	_, span := tracing.Start(context.Background(), "SharedChannelStore.SaveRemote")
+	defer span.End()
+
+	result, err := s.SharedChannelStore.SaveRemote(remote)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerSharedChannelStore) SaveUser(remote *model.SharedChannelUser) (*model.SharedChannelUser, error) {
+	_, span := tracing.Start(context.Background(), "SharedChannelStore.SaveUser")
+	defer span.End()
+
+	result, err := s.SharedChannelStore.SaveUser(remote)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerSharedChannelStore) Update(sc *model.SharedChannel) (*model.SharedChannel, error) {
+	_, span := tracing.Start(context.Background(), "SharedChannelStore.Update")
+	defer span.End()
+
+	result, err := s.SharedChannelStore.Update(sc)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerSharedChannelStore) UpdateAttachmentLastSyncAt(id string, syncTime int64) error {
+	_, span := tracing.Start(context.Background(), "SharedChannelStore.UpdateAttachmentLastSyncAt")
+	defer span.End()
+
+	err := s.SharedChannelStore.UpdateAttachmentLastSyncAt(id, syncTime)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerSharedChannelStore) UpdateRemote(remote *model.SharedChannelRemote) (*model.SharedChannelRemote, error) {
+	_, span := tracing.Start(context.Background(), "SharedChannelStore.UpdateRemote")
+	defer span.End()
+
+	result, err := s.SharedChannelStore.UpdateRemote(remote)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerSharedChannelStore) UpdateRemoteCursor(id string, cursor model.GetPostsSinceForSyncCursor) error {
+	_, span := tracing.Start(context.Background(), "SharedChannelStore.UpdateRemoteCursor")
+	defer span.End()
+
+	err := s.SharedChannelStore.UpdateRemoteCursor(id, cursor)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerSharedChannelStore) UpdateRemoteMembershipCursor(id string, syncTime int64) error {
+	_, span := tracing.Start(context.Background(), "SharedChannelStore.UpdateRemoteMembershipCursor")
+	defer span.End()
+
+	err := s.SharedChannelStore.UpdateRemoteMembershipCursor(id, syncTime)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerSharedChannelStore) UpdateUserLastMembershipSyncAt(userID string, channelID string, remoteID string, syncTime int64) error {
+	_, span := tracing.Start(context.Background(), "SharedChannelStore.UpdateUserLastMembershipSyncAt")
+	defer span.End()
+
+	err := s.SharedChannelStore.UpdateUserLastMembershipSyncAt(userID, channelID, remoteID, syncTime)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerSharedChannelStore) UpdateUserLastSyncAt(userID string, channelID string, remoteID string) error {
+	_, span := tracing.Start(context.Background(), "SharedChannelStore.UpdateUserLastSyncAt")
+	defer span.End()
+
+	err := s.SharedChannelStore.UpdateUserLastSyncAt(userID, channelID, remoteID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerSharedChannelStore) UpsertAttachment(remote *model.SharedChannelAttachment) (string, error) {
+	_, span := tracing.Start(context.Background(), "SharedChannelStore.UpsertAttachment")
+	defer span.End()
+
+	result, err := s.SharedChannelStore.UpsertAttachment(remote)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerStatusStore) Get(userID string) (*model.Status, error) {
+	_, span := tracing.Start(context.Background(), "StatusStore.Get")
+	defer span.End()
+
+	result, err := s.StatusStore.Get(userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerStatusStore) GetByIds(userIds []string) ([]*model.Status, error) {
+	_, span := tracing.Start(context.Background(), "StatusStore.GetByIds")
+	defer span.End()
+
+	result, err := s.StatusStore.GetByIds(userIds)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerStatusStore) GetTotalActiveUsersCount() (int64, error) {
+	_, span := tracing.Start(context.Background(), "StatusStore.GetTotalActiveUsersCount")
+	defer span.End()
+
+	result, err := s.StatusStore.GetTotalActiveUsersCount()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerStatusStore) ResetAll() error {
+	_, span := tracing.Start(context.Background(), "StatusStore.ResetAll")
+	defer span.End()
+
+	err := s.StatusStore.ResetAll()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerStatusStore) SaveOrUpdate(status *model.Status) error {
+	_, span := tracing.Start(context.Background(), "StatusStore.SaveOrUpdate")
+	defer span.End()
+
+	err := s.StatusStore.SaveOrUpdate(status)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerStatusStore) SaveOrUpdateMany(statuses map[string]*model.Status) error {
+	_, span := tracing.Start(context.Background(), "StatusStore.SaveOrUpdateMany")
+	defer span.End()
+
+	err := s.StatusStore.SaveOrUpdateMany(statuses)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerStatusStore) UpdateExpiredDNDStatuses() ([]*model.Status, error) {
+	_, span := tracing.Start(context.Background(), "StatusStore.UpdateExpiredDNDStatuses")
+	defer span.End()
+
+	result, err := s.StatusStore.UpdateExpiredDNDStatuses()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerStatusStore) UpdateLastActivityAt(userID string, lastActivityAt int64) error {
+	_, span := tracing.Start(context.Background(), "StatusStore.UpdateLastActivityAt")
+	defer span.End()
+
+	err := s.StatusStore.UpdateLastActivityAt(userID, lastActivityAt)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerSystemStore) Get() (model.StringMap, error) {
+	_, span := tracing.Start(context.Background(), "SystemStore.Get")
+	defer span.End()
+
+	result, err := s.SystemStore.Get()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerSystemStore) GetByName(name string) (*model.System, error) {
+	_, span := tracing.Start(context.Background(), "SystemStore.GetByName")
+	defer span.End()
+
+	result, err := s.SystemStore.GetByName(name)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerSystemStore) InsertIfExists(system *model.System) (*model.System, error) {
+	_, span := tracing.Start(context.Background(), "SystemStore.InsertIfExists")
+	defer span.End()
+
+	result, err := s.SystemStore.InsertIfExists(system)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerSystemStore) PermanentDeleteByName(name string) (*model.System, error) {
+	_, span := tracing.Start(context.Background(), "SystemStore.PermanentDeleteByName")
+	defer span.End()
+
+	result, err := s.SystemStore.PermanentDeleteByName(name)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerSystemStore) Save(system *model.System) error {
+	_, span := tracing.Start(context.Background(), "SystemStore.Save")
+	defer span.End()
+
+	err := s.SystemStore.Save(system)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerSystemStore) SaveOrUpdate(system *model.System) error {
+	_, span := tracing.Start(context.Background(), "SystemStore.SaveOrUpdate")
+	defer span.End()
+
+	err := s.SystemStore.SaveOrUpdate(system)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerSystemStore) Update(system *model.System) error {
+	_, span := tracing.Start(context.Background(), "SystemStore.Update")
+	defer span.End()
+
+	err := s.SystemStore.Update(system)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerTeamStore) AnalyticsGetTeamCountForScheme(schemeID string) (int64, error) {
+	_, span := tracing.Start(context.Background(), "TeamStore.AnalyticsGetTeamCountForScheme")
+	defer span.End()
+
+	result, err := s.TeamStore.AnalyticsGetTeamCountForScheme(schemeID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerTeamStore) AnalyticsTeamCount(opts *model.TeamSearch) (int64, error) {
+	_, span := tracing.Start(context.Background(), "TeamStore.AnalyticsTeamCount")
+	defer span.End()
+
+	result, err := s.TeamStore.AnalyticsTeamCount(opts)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerTeamStore) ClearAllCustomRoleAssignments() error {
+	_, span := tracing.Start(context.Background(), "TeamStore.ClearAllCustomRoleAssignments")
+	defer span.End()
+
+	err := s.TeamStore.ClearAllCustomRoleAssignments()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerTeamStore) ClearCaches() {
+	_, span := tracing.Start(context.Background(), "TeamStore.ClearCaches")
+	defer span.End()
+
+	s.TeamStore.ClearCaches()
+}
+
+func (s *TracingLayerTeamStore) Get(id string) (*model.Team, error) {
+	_, span := tracing.Start(context.Background(), "TeamStore.Get")
+	defer span.End()
+
+	result, err := s.TeamStore.Get(id)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerTeamStore) GetActiveMemberCount(teamID string, restrictions *model.ViewUsersRestrictions) (int64, error) {
+	_, span := tracing.Start(context.Background(), "TeamStore.GetActiveMemberCount")
+	defer span.End()
+
+	result, err := s.TeamStore.GetActiveMemberCount(teamID, restrictions)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerTeamStore) GetAll() ([]*model.Team, error) {
+	_, span := tracing.Start(context.Background(), "TeamStore.GetAll")
+	defer span.End()
+
+	result, err := s.TeamStore.GetAll()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerTeamStore) GetAllForExportAfter(limit int, afterID string) ([]*model.TeamForExport, error) {
+	_, span := tracing.Start(context.Background(), "TeamStore.GetAllForExportAfter")
+	defer span.End()
+
+	result, err := s.TeamStore.GetAllForExportAfter(limit, afterID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerTeamStore) GetAllPage(offset int, limit int, opts *model.TeamSearch) ([]*model.Team, error) {
+	_, span := tracing.Start(context.Background(), "TeamStore.GetAllPage")
+	defer span.End()
+
+	result, err := s.TeamStore.GetAllPage(offset, limit, opts)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerTeamStore) GetAllPrivateTeamListing() ([]*model.Team, error) {
+	_, span := tracing.Start(context.Background(), "TeamStore.GetAllPrivateTeamListing")
+	defer span.End()
+
+	result, err := s.TeamStore.GetAllPrivateTeamListing()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerTeamStore) GetAllTeamListing() ([]*model.Team, error) {
+	_, span := tracing.Start(context.Background(), "TeamStore.GetAllTeamListing")
+	defer span.End()
+
+	result, err := s.TeamStore.GetAllTeamListing()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerTeamStore) GetByEmptyInviteID() ([]*model.Team, error) {
+	_, span := tracing.Start(context.Background(), "TeamStore.GetByEmptyInviteID")
+	defer span.End()
+
+	result, err := s.TeamStore.GetByEmptyInviteID()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerTeamStore) GetByInviteId(inviteID string) (*model.Team, error) {
+	_, span := tracing.Start(context.Background(), "TeamStore.GetByInviteId")
+	defer span.End()
+
+	result, err := s.TeamStore.GetByInviteId(inviteID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerTeamStore) GetByName(name string) (*model.Team, error) {
+	_, span := tracing.Start(context.Background(), "TeamStore.GetByName")
+	defer span.End()
+
+	result, err := s.TeamStore.GetByName(name)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerTeamStore) GetByNames(name []string) ([]*model.Team, error) {
+	_, span := tracing.Start(context.Background(), "TeamStore.GetByNames")
+	defer span.End()
+
+	result, err := s.TeamStore.GetByNames(name)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerTeamStore) GetChannelUnreadsForAllTeams(excludeTeamID string, userID string) ([]*model.ChannelUnread, error) {
+	_, span := tracing.Start(context.Background(), "TeamStore.GetChannelUnreadsForAllTeams")
+	defer span.End()
+
+	result, err := s.TeamStore.GetChannelUnreadsForAllTeams(excludeTeamID, userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerTeamStore) GetChannelUnreadsForTeam(teamID string, userID string) ([]*model.ChannelUnread, error) {
+	_, span := tracing.Start(context.Background(), "TeamStore.GetChannelUnreadsForTeam")
+	defer span.End()
+
+	result, err := s.TeamStore.GetChannelUnreadsForTeam(teamID, userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerTeamStore) GetCommonTeamIDsForMultipleUsers(userIDs []string) ([]string, error) {
+	_, span := tracing.Start(context.Background(), "TeamStore.GetCommonTeamIDsForMultipleUsers")
+	defer span.End()
+
+	result, err := s.TeamStore.GetCommonTeamIDsForMultipleUsers(userIDs)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerTeamStore) GetCommonTeamIDsForTwoUsers(userID string, otherUserID string) ([]string, error) {
+	_, span := tracing.Start(context.Background(), "TeamStore.GetCommonTeamIDsForTwoUsers")
+	defer span.End()
+
+	result, err := s.TeamStore.GetCommonTeamIDsForTwoUsers(userID, otherUserID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerTeamStore) GetMany(ids []string) ([]*model.Team, error) {
+	_, span := tracing.Start(context.Background(), "TeamStore.GetMany")
+	defer span.End()
+
+	result, err := s.TeamStore.GetMany(ids)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerTeamStore) GetMember(rctx request.CTX, teamID string, userID string) (*model.TeamMember, error) {
+	_, span := tracing.Start(requestContext(rctx), "TeamStore.GetMember")
+	defer span.End()
+
+	result, err := s.TeamStore.GetMember(rctx, teamID, userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerTeamStore) GetMembers(teamID string, offset int, limit int, teamMembersGetOptions *model.TeamMembersGetOptions) ([]*model.TeamMember, error) {
+	_, span := tracing.Start(context.Background(), "TeamStore.GetMembers")
+	defer span.End()
+
+	result, err := s.TeamStore.GetMembers(teamID, offset, limit, teamMembersGetOptions)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerTeamStore) GetMembersByIds(teamID string, userIds []string, restrictions *model.ViewUsersRestrictions) ([]*model.TeamMember, error) {
+	_, span := tracing.Start(context.Background(), "TeamStore.GetMembersByIds")
+	defer span.End()
+
+	result, err := s.TeamStore.GetMembersByIds(teamID, userIds, restrictions)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerTeamStore) GetTeamMembersForExport(userID string) ([]*model.TeamMemberForExport, error) {
+	_, span := tracing.Start(context.Background(), "TeamStore.GetTeamMembersForExport")
+	defer span.End()
+
+	result, err := s.TeamStore.GetTeamMembersForExport(userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerTeamStore) GetTeamsByScheme(schemeID string, offset int, limit int) ([]*model.Team, error) {
+	_, span := tracing.Start(context.Background(), "TeamStore.GetTeamsByScheme")
+	defer span.End()
+
+	result, err := s.TeamStore.GetTeamsByScheme(schemeID, offset, limit)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerTeamStore) GetTeamsByUserId(userID string) ([]*model.Team, error) {
+	_, span := tracing.Start(context.Background(), "TeamStore.GetTeamsByUserId")
+	defer span.End()
+
+	result, err := s.TeamStore.GetTeamsByUserId(userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerTeamStore) GetTeamsForUser(rctx request.CTX, userID string, excludeTeamID string, includeDeleted bool) ([]*model.TeamMember, error) {
+	_, span := tracing.Start(requestContext(rctx), "TeamStore.GetTeamsForUser")
+	defer span.End()
+
+	result, err := s.TeamStore.GetTeamsForUser(rctx, userID, excludeTeamID, includeDeleted)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerTeamStore) GetTeamsForUserWithPagination(userID string, page int, perPage int) ([]*model.TeamMember, error) {
+	_, span := tracing.Start(context.Background(), "TeamStore.GetTeamsForUserWithPagination")
+	defer span.End()
+
+	result, err := s.TeamStore.GetTeamsForUserWithPagination(userID, page, perPage)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerTeamStore) GetTotalMemberCount(teamID string, restrictions *model.ViewUsersRestrictions) (int64, error) {
+	_, span := tracing.Start(context.Background(), "TeamStore.GetTotalMemberCount")
+	defer span.End()
+
+	result, err := s.TeamStore.GetTotalMemberCount(teamID, restrictions)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerTeamStore) GetUserTeamIds(userID string, allowFromCache bool) ([]string, error) {
+	_, span := tracing.Start(context.Background(), "TeamStore.GetUserTeamIds")
+	defer span.End()
+
+	result, err := s.TeamStore.GetUserTeamIds(userID, allowFromCache)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerTeamStore) GroupSyncedTeamCount() (int64, error) {
+	_, span := tracing.Start(context.Background(), "TeamStore.GroupSyncedTeamCount")
+	defer span.End()
+
+	result, err := s.TeamStore.GroupSyncedTeamCount()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerTeamStore) InvalidateAllTeamIdsForUser(userID string) {
+	_, span := tracing.Start(context.Background(), "TeamStore.InvalidateAllTeamIdsForUser")
+	defer span.End()
+
+	s.TeamStore.InvalidateAllTeamIdsForUser(userID)
+}
+
+func (s *TracingLayerTeamStore) MigrateTeamMembers(fromTeamID string, fromUserID string) (map[string]string, error) {
+	_, span := tracing.Start(context.Background(), "TeamStore.MigrateTeamMembers")
+	defer span.End()
+
+	result, err := s.TeamStore.MigrateTeamMembers(fromTeamID, fromUserID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerTeamStore) PermanentDelete(teamID string) error {
+	_, span := tracing.Start(context.Background(), "TeamStore.PermanentDelete")
+	defer span.End()
+
+	err := s.TeamStore.PermanentDelete(teamID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerTeamStore) RemoveAllMembersByTeam(teamID string) error {
+	_, span := tracing.Start(context.Background(), "TeamStore.RemoveAllMembersByTeam")
+	defer span.End()
+
+	err := s.TeamStore.RemoveAllMembersByTeam(teamID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerTeamStore) RemoveAllMembersByUser(rctx request.CTX, userID string) error {
+	_, span := tracing.Start(requestContext(rctx), "TeamStore.RemoveAllMembersByUser")
+	defer span.End()
+
+	err := s.TeamStore.RemoveAllMembersByUser(rctx, userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerTeamStore) RemoveMember(rctx request.CTX, teamID string, userID string) error {
+	_, span := tracing.Start(requestContext(rctx), "TeamStore.RemoveMember")
+	defer span.End()
+
+	err := s.TeamStore.RemoveMember(rctx, teamID, userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerTeamStore) RemoveMembers(rctx request.CTX, teamID string, userIds []string) error {
+	_, span := tracing.Start(requestContext(rctx), "TeamStore.RemoveMembers")
+	defer span.End()
+
+	err := s.TeamStore.RemoveMembers(rctx, teamID, userIds)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerTeamStore) ResetAllTeamSchemes() error {
+	_, span := tracing.Start(context.Background(), "TeamStore.ResetAllTeamSchemes")
+	defer span.End()
+
+	err := s.TeamStore.ResetAllTeamSchemes()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerTeamStore) Save(team *model.Team) (*model.Team, error) {
+	_, span := tracing.Start(context.Background(), "TeamStore.Save")
+	defer span.End()
+
+	result, err := s.TeamStore.Save(team)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerTeamStore) SaveMember(rctx request.CTX, member *model.TeamMember, maxUsersPerTeam int) (*model.TeamMember, error) {
+	_, span := tracing.Start(requestContext(rctx), "TeamStore.SaveMember")
+	defer span.End()
+
+	result, err := s.TeamStore.SaveMember(rctx, member, maxUsersPerTeam)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerTeamStore) SaveMultipleMembers(members []*model.TeamMember, maxUsersPerTeam int) ([]*model.TeamMember, error) {
+	_, span := tracing.Start(context.Background(), "TeamStore.SaveMultipleMembers")
+	defer span.End()
+
+	result, err := s.TeamStore.SaveMultipleMembers(members, maxUsersPerTeam)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerTeamStore) SearchAll(opts *model.TeamSearch) ([]*model.Team, error) {
+	_, span := tracing.Start(context.Background(), "TeamStore.SearchAll")
+	defer span.End()
+
+	result, err := s.TeamStore.SearchAll(opts)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerTeamStore) SearchAllPaged(opts *model.TeamSearch) ([]*model.Team, int64, error) {
+	_, span := tracing.Start(context.Background(), "TeamStore.SearchAllPaged")
+	defer span.End()
+
+	result, resultVar1, err := s.TeamStore.SearchAllPaged(opts)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, resultVar1, err
+}
+
+func (s *TracingLayerTeamStore) SearchOpen(opts *model.TeamSearch) ([]*model.Team, error) {
+	_, span := tracing.Start(context.Background(), "TeamStore.SearchOpen")
+	defer span.End()
+
+	result, err := s.TeamStore.SearchOpen(opts)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerTeamStore) SearchPrivate(opts *model.TeamSearch) ([]*model.Team, error) {
+	_, span := tracing.Start(context.Background(), "TeamStore.SearchPrivate")
+	defer span.End()
+
+	result, err := s.TeamStore.SearchPrivate(opts)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerTeamStore) Update(team *model.Team) (*model.Team, error) {
+	_, span := tracing.Start(context.Background(), "TeamStore.Update")
+	defer span.End()
+
+	result, err := s.TeamStore.Update(team)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerTeamStore) UpdateLastTeamIconUpdate(teamID string, curTime int64) error {
+	_, span := tracing.Start(context.Background(), "TeamStore.UpdateLastTeamIconUpdate")
+	defer span.End()
+
+	err := s.TeamStore.UpdateLastTeamIconUpdate(teamID, curTime)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerTeamStore) UpdateMember(rctx request.CTX, member *model.TeamMember) (*model.TeamMember, error) {
+	_, span := tracing.Start(requestContext(rctx), "TeamStore.UpdateMember")
+	defer span.End()
+
+	result, err := s.TeamStore.UpdateMember(rctx, member)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerTeamStore) UpdateMembersRole(teamID string, adminIDs []string) ([]*model.TeamMember, error) {
+	_, span := tracing.Start(context.Background(), "TeamStore.UpdateMembersRole")
+	defer span.End()
+
+	result, err := s.TeamStore.UpdateMembersRole(teamID, adminIDs)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerTeamStore) UpdateMultipleMembers(members []*model.TeamMember) ([]*model.TeamMember, error) {
+	_, span := tracing.Start(context.Background(), "TeamStore.UpdateMultipleMembers")
+	defer span.End()
+
+	result, err := s.TeamStore.UpdateMultipleMembers(members)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerTeamStore) UserBelongsToTeams(userID string, teamIds []string) (bool, error) {
+	_, span := tracing.Start(context.Background(), "TeamStore.UserBelongsToTeams")
+	defer span.End()
+
+	result, err := s.TeamStore.UserBelongsToTeams(userID, teamIds)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerTermsOfServiceStore) Get(id string, allowFromCache bool) (*model.TermsOfService, error) {
+	_, span := tracing.Start(context.Background(), "TermsOfServiceStore.Get")
+	defer span.End()
+
+	result, err := s.TermsOfServiceStore.Get(id, allowFromCache)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerTermsOfServiceStore) GetLatest(allowFromCache bool) (*model.TermsOfService, error) {
+	_, span := tracing.Start(context.Background(), "TermsOfServiceStore.GetLatest")
+	defer span.End()
+
+	result, err := s.TermsOfServiceStore.GetLatest(allowFromCache)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerTermsOfServiceStore) Save(termsOfService *model.TermsOfService) (*model.TermsOfService, error) {
+	_, span := tracing.Start(context.Background(), "TermsOfServiceStore.Save")
+	defer span.End()
+
+	result, err := s.TermsOfServiceStore.Save(termsOfService)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerThreadStore) DeleteMembershipForUser(userID string, postID string) error {
+	_, span := tracing.Start(context.Background(), "ThreadStore.DeleteMembershipForUser")
+	defer span.End()
+
+	err := s.ThreadStore.DeleteMembershipForUser(userID, postID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerThreadStore) DeleteMembershipsForChannel(userID string, channelID string) error {
+	_, span := tracing.Start(context.Background(), "ThreadStore.DeleteMembershipsForChannel")
+	defer span.End()
+
+	err := s.ThreadStore.DeleteMembershipsForChannel(userID, channelID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerThreadStore) DeleteOrphanedRows(limit int) (int64, error) {
+	_, span := tracing.Start(context.Background(), "ThreadStore.DeleteOrphanedRows")
+	defer span.End()
+
+	result, err := s.ThreadStore.DeleteOrphanedRows(limit)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerThreadStore) Get(id string) (*model.Thread, error) {
+	_, span := tracing.Start(context.Background(), "ThreadStore.Get")
+	defer span.End()
+
+	result, err := s.ThreadStore.Get(id)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerThreadStore) GetMembershipForUser(userID string, postID string) (*model.ThreadMembership, error) {
+	_, span := tracing.Start(context.Background(), "ThreadStore.GetMembershipForUser")
+	defer span.End()
+
+	result, err := s.ThreadStore.GetMembershipForUser(userID, postID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerThreadStore) GetMembershipsForUser(userID string, teamID string) ([]*model.ThreadMembership, error) {
+	_, span := tracing.Start(context.Background(), "ThreadStore.GetMembershipsForUser")
+	defer span.End()
+
+	result, err := s.ThreadStore.GetMembershipsForUser(userID, teamID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerThreadStore) GetTeamsUnreadForUser(userID string, teamIDs []string, includeUrgentMentionCount bool) (map[string]*model.TeamUnread, error) {
+	_, span := tracing.Start(context.Background(), "ThreadStore.GetTeamsUnreadForUser")
+	defer span.End()
+
+	result, err := s.ThreadStore.GetTeamsUnreadForUser(userID, teamIDs, includeUrgentMentionCount)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerThreadStore) GetThreadFollowers(threadID string, fetchOnlyActive bool) ([]string, error) {
+	_, span := tracing.Start(context.Background(), "ThreadStore.GetThreadFollowers")
+	defer span.End()
+
+	result, err := s.ThreadStore.GetThreadFollowers(threadID, fetchOnlyActive)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerThreadStore) GetThreadForUser(rctx request.CTX, threadMembership *model.ThreadMembership, extended bool, postPriorityIsEnabled bool) (*model.ThreadResponse, error) {
+	_, span := tracing.Start(requestContext(rctx), "ThreadStore.GetThreadForUser")
+	defer span.End()
+
+	result, err := s.ThreadStore.GetThreadForUser(rctx, threadMembership, extended, postPriorityIsEnabled)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerThreadStore) GetThreadMembershipsForExport(postID string) ([]*model.ThreadMembershipForExport, error) {
+	_, span := tracing.Start(context.Background(), "ThreadStore.GetThreadMembershipsForExport")
+	defer span.End()
+
+	result, err := s.ThreadStore.GetThreadMembershipsForExport(postID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerThreadStore) GetThreadUnreadReplyCount(threadMembership *model.ThreadMembership) (int64, error) {
+	_, span := tracing.Start(context.Background(), "ThreadStore.GetThreadUnreadReplyCount")
+	defer span.End()
+
+	result, err := s.ThreadStore.GetThreadUnreadReplyCount(threadMembership)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerThreadStore) GetThreadsForUser(rctx request.CTX, userID string, teamID string, opts model.GetUserThreadsOpts) ([]*model.ThreadResponse, error) {
+	_, span := tracing.Start(requestContext(rctx), "ThreadStore.GetThreadsForUser")
+	defer span.End()
+
+	result, err := s.ThreadStore.GetThreadsForUser(rctx, userID, teamID, opts)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerThreadStore) GetTotalThreads(userID string, teamID string, opts model.GetUserThreadsOpts) (int64, error) {
+	_, span := tracing.Start(context.Background(), "ThreadStore.GetTotalThreads")
+	defer span.End()
+
+	result, err := s.ThreadStore.GetTotalThreads(userID, teamID, opts)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerThreadStore) GetTotalUnreadMentions(userID string, teamID string, opts model.GetUserThreadsOpts) (int64, error) {
+	_, span := tracing.Start(context.Background(), "ThreadStore.GetTotalUnreadMentions")
+	defer span.End()
+
+	result, err := s.ThreadStore.GetTotalUnreadMentions(userID, teamID, opts)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerThreadStore) GetTotalUnreadThreads(userID string, teamID string, opts model.GetUserThreadsOpts) (int64, error) {
+	_, span := tracing.Start(context.Background(), "ThreadStore.GetTotalUnreadThreads")
+	defer span.End()
+
+	result, err := s.ThreadStore.GetTotalUnreadThreads(userID, teamID, opts)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerThreadStore) GetTotalUnreadUrgentMentions(userID string, teamID string, opts model.GetUserThreadsOpts) (int64, error) {
+	_, span := tracing.Start(context.Background(), "ThreadStore.GetTotalUnreadUrgentMentions")
+	defer span.End()
+
+	result, err := s.ThreadStore.GetTotalUnreadUrgentMentions(userID, teamID, opts)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerThreadStore) MaintainMembership(userID string, postID string, opts store.ThreadMembershipOpts) (*model.ThreadMembership, error) {
+	_, span := tracing.Start(context.Background(), "ThreadStore.MaintainMembership")
+	defer span.End()
+
+	result, err := s.ThreadStore.MaintainMembership(userID, postID, opts)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerThreadStore) MaintainMultipleFromImport(memberships []*model.ThreadMembership) ([]*model.ThreadMembership, error) {
+	_, span := tracing.Start(context.Background(), "ThreadStore.MaintainMultipleFromImport")
+	defer span.End()
+
+	result, err := s.ThreadStore.MaintainMultipleFromImport(memberships)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerThreadStore) MarkAllAsRead(userID string, threadIds []string) error {
+	_, span := tracing.Start(context.Background(), "ThreadStore.MarkAllAsRead")
+	defer span.End()
+
+	err := s.ThreadStore.MarkAllAsRead(userID, threadIds)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerThreadStore) MarkAllAsReadByChannels(userID string, channelIDs []string) error {
+	_, span := tracing.Start(context.Background(), "ThreadStore.MarkAllAsReadByChannels")
+	defer span.End()
+
+	err := s.ThreadStore.MarkAllAsReadByChannels(userID, channelIDs)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerThreadStore) MarkAllAsReadByTeam(userID string, teamID string) error {
+	_, span := tracing.Start(context.Background(), "ThreadStore.MarkAllAsReadByTeam")
+	defer span.End()
+
+	err := s.ThreadStore.MarkAllAsReadByTeam(userID, teamID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerThreadStore) MarkAsRead(userID string, threadID string, timestamp int64) error {
+	_, span := tracing.Start(context.Background(), "ThreadStore.MarkAsRead")
+	defer span.End()
+
+	err := s.ThreadStore.MarkAsRead(userID, threadID, timestamp)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerThreadStore) PermanentDeleteBatchForRetentionPolicies(retentionPolicyBatchConfigs model.RetentionPolicyBatchConfigs, cursor model.RetentionPolicyCursor) (int64, model.RetentionPolicyCursor, error) {
+	_, span := tracing.Start(context.Background(), "ThreadStore.PermanentDeleteBatchForRetentionPolicies")
+	defer span.End()
+
+	result, resultVar1, err := s.ThreadStore.PermanentDeleteBatchForRetentionPolicies(retentionPolicyBatchConfigs, cursor)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, resultVar1, err
+}
+
+func (s *TracingLayerThreadStore) PermanentDeleteBatchThreadMembershipsForRetentionPolicies(retentionPolicyBatchConfigs model.RetentionPolicyBatchConfigs, cursor model.RetentionPolicyCursor) (int64, model.RetentionPolicyCursor, error) {
+	_, span := tracing.Start(context.Background(), "ThreadStore.PermanentDeleteBatchThreadMembershipsForRetentionPolicies")
+	defer span.End()
+
+	result, resultVar1, err := s.ThreadStore.PermanentDeleteBatchThreadMembershipsForRetentionPolicies(retentionPolicyBatchConfigs, cursor)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, resultVar1, err
+}
+
+func (s *TracingLayerThreadStore) SaveMultipleMemberships(memberships []*model.ThreadMembership) ([]*model.ThreadMembership, error) {
+	_, span := tracing.Start(context.Background(), "ThreadStore.SaveMultipleMemberships")
+	defer span.End()
+
+	result, err := s.ThreadStore.SaveMultipleMemberships(memberships)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerThreadStore) UpdateMembership(membership *model.ThreadMembership) (*model.ThreadMembership, error) {
+	_, span := tracing.Start(context.Background(), "ThreadStore.UpdateMembership")
+	defer span.End()
+
+	result, err := s.ThreadStore.UpdateMembership(membership)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerThreadStore) UpdateTeamIdForChannelThreads(channelId string, teamId string) error {
+	_, span := tracing.Start(context.Background(), "ThreadStore.UpdateTeamIdForChannelThreads")
+	defer span.End()
+
+	err := s.ThreadStore.UpdateTeamIdForChannelThreads(channelId, teamId)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerTokenStore) Cleanup(expiryTime int64) {
+	_, span := tracing.Start(context.Background(), "TokenStore.Cleanup")
+	defer span.End()
+
+	s.TokenStore.Cleanup(expiryTime)
+}
+
+func (s *TracingLayerTokenStore) ConsumeOnce(tokenType string, tokenStr string) (*model.Token, error) {
+	_, span := tracing.Start(context.Background(), "TokenStore.ConsumeOnce")
+	defer span.End()
+
+	result, err := s.TokenStore.ConsumeOnce(tokenType, tokenStr)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerTokenStore) Delete(token string) error {
+	_, span := tracing.Start(context.Background(), "TokenStore.Delete")
+	defer span.End()
+
+	err := s.TokenStore.Delete(token)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerTokenStore) GetAllTokensByType(tokenType string) ([]*model.Token, error) {
+	_, span := tracing.Start(context.Background(), "TokenStore.GetAllTokensByType")
+	defer span.End()
+
+	result, err := s.TokenStore.GetAllTokensByType(tokenType)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerTokenStore) GetByToken(token string) (*model.Token, error) {
+	_, span := tracing.Start(context.Background(), "TokenStore.GetByToken")
+	defer span.End()
+
+	result, err := s.TokenStore.GetByToken(token)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerTokenStore) GetTokenByTypeAndEmail(tokenType string, email string) (*model.Token, error) {
+	_, span := tracing.Start(context.Background(), "TokenStore.GetTokenByTypeAndEmail")
+	defer span.End()
+
+	result, err := s.TokenStore.GetTokenByTypeAndEmail(tokenType, email)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerTokenStore) RemoveAllTokensByType(tokenType string) error {
+	_, span := tracing.Start(context.Background(), "TokenStore.RemoveAllTokensByType")
+	defer span.End()
+
+	err := s.TokenStore.RemoveAllTokensByType(tokenType)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerTokenStore) Save(recovery *model.Token) error {
+	_, span := tracing.Start(context.Background(), "TokenStore.Save")
+	defer span.End()
+
+	err := s.TokenStore.Save(recovery)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerUploadSessionStore) Delete(id string) error {
+	_, span := tracing.Start(context.Background(), "UploadSessionStore.Delete")
+	defer span.End()
+
+	err := s.UploadSessionStore.Delete(id)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerUploadSessionStore) Get(rctx request.CTX, id string) (*model.UploadSession, error) {
+	_, span := tracing.Start(requestContext(rctx), "UploadSessionStore.Get")
+	defer span.End()
+
+	result, err := s.UploadSessionStore.Get(rctx, id)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerUploadSessionStore) GetForUser(userID string) ([]*model.UploadSession, error) {
+	_, span := tracing.Start(context.Background(), "UploadSessionStore.GetForUser")
+	defer span.End()
+
+	result, err := s.UploadSessionStore.GetForUser(userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerUploadSessionStore) Save(session *model.UploadSession) (*model.UploadSession, error) {
+	_, span := tracing.Start(context.Background(), "UploadSessionStore.Save")
+	defer span.End()
+
+	result, err := s.UploadSessionStore.Save(session)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerUploadSessionStore) Update(session *model.UploadSession) error {
+	_, span := tracing.Start(context.Background(), "UploadSessionStore.Update")
+	defer span.End()
+
+	err := s.UploadSessionStore.Update(session)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerUserStore) AnalyticsActiveCount(timestamp int64, options model.UserCountOptions) (int64, error) {
+	_, span := tracing.Start(context.Background(), "UserStore.AnalyticsActiveCount")
+	defer span.End()
+
+	result, err := s.UserStore.AnalyticsActiveCount(timestamp, options)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerUserStore) AnalyticsActiveCountForPeriod(startTime int64, endTime int64, options model.UserCountOptions) (int32, error) {
+	_, span := tracing.Start(context.Background(), "UserStore.AnalyticsActiveCountForPeriod")
+	defer span.End()
+
+	result, err := s.UserStore.AnalyticsActiveCountForPeriod(startTime, endTime, options)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerUserStore) AnalyticsGetExternalUsers(hostDomain string) (bool, error) {
+	_, span := tracing.Start(context.Background(), "UserStore.AnalyticsGetExternalUsers")
+	defer span.End()
+
+	result, err := s.UserStore.AnalyticsGetExternalUsers(hostDomain)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerUserStore) AnalyticsGetGuestCount() (int64, error) {
+	_, span := tracing.Start(context.Background(), "UserStore.AnalyticsGetGuestCount")
+	defer span.End()
+
+	result, err := s.UserStore.AnalyticsGetGuestCount()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerUserStore) AnalyticsGetInactiveUsersCount() (int64, error) {
+	_, span := tracing.Start(context.Background(), "UserStore.AnalyticsGetInactiveUsersCount")
+	defer span.End()
+
+	result, err := s.UserStore.AnalyticsGetInactiveUsersCount()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerUserStore) AnalyticsGetSystemAdminCount() (int64, error) {
+	_, span := tracing.Start(context.Background(), "UserStore.AnalyticsGetSystemAdminCount")
+	defer span.End()
+
+	result, err := s.UserStore.AnalyticsGetSystemAdminCount()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerUserStore) AutocompleteUsersInChannel(rctx request.CTX, teamID string, channelID string, term string, options *model.UserSearchOptions) (*model.UserAutocompleteInChannel, error) {
+	_, span := tracing.Start(requestContext(rctx), "UserStore.AutocompleteUsersInChannel")
+	defer span.End()
+
+	result, err := s.UserStore.AutocompleteUsersInChannel(rctx, teamID, channelID, term, options)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerUserStore) ClearAllCustomRoleAssignments() error {
+	_, span := tracing.Start(context.Background(), "UserStore.ClearAllCustomRoleAssignments")
+	defer span.End()
+
+	err := s.UserStore.ClearAllCustomRoleAssignments()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerUserStore) ClearCaches() {
+	_, span := tracing.Start(context.Background(), "UserStore.ClearCaches")
+	defer span.End()
+
+	s.UserStore.ClearCaches()
+}
+
+func (s *TracingLayerUserStore) Count(options model.UserCountOptions) (int64, error) {
+	_, span := tracing.Start(context.Background(), "UserStore.Count")
+	defer span.End()
+
+	result, err := s.UserStore.Count(options)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerUserStore) DeactivateGuests() ([]string, error) {
+	_, span := tracing.Start(context.Background(), "UserStore.DeactivateGuests")
+	defer span.End()
+
+	result, err := s.UserStore.DeactivateGuests()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerUserStore) DeactivateMagicLinkGuests() ([]string, error) {
+	_, span := tracing.Start(context.Background(), "UserStore.DeactivateMagicLinkGuests")
+	defer span.End()
+
+	result, err := s.UserStore.DeactivateMagicLinkGuests()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerUserStore) DemoteUserToGuest(userID string) (*model.User, error) {
+	_, span := tracing.Start(context.Background(), "UserStore.DemoteUserToGuest")
+	defer span.End()
+
+	result, err := s.UserStore.DemoteUserToGuest(userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerUserStore) Get(ctx context.Context, id string) (*model.User, error) {
+	_, span := tracing.Start(parentContext(ctx), "UserStore.Get")
+	defer span.End()
+
+	result, err := s.UserStore.Get(ctx, id)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerUserStore) GetAll() ([]*model.User, error) {
+	_, span := tracing.Start(context.Background(), "UserStore.GetAll")
+	defer span.End()
+
+	result, err := s.UserStore.GetAll()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerUserStore) GetAllAfter(limit int, afterID string) ([]*model.User, error) {
+	_, span := tracing.Start(context.Background(), "UserStore.GetAllAfter")
+	defer span.End()
+
+	result, err := s.UserStore.GetAllAfter(limit, afterID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerUserStore) GetAllNotInAuthService(authServices []string) ([]*model.User, error) {
+	_, span := tracing.Start(context.Background(), "UserStore.GetAllNotInAuthService")
+	defer span.End()
+
+	result, err := s.UserStore.GetAllNotInAuthService(authServices)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerUserStore) GetAllProfiles(options *model.UserGetOptions) ([]*model.User, error) {
+	_, span := tracing.Start(context.Background(), "UserStore.GetAllProfiles")
+	defer span.End()
+
+	result, err := s.UserStore.GetAllProfiles(options)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerUserStore) GetAllProfilesInChannel(ctx context.Context, channelID string, allowFromCache bool) (map[string]*model.User, error) {
+	_, span := tracing.Start(parentContext(ctx), "UserStore.GetAllProfilesInChannel")
+	defer span.End()
+
+	result, err := s.UserStore.GetAllProfilesInChannel(ctx, channelID, allowFromCache)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerUserStore) GetAllUsingAuthService(authService string) ([]*model.User, error) {
+	_, span := tracing.Start(context.Background(), "UserStore.GetAllUsingAuthService")
+	defer span.End()
+
+	result, err := s.UserStore.GetAllUsingAuthService(authService)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerUserStore) GetAnyUnreadPostCountForChannel(userID string, channelID string) (int64, error) {
+	_, span := tracing.Start(context.Background(), "UserStore.GetAnyUnreadPostCountForChannel")
+	defer span.End()
+
+	result, err := s.UserStore.GetAnyUnreadPostCountForChannel(userID, channelID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerUserStore) GetByAuth(authData *string, authService string) (*model.User, error) {
+	_, span := tracing.Start(context.Background(), "UserStore.GetByAuth")
+	defer span.End()
+
+	result, err := s.UserStore.GetByAuth(authData, authService)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerUserStore) GetByEmail(email string) (*model.User, error) {
+	_, span := tracing.Start(context.Background(), "UserStore.GetByEmail")
+	defer span.End()
+
+	result, err := s.UserStore.GetByEmail(email)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerUserStore) GetByRemoteID(remoteID string) (*model.User, error) {
+	_, span := tracing.Start(context.Background(), "UserStore.GetByRemoteID")
+	defer span.End()
+
+	result, err := s.UserStore.GetByRemoteID(remoteID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerUserStore) GetByUsername(username string) (*model.User, error) {
+	_, span := tracing.Start(context.Background(), "UserStore.GetByUsername")
+	defer span.End()
+
+	result, err := s.UserStore.GetByUsername(username)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerUserStore) GetChannelGroupUsers(channelID string) ([]*model.User, error) {
+	_, span := tracing.Start(context.Background(), "UserStore.GetChannelGroupUsers")
+	defer span.End()
+
+	result, err := s.UserStore.GetChannelGroupUsers(channelID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerUserStore) GetEtagForAllProfiles() string {
+	_, span := tracing.Start(context.Background(), "UserStore.GetEtagForAllProfiles")
+	defer span.End()
+
+	result := s.UserStore.GetEtagForAllProfiles()
+	return result
+}
+
+func (s *TracingLayerUserStore) GetEtagForProfiles(teamID string) string {
+	_, span := tracing.Start(context.Background(), "UserStore.GetEtagForProfiles")
+	defer span.End()
+
+	result := s.UserStore.GetEtagForProfiles(teamID)
+	return result
+}
+
+func (s *TracingLayerUserStore) GetEtagForProfilesNotInTeam(teamID string) string {
+	_, span := tracing.Start(context.Background(), "UserStore.GetEtagForProfilesNotInTeam")
+	defer span.End()
+
+	result := s.UserStore.GetEtagForProfilesNotInTeam(teamID)
+	return result
+}
+
+func (s *TracingLayerUserStore) GetForLogin(loginID string, allowSignInWithUsername bool, allowSignInWithEmail bool) (*model.User, error) {
+	_, span := tracing.Start(context.Background(), "UserStore.GetForLogin")
+	defer span.End()
+
+	result, err := s.UserStore.GetForLogin(loginID, allowSignInWithUsername, allowSignInWithEmail)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerUserStore) GetKnownUsers(userID string) ([]string, error) {
+	_, span := tracing.Start(context.Background(), "UserStore.GetKnownUsers")
+	defer span.End()
+
+	result, err := s.UserStore.GetKnownUsers(userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerUserStore) GetMany(rctx request.CTX, ids []string) ([]*model.User, error) {
+	_, span := tracing.Start(requestContext(rctx), "UserStore.GetMany")
+	defer span.End()
+
+	result, err := s.UserStore.GetMany(rctx, ids)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerUserStore) GetMfaUsedTimestamps(userID string) ([]int, error) {
+	_, span := tracing.Start(context.Background(), "UserStore.GetMfaUsedTimestamps")
+	defer span.End()
+
+	result, err := s.UserStore.GetMfaUsedTimestamps(userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerUserStore) GetNewUsersForTeam(teamID string, offset int, limit int, viewRestrictions *model.ViewUsersRestrictions) ([]*model.User, error) {
+	_, span := tracing.Start(context.Background(), "UserStore.GetNewUsersForTeam")
+	defer span.End()
+
+	result, err := s.UserStore.GetNewUsersForTeam(teamID, offset, limit, viewRestrictions)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerUserStore) GetProfileByGroupChannelIdsForUser(userID string, channelIds []string) (map[string][]*model.User, error) {
+	_, span := tracing.Start(context.Background(), "UserStore.GetProfileByGroupChannelIdsForUser")
+	defer span.End()
+
+	result, err := s.UserStore.GetProfileByGroupChannelIdsForUser(userID, channelIds)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerUserStore) GetProfileByIds(rctx request.CTX, userIds []string, options *store.UserGetByIdsOpts, allowFromCache bool) ([]*model.User, error) {
+	_, span := tracing.Start(requestContext(rctx), "UserStore.GetProfileByIds")
+	defer span.End()
+
+	result, err := s.UserStore.GetProfileByIds(rctx, userIds, options, allowFromCache)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerUserStore) GetProfiles(options *model.UserGetOptions) ([]*model.User, error) {
+	_, span := tracing.Start(context.Background(), "UserStore.GetProfiles")
+	defer span.End()
+
+	result, err := s.UserStore.GetProfiles(options)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerUserStore) GetProfilesByUsernames(usernames []string, viewRestrictions *model.ViewUsersRestrictions) ([]*model.User, error) {
+	_, span := tracing.Start(context.Background(), "UserStore.GetProfilesByUsernames")
+	defer span.End()
+
+	result, err := s.UserStore.GetProfilesByUsernames(usernames, viewRestrictions)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerUserStore) GetProfilesInChannel(options *model.UserGetOptions) ([]*model.User, error) {
+	_, span := tracing.Start(context.Background(), "UserStore.GetProfilesInChannel")
+	defer span.End()
+
+	result, err := s.UserStore.GetProfilesInChannel(options)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerUserStore) GetProfilesInChannelByAdmin(options *model.UserGetOptions) ([]*model.User, error) {
+	_, span := tracing.Start(context.Background(), "UserStore.GetProfilesInChannelByAdmin")
+	defer span.End()
+
+	result, err := s.UserStore.GetProfilesInChannelByAdmin(options)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerUserStore) GetProfilesInChannelByStatus(options *model.UserGetOptions) ([]*model.User, error) {
+	_, span := tracing.Start(context.Background(), "UserStore.GetProfilesInChannelByStatus")
+	defer span.End()
+
+	result, err := s.UserStore.GetProfilesInChannelByStatus(options)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerUserStore) GetProfilesNotInChannel(teamID string, channelID string, groupConstrained bool, offset int, limit int, viewRestrictions *model.ViewUsersRestrictions) ([]*model.User, error) {
+	_, span := tracing.Start(context.Background(), "UserStore.GetProfilesNotInChannel")
+	defer span.End()
+
+	result, err := s.UserStore.GetProfilesNotInChannel(teamID, channelID, groupConstrained, offset, limit, viewRestrictions)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerUserStore) GetProfilesNotInTeam(teamID string, groupConstrained bool, offset int, limit int, viewRestrictions *model.ViewUsersRestrictions) ([]*model.User, error) {
+	_, span := tracing.Start(context.Background(), "UserStore.GetProfilesNotInTeam")
+	defer span.End()
+
+	result, err := s.UserStore.GetProfilesNotInTeam(teamID, groupConstrained, offset, limit, viewRestrictions)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerUserStore) GetProfilesWithoutTeam(options *model.UserGetOptions) ([]*model.User, error) {
+	_, span := tracing.Start(context.Background(), "UserStore.GetProfilesWithoutTeam")
+	defer span.End()
+
+	result, err := s.UserStore.GetProfilesWithoutTeam(options)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerUserStore) GetRecentlyActiveUsersForTeam(teamID string, offset int, limit int, viewRestrictions *model.ViewUsersRestrictions) ([]*model.User, error) {
+	_, span := tracing.Start(context.Background(), "UserStore.GetRecentlyActiveUsersForTeam")
+	defer span.End()
+
+	result, err := s.UserStore.GetRecentlyActiveUsersForTeam(teamID, offset, limit, viewRestrictions)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerUserStore) GetSystemAdminProfiles() (map[string]*model.User, error) {
+	_, span := tracing.Start(context.Background(), "UserStore.GetSystemAdminProfiles")
+	defer span.End()
+
+	result, err := s.UserStore.GetSystemAdminProfiles()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerUserStore) GetTeamGroupUsers(teamID string) ([]*model.User, error) {
+	_, span := tracing.Start(context.Background(), "UserStore.GetTeamGroupUsers")
+	defer span.End()
+
+	result, err := s.UserStore.GetTeamGroupUsers(teamID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerUserStore) GetUnreadCount(userID string, isCRTEnabled bool) (int64, error) {
+	_, span := tracing.Start(context.Background(), "UserStore.GetUnreadCount")
+	defer span.End()
+
+	result, err := s.UserStore.GetUnreadCount(userID, isCRTEnabled)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerUserStore) GetUnreadCountForChannel(userID string, channelID string) (int64, error) {
+	_, span := tracing.Start(context.Background(), "UserStore.GetUnreadCountForChannel")
+	defer span.End()
+
+	result, err := s.UserStore.GetUnreadCountForChannel(userID, channelID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerUserStore) GetUserCountForReport(filter *model.UserReportOptions) (int64, error) {
+	_, span := tracing.Start(context.Background(), "UserStore.GetUserCountForReport")
+	defer span.End()
+
+	result, err := s.UserStore.GetUserCountForReport(filter)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerUserStore) GetUserReport(filter *model.UserReportOptions) ([]*model.UserReportQuery, error) {
+	_, span := tracing.Start(context.Background(), "UserStore.GetUserReport")
+	defer span.End()
+
+	result, err := s.UserStore.GetUserReport(filter)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerUserStore) GetUsersBatchForIndexing(startTime int64, startFileID string, limit int) ([]*model.UserForIndexing, error) {
+	_, span := tracing.Start(context.Background(), "UserStore.GetUsersBatchForIndexing")
+	defer span.End()
+
+	result, err := s.UserStore.GetUsersBatchForIndexing(startTime, startFileID, limit)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerUserStore) GetUsersWithInvalidEmails(page int, perPage int, restrictedDomains string) ([]*model.User, error) {
+	_, span := tracing.Start(context.Background(), "UserStore.GetUsersWithInvalidEmails")
+	defer span.End()
+
+	result, err := s.UserStore.GetUsersWithInvalidEmails(page, perPage, restrictedDomains)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerUserStore) InferSystemInstallDate() (int64, error) {
+	_, span := tracing.Start(context.Background(), "UserStore.InferSystemInstallDate")
+	defer span.End()
+
+	result, err := s.UserStore.InferSystemInstallDate()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerUserStore) InsertUsers(users []*model.User) error {
+	_, span := tracing.Start(context.Background(), "UserStore.InsertUsers")
+	defer span.End()
+
+	err := s.UserStore.InsertUsers(users)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerUserStore) InvalidateProfileCacheForUser(userID string) {
+	_, span := tracing.Start(context.Background(), "UserStore.InvalidateProfileCacheForUser")
+	defer span.End()
+
+	s.UserStore.InvalidateProfileCacheForUser(userID)
+}
+
+func (s *TracingLayerUserStore) InvalidateProfilesInChannelCache(channelID string) {
+	_, span := tracing.Start(context.Background(), "UserStore.InvalidateProfilesInChannelCache")
+	defer span.End()
+
+	s.UserStore.InvalidateProfilesInChannelCache(channelID)
+}
+
+func (s *TracingLayerUserStore) InvalidateProfilesInChannelCacheByUser(userID string) {
+	_, span := tracing.Start(context.Background(), "UserStore.InvalidateProfilesInChannelCacheByUser")
+	defer span.End()
+
+	s.UserStore.InvalidateProfilesInChannelCacheByUser(userID)
+}
+
+func (s *TracingLayerUserStore) IsEmpty(excludeBots bool) (bool, error) {
+	_, span := tracing.Start(context.Background(), "UserStore.IsEmpty")
+	defer span.End()
+
+	result, err := s.UserStore.IsEmpty(excludeBots)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerUserStore) PermanentDelete(rctx request.CTX, userID string) error {
+	_, span := tracing.Start(requestContext(rctx), "UserStore.PermanentDelete")
+	defer span.End()
+
+	err := s.UserStore.PermanentDelete(rctx, userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerUserStore) PromoteGuestToUser(userID string) error {
+	_, span := tracing.Start(context.Background(), "UserStore.PromoteGuestToUser")
+	defer span.End()
+
+	err := s.UserStore.PromoteGuestToUser(userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerUserStore) RefreshPostStatsForUsers() error {
+	_, span := tracing.Start(context.Background(), "UserStore.RefreshPostStatsForUsers")
+	defer span.End()
+
+	err := s.UserStore.RefreshPostStatsForUsers()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerUserStore) ResetAuthDataToEmailForUsers(service string, userIDs []string, includeDeleted bool, dryRun bool) (int, error) {
+	_, span := tracing.Start(context.Background(), "UserStore.ResetAuthDataToEmailForUsers")
+	defer span.End()
+
+	result, err := s.UserStore.ResetAuthDataToEmailForUsers(service, userIDs, includeDeleted, dryRun)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerUserStore) ResetLastPictureUpdate(userID string) error {
+	_, span := tracing.Start(context.Background(), "UserStore.ResetLastPictureUpdate")
+	defer span.End()
+
+	err := s.UserStore.ResetLastPictureUpdate(userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerUserStore) Save(rctx request.CTX, user *model.User) (*model.User, error) {
+	_, span := tracing.Start(requestContext(rctx), "UserStore.Save")
+	defer span.End()
+
+	result, err := s.UserStore.Save(rctx, user)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerUserStore) Search(rctx request.CTX, teamID string, term string, options *model.UserSearchOptions) ([]*model.User, error) {
+	_, span := tracing.Start(requestContext(rctx), "UserStore.Search")
+	defer span.End()
+
+	result, err := s.UserStore.Search(rctx, teamID, term, options)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerUserStore) SearchCommonContentFlaggingReviewers(term string) ([]*model.User, error) {
+	_, span := tracing.Start(context.Background(), "UserStore.SearchCommonContentFlaggingReviewers")
+	defer span.End()
+
+	result, err := s.UserStore.SearchCommonContentFlaggingReviewers(term)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerUserStore) SearchInChannel(channelID string, term string, options *model.UserSearchOptions) ([]*model.User, error) {
+	_, span := tracing.Start(context.Background(), "UserStore.SearchInChannel")
+	defer span.End()
+
+	result, err := s.UserStore.SearchInChannel(channelID, term, options)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerUserStore) SearchInGroup(groupID string, term string, options *model.UserSearchOptions) ([]*model.User, error) {
+	_, span := tracing.Start(context.Background(), "UserStore.SearchInGroup")
+	defer span.End()
+
+	result, err := s.UserStore.SearchInGroup(groupID, term, options)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerUserStore) SearchNotInChannel(teamID string, channelID string, term string, options *model.UserSearchOptions) ([]*model.User, error) {
+	_, span := tracing.Start(context.Background(), "UserStore.SearchNotInChannel")
+	defer span.End()
+
+	result, err := s.UserStore.SearchNotInChannel(teamID, channelID, term, options)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerUserStore) SearchNotInGroup(groupID string, term string, options *model.UserSearchOptions) ([]*model.User, error) {
+	_, span := tracing.Start(context.Background(), "UserStore.SearchNotInGroup")
+	defer span.End()
+
+	result, err := s.UserStore.SearchNotInGroup(groupID, term, options)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerUserStore) SearchNotInTeam(notInTeamID string, term string, options *model.UserSearchOptions) ([]*model.User, error) {
+	_, span := tracing.Start(context.Background(), "UserStore.SearchNotInTeam")
+	defer span.End()
+
+	result, err := s.UserStore.SearchNotInTeam(notInTeamID, term, options)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerUserStore) SearchTeamContentFlaggingReviewers(teamId string, term string) ([]*model.User, error) {
+	_, span := tracing.Start(context.Background(), "UserStore.SearchTeamContentFlaggingReviewers")
+	defer span.End()
+
+	result, err := s.UserStore.SearchTeamContentFlaggingReviewers(teamId, term)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerUserStore) SearchWithoutTeam(term string, options *model.UserSearchOptions) ([]*model.User, error) {
+	_, span := tracing.Start(context.Background(), "UserStore.SearchWithoutTeam")
+	defer span.End()
+
+	result, err := s.UserStore.SearchWithoutTeam(term, options)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerUserStore) StoreMfaUsedTimestamps(userID string, ts []int) error {
+	_, span := tracing.Start(context.Background(), "UserStore.StoreMfaUsedTimestamps")
+	defer span.End()
+
+	err := s.UserStore.StoreMfaUsedTimestamps(userID, ts)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerUserStore) Update(rctx request.CTX, user *model.User, allowRoleUpdate bool) (*model.UserUpdate, error) {
+	_, span := tracing.Start(requestContext(rctx), "UserStore.Update")
+	defer span.End()
+
+	result, err := s.UserStore.Update(rctx, user, allowRoleUpdate)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerUserStore) UpdateAuthData(userID string, service string, authData *string, email string, resetMfa bool) (string, error) {
+	_, span := tracing.Start(context.Background(), "UserStore.UpdateAuthData")
+	defer span.End()
+
+	result, err := s.UserStore.UpdateAuthData(userID, service, authData, email, resetMfa)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerUserStore) UpdateFailedPasswordAttempts(userID string, attempts int) error {
+	_, span := tracing.Start(context.Background(), "UserStore.UpdateFailedPasswordAttempts")
+	defer span.End()
+
+	err := s.UserStore.UpdateFailedPasswordAttempts(userID, attempts)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerUserStore) UpdateLastLogin(userID string, lastLogin int64) error {
+	_, span := tracing.Start(context.Background(), "UserStore.UpdateLastLogin")
+	defer span.End()
+
+	err := s.UserStore.UpdateLastLogin(userID, lastLogin)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerUserStore) UpdateLastPictureUpdate(userID string) error {
+	_, span := tracing.Start(context.Background(), "UserStore.UpdateLastPictureUpdate")
+	defer span.End()
+
+	err := s.UserStore.UpdateLastPictureUpdate(userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerUserStore) UpdateMfaActive(userID string, active bool) error {
+	_, span := tracing.Start(context.Background(), "UserStore.UpdateMfaActive")
+	defer span.End()
+
+	err := s.UserStore.UpdateMfaActive(userID, active)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerUserStore) UpdateMfaSecret(userID string, secret string) error {
+	_, span := tracing.Start(context.Background(), "UserStore.UpdateMfaSecret")
+	defer span.End()
+
+	err := s.UserStore.UpdateMfaSecret(userID, secret)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerUserStore) UpdateNotifyProps(userID string, props map[string]string) error {
+	_, span := tracing.Start(context.Background(), "UserStore.UpdateNotifyProps")
+	defer span.End()
+
+	err := s.UserStore.UpdateNotifyProps(userID, props)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerUserStore) UpdatePassword(userID string, newPassword string) error {
+	_, span := tracing.Start(context.Background(), "UserStore.UpdatePassword")
+	defer span.End()
+
+	err := s.UserStore.UpdatePassword(userID, newPassword)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerUserStore) UpdateUpdateAt(userID string) (int64, error) {
+	_, span := tracing.Start(context.Background(), "UserStore.UpdateUpdateAt")
+	defer span.End()
+
+	result, err := s.UserStore.UpdateUpdateAt(userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerUserStore) VerifyEmail(userID string, email string) (string, error) {
+	_, span := tracing.Start(context.Background(), "UserStore.VerifyEmail")
+	defer span.End()
+
+	result, err := s.UserStore.VerifyEmail(userID, email)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerUserAccessTokenStore) Delete(tokenID string) error {
+	_, span := tracing.Start(context.Background(), "UserAccessTokenStore.Delete")
+	defer span.End()
+
+	err := s.UserAccessTokenStore.Delete(tokenID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerUserAccessTokenStore) DeleteAllForUser(userID string) error {
+	_, span := tracing.Start(context.Background(), "UserAccessTokenStore.DeleteAllForUser")
+	defer span.End()
+
+	err := s.UserAccessTokenStore.DeleteAllForUser(userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerUserAccessTokenStore) Get(tokenID string) (*model.UserAccessToken, error) {
+	_, span := tracing.Start(context.Background(), "UserAccessTokenStore.Get")
+	defer span.End()
+
+	result, err := s.UserAccessTokenStore.Get(tokenID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerUserAccessTokenStore) GetAll(offset int, limit int) ([]*model.UserAccessToken, error) {
+	_, span := tracing.Start(context.Background(), "UserAccessTokenStore.GetAll")
+	defer span.End()
+
+	result, err := s.UserAccessTokenStore.GetAll(offset, limit)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerUserAccessTokenStore) GetByToken(tokenString string) (*model.UserAccessToken, error) {
+	_, span := tracing.Start(context.Background(), "UserAccessTokenStore.GetByToken")
+	defer span.End()
+
+	result, err := s.UserAccessTokenStore.GetByToken(tokenString)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerUserAccessTokenStore) GetByUser(userID string, page int, perPage int) ([]*model.UserAccessToken, error) {
+	_, span := tracing.Start(context.Background(), "UserAccessTokenStore.GetByUser")
+	defer span.End()
+
+	result, err := s.UserAccessTokenStore.GetByUser(userID, page, perPage)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerUserAccessTokenStore) Save(token *model.UserAccessToken) (*model.UserAccessToken, error) {
+	_, span := tracing.Start(context.Background(), "UserAccessTokenStore.Save")
+	defer span.End()
+
+	result, err := s.UserAccessTokenStore.Save(token)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerUserAccessTokenStore) Search(term string) ([]*model.UserAccessToken, error) {
+	_, span := tracing.Start(context.Background(), "UserAccessTokenStore.Search")
+	defer span.End()
+
+	result, err := s.UserAccessTokenStore.Search(term)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerUserAccessTokenStore) UpdateTokenDisable(tokenID string) error {
+	_, span := tracing.Start(context.Background(), "UserAccessTokenStore.UpdateTokenDisable")
+	defer span.End()
+
+	err := s.UserAccessTokenStore.UpdateTokenDisable(tokenID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerUserAccessTokenStore) UpdateTokenEnable(tokenID string) error {
+	_, span := tracing.Start(context.Background(), "UserAccessTokenStore.UpdateTokenEnable")
+	defer span.End()
+
+	err := s.UserAccessTokenStore.UpdateTokenEnable(tokenID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerUserTermsOfServiceStore) Delete(userID string, termsOfServiceID string) error {
+	_, span := tracing.Start(context.Background(), "UserTermsOfServiceStore.Delete")
+	defer span.End()
+
+	err := s.UserTermsOfServiceStore.Delete(userID, termsOfServiceID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerUserTermsOfServiceStore) GetByUser(userID string) (*model.UserTermsOfService, error) {
+	_, span := tracing.Start(context.Background(), "UserTermsOfServiceStore.GetByUser")
+	defer span.End()
+
+	result, err := s.UserTermsOfServiceStore.GetByUser(userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerUserTermsOfServiceStore) Save(userTermsOfService *model.UserTermsOfService) (*model.UserTermsOfService, error) {
+	_, span := tracing.Start(context.Background(), "UserTermsOfServiceStore.Save")
+	defer span.End()
+
+	result, err := s.UserTermsOfServiceStore.Save(userTermsOfService)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerWebhookStore) AnalyticsIncomingCount(teamID string, userID string) (int64, error) {
+	_, span := tracing.Start(context.Background(), "WebhookStore.AnalyticsIncomingCount")
+	defer span.End()
+
+	result, err := s.WebhookStore.AnalyticsIncomingCount(teamID, userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerWebhookStore) AnalyticsOutgoingCount(teamID string) (int64, error) {
+	_, span := tracing.Start(context.Background(), "WebhookStore.AnalyticsOutgoingCount")
+	defer span.End()
+
+	result, err := s.WebhookStore.AnalyticsOutgoingCount(teamID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerWebhookStore) ClearCaches() {
+	_, span := tracing.Start(context.Background(), "WebhookStore.ClearCaches")
+	defer span.End()
+
+	s.WebhookStore.ClearCaches()
+}
+
+func (s *TracingLayerWebhookStore) DeleteIncoming(webhookID string, timestamp int64) error {
+	_, span := tracing.Start(context.Background(), "WebhookStore.DeleteIncoming")
+	defer span.End()
+
+	err := s.WebhookStore.DeleteIncoming(webhookID, timestamp)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerWebhookStore) DeleteOutgoing(webhookID string, timestamp int64) error {
+	_, span := tracing.Start(context.Background(), "WebhookStore.DeleteOutgoing")
+	defer span.End()
+
+	err := s.WebhookStore.DeleteOutgoing(webhookID, timestamp)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerWebhookStore) GetIncoming(id string, allowFromCache bool) (*model.IncomingWebhook, error) {
+	_, span := tracing.Start(context.Background(), "WebhookStore.GetIncoming")
+	defer span.End()
+
+	result, err := s.WebhookStore.GetIncoming(id, allowFromCache)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerWebhookStore) GetIncomingByChannel(channelID string) ([]*model.IncomingWebhook, error) {
+	_, span := tracing.Start(context.Background(), "WebhookStore.GetIncomingByChannel")
+	defer span.End()
+
+	result, err := s.WebhookStore.GetIncomingByChannel(channelID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerWebhookStore) GetIncomingByTeam(teamID string, offset int, limit int) ([]*model.IncomingWebhook, error) {
+	_, span := tracing.Start(context.Background(), "WebhookStore.GetIncomingByTeam")
+	defer span.End()
+
+	result, err := s.WebhookStore.GetIncomingByTeam(teamID, offset, limit)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerWebhookStore) GetIncomingByTeamByUser(teamID string, userID string, offset int, limit int) ([]*model.IncomingWebhook, error) {
+	_, span := tracing.Start(context.Background(), "WebhookStore.GetIncomingByTeamByUser")
+	defer span.End()
+
+	result, err := s.WebhookStore.GetIncomingByTeamByUser(teamID, userID, offset, limit)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerWebhookStore) GetIncomingList(offset int, limit int) ([]*model.IncomingWebhook, error) {
+	_, span := tracing.Start(context.Background(), "WebhookStore.GetIncomingList")
+	defer span.End()
+
+	result, err := s.WebhookStore.GetIncomingList(offset, limit)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerWebhookStore) GetIncomingListByUser(userID string, offset int, limit int) ([]*model.IncomingWebhook, error) {
+	_, span := tracing.Start(context.Background(), "WebhookStore.GetIncomingListByUser")
+	defer span.End()
+
+	result, err := s.WebhookStore.GetIncomingListByUser(userID, offset, limit)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerWebhookStore) GetOutgoing(id string) (*model.OutgoingWebhook, error) {
+	_, span := tracing.Start(context.Background(), "WebhookStore.GetOutgoing")
+	defer span.End()
+
+	result, err := s.WebhookStore.GetOutgoing(id)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerWebhookStore) GetOutgoingByChannel(channelID string, offset int, limit int) ([]*model.OutgoingWebhook, error) {
+	_, span := tracing.Start(context.Background(), "WebhookStore.GetOutgoingByChannel")
+	defer span.End()
+
+	result, err := s.WebhookStore.GetOutgoingByChannel(channelID, offset, limit)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerWebhookStore) GetOutgoingByChannelByUser(channelID string, userID string, offset int, limit int) ([]*model.OutgoingWebhook, error) {
+	_, span := tracing.Start(context.Background(), "WebhookStore.GetOutgoingByChannelByUser")
+	defer span.End()
+
+	result, err := s.WebhookStore.GetOutgoingByChannelByUser(channelID, userID, offset, limit)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerWebhookStore) GetOutgoingByTeam(teamID string, offset int, limit int) ([]*model.OutgoingWebhook, error) {
+	_, span := tracing.Start(context.Background(), "WebhookStore.GetOutgoingByTeam")
+	defer span.End()
+
+	result, err := s.WebhookStore.GetOutgoingByTeam(teamID, offset, limit)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerWebhookStore) GetOutgoingByTeamByUser(teamID string, userID string, offset int, limit int) ([]*model.OutgoingWebhook, error) {
+	_, span := tracing.Start(context.Background(), "WebhookStore.GetOutgoingByTeamByUser")
+	defer span.End()
+
+	result, err := s.WebhookStore.GetOutgoingByTeamByUser(teamID, userID, offset, limit)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerWebhookStore) GetOutgoingList(offset int, limit int) ([]*model.OutgoingWebhook, error) {
+	_, span := tracing.Start(context.Background(), "WebhookStore.GetOutgoingList")
+	defer span.End()
+
+	result, err := s.WebhookStore.GetOutgoingList(offset, limit)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerWebhookStore) GetOutgoingListByUser(userID string, offset int, limit int) ([]*model.OutgoingWebhook, error) {
+	_, span := tracing.Start(context.Background(), "WebhookStore.GetOutgoingListByUser")
+	defer span.End()
+
+	result, err := s.WebhookStore.GetOutgoingListByUser(userID, offset, limit)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerWebhookStore) InvalidateWebhookCache(webhook string) {
+	_, span := tracing.Start(context.Background(), "WebhookStore.InvalidateWebhookCache")
+	defer span.End()
+
+	s.WebhookStore.InvalidateWebhookCache(webhook)
+}
+
+func (s *TracingLayerWebhookStore) PermanentDeleteIncomingByChannel(channelID string) error {
+	_, span := tracing.Start(context.Background(), "WebhookStore.PermanentDeleteIncomingByChannel")
+	defer span.End()
+
+	err := s.WebhookStore.PermanentDeleteIncomingByChannel(channelID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerWebhookStore) PermanentDeleteIncomingByUser(userID string) error {
+	_, span := tracing.Start(context.Background(), "WebhookStore.PermanentDeleteIncomingByUser")
+	defer span.End()
+
+	err := s.WebhookStore.PermanentDeleteIncomingByUser(userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerWebhookStore) PermanentDeleteOutgoingByChannel(channelID string) error {
+	_, span := tracing.Start(context.Background(), "WebhookStore.PermanentDeleteOutgoingByChannel")
+	defer span.End()
+
+	err := s.WebhookStore.PermanentDeleteOutgoingByChannel(channelID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerWebhookStore) PermanentDeleteOutgoingByUser(userID string) error {
+	_, span := tracing.Start(context.Background(), "WebhookStore.PermanentDeleteOutgoingByUser")
+	defer span.End()
+
+	err := s.WebhookStore.PermanentDeleteOutgoingByUser(userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerWebhookStore) SaveIncoming(webhook *model.IncomingWebhook) (*model.IncomingWebhook, error) {
+	_, span := tracing.Start(context.Background(), "WebhookStore.SaveIncoming")
+	defer span.End()
+
+	result, err := s.WebhookStore.SaveIncoming(webhook)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerWebhookStore) SaveOutgoing(webhook *model.OutgoingWebhook) (*model.OutgoingWebhook, error) {
+	_, span := tracing.Start(context.Background(), "WebhookStore.SaveOutgoing")
+	defer span.End()
+
+	result, err := s.WebhookStore.SaveOutgoing(webhook)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerWebhookStore) UpdateIncoming(webhook *model.IncomingWebhook) (*model.IncomingWebhook, error) {
+	_, span := tracing.Start(context.Background(), "WebhookStore.UpdateIncoming")
+	defer span.End()
+
+	result, err := s.WebhookStore.UpdateIncoming(webhook)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerWebhookStore) UpdateOutgoing(hook *model.OutgoingWebhook) (*model.OutgoingWebhook, error) {
+	_, span := tracing.Start(context.Background(), "WebhookStore.UpdateOutgoing")
+	defer span.End()
+
+	result, err := s.WebhookStore.UpdateOutgoing(hook)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerWorkingHoursStore) Get(userID string) (*model.WorkingHours, error) {
+	_, span := tracing.Start(context.Background(), "WorkingHoursStore.Get")
+	defer span.End()
+
+	result, err := s.WorkingHoursStore.Get(userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerWorkingHoursStore) GetEnabled(afterUserID string, limit int) ([]*model.WorkingHours, error) {
+	_, span := tracing.Start(context.Background(), "WorkingHoursStore.GetEnabled")
+	defer span.End()
+
+	result, err := s.WorkingHoursStore.GetEnabled(afterUserID, limit)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerWorkingHoursStore) PermanentDeleteByUser(userID string) error {
+	_, span := tracing.Start(context.Background(), "WorkingHoursStore.PermanentDeleteByUser")
+	defer span.End()
+
+	err := s.WorkingHoursStore.PermanentDeleteByUser(userID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayerWorkingHoursStore) Save(workingHours *model.WorkingHours) (*model.WorkingHours, error) {
+	_, span := tracing.Start(context.Background(), "WorkingHoursStore.Save")
+	defer span.End()
+
+	result, err := s.WorkingHoursStore.Save(workingHours)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (s *TracingLayerWorkingHoursStore) SetInQuietHours(userID string, inQuietHours bool, quietStatusSet bool) error {
+	_, span := tracing.Start(context.Background(), "WorkingHoursStore.SetInQuietHours")
+	defer span.End()
+
+	err := s.WorkingHoursStore.SetInQuietHours(userID, inQuietHours, quietStatusSet)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func (s *TracingLayer) Close() {
+	s.Store.Close()
+}
+
+func (s *TracingLayer) DropAllTables() {
+	s.Store.DropAllTables()
+}
+
+func (s *TracingLayer) LockToMaster() {
+	s.Store.LockToMaster()
+}
+
+func (s *TracingLayer) MarkSystemRanUnitTests() {
+	s.Store.MarkSystemRanUnitTests()
+}
+
+func (s *TracingLayer) TotalMasterDbConnections() int {
+	return s.Store.TotalMasterDbConnections()
+}
+
+func (s *TracingLayer) TotalReadDbConnections() int {
+	return s.Store.TotalReadDbConnections()
+}
+
+func (s *TracingLayer) TotalSearchDbConnections() int {
+	return s.Store.TotalSearchDbConnections()
+}
+
+func (s *TracingLayer) UnlockFromMaster() {
+	s.Store.UnlockFromMaster()
+}
+
+func New(childStore store.Store) *TracingLayer {
+	newStore := TracingLayer{
+		Store: childStore,
+	}
+
+	newStore.AccessControlPolicyStore = &TracingLayerAccessControlPolicyStore{AccessControlPolicyStore: childStore.AccessControlPolicy(), Root: &newStore}
+	newStore.AttributesStore = &TracingLayerAttributesStore{AttributesStore: childStore.Attributes(), Root: &newStore}
+	newStore.AuditStore = &TracingLayerAuditStore{AuditStore: childStore.Audit(), Root: &newStore}
+	newStore.AuditLogStore = &TracingLayerAuditLogStore{AuditLogStore: childStore.AuditLog(), Root: &newStore}
+	newStore.AutoTranslationStore = &TracingLayerAutoTranslationStore{AutoTranslationStore: childStore.AutoTranslation(), Root: &newStore}
+	newStore.BotStore = &TracingLayerBotStore{BotStore: childStore.Bot(), Root: &newStore}
+	newStore.ChannelStore = &TracingLayerChannelStore{ChannelStore: childStore.Channel(), Root: &newStore}
+	newStore.ChannelBookmarkStore = &TracingLayerChannelBookmarkStore{ChannelBookmarkStore: childStore.ChannelBookmark(), Root: &newStore}
+	newStore.ChannelMemberHistoryStore = &TracingLayerChannelMemberHistoryStore{ChannelMemberHistoryStore: childStore.ChannelMemberHistory(), Root: &newStore}
+	newStore.ClusterDiscoveryStore = &TracingLayerClusterDiscoveryStore{ClusterDiscoveryStore: childStore.ClusterDiscovery(), Root: &newStore}
+	newStore.CommandStore = &TracingLayerCommandStore{CommandStore: childStore.Command(), Root: &newStore}
+	newStore.CommandWebhookStore = &TracingLayerCommandWebhookStore{CommandWebhookStore: childStore.CommandWebhook(), Root: &newStore}
+	newStore.ComplianceStore = &TracingLayerComplianceStore{ComplianceStore: childStore.Compliance(), Root: &newStore}
+	newStore.ContentFlaggingStore = &TracingLayerContentFlaggingStore{ContentFlaggingStore: childStore.ContentFlagging(), Root: &newStore}
+	newStore.DesktopTokensStore = &TracingLayerDesktopTokensStore{DesktopTokensStore: childStore.DesktopTokens(), Root: &newStore}
+	newStore.DraftStore = &TracingLayerDraftStore{DraftStore: childStore.Draft(), Root: &newStore}
+	newStore.EmailBatchNotificationStore = &TracingLayerEmailBatchNotificationStore{EmailBatchNotificationStore: childStore.EmailBatchNotification(), Root: &newStore}
+	newStore.EmojiStore = &TracingLayerEmojiStore{EmojiStore: childStore.Emoji(), Root: &newStore}
+	newStore.EventSubscriptionStore = &TracingLayerEventSubscriptionStore{EventSubscriptionStore: childStore.EventSubscription(), Root: &newStore}
+	newStore.FileInfoStore = &TracingLayerFileInfoStore{FileInfoStore: childStore.FileInfo(), Root: &newStore}
+	newStore.GroupStore = &TracingLayerGroupStore{GroupStore: childStore.Group(), Root: &newStore}
+	newStore.JobStore = &TracingLayerJobStore{JobStore: childStore.Job(), Root: &newStore}
+	newStore.LicenseStore = &TracingLayerLicenseStore{LicenseStore: childStore.License(), Root: &newStore}
+	newStore.LinkMetadataStore = &TracingLayerLinkMetadataStore{LinkMetadataStore: childStore.LinkMetadata(), Root: &newStore}
+	newStore.NotificationRuleStore = &TracingLayerNotificationRuleStore{NotificationRuleStore: childStore.NotificationRule(), Root: &newStore}
+	newStore.NotifyAdminStore = &TracingLayerNotifyAdminStore{NotifyAdminStore: childStore.NotifyAdmin(), Root: &newStore}
+	newStore.OAuthStore = &TracingLayerOAuthStore{OAuthStore: childStore.OAuth(), Root: &newStore}
+	newStore.OutgoingOAuthConnectionStore = &TracingLayerOutgoingOAuthConnectionStore{OutgoingOAuthConnectionStore: childStore.OutgoingOAuthConnection(), Root: &newStore}
+	newStore.PluginStore = &TracingLayerPluginStore{PluginStore: childStore.Plugin(), Root: &newStore}
+	newStore.PostStore = &TracingLayerPostStore{PostStore: childStore.Post(), Root: &newStore}
+	newStore.PostAcknowledgementStore = &TracingLayerPostAcknowledgementStore{PostAcknowledgementStore: childStore.PostAcknowledgement(), Root: &newStore}
+	newStore.PostPersistentNotificationStore = &TracingLayerPostPersistentNotificationStore{PostPersistentNotificationStore: childStore.PostPersistentNotification(), Root: &newStore}
+	newStore.PostPriorityStore = &TracingLayerPostPriorityStore{PostPriorityStore: childStore.PostPriority(), Root: &newStore}
+	newStore.PreferenceStore = &TracingLayerPreferenceStore{PreferenceStore: childStore.Preference(), Root: &newStore}
+	newStore.ProductNoticesStore = &TracingLayerProductNoticesStore{ProductNoticesStore: childStore.ProductNotices(), Root: &newStore}
+	newStore.PropertyFieldStore = &TracingLayerPropertyFieldStore{PropertyFieldStore: childStore.PropertyField(), Root: &newStore}
+	newStore.PropertyGroupStore = &TracingLayerPropertyGroupStore{PropertyGroupStore: childStore.PropertyGroup(), Root: &newStore}
+	newStore.PropertyValueStore = &TracingLayerPropertyValueStore{PropertyValueStore: childStore.PropertyValue(), Root: &newStore}
+	newStore.ReactionStore = &TracingLayerReactionStore{ReactionStore: childStore.Reaction(), Root: &newStore}
+	newStore.RemoteClusterStore = &TracingLayerRemoteClusterStore{RemoteClusterStore: childStore.RemoteCluster(), Root: &newStore}
+	newStore.RetentionPolicyStore = &TracingLayerRetentionPolicyStore{RetentionPolicyStore: childStore.RetentionPolicy(), Root: &newStore}
+	newStore.RoleStore = &TracingLayerRoleStore{RoleStore: childStore.Role(), Root: &newStore}
+	newStore.ScheduledPostStore = &TracingLayerScheduledPostStore{ScheduledPostStore: childStore.ScheduledPost(), Root: &newStore}
+	newStore.SchemeStore = &TracingLayerSchemeStore{SchemeStore: childStore.Scheme(), Root: &newStore}
+	newStore.SessionStore = &TracingLayerSessionStore{SessionStore: childStore.Session(), Root: &newStore}
+	newStore.SharedChannelStore = &TracingLayerSharedChannelStore{SharedChannelStore: childStore.SharedChannel(), Root: &newStore}
+	newStore.StatusStore = &TracingLayerStatusStore{StatusStore: childStore.Status(), Root: &newStore}
+	newStore.SystemStore = &TracingLayerSystemStore{SystemStore: childStore.System(), Root: &newStore}
+	newStore.TeamStore = &TracingLayerTeamStore{TeamStore: childStore.Team(), Root: &newStore}
+	newStore.TermsOfServiceStore = &TracingLayerTermsOfServiceStore{TermsOfServiceStore: childStore.TermsOfService(), Root: &newStore}
+	newStore.ThreadStore = &TracingLayerThreadStore{ThreadStore: childStore.Thread(), Root: &newStore}
+	newStore.TokenStore = &TracingLayerTokenStore{TokenStore: childStore.Token(), Root: &newStore}
+	newStore.UploadSessionStore = &TracingLayerUploadSessionStore{UploadSessionStore: childStore.UploadSession(), Root: &newStore}
+	newStore.UserStore = &TracingLayerUserStore{UserStore: childStore.User(), Root: &newStore}
+	newStore.UserAccessTokenStore = &TracingLayerUserAccessTokenStore{UserAccessTokenStore: childStore.UserAccessToken(), Root: &newStore}
+	newStore.UserTermsOfServiceStore = &TracingLayerUserTermsOfServiceStore{UserTermsOfServiceStore: childStore.UserTermsOfService(), Root: &newStore}
+	newStore.WebhookStore = &TracingLayerWebhookStore{WebhookStore: childStore.Webhook(), Root: &newStore}
+	newStore.WorkingHoursStore = &TracingLayerWorkingHoursStore{WorkingHoursStore: childStore.WorkingHours(), Root: &newStore}
+	return &newStore
+}
@@ -17,7 +17,7 @@ import (
 )
 
 type Context struct {
-	App           *app.App
+	App           app.AppIface
 	AppContext    request.CTX
 	Logger        *mlog.Logger
 	Params        *Params
@@ -16,13 +16,17 @@ import (
 	"time"
 
 	"github.com/klauspost/compress/gzhttp"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/mattermost/mattermost/server/public/model"
 	"github.com/mattermost/mattermost/server/public/shared/i18n"
 	"github.com/mattermost/mattermost/server/public/shared/mlog"
 	"github.com/mattermost/mattermost/server/public/shared/request"
 	"github.com/mattermost/mattermost/server/v8/channels/app"
+	"github.com/mattermost/mattermost/server/v8/channels/app/tracinglayer"
 	"github.com/mattermost/mattermost/server/v8/channels/utils"
+	"github.com/mattermost/mattermost/server/v8/platform/services/tracing"
 )
 
 func GetHandlerName(h func(*Context, http.ResponseWriter, *http.Request)) string {
@@ -78,6 +82,9 @@ type Handler struct {
 	IsLocal                   bool
 	DisableWhenBusy           bool
 	FileAPI                   bool
+	// SkipRecordWrite is set for the handlers of non-GET requests that don't change data their
+	// caller reads back, such as searches, so that they don't send the caller's reads to master.
+	SkipRecordWrite bool
 
 	cspShaDirective string
 }
@@ -179,9 +186,24 @@ func (h Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		}
 	}()
 
+	// Streaming requests are left out, as their span would last as long as the connection.
+	ctx := context.Background()
+	if !isStreamingRequest(r) {
+		var span trace.Span
+		ctx, span = tracing.StartHTTPServerSpan(r, h.HandlerName)
+		span.SetAttributes(attribute.String("request_id", requestID))
+		defer func() {
+			tracing.EndHTTPServerSpan(span, w.(*responseWriterWrapper).StatusCode())
+		}()
+
+		if *c.App.Config().TracingSettings.Enable {
+			c.App = tracinglayer.New(c.App, ctx)
+		}
+	}
+
 	t, _ := i18n.GetTranslationsAndLocaleFromRequest(r)
 	c.AppContext = request.NewContext(
-		context.Background(),
+		ctx,
 		requestID,
 		utils.GetIPAddress(r, c.App.Config().ServiceSettings.TrustedProxyIPHeader),
 		r.Header.Get("X-Forwarded-For"),
@@ -271,14 +293,6 @@ func (h Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 			c.AppContext = c.AppContext.WithSession(session)
 		}
 
-		// Rate limit by UserID
-		if c.App.Srv().RateLimiter != nil {
-			rateLimitExceeded = c.App.Srv().RateLimiter.UserIdRateLimit(c.AppContext.Session().UserId, w)
-			if rateLimitExceeded {
-				return
-			}
-		}
-
 		csrfChecked, csrfPassed := h.checkCSRFToken(c, r, tokenLocation, session)
 		if csrfChecked && !csrfPassed {
 			c.AppContext = c.AppContext.WithSession(&model.Session{})
@@ -311,6 +325,22 @@ func (h Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	// Rate limit by the rules matching the request, or by the default limit
+	if rateLimiter := c.App.Srv().RateLimiter; rateLimiter != nil {
+		path := r.URL.Path
+		if subpath, _ := utils.GetSubpathFromConfig(c.App.Config()); subpath != "/" {
+			path = strings.TrimPrefix(path, subpath)
+		}
+
+		rateLimitExceeded = rateLimiter.RequestRateLimit(r, path, c.AppContext.Session(), c.AppContext.IPAddress(), w)
+		if rateLimitExceeded {
+			return
+		}
+	}
+
+	// Read from master while the replicas may not have the caller's recent writes yet
+	c.AppContext = c.App.Srv().Platform().ReadYourWritesContext(c.AppContext)
+
 	c.Logger = c.App.Log().With(
 		mlog.String("path", c.AppContext.Path()),
 		mlog.String("request_id", c.AppContext.RequestId()),
@@ -351,6 +381,14 @@ func (h Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	if h.recordsWrite(r.Method) {
+		// The write is recorded before the response is sent, so that the caller's next
+		// requests read from master. Failed requests may have written too.
+		w.(*responseWriterWrapper).beforeHeader = func() {
+			c.App.Srv().Platform().RecordWrite(c.AppContext)
+		}
+	}
+
 	if c.Err == nil {
 		h.HandleFunc(c, w, r)
 	}
@@ -360,6 +398,27 @@ func (h Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		h.handleContextError(c, w, r)
 		return
 	}
+
+	// The response of the handlers that don't write one is sent once they return.
+	w.(*responseWriterWrapper).runBeforeHeader()
+}
+
+// isStreamingRequest reports whether the request is held open for as long as the client
+// stays connected, which is the case of the websocket endpoints and their server-sent
+// events and long polling fallbacks.
+func isStreamingRequest(r *http.Request) bool {
+	return strings.HasPrefix(r.URL.Path, model.APIURLSuffix+"/websocket")
+}
+
+// recordsWrite reports whether the requests of the method sent to the handler are recorded as
+// writes of their caller.
+func (h Handler) recordsWrite(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return false
+	default:
+		return !h.SkipRecordWrite
+	}
 }
 
 func (h Handler) recordMetrics(c *Context, r *http.Request, now time.Time, statusCode string) {
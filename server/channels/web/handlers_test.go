@@ -906,6 +906,31 @@ func TestCheckCSRFToken(t *testing.T) {
 	})
 }
 
+func TestIsStreamingRequest(t *testing.T) {
+	for path, expected := range map[string]bool{
+		"/api/v4/websocket":         true,
+		"/api/v4/websocket/events":  true,
+		"/api/v4/websocket/poll":    true,
+		"/api/v4/users/me":          false,
+		"/api/v4/posts/websocket":   false,
+		"/plugins/websocket/events": false,
+	} {
+		t.Run(path, func(t *testing.T) {
+			assert.Equal(t, expected, isStreamingRequest(httptest.NewRequest(http.MethodGet, path, nil)))
+		})
+	}
+}
+
+func TestHandlerRecordsWrite(t *testing.T) {
+	for _, method := range []string{http.MethodGet, http.MethodHead, http.MethodOptions} {
+		assert.False(t, Handler{}.recordsWrite(method), method)
+	}
+	for _, method := range []string{http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete} {
+		assert.True(t, Handler{}.recordsWrite(method), method)
+		assert.False(t, Handler{SkipRecordWrite: true}.recordsWrite(method), method)
+	}
+}
+
 func TestGetOriginClient(t *testing.T) {
 	testCases := []struct {
 		name           string
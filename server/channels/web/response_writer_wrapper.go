@@ -16,6 +16,9 @@ type responseWriterWrapper struct {
 	statusCodeWritten bool
 	hijacker          http.Hijacker
 	flusher           http.Flusher
+
+	// beforeHeader, if set, is called once right before the status code is sent.
+	beforeHeader func()
 }
 
 func newWrappedWriter(original http.ResponseWriter) *responseWriterWrapper {
@@ -33,7 +36,15 @@ func (rw *responseWriterWrapper) StatusCode() int {
 	return rw.statusCode
 }
 
+func (rw *responseWriterWrapper) runBeforeHeader() {
+	if beforeHeader := rw.beforeHeader; beforeHeader != nil {
+		rw.beforeHeader = nil
+		beforeHeader()
+	}
+}
+
 func (rw *responseWriterWrapper) WriteHeader(statusCode int) {
+	rw.runBeforeHeader()
 	rw.statusCode = statusCode
 	rw.statusCodeWritten = true
 	rw.ResponseWriter.WriteHeader(statusCode)
@@ -41,6 +52,7 @@ func (rw *responseWriterWrapper) WriteHeader(statusCode int) {
 
 func (rw *responseWriterWrapper) Write(data []byte) (int, error) {
 	if !rw.statusCodeWritten {
+		rw.runBeforeHeader()
 		rw.statusCode = http.StatusOK
 	}
 	return rw.ResponseWriter.Write(data)
@@ -60,3 +72,9 @@ func (rw *responseWriterWrapper) Flush() {
 		rw.flusher.Flush()
 	}
 }
+
+// Unwrap gives http.ResponseController access to the original ResponseWriter,
+// e.g. to set the write deadline of a streamed response.
+func (rw *responseWriterWrapper) Unwrap() http.ResponseWriter {
+	return rw.ResponseWriter
+}
@@ -94,3 +94,54 @@ func TestForSupportedFlush(t *testing.T) {
 	handler.ServeHTTP(resp, req)
 	assert.Equal(t, http.StatusOK, resp.StatusCode())
 }
+
+func TestForUnwrap(t *testing.T) {
+	original := httptest.NewRecorder()
+	resp := newWrappedWriter(original)
+	req := httptest.NewRequest("GET", "/api/v4/test", nil)
+	handler := TestHandler{func(w http.ResponseWriter, r *http.Request) {
+		assert.Same(t, original, w.(*responseWriterWrapper).Unwrap())
+		require.NoError(t, http.NewResponseController(w).Flush(), "Flush should be reachable through the ResponseController")
+	}}
+	handler.ServeHTTP(resp, req)
+	assert.True(t, original.Flushed)
+}
+
+type orderRecorder struct {
+	*httptest.ResponseRecorder
+	events *[]string
+}
+
+func (r orderRecorder) WriteHeader(statusCode int) {
+	*r.events = append(*r.events, "header")
+	r.ResponseRecorder.WriteHeader(statusCode)
+}
+
+func (r orderRecorder) Write(data []byte) (int, error) {
+	*r.events = append(*r.events, "write")
+	return r.ResponseRecorder.Write(data)
+}
+
+func TestBeforeHeader(t *testing.T) {
+	for name, write := range map[string]func(w http.ResponseWriter){
+		"WriteHeader": func(w http.ResponseWriter) { w.WriteHeader(http.StatusCreated) },
+		"Write":       func(w http.ResponseWriter) { _, _ = w.Write([]byte("body")) },
+	} {
+		t.Run(name, func(t *testing.T) {
+			var events []string
+			resp := newWrappedWriter(orderRecorder{httptest.NewRecorder(), &events})
+			resp.beforeHeader = func() {
+				events = append(events, "before")
+			}
+
+			write(resp)
+			_, err := resp.Write([]byte("more"))
+			require.NoError(t, err)
+			resp.runBeforeHeader()
+
+			require.NotEmpty(t, events)
+			assert.Equal(t, "before", events[0])
+			assert.NotContains(t, events[1:], "before")
+		})
+	}
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/mattermost/mattermost/server/public/model"
 	"github.com/mattermost/mattermost/server/public/plugin"
 	"github.com/mattermost/mattermost/server/v8/channels/utils"
+	"github.com/mattermost/mattermost/server/v8/platform/services/tracing"
 )
 
 const maxSAMLResponseSize = 2 * 1024 * 1024 // 2MB
@@ -197,6 +198,7 @@ func completeSaml(c *Context, w http.ResponseWriter, r *http.Request) {
 		IPAddress:      c.AppContext.IPAddress(),
 		AcceptLanguage: c.AppContext.AcceptLanguage(),
 		UserAgent:      c.AppContext.UserAgent(),
+		TraceContext:   tracing.Inject(c.AppContext.Context()),
 	}
 
 	var hookErr error
@@ -110,7 +110,8 @@ func staticFilesHandler(handler http.Handler) http.Handler {
 		//wrap our ResponseWriter with our no-cache 404-handler
 		w = &notFoundNoCacheResponseWriter{ResponseWriter: w}
 
-		if path.Base(r.URL.Path) == "remote_entry.js" {
+		// These files keep their name across builds, so browsers must check for a new version.
+		if base := path.Base(r.URL.Path); base == "remote_entry.js" || base == "service_worker.js" {
 			w.Header().Set("Cache-Control", "no-cache, max-age=31556926, public")
 		} else {
 			w.Header().Set("Cache-Control", "max-age=31556926, public")
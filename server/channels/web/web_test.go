@@ -385,6 +385,7 @@ func TestStaticFilesCaching(t *testing.T) {
 </html>`
 	fakeMainBundle := `module.exports = 'main';`
 	fakeRemoteEntry := `module.exports = 'remote';`
+	fakeServiceWorker := `self.addEventListener('push', () => {});`
 
 	err := os.WriteFile("./client/root.html", []byte(fakeRootHTML), 0600)
 	require.NoError(t, err)
@@ -392,6 +393,8 @@ func TestStaticFilesCaching(t *testing.T) {
 	require.NoError(t, err)
 	err = os.WriteFile("./client/remote_entry.js", []byte(fakeRemoteEntry), 0600)
 	require.NoError(t, err)
+	err = os.WriteFile("./client/service_worker.js", []byte(fakeServiceWorker), 0600)
+	require.NoError(t, err)
 
 	err = os.MkdirAll("./client/products/boards", 0777)
 	require.NoError(t, err)
@@ -438,6 +441,14 @@ func TestStaticFilesCaching(t *testing.T) {
 	require.Equal(t, http.StatusOK, res.Code)
 	require.Equal(t, fakeRemoteEntry, res.Body.String())
 	require.Equal(t, []string{"no-cache, max-age=31556926, public"}, res.Result().Header[http.CanonicalHeaderKey("Cache-Control")])
+
+	req, err = http.NewRequest("GET", "/static/service_worker.js", nil)
+	require.NoError(t, err)
+	res = httptest.NewRecorder()
+	th.Web.MainRouter.ServeHTTP(res, req)
+	require.Equal(t, http.StatusOK, res.Code)
+	require.Equal(t, fakeServiceWorker, res.Body.String())
+	require.Equal(t, []string{"no-cache, max-age=31556926, public"}, res.Result().Header[http.CanonicalHeaderKey("Cache-Control")])
 }
 
 func TestCheckClientCompatability(t *testing.T) {
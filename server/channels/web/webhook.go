@@ -4,6 +4,7 @@
 package web
 
 import (
+	"bytes"
 	"encoding/json"
 	"io"
 	"mime"
@@ -27,7 +28,16 @@ func incomingWebhook(c *Context, w http.ResponseWriter, r *http.Request) {
 	id := params["id"]
 	errCtx := map[string]any{"hook_id": id}
 
-	err := r.ParseForm()
+	// The raw body is kept around so signed hooks can be verified against
+	// exactly what was sent, and templated hooks can render it.
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		c.Err = model.NewAppError("incomingWebhook", "web.incoming_webhook.parse_form.app_error", errCtx, "", http.StatusBadRequest).Wrap(err)
+		return
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	err = r.ParseForm()
 	if err != nil {
 		c.Err = model.NewAppError("incomingWebhook", "web.incoming_webhook.parse_form.app_error", errCtx, "", http.StatusBadRequest).Wrap(err)
 		return
@@ -64,7 +74,15 @@ func incomingWebhook(c *Context, w http.ResponseWriter, r *http.Request) {
 	}()
 
 	errCtx["media_type"] = mediaType
-	if mediaType == "application/x-www-form-urlencoded" {
+	preparedPayload, appErr := c.App.PrepareIncomingWebhookRequest(id, r.Header, body)
+	if appErr != nil {
+		c.Err = model.NewAppError("incomingWebhook", "web.incoming_webhook.general.app_error", errCtx, "", appErr.StatusCode).Wrap(appErr)
+		return
+	}
+
+	if preparedPayload != nil {
+		incomingWebhookPayload = preparedPayload
+	} else if mediaType == "application/x-www-form-urlencoded" {
 		payload := strings.NewReader(r.FormValue("payload"))
 
 		incomingWebhookPayload, appErr = decodePayload(payload)
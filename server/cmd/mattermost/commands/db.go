@@ -327,6 +327,7 @@ func ConfigToFileBackendSettings(s *model.FileSettings, enableComplianceFeature
 		return filestore.FileBackendSettings{
 			DriverName: *s.DriverName,
 			Directory:  *s.Directory,
+			Encryption: filestore.NewEncryptionSettingsFromConfig(s),
 		}
 	}
 	return filestore.FileBackendSettings{
@@ -343,5 +344,6 @@ func ConfigToFileBackendSettings(s *model.FileSettings, enableComplianceFeature
 		AmazonS3Trace:                      s.AmazonS3Trace != nil && *s.AmazonS3Trace,
 		AmazonS3RequestTimeoutMilliseconds: *s.AmazonS3RequestTimeoutMilliseconds,
 		SkipVerify:                         skipVerify,
+		Encryption:                         filestore.NewEncryptionSettingsFromConfig(s),
 	}
 }
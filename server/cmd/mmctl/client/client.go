@@ -53,6 +53,8 @@ type Client interface {
 	UploadLicenseFile(ctx context.Context, data []byte) (*model.Response, error)
 	RemoveLicenseFile(ctx context.Context) (*model.Response, error)
 	GetLogs(ctx context.Context, page, perPage int) ([]string, *model.Response, error)
+	SearchAuditLog(ctx context.Context, search model.AuditLogSearch) ([]*model.AuditLogEntry, *model.Response, error)
+	VerifyAuditLog(ctx context.Context) (*model.AuditLogVerification, *model.Response, error)
 	GetRoleByName(ctx context.Context, name string) (*model.Role, *model.Response, error)
 	PatchRole(ctx context.Context, roleID string, patch *model.RolePatch) (*model.Role, *model.Response, error)
 	UploadPlugin(ctx context.Context, file io.Reader) (*model.Manifest, *model.Response, error)
@@ -99,6 +101,9 @@ type Client interface {
 	PatchConfig(context.Context, *model.Config) (*model.Config, *model.Response, error)
 	ReloadConfig(ctx context.Context) (*model.Response, error)
 	MigrateConfig(ctx context.Context, from, to string) (*model.Response, error)
+	GetConfigHistory(ctx context.Context) ([]*model.ConfigVersion, *model.Response, error)
+	DiffConfigVersions(ctx context.Context, from, to string) ([]*model.ConfigVersionDiff, *model.Response, error)
+	RollbackConfig(ctx context.Context, versionID string) (*model.Response, error)
 	SyncLdap(ctx context.Context) (*model.Response, error)
 	MigrateIdLdap(ctx context.Context, toAttribute string) (*model.Response, error)
 	GetUsers(ctx context.Context, page, perPage int, etag string) ([]*model.User, *model.Response, error)
@@ -0,0 +1,142 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/client"
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/printer"
+
+	"github.com/spf13/cobra"
+)
+
+var AuditCmd = &cobra.Command{
+	Use:   "audit",
+	Short: "Management of the database audit log",
+	Long:  "Search and verify the audit log kept in the database when ExperimentalAuditSettings.DatabaseEnabled is set.",
+}
+
+var AuditSearchCmd = &cobra.Command{
+	Use:   "search",
+	Short: "Search the audit log",
+	Long:  "Search the entries of the audit log, most recent first.",
+	Example: `  audit search --actor userID
+  audit search --event updateConfig --status fail
+  audit search --object channelID --start 2024-01-01T00:00:00+00:00 --end 2024-02-01T00:00:00+00:00`,
+	Args: cobra.NoArgs,
+	RunE: withClient(auditSearchCmdF),
+}
+
+var AuditVerifyCmd = &cobra.Command{
+	Use:     "verify",
+	Short:   "Verify the integrity of the audit log",
+	Long:    "Check the whole audit log for removed, reordered or modified entries.",
+	Example: `  audit verify`,
+	Args:    cobra.NoArgs,
+	RunE:    withClient(auditVerifyCmdF),
+}
+
+func init() {
+	AuditSearchCmd.Flags().String("actor", "", "Filter by the ID of the user who performed the action")
+	AuditSearchCmd.Flags().String("event", "", "Filter by event name")
+	AuditSearchCmd.Flags().String("object", "", "Filter by the ID of the affected object")
+	AuditSearchCmd.Flags().String("status", "", "Filter by status, e.g. success or fail")
+	AuditSearchCmd.Flags().String("start", "", "List entries created at or after a certain time (ISO 8601)")
+	AuditSearchCmd.Flags().String("end", "", "List entries created at or before a certain time (ISO 8601)")
+	AuditSearchCmd.Flags().Int("page", 0, "Page number to fetch")
+	AuditSearchCmd.Flags().Int("per-page", model.AuditLogSearchDefaultPerPage, fmt.Sprintf("Number of entries to fetch, at most %d", model.AuditLogSearchMaxPerPage))
+
+	AuditCmd.AddCommand(
+		AuditSearchCmd,
+		AuditVerifyCmd,
+	)
+
+	RootCmd.AddCommand(AuditCmd)
+}
+
+func parseAuditTimeFlag(cmd *cobra.Command, name string) (int64, error) {
+	value, err := cmd.Flags().GetString(name)
+	if err != nil || value == "" {
+		return 0, err
+	}
+
+	t, err := time.Parse(ISO8601Layout, value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s time '%s'", name, value)
+	}
+
+	return model.GetMillisForTime(t), nil
+}
+
+func auditSearchCmdF(c client.Client, cmd *cobra.Command, _ []string) error {
+	search := model.AuditLogSearch{}
+	search.ActorId, _ = cmd.Flags().GetString("actor")
+	search.EventName, _ = cmd.Flags().GetString("event")
+	search.ObjectId, _ = cmd.Flags().GetString("object")
+	search.Status, _ = cmd.Flags().GetString("status")
+	search.Page, _ = cmd.Flags().GetInt("page")
+	search.PerPage, _ = cmd.Flags().GetInt("per-page")
+
+	var err error
+	if search.StartTime, err = parseAuditTimeFlag(cmd, "start"); err != nil {
+		return err
+	}
+	if search.EndTime, err = parseAuditTimeFlag(cmd, "end"); err != nil {
+		return err
+	}
+
+	entries, _, err := c.SearchAuditLog(context.TODO(), search)
+	if err != nil {
+		return fmt.Errorf("failed to search the audit log: %w", err)
+	}
+
+	if len(entries) == 0 {
+		printer.Print("No audit log entries found")
+		return nil
+	}
+
+	for _, entry := range entries {
+		printer.PrintT(fmt.Sprintf("{{.Sequence}}\t%s\t{{.EventName}}\t{{.Status}}\tactor: {{.ActorId}}\tobject: {{.ObjectId}}",
+			model.GetTimeForMillis(entry.CreateAt).Format(ISO8601Layout)), entry)
+	}
+
+	return nil
+}
+
+func auditVerifyCmdF(c client.Client, _ *cobra.Command, _ []string) error {
+	verification, _, err := c.VerifyAuditLog(context.TODO())
+	if err != nil {
+		return fmt.Errorf("failed to verify the audit log: %w", err)
+	}
+
+	for _, issue := range verification.Issues {
+		switch issue.Type {
+		case model.AuditLogIssueGap:
+			printer.PrintT("{{.Count}} entries missing from sequence {{.Sequence}}", issue)
+		case model.AuditLogIssueBrokenChain:
+			printer.PrintT("Entry {{.Sequence}} is not chained to the previous entry", issue)
+		case model.AuditLogIssueModified:
+			printer.PrintT("Entry {{.Sequence}} has been modified", issue)
+		case model.AuditLogIssueTruncated:
+			printer.PrintT("{{.Count}} entries missing from the end of the log, from sequence {{.Sequence}}", issue)
+		case model.AuditLogIssueInvalidAnchor:
+			printer.PrintT("The anchor of the log at entry {{.Sequence}} is not signed with the hash key", issue)
+		default:
+			printer.PrintT("Entry {{.Sequence}}: {{.Type}}", issue)
+		}
+	}
+
+	if !verification.Verified {
+		return errors.New("the audit log failed verification")
+	}
+
+	printer.PrintT("Verified {{.Checked}} audit log entries", verification)
+
+	return nil
+}
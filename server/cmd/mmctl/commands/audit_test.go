@@ -0,0 +1,124 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package commands
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/printer"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/spf13/cobra"
+)
+
+func newAuditSearchTestCmd() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Flags().String("actor", "", "")
+	cmd.Flags().String("event", "", "")
+	cmd.Flags().String("object", "", "")
+	cmd.Flags().String("status", "", "")
+	cmd.Flags().String("start", "", "")
+	cmd.Flags().String("end", "", "")
+	cmd.Flags().Int("page", 0, "")
+	cmd.Flags().Int("per-page", model.AuditLogSearchDefaultPerPage, "")
+	return cmd
+}
+
+func (s *MmctlUnitTestSuite) TestAuditSearchCmd() {
+	s.Run("Search with filters", func() {
+		printer.Clean()
+		cmd := newAuditSearchTestCmd()
+		s.Require().NoError(cmd.Flags().Set("actor", "actorId"))
+		s.Require().NoError(cmd.Flags().Set("event", model.AuditEventUpdateConfig))
+		s.Require().NoError(cmd.Flags().Set("status", model.AuditStatusSuccess))
+		s.Require().NoError(cmd.Flags().Set("start", "2024-01-01T00:00:00+00:00"))
+		s.Require().NoError(cmd.Flags().Set("per-page", "10"))
+
+		expectedSearch := model.AuditLogSearch{
+			ActorId:   "actorId",
+			EventName: model.AuditEventUpdateConfig,
+			Status:    model.AuditStatusSuccess,
+			StartTime: model.GetMillisForTime(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)),
+			PerPage:   10,
+		}
+		entries := []*model.AuditLogEntry{
+			{Id: model.NewId(), Sequence: 2, EventName: model.AuditEventUpdateConfig, Status: model.AuditStatusSuccess, ActorId: "actorId"},
+			{Id: model.NewId(), Sequence: 1, EventName: model.AuditEventUpdateConfig, Status: model.AuditStatusSuccess, ActorId: "actorId"},
+		}
+
+		s.client.
+			EXPECT().
+			SearchAuditLog(context.TODO(), expectedSearch).
+			Return(entries, &model.Response{}, nil).
+			Times(1)
+
+		err := auditSearchCmdF(s.client, cmd, []string{})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 2)
+		s.Require().Equal(entries[0], printer.GetLines()[0])
+		s.Require().Equal(entries[1], printer.GetLines()[1])
+	})
+
+	s.Run("Invalid time", func() {
+		printer.Clean()
+		cmd := newAuditSearchTestCmd()
+		s.Require().NoError(cmd.Flags().Set("end", "yesterday"))
+
+		err := auditSearchCmdF(s.client, cmd, []string{})
+		s.Require().EqualError(err, "invalid end time 'yesterday'")
+		s.Require().Len(printer.GetLines(), 0)
+	})
+
+	s.Run("Search fails", func() {
+		printer.Clean()
+
+		s.client.
+			EXPECT().
+			SearchAuditLog(context.TODO(), model.AuditLogSearch{PerPage: model.AuditLogSearchDefaultPerPage}).
+			Return(nil, &model.Response{}, errors.New("mock error")).
+			Times(1)
+
+		err := auditSearchCmdF(s.client, newAuditSearchTestCmd(), []string{})
+		s.Require().EqualError(err, "failed to search the audit log: mock error")
+		s.Require().Len(printer.GetLines(), 0)
+	})
+}
+
+func (s *MmctlUnitTestSuite) TestAuditVerifyCmd() {
+	s.Run("Verified audit log", func() {
+		printer.Clean()
+
+		verification := &model.AuditLogVerification{Verified: true, Checked: 42, Issues: []*model.AuditLogIssue{}}
+		s.client.
+			EXPECT().
+			VerifyAuditLog(context.TODO()).
+			Return(verification, &model.Response{}, nil).
+			Times(1)
+
+		err := auditVerifyCmdF(s.client, &cobra.Command{}, []string{})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 1)
+		s.Require().Equal(verification, printer.GetLines()[0])
+	})
+
+	s.Run("Tampered audit log", func() {
+		printer.Clean()
+
+		gap := &model.AuditLogIssue{Sequence: 3, Type: model.AuditLogIssueGap, Count: 2}
+		modified := &model.AuditLogIssue{Sequence: 7, Type: model.AuditLogIssueModified}
+		s.client.
+			EXPECT().
+			VerifyAuditLog(context.TODO()).
+			Return(&model.AuditLogVerification{Checked: 8, Issues: []*model.AuditLogIssue{gap, modified}}, &model.Response{}, nil).
+			Times(1)
+
+		err := auditVerifyCmdF(s.client, &cobra.Command{}, []string{})
+		s.Require().EqualError(err, "the audit log failed verification")
+		s.Require().Len(printer.GetLines(), 2)
+		s.Require().Equal(gap, printer.GetLines()[0])
+		s.Require().Equal(modified, printer.GetLines()[1])
+	})
+}
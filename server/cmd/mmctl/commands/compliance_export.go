@@ -59,9 +59,9 @@ var ComplianceExportDownloadCmd = &cobra.Command{
 var ComplianceExportCreateCmd = &cobra.Command{
 	Use:     "create [complianceExportType] --date \"2025-03-27 -0400\"",
 	Example: "compliance-export create csv --date \"2025-03-27 -0400\"",
-	Long: "Create a compliance export job, of type 'csv' or 'actiance' or 'globalrelay'. If --date is set, the job will run for one day, from 12am to 12am (minus one millisecond) inclusively, in the format with timezone offset: `\"YYYY-MM-DD -0000\"`. E.g., \"2024-10-21 -0400\" for Oct 21, 2024 EDT timezone. \"2023-11-01 +0000\" for Nov 01, 2024 UTC. If set, the 'start' and 'end' flags will be ignored.\n\n" +
+	Long: "Create a compliance export job, of type 'csv', 'actiance', 'globalrelay', 'jsonl' or 'eml'. If --date is set, the job will run for one day, from 12am to 12am (minus one millisecond) inclusively, in the format with timezone offset: `\"YYYY-MM-DD -0000\"`. E.g., \"2024-10-21 -0400\" for Oct 21, 2024 EDT timezone. \"2023-11-01 +0000\" for Nov 01, 2024 UTC. If set, the 'start' and 'end' flags will be ignored.\n\n" +
 		"Important: Running a compliance export job from mmctl will NOT affect the next scheduled job's batch_start_time. This means that if you run a compliance export job from mmctl, the next scheduled job will run from the batch_end_time of the previous scheduled job, as usual.",
-	Short: "Create a compliance export job, of type 'csv' or 'actiance' or 'globalrelay'",
+	Short: "Create a compliance export job, of type 'csv', 'actiance', 'globalrelay', 'jsonl' or 'eml'",
 	Args:  cobra.MinimumNArgs(1),
 	RunE:  withClient(complianceExportCreateCmdF),
 }
@@ -164,8 +164,10 @@ func complianceExportCreateCmdF(c client.Client, command *cobra.Command, args []
 	exportType := args[0]
 	if exportType != model.ComplianceExportTypeActiance &&
 		exportType != model.ComplianceExportTypeCsv &&
-		exportType != model.ComplianceExportTypeGlobalrelay {
-		return fmt.Errorf("invalid export type: %s, must be one of: csv, actiance, globalrelay", exportType)
+		exportType != model.ComplianceExportTypeGlobalrelay &&
+		exportType != model.ComplianceExportTypeJsonl &&
+		exportType != model.ComplianceExportTypeEml {
+		return fmt.Errorf("invalid export type: %s, must be one of: csv, actiance, globalrelay, jsonl, eml", exportType)
 	}
 
 	dateStr, err := command.Flags().GetString("date")
@@ -12,6 +12,7 @@ import (
 	"reflect"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/mattermost/mattermost/server/public/model"
 	"github.com/mattermost/mattermost/server/v8/channels/utils"
@@ -129,7 +130,36 @@ var ConfigExportCmd = &cobra.Command{
 	RunE:    withClient(configExportCmdF),
 }
 
+var ConfigHistoryCmd = &cobra.Command{
+	Use:     "history",
+	Short:   "List the saved versions of the configuration",
+	Long:    "Lists the saved versions of the server configuration with their author and creation time, most recent first.",
+	Example: "config history",
+	Args:    cobra.NoArgs,
+	RunE:    withClient(configHistoryCmdF),
+}
+
+var ConfigDiffCmd = &cobra.Command{
+	Use:     "diff <from_version> [to_version]",
+	Short:   "Show the differences between two versions of the configuration",
+	Long:    "Shows the differences between two saved versions of the server configuration. If the second version is omitted, the active version is used. Sensitive values are redacted.",
+	Example: "config diff 7ydk5ibd7bdapqyd4f6kxhs4ge\nconfig diff 7ydk5ibd7bdapqyd4f6kxhs4ge qmhe3ouo9inz5pg1xr9ha89z6o",
+	Args:    cobra.RangeArgs(1, 2),
+	RunE:    withClient(configDiffCmdF),
+}
+
+var ConfigRollbackCmd = &cobra.Command{
+	Use:     "rollback <version>",
+	Short:   "Restore a previous version of the configuration",
+	Long:    "Validates a saved version of the server configuration and makes it the active one.",
+	Example: "config rollback 7ydk5ibd7bdapqyd4f6kxhs4ge",
+	Args:    cobra.ExactArgs(1),
+	RunE:    withClient(configRollbackCmdF),
+}
+
 func init() {
+	ConfigRollbackCmd.Flags().Bool("confirm", false, "confirm you really want to restore the configuration version")
+
 	ConfigResetCmd.Flags().Bool("confirm", false, "confirm you really want to reset all configuration settings to its default value")
 
 	ConfigSubpathCmd.Flags().StringP("assets-dir", "a", "", "directory of the Mattermost assets in the local filesystem")
@@ -151,6 +181,9 @@ func init() {
 		ConfigMigrateCmd,
 		ConfigSubpathCmd,
 		ConfigExportCmd,
+		ConfigHistoryCmd,
+		ConfigDiffCmd,
+		ConfigRollbackCmd,
 	)
 	RootCmd.AddCommand(ConfigCmd)
 }
@@ -536,6 +569,68 @@ func configReloadCmdF(c client.Client, _ *cobra.Command, _ []string) error {
 	return nil
 }
 
+func configHistoryCmdF(c client.Client, _ *cobra.Command, _ []string) error {
+	versions, _, err := c.GetConfigHistory(context.TODO())
+	if err != nil {
+		return err
+	}
+
+	for _, version := range versions {
+		author := version.AuthorId
+		if author == "" {
+			author = "system"
+		}
+		active := ""
+		if version.Active {
+			active = " (active)"
+		}
+		printer.PrintT(fmt.Sprintf("{{.Id}}: %s by %s%s", time.Unix(version.CreateAt/1000, 0), author, active), version)
+	}
+
+	return nil
+}
+
+func configDiffCmdF(c client.Client, _ *cobra.Command, args []string) error {
+	var to string
+	if len(args) > 1 {
+		to = args[1]
+	}
+
+	diffs, _, err := c.DiffConfigVersions(context.TODO(), args[0], to)
+	if err != nil {
+		return err
+	}
+
+	if len(diffs) == 0 {
+		printer.Print("The configuration versions are identical")
+		return nil
+	}
+
+	for _, diff := range diffs {
+		printer.PrintT(fmt.Sprintf("{{.Path}}: %v -> %v", diff.BaseVal, diff.ActualVal), diff)
+	}
+
+	return nil
+}
+
+func configRollbackCmdF(c client.Client, cmd *cobra.Command, args []string) error {
+	confirmFlag, _ := cmd.Flags().GetBool("confirm")
+	if !confirmFlag {
+		if err := getConfirmation(fmt.Sprintf(
+			"Are you sure you want to restore configuration version %s? (YES/NO): ",
+			args[0]), false); err != nil {
+			return err
+		}
+	}
+
+	if _, err := c.RollbackConfig(context.TODO(), args[0]); err != nil {
+		return err
+	}
+
+	printer.Print("Config rolled back successfully")
+	return nil
+}
+
 func configMigrateCmdF(c client.Client, cmd *cobra.Command, args []string) error {
 	isLocal, _ := cmd.Flags().GetBool("local")
 	if !isLocal {
@@ -848,6 +848,116 @@ func (s *MmctlUnitTestSuite) TestConfigReloadCmd() {
 	})
 }
 
+func (s *MmctlUnitTestSuite) TestConfigHistoryCmd() {
+	s.Run("Should list the versions", func() {
+		printer.Clean()
+
+		versions := []*model.ConfigVersion{
+			{Id: model.NewId(), CreateAt: model.GetMillis(), AuthorId: model.NewId(), Active: true},
+			{Id: model.NewId(), CreateAt: model.GetMillis() - 1000},
+		}
+		s.client.
+			EXPECT().
+			GetConfigHistory(context.TODO()).
+			Return(versions, &model.Response{StatusCode: http.StatusOK}, nil).
+			Times(1)
+
+		err := configHistoryCmdF(s.client, &cobra.Command{}, []string{})
+		s.Require().Nil(err)
+		s.Require().Len(printer.GetLines(), 2)
+		s.Equal(versions[0], printer.GetLines()[0])
+		s.Equal(versions[1], printer.GetLines()[1])
+		s.Len(printer.GetErrorLines(), 0)
+	})
+
+	s.Run("Should fail on error", func() {
+		printer.Clean()
+
+		s.client.
+			EXPECT().
+			GetConfigHistory(context.TODO()).
+			Return(nil, &model.Response{StatusCode: http.StatusNotImplemented}, errors.New("some-error")).
+			Times(1)
+
+		err := configHistoryCmdF(s.client, &cobra.Command{}, []string{})
+		s.Require().NotNil(err)
+	})
+}
+
+func (s *MmctlUnitTestSuite) TestConfigDiffCmd() {
+	s.Run("Should diff against the active version", func() {
+		printer.Clean()
+
+		from := model.NewId()
+		diffs := []*model.ConfigVersionDiff{
+			{Path: "ServiceSettings.SiteURL", BaseVal: "http://old", ActualVal: "http://new"},
+		}
+		s.client.
+			EXPECT().
+			DiffConfigVersions(context.TODO(), from, "").
+			Return(diffs, &model.Response{StatusCode: http.StatusOK}, nil).
+			Times(1)
+
+		err := configDiffCmdF(s.client, &cobra.Command{}, []string{from})
+		s.Require().Nil(err)
+		s.Require().Len(printer.GetLines(), 1)
+		s.Equal(diffs[0], printer.GetLines()[0])
+	})
+
+	s.Run("Should diff two versions", func() {
+		printer.Clean()
+
+		from, to := model.NewId(), model.NewId()
+		s.client.
+			EXPECT().
+			DiffConfigVersions(context.TODO(), from, to).
+			Return([]*model.ConfigVersionDiff{}, &model.Response{StatusCode: http.StatusOK}, nil).
+			Times(1)
+
+		err := configDiffCmdF(s.client, &cobra.Command{}, []string{from, to})
+		s.Require().Nil(err)
+		s.Require().Len(printer.GetLines(), 1)
+		s.Equal("The configuration versions are identical", printer.GetLines()[0])
+	})
+}
+
+func (s *MmctlUnitTestSuite) TestConfigRollbackCmd() {
+	s.Run("Should roll back the config", func() {
+		printer.Clean()
+
+		versionID := model.NewId()
+		s.client.
+			EXPECT().
+			RollbackConfig(context.TODO(), versionID).
+			Return(&model.Response{StatusCode: http.StatusOK}, nil).
+			Times(1)
+
+		cmd := &cobra.Command{}
+		cmd.Flags().Bool("confirm", true, "")
+
+		err := configRollbackCmdF(s.client, cmd, []string{versionID})
+		s.Require().Nil(err)
+		s.Len(printer.GetErrorLines(), 0)
+	})
+
+	s.Run("Should fail on error", func() {
+		printer.Clean()
+
+		versionID := model.NewId()
+		s.client.
+			EXPECT().
+			RollbackConfig(context.TODO(), versionID).
+			Return(&model.Response{StatusCode: http.StatusNotFound}, errors.New("some-error")).
+			Times(1)
+
+		cmd := &cobra.Command{}
+		cmd.Flags().Bool("confirm", true, "")
+
+		err := configRollbackCmdF(s.client, cmd, []string{versionID})
+		s.Require().NotNil(err)
+	})
+}
+
 func (s *MmctlUnitTestSuite) TestConfigMigrateCmd() {
 	s.Run("Should fail without the --local flag", func() {
 		printer.Clean()
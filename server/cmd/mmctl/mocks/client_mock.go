@@ -509,6 +509,22 @@ func (mr *MockClientMockRecorder) DemoteUserToGuest(arg0, arg1 interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DemoteUserToGuest", reflect.TypeOf((*MockClient)(nil).DemoteUserToGuest), arg0, arg1)
 }
 
+// DiffConfigVersions mocks base method.
+func (m *MockClient) DiffConfigVersions(arg0 context.Context, arg1, arg2 string) ([]*model.ConfigVersionDiff, *model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DiffConfigVersions", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*model.ConfigVersionDiff)
+	ret1, _ := ret[1].(*model.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// DiffConfigVersions indicates an expected call of DiffConfigVersions.
+func (mr *MockClientMockRecorder) DiffConfigVersions(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiffConfigVersions", reflect.TypeOf((*MockClient)(nil).DiffConfigVersions), arg0, arg1, arg2)
+}
+
 // DisableBot mocks base method.
 func (m *MockClient) DisableBot(arg0 context.Context, arg1 string) (*model.Bot, *model.Response, error) {
 	m.ctrl.T.Helper()
@@ -842,6 +858,22 @@ func (mr *MockClientMockRecorder) GetConfig(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConfig", reflect.TypeOf((*MockClient)(nil).GetConfig), arg0)
 }
 
+// GetConfigHistory mocks base method.
+func (m *MockClient) GetConfigHistory(arg0 context.Context) ([]*model.ConfigVersion, *model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetConfigHistory", arg0)
+	ret0, _ := ret[0].([]*model.ConfigVersion)
+	ret1, _ := ret[1].(*model.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetConfigHistory indicates an expected call of GetConfigHistory.
+func (mr *MockClientMockRecorder) GetConfigHistory(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConfigHistory", reflect.TypeOf((*MockClient)(nil).GetConfigHistory), arg0)
+}
+
 // GetConfigWithOptions mocks base method.
 func (m *MockClient) GetConfigWithOptions(arg0 context.Context, arg1 model.GetConfigOptions) (map[string]interface{}, *model.Response, error) {
 	m.ctrl.T.Helper()
@@ -2170,6 +2202,37 @@ func (mr *MockClientMockRecorder) RevokeUserAccessToken(arg0, arg1 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeUserAccessToken", reflect.TypeOf((*MockClient)(nil).RevokeUserAccessToken), arg0, arg1)
 }
 
+// RollbackConfig mocks base method.
+func (m *MockClient) RollbackConfig(arg0 context.Context, arg1 string) (*model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RollbackConfig", arg0, arg1)
+	ret0, _ := ret[0].(*model.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RollbackConfig indicates an expected call of RollbackConfig.
+func (mr *MockClientMockRecorder) RollbackConfig(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RollbackConfig", reflect.TypeOf((*MockClient)(nil).RollbackConfig), arg0, arg1)
+}
+
+// SearchAuditLog mocks base method.
+func (m *MockClient) SearchAuditLog(arg0 context.Context, arg1 model.AuditLogSearch) ([]*model.AuditLogEntry, *model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchAuditLog", arg0, arg1)
+	ret0, _ := ret[0].([]*model.AuditLogEntry)
+	ret1, _ := ret[1].(*model.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// SearchAuditLog indicates an expected call of SearchAuditLog.
+func (mr *MockClientMockRecorder) SearchAuditLog(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchAuditLog", reflect.TypeOf((*MockClient)(nil).SearchAuditLog), arg0, arg1)
+}
+
 // SearchTeams mocks base method.
 func (m *MockClient) SearchTeams(arg0 context.Context, arg1 *model.TeamSearch) ([]*model.Team, *model.Response, error) {
 	m.ctrl.T.Helper()
@@ -2558,6 +2621,22 @@ func (mr *MockClientMockRecorder) UploadPluginForced(arg0, arg1 interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadPluginForced", reflect.TypeOf((*MockClient)(nil).UploadPluginForced), arg0, arg1)
 }
 
+// VerifyAuditLog mocks base method.
+func (m *MockClient) VerifyAuditLog(arg0 context.Context) (*model.AuditLogVerification, *model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyAuditLog", arg0)
+	ret0, _ := ret[0].(*model.AuditLogVerification)
+	ret1, _ := ret[1].(*model.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// VerifyAuditLog indicates an expected call of VerifyAuditLog.
+func (mr *MockClientMockRecorder) VerifyAuditLog(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyAuditLog", reflect.TypeOf((*MockClient)(nil).VerifyAuditLog), arg0)
+}
+
 // VerifyUserEmailWithoutToken mocks base method.
 func (m *MockClient) VerifyUserEmailWithoutToken(arg0 context.Context, arg1 string) (*model.User, *model.Response, error) {
 	m.ctrl.T.Helper()
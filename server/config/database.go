@@ -165,11 +165,15 @@ func parseDSN(dsn string) (string, string, error) {
 
 // Set replaces the current configuration in its entirety and updates the backing store.
 func (ds *DatabaseStore) Set(newCfg *model.Config) error {
-	return ds.persist(newCfg)
+	return ds.persist(newCfg, "")
+}
+
+func (ds *DatabaseStore) setWithAuthor(newCfg *model.Config, authorID string) error {
+	return ds.persist(newCfg, authorID)
 }
 
 // persist writes the configuration to the configured database.
-func (ds *DatabaseStore) persist(cfg *model.Config) error {
+func (ds *DatabaseStore) persist(cfg *model.Config, authorID string) error {
 	b, err := marshalConfig(cfg)
 	if err != nil {
 		return errors.Wrap(err, "failed to serialize")
@@ -217,9 +221,10 @@ func (ds *DatabaseStore) persist(cfg *model.Config) error {
 		"create_at": model.GetMillis(),
 		"key":       "ConfigurationId",
 		"sha":       hex.EncodeToString(sum[0:]),
+		"author":    authorID,
 	}
 
-	if _, err := tx.NamedExec("INSERT INTO Configurations (Id, Value, CreateAt, Active, SHA) VALUES (:id, :value, :create_at, TRUE, :sha)", params); err != nil {
+	if _, err := tx.NamedExec("INSERT INTO Configurations (Id, Value, CreateAt, Active, SHA, Author) VALUES (:id, :value, :create_at, TRUE, :sha, :author)", params); err != nil {
 		return errors.Wrap(err, "failed to record new configuration")
 	}
 
@@ -250,6 +255,49 @@ func (ds *DatabaseStore) Load() ([]byte, error) {
 	return configurationData, nil
 }
 
+// history lists the configurations kept in the database, most recent first.
+func (ds *DatabaseStore) history() ([]*model.ConfigVersion, error) {
+	rows, err := ds.db.Query("SELECT Id, CreateAt, COALESCE(Author, ''), COALESCE(Active, FALSE) FROM Configurations ORDER BY CreateAt DESC")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to query configurations")
+	}
+	defer rows.Close()
+
+	versions := []*model.ConfigVersion{}
+	for rows.Next() {
+		var version model.ConfigVersion
+		if err := rows.Scan(&version.Id, &version.CreateAt, &version.AuthorId, &version.Active); err != nil {
+			return nil, errors.Wrap(err, "failed to scan configuration")
+		}
+		versions = append(versions, &version)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "failed to iterate configurations")
+	}
+
+	return versions, nil
+}
+
+// loadVersion retrieves a configuration kept in the database.
+func (ds *DatabaseStore) loadVersion(id string) ([]byte, error) {
+	query, args, err := sqlx.Named("SELECT Value FROM Configurations WHERE Id = :id", map[string]any{
+		"id": id,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var data []byte
+	row := ds.db.QueryRowx(ds.db.Rebind(query), args...)
+	if err = row.Scan(&data); err == sql.ErrNoRows {
+		return nil, ErrVersionNotFound
+	} else if err != nil {
+		return nil, errors.Wrapf(err, "failed to query configuration %s", id)
+	}
+
+	return data, nil
+}
+
 // GetFile fetches the contents of a previously persisted configuration file.
 func (ds *DatabaseStore) GetFile(name string) ([]byte, error) {
 	query, args, err := sqlx.Named("SELECT Data FROM ConfigurationFiles WHERE Name = :name", map[string]any{
@@ -615,6 +615,49 @@ func TestDatabaseStoreSet(t *testing.T) {
 	})
 }
 
+func TestDatabaseStoreHistory(t *testing.T) {
+	if testing.Short() {
+		t.SkipNow()
+	}
+
+	_, tearDown := setupConfigDatabase(t, minimalConfig, nil)
+	defer tearDown()
+
+	ds, err := newTestDatabaseStore(nil)
+	require.NoError(t, err)
+	defer ds.Close()
+
+	oldCfg := ds.Get().Clone()
+	newCfg := oldCfg.Clone()
+	newCfg.ServiceSettings.SiteURL = model.NewPointer("http://changed")
+	newCfg.EmailSettings.SMTPPassword = model.NewPointer("secret")
+	_, _, err = ds.SetWithAuthor(newCfg, "author")
+	require.NoError(t, err)
+
+	versions, err := ds.History()
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, len(versions), 2)
+	assert.Equal(t, "author", versions[0].AuthorId)
+	assert.True(t, versions[0].Active)
+	assert.False(t, versions[1].Active)
+
+	diffs, err := ds.DiffVersions(versions[1].Id, versions[0].Id)
+	require.NoError(t, err)
+	byPath := map[string]ConfigDiff{}
+	for _, d := range diffs {
+		byPath[d.Path] = d
+	}
+	assert.Equal(t, "http://changed", byPath["ServiceSettings.SiteURL"].ActualVal)
+	assert.Equal(t, model.FakeSetting, byPath["EmailSettings.SMTPPassword"].ActualVal)
+
+	cfg, err := ds.GetVersion(versions[1].Id)
+	require.NoError(t, err)
+	assert.Equal(t, *oldCfg.ServiceSettings.SiteURL, *cfg.ServiceSettings.SiteURL)
+
+	_, err = ds.GetVersion(model.NewId())
+	assert.ErrorIs(t, err, ErrVersionNotFound)
+}
+
 func TestDatabaseStoreLoad(t *testing.T) {
 	if testing.Short() {
 		t.SkipNow()
@@ -852,7 +895,7 @@ func TestDatabaseStoreLoad(t *testing.T) {
 		newCfg := minimalConfig.Clone()
 		dbStore, ok := ds.backingStore.(*DatabaseStore)
 		require.True(t, ok)
-		err = dbStore.persist(newCfg)
+		err = dbStore.persist(newCfg, "")
 		require.NoError(t, err)
 
 		err = ds.Load()
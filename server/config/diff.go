@@ -40,11 +40,15 @@ var configSensitivePaths = map[string]bool{
 	"LdapSettings.BindPassword":                              true,
 	"FileSettings.PublicLinkSalt":                            true,
 	"FileSettings.AmazonS3SecretAccessKey":                   true,
+	"FileSettings.MigrationAmazonS3SecretAccessKey":          true,
+	"FileSettings.EncryptionMasterKey":                       true,
+	"FileSettings.EncryptionPreviousMasterKeys":              true,
 	"SqlSettings.DataSource":                                 true,
 	"SqlSettings.AtRestEncryptKey":                           true,
 	"SqlSettings.DataSourceReplicas":                         true,
 	"SqlSettings.DataSourceSearchReplicas":                   true,
 	"EmailSettings.SMTPPassword":                             true,
+	"ExperimentalAuditSettings.DatabaseHashKey":              true,
 	"GitLabSettings.Secret":                                  true,
 	"GoogleSettings.Secret":                                  true,
 	"Office365Settings.Secret":                               true,
@@ -4,10 +4,15 @@
 package config
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
 
 	"github.com/pkg/errors"
 
@@ -103,20 +108,28 @@ func (fs *FileStore) resolveFilePath(name string) string {
 
 // Set replaces the current configuration in its entirety and updates the backing store.
 func (fs *FileStore) Set(newCfg *model.Config) error {
+	return fs.setWithAuthor(newCfg, "")
+}
+
+func (fs *FileStore) setWithAuthor(newCfg *model.Config, authorID string) error {
 	if *newCfg.ClusterSettings.Enable && *newCfg.ClusterSettings.ReadOnlyConfig {
 		return ErrReadOnlyConfiguration
 	}
 
-	return fs.persist(newCfg)
+	return fs.persist(newCfg, authorID)
 }
 
-// persist writes the configuration to the configured file.
-func (fs *FileStore) persist(cfg *model.Config) error {
+// persist writes the configuration to the configured file, keeping a copy in the history.
+func (fs *FileStore) persist(cfg *model.Config, authorID string) error {
 	b, err := marshalConfig(cfg)
 	if err != nil {
 		return errors.Wrap(err, "failed to serialize")
 	}
 
+	if err = fs.recordVersion(b, authorID); err != nil {
+		return errors.Wrap(err, "failed to record configuration history")
+	}
+
 	err = os.WriteFile(fs.path, b, 0600)
 	if err != nil {
 		return errors.Wrap(err, "failed to write file")
@@ -220,3 +233,189 @@ func (fs *FileStore) String() string {
 func (fs *FileStore) Close() error {
 	return nil
 }
+
+// fileConfigVersion is a version of the configuration kept in the history directory.
+type fileConfigVersion struct {
+	Id       string          `json:"id"`
+	CreateAt int64           `json:"create_at"`
+	AuthorId string          `json:"author_id,omitempty"`
+	SHA      string          `json:"sha"`
+	Config   json.RawMessage `json:"config"`
+}
+
+// historyDir returns the directory keeping previous versions of the configuration file.
+func (fs *FileStore) historyDir() string {
+	return fs.path + ".history"
+}
+
+// historyFiles returns the names of the files in the history directory, most recent first.
+func (fs *FileStore) historyFiles() ([]string, error) {
+	entries, err := os.ReadDir(fs.historyDir())
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Wrap(err, "failed to read history directory")
+	}
+
+	var names []string
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), ".json") {
+			names = append(names, entry.Name())
+		}
+	}
+	// Names start with the zero padded creation time.
+	slices.Sort(names)
+	slices.Reverse(names)
+
+	return names, nil
+}
+
+func (fs *FileStore) readVersion(name string) (*fileConfigVersion, error) {
+	data, err := os.ReadFile(filepath.Join(fs.historyDir(), name))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read %s", name)
+	}
+
+	var version fileConfigVersion
+	if err := json.Unmarshal(data, &version); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse %s", name)
+	}
+
+	return &version, nil
+}
+
+func configSHA(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// recordVersion adds the serialized configuration to the history, unless it is the same as the
+// most recent version.
+func (fs *FileStore) recordVersion(data []byte, authorID string) error {
+	names, err := fs.historyFiles()
+	if err != nil {
+		return err
+	}
+
+	sha := configSHA(data)
+	if len(names) > 0 {
+		latest, err := fs.readVersion(names[0])
+		if err != nil {
+			return err
+		}
+		if latest.SHA == sha {
+			return nil
+		}
+	}
+
+	version := &fileConfigVersion{
+		Id:       model.NewId(),
+		CreateAt: model.GetMillis(),
+		AuthorId: authorID,
+		SHA:      sha,
+		Config:   data,
+	}
+	b, err := json.Marshal(version)
+	if err != nil {
+		return errors.Wrap(err, "failed to serialize version")
+	}
+
+	if err := os.MkdirAll(fs.historyDir(), 0700); err != nil {
+		return errors.Wrap(err, "failed to create history directory")
+	}
+
+	name := fmt.Sprintf("%013d-%s.json", version.CreateAt, version.Id)
+	if err := os.WriteFile(filepath.Join(fs.historyDir(), name), b, 0600); err != nil {
+		return errors.Wrap(err, "failed to write version")
+	}
+
+	return nil
+}
+
+// history lists the versions kept in the history directory, most recent first. The version
+// matching the current content of the configuration file is the active one.
+func (fs *FileStore) history() ([]*model.ConfigVersion, error) {
+	names, err := fs.historyFiles()
+	if err != nil {
+		return nil, err
+	}
+
+	var activeSHA string
+	if data, err := os.ReadFile(fs.path); err == nil {
+		activeSHA = configSHA(data)
+	}
+
+	versions := []*model.ConfigVersion{}
+	for _, name := range names {
+		version, err := fs.readVersion(name)
+		if err != nil {
+			return nil, err
+		}
+		versions = append(versions, &model.ConfigVersion{
+			Id:       version.Id,
+			CreateAt: version.CreateAt,
+			AuthorId: version.AuthorId,
+			Active:   version.SHA == activeSHA,
+		})
+	}
+
+	return versions, nil
+}
+
+// loadVersion retrieves a version kept in the history directory.
+func (fs *FileStore) loadVersion(id string) ([]byte, error) {
+	if !model.IsValidId(id) {
+		return nil, ErrVersionNotFound
+	}
+
+	names, err := fs.historyFiles()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, name := range names {
+		if strings.HasSuffix(name, "-"+id+".json") {
+			version, err := fs.readVersion(name)
+			if err != nil {
+				return nil, err
+			}
+			return version.Config, nil
+		}
+	}
+
+	return nil, ErrVersionNotFound
+}
+
+// cleanUp removes versions from the history if they are older than threshold, keeping the
+// active version and the last 5 most recent ones.
+func (fs *FileStore) cleanUp(thresholdCreateAt int64) error {
+	names, err := fs.historyFiles()
+	if err != nil {
+		return err
+	}
+
+	var activeSHA string
+	if data, err := os.ReadFile(fs.path); err == nil {
+		activeSHA = configSHA(data)
+	}
+
+	for i, name := range names {
+		if i < 5 {
+			continue
+		}
+
+		version, err := fs.readVersion(name)
+		if err != nil {
+			return err
+		}
+		if version.CreateAt >= thresholdCreateAt || version.SHA == activeSHA {
+			continue
+		}
+
+		if err := os.Remove(filepath.Join(fs.historyDir(), name)); err != nil {
+			return errors.Wrapf(err, "failed to remove %s", name)
+		}
+	}
+
+	return nil
+}
@@ -616,6 +616,85 @@ func TestFileStoreSet(t *testing.T) {
 	})
 }
 
+func TestFileStoreHistory(t *testing.T) {
+	configStore, tearDown := setupConfigFileStore(t, minimalConfig)
+	defer tearDown()
+
+	initial, err := configStore.History()
+	require.NoError(t, err)
+
+	oldCfg := configStore.Get().Clone()
+	newCfg := oldCfg.Clone()
+	newCfg.ServiceSettings.SiteURL = model.NewPointer("http://changed")
+	newCfg.EmailSettings.SMTPPassword = model.NewPointer("secret")
+	_, _, err = configStore.SetWithAuthor(newCfg, "author")
+	require.NoError(t, err)
+
+	t.Run("version is recorded with its author", func(t *testing.T) {
+		versions, err := configStore.History()
+		require.NoError(t, err)
+		require.Len(t, versions, len(initial)+1)
+
+		assert.Equal(t, "author", versions[0].AuthorId)
+		assert.True(t, versions[0].Active)
+		for _, version := range versions[1:] {
+			assert.False(t, version.Active)
+		}
+	})
+
+	t.Run("unchanged configuration is not recorded again", func(t *testing.T) {
+		_, _, err := configStore.Set(configStore.GetNoEnv())
+		require.NoError(t, err)
+
+		versions, err := configStore.History()
+		require.NoError(t, err)
+		require.Len(t, versions, len(initial)+1)
+	})
+
+	t.Run("diff redacts sensitive settings", func(t *testing.T) {
+		versions, err := configStore.History()
+		require.NoError(t, err)
+		require.GreaterOrEqual(t, len(versions), 2)
+
+		diffs, err := configStore.DiffVersions(versions[1].Id, versions[0].Id)
+		require.NoError(t, err)
+
+		byPath := map[string]ConfigDiff{}
+		for _, d := range diffs {
+			byPath[d.Path] = d
+		}
+		assert.Equal(t, "http://changed", byPath["ServiceSettings.SiteURL"].ActualVal)
+		assert.Equal(t, model.FakeSetting, byPath["EmailSettings.SMTPPassword"].BaseVal)
+		assert.Equal(t, model.FakeSetting, byPath["EmailSettings.SMTPPassword"].ActualVal)
+	})
+
+	t.Run("previous version can be restored", func(t *testing.T) {
+		versions, err := configStore.History()
+		require.NoError(t, err)
+
+		cfg, err := configStore.GetVersion(versions[1].Id)
+		require.NoError(t, err)
+		assert.Equal(t, *oldCfg.ServiceSettings.SiteURL, *cfg.ServiceSettings.SiteURL)
+
+		_, _, err = configStore.SetWithAuthor(cfg, "other")
+		require.NoError(t, err)
+		assert.Equal(t, *oldCfg.ServiceSettings.SiteURL, *configStore.Get().ServiceSettings.SiteURL)
+
+		versions, err = configStore.History()
+		require.NoError(t, err)
+		assert.Equal(t, "other", versions[0].AuthorId)
+		assert.True(t, versions[0].Active)
+	})
+
+	t.Run("unknown version", func(t *testing.T) {
+		_, err := configStore.GetVersion(model.NewId())
+		assert.ErrorIs(t, err, ErrVersionNotFound)
+
+		_, err = configStore.GetVersion("../../etc/passwd")
+		assert.ErrorIs(t, err, ErrVersionNotFound)
+	})
+}
+
 func TestFileStoreLoad(t *testing.T) {
 	t.Run("file no longer exists", func(t *testing.T) {
 		path, tearDown := setupConfigFile(t, emptyConfig)
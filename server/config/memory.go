@@ -4,7 +4,9 @@
 package config
 
 import (
+	"bytes"
 	"fmt"
+	"sync"
 
 	"github.com/pkg/errors"
 
@@ -18,6 +20,16 @@ type MemoryStore struct {
 	validate                  bool
 	files                     map[string][]byte
 	savedConfig               *model.Config
+
+	keepHistory  bool
+	versionsLock sync.RWMutex
+	versions     []*memoryConfigVersion
+}
+
+// memoryConfigVersion is a version of the configuration kept by a memory store keeping a history.
+type memoryConfigVersion struct {
+	version model.ConfigVersion
+	data    []byte
 }
 
 // MemoryStoreOptions makes configuration of the memory store explicit.
@@ -26,6 +38,8 @@ type MemoryStoreOptions struct {
 	SkipValidation             bool
 	InitialConfig              *model.Config
 	InitialFiles               map[string][]byte
+	// KeepHistory keeps the versions of the configuration, as the database store does.
+	KeepHistory bool
 }
 
 // NewMemoryStore creates a new MemoryStore instance with default options.
@@ -51,6 +65,7 @@ func NewMemoryStoreWithOptions(options *MemoryStoreOptions) (*MemoryStore, error
 		validate:                  !options.SkipValidation,
 		files:                     initialFiles,
 		savedConfig:               savedConfig,
+		keepHistory:               options.KeepHistory,
 	}
 
 	return ms, nil
@@ -58,16 +73,88 @@ func NewMemoryStoreWithOptions(options *MemoryStoreOptions) (*MemoryStore, error
 
 // Set replaces the current configuration in its entirety.
 func (ms *MemoryStore) Set(newCfg *model.Config) error {
-	return ms.persist(newCfg)
+	return ms.persist(newCfg, "")
 }
 
-// persist copies the active config to the saved config.
-func (ms *MemoryStore) persist(cfg *model.Config) error {
+func (ms *MemoryStore) setWithAuthor(newCfg *model.Config, authorID string) error {
+	return ms.persist(newCfg, authorID)
+}
+
+// persist copies the active config to the saved config, keeping it as a new version if the
+// store keeps a history.
+func (ms *MemoryStore) persist(cfg *model.Config, authorID string) error {
 	ms.savedConfig = cfg.Clone()
 
+	if !ms.keepHistory {
+		return nil
+	}
+
+	data, err := marshalConfig(cfg)
+	if err != nil {
+		return errors.Wrap(err, "failed to serialize config")
+	}
+
+	ms.versionsLock.Lock()
+	defer ms.versionsLock.Unlock()
+
+	// Skip the version altogether if we're effectively writing the same configuration.
+	if len(ms.versions) > 0 {
+		active := ms.versions[len(ms.versions)-1]
+		if bytes.Equal(active.data, data) {
+			return nil
+		}
+		active.version.Active = false
+	}
+
+	ms.versions = append(ms.versions, &memoryConfigVersion{
+		version: model.ConfigVersion{
+			Id:       model.NewId(),
+			CreateAt: model.GetMillis(),
+			AuthorId: authorID,
+			Active:   true,
+		},
+		data: data,
+	})
+
 	return nil
 }
 
+// history lists the kept versions of the configuration, most recent first.
+func (ms *MemoryStore) history() ([]*model.ConfigVersion, error) {
+	if !ms.keepHistory {
+		return nil, ErrHistoryNotSupported
+	}
+
+	ms.versionsLock.RLock()
+	defer ms.versionsLock.RUnlock()
+
+	versions := make([]*model.ConfigVersion, 0, len(ms.versions))
+	for i := len(ms.versions) - 1; i >= 0; i-- {
+		version := ms.versions[i].version
+		versions = append(versions, &version)
+	}
+
+	return versions, nil
+}
+
+// loadVersion retrieves a kept version of the configuration.
+func (ms *MemoryStore) loadVersion(id string) ([]byte, error) {
+	if !ms.keepHistory {
+		return nil, ErrHistoryNotSupported
+	}
+
+	ms.versionsLock.RLock()
+	defer ms.versionsLock.RUnlock()
+
+	for _, v := range ms.versions {
+		if v.version.Id == id {
+			return v.data, nil
+		}
+	}
+
+	return nil, ErrVersionNotFound
+}
+
 // Load applies environment overrides to the default config as if a re-load had occurred.
 func (ms *MemoryStore) Load() ([]byte, error) {
 	cfgBytes, err := marshalConfig(ms.savedConfig)
@@ -9,6 +9,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
 )
 
 func setupConfigMemory(t *testing.T) {
@@ -203,3 +205,54 @@ func TestMemoryStoreString(t *testing.T) {
 
 	assert.Equal(t, "memory://", ms.String())
 }
+
+func TestMemoryStoreHistory(t *testing.T) {
+	setupConfigMemory(t)
+
+	t.Run("not kept by default", func(t *testing.T) {
+		ms, err := NewMemoryStore()
+		require.NoError(t, err)
+
+		_, err = ms.history()
+		require.ErrorIs(t, err, ErrHistoryNotSupported)
+	})
+
+	t.Run("versions are kept with their author", func(t *testing.T) {
+		ms, err := NewMemoryStoreWithOptions(&MemoryStoreOptions{KeepHistory: true})
+		require.NoError(t, err)
+		configStore, err := NewStoreFromBacking(ms, nil, false)
+		require.NoError(t, err)
+		defer configStore.Close()
+
+		initial, err := configStore.History()
+		require.NoError(t, err)
+
+		cfg := configStore.Get().Clone()
+		cfg.ServiceSettings.SiteURL = model.NewPointer("http://first")
+		_, _, err = configStore.SetWithAuthor(cfg, "author1")
+		require.NoError(t, err)
+
+		// The same configuration isn't kept twice.
+		_, _, err = configStore.SetWithAuthor(cfg, "author2")
+		require.NoError(t, err)
+
+		cfg.ServiceSettings.SiteURL = model.NewPointer("http://second")
+		_, _, err = configStore.Set(cfg)
+		require.NoError(t, err)
+
+		versions, err := configStore.History()
+		require.NoError(t, err)
+		require.Len(t, versions, len(initial)+2)
+		assert.True(t, versions[0].Active)
+		assert.Empty(t, versions[0].AuthorId)
+		assert.False(t, versions[1].Active)
+		assert.Equal(t, "author1", versions[1].AuthorId)
+
+		first, err := configStore.GetVersion(versions[1].Id)
+		require.NoError(t, err)
+		assert.Equal(t, "http://first", *first.ServiceSettings.SiteURL)
+
+		_, err = configStore.GetVersion(model.NewId())
+		require.ErrorIs(t, err, ErrVersionNotFound)
+	})
+}
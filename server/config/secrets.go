@@ -0,0 +1,348 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// SecretReferencePrefix starts configuration values that are resolved from a secret
+// resolver rather than used as is, e.g. secret://file/run/secrets/openai or
+// secret://vault/secret/data/mattermost#smtp_password.
+const SecretReferencePrefix = "secret://"
+
+// secretsRefreshInterval is how often secret references are resolved again to pick up
+// rotated values.
+var secretsRefreshInterval = time.Minute
+
+// secretResolveTimeout bounds the time spent resolving a single secret reference.
+const secretResolveTimeout = 10 * time.Second
+
+// SecretResolver resolves the secret references of a given scheme.
+type SecretResolver interface {
+	// Resolve returns the value of the secret designated by ref, the reference without
+	// its secret://<scheme>/ prefix.
+	Resolve(ctx context.Context, ref string) (string, error)
+}
+
+var (
+	secretResolversLock sync.RWMutex
+	secretResolvers     = map[string]SecretResolver{
+		"file":  &FileSecretResolver{},
+		"vault": &VaultSecretResolver{},
+	}
+)
+
+// RegisterSecretResolver makes resolver handle the secret://<scheme>/ references,
+// replacing any resolver previously registered for the scheme.
+func RegisterSecretResolver(scheme string, resolver SecretResolver) {
+	secretResolversLock.Lock()
+	defer secretResolversLock.Unlock()
+	secretResolvers[scheme] = resolver
+}
+
+// IsSecretReference returns true if value designates a secret to be resolved.
+func IsSecretReference(value string) bool {
+	return strings.HasPrefix(value, SecretReferencePrefix)
+}
+
+// resolveSecretReference returns the value of the secret designated by the given reference.
+func resolveSecretReference(ref string) (string, error) {
+	scheme, rest, ok := strings.Cut(strings.TrimPrefix(ref, SecretReferencePrefix), "/")
+	if !ok || rest == "" {
+		return "", errors.Errorf("malformed secret reference %q", ref)
+	}
+
+	secretResolversLock.RLock()
+	resolver, ok := secretResolvers[scheme]
+	secretResolversLock.RUnlock()
+	if !ok {
+		return "", errors.Errorf("no resolver for secret reference %q", ref)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), secretResolveTimeout)
+	defer cancel()
+
+	value, err := resolver.Resolve(ctx, rest)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to resolve secret reference %q", ref)
+	}
+
+	return value, nil
+}
+
+// forEachSecretReference walks the string settings of src and calls fn for each one
+// holding a secret reference, along with the current value of the same setting in dst
+// and a function replacing it. Both configurations must be of the same type. Values are
+// replaced rather than modified in place, as configurations may share pointers.
+func forEachSecretReference(src, dst any, fn func(ref, current string, set func(string)) error) error {
+	return walkSecretReferences(reflect.ValueOf(src), reflect.ValueOf(dst), fn)
+}
+
+func walkSecretReferences(src, dst reflect.Value, fn func(ref, current string, set func(string)) error) error {
+	switch src.Kind() {
+	case reflect.Ptr:
+		if src.IsNil() || dst.IsNil() {
+			return nil
+		}
+		if src.Elem().Kind() == reflect.String {
+			ref := src.Elem().String()
+			if !IsSecretReference(ref) {
+				return nil
+			}
+			return fn(ref, dst.Elem().String(), func(value string) {
+				if dst.CanSet() {
+					dst.Set(reflect.ValueOf(&value))
+				} else {
+					dst.Elem().SetString(value)
+				}
+			})
+		}
+		return walkSecretReferences(src.Elem(), dst.Elem(), fn)
+	case reflect.Struct:
+		for i := 0; i < src.NumField(); i++ {
+			if !src.Type().Field(i).IsExported() {
+				continue
+			}
+			if err := walkSecretReferences(src.Field(i), dst.Field(i), fn); err != nil {
+				return err
+			}
+		}
+	case reflect.String:
+		ref := src.String()
+		if !IsSecretReference(ref) || !dst.CanSet() {
+			return nil
+		}
+		return fn(ref, dst.String(), dst.SetString)
+	case reflect.Slice:
+		if src.Type().Elem().Kind() != reflect.String {
+			return nil
+		}
+		for i := 0; i < src.Len() && i < dst.Len(); i++ {
+			ref := src.Index(i).String()
+			if !IsSecretReference(ref) {
+				continue
+			}
+			err := fn(ref, dst.Index(i).String(), func(value string) {
+				values := reflect.MakeSlice(dst.Type(), dst.Len(), dst.Len())
+				reflect.Copy(values, dst)
+				values.Index(i).SetString(value)
+				dst.Set(values)
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// ErrUntrustedSecretReference is returned when a configuration write adds a secret reference.
+var ErrUntrustedSecretReference = errors.New("secret references can only be set in the configuration file or the environment")
+
+// checkSecretReferences returns an error if cfg holds a secret reference that trusted, the
+// configuration loaded from the backing store with the environment applied, doesn't hold
+// for the same setting.
+func checkSecretReferences(cfg, trusted any) error {
+	return forEachSecretReference(cfg, trusted, func(ref, current string, _ func(string)) error {
+		if ref != current {
+			return errors.Wrapf(ErrUntrustedSecretReference, "reference %q", ref)
+		}
+		return nil
+	})
+}
+
+// DefaultSecretFilesDirectory is the directory the file secret resolver reads from unless
+// configured otherwise, where Docker and Kubernetes mount secrets.
+const DefaultSecretFilesDirectory = "/run/secrets"
+
+// FileSecretResolver resolves secret://file/<path> references to the content of the file
+// at the absolute path, without trailing newlines. It suits secrets mounted by container
+// orchestrators, such as Docker or Kubernetes secrets. Only the files within Directory can
+// be read, symbolic links included.
+type FileSecretResolver struct {
+	// Directory holding the secret files. Defaults to the MM_SECRET_FILES_DIRECTORY
+	// environment variable, or DefaultSecretFilesDirectory.
+	Directory string
+}
+
+func (r *FileSecretResolver) Resolve(_ context.Context, ref string) (string, error) {
+	directory := r.Directory
+	if directory == "" {
+		directory = os.Getenv("MM_SECRET_FILES_DIRECTORY")
+	}
+	if directory == "" {
+		directory = DefaultSecretFilesDirectory
+	}
+
+	path, err := secretFilePath(directory, ref)
+	if err != nil {
+		return "", err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimRight(string(data), "\r\n"), nil
+}
+
+// secretFilePath returns the path of the file designated by ref, after checking that it
+// lies within directory once symbolic links are resolved.
+func secretFilePath(directory, ref string) (string, error) {
+	if slices.Contains(strings.Split(ref, "/"), "..") {
+		return "", errors.New("secret file paths can't contain ..")
+	}
+
+	directory, err := filepath.EvalSymlinks(directory)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to resolve the secret files directory")
+	}
+	path, err := filepath.EvalSymlinks(filepath.Clean("/" + ref))
+	if err != nil {
+		return "", err
+	}
+
+	if rel, err := filepath.Rel(directory, path); err != nil || rel == "." || rel == ".." || strings.HasPrefix(rel, "../") {
+		return "", errors.Errorf("secret files must be within %s", directory)
+	}
+
+	return path, nil
+}
+
+// VaultSecretResolver resolves secret://vault/<path>#<key> references by reading the
+// given key of the secret at path from a HashiCorp Vault compatible HTTP API. Both
+// version 1 and version 2 of the key/value secrets engine are supported.
+type VaultSecretResolver struct {
+	// Address of the Vault server. Defaults to the VAULT_ADDR environment variable.
+	Address string
+	// Token authenticating the requests. Defaults to the VAULT_TOKEN environment variable.
+	Token string
+	// Namespace of the secrets, if any. Defaults to the VAULT_NAMESPACE environment variable.
+	Namespace string
+	// Client sending the requests. Defaults to http.DefaultClient.
+	Client *http.Client
+}
+
+func (r *VaultSecretResolver) Resolve(ctx context.Context, ref string) (string, error) {
+	path, key, ok := strings.Cut(ref, "#")
+	if !ok || path == "" || key == "" {
+		return "", errors.New("vault secret references must be of the form secret://vault/<path>#<key>")
+	}
+
+	address := r.Address
+	if address == "" {
+		address = os.Getenv("VAULT_ADDR")
+	}
+	if address == "" {
+		return "", errors.New("vault address is not set")
+	}
+	token := r.Token
+	if token == "" {
+		token = os.Getenv("VAULT_TOKEN")
+	}
+	namespace := r.Namespace
+	if namespace == "" {
+		namespace = os.Getenv("VAULT_NAMESPACE")
+	}
+	client := r.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(address, "/")+"/v1/"+strings.TrimPrefix(path, "/"), nil)
+	if err != nil {
+		return "", err
+	}
+	if token != "" {
+		req.Header.Set("X-Vault-Token", token)
+	}
+	if namespace != "" {
+		req.Header.Set("X-Vault-Namespace", namespace)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("vault responded with status %d", resp.StatusCode)
+	}
+
+	var secret struct {
+		Data map[string]any `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&secret); err != nil {
+		return "", errors.Wrap(err, "failed to decode vault response")
+	}
+
+	data := secret.Data
+	// Version 2 of the key/value engine nests the secret along with its metadata.
+	if nested, ok := data["data"].(map[string]any); ok {
+		if _, hasMetadata := data["metadata"]; hasMetadata {
+			data = nested
+		}
+	}
+
+	value, ok := data[key]
+	if !ok {
+		return "", errors.Errorf("key %q not found in vault secret", key)
+	}
+	if s, ok := value.(string); ok {
+		return s, nil
+	}
+
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	return string(encoded), nil
+}
+
+// resolveSecrets replaces the secret references in cfg by their values, returning the
+// resolved values keyed by reference.
+func resolveSecrets(cfg any) (map[string]string, error) {
+	secrets := map[string]string{}
+	err := forEachSecretReference(cfg, cfg, func(ref, _ string, set func(string)) error {
+		value, err := resolveSecretReference(ref)
+		if err != nil {
+			return err
+		}
+		secrets[ref] = value
+		set(value)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return secrets, nil
+}
+
+// restoreSecretReferences puts back into cfg the secret references found in withRefs
+// wherever cfg holds the resolved value, so that secrets are never persisted.
+func restoreSecretReferences(cfg, withRefs any, secrets map[string]string) {
+	_ = forEachSecretReference(withRefs, cfg, func(ref, current string, set func(string)) error {
+		if value, ok := secrets[ref]; ok && current == value {
+			set(ref)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,298 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func loadMemoryStoreConfig(t *testing.T, ms *MemoryStore) *model.Config {
+	t.Helper()
+
+	data, err := ms.Load()
+	require.NoError(t, err)
+
+	var cfg model.Config
+	require.NoError(t, json.Unmarshal(data, &cfg))
+
+	return &cfg
+}
+
+// setMemoryStoreConfig writes cfg to the backing store and loads it, as an administrator
+// editing the configuration file would.
+func setMemoryStoreConfig(t *testing.T, configStore *Store, ms *MemoryStore, update func(cfg *model.Config)) {
+	t.Helper()
+
+	cfg := configStore.GetNoEnv().Clone()
+	update(cfg)
+	require.NoError(t, ms.Set(cfg))
+	require.NoError(t, configStore.Load())
+}
+
+func TestStoreSecretReferences(t *testing.T) {
+	secretsDir := t.TempDir()
+	t.Setenv("MM_SECRET_FILES_DIRECTORY", secretsDir)
+	secretPath := filepath.Join(secretsDir, "smtp")
+	require.NoError(t, os.WriteFile(secretPath, []byte("password\n"), 0600))
+	ref := "secret://file" + secretPath
+
+	ms, err := NewMemoryStore()
+	require.NoError(t, err)
+	configStore, err := NewStoreFromBacking(ms, nil, false)
+	require.NoError(t, err)
+	defer configStore.Close()
+
+	setMemoryStoreConfig(t, configStore, ms, func(cfg *model.Config) {
+		cfg.EmailSettings.SMTPPassword = model.NewPointer(ref)
+	})
+
+	t.Run("references are resolved but never persisted", func(t *testing.T) {
+		assert.Equal(t, "password", *configStore.Get().EmailSettings.SMTPPassword)
+		assert.Equal(t, ref, *configStore.GetNoEnv().EmailSettings.SMTPPassword)
+		assert.Equal(t, ref, *loadMemoryStoreConfig(t, ms).EmailSettings.SMTPPassword)
+		assert.Equal(t, ref, *configStore.RemoveEnvironmentOverrides(configStore.Get()).EmailSettings.SMTPPassword)
+	})
+
+	t.Run("saving the resolved configuration keeps the references", func(t *testing.T) {
+		cfg := configStore.Get().Clone()
+		cfg.ServiceSettings.SiteURL = model.NewPointer("http://changed")
+		_, _, err := configStore.Set(cfg)
+		require.NoError(t, err)
+
+		assert.Equal(t, "password", *configStore.Get().EmailSettings.SMTPPassword)
+		assert.Equal(t, ref, *loadMemoryStoreConfig(t, ms).EmailSettings.SMTPPassword)
+	})
+
+	t.Run("sanitized secrets keep the references", func(t *testing.T) {
+		cfg := configStore.Get().Clone()
+		cfg.Sanitize(nil, nil)
+		_, _, err := configStore.Set(cfg)
+		require.NoError(t, err)
+
+		assert.Equal(t, "password", *configStore.Get().EmailSettings.SMTPPassword)
+		assert.Equal(t, ref, *loadMemoryStoreConfig(t, ms).EmailSettings.SMTPPassword)
+	})
+
+	t.Run("rotated secrets are refreshed", func(t *testing.T) {
+		var notified *model.Config
+		id := configStore.AddListener(func(_, newCfg *model.Config) {
+			notified = newCfg
+		})
+		defer configStore.RemoveListener(id)
+
+		require.NoError(t, configStore.RefreshSecrets())
+		assert.Nil(t, notified)
+
+		require.NoError(t, os.WriteFile(secretPath, []byte("rotated"), 0600))
+		require.NoError(t, configStore.RefreshSecrets())
+
+		require.NotNil(t, notified)
+		assert.Equal(t, "rotated", *notified.EmailSettings.SMTPPassword)
+		assert.Equal(t, "rotated", *configStore.Get().EmailSettings.SMTPPassword)
+		assert.Equal(t, ref, *loadMemoryStoreConfig(t, ms).EmailSettings.SMTPPassword)
+	})
+
+	t.Run("references can't be added through writes", func(t *testing.T) {
+		cfg := configStore.Get().Clone()
+		cfg.TeamSettings.SiteName = model.NewPointer(ref)
+		_, _, err := configStore.Set(cfg)
+		require.ErrorIs(t, err, ErrUntrustedSecretReference)
+
+		cfg = configStore.Get().Clone()
+		cfg.EmailSettings.SMTPPassword = model.NewPointer("secret://file" + secretPath + "2")
+		_, _, err = configStore.Set(cfg)
+		require.ErrorIs(t, err, ErrUntrustedSecretReference)
+
+		assert.Equal(t, "rotated", *configStore.Get().EmailSettings.SMTPPassword)
+		assert.Equal(t, ref, *loadMemoryStoreConfig(t, ms).EmailSettings.SMTPPassword)
+		assert.NotEqual(t, "rotated", *configStore.Get().TeamSettings.SiteName)
+	})
+
+	t.Run("replacing a reference persists the new value", func(t *testing.T) {
+		cfg := configStore.Get().Clone()
+		cfg.EmailSettings.SMTPPassword = model.NewPointer("plain")
+		_, _, err := configStore.Set(cfg)
+		require.NoError(t, err)
+
+		assert.Equal(t, "plain", *loadMemoryStoreConfig(t, ms).EmailSettings.SMTPPassword)
+	})
+
+	t.Run("unresolvable references are rejected", func(t *testing.T) {
+		for _, ref := range []string{"secret://file" + secretPath + ".missing", "secret://unknown/secret"} {
+			cfg := configStore.GetNoEnv().Clone()
+			cfg.EmailSettings.SMTPPassword = model.NewPointer(ref)
+			require.NoError(t, ms.Set(cfg))
+			require.Error(t, configStore.Load())
+		}
+
+		assert.Equal(t, "plain", *configStore.Get().EmailSettings.SMTPPassword)
+	})
+}
+
+type funcSecretResolver func(ctx context.Context, ref string) (string, error)
+
+func (f funcSecretResolver) Resolve(ctx context.Context, ref string) (string, error) {
+	return f(ctx, ref)
+}
+
+func TestStoreRefreshSecrets(t *testing.T) {
+	var (
+		value   = "password"
+		failing bool
+		block   chan struct{}
+	)
+	RegisterSecretResolver("test", funcSecretResolver(func(_ context.Context, _ string) (string, error) {
+		if block != nil {
+			<-block
+		}
+		if failing {
+			return "", errors.New("unavailable")
+		}
+		return value, nil
+	}))
+	defer func() {
+		secretResolversLock.Lock()
+		delete(secretResolvers, "test")
+		secretResolversLock.Unlock()
+	}()
+
+	ms, err := NewMemoryStore()
+	require.NoError(t, err)
+	configStore, err := NewStoreFromBacking(ms, nil, false)
+	require.NoError(t, err)
+	defer configStore.Close()
+
+	setMemoryStoreConfig(t, configStore, ms, func(cfg *model.Config) {
+		cfg.EmailSettings.SMTPPassword = model.NewPointer("secret://test/smtp")
+	})
+	require.Equal(t, "password", *configStore.Get().EmailSettings.SMTPPassword)
+
+	t.Run("the configuration can be read while the secrets are resolved", func(t *testing.T) {
+		block = make(chan struct{})
+		value = "rotated"
+		done := make(chan error)
+		go func() {
+			done <- configStore.RefreshSecrets()
+		}()
+
+		assert.Equal(t, "password", *configStore.Get().EmailSettings.SMTPPassword)
+
+		close(block)
+		require.NoError(t, <-done)
+		block = nil
+		assert.Equal(t, "rotated", *configStore.Get().EmailSettings.SMTPPassword)
+	})
+
+	t.Run("the last known values are kept when resolving fails", func(t *testing.T) {
+		failing = true
+		defer func() { failing = false }()
+
+		require.NoError(t, configStore.RefreshSecrets())
+		assert.Equal(t, "rotated", *configStore.Get().EmailSettings.SMTPPassword)
+	})
+}
+
+func TestFileSecretResolver(t *testing.T) {
+	secretsDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(secretsDir, "token"), []byte("value\n"), 0600))
+	outsideDir := t.TempDir()
+	outsidePath := filepath.Join(outsideDir, "outside")
+	require.NoError(t, os.WriteFile(outsidePath, []byte("outside"), 0600))
+	require.NoError(t, os.Symlink(outsidePath, filepath.Join(secretsDir, "link")))
+
+	resolver := &FileSecretResolver{Directory: secretsDir}
+	resolve := func(path string) (string, error) {
+		return resolver.Resolve(context.Background(), strings.TrimPrefix(path, "/"))
+	}
+
+	value, err := resolve(filepath.Join(secretsDir, "token"))
+	require.NoError(t, err)
+	assert.Equal(t, "value", value)
+
+	_, err = resolve(outsidePath)
+	assert.Error(t, err, "files outside the directory can't be read")
+
+	_, err = resolve(secretsDir + "/../" + filepath.Base(outsideDir) + "/outside")
+	assert.Error(t, err, "paths can't contain ..")
+
+	_, err = resolve(filepath.Join(secretsDir, "link"))
+	assert.Error(t, err, "symbolic links can't point outside the directory")
+
+	_, err = resolve(secretsDir)
+	assert.Error(t, err, "the directory itself isn't a secret")
+}
+
+func TestVaultSecretResolver(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "token" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		switch r.URL.Path {
+		case "/v1/secret/data/mattermost":
+			_, _ = w.Write([]byte(`{"data": {"data": {"openai": "sk-v2"}, "metadata": {"version": 3}}}`))
+		case "/v1/kv/mattermost":
+			_, _ = w.Write([]byte(`{"data": {"openai": "sk-v1"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	RegisterSecretResolver("vault", &VaultSecretResolver{Address: server.URL, Token: "token"})
+	defer RegisterSecretResolver("vault", &VaultSecretResolver{})
+
+	t.Run("key/value version 2", func(t *testing.T) {
+		value, err := resolveSecretReference("secret://vault/secret/data/mattermost#openai")
+		require.NoError(t, err)
+		assert.Equal(t, "sk-v2", value)
+	})
+
+	t.Run("key/value version 1", func(t *testing.T) {
+		value, err := resolveSecretReference("secret://vault/kv/mattermost#openai")
+		require.NoError(t, err)
+		assert.Equal(t, "sk-v1", value)
+	})
+
+	t.Run("missing key", func(t *testing.T) {
+		_, err := resolveSecretReference("secret://vault/kv/mattermost#other")
+		assert.Error(t, err)
+
+		_, err = resolveSecretReference("secret://vault/kv/mattermost")
+		assert.Error(t, err)
+	})
+
+	t.Run("missing secret", func(t *testing.T) {
+		_, err := resolveSecretReference("secret://vault/kv/other#openai")
+		assert.Error(t, err)
+	})
+
+	t.Run("resolved through the store", func(t *testing.T) {
+		ms, err := NewMemoryStore()
+		require.NoError(t, err)
+		configStore, err := NewStoreFromBacking(ms, nil, false)
+		require.NoError(t, err)
+		defer configStore.Close()
+
+		setMemoryStoreConfig(t, configStore, ms, func(cfg *model.Config) {
+			cfg.AISettings.OpenAIAPIKey = model.NewPointer("secret://vault/secret/data/mattermost#openai")
+		})
+
+		assert.Equal(t, "sk-v2", *configStore.Get().AISettings.OpenAIAPIKey)
+	})
+}
@@ -5,6 +5,7 @@ package config
 
 import (
 	"encoding/json"
+	"maps"
 	"reflect"
 	"sync"
 	"time"
@@ -13,6 +14,7 @@ import (
 
 	"github.com/mattermost/mattermost/server/public/model"
 	"github.com/mattermost/mattermost/server/public/shared/i18n"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
 	"github.com/mattermost/mattermost/server/public/utils"
 )
 
@@ -20,6 +22,13 @@ var (
 	// ErrReadOnlyStore is returned when an attempt to modify a read-only
 	// configuration store is made.
 	ErrReadOnlyStore = errors.New("configuration store is read-only")
+
+	// ErrHistoryNotSupported is returned when the backing store does not keep
+	// previous versions of the configuration.
+	ErrHistoryNotSupported = errors.New("configuration store does not keep history")
+
+	// ErrVersionNotFound is returned when a configuration version does not exist.
+	ErrVersionNotFound = errors.New("configuration version not found")
 )
 
 // Store is the higher level object that handles storing and retrieval of config data.
@@ -35,6 +44,13 @@ type Store struct {
 
 	readOnly   bool
 	readOnlyFF bool
+
+	// secrets caches the resolved values of the secret references, keyed by reference.
+	secrets map[string]string
+
+	secretsRefresherLock sync.Mutex
+	secretsRefresherStop chan struct{}
+	secretsRefresherDone chan struct{}
 }
 
 // BackingStore defines the behaviour exposed by the underlying store
@@ -67,6 +83,20 @@ type BackingStore interface {
 	Close() error
 }
 
+// historyBackingStore is implemented by backing stores which keep previous
+// versions of the configuration.
+type historyBackingStore interface {
+	// setWithAuthor is Set, recording the user who made the change.
+	setWithAuthor(cfg *model.Config, authorID string) error
+
+	// history lists the saved versions of the configuration, most recent first.
+	history() ([]*model.ConfigVersion, error)
+
+	// loadVersion retrieves a saved version of the configuration, returning
+	// ErrVersionNotFound if it does not exist.
+	loadVersion(id string) ([]byte, error)
+}
+
 // NewStoreFromBacking creates and returns a new config store given a backing store.
 func NewStoreFromBacking(backingStore BackingStore, customDefaults *model.Config, readOnly bool) (*Store, error) {
 	store := &Store{
@@ -152,7 +182,9 @@ func (s *Store) GetEnvironmentOverridesWithFilter(filter func(reflect.StructFiel
 func (s *Store) RemoveEnvironmentOverrides(cfg *model.Config) *model.Config {
 	s.configLock.RLock()
 	defer s.configLock.RUnlock()
-	return removeEnvOverrides(cfg, s.configNoEnv, s.GetEnvironmentOverrides())
+	cfgNoEnv := removeEnvOverrides(cfg, s.configNoEnv, s.GetEnvironmentOverrides())
+	restoreSecretReferences(cfgNoEnv, s.configNoEnv, s.secrets)
+	return cfgNoEnv
 }
 
 // SetReadOnlyFF sets whether feature flags should be written out to
@@ -166,6 +198,12 @@ func (s *Store) SetReadOnlyFF(readOnly bool) {
 // Set replaces the current configuration in its entirety and updates the backing store.
 // It returns both old and new versions of the config.
 func (s *Store) Set(newCfg *model.Config) (*model.Config, *model.Config, error) {
+	return s.SetWithAuthor(newCfg, "")
+}
+
+// SetWithAuthor is Set, recording the given user as the author of the change in the
+// configuration history if the backing store keeps one.
+func (s *Store) SetWithAuthor(newCfg *model.Config, authorID string) (*model.Config, *model.Config, error) {
 	s.configLock.Lock()
 	defer s.configLock.Unlock()
 
@@ -189,6 +227,21 @@ func (s *Store) Set(newCfg *model.Config) (*model.Config, *model.Config, error)
 	newCfg = applyEnvironmentMap(newCfg, GetEnvironment())
 	fixConfig(newCfg)
 
+	// Secret references are only trusted from the configuration file and the environment,
+	// so that writing the configuration can't be used to read arbitrary secrets back.
+	if oldCfgNoEnv != nil {
+		if err := checkSecretReferences(newCfg, applyEnvironmentMap(oldCfgNoEnv, GetEnvironment())); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	newCfgWithRefs := newCfg
+	newCfg = newCfg.Clone()
+	secrets, err := resolveSecrets(newCfg)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to resolve secrets")
+	}
+
 	if err := newCfg.IsValid(); err != nil {
 		return nil, nil, errors.Wrap(err, "new configuration is invalid")
 	}
@@ -196,6 +249,16 @@ func (s *Store) Set(newCfg *model.Config) (*model.Config, *model.Config, error)
 	// We attempt to remove any environment override that may be present in the input config.
 	newCfgNoEnv := removeEnvOverrides(newCfg, oldCfgNoEnv, s.GetEnvironmentOverrides())
 
+	// Resolved secrets are never persisted: references set in the input config are kept,
+	// as well as the existing ones whose resolved value was sent back unchanged.
+	restoreSecretReferences(newCfgNoEnv, newCfgWithRefs, secrets)
+	restoreSecretReferences(newCfgNoEnv, oldCfgNoEnv, s.secrets)
+	for ref, value := range s.secrets {
+		if _, ok := secrets[ref]; !ok {
+			secrets[ref] = value
+		}
+	}
+
 	// Don't store feature flags unless we are on MM cloud
 	// MM cloud uses config in the DB as a cache of the feature flag
 	// settings in case the management system is down when a pod starts.
@@ -210,7 +273,7 @@ func (s *Store) Set(newCfg *model.Config) (*model.Config, *model.Config, error)
 		newCfgNoEnv.FeatureFlags = nil
 	}
 
-	if err := s.backingStore.Set(newCfgNoEnv); err != nil {
+	if err := s.persist(newCfgNoEnv, authorID); err != nil {
 		return nil, nil, errors.Wrap(err, "failed to persist")
 	}
 
@@ -228,6 +291,8 @@ func (s *Store) Set(newCfg *model.Config) (*model.Config, *model.Config, error)
 
 	s.configNoEnv = newCfgNoEnv
 	s.config = newCfg
+	s.secrets = secrets
+	s.startSecretsRefresher()
 
 	newCfgCopy := newCfg.Clone()
 
@@ -240,6 +305,87 @@ func (s *Store) Set(newCfg *model.Config) (*model.Config, *model.Config, error)
 	return oldCfg, newCfgCopy, nil
 }
 
+func (s *Store) persist(cfg *model.Config, authorID string) error {
+	if hs, ok := s.backingStore.(historyBackingStore); ok {
+		return hs.setWithAuthor(cfg, authorID)
+	}
+	return s.backingStore.Set(cfg)
+}
+
+// History lists the saved versions of the configuration, most recent first.
+func (s *Store) History() ([]*model.ConfigVersion, error) {
+	hs, ok := s.backingStore.(historyBackingStore)
+	if !ok {
+		return nil, ErrHistoryNotSupported
+	}
+
+	return hs.history()
+}
+
+// GetVersion retrieves a saved version of the configuration, with defaults applied
+// but without environment overrides.
+func (s *Store) GetVersion(id string) (*model.Config, error) {
+	hs, ok := s.backingStore.(historyBackingStore)
+	if !ok {
+		return nil, ErrHistoryNotSupported
+	}
+
+	data, err := hs.loadVersion(id)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := &model.Config{}
+	if err := json.Unmarshal(data, cfg); err != nil {
+		return nil, utils.HumanizeJSONError(err, data)
+	}
+	cfg.SetDefaults()
+
+	return cfg, nil
+}
+
+// DiffVersions returns the redacted differences between two saved versions of the configuration.
+func (s *Store) DiffVersions(baseID, actualID string) (ConfigDiffs, error) {
+	base, err := s.GetVersion(baseID)
+	if err != nil {
+		return nil, err
+	}
+
+	actual, err := s.GetVersion(actualID)
+	if err != nil {
+		return nil, err
+	}
+
+	diffs, err := Diff(base, actual)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to diff configurations")
+	}
+
+	// Sanitizing the configurations before diffing them would hide changes to secrets, so
+	// instead redact any setting whose value is altered by sanitization.
+	sanitizedBase, sanitizedActual := base.Clone(), actual.Clone()
+	sanitizedBase.Sanitize(nil, nil)
+	sanitizedActual.Sanitize(nil, nil)
+	sanitizedDiffs, err := Diff(sanitizedBase, sanitizedActual)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to diff configurations")
+	}
+
+	sanitized := make(map[string]ConfigDiff, len(sanitizedDiffs))
+	for _, d := range sanitizedDiffs {
+		sanitized[d.Path] = d
+	}
+	for i, d := range diffs {
+		sd, ok := sanitized[d.Path]
+		if !ok || !reflect.DeepEqual(sd.BaseVal, d.BaseVal) || !reflect.DeepEqual(sd.ActualVal, d.ActualVal) {
+			diffs[i].BaseVal = model.FakeSetting
+			diffs[i].ActualVal = model.FakeSetting
+		}
+	}
+
+	return diffs.Sanitize(), nil
+}
+
 // Load updates the current configuration from the backing store, possibly initializing.
 func (s *Store) Load() error {
 	s.configLock.Lock()
@@ -291,6 +437,12 @@ func (s *Store) Load() error {
 
 	loadedCfg = applyEnvironmentMap(loadedCfg, GetEnvironment())
 	fixConfig(loadedCfg)
+
+	secrets, err := resolveSecrets(loadedCfg)
+	if err != nil {
+		return errors.Wrap(err, "failed to resolve secrets")
+	}
+
 	if appErr := loadedCfg.IsValid(); appErr != nil {
 		// Translating the error before displaying it in the console.
 		// Defaulting to english for server side language.
@@ -333,6 +485,8 @@ func (s *Store) Load() error {
 
 	s.config = loadedCfg
 	s.configNoEnv = loadedCfgNoEnv
+	s.secrets = secrets
+	s.startSecretsRefresher()
 
 	loadedCfgCopy := loadedCfg.Clone()
 
@@ -345,6 +499,125 @@ func (s *Store) Load() error {
 	return nil
 }
 
+// RefreshSecrets resolves the secret references of the configuration again, notifying
+// the listeners if any of the secrets was rotated. The secrets are resolved without
+// holding the configuration lock, as resolvers may take a while to answer. The last
+// known value of a secret is kept when it can't be resolved.
+func (s *Store) RefreshSecrets() error {
+	s.configLock.RLock()
+	cfg := s.config
+	if cfg == nil {
+		s.configLock.RUnlock()
+		return nil
+	}
+	withRefs := applyEnvironmentMap(s.configNoEnv, GetEnvironment())
+	lastSecrets := maps.Clone(s.secrets)
+	s.configLock.RUnlock()
+
+	secrets := map[string]string{}
+	var resolveErr error
+	_ = forEachSecretReference(withRefs, withRefs, func(ref, _ string, _ func(string)) error {
+		if _, ok := secrets[ref]; ok {
+			return nil
+		}
+		value, err := resolveSecretReference(ref)
+		if err != nil {
+			value, ok := lastSecrets[ref]
+			if !ok {
+				resolveErr = err
+				return err
+			}
+			mlog.Warn("Failed to refresh configuration secret, keeping its last known value", mlog.Err(err))
+			secrets[ref] = value
+			return nil
+		}
+		secrets[ref] = value
+		return nil
+	})
+	if resolveErr != nil {
+		return errors.Wrap(resolveErr, "failed to resolve secrets")
+	}
+
+	s.configLock.Lock()
+	defer s.configLock.Unlock()
+
+	// The configuration was set or loaded again meanwhile, resolving its secrets then.
+	if s.config != cfg {
+		return nil
+	}
+
+	oldCfg := s.config
+	newCfg := s.config.Clone()
+	_ = forEachSecretReference(withRefs, newCfg, func(ref, _ string, set func(string)) error {
+		set(secrets[ref])
+		return nil
+	})
+	s.secrets = secrets
+
+	hasChanged, err := equal(oldCfg, newCfg)
+	if err != nil {
+		return errors.Wrap(err, "failed to compare configs")
+	}
+	if !hasChanged {
+		return nil
+	}
+
+	s.config = newCfg
+
+	s.configLock.Unlock()
+	s.invokeConfigListeners(oldCfg.Clone(), newCfg.Clone())
+	s.configLock.Lock()
+
+	return nil
+}
+
+// startSecretsRefresher periodically refreshes the secrets once the configuration
+// references any.
+func (s *Store) startSecretsRefresher() {
+	if len(s.secrets) == 0 {
+		return
+	}
+
+	s.secretsRefresherLock.Lock()
+	defer s.secretsRefresherLock.Unlock()
+	if s.secretsRefresherStop != nil {
+		return
+	}
+
+	stop, done := make(chan struct{}), make(chan struct{})
+	s.secretsRefresherStop, s.secretsRefresherDone = stop, done
+
+	go func() {
+		defer close(done)
+
+		ticker := time.NewTicker(secretsRefreshInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				if err := s.RefreshSecrets(); err != nil {
+					mlog.Warn("Failed to refresh configuration secrets", mlog.Err(err))
+				}
+			case <-stop:
+				return
+			}
+		}
+	}()
+}
+
+func (s *Store) stopSecretsRefresher() {
+	s.secretsRefresherLock.Lock()
+	stop, done := s.secretsRefresherStop, s.secretsRefresherDone
+	s.secretsRefresherStop, s.secretsRefresherDone = nil, nil
+	s.secretsRefresherLock.Unlock()
+
+	if stop != nil {
+		close(stop)
+		<-done
+	}
+}
+
 // GetFile fetches the contents of a previously persisted configuration file.
 // If no such file exists, an empty byte array will be returned without error.
 func (s *Store) GetFile(name string) ([]byte, error) {
@@ -387,6 +660,8 @@ func (s *Store) String() string {
 
 // Close cleans up resources associated with the store.
 func (s *Store) Close() error {
+	s.stopSecretsRefresher()
+
 	s.configLock.Lock()
 	defer s.configLock.Unlock()
 	return s.backingStore.Close()
@@ -399,14 +674,18 @@ func (s *Store) IsReadOnly() bool {
 	return s.readOnly
 }
 
-// Cleanup removes outdated configurations from the database.
-// this is a no-op function for FileStore type backing store.
+// Cleanup removes outdated configurations from the database, or from the history
+// directory for a FileStore type backing store.
 func (s *Store) CleanUp() error {
 	switch bs := s.backingStore.(type) {
 	case *DatabaseStore:
 		dur := time.Duration(*s.config.JobSettings.CleanupConfigThresholdDays) * time.Hour * 24
 		expiry := model.GetMillisForTime(time.Now().Add(-dur))
 		return bs.cleanUp(expiry)
+	case *FileStore:
+		dur := time.Duration(*s.config.JobSettings.CleanupConfigThresholdDays) * time.Hour * 24
+		expiry := model.GetMillisForTime(time.Now().Add(-dur))
+		return bs.cleanUp(expiry)
 	default:
 		return nil
 	}
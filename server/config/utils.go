@@ -33,6 +33,23 @@ func desanitize(actual, target *model.Config) {
 	if *target.FileSettings.AmazonS3SecretAccessKey == model.FakeSetting {
 		target.FileSettings.AmazonS3SecretAccessKey = actual.FileSettings.AmazonS3SecretAccessKey
 	}
+	if *target.FileSettings.MigrationAmazonS3SecretAccessKey == model.FakeSetting {
+		target.FileSettings.MigrationAmazonS3SecretAccessKey = actual.FileSettings.MigrationAmazonS3SecretAccessKey
+	}
+	if *target.FileSettings.EncryptionMasterKey == model.FakeSetting {
+		target.FileSettings.EncryptionMasterKey = actual.FileSettings.EncryptionMasterKey
+	}
+	if len(target.FileSettings.EncryptionPreviousMasterKeys) == len(actual.FileSettings.EncryptionPreviousMasterKeys) {
+		for i, value := range target.FileSettings.EncryptionPreviousMasterKeys {
+			if value == model.FakeSetting {
+				target.FileSettings.EncryptionPreviousMasterKeys[i] = actual.FileSettings.EncryptionPreviousMasterKeys[i]
+			}
+		}
+	}
+
+	if *target.ExperimentalAuditSettings.DatabaseHashKey == model.FakeSetting {
+		target.ExperimentalAuditSettings.DatabaseHashKey = actual.ExperimentalAuditSettings.DatabaseHashKey
+	}
 
 	if *target.EmailSettings.SMTPPassword == model.FakeSetting {
 		target.EmailSettings.SMTPPassword = actual.EmailSettings.SMTPPassword
@@ -24,6 +24,7 @@ type MetricsInterface interface {
 
 	IncrementHTTPRequest()
 	IncrementHTTPError()
+	IncrementHTTPRateLimitCounter(rule string, limited bool)
 
 	IncrementClusterRequest()
 	ObserveClusterRequestDuration(elapsed float64)
@@ -96,6 +97,7 @@ type MetricsInterface interface {
 
 	SetReplicaLagAbsolute(node string, value float64)
 	SetReplicaLagTime(node string, value float64)
+	IncrementDBReplicaReadRedirect(reason string)
 
 	IncrementNotificationCounter(notificationType model.NotificationType, platform string)
 	IncrementNotificationAckCounter(notificationType model.NotificationType, platform string)
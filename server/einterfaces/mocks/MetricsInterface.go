@@ -98,6 +98,11 @@ func (_m *MetricsInterface) IncrementClusterRequest() {
 	_m.Called()
 }
 
+// IncrementDBReplicaReadRedirect provides a mock function with given fields: reason
+func (_m *MetricsInterface) IncrementDBReplicaReadRedirect(reason string) {
+	_m.Called(reason)
+}
+
 // IncrementEtagHitCounter provides a mock function with given fields: route
 func (_m *MetricsInterface) IncrementEtagHitCounter(route string) {
 	_m.Called(route)
@@ -123,6 +128,11 @@ func (_m *MetricsInterface) IncrementHTTPError() {
 	_m.Called()
 }
 
+// IncrementHTTPRateLimitCounter provides a mock function with given fields: rule, limited
+func (_m *MetricsInterface) IncrementHTTPRateLimitCounter(rule string, limited bool) {
+	_m.Called(rule, limited)
+}
+
 // IncrementHTTPRequest provides a mock function with no fields
 func (_m *MetricsInterface) IncrementHTTPRequest() {
 	_m.Called()
@@ -0,0 +1,497 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.enterprise for license information.
+
+// Package access_control is the built-in policy administration and decision point of the attribute based access
+// control. The rules of the policies are CEL expressions on the custom profile attributes of the users, e.g.
+//
+//	user.attributes.Team == "Engineering" && "Go" in user.attributes.Skills
+//
+// The access decisions are evaluated in-process with the compiled expressions, which are cached. The users matching
+// an expression are searched by the database, from the translation of the expression to SQL.
+package access_control
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/google/cel-go/cel"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/app"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+	"github.com/mattermost/mattermost/server/v8/einterfaces"
+	ejobs "github.com/mattermost/mattermost/server/v8/einterfaces/jobs"
+)
+
+const (
+	// ActionAll matches all the actions in the rules of a policy.
+	ActionAll = "*"
+	// ActionJoinChannel is the action of joining a channel, and of staying a member of it.
+	ActionJoinChannel = "join_channel"
+)
+
+func init() {
+	app.RegisterAccessControlServiceInterface(func(a *app.App) einterfaces.AccessControlServiceInterface {
+		return New(a.Srv().Store(), a)
+	})
+	app.RegisterJobsAccessControlSyncJobInterface(func(s *app.Server) ejobs.AccessControlSyncJobInterface {
+		return &AccessControlSyncJobInterfaceImpl{Server: s}
+	})
+}
+
+// Store is the part of the store used by the service.
+type Store interface {
+	AccessControlPolicy() store.AccessControlPolicyStore
+	Attributes() store.AttributesStore
+}
+
+type AppIface interface {
+	Config() *model.Config
+	License() *model.License
+	ListCPAFields() ([]*model.CPAField, *model.AppError)
+}
+
+// AccessControlService implements both the policy administration point and the policy decision point.
+type AccessControlService struct {
+	store Store
+	app   AppIface
+
+	mut    sync.RWMutex
+	engine *engine
+}
+
+func New(store Store, app AppIface) *AccessControlService {
+	return &AccessControlService{
+		store: store,
+		app:   app,
+	}
+}
+
+// Init creates the CEL engine, once the server is licensed for attribute based access control.
+func (s *AccessControlService) Init(rctx request.CTX) *model.AppError {
+	if !model.MinimumEnterpriseAdvancedLicense(s.app.License()) {
+		return model.NewAppError("Init", "app.pap.init.app_error", nil, "license is not Enterprise Advanced", http.StatusNotImplemented)
+	}
+
+	s.mut.Lock()
+	defer s.mut.Unlock()
+	if s.engine != nil {
+		return nil
+	}
+
+	engine, err := newEngine()
+	if err != nil {
+		return model.NewAppError("Init", "app.pap.init.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	s.engine = engine
+
+	return nil
+}
+
+func (s *AccessControlService) getEngine(where string) (*engine, *model.AppError) {
+	s.mut.RLock()
+	defer s.mut.RUnlock()
+	if s.engine == nil {
+		return nil, model.NewAppError(where, "app.pap.is_ready.app_error", nil, "", http.StatusNotImplemented)
+	}
+	return s.engine, nil
+}
+
+// attributeTypes returns the type of each custom profile attribute, by name.
+func (s *AccessControlService) attributeTypes() (map[string]string, *model.AppError) {
+	fields, appErr := s.app.ListCPAFields()
+	if appErr != nil {
+		return nil, appErr
+	}
+	types := make(map[string]string, len(fields))
+	for _, field := range fields {
+		types[field.Name] = string(field.Type)
+	}
+	return types, nil
+}
+
+// CheckExpression compiles the expression, and checks that the attributes it uses exist and that the users
+// matching it can be searched.
+func (s *AccessControlService) CheckExpression(rctx request.CTX, expression string) ([]model.CELExpressionError, *model.AppError) {
+	engine, appErr := s.getEngine("CheckExpression")
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	checked, issues := engine.compile(expression)
+	if issues.Err() != nil {
+		errs := make([]model.CELExpressionError, 0, len(issues.Errors()))
+		for _, err := range issues.Errors() {
+			errs = append(errs, model.CELExpressionError{
+				Line:    err.Location.Line(),
+				Column:  err.Location.Column(),
+				Message: err.Message,
+			})
+		}
+		return errs, nil
+	}
+
+	native := checked.NativeRep()
+	locate := func(id int64, message string) model.CELExpressionError {
+		location := native.SourceInfo().GetStartLocation(id)
+		return model.CELExpressionError{Line: location.Line(), Column: location.Column(), Message: message}
+	}
+
+	errs := []model.CELExpressionError{}
+	if !checked.OutputType().IsExactType(cel.BoolType) && !checked.OutputType().IsExactType(cel.DynType) {
+		errs = append(errs, locate(native.Expr().ID(), "The expression must be a condition."))
+	}
+
+	types, appErr := s.attributeTypes()
+	if appErr != nil {
+		return nil, appErr
+	}
+	for id, name := range referencedAttributes(native.Expr()) {
+		if _, ok := types[name]; !ok {
+			errs = append(errs, locate(id, fmt.Sprintf("Unknown attribute %q.", name)))
+		}
+	}
+
+	if _, _, err := toSQL(native.Expr()); err != nil {
+		errs = append(errs, locate(err.id, err.message))
+	}
+
+	slices.SortFunc(errs, func(a, b model.CELExpressionError) int {
+		if a.Line != b.Line {
+			return a.Line - b.Line
+		}
+		return a.Column - b.Column
+	})
+	return errs, nil
+}
+
+// ExpressionToVisualAST converts the expression to the conditions of the visual editor.
+func (s *AccessControlService) ExpressionToVisualAST(rctx request.CTX, expression string) (*model.VisualExpression, *model.AppError) {
+	engine, appErr := s.getEngine("ExpressionToVisualAST")
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	checked, issues := engine.compile(expression)
+	if issues.Err() != nil {
+		return nil, model.NewAppError("ExpressionToVisualAST", "app.pap.expression_to_visual_ast.app_error", nil, "", http.StatusBadRequest).Wrap(issues.Err())
+	}
+
+	types, appErr := s.attributeTypes()
+	if appErr != nil {
+		return nil, appErr
+	}
+	visual, err := toVisualExpression(checked.NativeRep().Expr(), types)
+	if err != nil {
+		return nil, model.NewAppError("ExpressionToVisualAST", "app.pap.expression_to_visual_ast.app_error", nil, "", http.StatusBadRequest).Wrap(err)
+	}
+
+	return visual, nil
+}
+
+// NormalizePolicy returns the policy as is, as the expressions are saved with the attribute names.
+func (s *AccessControlService) NormalizePolicy(rctx request.CTX, policy *model.AccessControlPolicy) (*model.AccessControlPolicy, *model.AppError) {
+	return policy, nil
+}
+
+// GetPolicyRuleAttributes returns the attributes used by the rules of the policy and of the policies it imports,
+// for the action, with the values they are compared to.
+func (s *AccessControlService) GetPolicyRuleAttributes(rctx request.CTX, policyID string, action string) (map[string][]string, *model.AppError) {
+	engine, appErr := s.getEngine("GetPolicyRuleAttributes")
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	policy, appErr := s.GetPolicy(rctx, policyID)
+	if appErr != nil {
+		return nil, appErr
+	}
+	expressions, appErr := s.expressions(rctx, policy, action)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	attributes := make(map[string][]string)
+	for _, expression := range expressions {
+		checked, issues := engine.compile(expression)
+		if issues.Err() != nil {
+			return nil, model.NewAppError("GetPolicyRuleAttributes", "app.pap.get_policy_attributes.app_error", nil, "", http.StatusInternalServerError).Wrap(issues.Err())
+		}
+		collectAttributeValues(checked.NativeRep().Expr(), attributes)
+	}
+
+	return attributes, nil
+}
+
+// QueryUsersForExpression searches the users matching the expression.
+func (s *AccessControlService) QueryUsersForExpression(rctx request.CTX, expression string, opts model.SubjectSearchOptions) ([]*model.User, int64, *model.AppError) {
+	engine, appErr := s.getEngine("QueryUsersForExpression")
+	if appErr != nil {
+		return nil, 0, appErr
+	}
+
+	query, args, appErr := s.query(engine, []string{expression})
+	if appErr != nil {
+		return nil, 0, appErr
+	}
+	opts.Query, opts.Args = query, args
+
+	users, count, err := s.store.Attributes().SearchUsers(rctx, opts)
+	if err != nil {
+		return nil, 0, model.NewAppError("QueryUsersForExpression", "app.pap.query_expression.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return users, count, nil
+}
+
+// QueryUsersForResource searches the users who can perform the action on the channel.
+func (s *AccessControlService) QueryUsersForResource(rctx request.CTX, resourceID, action string, opts model.SubjectSearchOptions) ([]*model.User, int64, *model.AppError) {
+	engine, appErr := s.getEngine("QueryUsersForResource")
+	if appErr != nil {
+		return nil, 0, appErr
+	}
+
+	policy, appErr := s.GetPolicy(rctx, resourceID)
+	if appErr != nil {
+		return nil, 0, appErr
+	}
+	expressions, appErr := s.expressions(rctx, policy, action)
+	if appErr != nil {
+		return nil, 0, appErr
+	}
+	query, args, appErr := s.query(engine, expressions)
+	if appErr != nil {
+		return nil, 0, appErr
+	}
+	opts.Query, opts.Args = query, args
+
+	users, count, err := s.store.Attributes().SearchUsers(rctx, opts)
+	if err != nil {
+		return nil, 0, model.NewAppError("QueryUsersForResource", "app.pap.query_expression.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return users, count, nil
+}
+
+// GetChannelMembersToRemove returns the members of the channel who don't satisfy its policy for joining it
+// anymore.
+func (s *AccessControlService) GetChannelMembersToRemove(rctx request.CTX, channelID string) ([]*model.ChannelMember, *model.AppError) {
+	engine, appErr := s.getEngine("GetChannelMembersToRemove")
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	policy, appErr := s.GetPolicy(rctx, channelID)
+	if appErr != nil {
+		return nil, appErr
+	}
+	expressions, appErr := s.expressions(rctx, policy, ActionJoinChannel)
+	if appErr != nil {
+		return nil, appErr
+	}
+	if len(expressions) == 0 {
+		return []*model.ChannelMember{}, nil
+	}
+	query, args, appErr := s.query(engine, expressions)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	var members []*model.ChannelMember
+	opts := model.SubjectSearchOptions{Query: query, Args: args, Limit: memberBatchSize}
+	for {
+		batch, err := s.store.Attributes().GetChannelMembersToRemove(rctx, channelID, opts)
+		if err != nil {
+			return nil, model.NewAppError("GetChannelMembersToRemove", "app.pap.get_channel_members_to_remove.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+		members = append(members, batch...)
+		if len(batch) < memberBatchSize {
+			return members, nil
+		}
+		opts.Cursor.TargetID = batch[len(batch)-1].UserId
+	}
+}
+
+// memberBatchSize is the number of channel members fetched at once.
+const memberBatchSize = 1000
+
+// query translates the expressions, all of which must be satisfied, to a query for the attribute store.
+func (s *AccessControlService) query(engine *engine, expressions []string) (string, []any, *model.AppError) {
+	var query string
+	var args []any
+	for _, expression := range expressions {
+		checked, issues := engine.compile(expression)
+		if issues.Err() != nil {
+			return "", nil, model.NewAppError("query", "app.pap.query_expression.app_error", nil, "", http.StatusBadRequest).Wrap(issues.Err())
+		}
+		b := &sqlBuilder{args: args}
+		condition, err := b.build(checked.NativeRep().Expr())
+		if err != nil {
+			return "", nil, model.NewAppError("query", "app.pap.query_expression.app_error", nil, "", http.StatusBadRequest).Wrap(err)
+		}
+		if query != "" {
+			query += " AND "
+		}
+		query += condition
+		args = b.args
+	}
+	return query, args, nil
+}
+
+// expressions returns the expressions of the rules of the policy and of the policies it imports for the action,
+// all of which must be satisfied. The rules of the v0.1 policies which refer to the imported policies are
+// replaced by the rules of these policies. Inactive policies don't restrict anyone, while a missing imported
+// policy is an error with the status not found: its rules are unknown, so the policy can't be satisfied.
+func (s *AccessControlService) expressions(rctx request.CTX, policy *model.AccessControlPolicy, action string) ([]string, *model.AppError) {
+	if !policy.Active {
+		return nil, nil
+	}
+
+	var expressions []string
+	for _, importID := range policy.Imports {
+		parent, appErr := s.GetPolicy(rctx, importID)
+		if appErr != nil && appErr.StatusCode == http.StatusNotFound {
+			return nil, model.NewAppError("expressions", "app.pap.missing_imported_policy.app_error", map[string]any{"PolicyId": importID}, "", http.StatusNotFound).Wrap(appErr)
+		} else if appErr != nil {
+			return nil, appErr
+		}
+		if !parent.Active {
+			continue
+		}
+		expressions = append(expressions, ruleExpressions(parent, action)...)
+	}
+	for _, expression := range ruleExpressions(policy, action) {
+		if policy.Version == model.AccessControlPolicyVersionV0_1 && isPolicyReference(expression) {
+			continue
+		}
+		expressions = append(expressions, expression)
+	}
+	return expressions, nil
+}
+
+func ruleExpressions(policy *model.AccessControlPolicy, action string) []string {
+	var expressions []string
+	for _, rule := range policy.Rules {
+		if rule.Expression != "" && (slices.Contains(rule.Actions, action) || slices.Contains(rule.Actions, ActionAll)) {
+			expressions = append(expressions, rule.Expression)
+		}
+	}
+	return expressions
+}
+
+// isPolicyReference tells whether the expression is a reference to another policy, e.g. policies.id_<id>.
+func isPolicyReference(expression string) bool {
+	id, ok := strings.CutPrefix(strings.TrimSpace(expression), "policies.id_")
+	return ok && model.IsValidId(id)
+}
+
+// AccessEvaluation decides whether the subject can perform the action on the channel. A channel without a policy,
+// or with an inactive one, doesn't restrict anyone, while a policy importing a missing policy denies everyone.
+func (s *AccessControlService) AccessEvaluation(rctx request.CTX, accessRequest model.AccessRequest) (model.AccessDecision, *model.AppError) {
+	engine, appErr := s.getEngine("AccessEvaluation")
+	if appErr != nil {
+		return model.AccessDecision{}, appErr
+	}
+
+	if accessRequest.Resource.Type != model.AccessControlPolicyTypeChannel {
+		return model.AccessDecision{}, model.NewAppError("AccessEvaluation", "app.pdp.access_evaluation.app_error", nil, "unsupported resource type "+accessRequest.Resource.Type, http.StatusBadRequest)
+	}
+
+	policy, err := s.store.AccessControlPolicy().Get(rctx, accessRequest.Resource.ID)
+	var nfErr *store.ErrNotFound
+	if errors.As(err, &nfErr) {
+		return model.AccessDecision{Decision: true}, nil
+	} else if err != nil {
+		return model.AccessDecision{}, model.NewAppError("AccessEvaluation", "app.pdp.access_evaluation.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	expressions, appErr := s.expressions(rctx, policy, accessRequest.Action)
+	if appErr != nil && appErr.StatusCode == http.StatusNotFound {
+		rctx.Logger().Warn("Access denied by a policy importing a missing policy", mlog.String("policy_id", policy.ID), mlog.Err(appErr))
+		return model.AccessDecision{Decision: false}, nil
+	} else if appErr != nil {
+		return model.AccessDecision{}, appErr
+	}
+	for _, expression := range expressions {
+		matched, err := engine.evaluate(expression, accessRequest.Subject)
+		if err != nil {
+			return model.AccessDecision{}, model.NewAppError("AccessEvaluation", "app.pdp.access_evaluation.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+		if !matched {
+			rctx.Logger().Debug("Access denied by policy", mlog.String("policy_id", policy.ID), mlog.String("subject_id", accessRequest.Subject.ID), mlog.String("action", accessRequest.Action))
+			return model.AccessDecision{Decision: false}, nil
+		}
+	}
+
+	return model.AccessDecision{Decision: true}, nil
+}
+
+// SavePolicy checks the expressions of the rules and saves the policy.
+func (s *AccessControlService) SavePolicy(rctx request.CTX, policy *model.AccessControlPolicy) (*model.AccessControlPolicy, *model.AppError) {
+	if appErr := policy.IsValid(); appErr != nil {
+		return nil, appErr
+	}
+
+	for _, rule := range policy.Rules {
+		if policy.Version == model.AccessControlPolicyVersionV0_1 && isPolicyReference(rule.Expression) {
+			continue
+		}
+		errs, appErr := s.CheckExpression(rctx, rule.Expression)
+		if appErr != nil {
+			return nil, appErr
+		}
+		if len(errs) > 0 {
+			return nil, model.NewAppError("SavePolicy", "app.pap.save_policy.app_error", nil, fmt.Sprintf("invalid expression %q: %s", rule.Expression, errs[0].Message), http.StatusBadRequest)
+		}
+	}
+
+	for _, importID := range policy.Imports {
+		parent, appErr := s.GetPolicy(rctx, importID)
+		if appErr != nil {
+			return nil, appErr
+		}
+		if parent.Type != model.AccessControlPolicyTypeParent {
+			return nil, model.NewAppError("SavePolicy", "app.pap.save_policy.app_error", nil, "imported policy "+importID+" is not a parent policy", http.StatusBadRequest)
+		}
+	}
+
+	saved, err := s.store.AccessControlPolicy().Save(rctx, policy)
+	if err != nil {
+		var appErr *model.AppError
+		if errors.As(err, &appErr) {
+			return nil, appErr
+		}
+		return nil, model.NewAppError("SavePolicy", "app.pap.save_policy.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return saved, nil
+}
+
+func (s *AccessControlService) GetPolicy(rctx request.CTX, id string) (*model.AccessControlPolicy, *model.AppError) {
+	policy, err := s.store.AccessControlPolicy().Get(rctx, id)
+	if err != nil {
+		var nfErr *store.ErrNotFound
+		if errors.As(err, &nfErr) {
+			return nil, model.NewAppError("GetPolicy", "app.pap.get_policy.app_error", nil, "", http.StatusNotFound).Wrap(err)
+		}
+		return nil, model.NewAppError("GetPolicy", "app.pap.get_policy.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return policy, nil
+}
+
+// DeletePolicy deletes the policy. The channel policies importing a deleted parent policy deny access until they
+// are updated.
+func (s *AccessControlService) DeletePolicy(rctx request.CTX, id string) *model.AppError {
+	if err := s.store.AccessControlPolicy().Delete(rctx, id); err != nil {
+		return model.NewAppError("DeletePolicy", "app.pap.delete_policy.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return nil
+}
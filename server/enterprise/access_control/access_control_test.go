@@ -0,0 +1,187 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.enterprise for license information.
+
+package access_control
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+	"github.com/mattermost/mattermost/server/v8/channels/store/storetest/mocks"
+)
+
+type testStore struct {
+	policies   *mocks.AccessControlPolicyStore
+	attributes *mocks.AttributesStore
+}
+
+func (s *testStore) AccessControlPolicy() store.AccessControlPolicyStore { return s.policies }
+func (s *testStore) Attributes() store.AttributesStore                   { return s.attributes }
+
+type testApp struct {
+	fields []*model.CPAField
+}
+
+func (a *testApp) Config() *model.Config { return &model.Config{} }
+func (a *testApp) License() *model.License {
+	return model.NewTestLicenseSKU(model.LicenseShortSkuEnterpriseAdvanced)
+}
+func (a *testApp) ListCPAFields() ([]*model.CPAField, *model.AppError) { return a.fields, nil }
+
+func setupService(t *testing.T) (*AccessControlService, *testStore) {
+	t.Helper()
+
+	st := &testStore{
+		policies:   &mocks.AccessControlPolicyStore{},
+		attributes: &mocks.AttributesStore{},
+	}
+	app := &testApp{fields: []*model.CPAField{
+		{PropertyField: model.PropertyField{Name: "Team", Type: model.PropertyFieldTypeText}},
+	}}
+	s := New(st, app)
+	require.Nil(t, s.Init(request.TestContext(t)))
+	return s, st
+}
+
+func newPolicy(policyType string, active bool, expression string, imports ...string) *model.AccessControlPolicy {
+	return &model.AccessControlPolicy{
+		ID:      model.NewId(),
+		Name:    "Policy",
+		Type:    policyType,
+		Active:  active,
+		Version: model.AccessControlPolicyVersionV0_2,
+		Imports: imports,
+		Rules:   []model.AccessControlPolicyRule{{Actions: []string{ActionAll}, Expression: expression}},
+	}
+}
+
+func TestAccessEvaluation(t *testing.T) {
+	rctx := request.TestContext(t)
+	engineer := model.Subject{ID: model.NewId(), Type: "user", Attributes: map[string]any{"Team": "Engineering"}}
+	sales := model.Subject{ID: model.NewId(), Type: "user", Attributes: map[string]any{"Team": "Sales"}}
+
+	evaluate := func(t *testing.T, s *AccessControlService, channelID string, subject model.Subject) bool {
+		t.Helper()
+		decision, appErr := s.AccessEvaluation(rctx, model.AccessRequest{
+			Subject:  subject,
+			Resource: model.Resource{ID: channelID, Type: model.AccessControlPolicyTypeChannel},
+			Action:   ActionJoinChannel,
+		})
+		require.Nil(t, appErr)
+		return decision.Decision
+	}
+
+	t.Run("channel without a policy", func(t *testing.T) {
+		s, st := setupService(t)
+		channelID := model.NewId()
+		st.policies.On("Get", mock.Anything, channelID).Return(nil, store.NewErrNotFound("AccessControlPolicy", channelID))
+
+		assert.True(t, evaluate(t, s, channelID, sales))
+	})
+
+	t.Run("active policy", func(t *testing.T) {
+		s, st := setupService(t)
+		policy := newPolicy(model.AccessControlPolicyTypeChannel, true, `user.attributes.Team == "Engineering"`)
+		st.policies.On("Get", mock.Anything, policy.ID).Return(policy, nil)
+
+		assert.True(t, evaluate(t, s, policy.ID, engineer))
+		assert.False(t, evaluate(t, s, policy.ID, sales))
+	})
+
+	t.Run("inactive policy", func(t *testing.T) {
+		s, st := setupService(t)
+		policy := newPolicy(model.AccessControlPolicyTypeChannel, false, `user.attributes.Team == "Engineering"`)
+		st.policies.On("Get", mock.Anything, policy.ID).Return(policy, nil)
+
+		assert.True(t, evaluate(t, s, policy.ID, sales))
+	})
+
+	t.Run("rules of the parent policy", func(t *testing.T) {
+		s, st := setupService(t)
+		parent := newPolicy(model.AccessControlPolicyTypeParent, true, `user.attributes.Team == "Engineering"`)
+		policy := newPolicy(model.AccessControlPolicyTypeChannel, true, `true`, parent.ID)
+		st.policies.On("Get", mock.Anything, parent.ID).Return(parent, nil)
+		st.policies.On("Get", mock.Anything, policy.ID).Return(policy, nil)
+
+		assert.True(t, evaluate(t, s, policy.ID, engineer))
+		assert.False(t, evaluate(t, s, policy.ID, sales))
+	})
+
+	t.Run("inactive parent policy", func(t *testing.T) {
+		s, st := setupService(t)
+		parent := newPolicy(model.AccessControlPolicyTypeParent, false, `user.attributes.Team == "Engineering"`)
+		policy := newPolicy(model.AccessControlPolicyTypeChannel, true, `true`, parent.ID)
+		st.policies.On("Get", mock.Anything, parent.ID).Return(parent, nil)
+		st.policies.On("Get", mock.Anything, policy.ID).Return(policy, nil)
+
+		assert.True(t, evaluate(t, s, policy.ID, sales))
+	})
+
+	t.Run("missing parent policy", func(t *testing.T) {
+		s, st := setupService(t)
+		parentID := model.NewId()
+		policy := newPolicy(model.AccessControlPolicyTypeChannel, true, `true`, parentID)
+		st.policies.On("Get", mock.Anything, parentID).Return(nil, store.NewErrNotFound("AccessControlPolicy", parentID))
+		st.policies.On("Get", mock.Anything, policy.ID).Return(policy, nil)
+
+		assert.False(t, evaluate(t, s, policy.ID, engineer))
+	})
+}
+
+func TestSavePolicy(t *testing.T) {
+	rctx := request.TestContext(t)
+
+	t.Run("invalid expression", func(t *testing.T) {
+		s, st := setupService(t)
+		policy := newPolicy(model.AccessControlPolicyTypeParent, true, `user.attributes.Clearance == "Top Secret"`)
+
+		_, appErr := s.SavePolicy(rctx, policy)
+		require.NotNil(t, appErr)
+		assert.Equal(t, http.StatusBadRequest, appErr.StatusCode)
+		st.policies.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	})
+
+	t.Run("import of a channel policy", func(t *testing.T) {
+		s, st := setupService(t)
+		other := newPolicy(model.AccessControlPolicyTypeChannel, true, `true`)
+		policy := newPolicy(model.AccessControlPolicyTypeChannel, true, `true`, other.ID)
+		st.policies.On("Get", mock.Anything, other.ID).Return(other, nil)
+
+		_, appErr := s.SavePolicy(rctx, policy)
+		require.NotNil(t, appErr)
+		assert.Equal(t, http.StatusBadRequest, appErr.StatusCode)
+		st.policies.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	})
+
+	t.Run("import of a missing policy", func(t *testing.T) {
+		s, st := setupService(t)
+		parentID := model.NewId()
+		policy := newPolicy(model.AccessControlPolicyTypeChannel, true, `true`, parentID)
+		st.policies.On("Get", mock.Anything, parentID).Return(nil, store.NewErrNotFound("AccessControlPolicy", parentID))
+
+		_, appErr := s.SavePolicy(rctx, policy)
+		require.NotNil(t, appErr)
+		assert.Equal(t, http.StatusNotFound, appErr.StatusCode)
+		st.policies.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	})
+
+	t.Run("valid policy", func(t *testing.T) {
+		s, st := setupService(t)
+		parent := newPolicy(model.AccessControlPolicyTypeParent, true, `user.attributes.Team == "Engineering"`)
+		policy := newPolicy(model.AccessControlPolicyTypeChannel, true, `user.attributes.Team != "Sales"`, parent.ID)
+		st.policies.On("Get", mock.Anything, parent.ID).Return(parent, nil)
+		st.policies.On("Save", mock.Anything, policy).Return(policy, nil)
+
+		saved, appErr := s.SavePolicy(rctx, policy)
+		require.Nil(t, appErr)
+		assert.Equal(t, policy, saved)
+		st.policies.AssertCalled(t, "Save", mock.Anything, policy)
+	})
+}
@@ -0,0 +1,146 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.enterprise for license information.
+
+package access_control
+
+import (
+	"sync"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/ast"
+	"github.com/google/cel-go/common/operators"
+	"github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/common/types/ref"
+	"github.com/google/cel-go/common/types/traits"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+const (
+	// subjectVariable is the variable holding the subject of the request, e.g. user.attributes.Team.
+	subjectVariable = "user"
+	// attributesField is the field of the subject holding its attributes.
+	attributesField = "attributes"
+
+	// inAnyFunction is what a list literal on the left of the in operator expands to, e.g.
+	// ["a", "b"] in user.attributes.Programs. It is true if any of the values is one of the attribute values.
+	inAnyFunction = "@in_any"
+
+	// maxCachedPrograms bounds the number of compiled programs kept in memory.
+	maxCachedPrograms = 1000
+)
+
+// engine compiles the policy expressions and evaluates them against the attributes of the subjects. The compiled
+// programs are cached by expression, as the policies are evaluated every time a user joins a channel.
+type engine struct {
+	env *cel.Env
+
+	mut      sync.RWMutex
+	programs map[string]cel.Program
+}
+
+func newEngine() (*engine, error) {
+	env, err := cel.NewEnv(
+		cel.Variable(subjectVariable, cel.MapType(cel.StringType, cel.DynType)),
+		cel.Macros(cel.GlobalMacro(operators.In, 2, expandInAny)),
+		cel.Function(inAnyFunction, cel.Overload("list_in_any_dyn", []*cel.Type{cel.ListType(cel.DynType), cel.DynType}, cel.BoolType,
+			cel.BinaryBinding(inAny))),
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create the CEL environment")
+	}
+
+	return &engine{
+		env:      env,
+		programs: make(map[string]cel.Program),
+	}, nil
+}
+
+// expandInAny rewrites the in operator when its left operand is a list literal, which the standard in operator
+// would look up as a single element.
+func expandInAny(eh cel.MacroExprFactory, _ ast.Expr, args []ast.Expr) (ast.Expr, *cel.Error) {
+	if args[0].Kind() != ast.ListKind {
+		return nil, nil
+	}
+	return eh.NewCall(inAnyFunction, args[0], args[1]), nil
+}
+
+func inAny(lhs, rhs ref.Val) ref.Val {
+	values, ok := lhs.(traits.Lister)
+	if !ok {
+		return types.MaybeNoSuchOverloadErr(lhs)
+	}
+	attribute, isList := rhs.(traits.Lister)
+
+	it := values.Iterator()
+	for it.HasNext() == types.True {
+		value := it.Next()
+		if isList && attribute.Contains(value) == types.True {
+			return types.True
+		} else if !isList && value.Equal(rhs) == types.True {
+			return types.True
+		}
+	}
+	return types.False
+}
+
+// compile parses and type checks the expression.
+func (e *engine) compile(expression string) (*cel.Ast, *cel.Issues) {
+	return e.env.Compile(expression)
+}
+
+// program returns the compiled program of the expression, compiling it on the first use.
+func (e *engine) program(expression string) (cel.Program, error) {
+	e.mut.RLock()
+	prg, ok := e.programs[expression]
+	e.mut.RUnlock()
+	if ok {
+		return prg, nil
+	}
+
+	checked, issues := e.compile(expression)
+	if issues.Err() != nil {
+		return nil, errors.Wrap(issues.Err(), "failed to compile the expression")
+	}
+	prg, err := e.env.Program(checked)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create the program")
+	}
+
+	e.mut.Lock()
+	defer e.mut.Unlock()
+	if len(e.programs) >= maxCachedPrograms {
+		clear(e.programs)
+	}
+	e.programs[expression] = prg
+
+	return prg, nil
+}
+
+// evaluate evaluates the expression for the subject. An expression which can't be evaluated for the subject, e.g.
+// because the subject doesn't have one of the attributes, doesn't match.
+func (e *engine) evaluate(expression string, subject model.Subject) (bool, error) {
+	prg, err := e.program(expression)
+	if err != nil {
+		return false, err
+	}
+
+	attributes := subject.Attributes
+	if attributes == nil {
+		attributes = map[string]any{}
+	}
+	out, _, err := prg.Eval(map[string]any{
+		subjectVariable: map[string]any{
+			"id":            subject.ID,
+			"type":          subject.Type,
+			attributesField: attributes,
+		},
+	})
+	if err != nil {
+		return false, nil
+	}
+
+	matched, ok := out.Value().(bool)
+	return ok && matched, nil
+}
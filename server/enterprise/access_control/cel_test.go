@@ -0,0 +1,81 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.enterprise for license information.
+
+package access_control
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func TestEngineEvaluate(t *testing.T) {
+	e, err := newEngine()
+	require.NoError(t, err)
+
+	subject := model.Subject{
+		ID:   model.NewId(),
+		Type: "user",
+		Attributes: map[string]any{
+			"Team":     "Engineering",
+			"Programs": []any{"Alpha", "Beta"},
+		},
+	}
+
+	testCases := []struct {
+		name       string
+		expression string
+		expected   bool
+	}{
+		{"equals", `user.attributes.Team == "Engineering"`, true},
+		{"not equals", `user.attributes.Team != "Engineering"`, false},
+		{"index notation", `user.attributes["Team"] == "Engineering"`, true},
+		{"attribute in list", `user.attributes.Team in ["Sales", "Engineering"]`, true},
+		{"value in attribute", `"Beta" in user.attributes.Programs`, true},
+		{"any value in attribute", `["Gamma", "Alpha"] in user.attributes.Programs`, true},
+		{"no value in attribute", `["Gamma", "Delta"] in user.attributes.Programs`, false},
+		{"starts with", `user.attributes.Team.startsWith("Eng")`, true},
+		{"ends with", `user.attributes.Team.endsWith("ing")`, true},
+		{"contains", `user.attributes.Team.contains("gine")`, true},
+		{"and", `user.attributes.Team == "Engineering" && "Gamma" in user.attributes.Programs`, false},
+		{"or", `user.attributes.Team == "Sales" || "Alpha" in user.attributes.Programs`, true},
+		{"missing attribute", `user.attributes.Clearance == "Top Secret"`, false},
+		{"negated missing attribute", `!(user.attributes.Clearance == "Top Secret")`, false},
+		{"true", `true`, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			matched, err := e.evaluate(tc.expression, subject)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, matched)
+		})
+	}
+
+	t.Run("invalid expression", func(t *testing.T) {
+		_, err := e.evaluate(`user.attributes.Team ==`, subject)
+		require.Error(t, err)
+	})
+
+	t.Run("subject without attributes", func(t *testing.T) {
+		matched, err := e.evaluate(`user.attributes.Team == "Engineering"`, model.Subject{ID: model.NewId(), Type: "user"})
+		require.NoError(t, err)
+		assert.False(t, matched)
+	})
+}
+
+func TestEngineProgramCache(t *testing.T) {
+	e, err := newEngine()
+	require.NoError(t, err)
+
+	prg, err := e.program(`user.attributes.Team == "Engineering"`)
+	require.NoError(t, err)
+
+	cached, err := e.program(`user.attributes.Team == "Engineering"`)
+	require.NoError(t, err)
+	assert.Equal(t, prg, cached)
+	assert.Len(t, e.programs, 1)
+}
@@ -0,0 +1,397 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.enterprise for license information.
+
+package access_control
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/google/cel-go/common/ast"
+	"github.com/google/cel-go/common/operators"
+	"github.com/google/cel-go/common/overloads"
+	"github.com/google/cel-go/common/types"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+const attributePrefix = subjectVariable + "." + attributesField + "."
+
+// expressionError is an error about a part of an expression, located with the id of the expression node.
+type expressionError struct {
+	id      int64
+	message string
+}
+
+func (e *expressionError) Error() string {
+	return e.message
+}
+
+func unsupported(e ast.Expr) *expressionError {
+	return &expressionError{id: e.ID(), message: "This part of the expression isn't supported by the attribute search."}
+}
+
+// attributeName returns the name of the attribute the expression selects, for user.attributes.Name and
+// user.attributes["Name"].
+func attributeName(e ast.Expr) (string, bool) {
+	var operand ast.Expr
+	var name string
+	switch e.Kind() {
+	case ast.SelectKind:
+		operand, name = e.AsSelect().Operand(), e.AsSelect().FieldName()
+	case ast.CallKind:
+		call := e.AsCall()
+		if call.FunctionName() != operators.Index || len(call.Args()) != 2 {
+			return "", false
+		}
+		key, ok := stringLiteral(call.Args()[1])
+		if !ok {
+			return "", false
+		}
+		operand, name = call.Args()[0], key
+	default:
+		return "", false
+	}
+
+	if operand.Kind() != ast.SelectKind || operand.AsSelect().FieldName() != attributesField {
+		return "", false
+	}
+	subject := operand.AsSelect().Operand()
+	return name, subject.Kind() == ast.IdentKind && subject.AsIdent() == subjectVariable
+}
+
+func stringLiteral(e ast.Expr) (string, bool) {
+	if e.Kind() != ast.LiteralKind {
+		return "", false
+	}
+	s, ok := e.AsLiteral().(types.String)
+	return string(s), ok
+}
+
+// literal returns the value of a literal as a string, the way the attributes are compared by the database.
+func literal(e ast.Expr) (string, bool) {
+	if e.Kind() != ast.LiteralKind {
+		return "", false
+	}
+	switch v := e.AsLiteral().(type) {
+	case types.String:
+		return string(v), true
+	case types.Bool, types.Int, types.Uint, types.Double:
+		return fmt.Sprint(v.Value()), true
+	}
+	return "", false
+}
+
+func listLiteral(e ast.Expr) ([]string, bool) {
+	if e.Kind() != ast.ListKind {
+		return nil, false
+	}
+	values := make([]string, 0, e.AsList().Size())
+	for _, element := range e.AsList().Elements() {
+		value, ok := literal(element)
+		if !ok {
+			return nil, false
+		}
+		values = append(values, value)
+	}
+	return values, true
+}
+
+// walk calls f for the expression and all of its sub-expressions.
+func walk(e ast.Expr, f func(ast.Expr)) {
+	f(e)
+	switch e.Kind() {
+	case ast.CallKind:
+		if e.AsCall().IsMemberFunction() {
+			walk(e.AsCall().Target(), f)
+		}
+		for _, arg := range e.AsCall().Args() {
+			walk(arg, f)
+		}
+	case ast.SelectKind:
+		walk(e.AsSelect().Operand(), f)
+	case ast.ListKind:
+		for _, element := range e.AsList().Elements() {
+			walk(element, f)
+		}
+	case ast.ComprehensionKind:
+		c := e.AsComprehension()
+		walk(c.IterRange(), f)
+		walk(c.AccuInit(), f)
+		walk(c.LoopCondition(), f)
+		walk(c.LoopStep(), f)
+		walk(c.Result(), f)
+	case ast.MapKind:
+		for _, entry := range e.AsMap().Entries() {
+			walk(entry.AsMapEntry().Key(), f)
+			walk(entry.AsMapEntry().Value(), f)
+		}
+	}
+}
+
+// referencedAttributes returns the attributes used in the expression, with the ids of the nodes using them.
+func referencedAttributes(e ast.Expr) map[int64]string {
+	attributes := make(map[int64]string)
+	walk(e, func(e ast.Expr) {
+		if name, ok := attributeName(e); ok {
+			attributes[e.ID()] = name
+		}
+	})
+	return attributes
+}
+
+// comparison is a condition on a single attribute, in the form the visual editor and the attribute search
+// support.
+type comparison struct {
+	attribute string
+	operator  string
+	// values holds the literal the attribute is compared to, or the values of the list for the in operator.
+	values []string
+	// otherAttribute is set instead of the values when the attribute is compared to another attribute.
+	otherAttribute string
+}
+
+// parseComparison parses a call into a comparison, if the call is one.
+func parseComparison(call ast.CallExpr) (*comparison, bool) {
+	args := call.Args()
+	switch name := call.FunctionName(); name {
+	case operators.Equals, operators.NotEquals:
+		op := strings.Trim(name, "_")
+		attribute, ok := attributeName(args[0])
+		other := args[1]
+		if !ok {
+			// The literal is on the left, e.g. "Engineering" == user.attributes.Team.
+			if attribute, ok = attributeName(args[1]); !ok {
+				return nil, false
+			}
+			other = args[0]
+		}
+		if value, ok := literal(other); ok {
+			return &comparison{attribute: attribute, operator: op, values: []string{value}}, true
+		}
+		if otherAttribute, ok := attributeName(other); ok {
+			return &comparison{attribute: attribute, operator: op, otherAttribute: otherAttribute}, true
+		}
+	case operators.In:
+		if attribute, ok := attributeName(args[0]); ok {
+			if values, ok := listLiteral(args[1]); ok {
+				return &comparison{attribute: attribute, operator: "in", values: values}, true
+			}
+		} else if attribute, ok := attributeName(args[1]); ok {
+			// A single value in a multi-valued attribute, e.g. "Go" in user.attributes.Skills.
+			if value, ok := literal(args[0]); ok {
+				return &comparison{attribute: attribute, operator: "in", values: []string{value}}, true
+			}
+		}
+	case inAnyFunction:
+		if attribute, ok := attributeName(args[1]); ok {
+			if values, ok := listLiteral(args[0]); ok {
+				return &comparison{attribute: attribute, operator: "in", values: values}, true
+			}
+		}
+	case overloads.StartsWith, overloads.EndsWith, overloads.Contains:
+		if !call.IsMemberFunction() || len(args) != 1 {
+			return nil, false
+		}
+		if attribute, ok := attributeName(call.Target()); ok {
+			if value, ok := stringLiteral(args[0]); ok {
+				return &comparison{attribute: attribute, operator: name, values: []string{value}}, true
+			}
+		}
+	}
+	return nil, false
+}
+
+// conjunction returns the terms of a chain of &&.
+func conjunction(e ast.Expr) []ast.Expr {
+	if e.Kind() == ast.CallKind && e.AsCall().FunctionName() == operators.LogicalAnd {
+		var terms []ast.Expr
+		for _, arg := range e.AsCall().Args() {
+			terms = append(terms, conjunction(arg)...)
+		}
+		return terms
+	}
+	return []ast.Expr{e}
+}
+
+// toVisualExpression converts the expression to the conditions of the visual editor, which only supports
+// comparisons combined with &&.
+func toVisualExpression(e ast.Expr, attributeTypes map[string]string) (*model.VisualExpression, *expressionError) {
+	visual := &model.VisualExpression{Conditions: []model.Condition{}}
+	for _, term := range conjunction(e) {
+		if term.Kind() == ast.LiteralKind && term.AsLiteral() == types.True {
+			continue
+		}
+		if term.Kind() != ast.CallKind {
+			return nil, &expressionError{id: term.ID(), message: "Only comparisons of attributes are supported."}
+		}
+		cmp, ok := parseComparison(term.AsCall())
+		if !ok {
+			return nil, &expressionError{id: term.ID(), message: "Only comparisons of attributes are supported."}
+		}
+
+		condition := model.Condition{
+			Attribute:     attributePrefix + cmp.attribute,
+			Operator:      cmp.operator,
+			ValueType:     model.LiteralValue,
+			AttributeType: attributeTypes[cmp.attribute],
+		}
+		switch {
+		case cmp.otherAttribute != "":
+			condition.Value = attributePrefix + cmp.otherAttribute
+			condition.ValueType = model.AttrValue
+		case cmp.operator == "in":
+			values := make([]any, len(cmp.values))
+			for i, v := range cmp.values {
+				values[i] = v
+			}
+			condition.Value = values
+		default:
+			condition.Value = cmp.values[0]
+		}
+		visual.Conditions = append(visual.Conditions, condition)
+	}
+	return visual, nil
+}
+
+// collectAttributeValues adds the attributes used in the expression to the map, along with the values they are
+// compared to.
+func collectAttributeValues(e ast.Expr, attributes map[string][]string) {
+	walk(e, func(e ast.Expr) {
+		if name, ok := attributeName(e); ok {
+			if _, found := attributes[name]; !found {
+				attributes[name] = []string{}
+			}
+			return
+		}
+		if e.Kind() != ast.CallKind {
+			return
+		}
+		if cmp, ok := parseComparison(e.AsCall()); ok {
+			for _, value := range cmp.values {
+				if !slices.Contains(attributes[cmp.attribute], value) {
+					attributes[cmp.attribute] = append(attributes[cmp.attribute], value)
+				}
+			}
+		}
+	})
+}
+
+// sqlBuilder translates an expression into a condition on the attributes of the AttributeView, for the database
+// to search the users matching it. Everything but the SQL keywords is passed as arguments, and the placeholders are
+// numbered from 1.
+type sqlBuilder struct {
+	args []any
+}
+
+func toSQL(e ast.Expr) (string, []any, *expressionError) {
+	b := &sqlBuilder{}
+	query, err := b.build(e)
+	if err != nil {
+		return "", nil, err
+	}
+	return query, b.args, nil
+}
+
+func (b *sqlBuilder) arg(v any) string {
+	b.args = append(b.args, v)
+	return fmt.Sprintf("$%d::text", len(b.args))
+}
+
+// text is the value of the attribute as text, or NULL if the subject doesn't have it.
+func (b *sqlBuilder) text(attribute string) string {
+	return "(AttributeView.Attributes ->> " + b.arg(attribute) + ")"
+}
+
+// json is the value of the attribute as JSON, to look up the values of multi-valued attributes.
+func (b *sqlBuilder) json(attribute string) string {
+	return "(AttributeView.Attributes -> " + b.arg(attribute) + ")"
+}
+
+func (b *sqlBuilder) array(values []string) string {
+	placeholders := make([]string, len(values))
+	for i, v := range values {
+		placeholders[i] = b.arg(v)
+	}
+	return "ARRAY[" + strings.Join(placeholders, ", ") + "]::text[]"
+}
+
+func (b *sqlBuilder) build(e ast.Expr) (string, *expressionError) {
+	switch e.Kind() {
+	case ast.LiteralKind:
+		if v, ok := e.AsLiteral().(types.Bool); ok {
+			if v {
+				return "TRUE", nil
+			}
+			return "FALSE", nil
+		}
+		return "", unsupported(e)
+	case ast.CallKind:
+	default:
+		return "", unsupported(e)
+	}
+
+	call := e.AsCall()
+	switch call.FunctionName() {
+	case operators.LogicalAnd, operators.LogicalOr:
+		keyword := " AND "
+		if call.FunctionName() == operators.LogicalOr {
+			keyword = " OR "
+		}
+		terms := make([]string, 0, len(call.Args()))
+		for _, arg := range call.Args() {
+			term, err := b.build(arg)
+			if err != nil {
+				return "", err
+			}
+			terms = append(terms, term)
+		}
+		return "(" + strings.Join(terms, keyword) + ")", nil
+	case operators.LogicalNot:
+		term, err := b.build(call.Args()[0])
+		if err != nil {
+			return "", err
+		}
+		return "(NOT " + term + ")", nil
+	}
+
+	cmp, ok := parseComparison(call)
+	if !ok {
+		return "", unsupported(e)
+	}
+
+	switch cmp.operator {
+	case "==", "!=":
+		attribute := b.text(cmp.attribute)
+		var other string
+		if cmp.otherAttribute != "" {
+			other = b.text(cmp.otherAttribute)
+		} else {
+			other = b.arg(cmp.values[0])
+		}
+		return attribute + " " + strings.Replace(cmp.operator, "==", "=", 1) + " " + other, nil
+	case "in":
+		if len(cmp.values) == 0 {
+			return "FALSE", nil
+		}
+		if call.FunctionName() == operators.In && len(call.Args()) == 2 && call.Args()[1].Kind() == ast.ListKind {
+			// A single-valued attribute in a list of values.
+			return b.text(cmp.attribute) + " = ANY(" + b.array(cmp.values) + ")", nil
+		}
+		// Any of the values in the attribute. This matches the single-valued attributes too, as jsonb_exists_any
+		// looks up a string in itself.
+		return "jsonb_exists_any(" + b.json(cmp.attribute) + ", " + b.array(cmp.values) + ")", nil
+	case overloads.StartsWith:
+		return b.text(cmp.attribute) + " LIKE " + b.arg(escapeLike(cmp.values[0])+"%"), nil
+	case overloads.EndsWith:
+		return b.text(cmp.attribute) + " LIKE " + b.arg("%"+escapeLike(cmp.values[0])), nil
+	case overloads.Contains:
+		return b.text(cmp.attribute) + " LIKE " + b.arg("%"+escapeLike(cmp.values[0])+"%"), nil
+	}
+	return "", unsupported(e)
+}
+
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
@@ -0,0 +1,133 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.enterprise for license information.
+
+package access_control
+
+import (
+	"testing"
+
+	"github.com/google/cel-go/common/ast"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func parse(t *testing.T, expression string) ast.Expr {
+	t.Helper()
+
+	e, err := newEngine()
+	require.NoError(t, err)
+	checked, issues := e.compile(expression)
+	require.NoError(t, issues.Err())
+	return checked.NativeRep().Expr()
+}
+
+func TestToSQL(t *testing.T) {
+	testCases := []struct {
+		name       string
+		expression string
+		query      string
+		args       []any
+	}{
+		{
+			"equals",
+			`user.attributes.Team == "Engineering"`,
+			`(AttributeView.Attributes ->> $1::text) = $2::text`,
+			[]any{"Team", "Engineering"},
+		},
+		{
+			"literal on the left",
+			`"Engineering" != user.attributes.Team`,
+			`(AttributeView.Attributes ->> $1::text) != $2::text`,
+			[]any{"Team", "Engineering"},
+		},
+		{
+			"attributes",
+			`user.attributes.Team == user.attributes["Department"]`,
+			`(AttributeView.Attributes ->> $1::text) = (AttributeView.Attributes ->> $2::text)`,
+			[]any{"Team", "Department"},
+		},
+		{
+			"attribute in list",
+			`user.attributes.Team in ["Sales", "Engineering"]`,
+			`(AttributeView.Attributes ->> $1::text) = ANY(ARRAY[$2::text, $3::text]::text[])`,
+			[]any{"Team", "Sales", "Engineering"},
+		},
+		{
+			"any value in attribute",
+			`["Alpha", "Beta"] in user.attributes.Programs`,
+			`jsonb_exists_any((AttributeView.Attributes -> $1::text), ARRAY[$2::text, $3::text]::text[])`,
+			[]any{"Programs", "Alpha", "Beta"},
+		},
+		{
+			"empty list",
+			`user.attributes.Team in []`,
+			`FALSE`,
+			nil,
+		},
+		{
+			"starts with",
+			`user.attributes.Team.startsWith("Eng_")`,
+			`(AttributeView.Attributes ->> $1::text) LIKE $2::text`,
+			[]any{"Team", `Eng\_%`},
+		},
+		{
+			"boolean operators",
+			`user.attributes.Team == "Sales" || !(user.attributes.Team.contains("%"))`,
+			`((AttributeView.Attributes ->> $1::text) = $2::text OR (NOT (AttributeView.Attributes ->> $3::text) LIKE $4::text))`,
+			[]any{"Team", "Sales", "Team", `%\%%`},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			query, args, err := toSQL(parse(t, tc.expression))
+			require.Nil(t, err)
+			assert.Equal(t, tc.query, query)
+			assert.Equal(t, tc.args, args)
+		})
+	}
+
+	t.Run("unsupported", func(t *testing.T) {
+		_, _, err := toSQL(parse(t, `size(user.attributes.Team) > 3`))
+		require.NotNil(t, err)
+	})
+}
+
+func TestToVisualExpression(t *testing.T) {
+	attributeTypes := map[string]string{"Team": "select", "Programs": "multiselect"}
+
+	t.Run("comparisons", func(t *testing.T) {
+		visual, err := toVisualExpression(parse(t, `user.attributes.Team == "Engineering" && ["Alpha"] in user.attributes.Programs && user.attributes.Location.startsWith("US")`), attributeTypes)
+		require.Nil(t, err)
+		assert.Equal(t, []model.Condition{
+			{Attribute: "user.attributes.Team", Operator: "==", Value: "Engineering", ValueType: model.LiteralValue, AttributeType: "select"},
+			{Attribute: "user.attributes.Programs", Operator: "in", Value: []any{"Alpha"}, ValueType: model.LiteralValue, AttributeType: "multiselect"},
+			{Attribute: "user.attributes.Location", Operator: "startsWith", Value: "US", ValueType: model.LiteralValue},
+		}, visual.Conditions)
+	})
+
+	t.Run("attributes", func(t *testing.T) {
+		visual, err := toVisualExpression(parse(t, `user.attributes.Team != user.attributes.Department`), attributeTypes)
+		require.Nil(t, err)
+		require.Len(t, visual.Conditions, 1)
+		assert.Equal(t, "user.attributes.Department", visual.Conditions[0].Value)
+		assert.Equal(t, model.AttrValue, visual.Conditions[0].ValueType)
+	})
+
+	t.Run("or is not supported", func(t *testing.T) {
+		_, err := toVisualExpression(parse(t, `user.attributes.Team == "Sales" || user.attributes.Team == "Engineering"`), attributeTypes)
+		require.NotNil(t, err)
+	})
+}
+
+func TestCollectAttributeValues(t *testing.T) {
+	attributes := map[string][]string{}
+	collectAttributeValues(parse(t, `user.attributes.Team in ["Sales", "Engineering"] || (user.attributes.Team == "Sales" && user.attributes.Location != "")`), attributes)
+
+	assert.Equal(t, map[string][]string{
+		"Team":     {"Sales", "Engineering"},
+		"Location": {""},
+	}, attributes)
+}
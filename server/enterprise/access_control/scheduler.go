@@ -0,0 +1,17 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.enterprise for license information.
+
+package access_control
+
+import (
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/channels/jobs"
+)
+
+// MakeScheduler creates a scheduler running the job periodically, when attribute based access control is enabled.
+func MakeScheduler(jobServer *jobs.JobServer, license func() *model.License) *jobs.PeriodicScheduler {
+	isEnabled := func(cfg *model.Config) bool {
+		return model.MinimumEnterpriseAdvancedLicense(license()) && *cfg.AccessControlSettings.EnableAttributeBasedAccessControl
+	}
+	return jobs.NewPeriodicScheduler(jobServer, model.JobTypeAccessControlSync, syncInterval, isEnabled)
+}
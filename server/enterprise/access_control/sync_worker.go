@@ -0,0 +1,229 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.enterprise for license information.
+
+package access_control
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/app"
+	"github.com/mattermost/mattermost/server/v8/channels/jobs"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+	"github.com/mattermost/mattermost/server/v8/einterfaces"
+	ejobs "github.com/mattermost/mattermost/server/v8/einterfaces/jobs"
+)
+
+const (
+	// JobDataPolicyID restricts the job to a channel policy, or to the channel policies importing a parent policy.
+	JobDataPolicyID = "policy_id"
+
+	jobDataCursor          = "cursor"
+	jobDataAttributesFresh = "attributes_refreshed"
+
+	JobDataChannelsSynced = "channels_synced"
+	JobDataMembersRemoved = "members_removed"
+)
+
+const (
+	// policiesPerBatch is the number of channels synchronized by each batch of the job.
+	policiesPerBatch = 10
+	// timeBetweenBatches leaves room for the other jobs and requests between the batches.
+	timeBetweenBatches = 100 * time.Millisecond
+	// syncInterval is how often the channel memberships are synchronized with the policies.
+	syncInterval = time.Hour
+)
+
+type AccessControlSyncJobInterfaceImpl struct {
+	Server *app.Server
+}
+
+func (m *AccessControlSyncJobInterfaceImpl) MakeWorker() model.Worker {
+	a := app.New(app.ServerConnector(m.Server.Channels()))
+	return MakeWorker(m.Server.Jobs, m.Server.Store(), a, m.Server.Channels().AccessControl)
+}
+
+func (m *AccessControlSyncJobInterfaceImpl) MakeScheduler() ejobs.Scheduler {
+	return MakeScheduler(m.Server.Jobs, m.Server.License)
+}
+
+type SyncAppIface interface {
+	Config() *model.Config
+	License() *model.License
+	GetChannel(rctx request.CTX, channelID string) (*model.Channel, *model.AppError)
+	RemoveUserFromChannel(rctx request.CTX, userIDToRemove string, removerUserId string, channel *model.Channel) *model.AppError
+	MakeAuditRecord(rctx request.CTX, event string, initialStatus string) *model.AuditRecord
+	LogAuditRec(rctx request.CTX, rec *model.AuditRecord, err error)
+}
+
+// MakeWorker creates a worker that removes, in batches of channels, the members who don't satisfy the policy of
+// their channel anymore, e.g. because one of their attributes changed. Each removal is recorded in the audit log.
+func MakeWorker(jobServer *jobs.JobServer, store store.Store, app SyncAppIface, pap einterfaces.PolicyAdministrationPointInterface) *jobs.BatchWorker {
+	w := &syncWorker{
+		jobServer: jobServer,
+		store:     store,
+		app:       app,
+		pap:       pap,
+	}
+	return jobs.MakeBatchWorker(jobServer, store, timeBetweenBatches, w.doBatch)
+}
+
+type syncWorker struct {
+	jobServer *jobs.JobServer
+	store     store.Store
+	app       SyncAppIface
+	pap       einterfaces.PolicyAdministrationPointInterface
+}
+
+func (w *syncWorker) doBatch(rctx request.CTX, job *model.Job) bool {
+	logger := rctx.Logger()
+
+	if !model.MinimumEnterpriseAdvancedLicense(w.app.License()) || !*w.app.Config().AccessControlSettings.EnableAttributeBasedAccessControl || w.pap == nil {
+		w.setJobError(logger, job, model.NewAppError("doBatch", "ent.access_control.sync_job.app_error", nil, "attribute based access control is not enabled", http.StatusNotImplemented))
+		return true
+	}
+
+	if job.Data == nil {
+		job.Data = make(model.StringMap)
+	}
+
+	// The attributes are read from a materialized view, which is refreshed once per job.
+	if job.Data[jobDataAttributesFresh] == "" {
+		if err := w.store.Attributes().RefreshAttributes(); err != nil {
+			w.setJobError(logger, job, model.NewAppError("doBatch", "ent.access_control.sync_job.app_error", nil, "", http.StatusInternalServerError).Wrap(err))
+			return true
+		}
+		job.Data[jobDataAttributesFresh] = "true"
+	}
+
+	policies, appErr := w.nextPolicies(rctx, job)
+	if appErr != nil {
+		w.setJobError(logger, job, appErr)
+		return true
+	}
+	if len(policies) == 0 {
+		return w.finish(logger, job)
+	}
+
+	for _, policy := range policies {
+		if appErr := w.syncChannel(rctx, job, policy); appErr != nil {
+			w.setJobError(logger, job, appErr)
+			return true
+		}
+		job.Data[jobDataCursor] = policy.ID
+		job.Data[JobDataChannelsSynced] = strconv.Itoa(getInt(job, JobDataChannelsSynced) + 1)
+	}
+
+	if len(policies) < policiesPerBatch {
+		return w.finish(logger, job)
+	}
+
+	if appErr := w.jobServer.UpdateInProgressJobData(job); appErr != nil {
+		logger.Warn("Failed to update the job data", mlog.Err(appErr))
+	}
+	return false
+}
+
+// nextPolicies returns the next channel policies to synchronize.
+func (w *syncWorker) nextPolicies(rctx request.CTX, job *model.Job) ([]*model.AccessControlPolicy, *model.AppError) {
+	search := model.AccessControlPolicySearch{
+		Type:   model.AccessControlPolicyTypeChannel,
+		Cursor: model.AccessControlPolicyCursor{ID: job.Data[jobDataCursor]},
+		Limit:  policiesPerBatch,
+	}
+
+	if policyID := job.Data[JobDataPolicyID]; policyID != "" {
+		policy, appErr := w.pap.GetPolicy(rctx, policyID)
+		if appErr != nil {
+			return nil, appErr
+		}
+		if policy.Type == model.AccessControlPolicyTypeChannel {
+			if job.Data[jobDataCursor] != "" {
+				return nil, nil
+			}
+			return []*model.AccessControlPolicy{policy}, nil
+		}
+		search.ParentID = policyID
+	}
+
+	policies, _, err := w.store.AccessControlPolicy().SearchPolicies(rctx, search)
+	if err != nil {
+		return nil, model.NewAppError("nextPolicies", "ent.access_control.sync_job.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return policies, nil
+}
+
+// syncChannel removes the members of the channel who don't satisfy its policy anymore.
+func (w *syncWorker) syncChannel(rctx request.CTX, job *model.Job, policy *model.AccessControlPolicy) *model.AppError {
+	members, appErr := w.pap.GetChannelMembersToRemove(rctx, policy.ID)
+	if appErr != nil && appErr.Id == "app.pap.missing_imported_policy.app_error" {
+		// The members are kept rather than all removed, while the channel can't be joined anymore.
+		rctx.Logger().Warn("Skipping the policy importing a missing policy", mlog.String("policy_id", policy.ID), mlog.Err(appErr))
+		return nil
+	} else if appErr != nil {
+		return appErr
+	}
+	if len(members) == 0 {
+		return nil
+	}
+
+	channel, appErr := w.app.GetChannel(rctx, policy.ID)
+	if appErr != nil && appErr.StatusCode == http.StatusNotFound {
+		rctx.Logger().Warn("Skipping the policy of a missing channel", mlog.String("policy_id", policy.ID))
+		return nil
+	} else if appErr != nil {
+		return appErr
+	}
+
+	for _, member := range members {
+		rec := w.app.MakeAuditRecord(rctx, model.AuditEventRemoveChannelMemberByAccessPolicy, model.AuditStatusFail)
+		model.AddEventParameterToAuditRec(rec, "job_id", job.Id)
+		model.AddEventParameterToAuditRec(rec, "policy_id", policy.ID)
+		model.AddEventParameterToAuditRec(rec, "channel_id", channel.Id)
+		model.AddEventParameterToAuditRec(rec, "user_id", member.UserId)
+		rec.AddEventPriorState(member)
+		rec.AddEventObjectType("channel_member")
+
+		appErr := w.app.RemoveUserFromChannel(rctx, member.UserId, "", channel)
+		if appErr == nil {
+			rec.Success()
+			w.app.LogAuditRec(rctx, rec, nil)
+			job.Data[JobDataMembersRemoved] = strconv.Itoa(getInt(job, JobDataMembersRemoved) + 1)
+			continue
+		}
+
+		w.app.LogAuditRec(rctx, rec, appErr)
+		rctx.Logger().Warn("Failed to remove a member who doesn't satisfy the channel policy",
+			mlog.String("channel_id", channel.Id),
+			mlog.String("user_id", member.UserId),
+			mlog.Err(appErr),
+		)
+	}
+
+	rctx.Logger().Info("Synchronized the channel members with the policy", mlog.String("channel_id", channel.Id), mlog.Int("members_to_remove", len(members)))
+	return nil
+}
+
+func (w *syncWorker) finish(logger mlog.LoggerIFace, job *model.Job) bool {
+	if appErr := w.jobServer.SetJobSuccess(job); appErr != nil {
+		logger.Error("Failed to set the job as successful", mlog.Err(appErr))
+		w.setJobError(logger, job, appErr)
+	}
+	return true
+}
+
+func (w *syncWorker) setJobError(logger mlog.LoggerIFace, job *model.Job, appErr *model.AppError) {
+	logger.Error("Failed to synchronize the channel members with the access control policies", mlog.Err(appErr))
+	if err := w.jobServer.SetJobError(job, appErr); err != nil {
+		logger.Error("Failed to set the job error", mlog.Err(err))
+	}
+}
+
+func getInt(job *model.Job, key string) int {
+	n, _ := strconv.Atoi(job.Data[key])
+	return n
+}
@@ -0,0 +1,131 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.enterprise for license information.
+
+package access_control
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+type testSyncApp struct {
+	channel      *model.Channel
+	removeErrors map[string]*model.AppError
+	removed      []string
+	auditRecords []*model.AuditRecord
+	auditLogErrs []error
+}
+
+func (a *testSyncApp) Config() *model.Config { return &model.Config{} }
+func (a *testSyncApp) License() *model.License {
+	return model.NewTestLicenseSKU(model.LicenseShortSkuEnterpriseAdvanced)
+}
+
+func (a *testSyncApp) GetChannel(rctx request.CTX, channelID string) (*model.Channel, *model.AppError) {
+	if a.channel == nil || a.channel.Id != channelID {
+		return nil, model.NewAppError("GetChannel", "app.channel.get.existing.app_error", nil, "", http.StatusNotFound)
+	}
+	return a.channel, nil
+}
+
+func (a *testSyncApp) RemoveUserFromChannel(rctx request.CTX, userIDToRemove string, removerUserId string, channel *model.Channel) *model.AppError {
+	if appErr := a.removeErrors[userIDToRemove]; appErr != nil {
+		return appErr
+	}
+	a.removed = append(a.removed, userIDToRemove)
+	return nil
+}
+
+func (a *testSyncApp) MakeAuditRecord(rctx request.CTX, event string, initialStatus string) *model.AuditRecord {
+	return &model.AuditRecord{EventName: event, Status: initialStatus}
+}
+
+func (a *testSyncApp) LogAuditRec(rctx request.CTX, rec *model.AuditRecord, err error) {
+	a.auditRecords = append(a.auditRecords, rec)
+	a.auditLogErrs = append(a.auditLogErrs, err)
+}
+
+func TestSyncChannel(t *testing.T) {
+	rctx := request.TestContext(t)
+
+	t.Run("removes the members who don't satisfy the policy", func(t *testing.T) {
+		s, st := setupService(t)
+		policy := newPolicy(model.AccessControlPolicyTypeChannel, true, `user.attributes.Team == "Engineering"`)
+		st.policies.On("Get", mock.Anything, policy.ID).Return(policy, nil)
+		members := []*model.ChannelMember{
+			{ChannelId: policy.ID, UserId: model.NewId()},
+			{ChannelId: policy.ID, UserId: model.NewId()},
+		}
+		st.attributes.On("GetChannelMembersToRemove", mock.Anything, policy.ID, mock.Anything).Return(members, nil)
+
+		app := &testSyncApp{
+			channel: &model.Channel{Id: policy.ID, Type: model.ChannelTypePrivate},
+			removeErrors: map[string]*model.AppError{
+				members[1].UserId: model.NewAppError("RemoveUserFromChannel", "api.channel.remove_user_from_channel.app_error", nil, "", http.StatusInternalServerError),
+			},
+		}
+		w := &syncWorker{app: app, pap: s}
+		job := &model.Job{Id: model.NewId(), Data: model.StringMap{}}
+
+		require.Nil(t, w.syncChannel(rctx, job, policy))
+
+		assert.Equal(t, []string{members[0].UserId}, app.removed)
+		assert.Equal(t, "1", job.Data[JobDataMembersRemoved])
+
+		require.Len(t, app.auditRecords, 2)
+		for i, rec := range app.auditRecords {
+			assert.Equal(t, model.AuditEventRemoveChannelMemberByAccessPolicy, rec.EventName)
+			assert.Equal(t, job.Id, rec.EventData.Parameters["job_id"])
+			assert.Equal(t, policy.ID, rec.EventData.Parameters["policy_id"])
+			assert.Equal(t, policy.ID, rec.EventData.Parameters["channel_id"])
+			assert.Equal(t, members[i].UserId, rec.EventData.Parameters["user_id"])
+			assert.Equal(t, "channel_member", rec.EventData.ObjectType)
+		}
+		assert.Equal(t, model.AuditStatusSuccess, app.auditRecords[0].Status)
+		assert.NoError(t, app.auditLogErrs[0])
+		assert.Equal(t, model.AuditStatusFail, app.auditRecords[1].Status)
+		assert.Error(t, app.auditLogErrs[1])
+	})
+
+	t.Run("keeps the members of an inactive policy", func(t *testing.T) {
+		s, st := setupService(t)
+		policy := newPolicy(model.AccessControlPolicyTypeChannel, false, `user.attributes.Team == "Engineering"`)
+		st.policies.On("Get", mock.Anything, policy.ID).Return(policy, nil)
+
+		app := &testSyncApp{channel: &model.Channel{Id: policy.ID, Type: model.ChannelTypePrivate}}
+		w := &syncWorker{app: app, pap: s}
+		job := &model.Job{Id: model.NewId(), Data: model.StringMap{}}
+
+		require.Nil(t, w.syncChannel(rctx, job, policy))
+
+		assert.Empty(t, app.removed)
+		assert.Empty(t, app.auditRecords)
+		st.attributes.AssertNotCalled(t, "GetChannelMembersToRemove", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("keeps the members of a policy importing a missing policy", func(t *testing.T) {
+		s, st := setupService(t)
+		parentID := model.NewId()
+		policy := newPolicy(model.AccessControlPolicyTypeChannel, true, `true`, parentID)
+		st.policies.On("Get", mock.Anything, parentID).Return(nil, store.NewErrNotFound("AccessControlPolicy", parentID))
+		st.policies.On("Get", mock.Anything, policy.ID).Return(policy, nil)
+
+		app := &testSyncApp{channel: &model.Channel{Id: policy.ID, Type: model.ChannelTypePrivate}}
+		w := &syncWorker{app: app, pap: s}
+		job := &model.Job{Id: model.NewId(), Data: model.StringMap{}}
+
+		require.Nil(t, w.syncChannel(rctx, job, policy))
+
+		assert.Empty(t, app.removed)
+		assert.Empty(t, app.auditRecords)
+		st.attributes.AssertNotCalled(t, "GetChannelMembersToRemove", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,11 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+//go:build sourceavailable && !enterprise
+
+package enterprise
+
+import (
+	// Needed to ensure the init() method in the EE gets run. Enterprise builds use their own access control service.
+	_ "github.com/mattermost/mattermost/server/v8/enterprise/access_control"
+)
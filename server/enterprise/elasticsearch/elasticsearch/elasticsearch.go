@@ -717,6 +717,44 @@ func (es *ElasticsearchInterfaceImpl) DeleteUserPosts(rctx request.CTX, userID s
 	return nil
 }
 
+func (es *ElasticsearchInterfaceImpl) DeletePosts(rctx request.CTX, postIDs []string) *model.AppError {
+	if len(postIDs) == 0 {
+		return nil
+	}
+
+	es.mutex.RLock()
+	defer es.mutex.RUnlock()
+
+	if atomic.LoadInt32(&es.ready) == 0 {
+		return model.NewAppError("Elasticsearch.DeletePosts", "ent.elasticsearch.not_started.error", map[string]any{"Backend": model.ElasticsearchSettingsESBackend}, "", http.StatusInternalServerError)
+	}
+
+	postIndexes, err := es.getPostIndexNames()
+	if err != nil {
+		return model.NewAppError("Elasticsearch.DeletePosts", "ent.elasticsearch.delete_posts.error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(*es.Platform.Config().ElasticsearchSettings.RequestTimeoutSeconds)*time.Second)
+	defer cancel()
+
+	query := &types.Query{
+		Ids: &types.IdsQuery{Values: postIDs},
+	}
+
+	deleteQuery := es.client.DeleteByQuery(strings.Join(postIndexes, ",")).
+		Request(&deletebyquery.Request{
+			Query: query,
+		})
+
+	response, err := deleteQuery.Do(ctx)
+	if err != nil {
+		return model.NewAppError("Elasticsearch.DeletePosts", "ent.elasticsearch.delete_posts.error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	rctx.Logger().Debug("Posts deleted", mlog.Int("count", len(postIDs)), mlog.Int("deleted", *response.Deleted))
+
+	return nil
+}
+
 func (es *ElasticsearchInterfaceImpl) deletePost(indexName, postID string) *model.AppError {
 	var err error
 	if es.bulkProcessor != nil {
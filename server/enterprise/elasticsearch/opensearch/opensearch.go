@@ -777,6 +777,48 @@ func (os *OpensearchInterfaceImpl) DeleteUserPosts(rctx request.CTX, userID stri
 	return nil
 }
 
+func (os *OpensearchInterfaceImpl) DeletePosts(rctx request.CTX, postIDs []string) *model.AppError {
+	if len(postIDs) == 0 {
+		return nil
+	}
+
+	os.mutex.RLock()
+	defer os.mutex.RUnlock()
+
+	if atomic.LoadInt32(&os.ready) == 0 {
+		return model.NewAppError("Opensearch.DeletePosts", "ent.elasticsearch.not_started.error", map[string]any{"Backend": model.ElasticsearchSettingsOSBackend}, "", http.StatusInternalServerError)
+	}
+
+	postIndexes, err := os.getPostIndexNames()
+	if err != nil {
+		return model.NewAppError("Opensearch.DeletePosts", "ent.elasticsearch.delete_posts.error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(*os.Platform.Config().ElasticsearchSettings.RequestTimeoutSeconds)*time.Second)
+	defer cancel()
+
+	query := &types.Query{
+		Ids: &types.IdsQuery{Values: postIDs},
+	}
+
+	queryBuf, err := json.Marshal(deletebyquery.Request{
+		Query: query,
+	})
+	if err != nil {
+		return model.NewAppError("Opensearch.DeletePosts", "api.marshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	response, err := os.client.Document.DeleteByQuery(ctx, opensearchapi.DocumentDeleteByQueryReq{
+		Indices: postIndexes,
+		Body:    bytes.NewReader(queryBuf),
+	})
+	if err != nil {
+		return model.NewAppError("Opensearch.DeletePosts", "ent.elasticsearch.delete_posts.error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	rctx.Logger().Debug("Posts deleted", mlog.Int("count", len(postIDs)), mlog.Int("deleted", response.Deleted))
+
+	return nil
+}
+
 func (os *OpensearchInterfaceImpl) deletePost(indexName, postID string) *model.AppError {
 	var err error
 	if os.bulkProcessor != nil {
@@ -37,8 +37,6 @@ import (
 	// Needed to ensure the init() method in the EE gets run
 	_ "github.com/mattermost/enterprise/access_control"
 	// Needed to ensure the init() method in the EE gets run
-	_ "github.com/mattermost/enterprise/message_export"
-	// Needed to ensure the init() method in the EE gets run
 	_ "github.com/mattermost/enterprise/message_export/actiance_export"
 	// Needed to ensure the init() method in the EE gets run
 	_ "github.com/mattermost/enterprise/push_proxy"
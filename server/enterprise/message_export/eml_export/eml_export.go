@@ -0,0 +1,377 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.enterprise for license information.
+
+// Package eml_export writes message export batches as RFC 5322 messages, for archiving and e-discovery tools.
+//
+// Each batch is a zip file holding one .eml file per conversation, i.e. per channel with activity during the batch,
+// and a manifest.json with the size and SHA-256 checksum of every .eml file. Each message has a plain text transcript
+// of the conversation, followed by the attachments uploaded during the batch.
+package eml_export
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/textproto"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/enterprise/message_export/shared"
+	"github.com/mattermost/mattermost/server/v8/platform/shared/filestore"
+)
+
+const (
+	ConversationsDir = "conversations"
+
+	timestampFormat = "2006-01-02 15:04:05.000 MST"
+
+	// base64LineLength is the line length of the base64 encoded attachments, as required by RFC 2045.
+	base64LineLength = 76
+)
+
+// EmlExport writes the batch described by p to p.BatchPath on the export backend.
+func EmlExport(rctx request.CTX, p shared.ExportParams) (shared.RunExportResults, error) {
+	start := time.Now()
+	genericData, err := shared.GetGenericExportData(p)
+	if err != nil {
+		return shared.RunExportResults{}, err
+	}
+	results := genericData.Results
+	results.NumChannels = len(genericData.Exports)
+	results.ProcessingPostsMs = time.Since(start).Milliseconds()
+
+	exports := genericData.Exports
+	sort.Slice(exports, func(i, j int) bool {
+		return exports[i].ChannelId < exports[j].ChannelId
+	})
+
+	manifest := shared.NewManifest(p, genericData.Metadata.MessagesCount, len(exports))
+	start = time.Now()
+	archive, err := shared.WriteArchive(p.ExportBackend, p.BatchPath, manifest, func(a *shared.Archive) error {
+		for _, channel := range exports {
+			w, err := a.Create(path.Join(ConversationsDir, channel.ChannelId+".eml"))
+			if err != nil {
+				return err
+			}
+			writeResult, err := writeConversation(rctx, w, p, channel)
+			if err != nil {
+				return err
+			}
+			results.TransferringFilesMs += writeResult.TransferringFilesMs
+			results.ProcessingXmlMs += writeResult.ProcessingXmlMs
+			results.NumWarnings += writeResult.NumWarnings
+		}
+		return nil
+	})
+	if err != nil {
+		return results, err
+	}
+	results.TransferringZipMs = time.Since(start).Milliseconds()
+	results.Archive = archive
+
+	return results, nil
+}
+
+type transcriptLine struct {
+	timestamp int64
+	text      string
+}
+
+// writeConversation writes the message for the channel's activity during the batch.
+func writeConversation(rctx request.CTX, w io.Writer, p shared.ExportParams, channel shared.ChannelExport) (shared.WriteExportResult, error) {
+	var result shared.WriteExportResult
+	start := time.Now()
+
+	attachments, missing := checkAttachments(p.FileAttachmentBackend, channel.UploadStarts)
+	result.NumWarnings += len(missing)
+	for _, fileInfo := range missing {
+		rctx.Logger().Warn(shared.MissingFileMessageDuringBackendRead, mlog.String("post_id", fileInfo.PostId), mlog.String("filename", fileInfo.Path))
+	}
+
+	mw := multipart.NewWriter(w)
+	if err := writeHeaders(w, p, channel, mw.Boundary()); err != nil {
+		return result, err
+	}
+
+	part, err := mw.CreatePart(textproto.MIMEHeader{
+		"Content-Type":              {"text/plain; charset=utf-8"},
+		"Content-Transfer-Encoding": {"quoted-printable"},
+	})
+	if err != nil {
+		return result, errors.Wrap(err, "unable to create the transcript")
+	}
+	qp := quotedprintable.NewWriter(part)
+	if _, err := io.WriteString(qp, transcript(channel, missing)); err != nil {
+		return result, errors.Wrap(err, "unable to write the transcript")
+	}
+	if err := qp.Close(); err != nil {
+		return result, errors.Wrap(err, "unable to write the transcript")
+	}
+	result.ProcessingXmlMs = time.Since(start).Milliseconds()
+
+	start = time.Now()
+	for _, fileInfo := range attachments {
+		if err := writeAttachment(mw, p.FileAttachmentBackend, fileInfo); err != nil {
+			return result, err
+		}
+	}
+	result.TransferringFilesMs = time.Since(start).Milliseconds()
+
+	if err := mw.Close(); err != nil {
+		return result, errors.Wrap(err, "unable to finish the message")
+	}
+	return result, nil
+}
+
+func writeHeaders(w io.Writer, p shared.ExportParams, channel shared.ChannelExport, boundary string) error {
+	participants := participants(channel)
+	from := &mail.Address{Name: "Mattermost", Address: "noreply@" + domain(p.Config)}
+	if len(participants) > 0 {
+		from = participants[0]
+	}
+	to := make([]string, 0, len(participants))
+	for _, participant := range participants {
+		to = append(to, participant.String())
+	}
+	if len(to) == 0 {
+		to = append(to, from.String())
+	}
+
+	subject := fmt.Sprintf("Mattermost %s conversation: %s", shared.ChannelTypeDisplayName(channel.ChannelType), channel.DisplayName)
+	if channel.TeamDisplayName != "" {
+		subject += " (" + channel.TeamDisplayName + ")"
+	}
+
+	headers := [][2]string{
+		{"MIME-Version", "1.0"},
+		{"Message-ID", fmt.Sprintf("<%s.%d.%d@%s>", channel.ChannelId, channel.StartTime, channel.EndTime, domain(p.Config))},
+		{"Date", time.UnixMilli(channel.EndTime).UTC().Format(time.RFC1123Z)},
+		{"From", from.String()},
+		// Long lists of participants are folded, one address per line.
+		{"To", strings.Join(to, ",\r\n ")},
+		{"Subject", mime.QEncoding.Encode("utf-8", subject)},
+		{"X-Mattermost-ChannelId", channel.ChannelId},
+		{"X-Mattermost-ChannelName", mime.QEncoding.Encode("utf-8", channel.ChannelName)},
+		{"X-Mattermost-ChannelType", shared.ChannelTypeDisplayName(channel.ChannelType)},
+		{"X-Mattermost-TeamId", channel.TeamId},
+		{"X-Mattermost-StartTime", fmt.Sprint(channel.StartTime)},
+		{"X-Mattermost-EndTime", fmt.Sprint(channel.EndTime)},
+		{"Content-Type", mime.FormatMediaType("multipart/mixed", map[string]string{"boundary": boundary})},
+	}
+	for _, header := range headers {
+		if header[1] == "" {
+			continue
+		}
+		if _, err := fmt.Fprintf(w, "%s: %s\r\n", header[0], header[1]); err != nil {
+			return errors.Wrap(err, "unable to write the headers")
+		}
+	}
+	if _, err := io.WriteString(w, "\r\n"); err != nil {
+		return errors.Wrap(err, "unable to write the headers")
+	}
+	return nil
+}
+
+// participants returns the addresses of the users who were in the channel or posted during the batch, in order of
+// appearance.
+func participants(channel shared.ChannelExport) []*mail.Address {
+	var addresses []*mail.Address
+	seen := make(map[string]bool)
+	add := func(username, email string) {
+		if email == "" || seen[email] {
+			return
+		}
+		seen[email] = true
+		addresses = append(addresses, &mail.Address{Name: username, Address: email})
+	}
+
+	for _, post := range channel.Posts {
+		add(model.SafeDereference(post.Username), model.SafeDereference(post.UserEmail))
+	}
+	joins := make([]shared.JoinExport, len(channel.JoinEvents))
+	copy(joins, channel.JoinEvents)
+	sort.SliceStable(joins, func(i, j int) bool {
+		return joins[i].JoinTime < joins[j].JoinTime
+	})
+	for _, join := range joins {
+		add(join.Username, join.UserEmail)
+	}
+	return addresses
+}
+
+func domain(config *model.Config) string {
+	if config != nil && config.ServiceSettings.SiteURL != nil {
+		if siteURL, err := url.Parse(*config.ServiceSettings.SiteURL); err == nil && siteURL.Hostname() != "" {
+			return siteURL.Hostname()
+		}
+	}
+	return "localhost"
+}
+
+func transcript(channel shared.ChannelExport, missing []*model.FileInfo) string {
+	missingIds := make(map[string]bool, len(missing))
+	for _, fileInfo := range missing {
+		missingIds[fileInfo.Id] = true
+	}
+
+	var lines []transcriptLine
+	for _, join := range channel.JoinEvents {
+		lines = append(lines, transcriptLine{join.JoinTime, fmt.Sprintf("%s joined the channel", user(join.Username, join.UserEmail))})
+	}
+	for _, leave := range channel.LeaveEvents {
+		if leave.ClosedOut {
+			continue
+		}
+		lines = append(lines, transcriptLine{leave.LeaveTime, fmt.Sprintf("%s left the channel", user(leave.Username, leave.UserEmail))})
+	}
+	for _, post := range channel.Posts {
+		author := user(model.SafeDereference(post.Username), model.SafeDereference(post.UserEmail))
+		message := model.SafeDereference(post.PostMessage)
+		if rootId := model.SafeDereference(post.PostRootId); rootId != "" {
+			author += " (reply to " + rootId + ")"
+		}
+
+		switch post.UpdatedType {
+		case shared.EditedNewMsg:
+			lines = append(lines, transcriptLine{post.UpdateAt, fmt.Sprintf("%s edited message %s: %s", author, model.SafeDereference(post.PostId), message)})
+		case shared.EditedOriginalMsg:
+			lines = append(lines, transcriptLine{post.UpdateAt, fmt.Sprintf("%s edited message %s, previously: %s", author, post.EditedNewMsgId, message)})
+		case shared.UpdatedNoMsgChange:
+			lines = append(lines, transcriptLine{post.UpdateAt, fmt.Sprintf("%s's message %s was updated: %s", author, model.SafeDereference(post.PostId), message)})
+		case shared.Deleted:
+			lines = append(lines, transcriptLine{post.UpdateAt, fmt.Sprintf("%s deleted message %s: %s", author, model.SafeDereference(post.PostId), message)})
+		default:
+			lines = append(lines, transcriptLine{model.SafeDereference(post.PostCreateAt), fmt.Sprintf("%s: %s", author, message)})
+		}
+
+		for _, upload := range post.AttachmentCreates {
+			note := "attached"
+			if missingIds[upload.FileInfo.Id] {
+				note = "missing from the file store"
+			}
+			lines = append(lines, transcriptLine{model.SafeDereference(post.PostCreateAt), fmt.Sprintf("%s uploaded %s (%s)", author, upload.FileInfo.Name, note)})
+		}
+		for _, deleted := range post.AttachmentDeletes {
+			lines = append(lines, transcriptLine{deleted.UpdateAt, fmt.Sprintf("%s deleted file %s", author, deleted.FileInfo.Name)})
+		}
+	}
+	sort.SliceStable(lines, func(i, j int) bool {
+		return lines[i].timestamp < lines[j].timestamp
+	})
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Conversation: %s (%s)\n", channel.DisplayName, channel.ChannelName)
+	if channel.TeamName != "" {
+		fmt.Fprintf(&b, "Team: %s (%s)\n", channel.TeamDisplayName, channel.TeamName)
+	}
+	fmt.Fprintf(&b, "Period: %s - %s\n\n", formatTimestamp(channel.StartTime), formatTimestamp(channel.EndTime))
+	for _, line := range lines {
+		fmt.Fprintf(&b, "[%s] %s\n", formatTimestamp(line.timestamp), line.text)
+	}
+	return b.String()
+}
+
+func user(username, email string) string {
+	if email == "" {
+		return "@" + username
+	}
+	return fmt.Sprintf("@%s <%s>", username, email)
+}
+
+func formatTimestamp(millis int64) string {
+	return time.UnixMilli(millis).UTC().Format(timestampFormat)
+}
+
+// checkAttachments returns the attachments to add to the message, and the ones missing from the file store.
+func checkAttachments(backend filestore.FileBackend, uploads []*shared.FileUploadStartExport) ([]*model.FileInfo, []*model.FileInfo) {
+	var attachments, missing []*model.FileInfo
+	seen := make(map[string]bool)
+	for _, upload := range uploads {
+		if seen[upload.FileInfo.Id] {
+			continue
+		}
+		seen[upload.FileInfo.Id] = true
+
+		if exists, err := backend.FileExists(upload.FileInfo.Path); err != nil || !exists {
+			missing = append(missing, upload.FileInfo)
+			continue
+		}
+		attachments = append(attachments, upload.FileInfo)
+	}
+	return attachments, missing
+}
+
+func writeAttachment(mw *multipart.Writer, backend filestore.FileBackend, fileInfo *model.FileInfo) error {
+	r, err := backend.Reader(fileInfo.Path)
+	if err != nil {
+		return errors.Wrapf(err, "unable to read attachment %s", fileInfo.Id)
+	}
+	defer r.Close()
+
+	mimeType := fileInfo.MimeType
+	if mimeType == "" {
+		mimeType = "application/octet-stream"
+	}
+	part, err := mw.CreatePart(textproto.MIMEHeader{
+		"Content-Type":              {mime.FormatMediaType(mimeType, map[string]string{"name": fileInfo.Name})},
+		"Content-Disposition":       {mime.FormatMediaType("attachment", map[string]string{"filename": fileInfo.Name})},
+		"Content-Transfer-Encoding": {"base64"},
+		"X-Mattermost-FileId":       {fileInfo.Id},
+		"X-Mattermost-PostId":       {fileInfo.PostId},
+	})
+	if err != nil {
+		return errors.Wrapf(err, "unable to create attachment %s", fileInfo.Id)
+	}
+
+	encoder := base64.NewEncoder(base64.StdEncoding, &lineWrapper{w: part, max: base64LineLength})
+	if _, err := io.Copy(encoder, r); err != nil {
+		return errors.Wrapf(err, "unable to write attachment %s", fileInfo.Id)
+	}
+	if err := encoder.Close(); err != nil {
+		return errors.Wrapf(err, "unable to write attachment %s", fileInfo.Id)
+	}
+	if _, err := io.WriteString(part, "\r\n"); err != nil {
+		return errors.Wrapf(err, "unable to write attachment %s", fileInfo.Id)
+	}
+	return nil
+}
+
+// lineWrapper breaks the lines written to w at max characters.
+type lineWrapper struct {
+	w   io.Writer
+	max int
+	n   int
+}
+
+func (l *lineWrapper) Write(p []byte) (int, error) {
+	written := 0
+	for len(p) > 0 {
+		if l.n == l.max {
+			if _, err := io.WriteString(l.w, "\r\n"); err != nil {
+				return written, err
+			}
+			l.n = 0
+		}
+		chunk := min(len(p), l.max-l.n)
+		n, err := l.w.Write(p[:chunk])
+		written += n
+		l.n += n
+		if err != nil {
+			return written, err
+		}
+		p = p[chunk:]
+	}
+	return written, nil
+}
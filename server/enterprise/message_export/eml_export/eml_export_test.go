@@ -0,0 +1,219 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.enterprise for license information.
+
+package eml_export
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/enterprise/message_export/shared"
+	"github.com/mattermost/mattermost/server/v8/platform/shared/filestore"
+)
+
+type fileInfoStore struct {
+	files map[string][]*model.FileInfo
+}
+
+func (s *fileInfoStore) GetForPost(postID string, _, _, _ bool) ([]*model.FileInfo, error) {
+	return s.files[postID], nil
+}
+
+// exportStore only implements the FileInfo store, which is the only one used by the export.
+type exportStore struct {
+	shared.MessageExportStore
+	fileInfoStore *fileInfoStore
+}
+
+func (s *exportStore) FileInfo() shared.MEFileInfoStore {
+	return s.fileInfoStore
+}
+
+func TestEmlExport(t *testing.T) {
+	backend, err := filestore.NewFileBackend(filestore.FileBackendSettings{DriverName: model.ImageDriverLocal, Directory: t.TempDir()})
+	require.NoError(t, err)
+
+	channelId := model.NewId()
+	otherChannelId := model.NewId()
+	userId := model.NewId()
+	otherUserId := model.NewId()
+	postId := model.NewId()
+
+	content := bytes.Repeat([]byte("attachment content "), 20)
+	fileInfo := &model.FileInfo{Id: model.NewId(), PostId: postId, Name: "résumé.txt", Path: "data/resume.txt", Size: int64(len(content)), MimeType: "text/plain"}
+	_, err = backend.WriteFile(bytes.NewReader(content), fileInfo.Path)
+	require.NoError(t, err)
+
+	cfg := &model.Config{}
+	cfg.SetDefaults()
+	cfg.ServiceSettings.SiteURL = model.NewPointer("https://chat.example.com")
+
+	params := shared.ExportParams{
+		ExportType: model.ComplianceExportTypeEml,
+		ChannelMetadata: map[string]*shared.MetadataChannel{
+			channelId: {
+				TeamId:             model.NewPointer(model.NewId()),
+				ChannelId:          channelId,
+				ChannelName:        "channel-name",
+				ChannelDisplayName: "Ünicode Channel",
+				ChannelType:        model.ChannelTypePrivate,
+			},
+			otherChannelId: {
+				ChannelId:          otherChannelId,
+				ChannelName:        "other-channel",
+				ChannelDisplayName: "Other",
+				ChannelType:        model.ChannelTypeOpen,
+			},
+		},
+		Posts: []*model.MessageExport{{
+			TeamId:             model.NewPointer(model.NewId()),
+			TeamName:           model.NewPointer("team-name"),
+			TeamDisplayName:    model.NewPointer("Team"),
+			ChannelId:          model.NewPointer(channelId),
+			ChannelName:        model.NewPointer("channel-name"),
+			ChannelDisplayName: model.NewPointer("Ünicode Channel"),
+			ChannelType:        model.NewPointer(model.ChannelTypePrivate),
+			UserId:             model.NewPointer(userId),
+			UserEmail:          model.NewPointer("author@example.com"),
+			Username:           model.NewPointer("author"),
+			PostId:             model.NewPointer(postId),
+			PostCreateAt:       model.NewPointer(int64(200)),
+			PostUpdateAt:       model.NewPointer(int64(200)),
+			PostDeleteAt:       model.NewPointer(int64(0)),
+			PostEditAt:         model.NewPointer(int64(0)),
+			PostMessage:        model.NewPointer("hello, with a long line that is longer than seventy six characters, which quoted-printable has to break"),
+			PostType:           model.NewPointer(""),
+			PostRootId:         model.NewPointer(""),
+			PostProps:          model.NewPointer("{}"),
+			PostOriginalId:     model.NewPointer(""),
+			PostFileIds:        model.StringArray{fileInfo.Id},
+		}},
+		ChannelMemberHistories: map[string][]*model.ChannelMemberHistoryResult{
+			channelId: {
+				{ChannelId: channelId, UserId: otherUserId, UserEmail: "member@example.com", Username: "member", JoinTime: 150},
+			},
+			otherChannelId: {
+				{ChannelId: otherChannelId, UserId: otherUserId, UserEmail: "member@example.com", Username: "member", JoinTime: 250, LeaveTime: model.NewPointer(int64(260))},
+			},
+		},
+		JobStartTime:          100,
+		BatchPath:             "export/batch000-100-300.zip",
+		BatchStartTime:        100,
+		BatchEndTime:          300,
+		Config:                cfg,
+		Db:                    &exportStore{fileInfoStore: &fileInfoStore{files: map[string][]*model.FileInfo{postId: {fileInfo}}}},
+		FileAttachmentBackend: backend,
+		ExportBackend:         backend,
+	}
+
+	results, err := EmlExport(request.TestContext(t), params)
+	require.NoError(t, err)
+	assert.Equal(t, 2, results.NumChannels)
+	assert.Zero(t, results.NumWarnings)
+
+	zipBytes, err := backend.ReadFile(params.BatchPath)
+	require.NoError(t, err)
+	sum := sha256.Sum256(zipBytes)
+	assert.Equal(t, hex.EncodeToString(sum[:]), results.Archive.SHA256)
+
+	r, err := zip.NewReader(bytes.NewReader(zipBytes), int64(len(zipBytes)))
+	require.NoError(t, err)
+	files := make(map[string][]byte)
+	for _, f := range r.File {
+		rc, err := f.Open()
+		require.NoError(t, err)
+		files[f.Name], err = io.ReadAll(rc)
+		require.NoError(t, err)
+		rc.Close()
+	}
+	require.Len(t, files, 3)
+
+	var manifest shared.Manifest
+	require.NoError(t, json.Unmarshal(files[shared.ManifestFileName], &manifest))
+	require.Len(t, manifest.Files, 2)
+	for _, entry := range manifest.Files {
+		sum := sha256.Sum256(files[entry.Path])
+		assert.Equal(t, hex.EncodeToString(sum[:]), entry.SHA256, entry.Path)
+	}
+
+	msg, err := mail.ReadMessage(bytes.NewReader(files[ConversationsDir+"/"+channelId+".eml"]))
+	require.NoError(t, err)
+
+	t.Run("headers", func(t *testing.T) {
+		subject, err := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+		require.NoError(t, err)
+		assert.Equal(t, "Mattermost private conversation: Ünicode Channel (Team)", subject)
+
+		from, err := msg.Header.AddressList("From")
+		require.NoError(t, err)
+		assert.Equal(t, []*mail.Address{{Name: "author", Address: "author@example.com"}}, from)
+
+		to, err := msg.Header.AddressList("To")
+		require.NoError(t, err)
+		require.Len(t, to, 2)
+		assert.Equal(t, "member@example.com", to[1].Address)
+
+		assert.True(t, strings.HasSuffix(msg.Header.Get("Message-ID"), "@chat.example.com>"))
+		assert.Equal(t, channelId, msg.Header.Get("X-Mattermost-ChannelId"))
+		_, err = msg.Header.Date()
+		require.NoError(t, err)
+	})
+
+	t.Run("body", func(t *testing.T) {
+		mediaType, mediaParams, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+		require.NoError(t, err)
+		require.Equal(t, "multipart/mixed", mediaType)
+
+		mr := multipart.NewReader(msg.Body, mediaParams["boundary"])
+		part, err := mr.NextPart()
+		require.NoError(t, err)
+		transcript, err := io.ReadAll(part)
+		require.NoError(t, err)
+		assert.Contains(t, string(transcript), "Conversation: Ünicode Channel (channel-name)")
+		assert.Contains(t, string(transcript), "@member <member@example.com> joined the channel")
+		assert.Contains(t, string(transcript), "@author <author@example.com>: hello, with a long line that is longer than seventy six characters, which quoted-printable has to break")
+		assert.Contains(t, string(transcript), "@author <author@example.com> uploaded résumé.txt (attached)")
+
+		part, err = mr.NextPart()
+		require.NoError(t, err)
+		assert.Equal(t, "résumé.txt", part.FileName())
+		assert.Equal(t, fileInfo.Id, part.Header.Get("X-Mattermost-FileId"))
+		encoded, err := io.ReadAll(part)
+		require.NoError(t, err)
+		for _, line := range strings.Split(strings.TrimSpace(string(encoded)), "\r\n") {
+			assert.LessOrEqual(t, len(line), base64LineLength)
+		}
+		decoded, err := io.ReadAll(base64.NewDecoder(base64.StdEncoding, bytes.NewReader(encoded)))
+		require.NoError(t, err)
+		assert.Equal(t, content, decoded)
+
+		_, err = mr.NextPart()
+		assert.Equal(t, io.EOF, err)
+	})
+
+	t.Run("conversation without posts", func(t *testing.T) {
+		msg, err := mail.ReadMessage(bytes.NewReader(files[ConversationsDir+"/"+otherChannelId+".eml"]))
+		require.NoError(t, err)
+		from, err := msg.Header.AddressList("From")
+		require.NoError(t, err)
+		assert.Equal(t, "member@example.com", from[0].Address)
+		body, err := io.ReadAll(msg.Body)
+		require.NoError(t, err)
+		assert.Contains(t, string(body), "left the channel")
+	})
+}
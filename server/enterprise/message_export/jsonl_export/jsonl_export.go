@@ -0,0 +1,291 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.enterprise for license information.
+
+// Package jsonl_export writes message export batches as JSON Lines.
+//
+// Each batch is a zip file holding:
+//
+//   - messages.jsonl: one Record per line, sorted by timestamp.
+//   - files/<file id>/<file name>: the attachments uploaded during the batch.
+//   - manifest.json: the size and SHA-256 checksum of every other file in the archive.
+//
+// The event of a record is one of:
+//
+//   - "post": a message was posted.
+//   - "update": a message was updated without changing its text, e.g. when it was replied to.
+//   - "edit": a message was edited. An edit produces two records: one for the edited post with its new message, and
+//     one for the archived copy of the previous message, whose edited_post_id is the id of the edited post.
+//   - "delete": a message, or an attachment when files is set, was deleted.
+//   - "join" and "leave": a user joined or left the channel. These records have no post.
+//
+// Timestamps are in milliseconds since the epoch. Fields which don't apply to a record are omitted.
+package jsonl_export
+
+import (
+	"bufio"
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/enterprise/message_export/shared"
+)
+
+const (
+	MessagesFileName = "messages.jsonl"
+	FilesDir         = "files"
+
+	EventPost   = "post"
+	EventUpdate = "update"
+	EventEdit   = "edit"
+	EventDelete = "delete"
+	EventJoin   = "join"
+	EventLeave  = "leave"
+)
+
+type Record struct {
+	Event     string  `json:"event"`
+	Timestamp int64   `json:"timestamp"`
+	Team      *Team   `json:"team,omitempty"`
+	Channel   Channel `json:"channel"`
+	User      User    `json:"user"`
+	Post      *Post   `json:"post,omitempty"`
+	Files     []File  `json:"files,omitempty"`
+}
+
+type Team struct {
+	Id          string `json:"id"`
+	Name        string `json:"name,omitempty"`
+	DisplayName string `json:"display_name,omitempty"`
+}
+
+type Channel struct {
+	Id          string `json:"id"`
+	Name        string `json:"name"`
+	DisplayName string `json:"display_name"`
+	Type        string `json:"type"`
+}
+
+type User struct {
+	Id       string `json:"id"`
+	Username string `json:"username"`
+	Email    string `json:"email"`
+	Type     string `json:"type"`
+}
+
+type Post struct {
+	Id           string          `json:"id"`
+	RootId       string          `json:"root_id,omitempty"`
+	EditedPostId string          `json:"edited_post_id,omitempty"`
+	PreviewsPost string          `json:"previews_post,omitempty"`
+	Type         string          `json:"type,omitempty"`
+	Message      string          `json:"message"`
+	Props        json.RawMessage `json:"props,omitempty"`
+	CreateAt     int64           `json:"create_at"`
+	UpdateAt     int64           `json:"update_at"`
+	EditAt       int64           `json:"edit_at,omitempty"`
+	DeleteAt     int64           `json:"delete_at,omitempty"`
+}
+
+// File is an attachment. Path and SHA256 are only set when the attachment was copied into the archive.
+type File struct {
+	Id       string `json:"id"`
+	Name     string `json:"name"`
+	MimeType string `json:"mime_type,omitempty"`
+	Size     int64  `json:"size"`
+	Path     string `json:"path,omitempty"`
+	SHA256   string `json:"sha256,omitempty"`
+	DeleteAt int64  `json:"delete_at,omitempty"`
+}
+
+// JsonlExport writes the batch described by p to p.BatchPath on the export backend.
+func JsonlExport(rctx request.CTX, p shared.ExportParams) (shared.RunExportResults, error) {
+	start := time.Now()
+	genericData, err := shared.GetGenericExportData(p)
+	if err != nil {
+		return shared.RunExportResults{}, err
+	}
+	results := genericData.Results
+	results.NumChannels = len(genericData.Exports)
+	results.ProcessingPostsMs = time.Since(start).Milliseconds()
+
+	manifest := shared.NewManifest(p, genericData.Metadata.MessagesCount, len(genericData.Exports))
+	start = time.Now()
+	archive, err := shared.WriteArchive(p.ExportBackend, p.BatchPath, manifest, func(a *shared.Archive) error {
+		writeResult, err := write(rctx, a, p, genericData.Exports)
+		results.WriteExportResult = writeResult
+		return err
+	})
+	if err != nil {
+		return results, err
+	}
+	results.TransferringZipMs = time.Since(start).Milliseconds()
+	results.Archive = archive
+
+	return results, nil
+}
+
+func write(rctx request.CTX, a *shared.Archive, p shared.ExportParams, exports []shared.ChannelExport) (shared.WriteExportResult, error) {
+	var result shared.WriteExportResult
+
+	// The attachments are copied first, as the records hold their checksums.
+	start := time.Now()
+	copied := make(map[string]shared.ManifestEntry)
+	for _, channel := range exports {
+		for _, upload := range channel.UploadStarts {
+			if _, ok := copied[upload.FileInfo.Id]; ok {
+				continue
+			}
+			entry, found, err := a.AddAttachment(rctx, p.FileAttachmentBackend, upload.FileInfo, FilesDir)
+			if err != nil {
+				return result, err
+			}
+			if !found {
+				result.NumWarnings++
+				continue
+			}
+			copied[upload.FileInfo.Id] = entry
+		}
+	}
+	result.TransferringFilesMs = time.Since(start).Milliseconds()
+
+	start = time.Now()
+	records := make([]Record, 0)
+	for _, channel := range exports {
+		records = append(records, channelRecords(channel, copied)...)
+	}
+	sort.SliceStable(records, func(i, j int) bool {
+		return records[i].Timestamp < records[j].Timestamp
+	})
+
+	w, err := a.Create(MessagesFileName)
+	if err != nil {
+		return result, err
+	}
+	buffered := bufio.NewWriter(w)
+	encoder := json.NewEncoder(buffered)
+	encoder.SetEscapeHTML(false)
+	for _, record := range records {
+		if err := encoder.Encode(record); err != nil {
+			return result, errors.Wrap(err, "unable to write record")
+		}
+	}
+	if err := buffered.Flush(); err != nil {
+		return result, errors.Wrap(err, "unable to write records")
+	}
+	result.ProcessingXmlMs = time.Since(start).Milliseconds()
+
+	return result, nil
+}
+
+func channelRecords(channel shared.ChannelExport, copied map[string]shared.ManifestEntry) []Record {
+	c := Channel{
+		Id:          channel.ChannelId,
+		Name:        channel.ChannelName,
+		DisplayName: channel.DisplayName,
+		Type:        shared.ChannelTypeDisplayName(channel.ChannelType),
+	}
+	var team *Team
+	if channel.TeamId != "" {
+		team = &Team{Id: channel.TeamId, Name: channel.TeamName, DisplayName: channel.TeamDisplayName}
+	}
+
+	records := make([]Record, 0, len(channel.Posts)+len(channel.JoinEvents)+len(channel.LeaveEvents))
+	for _, join := range channel.JoinEvents {
+		records = append(records, Record{
+			Event:     EventJoin,
+			Timestamp: join.JoinTime,
+			Team:      team,
+			Channel:   c,
+			User:      User{Id: join.UserId, Username: join.Username, Email: join.UserEmail, Type: string(join.UserType)},
+		})
+	}
+	for _, leave := range channel.LeaveEvents {
+		if leave.ClosedOut {
+			continue
+		}
+		records = append(records, Record{
+			Event:     EventLeave,
+			Timestamp: leave.LeaveTime,
+			Team:      team,
+			Channel:   c,
+			User:      User{Id: leave.UserId, Username: leave.Username, Email: leave.UserEmail, Type: string(leave.UserType)},
+		})
+	}
+
+	for _, post := range channel.Posts {
+		record := Record{
+			Team:    team,
+			Channel: c,
+			User: User{
+				Id:       model.SafeDereference(post.UserId),
+				Username: model.SafeDereference(post.Username),
+				Email:    model.SafeDereference(post.UserEmail),
+				Type:     string(post.UserType),
+			},
+			Post: &Post{
+				Id:           model.SafeDereference(post.PostId),
+				RootId:       model.SafeDereference(post.PostRootId),
+				EditedPostId: post.EditedNewMsgId,
+				PreviewsPost: post.PreviewsPost,
+				Type:         model.SafeDereference(post.PostType),
+				Message:      model.SafeDereference(post.PostMessage),
+				CreateAt:     model.SafeDereference(post.PostCreateAt),
+				UpdateAt:     model.SafeDereference(post.PostUpdateAt),
+				EditAt:       model.SafeDereference(post.PostEditAt),
+				DeleteAt:     model.SafeDereference(post.PostDeleteAt),
+			},
+		}
+		if props := model.SafeDereference(post.PostProps); props != "" && json.Valid([]byte(props)) {
+			record.Post.Props = json.RawMessage(props)
+		}
+
+		switch post.UpdatedType {
+		case shared.EditedOriginalMsg, shared.EditedNewMsg:
+			record.Event = EventEdit
+			record.Timestamp = post.UpdateAt
+		case shared.UpdatedNoMsgChange:
+			record.Event = EventUpdate
+			record.Timestamp = post.UpdateAt
+		case shared.Deleted:
+			record.Event = EventDelete
+			record.Timestamp = post.UpdateAt
+		default:
+			record.Event = EventPost
+			record.Timestamp = record.Post.CreateAt
+		}
+		for _, upload := range post.AttachmentCreates {
+			record.Files = append(record.Files, toFile(upload.FileInfo, copied))
+		}
+		records = append(records, record)
+
+		// Deleted attachments get their own records.
+		for _, deleted := range post.AttachmentDeletes {
+			deleteRecord := record
+			deleteRecord.Event = EventDelete
+			deleteRecord.Timestamp = deleted.UpdateAt
+			deleteRecord.Files = []File{toFile(deleted.FileInfo, copied)}
+			records = append(records, deleteRecord)
+		}
+	}
+
+	return records
+}
+
+func toFile(fileInfo *model.FileInfo, copied map[string]shared.ManifestEntry) File {
+	file := File{
+		Id:       fileInfo.Id,
+		Name:     fileInfo.Name,
+		MimeType: fileInfo.MimeType,
+		Size:     fileInfo.Size,
+		DeleteAt: fileInfo.DeleteAt,
+	}
+	if entry, ok := copied[fileInfo.Id]; ok {
+		file.Path = entry.Path
+		file.SHA256 = entry.SHA256
+	}
+	return file
+}
@@ -0,0 +1,216 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.enterprise for license information.
+
+package jsonl_export
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/enterprise/message_export/shared"
+	"github.com/mattermost/mattermost/server/v8/platform/shared/filestore"
+)
+
+type fileInfoStore struct {
+	files map[string][]*model.FileInfo
+}
+
+func (s *fileInfoStore) GetForPost(postID string, _, _, _ bool) ([]*model.FileInfo, error) {
+	return s.files[postID], nil
+}
+
+// exportStore only implements the FileInfo store, which is the only one used by the export.
+type exportStore struct {
+	shared.MessageExportStore
+	fileInfoStore *fileInfoStore
+}
+
+func (s *exportStore) FileInfo() shared.MEFileInfoStore {
+	return s.fileInfoStore
+}
+
+func readZip(t *testing.T, backend filestore.FileBackend, path string) map[string][]byte {
+	t.Helper()
+	b, err := backend.ReadFile(path)
+	require.NoError(t, err)
+	r, err := zip.NewReader(bytes.NewReader(b), int64(len(b)))
+	require.NoError(t, err)
+
+	files := make(map[string][]byte)
+	for _, f := range r.File {
+		rc, err := f.Open()
+		require.NoError(t, err)
+		content, err := io.ReadAll(rc)
+		require.NoError(t, err)
+		rc.Close()
+		files[f.Name] = content
+	}
+	return files
+}
+
+func sha(b []byte) string {
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])
+}
+
+func TestJsonlExport(t *testing.T) {
+	backend, err := filestore.NewFileBackend(filestore.FileBackendSettings{DriverName: model.ImageDriverLocal, Directory: t.TempDir()})
+	require.NoError(t, err)
+
+	channelId := model.NewId()
+	teamId := model.NewId()
+	userId := model.NewId()
+	postWithFile := model.NewId()
+	editedPost := model.NewId()
+	archivedPost := model.NewId()
+	deletedPost := model.NewId()
+
+	fileInfo := &model.FileInfo{Id: model.NewId(), PostId: postWithFile, Name: "report.txt", Path: "data/report.txt", Size: 5, MimeType: "text/plain"}
+	_, err = backend.WriteFile(bytes.NewReader([]byte("hello")), fileInfo.Path)
+	require.NoError(t, err)
+	missingFileInfo := &model.FileInfo{Id: model.NewId(), PostId: postWithFile, Name: "missing.txt", Path: "data/missing.txt", Size: 5}
+
+	exportPost := func(id string, createAt, updateAt, deleteAt, editAt int64, message, originalId, props string, fileIds model.StringArray) *model.MessageExport {
+		return &model.MessageExport{
+			TeamId:             model.NewPointer(teamId),
+			TeamName:           model.NewPointer("team-name"),
+			TeamDisplayName:    model.NewPointer("Team"),
+			ChannelId:          model.NewPointer(channelId),
+			ChannelName:        model.NewPointer("channel-name"),
+			ChannelDisplayName: model.NewPointer("Channel"),
+			ChannelType:        model.NewPointer(model.ChannelTypeOpen),
+			UserId:             model.NewPointer(userId),
+			UserEmail:          model.NewPointer("user@example.com"),
+			Username:           model.NewPointer("user"),
+			PostId:             model.NewPointer(id),
+			PostCreateAt:       model.NewPointer(createAt),
+			PostUpdateAt:       model.NewPointer(updateAt),
+			PostDeleteAt:       model.NewPointer(deleteAt),
+			PostEditAt:         model.NewPointer(editAt),
+			PostMessage:        model.NewPointer(message),
+			PostType:           model.NewPointer(""),
+			PostRootId:         model.NewPointer(""),
+			PostProps:          model.NewPointer(props),
+			PostOriginalId:     model.NewPointer(originalId),
+			PostFileIds:        fileIds,
+		}
+	}
+
+	params := shared.ExportParams{
+		ExportType: model.ComplianceExportTypeJsonl,
+		ChannelMetadata: map[string]*shared.MetadataChannel{
+			channelId: {
+				TeamId:             model.NewPointer(teamId),
+				ChannelId:          channelId,
+				ChannelName:        "channel-name",
+				ChannelDisplayName: "Channel",
+				ChannelType:        model.ChannelTypeOpen,
+				StartTime:          100,
+				EndTime:            1000,
+			},
+		},
+		Posts: []*model.MessageExport{
+			exportPost(postWithFile, 200, 200, 0, 0, "with a file", "", "{}", model.StringArray{fileInfo.Id, missingFileInfo.Id}),
+			exportPost(archivedPost, 300, 400, 400, 0, "before the edit", editedPost, "{}", nil),
+			exportPost(editedPost, 300, 400, 0, 400, "after the edit", "", "{}", nil),
+			exportPost(deletedPost, 500, 600, 600, 0, "deleted", "", `{"deleteBy":"`+userId+`"}`, nil),
+		},
+		ChannelMemberHistories: map[string][]*model.ChannelMemberHistoryResult{
+			channelId: {
+				{ChannelId: channelId, UserId: userId, UserEmail: "user@example.com", Username: "user", JoinTime: 150, LeaveTime: model.NewPointer(int64(700))},
+			},
+		},
+		JobStartTime:          100,
+		BatchPath:             "export/batch001-100-700.zip",
+		BatchNumber:           1,
+		BatchStartTime:        100,
+		BatchEndTime:          700,
+		Db:                    &exportStore{fileInfoStore: &fileInfoStore{files: map[string][]*model.FileInfo{postWithFile: {fileInfo, missingFileInfo}}}},
+		FileAttachmentBackend: backend,
+		ExportBackend:         backend,
+	}
+
+	results, err := JsonlExport(request.TestContext(t), params)
+	require.NoError(t, err)
+	assert.Equal(t, 1, results.NumChannels)
+	assert.Equal(t, 1, results.NumWarnings)
+
+	zipBytes, err := backend.ReadFile(params.BatchPath)
+	require.NoError(t, err)
+	assert.Equal(t, sha(zipBytes), results.Archive.SHA256)
+	assert.Equal(t, int64(len(zipBytes)), results.Archive.Size)
+	assert.Equal(t, "batch001-100-700.zip", results.Archive.Path)
+
+	files := readZip(t, backend, params.BatchPath)
+	filePath := FilesDir + "/" + fileInfo.Id + "/report.txt"
+	require.Len(t, files, 3)
+	require.Contains(t, files, MessagesFileName)
+	require.Contains(t, files, filePath)
+	require.Contains(t, files, shared.ManifestFileName)
+
+	t.Run("manifest", func(t *testing.T) {
+		var manifest shared.Manifest
+		require.NoError(t, json.Unmarshal(files[shared.ManifestFileName], &manifest))
+		assert.Equal(t, shared.ManifestVersion, manifest.Version)
+		assert.Equal(t, model.ComplianceExportTypeJsonl, manifest.ExportType)
+		assert.Equal(t, 1, manifest.BatchNumber)
+		assert.Equal(t, int64(700), manifest.BatchEndTime)
+		require.Len(t, manifest.Files, 2)
+		for _, entry := range manifest.Files {
+			assert.Equal(t, sha(files[entry.Path]), entry.SHA256, entry.Path)
+			assert.Equal(t, int64(len(files[entry.Path])), entry.Size, entry.Path)
+		}
+	})
+
+	t.Run("records", func(t *testing.T) {
+		var records []Record
+		scanner := bufio.NewScanner(bytes.NewReader(files[MessagesFileName]))
+		for scanner.Scan() {
+			var record Record
+			require.NoError(t, json.Unmarshal(scanner.Bytes(), &record))
+			records = append(records, record)
+		}
+		require.NoError(t, scanner.Err())
+
+		var events []string
+		for _, record := range records {
+			events = append(events, record.Event)
+			assert.Equal(t, channelId, record.Channel.Id)
+			assert.Equal(t, "public", record.Channel.Type)
+			assert.Equal(t, userId, record.User.Id)
+		}
+		assert.Equal(t, []string{EventJoin, EventPost, EventEdit, EventEdit, EventPost, EventDelete, EventLeave}, events)
+
+		posted := records[1]
+		require.NotNil(t, posted.Team)
+		assert.Equal(t, "team-name", posted.Team.Name)
+		assert.Equal(t, "with a file", posted.Post.Message)
+		require.Len(t, posted.Files, 2)
+		assert.Equal(t, filePath, posted.Files[0].Path)
+		assert.Equal(t, sha([]byte("hello")), posted.Files[0].SHA256)
+		assert.Empty(t, posted.Files[1].Path)
+
+		edits := map[string]Record{records[2].Post.Id: records[2], records[3].Post.Id: records[3]}
+		assert.Equal(t, "after the edit", edits[editedPost].Post.Message)
+		assert.Equal(t, "before the edit", edits[archivedPost].Post.Message)
+		assert.Equal(t, editedPost, edits[archivedPost].Post.EditedPostId)
+
+		// The deleted post is recorded as posted, then deleted.
+		assert.Equal(t, deletedPost, records[4].Post.Id)
+		assert.Equal(t, int64(500), records[4].Timestamp)
+		assert.Equal(t, deletedPost, records[5].Post.Id)
+		assert.Equal(t, int64(600), records[5].Timestamp)
+		assert.JSONEq(t, `{"deleteBy":"`+userId+`"}`, string(records[5].Post.Props))
+	})
+}
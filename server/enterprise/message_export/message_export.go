@@ -0,0 +1,91 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.enterprise for license information.
+
+package message_export
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/app"
+	"github.com/mattermost/mattermost/server/v8/einterfaces"
+	ejobs "github.com/mattermost/mattermost/server/v8/einterfaces/jobs"
+	"github.com/mattermost/mattermost/server/v8/enterprise/message_export/shared"
+)
+
+// jobStatusPollingInterval is how often StartSynchronizeJob checks whether the job finished.
+var jobStatusPollingInterval = time.Second
+
+func init() {
+	app.RegisterMessageExportInterface(func(a *app.App) einterfaces.MessageExportInterface {
+		return &MessageExportInterfaceImpl{Server: a.Srv()}
+	})
+	app.RegisterJobsMessageExportJobInterface(func(s *app.Server) ejobs.MessageExportJobInterface {
+		return &MessageExportJobInterfaceImpl{Server: s}
+	})
+}
+
+type MessageExportInterfaceImpl struct {
+	Server *app.Server
+}
+
+type MessageExportJobInterfaceImpl struct {
+	Server *app.Server
+}
+
+func (m *MessageExportJobInterfaceImpl) MakeWorker() model.Worker {
+	return MakeWorker(m.Server.Jobs, m.Server.Store(), m.Server)
+}
+
+func (m *MessageExportJobInterfaceImpl) MakeScheduler() ejobs.Scheduler {
+	return MakeScheduler(m.Server.Jobs, m.Server.License)
+}
+
+// StartSynchronizeJob creates a message export job and waits for it to finish, or for the context to be done. The
+// job exports the messages from exportFromTimestamp, if it's positive, and otherwise picks up where the last
+// successful job stopped.
+func (m *MessageExportInterfaceImpl) StartSynchronizeJob(rctx request.CTX, exportFromTimestamp int64) (*model.Job, *model.AppError) {
+	if license := m.Server.License(); license == nil || !*license.Features.MessageExport {
+		return nil, model.NewAppError("StartSynchronizeJob", "ent.message_export.license.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	jobData := map[string]string{
+		shared.JobDataInitiatedBy: "cli",
+	}
+	if exportFromTimestamp > 0 {
+		jobData[shared.JobDataBatchStartTime] = strconv.FormatInt(exportFromTimestamp, 10)
+		jobData[shared.JobDataBatchStartId] = ""
+	}
+
+	job, appErr := m.Server.Jobs.CreateJob(rctx, model.JobTypeMessageExport, jobData)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	ticker := time.NewTicker(jobStatusPollingInterval)
+	defer ticker.Stop()
+	for isRunning(job) {
+		select {
+		case <-rctx.Context().Done():
+			return job, model.NewAppError("StartSynchronizeJob", "ent.message_export.synchronize_job.timeout.app_error", nil, "", http.StatusRequestTimeout).Wrap(rctx.Context().Err())
+		case <-ticker.C:
+		}
+
+		if job, appErr = m.Server.Jobs.GetJob(rctx, job.Id); appErr != nil {
+			return nil, appErr
+		}
+	}
+
+	return job, nil
+}
+
+func isRunning(job *model.Job) bool {
+	switch job.Status {
+	case model.JobStatusPending, model.JobStatusInProgress, model.JobStatusCancelRequested:
+		return true
+	}
+	return false
+}
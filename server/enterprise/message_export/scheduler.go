@@ -0,0 +1,27 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.enterprise for license information.
+
+package message_export
+
+import (
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/channels/jobs"
+)
+
+// MakeScheduler creates a scheduler running the job daily at the configured time, when message export is enabled.
+func MakeScheduler(jobServer *jobs.JobServer, license func() *model.License) *jobs.DailyScheduler {
+	startTime := func(cfg *model.Config) *time.Time {
+		parsedTime, err := time.Parse("15:04", *cfg.MessageExportSettings.DailyRunTime)
+		if err == nil {
+			return &parsedTime
+		}
+		return nil
+	}
+	isEnabled := func(cfg *model.Config) bool {
+		l := license()
+		return l != nil && *l.Features.MessageExport && *cfg.MessageExportSettings.EnableExport
+	}
+	return jobs.NewDailyScheduler(jobServer, model.JobTypeMessageExport, startTime, isEnabled)
+}
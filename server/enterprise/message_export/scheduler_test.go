@@ -0,0 +1,34 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.enterprise for license information.
+
+package message_export
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func TestSchedulerEnabled(t *testing.T) {
+	if testing.Short() {
+		t.SkipNow()
+	}
+	th := setup(t)
+	license := model.NewTestLicense("message_export")
+	scheduler := MakeScheduler(th.jobServer, func() *model.License { return license })
+
+	cfg := th.app.config.Clone()
+	cfg.MessageExportSettings.EnableExport = model.NewPointer(false)
+	assert.False(t, scheduler.Enabled(cfg))
+
+	cfg.MessageExportSettings.EnableExport = model.NewPointer(true)
+	assert.True(t, scheduler.Enabled(cfg))
+
+	license = nil
+	assert.False(t, scheduler.Enabled(cfg))
+
+	license = model.NewTestLicenseWithFalseDefaults("message_export")
+	assert.False(t, scheduler.Enabled(cfg))
+}
@@ -0,0 +1,214 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.enterprise for license information.
+
+package shared
+
+import (
+	"archive/zip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"hash"
+	"io"
+	"path"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/platform/shared/filestore"
+)
+
+const (
+	// ManifestFileName is the name of the manifest written at the root of each batch archive, and of the
+	// manifest listing the batches in the export directory.
+	ManifestFileName = "manifest.json"
+
+	ManifestVersion = 1
+)
+
+// ManifestEntry describes a file of an export, with its SHA-256 checksum in hex.
+type ManifestEntry struct {
+	Path   string `json:"path"`
+	Size   int64  `json:"size"`
+	SHA256 string `json:"sha256"`
+}
+
+// Manifest lists the files of a batch archive, so that the consumer of the export can check that nothing is missing
+// or has been altered.
+type Manifest struct {
+	Version        int             `json:"version"`
+	ExportType     string          `json:"export_type"`
+	BatchNumber    int             `json:"batch_number"`
+	BatchStartTime int64           `json:"batch_start_time"`
+	BatchEndTime   int64           `json:"batch_end_time"`
+	JobStartTime   int64           `json:"job_start_time"`
+	CreateAt       int64           `json:"create_at"`
+	MessagesCount  int             `json:"messages_count"`
+	ChannelsCount  int             `json:"channels_count"`
+	Files          []ManifestEntry `json:"files"`
+}
+
+// BatchManifestEntry describes a batch archive in the manifest of the export directory.
+type BatchManifestEntry struct {
+	ManifestEntry
+	BatchNumber    int   `json:"batch_number"`
+	BatchStartTime int64 `json:"batch_start_time"`
+	BatchEndTime   int64 `json:"batch_end_time"`
+	MessagesCount  int   `json:"messages_count"`
+}
+
+// ExportManifest lists the batch archives written by a job in the export directory.
+type ExportManifest struct {
+	Version      int                  `json:"version"`
+	ExportType   string               `json:"export_type"`
+	JobId        string               `json:"job_id"`
+	JobStartTime int64                `json:"job_start_time"`
+	JobEndTime   int64                `json:"job_end_time"`
+	Batches      []BatchManifestEntry `json:"batches"`
+}
+
+// NewManifest returns the manifest of the batch described by p.
+func NewManifest(p ExportParams, messagesCount, channelsCount int) Manifest {
+	return Manifest{
+		Version:        ManifestVersion,
+		ExportType:     p.ExportType,
+		BatchNumber:    p.BatchNumber,
+		BatchStartTime: p.BatchStartTime,
+		BatchEndTime:   p.BatchEndTime,
+		JobStartTime:   p.JobStartTime,
+		CreateAt:       model.GetMillis(),
+		MessagesCount:  messagesCount,
+		ChannelsCount:  channelsCount,
+		Files:          []ManifestEntry{},
+	}
+}
+
+// Archive writes a zip file, recording the size and checksum of every file added to it in the manifest written
+// when the archive is closed.
+type Archive struct {
+	zw       *zip.Writer
+	manifest Manifest
+	current  *archiveFile
+}
+
+type archiveFile struct {
+	w     io.Writer
+	hash  hash.Hash
+	entry ManifestEntry
+}
+
+func (f *archiveFile) Write(p []byte) (int, error) {
+	n, err := f.w.Write(p)
+	f.hash.Write(p[:n])
+	f.entry.Size += int64(n)
+	return n, err
+}
+
+func NewArchive(w io.Writer, manifest Manifest) *Archive {
+	return &Archive{
+		zw:       zip.NewWriter(w),
+		manifest: manifest,
+	}
+}
+
+// Create adds a file to the archive. The returned writer is valid until the next call to Create, AddFile or Close.
+func (a *Archive) Create(name string) (io.Writer, error) {
+	a.closeCurrent()
+
+	w, err := a.zw.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate})
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to create %s in the archive", name)
+	}
+	a.current = &archiveFile{w: w, hash: sha256.New(), entry: ManifestEntry{Path: name}}
+	return a.current, nil
+}
+
+// AddFile adds a file with the contents of r to the archive, returning its manifest entry.
+func (a *Archive) AddFile(name string, r io.Reader) (ManifestEntry, error) {
+	w, err := a.Create(name)
+	if err != nil {
+		return ManifestEntry{}, err
+	}
+	if _, err := io.Copy(w, r); err != nil {
+		return ManifestEntry{}, errors.Wrapf(err, "unable to write %s to the archive", name)
+	}
+	return a.closeCurrent(), nil
+}
+
+// AddAttachment copies a file attachment from the backend into the archive under dir. A missing attachment is
+// logged and reported through the returned bool, as it shouldn't fail the export.
+func (a *Archive) AddAttachment(rctx request.CTX, backend filestore.FileBackend, fileInfo *model.FileInfo, dir string) (ManifestEntry, bool, error) {
+	r, err := backend.Reader(fileInfo.Path)
+	if err != nil {
+		rctx.Logger().Warn(MissingFileMessageDuringBackendRead, mlog.String("post_id", fileInfo.PostId), mlog.String("filename", fileInfo.Path), mlog.Err(err))
+		return ManifestEntry{}, false, nil
+	}
+	defer r.Close()
+
+	entry, err := a.AddFile(path.Join(dir, fileInfo.Id, fileInfo.Name), r)
+	if err != nil {
+		return ManifestEntry{}, false, err
+	}
+	return entry, true, nil
+}
+
+func (a *Archive) closeCurrent() ManifestEntry {
+	if a.current == nil {
+		return ManifestEntry{}
+	}
+	entry := a.current.entry
+	entry.SHA256 = hex.EncodeToString(a.current.hash.Sum(nil))
+	a.manifest.Files = append(a.manifest.Files, entry)
+	a.current = nil
+	return entry
+}
+
+// Close writes the manifest and finishes the zip file. It doesn't close the underlying writer.
+func (a *Archive) Close() error {
+	a.closeCurrent()
+
+	w, err := a.zw.Create(ManifestFileName)
+	if err != nil {
+		return errors.Wrap(err, "unable to create the manifest")
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(a.manifest); err != nil {
+		return errors.Wrap(err, "unable to write the manifest")
+	}
+	return a.zw.Close()
+}
+
+// WriteArchive streams the archive filled by write to batchPath on the export backend, and returns the size and
+// checksum of the zip file.
+func WriteArchive(backend filestore.FileBackend, batchPath string, manifest Manifest, write func(a *Archive) error) (ManifestEntry, error) {
+	pr, pw := io.Pipe()
+	zipHash := sha256.New()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		a := NewArchive(io.MultiWriter(pw, zipHash), manifest)
+		err := write(a)
+		if err == nil {
+			err = a.Close()
+		}
+		pw.CloseWithError(err)
+	}()
+
+	size, err := backend.WriteFile(pr, batchPath)
+	// Unblock the writer if the backend stopped reading early.
+	pr.CloseWithError(err)
+	<-done
+	if err != nil {
+		return ManifestEntry{}, errors.Wrapf(err, "unable to write the archive to %s", batchPath)
+	}
+
+	return ManifestEntry{
+		Path:   path.Base(batchPath),
+		Size:   size,
+		SHA256: hex.EncodeToString(zipHash.Sum(nil)),
+	}, nil
+}
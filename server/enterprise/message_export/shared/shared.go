@@ -242,6 +242,7 @@ type ExportParams struct {
 	ChannelMemberHistories map[string][]*model.ChannelMemberHistoryResult
 	JobStartTime           int64
 	BatchPath              string
+	BatchNumber            int
 	BatchStartTime         int64
 	BatchEndTime           int64
 	Config                 *model.Config
@@ -256,6 +257,9 @@ type WriteExportResult struct {
 	ProcessingXmlMs     int64
 	TransferringZipMs   int64
 	NumWarnings         int
+
+	// Archive is the batch archive with its checksum, for the export types writing a manifest.
+	Archive ManifestEntry
 }
 
 type RunExportResults struct {
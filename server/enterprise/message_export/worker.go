@@ -0,0 +1,374 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.enterprise for license information.
+
+package message_export
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path"
+	"strconv"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/jobs"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+	"github.com/mattermost/mattermost/server/v8/enterprise/message_export/eml_export"
+	"github.com/mattermost/mattermost/server/v8/enterprise/message_export/jsonl_export"
+	"github.com/mattermost/mattermost/server/v8/enterprise/message_export/shared"
+	"github.com/mattermost/mattermost/server/v8/platform/shared/filestore"
+)
+
+const (
+	timeBetweenBatches = 100 * time.Millisecond
+
+	// batchEntriesDir is the directory of the export holding the manifest entry of each batch written so far, until
+	// the manifest of the export is written. Only the cursor and the number of batches are kept in the job data.
+	batchEntriesDir = ".batches"
+)
+
+// Exporter writes a batch of messages to the export backend.
+type Exporter func(rctx request.CTX, p shared.ExportParams) (shared.RunExportResults, error)
+
+type exporter struct {
+	export Exporter
+	// manifest tells whether the exporter returns the checksummed archive of each batch, which the worker lists in
+	// the manifest of the export.
+	manifest bool
+}
+
+// exporters are the export types supported by the worker.
+var exporters = map[string]exporter{
+	model.ComplianceExportTypeJsonl: {export: jsonl_export.JsonlExport, manifest: true},
+	model.ComplianceExportTypeEml:   {export: eml_export.EmlExport, manifest: true},
+}
+
+// RegisterExporter makes the worker delegate an export type to its exporter, e.g. the actiance, csv and
+// globalrelay exporters of the enterprise builds. It must be called from an init function.
+func RegisterExporter(exportType string, export Exporter) {
+	exporters[exportType] = exporter{export: export}
+}
+
+type AppIface interface {
+	Config() *model.Config
+	License() *model.License
+}
+
+// MakeWorker creates a worker exporting, in batches, the messages and channel activity since the end of the last
+// successful export. Each batch is written to the export directory as a zip file with a manifest of checksums, and
+// the job writes a manifest of the batches when it's done.
+func MakeWorker(jobServer *jobs.JobServer, store store.Store, app AppIface) *jobs.BatchWorker {
+	w := &worker{
+		jobServer: jobServer,
+		store:     shared.NewMessageExportStore(store),
+		app:       app,
+	}
+	return jobs.MakeBatchWorker(jobServer, store, timeBetweenBatches, w.doBatch)
+}
+
+type worker struct {
+	jobServer *jobs.JobServer
+	store     shared.MessageExportStore
+	app       AppIface
+
+	// The channel metadata and member histories of the export period are computed once per job, and kept between
+	// batches. A worker only runs one job at a time.
+	periodJobId string
+	period      shared.JobData
+}
+
+func (w *worker) doBatch(rctx request.CTX, job *model.Job) bool {
+	logger := rctx.Logger()
+
+	if license := w.app.License(); license == nil || !*license.Features.MessageExport {
+		w.setJobError(logger, job, model.NewAppError("doBatch", "ent.message_export.license.app_error", nil, "", http.StatusNotImplemented))
+		return true
+	}
+
+	if job.Data == nil {
+		job.Data = make(model.StringMap)
+	}
+	// The batch size is only set when the job starts.
+	if job.Data[shared.JobDataBatchSize] == "" {
+		if appErr := w.start(job); appErr != nil {
+			w.setJobError(logger, job, appErr)
+			return true
+		}
+	}
+
+	data, err := shared.StringMapToJobDataWithZeroValues(job.Data)
+	if err != nil {
+		w.setJobError(logger, job, model.NewAppError("doBatch", "ent.message_export.job_data_conversion.app_error", nil, "", http.StatusInternalServerError).Wrap(err))
+		return true
+	}
+
+	exporter, ok := exporters[data.ExportType]
+	if !ok {
+		w.setJobError(logger, job, model.NewAppError("doBatch", "ent.message_export.export_type.app_error", map[string]any{"ExportType": data.ExportType}, "", http.StatusBadRequest))
+		return true
+	}
+
+	if w.periodJobId != job.Id {
+		data.ExportPeriodStartTime = data.JobStartTime
+		reportProgress := func(message string) {
+			job.Data["progress_message"] = message
+			if appErr := w.jobServer.UpdateInProgressJobData(job); appErr != nil {
+				logger.Warn("Failed to update the job data", mlog.Err(appErr))
+			}
+		}
+		if data, err = shared.GetInitialExportPeriodData(rctx, w.store, data, reportProgress); err != nil {
+			w.setJobError(logger, job, model.NewAppError("doBatch", "ent.message_export.calculate_channel_exports.app_error", nil, "", http.StatusInternalServerError).Wrap(err))
+			return true
+		}
+		w.periodJobId = job.Id
+		w.period = data
+	}
+	cursor := model.MessageExportCursor{
+		LastPostUpdateAt: data.BatchStartTime,
+		LastPostId:       data.BatchStartId,
+		UntilUpdateAt:    data.JobEndTime,
+	}
+
+	posts, cursor, err := w.store.Compliance().MessageExport(rctx, cursor, data.BatchSize)
+	if err != nil {
+		w.setJobError(logger, job, model.NewAppError("doBatch", "ent.message_export.run_export.app_error", nil, "", http.StatusInternalServerError).Wrap(err))
+		return true
+	}
+	if len(posts) == 0 {
+		return w.finish(rctx, job, data)
+	}
+
+	cfg := w.app.Config()
+	exportBackend, err := shared.GetExportBackend(rctx, cfg)
+	if err != nil {
+		w.setJobError(logger, job, model.NewAppError("doBatch", "ent.message_export.run_export.app_error", nil, "", http.StatusInternalServerError).Wrap(err))
+		return true
+	}
+	fileAttachmentBackend, err := shared.GetFileAttachmentBackend(rctx, cfg)
+	if err != nil {
+		w.setJobError(logger, job, model.NewAppError("doBatch", "ent.message_export.run_export.app_error", nil, "", http.StatusInternalServerError).Wrap(err))
+		return true
+	}
+
+	batchEndTime := cursor.LastPostUpdateAt
+	params := shared.ExportParams{
+		ExportType:             data.ExportType,
+		ChannelMetadata:        w.period.ChannelMetadata,
+		Posts:                  posts,
+		ChannelMemberHistories: w.period.ChannelMemberHistories,
+		JobStartTime:           data.JobStartTime,
+		BatchPath:              shared.GetBatchPath(data.ExportDir, data.BatchStartTime, batchEndTime, data.BatchNumber),
+		BatchNumber:            data.BatchNumber,
+		BatchStartTime:         data.BatchStartTime,
+		BatchEndTime:           batchEndTime,
+		Config:                 cfg,
+		Db:                     w.store,
+		FileAttachmentBackend:  fileAttachmentBackend,
+		ExportBackend:          exportBackend,
+	}
+	resetCounts(params.ChannelMetadata)
+	results, err := exporter.export(rctx, params)
+	if err != nil {
+		w.setJobError(logger, job, model.NewAppError("doBatch", "ent.message_export.run_export.app_error", nil, "", http.StatusInternalServerError).Wrap(err))
+		return true
+	}
+	logger.Info("Exported message export batch",
+		mlog.Int("batch_number", data.BatchNumber),
+		mlog.Int("posts", len(posts)),
+		mlog.Int("channels", results.NumChannels),
+		mlog.Int("warnings", results.NumWarnings),
+		mlog.String("batch_path", params.BatchPath),
+	)
+
+	if exporter.manifest {
+		entry := shared.BatchManifestEntry{
+			ManifestEntry:  results.Archive,
+			BatchNumber:    data.BatchNumber,
+			BatchStartTime: data.BatchStartTime,
+			BatchEndTime:   batchEndTime,
+			MessagesCount:  len(posts),
+		}
+		if err := writeJSON(exportBackend, batchEntryPath(data.ExportDir, data.BatchNumber), entry); err != nil {
+			w.setJobError(logger, job, model.NewAppError("doBatch", "ent.message_export.run_export.app_error", nil, "", http.StatusInternalServerError).Wrap(err))
+			return true
+		}
+	}
+
+	data.BatchStartTime = batchEndTime
+	data.BatchStartId = cursor.LastPostId
+	data.BatchNumber++
+	data.MessagesExported += len(posts)
+	data.WarningCount += results.NumWarnings
+	setJobData(job, data)
+
+	if len(posts) < data.BatchSize {
+		return w.finish(rctx, job, data)
+	}
+
+	progress := int64(99)
+	if data.TotalPostsExpected > 0 {
+		progress = min(progress, int64(data.MessagesExported*100/data.TotalPostsExpected))
+	}
+	if appErr := w.jobServer.SetJobProgress(job, progress); appErr != nil {
+		logger.Warn("Failed to update the job progress", mlog.Err(appErr))
+	}
+	return false
+}
+
+// start records the export period and settings of the job, so they don't change while the job runs. Unless given
+// a start time, the job picks up where the last successful job stopped.
+func (w *worker) start(job *model.Job) *model.AppError {
+	cfg := w.app.Config()
+	data, err := shared.StringMapToJobDataWithZeroValues(job.Data)
+	if err != nil {
+		return model.NewAppError("start", "ent.message_export.job_data_conversion.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	if _, ok := job.Data[shared.JobDataBatchStartTime]; !ok {
+		previousJob, appErr := w.jobServer.GetLastSuccessfulJobByType(model.JobTypeMessageExport)
+		if appErr != nil {
+			return appErr
+		}
+		if previousJob == nil {
+			data.BatchStartTime = *cfg.MessageExportSettings.ExportFromTimestamp
+			data.BatchStartId = ""
+		} else {
+			previousData, err := shared.StringMapToJobDataWithZeroValues(previousJob.Data)
+			if err != nil {
+				return model.NewAppError("start", "ent.message_export.job_data_conversion.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+			}
+			data.BatchStartTime = previousData.BatchStartTime
+			data.BatchStartId = previousData.BatchStartId
+		}
+	}
+	if _, ok := job.Data[shared.JobDataJobStartTime]; !ok {
+		data.JobStartTime = data.BatchStartTime
+		data.JobStartId = data.BatchStartId
+	}
+	if data.JobEndTime == 0 {
+		data.JobEndTime = model.GetMillis()
+	}
+	if data.ExportType == "" {
+		data.ExportType = *cfg.MessageExportSettings.ExportFormat
+	}
+	if data.ExportDir == "" {
+		data.ExportDir = path.Join(model.ComplianceExportPath, time.Now().Format(model.ComplianceExportDirectoryFormat))
+	}
+	data.BatchSize = *cfg.MessageExportSettings.BatchSize
+	data.ChannelBatchSize = *cfg.MessageExportSettings.ChannelBatchSize
+	data.ChannelHistoryBatchSize = *cfg.MessageExportSettings.ChannelHistoryBatchSize
+
+	setJobData(job, data)
+	return nil
+}
+
+// finish writes the manifest of the batches to the export directory, and marks the job as done.
+func (w *worker) finish(rctx request.CTX, job *model.Job, data shared.JobData) bool {
+	logger := rctx.Logger()
+	w.periodJobId = ""
+	w.period = shared.JobData{}
+
+	if exporters[data.ExportType].manifest && data.BatchNumber > 0 {
+		if err := w.writeExportManifest(rctx, job, data); err != nil {
+			w.setJobError(logger, job, model.NewAppError("finish", "ent.message_export.run_export.app_error", nil, "", http.StatusInternalServerError).Wrap(err))
+			return true
+		}
+	}
+
+	if appErr := w.jobServer.SetJobProgress(job, 100); appErr != nil {
+		logger.Warn("Failed to update the job progress", mlog.Err(appErr))
+	}
+
+	if data.WarningCount > 0 {
+		if appErr := w.jobServer.SetJobWarning(job); appErr != nil {
+			logger.Error("Failed to set the job warning", mlog.Err(appErr))
+			w.setJobError(logger, job, appErr)
+		}
+		return true
+	}
+	if appErr := w.jobServer.SetJobSuccess(job); appErr != nil {
+		logger.Error("Failed to set the job as successful", mlog.Err(appErr))
+		w.setJobError(logger, job, appErr)
+	}
+	return true
+}
+
+// writeExportManifest lists the batches of the job, recorded as they were written, in the manifest of the export
+// directory.
+func (w *worker) writeExportManifest(rctx request.CTX, job *model.Job, data shared.JobData) error {
+	backend, err := shared.GetExportBackend(rctx, w.app.Config())
+	if err != nil {
+		return err
+	}
+
+	manifest := shared.ExportManifest{
+		Version:      shared.ManifestVersion,
+		ExportType:   data.ExportType,
+		JobId:        job.Id,
+		JobStartTime: data.JobStartTime,
+		JobEndTime:   data.JobEndTime,
+		Batches:      make([]shared.BatchManifestEntry, 0, data.BatchNumber),
+	}
+	for batchNumber := range data.BatchNumber {
+		entryPath := batchEntryPath(data.ExportDir, batchNumber)
+		b, err := backend.ReadFile(entryPath)
+		if err != nil {
+			return fmt.Errorf("unable to read the manifest entry of the batch from %s: %w", entryPath, err)
+		}
+		var entry shared.BatchManifestEntry
+		if err := json.Unmarshal(b, &entry); err != nil {
+			return fmt.Errorf("unable to read the manifest entry of the batch from %s: %w", entryPath, err)
+		}
+		manifest.Batches = append(manifest.Batches, entry)
+	}
+
+	if err := writeJSON(backend, path.Join(data.ExportDir, shared.ManifestFileName), manifest); err != nil {
+		return err
+	}
+	if err := backend.RemoveDirectory(path.Join(data.ExportDir, batchEntriesDir)); err != nil {
+		rctx.Logger().Warn("Failed to remove the manifest entries of the batches", mlog.String("export_dir", data.ExportDir), mlog.Err(err))
+	}
+	return nil
+}
+
+func writeJSON(backend filestore.FileBackend, filePath string, v any) error {
+	b, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	if _, err := backend.WriteFile(bytes.NewReader(b), filePath); err != nil {
+		return fmt.Errorf("unable to write %s: %w", filePath, err)
+	}
+	return nil
+}
+
+func batchEntryPath(exportDir string, batchNumber int) string {
+	return path.Join(exportDir, batchEntriesDir, strconv.Itoa(batchNumber)+".json")
+}
+
+func (w *worker) setJobError(logger mlog.LoggerIFace, job *model.Job, appErr *model.AppError) {
+	logger.Error("Failed to run message export. Exiting", mlog.Err(appErr))
+	w.periodJobId = ""
+	w.period = shared.JobData{}
+	if err := w.jobServer.SetJobError(job, appErr); err != nil {
+		logger.Error("Failed to set the job error", mlog.Err(err))
+	}
+}
+
+func setJobData(job *model.Job, data shared.JobData) {
+	for key, value := range shared.JobDataToStringMap(data) {
+		job.Data[key] = value
+	}
+}
+
+// resetCounts clears the message counts of the channels, which are computed for each batch.
+func resetCounts(channels map[string]*shared.MetadataChannel) {
+	for _, channel := range channels {
+		channel.MessagesCount = 0
+		channel.AttachmentsCount = 0
+	}
+}
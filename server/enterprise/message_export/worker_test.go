@@ -0,0 +1,467 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.enterprise for license information.
+
+package message_export
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/jobs"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+	"github.com/mattermost/mattermost/server/v8/channels/utils/testutils"
+	"github.com/mattermost/mattermost/server/v8/enterprise/message_export/jsonl_export"
+	"github.com/mattermost/mattermost/server/v8/enterprise/message_export/shared"
+	"github.com/mattermost/mattermost/server/v8/platform/shared/filestore"
+)
+
+type testApp struct {
+	config  *model.Config
+	license *model.License
+}
+
+func (a *testApp) Config() *model.Config {
+	return a.config
+}
+
+func (a *testApp) License() *model.License {
+	return a.license
+}
+
+type testHelper struct {
+	rctx      request.CTX
+	store     store.Store
+	app       *testApp
+	jobServer *jobs.JobServer
+
+	channel *model.Channel
+	user    *model.User
+}
+
+func setup(t *testing.T) *testHelper {
+	ss := mainHelper.GetStore()
+	ss.DropAllTables()
+
+	cfg := &model.Config{}
+	cfg.SetDefaults()
+	cfg.FileSettings.DriverName = model.NewPointer(model.ImageDriverLocal)
+	cfg.FileSettings.Directory = model.NewPointer(t.TempDir())
+	cfg.MessageExportSettings.ExportFormat = model.NewPointer(model.ComplianceExportTypeJsonl)
+	cfg.MessageExportSettings.ExportFromTimestamp = model.NewPointer(int64(0))
+	cfg.MessageExportSettings.BatchSize = model.NewPointer(2)
+
+	th := &testHelper{
+		rctx:      request.TestContext(t),
+		store:     ss,
+		jobServer: jobs.NewJobServer(&testutils.StaticConfigService{Cfg: cfg}, ss, nil, mlog.CreateConsoleTestLogger(t)),
+		app: &testApp{
+			config:  cfg,
+			license: model.NewTestLicense("message_export"),
+		},
+	}
+
+	team, err := ss.Team().Save(&model.Team{
+		DisplayName: "Team",
+		Name:        "t-" + model.NewId(),
+		Email:       "success+" + model.NewId() + "@simulator.amazonses.com",
+		Type:        model.TeamOpen,
+	})
+	require.NoError(t, err)
+	th.channel, err = ss.Channel().Save(th.rctx, &model.Channel{
+		TeamId:      team.Id,
+		DisplayName: "Channel",
+		Name:        "c-" + model.NewId(),
+		Type:        model.ChannelTypeOpen,
+	}, -1)
+	require.NoError(t, err)
+	th.user, err = ss.User().Save(th.rctx, &model.User{
+		Email:    "success+" + model.NewId() + "@simulator.amazonses.com",
+		Username: "u" + model.NewId(),
+	})
+	require.NoError(t, err)
+
+	return th
+}
+
+func (th *testHelper) newWorker() *worker {
+	return &worker{
+		jobServer: th.jobServer,
+		store:     shared.NewMessageExportStore(th.store),
+		app:       th.app,
+	}
+}
+
+func (th *testHelper) backend(t *testing.T) filestore.FileBackend {
+	backend, err := shared.GetExportBackend(th.rctx, th.app.config)
+	require.NoError(t, err)
+	return backend
+}
+
+func (th *testHelper) createPost(t *testing.T, fileIDs ...string) *model.Post {
+	post, err := th.store.Post().Save(th.rctx, &model.Post{
+		ChannelId: th.channel.Id,
+		UserId:    th.user.Id,
+		Message:   "message " + model.NewId(),
+		FileIds:   fileIDs,
+	})
+	require.NoError(t, err)
+	return post
+}
+
+// createPostWithFile creates a post with an attachment, which is only written to the file store when upload is set.
+func (th *testHelper) createPostWithFile(t *testing.T, content []byte, upload bool) (*model.Post, *model.FileInfo) {
+	id := model.NewId()
+	fileInfo := &model.FileInfo{
+		Id:        id,
+		CreatorId: th.user.Id,
+		ChannelId: th.channel.Id,
+		Name:      "file.txt",
+		Path:      "data/" + id + "/file.txt",
+		Size:      int64(len(content)),
+	}
+	if upload {
+		_, err := th.backend(t).WriteFile(bytes.NewReader(content), fileInfo.Path)
+		require.NoError(t, err)
+	}
+
+	post := th.createPost(t, id)
+	fileInfo.PostId = post.Id
+	fileInfo, err := th.store.FileInfo().Save(th.rctx, fileInfo)
+	require.NoError(t, err)
+	return post, fileInfo
+}
+
+func (th *testHelper) newJob(t *testing.T, data model.StringMap) *model.Job {
+	if data == nil {
+		data = model.StringMap{}
+	}
+	// The last successful job is the newest one, so jobs mustn't be created within the same millisecond.
+	time.Sleep(time.Millisecond)
+	job, err := th.store.Job().Save(&model.Job{
+		Id:       model.NewId(),
+		Type:     model.JobTypeMessageExport,
+		CreateAt: model.GetMillis(),
+		Status:   model.JobStatusInProgress,
+		Data:     data,
+	})
+	require.NoError(t, err)
+	return job
+}
+
+// runJob runs the batches of the job until it's done, and returns the job as stored.
+func (th *testHelper) runJob(t *testing.T, w *worker, job *model.Job) *model.Job {
+	for range 100 {
+		if w.doBatch(th.rctx, job) {
+			stored, err := th.store.Job().Get(th.rctx, job.Id)
+			require.NoError(t, err)
+			return stored
+		}
+	}
+	require.FailNow(t, "the job didn't finish")
+	return nil
+}
+
+// readExport checks the manifest of the export directory of the job against the batch archives, and the manifest of
+// each archive against its contents. It returns the manifest of the export, and the files of the archives.
+func (th *testHelper) readExport(t *testing.T, job *model.Job) (shared.ExportManifest, map[string][]byte) {
+	t.Helper()
+	backend := th.backend(t)
+	exportDir := job.Data[shared.JobDataExportDir]
+	require.NotEmpty(t, exportDir)
+
+	b, err := backend.ReadFile(path.Join(exportDir, shared.ManifestFileName))
+	require.NoError(t, err)
+	var exportManifest shared.ExportManifest
+	require.NoError(t, json.Unmarshal(b, &exportManifest))
+	assert.Equal(t, shared.ManifestVersion, exportManifest.Version)
+	assert.Equal(t, job.Id, exportManifest.JobId)
+
+	files := make(map[string][]byte)
+	for i, batch := range exportManifest.Batches {
+		assert.Equal(t, i, batch.BatchNumber)
+
+		zipBytes, err := backend.ReadFile(path.Join(exportDir, batch.Path))
+		require.NoError(t, err)
+		assert.Equal(t, batch.Size, int64(len(zipBytes)))
+		assert.Equal(t, checksum(zipBytes), batch.SHA256, "checksum of %s", batch.Path)
+
+		zr, err := zip.NewReader(bytes.NewReader(zipBytes), int64(len(zipBytes)))
+		require.NoError(t, err)
+		contents := make(map[string][]byte)
+		for _, f := range zr.File {
+			r, err := f.Open()
+			require.NoError(t, err)
+			content, err := io.ReadAll(r)
+			require.NoError(t, err)
+			require.NoError(t, r.Close())
+			contents[f.Name] = content
+		}
+
+		var manifest shared.Manifest
+		require.Contains(t, contents, shared.ManifestFileName)
+		require.NoError(t, json.Unmarshal(contents[shared.ManifestFileName], &manifest))
+		assert.Equal(t, batch.BatchNumber, manifest.BatchNumber)
+		assert.Equal(t, batch.MessagesCount, manifest.MessagesCount)
+		assert.Len(t, manifest.Files, len(contents)-1, "every file of %s should be in its manifest", batch.Path)
+		for _, entry := range manifest.Files {
+			require.Contains(t, contents, entry.Path)
+			assert.Equal(t, entry.Size, int64(len(contents[entry.Path])))
+			assert.Equal(t, checksum(contents[entry.Path]), entry.SHA256, "checksum of %s in %s", entry.Path, batch.Path)
+			files[path.Join(batch.Path, entry.Path)] = contents[entry.Path]
+		}
+	}
+
+	return exportManifest, files
+}
+
+// readPostRecords returns the records of the messages of every batch of the export, in order. The join and leave
+// records are left out.
+func readPostRecords(t *testing.T, exportManifest shared.ExportManifest, files map[string][]byte) []jsonl_export.Record {
+	t.Helper()
+	var records []jsonl_export.Record
+	for _, batch := range exportManifest.Batches {
+		content, ok := files[path.Join(batch.Path, jsonl_export.MessagesFileName)]
+		require.True(t, ok, "%s should hold the messages", batch.Path)
+		scanner := bufio.NewScanner(bytes.NewReader(content))
+		for scanner.Scan() {
+			var record jsonl_export.Record
+			require.NoError(t, json.Unmarshal(scanner.Bytes(), &record))
+			if record.Post != nil {
+				records = append(records, record)
+			}
+		}
+		require.NoError(t, scanner.Err())
+	}
+	return records
+}
+
+func checksum(b []byte) string {
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])
+}
+
+func TestWorker(t *testing.T) {
+	if testing.Short() {
+		t.SkipNow()
+	}
+
+	t.Run("requires a license", func(t *testing.T) {
+		th := setup(t)
+		th.createPost(t)
+		th.app.license = model.NewTestLicenseWithFalseDefaults("message_export")
+
+		job := th.runJob(t, th.newWorker(), th.newJob(t, nil))
+		assert.Equal(t, model.JobStatusError, job.Status)
+		assert.Empty(t, job.Data[shared.JobDataBatchNumber])
+	})
+
+	t.Run("exports in batches", func(t *testing.T) {
+		th := setup(t)
+		var postIDs []string
+		for range 3 {
+			postIDs = append(postIDs, th.createPost(t).Id)
+		}
+		content := []byte("attachment content")
+		post, fileInfo := th.createPostWithFile(t, content, true)
+		postIDs = append(postIDs, post.Id)
+		postIDs = append(postIDs, th.createPost(t).Id)
+
+		job := th.runJob(t, th.newWorker(), th.newJob(t, nil))
+		require.Equal(t, model.JobStatusSuccess, job.Status, job.Data["error"])
+		assert.Equal(t, int64(100), job.Progress)
+		assert.Equal(t, "5", job.Data[shared.JobDataMessagesExported])
+		assert.Equal(t, "0", job.Data[shared.JobDataWarningCount])
+
+		exportManifest, files := th.readExport(t, job)
+		assert.Equal(t, model.ComplianceExportTypeJsonl, exportManifest.ExportType)
+		require.Len(t, exportManifest.Batches, 3)
+		assert.Equal(t, []int{2, 2, 1}, []int{
+			exportManifest.Batches[0].MessagesCount,
+			exportManifest.Batches[1].MessagesCount,
+			exportManifest.Batches[2].MessagesCount,
+		})
+		assert.Equal(t, "3", job.Data[shared.JobDataBatchNumber])
+		exists, err := th.backend(t).FileExists(path.Join(job.Data[shared.JobDataExportDir], batchEntriesDir))
+		require.NoError(t, err)
+		assert.False(t, exists, "the manifest entries of the batches should be removed once listed in the manifest")
+
+		var exported []string
+		for _, record := range readPostRecords(t, exportManifest, files) {
+			assert.Equal(t, jsonl_export.EventPost, record.Event)
+			assert.Equal(t, th.user.Id, record.User.Id)
+			exported = append(exported, record.Post.Id)
+			if record.Post.Id != post.Id {
+				continue
+			}
+			require.Len(t, record.Files, 1)
+			assert.Equal(t, fileInfo.Id, record.Files[0].Id)
+			assert.Equal(t, checksum(content), record.Files[0].SHA256)
+			attachment := path.Join(jsonl_export.FilesDir, fileInfo.Id, fileInfo.Name)
+			assert.Equal(t, attachment, record.Files[0].Path)
+			copies := 0
+			for _, batch := range exportManifest.Batches {
+				if copied, ok := files[path.Join(batch.Path, attachment)]; ok {
+					assert.Equal(t, content, copied)
+					copies++
+				}
+			}
+			assert.Equal(t, 1, copies, "the attachment should be in the batch of its post")
+		}
+		assert.ElementsMatch(t, postIDs, exported)
+	})
+
+	t.Run("picks up where the last successful job stopped", func(t *testing.T) {
+		th := setup(t)
+		th.createPost(t)
+		th.createPost(t)
+		w := th.newWorker()
+
+		job := th.runJob(t, w, th.newJob(t, nil))
+		require.Equal(t, model.JobStatusSuccess, job.Status, job.Data["error"])
+		assert.Equal(t, "2", job.Data[shared.JobDataMessagesExported])
+
+		post := th.createPost(t)
+		job = th.runJob(t, w, th.newJob(t, nil))
+		require.Equal(t, model.JobStatusSuccess, job.Status, job.Data["error"])
+		assert.Equal(t, "1", job.Data[shared.JobDataMessagesExported])
+
+		exportManifest, files := th.readExport(t, job)
+		records := readPostRecords(t, exportManifest, files)
+		require.Len(t, records, 1)
+		assert.Equal(t, post.Id, records[0].Post.Id)
+
+		// With nothing new to export, no manifest is written.
+		job = th.runJob(t, w, th.newJob(t, nil))
+		require.Equal(t, model.JobStatusSuccess, job.Status, job.Data["error"])
+		assert.Equal(t, "0", job.Data[shared.JobDataMessagesExported])
+		assert.Equal(t, "0", job.Data[shared.JobDataBatchNumber])
+	})
+
+	t.Run("a job resumed by another worker keeps the batches written before", func(t *testing.T) {
+		th := setup(t)
+		var postIDs []string
+		for range 5 {
+			postIDs = append(postIDs, th.createPost(t).Id)
+		}
+
+		job := th.newJob(t, nil)
+		require.False(t, th.newWorker().doBatch(th.rctx, job))
+		stored, err := th.store.Job().Get(th.rctx, job.Id)
+		require.NoError(t, err)
+		assert.Equal(t, "1", stored.Data[shared.JobDataBatchNumber])
+
+		job = th.runJob(t, th.newWorker(), stored)
+		require.Equal(t, model.JobStatusSuccess, job.Status, job.Data["error"])
+
+		exportManifest, files := th.readExport(t, job)
+		require.Len(t, exportManifest.Batches, 3)
+		var exported []string
+		for _, record := range readPostRecords(t, exportManifest, files) {
+			exported = append(exported, record.Post.Id)
+		}
+		assert.ElementsMatch(t, postIDs, exported)
+	})
+
+	t.Run("other export types are delegated to their exporter", func(t *testing.T) {
+		th := setup(t)
+		th.createPost(t)
+		th.createPost(t)
+		th.createPost(t)
+
+		var batches []shared.ExportParams
+		RegisterExporter(model.ComplianceExportTypeCsv, func(rctx request.CTX, p shared.ExportParams) (shared.RunExportResults, error) {
+			batches = append(batches, p)
+			return shared.RunExportResults{}, nil
+		})
+		t.Cleanup(func() {
+			delete(exporters, model.ComplianceExportTypeCsv)
+		})
+
+		job := th.runJob(t, th.newWorker(), th.newJob(t, model.StringMap{shared.JobDataExportType: model.ComplianceExportTypeCsv}))
+		require.Equal(t, model.JobStatusSuccess, job.Status, job.Data["error"])
+		assert.Equal(t, "3", job.Data[shared.JobDataMessagesExported])
+
+		require.Len(t, batches, 2)
+		assert.Equal(t, model.ComplianceExportTypeCsv, batches[0].ExportType)
+		assert.Len(t, batches[0].Posts, 2)
+		assert.Len(t, batches[1].Posts, 1)
+		assert.Equal(t, 1, batches[1].BatchNumber)
+
+		exists, err := th.backend(t).FileExists(path.Join(job.Data[shared.JobDataExportDir], shared.ManifestFileName))
+		require.NoError(t, err)
+		assert.False(t, exists, "the delegated export types don't have a manifest")
+	})
+
+	t.Run("missing attachments are warnings", func(t *testing.T) {
+		th := setup(t)
+		post, fileInfo := th.createPostWithFile(t, []byte("attachment content"), false)
+
+		job := th.runJob(t, th.newWorker(), th.newJob(t, nil))
+		require.Equal(t, model.JobStatusWarning, job.Status, job.Data["error"])
+		assert.Equal(t, "1", job.Data[shared.JobDataMessagesExported])
+		assert.Equal(t, "1", job.Data[shared.JobDataWarningCount])
+
+		exportManifest, files := th.readExport(t, job)
+		records := readPostRecords(t, exportManifest, files)
+		require.Len(t, records, 1)
+		assert.Equal(t, post.Id, records[0].Post.Id)
+		require.Len(t, records[0].Files, 1)
+		assert.Equal(t, fileInfo.Id, records[0].Files[0].Id)
+		assert.Empty(t, records[0].Files[0].Path)
+		assert.Empty(t, records[0].Files[0].SHA256)
+	})
+
+	t.Run("unknown export type", func(t *testing.T) {
+		th := setup(t)
+		th.createPost(t)
+
+		job := th.runJob(t, th.newWorker(), th.newJob(t, model.StringMap{shared.JobDataExportType: "junk"}))
+		assert.Equal(t, model.JobStatusError, job.Status)
+		assert.Contains(t, job.Data["error"], "ent.message_export.export_type.app_error")
+	})
+
+	t.Run("the export backend fails", func(t *testing.T) {
+		th := setup(t)
+		th.createPost(t)
+		th.app.config.FileSettings.DriverName = model.NewPointer("junk")
+
+		job := th.runJob(t, th.newWorker(), th.newJob(t, nil))
+		assert.Equal(t, model.JobStatusError, job.Status)
+		assert.Contains(t, job.Data["error"], "ent.message_export.run_export.app_error")
+		assert.Equal(t, "0", job.Data[shared.JobDataBatchNumber])
+	})
+
+	t.Run("a failed job is picked up by the next one", func(t *testing.T) {
+		th := setup(t)
+		post := th.createPost(t)
+		w := th.newWorker()
+		driverName := th.app.config.FileSettings.DriverName
+		th.app.config.FileSettings.DriverName = model.NewPointer("junk")
+
+		job := th.runJob(t, w, th.newJob(t, nil))
+		require.Equal(t, model.JobStatusError, job.Status)
+
+		th.app.config.FileSettings.DriverName = driverName
+		job = th.runJob(t, w, th.newJob(t, nil))
+		require.Equal(t, model.JobStatusSuccess, job.Status, job.Data["error"])
+
+		exportManifest, files := th.readExport(t, job)
+		records := readPostRecords(t, exportManifest, files)
+		require.Len(t, records, 1)
+		assert.Equal(t, post.Id, records[0].Post.Id)
+	})
+}
@@ -0,0 +1,23 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+//go:build enterprise
+
+package enterprise
+
+import (
+	"github.com/mattermost/enterprise/message_export/actiance_export"
+	"github.com/mattermost/enterprise/message_export/csv_export"
+	"github.com/mattermost/enterprise/message_export/global_relay_export"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/enterprise/message_export"
+)
+
+// The message export worker delegates the export types of the enterprise builds to their exporters.
+func init() {
+	message_export.RegisterExporter(model.ComplianceExportTypeActiance, actiance_export.ActianceExport)
+	message_export.RegisterExporter(model.ComplianceExportTypeCsv, csv_export.CsvExport)
+	message_export.RegisterExporter(model.ComplianceExportTypeGlobalrelay, global_relay_export.GlobalRelayExport)
+	message_export.RegisterExporter(model.ComplianceExportTypeGlobalrelayZip, global_relay_export.GlobalRelayExport)
+}
@@ -61,6 +61,7 @@ type MetricsInterfaceImpl struct {
 	DbSearchConnectionsGauge prometheus.GaugeFunc
 	DbReplicaLagGaugeAbs     *prometheus.GaugeVec
 	DbReplicaLagGaugeTime    *prometheus.GaugeVec
+	DbReplicaReadRedirects   *prometheus.CounterVec
 
 	PostCreateCounter     prometheus.Counter
 	WebhookPostCounter    prometheus.Counter
@@ -69,9 +70,10 @@ type MetricsInterfaceImpl struct {
 	PostBroadcastCounter  prometheus.Counter
 	PostFileAttachCounter prometheus.Counter
 
-	HTTPRequestsCounter prometheus.Counter
-	HTTPErrorsCounter   prometheus.Counter
-	HTTPWebsocketsGauge *prometheus.GaugeVec
+	HTTPRequestsCounter  prometheus.Counter
+	HTTPErrorsCounter    prometheus.Counter
+	HTTPRateLimitCounter *prometheus.CounterVec
+	HTTPWebsocketsGauge  *prometheus.GaugeVec
 
 	ClusterRequestsDuration prometheus.Histogram
 	ClusterRequestsCounter  prometheus.Counter
@@ -415,6 +417,18 @@ func New(ps *platform.PlatformService, driver, dataSource string) *MetricsInterf
 	)
 	m.Registry.MustRegister(m.DbReplicaLagGaugeTime)
 
+	m.DbReplicaReadRedirects = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace:   MetricsNamespace,
+			Subsystem:   MetricsSubsystemDB,
+			Name:        "replica_read_redirects_total",
+			Help:        "The total number of reads sent elsewhere than the replica they would go to, by reason.",
+			ConstLabels: additionalLabels,
+		},
+		[]string{"reason"},
+	)
+	m.Registry.MustRegister(m.DbReplicaReadRedirects)
+
 	// HTTP Subsystem
 
 	m.HTTPWebsocketsGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
@@ -444,6 +458,15 @@ func New(ps *platform.PlatformService, driver, dataSource string) *MetricsInterf
 	})
 	m.Registry.MustRegister(m.HTTPErrorsCounter)
 
+	m.HTTPRateLimitCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace:   MetricsNamespace,
+		Subsystem:   MetricsSubsystemHTTP,
+		Name:        "rate_limit_requests_total",
+		Help:        "The total number of http API requests checked against a rate limit, by rule and result.",
+		ConstLabels: additionalLabels,
+	}, []string{"rule", "result"})
+	m.Registry.MustRegister(m.HTTPRateLimitCounter)
+
 	// Cluster Subsystem
 
 	m.ClusterHealthGauge = prometheus.NewGaugeFunc(prometheus.GaugeOpts{
@@ -527,7 +550,9 @@ func New(ps *platform.PlatformService, driver, dataSource string) *MetricsInterf
 		model.ClusterEventRemovePlugin,
 		model.ClusterEventPluginEvent,
 		model.ClusterEventInvalidateCacheForTermsOfService,
+		model.ClusterEventInvalidateCacheForWorkingHours,
 		model.ClusterEventBusyStateChanged,
+		model.ClusterEventEventStreamRequest,
 	} {
 		m.ClusterEventMap[event] = m.ClusterEventTypeCounters.With(prometheus.Labels{"name": string(event)})
 	}
@@ -1738,6 +1763,14 @@ func (mi *MetricsInterfaceImpl) IncrementHTTPError() {
 	mi.HTTPErrorsCounter.Inc()
 }
 
+func (mi *MetricsInterfaceImpl) IncrementHTTPRateLimitCounter(rule string, limited bool) {
+	result := "allowed"
+	if limited {
+		result = "limited"
+	}
+	mi.HTTPRateLimitCounter.With(prometheus.Labels{"rule": rule, "result": result}).Inc()
+}
+
 func (mi *MetricsInterfaceImpl) IncrementClusterRequest() {
 	mi.ClusterRequestsCounter.Inc()
 }
@@ -1997,6 +2030,12 @@ func (mi *MetricsInterfaceImpl) SetReplicaLagTime(node string, value float64) {
 	mi.DbReplicaLagGaugeTime.With(prometheus.Labels{"node": node}).Set(value)
 }
 
+// IncrementDBReplicaReadRedirect counts a read sent away from the replicas, or away from a
+// lagging replica, for the given reason.
+func (mi *MetricsInterfaceImpl) IncrementDBReplicaReadRedirect(reason string) {
+	mi.DbReplicaReadRedirects.With(prometheus.Labels{"reason": reason}).Inc()
+}
+
 func normalizeNotificationPlatform(platform string) string {
 	switch platform {
 	case "apple_rn-v2", "apple_rnbeta-v2", "ios":
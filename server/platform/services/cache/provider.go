@@ -139,6 +139,15 @@ func (r *redisProvider) Type() string {
 	return model.CacheTypeRedis
 }
 
+// RedisClient returns the Redis client of provider, or nil if provider isn't
+// backed by Redis. It allows other services to share the connection.
+func RedisClient(provider Provider) rueidis.Client {
+	if r, ok := provider.(*redisProvider); ok {
+		return r.client
+	}
+	return nil
+}
+
 // Close releases any resources used by the cache provider.
 func (r *redisProvider) Close() error {
 	r.client.Close()
@@ -0,0 +1,459 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+// Package cluster implements the communication between the nodes of a
+// high availability deployment. Nodes discover each other through the
+// ClusterDiscovery table, elect a leader by holding a lease in the database
+// and exchange messages over Redis pub/sub, or over Postgres LISTEN/NOTIFY
+// when Redis isn't used.
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/redis/rueidis"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+	"github.com/mattermost/mattermost/server/v8/einterfaces"
+)
+
+const (
+	// DiscoveryPingInterval is how often nodes record that they're alive.
+	DiscoveryPingInterval = 15 * time.Second
+	// NodeTimeout is how long a node can go without recording that it's
+	// alive before it's considered gone.
+	NodeTimeout = 3 * DiscoveryPingInterval
+
+	// LeaseDuration is how long the leadership lasts without being renewed.
+	LeaseDuration = 30 * time.Second
+	// LeaseRenewInterval is how often the leader renews its lease, and the
+	// other nodes attempt to take it.
+	LeaseRenewInterval = 10 * time.Second
+
+	sendQueueSize  = 10000
+	publishTimeout = 5 * time.Second
+	// maxHealthScore bounds the health score, which counts the consecutive
+	// failures to publish messages.
+	maxHealthScore = 10
+)
+
+// PlatformIface is the subset of the platform service the cluster relies on.
+type PlatformIface interface {
+	Config() *model.Config
+	Log() mlog.LoggerIFace
+	GetStore() store.Store
+	ClientConfigHash() string
+	ReloadConfig() error
+	InvokeClusterLeaderChangedListeners()
+	TotalWebsocketConnections() int
+	WebConnCountForUser(userID string) int
+	GetWSQueues(userID, connectionID string, seqNum int64) (*model.WSQueues, error)
+	GetLogsSkipSend(rctx request.CTX, page, perPage int, logFilter *model.LogFilter) ([]string, *model.AppError)
+	GetPluginStatuses() (model.PluginStatuses, *model.AppError)
+	GenerateSupportPacket(rctx request.CTX, options *model.SupportPacketOptions) ([]model.FileData, error)
+}
+
+// envelope wraps the messages exchanged between nodes.
+type envelope struct {
+	Sender string `json:"sender"`
+	// RequestID is set on the requests that expect a response from each
+	// node, and on those responses.
+	RequestID  string                `json:"request_id,omitempty"`
+	IsResponse bool                  `json:"is_response,omitempty"`
+	Message    *model.ClusterMessage `json:"message"`
+}
+
+// Cluster is the built-in implementation of einterfaces.ClusterInterface.
+type Cluster struct {
+	platform    PlatformIface
+	redisClient rueidis.Client
+	logger      mlog.LoggerIFace
+	nodeID      string
+
+	handlersMut sync.RWMutex
+	handlers    map[model.ClusterEvent]einterfaces.ClusterMessageHandler
+
+	pendingMut sync.Mutex
+	pending    map[string]chan *envelope
+
+	// The following are set when inter-node communication starts.
+	transport     transport
+	clusterName   string
+	discovery     *model.ClusterDiscovery
+	schemaVersion string
+
+	running        atomic.Bool
+	leader         atomic.Bool
+	healthScore    atomic.Int32
+	sendQueue      chan *envelope
+	stop           chan struct{}
+	wg             sync.WaitGroup
+	leaseExpiresAt time.Time
+}
+
+// New creates the cluster of the given platform. Messages are exchanged
+// through redisClient if it's not nil, or through the database otherwise.
+func New(platform PlatformIface, redisClient rueidis.Client) *Cluster {
+	c := &Cluster{
+		platform:    platform,
+		redisClient: redisClient,
+		logger:      platform.Log(),
+		nodeID:      model.NewId(),
+		handlers:    make(map[model.ClusterEvent]einterfaces.ClusterMessageHandler),
+		pending:     make(map[string]chan *envelope),
+	}
+	c.handlers[model.ClusterGossipEventRequestSaveConfig] = c.handleSaveConfig
+	return c
+}
+
+func (c *Cluster) StartInterNodeCommunication() {
+	if c.running.Load() {
+		return
+	}
+
+	cfg := c.platform.Config()
+	ss := c.platform.GetStore()
+
+	c.clusterName = *cfg.ClusterSettings.ClusterName
+	if c.clusterName == "" {
+		c.logger.Error("Failed to start the cluster, ClusterSettings.ClusterName must be set")
+		return
+	}
+	c.discovery = c.newDiscovery(cfg)
+	if version, err := ss.GetDBSchemaVersion(); err == nil {
+		c.schemaVersion = strconv.Itoa(version)
+	}
+
+	if c.redisClient != nil {
+		c.transport = newRedisTransport(c.redisClient, c.logger)
+	} else {
+		c.transport = newPostgresTransport(ss.GetInternalMasterDB(), *cfg.SqlSettings.DataSource, c.logger)
+	}
+
+	if err := c.start(); err != nil {
+		c.logger.Error("Failed to start the cluster", mlog.String("transport", c.transport.name()), mlog.Err(err))
+		return
+	}
+
+	c.logger.Info("Cluster started",
+		mlog.String("node_id", c.nodeID),
+		mlog.String("cluster_name", c.clusterName),
+		mlog.String("hostname", c.discovery.Hostname),
+		mlog.String("transport", c.transport.name()),
+	)
+}
+
+// start subscribes to the transport and starts the background tasks, once
+// the transport is set.
+func (c *Cluster) start() error {
+	c.sendQueue = make(chan *envelope, sendQueueSize)
+	c.stop = make(chan struct{})
+
+	if err := c.transport.subscribe([]string{broadcastChannel(c.clusterName), nodeChannel(c.clusterName, c.nodeID)}, c.NotifyMsg); err != nil {
+		return err
+	}
+	c.running.Store(true)
+
+	c.wg.Add(3)
+	go c.sendLoop()
+	go c.discoveryLoop()
+	go c.leaderLoop()
+	return nil
+}
+
+func (c *Cluster) StopInterNodeCommunication() {
+	if !c.running.CompareAndSwap(true, false) {
+		return
+	}
+
+	close(c.stop)
+	c.wg.Wait()
+
+	if err := c.transport.close(); err != nil {
+		c.logger.Warn("Failed to close the cluster transport", mlog.Err(err))
+	}
+	c.logger.Info("Cluster stopped", mlog.String("node_id", c.nodeID))
+}
+
+func (c *Cluster) newDiscovery(cfg *model.Config) *model.ClusterDiscovery {
+	discovery := &model.ClusterDiscovery{
+		Id:          c.nodeID,
+		Type:        model.CDSTypeApp,
+		ClusterName: c.clusterName,
+		Hostname:    *cfg.ClusterSettings.OverrideHostname,
+		GossipPort:  int32(*cfg.ClusterSettings.GossipPort),
+	}
+	if *cfg.ClusterSettings.UseIPAddress {
+		discovery.AutoFillIPAddress(*cfg.ClusterSettings.NetworkInterface, *cfg.ClusterSettings.AdvertiseAddress)
+	} else {
+		discovery.AutoFillHostname()
+	}
+	return discovery
+}
+
+// discoveryLoop records this node in the ClusterDiscovery table and keeps it
+// alive until the cluster stops.
+func (c *Cluster) discoveryLoop() {
+	defer c.wg.Done()
+
+	discoveryStore := c.platform.GetStore().ClusterDiscovery()
+	if err := discoveryStore.Cleanup(); err != nil {
+		c.logger.Warn("Failed to clean up the outdated cluster discovery information", mlog.Err(err))
+	}
+	// A previous run on the same host might have left its record behind.
+	if _, err := discoveryStore.Delete(c.discovery); err != nil {
+		c.logger.Warn("Failed to delete the previous cluster discovery information", mlog.Err(err))
+	}
+	if err := discoveryStore.Save(c.discovery); err != nil {
+		c.logger.Error("Failed to save the cluster discovery information", mlog.Err(err))
+	}
+
+	ticker := time.NewTicker(DiscoveryPingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := discoveryStore.SetLastPingAt(c.discovery); err != nil {
+				c.logger.Warn("Failed to update the cluster discovery information", mlog.Err(err))
+			}
+		case <-c.stop:
+			if _, err := discoveryStore.Delete(c.discovery); err != nil {
+				c.logger.Warn("Failed to delete the cluster discovery information", mlog.Err(err))
+			}
+			return
+		}
+	}
+}
+
+// otherNodes returns the ids of the other nodes of the cluster that are
+// alive.
+func (c *Cluster) otherNodes() ([]string, error) {
+	discoveries, err := c.platform.GetStore().ClusterDiscovery().GetAll(model.CDSTypeApp, c.clusterName)
+	if err != nil {
+		return nil, err
+	}
+
+	aliveAfter := model.GetMillis() - NodeTimeout.Milliseconds()
+	var nodes []string
+	for _, discovery := range discoveries {
+		if discovery.Id != c.nodeID && discovery.LastPingAt > aliveAfter {
+			nodes = append(nodes, discovery.Id)
+		}
+	}
+	return nodes, nil
+}
+
+// leaderLoop attempts to take or renew the leadership lease until the cluster
+// stops, when the lease is released for another node to take over.
+func (c *Cluster) leaderLoop() {
+	defer c.wg.Done()
+
+	leaseName := broadcastChannel(c.clusterName)
+	ticker := time.NewTicker(LeaseRenewInterval)
+	defer ticker.Stop()
+
+	for {
+		c.renewLease(leaseName)
+
+		select {
+		case <-ticker.C:
+		case <-c.stop:
+			if c.leader.Load() {
+				if err := c.platform.GetStore().ClusterDiscovery().ReleaseLease(leaseName, c.nodeID); err != nil {
+					c.logger.Warn("Failed to release the cluster leadership", mlog.Err(err))
+				}
+				c.setLeader(false)
+			}
+			return
+		}
+	}
+}
+
+func (c *Cluster) renewLease(leaseName string) {
+	now := time.Now()
+	expiresAt := now.Add(LeaseDuration)
+
+	acquired, err := c.platform.GetStore().ClusterDiscovery().AcquireLease(leaseName, c.nodeID, LeaseDuration.Milliseconds())
+	if err != nil {
+		c.logger.Warn("Failed to renew the cluster leadership", mlog.Err(err))
+		// Keep the leadership as long as the lease is known to last, as the
+		// other nodes can't take it over before then.
+		c.setLeader(c.leader.Load() && now.Before(c.leaseExpiresAt))
+		return
+	}
+
+	if acquired {
+		c.leaseExpiresAt = expiresAt
+	}
+	c.setLeader(acquired)
+}
+
+func (c *Cluster) setLeader(leader bool) {
+	if c.leader.Swap(leader) != leader {
+		c.logger.Info("Cluster leadership changed", mlog.String("node_id", c.nodeID), mlog.Bool("leader", leader))
+		c.platform.InvokeClusterLeaderChangedListeners()
+	}
+}
+
+func (c *Cluster) IsLeader() bool {
+	return c.leader.Load()
+}
+
+func (c *Cluster) GetClusterId() string {
+	return c.nodeID
+}
+
+func (c *Cluster) HealthScore() int {
+	return int(c.healthScore.Load())
+}
+
+func (c *Cluster) GetMyClusterInfo() *model.ClusterInfo {
+	info := &model.ClusterInfo{
+		Id:            c.nodeID,
+		Version:       model.CurrentVersion,
+		SchemaVersion: c.schemaVersion,
+		ConfigHash:    c.platform.ClientConfigHash(),
+		IPAddress:     model.GetServerIPAddress(*c.platform.Config().ClusterSettings.NetworkInterface),
+	}
+	if c.discovery != nil {
+		info.Hostname = c.discovery.Hostname
+	}
+	return info
+}
+
+func (c *Cluster) RegisterClusterMessageHandler(event model.ClusterEvent, crm einterfaces.ClusterMessageHandler) {
+	c.handlersMut.Lock()
+	defer c.handlersMut.Unlock()
+	c.handlers[event] = crm
+}
+
+// SendClusterMessage sends msg to all the other nodes. The message is queued
+// unless it needs to be sent before returning.
+func (c *Cluster) SendClusterMessage(msg *model.ClusterMessage) {
+	if !c.running.Load() {
+		return
+	}
+
+	env := &envelope{Sender: c.nodeID, Message: msg}
+	if msg.WaitForAllToSend {
+		// Failures are logged by publish.
+		_ = c.publish(broadcastChannel(c.clusterName), env)
+		return
+	}
+
+	if msg.SendType == model.ClusterSendReliable {
+		select {
+		case c.sendQueue <- env:
+		case <-c.stop:
+		}
+		return
+	}
+
+	select {
+	case c.sendQueue <- env:
+	default:
+		c.logger.Warn("Cluster send queue is full, dropping message", mlog.String("event", string(msg.Event)))
+	}
+}
+
+func (c *Cluster) SendClusterMessageToNode(nodeID string, msg *model.ClusterMessage) error {
+	return c.publish(nodeChannel(c.clusterName, nodeID), &envelope{Sender: c.nodeID, Message: msg})
+}
+
+func (c *Cluster) sendLoop() {
+	defer c.wg.Done()
+
+	for {
+		select {
+		case env := <-c.sendQueue:
+			_ = c.publish(broadcastChannel(c.clusterName), env)
+		case <-c.stop:
+			return
+		}
+	}
+}
+
+func (c *Cluster) publish(channel string, env *envelope) error {
+	payload, err := json.Marshal(env)
+	if err != nil {
+		c.logger.Error("Failed to encode cluster message", mlog.String("event", string(env.Message.Event)), mlog.Err(err))
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), publishTimeout)
+	defer cancel()
+
+	if err := c.transport.publish(ctx, channel, payload); err != nil {
+		if c.healthScore.Load() < maxHealthScore {
+			c.healthScore.Add(1)
+		}
+		c.logger.Warn("Failed to send cluster message", mlog.String("event", string(env.Message.Event)), mlog.Err(err))
+		return err
+	}
+	c.healthScore.Store(0)
+	return nil
+}
+
+// NotifyMsg processes a message received from another node.
+func (c *Cluster) NotifyMsg(buf []byte) {
+	var env envelope
+	if err := json.Unmarshal(buf, &env); err != nil {
+		c.logger.Warn("Failed to decode cluster message", mlog.Err(err))
+		return
+	}
+	if env.Sender == c.nodeID || env.Message == nil {
+		return
+	}
+
+	if env.IsResponse {
+		c.receiveResponse(&env)
+		return
+	}
+	if env.RequestID != "" {
+		// Requests can take a while to process, so they're handled apart
+		// not to hold up the other messages.
+		go c.handleRequest(&env)
+		return
+	}
+
+	c.handlersMut.RLock()
+	handler, ok := c.handlers[env.Message.Event]
+	c.handlersMut.RUnlock()
+	if !ok {
+		c.logger.Debug("No handler for cluster message", mlog.String("event", string(env.Message.Event)))
+		return
+	}
+	handler(env.Message)
+}
+
+func (c *Cluster) handleSaveConfig(msg *model.ClusterMessage) {
+	if err := c.platform.ReloadConfig(); err != nil {
+		c.logger.Error("Failed to reload the configuration changed by another node", mlog.Err(err))
+	}
+}
+
+// ConfigChanged asks the other nodes to reload their configuration, which is
+// shared through the database.
+func (c *Cluster) ConfigChanged(previousConfig *model.Config, newConfig *model.Config, sendToOtherServer bool) *model.AppError {
+	if !sendToOtherServer || !c.running.Load() {
+		return nil
+	}
+
+	if err := c.publish(broadcastChannel(c.clusterName), &envelope{
+		Sender:  c.nodeID,
+		Message: &model.ClusterMessage{Event: model.ClusterGossipEventRequestSaveConfig},
+	}); err != nil {
+		return model.NewAppError("ConfigChanged", "ent.cluster.request.error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return nil
+}
@@ -0,0 +1,326 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package cluster
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+	"github.com/mattermost/mattermost/server/v8/channels/store/storetest/mocks"
+)
+
+// memoryBus connects memory transports, delivering payloads synchronously.
+type memoryBus struct {
+	mut         sync.RWMutex
+	subscribers map[string][]func([]byte)
+}
+
+func newMemoryBus() *memoryBus {
+	return &memoryBus{subscribers: make(map[string][]func([]byte))}
+}
+
+type memoryTransport struct {
+	bus *memoryBus
+}
+
+func (t *memoryTransport) name() string {
+	return "memory"
+}
+
+func (t *memoryTransport) publish(ctx context.Context, channel string, payload []byte) error {
+	t.bus.mut.RLock()
+	receivers := t.bus.subscribers[channel]
+	t.bus.mut.RUnlock()
+	for _, receive := range receivers {
+		receive(payload)
+	}
+	return nil
+}
+
+func (t *memoryTransport) subscribe(channels []string, receive func(payload []byte)) error {
+	t.bus.mut.Lock()
+	defer t.bus.mut.Unlock()
+	for _, channel := range channels {
+		t.bus.subscribers[channel] = append(t.bus.subscribers[channel], receive)
+	}
+	return nil
+}
+
+func (t *memoryTransport) close() error {
+	return nil
+}
+
+type testPlatform struct {
+	logger           mlog.LoggerIFace
+	store            store.Store
+	webConnCount     int
+	leaderChanges    atomic.Int32
+	configReloads    atomic.Int32
+	wsQueues         *model.WSQueues
+	webSocketConns   int
+	pluginStatuses   model.PluginStatuses
+	supportPacketErr error
+}
+
+func (p *testPlatform) Config() *model.Config {
+	cfg := &model.Config{}
+	cfg.SetDefaults()
+	return cfg
+}
+func (p *testPlatform) Log() mlog.LoggerIFace                { return p.logger }
+func (p *testPlatform) GetStore() store.Store                { return p.store }
+func (p *testPlatform) ClientConfigHash() string             { return "hash" }
+func (p *testPlatform) ReloadConfig() error                  { p.configReloads.Add(1); return nil }
+func (p *testPlatform) InvokeClusterLeaderChangedListeners() { p.leaderChanges.Add(1) }
+func (p *testPlatform) TotalWebsocketConnections() int       { return p.webSocketConns }
+func (p *testPlatform) WebConnCountForUser(userID string) int {
+	return p.webConnCount
+}
+func (p *testPlatform) GetWSQueues(userID, connectionID string, seqNum int64) (*model.WSQueues, error) {
+	return p.wsQueues, nil
+}
+func (p *testPlatform) GetLogsSkipSend(rctx request.CTX, page, perPage int, logFilter *model.LogFilter) ([]string, *model.AppError) {
+	return []string{"line"}, nil
+}
+func (p *testPlatform) GetPluginStatuses() (model.PluginStatuses, *model.AppError) {
+	return p.pluginStatuses, nil
+}
+func (p *testPlatform) GenerateSupportPacket(rctx request.CTX, options *model.SupportPacketOptions) ([]model.FileData, error) {
+	return []model.FileData{{Filename: "mattermost.log", Body: []byte("log")}}, p.supportPacketErr
+}
+
+// testNodes tracks the nodes started by a test, which all share the same
+// discovery store.
+type testNodes struct {
+	t         *testing.T
+	bus       *memoryBus
+	mut       sync.Mutex
+	discovery []*model.ClusterDiscovery
+}
+
+func newTestNodes(t *testing.T) *testNodes {
+	return &testNodes{t: t, bus: newMemoryBus()}
+}
+
+func (n *testNodes) discoveries() []*model.ClusterDiscovery {
+	n.mut.Lock()
+	defer n.mut.Unlock()
+	return append([]*model.ClusterDiscovery{}, n.discovery...)
+}
+
+// addGhost records a node that is alive according to the discovery store but
+// never responds.
+func (n *testNodes) addGhost() {
+	n.mut.Lock()
+	defer n.mut.Unlock()
+	n.discovery = append(n.discovery, &model.ClusterDiscovery{Id: model.NewId(), LastPingAt: model.GetMillis()})
+}
+
+func (n *testNodes) start(platform *testPlatform, acquireLease bool) *Cluster {
+	discoveryStore := &mocks.ClusterDiscoveryStore{}
+	discoveryStore.On("Cleanup").Return(nil).Maybe()
+	discoveryStore.On("Delete", mock.Anything).Return(true, nil).Maybe()
+	discoveryStore.On("SetLastPingAt", mock.Anything).Return(nil).Maybe()
+	discoveryStore.On("ReleaseLease", mock.Anything, mock.Anything).Return(nil).Maybe()
+	discoveryStore.On("AcquireLease", mock.Anything, mock.Anything, mock.Anything).Return(acquireLease, nil).Maybe()
+	discoveryStore.On("Save", mock.Anything).Return(nil).Maybe()
+	discoveryStore.On("GetAll", model.CDSTypeApp, "test").Return(func(string, string) []*model.ClusterDiscovery {
+		return n.discoveries()
+	}, nil).Maybe()
+
+	ss := &mocks.Store{}
+	ss.On("ClusterDiscovery").Return(discoveryStore)
+	ss.On("TotalReadDbConnections").Return(2).Maybe()
+	ss.On("TotalMasterDbConnections").Return(3).Maybe()
+
+	platform.logger = mlog.CreateConsoleTestLogger(n.t)
+	platform.store = ss
+
+	c := New(platform, nil)
+	c.clusterName = "test"
+	c.discovery = &model.ClusterDiscovery{Id: c.nodeID, Hostname: "host-" + c.nodeID}
+	c.transport = &memoryTransport{bus: n.bus}
+	require.NoError(n.t, c.start())
+	n.t.Cleanup(c.StopInterNodeCommunication)
+
+	n.mut.Lock()
+	n.discovery = append(n.discovery, &model.ClusterDiscovery{Id: c.nodeID, LastPingAt: model.GetMillis()})
+	n.mut.Unlock()
+	return c
+}
+
+func TestSendClusterMessage(t *testing.T) {
+	nodes := newTestNodes(t)
+	node1 := nodes.start(&testPlatform{}, false)
+	node2 := nodes.start(&testPlatform{}, false)
+	node3 := nodes.start(&testPlatform{}, false)
+
+	var received1, received2, received3 atomic.Int32
+	node1.RegisterClusterMessageHandler(model.ClusterEventPublish, func(msg *model.ClusterMessage) { received1.Add(1) })
+	node2.RegisterClusterMessageHandler(model.ClusterEventPublish, func(msg *model.ClusterMessage) {
+		assert.Equal(t, []byte("data"), msg.Data)
+		received2.Add(1)
+	})
+	node3.RegisterClusterMessageHandler(model.ClusterEventPublish, func(msg *model.ClusterMessage) { received3.Add(1) })
+
+	t.Run("broadcast", func(t *testing.T) {
+		node1.SendClusterMessage(&model.ClusterMessage{Event: model.ClusterEventPublish, Data: []byte("data"), WaitForAllToSend: true})
+		assert.Equal(t, int32(0), received1.Load(), "the sender doesn't receive its own messages")
+		assert.Equal(t, int32(1), received2.Load())
+		assert.Equal(t, int32(1), received3.Load())
+	})
+
+	t.Run("queued", func(t *testing.T) {
+		node1.SendClusterMessage(&model.ClusterMessage{Event: model.ClusterEventPublish, Data: []byte("data"), SendType: model.ClusterSendReliable})
+		require.Eventually(t, func() bool {
+			return received2.Load() == 2 && received3.Load() == 2
+		}, 5*time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("to node", func(t *testing.T) {
+		require.NoError(t, node1.SendClusterMessageToNode(node3.GetClusterId(), &model.ClusterMessage{Event: model.ClusterEventPublish}))
+		assert.Equal(t, int32(2), received2.Load())
+		assert.Equal(t, int32(3), received3.Load())
+	})
+
+	t.Run("not running", func(t *testing.T) {
+		node3.StopInterNodeCommunication()
+		node3.SendClusterMessage(&model.ClusterMessage{Event: model.ClusterEventPublish, Data: []byte("data"), WaitForAllToSend: true})
+		assert.Equal(t, int32(0), received1.Load())
+		assert.Equal(t, int32(2), received2.Load())
+	})
+}
+
+func TestConfigChanged(t *testing.T) {
+	nodes := newTestNodes(t)
+	platform1 := &testPlatform{}
+	platform2 := &testPlatform{}
+	node1 := nodes.start(platform1, false)
+	nodes.start(platform2, false)
+
+	require.Nil(t, node1.ConfigChanged(nil, nil, false))
+	assert.Equal(t, int32(0), platform2.configReloads.Load())
+
+	require.Nil(t, node1.ConfigChanged(nil, nil, true))
+	assert.Equal(t, int32(0), platform1.configReloads.Load())
+	assert.Equal(t, int32(1), platform2.configReloads.Load())
+}
+
+func TestRequests(t *testing.T) {
+	nodes := newTestNodes(t)
+	node1 := nodes.start(&testPlatform{webConnCount: 1, webSocketConns: 1}, false)
+	node2 := nodes.start(&testPlatform{
+		webConnCount:   2,
+		webSocketConns: 10,
+		wsQueues:       &model.WSQueues{ReuseCount: 1},
+		pluginStatuses: model.PluginStatuses{{PluginId: "plugin"}},
+	}, false)
+	node3 := nodes.start(&testPlatform{webConnCount: 3, webSocketConns: 20}, false)
+	rctx := request.EmptyContext(node1.logger)
+
+	t.Run("web conn count", func(t *testing.T) {
+		count, appErr := node1.WebConnCountForUser(model.NewId())
+		require.Nil(t, appErr)
+		assert.Equal(t, 5, count, "only the other nodes are counted")
+	})
+
+	t.Run("cluster stats", func(t *testing.T) {
+		stats, appErr := node1.GetClusterStats(rctx)
+		require.Nil(t, appErr)
+		require.Len(t, stats, 2)
+		assert.Equal(t, 30, stats[0].TotalWebsocketConnections+stats[1].TotalWebsocketConnections)
+		assert.Equal(t, 3, stats[0].TotalMasterDbConnections)
+	})
+
+	t.Run("websocket queues", func(t *testing.T) {
+		queues, err := node1.GetWSQueues(model.NewId(), model.NewId(), 1)
+		require.NoError(t, err)
+		require.Len(t, queues, 2)
+		assert.Equal(t, 1, queues[node2.GetClusterId()].ReuseCount)
+		assert.Nil(t, queues[node3.GetClusterId()])
+	})
+
+	t.Run("plugin statuses", func(t *testing.T) {
+		statuses, appErr := node1.GetPluginStatuses()
+		require.Nil(t, appErr)
+		require.Len(t, statuses, 1)
+		assert.Equal(t, "plugin", statuses[0].PluginId)
+	})
+
+	t.Run("logs", func(t *testing.T) {
+		logs, appErr := node1.QueryLogs(rctx, 0, 10)
+		require.Nil(t, appErr)
+		assert.Equal(t, map[string][]string{
+			"host-" + node2.GetClusterId(): {"line"},
+			"host-" + node3.GetClusterId(): {"line"},
+		}, logs)
+
+		lines, appErr := node1.GetLogs(rctx, 0, 10)
+		require.Nil(t, appErr)
+		assert.Len(t, lines, 12)
+	})
+
+	t.Run("support packet", func(t *testing.T) {
+		files, err := node1.GenerateSupportPacket(rctx, &model.SupportPacketOptions{})
+		require.NoError(t, err)
+		require.Len(t, files, 2)
+		assert.Equal(t, "host-"+node2.GetClusterId()+"/mattermost.log", files[node2.GetClusterId()][0].Filename)
+	})
+
+	t.Run("cluster infos", func(t *testing.T) {
+		infos, err := node1.GetClusterInfos()
+		require.NoError(t, err)
+		require.Len(t, infos, 3)
+		for _, info := range infos {
+			assert.Equal(t, "host-"+info.Id, info.Hostname)
+		}
+	})
+
+	t.Run("node not responding", func(t *testing.T) {
+		previous := requestTimeout
+		requestTimeout = 100 * time.Millisecond
+		t.Cleanup(func() { requestTimeout = previous })
+		nodes.addGhost()
+
+		_, appErr := node1.WebConnCountForUser(model.NewId())
+		require.NotNil(t, appErr)
+		assert.Equal(t, "ent.cluster.timeout.error", appErr.Id)
+
+		_, err := node1.GetWSQueues(model.NewId(), model.NewId(), 1)
+		require.Error(t, err)
+
+		// The responses received are used when they don't need to be
+		// exhaustive.
+		stats, appErr := node1.GetClusterStats(rctx)
+		require.Nil(t, appErr)
+		assert.Len(t, stats, 2)
+	})
+}
+
+func TestLeader(t *testing.T) {
+	nodes := newTestNodes(t)
+
+	platform := &testPlatform{}
+	leader := nodes.start(platform, true)
+	follower := nodes.start(&testPlatform{}, false)
+
+	require.Eventually(t, leader.IsLeader, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, int32(1), platform.leaderChanges.Load())
+	assert.False(t, follower.IsLeader())
+
+	leader.StopInterNodeCommunication()
+	assert.False(t, leader.IsLeader())
+	assert.Equal(t, int32(2), platform.leaderChanges.Load())
+}
@@ -0,0 +1,248 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package cluster
+
+import (
+	"context"
+	"database/sql"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+const (
+	// maxNotificationChunkSize keeps notifications under the 8000 bytes
+	// Postgres accepts, leaving room for the chunk header.
+	maxNotificationChunkSize = 7000
+
+	// incompleteMessageTimeout is how long the chunks of a message are kept
+	// waiting for the rest of them.
+	incompleteMessageTimeout = time.Minute
+
+	listenerMinReconnectInterval = time.Second
+	listenerMaxReconnectInterval = 30 * time.Second
+	listenerPingInterval         = time.Minute
+)
+
+// postgresTransport exchanges payloads with LISTEN/NOTIFY. It's used when
+// Redis isn't available.
+//
+// Payloads are base64 encoded, since notifications must be text, and split
+// into chunks when they're too large for a single notification. The chunks of
+// a payload are sent in a single transaction, so they're delivered together.
+type postgresTransport struct {
+	db         *sql.DB
+	dataSource string
+	logger     mlog.LoggerIFace
+
+	listener *pq.Listener
+	stop     chan struct{}
+	wg       sync.WaitGroup
+}
+
+func newPostgresTransport(db *sql.DB, dataSource string, logger mlog.LoggerIFace) *postgresTransport {
+	return &postgresTransport{
+		db:         db,
+		dataSource: dataSource,
+		logger:     logger,
+		stop:       make(chan struct{}),
+	}
+}
+
+func (t *postgresTransport) name() string {
+	return "postgres"
+}
+
+func (t *postgresTransport) publish(ctx context.Context, channel string, payload []byte) error {
+	chunks := splitPayload(model.NewId(), payload)
+
+	tx, err := t.db.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to begin transaction")
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	for _, chunk := range chunks {
+		if _, err := tx.ExecContext(ctx, "SELECT pg_notify($1, $2)", channel, chunk); err != nil {
+			return errors.Wrap(err, "failed to notify")
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.Wrap(err, "failed to commit transaction")
+	}
+	return nil
+}
+
+func (t *postgresTransport) subscribe(channels []string, receive func(payload []byte)) error {
+	t.listener = pq.NewListener(t.dataSource, listenerMinReconnectInterval, listenerMaxReconnectInterval, func(event pq.ListenerEventType, err error) {
+		switch event {
+		case pq.ListenerEventDisconnected:
+			t.logger.Warn("Cluster listener disconnected from the database", mlog.Err(err))
+		case pq.ListenerEventReconnected:
+			t.logger.Info("Cluster listener reconnected to the database")
+		case pq.ListenerEventConnectionAttemptFailed:
+			t.logger.Warn("Cluster listener failed to connect to the database", mlog.Err(err))
+		}
+	})
+
+	for _, channel := range channels {
+		if err := t.listener.Listen(channel); err != nil {
+			t.listener.Close()
+			return errors.Wrapf(err, "failed to listen to %s", channel)
+		}
+	}
+
+	assembler := newChunkAssembler()
+
+	t.wg.Add(1)
+	go func() {
+		defer t.wg.Done()
+		ticker := time.NewTicker(listenerPingInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case notification := <-t.listener.Notify:
+				// A nil notification is sent after reconnecting, when
+				// notifications might have been missed.
+				if notification == nil {
+					continue
+				}
+				payload, err := assembler.add(notification.Extra)
+				if err != nil {
+					t.logger.Warn("Failed to decode cluster notification", mlog.Err(err))
+					continue
+				}
+				if payload != nil {
+					receive(payload)
+				}
+			case <-ticker.C:
+				if err := t.listener.Ping(); err != nil {
+					t.logger.Warn("Failed to ping the database from the cluster listener", mlog.Err(err))
+				}
+				assembler.expire(time.Now().Add(-incompleteMessageTimeout))
+			case <-t.stop:
+				return
+			}
+		}
+	}()
+
+	return nil
+}
+
+func (t *postgresTransport) close() error {
+	if t.listener == nil {
+		return nil
+	}
+	close(t.stop)
+	t.wg.Wait()
+	return t.listener.Close()
+}
+
+// splitPayload encodes payload into one or more notifications, each prefixed
+// with the id of the message, the index of the chunk and the total number of
+// chunks.
+func splitPayload(id string, payload []byte) []string {
+	encoded := base64.StdEncoding.EncodeToString(payload)
+	total := max((len(encoded)+maxNotificationChunkSize-1)/maxNotificationChunkSize, 1)
+
+	chunks := make([]string, 0, total)
+	for i := range total {
+		end := min((i+1)*maxNotificationChunkSize, len(encoded))
+		chunks = append(chunks, fmt.Sprintf("%s:%d:%d:%s", id, i, total, encoded[i*maxNotificationChunkSize:end]))
+	}
+	return chunks
+}
+
+type incompleteMessage struct {
+	chunks   []string
+	received int
+	firstAt  time.Time
+}
+
+// chunkAssembler puts back together the payloads split by splitPayload.
+type chunkAssembler struct {
+	incomplete map[string]*incompleteMessage
+}
+
+func newChunkAssembler() *chunkAssembler {
+	return &chunkAssembler{
+		incomplete: make(map[string]*incompleteMessage),
+	}
+}
+
+// add processes a notification, returning the payload once all its chunks
+// have been received, or nil otherwise.
+func (a *chunkAssembler) add(notification string) ([]byte, error) {
+	parts := strings.SplitN(notification, ":", 4)
+	if len(parts) != 4 {
+		return nil, errors.New("malformed notification")
+	}
+	id, data := parts[0], parts[3]
+	index, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return nil, errors.Wrap(err, "malformed chunk index")
+	}
+	total, err := strconv.Atoi(parts[2])
+	if err != nil || total < 1 || index < 0 || index >= total {
+		return nil, errors.New("malformed chunk count")
+	}
+
+	if total == 1 {
+		return decodeChunks([]string{data})
+	}
+
+	msg, ok := a.incomplete[id]
+	if !ok {
+		msg = &incompleteMessage{
+			chunks:  make([]string, total),
+			firstAt: time.Now(),
+		}
+		a.incomplete[id] = msg
+	}
+	if len(msg.chunks) != total {
+		delete(a.incomplete, id)
+		return nil, errors.New("inconsistent chunk count")
+	}
+	if msg.chunks[index] == "" {
+		msg.received++
+	}
+	msg.chunks[index] = data
+
+	if msg.received < total {
+		return nil, nil
+	}
+	delete(a.incomplete, id)
+	return decodeChunks(msg.chunks)
+}
+
+// expire drops the messages still missing chunks that started arriving before
+// the given time.
+func (a *chunkAssembler) expire(before time.Time) {
+	for id, msg := range a.incomplete {
+		if msg.firstAt.Before(before) {
+			delete(a.incomplete, id)
+		}
+	}
+}
+
+func decodeChunks(chunks []string) ([]byte, error) {
+	payload, err := base64.StdEncoding.DecodeString(strings.Join(chunks, ""))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode payload")
+	}
+	return payload, nil
+}
@@ -0,0 +1,102 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package cluster
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func TestChunkAssembler(t *testing.T) {
+	t.Run("single chunk", func(t *testing.T) {
+		chunks := splitPayload(model.NewId(), []byte("payload"))
+		require.Len(t, chunks, 1)
+
+		payload, err := newChunkAssembler().add(chunks[0])
+		require.NoError(t, err)
+		assert.Equal(t, []byte("payload"), payload)
+	})
+
+	t.Run("empty payload", func(t *testing.T) {
+		chunks := splitPayload(model.NewId(), nil)
+		require.Len(t, chunks, 1)
+
+		payload, err := newChunkAssembler().add(chunks[0])
+		require.NoError(t, err)
+		assert.NotNil(t, payload)
+		assert.Empty(t, payload)
+	})
+
+	t.Run("multiple chunks, interleaved", func(t *testing.T) {
+		large1 := bytes.Repeat([]byte("a"), 3*maxNotificationChunkSize)
+		large2 := bytes.Repeat([]byte("b"), 2*maxNotificationChunkSize)
+		chunks1 := splitPayload(model.NewId(), large1)
+		chunks2 := splitPayload(model.NewId(), large2)
+		require.Len(t, chunks1, 4)
+		require.Len(t, chunks2, 3)
+		for _, chunk := range chunks1 {
+			assert.Less(t, len(chunk), 8000)
+		}
+
+		assembler := newChunkAssembler()
+		var payloads [][]byte
+		for i := range 4 {
+			for _, chunks := range [][]string{chunks1, chunks2} {
+				if i >= len(chunks) {
+					continue
+				}
+				payload, err := assembler.add(chunks[i])
+				require.NoError(t, err)
+				if payload != nil {
+					payloads = append(payloads, payload)
+				}
+			}
+		}
+
+		require.Len(t, payloads, 2)
+		assert.Equal(t, large2, payloads[0])
+		assert.Equal(t, large1, payloads[1])
+		assert.Empty(t, assembler.incomplete)
+	})
+
+	t.Run("expired chunks", func(t *testing.T) {
+		chunks := splitPayload(model.NewId(), bytes.Repeat([]byte("a"), 2*maxNotificationChunkSize))
+		assembler := newChunkAssembler()
+
+		payload, err := assembler.add(chunks[0])
+		require.NoError(t, err)
+		assert.Nil(t, payload)
+
+		assembler.expire(time.Now().Add(time.Minute))
+		assert.Empty(t, assembler.incomplete)
+
+		payload, err = assembler.add(chunks[1])
+		require.NoError(t, err)
+		assert.Nil(t, payload, "the message can't be completed once its first chunks have expired")
+	})
+
+	t.Run("malformed", func(t *testing.T) {
+		for _, notification := range []string{"", "id", "id:a:1:data", "id:0:b:data", "id:1:1:data", "id:0:1:not base64!"} {
+			_, err := newChunkAssembler().add(notification)
+			assert.Error(t, err, notification)
+		}
+	})
+}
+
+func TestChannelNames(t *testing.T) {
+	nodeID := model.NewId()
+	channel := nodeChannel(strings.Repeat("a", 200), nodeID)
+
+	// Postgres truncates identifiers longer than 63 bytes.
+	assert.LessOrEqual(t, len(channel), 63)
+	assert.True(t, strings.HasPrefix(channel, broadcastChannel(strings.Repeat("a", 200))))
+	assert.NotEqual(t, broadcastChannel("cluster1"), broadcastChannel("cluster2"))
+}
@@ -0,0 +1,83 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package cluster
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/redis/rueidis"
+
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+const redisResubscribeDelay = time.Second
+
+// redisTransport exchanges payloads over Redis pub/sub, using the client of
+// the Redis cache.
+type redisTransport struct {
+	client rueidis.Client
+	logger mlog.LoggerIFace
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func newRedisTransport(client rueidis.Client, logger mlog.LoggerIFace) *redisTransport {
+	return &redisTransport{
+		client: client,
+		logger: logger,
+	}
+}
+
+func (t *redisTransport) name() string {
+	return "redis"
+}
+
+func (t *redisTransport) publish(ctx context.Context, channel string, payload []byte) error {
+	return t.client.Do(ctx, t.client.B().Publish().Channel(channel).Message(rueidis.BinaryString(payload)).Build()).Error()
+}
+
+func (t *redisTransport) subscribe(channels []string, receive func(payload []byte)) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.cancel = cancel
+
+	t.wg.Add(1)
+	go func() {
+		defer t.wg.Done()
+		for {
+			// Receive blocks until the subscription is interrupted, in which
+			// case messages published in the meantime are lost.
+			err := t.client.Receive(ctx, t.client.B().Subscribe().Channel(channels...).Build(), func(msg rueidis.PubSubMessage) {
+				receive([]byte(msg.Message))
+			})
+			if ctx.Err() != nil {
+				return
+			}
+			if err != nil && !errors.Is(err, context.Canceled) {
+				t.logger.Warn("Cluster subscription to Redis interrupted, resubscribing", mlog.Err(err))
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(redisResubscribeDelay):
+			}
+		}
+	}()
+
+	return nil
+}
+
+// close stops the subscription. The client is owned by the cache provider and
+// is left open.
+func (t *redisTransport) close() error {
+	if t.cancel != nil {
+		t.cancel()
+	}
+	t.wg.Wait()
+	return nil
+}
@@ -0,0 +1,376 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package cluster
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path"
+	"slices"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+)
+
+// requestTimeout is how long the responses of the other nodes are awaited.
+var requestTimeout = 15 * time.Second
+
+const (
+	clusterEventRequestClusterInfo  model.ClusterEvent = "gossip_request_cluster_info"
+	clusterEventResponseClusterInfo model.ClusterEvent = "gossip_response_cluster_info"
+
+	propHostname = "hostname"
+	propError    = "error"
+)
+
+// errRequestTimeout is returned along with the responses received so far
+// when some nodes didn't respond in time.
+var errRequestTimeout = errors.New("timed out waiting for the other nodes to respond")
+
+type logsRequest struct {
+	Page    int `json:"page"`
+	PerPage int `json:"per_page"`
+}
+
+type wsQueuesRequest struct {
+	UserID       string `json:"user_id"`
+	ConnectionID string `json:"connection_id"`
+	SeqNum       int64  `json:"seq_num"`
+}
+
+// request sends a request to all the other nodes, returning their responses
+// by node id.
+func (c *Cluster) request(event model.ClusterEvent, data any) (map[string]*model.ClusterMessage, error) {
+	if !c.running.Load() {
+		return nil, nil
+	}
+
+	nodes, err := c.otherNodes()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get the nodes of the cluster")
+	}
+	if len(nodes) == 0 {
+		return nil, nil
+	}
+
+	buf, err := json.Marshal(data)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to encode the request")
+	}
+
+	requestID := model.NewId()
+	responses := make(chan *envelope, len(nodes))
+	c.pendingMut.Lock()
+	c.pending[requestID] = responses
+	c.pendingMut.Unlock()
+	defer func() {
+		c.pendingMut.Lock()
+		delete(c.pending, requestID)
+		c.pendingMut.Unlock()
+	}()
+
+	if err := c.publish(broadcastChannel(c.clusterName), &envelope{
+		Sender:    c.nodeID,
+		RequestID: requestID,
+		Message:   &model.ClusterMessage{Event: event, Data: buf},
+	}); err != nil {
+		return nil, errors.Wrap(err, "failed to send the request")
+	}
+
+	results := make(map[string]*model.ClusterMessage, len(nodes))
+	timer := time.NewTimer(requestTimeout)
+	defer timer.Stop()
+
+	for !containsAll(results, nodes) {
+		select {
+		case env := <-responses:
+			results[env.Sender] = env.Message
+		case <-timer.C:
+			return results, errRequestTimeout
+		}
+	}
+	return results, nil
+}
+
+func containsAll(results map[string]*model.ClusterMessage, nodes []string) bool {
+	for _, node := range nodes {
+		if _, ok := results[node]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *Cluster) receiveResponse(env *envelope) {
+	c.pendingMut.Lock()
+	responses, ok := c.pending[env.RequestID]
+	c.pendingMut.Unlock()
+	if !ok {
+		// The request has timed out.
+		return
+	}
+
+	select {
+	case responses <- env:
+	default:
+		// The channel is sized for the nodes known when the request was sent,
+		// so responses from nodes that joined since are dropped.
+	}
+}
+
+// decodeResponses decodes the data of each response into a T, skipping the
+// responses reporting an error.
+func decodeResponses[T any](c *Cluster, responses map[string]*model.ClusterMessage) map[string]T {
+	decoded := make(map[string]T, len(responses))
+	for nodeID, msg := range responses {
+		if errMsg := msg.Props[propError]; errMsg != "" {
+			c.logger.Warn("Cluster node failed to process request", mlog.String("node_id", nodeID), mlog.String("event", string(msg.Event)), mlog.String("error", errMsg))
+			continue
+		}
+		var value T
+		if err := json.Unmarshal(msg.Data, &value); err != nil {
+			c.logger.Warn("Failed to decode cluster response", mlog.String("node_id", nodeID), mlog.String("event", string(msg.Event)), mlog.Err(err))
+			continue
+		}
+		decoded[nodeID] = value
+	}
+	return decoded
+}
+
+// handleRequest processes a request from another node and sends back the
+// response.
+func (c *Cluster) handleRequest(env *envelope) {
+	rctx := request.EmptyContext(c.logger)
+
+	event, data, err := c.processRequest(rctx, env.Message)
+	response := &model.ClusterMessage{
+		Event: event,
+		Props: map[string]string{propHostname: c.discovery.Hostname},
+	}
+	if err != nil {
+		response.Props[propError] = err.Error()
+	} else if response.Data, err = json.Marshal(data); err != nil {
+		response.Props[propError] = err.Error()
+	}
+
+	if err := c.publish(nodeChannel(c.clusterName, env.Sender), &envelope{
+		Sender:     c.nodeID,
+		RequestID:  env.RequestID,
+		IsResponse: true,
+		Message:    response,
+	}); err != nil {
+		c.logger.Warn("Failed to respond to cluster request", mlog.String("event", string(env.Message.Event)), mlog.Err(err))
+	}
+}
+
+func (c *Cluster) processRequest(rctx request.CTX, msg *model.ClusterMessage) (model.ClusterEvent, any, error) {
+	switch msg.Event {
+	case model.ClusterGossipEventRequestGetLogs:
+		var req logsRequest
+		if err := json.Unmarshal(msg.Data, &req); err != nil {
+			return model.ClusterGossipEventResponseGetLogs, nil, err
+		}
+		lines, appErr := c.platform.GetLogsSkipSend(rctx, req.Page, req.PerPage, &model.LogFilter{})
+		if appErr != nil {
+			return model.ClusterGossipEventResponseGetLogs, nil, appErr
+		}
+		return model.ClusterGossipEventResponseGetLogs, lines, nil
+
+	case model.ClusterGossipEventRequestGetClusterStats:
+		ss := c.platform.GetStore()
+		return model.ClusterGossipEventResponseGetClusterStats, &model.ClusterStats{
+			Id:                        c.nodeID,
+			TotalWebsocketConnections: c.platform.TotalWebsocketConnections(),
+			TotalReadDbConnections:    ss.TotalReadDbConnections(),
+			TotalMasterDbConnections:  ss.TotalMasterDbConnections(),
+		}, nil
+
+	case model.ClusterGossipEventRequestGetPluginStatuses:
+		statuses, appErr := c.platform.GetPluginStatuses()
+		if appErr != nil {
+			return model.ClusterGossipEventResponseGetPluginStatuses, nil, appErr
+		}
+		return model.ClusterGossipEventResponseGetPluginStatuses, statuses, nil
+
+	case model.ClusterGossipEventRequestGenerateSupportPacket:
+		var options model.SupportPacketOptions
+		if err := json.Unmarshal(msg.Data, &options); err != nil {
+			return model.ClusterGossipEventResponseGenerateSupportPacket, nil, err
+		}
+		files, err := c.platform.GenerateSupportPacket(rctx, &options)
+		if err != nil {
+			c.logger.Warn("Failed to generate parts of the Support Packet", mlog.Err(err))
+		}
+		// The files of each node are put in a directory named after it.
+		for i := range files {
+			files[i].Filename = path.Join(c.discovery.Hostname, files[i].Filename)
+		}
+		return model.ClusterGossipEventResponseGenerateSupportPacket, files, nil
+
+	case model.ClusterGossipEventRequestWebConnCount:
+		var userID string
+		if err := json.Unmarshal(msg.Data, &userID); err != nil {
+			return model.ClusterGossipEventResponseWebConnCount, nil, err
+		}
+		return model.ClusterGossipEventResponseWebConnCount, c.platform.WebConnCountForUser(userID), nil
+
+	case model.ClusterGossipEventRequestWSQueues:
+		var req wsQueuesRequest
+		if err := json.Unmarshal(msg.Data, &req); err != nil {
+			return model.ClusterGossipEventResponseWSQueues, nil, err
+		}
+		queues, err := c.platform.GetWSQueues(req.UserID, req.ConnectionID, req.SeqNum)
+		if err != nil {
+			return model.ClusterGossipEventResponseWSQueues, nil, err
+		}
+		return model.ClusterGossipEventResponseWSQueues, queues, nil
+
+	case clusterEventRequestClusterInfo:
+		return clusterEventResponseClusterInfo, c.GetMyClusterInfo(), nil
+	}
+
+	return model.ClusterEventNone, nil, fmt.Errorf("unknown request %q", msg.Event)
+}
+
+// logPartialResponses logs when some nodes didn't respond to a request whose
+// responses are used regardless.
+func (c *Cluster) logPartialResponses(event model.ClusterEvent, err error) {
+	if err != nil {
+		c.logger.Warn("Failed to get responses from all the nodes of the cluster", mlog.String("event", string(event)), mlog.Err(err))
+	}
+}
+
+func (c *Cluster) GetClusterInfos() ([]*model.ClusterInfo, error) {
+	responses, err := c.request(clusterEventRequestClusterInfo, nil)
+	c.logPartialResponses(clusterEventRequestClusterInfo, err)
+
+	infos := []*model.ClusterInfo{c.GetMyClusterInfo()}
+	for _, info := range decodeResponses[*model.ClusterInfo](c, responses) {
+		infos = append(infos, info)
+	}
+	slices.SortFunc(infos, func(a, b *model.ClusterInfo) int {
+		return cmp.Compare(a.Hostname, b.Hostname)
+	})
+	return infos, nil
+}
+
+func (c *Cluster) GetClusterStats(rctx request.CTX) ([]*model.ClusterStats, *model.AppError) {
+	responses, err := c.request(model.ClusterGossipEventRequestGetClusterStats, nil)
+	c.logPartialResponses(model.ClusterGossipEventRequestGetClusterStats, err)
+
+	var stats []*model.ClusterStats
+	for _, nodeStats := range decodeResponses[*model.ClusterStats](c, responses) {
+		stats = append(stats, nodeStats)
+	}
+	return stats, nil
+}
+
+func (c *Cluster) GetLogs(rctx request.CTX, page, perPage int) ([]string, *model.AppError) {
+	logs, appErr := c.QueryLogs(rctx, page, perPage)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	hostnames := make([]string, 0, len(logs))
+	for hostname := range logs {
+		hostnames = append(hostnames, hostname)
+	}
+	slices.Sort(hostnames)
+
+	var lines []string
+	for _, hostname := range hostnames {
+		lines = append(lines,
+			"-----------------------------------------------------------------------------------------------------------",
+			"-----------------------------------------------------------------------------------------------------------",
+			hostname,
+			"-----------------------------------------------------------------------------------------------------------",
+			"-----------------------------------------------------------------------------------------------------------",
+		)
+		lines = append(lines, logs[hostname]...)
+	}
+	return lines, nil
+}
+
+// QueryLogs returns the logs of the other nodes by hostname.
+func (c *Cluster) QueryLogs(rctx request.CTX, page, perPage int) (map[string][]string, *model.AppError) {
+	responses, err := c.request(model.ClusterGossipEventRequestGetLogs, &logsRequest{Page: page, PerPage: perPage})
+	c.logPartialResponses(model.ClusterGossipEventRequestGetLogs, err)
+
+	logs := make(map[string][]string, len(responses))
+	for nodeID, lines := range decodeResponses[[]string](c, responses) {
+		logs[responses[nodeID].Props[propHostname]] = lines
+	}
+	return logs, nil
+}
+
+// GenerateSupportPacket returns the Support Packet files of the other nodes by
+// node id. Each node's files are in a directory named after its hostname.
+func (c *Cluster) GenerateSupportPacket(rctx request.CTX, options *model.SupportPacketOptions) (map[string][]model.FileData, error) {
+	responses, err := c.request(model.ClusterGossipEventRequestGenerateSupportPacket, options)
+	return decodeResponses[[]model.FileData](c, responses), err
+}
+
+func (c *Cluster) GetPluginStatuses() (model.PluginStatuses, *model.AppError) {
+	responses, err := c.request(model.ClusterGossipEventRequestGetPluginStatuses, nil)
+	c.logPartialResponses(model.ClusterGossipEventRequestGetPluginStatuses, err)
+
+	var statuses model.PluginStatuses
+	for _, nodeStatuses := range decodeResponses[model.PluginStatuses](c, responses) {
+		statuses = append(statuses, nodeStatuses...)
+	}
+	return statuses, nil
+}
+
+// WebConnCountForUser returns the number of connections of the user to the
+// other nodes. It fails unless every node responds, as callers rely on the
+// count being accurate.
+func (c *Cluster) WebConnCountForUser(userID string) (int, *model.AppError) {
+	responses, err := c.request(model.ClusterGossipEventRequestWebConnCount, userID)
+	if err != nil {
+		return 0, requestAppError("WebConnCountForUser", err)
+	}
+
+	counts := decodeResponses[int](c, responses)
+	if len(counts) != len(responses) {
+		return 0, model.NewAppError("WebConnCountForUser", "ent.cluster.response.error", nil, "", http.StatusInternalServerError)
+	}
+
+	var total int
+	for _, count := range counts {
+		total += count
+	}
+	return total, nil
+}
+
+// GetWSQueues returns the websocket queues of the connection on the other
+// nodes, by node id.
+func (c *Cluster) GetWSQueues(userID, connectionID string, seqNum int64) (map[string]*model.WSQueues, error) {
+	responses, err := c.request(model.ClusterGossipEventRequestWSQueues, &wsQueuesRequest{
+		UserID:       userID,
+		ConnectionID: connectionID,
+		SeqNum:       seqNum,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	queues := decodeResponses[*model.WSQueues](c, responses)
+	if len(queues) != len(responses) {
+		return nil, errors.New("failed to get the websocket queues from all the nodes of the cluster")
+	}
+	return queues, nil
+}
+
+func requestAppError(where string, err error) *model.AppError {
+	if errors.Is(err, errRequestTimeout) {
+		return model.NewAppError(where, "ent.cluster.timeout.error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return model.NewAppError(where, "ent.cluster.request.error", nil, "", http.StatusInternalServerError).Wrap(err)
+}
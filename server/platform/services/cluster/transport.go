@@ -0,0 +1,42 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package cluster
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// transport carries payloads between the nodes of the cluster over named
+// channels. Every node subscribes to the channel shared by the whole cluster
+// and to its own channel, and payloads published to a channel are delivered
+// to all the nodes subscribed to it, including the publisher.
+type transport interface {
+	// publish sends payload to the nodes subscribed to channel.
+	publish(ctx context.Context, channel string, payload []byte) error
+	// subscribe starts delivering the payloads published to channels to
+	// receive, until close is called.
+	subscribe(channels []string, receive func(payload []byte)) error
+	// close stops the delivery of payloads and releases the resources held by
+	// the transport.
+	close() error
+	// name identifies the transport in logs.
+	name() string
+}
+
+const channelPrefix = "mm_cluster_"
+
+// broadcastChannel returns the channel shared by all the nodes of the
+// cluster called clusterName. Cluster names are hashed to keep channel names
+// short and free of characters that Postgres doesn't accept in identifiers.
+func broadcastChannel(clusterName string) string {
+	hash := sha256.Sum256([]byte(clusterName))
+	return channelPrefix + hex.EncodeToString(hash[:8])
+}
+
+// nodeChannel returns the channel only the node nodeID subscribes to.
+func nodeChannel(clusterName, nodeID string) string {
+	return broadcastChannel(clusterName) + "_" + nodeID
+}
@@ -28,6 +28,9 @@ type SearchEngineInterface interface {
 	IndexPost(post *model.Post, teamId string) *model.AppError
 	SearchPosts(channels model.ChannelList, searchParams []*model.SearchParams, page, perPage int) ([]string, model.PostSearchMatches, *model.AppError)
 	DeletePost(post *model.Post) *model.AppError
+	// DeletePosts deletes the given posts from every post index, for when
+	// their creation time, which determines their index, isn't known.
+	DeletePosts(rctx request.CTX, postIDs []string) *model.AppError
 	DeleteChannelPosts(rctx request.CTX, channelID string) *model.AppError
 	DeleteUserPosts(rctx request.CTX, userID string) *model.AppError
 	// IndexChannel indexes a given channel. The userIDs are only populated
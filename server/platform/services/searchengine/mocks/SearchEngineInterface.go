@@ -157,6 +157,26 @@ func (_m *SearchEngineInterface) DeletePostFiles(rctx request.CTX, postID string
 	return r0
 }
 
+// DeletePosts provides a mock function with given fields: rctx, postIDs
+func (_m *SearchEngineInterface) DeletePosts(rctx request.CTX, postIDs []string) *model.AppError {
+	ret := _m.Called(rctx, postIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeletePosts")
+	}
+
+	var r0 *model.AppError
+	if rf, ok := ret.Get(0).(func(request.CTX, []string) *model.AppError); ok {
+		r0 = rf(rctx, postIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.AppError)
+		}
+	}
+
+	return r0
+}
+
 // DeleteUser provides a mock function with given fields: user
 func (_m *SearchEngineInterface) DeleteUser(user *model.User) *model.AppError {
 	ret := _m.Called(user)
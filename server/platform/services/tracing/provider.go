@@ -0,0 +1,129 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package tracing
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace/noop"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+// shutdownTimeout bounds the time spent exporting the pending spans when tracing is
+// reconfigured or stopped.
+const shutdownTimeout = 5 * time.Second
+
+// Provider installs the global tracer provider and propagator according to the
+// TracingSettings.
+type Provider struct {
+	lock     sync.Mutex
+	settings model.TracingSettings
+	provider *sdktrace.TracerProvider
+	// newExporter creates the exporter of the spans, overridden in tests.
+	newExporter func(settings model.TracingSettings) (sdktrace.SpanExporter, error)
+}
+
+// NewProvider returns a Provider, leaving tracing disabled until configured.
+func NewProvider() *Provider {
+	return &Provider{
+		newExporter: newOTLPExporter,
+	}
+}
+
+func newOTLPExporter(settings model.TracingSettings) (sdktrace.SpanExporter, error) {
+	// The exporter connects lazily, so an unreachable endpoint doesn't fail here.
+	return otlptracehttp.New(context.Background(), otlptracehttp.WithEndpointURL(*settings.OTLPEndpoint))
+}
+
+// Configure applies the settings, replacing the current tracer provider if they changed.
+// The spans pending in the replaced provider are exported first.
+func (p *Provider) Configure(settings model.TracingSettings) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if p.settings.Enable != nil && sameSettings(p.settings, settings) {
+		return nil
+	}
+
+	var provider *sdktrace.TracerProvider
+	if *settings.Enable {
+		exporter, err := p.newExporter(settings)
+		if err != nil {
+			return errors.Wrap(err, "failed to create the trace exporter")
+		}
+
+		provider = sdktrace.NewTracerProvider(
+			sdktrace.WithBatcher(exporter),
+			sdktrace.WithResource(resource.NewWithAttributes(
+				semconv.SchemaURL,
+				semconv.ServiceName(*settings.ServiceName),
+				semconv.ServiceVersion(model.CurrentVersion),
+			)),
+			sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(*settings.SampleRate))),
+		)
+		otel.SetTracerProvider(provider)
+		otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	} else {
+		otel.SetTracerProvider(noop.NewTracerProvider())
+		otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator())
+	}
+
+	previous := p.provider
+	p.provider = provider
+	p.settings = settings
+
+	return shutdownProvider(previous)
+}
+
+func sameSettings(a, b model.TracingSettings) bool {
+	if *a.Enable != *b.Enable {
+		return false
+	}
+
+	return !*a.Enable || (*a.OTLPEndpoint == *b.OTLPEndpoint && *a.ServiceName == *b.ServiceName && *a.SampleRate == *b.SampleRate)
+}
+
+// Shutdown exports the pending spans and disables tracing.
+func (p *Provider) Shutdown() error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if p.provider == nil {
+		return nil
+	}
+
+	otel.SetTracerProvider(noop.NewTracerProvider())
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator())
+
+	previous := p.provider
+	p.provider = nil
+	p.settings = model.TracingSettings{}
+
+	return shutdownProvider(previous)
+}
+
+func shutdownProvider(provider *sdktrace.TracerProvider) error {
+	if provider == nil {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	if err := provider.Shutdown(ctx); err != nil {
+		return errors.Wrap(err, "failed to shut down the tracer provider")
+	}
+
+	return nil
+}
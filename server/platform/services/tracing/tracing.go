@@ -0,0 +1,98 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+// Package tracing records OpenTelemetry spans and exports them to an OTLP endpoint.
+//
+// Spans are started through the global OpenTelemetry tracer provider, which the Provider
+// replaces as the TracingSettings change. While tracing is disabled, the global provider
+// discards every span at a negligible cost, so callers need not check whether tracing
+// is enabled.
+package tracing
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "github.com/mattermost/mattermost/server"
+
+func tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
+
+// Start starts a span, child of the span carried by ctx if any. The returned context
+// carries the new span.
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return tracer().Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// End ends the span, marking it as failed if err is not nil.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// Record records a span for a call that already completed, child of the span carried by
+// ctx if any.
+func Record(ctx context.Context, name string, start, end time.Time, success bool, attrs ...attribute.KeyValue) {
+	_, span := tracer().Start(ctx, name, trace.WithTimestamp(start), trace.WithAttributes(attrs...))
+	if !success {
+		span.SetStatus(codes.Error, "")
+	}
+	span.End(trace.WithTimestamp(end))
+}
+
+// Inject returns the trace context carried by ctx, to pass it on to another process such
+// as a plugin. It returns nil if ctx carries no span.
+func Inject(ctx context.Context) map[string]string {
+	if !trace.SpanContextFromContext(ctx).IsValid() {
+		return nil
+	}
+
+	carrier := propagation.MapCarrier{}
+	otel.GetTextMapPropagator().Inject(ctx, carrier)
+	return carrier
+}
+
+// Extract returns a context carrying the trace context returned by Inject.
+func Extract(traceContext map[string]string) context.Context {
+	return otel.GetTextMapPropagator().Extract(context.Background(), propagation.MapCarrier(traceContext))
+}
+
+// ExtractHTTP returns a context carrying the trace context propagated by the caller of an
+// incoming request, if any.
+func ExtractHTTP(r *http.Request) context.Context {
+	return otel.GetTextMapPropagator().Extract(context.Background(), propagation.HeaderCarrier(r.Header))
+}
+
+// StartHTTPServerSpan starts the span of an incoming request, continuing the trace
+// propagated by the caller if any.
+func StartHTTPServerSpan(r *http.Request, name string) (context.Context, trace.Span) {
+	return tracer().Start(ExtractHTTP(r), name,
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(
+			semconv.HTTPRequestMethodKey.String(r.Method),
+			semconv.URLPath(r.URL.Path),
+		),
+	)
+}
+
+// EndHTTPServerSpan records the status code of the response and ends the span.
+func EndHTTPServerSpan(span trace.Span, statusCode int) {
+	span.SetAttributes(semconv.HTTPResponseStatusCode(statusCode))
+	if statusCode >= http.StatusInternalServerError {
+		span.SetStatus(codes.Error, http.StatusText(statusCode))
+	}
+	span.End()
+}
@@ -0,0 +1,148 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package tracing
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+// inMemoryExporter keeps the exported spans on shutdown, unlike tracetest.InMemoryExporter.
+type inMemoryExporter struct {
+	*tracetest.InMemoryExporter
+}
+
+func (e inMemoryExporter) Shutdown(context.Context) error {
+	return nil
+}
+
+func setupProvider(t *testing.T) (*Provider, *tracetest.InMemoryExporter) {
+	t.Helper()
+
+	exporter := tracetest.NewInMemoryExporter()
+	provider := NewProvider()
+	provider.newExporter = func(model.TracingSettings) (sdktrace.SpanExporter, error) {
+		return inMemoryExporter{exporter}, nil
+	}
+
+	settings := model.TracingSettings{}
+	settings.SetDefaults()
+	settings.Enable = model.NewPointer(true)
+	require.NoError(t, provider.Configure(settings))
+	t.Cleanup(func() {
+		require.NoError(t, provider.Shutdown())
+	})
+
+	return provider, exporter
+}
+
+func spanNames(spans tracetest.SpanStubs) []string {
+	names := make([]string, 0, len(spans))
+	for _, span := range spans {
+		names = append(names, span.Name)
+	}
+	return names
+}
+
+func TestProvider(t *testing.T) {
+	provider, exporter := setupProvider(t)
+
+	ctx, parent := Start(context.Background(), "parent")
+	_, child := Start(ctx, "child")
+	End(child, errors.New("failure"))
+	End(parent, nil)
+
+	// Reconfiguring with the same settings keeps the provider, and its pending spans.
+	settings := provider.settings
+	require.NoError(t, provider.Configure(settings))
+	assert.Empty(t, exporter.GetSpans())
+
+	settings.Enable = model.NewPointer(false)
+	require.NoError(t, provider.Configure(settings))
+
+	spans := exporter.GetSpans()
+	require.Equal(t, []string{"child", "parent"}, spanNames(spans))
+	assert.Equal(t, spans[1].SpanContext.TraceID(), spans[0].SpanContext.TraceID())
+	assert.Equal(t, spans[1].SpanContext.SpanID(), spans[0].Parent.SpanID())
+	assert.Equal(t, codes.Error, spans[0].Status.Code)
+
+	exporter.Reset()
+	_, span := Start(context.Background(), "disabled")
+	assert.False(t, span.IsRecording())
+	span.End()
+}
+
+func TestHTTPPropagation(t *testing.T) {
+	provider, exporter := setupProvider(t)
+
+	var serverSpanContext trace.SpanContext
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, span := StartHTTPServerSpan(r, "handler")
+		serverSpanContext = span.SpanContext()
+		w.WriteHeader(http.StatusTeapot)
+		EndHTTPServerSpan(span, http.StatusTeapot)
+	}))
+	defer server.Close()
+
+	ctx, parent := Start(context.Background(), "parent")
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/path?secret=value", nil)
+	require.NoError(t, err)
+
+	resp, err := (&http.Client{Transport: NewTransport(nil)}).Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	parent.End()
+
+	assert.Empty(t, req.Header.Get("traceparent"), "the original request must not be modified")
+	require.NoError(t, provider.Shutdown())
+
+	spans := exporter.GetSpans()
+	require.Equal(t, []string{"handler", "HTTP GET", "parent"}, spanNames(spans))
+	handler, client := spans[0], spans[1]
+
+	assert.Equal(t, parent.SpanContext().TraceID(), serverSpanContext.TraceID())
+	assert.Equal(t, client.SpanContext.SpanID(), handler.Parent.SpanID())
+	assert.Equal(t, parent.SpanContext().SpanID(), client.Parent.SpanID())
+	assert.Equal(t, codes.Error, client.Status.Code)
+	assert.Equal(t, codes.Unset, handler.Status.Code)
+	for _, attr := range client.Attributes {
+		assert.NotContains(t, attr.Value.Emit(), "secret")
+	}
+}
+
+func TestContextPropagation(t *testing.T) {
+	provider, exporter := setupProvider(t)
+
+	assert.Nil(t, Inject(context.Background()))
+
+	ctx, parent := Start(context.Background(), "parent")
+	traceContext := Inject(ctx)
+	assert.Contains(t, traceContext, "traceparent")
+
+	end := time.Now()
+	Record(Extract(traceContext), "recorded", end.Add(-time.Second), end, false)
+	parent.End()
+	require.NoError(t, provider.Shutdown())
+
+	spans := exporter.GetSpans()
+	require.Equal(t, []string{"recorded", "parent"}, spanNames(spans))
+	recorded := spans[0]
+	assert.Equal(t, parent.SpanContext().TraceID(), recorded.SpanContext.TraceID())
+	assert.Equal(t, parent.SpanContext().SpanID(), recorded.Parent.SpanID())
+	assert.Equal(t, codes.Error, recorded.Status.Code)
+	assert.Equal(t, time.Second, recorded.EndTime.Sub(recorded.StartTime))
+}
@@ -0,0 +1,88 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package tracing
+
+import (
+	"net/http"
+	"net/url"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/mattermost/mattermost/server/public/shared/httpservice"
+)
+
+// Transport is an http.RoundTripper recording a span for every request, and propagating
+// the trace to the server handling it.
+type Transport struct {
+	// Base is the http.RoundTripper actually making the requests. Defaults to http.DefaultTransport.
+	Base http.RoundTripper
+}
+
+// NewTransport returns a Transport making the requests with base.
+func NewTransport(base http.RoundTripper) *Transport {
+	return &Transport{Base: base}
+}
+
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	// The query string and credentials are left out, as they may hold secrets.
+	target := url.URL{Scheme: req.URL.Scheme, Host: req.URL.Host, Path: req.URL.Path}
+	ctx, span := tracer().Start(req.Context(), "HTTP "+req.Method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.HTTPRequestMethodKey.String(req.Method),
+			semconv.URLFull(target.String()),
+			semconv.ServerAddress(req.URL.Hostname()),
+		),
+	)
+	defer span.End()
+
+	// A RoundTripper must not modify the request it is given.
+	req = req.Clone(ctx)
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(req.Header))
+
+	resp, err := base.RoundTrip(req)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return resp, err
+	}
+
+	span.SetAttributes(semconv.HTTPResponseStatusCode(resp.StatusCode))
+	if resp.StatusCode >= http.StatusBadRequest {
+		span.SetStatus(codes.Error, http.StatusText(resp.StatusCode))
+	}
+
+	return resp, nil
+}
+
+type httpService struct {
+	httpservice.HTTPService
+}
+
+// WrapHTTPService returns an HTTPService whose clients and transports record a span for
+// every request.
+func WrapHTTPService(service httpservice.HTTPService) httpservice.HTTPService {
+	return &httpService{service}
+}
+
+func (s *httpService) MakeClient(trustURLs bool) *http.Client {
+	client := s.HTTPService.MakeClient(trustURLs)
+	client.Transport = NewTransport(client.Transport)
+	return client
+}
+
+func (s *httpService) MakeTransport(trustURLs bool) *httpservice.MattermostTransport {
+	transport := s.HTTPService.MakeTransport(trustURLs)
+	transport.Transport = NewTransport(transport.Transport)
+	return transport
+}
@@ -0,0 +1,948 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package filestore
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"io"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Encrypted files start with a header holding the data key of the file,
+// wrapped by a master key:
+//
+//	magic (8) | chunk size (4) | key id length (2) | key id | wrapped key length (2) | wrapped key
+//
+// followed by one segment per WriteFile or AppendFile call. A segment is a
+// sequence of AES-256-GCM sealed chunks of chunk size bytes of plaintext (the
+// last one possibly shorter or empty), followed by a trailer:
+//
+//	plaintext length (8) | segment index (4) | nonce prefix (8)
+//
+// Each chunk is sealed with the nonce prefix of its segment, its index and
+// whether it's the last chunk of the file as nonce, and with the segment
+// index, chunk index and whether it's the last chunk of the segment and of the
+// file as additional data, so that chunks can't be reordered, moved between
+// segments, or cut off the end of a segment or of the file. Segment boundaries
+// are found by walking the trailers back from the end of the file. Appending a
+// segment only re-seals the last chunk of the file, which stops being the
+// final one, under its other nonce.
+const (
+	encryptedFileMagic        = "MMFSENC1"
+	encryptedFixedHeaderSize  = len(encryptedFileMagic) + 4 + 2
+	encryptedChunkSize        = 64 * 1024
+	encryptedDataKeySize      = 32
+	encryptedNoncePrefixSize  = 8
+	encryptedChunkNonceSize   = encryptedNoncePrefixSize + 4
+	encryptedChunkAADSize     = 4 + 4 + 1
+	encryptedTagSize          = 16
+	encryptedTrailerSize      = 8 + 4 + encryptedNoncePrefixSize
+	encryptedRekeyFileSuffix  = ".rekey"
+	encryptedAppendFileSuffix = ".append"
+
+	maxKeyIDLength          = 255
+	maxWrappedKeyLength     = 1024
+	maxEncryptedChunkSize   = 16 * 1024 * 1024
+	maxEncryptedSegmentSize = 1 << 50
+
+	// The top bit of the chunk index in the nonce flags the final chunk of
+	// the file.
+	encryptedFinalChunkFlag   = 1 << 31
+	maxEncryptedSegmentChunks = encryptedFinalChunkFlag
+)
+
+var errNotEncrypted = errors.New("file is not encrypted")
+
+// EncryptedFileBackend wraps a FileBackend to encrypt files at rest with a
+// data key per file, itself wrapped by a master key from a KeyProvider.
+// Files written before encryption was enabled are read as they are, and can
+// be encrypted in place with RekeyFile.
+type EncryptedFileBackend struct {
+	backend   FileBackend
+	keys      KeyProvider
+	chunkSize int
+}
+
+func NewEncryptedFileBackend(backend FileBackend, keys KeyProvider) *EncryptedFileBackend {
+	return &EncryptedFileBackend{
+		backend:   backend,
+		keys:      keys,
+		chunkSize: encryptedChunkSize,
+	}
+}
+
+// Unwrap returns the backend holding the encrypted files.
+func (b *EncryptedFileBackend) Unwrap() FileBackend {
+	return b.backend
+}
+
+type encryptedFileHeader struct {
+	chunkSize  uint32
+	keyID      string
+	wrappedKey []byte
+}
+
+func (h *encryptedFileHeader) marshal() []byte {
+	buf := make([]byte, 0, encryptedFixedHeaderSize+len(h.keyID)+2+len(h.wrappedKey))
+	buf = append(buf, encryptedFileMagic...)
+	buf = binary.BigEndian.AppendUint32(buf, h.chunkSize)
+	buf = binary.BigEndian.AppendUint16(buf, uint16(len(h.keyID)))
+	buf = append(buf, h.keyID...)
+	buf = binary.BigEndian.AppendUint16(buf, uint16(len(h.wrappedKey)))
+	return append(buf, h.wrappedKey...)
+}
+
+// readEncryptedFileHeader reads the header at the start of r, returning
+// errNotEncrypted if r doesn't start with one.
+func readEncryptedFileHeader(r io.Reader) (*encryptedFileHeader, int64, error) {
+	fixed := make([]byte, encryptedFixedHeaderSize)
+	if _, err := io.ReadFull(r, fixed); err != nil {
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			return nil, 0, errNotEncrypted
+		}
+		return nil, 0, err
+	}
+	if string(fixed[:len(encryptedFileMagic)]) != encryptedFileMagic {
+		return nil, 0, errNotEncrypted
+	}
+
+	header := &encryptedFileHeader{
+		chunkSize: binary.BigEndian.Uint32(fixed[len(encryptedFileMagic):]),
+	}
+	if header.chunkSize == 0 || header.chunkSize > maxEncryptedChunkSize {
+		return nil, 0, errors.Errorf("invalid chunk size %d", header.chunkSize)
+	}
+
+	keyID := make([]byte, binary.BigEndian.Uint16(fixed[len(encryptedFileMagic)+4:]))
+	if len(keyID) == 0 || len(keyID) > maxKeyIDLength {
+		return nil, 0, errors.New("invalid key id")
+	}
+	if _, err := io.ReadFull(r, keyID); err != nil {
+		return nil, 0, errors.Wrap(err, "unable to read key id")
+	}
+	header.keyID = string(keyID)
+
+	var wrappedKeyLen [2]byte
+	if _, err := io.ReadFull(r, wrappedKeyLen[:]); err != nil {
+		return nil, 0, errors.Wrap(err, "unable to read wrapped key")
+	}
+	header.wrappedKey = make([]byte, binary.BigEndian.Uint16(wrappedKeyLen[:]))
+	if len(header.wrappedKey) == 0 || len(header.wrappedKey) > maxWrappedKeyLength {
+		return nil, 0, errors.New("invalid wrapped key")
+	}
+	if _, err := io.ReadFull(r, header.wrappedKey); err != nil {
+		return nil, 0, errors.Wrap(err, "unable to read wrapped key")
+	}
+
+	return header, int64(encryptedFixedHeaderSize + len(keyID) + 2 + len(header.wrappedKey)), nil
+}
+
+func newDataKeyAEAD(dataKey []byte) (cipher.AEAD, error) {
+	if len(dataKey) != encryptedDataKeySize {
+		return nil, errors.New("invalid data key")
+	}
+	block, err := aes.NewCipher(dataKey)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// newDataKey generates a data key for a new file, returning its header and
+// the cipher to seal its chunks with.
+func (b *EncryptedFileBackend) newDataKey() (*encryptedFileHeader, cipher.AEAD, error) {
+	dataKey := make([]byte, encryptedDataKeySize)
+	if _, err := rand.Read(dataKey); err != nil {
+		return nil, nil, errors.Wrap(err, "unable to generate data key")
+	}
+
+	keyID := b.keys.ActiveKeyID()
+	wrappedKey, err := b.keys.WrapKey(keyID, dataKey)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "unable to wrap data key")
+	}
+
+	aead, err := newDataKeyAEAD(dataKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &encryptedFileHeader{
+		chunkSize:  uint32(b.chunkSize),
+		keyID:      keyID,
+		wrappedKey: wrappedKey,
+	}, aead, nil
+}
+
+// chunkAAD returns the additional data of a chunk. lastInSegment and
+// lastInFile flag the last chunk of its segment and of the whole file.
+func chunkAAD(segment, chunk uint32, lastInSegment, lastInFile bool) []byte {
+	aad := make([]byte, encryptedChunkAADSize)
+	binary.BigEndian.PutUint32(aad, segment)
+	binary.BigEndian.PutUint32(aad[4:], chunk)
+	if lastInSegment {
+		aad[8] |= 1
+	}
+	if lastInFile {
+		aad[8] |= 2
+	}
+	return aad
+}
+
+// chunkNonce returns the nonce of a chunk. The final chunk of the file gets a
+// nonce of its own, so that it can be sealed again as a non-final one when
+// data is appended to the file without reusing its nonce.
+func chunkNonce(noncePrefix []byte, chunk uint32, final bool) []byte {
+	nonce := make([]byte, encryptedChunkNonceSize)
+	copy(nonce, noncePrefix)
+	if final {
+		chunk |= encryptedFinalChunkFlag
+	}
+	binary.BigEndian.PutUint32(nonce[encryptedNoncePrefixSize:], chunk)
+	return nonce
+}
+
+func segmentTrailer(plainLen int64, segment uint32, noncePrefix []byte) []byte {
+	trailer := make([]byte, 0, encryptedTrailerSize)
+	trailer = binary.BigEndian.AppendUint64(trailer, uint64(plainLen))
+	trailer = binary.BigEndian.AppendUint32(trailer, segment)
+	return append(trailer, noncePrefix...)
+}
+
+// chunkCount returns the number of chunks of a segment with plainLen bytes
+// of plaintext. Empty segments still hold one empty chunk.
+func chunkCount(plainLen, chunkSize int64) int64 {
+	return max((plainLen+chunkSize-1)/chunkSize, 1)
+}
+
+// encryptSegment reads src until EOF and writes it to dst as segment number
+// segment, returning the number of plaintext bytes written. final is whether
+// the segment is the last one of the file.
+func encryptSegment(dst io.Writer, src io.Reader, aead cipher.AEAD, chunkSize int, segment uint32, final bool) (int64, error) {
+	noncePrefix := make([]byte, encryptedNoncePrefixSize)
+	if _, err := rand.Read(noncePrefix); err != nil {
+		return 0, errors.Wrap(err, "unable to generate nonce")
+	}
+
+	readChunk := func(buf []byte) (int, error) {
+		n, err := io.ReadFull(src, buf)
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			err = nil
+		}
+		return n, err
+	}
+
+	cur := make([]byte, chunkSize)
+	next := make([]byte, chunkSize)
+	sealed := make([]byte, 0, chunkSize+aead.Overhead())
+
+	n, err := readChunk(cur)
+	if err != nil {
+		return 0, err
+	}
+
+	var written int64
+	for chunk := uint32(0); ; chunk++ {
+		if chunk == maxEncryptedSegmentChunks {
+			return written, errors.New("too much data for a segment")
+		}
+
+		// A full chunk is only the last one if nothing follows it.
+		var m int
+		last := n < chunkSize
+		if !last {
+			if m, err = readChunk(next); err != nil {
+				return written, err
+			}
+			last = m == 0
+		}
+
+		sealed = aead.Seal(sealed[:0], chunkNonce(noncePrefix, chunk, last && final), cur[:n], chunkAAD(segment, chunk, last, last && final))
+		if _, err = dst.Write(sealed); err != nil {
+			return written, err
+		}
+		written += int64(n)
+
+		if last {
+			break
+		}
+		cur, next = next, cur
+		n = m
+	}
+
+	if _, err := dst.Write(segmentTrailer(written, segment, noncePrefix)); err != nil {
+		return written, err
+	}
+
+	return written, nil
+}
+
+type encryptedSegment struct {
+	index       uint32
+	offset      int64
+	plainOffset int64
+	plainLen    int64
+	noncePrefix []byte
+}
+
+// readSegment reads the trailer of the segment ending at end.
+func readSegment(r io.ReadSeeker, end, headerLen, chunkSize int64) (encryptedSegment, error) {
+	if end-headerLen < encryptedTrailerSize {
+		return encryptedSegment{}, errors.New("corrupted encrypted file")
+	}
+	if _, err := r.Seek(end-encryptedTrailerSize, io.SeekStart); err != nil {
+		return encryptedSegment{}, errors.Wrap(err, "unable to read segment trailer")
+	}
+	trailer := make([]byte, encryptedTrailerSize)
+	if _, err := io.ReadFull(r, trailer); err != nil {
+		return encryptedSegment{}, errors.Wrap(err, "unable to read segment trailer")
+	}
+
+	plainLen := int64(binary.BigEndian.Uint64(trailer))
+	if plainLen < 0 || plainLen > maxEncryptedSegmentSize || chunkCount(plainLen, chunkSize) > maxEncryptedSegmentChunks {
+		return encryptedSegment{}, errors.New("corrupted encrypted file")
+	}
+	offset := end - encryptedTrailerSize - plainLen - chunkCount(plainLen, chunkSize)*encryptedTagSize
+	if offset < headerLen {
+		return encryptedSegment{}, errors.New("corrupted encrypted file")
+	}
+
+	return encryptedSegment{
+		index:       binary.BigEndian.Uint32(trailer[8:]),
+		offset:      offset,
+		plainLen:    plainLen,
+		noncePrefix: bytes.Clone(trailer[12:]),
+	}, nil
+}
+
+type encryptedFile struct {
+	header    *encryptedFileHeader
+	headerLen int64
+	aead      cipher.AEAD
+	segments  []encryptedSegment
+	size      int64
+}
+
+// openEncryptedFileHeader reads the header of the file in r and unwraps its
+// data key, returning errNotEncrypted if it's not encrypted.
+func (b *EncryptedFileBackend) openEncryptedFileHeader(r io.Reader) (*encryptedFileHeader, int64, cipher.AEAD, error) {
+	header, headerLen, err := readEncryptedFileHeader(r)
+	if err != nil {
+		return nil, 0, nil, err
+	}
+
+	dataKey, err := b.keys.UnwrapKey(header.keyID, header.wrappedKey)
+	if err != nil {
+		return nil, 0, nil, err
+	}
+	aead, err := newDataKeyAEAD(dataKey)
+	if err != nil {
+		return nil, 0, nil, err
+	}
+
+	return header, headerLen, aead, nil
+}
+
+// openEncryptedFile reads the header and segment table of the file in r,
+// returning errNotEncrypted if it's not encrypted.
+func (b *EncryptedFileBackend) openEncryptedFile(r io.ReadSeeker) (*encryptedFile, error) {
+	header, headerLen, aead, err := b.openEncryptedFileHeader(r)
+	if err != nil {
+		return nil, err
+	}
+
+	end, err := r.Seek(0, io.SeekEnd)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to find the end of the file")
+	}
+
+	// Even empty files have a segment, so a file cut down to its header has
+	// lost its contents.
+	var segments []encryptedSegment
+	for pos := end; pos > headerLen || len(segments) == 0; {
+		segment, err := readSegment(r, pos, headerLen, int64(header.chunkSize))
+		if err != nil {
+			return nil, err
+		}
+		if len(segments) > 0 && segment.index != segments[len(segments)-1].index-1 {
+			return nil, errors.New("corrupted encrypted file")
+		}
+		segments = append(segments, segment)
+		pos = segment.offset
+	}
+	if segments[len(segments)-1].index != 0 {
+		return nil, errors.New("corrupted encrypted file")
+	}
+
+	file := &encryptedFile{
+		header:    header,
+		headerLen: headerLen,
+		aead:      aead,
+		segments:  make([]encryptedSegment, 0, len(segments)),
+	}
+	for i := len(segments) - 1; i >= 0; i-- {
+		segment := segments[i]
+		segment.plainOffset = file.size
+		file.size += segment.plainLen
+		file.segments = append(file.segments, segment)
+	}
+
+	return file, nil
+}
+
+// encryptedReader decrypts a file one chunk at a time, keeping the last
+// decrypted chunk around so that sequential and nearby reads don't need to
+// decrypt it again. The end of the file is only reported once its last chunk
+// has been opened, so that a file cut off at a segment boundary isn't taken
+// for a shorter one.
+type encryptedReader struct {
+	r          ReadCloseSeeker
+	file       *encryptedFile
+	pos        int64
+	sealed     []byte
+	chunk      []byte
+	chunkStart int64
+	hasChunk   bool
+	hasFinal   bool
+}
+
+func (r *encryptedReader) Read(p []byte) (int, error) {
+	if r.pos >= r.file.size {
+		if !r.hasFinal {
+			last := len(r.file.segments) - 1
+			if err := r.loadChunk(last, chunkCount(r.file.segments[last].plainLen, int64(r.file.header.chunkSize))-1); err != nil {
+				return 0, err
+			}
+		}
+		return 0, io.EOF
+	}
+
+	if !r.hasChunk || r.pos < r.chunkStart || r.pos >= r.chunkStart+int64(len(r.chunk)) {
+		segments := r.file.segments
+		index := sort.Search(len(segments), func(i int) bool {
+			return segments[i].plainOffset+segments[i].plainLen > r.pos
+		})
+		if err := r.loadChunk(index, (r.pos-segments[index].plainOffset)/int64(r.file.header.chunkSize)); err != nil {
+			return 0, err
+		}
+	}
+
+	n := copy(p, r.chunk[r.pos-r.chunkStart:])
+	r.pos += int64(n)
+	return n, nil
+}
+
+// loadChunk decrypts the given chunk of the segment at index.
+func (r *encryptedReader) loadChunk(index int, chunk int64) error {
+	segments := r.file.segments
+	segment := segments[index]
+
+	chunkSize := int64(r.file.header.chunkSize)
+	plainLen := min(chunkSize, segment.plainLen-chunk*chunkSize)
+
+	if _, err := r.r.Seek(segment.offset+chunk*(chunkSize+encryptedTagSize), io.SeekStart); err != nil {
+		return errors.Wrap(err, "unable to seek to the encrypted chunk")
+	}
+	sealed := r.sealed[:plainLen+encryptedTagSize]
+	if _, err := io.ReadFull(r.r, sealed); err != nil {
+		return errors.Wrap(err, "unable to read the encrypted chunk")
+	}
+
+	last := chunk == chunkCount(segment.plainLen, chunkSize)-1
+	final := last && index == len(segments)-1
+	plain, err := r.file.aead.Open(r.chunk[:0], chunkNonce(segment.noncePrefix, uint32(chunk), final), sealed, chunkAAD(segment.index, uint32(chunk), last, final))
+	if err != nil {
+		r.hasChunk = false
+		return errors.Wrap(err, "unable to decrypt the file")
+	}
+
+	r.chunk = plain
+	r.chunkStart = segment.plainOffset + chunk*chunkSize
+	r.hasChunk = true
+	r.hasFinal = r.hasFinal || final
+	return nil
+}
+
+func (r *encryptedReader) Seek(offset int64, whence int) (int64, error) {
+	var pos int64
+	switch whence {
+	case io.SeekStart:
+		pos = offset
+	case io.SeekCurrent:
+		pos = r.pos + offset
+	case io.SeekEnd:
+		pos = r.file.size + offset
+	default:
+		return 0, errors.New("invalid whence")
+	}
+
+	if pos < 0 {
+		return 0, errors.New("negative position")
+	}
+
+	r.pos = pos
+	return pos, nil
+}
+
+func (r *encryptedReader) Close() error {
+	return r.r.Close()
+}
+
+func (b *EncryptedFileBackend) DriverName() string {
+	return b.backend.DriverName()
+}
+
+func (b *EncryptedFileBackend) TestConnection() error {
+	return b.backend.TestConnection()
+}
+
+func (b *EncryptedFileBackend) Reader(path string) (ReadCloseSeeker, error) {
+	r, err := b.backend.Reader(path)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := b.openEncryptedFile(r)
+	if err == errNotEncrypted {
+		if _, err = r.Seek(0, io.SeekStart); err != nil {
+			r.Close()
+			return nil, errors.Wrapf(err, "unable to read file %s", path)
+		}
+		return r, nil
+	} else if err != nil {
+		r.Close()
+		return nil, errors.Wrapf(err, "unable to open encrypted file %s", path)
+	}
+
+	return newEncryptedReader(r, file), nil
+}
+
+func newEncryptedReader(r ReadCloseSeeker, file *encryptedFile) *encryptedReader {
+	return &encryptedReader{
+		r:      r,
+		file:   file,
+		sealed: make([]byte, file.header.chunkSize+encryptedTagSize),
+		chunk:  make([]byte, 0, file.header.chunkSize),
+	}
+}
+
+func (b *EncryptedFileBackend) ReadFile(path string) ([]byte, error) {
+	r, err := b.Reader(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to read file %s", path)
+	}
+	defer r.Close()
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to read file %s", path)
+	}
+	return data, nil
+}
+
+func (b *EncryptedFileBackend) FileExists(path string) (bool, error) {
+	return b.backend.FileExists(path)
+}
+
+func (b *EncryptedFileBackend) FileSize(path string) (int64, error) {
+	r, err := b.backend.Reader(path)
+	if err != nil {
+		return 0, errors.Wrapf(err, "unable to get file size for %s", path)
+	}
+	defer r.Close()
+
+	file, err := b.openEncryptedFile(r)
+	if err == errNotEncrypted {
+		return b.backend.FileSize(path)
+	} else if err != nil {
+		return 0, errors.Wrapf(err, "unable to get file size for %s", path)
+	}
+	return file.size, nil
+}
+
+// CopyFile and MoveFile don't need to re-encrypt anything, as data keys
+// aren't bound to the path of the file.
+func (b *EncryptedFileBackend) CopyFile(oldPath, newPath string) error {
+	return b.backend.CopyFile(oldPath, newPath)
+}
+
+func (b *EncryptedFileBackend) MoveFile(oldPath, newPath string) error {
+	return b.backend.MoveFile(oldPath, newPath)
+}
+
+func (b *EncryptedFileBackend) WriteFile(fr io.Reader, path string) (int64, error) {
+	return b.writeFile(context.Background(), fr, path, b.backend.WriteFile)
+}
+
+func (b *EncryptedFileBackend) WriteFileContext(ctx context.Context, fr io.Reader, path string) (int64, error) {
+	return b.writeFile(ctx, fr, path, func(r io.Reader, path string) (int64, error) {
+		return TryWriteFileContext(ctx, b.backend, r, path)
+	})
+}
+
+func (b *EncryptedFileBackend) writeFile(ctx context.Context, fr io.Reader, path string, write func(io.Reader, string) (int64, error)) (int64, error) {
+	header, aead, err := b.newDataKey()
+	if err != nil {
+		return 0, errors.Wrapf(err, "unable to encrypt the file %s", path)
+	}
+
+	return b.writeEncrypted(ctx, path, write, func(w io.Writer) (int64, error) {
+		if _, err := w.Write(header.marshal()); err != nil {
+			return 0, err
+		}
+		return encryptSegment(w, fr, aead, b.chunkSize, 0, true)
+	})
+}
+
+// AppendFile adds fr to the file at path as a new segment. Only the last
+// chunk of the file is written again, as it stops being the final one, along
+// with the new segment.
+func (b *EncryptedFileBackend) AppendFile(fr io.Reader, path string) (int64, error) {
+	modTime, err := b.backend.FileModTime(path)
+	if err != nil {
+		return 0, errors.Wrapf(err, "unable to find the file %s to append the data", path)
+	}
+
+	r, err := b.backend.Reader(path)
+	if err != nil {
+		return 0, errors.Wrapf(err, "unable to find the file %s to append the data", path)
+	}
+	defer r.Close()
+
+	header, headerLen, aead, err := b.openEncryptedFileHeader(r)
+	if err == errNotEncrypted {
+		// Keep appending in plaintext to files, like upload sessions, that
+		// were started before encryption was enabled.
+		return b.backend.AppendFile(fr, path)
+	} else if err != nil {
+		return 0, errors.Wrapf(err, "unable to open encrypted file %s to append the data", path)
+	}
+
+	end, err := r.Seek(0, io.SeekEnd)
+	if err != nil {
+		return 0, errors.Wrapf(err, "unable to find the end of the file %s to append the data", path)
+	}
+	chunkSize := int64(header.chunkSize)
+	last, err := readSegment(r, end, headerLen, chunkSize)
+	if err != nil {
+		return 0, errors.Wrapf(err, "unable to open encrypted file %s to append the data", path)
+	}
+
+	chunk := chunkCount(last.plainLen, chunkSize) - 1
+	chunkOffset := last.offset + chunk*(chunkSize+encryptedTagSize)
+	if _, err = r.Seek(chunkOffset, io.SeekStart); err != nil {
+		return 0, errors.Wrapf(err, "unable to read the last chunk of the file %s", path)
+	}
+	sealed := make([]byte, end-encryptedTrailerSize-chunkOffset)
+	if _, err = io.ReadFull(r, sealed); err != nil {
+		return 0, errors.Wrapf(err, "unable to read the last chunk of the file %s", path)
+	}
+	plain, err := aead.Open(nil, chunkNonce(last.noncePrefix, uint32(chunk), true), sealed, chunkAAD(last.index, uint32(chunk), true, true))
+	if err != nil {
+		return 0, errors.Wrapf(err, "unable to decrypt the last chunk of the file %s", path)
+	}
+
+	encrypt := func(w io.Writer) (int64, error) {
+		sealed = aead.Seal(sealed[:0], chunkNonce(last.noncePrefix, uint32(chunk), false), plain, chunkAAD(last.index, uint32(chunk), true, false))
+		if _, err := w.Write(append(sealed, segmentTrailer(last.plainLen, last.index, last.noncePrefix)...)); err != nil {
+			return 0, err
+		}
+		return encryptSegment(w, fr, aead, int(chunkSize), last.index+1, true)
+	}
+
+	type tailWriter interface {
+		writeFileTail(fr io.Reader, path string, offset int64) (int64, error)
+	}
+
+	if tw, ok := b.backend.(tailWriter); ok {
+		return b.writeEncrypted(context.Background(), path, func(r io.Reader, path string) (int64, error) {
+			return tw.writeFileTail(r, path, chunkOffset)
+		}, encrypt)
+	}
+
+	// Backends that can't replace the end of a file get it written again
+	// next to it, before replacing it, unless it's modified in the meantime.
+	tmpPath := path + encryptedAppendFileSuffix
+	written, err := b.writeEncrypted(context.Background(), tmpPath, b.backend.WriteFile, func(w io.Writer) (int64, error) {
+		if _, err := r.Seek(0, io.SeekStart); err != nil {
+			return 0, err
+		}
+		if _, err := io.CopyN(w, r, chunkOffset); err != nil {
+			return 0, err
+		}
+		return encrypt(w)
+	})
+	if err != nil {
+		b.backend.RemoveFile(tmpPath)
+		return 0, err
+	}
+
+	if newModTime, modErr := b.backend.FileModTime(path); modErr != nil || !newModTime.Equal(modTime) {
+		b.backend.RemoveFile(tmpPath)
+		return 0, errors.Errorf("file %s was modified while data was appended to it", path)
+	}
+
+	if err = b.backend.MoveFile(tmpPath, path); err != nil {
+		b.backend.RemoveFile(tmpPath)
+		return 0, errors.Wrapf(err, "unable to replace the file %s to append the data", path)
+	}
+
+	return written, nil
+}
+
+// writeEncrypted streams what encrypt writes to write, returning the number
+// of plaintext bytes encrypt reports. The stream is aborted if ctx is done
+// before it ends.
+func (b *EncryptedFileBackend) writeEncrypted(ctx context.Context, path string, write func(io.Reader, string) (int64, error), encrypt func(w io.Writer) (int64, error)) (int64, error) {
+	type result struct {
+		written int64
+		err     error
+	}
+
+	pr, pw := io.Pipe()
+	done := make(chan result, 1)
+	go func() {
+		var res result
+		res.written, res.err = encrypt(pw)
+		pw.CloseWithError(res.err)
+		done <- res
+	}()
+
+	if ctx.Done() != nil {
+		finished := make(chan struct{})
+		defer close(finished)
+		go func() {
+			select {
+			case <-ctx.Done():
+				pr.CloseWithError(ctx.Err())
+			case <-finished:
+			}
+		}()
+	}
+
+	if _, err := write(pr, path); err != nil {
+		pr.CloseWithError(err)
+		return 0, err
+	}
+
+	res := <-done
+	if res.err != nil {
+		return 0, errors.Wrapf(res.err, "unable to encrypt the file %s", path)
+	}
+	return res.written, nil
+}
+
+func (b *EncryptedFileBackend) RemoveFile(path string) error {
+	return b.backend.RemoveFile(path)
+}
+
+func (b *EncryptedFileBackend) FileModTime(path string) (time.Time, error) {
+	return b.backend.FileModTime(path)
+}
+
+func (b *EncryptedFileBackend) ListDirectory(path string) ([]string, error) {
+	return b.backend.ListDirectory(path)
+}
+
+func (b *EncryptedFileBackend) ListDirectoryRecursively(path string) ([]string, error) {
+	return b.backend.ListDirectoryRecursively(path)
+}
+
+func (b *EncryptedFileBackend) listDirectoryRecursivelyAfter(path, after string, limit int) ([]string, error) {
+	return ListDirectoryRecursivelyAfter(b.backend, path, after, limit)
+}
+
+func (b *EncryptedFileBackend) RemoveDirectory(path string) error {
+	return b.backend.RemoveDirectory(path)
+}
+
+// isDirectory reports whether path is a directory, for backends that can tell
+// directories and missing files apart. Other backends only have files, so
+// anything that isn't one is treated as a possibly empty directory.
+func (b *EncryptedFileBackend) isDirectory(path string) (bool, error) {
+	type directoryChecker interface {
+		isDirectory(path string) (bool, error)
+	}
+
+	if dc, ok := b.backend.(directoryChecker); ok {
+		return dc.isDirectory(path)
+	}
+
+	exists, err := b.backend.FileExists(path)
+	if err != nil {
+		return false, err
+	}
+	return !exists, nil
+}
+
+// ZipReader will create a zip of path. If path is a single file, it will zip the single file.
+// If deflate is true, the contents will be compressed. It will stream the zip to io.ReadCloser.
+func (b *EncryptedFileBackend) ZipReader(path string, deflate bool) (io.ReadCloser, error) {
+	deflateMethod := zip.Store
+	if deflate {
+		deflateMethod = zip.Deflate
+	}
+
+	isDir, err := b.isDirectory(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to stat path %s", path)
+	}
+
+	baseDir := filepath.Dir(path)
+	files := []string{path}
+	if isDir {
+		baseDir = path
+		if files, err = b.backend.ListDirectoryRecursively(path); err != nil {
+			return nil, errors.Wrapf(err, "unable to list the directory %s", path)
+		}
+	}
+
+	pr, pw := io.Pipe()
+
+	go func() {
+		defer pw.Close()
+
+		zipWriter := zip.NewWriter(pw)
+		defer zipWriter.Close()
+
+		for _, file := range files {
+			if err := b.copyFileToZipWriter(zipWriter, file, baseDir, deflateMethod); err != nil {
+				pw.CloseWithError(err)
+				return
+			}
+		}
+	}()
+
+	return pr, nil
+}
+
+func (b *EncryptedFileBackend) copyFileToZipWriter(zipWriter *zip.Writer, path, baseDir string, deflateMethod uint16) error {
+	relPath, err := filepath.Rel(baseDir, path)
+	if err != nil {
+		return errors.Wrapf(err, "unable to get relative path for %s", path)
+	}
+
+	modTime, err := b.backend.FileModTime(path)
+	if err != nil {
+		return errors.Wrapf(err, "unable to get modification time for file %s", path)
+	}
+
+	header := &zip.FileHeader{
+		Name:     filepath.ToSlash(relPath),
+		Method:   deflateMethod,
+		Modified: modTime,
+	}
+	header.SetMode(0644) // rw-r--r-- permissions
+
+	writer, err := zipWriter.CreateHeader(header)
+	if err != nil {
+		return errors.Wrapf(err, "unable to create zip entry for %s", relPath)
+	}
+
+	r, err := b.Reader(path)
+	if err != nil {
+		return errors.Wrapf(err, "unable to open file %s", path)
+	}
+	defer r.Close()
+
+	if _, err := io.Copy(writer, r); err != nil {
+		return errors.Wrapf(err, "unable to copy file content for %s", relPath)
+	}
+
+	return nil
+}
+
+// IsEncryptionTempFile returns whether path is a temporary file left behind
+// by RekeyFile or AppendFile.
+func IsEncryptionTempFile(path string) bool {
+	ext := filepath.Ext(path)
+	return ext == encryptedRekeyFileSuffix || ext == encryptedAppendFileSuffix
+}
+
+// RekeyFile makes sure that the file at path is encrypted with a data key
+// wrapped by the active master key. Plaintext files get encrypted, while
+// files encrypted under another master key only get their data key
+// re-wrapped, leaving their contents as they are. It returns whether the file
+// had to be rewritten.
+//
+// The new version of the file is written next to it before replacing it, and
+// the file is left untouched if it's modified in the meantime.
+func (b *EncryptedFileBackend) RekeyFile(path string) (bool, error) {
+	modTime, err := b.backend.FileModTime(path)
+	if err != nil {
+		return false, errors.Wrapf(err, "unable to get modification time for file %s", path)
+	}
+
+	r, err := b.backend.Reader(path)
+	if err != nil {
+		return false, errors.Wrapf(err, "unable to open file %s", path)
+	}
+	defer r.Close()
+
+	tmpPath := path + encryptedRekeyFileSuffix
+	file, err := b.openEncryptedFile(r)
+	switch {
+	case err == errNotEncrypted:
+		if _, err = r.Seek(0, io.SeekStart); err != nil {
+			return false, errors.Wrapf(err, "unable to read file %s", path)
+		}
+		_, err = b.writeFile(context.Background(), r, tmpPath, b.backend.WriteFile)
+	case err != nil:
+		return false, errors.Wrapf(err, "unable to open encrypted file %s", path)
+	case file.header.keyID == b.keys.ActiveKeyID():
+		return false, nil
+	default:
+		err = b.rewrapDataKey(r, file, tmpPath)
+	}
+	if err != nil {
+		b.backend.RemoveFile(tmpPath)
+		return false, errors.Wrapf(err, "unable to write the re-keyed file %s", path)
+	}
+
+	if newModTime, modErr := b.backend.FileModTime(path); modErr != nil || !newModTime.Equal(modTime) {
+		b.backend.RemoveFile(tmpPath)
+		return false, errors.Errorf("file %s was modified while being re-keyed", path)
+	}
+
+	if err = b.backend.MoveFile(tmpPath, path); err != nil {
+		b.backend.RemoveFile(tmpPath)
+		return false, errors.Wrapf(err, "unable to replace the file %s with its re-keyed version", path)
+	}
+
+	return true, nil
+}
+
+// rewrapDataKey writes the file in r to path with its data key wrapped by the
+// active master key, copying its segments verbatim.
+func (b *EncryptedFileBackend) rewrapDataKey(r io.ReadSeeker, file *encryptedFile, path string) error {
+	dataKey, err := b.keys.UnwrapKey(file.header.keyID, file.header.wrappedKey)
+	if err != nil {
+		return err
+	}
+
+	header := &encryptedFileHeader{
+		chunkSize: file.header.chunkSize,
+		keyID:     b.keys.ActiveKeyID(),
+	}
+	if header.wrappedKey, err = b.keys.WrapKey(header.keyID, dataKey); err != nil {
+		return err
+	}
+
+	if _, err = r.Seek(file.headerLen, io.SeekStart); err != nil {
+		return err
+	}
+
+	_, err = b.backend.WriteFile(io.MultiReader(bytes.NewReader(header.marshal()), r), path)
+	return err
+}
@@ -0,0 +1,343 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package filestore
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"io"
+	mrand "math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func newTestMasterKey(t *testing.T) string {
+	key := make([]byte, model.FileEncryptionMasterKeyLength)
+	_, err := rand.Read(key)
+	require.NoError(t, err)
+	return base64.StdEncoding.EncodeToString(key)
+}
+
+func newTestEncryptedFileBackend(t *testing.T, dir string, masterKey string, previousMasterKeys ...string) *EncryptedFileBackend {
+	keys, err := NewConfigKeyProvider(masterKey, previousMasterKeys)
+	require.NoError(t, err)
+	return NewEncryptedFileBackend(&LocalFileBackend{directory: dir}, keys)
+}
+
+func TestEncryptedLocalFileBackendTestSuite(t *testing.T) {
+	suite.Run(t, &FileBackendTestSuite{
+		settings: FileBackendSettings{
+			DriverName: driverLocal,
+			Directory:  t.TempDir(),
+			Encryption: EncryptionSettings{
+				Enable:      true,
+				KeyProvider: model.FileEncryptionKeyProviderConfig,
+				MasterKey:   newTestMasterKey(t),
+			},
+		},
+	})
+}
+
+func TestEncryptedFileBackend(t *testing.T) {
+	dir := t.TempDir()
+	backend := newTestEncryptedFileBackend(t, dir, newTestMasterKey(t))
+	// Small chunks make it cheap to cover chunk and segment boundaries.
+	backend.chunkSize = 16
+
+	t.Run("files are encrypted at rest", func(t *testing.T) {
+		data := []byte("some very secret attachment content")
+		written, err := backend.WriteFile(bytes.NewReader(data), "secret.txt")
+		require.NoError(t, err)
+		assert.EqualValues(t, len(data), written)
+
+		raw, err := os.ReadFile(filepath.Join(dir, "secret.txt"))
+		require.NoError(t, err)
+		assert.True(t, bytes.HasPrefix(raw, []byte(encryptedFileMagic)))
+		assert.NotContains(t, string(raw), "secret")
+
+		read, err := backend.ReadFile("secret.txt")
+		require.NoError(t, err)
+		assert.Equal(t, data, read)
+	})
+
+	t.Run("empty files", func(t *testing.T) {
+		_, err := backend.WriteFile(bytes.NewReader(nil), "empty.txt")
+		require.NoError(t, err)
+
+		read, err := backend.ReadFile("empty.txt")
+		require.NoError(t, err)
+		assert.Empty(t, read)
+
+		size, err := backend.FileSize("empty.txt")
+		require.NoError(t, err)
+		assert.Zero(t, size)
+	})
+
+	// Backends that can't replace the end of a file get it written again.
+	type plainBackend struct{ FileBackend }
+	rewriting := &EncryptedFileBackend{backend: plainBackend{backend.backend}, keys: backend.keys, chunkSize: backend.chunkSize}
+
+	for name, backend := range map[string]*EncryptedFileBackend{"in place": backend, "rewritten": rewriting} {
+		t.Run("append and seek across chunks and segments "+name, func(t *testing.T) {
+			var expected []byte
+			for i, size := range []int{100, 33, 0, 16, 1} {
+				data := make([]byte, size)
+				_, err := rand.Read(data)
+				require.NoError(t, err)
+				expected = append(expected, data...)
+
+				before, _ := os.ReadFile(filepath.Join(dir, "appended.bin"))
+				var written int64
+				if i == 0 {
+					written, err = backend.WriteFile(bytes.NewReader(data), "appended.bin")
+				} else {
+					written, err = backend.AppendFile(bytes.NewReader(data), "appended.bin")
+				}
+				require.NoError(t, err)
+				assert.EqualValues(t, size, written)
+
+				// Only the last chunk and trailer of the file are written
+				// again on append.
+				if i > 0 {
+					after, err := os.ReadFile(filepath.Join(dir, "appended.bin"))
+					require.NoError(t, err)
+					kept := len(before) - encryptedTrailerSize - backend.chunkSize - encryptedTagSize
+					assert.Equal(t, before[:kept], after[:kept])
+				}
+			}
+			exists, err := backend.FileExists("appended.bin" + encryptedAppendFileSuffix)
+			require.NoError(t, err)
+			assert.False(t, exists)
+
+			size, err := backend.FileSize("appended.bin")
+			require.NoError(t, err)
+			assert.EqualValues(t, len(expected), size)
+
+			read, err := backend.ReadFile("appended.bin")
+			require.NoError(t, err)
+			assert.Equal(t, expected, read)
+
+			r, err := backend.Reader("appended.bin")
+			require.NoError(t, err)
+			defer r.Close()
+
+			for range 50 {
+				offset := mrand.Int63n(int64(len(expected)))
+				length := mrand.Intn(40) + 1
+
+				pos, err := r.Seek(offset, io.SeekStart)
+				require.NoError(t, err)
+				require.Equal(t, offset, pos)
+
+				buf := make([]byte, length)
+				n, err := io.ReadFull(r, buf)
+				if err != io.ErrUnexpectedEOF {
+					require.NoError(t, err)
+				}
+				assert.Equal(t, expected[offset:offset+int64(n)], buf[:n])
+			}
+
+			pos, err := r.Seek(-5, io.SeekEnd)
+			require.NoError(t, err)
+			assert.EqualValues(t, len(expected)-5, pos)
+			tail, err := io.ReadAll(r)
+			require.NoError(t, err)
+			assert.Equal(t, expected[len(expected)-5:], tail)
+		})
+	}
+
+	t.Run("tampering is detected", func(t *testing.T) {
+		_, err := backend.WriteFile(bytes.NewReader(bytes.Repeat([]byte("a"), 64)), "tampered.txt")
+		require.NoError(t, err)
+
+		path := filepath.Join(dir, "tampered.txt")
+		raw, err := os.ReadFile(path)
+		require.NoError(t, err)
+		raw[len(raw)-encryptedTrailerSize-1] ^= 0xff
+		require.NoError(t, os.WriteFile(path, raw, 0600))
+
+		_, err = backend.ReadFile("tampered.txt")
+		assert.Error(t, err)
+	})
+
+	t.Run("truncation is detected", func(t *testing.T) {
+		_, err := backend.WriteFile(bytes.NewReader(bytes.Repeat([]byte("a"), 40)), "truncated.txt")
+		require.NoError(t, err)
+		_, err = backend.AppendFile(bytes.NewReader(bytes.Repeat([]byte("b"), 20)), "truncated.txt")
+		require.NoError(t, err)
+		exists, err := backend.FileExists("truncated.txt" + encryptedAppendFileSuffix)
+		require.NoError(t, err)
+		assert.False(t, exists)
+
+		path := filepath.Join(dir, "truncated.txt")
+		f, err := os.Open(path)
+		require.NoError(t, err)
+		file, err := backend.openEncryptedFile(f)
+		require.NoError(t, f.Close())
+		require.NoError(t, err)
+		require.Len(t, file.segments, 2)
+
+		// Cutting the last segment off leaves a well formed file whose last
+		// segment wasn't sealed as the final one.
+		raw, err := os.ReadFile(path)
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(path, raw[:file.segments[1].offset], 0600))
+
+		_, err = backend.ReadFile("truncated.txt")
+		assert.Error(t, err)
+
+		// Skipping to the end still checks that the file ends there.
+		r, err := backend.Reader("truncated.txt")
+		require.NoError(t, err)
+		defer r.Close()
+		_, err = r.Seek(0, io.SeekEnd)
+		require.NoError(t, err)
+		_, err = r.Read(make([]byte, 1))
+		assert.Error(t, err)
+		assert.NotErrorIs(t, err, io.EOF)
+	})
+
+	t.Run("a file cut down to its header is rejected", func(t *testing.T) {
+		_, err := backend.WriteFile(bytes.NewReader(nil), "header.txt")
+		require.NoError(t, err)
+
+		path := filepath.Join(dir, "header.txt")
+		raw, err := os.ReadFile(path)
+		require.NoError(t, err)
+		_, headerLen, err := readEncryptedFileHeader(bytes.NewReader(raw))
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(path, raw[:headerLen], 0600))
+
+		_, err = backend.ReadFile("header.txt")
+		assert.Error(t, err)
+		_, err = backend.FileSize("header.txt")
+		assert.Error(t, err)
+		_, err = backend.AppendFile(bytes.NewReader([]byte("data")), "header.txt")
+		assert.Error(t, err)
+	})
+
+	t.Run("plaintext files are read and appended as they are", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "plain.txt"), []byte("hello"), 0600))
+
+		_, err := backend.AppendFile(bytes.NewReader([]byte(" world")), "plain.txt")
+		require.NoError(t, err)
+
+		raw, err := os.ReadFile(filepath.Join(dir, "plain.txt"))
+		require.NoError(t, err)
+		assert.Equal(t, "hello world", string(raw))
+
+		read, err := backend.ReadFile("plain.txt")
+		require.NoError(t, err)
+		assert.Equal(t, "hello world", string(read))
+
+		size, err := backend.FileSize("plain.txt")
+		require.NoError(t, err)
+		assert.EqualValues(t, 11, size)
+	})
+
+	t.Run("copied and moved files stay readable", func(t *testing.T) {
+		require.NoError(t, backend.CopyFile("secret.txt", "copies/secret.txt"))
+		require.NoError(t, backend.MoveFile("copies/secret.txt", "moved/secret.txt"))
+
+		read, err := backend.ReadFile("moved/secret.txt")
+		require.NoError(t, err)
+		assert.Equal(t, "some very secret attachment content", string(read))
+	})
+
+	t.Run("unknown master key", func(t *testing.T) {
+		other := newTestEncryptedFileBackend(t, dir, newTestMasterKey(t))
+		_, err := other.ReadFile("secret.txt")
+		assert.Error(t, err)
+	})
+}
+
+func TestEncryptedFileBackendRekeyFile(t *testing.T) {
+	dir := t.TempDir()
+	oldKey := newTestMasterKey(t)
+	newKey := newTestMasterKey(t)
+
+	oldBackend := newTestEncryptedFileBackend(t, dir, oldKey)
+	_, err := oldBackend.WriteFile(bytes.NewReader([]byte("encrypted with the old key")), "old.txt")
+	require.NoError(t, err)
+	_, err = oldBackend.AppendFile(bytes.NewReader([]byte(" and appended")), "old.txt")
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "plain.txt"), []byte("not encrypted yet"), 0600))
+
+	backend := newTestEncryptedFileBackend(t, dir, newKey, oldKey)
+
+	changed, err := backend.RekeyFile("old.txt")
+	require.NoError(t, err)
+	assert.True(t, changed)
+
+	changed, err = backend.RekeyFile("plain.txt")
+	require.NoError(t, err)
+	assert.True(t, changed)
+
+	raw, err := os.ReadFile(filepath.Join(dir, "plain.txt"))
+	require.NoError(t, err)
+	assert.True(t, bytes.HasPrefix(raw, []byte(encryptedFileMagic)))
+
+	changed, err = backend.RekeyFile("old.txt")
+	require.NoError(t, err)
+	assert.False(t, changed, "files under the active key are left alone")
+
+	files, err := backend.ListDirectoryRecursively("")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"old.txt", "plain.txt"}, files)
+
+	// The old key is no longer needed to read any of the files.
+	newOnly := newTestEncryptedFileBackend(t, dir, newKey)
+	read, err := newOnly.ReadFile("old.txt")
+	require.NoError(t, err)
+	assert.Equal(t, "encrypted with the old key and appended", string(read))
+
+	read, err = newOnly.ReadFile("plain.txt")
+	require.NoError(t, err)
+	assert.Equal(t, "not encrypted yet", string(read))
+}
+
+func TestLocalKMSKeyProvider(t *testing.T) {
+	keyringFile := filepath.Join(t.TempDir(), "keyring.json")
+
+	t.Run("valid keyring", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(keyringFile, []byte(`{"active_key_id": "2025", "keys": {"2024": "`+newTestMasterKey(t)+`", "2025": "`+newTestMasterKey(t)+`"}}`), 0600))
+
+		keys, err := NewLocalKMSKeyProvider(keyringFile)
+		require.NoError(t, err)
+		assert.Equal(t, "2025", keys.ActiveKeyID())
+
+		dataKey := []byte("0123456789abcdef0123456789abcdef")
+		wrapped, err := keys.WrapKey("2024", dataKey)
+		require.NoError(t, err)
+
+		unwrapped, err := keys.UnwrapKey("2024", wrapped)
+		require.NoError(t, err)
+		assert.Equal(t, dataKey, unwrapped)
+
+		_, err = keys.UnwrapKey("2025", wrapped)
+		assert.Error(t, err)
+	})
+
+	t.Run("missing active key", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(keyringFile, []byte(`{"active_key_id": "2026", "keys": {"2025": "`+newTestMasterKey(t)+`"}}`), 0600))
+
+		_, err := NewLocalKMSKeyProvider(keyringFile)
+		assert.Error(t, err)
+	})
+
+	t.Run("invalid key length", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(keyringFile, []byte(`{"active_key_id": "2025", "keys": {"2025": "c2hvcnQ="}}`), 0600))
+
+		_, err := NewLocalKMSKeyProvider(keyringFile)
+		assert.Error(t, err)
+	})
+}
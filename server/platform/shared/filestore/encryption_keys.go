@@ -0,0 +1,198 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package filestore
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+// KeyProvider wraps and unwraps the per-file data keys used by an
+// EncryptedFileBackend with a set of master keys identified by id.
+type KeyProvider interface {
+	// ActiveKeyID returns the id of the master key used to wrap new data keys.
+	ActiveKeyID() string
+	WrapKey(keyID string, dataKey []byte) ([]byte, error)
+	UnwrapKey(keyID string, wrappedKey []byte) ([]byte, error)
+}
+
+type EncryptionSettings struct {
+	Enable             bool
+	KeyProvider        string
+	MasterKey          string
+	PreviousMasterKeys []string
+	KeyringFile        string
+}
+
+func NewEncryptionSettingsFromConfig(fileSettings *model.FileSettings) EncryptionSettings {
+	if fileSettings.EnableEncryption == nil || !*fileSettings.EnableEncryption {
+		return EncryptionSettings{}
+	}
+	return EncryptionSettings{
+		Enable:             true,
+		KeyProvider:        *fileSettings.EncryptionKeyProvider,
+		MasterKey:          *fileSettings.EncryptionMasterKey,
+		PreviousMasterKeys: fileSettings.EncryptionPreviousMasterKeys,
+		KeyringFile:        *fileSettings.EncryptionKeyringFile,
+	}
+}
+
+// NewKeyProvider creates the key provider configured in settings.
+func NewKeyProvider(settings EncryptionSettings) (KeyProvider, error) {
+	switch settings.KeyProvider {
+	case model.FileEncryptionKeyProviderConfig, "":
+		return NewConfigKeyProvider(settings.MasterKey, settings.PreviousMasterKeys)
+	case model.FileEncryptionKeyProviderLocalKMS:
+		return NewLocalKMSKeyProvider(settings.KeyringFile)
+	}
+	return nil, errors.Errorf("unknown file encryption key provider %q", settings.KeyProvider)
+}
+
+// masterKeys implements KeyProvider by wrapping data keys with AES-256-GCM
+// under one of a set of in-memory master keys.
+type masterKeys struct {
+	activeKeyID string
+	keys        map[string]cipher.AEAD
+}
+
+func newMasterKeys(activeKeyID string, keys map[string][]byte) (*masterKeys, error) {
+	mk := &masterKeys{
+		activeKeyID: activeKeyID,
+		keys:        make(map[string]cipher.AEAD, len(keys)),
+	}
+
+	for id, key := range keys {
+		if len(key) != model.FileEncryptionMasterKeyLength {
+			return nil, errors.Errorf("master key %s must be %d bytes long", id, model.FileEncryptionMasterKeyLength)
+		}
+		block, err := aes.NewCipher(key)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid master key %s", id)
+		}
+		aead, err := cipher.NewGCM(block)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid master key %s", id)
+		}
+		mk.keys[id] = aead
+	}
+
+	if _, ok := mk.keys[activeKeyID]; !ok {
+		return nil, errors.Errorf("active master key %q not found", activeKeyID)
+	}
+
+	return mk, nil
+}
+
+func (mk *masterKeys) ActiveKeyID() string {
+	return mk.activeKeyID
+}
+
+func (mk *masterKeys) WrapKey(keyID string, dataKey []byte) ([]byte, error) {
+	aead, ok := mk.keys[keyID]
+	if !ok {
+		return nil, errors.Errorf("unknown master key %q", keyID)
+	}
+
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(dataKey)+aead.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, errors.Wrap(err, "unable to generate nonce")
+	}
+
+	return aead.Seal(nonce, nonce, dataKey, []byte(keyID)), nil
+}
+
+func (mk *masterKeys) UnwrapKey(keyID string, wrappedKey []byte) ([]byte, error) {
+	aead, ok := mk.keys[keyID]
+	if !ok {
+		return nil, errors.Errorf("unknown master key %q", keyID)
+	}
+
+	if len(wrappedKey) < aead.NonceSize() {
+		return nil, errors.New("wrapped key is too short")
+	}
+
+	dataKey, err := aead.Open(nil, wrappedKey[:aead.NonceSize()], wrappedKey[aead.NonceSize():], []byte(keyID))
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to unwrap data key with master key %q", keyID)
+	}
+	return dataKey, nil
+}
+
+// MasterKeyID returns the id under which a master key from the config is
+// known, derived from the key itself so that it doesn't need to be configured.
+func MasterKeyID(key []byte) string {
+	sum := sha256.Sum256(key)
+	return hex.EncodeToString(sum[:8])
+}
+
+// NewConfigKeyProvider creates a key provider from base64 encoded master keys.
+// New data keys are wrapped with masterKey, while previousMasterKeys remain
+// available to read files until they have been re-keyed.
+func NewConfigKeyProvider(masterKey string, previousMasterKeys []string) (KeyProvider, error) {
+	keys := make(map[string][]byte, len(previousMasterKeys)+1)
+
+	var activeKeyID string
+	for i, encoded := range append([]string{masterKey}, previousMasterKeys...) {
+		key, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to decode master key")
+		}
+
+		id := MasterKeyID(key)
+		if i == 0 {
+			activeKeyID = id
+		}
+		keys[id] = key
+	}
+
+	return newMasterKeys(activeKeyID, keys)
+}
+
+// localKeyring is the format of the keyring file read by the local KMS key
+// provider. Keys are base64 encoded.
+type localKeyring struct {
+	ActiveKeyID string            `json:"active_key_id"`
+	Keys        map[string]string `json:"keys"`
+}
+
+// NewLocalKMSKeyProvider creates a key provider backed by a JSON keyring file,
+// standing in for an external KMS on deployments that don't have one. The file
+// holds the master keys by id along with the id of the active one:
+//
+//	{"active_key_id": "2025-01", "keys": {"2024-01": "<base64>", "2025-01": "<base64>"}}
+func NewLocalKMSKeyProvider(keyringFile string) (KeyProvider, error) {
+	data, err := os.ReadFile(keyringFile)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to read keyring file %s", keyringFile)
+	}
+
+	var keyring localKeyring
+	if err := json.Unmarshal(data, &keyring); err != nil {
+		return nil, errors.Wrapf(err, "unable to parse keyring file %s", keyringFile)
+	}
+
+	keys := make(map[string][]byte, len(keyring.Keys))
+	for id, encoded := range keyring.Keys {
+		if id == "" || len(id) > maxKeyIDLength {
+			return nil, errors.Errorf("invalid key id %q in keyring file %s", id, keyringFile)
+		}
+		key, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, errors.Wrapf(err, "unable to decode key %s in keyring file %s", id, keyringFile)
+		}
+		keys[id] = key
+	}
+
+	return newMasterKeys(keyring.ActiveKeyID, keys)
+}
@@ -0,0 +1,271 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package filestore
+
+import (
+	"context"
+	"io"
+	"slices"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+// FallbackFileBackend serves files from a primary backend, falling back to a
+// secondary one for the files that the primary doesn't have. It's used while
+// migrating between storage backends, so that files that have yet to be copied
+// to the new backend are still served from the old one.
+//
+// New files are only ever written to the primary backend. Files that only
+// exist in the fallback backend are brought over to the primary before being
+// modified, so the fallback backend is never written to, apart from removals.
+type FallbackFileBackend struct {
+	primary  FileBackend
+	fallback FileBackend
+}
+
+func NewFallbackFileBackend(primary, fallback FileBackend) *FallbackFileBackend {
+	return &FallbackFileBackend{
+		primary:  primary,
+		fallback: fallback,
+	}
+}
+
+// WithMigrationFallback wraps backend to fall back to the storage migration
+// target when the dual-read period of a storage migration is enabled in
+// fileSettings. Otherwise backend is returned as is.
+func WithMigrationFallback(backend FileBackend, fileSettings *model.FileSettings, enableComplianceFeature bool, skipVerify bool) (FileBackend, error) {
+	if fileSettings.MigrationDualRead == nil || !*fileSettings.MigrationDualRead || fileSettings.MigrationDriverName == nil || *fileSettings.MigrationDriverName == "" {
+		return backend, nil
+	}
+
+	fallback, err := NewFileBackend(NewMigrationFileBackendSettingsFromConfig(fileSettings, enableComplianceFeature, skipVerify))
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to initialize the storage migration fallback backend")
+	}
+	return NewFallbackFileBackend(backend, fallback), nil
+}
+
+// Unwrap returns the primary backend.
+func (b *FallbackFileBackend) Unwrap() FileBackend {
+	return b.primary
+}
+
+func (b *FallbackFileBackend) DriverName() string {
+	return b.primary.DriverName()
+}
+
+func (b *FallbackFileBackend) TestConnection() error {
+	if err := b.primary.TestConnection(); err != nil {
+		return err
+	}
+	if err := b.fallback.TestConnection(); err != nil {
+		return errors.Wrap(err, "unable to connect to the fallback storage")
+	}
+	return nil
+}
+
+func (b *FallbackFileBackend) Reader(path string) (ReadCloseSeeker, error) {
+	r, err := b.primary.Reader(path)
+	if err != nil {
+		if fr, fallbackErr := b.fallback.Reader(path); fallbackErr == nil {
+			return fr, nil
+		}
+		return nil, err
+	}
+	return r, nil
+}
+
+func (b *FallbackFileBackend) ReadFile(path string) ([]byte, error) {
+	data, err := b.primary.ReadFile(path)
+	if err != nil {
+		if fallbackData, fallbackErr := b.fallback.ReadFile(path); fallbackErr == nil {
+			return fallbackData, nil
+		}
+		return nil, err
+	}
+	return data, nil
+}
+
+func (b *FallbackFileBackend) FileExists(path string) (bool, error) {
+	exists, err := b.primary.FileExists(path)
+	if err != nil || exists {
+		return exists, err
+	}
+	return b.fallback.FileExists(path)
+}
+
+func (b *FallbackFileBackend) FileSize(path string) (int64, error) {
+	size, err := b.primary.FileSize(path)
+	if err != nil {
+		if fallbackSize, fallbackErr := b.fallback.FileSize(path); fallbackErr == nil {
+			return fallbackSize, nil
+		}
+		return 0, err
+	}
+	return size, nil
+}
+
+func (b *FallbackFileBackend) FileModTime(path string) (time.Time, error) {
+	modTime, err := b.primary.FileModTime(path)
+	if err != nil {
+		if fallbackModTime, fallbackErr := b.fallback.FileModTime(path); fallbackErr == nil {
+			return fallbackModTime, nil
+		}
+		return time.Time{}, err
+	}
+	return modTime, nil
+}
+
+// bringToPrimary copies path from the fallback backend to the primary one if
+// only the fallback backend has it.
+func (b *FallbackFileBackend) bringToPrimary(path string) error {
+	exists, err := b.primary.FileExists(path)
+	if err != nil || exists {
+		return err
+	}
+
+	exists, err = b.fallback.FileExists(path)
+	if err != nil || !exists {
+		return err
+	}
+
+	r, err := b.fallback.Reader(path)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	if _, err := b.primary.WriteFile(r, path); err != nil {
+		return errors.Wrapf(err, "unable to copy %s from the fallback storage", path)
+	}
+	return nil
+}
+
+func (b *FallbackFileBackend) CopyFile(oldPath, newPath string) error {
+	if err := b.bringToPrimary(oldPath); err != nil {
+		return err
+	}
+	return b.primary.CopyFile(oldPath, newPath)
+}
+
+func (b *FallbackFileBackend) MoveFile(oldPath, newPath string) error {
+	if err := b.bringToPrimary(oldPath); err != nil {
+		return err
+	}
+	if err := b.primary.MoveFile(oldPath, newPath); err != nil {
+		return err
+	}
+	return b.removeFromFallback(oldPath)
+}
+
+func (b *FallbackFileBackend) WriteFile(fr io.Reader, path string) (int64, error) {
+	return b.primary.WriteFile(fr, path)
+}
+
+func (b *FallbackFileBackend) WriteFileContext(ctx context.Context, fr io.Reader, path string) (int64, error) {
+	return TryWriteFileContext(ctx, b.primary, fr, path)
+}
+
+func (b *FallbackFileBackend) AppendFile(fr io.Reader, path string) (int64, error) {
+	if err := b.bringToPrimary(path); err != nil {
+		return 0, err
+	}
+	return b.primary.AppendFile(fr, path)
+}
+
+func (b *FallbackFileBackend) RemoveFile(path string) error {
+	exists, err := b.primary.FileExists(path)
+	if err != nil {
+		return err
+	}
+	if exists {
+		if err := b.primary.RemoveFile(path); err != nil {
+			return err
+		}
+	}
+	return b.removeFromFallback(path)
+}
+
+func (b *FallbackFileBackend) removeFromFallback(path string) error {
+	exists, err := b.fallback.FileExists(path)
+	if err != nil || !exists {
+		return err
+	}
+	return b.fallback.RemoveFile(path)
+}
+
+func (b *FallbackFileBackend) ListDirectory(path string) ([]string, error) {
+	paths, err := b.primary.ListDirectory(path)
+	fallbackPaths, fallbackErr := b.fallback.ListDirectory(path)
+	return mergeListings(paths, err, fallbackPaths, fallbackErr)
+}
+
+func (b *FallbackFileBackend) ListDirectoryRecursively(path string) ([]string, error) {
+	paths, err := b.primary.ListDirectoryRecursively(path)
+	fallbackPaths, fallbackErr := b.fallback.ListDirectoryRecursively(path)
+	return mergeListings(paths, err, fallbackPaths, fallbackErr)
+}
+
+// listDirectoryRecursivelyAfter merges the pages of both backends, which are
+// in the same order, and keeps the first limit paths.
+func (b *FallbackFileBackend) listDirectoryRecursivelyAfter(path, after string, limit int) ([]string, error) {
+	paths, err := ListDirectoryRecursivelyAfter(b.primary, path, after, limit)
+	fallbackPaths, fallbackErr := ListDirectoryRecursivelyAfter(b.fallback, path, after, limit)
+	paths, err = mergeListings(paths, err, fallbackPaths, fallbackErr)
+	if err != nil {
+		return nil, err
+	}
+	slices.Sort(paths)
+	return paths[:min(limit, len(paths))], nil
+}
+
+// mergeListings merges the listing of the primary backend with the one of the
+// fallback backend, without duplicates. An error is only returned if neither
+// backend could be listed.
+func mergeListings(paths []string, err error, fallbackPaths []string, fallbackErr error) ([]string, error) {
+	if err != nil && fallbackErr != nil {
+		return nil, err
+	} else if err != nil {
+		return fallbackPaths, nil
+	} else if fallbackErr != nil {
+		return paths, nil
+	}
+
+	seen := make(map[string]bool, len(paths))
+	for _, path := range paths {
+		seen[path] = true
+	}
+	for _, path := range fallbackPaths {
+		if !seen[path] {
+			paths = append(paths, path)
+		}
+	}
+	return paths, nil
+}
+
+func (b *FallbackFileBackend) RemoveDirectory(path string) error {
+	if err := b.primary.RemoveDirectory(path); err != nil {
+		return err
+	}
+	return b.fallback.RemoveDirectory(path)
+}
+
+// ZipReader zips path from the primary backend, or from the fallback backend
+// if the primary has nothing under path yet.
+func (b *FallbackFileBackend) ZipReader(path string, deflate bool) (io.ReadCloser, error) {
+	exists, err := b.primary.FileExists(path)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		files, _ := b.primary.ListDirectoryRecursively(path)
+		if len(files) == 0 {
+			return b.fallback.ZipReader(path, deflate)
+		}
+	}
+	return b.primary.ZipReader(path, deflate)
+}
@@ -0,0 +1,163 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package filestore
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func TestFallbackFileBackend(t *testing.T) {
+	primaryDir := t.TempDir()
+	fallbackDir := t.TempDir()
+	backend := NewFallbackFileBackend(&LocalFileBackend{directory: primaryDir}, &LocalFileBackend{directory: fallbackDir})
+
+	writeFallback := func(t *testing.T, path, data string) {
+		t.Helper()
+		require.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(fallbackDir, path)), 0750))
+		require.NoError(t, os.WriteFile(filepath.Join(fallbackDir, path), []byte(data), 0600))
+	}
+
+	t.Run("reads fall back to files not yet copied", func(t *testing.T) {
+		writeFallback(t, "old/file.txt", "old data")
+
+		data, err := backend.ReadFile("old/file.txt")
+		require.NoError(t, err)
+		assert.Equal(t, "old data", string(data))
+
+		exists, err := backend.FileExists("old/file.txt")
+		require.NoError(t, err)
+		assert.True(t, exists)
+
+		size, err := backend.FileSize("old/file.txt")
+		require.NoError(t, err)
+		assert.EqualValues(t, 8, size)
+
+		r, err := backend.Reader("old/file.txt")
+		require.NoError(t, err)
+		r.Close()
+
+		_, err = backend.ReadFile("old/missing.txt")
+		assert.Error(t, err)
+	})
+
+	t.Run("the primary backend takes precedence", func(t *testing.T) {
+		writeFallback(t, "both.txt", "stale")
+		_, err := backend.WriteFile(bytes.NewReader([]byte("fresh")), "both.txt")
+		require.NoError(t, err)
+
+		data, err := backend.ReadFile("both.txt")
+		require.NoError(t, err)
+		assert.Equal(t, "fresh", string(data))
+	})
+
+	t.Run("new files are only written to the primary backend", func(t *testing.T) {
+		_, err := backend.WriteFile(bytes.NewReader([]byte("new")), "new.txt")
+		require.NoError(t, err)
+
+		assert.FileExists(t, filepath.Join(primaryDir, "new.txt"))
+		assert.NoFileExists(t, filepath.Join(fallbackDir, "new.txt"))
+	})
+
+	t.Run("appending brings the file to the primary backend", func(t *testing.T) {
+		writeFallback(t, "upload.bin", "part1")
+
+		_, err := backend.AppendFile(bytes.NewReader([]byte("part2")), "upload.bin")
+		require.NoError(t, err)
+
+		data, err := os.ReadFile(filepath.Join(primaryDir, "upload.bin"))
+		require.NoError(t, err)
+		assert.Equal(t, "part1part2", string(data))
+	})
+
+	t.Run("moving a file not yet copied", func(t *testing.T) {
+		writeFallback(t, "tmp/moved.txt", "moved")
+
+		require.NoError(t, backend.MoveFile("tmp/moved.txt", "final/moved.txt"))
+
+		data, err := backend.ReadFile("final/moved.txt")
+		require.NoError(t, err)
+		assert.Equal(t, "moved", string(data))
+
+		exists, err := backend.FileExists("tmp/moved.txt")
+		require.NoError(t, err)
+		assert.False(t, exists)
+	})
+
+	t.Run("removing deletes the file from both backends", func(t *testing.T) {
+		writeFallback(t, "removed.txt", "old")
+		_, err := backend.WriteFile(bytes.NewReader([]byte("new")), "removed.txt")
+		require.NoError(t, err)
+
+		require.NoError(t, backend.RemoveFile("removed.txt"))
+
+		exists, err := backend.FileExists("removed.txt")
+		require.NoError(t, err)
+		assert.False(t, exists)
+	})
+
+	t.Run("listings are merged", func(t *testing.T) {
+		writeFallback(t, "list/a.txt", "a")
+		writeFallback(t, "list/b.txt", "b")
+		_, err := backend.WriteFile(bytes.NewReader([]byte("b")), "list/b.txt")
+		require.NoError(t, err)
+		_, err = backend.WriteFile(bytes.NewReader([]byte("c")), "list/c.txt")
+		require.NoError(t, err)
+
+		paths, err := backend.ListDirectory("list")
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"list/a.txt", "list/b.txt", "list/c.txt"}, paths)
+
+		paths, err = backend.ListDirectoryRecursively("list")
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"list/a.txt", "list/b.txt", "list/c.txt"}, paths)
+
+		paths, err = ListDirectoryRecursivelyAfter(backend, "list", "", 2)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"list/a.txt", "list/b.txt"}, paths)
+
+		paths, err = ListDirectoryRecursivelyAfter(backend, "list", "list/b.txt", 2)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"list/c.txt"}, paths)
+	})
+}
+
+func TestWithMigrationFallback(t *testing.T) {
+	backend := &LocalFileBackend{directory: t.TempDir()}
+
+	fileSettings := &model.FileSettings{}
+	fileSettings.SetDefaults(false)
+
+	wrapped, err := WithMigrationFallback(backend, fileSettings, false, false)
+	require.NoError(t, err)
+	assert.Same(t, backend, wrapped)
+
+	fileSettings.MigrationDriverName = model.NewPointer(model.ImageDriverLocal)
+	fileSettings.MigrationDirectory = model.NewPointer(t.TempDir())
+	wrapped, err = WithMigrationFallback(backend, fileSettings, false, false)
+	require.NoError(t, err)
+	assert.Same(t, backend, wrapped, "dual reads are not enabled")
+
+	fileSettings.MigrationDualRead = model.NewPointer(true)
+	wrapped, err = WithMigrationFallback(backend, fileSettings, false, false)
+	require.NoError(t, err)
+	require.IsType(t, &FallbackFileBackend{}, wrapped)
+	assert.Same(t, backend, wrapped.(*FallbackFileBackend).Unwrap())
+}
+
+func TestUnwrapFileBackend(t *testing.T) {
+	backend := &LocalFileBackend{directory: t.TempDir()}
+	assert.Same(t, backend, UnwrapFileBackend(backend))
+
+	encrypted := newTestEncryptedFileBackend(t, t.TempDir(), newTestMasterKey(t))
+	wrapped := NewFallbackFileBackend(encrypted, backend)
+	assert.Same(t, encrypted.Unwrap(), UnwrapFileBackend(wrapped))
+}
@@ -6,6 +6,7 @@ package filestore
 import (
 	"context"
 	"io"
+	"slices"
 	"time"
 
 	"github.com/mattermost/mattermost/server/public/model"
@@ -65,6 +66,7 @@ type FileBackendSettings struct {
 	AmazonS3PresignExpiresSeconds      int64
 	AmazonS3UploadPartSizeBytes        int64
 	AmazonS3StorageClass               string
+	Encryption                         EncryptionSettings
 }
 
 func NewFileBackendSettingsFromConfig(fileSettings *model.FileSettings, enableComplianceFeature bool, skipVerify bool) FileBackendSettings {
@@ -72,6 +74,7 @@ func NewFileBackendSettingsFromConfig(fileSettings *model.FileSettings, enableCo
 		return FileBackendSettings{
 			DriverName: *fileSettings.DriverName,
 			Directory:  *fileSettings.Directory,
+			Encryption: NewEncryptionSettingsFromConfig(fileSettings),
 		}
 	}
 	return FileBackendSettings{
@@ -90,6 +93,7 @@ func NewFileBackendSettingsFromConfig(fileSettings *model.FileSettings, enableCo
 		SkipVerify:                         skipVerify,
 		AmazonS3UploadPartSizeBytes:        *fileSettings.AmazonS3UploadPartSizeBytes,
 		AmazonS3StorageClass:               *fileSettings.AmazonS3StorageClass,
+		Encryption:                         NewEncryptionSettingsFromConfig(fileSettings),
 	}
 }
 
@@ -120,6 +124,37 @@ func NewExportFileBackendSettingsFromConfig(fileSettings *model.FileSettings, en
 	}
 }
 
+// NewMigrationFileBackendSettingsFromConfig returns the settings of the storage
+// migration target. Files are encrypted at rest in the target just like in the
+// current storage.
+func NewMigrationFileBackendSettingsFromConfig(fileSettings *model.FileSettings, enableComplianceFeature bool, skipVerify bool) FileBackendSettings {
+	if *fileSettings.MigrationDriverName == model.ImageDriverLocal {
+		return FileBackendSettings{
+			DriverName: *fileSettings.MigrationDriverName,
+			Directory:  *fileSettings.MigrationDirectory,
+			Encryption: NewEncryptionSettingsFromConfig(fileSettings),
+		}
+	}
+	return FileBackendSettings{
+		DriverName:                         *fileSettings.MigrationDriverName,
+		AmazonS3AccessKeyId:                *fileSettings.MigrationAmazonS3AccessKeyId,
+		AmazonS3SecretAccessKey:            *fileSettings.MigrationAmazonS3SecretAccessKey,
+		AmazonS3Bucket:                     *fileSettings.MigrationAmazonS3Bucket,
+		AmazonS3PathPrefix:                 *fileSettings.MigrationAmazonS3PathPrefix,
+		AmazonS3Region:                     *fileSettings.MigrationAmazonS3Region,
+		AmazonS3Endpoint:                   *fileSettings.MigrationAmazonS3Endpoint,
+		AmazonS3SSL:                        fileSettings.MigrationAmazonS3SSL == nil || *fileSettings.MigrationAmazonS3SSL,
+		AmazonS3SignV2:                     fileSettings.MigrationAmazonS3SignV2 != nil && *fileSettings.MigrationAmazonS3SignV2,
+		AmazonS3SSE:                        fileSettings.MigrationAmazonS3SSE != nil && *fileSettings.MigrationAmazonS3SSE && enableComplianceFeature,
+		AmazonS3Trace:                      fileSettings.MigrationAmazonS3Trace != nil && *fileSettings.MigrationAmazonS3Trace,
+		AmazonS3RequestTimeoutMilliseconds: *fileSettings.MigrationAmazonS3RequestTimeoutMilliseconds,
+		AmazonS3UploadPartSizeBytes:        *fileSettings.MigrationAmazonS3UploadPartSizeBytes,
+		AmazonS3StorageClass:               *fileSettings.MigrationAmazonS3StorageClass,
+		SkipVerify:                         skipVerify,
+		Encryption:                         NewEncryptionSettingsFromConfig(fileSettings),
+	}
+}
+
 func (settings *FileBackendSettings) CheckMandatoryS3Fields() error {
 	if settings.AmazonS3Bucket == "" {
 		return errors.New("missing s3 bucket settings")
@@ -144,6 +179,19 @@ func NewExportFileBackend(settings FileBackendSettings) (FileBackend, error) {
 }
 
 func newFileBackend(settings FileBackendSettings, canBeCloud bool) (FileBackend, error) {
+	backend, err := newUnencryptedFileBackend(settings, canBeCloud)
+	if err != nil || !settings.Encryption.Enable {
+		return backend, err
+	}
+
+	keys, err := NewKeyProvider(settings.Encryption)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to load the file encryption keys")
+	}
+	return NewEncryptedFileBackend(backend, keys), nil
+}
+
+func newUnencryptedFileBackend(settings FileBackendSettings, canBeCloud bool) (FileBackend, error) {
 	switch settings.DriverName {
 	case driverS3:
 		newBackendFn := NewS3FileBackend
@@ -163,6 +211,44 @@ func newFileBackend(settings FileBackendSettings, canBeCloud bool) (FileBackend,
 	return nil, errors.New("no valid filestorage driver found")
 }
 
+// UnwrapFileBackend returns the backend that fb keeps the files in, past the
+// backends, such as the encrypted or fallback ones, wrapping it.
+func UnwrapFileBackend(fb FileBackend) FileBackend {
+	for {
+		wrapper, ok := fb.(interface{ Unwrap() FileBackend })
+		if !ok {
+			return fb
+		}
+		fb = wrapper.Unwrap()
+	}
+}
+
+// ListDirectoryRecursivelyAfter returns, in lexical order, up to limit of the
+// files under path whose path comes after after, so that large directories
+// can be walked one page at a time by passing the last path of a page to get
+// the next one. Backends that can't list a page at a time are listed
+// entirely, and the page is picked from the result.
+func ListDirectoryRecursivelyAfter(fb FileBackend, path, after string, limit int) ([]string, error) {
+	type pagedLister interface {
+		listDirectoryRecursivelyAfter(path, after string, limit int) ([]string, error)
+	}
+
+	if pl, ok := fb.(pagedLister); ok {
+		return pl.listDirectoryRecursivelyAfter(path, after, limit)
+	}
+
+	paths, err := fb.ListDirectoryRecursively(path)
+	if err != nil {
+		return nil, err
+	}
+	slices.Sort(paths)
+	start, found := slices.BinarySearch(paths, after)
+	if found {
+		start++
+	}
+	return paths[start:min(start+limit, len(paths))], nil
+}
+
 // TryWriteFileContext checks if the file backend supports context writes and passes the context in that case.
 // Should the file backend not support contexts, it just calls WriteFile instead. This can be used to disable
 // the timeouts for long writes (like exports).
@@ -13,6 +13,7 @@ import (
 	"math/rand"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
 	"time"
@@ -416,6 +417,45 @@ func (s *FileBackendTestSuite) TestListDirectory() {
 	s.backend.RemoveFile(path2)
 }
 
+func (s *FileBackendTestSuite) TestListDirectoryRecursivelyAfter() {
+	dir := randomString()
+	var expected []string
+	for _, name := range []string{"a/b", "a-b/x", "a/c/d", "a.txt", "b", "a/c/e", "ab"} {
+		path := dir + "/" + name
+		_, err := s.backend.WriteFile(bytes.NewReader([]byte("test")), path)
+		s.Require().NoError(err)
+		expected = append(expected, path)
+	}
+	defer s.backend.RemoveDirectory(dir)
+	slices.Sort(expected)
+
+	// Hiding the backend behind the interface falls back to listing the
+	// whole directory.
+	type plainBackend struct{ FileBackend }
+
+	for name, backend := range map[string]FileBackend{"paged": s.backend, "listed": plainBackend{s.backend}} {
+		s.Run(name, func() {
+			var paths []string
+			after := ""
+			for range len(expected) {
+				page, err := ListDirectoryRecursivelyAfter(backend, dir, after, 2)
+				s.Require().NoError(err)
+				s.LessOrEqual(len(page), 2)
+				if len(page) == 0 {
+					break
+				}
+				paths = append(paths, page...)
+				after = page[len(page)-1]
+			}
+			s.Equal(expected, paths)
+
+			page, err := ListDirectoryRecursivelyAfter(backend, dir, dir+"/a.txt", 3)
+			s.Require().NoError(err)
+			s.Equal([]string{dir + "/a/b", dir + "/a/c/d", dir + "/a/c/e"}, page)
+		})
+	}
+}
+
 func (s *FileBackendTestSuite) TestListDirectoryRecursively() {
 	b := []byte("test")
 	path1 := "19700101/" + randomString()
@@ -554,6 +594,35 @@ func (s *FileBackendTestSuite) TestAppendFile() {
 	})
 }
 
+func (s *FileBackendTestSuite) TestWriteFileTail() {
+	tw, ok := s.backend.(interface {
+		writeFileTail(fr io.Reader, path string, offset int64) (int64, error)
+	})
+	if !ok {
+		s.T().Skip("the backend can't replace the end of a file")
+	}
+
+	// Files past the minimum part size are composed on S3, smaller ones are
+	// written again.
+	for _, size := range []int{1024, s3MinComposePartSize + 1024} {
+		s.Run(fmt.Sprintf("%d bytes", size), func() {
+			path := "tests/" + randomString()
+			b := bytes.Repeat([]byte{'A'}, size)
+			_, err := s.backend.WriteFile(bytes.NewReader(b), path)
+			s.Require().NoError(err)
+			defer s.backend.RemoveFile(path)
+
+			written, err := tw.writeFileTail(bytes.NewReader(bytes.Repeat([]byte{'B'}, 2048)), path, int64(size-512))
+			s.Require().NoError(err)
+			s.EqualValues(2048, written)
+
+			read, err := s.backend.ReadFile(path)
+			s.Require().NoError(err)
+			s.Equal(append(b[:size-512], bytes.Repeat([]byte{'B'}, 2048)...), read)
+		})
+	}
+}
+
 func (s *FileBackendTestSuite) TestFileSize() {
 	s.Run("nonexistent file", func() {
 		size, err := s.backend.FileSize("tests/nonexistentfile")
@@ -9,6 +9,8 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/pkg/errors"
@@ -113,6 +115,14 @@ func (b *LocalFileBackend) FileExists(path string) (bool, error) {
 	return true, nil
 }
 
+func (b *LocalFileBackend) isDirectory(path string) (bool, error) {
+	info, err := os.Stat(filepath.Join(b.directory, path))
+	if err != nil {
+		return false, errors.Wrapf(err, "unable to stat path %s", path)
+	}
+	return info.IsDir(), nil
+}
+
 func (b *LocalFileBackend) FileSize(path string) (int64, error) {
 	info, err := os.Stat(filepath.Join(b.directory, path))
 	if err != nil {
@@ -186,6 +196,28 @@ func (b *LocalFileBackend) AppendFile(fr io.Reader, path string) (int64, error)
 	return written, nil
 }
 
+// writeFileTail replaces the data of the file at path from offset on with the
+// one of fr, writing it in place.
+func (b *LocalFileBackend) writeFileTail(fr io.Reader, path string, offset int64) (int64, error) {
+	fp := filepath.Join(b.directory, path)
+	fw, err := os.OpenFile(fp, os.O_WRONLY, 0600)
+	if err != nil {
+		return 0, errors.Wrapf(err, "unable to open the file %s to write the data", path)
+	}
+	defer fw.Close()
+	if _, err = fw.Seek(offset, io.SeekStart); err != nil {
+		return 0, errors.Wrapf(err, "unable to write the data in the file %s", path)
+	}
+	written, err := io.Copy(fw, fr)
+	if err != nil {
+		return written, errors.Wrapf(err, "unable to write the data in the file %s", path)
+	}
+	if err = fw.Truncate(offset + written); err != nil {
+		return written, errors.Wrapf(err, "unable to write the data in the file %s", path)
+	}
+	return written, nil
+}
+
 func (b *LocalFileBackend) RemoveFile(path string) error {
 	if err := os.Remove(filepath.Join(b.directory, path)); err != nil {
 		return errors.Wrapf(err, "unable to remove the file %s", path)
@@ -252,6 +284,56 @@ func (b *LocalFileBackend) ListDirectoryRecursively(path string) ([]string, erro
 	return appendRecursively(b.directory, path, MaxRecursionDepth)
 }
 
+// listDirectoryRecursivelyAfter walks the directories in lexical order of the
+// paths under them, skipping the ones that only hold paths up to after.
+func (b *LocalFileBackend) listDirectoryRecursivelyAfter(path, after string, limit int) ([]string, error) {
+	results := []string{}
+	err := b.appendRecursivelyAfter(&results, path, after, limit, MaxRecursionDepth)
+	return results, err
+}
+
+func (b *LocalFileBackend) appendRecursivelyAfter(results *[]string, path, after string, limit, maxDepth int) error {
+	dirEntries, err := os.ReadDir(filepath.Join(b.directory, path))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return errors.Wrapf(err, "unable to list the directory %s", path)
+	}
+
+	// The paths under a directory are ordered as if its name ended with a
+	// slash, which puts "a-b" before "a/b".
+	sortKey := func(dirEntry os.DirEntry) string {
+		if dirEntry.IsDir() {
+			return dirEntry.Name() + "/"
+		}
+		return dirEntry.Name()
+	}
+	slices.SortFunc(dirEntries, func(a, b os.DirEntry) int {
+		return strings.Compare(sortKey(a), sortKey(b))
+	})
+
+	for _, dirEntry := range dirEntries {
+		if len(*results) >= limit {
+			return nil
+		}
+		entryPath := filepath.Join(path, dirEntry.Name())
+		if !dirEntry.IsDir() || maxDepth <= 0 {
+			if entryPath > after {
+				*results = append(*results, entryPath)
+			}
+			continue
+		}
+		if prefix := entryPath + "/"; prefix < after && !strings.HasPrefix(after, prefix) {
+			continue
+		}
+		if err := b.appendRecursivelyAfter(results, entryPath, after, limit, maxDepth-1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (b *LocalFileBackend) RemoveDirectory(path string) error {
 	if err := os.RemoveAll(filepath.Join(b.directory, path)); err != nil {
 		return errors.Wrapf(err, "unable to remove the directory %s", path)
@@ -588,6 +588,84 @@ func (b *S3FileBackend) AppendFile(fr io.Reader, path string) (int64, error) {
 	return info.Size, nil
 }
 
+// s3MinComposePartSize is the minimum size of all the parts but the last
+// one of a composed object.
+const s3MinComposePartSize = 5 * 1024 * 1024
+
+// writeFileTail replaces the data of the file at path from offset on with the
+// one of fr. Past the minimum part size, the head of the file is copied on the
+// server side, like AppendFile does, so only the new data is uploaded.
+func (b *S3FileBackend) writeFileTail(fr io.Reader, path string, offset int64) (int64, error) {
+	fp, err := b.prefixedPath(path)
+	if err != nil {
+		return 0, errors.Wrapf(err, "unable to prefix path %s", path)
+	}
+	if offset <= 0 {
+		return b.WriteFile(fr, path)
+	}
+
+	options := s3PutOptions(b.encrypt, getContentType(filepath.Ext(fp)), b.uploadPartSize, b.storageClass)
+	if b.isCloud {
+		options.DisableContentSha256 = true
+	}
+
+	if offset < s3MinComposePartSize {
+		ctx, cancel := context.WithTimeout(context.Background(), b.timeout)
+		defer cancel()
+		getOptions := s3.GetObjectOptions{}
+		if err = getOptions.SetRange(0, offset-1); err != nil {
+			return 0, errors.Wrapf(err, "unable to read the file %s", path)
+		}
+		head, err2 := b.client.GetObject(ctx, b.bucket, fp, getOptions)
+		if err2 != nil {
+			return 0, errors.Wrapf(err2, "unable to read the file %s", path)
+		}
+		defer head.Close()
+
+		info, err2 := b.client.PutObject(ctx, b.bucket, fp, io.MultiReader(io.LimitReader(head, offset), fr), -1, options)
+		if err2 != nil {
+			return 0, errors.Wrapf(err2, "unable write the data in the file %s", path)
+		}
+		return info.Size - offset, nil
+	}
+
+	partName := fp + ".part"
+	ctx, cancel := context.WithTimeout(context.Background(), b.timeout)
+	defer cancel()
+	info, err := b.client.PutObject(ctx, b.bucket, partName, fr, -1, options)
+	if err != nil {
+		return 0, errors.Wrapf(err, "unable write the data in the file %s", path)
+	}
+	defer func() {
+		ctx2, cancel2 := context.WithTimeout(context.Background(), b.timeout)
+		defer cancel2()
+		b.client.RemoveObject(ctx2, b.bucket, partName, s3.RemoveObjectOptions{})
+	}()
+
+	headOpts := s3.CopySrcOptions{
+		Bucket:     b.bucket,
+		Object:     fp,
+		MatchRange: true,
+		Start:      0,
+		End:        offset - 1,
+	}
+	partOpts := s3.CopySrcOptions{
+		Bucket: b.bucket,
+		Object: partName,
+	}
+	dstOpts := s3.CopyDestOptions{
+		Bucket:     b.bucket,
+		Object:     fp,
+		Encryption: options.ServerSideEncryption,
+	}
+	ctx3, cancel3 := context.WithTimeout(context.Background(), b.timeout)
+	defer cancel3()
+	if _, err = b.client.ComposeObject(ctx3, dstOpts, headOpts, partOpts); err != nil {
+		return 0, errors.Wrapf(err, "unable write the data in the file %s", path)
+	}
+	return info.Size, nil
+}
+
 func (b *S3FileBackend) RemoveFile(path string) error {
 	path, err := b.prefixedPath(path)
 	if err != nil {
@@ -651,6 +729,42 @@ func (b *S3FileBackend) ListDirectoryRecursively(path string) ([]string, error)
 	return b.listDirectory(path, true)
 }
 
+// listDirectoryRecursivelyAfter relies on S3 listing keys in lexical order,
+// starting after the given one.
+func (b *S3FileBackend) listDirectoryRecursivelyAfter(path, after string, limit int) ([]string, error) {
+	path, err := b.prefixedPath(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to prefix path %s", path)
+	}
+	if !strings.HasSuffix(path, "/") && path != "" {
+		path = path + "/"
+	}
+
+	opts := s3.ListObjectsOptions{
+		Prefix:    path,
+		Recursive: true,
+	}
+	if after != "" {
+		opts.StartAfter = filepath.Join(b.pathPrefix, after)
+	}
+	// Cancelling the context stops the listing once the page is full.
+	ctx, cancel := context.WithTimeout(context.Background(), b.timeout)
+	defer cancel()
+	paths := []string{}
+	for object := range b.client.ListObjects(ctx, b.bucket, opts) {
+		if object.Err != nil {
+			return nil, errors.Wrapf(object.Err, "unable to list the directory %s", path)
+		}
+		if trimmed := strings.Trim(strings.TrimPrefix(object.Key, b.pathPrefix), "/"); trimmed != "" {
+			paths = append(paths, trimmed)
+		}
+		if len(paths) >= limit {
+			break
+		}
+	}
+	return paths, nil
+}
+
 func (b *S3FileBackend) RemoveDirectory(path string) error {
 	path, err := b.prefixedPath(path)
 	if err != nil {
@@ -0,0 +1,60 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"net/http"
+	"unicode/utf8"
+)
+
+const (
+	AIFeatureSummarization  = "summarization"
+	AIFeatureFormatting     = "formatting"
+	AIFeatureActionItems    = "action_items"
+	AIFeatureCompletion     = "completion"
+	AIFeatureConnectionTest = "connection_test"
+
+	AICompletionPromptMaxRunes = 100000
+)
+
+// AIPrompt is a prompt about to be sent to the LLM, as seen by the AIPromptWillBeSent hook.
+type AIPrompt struct {
+	// Feature is the AI feature the prompt is for, e.g. AIFeatureSummarization.
+	Feature string `json:"feature"`
+	// UserId is the user the prompt is sent on behalf of, if any.
+	UserId string `json:"user_id,omitempty"`
+	// PluginId is the plugin which sent the prompt, if any.
+	PluginId     string `json:"plugin_id,omitempty"`
+	Model        string `json:"model"`
+	SystemPrompt string `json:"system_prompt,omitempty"`
+	UserPrompt   string `json:"user_prompt"`
+}
+
+// AICompletionRequest is a raw completion requested by a plugin.
+type AICompletionRequest struct {
+	SystemPrompt string `json:"system_prompt,omitempty"`
+	UserPrompt   string `json:"user_prompt"`
+	// Model defaults to the model configured in the AI settings.
+	Model string `json:"model,omitempty"`
+}
+
+func (r *AICompletionRequest) IsValid() *AppError {
+	if r.UserPrompt == "" {
+		return NewAppError("AICompletionRequest.IsValid", "model.ai_completion_request.is_valid.user_prompt.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if utf8.RuneCountInString(r.SystemPrompt)+utf8.RuneCountInString(r.UserPrompt) > AICompletionPromptMaxRunes {
+		return NewAppError("AICompletionRequest.IsValid", "model.ai_completion_request.is_valid.too_long.app_error", map[string]any{"Max": AICompletionPromptMaxRunes}, "", http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+// AICompletionResponse is the result of an AICompletionRequest.
+type AICompletionResponse struct {
+	Text             string `json:"text"`
+	Model            string `json:"model"`
+	PromptTokens     int    `json:"prompt_tokens"`
+	CompletionTokens int    `json:"completion_tokens"`
+}
@@ -0,0 +1,39 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAICompletionRequestIsValid(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		r := &AICompletionRequest{SystemPrompt: "Be brief.", UserPrompt: "Hello"}
+		require.Nil(t, r.IsValid())
+	})
+
+	t.Run("missing user prompt", func(t *testing.T) {
+		r := &AICompletionRequest{SystemPrompt: "Be brief."}
+		appErr := r.IsValid()
+		require.NotNil(t, appErr)
+		assert.Equal(t, "model.ai_completion_request.is_valid.user_prompt.app_error", appErr.Id)
+	})
+
+	t.Run("too long", func(t *testing.T) {
+		r := &AICompletionRequest{
+			SystemPrompt: strings.Repeat("é", AICompletionPromptMaxRunes/2),
+			UserPrompt:   strings.Repeat("é", AICompletionPromptMaxRunes/2+1),
+		}
+		appErr := r.IsValid()
+		require.NotNil(t, appErr)
+		assert.Equal(t, "model.ai_completion_request.is_valid.too_long.app_error", appErr.Id)
+
+		r.UserPrompt = r.UserPrompt[2:]
+		require.Nil(t, r.IsValid())
+	})
+}
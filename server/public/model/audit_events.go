@@ -5,13 +5,14 @@ package model
 
 // Access Control & Security
 const (
-	AuditEventApplyIPFilters            = "applyIPFilters"            // apply IP address filtering
-	AuditEventAssignAccessPolicy        = "assignAccessPolicy"        // assign access control policy to channels
-	AuditEventCreateAccessControlPolicy = "createAccessControlPolicy" // create access control policy
-	AuditEventDeleteAccessControlPolicy = "deleteAccessControlPolicy" // delete access control policy
-	AuditEventUnassignAccessPolicy      = "unassignAccessPolicy"      // remove access control policy from channels
-	AuditEventUpdateActiveStatus        = "updateActiveStatus"        // update active/inactive status of access control policy
-	AuditEventSetActiveStatus           = "setActiveStatus"           // set active/inactive status of multiple access control policies
+	AuditEventApplyIPFilters                    = "applyIPFilters"                    // apply IP address filtering
+	AuditEventAssignAccessPolicy                = "assignAccessPolicy"                // assign access control policy to channels
+	AuditEventCreateAccessControlPolicy         = "createAccessControlPolicy"         // create access control policy
+	AuditEventDeleteAccessControlPolicy         = "deleteAccessControlPolicy"         // delete access control policy
+	AuditEventRemoveChannelMemberByAccessPolicy = "removeChannelMemberByAccessPolicy" // remove channel member who no longer satisfies the channel's access control policy
+	AuditEventUnassignAccessPolicy              = "unassignAccessPolicy"              // remove access control policy from channels
+	AuditEventUpdateActiveStatus                = "updateActiveStatus"                // update active/inactive status of access control policy
+	AuditEventSetActiveStatus                   = "setActiveStatus"                   // set active/inactive status of multiple access control policies
 )
 
 // Audit & Certificates
@@ -20,6 +21,8 @@ const (
 	AuditEventGetAudits                 = "getAudits"                 // get audit log entries
 	AuditEventGetUserAudits             = "getUserAudits"             // get audit log entries for specific user
 	AuditEventRemoveAuditLogCertificate = "removeAuditLogCertificate" // remove certificate used for audit log transmission
+	AuditEventSearchAuditLog            = "searchAuditLog"            // search the database audit log
+	AuditEventVerifyAuditLog            = "verifyAuditLog"            // verify the integrity of the database audit log
 )
 
 // Bots
@@ -110,9 +113,11 @@ const (
 	AuditEventLocalGetClientConfig = "localGetClientConfig" // get client configuration locally
 	AuditEventLocalGetConfig       = "localGetConfig"       // get server configuration locally
 	AuditEventLocalPatchConfig     = "localPatchConfig"     // update server configuration locally
+	AuditEventLocalRollbackConfig  = "localRollbackConfig"  // restore a previous version of the server configuration locally
 	AuditEventLocalUpdateConfig    = "localUpdateConfig"    // update server configuration locally
 	AuditEventMigrateConfig        = "migrateConfig"        // migrate configs with file values from one store to another
 	AuditEventPatchConfig          = "patchConfig"          // update server configuration
+	AuditEventRollbackConfig       = "rollbackConfig"       // restore a previous version of the server configuration
 	AuditEventUpdateConfig         = "updateConfig"         // update server configuration
 )
 
@@ -151,11 +156,12 @@ const (
 
 // Files
 const (
-	AuditEventGetFile                   = "getFile"                   // get or download file
-	AuditEventGetFileLink               = "getFileLink"               // generate link for file sharing
-	AuditEventUploadFileMultipart       = "uploadFileMultipart"       // upload file using multipart form data
-	AuditEventUploadFileMultipartLegacy = "uploadFileMultipartLegacy" // upload file using legacy multipart method
-	AuditEventUploadFileSimple          = "uploadFileSimple"          // upload file using simple direct upload method
+	AuditEventCutOverFileStorageMigration = "cutOverFileStorageMigration" // switch file storage to the migration target
+	AuditEventGetFile                     = "getFile"                     // get or download file
+	AuditEventGetFileLink                 = "getFileLink"                 // generate link for file sharing
+	AuditEventUploadFileMultipart         = "uploadFileMultipart"         // upload file using multipart form data
+	AuditEventUploadFileMultipartLegacy   = "uploadFileMultipartLegacy"   // upload file using legacy multipart method
+	AuditEventUploadFileSimple            = "uploadFileSimple"            // upload file using simple direct upload method
 )
 
 // Groups
@@ -208,6 +214,11 @@ const (
 	AuditEventRequestTrialLicense = "requestTrialLicense" // request trial license
 )
 
+// Notification Rules
+const (
+	AuditEventUpdateNotificationRules = "updateNotificationRules" // replace personal notification rules of user
+)
+
 // OAuth
 const (
 	AuditEventAuthorizeOAuthApp                          = "authorizeOAuthApp"                          // authorize OAuth app
@@ -293,9 +304,12 @@ const (
 
 // Scheduled Posts
 const (
-	AuditEventCreateSchedulePost  = "createSchedulePost"  // create post scheduled for future delivery
-	AuditEventDeleteScheduledPost = "deleteScheduledPost" // delete scheduled post before delivery
-	AuditEventUpdateScheduledPost = "updateScheduledPost" // update scheduled post
+	AuditEventCreateSchedulePost          = "createSchedulePost"          // create post scheduled for future delivery
+	AuditEventDeleteScheduledPost         = "deleteScheduledPost"         // delete scheduled post before delivery
+	AuditEventPauseScheduledPost          = "pauseScheduledPost"          // pause recurring scheduled post
+	AuditEventResumeScheduledPost         = "resumeScheduledPost"         // resume paused recurring scheduled post
+	AuditEventSkipScheduledPostOccurrence = "skipScheduledPostOccurrence" // skip next occurrence of recurring scheduled post
+	AuditEventUpdateScheduledPost         = "updateScheduledPost"         // update scheduled post
 )
 
 // Schemes
@@ -380,10 +394,12 @@ const (
 // Users
 const (
 	AuditEventAttachDeviceId               = "attachDeviceId"               // attach device ID to user session for mobile app
+	AuditEventAttachWebPushSubscription    = "attachWebPushSubscription"    // attach browser push subscription to user session
 	AuditEventCreateUser                   = "createUser"                   // create user account
 	AuditEventCreateUserAccessToken        = "createUserAccessToken"        // create personal access token for user API access
 	AuditEventDeleteUser                   = "deleteUser"                   // delete user account
 	AuditEventDemoteUserToGuest            = "demoteUserToGuest"            // demote regular user to guest account with limited permissions
+	AuditEventDetachWebPushSubscription    = "detachWebPushSubscription"    // detach browser push subscription from user session
 	AuditEventDisableUserAccessToken       = "disableUserAccessToken"       // disable user personal access token
 	AuditEventEnableUserAccessToken        = "enableUserAccessToken"        // enable user personal access token
 	AuditEventExtendSessionExpiry          = "extendSessionExpiry"          // extend user session expiration time
@@ -418,16 +434,20 @@ const (
 
 // Webhooks
 const (
-	AuditEventCreateIncomingHook      = "createIncomingHook"      // create incoming webhook
-	AuditEventCreateOutgoingHook      = "createOutgoingHook"      // create outgoing webhook
-	AuditEventDeleteIncomingHook      = "deleteIncomingHook"      // delete incoming webhook
-	AuditEventDeleteOutgoingHook      = "deleteOutgoingHook"      // delete outgoing webhook
-	AuditEventGetIncomingHook         = "getIncomingHook"         // get incoming webhook details
-	AuditEventGetOutgoingHook         = "getOutgoingHook"         // get outgoing webhook details
-	AuditEventLocalCreateIncomingHook = "localCreateIncomingHook" // create incoming webhook locally
-	AuditEventRegenOutgoingHookToken  = "regenOutgoingHookToken"  // regenerate authentication token
-	AuditEventUpdateIncomingHook      = "updateIncomingHook"      // update incoming webhook
-	AuditEventUpdateOutgoingHook      = "updateOutgoingHook"      // update outgoing webhook
+	AuditEventCreateEventSubscription      = "createEventSubscription"      // create server event subscription
+	AuditEventCreateIncomingHook           = "createIncomingHook"           // create incoming webhook
+	AuditEventCreateOutgoingHook           = "createOutgoingHook"           // create outgoing webhook
+	AuditEventDeleteEventSubscription      = "deleteEventSubscription"      // delete server event subscription
+	AuditEventDeleteIncomingHook           = "deleteIncomingHook"           // delete incoming webhook
+	AuditEventDeleteOutgoingHook           = "deleteOutgoingHook"           // delete outgoing webhook
+	AuditEventGetIncomingHook              = "getIncomingHook"              // get incoming webhook details
+	AuditEventGetOutgoingHook              = "getOutgoingHook"              // get outgoing webhook details
+	AuditEventLocalCreateIncomingHook      = "localCreateIncomingHook"      // create incoming webhook locally
+	AuditEventRegenEventSubscriptionSecret = "regenEventSubscriptionSecret" // regenerate event subscription signing secret
+	AuditEventRegenOutgoingHookToken       = "regenOutgoingHookToken"       // regenerate authentication token
+	AuditEventUpdateEventSubscription      = "updateEventSubscription"      // update server event subscription
+	AuditEventUpdateIncomingHook           = "updateIncomingHook"           // update incoming webhook
+	AuditEventUpdateOutgoingHook           = "updateOutgoingHook"           // update outgoing webhook
 )
 
 // Content Flagging
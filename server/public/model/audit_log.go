@@ -0,0 +1,176 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+)
+
+const (
+	AuditLogSearchDefaultPerPage = 60
+	AuditLogSearchMaxPerPage     = 200
+
+	// AuditLogHashKeyMinLength is the minimum length of the key of the audit log hashes.
+	AuditLogHashKeyMinLength = 32
+
+	// AuditLogIssueGap reports missing sequence numbers, i.e. removed entries.
+	AuditLogIssueGap = "gap"
+	// AuditLogIssueBrokenChain reports an entry not chained to the one preceding it.
+	AuditLogIssueBrokenChain = "broken_chain"
+	// AuditLogIssueModified reports an entry whose content does not match its hash.
+	AuditLogIssueModified = "modified"
+	// AuditLogIssueTruncated reports entries removed from the end of the log, i.e. entries
+	// missing up to the last anchored one.
+	AuditLogIssueTruncated = "truncated"
+	// AuditLogIssueInvalidAnchor reports an anchor not signed with the key of the log.
+	AuditLogIssueInvalidAnchor = "invalid_anchor"
+)
+
+// AuditLogEntry is an audit record kept by the database audit sink. Entries are numbered
+// sequentially and each one includes the hash of the entry preceding it, so that removed,
+// reordered or modified entries can be detected. Hashes are keyed by a secret kept out of
+// the database, so that they can't be recomputed by someone who modifies the log.
+type AuditLogEntry struct {
+	Id        string `json:"id"`
+	Sequence  int64  `json:"sequence"`
+	CreateAt  int64  `json:"create_at"`
+	EventName string `json:"event_name"`
+	Status    string `json:"status"`
+	ActorId   string `json:"actor_id"`
+	ObjectId  string `json:"object_id"`
+	// Record is the complete audit record, serialized as JSON.
+	Record   string `json:"record"`
+	PrevHash string `json:"prev_hash"`
+	Hash     string `json:"hash"`
+}
+
+// NewAuditLogEntry creates an entry for the given audit record. Its sequence number and
+// hashes are set when it is appended to the log.
+func NewAuditLogEntry(rec AuditRecord) (*AuditLogEntry, error) {
+	data, err := json.Marshal(rec)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AuditLogEntry{
+		Id:        NewId(),
+		CreateAt:  GetMillis(),
+		EventName: rec.EventName,
+		Status:    rec.Status,
+		ActorId:   rec.Actor.UserId,
+		ObjectId:  auditRecordObjectId(rec),
+		Record:    string(data),
+	}, nil
+}
+
+// auditRecordObjectId returns the id of the object affected by the audited event, if any.
+func auditRecordObjectId(rec AuditRecord) string {
+	for _, state := range []map[string]any{rec.EventData.ResultState, rec.EventData.PriorState, rec.EventData.Parameters} {
+		if id, ok := state["id"].(string); ok && id != "" {
+			return id
+		}
+	}
+	return ""
+}
+
+// ComputeHash returns the HMAC-SHA256 of the entry keyed by key, covering its content and
+// the hash of the previous entry.
+func (e *AuditLogEntry) ComputeHash(key []byte) string {
+	// Marshalling a slice of scalars is deterministic.
+	data, _ := json.Marshal([]any{e.Id, e.Sequence, e.CreateAt, e.EventName, e.Status, e.ActorId, e.ObjectId, e.Record, e.PrevHash})
+	return computeAuditLogMac(key, data)
+}
+
+func computeAuditLogMac(key, data []byte) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write(data)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// AuditLogAnchor records the head of the audit log outside of the database, so that entries
+// removed from the end of the log are detected.
+type AuditLogAnchor struct {
+	Sequence int64  `json:"sequence"`
+	Hash     string `json:"hash"`
+	CreateAt int64  `json:"create_at"`
+	Mac      string `json:"mac"`
+}
+
+// ComputeMac returns the HMAC-SHA256 of the anchor keyed by key.
+func (a *AuditLogAnchor) ComputeMac(key []byte) string {
+	data, _ := json.Marshal([]any{a.Sequence, a.Hash, a.CreateAt})
+	return computeAuditLogMac(key, data)
+}
+
+// IsValid reports whether the anchor was signed with key.
+func (a *AuditLogAnchor) IsValid(key []byte) bool {
+	return hmac.Equal([]byte(a.Mac), []byte(a.ComputeMac(key)))
+}
+
+func (e *AuditLogEntry) IsValid() *AppError {
+	if !IsValidId(e.Id) {
+		return NewAppError("AuditLogEntry.IsValid", "model.audit_log_entry.is_valid.id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if e.CreateAt == 0 {
+		return NewAppError("AuditLogEntry.IsValid", "model.audit_log_entry.is_valid.create_at.app_error", nil, "id="+e.Id, http.StatusBadRequest)
+	}
+
+	if e.EventName == "" || len(e.EventName) > 128 {
+		return NewAppError("AuditLogEntry.IsValid", "model.audit_log_entry.is_valid.event_name.app_error", nil, "id="+e.Id, http.StatusBadRequest)
+	}
+
+	if len(e.Status) > 32 || len(e.ActorId) > 128 || len(e.ObjectId) > 128 {
+		return NewAppError("AuditLogEntry.IsValid", "model.audit_log_entry.is_valid.too_long.app_error", nil, "id="+e.Id, http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+// AuditLogSearch filters the entries of the audit log. Empty fields match any entry.
+type AuditLogSearch struct {
+	ActorId   string `json:"actor_id,omitempty"`
+	EventName string `json:"event_name,omitempty"`
+	ObjectId  string `json:"object_id,omitempty"`
+	Status    string `json:"status,omitempty"`
+	// StartTime and EndTime bound the creation time of the entries, in milliseconds.
+	StartTime int64 `json:"start_time,omitempty"`
+	EndTime   int64 `json:"end_time,omitempty"`
+	Page      int   `json:"page"`
+	PerPage   int   `json:"per_page"`
+}
+
+func (s AuditLogSearch) Auditable() map[string]any {
+	return map[string]any{
+		"actor_id":   s.ActorId,
+		"event_name": s.EventName,
+		"object_id":  s.ObjectId,
+		"status":     s.Status,
+		"start_time": s.StartTime,
+		"end_time":   s.EndTime,
+		"page":       s.Page,
+		"per_page":   s.PerPage,
+	}
+}
+
+// AuditLogVerification is the result of checking the integrity of the audit log.
+type AuditLogVerification struct {
+	Verified bool             `json:"verified"`
+	Checked  int64            `json:"checked"`
+	Issues   []*AuditLogIssue `json:"issues"`
+}
+
+// AuditLogIssue is an integrity problem found in the audit log.
+type AuditLogIssue struct {
+	// Sequence is the sequence number of the affected entry. For gaps and truncations, it
+	// is the first missing sequence number.
+	Sequence int64  `json:"sequence"`
+	Type     string `json:"type"`
+	// Count is the number of missing entries for gaps and truncations.
+	Count int64 `json:"count,omitempty"`
+}
@@ -0,0 +1,60 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewAuditLogEntry(t *testing.T) {
+	rec := AuditRecord{EventName: AuditEventPatchChannel, Status: AuditStatusSuccess}
+	rec.Actor.UserId = NewId()
+	channelID := NewId()
+	AddEventParameterToAuditRec(&rec, "id", channelID)
+
+	entry, err := NewAuditLogEntry(rec)
+	require.NoError(t, err)
+	require.Nil(t, entry.IsValid())
+	assert.Equal(t, rec.Actor.UserId, entry.ActorId)
+	assert.Equal(t, channelID, entry.ObjectId)
+	assert.Contains(t, entry.Record, channelID)
+
+	rec.EventData.ResultState = map[string]any{"id": "result"}
+	entry, err = NewAuditLogEntry(rec)
+	require.NoError(t, err)
+	assert.Equal(t, "result", entry.ObjectId)
+}
+
+func TestAuditLogEntryComputeHash(t *testing.T) {
+	key := []byte(NewRandomString(AuditLogHashKeyMinLength))
+	entry := &AuditLogEntry{Id: NewId(), Sequence: 1, CreateAt: GetMillis(), EventName: AuditEventPatchChannel}
+	hash := entry.ComputeHash(key)
+	assert.Len(t, hash, 64)
+	assert.Equal(t, hash, entry.ComputeHash(key))
+	assert.NotEqual(t, hash, entry.ComputeHash([]byte(NewRandomString(AuditLogHashKeyMinLength))))
+
+	entry.Hash = "ignored"
+	assert.Equal(t, hash, entry.ComputeHash(key))
+
+	entry.PrevHash = hash
+	assert.NotEqual(t, hash, entry.ComputeHash(key))
+	entry.PrevHash = ""
+
+	entry.Status = AuditStatusFail
+	assert.NotEqual(t, hash, entry.ComputeHash(key))
+}
+
+func TestAuditLogAnchor(t *testing.T) {
+	key := []byte(NewRandomString(AuditLogHashKeyMinLength))
+	anchor := &AuditLogAnchor{Sequence: 42, Hash: NewId(), CreateAt: GetMillis()}
+	anchor.Mac = anchor.ComputeMac(key)
+	assert.True(t, anchor.IsValid(key))
+	assert.False(t, anchor.IsValid([]byte(NewRandomString(AuditLogHashKeyMinLength))))
+
+	anchor.Sequence = 41
+	assert.False(t, anchor.IsValid(key))
+}
@@ -56,12 +56,14 @@ func (c *ChannelBannerInfo) Scan(value any) error {
 		return nil
 	}
 
-	b, ok := value.([]byte)
-	if !ok {
-		return fmt.Errorf("expected []byte, got %T", value)
+	switch b := value.(type) {
+	case []byte:
+		return json.Unmarshal(b, c)
+	case string:
+		return json.Unmarshal([]byte(b), c)
 	}
 
-	return json.Unmarshal(b, c)
+	return fmt.Errorf("expected []byte or string, got %T", value)
 }
 
 func (c ChannelBannerInfo) Value() (driver.Value, error) {
@@ -379,6 +379,10 @@ func (c *Client4) testS3Route() string {
 	return "/file/s3_test"
 }
 
+func (c *Client4) fileStorageMigrationRoute() string {
+	return "/file/storage_migration"
+}
+
 func (c *Client4) databaseRoute() string {
 	return "/database"
 }
@@ -419,6 +423,14 @@ func (c *Client4) outgoingWebhookRoute(hookID string) string {
 	return fmt.Sprintf(c.outgoingWebhooksRoute()+"/%v", hookID)
 }
 
+func (c *Client4) eventSubscriptionsRoute() string {
+	return "/hooks/events"
+}
+
+func (c *Client4) eventSubscriptionRoute(subscriptionID string) string {
+	return fmt.Sprintf(c.eventSubscriptionsRoute()+"/%v", subscriptionID)
+}
+
 func (c *Client4) preferencesRoute(userId string) string {
 	return c.userRoute(userId) + "/preferences"
 }
@@ -1578,6 +1590,27 @@ func (c *Client4) AttachDeviceProps(ctx context.Context, newProps map[string]str
 	return BuildResponse(r), nil
 }
 
+// AttachWebPushSubscription attaches the push subscription of the browser to the current session,
+// for it to receive push notifications.
+func (c *Client4) AttachWebPushSubscription(ctx context.Context, subscription *WebPushSubscription) (*Response, error) {
+	r, err := c.DoAPIPutJSON(ctx, c.usersRoute()+"/sessions/web_push", subscription)
+	if err != nil {
+		return BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return BuildResponse(r), nil
+}
+
+// DetachWebPushSubscription stops sending push notifications to the browser of the current session.
+func (c *Client4) DetachWebPushSubscription(ctx context.Context) (*Response, error) {
+	r, err := c.DoAPIDelete(ctx, c.usersRoute()+"/sessions/web_push")
+	if err != nil {
+		return BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return BuildResponse(r), nil
+}
+
 // GetTeamsUnreadForUser will return an array with TeamUnread objects that contain the amount
 // of unread messages and mentions the current user has for the teams it belongs to.
 // An optional team ID can be set to exclude that team from the results.
@@ -3521,6 +3554,33 @@ func (c *Client4) DeleteScheduledPost(ctx context.Context, scheduledPostId strin
 	return DecodeJSONFromResponse[*ScheduledPost](r)
 }
 
+func (c *Client4) SkipNextScheduledPostOccurrence(ctx context.Context, scheduledPostId string) (*ScheduledPost, *Response, error) {
+	r, err := c.DoAPIPost(ctx, c.postsRoute()+"/schedule/"+scheduledPostId+"/skip_next", "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return DecodeJSONFromResponse[*ScheduledPost](r)
+}
+
+func (c *Client4) PauseScheduledPost(ctx context.Context, scheduledPostId string) (*ScheduledPost, *Response, error) {
+	r, err := c.DoAPIPost(ctx, c.postsRoute()+"/schedule/"+scheduledPostId+"/pause", "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return DecodeJSONFromResponse[*ScheduledPost](r)
+}
+
+func (c *Client4) ResumeScheduledPost(ctx context.Context, scheduledPostId string) (*ScheduledPost, *Response, error) {
+	r, err := c.DoAPIPost(ctx, c.postsRoute()+"/schedule/"+scheduledPostId+"/resume", "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return DecodeJSONFromResponse[*ScheduledPost](r)
+}
+
 func (c *Client4) GetPostsForReporting(ctx context.Context, options ReportPostOptions, cursor ReportPostOptionsCursor) (*ReportPostListResponse, *Response, error) {
 	request := struct {
 		ReportPostOptions
@@ -4044,6 +4104,18 @@ func (c *Client4) TestS3Connection(ctx context.Context, config *Config) (*Respon
 	return BuildResponse(r), nil
 }
 
+// CutOverFileStorageMigration switches the file storage to the storage
+// migration target once its files have been copied. The server needs to be
+// restarted for the change to take effect.
+func (c *Client4) CutOverFileStorageMigration(ctx context.Context) (*Response, error) {
+	r, err := c.DoAPIPost(ctx, c.fileStorageMigrationRoute()+"/cutover", "")
+	if err != nil {
+		return BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return BuildResponse(r), nil
+}
+
 // GetConfig will retrieve the server config with some sanitized items.
 func (c *Client4) GetConfig(ctx context.Context) (*Config, *Response, error) {
 	r, err := c.DoAPIGet(ctx, c.configRoute(), "")
@@ -4108,6 +4180,42 @@ func (c *Client4) GetEnvironmentConfig(ctx context.Context) (map[string]any, *Re
 	return StringInterfaceFromJSON(r.Body), BuildResponse(r), nil
 }
 
+// GetConfigHistory will retrieve the saved versions of the server configuration, most recent first.
+func (c *Client4) GetConfigHistory(ctx context.Context) ([]*ConfigVersion, *Response, error) {
+	r, err := c.DoAPIGet(ctx, c.configRoute()+"/history", "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return DecodeJSONFromResponse[[]*ConfigVersion](r)
+}
+
+// DiffConfigVersions will retrieve the redacted differences between two saved versions of the
+// server configuration. An empty to compares against the active version.
+func (c *Client4) DiffConfigVersions(ctx context.Context, from, to string) ([]*ConfigVersionDiff, *Response, error) {
+	values := url.Values{}
+	values.Set("from", from)
+	if to != "" {
+		values.Set("to", to)
+	}
+	r, err := c.DoAPIGet(ctx, c.configRoute()+"/history/diff?"+values.Encode(), "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return DecodeJSONFromResponse[[]*ConfigVersionDiff](r)
+}
+
+// RollbackConfig will restore a saved version of the server configuration.
+func (c *Client4) RollbackConfig(ctx context.Context, versionID string) (*Response, error) {
+	r, err := c.DoAPIPost(ctx, c.configRoute()+"/history/"+versionID+"/rollback", "")
+	if err != nil {
+		return BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return BuildResponse(r), nil
+}
+
 // GetOldClientLicense will retrieve the parts of the server license needed by the
 // client, formatted in the old format.
 func (c *Client4) GetOldClientLicense(ctx context.Context, etag string) (map[string]string, *Response, error) {
@@ -4403,6 +4511,75 @@ func (c *Client4) DeleteOutgoingWebhook(ctx context.Context, hookId string) (*Re
 	return BuildResponse(r), nil
 }
 
+// Event Subscriptions Section
+
+// CreateEventSubscription registers an endpoint that receives server events.
+func (c *Client4) CreateEventSubscription(ctx context.Context, subscription *EventSubscription) (*EventSubscription, *Response, error) {
+	r, err := c.DoAPIPostJSON(ctx, c.eventSubscriptionsRoute(), subscription)
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return DecodeJSONFromResponse[*EventSubscription](r)
+}
+
+// UpdateEventSubscription updates the endpoint, events or scope of an event subscription.
+func (c *Client4) UpdateEventSubscription(ctx context.Context, subscription *EventSubscription) (*EventSubscription, *Response, error) {
+	r, err := c.DoAPIPutJSON(ctx, c.eventSubscriptionRoute(subscription.Id), subscription)
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return DecodeJSONFromResponse[*EventSubscription](r)
+}
+
+// GetEventSubscriptions returns a page of event subscriptions, optionally only those scoped to
+// teamID. Page counting starts at 0.
+func (c *Client4) GetEventSubscriptions(ctx context.Context, teamID string, page int, perPage int) ([]*EventSubscription, *Response, error) {
+	values := url.Values{}
+	values.Set("page", strconv.Itoa(page))
+	values.Set("per_page", strconv.Itoa(perPage))
+	if teamID != "" {
+		values.Set("team_id", teamID)
+	}
+	r, err := c.DoAPIGet(ctx, c.eventSubscriptionsRoute()+"?"+values.Encode(), "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return DecodeJSONFromResponse[[]*EventSubscription](r)
+}
+
+// GetEventSubscription returns the event subscription requested by id.
+func (c *Client4) GetEventSubscription(ctx context.Context, subscriptionID string) (*EventSubscription, *Response, error) {
+	r, err := c.DoAPIGet(ctx, c.eventSubscriptionRoute(subscriptionID), "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return DecodeJSONFromResponse[*EventSubscription](r)
+}
+
+// RegenEventSubscriptionSecret regenerates the secret deliveries of an event subscription are signed with.
+func (c *Client4) RegenEventSubscriptionSecret(ctx context.Context, subscriptionID string) (*EventSubscription, *Response, error) {
+	r, err := c.DoAPIPost(ctx, c.eventSubscriptionRoute(subscriptionID)+"/regen_secret", "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return DecodeJSONFromResponse[*EventSubscription](r)
+}
+
+// DeleteEventSubscription deletes the event subscription requested by id.
+func (c *Client4) DeleteEventSubscription(ctx context.Context, subscriptionID string) (*Response, error) {
+	r, err := c.DoAPIDelete(ctx, c.eventSubscriptionRoute(subscriptionID))
+	if err != nil {
+		return BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return BuildResponse(r), nil
+}
+
 // Preferences Section
 
 // GetPreferences returns the user's preferences.
@@ -4968,6 +5145,47 @@ func (c *Client4) GetAudits(ctx context.Context, page int, perPage int, etag str
 	return DecodeJSONFromResponse[Audits](r)
 }
 
+// SearchAuditLog returns the entries of the database audit log matching the search, most recent first.
+func (c *Client4) SearchAuditLog(ctx context.Context, search AuditLogSearch) ([]*AuditLogEntry, *Response, error) {
+	values := url.Values{}
+	if search.ActorId != "" {
+		values.Set("actor_id", search.ActorId)
+	}
+	if search.EventName != "" {
+		values.Set("event_name", search.EventName)
+	}
+	if search.ObjectId != "" {
+		values.Set("object_id", search.ObjectId)
+	}
+	if search.Status != "" {
+		values.Set("status", search.Status)
+	}
+	if search.StartTime > 0 {
+		values.Set("start_time", strconv.FormatInt(search.StartTime, 10))
+	}
+	if search.EndTime > 0 {
+		values.Set("end_time", strconv.FormatInt(search.EndTime, 10))
+	}
+	values.Set("page", strconv.Itoa(search.Page))
+	values.Set("per_page", strconv.Itoa(search.PerPage))
+	r, err := c.DoAPIGet(ctx, "/audit_logs?"+values.Encode(), "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return DecodeJSONFromResponse[[]*AuditLogEntry](r)
+}
+
+// VerifyAuditLog checks the integrity of the database audit log.
+func (c *Client4) VerifyAuditLog(ctx context.Context) (*AuditLogVerification, *Response, error) {
+	r, err := c.DoAPIGet(ctx, "/audit_logs/verify", "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return DecodeJSONFromResponse[*AuditLogVerification](r)
+}
+
 // Brand Section
 
 // GetBrandImage retrieves the previously uploaded brand image.
@@ -5689,6 +5907,57 @@ func (c *Client4) UpdateUserCustomStatus(ctx context.Context, userId string, use
 	return userCustomStatus, BuildResponse(r), nil
 }
 
+// GetUserWorkingHours returns the working hours of a user based on the provided user id string.
+func (c *Client4) GetUserWorkingHours(ctx context.Context, userId string) (*WorkingHours, *Response, error) {
+	r, err := c.DoAPIGet(ctx, c.userStatusRoute(userId)+"/working_hours", "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return DecodeJSONFromResponse[*WorkingHours](r)
+}
+
+// UpdateUserWorkingHours sets the working hours of a user based on the provided user id string.
+func (c *Client4) UpdateUserWorkingHours(ctx context.Context, userId string, workingHours *WorkingHours) (*WorkingHours, *Response, error) {
+	r, err := c.DoAPIPutJSON(ctx, c.userStatusRoute(userId)+"/working_hours", workingHours)
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return DecodeJSONFromResponse[*WorkingHours](r)
+}
+
+// GetNotificationRules returns the personal notification rules of a user, in the order they apply.
+func (c *Client4) GetNotificationRules(ctx context.Context, userId string) ([]*NotificationRule, *Response, error) {
+	r, err := c.DoAPIGet(ctx, c.userRoute(userId)+"/notification_rules", "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return DecodeJSONFromResponse[[]*NotificationRule](r)
+}
+
+// UpdateNotificationRules replaces the personal notification rules of a user with the given
+// ordered list.
+func (c *Client4) UpdateNotificationRules(ctx context.Context, userId string, rules []*NotificationRule) ([]*NotificationRule, *Response, error) {
+	r, err := c.DoAPIPutJSON(ctx, c.userRoute(userId)+"/notification_rules", rules)
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return DecodeJSONFromResponse[[]*NotificationRule](r)
+}
+
+// TestNotificationRule returns the recent posts of the channels of a user the given rule matches.
+func (c *Client4) TestNotificationRule(ctx context.Context, userId string, rule *NotificationRule) (*NotificationRuleTestResult, *Response, error) {
+	r, err := c.DoAPIPostJSON(ctx, c.userRoute(userId)+"/notification_rules/test", rule)
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return DecodeJSONFromResponse[*NotificationRuleTestResult](r)
+}
+
 // RemoveUserCustomStatus remove a user's custom status based on the provided user id string.
 func (c *Client4) RemoveUserCustomStatus(ctx context.Context, userId string) (*Response, error) {
 	r, err := c.DoAPIDelete(ctx, c.userStatusRoute(userId)+"/custom")
@@ -7404,6 +7673,22 @@ func (c *Client4) GetAppliedSchemaMigrations(ctx context.Context) ([]AppliedMigr
 	return DecodeJSONFromResponse[[]AppliedMigration](r)
 }
 
+// GetRateLimitCounters returns the state of the API rate limits of a key, for every rule or
+// only the given one when rule isn't empty.
+func (c *Client4) GetRateLimitCounters(ctx context.Context, key, rule string) ([]*RateLimitCounter, *Response, error) {
+	values := url.Values{}
+	values.Set("key", key)
+	if rule != "" {
+		values.Set("rule", rule)
+	}
+	r, err := c.DoAPIGet(ctx, c.systemRoute()+"/rate_limits?"+values.Encode(), "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return DecodeJSONFromResponse[[]*RateLimitCounter](r)
+}
+
 // Usage Section
 
 // GetPostsUsage returns rounded off total usage of posts for the instance
@@ -0,0 +1,23 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+// ConfigVersion is a saved version of the configuration.
+type ConfigVersion struct {
+	Id       string `json:"id"`
+	CreateAt int64  `json:"create_at"`
+	// AuthorId is the user who saved the version. It is empty for versions saved by the
+	// server itself or from the command line.
+	AuthorId string `json:"author_id,omitempty"`
+	// Active is true for the version currently in use.
+	Active bool `json:"active"`
+}
+
+// ConfigVersionDiff is a setting that differs between two versions of the configuration.
+// Sensitive values are redacted.
+type ConfigVersionDiff struct {
+	Path      string `json:"path"`
+	BaseVal   any    `json:"base_val"`
+	ActualVal any    `json:"actual_val"`
+}
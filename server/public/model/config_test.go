@@ -4,6 +4,7 @@
 package model
 
 import (
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"maps"
@@ -108,6 +109,25 @@ func TestConfigIsValid(t *testing.T) {
 			require.Nil(t, c.IsValid())
 		})
 	})
+
+	t.Run("websocket event log", func(t *testing.T) {
+		c := Config{}
+		c.SetDefaults()
+		c.ServiceSettings.EnableWebSocketEventLog = NewPointer(true)
+		appErr := c.IsValid()
+		require.NotNil(t, appErr)
+		assert.Equal(t, "model.config.is_valid.websocket_event_log_redis.app_error", appErr.Id)
+
+		c.CacheSettings.CacheType = NewPointer(CacheTypeRedis)
+		c.CacheSettings.RedisAddress = NewPointer("localhost:6379")
+		c.CacheSettings.RedisDB = NewPointer(0)
+		require.Nil(t, c.IsValid())
+
+		c.ServiceSettings.WebSocketEventLogMaxEvents = NewPointer(0)
+		appErr = c.IsValid()
+		require.NotNil(t, appErr)
+		assert.Equal(t, "model.config.is_valid.websocket_event_log_max_events.app_error", appErr.Id)
+	})
 }
 
 func TestConfigEmptySiteName(t *testing.T) {
@@ -208,6 +228,101 @@ func TestConfigDefaultFileSettingsS3SSE(t *testing.T) {
 	require.False(t, *c1.FileSettings.AmazonS3SSE)
 }
 
+func TestFileSettingsEncryptionValidation(t *testing.T) {
+	validKey := base64.StdEncoding.EncodeToString(make([]byte, FileEncryptionMasterKeyLength))
+
+	testCases := []struct {
+		name    string
+		setup   func(fs *FileSettings)
+		errorID string
+	}{
+		{"disabled", func(fs *FileSettings) { fs.EnableEncryption = NewPointer(false) }, ""},
+		{"valid master key", func(fs *FileSettings) {
+			fs.EncryptionMasterKey = NewPointer(validKey)
+			fs.EncryptionPreviousMasterKeys = []string{validKey}
+		}, ""},
+		{"missing master key", func(fs *FileSettings) {}, "model.config.is_valid.file_encryption_master_key.app_error"},
+		{"short master key", func(fs *FileSettings) {
+			fs.EncryptionMasterKey = NewPointer(base64.StdEncoding.EncodeToString([]byte("short")))
+		}, "model.config.is_valid.file_encryption_master_key.app_error"},
+		{"invalid previous master key", func(fs *FileSettings) {
+			fs.EncryptionMasterKey = NewPointer(validKey)
+			fs.EncryptionPreviousMasterKeys = []string{"not base64"}
+		}, "model.config.is_valid.file_encryption_master_key.app_error"},
+		{"local kms", func(fs *FileSettings) {
+			fs.EncryptionKeyProvider = NewPointer(FileEncryptionKeyProviderLocalKMS)
+			fs.EncryptionKeyringFile = NewPointer("./config/keyring.json")
+		}, ""},
+		{"local kms without keyring file", func(fs *FileSettings) {
+			fs.EncryptionKeyProvider = NewPointer(FileEncryptionKeyProviderLocalKMS)
+		}, "model.config.is_valid.file_encryption_keyring_file.app_error"},
+		{"unknown key provider", func(fs *FileSettings) {
+			fs.EncryptionKeyProvider = NewPointer("vault")
+		}, "model.config.is_valid.file_encryption_key_provider.app_error"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := &Config{}
+			cfg.SetDefaults()
+			cfg.FileSettings.EnableEncryption = NewPointer(true)
+			tc.setup(&cfg.FileSettings)
+
+			err := cfg.FileSettings.isValid()
+			if tc.errorID == "" {
+				require.Nil(t, err)
+			} else {
+				require.NotNil(t, err)
+				require.Equal(t, tc.errorID, err.Id)
+			}
+		})
+	}
+}
+
+func TestFileSettingsMigration(t *testing.T) {
+	t.Run("validation", func(t *testing.T) {
+		fs := &FileSettings{}
+		fs.SetDefaults(false)
+		require.Nil(t, fs.isValid())
+
+		fs.MigrationDriverName = NewPointer("ftp")
+		appErr := fs.isValid()
+		require.NotNil(t, appErr)
+		require.Equal(t, "model.config.is_valid.file_migration_driver.app_error", appErr.Id)
+
+		fs.MigrationDriverName = NewPointer(ImageDriverS3)
+		fs.MigrationAmazonS3StorageClass = NewPointer("UNKNOWN")
+		appErr = fs.isValid()
+		require.NotNil(t, appErr)
+		require.Equal(t, "model.config.is_valid.storage_class.app_error", appErr.Id)
+	})
+
+	t.Run("swap migration target", func(t *testing.T) {
+		fs := &FileSettings{}
+		fs.SetDefaults(false)
+		fs.MigrationDriverName = NewPointer(ImageDriverS3)
+		fs.MigrationAmazonS3Bucket = NewPointer("new-bucket")
+		fs.MigrationAmazonS3SecretAccessKey = NewPointer("secret")
+
+		fs.SwapMigrationTarget()
+		assert.Equal(t, ImageDriverS3, *fs.DriverName)
+		assert.Equal(t, "new-bucket", *fs.AmazonS3Bucket)
+		assert.Equal(t, "secret", *fs.AmazonS3SecretAccessKey)
+		assert.Equal(t, ImageDriverLocal, *fs.MigrationDriverName)
+		assert.Equal(t, FileSettingsDefaultDirectory, *fs.MigrationDirectory)
+		assert.Empty(t, *fs.MigrationAmazonS3Bucket)
+	})
+
+	t.Run("secret access key is sanitized", func(t *testing.T) {
+		c := Config{}
+		c.SetDefaults()
+		c.FileSettings.MigrationAmazonS3SecretAccessKey = NewPointer("secret")
+
+		c.Sanitize(nil, nil)
+		assert.Equal(t, FakeSetting, *c.FileSettings.MigrationAmazonS3SecretAccessKey)
+	})
+}
+
 func TestFileSettingsDirectoryWhitespaceValidation(t *testing.T) {
 	// Define Unicode whitespace characters to test
 	unicodeWhitespaces := []struct {
@@ -337,6 +452,159 @@ func TestWranglerSettingsIsValid(t *testing.T) {
 	}
 }
 
+func TestTracingSettingsIsValid(t *testing.T) {
+	for name, test := range map[string]struct {
+		Mutate  func(s *TracingSettings)
+		ErrorID string
+	}{
+		"disabled with invalid settings": {
+			Mutate: func(s *TracingSettings) {
+				*s.OTLPEndpoint = ""
+				*s.SampleRate = 2
+			},
+		},
+		"enabled with defaults": {
+			Mutate: func(s *TracingSettings) {
+				*s.Enable = true
+			},
+		},
+		"invalid endpoint": {
+			Mutate: func(s *TracingSettings) {
+				*s.Enable = true
+				*s.OTLPEndpoint = "localhost:4318"
+			},
+			ErrorID: "model.config.is_valid.tracing_otlp_endpoint.app_error",
+		},
+		"empty service name": {
+			Mutate: func(s *TracingSettings) {
+				*s.Enable = true
+				*s.ServiceName = ""
+			},
+			ErrorID: "model.config.is_valid.tracing_service_name.app_error",
+		},
+		"sample rate out of range": {
+			Mutate: func(s *TracingSettings) {
+				*s.Enable = true
+				*s.SampleRate = 1.5
+			},
+			ErrorID: "model.config.is_valid.tracing_sample_rate.app_error",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			settings := TracingSettings{}
+			settings.SetDefaults()
+			test.Mutate(&settings)
+
+			appErr := settings.isValid()
+			if test.ErrorID == "" {
+				require.Nil(t, appErr)
+			} else {
+				require.NotNil(t, appErr)
+				assert.Equal(t, test.ErrorID, appErr.Id)
+			}
+		})
+	}
+}
+
+func TestConfigIsValidSqlite(t *testing.T) {
+	for name, test := range map[string]struct {
+		Mutate  func(c *Config)
+		ErrorID string
+	}{
+		"single node": {
+			Mutate: func(c *Config) {},
+		},
+		"unknown driver": {
+			Mutate: func(c *Config) {
+				*c.SqlSettings.DriverName = "mysql"
+			},
+			ErrorID: "model.config.is_valid.sql_driver.app_error",
+		},
+		"postgres data source": {
+			Mutate: func(c *Config) {
+				*c.SqlSettings.DataSource = SqlSettingsDefaultDataSource
+			},
+			ErrorID: "model.config.is_valid.sqlite_data_src.app_error",
+		},
+		"read replicas": {
+			Mutate: func(c *Config) {
+				c.SqlSettings.DataSourceReplicas = []string{"replica.db"}
+			},
+			ErrorID: "model.config.is_valid.sqlite_replicas.app_error",
+		},
+		"cluster enabled": {
+			Mutate: func(c *Config) {
+				*c.ClusterSettings.Enable = true
+			},
+			ErrorID: "model.config.is_valid.sqlite_cluster.app_error",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			c := Config{SqlSettings: SqlSettings{DriverName: NewPointer(DatabaseDriverSqlite)}}
+			c.SetDefaults()
+			require.Equal(t, SqlSettingsDefaultSqliteDataSource, *c.SqlSettings.DataSource)
+			test.Mutate(&c)
+
+			appErr := c.IsValid()
+			if test.ErrorID == "" {
+				require.Nil(t, appErr)
+			} else {
+				require.NotNil(t, appErr)
+				assert.Equal(t, test.ErrorID, appErr.Id)
+			}
+		})
+	}
+}
+
+func TestConfigIsValidReplicaReads(t *testing.T) {
+	for name, test := range map[string]struct {
+		Mutate  func(c *Config)
+		ErrorID string
+	}{
+		"defaults": {
+			Mutate: func(c *Config) {},
+		},
+		"read-your-writes disabled": {
+			Mutate: func(c *Config) {
+				*c.SqlSettings.ReadYourWritesWindowSeconds = 0
+			},
+		},
+		"negative read-your-writes window": {
+			Mutate: func(c *Config) {
+				*c.SqlSettings.ReadYourWritesWindowSeconds = -1
+			},
+			ErrorID: "model.config.is_valid.sql_read_your_writes_window.app_error",
+		},
+		"maximum replica lag": {
+			Mutate: func(c *Config) {
+				*c.SqlSettings.ReplicaMaxLagSeconds = 30
+			},
+		},
+		"negative maximum replica lag": {
+			Mutate: func(c *Config) {
+				*c.SqlSettings.ReplicaMaxLagSeconds = -1
+			},
+			ErrorID: "model.config.is_valid.sql_replica_max_lag.app_error",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			c := Config{}
+			c.SetDefaults()
+			require.Equal(t, 5, *c.SqlSettings.ReadYourWritesWindowSeconds)
+			require.Equal(t, 0, *c.SqlSettings.ReplicaMaxLagSeconds)
+			test.Mutate(&c)
+
+			appErr := c.IsValid()
+			if test.ErrorID == "" {
+				require.Nil(t, appErr)
+			} else {
+				require.NotNil(t, appErr)
+				assert.Equal(t, test.ErrorID, appErr.Id)
+			}
+		})
+	}
+}
+
 func TestConfigIsValidDefaultAlgorithms(t *testing.T) {
 	c1 := Config{}
 	c1.SetDefaults()
@@ -635,6 +903,21 @@ func TestMessageExportSettingsIsValidActiance(t *testing.T) {
 	require.Nil(t, mes.isValid())
 }
 
+func TestMessageExportSettingsIsValidJsonlAndEml(t *testing.T) {
+	for _, format := range []string{ComplianceExportTypeJsonl, ComplianceExportTypeEml} {
+		mes := &MessageExportSettings{
+			EnableExport:        NewPointer(true),
+			ExportFormat:        NewPointer(format),
+			ExportFromTimestamp: NewPointer(int64(0)),
+			DailyRunTime:        NewPointer("15:04"),
+			BatchSize:           NewPointer(100),
+		}
+
+		// should pass because everything is valid
+		require.Nil(t, mes.isValid(), format)
+	}
+}
+
 func TestMessageExportSettingsIsValidGlobalRelaySettingsMissing(t *testing.T) {
 	mes := &MessageExportSettings{
 		EnableExport:        NewPointer(true),
@@ -1770,6 +2053,12 @@ func TestSanitizeDataSource(t *testing.T) {
 			assert.Equal(t, tc.Sanitized, out)
 		}
 	})
+
+	t.Run(DatabaseDriverSqlite, func(t *testing.T) {
+		out, err := SanitizeDataSource(DatabaseDriverSqlite, "/var/lib/mattermost/mattermost.db?_pragma=busy_timeout(5000)")
+		require.NoError(t, err)
+		assert.Equal(t, "/var/lib/mattermost/mattermost.db?_pragma=busy_timeout(5000)", out)
+	})
 }
 
 func TestConfigFilteredByTag(t *testing.T) {
@@ -1896,6 +2185,104 @@ func TestConfigImportSettingsIsValid(t *testing.T) {
 	require.Equal(t, "model.config.is_valid.import.retention_days_too_low.app_error", appErr.Id)
 }
 
+func TestConfigRateLimitSettingsIsValid(t *testing.T) {
+	newRule := func(name string) *RateLimitRule {
+		return &RateLimitRule{
+			Name:      NewPointer(name),
+			Routes:    []string{"/api/v4/posts", "/api/v4/channels/*/posts"},
+			Methods:   []string{"post", "PUT"},
+			AuthTypes: []string{RateLimitAuthTypeAccessToken, RateLimitAuthTypeBot},
+		}
+	}
+
+	cfg := Config{}
+	cfg.SetDefaults()
+	cfg.RateLimitSettings.Rules = []*RateLimitRule{newRule("posts")}
+	cfg.RateLimitSettings.SetDefaults()
+
+	require.Equal(t, RateLimitStoreTypeMemory, *cfg.RateLimitSettings.StoreType)
+	require.Equal(t, 60, *cfg.RateLimitSettings.Rules[0].PerMinute)
+	require.Equal(t, 0, *cfg.RateLimitSettings.Rules[0].MaxBurst)
+	require.Nil(t, cfg.RateLimitSettings.isValid())
+
+	testCases := []struct {
+		name       string
+		update     func(s *RateLimitSettings)
+		expectedID string
+	}{
+		{
+			name:       "unknown store",
+			update:     func(s *RateLimitSettings) { s.StoreType = NewPointer("memcached") },
+			expectedID: "model.config.is_valid.rate_limit_store_type.app_error",
+		},
+		{
+			name:       "empty rule name",
+			update:     func(s *RateLimitSettings) { s.Rules[0].Name = NewPointer("") },
+			expectedID: "model.config.is_valid.rate_limit_rule_name.app_error",
+		},
+		{
+			name:       "default rule name",
+			update:     func(s *RateLimitSettings) { s.Rules[0].Name = NewPointer(RateLimitDefaultRuleName) },
+			expectedID: "model.config.is_valid.rate_limit_rule_name.app_error",
+		},
+		{
+			name: "duplicate rule name",
+			update: func(s *RateLimitSettings) {
+				s.Rules = append(s.Rules, newRule("posts"))
+				s.SetDefaults()
+			},
+			expectedID: "model.config.is_valid.rate_limit_rule_name.app_error",
+		},
+		{
+			name:       "no rate",
+			update:     func(s *RateLimitSettings) { s.Rules[0].PerMinute = NewPointer(0) },
+			expectedID: "model.config.is_valid.rate_limit_rule_per_minute.app_error",
+		},
+		{
+			name:       "negative burst",
+			update:     func(s *RateLimitSettings) { s.Rules[0].MaxBurst = NewPointer(-1) },
+			expectedID: "model.config.is_valid.rate_limit_rule_max_burst.app_error",
+		},
+		{
+			name:       "relative route",
+			update:     func(s *RateLimitSettings) { s.Rules[0].Routes = []string{"api/v4/posts"} },
+			expectedID: "model.config.is_valid.rate_limit_rule_route.app_error",
+		},
+		{
+			name:       "unknown method",
+			update:     func(s *RateLimitSettings) { s.Rules[0].Methods = []string{"FETCH"} },
+			expectedID: "model.config.is_valid.rate_limit_rule_method.app_error",
+		},
+		{
+			name:       "unknown auth type",
+			update:     func(s *RateLimitSettings) { s.Rules[0].AuthTypes = []string{"cookie"} },
+			expectedID: "model.config.is_valid.rate_limit_rule_auth_type.app_error",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			settings := RateLimitSettings{Rules: []*RateLimitRule{newRule("posts")}}
+			settings.SetDefaults()
+			tc.update(&settings)
+
+			appErr := settings.isValid()
+			require.NotNil(t, appErr)
+			require.Equal(t, tc.expectedID, appErr.Id)
+		})
+	}
+
+	t.Run("redis store requires the redis cache", func(t *testing.T) {
+		cfg := Config{}
+		cfg.SetDefaults()
+		cfg.RateLimitSettings.StoreType = NewPointer(RateLimitStoreTypeRedis)
+
+		appErr := cfg.IsValid()
+		require.NotNil(t, appErr)
+		require.Equal(t, "model.config.is_valid.rate_limit_redis_store.app_error", appErr.Id)
+	})
+}
+
 func TestConfigExportSettingsDefaults(t *testing.T) {
 	cfg := Config{}
 	cfg.SetDefaults()
@@ -2152,6 +2539,95 @@ func TestConfigGetFileRetentionHours(t *testing.T) {
 	}
 }
 
+func TestDataRetentionSettingsLegalHold(t *testing.T) {
+	t.Run("defaults to empty lists", func(t *testing.T) {
+		c := Config{}
+		c.SetDefaults()
+		require.Empty(t, c.DataRetentionSettings.LegalHoldUserIds)
+		require.Empty(t, c.DataRetentionSettings.LegalHoldChannelIds)
+		require.Nil(t, c.DataRetentionSettings.isValid())
+	})
+
+	t.Run("accepts valid ids", func(t *testing.T) {
+		c := Config{}
+		c.SetDefaults()
+		c.DataRetentionSettings.LegalHoldUserIds = []string{NewId()}
+		c.DataRetentionSettings.LegalHoldChannelIds = []string{NewId(), NewId()}
+		require.Nil(t, c.DataRetentionSettings.isValid())
+	})
+
+	t.Run("rejects an invalid user id", func(t *testing.T) {
+		c := Config{}
+		c.SetDefaults()
+		c.DataRetentionSettings.LegalHoldUserIds = []string{"invalid"}
+		appErr := c.DataRetentionSettings.isValid()
+		require.NotNil(t, appErr)
+		require.Equal(t, "model.config.is_valid.data_retention.legal_hold_user_id.app_error", appErr.Id)
+	})
+
+	t.Run("rejects an invalid channel id", func(t *testing.T) {
+		c := Config{}
+		c.SetDefaults()
+		c.DataRetentionSettings.LegalHoldChannelIds = []string{"invalid"}
+		appErr := c.DataRetentionSettings.isValid()
+		require.NotNil(t, appErr)
+		require.Equal(t, "model.config.is_valid.data_retention.legal_hold_channel_id.app_error", appErr.Id)
+	})
+}
+
+func TestEmailSettingsBatchingWindow(t *testing.T) {
+	t.Run("defaults to no window", func(t *testing.T) {
+		c := Config{}
+		c.SetDefaults()
+		require.Empty(t, *c.EmailSettings.EmailBatchingWindowStart)
+		require.Empty(t, *c.EmailSettings.EmailBatchingWindowEnd)
+		require.Nil(t, c.EmailSettings.isValid())
+	})
+
+	t.Run("accepts a window", func(t *testing.T) {
+		c := Config{}
+		c.SetDefaults()
+		c.EmailSettings.EmailBatchingWindowStart = NewPointer("22:00")
+		c.EmailSettings.EmailBatchingWindowEnd = NewPointer("06:30")
+		require.Nil(t, c.EmailSettings.isValid())
+	})
+
+	t.Run("rejects a window without an end", func(t *testing.T) {
+		c := Config{}
+		c.SetDefaults()
+		c.EmailSettings.EmailBatchingWindowStart = NewPointer("08:00")
+		appErr := c.EmailSettings.isValid()
+		require.NotNil(t, appErr)
+		require.Equal(t, "model.config.is_valid.email_batching_window.app_error", appErr.Id)
+	})
+
+	t.Run("rejects an invalid time", func(t *testing.T) {
+		c := Config{}
+		c.SetDefaults()
+		c.EmailSettings.EmailBatchingWindowStart = NewPointer("8am")
+		c.EmailSettings.EmailBatchingWindowEnd = NewPointer("18:00")
+		appErr := c.EmailSettings.isValid()
+		require.NotNil(t, appErr)
+		require.Equal(t, "model.config.is_valid.email_batching_window.app_error", appErr.Id)
+	})
+}
+
+func TestEmailSettingsWebPushSubject(t *testing.T) {
+	for _, subject := range []string{"", "mailto:admin@example.com", "https://example.com"} {
+		c := Config{}
+		c.SetDefaults()
+		c.EmailSettings.WebPushSubject = NewPointer(subject)
+		require.Nil(t, c.EmailSettings.isValid(), subject)
+	}
+
+	c := Config{}
+	c.SetDefaults()
+	c.EmailSettings.WebPushSubject = NewPointer("admin@example.com")
+	appErr := c.EmailSettings.isValid()
+	require.NotNil(t, appErr)
+	require.Equal(t, "model.config.is_valid.web_push_subject.app_error", appErr.Id)
+}
+
 func TestConfigDefaultConnectedWorkspacesSettings(t *testing.T) {
 	t.Run("if the config is new, default values should be established", func(t *testing.T) {
 		c := Config{}
@@ -96,6 +96,10 @@ type RetentionPolicyBatchConfigs struct {
 	GlobalPolicyEndTime int64
 	Limit               int64
 	PreservePinnedPosts bool
+	// LegalHoldUserIDs and LegalHoldChannelIDs exclude the records of the
+	// given users and channels from the deletion.
+	LegalHoldUserIDs    []string
+	LegalHoldChannelIDs []string
 }
 
 func (r *RetentionIdsForDeletion) PreSave() {
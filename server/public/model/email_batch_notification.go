@@ -0,0 +1,25 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+// EmailBatchNotification is a notification email for a post, waiting to be sent to the user in the next batch.
+type EmailBatchNotification struct {
+	Id        string
+	UserId    string
+	PostId    string
+	ChannelId string
+	RootId    string
+	TeamName  string
+	CreateAt  int64
+}
+
+func (n *EmailBatchNotification) PreSave() {
+	if n.Id == "" {
+		n.Id = NewId()
+	}
+
+	if n.CreateAt == 0 {
+		n.CreateAt = GetMillis()
+	}
+}
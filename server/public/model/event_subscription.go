@@ -0,0 +1,192 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"net/http"
+	"slices"
+)
+
+// Server events that can be subscribed to. Deliveries for each of them carry
+// the payload of the WebSocket event the server broadcasts for the change.
+const (
+	EventSubscriptionEventChannelCreated         = "channel_created"
+	EventSubscriptionEventChannelUpdated         = "channel_updated"
+	EventSubscriptionEventChannelArchived        = "channel_archived"
+	EventSubscriptionEventUserAddedToTeam        = "user_added_to_team"
+	EventSubscriptionEventUserRemovedFromTeam    = "user_removed_from_team"
+	EventSubscriptionEventUserAddedToChannel     = "user_added_to_channel"
+	EventSubscriptionEventUserRemovedFromChannel = "user_removed_from_channel"
+	EventSubscriptionEventReactionAdded          = "reaction_added"
+	EventSubscriptionEventReactionRemoved        = "reaction_removed"
+	EventSubscriptionEventPostEdited             = "post_edited"
+	EventSubscriptionEventPostDeleted            = "post_deleted"
+	EventSubscriptionEventUserDeactivated        = "user_deactivated"
+
+	EventSubscriptionEventHeader     = "X-Mattermost-Event"
+	EventSubscriptionDeliveryHeader  = "X-Mattermost-Delivery"
+	EventSubscriptionSignatureHeader = IncomingWebhookSignatureHeader
+	EventSubscriptionTimestampHeader = IncomingWebhookTimestampHeader
+
+	EventSubscriptionMaxEvents = 64
+)
+
+// EventSubscriptionEvents lists every event an EventSubscription may include.
+var EventSubscriptionEvents = []string{
+	EventSubscriptionEventChannelCreated,
+	EventSubscriptionEventChannelUpdated,
+	EventSubscriptionEventChannelArchived,
+	EventSubscriptionEventUserAddedToTeam,
+	EventSubscriptionEventUserRemovedFromTeam,
+	EventSubscriptionEventUserAddedToChannel,
+	EventSubscriptionEventUserRemovedFromChannel,
+	EventSubscriptionEventReactionAdded,
+	EventSubscriptionEventReactionRemoved,
+	EventSubscriptionEventPostEdited,
+	EventSubscriptionEventPostDeleted,
+	EventSubscriptionEventUserDeactivated,
+}
+
+// EventSubscription registers an endpoint that receives signed deliveries for
+// a set of server events, optionally scoped to a team or channel.
+type EventSubscription struct {
+	Id          string      `json:"id"`
+	CreateAt    int64       `json:"create_at"`
+	UpdateAt    int64       `json:"update_at"`
+	DeleteAt    int64       `json:"delete_at"`
+	CreatorId   string      `json:"creator_id"`
+	TeamId      string      `json:"team_id"`
+	ChannelId   string      `json:"channel_id"`
+	DisplayName string      `json:"display_name"`
+	Description string      `json:"description"`
+	CallbackURL string      `json:"callback_url"`
+	Secret      string      `json:"secret"`
+	Events      StringArray `json:"events"`
+}
+
+func (o *EventSubscription) Auditable() map[string]any {
+	return map[string]any{
+		"id":           o.Id,
+		"create_at":    o.CreateAt,
+		"update_at":    o.UpdateAt,
+		"delete_at":    o.DeleteAt,
+		"creator_id":   o.CreatorId,
+		"team_id":      o.TeamId,
+		"channel_id":   o.ChannelId,
+		"display_name": o.DisplayName,
+		"description":  o.Description,
+		"callback_url": o.CallbackURL,
+		"events":       o.Events,
+	}
+}
+
+// EventSubscriptionDelivery is the body POSTed to a subscription's callback
+// URL. Data holds the WebSocket event payload for the change.
+type EventSubscriptionDelivery struct {
+	Id             string         `json:"id"`
+	SubscriptionId string         `json:"subscription_id"`
+	Event          string         `json:"event"`
+	CreateAt       int64          `json:"create_at"`
+	TeamId         string         `json:"team_id"`
+	ChannelId      string         `json:"channel_id"`
+	Data           map[string]any `json:"data"`
+}
+
+func (o *EventSubscription) IsValid() *AppError {
+	if !IsValidId(o.Id) {
+		return NewAppError("EventSubscription.IsValid", "model.event_subscription.id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if o.CreateAt == 0 {
+		return NewAppError("EventSubscription.IsValid", "model.event_subscription.create_at.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if o.UpdateAt == 0 {
+		return NewAppError("EventSubscription.IsValid", "model.event_subscription.update_at.app_error", nil, "id="+o.Id, http.StatusBadRequest)
+	}
+
+	if !IsValidId(o.CreatorId) {
+		return NewAppError("EventSubscription.IsValid", "model.event_subscription.creator_id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if o.TeamId != "" && !IsValidId(o.TeamId) {
+		return NewAppError("EventSubscription.IsValid", "model.event_subscription.team_id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if o.ChannelId != "" && !IsValidId(o.ChannelId) {
+		return NewAppError("EventSubscription.IsValid", "model.event_subscription.channel_id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if len(o.DisplayName) > 64 {
+		return NewAppError("EventSubscription.IsValid", "model.event_subscription.display_name.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if len(o.Description) > 500 {
+		return NewAppError("EventSubscription.IsValid", "model.event_subscription.description.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if len(o.CallbackURL) > 1024 || !IsValidHTTPURL(o.CallbackURL) {
+		return NewAppError("EventSubscription.IsValid", "model.event_subscription.callback_url.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if len(o.Secret) != 26 {
+		return NewAppError("EventSubscription.IsValid", "model.event_subscription.secret.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if len(o.Events) == 0 || len(o.Events) > EventSubscriptionMaxEvents {
+		return NewAppError("EventSubscription.IsValid", "model.event_subscription.events.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	for _, event := range o.Events {
+		if !slices.Contains(EventSubscriptionEvents, event) {
+			return NewAppError("EventSubscription.IsValid", "model.event_subscription.unknown_event.app_error", map[string]any{"Event": event}, "", http.StatusBadRequest)
+		}
+	}
+
+	return nil
+}
+
+func (o *EventSubscription) PreSave() {
+	if o.Id == "" {
+		o.Id = NewId()
+	}
+
+	if o.Secret == "" {
+		o.Secret = NewId()
+	}
+
+	o.CreateAt = GetMillis()
+	o.UpdateAt = o.CreateAt
+}
+
+func (o *EventSubscription) PreUpdate() {
+	o.UpdateAt = GetMillis()
+}
+
+// Matches returns whether a delivery for event, raised in the given team and
+// channel, should go to this subscription. Empty TeamId or ChannelId on the
+// subscription match any team or channel; events with no team, such as
+// user_deactivated, only reach subscriptions that aren't scoped to one.
+func (o *EventSubscription) Matches(event, teamID, channelID string) bool {
+	if o.DeleteAt != 0 || !o.Events.Contains(event) {
+		return false
+	}
+
+	if o.TeamId != "" && o.TeamId != teamID {
+		return false
+	}
+
+	if o.ChannelId != "" && o.ChannelId != channelID {
+		return false
+	}
+
+	return true
+}
+
+// ComputeEventSubscriptionSignature returns the signature of a delivery body
+// sent at timestamp (unix seconds). It uses the same scheme as signed incoming
+// webhooks, see ComputeIncomingWebhookSignature.
+func ComputeEventSubscriptionSignature(secret string, timestamp string, body []byte) string {
+	return computeWebhookSignature(secret, timestamp, body)
+}
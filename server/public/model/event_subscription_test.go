@@ -0,0 +1,113 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventSubscriptionIsValid(t *testing.T) {
+	o := EventSubscription{}
+	require.NotNil(t, o.IsValid())
+
+	o.Id = NewId()
+	require.NotNil(t, o.IsValid())
+
+	o.CreateAt = GetMillis()
+	require.NotNil(t, o.IsValid())
+
+	o.UpdateAt = GetMillis()
+	require.NotNil(t, o.IsValid())
+
+	o.CreatorId = "123"
+	require.NotNil(t, o.IsValid())
+
+	o.CreatorId = NewId()
+	o.TeamId = "123"
+	require.NotNil(t, o.IsValid())
+
+	o.TeamId = NewId()
+	o.ChannelId = "123"
+	require.NotNil(t, o.IsValid())
+
+	o.ChannelId = ""
+	o.DisplayName = strings.Repeat("1", 65)
+	require.NotNil(t, o.IsValid())
+
+	o.DisplayName = "events"
+	o.Description = strings.Repeat("1", 501)
+	require.NotNil(t, o.IsValid())
+
+	o.Description = ""
+	o.CallbackURL = "nowhere.com/"
+	require.NotNil(t, o.IsValid())
+
+	o.CallbackURL = "http://nowhere.com/"
+	require.NotNil(t, o.IsValid())
+
+	o.Secret = NewId()
+	require.NotNil(t, o.IsValid())
+
+	o.Events = StringArray{"not_an_event"}
+	require.NotNil(t, o.IsValid())
+
+	o.Events = StringArray{EventSubscriptionEventChannelCreated, EventSubscriptionEventPostEdited}
+	require.Nil(t, o.IsValid())
+}
+
+func TestEventSubscriptionPreSave(t *testing.T) {
+	o := EventSubscription{}
+	o.PreSave()
+
+	assert.True(t, IsValidId(o.Id))
+	assert.True(t, IsValidId(o.Secret))
+	assert.NotZero(t, o.CreateAt)
+	assert.Equal(t, o.CreateAt, o.UpdateAt)
+}
+
+func TestEventSubscriptionMatches(t *testing.T) {
+	teamID := NewId()
+	channelID := NewId()
+
+	global := &EventSubscription{Events: StringArray{EventSubscriptionEventUserDeactivated, EventSubscriptionEventPostEdited}}
+	assert.True(t, global.Matches(EventSubscriptionEventUserDeactivated, "", ""))
+	assert.True(t, global.Matches(EventSubscriptionEventPostEdited, teamID, channelID))
+	assert.False(t, global.Matches(EventSubscriptionEventPostDeleted, teamID, channelID))
+
+	team := &EventSubscription{TeamId: teamID, Events: StringArray{EventSubscriptionEventUserDeactivated, EventSubscriptionEventPostEdited}}
+	assert.False(t, team.Matches(EventSubscriptionEventUserDeactivated, "", ""))
+	assert.True(t, team.Matches(EventSubscriptionEventPostEdited, teamID, channelID))
+	assert.False(t, team.Matches(EventSubscriptionEventPostEdited, NewId(), channelID))
+
+	channel := &EventSubscription{TeamId: teamID, ChannelId: channelID, Events: StringArray{EventSubscriptionEventPostEdited}}
+	assert.True(t, channel.Matches(EventSubscriptionEventPostEdited, teamID, channelID))
+	assert.False(t, channel.Matches(EventSubscriptionEventPostEdited, teamID, NewId()))
+
+	channel.DeleteAt = GetMillis()
+	assert.False(t, channel.Matches(EventSubscriptionEventPostEdited, teamID, channelID))
+}
+
+func TestComputeEventSubscriptionSignature(t *testing.T) {
+	secret := "a-signing-secret-value"
+	body := []byte(`{"event":"post_edited"}`)
+	now := time.Now()
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+
+	signature := ComputeEventSubscriptionSignature(secret, timestamp, body)
+	assert.Equal(t, ComputeIncomingWebhookSignature(secret, timestamp, body), signature)
+
+	// Receivers verify deliveries the same way signed incoming webhooks are verified.
+	header := http.Header{}
+	header.Set(EventSubscriptionTimestampHeader, timestamp)
+	header.Set(EventSubscriptionSignatureHeader, signature)
+	hook := &IncomingWebhook{SigningSecret: secret}
+	assert.Nil(t, hook.VerifySignature(header, body, now))
+}
@@ -5,14 +5,33 @@ package model
 
 import (
 	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"io"
 	"net/http"
 	"regexp"
+	"strconv"
+	"strings"
+	"time"
 )
 
 const (
 	DefaultWebhookUsername = "webhook"
+
+	IncomingWebhookSignatureHeader = "X-Mattermost-Signature"
+	IncomingWebhookTimestampHeader = "X-Mattermost-Request-Timestamp"
+	IncomingWebhookSignatureV1     = "v1"
+
+	// IncomingWebhookSignatureTolerance is how far the request timestamp of a
+	// signed incoming webhook may drift from the server clock before the
+	// request is rejected as a potential replay.
+	IncomingWebhookSignatureTolerance = 5 * time.Minute
+
+	IncomingWebhookSigningSecretMinLength = 16
+	IncomingWebhookSigningSecretMaxLength = 128
+	IncomingWebhookPayloadTemplateMaxSize = 16 * 1024
 )
 
 type IncomingWebhook struct {
@@ -28,6 +47,15 @@ type IncomingWebhook struct {
 	Username      string `json:"username"`
 	IconURL       string `json:"icon_url"`
 	ChannelLocked bool   `json:"channel_locked"`
+	// SigningSecret, when set, requires every request to the hook to carry a
+	// valid HMAC-SHA256 signature of its timestamp and body.
+	SigningSecret string `json:"signing_secret,omitempty"`
+	// RemoveSigningSecret, when set on an update, removes the signing secret,
+	// which an empty SigningSecret would keep.
+	RemoveSigningSecret bool `db:"-" json:"remove_signing_secret,omitempty"`
+	// PayloadTemplate, when set, is a text/template that turns an arbitrary
+	// JSON payload into an IncomingWebhookRequest.
+	PayloadTemplate string `json:"payload_template,omitempty"`
 }
 
 func (o *IncomingWebhook) Auditable() map[string]any {
@@ -44,6 +72,8 @@ func (o *IncomingWebhook) Auditable() map[string]any {
 		"username":       o.Username,
 		"icon_url:":      o.IconURL,
 		"channel_locked": o.ChannelLocked,
+		"signed":         o.SigningSecret != "",
+		"templated":      o.PayloadTemplate != "",
 	}
 }
 
@@ -105,9 +135,82 @@ func (o *IncomingWebhook) IsValid() *AppError {
 		return NewAppError("IncomingWebhook.IsValid", "model.incoming_hook.icon_url.app_error", nil, "", http.StatusBadRequest)
 	}
 
+	if o.SigningSecret != "" && (len(o.SigningSecret) < IncomingWebhookSigningSecretMinLength || len(o.SigningSecret) > IncomingWebhookSigningSecretMaxLength) {
+		return NewAppError("IncomingWebhook.IsValid", "model.incoming_hook.signing_secret.app_error", map[string]any{"Min": IncomingWebhookSigningSecretMinLength, "Max": IncomingWebhookSigningSecretMaxLength}, "", http.StatusBadRequest)
+	}
+
+	if len(o.PayloadTemplate) > IncomingWebhookPayloadTemplateMaxSize {
+		return NewAppError("IncomingWebhook.IsValid", "model.incoming_hook.payload_template.app_error", nil, "", http.StatusBadRequest)
+	}
+
 	return nil
 }
 
+// Sanitize removes the signing secret, which is only returned when the webhook
+// is created.
+func (o *IncomingWebhook) Sanitize() {
+	o.SigningSecret = ""
+}
+
+// IsSigned returns whether requests to the webhook must be signed.
+func (o *IncomingWebhook) IsSigned() bool {
+	return o.SigningSecret != ""
+}
+
+// ComputeIncomingWebhookSignature returns the v1 signature of body sent at
+// timestamp (unix seconds), in the form "v1=<hex hmac-sha256>". The signed
+// content is "v1:<timestamp>:<body>".
+func ComputeIncomingWebhookSignature(secret string, timestamp string, body []byte) string {
+	return computeWebhookSignature(secret, timestamp, body)
+}
+
+// computeWebhookSignature signs the requests of both signed incoming webhooks
+// and event subscription deliveries, so receivers verify them the same way.
+func computeWebhookSignature(secret string, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(IncomingWebhookSignatureV1 + ":" + timestamp + ":"))
+	mac.Write(body)
+	return IncomingWebhookSignatureV1 + "=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifySignature checks the signature and timestamp headers of a request to
+// the webhook against its signing secret. Requests whose timestamp is further
+// than IncomingWebhookSignatureTolerance from now are rejected so a captured
+// request can't be replayed later.
+func (o *IncomingWebhook) VerifySignature(header http.Header, body []byte, now time.Time) *AppError {
+	if !o.IsSigned() {
+		return nil
+	}
+
+	timestamp := header.Get(IncomingWebhookTimestampHeader)
+	signature := header.Get(IncomingWebhookSignatureHeader)
+	if timestamp == "" || signature == "" {
+		return NewAppError("IncomingWebhook.VerifySignature", "model.incoming_hook.signature.missing.app_error", nil, "", http.StatusUnauthorized)
+	}
+
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return NewAppError("IncomingWebhook.VerifySignature", "model.incoming_hook.signature.timestamp.app_error", nil, "", http.StatusUnauthorized).Wrap(err)
+	}
+
+	drift := now.Sub(time.Unix(seconds, 0))
+	if drift < 0 {
+		drift = -drift
+	}
+	if drift > IncomingWebhookSignatureTolerance {
+		return NewAppError("IncomingWebhook.VerifySignature", "model.incoming_hook.signature.timestamp.app_error", nil, "drift="+drift.String(), http.StatusUnauthorized)
+	}
+
+	expected := ComputeIncomingWebhookSignature(o.SigningSecret, timestamp, body)
+	for _, candidate := range strings.Split(signature, ",") {
+		if hmac.Equal([]byte(strings.TrimSpace(candidate)), []byte(expected)) {
+			return nil
+		}
+	}
+
+	return NewAppError("IncomingWebhook.VerifySignature", "model.incoming_hook.signature.invalid.app_error", nil, "", http.StatusUnauthorized)
+}
+
 func (o *IncomingWebhook) PreSave() {
 	if o.Id == "" {
 		o.Id = NewId()
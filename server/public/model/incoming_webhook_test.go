@@ -4,8 +4,11 @@
 package model
 
 import (
+	"net/http"
+	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
@@ -65,6 +68,80 @@ func TestIncomingWebhookIsValid(t *testing.T) {
 
 	o.IconURL = strings.Repeat("1", 1024)
 	require.Nil(t, o.IsValid())
+
+	o.SigningSecret = strings.Repeat("s", IncomingWebhookSigningSecretMinLength-1)
+	require.NotNil(t, o.IsValid())
+
+	o.SigningSecret = strings.Repeat("s", IncomingWebhookSigningSecretMaxLength+1)
+	require.NotNil(t, o.IsValid())
+
+	o.SigningSecret = strings.Repeat("s", IncomingWebhookSigningSecretMinLength)
+	require.Nil(t, o.IsValid())
+
+	o.PayloadTemplate = strings.Repeat("t", IncomingWebhookPayloadTemplateMaxSize+1)
+	require.NotNil(t, o.IsValid())
+
+	o.PayloadTemplate = strings.Repeat("t", IncomingWebhookPayloadTemplateMaxSize)
+	require.Nil(t, o.IsValid())
+}
+
+func TestIncomingWebhookVerifySignature(t *testing.T) {
+	secret := "0123456789abcdef0123"
+	body := []byte(`{"text":"hello"}`)
+	now := time.Unix(1700000000, 0)
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+
+	signedHeader := func(ts string, sig string) http.Header {
+		h := http.Header{}
+		h.Set(IncomingWebhookTimestampHeader, ts)
+		h.Set(IncomingWebhookSignatureHeader, sig)
+		return h
+	}
+
+	t.Run("unsigned hook accepts any request", func(t *testing.T) {
+		hook := &IncomingWebhook{}
+		require.Nil(t, hook.VerifySignature(http.Header{}, body, now))
+	})
+
+	hook := &IncomingWebhook{SigningSecret: secret}
+
+	t.Run("valid signature", func(t *testing.T) {
+		sig := ComputeIncomingWebhookSignature(secret, timestamp, body)
+		require.Nil(t, hook.VerifySignature(signedHeader(timestamp, sig), body, now))
+	})
+
+	t.Run("valid signature among several during rotation", func(t *testing.T) {
+		sig := ComputeIncomingWebhookSignature(secret, timestamp, body)
+		old := ComputeIncomingWebhookSignature("an-older-secret-value", timestamp, body)
+		require.Nil(t, hook.VerifySignature(signedHeader(timestamp, old+", "+sig), body, now))
+	})
+
+	t.Run("missing headers", func(t *testing.T) {
+		appErr := hook.VerifySignature(http.Header{}, body, now)
+		require.NotNil(t, appErr)
+		require.Equal(t, http.StatusUnauthorized, appErr.StatusCode)
+	})
+
+	t.Run("tampered body", func(t *testing.T) {
+		sig := ComputeIncomingWebhookSignature(secret, timestamp, body)
+		require.NotNil(t, hook.VerifySignature(signedHeader(timestamp, sig), []byte(`{"text":"bye"}`), now))
+	})
+
+	t.Run("wrong secret", func(t *testing.T) {
+		sig := ComputeIncomingWebhookSignature("not-the-right-secret", timestamp, body)
+		require.NotNil(t, hook.VerifySignature(signedHeader(timestamp, sig), body, now))
+	})
+
+	t.Run("replayed request outside tolerance", func(t *testing.T) {
+		sig := ComputeIncomingWebhookSignature(secret, timestamp, body)
+		later := now.Add(IncomingWebhookSignatureTolerance + time.Second)
+		require.NotNil(t, hook.VerifySignature(signedHeader(timestamp, sig), body, later))
+	})
+
+	t.Run("malformed timestamp", func(t *testing.T) {
+		sig := ComputeIncomingWebhookSignature(secret, "yesterday", body)
+		require.NotNil(t, hook.VerifySignature(signedHeader("yesterday", sig), body, now))
+	})
 }
 
 func TestIncomingWebhookPreSave(t *testing.T) {
@@ -46,6 +46,8 @@ const (
 	JobTypeMobileSessionMetadata         = "mobile_session_metadata"
 	JobTypeAccessControlSync             = "access_control_sync"
 	JobTypePushProxyAuth                 = "push_proxy_auth"
+	JobTypeFileEncryptionRekey           = "file_encryption_rekey"
+	JobTypeFileStorageMigration          = "file_storage_migration"
 
 	JobStatusPending         = "pending"
 	JobStatusInProgress      = "in_progress"
@@ -79,6 +81,8 @@ var AllJobTypes = [...]string{
 	JobTypeCleanupDesktopTokens,
 	JobTypeRefreshMaterializedViews,
 	JobTypeMobileSessionMetadata,
+	JobTypeFileEncryptionRekey,
+	JobTypeFileStorageMigration,
 }
 
 type Job struct {
@@ -236,8 +236,20 @@ type ManifestServer struct {
 	// If your plugin is compiled for multiple platforms, consider bundling them together
 	// and using the Executables field instead.
 	Executable string `json:"executable" yaml:"executable"`
+
+	// Runtime is the runtime used to execute the server component. It defaults to
+	// ManifestServerRuntimeRPC, which launches the executable as a separate process.
+	//
+	// With ManifestServerRuntimeWasm, the executable is a WebAssembly module targeting WASI
+	// that is loaded in-process by the server and restricted to a subset of the plugin API.
+	Runtime string `json:"runtime,omitempty" yaml:"runtime,omitempty"`
 }
 
+const (
+	ManifestServerRuntimeRPC  = "rpc"
+	ManifestServerRuntimeWasm = "wasm"
+)
+
 type ManifestWebapp struct {
 	// The path to your webapp bundle. This should be relative to the root of your bundle and the
 	// location of the manifest file.
@@ -294,6 +306,11 @@ func (m *Manifest) HasServer() bool {
 	return m.Server != nil
 }
 
+// IsWasm returns true if the server component of the plugin is a WebAssembly module.
+func (m *Manifest) IsWasm() bool {
+	return m.Server != nil && m.Server.Runtime == ManifestServerRuntimeWasm
+}
+
 func (m *Manifest) HasWebapp() bool {
 	return m.Webapp != nil
 }
@@ -345,6 +362,14 @@ func (m *Manifest) IsValid() error {
 		}
 	}
 
+	if m.Server != nil {
+		switch m.Server.Runtime {
+		case "", ManifestServerRuntimeRPC, ManifestServerRuntimeWasm:
+		default:
+			return errors.Errorf("invalid server runtime %q", m.Server.Runtime)
+		}
+	}
+
 	if m.SettingsSchema != nil {
 		err := m.SettingsSchema.isValid()
 		if err != nil {
@@ -0,0 +1,293 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"regexp"
+	"slices"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	// NotificationRuleActionNotify sends the notifications selected by the action, in place of the
+	// notification preferences of the user. The status of the user still applies.
+	NotificationRuleActionNotify = "notify"
+	// NotificationRuleActionSuppress sends no notification.
+	NotificationRuleActionSuppress = "suppress"
+	// NotificationRuleActionEscalate sends every notification, regardless of the notification
+	// preferences and status of the user.
+	NotificationRuleActionEscalate = "escalate"
+
+	// NotificationRulePriorityStandard matches the posts without a priority.
+	NotificationRulePriorityStandard = "standard"
+
+	NotificationRulesMaxPerUser             = 50
+	NotificationRuleNameMaxRunes            = 64
+	NotificationRuleMessagePatternMaxLength = 256
+	NotificationRuleMaxIds                  = 100
+)
+
+// NotificationRule decides how a user is notified of the posts it matches. The rules of a user are
+// ordered, and the first enabled rule matching a post applies.
+type NotificationRule struct {
+	Id         string                     `json:"id"`
+	UserId     string                     `json:"user_id"`
+	Name       string                     `json:"name"`
+	Enabled    bool                       `json:"enabled"`
+	SortOrder  int64                      `json:"sort_order"`
+	Conditions NotificationRuleConditions `json:"conditions"`
+	Action     NotificationRuleAction     `json:"action"`
+	CreateAt   int64                      `json:"create_at"`
+	UpdateAt   int64                      `json:"update_at"`
+
+	messagePattern *regexp.Regexp
+}
+
+// NotificationRuleConditions are the conditions a post must all meet to match a rule. Conditions left
+// empty match every post.
+type NotificationRuleConditions struct {
+	ChannelIds []string `json:"channel_ids,omitempty"`
+	SenderIds  []string `json:"sender_ids,omitempty"`
+	FromBot    *bool    `json:"from_bot,omitempty"`
+	// MessagePattern is a regular expression, in the RE2 syntax, matched against the message.
+	MessagePattern string   `json:"message_pattern,omitempty"`
+	Priorities     []string `json:"priorities,omitempty"`
+	// ThreadParticipant matches the replies to threads the user follows, or the other posts.
+	ThreadParticipant *bool `json:"thread_participant,omitempty"`
+	// TimeStart and TimeEnd are in the "15:04" format, in the timezone of the user. A range ending
+	// before it starts spans midnight.
+	TimeStart string `json:"time_start,omitempty"`
+	TimeEnd   string `json:"time_end,omitempty"`
+}
+
+type NotificationRuleAction struct {
+	Type    string `json:"type"`
+	Push    bool   `json:"push,omitempty"`
+	Email   bool   `json:"email,omitempty"`
+	Desktop bool   `json:"desktop,omitempty"`
+}
+
+// NotificationRulePost is a post a rule is evaluated against, along with what the rule conditions
+// need to know about it.
+type NotificationRulePost struct {
+	Post              *Post
+	SenderIsBot       bool
+	ThreadParticipant bool
+	// Time is when the post is notified, in the timezone of the user.
+	Time time.Time
+}
+
+// NotificationRuleTestResult lists the recent posts a rule matches.
+type NotificationRuleTestResult struct {
+	Evaluated int       `json:"evaluated"`
+	Matched   *PostList `json:"matched"`
+}
+
+func (r *NotificationRule) PreSave() {
+	if r.Id == "" {
+		r.Id = NewId()
+	}
+
+	if r.CreateAt == 0 {
+		r.CreateAt = GetMillis()
+	}
+	r.UpdateAt = GetMillis()
+}
+
+func (r *NotificationRule) IsValid() *AppError {
+	if !IsValidId(r.Id) {
+		return NewAppError("NotificationRule.IsValid", "model.notification_rule.is_valid.id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if !IsValidId(r.UserId) {
+		return NewAppError("NotificationRule.IsValid", "model.notification_rule.is_valid.user_id.app_error", nil, "id="+r.Id, http.StatusBadRequest)
+	}
+
+	if r.Name == "" || utf8.RuneCountInString(r.Name) > NotificationRuleNameMaxRunes {
+		return NewAppError("NotificationRule.IsValid", "model.notification_rule.is_valid.name.app_error", nil, "id="+r.Id, http.StatusBadRequest)
+	}
+
+	if appErr := r.Conditions.isValid(r.Id); appErr != nil {
+		return appErr
+	}
+
+	if appErr := r.Action.isValid(r.Id); appErr != nil {
+		return appErr
+	}
+
+	return nil
+}
+
+func (c *NotificationRuleConditions) isValid(ruleID string) *AppError {
+	if len(c.ChannelIds) > NotificationRuleMaxIds || slices.ContainsFunc(c.ChannelIds, func(id string) bool { return !IsValidId(id) }) {
+		return NewAppError("NotificationRule.IsValid", "model.notification_rule.is_valid.channel_ids.app_error", nil, "id="+ruleID, http.StatusBadRequest)
+	}
+
+	if len(c.SenderIds) > NotificationRuleMaxIds || slices.ContainsFunc(c.SenderIds, func(id string) bool { return !IsValidId(id) }) {
+		return NewAppError("NotificationRule.IsValid", "model.notification_rule.is_valid.sender_ids.app_error", nil, "id="+ruleID, http.StatusBadRequest)
+	}
+
+	if len(c.MessagePattern) > NotificationRuleMessagePatternMaxLength {
+		return NewAppError("NotificationRule.IsValid", "model.notification_rule.is_valid.message_pattern.app_error", nil, "id="+ruleID, http.StatusBadRequest)
+	}
+	if _, err := regexp.Compile(c.MessagePattern); err != nil {
+		return NewAppError("NotificationRule.IsValid", "model.notification_rule.is_valid.message_pattern.app_error", nil, "id="+ruleID, http.StatusBadRequest).Wrap(err)
+	}
+
+	for _, priority := range c.Priorities {
+		if priority != NotificationRulePriorityStandard && priority != PostPriorityImportant && priority != PostPriorityUrgent {
+			return NewAppError("NotificationRule.IsValid", "model.notification_rule.is_valid.priorities.app_error", nil, "id="+ruleID, http.StatusBadRequest)
+		}
+	}
+
+	if c.TimeStart != "" || c.TimeEnd != "" {
+		if _, _, err := parseWorkingHoursRange(c.TimeStart, c.TimeEnd); err != nil {
+			return NewAppError("NotificationRule.IsValid", "model.notification_rule.is_valid.time.app_error", nil, "id="+ruleID, http.StatusBadRequest).Wrap(err)
+		}
+	}
+
+	return nil
+}
+
+func (a *NotificationRuleAction) isValid(ruleID string) *AppError {
+	switch a.Type {
+	case NotificationRuleActionNotify:
+		if !a.Push && !a.Email && !a.Desktop {
+			return NewAppError("NotificationRule.IsValid", "model.notification_rule.is_valid.action.app_error", nil, "id="+ruleID, http.StatusBadRequest)
+		}
+	case NotificationRuleActionSuppress, NotificationRuleActionEscalate:
+	default:
+		return NewAppError("NotificationRule.IsValid", "model.notification_rule.is_valid.action.app_error", nil, "id="+ruleID, http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+// Matches returns whether the post meets all the conditions of the rule. It doesn't check whether
+// the rule is enabled.
+func (r *NotificationRule) Matches(p *NotificationRulePost) bool {
+	c := &r.Conditions
+
+	if len(c.ChannelIds) > 0 && !slices.Contains(c.ChannelIds, p.Post.ChannelId) {
+		return false
+	}
+
+	if len(c.SenderIds) > 0 && !slices.Contains(c.SenderIds, p.Post.UserId) {
+		return false
+	}
+
+	if c.FromBot != nil && *c.FromBot != p.SenderIsBot {
+		return false
+	}
+
+	if len(c.Priorities) > 0 && !slices.Contains(c.Priorities, notificationRulePriority(p.Post)) {
+		return false
+	}
+
+	if c.ThreadParticipant != nil && *c.ThreadParticipant != (p.Post.RootId != "" && p.ThreadParticipant) {
+		return false
+	}
+
+	if c.TimeStart != "" || c.TimeEnd != "" {
+		start, end, err := parseWorkingHoursRange(c.TimeStart, c.TimeEnd)
+		if err != nil {
+			return false
+		}
+		minutes := p.Time.Hour()*60 + p.Time.Minute()
+		if start < end && (minutes < start || minutes >= end) {
+			return false
+		}
+		if start > end && minutes < start && minutes >= end {
+			return false
+		}
+	}
+
+	if c.MessagePattern != "" {
+		if r.messagePattern == nil {
+			pattern, err := regexp.Compile(c.MessagePattern)
+			if err != nil {
+				return false
+			}
+			r.messagePattern = pattern
+		}
+		if !r.messagePattern.MatchString(p.Post.Message) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func notificationRulePriority(post *Post) string {
+	if priority := post.GetPriority(); priority != nil && priority.Priority != nil && *priority.Priority != "" {
+		return *priority.Priority
+	}
+	return NotificationRulePriorityStandard
+}
+
+// SendsPush returns whether the action sends a push notification.
+func (a *NotificationRuleAction) SendsPush() bool {
+	return a.Type == NotificationRuleActionEscalate || (a.Type == NotificationRuleActionNotify && a.Push)
+}
+
+// SendsEmail returns whether the action sends an email notification.
+func (a *NotificationRuleAction) SendsEmail() bool {
+	return a.Type == NotificationRuleActionEscalate || (a.Type == NotificationRuleActionNotify && a.Email)
+}
+
+// DesktopDecision returns how the clients handle the desktop notification: they notify, notify
+// regardless of the status of the user, or don't notify.
+func (a *NotificationRuleAction) DesktopDecision() string {
+	switch {
+	case a.Type == NotificationRuleActionEscalate:
+		return NotificationRuleActionEscalate
+	case a.Type == NotificationRuleActionNotify && a.Desktop:
+		return NotificationRuleActionNotify
+	}
+	return NotificationRuleActionSuppress
+}
+
+func (c NotificationRuleConditions) Value() (driver.Value, error) {
+	j, err := json.Marshal(c)
+	if err != nil {
+		return nil, err
+	}
+	return string(j), nil
+}
+
+func (c *NotificationRuleConditions) Scan(value any) error {
+	return scanNotificationRuleJSON(value, c)
+}
+
+func (a NotificationRuleAction) Value() (driver.Value, error) {
+	j, err := json.Marshal(a)
+	if err != nil {
+		return nil, err
+	}
+	return string(j), nil
+}
+
+func (a *NotificationRuleAction) Scan(value any) error {
+	return scanNotificationRuleJSON(value, a)
+}
+
+func scanNotificationRuleJSON(value any, v any) error {
+	if value == nil {
+		return nil
+	}
+
+	switch data := value.(type) {
+	case []byte:
+		return json.Unmarshal(data, v)
+	case string:
+		return json.Unmarshal([]byte(data), v)
+	}
+
+	return errors.New("received value is neither a byte slice nor string")
+}
@@ -0,0 +1,113 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotificationRuleIsValid(t *testing.T) {
+	rule := NotificationRule{UserId: NewId(), Name: "Alerts"}
+	rule.PreSave()
+	require.NotNil(t, rule.IsValid())
+
+	rule.Action = NotificationRuleAction{Type: NotificationRuleActionNotify}
+	require.NotNil(t, rule.IsValid())
+
+	rule.Action.Push = true
+	require.Nil(t, rule.IsValid())
+
+	rule.Action = NotificationRuleAction{Type: NotificationRuleActionSuppress}
+	require.Nil(t, rule.IsValid())
+
+	rule.Name = strings.Repeat("a", NotificationRuleNameMaxRunes+1)
+	require.NotNil(t, rule.IsValid())
+	rule.Name = "Alerts"
+
+	rule.Conditions.ChannelIds = []string{"invalid"}
+	require.NotNil(t, rule.IsValid())
+	rule.Conditions.ChannelIds = []string{NewId()}
+
+	rule.Conditions.MessagePattern = "(unclosed"
+	require.NotNil(t, rule.IsValid())
+	rule.Conditions.MessagePattern = `(?i)\bincident\b`
+
+	rule.Conditions.Priorities = []string{"high"}
+	require.NotNil(t, rule.IsValid())
+	rule.Conditions.Priorities = []string{NotificationRulePriorityStandard, PostPriorityUrgent}
+
+	rule.Conditions.TimeStart = "09:00"
+	require.NotNil(t, rule.IsValid())
+	rule.Conditions.TimeEnd = "17:00"
+
+	require.Nil(t, rule.IsValid())
+}
+
+func TestNotificationRuleMatches(t *testing.T) {
+	channelID := NewId()
+	senderID := NewId()
+	at := func(hour int) time.Time {
+		return time.Date(2026, time.October, 5, hour, 0, 0, 0, time.UTC)
+	}
+	post := func(message string) *Post {
+		return &Post{ChannelId: channelID, UserId: senderID, Message: message}
+	}
+	urgentPost := post("server down")
+	urgentPost.Metadata = &PostMetadata{Priority: &PostPriority{Priority: NewPointer(PostPriorityUrgent)}}
+	reply := post("reply")
+	reply.RootId = NewId()
+
+	testCases := []struct {
+		name       string
+		conditions NotificationRuleConditions
+		post       NotificationRulePost
+		expected   bool
+	}{
+		{"no condition", NotificationRuleConditions{}, NotificationRulePost{Post: post("hello"), Time: at(10)}, true},
+		{"channel", NotificationRuleConditions{ChannelIds: []string{channelID}}, NotificationRulePost{Post: post("hello"), Time: at(10)}, true},
+		{"other channel", NotificationRuleConditions{ChannelIds: []string{NewId()}}, NotificationRulePost{Post: post("hello"), Time: at(10)}, false},
+		{"other sender", NotificationRuleConditions{SenderIds: []string{NewId()}}, NotificationRulePost{Post: post("hello"), Time: at(10)}, false},
+		{"from bot", NotificationRuleConditions{FromBot: NewPointer(true)}, NotificationRulePost{Post: post("hello"), SenderIsBot: true, Time: at(10)}, true},
+		{"not from bot", NotificationRuleConditions{FromBot: NewPointer(true)}, NotificationRulePost{Post: post("hello"), Time: at(10)}, false},
+		{"pattern", NotificationRuleConditions{MessagePattern: `(?i)\bdown\b`}, NotificationRulePost{Post: post("Server DOWN"), Time: at(10)}, true},
+		{"pattern not matching", NotificationRuleConditions{MessagePattern: `(?i)\bdown\b`}, NotificationRulePost{Post: post("downtime"), Time: at(10)}, false},
+		{"urgent", NotificationRuleConditions{Priorities: []string{PostPriorityUrgent}}, NotificationRulePost{Post: urgentPost, Time: at(10)}, true},
+		{"standard", NotificationRuleConditions{Priorities: []string{NotificationRulePriorityStandard}}, NotificationRulePost{Post: urgentPost, Time: at(10)}, false},
+		{"thread participant", NotificationRuleConditions{ThreadParticipant: NewPointer(true)}, NotificationRulePost{Post: reply, ThreadParticipant: true, Time: at(10)}, true},
+		{"root post", NotificationRuleConditions{ThreadParticipant: NewPointer(true)}, NotificationRulePost{Post: post("hello"), ThreadParticipant: true, Time: at(10)}, false},
+		{"not thread participant", NotificationRuleConditions{ThreadParticipant: NewPointer(false)}, NotificationRulePost{Post: reply, Time: at(10)}, true},
+		{"time of day", NotificationRuleConditions{TimeStart: "09:00", TimeEnd: "17:00"}, NotificationRulePost{Post: post("hello"), Time: at(10)}, true},
+		{"outside time of day", NotificationRuleConditions{TimeStart: "09:00", TimeEnd: "17:00"}, NotificationRulePost{Post: post("hello"), Time: at(17)}, false},
+		{"night", NotificationRuleConditions{TimeStart: "22:00", TimeEnd: "06:00"}, NotificationRulePost{Post: post("hello"), Time: at(3)}, true},
+		{"outside night", NotificationRuleConditions{TimeStart: "22:00", TimeEnd: "06:00"}, NotificationRulePost{Post: post("hello"), Time: at(12)}, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rule := NotificationRule{Conditions: tc.conditions}
+			assert.Equal(t, tc.expected, rule.Matches(&tc.post))
+		})
+	}
+}
+
+func TestNotificationRuleAction(t *testing.T) {
+	escalate := NotificationRuleAction{Type: NotificationRuleActionEscalate}
+	assert.True(t, escalate.SendsPush())
+	assert.True(t, escalate.SendsEmail())
+	assert.Equal(t, NotificationRuleActionEscalate, escalate.DesktopDecision())
+
+	notify := NotificationRuleAction{Type: NotificationRuleActionNotify, Push: true}
+	assert.True(t, notify.SendsPush())
+	assert.False(t, notify.SendsEmail())
+	assert.Equal(t, NotificationRuleActionSuppress, notify.DesktopDecision())
+
+	suppress := NotificationRuleAction{Type: NotificationRuleActionSuppress, Push: true}
+	assert.False(t, suppress.SendsPush())
+	assert.Equal(t, NotificationRuleActionSuppress, suppress.DesktopDecision())
+}
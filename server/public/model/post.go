@@ -95,7 +95,8 @@ const (
 	PostPropsAIGeneratedByUserID      = "ai_generated_by"
 	PostPropsAIGeneratedByUsername    = "ai_generated_by_username"
 
-	PostPriorityUrgent = "urgent"
+	PostPriorityUrgent    = "urgent"
+	PostPriorityImportant = "important"
 )
 
 type Post struct {
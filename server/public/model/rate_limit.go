@@ -0,0 +1,17 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+// RateLimitCounter is the state of a rate limit rule for one of the keys it counts requests by.
+type RateLimitCounter struct {
+	Rule      string `json:"rule"`
+	Key       string `json:"key"`
+	Limit     int    `json:"limit"`
+	Remaining int    `json:"remaining"`
+	// ResetAfter is the time in milliseconds after which the key is back to its full limit.
+	ResetAfter int64 `json:"reset_after"`
+	// RetryAfter is the time in milliseconds until the next request is allowed, or -1 when it
+	// is allowed already.
+	RetryAfter int64 `json:"retry_after"`
+}
@@ -6,6 +6,7 @@ package model
 import (
 	"fmt"
 	"net/http"
+	"time"
 )
 
 const (
@@ -33,6 +34,8 @@ type ScheduledPost struct {
 	ScheduledAt int64  `json:"scheduled_at"`
 	ProcessedAt int64  `json:"processed_at"`
 	ErrorCode   string `json:"error_code"`
+
+	Recurrence *ScheduledPostRecurrence `json:"recurrence,omitempty"`
 }
 
 func (s *ScheduledPost) IsValid(maxMessageSize int) *AppError {
@@ -65,6 +68,12 @@ func (s *ScheduledPost) BaseIsValid() *AppError {
 		return NewAppError("ScheduledPost.IsValid", "model.scheduled_post.is_valid.processed_at.app_error", nil, "id="+s.Id, http.StatusBadRequest)
 	}
 
+	if s.Recurrence != nil {
+		if appErr := s.Recurrence.IsValid(); appErr != nil {
+			return appErr
+		}
+	}
+
 	return nil
 }
 
@@ -76,6 +85,10 @@ func (s *ScheduledPost) PreSave() {
 	s.ProcessedAt = 0
 	s.ErrorCode = ""
 
+	if s.Recurrence != nil {
+		s.Recurrence.Occurrences = 0
+	}
+
 	s.Draft.PreSave()
 }
 
@@ -145,6 +158,7 @@ func (s *ScheduledPost) Auditable() map[string]any {
 		"props":      s.GetProps(),
 		"file_ids":   s.FileIds,
 		"metadata":   metaData,
+		"recurrence": s.Recurrence,
 	}
 }
 
@@ -154,6 +168,87 @@ func (s *ScheduledPost) RestoreNonUpdatableFields(originalScheduledPost *Schedul
 	s.UserId = originalScheduledPost.UserId
 	s.ChannelId = originalScheduledPost.ChannelId
 	s.RootId = originalScheduledPost.RootId
+
+	if s.Recurrence != nil {
+		s.Recurrence.Occurrences = 0
+		if originalScheduledPost.Recurrence != nil {
+			s.Recurrence.Occurrences = originalScheduledPost.Recurrence.Occurrences
+		}
+	}
+}
+
+// Clone returns a copy of the scheduled post, including its recurrence, so
+// that advancing the copy's series leaves the original untouched.
+func (s *ScheduledPost) Clone() *ScheduledPost {
+	clone := &ScheduledPost{
+		Draft: Draft{
+			CreateAt:  s.CreateAt,
+			UpdateAt:  s.UpdateAt,
+			DeleteAt:  s.DeleteAt,
+			UserId:    s.UserId,
+			ChannelId: s.ChannelId,
+			RootId:    s.RootId,
+			Message:   s.Message,
+			FileIds:   s.FileIds,
+			Metadata:  s.Metadata,
+			Priority:  s.Priority,
+		},
+		Id:          s.Id,
+		ScheduledAt: s.ScheduledAt,
+		ProcessedAt: s.ProcessedAt,
+		ErrorCode:   s.ErrorCode,
+	}
+	clone.SetProps(s.GetProps())
+
+	if s.Recurrence != nil {
+		recurrence := *s.Recurrence
+		clone.Recurrence = &recurrence
+	}
+
+	return clone
+}
+
+func (s *ScheduledPost) IsRecurring() bool {
+	return s.Recurrence != nil
+}
+
+// NormalizeRecurrence fills in the recurrence fields that are derived from the
+// first occurrence: the time zone it's evaluated in, and for monthly rules the
+// day of the month, so that a series starting on the 31st doesn't drift to the
+// 28th after February.
+func (s *ScheduledPost) NormalizeRecurrence(defaultTimeZone string) {
+	if s.Recurrence == nil {
+		return
+	}
+
+	if s.Recurrence.TimeZone == "" {
+		s.Recurrence.TimeZone = defaultTimeZone
+	}
+
+	if s.Recurrence.MonthDay == 0 && (s.Recurrence.Frequency == ScheduledPostRecurrenceMonthly || s.Recurrence.Frequency == ScheduledPostRecurrenceRRule) {
+		if loc, err := time.LoadLocation(s.Recurrence.TimeZone); err == nil {
+			s.Recurrence.MonthDay = time.UnixMilli(s.ScheduledAt).In(loc).Day()
+		}
+	}
+}
+
+// AdvanceRecurrence moves a recurring scheduled post on to its next
+// occurrence, counting the current one as passed. It returns false when the
+// series has no further occurrences, or the post isn't recurring.
+func (s *ScheduledPost) AdvanceRecurrence() bool {
+	if s.Recurrence == nil {
+		return false
+	}
+
+	s.Recurrence.Occurrences++
+	next, ok := s.Recurrence.NextOccurrence(s.ScheduledAt)
+	if !ok || s.Recurrence.HasEnded(next) {
+		return false
+	}
+
+	s.ScheduledAt = next
+	s.ErrorCode = ""
+	return true
 }
 
 func (s *ScheduledPost) SanitizeInput() {
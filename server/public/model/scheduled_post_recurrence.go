@@ -0,0 +1,315 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	ScheduledPostRecurrenceDaily    = "daily"
+	ScheduledPostRecurrenceWeekdays = "weekdays"
+	ScheduledPostRecurrenceWeekly   = "weekly"
+	ScheduledPostRecurrenceMonthly  = "monthly"
+	ScheduledPostRecurrenceRRule    = "rrule"
+
+	ScheduledPostRecurrenceMaxInterval = 366
+	ScheduledPostRecurrenceRRuleMaxLen = 256
+
+	// scheduledPostRecurrenceMaxSearchDays bounds how far ahead the next
+	// occurrence is searched for, so that rules which can't produce one
+	// (e.g. BYMONTHDAY=31 with INTERVAL=2 starting in a 30 day month)
+	// terminate.
+	scheduledPostRecurrenceMaxSearchDays = 5 * 366
+)
+
+var rruleWeekdays = map[string]time.Weekday{
+	"SU": time.Sunday,
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+}
+
+// ScheduledPostRecurrence turns a scheduled post into a series. The post's
+// ScheduledAt always holds the next pending occurrence; once it's sent (or
+// skipped) ScheduledAt moves on to the occurrence after it, evaluated in
+// TimeZone so that wall clock time is kept across DST changes.
+//
+// Frequency is one of daily, weekdays, weekly, monthly or rrule. For rrule,
+// RRule holds an RFC 5545 subset: FREQ (DAILY, WEEKLY or MONTHLY), INTERVAL,
+// BYDAY (without ordinals), BYMONTHDAY, COUNT and UNTIL.
+type ScheduledPostRecurrence struct {
+	Frequency string         `json:"frequency"`
+	Interval  int            `json:"interval,omitempty"`
+	Weekdays  []time.Weekday `json:"weekdays,omitempty"`
+	MonthDay  int            `json:"month_day,omitempty"`
+	RRule     string         `json:"rrule,omitempty"`
+	TimeZone  string         `json:"time_zone"`
+
+	// EndAt and Count end the series after a date or a number of
+	// occurrences, whichever comes first. Zero means no limit.
+	EndAt int64 `json:"end_at,omitempty"`
+	Count int   `json:"count,omitempty"`
+
+	// Occurrences counts the occurrences that have already passed, whether
+	// they were sent, skipped or failed.
+	Occurrences int  `json:"occurrences"`
+	Paused      bool `json:"paused"`
+}
+
+func (r *ScheduledPostRecurrence) Scan(value any) error {
+	if value == nil {
+		return nil
+	}
+
+	b, ok := value.([]byte)
+	if !ok {
+		return fmt.Errorf("expected []byte, got %T", value)
+	}
+
+	return json.Unmarshal(b, r)
+}
+
+func (r ScheduledPostRecurrence) Value() (driver.Value, error) {
+	j, err := json.Marshal(r)
+	if err != nil {
+		return nil, err
+	}
+	return string(j), nil
+}
+
+func (r *ScheduledPostRecurrence) IsValid() *AppError {
+	switch r.Frequency {
+	case ScheduledPostRecurrenceDaily, ScheduledPostRecurrenceWeekdays, ScheduledPostRecurrenceWeekly, ScheduledPostRecurrenceMonthly:
+	case ScheduledPostRecurrenceRRule:
+		if _, err := r.rule(); err != nil {
+			return NewAppError("ScheduledPostRecurrence.IsValid", "model.scheduled_post.is_valid.recurrence.rrule.app_error", map[string]any{"Error": err.Error()}, "", http.StatusBadRequest).Wrap(err)
+		}
+	default:
+		return NewAppError("ScheduledPostRecurrence.IsValid", "model.scheduled_post.is_valid.recurrence.frequency.app_error", nil, "frequency="+r.Frequency, http.StatusBadRequest)
+	}
+
+	if r.Interval < 0 || r.Interval > ScheduledPostRecurrenceMaxInterval {
+		return NewAppError("ScheduledPostRecurrence.IsValid", "model.scheduled_post.is_valid.recurrence.interval.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	for _, day := range r.Weekdays {
+		if day < time.Sunday || day > time.Saturday {
+			return NewAppError("ScheduledPostRecurrence.IsValid", "model.scheduled_post.is_valid.recurrence.weekdays.app_error", nil, "", http.StatusBadRequest)
+		}
+	}
+
+	if r.MonthDay < 0 || r.MonthDay > 31 {
+		return NewAppError("ScheduledPostRecurrence.IsValid", "model.scheduled_post.is_valid.recurrence.month_day.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if _, err := time.LoadLocation(r.TimeZone); err != nil {
+		return NewAppError("ScheduledPostRecurrence.IsValid", "model.scheduled_post.is_valid.recurrence.time_zone.app_error", nil, "", http.StatusBadRequest).Wrap(err)
+	}
+
+	if r.EndAt < 0 || r.Count < 0 || r.Occurrences < 0 {
+		return NewAppError("ScheduledPostRecurrence.IsValid", "model.scheduled_post.is_valid.recurrence.end.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+// HasEnded returns whether occurrenceAt falls outside of the series, either
+// because it's past the end date or because the occurrence count has already
+// been reached.
+func (r *ScheduledPostRecurrence) HasEnded(occurrenceAt int64) bool {
+	rule, err := r.rule()
+	if err != nil {
+		return true
+	}
+
+	if rule.Count > 0 && r.Occurrences >= rule.Count {
+		return true
+	}
+
+	return rule.EndAt > 0 && occurrenceAt > rule.EndAt
+}
+
+// NextOccurrence returns the first occurrence strictly after previous, and
+// false if the series has no occurrence after it.
+func (r *ScheduledPostRecurrence) NextOccurrence(previous int64) (int64, bool) {
+	rule, err := r.rule()
+	if err != nil {
+		return 0, false
+	}
+
+	loc, err := time.LoadLocation(r.TimeZone)
+	if err != nil {
+		return 0, false
+	}
+
+	prev := time.UnixMilli(previous).In(loc)
+	interval := max(rule.Interval, 1)
+
+	weekdays := rule.Weekdays
+	switch rule.Frequency {
+	case ScheduledPostRecurrenceWeekdays:
+		weekdays = []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday}
+	case ScheduledPostRecurrenceWeekly:
+		if len(weekdays) == 0 {
+			weekdays = []time.Weekday{prev.Weekday()}
+		}
+	}
+
+	monthDay := rule.MonthDay
+	if monthDay == 0 {
+		monthDay = prev.Day()
+	}
+
+	for days := 1; days <= scheduledPostRecurrenceMaxSearchDays; days++ {
+		candidate := time.Date(prev.Year(), prev.Month(), prev.Day()+days, prev.Hour(), prev.Minute(), prev.Second(), prev.Nanosecond(), loc)
+
+		var matches bool
+		switch rule.Frequency {
+		case ScheduledPostRecurrenceDaily:
+			matches = days%interval == 0 && (len(weekdays) == 0 || slices.Contains(weekdays, candidate.Weekday()))
+		case ScheduledPostRecurrenceWeekdays:
+			matches = slices.Contains(weekdays, candidate.Weekday())
+		case ScheduledPostRecurrenceWeekly:
+			matches = weeksBetween(prev, candidate)%interval == 0 && slices.Contains(weekdays, candidate.Weekday())
+		case ScheduledPostRecurrenceMonthly:
+			months := (candidate.Year()-prev.Year())*12 + int(candidate.Month()-prev.Month())
+			matches = months%interval == 0 && candidate.Day() == min(monthDay, daysIn(candidate.Month(), candidate.Year()))
+		}
+
+		if !matches {
+			continue
+		}
+
+		next := candidate.UnixMilli()
+		if rule.EndAt > 0 && next > rule.EndAt {
+			return 0, false
+		}
+		return next, true
+	}
+
+	return 0, false
+}
+
+// rule returns the recurrence with RRule, if any, expanded into the
+// structured fields.
+func (r *ScheduledPostRecurrence) rule() (*ScheduledPostRecurrence, error) {
+	if r.Frequency != ScheduledPostRecurrenceRRule {
+		return r, nil
+	}
+
+	if r.RRule == "" || len(r.RRule) > ScheduledPostRecurrenceRRuleMaxLen {
+		return nil, fmt.Errorf("rrule must be between 1 and %d characters", ScheduledPostRecurrenceRRuleMaxLen)
+	}
+
+	rule := &ScheduledPostRecurrence{
+		MonthDay:    r.MonthDay,
+		TimeZone:    r.TimeZone,
+		EndAt:       r.EndAt,
+		Count:       r.Count,
+		Occurrences: r.Occurrences,
+	}
+
+	for part := range strings.SplitSeq(strings.TrimPrefix(strings.ToUpper(r.RRule), "RRULE:"), ";") {
+		key, value, ok := strings.Cut(part, "=")
+		if !ok || value == "" {
+			return nil, fmt.Errorf("invalid rrule part %q", part)
+		}
+
+		switch key {
+		case "FREQ":
+			switch value {
+			case "DAILY":
+				rule.Frequency = ScheduledPostRecurrenceDaily
+			case "WEEKLY":
+				rule.Frequency = ScheduledPostRecurrenceWeekly
+			case "MONTHLY":
+				rule.Frequency = ScheduledPostRecurrenceMonthly
+			default:
+				return nil, fmt.Errorf("unsupported rrule frequency %q", value)
+			}
+		case "INTERVAL":
+			interval, err := strconv.Atoi(value)
+			if err != nil || interval < 1 || interval > ScheduledPostRecurrenceMaxInterval {
+				return nil, fmt.Errorf("invalid rrule interval %q", value)
+			}
+			rule.Interval = interval
+		case "BYDAY":
+			for day := range strings.SplitSeq(value, ",") {
+				weekday, ok := rruleWeekdays[day]
+				if !ok {
+					return nil, fmt.Errorf("unsupported rrule day %q", day)
+				}
+				rule.Weekdays = append(rule.Weekdays, weekday)
+			}
+		case "BYMONTHDAY":
+			monthDay, err := strconv.Atoi(value)
+			if err != nil || monthDay < 1 || monthDay > 31 {
+				return nil, fmt.Errorf("invalid rrule month day %q", value)
+			}
+			rule.MonthDay = monthDay
+		case "COUNT":
+			count, err := strconv.Atoi(value)
+			if err != nil || count < 1 {
+				return nil, fmt.Errorf("invalid rrule count %q", value)
+			}
+			if rule.Count == 0 || count < rule.Count {
+				rule.Count = count
+			}
+		case "UNTIL":
+			until, err := parseRRuleUntil(value)
+			if err != nil {
+				return nil, err
+			}
+			if rule.EndAt == 0 || until < rule.EndAt {
+				rule.EndAt = until
+			}
+		case "WKST":
+			// Weeks always start on Sunday.
+		default:
+			return nil, fmt.Errorf("unsupported rrule part %q", key)
+		}
+	}
+
+	if rule.Frequency == "" {
+		return nil, fmt.Errorf("rrule is missing FREQ")
+	}
+
+	if rule.Frequency == ScheduledPostRecurrenceMonthly && len(rule.Weekdays) > 0 {
+		return nil, fmt.Errorf("BYDAY is not supported with FREQ=MONTHLY")
+	}
+
+	return rule, nil
+}
+
+func parseRRuleUntil(value string) (int64, error) {
+	for _, layout := range []string{"20060102T150405Z", "20060102"} {
+		if until, err := time.Parse(layout, value); err == nil {
+			return until.UnixMilli(), nil
+		}
+	}
+	return 0, fmt.Errorf("invalid rrule until %q", value)
+}
+
+// weeksBetween returns the number of Sunday-started weeks between a and b.
+func weeksBetween(a, b time.Time) int {
+	startOfWeek := func(t time.Time) time.Time {
+		return time.Date(t.Year(), t.Month(), t.Day()-int(t.Weekday()), 0, 0, 0, 0, time.UTC)
+	}
+	return int(startOfWeek(b).Sub(startOfWeek(a)).Hours() / (24 * 7))
+}
+
+func daysIn(month time.Month, year int) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
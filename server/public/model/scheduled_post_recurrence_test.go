@@ -0,0 +1,212 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScheduledPostRecurrenceIsValid(t *testing.T) {
+	testCases := []struct {
+		name       string
+		recurrence ScheduledPostRecurrence
+		valid      bool
+	}{
+		{"daily", ScheduledPostRecurrence{Frequency: ScheduledPostRecurrenceDaily, TimeZone: "UTC"}, true},
+		{"weekly on chosen days", ScheduledPostRecurrence{Frequency: ScheduledPostRecurrenceWeekly, Weekdays: []time.Weekday{time.Monday, time.Friday}, TimeZone: "Europe/Berlin"}, true},
+		{"rrule", ScheduledPostRecurrence{Frequency: ScheduledPostRecurrenceRRule, RRule: "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,WE;COUNT=10", TimeZone: "UTC"}, true},
+		{"unknown frequency", ScheduledPostRecurrence{Frequency: "yearly", TimeZone: "UTC"}, false},
+		{"negative interval", ScheduledPostRecurrence{Frequency: ScheduledPostRecurrenceDaily, Interval: -1}, false},
+		{"invalid weekday", ScheduledPostRecurrence{Frequency: ScheduledPostRecurrenceWeekly, Weekdays: []time.Weekday{7}}, false},
+		{"invalid month day", ScheduledPostRecurrence{Frequency: ScheduledPostRecurrenceMonthly, MonthDay: 32}, false},
+		{"invalid time zone", ScheduledPostRecurrence{Frequency: ScheduledPostRecurrenceDaily, TimeZone: "Nowhere/Nothing"}, false},
+		{"negative count", ScheduledPostRecurrence{Frequency: ScheduledPostRecurrenceDaily, Count: -1}, false},
+		{"empty rrule", ScheduledPostRecurrence{Frequency: ScheduledPostRecurrenceRRule}, false},
+		{"unsupported rrule frequency", ScheduledPostRecurrence{Frequency: ScheduledPostRecurrenceRRule, RRule: "FREQ=YEARLY"}, false},
+		{"rrule with ordinal day", ScheduledPostRecurrence{Frequency: ScheduledPostRecurrenceRRule, RRule: "FREQ=MONTHLY;BYDAY=1MO"}, false},
+		{"rrule missing frequency", ScheduledPostRecurrence{Frequency: ScheduledPostRecurrenceRRule, RRule: "INTERVAL=2"}, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.valid {
+				assert.Nil(t, tc.recurrence.IsValid())
+			} else {
+				assert.NotNil(t, tc.recurrence.IsValid())
+			}
+		})
+	}
+}
+
+func TestScheduledPostRecurrenceNextOccurrence(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	at := func(year int, month time.Month, day, hour, minute int) int64 {
+		return time.Date(year, month, day, hour, minute, 0, 0, newYork).UnixMilli()
+	}
+
+	testCases := []struct {
+		name       string
+		recurrence ScheduledPostRecurrence
+		previous   int64
+		expected   []int64
+	}{
+		{
+			name:       "daily keeps wall clock time across DST",
+			recurrence: ScheduledPostRecurrence{Frequency: ScheduledPostRecurrenceDaily},
+			previous:   at(2025, time.March, 8, 9, 0),
+			expected:   []int64{at(2025, time.March, 9, 9, 0), at(2025, time.March, 10, 9, 0)},
+		},
+		{
+			name:       "every other day",
+			recurrence: ScheduledPostRecurrence{Frequency: ScheduledPostRecurrenceDaily, Interval: 2},
+			previous:   at(2025, time.June, 1, 9, 0),
+			expected:   []int64{at(2025, time.June, 3, 9, 0), at(2025, time.June, 5, 9, 0)},
+		},
+		{
+			name:       "weekdays skip the weekend",
+			recurrence: ScheduledPostRecurrence{Frequency: ScheduledPostRecurrenceWeekdays},
+			previous:   at(2025, time.June, 5, 9, 30), // Thursday
+			expected:   []int64{at(2025, time.June, 6, 9, 30), at(2025, time.June, 9, 9, 30)},
+		},
+		{
+			name:       "weekly on the same day",
+			recurrence: ScheduledPostRecurrence{Frequency: ScheduledPostRecurrenceWeekly},
+			previous:   at(2025, time.June, 2, 10, 0), // Monday
+			expected:   []int64{at(2025, time.June, 9, 10, 0), at(2025, time.June, 16, 10, 0)},
+		},
+		{
+			name:       "every other week on chosen days",
+			recurrence: ScheduledPostRecurrence{Frequency: ScheduledPostRecurrenceWeekly, Interval: 2, Weekdays: []time.Weekday{time.Monday, time.Friday}},
+			previous:   at(2025, time.June, 2, 10, 0), // Monday
+			expected:   []int64{at(2025, time.June, 6, 10, 0), at(2025, time.June, 16, 10, 0), at(2025, time.June, 20, 10, 0)},
+		},
+		{
+			name:       "monthly on the 31st clamps to the end of shorter months",
+			recurrence: ScheduledPostRecurrence{Frequency: ScheduledPostRecurrenceMonthly, MonthDay: 31},
+			previous:   at(2025, time.January, 31, 8, 0),
+			expected:   []int64{at(2025, time.February, 28, 8, 0), at(2025, time.March, 31, 8, 0), at(2025, time.April, 30, 8, 0)},
+		},
+		{
+			name:       "rrule weekly",
+			recurrence: ScheduledPostRecurrence{Frequency: ScheduledPostRecurrenceRRule, RRule: "RRULE:FREQ=WEEKLY;BYDAY=TU,TH"},
+			previous:   at(2025, time.June, 3, 17, 0), // Tuesday
+			expected:   []int64{at(2025, time.June, 5, 17, 0), at(2025, time.June, 10, 17, 0)},
+		},
+		{
+			name:       "rrule monthly by month day",
+			recurrence: ScheduledPostRecurrence{Frequency: ScheduledPostRecurrenceRRule, RRule: "FREQ=MONTHLY;INTERVAL=3;BYMONTHDAY=15"},
+			previous:   at(2025, time.January, 15, 12, 0),
+			expected:   []int64{at(2025, time.April, 15, 12, 0), at(2025, time.July, 15, 12, 0)},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.recurrence.TimeZone = "America/New_York"
+			previous := tc.previous
+			for _, expected := range tc.expected {
+				next, ok := tc.recurrence.NextOccurrence(previous)
+				require.True(t, ok)
+				assert.Equal(t, time.UnixMilli(expected).In(newYork), time.UnixMilli(next).In(newYork))
+				previous = next
+			}
+		})
+	}
+
+	t.Run("stops at the end date", func(t *testing.T) {
+		recurrence := ScheduledPostRecurrence{
+			Frequency: ScheduledPostRecurrenceDaily,
+			TimeZone:  "America/New_York",
+			EndAt:     at(2025, time.June, 2, 12, 0),
+		}
+
+		next, ok := recurrence.NextOccurrence(at(2025, time.June, 1, 9, 0))
+		require.True(t, ok)
+		assert.Equal(t, at(2025, time.June, 2, 9, 0), next)
+
+		_, ok = recurrence.NextOccurrence(next)
+		assert.False(t, ok)
+	})
+
+	t.Run("stops at the rrule until date", func(t *testing.T) {
+		recurrence := ScheduledPostRecurrence{
+			Frequency: ScheduledPostRecurrenceRRule,
+			RRule:     "FREQ=DAILY;UNTIL=20250602T000000Z",
+			TimeZone:  "UTC",
+		}
+
+		_, ok := recurrence.NextOccurrence(time.Date(2025, time.June, 1, 9, 0, 0, 0, time.UTC).UnixMilli())
+		assert.False(t, ok)
+	})
+}
+
+func TestScheduledPostAdvanceRecurrence(t *testing.T) {
+	start := time.Date(2025, time.June, 1, 9, 0, 0, 0, time.UTC).UnixMilli()
+
+	t.Run("not recurring", func(t *testing.T) {
+		scheduledPost := &ScheduledPost{ScheduledAt: start}
+		assert.False(t, scheduledPost.AdvanceRecurrence())
+		assert.Equal(t, start, scheduledPost.ScheduledAt)
+	})
+
+	t.Run("honours the occurrence count", func(t *testing.T) {
+		scheduledPost := &ScheduledPost{
+			ScheduledAt: start,
+			ErrorCode:   ScheduledPostErrorUnknownError,
+			Recurrence: &ScheduledPostRecurrence{
+				Frequency: ScheduledPostRecurrenceDaily,
+				TimeZone:  "UTC",
+				Count:     3,
+			},
+		}
+
+		require.True(t, scheduledPost.AdvanceRecurrence())
+		assert.Equal(t, start+24*60*60*1000, scheduledPost.ScheduledAt)
+		assert.Empty(t, scheduledPost.ErrorCode)
+		assert.Equal(t, 1, scheduledPost.Recurrence.Occurrences)
+
+		require.True(t, scheduledPost.AdvanceRecurrence())
+		assert.False(t, scheduledPost.AdvanceRecurrence())
+		assert.Equal(t, 3, scheduledPost.Recurrence.Occurrences)
+	})
+
+	t.Run("clone is independent", func(t *testing.T) {
+		scheduledPost := &ScheduledPost{
+			ScheduledAt: start,
+			Recurrence: &ScheduledPostRecurrence{
+				Frequency: ScheduledPostRecurrenceDaily,
+				TimeZone:  "UTC",
+			},
+		}
+
+		occurrence := scheduledPost.Clone()
+		require.True(t, scheduledPost.AdvanceRecurrence())
+		assert.Equal(t, start, occurrence.ScheduledAt)
+		assert.Equal(t, 0, occurrence.Recurrence.Occurrences)
+	})
+}
+
+func TestScheduledPostNormalizeRecurrence(t *testing.T) {
+	scheduledPost := &ScheduledPost{
+		// 2025-01-31 23:30 in UTC is already February 1st in Tokyo.
+		ScheduledAt: time.Date(2025, time.January, 31, 23, 30, 0, 0, time.UTC).UnixMilli(),
+		Recurrence:  &ScheduledPostRecurrence{Frequency: ScheduledPostRecurrenceMonthly},
+	}
+
+	scheduledPost.NormalizeRecurrence("Asia/Tokyo")
+	assert.Equal(t, "Asia/Tokyo", scheduledPost.Recurrence.TimeZone)
+	assert.Equal(t, 1, scheduledPost.Recurrence.MonthDay)
+
+	scheduledPost.Recurrence.MonthDay = 0
+	scheduledPost.Recurrence.TimeZone = "UTC"
+	scheduledPost.NormalizeRecurrence("Asia/Tokyo")
+	assert.Equal(t, "UTC", scheduledPost.Recurrence.TimeZone)
+	assert.Equal(t, 31, scheduledPost.Recurrence.MonthDay)
+}
@@ -0,0 +1,77 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const (
+	// WebPushPublicKeyLength is the length of an uncompressed P-256 public key.
+	WebPushPublicKeyLength = 65
+	// WebPushAuthSecretLength is the length of the authentication secret of a subscription.
+	WebPushAuthSecretLength = 16
+
+	WebPushSubscriptionMaxEndpointLength = 2048
+)
+
+// WebPushSubscription is the push subscription a browser creates through the
+// Push API, as returned by PushSubscription.toJSON().
+type WebPushSubscription struct {
+	Endpoint string                  `json:"endpoint"`
+	Keys     WebPushSubscriptionKeys `json:"keys"`
+}
+
+// WebPushSubscriptionKeys holds the keys the notification payloads are
+// encrypted with, encoded in unpadded base64url.
+type WebPushSubscriptionKeys struct {
+	P256dh string `json:"p256dh"`
+	Auth   string `json:"auth"`
+}
+
+// Auditable identifies the subscription by its device id, leaving out its endpoint and keys.
+func (s *WebPushSubscription) Auditable() map[string]any {
+	return map[string]any{
+		"device_id": s.DeviceId(),
+	}
+}
+
+func (s *WebPushSubscription) IsValid() *AppError {
+	if len(s.Endpoint) > WebPushSubscriptionMaxEndpointLength {
+		return NewAppError("WebPushSubscription.IsValid", "model.web_push_subscription.is_valid.endpoint.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	u, err := url.Parse(s.Endpoint)
+	if err != nil || u.Scheme != "https" || u.Host == "" {
+		return NewAppError("WebPushSubscription.IsValid", "model.web_push_subscription.is_valid.endpoint.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if key, err := DecodeWebPushKey(s.Keys.P256dh); err != nil || len(key) != WebPushPublicKeyLength || key[0] != 0x04 {
+		return NewAppError("WebPushSubscription.IsValid", "model.web_push_subscription.is_valid.p256dh.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if secret, err := DecodeWebPushKey(s.Keys.Auth); err != nil || len(secret) != WebPushAuthSecretLength {
+		return NewAppError("WebPushSubscription.IsValid", "model.web_push_subscription.is_valid.auth.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+// DeviceId returns the device id of the sessions the subscription is attached
+// to. It is derived from the endpoint, which is unique to the subscription.
+func (s *WebPushSubscription) DeviceId() string {
+	hash := sha256.Sum256([]byte(s.Endpoint))
+	return PushNotifyWebPush + ":" + hex.EncodeToString(hash[:])
+}
+
+// DecodeWebPushKey decodes a key of a push subscription. Browsers encode them
+// in unpadded base64url, but padded values are accepted too.
+func DecodeWebPushKey(key string) ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(strings.TrimRight(key, "="))
+}
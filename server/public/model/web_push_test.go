@@ -0,0 +1,59 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebPushSubscriptionIsValid(t *testing.T) {
+	publicKey := make([]byte, WebPushPublicKeyLength)
+	publicKey[0] = 0x04
+
+	s := WebPushSubscription{}
+	require.NotNil(t, s.IsValid())
+
+	s.Endpoint = "http://push.example.com/send/abc"
+	require.NotNil(t, s.IsValid())
+
+	s.Endpoint = "https://push.example.com/" + strings.Repeat("a", WebPushSubscriptionMaxEndpointLength)
+	require.NotNil(t, s.IsValid())
+
+	s.Endpoint = "https://push.example.com/send/abc"
+	require.NotNil(t, s.IsValid())
+
+	s.Keys.P256dh = base64.RawURLEncoding.EncodeToString(publicKey[1:])
+	require.NotNil(t, s.IsValid())
+
+	s.Keys.P256dh = base64.RawURLEncoding.EncodeToString(publicKey)
+	require.NotNil(t, s.IsValid())
+
+	s.Keys.Auth = base64.RawURLEncoding.EncodeToString(make([]byte, 8))
+	require.NotNil(t, s.IsValid())
+
+	s.Keys.Auth = base64.RawURLEncoding.EncodeToString(make([]byte, WebPushAuthSecretLength))
+	require.Nil(t, s.IsValid())
+
+	s.Keys.Auth = base64.URLEncoding.EncodeToString(make([]byte, WebPushAuthSecretLength))
+	require.Nil(t, s.IsValid())
+}
+
+func TestWebPushSubscriptionDeviceId(t *testing.T) {
+	s := WebPushSubscription{Endpoint: "https://push.example.com/send/abc"}
+	other := WebPushSubscription{Endpoint: "https://push.example.com/send/def"}
+
+	deviceID := s.DeviceId()
+	assert.True(t, strings.HasPrefix(deviceID, PushNotifyWebPush+":"))
+	assert.Equal(t, deviceID, s.DeviceId())
+	assert.NotEqual(t, deviceID, other.DeviceId())
+
+	pn := &PushNotification{}
+	pn.SetDeviceIdAndPlatform(deviceID)
+	assert.Equal(t, PushNotifyWebPush, pn.Platform)
+}
@@ -45,6 +45,7 @@ const (
 	WebsocketEventEphemeralMessage                    WebsocketEventType = "ephemeral_message"
 	WebsocketEventStatusChange                        WebsocketEventType = "status_change"
 	WebsocketEventHello                               WebsocketEventType = "hello"
+	WebsocketEventResyncRequired                      WebsocketEventType = "resync_required"
 	WebsocketAuthenticationChallenge                  WebsocketEventType = "authentication_challenge"
 	WebsocketEventReactionAdded                       WebsocketEventType = "reaction_added"
 	WebsocketEventReactionRemoved                     WebsocketEventType = "reaction_removed"
@@ -0,0 +1,259 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	WorkingHoursMaxRanges     = 28
+	WorkingHoursMaxExceptions = 100
+
+	// WorkingHoursStatusInterval is how often the job switching the statuses of users in and out of
+	// their quiet hours runs.
+	WorkingHoursStatusInterval = 1 * time.Minute
+
+	workingHoursTimeLayout = "15:04"
+	workingHoursDateLayout = "2006-01-02"
+)
+
+// WorkingHours is the weekly schedule a user works on. Outside of it the user is in quiet hours: the
+// user's status is switched to QuietStatus, and notifications are held back unless urgent posts are
+// allowed to bypass them.
+type WorkingHours struct {
+	UserId       string                 `json:"user_id"`
+	Enabled      bool                   `json:"enabled"`
+	Timezone     string                 `json:"timezone"`
+	Schedule     WorkingHoursRanges     `json:"schedule"`
+	Exceptions   WorkingHoursExceptions `json:"exceptions"`
+	QuietStatus  string                 `json:"quiet_status"`
+	UrgentBypass bool                   `json:"urgent_bypass"`
+	// InQuietHours is maintained by the server, to switch the status of the user only when the quiet
+	// hours start or end.
+	InQuietHours bool `json:"in_quiet_hours"`
+	// QuietStatusSet is whether the server switched the status of the user when the quiet hours
+	// started, as opposed to the user setting it on their own.
+	QuietStatusSet bool  `json:"-"`
+	UpdateAt       int64 `json:"update_at"`
+}
+
+// WorkingHoursRange is a range of working time on a day of the week, from Sunday (0) to Saturday (6).
+// A range ending before it starts spans midnight, ending on the next day.
+type WorkingHoursRange struct {
+	Day   time.Weekday `json:"day"`
+	Start string       `json:"start"`
+	End   string       `json:"end"`
+}
+
+// WorkingHoursException replaces the schedule on a date, such as a holiday. Without a start and end,
+// the whole date is outside of working hours.
+type WorkingHoursException struct {
+	Date  string `json:"date"`
+	Name  string `json:"name,omitempty"`
+	Start string `json:"start,omitempty"`
+	End   string `json:"end,omitempty"`
+}
+
+type WorkingHoursRanges []WorkingHoursRange
+
+type WorkingHoursExceptions []WorkingHoursException
+
+func (wh *WorkingHours) PreSave() {
+	if wh.QuietStatus == "" {
+		wh.QuietStatus = StatusDnd
+	}
+	if wh.Schedule == nil {
+		wh.Schedule = WorkingHoursRanges{}
+	}
+	if wh.Exceptions == nil {
+		wh.Exceptions = WorkingHoursExceptions{}
+	}
+
+	wh.UpdateAt = GetMillis()
+}
+
+func (wh *WorkingHours) IsValid() *AppError {
+	if !IsValidId(wh.UserId) {
+		return NewAppError("WorkingHours.IsValid", "model.working_hours.is_valid.user_id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if wh.Timezone == "" {
+		return NewAppError("WorkingHours.IsValid", "model.working_hours.is_valid.timezone.app_error", nil, "", http.StatusBadRequest)
+	}
+	if _, err := time.LoadLocation(wh.Timezone); err != nil {
+		return NewAppError("WorkingHours.IsValid", "model.working_hours.is_valid.timezone.app_error", nil, "", http.StatusBadRequest).Wrap(err)
+	}
+
+	if wh.QuietStatus != StatusDnd && wh.QuietStatus != StatusAway {
+		return NewAppError("WorkingHours.IsValid", "model.working_hours.is_valid.quiet_status.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if len(wh.Schedule) > WorkingHoursMaxRanges {
+		return NewAppError("WorkingHours.IsValid", "model.working_hours.is_valid.schedule.app_error", nil, "", http.StatusBadRequest)
+	}
+	for _, r := range wh.Schedule {
+		if r.Day < time.Sunday || r.Day > time.Saturday {
+			return NewAppError("WorkingHours.IsValid", "model.working_hours.is_valid.schedule.app_error", nil, "", http.StatusBadRequest)
+		}
+		if _, _, err := parseWorkingHoursRange(r.Start, r.End); err != nil {
+			return NewAppError("WorkingHours.IsValid", "model.working_hours.is_valid.schedule.app_error", nil, "", http.StatusBadRequest).Wrap(err)
+		}
+	}
+
+	if len(wh.Exceptions) > WorkingHoursMaxExceptions {
+		return NewAppError("WorkingHours.IsValid", "model.working_hours.is_valid.exceptions.app_error", nil, "", http.StatusBadRequest)
+	}
+	for _, e := range wh.Exceptions {
+		if _, err := time.Parse(workingHoursDateLayout, e.Date); err != nil {
+			return NewAppError("WorkingHours.IsValid", "model.working_hours.is_valid.exceptions.app_error", nil, "", http.StatusBadRequest).Wrap(err)
+		}
+		if len(e.Name) > 64 {
+			return NewAppError("WorkingHours.IsValid", "model.working_hours.is_valid.exceptions.app_error", nil, "", http.StatusBadRequest)
+		}
+		if e.Start == "" && e.End == "" {
+			continue
+		}
+		if _, _, err := parseWorkingHoursRange(e.Start, e.End); err != nil {
+			return NewAppError("WorkingHours.IsValid", "model.working_hours.is_valid.exceptions.app_error", nil, "", http.StatusBadRequest).Wrap(err)
+		}
+	}
+
+	return nil
+}
+
+// IsWorkingTime returns whether the given time is within the working hours, in their timezone.
+func (wh *WorkingHours) IsWorkingTime(t time.Time) bool {
+	local := t.In(loadWorkingHoursLocation(wh.Timezone))
+	minutes := local.Hour()*60 + local.Minute()
+
+	date := local.Format(workingHoursDateLayout)
+	for _, e := range wh.Exceptions {
+		if e.Date != date {
+			continue
+		}
+		start, end, err := parseWorkingHoursRange(e.Start, e.End)
+		if err != nil {
+			return false
+		}
+		return isInWorkingHoursRange(minutes, start, end)
+	}
+
+	weekday := local.Weekday()
+	for _, r := range wh.Schedule {
+		start, end, err := parseWorkingHoursRange(r.Start, r.End)
+		if err != nil {
+			continue
+		}
+		if start < end {
+			if r.Day == weekday && minutes >= start && minutes < end {
+				return true
+			}
+			continue
+		}
+		// The range spans midnight.
+		if (r.Day == weekday && minutes >= start) || ((r.Day+1)%7 == weekday && minutes < end) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsQuietTime returns whether the working hours are enabled, and the given time is outside of them.
+func (wh *WorkingHours) IsQuietTime(t time.Time) bool {
+	return wh.Enabled && !wh.IsWorkingTime(t)
+}
+
+// workingHoursLocations caches the locations of the working hours timezones, as loading them reads
+// the timezone database.
+var workingHoursLocations sync.Map
+
+// loadWorkingHoursLocation returns the location of the timezone, or UTC when it is unknown.
+func loadWorkingHoursLocation(timezone string) *time.Location {
+	if location, ok := workingHoursLocations.Load(timezone); ok {
+		return location.(*time.Location)
+	}
+
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		location = time.UTC
+	}
+	workingHoursLocations.Store(timezone, location)
+
+	return location
+}
+
+// parseWorkingHoursRange returns the start and end of the range, in minutes since midnight.
+func parseWorkingHoursRange(start, end string) (int, int, error) {
+	startTime, err := time.Parse(workingHoursTimeLayout, start)
+	if err != nil {
+		return 0, 0, err
+	}
+	endTime, err := time.Parse(workingHoursTimeLayout, end)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	startMinutes := startTime.Hour()*60 + startTime.Minute()
+	endMinutes := endTime.Hour()*60 + endTime.Minute()
+	if startMinutes == endMinutes {
+		return 0, 0, errors.New("empty range")
+	}
+
+	return startMinutes, endMinutes, nil
+}
+
+// isInWorkingHoursRange returns whether the minutes are in the range of a single day. The part of a
+// range spanning midnight which is on the next day is ignored.
+func isInWorkingHoursRange(minutes, start, end int) bool {
+	if start < end {
+		return minutes >= start && minutes < end
+	}
+	return minutes >= start
+}
+
+func (r WorkingHoursRanges) Value() (driver.Value, error) {
+	j, err := json.Marshal(r)
+	if err != nil {
+		return nil, err
+	}
+	return string(j), nil
+}
+
+func (r *WorkingHoursRanges) Scan(value any) error {
+	return scanWorkingHoursJSON(value, r)
+}
+
+func (e WorkingHoursExceptions) Value() (driver.Value, error) {
+	j, err := json.Marshal(e)
+	if err != nil {
+		return nil, err
+	}
+	return string(j), nil
+}
+
+func (e *WorkingHoursExceptions) Scan(value any) error {
+	return scanWorkingHoursJSON(value, e)
+}
+
+func scanWorkingHoursJSON(value any, v any) error {
+	if value == nil {
+		return nil
+	}
+
+	switch data := value.(type) {
+	case []byte:
+		return json.Unmarshal(data, v)
+	case string:
+		return json.Unmarshal([]byte(data), v)
+	}
+
+	return errors.New("received value is neither a byte slice nor string")
+}
@@ -0,0 +1,119 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkingHoursIsValid(t *testing.T) {
+	wh := WorkingHours{}
+	require.NotNil(t, wh.IsValid())
+
+	wh.UserId = NewId()
+	require.NotNil(t, wh.IsValid())
+
+	wh.Timezone = "Nowhere/Special"
+	require.NotNil(t, wh.IsValid())
+
+	wh.Timezone = "Europe/Paris"
+	require.NotNil(t, wh.IsValid())
+
+	wh.PreSave()
+	require.Nil(t, wh.IsValid())
+	assert.Equal(t, StatusDnd, wh.QuietStatus)
+
+	wh.QuietStatus = StatusOffline
+	require.NotNil(t, wh.IsValid())
+
+	wh.QuietStatus = StatusAway
+	wh.Schedule = WorkingHoursRanges{{Day: 7, Start: "09:00", End: "17:00"}}
+	require.NotNil(t, wh.IsValid())
+
+	wh.Schedule = WorkingHoursRanges{{Day: time.Monday, Start: "09:00", End: "09:00"}}
+	require.NotNil(t, wh.IsValid())
+
+	wh.Schedule = WorkingHoursRanges{{Day: time.Monday, Start: "9am", End: "17:00"}}
+	require.NotNil(t, wh.IsValid())
+
+	wh.Schedule = WorkingHoursRanges{{Day: time.Monday, Start: "09:00", End: "17:00"}, {Day: time.Friday, Start: "22:00", End: "06:00"}}
+	require.Nil(t, wh.IsValid())
+
+	wh.Exceptions = WorkingHoursExceptions{{Date: "25/12/2026"}}
+	require.NotNil(t, wh.IsValid())
+
+	wh.Exceptions = WorkingHoursExceptions{{Date: "2026-12-24", Start: "09:00"}}
+	require.NotNil(t, wh.IsValid())
+
+	wh.Exceptions = WorkingHoursExceptions{{Date: "2026-12-25", Name: "Christmas"}, {Date: "2026-12-24", Start: "09:00", End: "12:00"}}
+	require.Nil(t, wh.IsValid())
+}
+
+func TestWorkingHoursIsWorkingTime(t *testing.T) {
+	wh := WorkingHours{
+		Enabled:  true,
+		Timezone: "America/New_York",
+		Schedule: WorkingHoursRanges{
+			{Day: time.Monday, Start: "09:00", End: "17:00"},
+			{Day: time.Friday, Start: "22:00", End: "02:00"},
+		},
+		Exceptions: WorkingHoursExceptions{
+			{Date: "2026-10-12", Name: "Holiday"},
+			{Date: "2026-10-19", Start: "10:00", End: "12:00"},
+		},
+	}
+
+	location, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2026, time.October, day, hour, minute, 0, 0, location).In(time.UTC)
+	}
+
+	testCases := []struct {
+		name     string
+		time     time.Time
+		expected bool
+	}{
+		{"monday morning", at(5, 9, 0), true},
+		{"monday end", at(5, 17, 0), false},
+		{"monday at night", at(5, 3, 0), false},
+		{"tuesday", at(6, 10, 0), false},
+		{"friday night", at(9, 23, 30), true},
+		{"saturday after midnight", at(10, 1, 59), true},
+		{"saturday morning", at(10, 2, 0), false},
+		{"holiday", at(12, 10, 0), false},
+		{"shortened day", at(19, 11, 0), true},
+		{"shortened day afternoon", at(19, 14, 0), false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, wh.IsWorkingTime(tc.time))
+			assert.Equal(t, !tc.expected, wh.IsQuietTime(tc.time))
+		})
+	}
+
+	wh.Enabled = false
+	assert.False(t, wh.IsQuietTime(at(5, 3, 0)))
+}
+
+func TestWorkingHoursRangesScan(t *testing.T) {
+	ranges := WorkingHoursRanges{{Day: time.Monday, Start: "09:00", End: "17:00"}}
+	value, err := ranges.Value()
+	require.NoError(t, err)
+
+	var scanned WorkingHoursRanges
+	require.NoError(t, scanned.Scan(value))
+	assert.Equal(t, ranges, scanned)
+
+	var exceptions WorkingHoursExceptions
+	require.NoError(t, exceptions.Scan([]byte(`[{"date":"2026-12-25"}]`)))
+	assert.Equal(t, WorkingHoursExceptions{{Date: "2026-12-25"}}, exceptions)
+
+	require.Error(t, exceptions.Scan(42))
+}
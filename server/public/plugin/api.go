@@ -1564,6 +1564,38 @@ type API interface {
 	// @tag Audit
 	// Minimum server version: 10.10
 	LogAuditRecWithLevel(rec *model.AuditRecord, level mlog.Level)
+
+	// SummarizeThread generates a summary of the thread the post belongs to, on behalf of the user.
+	// level is one of "brief", "standard" or "detailed", and defaults to "standard".
+	// The user must be able to read the channel of the thread.
+	//
+	// @tag AI
+	// Minimum server version: 11.2
+	SummarizeThread(userID, postID, level string) (*model.AISummary, *model.AppError)
+
+	// FormatMessage rewrites the message according to the formatting profile, on behalf of the user.
+	// profile defaults to "professional".
+	//
+	// @tag AI
+	// Minimum server version: 11.2
+	FormatMessage(userID, message, profile string) (string, *model.AppError)
+
+	// CreateActionItem creates an action item on behalf of its creator, who must be able
+	// to read the channel of the action item.
+	//
+	// @tag AI
+	// Minimum server version: 11.2
+	CreateActionItem(item *model.AIActionItem) (*model.AIActionItem, *model.AppError)
+
+	// CreateAICompletion sends the prompt to the LLM configured for the server, on behalf of the user,
+	// and returns its completion.
+	//
+	// Prompts sent through this API count against the AI rate limit of the server, per plugin,
+	// and can be modified or rejected by the AIPromptWillBeSent hook.
+	//
+	// @tag AI
+	// Minimum server version: 11.2
+	CreateAICompletion(userID string, request *model.AICompletionRequest) (*model.AICompletionResponse, *model.AppError)
 }
 
 var handshake = plugin.HandshakeConfig{
@@ -1671,3 +1671,31 @@ func (api *apiTimerLayer) LogAuditRecWithLevel(rec *model.AuditRecord, level mlo
 	api.apiImpl.LogAuditRecWithLevel(rec, level)
 	api.recordTime(startTime, "LogAuditRecWithLevel", true)
 }
+
+func (api *apiTimerLayer) SummarizeThread(userID, postID, level string) (*model.AISummary, *model.AppError) {
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.SummarizeThread(userID, postID, level)
+	api.recordTime(startTime, "SummarizeThread", _returnsB == nil)
+	return _returnsA, _returnsB
+}
+
+func (api *apiTimerLayer) FormatMessage(userID, message, profile string) (string, *model.AppError) {
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.FormatMessage(userID, message, profile)
+	api.recordTime(startTime, "FormatMessage", _returnsB == nil)
+	return _returnsA, _returnsB
+}
+
+func (api *apiTimerLayer) CreateActionItem(item *model.AIActionItem) (*model.AIActionItem, *model.AppError) {
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.CreateActionItem(item)
+	api.recordTime(startTime, "CreateActionItem", _returnsB == nil)
+	return _returnsA, _returnsB
+}
+
+func (api *apiTimerLayer) CreateAICompletion(userID string, request *model.AICompletionRequest) (*model.AICompletionResponse, *model.AppError) {
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.CreateAICompletion(userID, request)
+	api.recordTime(startTime, "CreateAICompletion", _returnsB == nil)
+	return _returnsA, _returnsB
+}
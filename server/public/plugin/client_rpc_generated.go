@@ -1234,6 +1234,294 @@ func (s *hooksRPCServer) OnSAMLLogin(args *Z_OnSAMLLoginArgs, returns *Z_OnSAMLL
 	return nil
 }
 
+func init() {
+	hookNameToId["ActionItemHasBeenCreated"] = ActionItemHasBeenCreatedID
+}
+
+type Z_ActionItemHasBeenCreatedArgs struct {
+	A *Context
+	B *model.AIActionItem
+}
+
+type Z_ActionItemHasBeenCreatedReturns struct {
+}
+
+func (g *hooksRPCClient) ActionItemHasBeenCreated(c *Context, item *model.AIActionItem) {
+	_args := &Z_ActionItemHasBeenCreatedArgs{c, item}
+	_returns := &Z_ActionItemHasBeenCreatedReturns{}
+	if g.implemented[ActionItemHasBeenCreatedID] {
+		if err := g.client.Call("Plugin.ActionItemHasBeenCreated", _args, _returns); err != nil {
+			g.log.Error("RPC call ActionItemHasBeenCreated to plugin failed.", mlog.Err(err))
+		}
+	}
+
+}
+
+func (s *hooksRPCServer) ActionItemHasBeenCreated(args *Z_ActionItemHasBeenCreatedArgs, returns *Z_ActionItemHasBeenCreatedReturns) error {
+	if hook, ok := s.impl.(interface {
+		ActionItemHasBeenCreated(c *Context, item *model.AIActionItem)
+	}); ok {
+		hook.ActionItemHasBeenCreated(args.A, args.B)
+	} else {
+		return encodableError(fmt.Errorf("Hook ActionItemHasBeenCreated called but not implemented."))
+	}
+	return nil
+}
+
+func init() {
+	hookNameToId["ActionItemHasBeenUpdated"] = ActionItemHasBeenUpdatedID
+}
+
+type Z_ActionItemHasBeenUpdatedArgs struct {
+	A *Context
+	B *model.AIActionItem
+	C *model.AIActionItem
+}
+
+type Z_ActionItemHasBeenUpdatedReturns struct {
+}
+
+func (g *hooksRPCClient) ActionItemHasBeenUpdated(c *Context, newItem, oldItem *model.AIActionItem) {
+	_args := &Z_ActionItemHasBeenUpdatedArgs{c, newItem, oldItem}
+	_returns := &Z_ActionItemHasBeenUpdatedReturns{}
+	if g.implemented[ActionItemHasBeenUpdatedID] {
+		if err := g.client.Call("Plugin.ActionItemHasBeenUpdated", _args, _returns); err != nil {
+			g.log.Error("RPC call ActionItemHasBeenUpdated to plugin failed.", mlog.Err(err))
+		}
+	}
+
+}
+
+func (s *hooksRPCServer) ActionItemHasBeenUpdated(args *Z_ActionItemHasBeenUpdatedArgs, returns *Z_ActionItemHasBeenUpdatedReturns) error {
+	if hook, ok := s.impl.(interface {
+		ActionItemHasBeenUpdated(c *Context, newItem, oldItem *model.AIActionItem)
+	}); ok {
+		hook.ActionItemHasBeenUpdated(args.A, args.B, args.C)
+	} else {
+		return encodableError(fmt.Errorf("Hook ActionItemHasBeenUpdated called but not implemented."))
+	}
+	return nil
+}
+
+func init() {
+	hookNameToId["AIPromptWillBeSent"] = AIPromptWillBeSentID
+}
+
+type Z_AIPromptWillBeSentArgs struct {
+	A *Context
+	B *model.AIPrompt
+}
+
+type Z_AIPromptWillBeSentReturns struct {
+	A *model.AIPrompt
+	B string
+}
+
+func (g *hooksRPCClient) AIPromptWillBeSent(c *Context, prompt *model.AIPrompt) (*model.AIPrompt, string) {
+	_args := &Z_AIPromptWillBeSentArgs{c, prompt}
+	_returns := &Z_AIPromptWillBeSentReturns{}
+	if g.implemented[AIPromptWillBeSentID] {
+		if err := g.client.Call("Plugin.AIPromptWillBeSent", _args, _returns); err != nil {
+			g.log.Error("RPC call AIPromptWillBeSent to plugin failed.", mlog.Err(err))
+		}
+	}
+	return _returns.A, _returns.B
+}
+
+func (s *hooksRPCServer) AIPromptWillBeSent(args *Z_AIPromptWillBeSentArgs, returns *Z_AIPromptWillBeSentReturns) error {
+	if hook, ok := s.impl.(interface {
+		AIPromptWillBeSent(c *Context, prompt *model.AIPrompt) (*model.AIPrompt, string)
+	}); ok {
+		returns.A, returns.B = hook.AIPromptWillBeSent(args.A, args.B)
+	} else {
+		return encodableError(fmt.Errorf("Hook AIPromptWillBeSent called but not implemented."))
+	}
+	return nil
+}
+
+func init() {
+	hookNameToId["ChannelWillBeCreated"] = ChannelWillBeCreatedID
+}
+
+type Z_ChannelWillBeCreatedArgs struct {
+	A *Context
+	B *model.Channel
+}
+
+type Z_ChannelWillBeCreatedReturns struct {
+	A *model.Channel
+	B string
+}
+
+func (g *hooksRPCClient) ChannelWillBeCreated(c *Context, channel *model.Channel) (*model.Channel, string) {
+	_args := &Z_ChannelWillBeCreatedArgs{c, channel}
+	_returns := &Z_ChannelWillBeCreatedReturns{}
+	if g.implemented[ChannelWillBeCreatedID] {
+		if err := g.client.Call("Plugin.ChannelWillBeCreated", _args, _returns); err != nil {
+			g.log.Error("RPC call ChannelWillBeCreated to plugin failed.", mlog.Err(err))
+		}
+	}
+	return _returns.A, _returns.B
+}
+
+func (s *hooksRPCServer) ChannelWillBeCreated(args *Z_ChannelWillBeCreatedArgs, returns *Z_ChannelWillBeCreatedReturns) error {
+	if hook, ok := s.impl.(interface {
+		ChannelWillBeCreated(c *Context, channel *model.Channel) (*model.Channel, string)
+	}); ok {
+		returns.A, returns.B = hook.ChannelWillBeCreated(args.A, args.B)
+	} else {
+		return encodableError(fmt.Errorf("Hook ChannelWillBeCreated called but not implemented."))
+	}
+	return nil
+}
+
+func init() {
+	hookNameToId["ChannelWillBeArchived"] = ChannelWillBeArchivedID
+}
+
+type Z_ChannelWillBeArchivedArgs struct {
+	A *Context
+	B *model.Channel
+	C *model.User
+}
+
+type Z_ChannelWillBeArchivedReturns struct {
+	A string
+}
+
+func (g *hooksRPCClient) ChannelWillBeArchived(c *Context, channel *model.Channel, actor *model.User) string {
+	_args := &Z_ChannelWillBeArchivedArgs{c, channel, actor}
+	_returns := &Z_ChannelWillBeArchivedReturns{}
+	if g.implemented[ChannelWillBeArchivedID] {
+		if err := g.client.Call("Plugin.ChannelWillBeArchived", _args, _returns); err != nil {
+			g.log.Error("RPC call ChannelWillBeArchived to plugin failed.", mlog.Err(err))
+		}
+	}
+	return _returns.A
+}
+
+func (s *hooksRPCServer) ChannelWillBeArchived(args *Z_ChannelWillBeArchivedArgs, returns *Z_ChannelWillBeArchivedReturns) error {
+	if hook, ok := s.impl.(interface {
+		ChannelWillBeArchived(c *Context, channel *model.Channel, actor *model.User) string
+	}); ok {
+		returns.A = hook.ChannelWillBeArchived(args.A, args.B, args.C)
+	} else {
+		return encodableError(fmt.Errorf("Hook ChannelWillBeArchived called but not implemented."))
+	}
+	return nil
+}
+
+func init() {
+	hookNameToId["UserWillJoinChannel"] = UserWillJoinChannelID
+}
+
+type Z_UserWillJoinChannelArgs struct {
+	A *Context
+	B *model.ChannelMember
+	C *model.Channel
+}
+
+type Z_UserWillJoinChannelReturns struct {
+	A *model.ChannelMember
+	B string
+}
+
+func (g *hooksRPCClient) UserWillJoinChannel(c *Context, channelMember *model.ChannelMember, channel *model.Channel) (*model.ChannelMember, string) {
+	_args := &Z_UserWillJoinChannelArgs{c, channelMember, channel}
+	_returns := &Z_UserWillJoinChannelReturns{}
+	if g.implemented[UserWillJoinChannelID] {
+		if err := g.client.Call("Plugin.UserWillJoinChannel", _args, _returns); err != nil {
+			g.log.Error("RPC call UserWillJoinChannel to plugin failed.", mlog.Err(err))
+		}
+	}
+	return _returns.A, _returns.B
+}
+
+func (s *hooksRPCServer) UserWillJoinChannel(args *Z_UserWillJoinChannelArgs, returns *Z_UserWillJoinChannelReturns) error {
+	if hook, ok := s.impl.(interface {
+		UserWillJoinChannel(c *Context, channelMember *model.ChannelMember, channel *model.Channel) (*model.ChannelMember, string)
+	}); ok {
+		returns.A, returns.B = hook.UserWillJoinChannel(args.A, args.B, args.C)
+	} else {
+		return encodableError(fmt.Errorf("Hook UserWillJoinChannel called but not implemented."))
+	}
+	return nil
+}
+
+func init() {
+	hookNameToId["UserWillJoinTeam"] = UserWillJoinTeamID
+}
+
+type Z_UserWillJoinTeamArgs struct {
+	A *Context
+	B *model.TeamMember
+	C *model.Team
+}
+
+type Z_UserWillJoinTeamReturns struct {
+	A *model.TeamMember
+	B string
+}
+
+func (g *hooksRPCClient) UserWillJoinTeam(c *Context, teamMember *model.TeamMember, team *model.Team) (*model.TeamMember, string) {
+	_args := &Z_UserWillJoinTeamArgs{c, teamMember, team}
+	_returns := &Z_UserWillJoinTeamReturns{}
+	if g.implemented[UserWillJoinTeamID] {
+		if err := g.client.Call("Plugin.UserWillJoinTeam", _args, _returns); err != nil {
+			g.log.Error("RPC call UserWillJoinTeam to plugin failed.", mlog.Err(err))
+		}
+	}
+	return _returns.A, _returns.B
+}
+
+func (s *hooksRPCServer) UserWillJoinTeam(args *Z_UserWillJoinTeamArgs, returns *Z_UserWillJoinTeamReturns) error {
+	if hook, ok := s.impl.(interface {
+		UserWillJoinTeam(c *Context, teamMember *model.TeamMember, team *model.Team) (*model.TeamMember, string)
+	}); ok {
+		returns.A, returns.B = hook.UserWillJoinTeam(args.A, args.B, args.C)
+	} else {
+		return encodableError(fmt.Errorf("Hook UserWillJoinTeam called but not implemented."))
+	}
+	return nil
+}
+
+func init() {
+	hookNameToId["UserWillBeAddedToGroupSyncable"] = UserWillBeAddedToGroupSyncableID
+}
+
+type Z_UserWillBeAddedToGroupSyncableArgs struct {
+	A *Context
+	B string
+	C string
+	D model.GroupSyncableType
+}
+
+type Z_UserWillBeAddedToGroupSyncableReturns struct {
+	A string
+}
+
+func (g *hooksRPCClient) UserWillBeAddedToGroupSyncable(c *Context, userID, syncableID string, syncableType model.GroupSyncableType) string {
+	_args := &Z_UserWillBeAddedToGroupSyncableArgs{c, userID, syncableID, syncableType}
+	_returns := &Z_UserWillBeAddedToGroupSyncableReturns{}
+	if g.implemented[UserWillBeAddedToGroupSyncableID] {
+		if err := g.client.Call("Plugin.UserWillBeAddedToGroupSyncable", _args, _returns); err != nil {
+			g.log.Error("RPC call UserWillBeAddedToGroupSyncable to plugin failed.", mlog.Err(err))
+		}
+	}
+	return _returns.A
+}
+
+func (s *hooksRPCServer) UserWillBeAddedToGroupSyncable(args *Z_UserWillBeAddedToGroupSyncableArgs, returns *Z_UserWillBeAddedToGroupSyncableReturns) error {
+	if hook, ok := s.impl.(interface {
+		UserWillBeAddedToGroupSyncable(c *Context, userID, syncableID string, syncableType model.GroupSyncableType) string
+	}); ok {
+		returns.A = hook.UserWillBeAddedToGroupSyncable(args.A, args.B, args.C, args.D)
+	} else {
+		return encodableError(fmt.Errorf("Hook UserWillBeAddedToGroupSyncable called but not implemented."))
+	}
+	return nil
+}
+
 type Z_RegisterCommandArgs struct {
 	A *model.Command
 }
@@ -7910,3 +8198,124 @@ func (s *apiRPCServer) DeletePropertyValuesForField(args *Z_DeletePropertyValues
 	}
 	return nil
 }
+
+type Z_SummarizeThreadArgs struct {
+	A string
+	B string
+	C string
+}
+
+type Z_SummarizeThreadReturns struct {
+	A *model.AISummary
+	B *model.AppError
+}
+
+func (g *apiRPCClient) SummarizeThread(userID, postID, level string) (*model.AISummary, *model.AppError) {
+	_args := &Z_SummarizeThreadArgs{userID, postID, level}
+	_returns := &Z_SummarizeThreadReturns{}
+	if err := g.client.Call("Plugin.SummarizeThread", _args, _returns); err != nil {
+		log.Printf("RPC call to SummarizeThread API failed: %s", err.Error())
+	}
+	return _returns.A, _returns.B
+}
+
+func (s *apiRPCServer) SummarizeThread(args *Z_SummarizeThreadArgs, returns *Z_SummarizeThreadReturns) error {
+	if hook, ok := s.impl.(interface {
+		SummarizeThread(userID, postID, level string) (*model.AISummary, *model.AppError)
+	}); ok {
+		returns.A, returns.B = hook.SummarizeThread(args.A, args.B, args.C)
+	} else {
+		return encodableError(fmt.Errorf("API SummarizeThread called but not implemented."))
+	}
+	return nil
+}
+
+type Z_FormatMessageArgs struct {
+	A string
+	B string
+	C string
+}
+
+type Z_FormatMessageReturns struct {
+	A string
+	B *model.AppError
+}
+
+func (g *apiRPCClient) FormatMessage(userID, message, profile string) (string, *model.AppError) {
+	_args := &Z_FormatMessageArgs{userID, message, profile}
+	_returns := &Z_FormatMessageReturns{}
+	if err := g.client.Call("Plugin.FormatMessage", _args, _returns); err != nil {
+		log.Printf("RPC call to FormatMessage API failed: %s", err.Error())
+	}
+	return _returns.A, _returns.B
+}
+
+func (s *apiRPCServer) FormatMessage(args *Z_FormatMessageArgs, returns *Z_FormatMessageReturns) error {
+	if hook, ok := s.impl.(interface {
+		FormatMessage(userID, message, profile string) (string, *model.AppError)
+	}); ok {
+		returns.A, returns.B = hook.FormatMessage(args.A, args.B, args.C)
+	} else {
+		return encodableError(fmt.Errorf("API FormatMessage called but not implemented."))
+	}
+	return nil
+}
+
+type Z_CreateActionItemArgs struct {
+	A *model.AIActionItem
+}
+
+type Z_CreateActionItemReturns struct {
+	A *model.AIActionItem
+	B *model.AppError
+}
+
+func (g *apiRPCClient) CreateActionItem(item *model.AIActionItem) (*model.AIActionItem, *model.AppError) {
+	_args := &Z_CreateActionItemArgs{item}
+	_returns := &Z_CreateActionItemReturns{}
+	if err := g.client.Call("Plugin.CreateActionItem", _args, _returns); err != nil {
+		log.Printf("RPC call to CreateActionItem API failed: %s", err.Error())
+	}
+	return _returns.A, _returns.B
+}
+
+func (s *apiRPCServer) CreateActionItem(args *Z_CreateActionItemArgs, returns *Z_CreateActionItemReturns) error {
+	if hook, ok := s.impl.(interface {
+		CreateActionItem(item *model.AIActionItem) (*model.AIActionItem, *model.AppError)
+	}); ok {
+		returns.A, returns.B = hook.CreateActionItem(args.A)
+	} else {
+		return encodableError(fmt.Errorf("API CreateActionItem called but not implemented."))
+	}
+	return nil
+}
+
+type Z_CreateAICompletionArgs struct {
+	A string
+	B *model.AICompletionRequest
+}
+
+type Z_CreateAICompletionReturns struct {
+	A *model.AICompletionResponse
+	B *model.AppError
+}
+
+func (g *apiRPCClient) CreateAICompletion(userID string, request *model.AICompletionRequest) (*model.AICompletionResponse, *model.AppError) {
+	_args := &Z_CreateAICompletionArgs{userID, request}
+	_returns := &Z_CreateAICompletionReturns{}
+	if err := g.client.Call("Plugin.CreateAICompletion", _args, _returns); err != nil {
+		log.Printf("RPC call to CreateAICompletion API failed: %s", err.Error())
+	}
+	return _returns.A, _returns.B
+}
+
+func (s *apiRPCServer) CreateAICompletion(args *Z_CreateAICompletionArgs, returns *Z_CreateAICompletionReturns) error {
+	if hook, ok := s.impl.(interface {
+		CreateAICompletion(userID string, request *model.AICompletionRequest) (*model.AICompletionResponse, *model.AppError)
+	}); ok {
+		returns.A, returns.B = hook.CreateAICompletion(args.A, args.B)
+	} else {
+		return encodableError(fmt.Errorf("API CreateAICompletion called but not implemented."))
+	}
+	return nil
+}
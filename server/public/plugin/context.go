@@ -12,4 +12,7 @@ type Context struct {
 	IPAddress      string
 	AcceptLanguage string
 	UserAgent      string
+	// TraceContext carries the W3C trace context (traceparent and tracestate) of the request
+	// or hook event, for plugins to continue its trace.
+	TraceContext map[string]string
 }
@@ -33,7 +33,7 @@ type registeredPlugin struct {
 	State      int
 	Error      string
 
-	supervisor *supervisor
+	supervisor pluginSupervisor
 }
 
 // PrepackagedPlugin is a plugin prepackaged with the server and found on startup.
@@ -60,6 +60,8 @@ type Environment struct {
 	prepackagedPlugins               []*PrepackagedPlugin
 	transitionallyPrepackagedPlugins []*PrepackagedPlugin
 	prepackagedPluginsLock           sync.RWMutex
+	wasmLimits                       WasmLimits
+	wasmLimitsLock                   sync.RWMutex
 }
 
 func NewEnvironment(
@@ -198,7 +200,7 @@ func (env *Environment) setPluginState(id string, state int) {
 }
 
 // setPluginSupervisor records the supervisor for a registered plugin.
-func (env *Environment) setPluginSupervisor(id string, supervisor *supervisor) {
+func (env *Environment) setPluginSupervisor(id string, supervisor pluginSupervisor) {
 	if rp, ok := env.registeredPlugins.Load(id); ok {
 		p := rp.(registeredPlugin)
 		p.supervisor = supervisor
@@ -286,6 +288,19 @@ func (env *Environment) startPluginServer(pluginInfo *model.BundleInfo, opts ...
 		return errors.Wrapf(err, "unable to start plugin: %v", pluginInfo.Manifest.Id)
 	}
 
+	return env.activatePluginServer(pluginInfo, sup)
+}
+
+func (env *Environment) startWasmPluginServer(pluginInfo *model.BundleInfo) error {
+	sup, err := newWasmSupervisor(pluginInfo, env.newAPIImpl(pluginInfo.Manifest), env.logger, env.metrics, env.getWasmLimits())
+	if err != nil {
+		return errors.Wrapf(err, "unable to start plugin: %v", pluginInfo.Manifest.Id)
+	}
+
+	return env.activatePluginServer(pluginInfo, sup)
+}
+
+func (env *Environment) activatePluginServer(pluginInfo *model.BundleInfo, sup pluginSupervisor) error {
 	// We pre-emptively set the state to running to prevent re-entrancy issues.
 	// The plugin's OnActivate hook can in-turn call UpdateConfiguration
 	// which again calls this method. This method is guarded against multiple calls,
@@ -365,7 +380,11 @@ func (env *Environment) Activate(id string) (manifest *model.Manifest, activated
 	}
 
 	if pluginInfo.Manifest.HasServer() {
-		err = env.startPluginServer(pluginInfo, WithExecutableFromManifest(pluginInfo))
+		if pluginInfo.Manifest.IsWasm() {
+			err = env.startWasmPluginServer(pluginInfo)
+		} else {
+			err = env.startPluginServer(pluginInfo, WithExecutableFromManifest(pluginInfo))
+		}
 		if err != nil {
 			return nil, false, err
 		}
@@ -649,6 +668,19 @@ func (env *Environment) SetPrepackagedPlugins(plugins, transitionalPlugins []*Pr
 	env.prepackagedPluginsLock.Unlock()
 }
 
+// SetWasmLimits sets the resource limits applied to WebAssembly plugins activated from now on.
+func (env *Environment) SetWasmLimits(limits WasmLimits) {
+	env.wasmLimitsLock.Lock()
+	env.wasmLimits = limits
+	env.wasmLimitsLock.Unlock()
+}
+
+func (env *Environment) getWasmLimits() WasmLimits {
+	env.wasmLimitsLock.RLock()
+	defer env.wasmLimitsLock.RUnlock()
+	return env.wasmLimits
+}
+
 func newRegisteredPlugin(bundle *model.BundleInfo) registeredPlugin {
 	state := model.PluginStateNotRunning
 	return registeredPlugin{State: state, BundleInfo: bundle}
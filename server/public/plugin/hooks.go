@@ -64,6 +64,14 @@ const (
 	GenerateSupportDataID                     = 45
 	OnSAMLLoginID                             = 46
 	EmailNotificationWillBeSentID             = 47
+	ActionItemHasBeenCreatedID                = 48
+	ActionItemHasBeenUpdatedID                = 49
+	AIPromptWillBeSentID                      = 50
+	ChannelWillBeCreatedID                    = 51
+	ChannelWillBeArchivedID                   = 52
+	UserWillJoinChannelID                     = 53
+	UserWillJoinTeamID                        = 54
+	UserWillBeAddedToGroupSyncableID          = 55
 	TotalHooksID                              = iota
 )
 
@@ -418,4 +426,88 @@ type Hooks interface {
 	//
 	// Minimum server version: 10.7
 	OnSAMLLogin(c *Context, user *model.User, assertion *saml2.AssertionInfo) error
+
+	// ActionItemHasBeenCreated is invoked after an action item has been created, either by a user
+	// or from the action items detected in a post.
+	//
+	// Minimum server version: 11.2
+	ActionItemHasBeenCreated(c *Context, item *model.AIActionItem)
+
+	// ActionItemHasBeenUpdated is invoked after an action item has been updated, including when
+	// it's completed.
+	//
+	// Minimum server version: 11.2
+	ActionItemHasBeenUpdated(c *Context, newItem, oldItem *model.AIActionItem)
+
+	// AIPromptWillBeSent is invoked before a prompt is sent to the LLM configured for the server,
+	// whichever AI feature the prompt is for. This allows plugins to redact the content of the
+	// prompt before it leaves the server.
+	//
+	// To reject the prompt, return an non-empty string describing why the prompt was rejected.
+	// To modify the prompt, return the replacement, non-nil *model.AIPrompt and an empty string.
+	// To allow the prompt without modification, return a nil *model.AIPrompt and an empty string.
+	//
+	// Only the system prompt, the user prompt and the model of the prompt can be modified.
+	//
+	// Note that this method will be called for prompts sent by plugins, including the plugin that
+	// sent the prompt.
+	//
+	// Minimum server version: 11.2
+	AIPromptWillBeSent(c *Context, prompt *model.AIPrompt) (*model.AIPrompt, string)
+
+	// ChannelWillBeCreated is invoked before a public or private channel is created. It isn't
+	// invoked for direct and group messages, nor for the default channels created with a team,
+	// so that a plugin can't leave a new team without them.
+	//
+	// To reject the channel, return an non-empty string describing why the channel was rejected.
+	// To modify the channel, return the replacement, non-nil *model.Channel and an empty string.
+	// To allow the channel without modification, return a nil *model.Channel and an empty string.
+	//
+	// Only the name, display name, purpose and header of the channel can be modified.
+	//
+	// Note that this method will be called for channels created by plugins, including the plugin that
+	// created the channel.
+	//
+	// Minimum server version: 11.2
+	ChannelWillBeCreated(c *Context, channel *model.Channel) (*model.Channel, string)
+
+	// ChannelWillBeArchived is invoked before a channel is archived. actor is the user archiving
+	// the channel, or nil if the channel is archived by the system.
+	//
+	// To reject the archival, return an non-empty string describing why it was rejected.
+	//
+	// Minimum server version: 11.2
+	ChannelWillBeArchived(c *Context, channel *model.Channel, actor *model.User) string
+
+	// UserWillJoinChannel is invoked before a user joins or is added to a channel, whoever added them.
+	//
+	// To keep the user from joining, return an non-empty string describing why they were rejected.
+	// To modify the membership, return the replacement, non-nil *model.ChannelMember and an empty string.
+	// To allow the membership without modification, return a nil *model.ChannelMember and an empty string.
+	//
+	// Only the notification preferences and the admin role of the member can be modified.
+	//
+	// Minimum server version: 11.2
+	UserWillJoinChannel(c *Context, channelMember *model.ChannelMember, channel *model.Channel) (*model.ChannelMember, string)
+
+	// UserWillJoinTeam is invoked before a user joins or is added to a team, whoever added them.
+	//
+	// To keep the user from joining, return an non-empty string describing why they were rejected.
+	// To modify the membership, return the replacement, non-nil *model.TeamMember and an empty string.
+	// To allow the membership without modification, return a nil *model.TeamMember and an empty string.
+	//
+	// Only the admin role of the member can be modified.
+	//
+	// Minimum server version: 11.2
+	UserWillJoinTeam(c *Context, teamMember *model.TeamMember, team *model.Team) (*model.TeamMember, string)
+
+	// UserWillBeAddedToGroupSyncable is invoked before a user is added to a team or channel because
+	// one of their groups is synced with it. syncableID is the id of the team or channel.
+	//
+	// To keep the user from being added, return an non-empty string describing why they were rejected.
+	// The user is then skipped until the next sync. Note that UserWillJoinTeam and UserWillJoinChannel
+	// are invoked as well for the users who aren't rejected.
+	//
+	// Minimum server version: 11.2
+	UserWillBeAddedToGroupSyncable(c *Context, userID, syncableID string, syncableType model.GroupSyncableType) string
 }
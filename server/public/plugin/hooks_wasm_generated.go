@@ -0,0 +1,486 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+// Code generated by "make pluginapi"
+// DO NOT EDIT
+
+package plugin
+
+import (
+	saml2 "github.com/mattermost/gosaml2"
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+func (g *hooksWasmClient) OnDeactivate() error {
+	_args := &Z_OnDeactivateArgs{}
+	_returns := &Z_OnDeactivateReturns{}
+	if g.implemented[OnDeactivateID] {
+		if err := g.call("OnDeactivate", _args, _returns); err != nil {
+			g.log.Error("WASM call OnDeactivate to plugin failed.", mlog.Err(err))
+		}
+	}
+	return _returns.A
+}
+
+func (g *hooksWasmClient) OnConfigurationChange() error {
+	_args := &Z_OnConfigurationChangeArgs{}
+	_returns := &Z_OnConfigurationChangeReturns{}
+	if g.implemented[OnConfigurationChangeID] {
+		if err := g.call("OnConfigurationChange", _args, _returns); err != nil {
+			g.log.Error("WASM call OnConfigurationChange to plugin failed.", mlog.Err(err))
+		}
+	}
+	return _returns.A
+}
+
+func (g *hooksWasmClient) ExecuteCommand(c *Context, args *model.CommandArgs) (*model.CommandResponse, *model.AppError) {
+	_args := &Z_ExecuteCommandArgs{c, args}
+	_returns := &Z_ExecuteCommandReturns{}
+	if g.implemented[ExecuteCommandID] {
+		if err := g.call("ExecuteCommand", _args, _returns); err != nil {
+			g.log.Error("WASM call ExecuteCommand to plugin failed.", mlog.Err(err))
+		}
+	}
+	return _returns.A, _returns.B
+}
+
+func (g *hooksWasmClient) UserHasBeenCreated(c *Context, user *model.User) {
+	_args := &Z_UserHasBeenCreatedArgs{c, user}
+	_returns := &Z_UserHasBeenCreatedReturns{}
+	if g.implemented[UserHasBeenCreatedID] {
+		if err := g.call("UserHasBeenCreated", _args, _returns); err != nil {
+			g.log.Error("WASM call UserHasBeenCreated to plugin failed.", mlog.Err(err))
+		}
+	}
+
+}
+
+func (g *hooksWasmClient) UserWillLogIn(c *Context, user *model.User) string {
+	_args := &Z_UserWillLogInArgs{c, user}
+	_returns := &Z_UserWillLogInReturns{}
+	if g.implemented[UserWillLogInID] {
+		if err := g.call("UserWillLogIn", _args, _returns); err != nil {
+			g.log.Error("WASM call UserWillLogIn to plugin failed.", mlog.Err(err))
+		}
+	}
+	return _returns.A
+}
+
+func (g *hooksWasmClient) UserHasLoggedIn(c *Context, user *model.User) {
+	_args := &Z_UserHasLoggedInArgs{c, user}
+	_returns := &Z_UserHasLoggedInReturns{}
+	if g.implemented[UserHasLoggedInID] {
+		if err := g.call("UserHasLoggedIn", _args, _returns); err != nil {
+			g.log.Error("WASM call UserHasLoggedIn to plugin failed.", mlog.Err(err))
+		}
+	}
+
+}
+
+func (g *hooksWasmClient) MessageHasBeenPosted(c *Context, post *model.Post) {
+	_args := &Z_MessageHasBeenPostedArgs{c, post}
+	_returns := &Z_MessageHasBeenPostedReturns{}
+	if g.implemented[MessageHasBeenPostedID] {
+		if err := g.call("MessageHasBeenPosted", _args, _returns); err != nil {
+			g.log.Error("WASM call MessageHasBeenPosted to plugin failed.", mlog.Err(err))
+		}
+	}
+
+}
+
+func (g *hooksWasmClient) MessageHasBeenUpdated(c *Context, newPost, oldPost *model.Post) {
+	_args := &Z_MessageHasBeenUpdatedArgs{c, newPost, oldPost}
+	_returns := &Z_MessageHasBeenUpdatedReturns{}
+	if g.implemented[MessageHasBeenUpdatedID] {
+		if err := g.call("MessageHasBeenUpdated", _args, _returns); err != nil {
+			g.log.Error("WASM call MessageHasBeenUpdated to plugin failed.", mlog.Err(err))
+		}
+	}
+
+}
+
+func (g *hooksWasmClient) MessageHasBeenDeleted(c *Context, post *model.Post) {
+	_args := &Z_MessageHasBeenDeletedArgs{c, post}
+	_returns := &Z_MessageHasBeenDeletedReturns{}
+	if g.implemented[MessageHasBeenDeletedID] {
+		if err := g.call("MessageHasBeenDeleted", _args, _returns); err != nil {
+			g.log.Error("WASM call MessageHasBeenDeleted to plugin failed.", mlog.Err(err))
+		}
+	}
+
+}
+
+func (g *hooksWasmClient) ChannelHasBeenCreated(c *Context, channel *model.Channel) {
+	_args := &Z_ChannelHasBeenCreatedArgs{c, channel}
+	_returns := &Z_ChannelHasBeenCreatedReturns{}
+	if g.implemented[ChannelHasBeenCreatedID] {
+		if err := g.call("ChannelHasBeenCreated", _args, _returns); err != nil {
+			g.log.Error("WASM call ChannelHasBeenCreated to plugin failed.", mlog.Err(err))
+		}
+	}
+
+}
+
+func (g *hooksWasmClient) UserHasJoinedChannel(c *Context, channelMember *model.ChannelMember, actor *model.User) {
+	_args := &Z_UserHasJoinedChannelArgs{c, channelMember, actor}
+	_returns := &Z_UserHasJoinedChannelReturns{}
+	if g.implemented[UserHasJoinedChannelID] {
+		if err := g.call("UserHasJoinedChannel", _args, _returns); err != nil {
+			g.log.Error("WASM call UserHasJoinedChannel to plugin failed.", mlog.Err(err))
+		}
+	}
+
+}
+
+func (g *hooksWasmClient) UserHasLeftChannel(c *Context, channelMember *model.ChannelMember, actor *model.User) {
+	_args := &Z_UserHasLeftChannelArgs{c, channelMember, actor}
+	_returns := &Z_UserHasLeftChannelReturns{}
+	if g.implemented[UserHasLeftChannelID] {
+		if err := g.call("UserHasLeftChannel", _args, _returns); err != nil {
+			g.log.Error("WASM call UserHasLeftChannel to plugin failed.", mlog.Err(err))
+		}
+	}
+
+}
+
+func (g *hooksWasmClient) UserHasJoinedTeam(c *Context, teamMember *model.TeamMember, actor *model.User) {
+	_args := &Z_UserHasJoinedTeamArgs{c, teamMember, actor}
+	_returns := &Z_UserHasJoinedTeamReturns{}
+	if g.implemented[UserHasJoinedTeamID] {
+		if err := g.call("UserHasJoinedTeam", _args, _returns); err != nil {
+			g.log.Error("WASM call UserHasJoinedTeam to plugin failed.", mlog.Err(err))
+		}
+	}
+
+}
+
+func (g *hooksWasmClient) UserHasLeftTeam(c *Context, teamMember *model.TeamMember, actor *model.User) {
+	_args := &Z_UserHasLeftTeamArgs{c, teamMember, actor}
+	_returns := &Z_UserHasLeftTeamReturns{}
+	if g.implemented[UserHasLeftTeamID] {
+		if err := g.call("UserHasLeftTeam", _args, _returns); err != nil {
+			g.log.Error("WASM call UserHasLeftTeam to plugin failed.", mlog.Err(err))
+		}
+	}
+
+}
+
+func (g *hooksWasmClient) ReactionHasBeenAdded(c *Context, reaction *model.Reaction) {
+	_args := &Z_ReactionHasBeenAddedArgs{c, reaction}
+	_returns := &Z_ReactionHasBeenAddedReturns{}
+	if g.implemented[ReactionHasBeenAddedID] {
+		if err := g.call("ReactionHasBeenAdded", _args, _returns); err != nil {
+			g.log.Error("WASM call ReactionHasBeenAdded to plugin failed.", mlog.Err(err))
+		}
+	}
+
+}
+
+func (g *hooksWasmClient) ReactionHasBeenRemoved(c *Context, reaction *model.Reaction) {
+	_args := &Z_ReactionHasBeenRemovedArgs{c, reaction}
+	_returns := &Z_ReactionHasBeenRemovedReturns{}
+	if g.implemented[ReactionHasBeenRemovedID] {
+		if err := g.call("ReactionHasBeenRemoved", _args, _returns); err != nil {
+			g.log.Error("WASM call ReactionHasBeenRemoved to plugin failed.", mlog.Err(err))
+		}
+	}
+
+}
+
+func (g *hooksWasmClient) OnPluginClusterEvent(c *Context, ev model.PluginClusterEvent) {
+	_args := &Z_OnPluginClusterEventArgs{c, ev}
+	_returns := &Z_OnPluginClusterEventReturns{}
+	if g.implemented[OnPluginClusterEventID] {
+		if err := g.call("OnPluginClusterEvent", _args, _returns); err != nil {
+			g.log.Error("WASM call OnPluginClusterEvent to plugin failed.", mlog.Err(err))
+		}
+	}
+
+}
+
+func (g *hooksWasmClient) OnWebSocketConnect(webConnID, userID string) {
+	_args := &Z_OnWebSocketConnectArgs{webConnID, userID}
+	_returns := &Z_OnWebSocketConnectReturns{}
+	if g.implemented[OnWebSocketConnectID] {
+		if err := g.call("OnWebSocketConnect", _args, _returns); err != nil {
+			g.log.Error("WASM call OnWebSocketConnect to plugin failed.", mlog.Err(err))
+		}
+	}
+
+}
+
+func (g *hooksWasmClient) OnWebSocketDisconnect(webConnID, userID string) {
+	_args := &Z_OnWebSocketDisconnectArgs{webConnID, userID}
+	_returns := &Z_OnWebSocketDisconnectReturns{}
+	if g.implemented[OnWebSocketDisconnectID] {
+		if err := g.call("OnWebSocketDisconnect", _args, _returns); err != nil {
+			g.log.Error("WASM call OnWebSocketDisconnect to plugin failed.", mlog.Err(err))
+		}
+	}
+
+}
+
+func (g *hooksWasmClient) WebSocketMessageHasBeenPosted(webConnID, userID string, req *model.WebSocketRequest) {
+	_args := &Z_WebSocketMessageHasBeenPostedArgs{webConnID, userID, req}
+	_returns := &Z_WebSocketMessageHasBeenPostedReturns{}
+	if g.implemented[WebSocketMessageHasBeenPostedID] {
+		if err := g.call("WebSocketMessageHasBeenPosted", _args, _returns); err != nil {
+			g.log.Error("WASM call WebSocketMessageHasBeenPosted to plugin failed.", mlog.Err(err))
+		}
+	}
+
+}
+
+func (g *hooksWasmClient) RunDataRetention(nowTime, batchSize int64) (int64, error) {
+	_args := &Z_RunDataRetentionArgs{nowTime, batchSize}
+	_returns := &Z_RunDataRetentionReturns{}
+	if g.implemented[RunDataRetentionID] {
+		if err := g.call("RunDataRetention", _args, _returns); err != nil {
+			g.log.Error("WASM call RunDataRetention to plugin failed.", mlog.Err(err))
+		}
+	}
+	return _returns.A, _returns.B
+}
+
+func (g *hooksWasmClient) OnInstall(c *Context, event model.OnInstallEvent) error {
+	_args := &Z_OnInstallArgs{c, event}
+	_returns := &Z_OnInstallReturns{}
+	if g.implemented[OnInstallID] {
+		if err := g.call("OnInstall", _args, _returns); err != nil {
+			g.log.Error("WASM call OnInstall to plugin failed.", mlog.Err(err))
+		}
+	}
+	return _returns.A
+}
+
+func (g *hooksWasmClient) OnSendDailyTelemetry() {
+	_args := &Z_OnSendDailyTelemetryArgs{}
+	_returns := &Z_OnSendDailyTelemetryReturns{}
+	if g.implemented[OnSendDailyTelemetryID] {
+		if err := g.call("OnSendDailyTelemetry", _args, _returns); err != nil {
+			g.log.Error("WASM call OnSendDailyTelemetry to plugin failed.", mlog.Err(err))
+		}
+	}
+
+}
+
+func (g *hooksWasmClient) OnCloudLimitsUpdated(limits *model.ProductLimits) {
+	_args := &Z_OnCloudLimitsUpdatedArgs{limits}
+	_returns := &Z_OnCloudLimitsUpdatedReturns{}
+	if g.implemented[OnCloudLimitsUpdatedID] {
+		if err := g.call("OnCloudLimitsUpdated", _args, _returns); err != nil {
+			g.log.Error("WASM call OnCloudLimitsUpdated to plugin failed.", mlog.Err(err))
+		}
+	}
+
+}
+
+func (g *hooksWasmClient) ConfigurationWillBeSaved(newCfg *model.Config) (*model.Config, error) {
+	_args := &Z_ConfigurationWillBeSavedArgs{newCfg}
+	_returns := &Z_ConfigurationWillBeSavedReturns{}
+	if g.implemented[ConfigurationWillBeSavedID] {
+		if err := g.call("ConfigurationWillBeSaved", _args, _returns); err != nil {
+			g.log.Error("WASM call ConfigurationWillBeSaved to plugin failed.", mlog.Err(err))
+		}
+	}
+	return _returns.A, _returns.B
+}
+
+func (g *hooksWasmClient) EmailNotificationWillBeSent(emailNotification *model.EmailNotification) (*model.EmailNotificationContent, string) {
+	_args := &Z_EmailNotificationWillBeSentArgs{emailNotification}
+	_returns := &Z_EmailNotificationWillBeSentReturns{}
+	if g.implemented[EmailNotificationWillBeSentID] {
+		if err := g.call("EmailNotificationWillBeSent", _args, _returns); err != nil {
+			g.log.Error("WASM call EmailNotificationWillBeSent to plugin failed.", mlog.Err(err))
+		}
+	}
+	return _returns.A, _returns.B
+}
+
+func (g *hooksWasmClient) NotificationWillBePushed(pushNotification *model.PushNotification, userID string) (*model.PushNotification, string) {
+	_args := &Z_NotificationWillBePushedArgs{pushNotification, userID}
+	_returns := &Z_NotificationWillBePushedReturns{}
+	if g.implemented[NotificationWillBePushedID] {
+		if err := g.call("NotificationWillBePushed", _args, _returns); err != nil {
+			g.log.Error("WASM call NotificationWillBePushed to plugin failed.", mlog.Err(err))
+		}
+	}
+	return _returns.A, _returns.B
+}
+
+func (g *hooksWasmClient) UserHasBeenDeactivated(c *Context, user *model.User) {
+	_args := &Z_UserHasBeenDeactivatedArgs{c, user}
+	_returns := &Z_UserHasBeenDeactivatedReturns{}
+	if g.implemented[UserHasBeenDeactivatedID] {
+		if err := g.call("UserHasBeenDeactivated", _args, _returns); err != nil {
+			g.log.Error("WASM call UserHasBeenDeactivated to plugin failed.", mlog.Err(err))
+		}
+	}
+
+}
+
+func (g *hooksWasmClient) OnSharedChannelsSyncMsg(msg *model.SyncMsg, rc *model.RemoteCluster) (model.SyncResponse, error) {
+	_args := &Z_OnSharedChannelsSyncMsgArgs{msg, rc}
+	_returns := &Z_OnSharedChannelsSyncMsgReturns{}
+	if g.implemented[OnSharedChannelsSyncMsgID] {
+		if err := g.call("OnSharedChannelsSyncMsg", _args, _returns); err != nil {
+			g.log.Error("WASM call OnSharedChannelsSyncMsg to plugin failed.", mlog.Err(err))
+		}
+	}
+	return _returns.A, _returns.B
+}
+
+func (g *hooksWasmClient) OnSharedChannelsPing(rc *model.RemoteCluster) bool {
+	_args := &Z_OnSharedChannelsPingArgs{rc}
+	_returns := &Z_OnSharedChannelsPingReturns{}
+	if g.implemented[OnSharedChannelsPingID] {
+		if err := g.call("OnSharedChannelsPing", _args, _returns); err != nil {
+			g.log.Error("WASM call OnSharedChannelsPing to plugin failed.", mlog.Err(err))
+		}
+	}
+	return _returns.A
+}
+
+func (g *hooksWasmClient) PreferencesHaveChanged(c *Context, preferences []model.Preference) {
+	_args := &Z_PreferencesHaveChangedArgs{c, preferences}
+	_returns := &Z_PreferencesHaveChangedReturns{}
+	if g.implemented[PreferencesHaveChangedID] {
+		if err := g.call("PreferencesHaveChanged", _args, _returns); err != nil {
+			g.log.Error("WASM call PreferencesHaveChanged to plugin failed.", mlog.Err(err))
+		}
+	}
+
+}
+
+func (g *hooksWasmClient) OnSharedChannelsAttachmentSyncMsg(fi *model.FileInfo, post *model.Post, rc *model.RemoteCluster) error {
+	_args := &Z_OnSharedChannelsAttachmentSyncMsgArgs{fi, post, rc}
+	_returns := &Z_OnSharedChannelsAttachmentSyncMsgReturns{}
+	if g.implemented[OnSharedChannelsAttachmentSyncMsgID] {
+		if err := g.call("OnSharedChannelsAttachmentSyncMsg", _args, _returns); err != nil {
+			g.log.Error("WASM call OnSharedChannelsAttachmentSyncMsg to plugin failed.", mlog.Err(err))
+		}
+	}
+	return _returns.A
+}
+
+func (g *hooksWasmClient) OnSharedChannelsProfileImageSyncMsg(user *model.User, rc *model.RemoteCluster) error {
+	_args := &Z_OnSharedChannelsProfileImageSyncMsgArgs{user, rc}
+	_returns := &Z_OnSharedChannelsProfileImageSyncMsgReturns{}
+	if g.implemented[OnSharedChannelsProfileImageSyncMsgID] {
+		if err := g.call("OnSharedChannelsProfileImageSyncMsg", _args, _returns); err != nil {
+			g.log.Error("WASM call OnSharedChannelsProfileImageSyncMsg to plugin failed.", mlog.Err(err))
+		}
+	}
+	return _returns.A
+}
+
+func (g *hooksWasmClient) GenerateSupportData(c *Context) ([]*model.FileData, error) {
+	_args := &Z_GenerateSupportDataArgs{c}
+	_returns := &Z_GenerateSupportDataReturns{}
+	if g.implemented[GenerateSupportDataID] {
+		if err := g.call("GenerateSupportData", _args, _returns); err != nil {
+			g.log.Error("WASM call GenerateSupportData to plugin failed.", mlog.Err(err))
+		}
+	}
+	return _returns.A, _returns.B
+}
+
+func (g *hooksWasmClient) OnSAMLLogin(c *Context, user *model.User, assertion *saml2.AssertionInfo) error {
+	_args := &Z_OnSAMLLoginArgs{c, user, assertion}
+	_returns := &Z_OnSAMLLoginReturns{}
+	if g.implemented[OnSAMLLoginID] {
+		if err := g.call("OnSAMLLogin", _args, _returns); err != nil {
+			g.log.Error("WASM call OnSAMLLogin to plugin failed.", mlog.Err(err))
+		}
+	}
+	return _returns.A
+}
+
+func (g *hooksWasmClient) ActionItemHasBeenCreated(c *Context, item *model.AIActionItem) {
+	_args := &Z_ActionItemHasBeenCreatedArgs{c, item}
+	_returns := &Z_ActionItemHasBeenCreatedReturns{}
+	if g.implemented[ActionItemHasBeenCreatedID] {
+		if err := g.call("ActionItemHasBeenCreated", _args, _returns); err != nil {
+			g.log.Error("WASM call ActionItemHasBeenCreated to plugin failed.", mlog.Err(err))
+		}
+	}
+
+}
+
+func (g *hooksWasmClient) ActionItemHasBeenUpdated(c *Context, newItem, oldItem *model.AIActionItem) {
+	_args := &Z_ActionItemHasBeenUpdatedArgs{c, newItem, oldItem}
+	_returns := &Z_ActionItemHasBeenUpdatedReturns{}
+	if g.implemented[ActionItemHasBeenUpdatedID] {
+		if err := g.call("ActionItemHasBeenUpdated", _args, _returns); err != nil {
+			g.log.Error("WASM call ActionItemHasBeenUpdated to plugin failed.", mlog.Err(err))
+		}
+	}
+
+}
+
+func (g *hooksWasmClient) AIPromptWillBeSent(c *Context, prompt *model.AIPrompt) (*model.AIPrompt, string) {
+	_args := &Z_AIPromptWillBeSentArgs{c, prompt}
+	_returns := &Z_AIPromptWillBeSentReturns{}
+	if g.implemented[AIPromptWillBeSentID] {
+		if err := g.call("AIPromptWillBeSent", _args, _returns); err != nil {
+			g.log.Error("WASM call AIPromptWillBeSent to plugin failed.", mlog.Err(err))
+		}
+	}
+	return _returns.A, _returns.B
+}
+
+func (g *hooksWasmClient) ChannelWillBeCreated(c *Context, channel *model.Channel) (*model.Channel, string) {
+	_args := &Z_ChannelWillBeCreatedArgs{c, channel}
+	_returns := &Z_ChannelWillBeCreatedReturns{}
+	if g.implemented[ChannelWillBeCreatedID] {
+		if err := g.call("ChannelWillBeCreated", _args, _returns); err != nil {
+			g.log.Error("WASM call ChannelWillBeCreated to plugin failed.", mlog.Err(err))
+		}
+	}
+	return _returns.A, _returns.B
+}
+
+func (g *hooksWasmClient) ChannelWillBeArchived(c *Context, channel *model.Channel, actor *model.User) string {
+	_args := &Z_ChannelWillBeArchivedArgs{c, channel, actor}
+	_returns := &Z_ChannelWillBeArchivedReturns{}
+	if g.implemented[ChannelWillBeArchivedID] {
+		if err := g.call("ChannelWillBeArchived", _args, _returns); err != nil {
+			g.log.Error("WASM call ChannelWillBeArchived to plugin failed.", mlog.Err(err))
+		}
+	}
+	return _returns.A
+}
+
+func (g *hooksWasmClient) UserWillJoinChannel(c *Context, channelMember *model.ChannelMember, channel *model.Channel) (*model.ChannelMember, string) {
+	_args := &Z_UserWillJoinChannelArgs{c, channelMember, channel}
+	_returns := &Z_UserWillJoinChannelReturns{}
+	if g.implemented[UserWillJoinChannelID] {
+		if err := g.call("UserWillJoinChannel", _args, _returns); err != nil {
+			g.log.Error("WASM call UserWillJoinChannel to plugin failed.", mlog.Err(err))
+		}
+	}
+	return _returns.A, _returns.B
+}
+
+func (g *hooksWasmClient) UserWillJoinTeam(c *Context, teamMember *model.TeamMember, team *model.Team) (*model.TeamMember, string) {
+	_args := &Z_UserWillJoinTeamArgs{c, teamMember, team}
+	_returns := &Z_UserWillJoinTeamReturns{}
+	if g.implemented[UserWillJoinTeamID] {
+		if err := g.call("UserWillJoinTeam", _args, _returns); err != nil {
+			g.log.Error("WASM call UserWillJoinTeam to plugin failed.", mlog.Err(err))
+		}
+	}
+	return _returns.A, _returns.B
+}
+
+func (g *hooksWasmClient) UserWillBeAddedToGroupSyncable(c *Context, userID, syncableID string, syncableType model.GroupSyncableType) string {
+	_args := &Z_UserWillBeAddedToGroupSyncableArgs{c, userID, syncableID, syncableType}
+	_returns := &Z_UserWillBeAddedToGroupSyncableReturns{}
+	if g.implemented[UserWillBeAddedToGroupSyncableID] {
+		if err := g.call("UserWillBeAddedToGroupSyncable", _args, _returns); err != nil {
+			g.log.Error("WASM call UserWillBeAddedToGroupSyncable to plugin failed.", mlog.Err(err))
+		}
+	}
+	return _returns.A
+}
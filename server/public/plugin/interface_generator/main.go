@@ -184,6 +184,25 @@ func FieldListToRecordSuccess(structPrefix string, fieldList *ast.FieldList) str
 	return fmt.Sprintf("%s == nil", result)
 }
 
+// FieldListToContextParam returns the name of the *Context parameter, or nil if there's none.
+func FieldListToContextParam(fieldList *ast.FieldList, fileset *token.FileSet) string {
+	if fieldList == nil {
+		return "nil"
+	}
+	for _, field := range fieldList.List {
+		typeNameBuffer := &bytes.Buffer{}
+		err := printer.Fprint(typeNameBuffer, fileset, field.Type)
+		if err != nil {
+			panic(err)
+		}
+		if typeNameBuffer.String() == "*Context" && len(field.Names) > 0 {
+			return field.Names[0].Name
+		}
+	}
+
+	return "nil"
+}
+
 func FieldListToStructList(fieldList *ast.FieldList, fileset *token.FileSet) string {
 	result := []string{}
 	if fieldList == nil || len(fieldList.List) == 0 {
@@ -454,10 +473,13 @@ type hooksTimerLayer struct {
 	metrics   metricsInterface
 }
 
-func (hooks *hooksTimerLayer) recordTime(startTime timePkg.Time, name string, success bool) {
+func (hooks *hooksTimerLayer) recordTime(c *Context, startTime timePkg.Time, name string, success bool) {
 	if hooks.metrics != nil {
 		elapsedTime := float64(timePkg.Since(startTime)) / float64(timePkg.Second)
 		hooks.metrics.ObservePluginHookDuration(hooks.pluginID, name, success, elapsedTime)
+		if tracer, ok := hooks.metrics.(hooksTracer); ok {
+			tracer.TracePluginHook(c, hooks.pluginID, name, success, startTime)
+		}
 	}
 }
 
@@ -466,13 +488,42 @@ func (hooks *hooksTimerLayer) recordTime(startTime timePkg.Time, name string, su
 func (hooks *hooksTimerLayer) {{.Name}}{{funcStyle .Params}} {{funcStyle .Return}} {
 	startTime := timePkg.Now()
 	{{ if .Return }} {{destruct "_returns" .Return}} := {{ end }} hooks.hooksImpl.{{.Name}}({{valuesOnly .Params}})
-	hooks.recordTime(startTime, "{{.Name}}", {{ shouldRecordSuccess "_returns" .Return }})
+	hooks.recordTime({{contextParam .Params}}, startTime, "{{.Name}}", {{ shouldRecordSuccess "_returns" .Return }})
 	{{ if .Return }} return {{destruct "_returns" .Return}} {{end -}}
 }
 
 {{end}}
 `
 
+var hooksWasmTemplate = `// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+// Code generated by "make pluginapi"
+// DO NOT EDIT
+
+package plugin
+
+import (
+	saml2 "github.com/mattermost/gosaml2"
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+{{range .HooksMethods}}
+
+func (g *hooksWasmClient) {{.Name}}{{funcStyle .Params}} {{funcStyle .Return}} {
+	_args := &{{.Name | obscure}}Args{ {{valuesOnly .Params}} }
+	_returns := &{{.Name | obscure}}Returns{}
+	if g.implemented[{{.Name}}ID] {
+		if err := g.call("{{.Name}}", _args, _returns); err != nil {
+			g.log.Error("WASM call {{.Name}} to plugin failed.", mlog.Err(err))
+		}
+	}
+	{{ if .Return }} return {{destruct "_returns." .Return}} {{ end }}
+}
+{{end}}
+`
+
 type MethodParams struct {
 	Name   string
 	Params *ast.FieldList
@@ -537,6 +588,26 @@ func generateHooksGlue(info *PluginInterfaceInfo) {
 	if err := os.WriteFile(filepath.Join(getPluginPackageDir(), "client_rpc_generated.go"), formatted, 0664); err != nil {
 		panic(err)
 	}
+
+	wasmTemplate, err := template.New("hooks").Funcs(templateFunctions).Parse(hooksWasmTemplate)
+	if err != nil {
+		panic(err)
+	}
+
+	templateResult = &bytes.Buffer{}
+	err = wasmTemplate.Execute(templateResult, &templateParams)
+	if err != nil {
+		panic(err)
+	}
+
+	formatted, err = imports.Process("", templateResult.Bytes(), nil)
+	if err != nil {
+		panic(err)
+	}
+
+	if err := os.WriteFile(filepath.Join(getPluginPackageDir(), "hooks_wasm_generated.go"), formatted, 0664); err != nil {
+		panic(err)
+	}
 }
 
 func generatePluginTimerLayer(info *PluginInterfaceInfo) {
@@ -550,6 +621,7 @@ func generatePluginTimerLayer(info *PluginInterfaceInfo) {
 		"shouldRecordSuccess": func(structPrefix string, fields *ast.FieldList) string {
 			return FieldListToRecordSuccess(structPrefix, fields)
 		},
+		"contextParam": func(fields *ast.FieldList) string { return FieldListToContextParam(fields, info.FileSet) },
 	}
 
 	// Prepare template params
@@ -3,9 +3,18 @@
 
 package plugin
 
+import "time"
+
 type metricsInterface interface {
 	ObservePluginHookDuration(pluginID, hookName string, success bool, elapsed float64)
 	ObservePluginMultiHookIterationDuration(pluginID string, elapsed float64)
 	ObservePluginMultiHookDuration(elapsed float64)
 	ObservePluginAPIDuration(pluginID, apiName string, success bool, elapsed float64)
 }
+
+// hooksTracer is optionally implemented by the metrics given to the environment, to trace
+// the hooks as part of the requests that triggered them. c is nil for the hooks that don't
+// take a Context.
+type hooksTracer interface {
+	TracePluginHook(c *Context, pluginID, hookName string, success bool, startTime time.Time)
+}
@@ -204,6 +204,70 @@ func (_m *API) CountPropertyFieldsForTarget(groupID string, targetType string, t
 	return r0, r1
 }
 
+// CreateAICompletion provides a mock function with given fields: userID, request
+func (_m *API) CreateAICompletion(userID string, request *model.AICompletionRequest) (*model.AICompletionResponse, *model.AppError) {
+	ret := _m.Called(userID, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateAICompletion")
+	}
+
+	var r0 *model.AICompletionResponse
+	var r1 *model.AppError
+	if rf, ok := ret.Get(0).(func(string, *model.AICompletionRequest) (*model.AICompletionResponse, *model.AppError)); ok {
+		return rf(userID, request)
+	}
+	if rf, ok := ret.Get(0).(func(string, *model.AICompletionRequest) *model.AICompletionResponse); ok {
+		r0 = rf(userID, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.AICompletionResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, *model.AICompletionRequest) *model.AppError); ok {
+		r1 = rf(userID, request)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.AppError)
+		}
+	}
+
+	return r0, r1
+}
+
+// CreateActionItem provides a mock function with given fields: item
+func (_m *API) CreateActionItem(item *model.AIActionItem) (*model.AIActionItem, *model.AppError) {
+	ret := _m.Called(item)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateActionItem")
+	}
+
+	var r0 *model.AIActionItem
+	var r1 *model.AppError
+	if rf, ok := ret.Get(0).(func(*model.AIActionItem) (*model.AIActionItem, *model.AppError)); ok {
+		return rf(item)
+	}
+	if rf, ok := ret.Get(0).(func(*model.AIActionItem) *model.AIActionItem); ok {
+		r0 = rf(item)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.AIActionItem)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*model.AIActionItem) *model.AppError); ok {
+		r1 = rf(item)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.AppError)
+		}
+	}
+
+	return r0, r1
+}
+
 // CreateBot provides a mock function with given fields: bot
 func (_m *API) CreateBot(bot *model.Bot) (*model.Bot, *model.AppError) {
 	ret := _m.Called(bot)
@@ -1249,6 +1313,36 @@ func (_m *API) ExtendSessionExpiry(sessionID string, newExpiry int64) *model.App
 	return r0
 }
 
+// FormatMessage provides a mock function with given fields: userID, message, profile
+func (_m *API) FormatMessage(userID string, message string, profile string) (string, *model.AppError) {
+	ret := _m.Called(userID, message, profile)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FormatMessage")
+	}
+
+	var r0 string
+	var r1 *model.AppError
+	if rf, ok := ret.Get(0).(func(string, string, string) (string, *model.AppError)); ok {
+		return rf(userID, message, profile)
+	}
+	if rf, ok := ret.Get(0).(func(string, string, string) string); ok {
+		r0 = rf(userID, message, profile)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string, string) *model.AppError); ok {
+		r1 = rf(userID, message, profile)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.AppError)
+		}
+	}
+
+	return r0, r1
+}
+
 // GetBot provides a mock function with given fields: botUserId, includeDeleted
 func (_m *API) GetBot(botUserId string, includeDeleted bool) (*model.Bot, *model.AppError) {
 	ret := _m.Called(botUserId, includeDeleted)
@@ -5325,6 +5419,38 @@ func (_m *API) ShareChannel(sc *model.SharedChannel) (*model.SharedChannel, erro
 	return r0, r1
 }
 
+// SummarizeThread provides a mock function with given fields: userID, postID, level
+func (_m *API) SummarizeThread(userID string, postID string, level string) (*model.AISummary, *model.AppError) {
+	ret := _m.Called(userID, postID, level)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SummarizeThread")
+	}
+
+	var r0 *model.AISummary
+	var r1 *model.AppError
+	if rf, ok := ret.Get(0).(func(string, string, string) (*model.AISummary, *model.AppError)); ok {
+		return rf(userID, postID, level)
+	}
+	if rf, ok := ret.Get(0).(func(string, string, string) *model.AISummary); ok {
+		r0 = rf(userID, postID, level)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.AISummary)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string, string) *model.AppError); ok {
+		r1 = rf(userID, postID, level)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.AppError)
+		}
+	}
+
+	return r0, r1
+}
+
 // SyncSharedChannel provides a mock function with given fields: channelID
 func (_m *API) SyncSharedChannel(channelID string) error {
 	ret := _m.Called(channelID)
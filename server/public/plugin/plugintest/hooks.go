@@ -22,11 +22,99 @@ type Hooks struct {
 	mock.Mock
 }
 
+// AIPromptWillBeSent provides a mock function with given fields: c, prompt
+func (_m *Hooks) AIPromptWillBeSent(c *plugin.Context, prompt *model.AIPrompt) (*model.AIPrompt, string) {
+	ret := _m.Called(c, prompt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AIPromptWillBeSent")
+	}
+
+	var r0 *model.AIPrompt
+	var r1 string
+	if rf, ok := ret.Get(0).(func(*plugin.Context, *model.AIPrompt) (*model.AIPrompt, string)); ok {
+		return rf(c, prompt)
+	}
+	if rf, ok := ret.Get(0).(func(*plugin.Context, *model.AIPrompt) *model.AIPrompt); ok {
+		r0 = rf(c, prompt)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.AIPrompt)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*plugin.Context, *model.AIPrompt) string); ok {
+		r1 = rf(c, prompt)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	return r0, r1
+}
+
+// ActionItemHasBeenCreated provides a mock function with given fields: c, item
+func (_m *Hooks) ActionItemHasBeenCreated(c *plugin.Context, item *model.AIActionItem) {
+	_m.Called(c, item)
+}
+
+// ActionItemHasBeenUpdated provides a mock function with given fields: c, newItem, oldItem
+func (_m *Hooks) ActionItemHasBeenUpdated(c *plugin.Context, newItem *model.AIActionItem, oldItem *model.AIActionItem) {
+	_m.Called(c, newItem, oldItem)
+}
+
 // ChannelHasBeenCreated provides a mock function with given fields: c, channel
 func (_m *Hooks) ChannelHasBeenCreated(c *plugin.Context, channel *model.Channel) {
 	_m.Called(c, channel)
 }
 
+// ChannelWillBeArchived provides a mock function with given fields: c, channel, actor
+func (_m *Hooks) ChannelWillBeArchived(c *plugin.Context, channel *model.Channel, actor *model.User) string {
+	ret := _m.Called(c, channel, actor)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ChannelWillBeArchived")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(*plugin.Context, *model.Channel, *model.User) string); ok {
+		r0 = rf(c, channel, actor)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// ChannelWillBeCreated provides a mock function with given fields: c, channel
+func (_m *Hooks) ChannelWillBeCreated(c *plugin.Context, channel *model.Channel) (*model.Channel, string) {
+	ret := _m.Called(c, channel)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ChannelWillBeCreated")
+	}
+
+	var r0 *model.Channel
+	var r1 string
+	if rf, ok := ret.Get(0).(func(*plugin.Context, *model.Channel) (*model.Channel, string)); ok {
+		return rf(c, channel)
+	}
+	if rf, ok := ret.Get(0).(func(*plugin.Context, *model.Channel) *model.Channel); ok {
+		r0 = rf(c, channel)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Channel)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*plugin.Context, *model.Channel) string); ok {
+		r1 = rf(c, channel)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	return r0, r1
+}
+
 // ConfigurationWillBeSaved provides a mock function with given fields: newCfg
 func (_m *Hooks) ConfigurationWillBeSaved(newCfg *model.Config) (*model.Config, error) {
 	ret := _m.Called(newCfg)
@@ -619,6 +707,84 @@ func (_m *Hooks) UserHasLoggedIn(c *plugin.Context, user *model.User) {
 	_m.Called(c, user)
 }
 
+// UserWillBeAddedToGroupSyncable provides a mock function with given fields: c, userID, syncableID, syncableType
+func (_m *Hooks) UserWillBeAddedToGroupSyncable(c *plugin.Context, userID string, syncableID string, syncableType model.GroupSyncableType) string {
+	ret := _m.Called(c, userID, syncableID, syncableType)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UserWillBeAddedToGroupSyncable")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(*plugin.Context, string, string, model.GroupSyncableType) string); ok {
+		r0 = rf(c, userID, syncableID, syncableType)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// UserWillJoinChannel provides a mock function with given fields: c, channelMember, channel
+func (_m *Hooks) UserWillJoinChannel(c *plugin.Context, channelMember *model.ChannelMember, channel *model.Channel) (*model.ChannelMember, string) {
+	ret := _m.Called(c, channelMember, channel)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UserWillJoinChannel")
+	}
+
+	var r0 *model.ChannelMember
+	var r1 string
+	if rf, ok := ret.Get(0).(func(*plugin.Context, *model.ChannelMember, *model.Channel) (*model.ChannelMember, string)); ok {
+		return rf(c, channelMember, channel)
+	}
+	if rf, ok := ret.Get(0).(func(*plugin.Context, *model.ChannelMember, *model.Channel) *model.ChannelMember); ok {
+		r0 = rf(c, channelMember, channel)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.ChannelMember)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*plugin.Context, *model.ChannelMember, *model.Channel) string); ok {
+		r1 = rf(c, channelMember, channel)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	return r0, r1
+}
+
+// UserWillJoinTeam provides a mock function with given fields: c, teamMember, team
+func (_m *Hooks) UserWillJoinTeam(c *plugin.Context, teamMember *model.TeamMember, team *model.Team) (*model.TeamMember, string) {
+	ret := _m.Called(c, teamMember, team)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UserWillJoinTeam")
+	}
+
+	var r0 *model.TeamMember
+	var r1 string
+	if rf, ok := ret.Get(0).(func(*plugin.Context, *model.TeamMember, *model.Team) (*model.TeamMember, string)); ok {
+		return rf(c, teamMember, team)
+	}
+	if rf, ok := ret.Get(0).(func(*plugin.Context, *model.TeamMember, *model.Team) *model.TeamMember); ok {
+		r0 = rf(c, teamMember, team)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.TeamMember)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*plugin.Context, *model.TeamMember, *model.Team) string); ok {
+		r1 = rf(c, teamMember, team)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	return r0, r1
+}
+
 // UserWillLogIn provides a mock function with given fields: c, user
 func (_m *Hooks) UserWillLogIn(c *plugin.Context, user *model.User) string {
 	ret := _m.Called(c, user)
@@ -22,6 +22,14 @@ import (
 	"github.com/mattermost/mattermost/server/public/shared/mlog"
 )
 
+// pluginSupervisor manages the server component of a running plugin.
+type pluginSupervisor interface {
+	Hooks() Hooks
+	Implements(hookId int) bool
+	PerformHealthCheck() error
+	Shutdown()
+}
+
 type supervisor struct {
 	lock         sync.RWMutex
 	pluginID     string
@@ -15,7 +15,13 @@ import (
 )
 
 func CompileGo(t *testing.T, sourceCode, outputPath string) {
-	compileGo(t, "go", sourceCode, outputPath)
+	compileGo(t, "go", sourceCode, outputPath, nil)
+}
+
+// CompileGoWasm compiles the given source to a WASI reactor module, as loaded by the
+// WebAssembly plugin runtime.
+func CompileGoWasm(t *testing.T, sourceCode, outputPath string) {
+	compileGo(t, "go", sourceCode, outputPath, []string{"GOOS=wasip1", "GOARCH=wasm"}, "-buildmode=c-shared")
 }
 
 func CompileGoVersion(t *testing.T, goVersion, sourceCode, outputPath string) {
@@ -23,10 +29,10 @@ func CompileGoVersion(t *testing.T, goVersion, sourceCode, outputPath string) {
 	if goVersion != "" {
 		goBin = os.Getenv("GOBIN")
 	}
-	compileGo(t, filepath.Join(goBin, "go"+goVersion), sourceCode, outputPath)
+	compileGo(t, filepath.Join(goBin, "go"+goVersion), sourceCode, outputPath, nil)
 }
 
-func compileGo(t *testing.T, goBin, sourceCode, outputPath string) {
+func compileGo(t *testing.T, goBin, sourceCode, outputPath string, env []string, buildArgs ...string) {
 	dir, err := os.MkdirTemp(".", "")
 	require.NoError(t, err)
 	defer os.RemoveAll(dir)
@@ -44,8 +50,12 @@ func compileGo(t *testing.T, goBin, sourceCode, outputPath string) {
 	serverPath := filepath.Dir(filepath.Dir(sourceFile))
 
 	out := &bytes.Buffer{}
-	cmd := exec.Command(goBin, "build", "-o", outputPath, main)
+	args := append([]string{"build"}, buildArgs...)
+	cmd := exec.Command(goBin, append(args, "-o", outputPath, main)...)
 	cmd.Dir = serverPath
+	if env != nil {
+		cmd.Env = append(os.Environ(), env...)
+	}
 	cmd.Stdout = out
 	cmd.Stderr = out
 	err = cmd.Run()
@@ -0,0 +1,397 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package plugin
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+// hooksWasmClient implements Hooks by calling into a WebAssembly module. Most hooks are
+// generated into hooks_wasm_generated.go, using the argument structs of the RPC glue.
+type hooksWasmClient struct {
+	sup         *wasmSupervisor
+	log         *mlog.Logger
+	implemented [TotalHooksID]bool
+}
+
+var _ Hooks = &hooksWasmClient{}
+
+// wasmAllowedAPIMethods are the API methods WebAssembly plugins can call. Anything reaching
+// beyond the plugin's own data and the basic messaging primitives is deliberately left out.
+var wasmAllowedAPIMethods = map[string]bool{
+	"LogDebug":              true,
+	"LogInfo":               true,
+	"LogWarn":               true,
+	"LogError":              true,
+	"GetPluginID":           true,
+	"GetPluginConfig":       true,
+	"GetServerVersion":      true,
+	"KVSet":                 true,
+	"KVSetWithExpiry":       true,
+	"KVSetWithOptions":      true,
+	"KVCompareAndSet":       true,
+	"KVGet":                 true,
+	"KVDelete":              true,
+	"KVList":                true,
+	"RegisterCommand":       true,
+	"UnregisterCommand":     true,
+	"GetUser":               true,
+	"GetUserByUsername":     true,
+	"GetTeam":               true,
+	"GetTeamByName":         true,
+	"GetTeamMember":         true,
+	"GetChannel":            true,
+	"GetChannelByName":      true,
+	"GetChannelMember":      true,
+	"GetDirectChannel":      true,
+	"GetPost":               true,
+	"CreatePost":            true,
+	"UpdatePost":            true,
+	"SendEphemeralPost":     true,
+	"AddReaction":           true,
+	"RemoveReaction":        true,
+	"PublishWebSocketEvent": true,
+}
+
+var errorType = reflect.TypeFor[error]()
+
+// encodeWasmValues encodes values as a JSON object keyed by position.
+func encodeWasmValues(values []reflect.Value) []byte {
+	encoded := make(map[string]any, len(values))
+	for i, value := range values {
+		key := string(rune('A' + i))
+		if value.Type() == errorType {
+			if value.IsNil() {
+				encoded[key] = nil
+			} else {
+				encoded[key] = value.Interface().(error).Error()
+			}
+			continue
+		}
+		encoded[key] = value.Interface()
+	}
+
+	data, err := json.Marshal(encoded)
+	if err != nil {
+		return encodeWasmError(err)
+	}
+	return data
+}
+
+func encodeWasmError(err error) []byte {
+	data, _ := json.Marshal(map[string]string{"error": err.Error()})
+	return data
+}
+
+// decodeWasmValues decodes a JSON object keyed by position into the fields of the struct
+// pointed to by values, leaving fields missing from data untouched.
+func decodeWasmValues(data []byte, values any) error {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	v := reflect.ValueOf(values).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+		value, ok := raw[field.Name]
+		if !ok {
+			continue
+		}
+
+		if field.Type == errorType {
+			var message *string
+			if err := json.Unmarshal(value, &message); err != nil {
+				return errors.Wrapf(err, "failed to decode %s", field.Name)
+			}
+			if message != nil {
+				v.Field(i).Set(reflect.ValueOf(errors.New(*message)))
+			}
+			continue
+		}
+
+		if err := json.Unmarshal(value, v.Field(i).Addr().Interface()); err != nil {
+			return errors.Wrapf(err, "failed to decode %s", field.Name)
+		}
+	}
+
+	return nil
+}
+
+// setWasmError sets the first error field of returns, if any, to err.
+func setWasmError(returns any, err error) {
+	v := reflect.ValueOf(returns).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		if v.Type().Field(i).Type == errorType {
+			v.Field(i).Set(reflect.ValueOf(err))
+			return
+		}
+	}
+}
+
+// call invokes the named hook. On failure, the error is also returned through the hook's own
+// error result, if it has one.
+func (g *hooksWasmClient) call(name string, args, returns any) error {
+	err := g.callHook(name, args, returns)
+	if err != nil {
+		setWasmError(returns, err)
+	}
+	return err
+}
+
+func (g *hooksWasmClient) callHook(name string, args, returns any) error {
+	data, err := json.Marshal(args)
+	if err != nil {
+		return errors.Wrap(err, "failed to encode arguments")
+	}
+
+	result, err := g.sup.call(wasmExportHook, []byte(name), data)
+	if err != nil {
+		return err
+	}
+	if len(result) == 0 {
+		return nil
+	}
+
+	return decodeWasmValues(result, returns)
+}
+
+// callWasmAPI invokes the named API method with arguments encoded by the module, returning the
+// encoded results.
+func callWasmAPI(apiImpl API, name string, args []byte) []byte {
+	if !wasmAllowedAPIMethods[name] || apiImpl == nil {
+		return encodeWasmError(fmt.Errorf("API %s is not available to WebAssembly plugins", name))
+	}
+
+	method := reflect.ValueOf(apiImpl).MethodByName(name)
+	if !method.IsValid() {
+		return encodeWasmError(fmt.Errorf("API %s not found", name))
+	}
+
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(args, &raw); err != nil {
+		return encodeWasmError(errors.Wrap(err, "failed to decode arguments"))
+	}
+
+	methodType := method.Type()
+	in := make([]reflect.Value, methodType.NumIn())
+	for i := range in {
+		in[i] = reflect.New(methodType.In(i))
+		if value, ok := raw[string(rune('A'+i))]; ok {
+			if err := json.Unmarshal(value, in[i].Interface()); err != nil {
+				return encodeWasmError(errors.Wrapf(err, "failed to decode argument %d", i))
+			}
+		}
+		in[i] = in[i].Elem()
+	}
+
+	var out []reflect.Value
+	if methodType.IsVariadic() {
+		out = method.CallSlice(in)
+	} else {
+		out = method.Call(in)
+	}
+
+	return encodeWasmValues(out)
+}
+
+//
+// Below are hooks that can not be auto generated
+//
+
+func (g *hooksWasmClient) Implemented() ([]string, error) {
+	result, err := g.sup.call(wasmExportImplemented)
+	if err != nil {
+		return nil, err
+	}
+
+	var impl []string
+	if err := json.Unmarshal(result, &impl); err != nil {
+		return nil, errors.Wrap(err, "failed to decode implemented hooks")
+	}
+
+	for _, hookName := range impl {
+		if hookId, ok := hookNameToId[hookName]; ok {
+			g.implemented[hookId] = true
+		} else if hookName == "OnActivate" {
+			// OnActivate is not part of the generated glue, so has no entry in hookNameToId.
+			g.implemented[OnActivateID] = true
+		}
+	}
+
+	return impl, nil
+}
+
+func (g *hooksWasmClient) OnActivate() error {
+	_returns := &Z_OnActivateReturns{}
+	if g.implemented[OnActivateID] {
+		if err := g.call("OnActivate", struct{}{}, _returns); err != nil {
+			g.log.Error("WASM call OnActivate to plugin failed.", mlog.Err(err))
+		}
+	}
+	return _returns.A
+}
+
+// wasmHTTPRequest is the encoding of the request passed to the ServeHTTP and ServeMetrics hooks.
+type wasmHTTPRequest struct {
+	Method     string
+	URL        string
+	Header     http.Header
+	RemoteAddr string
+	Body       []byte
+}
+
+// wasmHTTPResponse is the encoding of the response returned by the ServeHTTP and ServeMetrics hooks.
+type wasmHTTPResponse struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+}
+
+type wasmServeHTTPArgs struct {
+	A *Context
+	B *wasmHTTPRequest
+}
+
+type wasmServeHTTPReturns struct {
+	A *wasmHTTPResponse
+}
+
+func (g *hooksWasmClient) serveHTTP(hookId int, name string, c *Context, w http.ResponseWriter, r *http.Request) {
+	if !g.implemented[hookId] {
+		http.NotFound(w, r)
+		return
+	}
+
+	// The whole body is handed to the module, so it can be no larger than its memory.
+	limit := int64(g.sup.limits.MemoryLimitMB) * 1024 * 1024
+	body, err := io.ReadAll(io.LimitReader(r.Body, limit+1))
+	if err != nil {
+		http.Error(w, "failed to read request body", http.StatusBadRequest)
+		return
+	}
+	if int64(len(body)) > limit {
+		http.Error(w, "request body too large", http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	_args := &wasmServeHTTPArgs{c, &wasmHTTPRequest{
+		Method:     r.Method,
+		URL:        r.URL.String(),
+		Header:     r.Header,
+		RemoteAddr: r.RemoteAddr,
+		Body:       body,
+	}}
+	_returns := &wasmServeHTTPReturns{}
+	if err := g.call(name, _args, _returns); err != nil || _returns.A == nil {
+		g.log.Error("WASM call "+name+" to plugin failed.", mlog.Err(err))
+		http.Error(w, "500 internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	for k, v := range _returns.A.Header {
+		w.Header()[k] = v
+	}
+	if _returns.A.StatusCode != 0 {
+		w.WriteHeader(_returns.A.StatusCode)
+	}
+	if _, err := w.Write(_returns.A.Body); err != nil {
+		g.log.Warn("Failed to write "+name+" response", mlog.Err(err))
+	}
+}
+
+func (g *hooksWasmClient) ServeHTTP(c *Context, w http.ResponseWriter, r *http.Request) {
+	g.serveHTTP(ServeHTTPID, "ServeHTTP", c, w, r)
+}
+
+func (g *hooksWasmClient) ServeMetrics(c *Context, w http.ResponseWriter, r *http.Request) {
+	g.serveHTTP(ServeMetricsID, "ServeMetrics", c, w, r)
+}
+
+type wasmFileWillBeUploadedArgs struct {
+	A *Context
+	B *model.FileInfo
+	C []byte
+}
+
+type wasmFileWillBeUploadedReturns struct {
+	A *model.FileInfo
+	B string
+	C []byte
+}
+
+// FileWillBeUploaded hands the whole file to the module. Any content it returns replaces the
+// uploaded file.
+func (g *hooksWasmClient) FileWillBeUploaded(c *Context, info *model.FileInfo, file io.Reader, output io.Writer) (*model.FileInfo, string) {
+	if !g.implemented[FileWillBeUploadedID] {
+		return info, ""
+	}
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		g.log.Error("Failed to read file for FileWillBeUploaded.", mlog.Err(err))
+		return info, ""
+	}
+
+	_args := &wasmFileWillBeUploadedArgs{c, info, data}
+	_returns := &wasmFileWillBeUploadedReturns{A: info}
+	if err := g.call("FileWillBeUploaded", _args, _returns); err != nil {
+		g.log.Error("WASM call FileWillBeUploaded to plugin failed.", mlog.Err(err))
+		return info, ""
+	}
+
+	if len(_returns.C) > 0 {
+		if _, err := io.Copy(output, bytes.NewReader(_returns.C)); err != nil {
+			g.log.Error("Failed to write FileWillBeUploaded output.", mlog.Err(err))
+		}
+	}
+
+	return _returns.A, _returns.B
+}
+
+func (g *hooksWasmClient) MessageWillBePosted(c *Context, post *model.Post) (*model.Post, string) {
+	_args := &Z_MessageWillBePostedArgs{c, post}
+	_returns := &Z_MessageWillBePostedReturns{A: _args.B}
+	if g.implemented[MessageWillBePostedID] {
+		if err := g.call("MessageWillBePosted", _args, _returns); err != nil {
+			g.log.Error("WASM call MessageWillBePosted to plugin failed.", mlog.Err(err))
+			return post, ""
+		}
+	}
+	return _returns.A, _returns.B
+}
+
+func (g *hooksWasmClient) MessageWillBeUpdated(c *Context, newPost, oldPost *model.Post) (*model.Post, string) {
+	_args := &Z_MessageWillBeUpdatedArgs{c, newPost, oldPost}
+	_returns := &Z_MessageWillBeUpdatedReturns{A: _args.B}
+	if g.implemented[MessageWillBeUpdatedID] {
+		if err := g.call("MessageWillBeUpdated", _args, _returns); err != nil {
+			g.log.Error("WASM call MessageWillBeUpdated to plugin failed.", mlog.Err(err))
+			return newPost, ""
+		}
+	}
+	return _returns.A, _returns.B
+}
+
+func (g *hooksWasmClient) MessagesWillBeConsumed(posts []*model.Post) []*model.Post {
+	_args := &Z_MessagesWillBeConsumedArgs{posts}
+	_returns := &Z_MessagesWillBeConsumedReturns{A: posts}
+	if g.implemented[MessagesWillBeConsumedID] {
+		if err := g.call("MessagesWillBeConsumed", _args, _returns); err != nil {
+			g.log.Error("WASM call MessagesWillBeConsumed to plugin failed.", mlog.Err(err))
+			return posts
+		}
+	}
+	return _returns.A
+}
@@ -0,0 +1,357 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package plugin
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/tetratelabs/wazero"
+	"github.com/tetratelabs/wazero/api"
+	"github.com/tetratelabs/wazero/imports/wasi_snapshot_preview1"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+// WebAssembly plugins are WASI (wasip1) reactor modules loaded in-process. Values crossing the
+// module boundary are JSON objects keyed by argument position ("A", "B", ...), the same layout
+// used by the RPC glue. Errors are encoded as their message, or null.
+//
+// The module must export:
+//
+//	malloc(size i32) i32       allocate size bytes owned by the host until freed
+//	free(ptr i32)              release memory returned by malloc or by the functions below
+//	mm_implemented() i64       the JSON list of implemented hook names
+//	mm_hook(name, args) i64    invoke the named hook
+//
+// Strings and buffers are passed as (ptr i32, len i32) pairs, and returned as a single i64
+// holding ptr<<32 | len. The module may import the following from the "mattermost" module:
+//
+//	api_call(name, args) i64   invoke the named plugin API method
+//
+// Only the API methods listed in wasmAllowedAPIMethods can be called.
+const (
+	wasmHostModuleName = "mattermost"
+
+	wasmExportMalloc      = "malloc"
+	wasmExportFree        = "free"
+	wasmExportImplemented = "mm_implemented"
+	wasmExportHook        = "mm_hook"
+	wasmExportInitialize  = "_initialize"
+
+	wasmPageSize = 64 * 1024
+)
+
+// WasmLimits are the resource limits applied to each WebAssembly plugin.
+type WasmLimits struct {
+	// MemoryLimitMB is the maximum size of the plugin's linear memory.
+	MemoryLimitMB int
+	// HookTimeout is the maximum time the plugin may execute for a single hook. Time spent
+	// waiting on API calls is not counted. A plugin exceeding it is terminated.
+	HookTimeout time.Duration
+}
+
+func (l WasmLimits) withDefaults() WasmLimits {
+	if l.MemoryLimitMB <= 0 {
+		l.MemoryLimitMB = model.PluginSettingsDefaultWasmMemoryLimitMB
+	}
+	if l.HookTimeout <= 0 {
+		l.HookTimeout = model.PluginSettingsDefaultWasmHookTimeoutMilliseconds * time.Millisecond
+	}
+	return l
+}
+
+type wasmSupervisor struct {
+	pluginID string
+	log      *mlog.Logger
+	limits   WasmLimits
+	apiImpl  API
+	runtime  wazero.Runtime
+	module   api.Module
+	hooks    Hooks
+	client   *hooksWasmClient
+
+	// busy serializes calls into the module, which is single threaded.
+	busy chan struct{}
+}
+
+func getWasmExecutablePath(pluginInfo *model.BundleInfo) (string, error) {
+	executable := pluginInfo.Manifest.GetExecutableForRuntime("wasip1", "wasm")
+	if executable == "" {
+		return "", fmt.Errorf("backend executable not found for environment: wasip1/wasm")
+	}
+
+	executable = filepath.Clean(filepath.Join(".", executable))
+	if strings.HasPrefix(executable, "..") {
+		return "", fmt.Errorf("invalid backend executable: %s", executable)
+	}
+
+	return filepath.Join(pluginInfo.Path, executable), nil
+}
+
+func newWasmSupervisor(pluginInfo *model.BundleInfo, apiImpl API, parentLogger *mlog.Logger, metrics metricsInterface, limits WasmLimits) (retSupervisor *wasmSupervisor, retErr error) {
+	limits = limits.withDefaults()
+	sup := &wasmSupervisor{
+		pluginID: pluginInfo.Manifest.Id,
+		log:      pluginInfo.WrapLogger(parentLogger),
+		limits:   limits,
+		busy:     make(chan struct{}, 1),
+	}
+	if apiImpl != nil {
+		sup.apiImpl = &apiTimerLayer{pluginInfo.Manifest.Id, apiImpl, metrics}
+	}
+
+	executable, err := getWasmExecutablePath(pluginInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	wasm, err := os.ReadFile(executable)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to read plugin module")
+	}
+
+	ctx := context.Background()
+	sup.runtime = wazero.NewRuntimeWithConfig(ctx, wazero.NewRuntimeConfig().
+		WithMemoryLimitPages(uint32(limits.MemoryLimitMB*1024*1024/wasmPageSize)).
+		WithCloseOnContextDone(true))
+
+	defer func() {
+		if retErr != nil {
+			sup.Shutdown()
+		}
+	}()
+
+	if _, err = wasi_snapshot_preview1.Instantiate(ctx, sup.runtime); err != nil {
+		return nil, errors.Wrap(err, "unable to instantiate WASI")
+	}
+
+	_, err = sup.runtime.NewHostModuleBuilder(wasmHostModuleName).
+		NewFunctionBuilder().
+		WithGoModuleFunction(api.GoModuleFunc(sup.apiCall), []api.ValueType{api.ValueTypeI32, api.ValueTypeI32, api.ValueTypeI32, api.ValueTypeI32}, []api.ValueType{api.ValueTypeI64}).
+		Export("api_call").
+		Instantiate(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to instantiate host module")
+	}
+
+	compiled, err := sup.runtime.CompileModule(ctx, wasm)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to compile plugin module")
+	}
+
+	// The module gets no filesystem, environment or arguments. Its output goes to the
+	// plugin's log, like the stdout and stderr of RPC plugins.
+	moduleConfig := wazero.NewModuleConfig().
+		WithName(sup.pluginID).
+		WithStdout(sup.log.With(mlog.String("source", "plugin_stdout")).StdLogWriter()).
+		WithStderr(sup.log.With(mlog.String("source", "plugin_stderr")).StdLogWriter()).
+		WithSysWalltime().
+		WithSysNanotime().
+		WithRandSource(rand.Reader).
+		WithStartFunctions(wasmExportInitialize)
+
+	budget := newCPUBudget(limits.HookTimeout)
+	sup.module, err = sup.runtime.InstantiateModule(budget, compiled, moduleConfig)
+	budget.release()
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to instantiate plugin module")
+	}
+
+	for _, name := range []string{wasmExportMalloc, wasmExportFree, wasmExportImplemented, wasmExportHook} {
+		if sup.module.ExportedFunction(name) == nil {
+			return nil, fmt.Errorf("plugin module does not export %s", name)
+		}
+	}
+
+	sup.client = &hooksWasmClient{sup: sup, log: sup.log}
+	sup.hooks = &hooksTimerLayer{pluginInfo.Manifest.Id, sup.client, metrics}
+
+	if _, err := sup.hooks.Implemented(); err != nil {
+		return nil, err
+	}
+
+	return sup, nil
+}
+
+func (sup *wasmSupervisor) Hooks() Hooks {
+	return sup.hooks
+}
+
+func (sup *wasmSupervisor) Implements(hookId int) bool {
+	return sup.client.implemented[hookId]
+}
+
+// PerformHealthCheck reports the plugin as crashed once its module has been closed, which
+// happens when it traps, exits or exceeds its time limit.
+func (sup *wasmSupervisor) PerformHealthCheck() error {
+	if sup.module.IsClosed() {
+		return fmt.Errorf("plugin module is closed")
+	}
+
+	return nil
+}
+
+func (sup *wasmSupervisor) Shutdown() {
+	if sup.runtime != nil {
+		if err := sup.runtime.Close(context.Background()); err != nil {
+			sup.log.Warn("Failed to close plugin runtime", mlog.Err(err))
+		}
+	}
+}
+
+// call invokes the named export with the given buffers, returning the buffer it returns.
+func (sup *wasmSupervisor) call(name string, buffers ...[]byte) ([]byte, error) {
+	// Waiting does not count towards the budget, but is bounded so that a hook triggered by an
+	// API call of the same plugin gives up instead of waiting forever.
+	timer := time.NewTimer(sup.limits.HookTimeout)
+	defer timer.Stop()
+	select {
+	case sup.busy <- struct{}{}:
+	case <-timer.C:
+		return nil, fmt.Errorf("timed out waiting for plugin to call %s", name)
+	}
+	defer func() { <-sup.busy }()
+
+	if sup.module.IsClosed() {
+		return nil, fmt.Errorf("plugin module is closed")
+	}
+
+	budget := newCPUBudget(sup.limits.HookTimeout)
+	defer budget.release()
+
+	params := make([]uint64, 0, 2*len(buffers))
+	for _, buffer := range buffers {
+		ptr, err := sup.writeBuffer(budget, buffer)
+		if err != nil {
+			return nil, err
+		}
+		defer sup.free(budget, ptr)
+		params = append(params, uint64(ptr), uint64(len(buffer)))
+	}
+
+	results, err := sup.module.ExportedFunction(name).Call(budget, params...)
+	if err != nil {
+		if budget.Err() != nil {
+			return nil, fmt.Errorf("plugin exceeded its time limit of %s calling %s", sup.limits.HookTimeout, name)
+		}
+		// A trap, such as running out of memory, leaves the module in an undefined state.
+		// Close it so that the health check restarts the plugin.
+		if closeErr := sup.module.Close(context.Background()); closeErr != nil {
+			sup.log.Warn("Failed to close plugin module", mlog.Err(closeErr))
+		}
+		return nil, errors.Wrapf(err, "failed to call %s", name)
+	}
+
+	ptr, length := uint32(results[0]>>32), uint32(results[0])
+	if ptr == 0 {
+		return nil, nil
+	}
+	defer sup.free(budget, ptr)
+
+	data, ok := sup.module.Memory().Read(ptr, length)
+	if !ok {
+		return nil, fmt.Errorf("%s returned out of bounds memory", name)
+	}
+
+	// Copy, as the memory is released before returning.
+	return append([]byte(nil), data...), nil
+}
+
+// writeBuffer copies buffer into memory allocated within the module.
+func (sup *wasmSupervisor) writeBuffer(ctx context.Context, buffer []byte) (uint32, error) {
+	results, err := sup.module.ExportedFunction(wasmExportMalloc).Call(ctx, uint64(len(buffer)))
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to allocate plugin memory")
+	}
+
+	ptr := uint32(results[0])
+	if !sup.module.Memory().Write(ptr, buffer) {
+		return 0, fmt.Errorf("malloc returned out of bounds memory")
+	}
+
+	return ptr, nil
+}
+
+func (sup *wasmSupervisor) free(ctx context.Context, ptr uint32) {
+	if _, err := sup.module.ExportedFunction(wasmExportFree).Call(ctx, uint64(ptr)); err != nil {
+		sup.log.Warn("Failed to free plugin memory", mlog.Err(err))
+	}
+}
+
+// apiCall implements the api_call host function.
+func (sup *wasmSupervisor) apiCall(ctx context.Context, module api.Module, stack []uint64) {
+	if budget, ok := ctx.Value(cpuBudgetKey{}).(*cpuBudget); ok {
+		budget.pause()
+		defer budget.resume()
+	}
+
+	name, ok := module.Memory().Read(uint32(stack[0]), uint32(stack[1]))
+	if !ok {
+		panic("api_call name out of bounds")
+	}
+	args, ok := module.Memory().Read(uint32(stack[2]), uint32(stack[3]))
+	if !ok {
+		panic("api_call arguments out of bounds")
+	}
+
+	result := callWasmAPI(sup.apiImpl, string(name), args)
+
+	ptr, err := sup.writeBuffer(ctx, result)
+	if err != nil {
+		panic(err)
+	}
+	stack[0] = uint64(ptr)<<32 | uint64(len(result))
+}
+
+type cpuBudgetKey struct{}
+
+// cpuBudget is a context cancelled once a call has executed within the module for longer than
+// its budget. Time spent in host functions is excluded by pausing the budget.
+type cpuBudget struct {
+	context.Context
+	cancel context.CancelFunc
+
+	mut       sync.Mutex
+	remaining time.Duration
+	started   time.Time
+	timer     *time.Timer
+}
+
+func newCPUBudget(budget time.Duration) *cpuBudget {
+	ctx, cancel := context.WithCancel(context.Background())
+	b := &cpuBudget{cancel: cancel, remaining: budget}
+	b.Context = context.WithValue(ctx, cpuBudgetKey{}, b)
+	b.resume()
+	return b
+}
+
+func (b *cpuBudget) pause() {
+	b.mut.Lock()
+	defer b.mut.Unlock()
+	if b.timer.Stop() {
+		b.remaining -= time.Since(b.started)
+	}
+}
+
+func (b *cpuBudget) resume() {
+	b.mut.Lock()
+	defer b.mut.Unlock()
+	b.started = time.Now()
+	b.timer = time.AfterFunc(b.remaining, b.cancel)
+}
+
+func (b *cpuBudget) release() {
+	b.mut.Lock()
+	defer b.mut.Unlock()
+	b.timer.Stop()
+}
@@ -0,0 +1,237 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package plugin
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/plugin/utils"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+const wasmTestPluginSource = `
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"unsafe"
+)
+
+var allocations = map[uint32][]byte{}
+
+//go:wasmexport malloc
+func malloc(size uint32) uint32 {
+	buf := make([]byte, size+1)
+	ptr := uint32(uintptr(unsafe.Pointer(&buf[0])))
+	allocations[ptr] = buf
+	return ptr
+}
+
+//go:wasmexport free
+func free(ptr uint32) {
+	delete(allocations, ptr)
+}
+
+//go:wasmimport mattermost api_call
+func apiCall(namePtr, nameLen, argsPtr, argsLen uint32) uint64
+
+func read(ptr, length uint32) []byte {
+	return unsafe.Slice((*byte)(unsafe.Pointer(uintptr(ptr))), length)
+}
+
+func pack(data []byte) uint64 {
+	ptr := malloc(uint32(len(data)))
+	copy(allocations[ptr], data)
+	return uint64(ptr)<<32 | uint64(len(data))
+}
+
+func callAPI(name string, args map[string]any) map[string]json.RawMessage {
+	n := []byte(name)
+	a, _ := json.Marshal(args)
+	result := apiCall(uint32(uintptr(unsafe.Pointer(&n[0]))), uint32(len(n)), uint32(uintptr(unsafe.Pointer(&a[0]))), uint32(len(a)))
+	ptr, length := uint32(result>>32), uint32(result)
+	var out map[string]json.RawMessage
+	json.Unmarshal(read(ptr, length), &out)
+	free(ptr)
+	return out
+}
+
+//go:wasmexport mm_implemented
+func implemented() uint64 {
+	data, _ := json.Marshal([]string{"OnActivate", "OnConfigurationChange", "ExecuteCommand", "MessageWillBePosted", "ServeHTTP"})
+	return pack(data)
+}
+
+var memory [][]byte
+
+//go:wasmexport mm_hook
+func hook(namePtr, nameLen, argsPtr, argsLen uint32) uint64 {
+	var args map[string]json.RawMessage
+	json.Unmarshal(read(argsPtr, argsLen), &args)
+
+	var returns map[string]any
+	switch string(read(namePtr, nameLen)) {
+	case "OnActivate":
+		callAPI("LogInfo", map[string]any{"A": "activated", "B": []any{"runtime", "wasm"}})
+		returns = map[string]any{"A": nil}
+	case "OnConfigurationChange":
+		returns = map[string]any{"A": "configuration rejected"}
+	case "MessageWillBePosted":
+		var post map[string]any
+		json.Unmarshal(args["B"], &post)
+		if strings.Contains(post["message"].(string), "reject") {
+			returns = map[string]any{"A": nil, "B": "rejected"}
+			break
+		}
+		post["message"] = post["message"].(string) + " (from wasm)"
+		returns = map[string]any{"A": post, "B": ""}
+	case "ExecuteCommand":
+		var commandArgs map[string]any
+		json.Unmarshal(args["B"], &commandArgs)
+		switch commandArgs["command"] {
+		case "/kv":
+			result := callAPI("KVGet", map[string]any{"A": "key"})
+			var value []byte
+			json.Unmarshal(result["A"], &value)
+			returns = map[string]any{"A": map[string]any{"text": string(value)}}
+		case "/config":
+			result := callAPI("GetConfig", map[string]any{})
+			var message string
+			json.Unmarshal(result["error"], &message)
+			returns = map[string]any{"A": map[string]any{"text": message}}
+		case "/loop":
+			for {
+			}
+		case "/alloc":
+			for {
+				memory = append(memory, make([]byte, 1024*1024))
+			}
+		}
+	case "ServeHTTP":
+		var request map[string]any
+		json.Unmarshal(args["B"], &request)
+		returns = map[string]any{"A": map[string]any{"StatusCode": 201, "Body": []byte(request["Method"].(string) + " " + request["URL"].(string))}}
+	}
+
+	data, _ := json.Marshal(returns)
+	return pack(data)
+}
+
+func main() {}
+`
+
+type wasmTestAPI struct {
+	API
+	logged []string
+}
+
+func (api *wasmTestAPI) LogInfo(msg string, keyValuePairs ...any) {
+	api.logged = append(api.logged, msg)
+	for _, kv := range keyValuePairs {
+		api.logged = append(api.logged, kv.(string))
+	}
+}
+
+func (api *wasmTestAPI) KVGet(key string) ([]byte, *model.AppError) {
+	return []byte("value of " + key), nil
+}
+
+func TestWasmSupervisor(t *testing.T) {
+	dir := t.TempDir()
+
+	utils.CompileGoWasm(t, wasmTestPluginSource, filepath.Join(dir, "plugin.wasm"))
+	err := os.WriteFile(filepath.Join(dir, "plugin.json"), []byte(`{"id": "foo", "server": {"executable": "plugin.wasm", "runtime": "wasm"}}`), 0600)
+	require.NoError(t, err)
+
+	bundle := model.BundleInfoForPath(dir)
+	require.True(t, bundle.Manifest.IsWasm())
+
+	newSupervisor := func(t *testing.T, api API) *wasmSupervisor {
+		t.Helper()
+		sup, err := newWasmSupervisor(bundle, api, mlog.CreateConsoleTestLogger(t), nil, WasmLimits{MemoryLimitMB: 64, HookTimeout: 500 * time.Millisecond})
+		require.NoError(t, err)
+		t.Cleanup(sup.Shutdown)
+		return sup
+	}
+
+	t.Run("hooks", func(t *testing.T) {
+		api := &wasmTestAPI{}
+		sup := newSupervisor(t, api)
+
+		assert.True(t, sup.Implements(OnActivateID))
+		assert.True(t, sup.Implements(MessageWillBePostedID))
+		assert.False(t, sup.Implements(MessageHasBeenPostedID))
+
+		require.NoError(t, sup.Hooks().OnActivate())
+		assert.Equal(t, []string{"activated", "runtime", "wasm"}, api.logged)
+
+		assert.EqualError(t, sup.Hooks().OnConfigurationChange(), "configuration rejected")
+
+		post, rejection := sup.Hooks().MessageWillBePosted(&Context{}, &model.Post{Id: "post", Message: "hello"})
+		assert.Empty(t, rejection)
+		require.NotNil(t, post)
+		assert.Equal(t, "post", post.Id)
+		assert.Equal(t, "hello (from wasm)", post.Message)
+
+		post, rejection = sup.Hooks().MessageWillBePosted(&Context{}, &model.Post{Message: "reject me"})
+		assert.Nil(t, post)
+		assert.Equal(t, "rejected", rejection)
+
+		response, appErr := sup.Hooks().ExecuteCommand(&Context{}, &model.CommandArgs{Command: "/kv"})
+		require.Nil(t, appErr)
+		assert.Equal(t, "value of key", response.Text)
+
+		w := httptest.NewRecorder()
+		sup.Hooks().ServeHTTP(&Context{}, w, httptest.NewRequest(http.MethodPost, "/plugins/foo/hello", strings.NewReader("body")))
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Equal(t, "POST /plugins/foo/hello", w.Body.String())
+
+		assert.NoError(t, sup.PerformHealthCheck())
+	})
+
+	t.Run("API methods outside the allowlist are rejected", func(t *testing.T) {
+		sup := newSupervisor(t, &wasmTestAPI{})
+
+		response, appErr := sup.Hooks().ExecuteCommand(&Context{}, &model.CommandArgs{Command: "/config"})
+		require.Nil(t, appErr)
+		assert.Equal(t, "API GetConfig is not available to WebAssembly plugins", response.Text)
+	})
+
+	t.Run("hook exceeding the time limit terminates the plugin", func(t *testing.T) {
+		sup := newSupervisor(t, &wasmTestAPI{})
+
+		response, appErr := sup.Hooks().ExecuteCommand(&Context{}, &model.CommandArgs{Command: "/loop"})
+		assert.Nil(t, response)
+		assert.Nil(t, appErr)
+		assert.Error(t, sup.PerformHealthCheck())
+
+		assert.Error(t, sup.Hooks().OnConfigurationChange())
+	})
+
+	t.Run("plugin exceeding the memory limit is terminated", func(t *testing.T) {
+		sup := newSupervisor(t, &wasmTestAPI{})
+
+		sup.Hooks().ExecuteCommand(&Context{}, &model.CommandArgs{Command: "/alloc"})
+		assert.Error(t, sup.PerformHealthCheck())
+	})
+
+	t.Run("missing module", func(t *testing.T) {
+		missing := model.BundleInfoForPath(dir)
+		missing.Manifest.Server.Executable = "missing.wasm"
+		sup, err := newWasmSupervisor(missing, nil, mlog.CreateConsoleTestLogger(t), nil, WasmLimits{})
+		assert.Error(t, err)
+		assert.Nil(t, sup)
+	})
+}
@@ -0,0 +1,54 @@
+package pluginapi
+
+import (
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/plugin"
+)
+
+// AIService exposes the AI features of the server, which are backed by the LLM configured
+// for the server.
+//
+// The features must be enabled in the AI settings of the server. Prompts sent through this
+// service count against the AI rate limit of the server and can be modified or rejected by
+// the AIPromptWillBeSent hook.
+type AIService struct {
+	api plugin.API
+}
+
+// SummarizeThread generates a summary of the thread the post belongs to, on behalf of the user.
+// level is one of "brief", "standard" or "detailed", and defaults to "standard".
+//
+// Minimum server version: 11.2
+func (a *AIService) SummarizeThread(userID, postID, level string) (*model.AISummary, error) {
+	summary, appErr := a.api.SummarizeThread(userID, postID, level)
+
+	return summary, normalizeAppErr(appErr)
+}
+
+// FormatMessage rewrites the message according to the formatting profile, on behalf of the user.
+// profile defaults to "professional".
+//
+// Minimum server version: 11.2
+func (a *AIService) FormatMessage(userID, message, profile string) (string, error) {
+	formatted, appErr := a.api.FormatMessage(userID, message, profile)
+
+	return formatted, normalizeAppErr(appErr)
+}
+
+// CreateActionItem creates an action item on behalf of its creator.
+//
+// Minimum server version: 11.2
+func (a *AIService) CreateActionItem(item *model.AIActionItem) (*model.AIActionItem, error) {
+	item, appErr := a.api.CreateActionItem(item)
+
+	return item, normalizeAppErr(appErr)
+}
+
+// CreateCompletion sends the prompt to the LLM on behalf of the user and returns its completion.
+//
+// Minimum server version: 11.2
+func (a *AIService) CreateCompletion(userID string, request *model.AICompletionRequest) (*model.AICompletionResponse, error) {
+	response, appErr := a.api.CreateAICompletion(userID, request)
+
+	return response, normalizeAppErr(appErr)
+}
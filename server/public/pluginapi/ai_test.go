@@ -0,0 +1,127 @@
+package pluginapi_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/plugin/plugintest"
+	"github.com/mattermost/mattermost/server/public/pluginapi"
+)
+
+func TestAISummarizeThread(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		api := &plugintest.API{}
+		defer api.AssertExpectations(t)
+		client := pluginapi.NewClient(api, &plugintest.Driver{})
+
+		api.On("SummarizeThread", "user", "post", "brief").Return(&model.AISummary{Summary: "summary"}, nil)
+
+		summary, err := client.AI.SummarizeThread("user", "post", "brief")
+		require.NoError(t, err)
+		require.Equal(t, "summary", summary.Summary)
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		api := &plugintest.API{}
+		defer api.AssertExpectations(t)
+		client := pluginapi.NewClient(api, &plugintest.Driver{})
+
+		appErr := newAppError()
+
+		api.On("SummarizeThread", "user", "post", "").Return(nil, appErr)
+
+		summary, err := client.AI.SummarizeThread("user", "post", "")
+		require.Equal(t, appErr, err)
+		require.Nil(t, summary)
+	})
+}
+
+func TestAIFormatMessage(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		api := &plugintest.API{}
+		defer api.AssertExpectations(t)
+		client := pluginapi.NewClient(api, &plugintest.Driver{})
+
+		api.On("FormatMessage", "user", "hey", "casual").Return("Hey!", nil)
+
+		formatted, err := client.AI.FormatMessage("user", "hey", "casual")
+		require.NoError(t, err)
+		require.Equal(t, "Hey!", formatted)
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		api := &plugintest.API{}
+		defer api.AssertExpectations(t)
+		client := pluginapi.NewClient(api, &plugintest.Driver{})
+
+		appErr := newAppError()
+
+		api.On("FormatMessage", "user", "hey", "").Return("", appErr)
+
+		formatted, err := client.AI.FormatMessage("user", "hey", "")
+		require.Equal(t, appErr, err)
+		require.Empty(t, formatted)
+	})
+}
+
+func TestAICreateActionItem(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		api := &plugintest.API{}
+		defer api.AssertExpectations(t)
+		client := pluginapi.NewClient(api, &plugintest.Driver{})
+
+		item := &model.AIActionItem{Description: "Ship it"}
+		api.On("CreateActionItem", item).Return(&model.AIActionItem{Id: "1", Description: "Ship it"}, nil)
+
+		created, err := client.AI.CreateActionItem(item)
+		require.NoError(t, err)
+		require.Equal(t, "1", created.Id)
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		api := &plugintest.API{}
+		defer api.AssertExpectations(t)
+		client := pluginapi.NewClient(api, &plugintest.Driver{})
+
+		appErr := newAppError()
+
+		item := &model.AIActionItem{Description: "Ship it"}
+		api.On("CreateActionItem", item).Return(nil, appErr)
+
+		created, err := client.AI.CreateActionItem(item)
+		require.Equal(t, appErr, err)
+		require.Nil(t, created)
+	})
+}
+
+func TestAICreateCompletion(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		api := &plugintest.API{}
+		defer api.AssertExpectations(t)
+		client := pluginapi.NewClient(api, &plugintest.Driver{})
+
+		request := &model.AICompletionRequest{UserPrompt: "Hello"}
+		api.On("CreateAICompletion", "user", request).Return(&model.AICompletionResponse{Text: "Hi"}, nil)
+
+		response, err := client.AI.CreateCompletion("user", request)
+		require.NoError(t, err)
+		require.Equal(t, "Hi", response.Text)
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		api := &plugintest.API{}
+		defer api.AssertExpectations(t)
+		client := pluginapi.NewClient(api, &plugintest.Driver{})
+
+		appErr := newAppError()
+
+		request := &model.AICompletionRequest{UserPrompt: "Hello"}
+		api.On("CreateAICompletion", "user", request).Return(nil, appErr)
+
+		response, err := client.AI.CreateCompletion("user", request)
+		require.Equal(t, appErr, err)
+		require.Nil(t, response)
+	})
+}
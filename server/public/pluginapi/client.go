@@ -10,6 +10,7 @@ import (
 type Client struct {
 	api plugin.API
 
+	AI            AIService
 	Bot           BotService
 	Channel       ChannelService
 	Cluster       ClusterService
@@ -41,6 +42,7 @@ func NewClient(api plugin.API, driver plugin.Driver) *Client {
 	return &Client{
 		api: api,
 
+		AI:            AIService{api: api},
 		Bot:           BotService{api: api},
 		Channel:       ChannelService{api: api},
 		Cluster:       ClusterService{api: api},
@@ -41,6 +41,7 @@ export type NewPostMessageProps = {
     set_online: boolean;
     mentions?: string;
     followers?: string;
+    notification_rule?: 'notify' | 'suppress' | 'escalate';
     team_id: string;
     should_ack: boolean;
     otherFile?: 'true';
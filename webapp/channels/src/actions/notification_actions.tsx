@@ -277,7 +277,15 @@ function shouldSkipNotification(
         return undefined;
     }
 
-    if (isChannelMuted(member)) {
+    // A notification rule of the user matching the post replaces their notification preferences.
+    const notificationRule = msgProps.notification_rule;
+    if (notificationRule === 'suppress') {
+        return {status: 'not_sent', reason: 'notification_rule'};
+    } else if (notificationRule === 'escalate') {
+        return undefined;
+    }
+
+    if (notificationRule !== 'notify' && isChannelMuted(member)) {
         return {status: 'not_sent', reason: 'channel_muted'};
     }
 
@@ -286,6 +294,10 @@ function shouldSkipNotification(
         return {status: 'not_sent', reason: 'user_status', data: userStatus};
     }
 
+    if (notificationRule === 'notify') {
+        return shouldSkipNotificationOfOpenChannel(state, post, channel, isCrtReply);
+    }
+
     let mentions = [];
     if (msgProps.mentions) {
         mentions = JSON.parse(msgProps.mentions);
@@ -394,6 +406,15 @@ function shouldSkipNotification(
         return {status: 'not_sent', reason: 'not_following_thread'};
     }
 
+    return shouldSkipNotificationOfOpenChannel(state, post, channel, isCrtReply);
+}
+
+function shouldSkipNotificationOfOpenChannel(
+    state: GlobalState,
+    post: Post,
+    channel: Pick<Channel, 'type' | 'id'>,
+    isCrtReply: boolean,
+) {
     // Notify if you're not looking in the right channel or when
     // the window itself is not active
     const activeChannel = getCurrentChannel(state);
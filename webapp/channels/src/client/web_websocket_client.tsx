@@ -1,7 +1,14 @@
 // Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
 // See LICENSE.txt for license information.
 
-import {WebSocketClient} from '@mattermost/client';
+import {EventStreamSocket, WebSocketClient} from '@mattermost/client';
 
-const WebClient = new WebSocketClient();
+function newEventStream(url: string) {
+    return new EventStreamSocket(url) as unknown as WebSocket;
+}
+
+// Falls back to an event stream where websockets can't connect, e.g. behind a proxy stripping the Upgrade header.
+const WebClient = new WebSocketClient({
+    newEventStreamFn: typeof EventSource === 'undefined' ? null : newEventStream,
+});
 export default WebClient;
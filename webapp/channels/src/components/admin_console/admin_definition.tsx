@@ -2657,7 +2657,6 @@ const AdminDefinition: AdminDefinitionType = {
                             isDisabled: it.any(
                                 it.not(it.userHasWritePermissionOnResource(RESOURCE_KEYS.SITE.NOTIFICATIONS)),
                                 it.stateIsFalse('EmailSettings.SendEmailNotifications'),
-                                it.configIsTrue('ClusterSettings', 'Enable'),
                                 it.configIsFalse('ServiceSettings', 'SiteURL'),
                             ),
                             isHidden: it.licensedForFeature('Cloud'),
@@ -11,6 +11,7 @@ import ExternalLink from 'components/external_link';
 
 import {Constants, DocLinks} from 'utils/constants';
 
+import BooleanSetting from './boolean_setting';
 import DropdownSetting from './dropdown_setting';
 import OLDAdminSettings from './old_admin_settings';
 import type {BaseProps, BaseState} from './old_admin_settings';
@@ -28,6 +29,8 @@ type State = BaseState & {
     pushNotificationServerLocation: EmailSettings['PushNotificationServerLocation'];
     agree: boolean;
     maxNotificationsPerChannel: number;
+    enableWebPush: boolean;
+    webPushSubject: string;
 };
 
 const PUSH_NOTIFICATIONS_OFF = 'off';
@@ -53,12 +56,18 @@ const messages = defineMessages({
     pushNotificationServer: {id: 'admin.environment.pushNotificationServer', defaultMessage: 'Push Notification Server'},
     pushTitle: {id: 'admin.email.pushTitle', defaultMessage: 'Enable Push Notifications: '},
     pushServerTitle: {id: 'admin.email.pushServerTitle', defaultMessage: 'Push Notification Server:'},
+    enableWebPushTitle: {id: 'admin.email.enableWebPushTitle', defaultMessage: 'Enable Web Push Notifications:'},
+    enableWebPushDescription: {id: 'admin.email.enableWebPushDescription', defaultMessage: 'When true, browsers and installed web apps can subscribe to push notifications, which are sent directly to the push services of the browsers without going through the Push Notification Server.'},
+    webPushSubjectTitle: {id: 'admin.email.webPushSubjectTitle', defaultMessage: 'Web Push Contact:'},
+    webPushSubjectDescription: {id: 'admin.email.webPushSubjectDescription', defaultMessage: 'A mailto: or https:// URL the push services of the browsers can contact the administrators at. Defaults to the notification email address.'},
 });
 
 export const searchableStrings = [
     messages.pushNotificationServer,
     messages.pushTitle,
     messages.pushServerTitle,
+    messages.enableWebPushTitle,
+    messages.webPushSubjectTitle,
 ];
 
 class PushSettings extends OLDAdminSettings<Props, State> {
@@ -109,6 +118,8 @@ class PushSettings extends OLDAdminSettings<Props, State> {
         config.EmailSettings.SendPushNotifications = this.state.pushNotificationServerType !== PUSH_NOTIFICATIONS_OFF;
         config.EmailSettings.PushNotificationServer = this.state.pushNotificationServer.trim();
         config.TeamSettings.MaxNotificationsPerChannel = this.state.maxNotificationsPerChannel;
+        config.EmailSettings.EnableWebPush = this.state.enableWebPush;
+        config.EmailSettings.WebPushSubject = this.state.webPushSubject.trim();
 
         return config;
     };
@@ -160,6 +171,8 @@ class PushSettings extends OLDAdminSettings<Props, State> {
             pushNotificationServer,
             maxNotificationsPerChannel,
             agree,
+            enableWebPush: config.EmailSettings.EnableWebPush,
+            webPushSubject: config.EmailSettings.WebPushSubject,
         };
     }
 
@@ -383,6 +396,25 @@ class PushSettings extends OLDAdminSettings<Props, State> {
                     disabled={this.props.isDisabled || this.state.pushNotificationServerType !== PUSH_NOTIFICATIONS_CUSTOM}
                     setByEnv={this.isSetByEnv('EmailSettings.PushNotificationServer')}
                 />
+                <BooleanSetting
+                    id='enableWebPush'
+                    label={<FormattedMessage {...messages.enableWebPushTitle}/>}
+                    helpText={<FormattedMessage {...messages.enableWebPushDescription}/>}
+                    value={this.state.enableWebPush}
+                    onChange={this.handleChange}
+                    setByEnv={this.isSetByEnv('EmailSettings.EnableWebPush')}
+                    disabled={this.props.isDisabled || this.state.pushNotificationServerType === PUSH_NOTIFICATIONS_OFF}
+                />
+                <TextSetting
+                    id='webPushSubject'
+                    label={<FormattedMessage {...messages.webPushSubjectTitle}/>}
+                    placeholder={defineMessage({id: 'admin.email.webPushSubjectExample', defaultMessage: 'E.g.: "mailto:admin@example.com"'})}
+                    helpText={<FormattedMessage {...messages.webPushSubjectDescription}/>}
+                    value={this.state.webPushSubject}
+                    onChange={this.handleChange}
+                    setByEnv={this.isSetByEnv('EmailSettings.WebPushSubject')}
+                    disabled={this.props.isDisabled || this.state.pushNotificationServerType === PUSH_NOTIFICATIONS_OFF || !this.state.enableWebPush}
+                />
                 <TextSetting
                     id='maxNotificationsPerChannel'
                     type='number'
@@ -42,6 +42,7 @@ export function mapStateToProps(state: GlobalState, ownProps: Props) {
         mfaRequired: checkIfMFARequired(getCurrentUser(state), license, config, ownProps.match.url),
         showTermsOfService,
         customProfileAttributesEnabled: isEnterpriseLicense(license) && getFeatureFlagValue(state, 'CustomProfileAttributes') === 'true',
+        webPushPublicKey: config.EnableWebPush === 'true' ? config.WebPushPublicKey : undefined,
     };
 }
 
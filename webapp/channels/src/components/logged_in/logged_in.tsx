@@ -17,8 +17,9 @@ import Constants from 'utils/constants';
 import DesktopApp from 'utils/desktop_api';
 import {isKeyPressed} from 'utils/keyboard';
 import {getBrowserTimezone} from 'utils/timezone';
-import {isAndroid, isIos} from 'utils/user_agent';
+import {isAndroid, isDesktopApp, isIos} from 'utils/user_agent';
 import {doesCookieContainsMMUserId} from 'utils/utils';
+import {setupWebPush} from 'utils/web_push';
 
 declare global {
     interface Window {
@@ -35,6 +36,7 @@ export type Props = {
     children?: React.ReactNode;
     mfaRequired: boolean;
     customProfileAttributesEnabled: boolean;
+    webPushPublicKey?: string;
     actions: {
         autoUpdateTimezone: (deviceTimezone: string) => void;
         getChannelURLAction: (channelId: string, teamId: string, url: string) => void;
@@ -50,6 +52,7 @@ export type Props = {
 
 export default class LoggedIn extends React.PureComponent<Props> {
     private cleanupDesktopListeners?: () => void;
+    private cleanupWebPush?: () => void;
 
     constructor(props: Props) {
         super(props);
@@ -114,6 +117,11 @@ export default class LoggedIn extends React.PureComponent<Props> {
         if (this.isValidState() && !this.props.mfaRequired) {
             BrowserStore.signalLogin();
             DesktopApp.signalLogin();
+
+            // The Desktop App shows its own notifications
+            if (this.props.webPushPublicKey && !isDesktopApp()) {
+                this.cleanupWebPush = setupWebPush(this.props.webPushPublicKey, (channelId, teamId) => this.clickNotification(channelId, teamId, ''));
+            }
         }
     }
 
@@ -125,6 +133,7 @@ export default class LoggedIn extends React.PureComponent<Props> {
         window.removeEventListener('blur', this.onBlurListener);
 
         this.cleanupDesktopListeners?.();
+        this.cleanupWebPush?.();
     }
 
     public render(): React.ReactNode {
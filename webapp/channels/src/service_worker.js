@@ -0,0 +1,86 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+// This service worker shows the Web Push notifications the server sends to the browser while none of
+// its Mattermost tabs is in use. It is registered from utils/web_push and served from the static
+// directory, so the URLs below are relative to it.
+
+const NOTIFICATION_ICON = 'images/favicon/android-chrome-192x192.png';
+
+const PUSH_TYPE_MESSAGE = 'message';
+const PUSH_TYPE_CLEAR = 'clear';
+
+const NOTIFICATION_CLICKED_MESSAGE = 'web_push_notification_clicked';
+
+function getWindowClients() {
+    return self.clients.matchAll({type: 'window', includeUncontrolled: true});
+}
+
+async function showMessageNotification(msg) {
+    // The open tab shows its own desktop notifications while it's in use.
+    const windowClients = await getWindowClients();
+    if (windowClients.some((client) => client.focused)) {
+        return;
+    }
+
+    const title = msg.channel_name || msg.sender_name || 'Mattermost';
+    await self.registration.showNotification(title, {
+        body: msg.message,
+        icon: NOTIFICATION_ICON,
+        tag: msg.channel_id,
+        renotify: true,
+        data: {
+            channel_id: msg.channel_id,
+            team_id: msg.team_id,
+            post_id: msg.post_id,
+        },
+    });
+}
+
+async function clearNotifications(msg) {
+    const notifications = await self.registration.getNotifications({tag: msg.channel_id});
+    notifications.forEach((notification) => notification.close());
+}
+
+self.addEventListener('push', (event) => {
+    if (!event.data) {
+        return;
+    }
+
+    let msg;
+    try {
+        msg = event.data.json();
+    } catch {
+        return;
+    }
+
+    if (msg.type === PUSH_TYPE_MESSAGE) {
+        event.waitUntil(showMessageNotification(msg));
+    } else if (msg.type === PUSH_TYPE_CLEAR && msg.channel_id) {
+        event.waitUntil(clearNotifications(msg));
+    }
+});
+
+async function openNotification(data) {
+    // An open tab switches to the channel like for its own desktop notifications, as it can't be
+    // navigated from here unless this worker controls it.
+    const windowClients = await getWindowClients();
+    if (windowClients.length > 0) {
+        const client = windowClients[0];
+        await client.focus();
+        client.postMessage({type: NOTIFICATION_CLICKED_MESSAGE, channel_id: data.channel_id, team_id: data.team_id});
+        return;
+    }
+
+    await self.clients.openWindow(new URL(`../_redirect/pl/${data.post_id}`, self.registration.scope).href);
+}
+
+self.addEventListener('notificationclick', (event) => {
+    event.notification.close();
+
+    if (!event.notification.data || !event.notification.data.post_id) {
+        return;
+    }
+
+    event.waitUntil(openNotification(event.notification.data));
+});
@@ -0,0 +1,90 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+import {Client4} from 'mattermost-redux/client';
+
+import {decodeApplicationServerKey, registerWebPush} from './web_push';
+
+declare global {
+    interface Window {
+        Notification: any;
+        PushManager: any;
+    }
+}
+
+describe('decodeApplicationServerKey', () => {
+    it('should decode unpadded base64url', () => {
+        expect(Array.from(decodeApplicationServerKey('AQID'))).toEqual([1, 2, 3]);
+        expect(Array.from(decodeApplicationServerKey('-_8'))).toEqual([0xfb, 0xff]);
+    });
+});
+
+describe('registerWebPush', () => {
+    const publicKey = 'AQID';
+    const subscriptionJSON = {endpoint: 'https://push.example.com/1', keys: {p256dh: 'p256dh', auth: 'auth'}};
+
+    let pushManager: {getSubscription: jest.Mock; subscribe: jest.Mock};
+    let register: jest.Mock;
+
+    const newSubscription = (key: number[]) => ({
+        options: {applicationServerKey: new Uint8Array(key).buffer},
+        toJSON: () => subscriptionJSON,
+        unsubscribe: jest.fn(() => Promise.resolve(true)),
+    });
+
+    beforeEach(() => {
+        window.Notification = {permission: 'granted', requestPermission: jest.fn()};
+        window.PushManager = jest.fn();
+
+        pushManager = {
+            getSubscription: jest.fn(() => Promise.resolve(null)),
+            subscribe: jest.fn(() => Promise.resolve(newSubscription([1, 2, 3]))),
+        };
+        register = jest.fn(() => Promise.resolve({pushManager}));
+        Object.defineProperty(navigator, 'serviceWorker', {value: {register}, configurable: true});
+
+        Client4.attachWebPushSubscription = jest.fn().mockResolvedValue({status: 'OK'});
+    });
+
+    afterEach(() => {
+        delete (navigator as any).serviceWorker;
+        delete window.PushManager;
+    });
+
+    it('should subscribe and attach the subscription to the session', async () => {
+        await registerWebPush(publicKey);
+
+        expect(register).toHaveBeenCalledWith('/static/service_worker.js');
+        expect(pushManager.subscribe).toHaveBeenCalledWith({userVisibleOnly: true, applicationServerKey: decodeApplicationServerKey(publicKey)});
+        expect(Client4.attachWebPushSubscription).toHaveBeenCalledWith(subscriptionJSON);
+    });
+
+    it('should reuse a subscription made for the same key', async () => {
+        pushManager.getSubscription.mockResolvedValue(newSubscription([1, 2, 3]));
+
+        await registerWebPush(publicKey);
+
+        expect(pushManager.subscribe).not.toHaveBeenCalled();
+        expect(Client4.attachWebPushSubscription).toHaveBeenCalledWith(subscriptionJSON);
+    });
+
+    it('should replace a subscription made for another key', async () => {
+        const previous = newSubscription([4, 5, 6]);
+        pushManager.getSubscription.mockResolvedValue(previous);
+
+        await registerWebPush(publicKey);
+
+        expect(previous.unsubscribe).toHaveBeenCalled();
+        expect(pushManager.subscribe).toHaveBeenCalled();
+        expect(Client4.attachWebPushSubscription).toHaveBeenCalledWith(subscriptionJSON);
+    });
+
+    it('should do nothing until notifications are allowed', async () => {
+        window.Notification.permission = 'default';
+
+        await registerWebPush(publicKey);
+
+        expect(register).not.toHaveBeenCalled();
+        expect(Client4.attachWebPushSubscription).not.toHaveBeenCalled();
+    });
+});
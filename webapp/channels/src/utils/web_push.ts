@@ -0,0 +1,98 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+import type {WebPushSubscription} from '@mattermost/types/sessions';
+
+import {Client4} from 'mattermost-redux/client';
+
+import {isNotificationAPISupported, NotificationPermissionGranted} from 'utils/notifications';
+
+// Sent by the service worker when a notification is clicked while a tab is open.
+const NOTIFICATION_CLICKED_MESSAGE = 'web_push_notification_clicked';
+
+export function isWebPushSupported(): boolean {
+    return isNotificationAPISupported() && 'serviceWorker' in navigator && 'PushManager' in window;
+}
+
+// decodeApplicationServerKey decodes the VAPID public key of the server, which is encoded in unpadded base64url.
+export function decodeApplicationServerKey(key: string): Uint8Array {
+    const base64 = key.replace(/-/g, '+').replace(/_/g, '/').padEnd(Math.ceil(key.length / 4) * 4, '=');
+    return Uint8Array.from(atob(base64), (c) => c.charCodeAt(0));
+}
+
+function isSameKey(a: ArrayBuffer | null, b: Uint8Array): boolean {
+    if (!a || a.byteLength !== b.length) {
+        return false;
+    }
+
+    const bytes = new Uint8Array(a);
+    return bytes.every((byte, i) => byte === b[i]);
+}
+
+// registerWebPush subscribes the browser to push notifications and attaches its subscription to the current
+// session, so that the server sends it the notifications of the user while none of their tabs is in use.
+export async function registerWebPush(publicKey: string): Promise<void> {
+    if (!publicKey || !isWebPushSupported() || Notification.permission !== NotificationPermissionGranted) {
+        return;
+    }
+
+    const registration = await navigator.serviceWorker.register(`${window.publicPath || '/static/'}service_worker.js`);
+    const applicationServerKey = decodeApplicationServerKey(publicKey);
+
+    let subscription = await registration.pushManager.getSubscription();
+
+    // A subscription made for a previous key of the server can't receive its notifications anymore.
+    if (subscription && !isSameKey(subscription.options.applicationServerKey, applicationServerKey)) {
+        await subscription.unsubscribe();
+        subscription = null;
+    }
+
+    if (!subscription) {
+        subscription = await registration.pushManager.subscribe({userVisibleOnly: true, applicationServerKey});
+    }
+
+    await Client4.attachWebPushSubscription(subscription.toJSON() as WebPushSubscription);
+}
+
+// setupWebPush registers the browser for push notifications, now if the user already allowed notifications or
+// once they do. The returned function stops listening for the clicks on notifications and permission changes.
+export function setupWebPush(publicKey: string, onNotificationClicked: (channelId: string, teamId: string) => void): () => void {
+    if (!publicKey || !isWebPushSupported()) {
+        return () => {};
+    }
+
+    const register = () => {
+        registerWebPush(publicKey).catch((err) => {
+            // eslint-disable-next-line no-console
+            console.error('Failed to register for push notifications', err);
+        });
+    };
+    register();
+
+    const handleMessage = (event: MessageEvent) => {
+        if (event.data?.type === NOTIFICATION_CLICKED_MESSAGE) {
+            onNotificationClicked(event.data.channel_id, event.data.team_id);
+        }
+    };
+    navigator.serviceWorker.addEventListener('message', handleMessage);
+
+    let permissionStatus: PermissionStatus | undefined;
+    let stopped = false;
+    navigator.permissions?.query({name: 'notifications'}).then((status) => {
+        if (stopped) {
+            return;
+        }
+        permissionStatus = status;
+        permissionStatus.onchange = register;
+    }).catch(() => {
+        // Some browsers can't query the notifications permission, the registration then waits for the next load.
+    });
+
+    return () => {
+        stopped = true;
+        navigator.serviceWorker.removeEventListener('message', handleMessage);
+        if (permissionStatus) {
+            permissionStatus.onchange = null;
+        }
+    };
+}
@@ -126,7 +126,7 @@ import type {Role} from '@mattermost/types/roles';
 import type {SamlCertificateStatus, SamlMetadataResponse} from '@mattermost/types/saml';
 import type {ScheduledPost} from '@mattermost/types/schedule_post';
 import type {Scheme} from '@mattermost/types/schemes';
-import type {Session} from '@mattermost/types/sessions';
+import type {Session, WebPushSubscription} from '@mattermost/types/sessions';
 import type {CompleteOnboardingRequest} from '@mattermost/types/setup';
 import type {RemoteClusterInfo, SharedChannelRemote} from '@mattermost/types/shared_channels';
 import type {
@@ -1062,6 +1062,20 @@ export default class Client4 {
         );
     };
 
+    attachWebPushSubscription = (subscription: WebPushSubscription) => {
+        return this.doFetch<StatusOK>(
+            `${this.getUsersRoute()}/sessions/web_push`,
+            {method: 'put', body: JSON.stringify(subscription)},
+        );
+    };
+
+    detachWebPushSubscription = () => {
+        return this.doFetch<StatusOK>(
+            `${this.getUsersRoute()}/sessions/web_push`,
+            {method: 'delete'},
+        );
+    };
+
     getUserAudits = (userId: string, page = 0, perPage = PER_PAGE_DEFAULT) => {
         return this.doFetch<Audit[]>(
             `${this.getUserRoute(userId)}/audits${buildQueryString({page, per_page: perPage})}`,
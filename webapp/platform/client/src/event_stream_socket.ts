@@ -0,0 +1,129 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+const WEBSOCKET_HELLO = 'hello';
+
+// Close code of a connection lost without a close frame, as for a websocket.
+const abnormalClosureCode = 1006;
+
+/**
+ * EventStreamSocket stands in for a WebSocket when the websocket can't connect, e.g. because a proxy
+ * strips the Upgrade header. The server sends the events as Server-Sent Events from
+ * /api/v4/websocket/events, and the requests are sent to /api/v4/websocket/actions, their responses
+ * coming back through the stream as they would through the websocket.
+ *
+ * It doesn't reconnect on its own: like a websocket, it closes when the stream fails, for the client to
+ * reconnect with the connection id and sequence number it got so far.
+ */
+export default class EventStreamSocket {
+    public readonly url: string;
+    public readyState: number = WebSocket.CONNECTING;
+
+    public onopen: ((event: Event) => void) | null = null;
+    public onclose: ((event: CloseEvent) => void) | null = null;
+    public onerror: ((event: Event) => void) | null = null;
+    public onmessage: ((event: MessageEvent) => void) | null = null;
+
+    private source: EventSource;
+    private actionsUrl: URL;
+
+    constructor(url: string) {
+        this.url = url;
+
+        // The url is the one of the websocket, e.g. wss://example.com/api/v4/websocket?connection_id=...
+        const eventsUrl = new URL(url);
+        eventsUrl.protocol = eventsUrl.protocol === 'wss:' ? 'https:' : 'http:';
+        eventsUrl.pathname = eventsUrl.pathname.replace(/\/$/, '');
+
+        this.actionsUrl = new URL(eventsUrl.toString());
+        this.actionsUrl.pathname += '/actions';
+        this.actionsUrl.search = '';
+        this.setConnectionId(eventsUrl.searchParams.get('connection_id') || '');
+
+        eventsUrl.pathname += '/events';
+
+        this.source = new EventSource(eventsUrl.toString(), {withCredentials: true});
+        this.source.onopen = (event) => {
+            this.readyState = WebSocket.OPEN;
+            this.onopen?.(event);
+        };
+        this.source.onmessage = (event) => {
+            try {
+                const msg = JSON.parse(event.data);
+                if (msg.event === WEBSOCKET_HELLO) {
+                    this.setConnectionId(msg.data.connection_id);
+                }
+            } catch {
+                // The client reports the messages it can't parse.
+            }
+
+            this.onmessage?.(event);
+        };
+        this.source.onerror = (event) => {
+            this.onerror?.(event);
+            this.closeWithCode(abnormalClosureCode, false);
+        };
+    }
+
+    send(data: string) {
+        if (this.readyState !== WebSocket.OPEN) {
+            return;
+        }
+
+        const headers: Record<string, string> = {
+            'Content-Type': 'application/json',
+            'X-Requested-With': 'XMLHttpRequest',
+        };
+        const csrfToken = getCSRFFromCookie();
+        if (csrfToken) {
+            headers['X-CSRF-Token'] = csrfToken;
+        }
+
+        fetch(this.actionsUrl.toString(), {
+            method: 'POST',
+            body: data,
+            headers,
+            credentials: 'include',
+        }).then((response) => {
+            // The server no longer has the connection, so the stream needs to be opened again.
+            if (response.status === 404) {
+                this.closeWithCode(abnormalClosureCode, false);
+            }
+        }).catch(() => {
+            // The request is lost, as it would be with a websocket. The pings going unanswered
+            // make the client reconnect.
+        });
+    }
+
+    close() {
+        this.closeWithCode(1000, true);
+    }
+
+    private closeWithCode(code: number, wasClean: boolean) {
+        if (this.readyState === WebSocket.CLOSED) {
+            return;
+        }
+
+        this.readyState = WebSocket.CLOSED;
+        this.source.close();
+        this.onclose?.(new CloseEvent('close', {code, wasClean}));
+    }
+
+    private setConnectionId(connectionId: string) {
+        this.actionsUrl.searchParams.set('connection_id', connectionId);
+    }
+}
+
+function getCSRFFromCookie() {
+    if (typeof document === 'undefined' || !document.cookie) {
+        return '';
+    }
+
+    for (const cookie of document.cookie.split(';')) {
+        const trimmed = cookie.trim();
+        if (trimmed.startsWith('MMCSRF=')) {
+            return trimmed.replace('MMCSRF=', '');
+        }
+    }
+    return '';
+}
@@ -10,3 +10,4 @@ export {
 
 export type {WebSocketMessage} from './websocket';
 export {default as WebSocketClient} from './websocket';
+export {default as EventStreamSocket} from './event_stream_socket';
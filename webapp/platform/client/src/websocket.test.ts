@@ -631,4 +631,123 @@ describe('websocketclient', () => {
 
         jest.useRealTimers();
     });
+
+    test('should call missed message listeners on resync required', () => {
+        jest.useFakeTimers();
+
+        const mockWebSocket = new MockWebSocket();
+        const client = new WebSocketClient({
+            newWebSocketFn: (url: string) => {
+                mockWebSocket.url = url;
+                setTimeout(() => {
+                    if (mockWebSocket.onopen) {
+                        mockWebSocket.open();
+                    }
+                }, 1);
+                return mockWebSocket;
+            },
+            minWebSocketRetryTime: 1,
+            reconnectJitterRange: 1,
+        });
+
+        const messageListener = jest.fn();
+        const missedMessageListener = jest.fn();
+        client.addMessageListener(messageListener);
+        client.addMissedMessageListener(missedMessageListener);
+
+        client.initialize('mock.url');
+        jest.advanceTimersByTime(1);
+
+        mockWebSocket.onmessage!({data: JSON.stringify({event: 'hello', data: {connection_id: 'connection_id'}, seq: 0})});
+        expect(missedMessageListener).not.toHaveBeenCalled();
+
+        mockWebSocket.onmessage!({data: JSON.stringify({event: 'resync_required', data: {}, seq: 1})});
+        expect(missedMessageListener).toHaveBeenCalledTimes(1);
+        expect(messageListener).toHaveBeenCalledTimes(2);
+
+        // The sequence carries on.
+        mockWebSocket.onmessage!({data: JSON.stringify({event: 'posted', data: {}, seq: 2})});
+        expect(messageListener).toHaveBeenCalledTimes(3);
+
+        client.close();
+
+        jest.useRealTimers();
+    });
+
+    test('should fall back to an event stream when websockets fail to open', () => {
+        jest.useFakeTimers();
+
+        const sockets: MockWebSocket[] = [];
+        const newWebSocketFn = jest.fn((url: string) => {
+            const socket = new MockWebSocket();
+            socket.url = url;
+            sockets.push(socket);
+            return socket;
+        });
+
+        const eventStream = new MockWebSocket();
+        const newEventStreamFn = jest.fn((url: string) => {
+            eventStream.url = url;
+            return eventStream;
+        });
+
+        const client = new WebSocketClient({
+            newWebSocketFn,
+            newEventStreamFn,
+            maxWebSocketFailsBeforeEventStream: 2,
+            minWebSocketRetryTime: 1,
+            reconnectJitterRange: 1,
+        });
+
+        client.initialize('mock.url');
+        sockets[0].close();
+        jest.advanceTimersByTime(10);
+        expect(newWebSocketFn).toHaveBeenCalledTimes(2);
+        expect(newEventStreamFn).not.toHaveBeenCalled();
+
+        sockets[1].close();
+        jest.advanceTimersByTime(10);
+        expect(newWebSocketFn).toHaveBeenCalledTimes(2);
+        expect(newEventStreamFn).toHaveBeenCalledTimes(1);
+        expect(eventStream.url).toContain('mock.url?connection_id=');
+
+        // The event stream is kept once it works.
+        eventStream.open();
+        eventStream.close();
+        jest.advanceTimersByTime(10);
+        expect(newWebSocketFn).toHaveBeenCalledTimes(2);
+        expect(newEventStreamFn).toHaveBeenCalledTimes(2);
+
+        client.close();
+
+        jest.useRealTimers();
+    });
+
+    test('should keep using websockets that opened before failing', () => {
+        jest.useFakeTimers();
+
+        const mockWebSocket = new MockWebSocket();
+        const newEventStreamFn = jest.fn();
+
+        const client = new WebSocketClient({
+            newWebSocketFn: (url: string) => {
+                mockWebSocket.url = url;
+                return mockWebSocket;
+            },
+            newEventStreamFn,
+            maxWebSocketFailsBeforeEventStream: 1,
+            minWebSocketRetryTime: 1,
+            reconnectJitterRange: 1,
+        });
+
+        client.initialize('mock.url');
+        mockWebSocket.open();
+        mockWebSocket.close();
+        jest.advanceTimersByTime(10);
+        expect(newEventStreamFn).not.toHaveBeenCalled();
+
+        client.close();
+
+        jest.useRealTimers();
+    });
 });
@@ -2,6 +2,7 @@
 // See LICENSE.txt for license information.
 
 const WEBSOCKET_HELLO = 'hello';
+const WEBSOCKET_RESYNC_REQUIRED = 'resync_required';
 
 export type MessageListener = (msg: WebSocketMessage) => void;
 export type FirstConnectListener = () => void;
@@ -17,6 +18,11 @@ export type WebSocketClientConfig = {
     reconnectJitterRange: number;
     newWebSocketFn: (url: string) => WebSocket;
     clientPingInterval: number;
+
+    // newEventStreamFn, when set, creates the connection used instead of a websocket
+    // once maxWebSocketFailsBeforeEventStream attempts in a row failed to open one.
+    newEventStreamFn: ((url: string) => WebSocket) | null;
+    maxWebSocketFailsBeforeEventStream: number;
 }
 
 // Custom close error codes must be in the range of 4000-4999
@@ -32,6 +38,8 @@ const defaultWebSocketClientConfig: WebSocketClientConfig = {
         return new WebSocket(url);
     },
     clientPingInterval: 30000, // 30 seconds
+    newEventStreamFn: null,
+    maxWebSocketFailsBeforeEventStream: 3,
 };
 
 export default class WebSocketClient {
@@ -92,6 +100,10 @@ export default class WebSocketClient {
     private serverHostname: string | null;
     private postedAck: boolean;
 
+    // useEventStream is set once websockets fail to open, e.g. because a proxy
+    // strips the Upgrade header, to connect through an event stream instead.
+    private useEventStream: boolean;
+
     private pingInterval: ReturnType<typeof setInterval> | null;
     private waitingForPong: boolean;
 
@@ -111,6 +123,7 @@ export default class WebSocketClient {
         this.connectionId = '';
         this.serverHostname = '';
         this.postedAck = false;
+        this.useEventStream = false;
         this.reconnectTimeout = null;
         this.config = {...defaultWebSocketClientConfig, ...config};
         this.pingInterval = null;
@@ -214,12 +227,16 @@ export default class WebSocketClient {
             websocketUrl += `&disconnect_err_code=${encodeURIComponent(this.lastErrCode)}`;
         }
 
-        if (this.config.newWebSocketFn) {
+        if (this.useEventStream && this.config.newEventStreamFn) {
+            this.conn = this.config.newEventStreamFn(websocketUrl);
+        } else if (this.config.newWebSocketFn) {
             this.conn = this.config.newWebSocketFn(websocketUrl);
         } else {
             this.conn = new WebSocket(websocketUrl);
         }
 
+        let opened = false;
+
         const onclose = (event: CloseEvent) => {
             this.conn = null;
             this.responseSequence = 1;
@@ -234,6 +251,12 @@ export default class WebSocketClient {
 
             this.connectFailCount++;
 
+            if (!opened && !this.useEventStream && this.config.newEventStreamFn &&
+                this.connectFailCount >= this.config.maxWebSocketFailsBeforeEventStream) {
+                console.log('websocket failed to open, falling back to an event stream'); //eslint-disable-line no-console
+                this.useEventStream = true;
+            }
+
             this.closeCallback?.(this.connectFailCount);
             this.closeListeners.forEach((listener) => listener(this.connectFailCount));
 
@@ -269,6 +292,8 @@ export default class WebSocketClient {
         this.conn.onclose = onclose;
 
         this.conn.onopen = () => {
+            opened = true;
+
             if (token) {
                 this.sendMessage('authentication_challenge', {token});
             }
@@ -370,15 +395,7 @@ export default class WebSocketClient {
                     if (this.connectionId !== '' && this.connectionId !== msg.data.connection_id) {
                         console.log('long timeout, or server restart, or sequence number is not found.'); //eslint-disable-line no-console
 
-                        this.missedEventCallback?.();
-
-                        for (const listener of this.missedMessageListeners) {
-                            try {
-                                listener();
-                            } catch (e) {
-                                console.log(`missed message listener "${listener.name}" failed: ${e}`); // eslint-disable-line no-console
-                            }
-                        }
+                        this.callMissedMessageListeners();
 
                         this.serverSequence = 0;
                     }
@@ -417,12 +434,32 @@ export default class WebSocketClient {
                 }
                 this.serverSequence = msg.seq + 1;
 
+                // The server no longer has all the events missed while disconnected,
+                // so we do the sync calls, and carry on with the same sequence.
+                if (msg.event === WEBSOCKET_RESYNC_REQUIRED) {
+                    console.log('missed websocket events are no longer available.'); //eslint-disable-line no-console
+
+                    this.callMissedMessageListeners();
+                }
+
                 this.eventCallback?.(msg);
                 this.messageListeners.forEach((listener) => listener(msg));
             }
         };
     }
 
+    private callMissedMessageListeners() {
+        this.missedEventCallback?.();
+
+        for (const listener of this.missedMessageListeners) {
+            try {
+                listener();
+            } catch (e) {
+                console.log(`missed message listener "${listener.name}" failed: ${e}`); // eslint-disable-line no-console
+            }
+        }
+    }
+
     /**
      * @deprecated Use addMessageListener instead
      */
@@ -190,6 +190,8 @@ export type ClientConfig = {
     SchemaVersion: string;
     SendEmailNotifications: string;
     SendPushNotifications: string;
+    EnableWebPush: string;
+    WebPushPublicKey: string;
     ShowEmailAddress: string;
     SiteName: string;
     SiteURL: string;
@@ -424,6 +426,9 @@ export type ServiceSettings = {
     MaximumURLLength: number;
     ScheduledPosts: boolean;
     EnableWebHubChannelIteration: boolean;
+    EnableWebSocketEventLog: boolean;
+    WebSocketEventLogRetentionSeconds: number;
+    WebSocketEventLogMaxEvents: number;
     FrameAncestors: string;
     DeleteAccountLink: string;
 };
@@ -504,6 +509,8 @@ export type ExperimentalAuditSettings = {
     FileMaxQueueSize: number;
     AdvancedLoggingJSON: Record<string, any>;
     Certificate: string;
+    DatabaseEnabled: boolean;
+    DatabaseHashKey: string;
 };
 
 export type PasswordSettings = {
@@ -605,6 +612,8 @@ export type EmailSettings = {
     PushNotificationServerLocation: 'global' | 'us' | 'de' | 'jp';
     PushNotificationContents: string;
     PushNotificationBuffer: number;
+    EnableWebPush: boolean;
+    WebPushSubject: string;
     EnableEmailBatching: boolean;
     EmailBatchingBufferSize: number;
     EmailBatchingInterval: number;
@@ -832,6 +841,13 @@ export type MetricsSettings = {
     ClientSideUserIds: string[];
 };
 
+export type TracingSettings = {
+    Enable: boolean;
+    OTLPEndpoint: string;
+    ServiceName: string;
+    SampleRate: number;
+};
+
 export type ExperimentalSettings = {
     LinkMetadataTimeoutMilliseconds: number;
     RestrictSystemAdmin: boolean;
@@ -948,6 +964,8 @@ export type PluginSettings = {
     MarketplaceURL: string;
     SignaturePublicKeyFiles: string[];
     ChimeraOAuthProxyURL: string;
+    WasmMemoryLimitMB: number;
+    WasmHookTimeoutMilliseconds: number;
 };
 
 export type DisplaySettings = {
@@ -1054,6 +1072,7 @@ export type AdminConfig = {
     NativeAppSettings: NativeAppSettings;
     ClusterSettings: ClusterSettings;
     MetricsSettings: MetricsSettings;
+    TracingSettings: TracingSettings;
     ExperimentalSettings: ExperimentalSettings;
     AnalyticsSettings: AnalyticsSettings;
     CacheSettings: CacheSettings;
@@ -17,3 +17,12 @@ export type Session = {
     team_members: TeamMembership[];
     local: boolean;
 }
+
+// WebPushSubscription is the push subscription of a browser, as returned by PushSubscription.toJSON().
+export type WebPushSubscription = {
+    endpoint: string;
+    keys: {
+        p256dh: string;
+        auth: string;
+    };
+}